@@ -0,0 +1,154 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// Subtopic is the channel subtopic clients and the rules engine publish
+// alarm reports to.
+const Subtopic = "alarms"
+
+var (
+	// ErrInvalidTransition indicates that the alarm can't be moved to the requested state.
+	ErrInvalidTransition = errors.New("invalid alarm status transition")
+
+	// ErrMalformedReport indicates that the alarm report payload is malformed.
+	ErrMalformedReport = errors.New("malformed alarm report")
+)
+
+// Report is the payload of the messages published to the alarms subtopic.
+type Report struct {
+	RuleID      string `json:"rule_id,omitempty"`
+	Measurement string `json:"measurement,omitempty"`
+	Value       string `json:"value,omitempty"`
+	Unit        string `json:"unit,omitempty"`
+	Threshold   string `json:"threshold,omitempty"`
+	Cause       string `json:"cause,omitempty"`
+	Severity    uint8  `json:"severity,omitempty"`
+}
+
+// Alarm represents an incident raised by a client or a rule. Repeated
+// reports of the same client, channel and rule are counted by the alarm
+// until it's cleared.
+type Alarm struct {
+	ID             string    `json:"id"`
+	DomainID       string    `json:"domain_id"`
+	ChannelID      string    `json:"channel_id"`
+	ClientID       string    `json:"client_id,omitempty"`
+	RuleID         string    `json:"rule_id,omitempty"`
+	Status         Status    `json:"status"`
+	Severity       uint8     `json:"severity"`
+	Measurement    string    `json:"measurement,omitempty"`
+	Value          string    `json:"value,omitempty"`
+	Unit           string    `json:"unit,omitempty"`
+	Threshold      string    `json:"threshold,omitempty"`
+	Cause          string    `json:"cause,omitempty"`
+	Count          uint64    `json:"count"`
+	AssigneeID     string    `json:"assignee_id,omitempty"`
+	AssignedBy     string    `json:"assigned_by,omitempty"`
+	AssignedAt     time.Time `json:"assigned_at,omitempty"`
+	AcknowledgedBy string    `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt time.Time `json:"acknowledged_at,omitempty"`
+	ClearedBy      string    `json:"cleared_by,omitempty"`
+	ClearedAt      time.Time `json:"cleared_at,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// AlarmsPage represents a page of alarms.
+type AlarmsPage struct {
+	Total  uint64  `json:"total"`
+	Offset uint64  `json:"offset"`
+	Limit  uint64  `json:"limit"`
+	Alarms []Alarm `json:"alarms"`
+}
+
+func (page AlarmsPage) MarshalJSON() ([]byte, error) {
+	type Alias AlarmsPage
+	a := struct {
+		Alias
+	}{
+		Alias: Alias(page),
+	}
+
+	if a.Alarms == nil {
+		a.Alarms = make([]Alarm, 0)
+	}
+
+	return json.Marshal(a)
+}
+
+// PageMeta is used to filter alarms.
+type PageMeta struct {
+	Offset     uint64 `json:"offset" db:"offset"`
+	Limit      uint64 `json:"limit" db:"limit"`
+	DomainID   string `json:"domain_id,omitempty" db:"domain_id"`
+	ChannelID  string `json:"channel_id,omitempty" db:"channel_id"`
+	ClientID   string `json:"client_id,omitempty" db:"client_id"`
+	RuleID     string `json:"rule_id,omitempty" db:"rule_id"`
+	AssigneeID string `json:"assignee_id,omitempty" db:"assignee_id"`
+	Status     Status `json:"status,omitempty" db:"status"`
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// CreateAlarm raises the alarm in the domain of the alarm channel.
+	// If there is an alarm of the same client, channel and rule which
+	// is not cleared yet, that alarm is updated instead.
+	CreateAlarm(ctx context.Context, a Alarm) (Alarm, error)
+
+	// ViewAlarm retrieves the alarm identified by the given ID.
+	ViewAlarm(ctx context.Context, session smqauthn.Session, id string) (Alarm, error)
+
+	// ListAlarms retrieves the alarms of the session domain filtered by the page metadata.
+	ListAlarms(ctx context.Context, session smqauthn.Session, pm PageMeta) (AlarmsPage, error)
+
+	// AcknowledgeAlarm marks the active alarm as acknowledged by the session user.
+	AcknowledgeAlarm(ctx context.Context, session smqauthn.Session, id string) (Alarm, error)
+
+	// ClearAlarm marks the alarm as cleared by the session user.
+	ClearAlarm(ctx context.Context, session smqauthn.Session, id string) (Alarm, error)
+
+	// AssignAlarm assigns the alarm which is not cleared to the given user.
+	AssignAlarm(ctx context.Context, session smqauthn.Session, id, assigneeID string) (Alarm, error)
+
+	// RemoveAlarm removes the alarm identified by the given ID.
+	RemoveAlarm(ctx context.Context, session smqauthn.Session, id string) error
+}
+
+// Repository specifies an alarm persistence API.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save persists the alarm. If there is an alarm of the same client,
+	// channel and rule which is not cleared yet, that alarm count, severity
+	// and measurement are updated instead and the updated alarm is returned.
+	Save(ctx context.Context, a Alarm) (Alarm, error)
+
+	// RetrieveByID retrieves the alarm having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Alarm, error)
+
+	// RetrieveAll retrieves alarms filtered by the page metadata.
+	RetrieveAll(ctx context.Context, pm PageMeta) (AlarmsPage, error)
+
+	// UpdateStatus updates the alarm status along with the user who
+	// acknowledged or cleared it.
+	UpdateStatus(ctx context.Context, a Alarm) (Alarm, error)
+
+	// UpdateAssignee updates the alarm assignee.
+	UpdateAssignee(ctx context.Context, a Alarm) (Alarm, error)
+
+	// Remove removes the alarm having the provided identifier.
+	Remove(ctx context.Context, id string) error
+}
@@ -0,0 +1,562 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/alarms/api"
+	"github.com/absmach/supermq/alarms/mocks"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	validToken   = "valid"
+	invalidToken = "invalid"
+	contentType  = "application/json"
+	instanceID   = "5de9b29a-feb9-11ed-be56-0242ac120002"
+)
+
+var (
+	validID   = testsutil.GenerateUUID(&testing.T{})
+	domainID  = testsutil.GenerateUUID(&testing.T{})
+	channelID = testsutil.GenerateUUID(&testing.T{})
+	clientID  = testsutil.GenerateUUID(&testing.T{})
+	session   = smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID}
+	alarm     = alarms.Alarm{
+		ID:        testsutil.GenerateUUID(&testing.T{}),
+		DomainID:  domainID,
+		ChannelID: channelID,
+		ClientID:  clientID,
+		Severity:  3,
+		Cause:     "temperature too high",
+		Count:     1,
+	}
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	token       string
+	contentType string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+func newAlarmsServer() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	mux := api.MakeHandler(svc, authn, smqlog.NewMock(), "alarms", instanceID)
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func TestViewAlarm(t *testing.T) {
+	as, svc, authn := newAlarmsServer()
+	defer as.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnRes smqauthn.Session
+		authnErr error
+		svcRes   alarms.Alarm
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "view alarm successfully",
+			token:    validToken,
+			id:       alarm.ID,
+			authnRes: session,
+			svcRes:   alarm,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "view alarm with invalid token",
+			token:    invalidToken,
+			id:       alarm.ID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:   "view alarm with empty token",
+			id:     alarm.ID,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:     "view non-existing alarm",
+			token:    validToken,
+			id:       validID,
+			authnRes: session,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+		{
+			desc:     "view alarm with service error",
+			token:    validToken,
+			id:       alarm.ID,
+			authnRes: session,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ViewAlarm", mock.Anything, tc.authnRes, tc.id).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: as.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/alarms/%s", as.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody alarms.Alarm
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.ID, resBody.ID, fmt.Sprintf("%s: expected alarm %s got %s", tc.desc, tc.svcRes.ID, resBody.ID))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListAlarms(t *testing.T) {
+	as, svc, authn := newAlarmsServer()
+	defer as.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		authnRes smqauthn.Session
+		authnErr error
+		pm       alarms.PageMeta
+		svcRes   alarms.AlarmsPage
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list alarms successfully",
+			token:    validToken,
+			authnRes: session,
+			pm:       alarms.PageMeta{Limit: 10, Status: alarms.AllStatus},
+			svcRes:   alarms.AlarmsPage{Total: 1, Limit: 10, Alarms: []alarms.Alarm{alarm}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list alarms of channel and client",
+			token:    validToken,
+			query:    fmt.Sprintf("channel_id=%s&client_id=%s", channelID, clientID),
+			authnRes: session,
+			pm:       alarms.PageMeta{Limit: 10, ChannelID: channelID, ClientID: clientID, Status: alarms.AllStatus},
+			svcRes:   alarms.AlarmsPage{Total: 1, Limit: 10, Alarms: []alarms.Alarm{alarm}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list alarms of rule and assignee",
+			token:    validToken,
+			query:    fmt.Sprintf("rule_id=%s&assignee_id=%s", validID, validID),
+			authnRes: session,
+			pm:       alarms.PageMeta{Limit: 10, RuleID: validID, AssigneeID: validID, Status: alarms.AllStatus},
+			svcRes:   alarms.AlarmsPage{Limit: 10},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list acknowledged alarms",
+			token:    validToken,
+			query:    "status=acknowledged",
+			authnRes: session,
+			pm:       alarms.PageMeta{Limit: 10, Status: alarms.AcknowledgedStatus},
+			svcRes:   alarms.AlarmsPage{Limit: 10},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list alarms with offset and limit",
+			token:    validToken,
+			query:    "offset=1&limit=5",
+			authnRes: session,
+			pm:       alarms.PageMeta{Offset: 1, Limit: 5, Status: alarms.AllStatus},
+			svcRes:   alarms.AlarmsPage{Total: 1, Offset: 1, Limit: 5},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list alarms with invalid token",
+			token:    invalidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list alarms with invalid status",
+			token:    validToken,
+			query:    "status=invalid",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list alarms with invalid limit",
+			token:    validToken,
+			query:    "limit=invalid",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list alarms with limit exceeding maximum",
+			token:    validToken,
+			query:    "limit=1000",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list alarms with service error",
+			token:    validToken,
+			authnRes: session,
+			pm:       alarms.PageMeta{Limit: 10, Status: alarms.AllStatus},
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ListAlarms", mock.Anything, tc.authnRes, tc.pm).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: as.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/alarms/?%s", as.URL, domainID, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody alarms.AlarmsPage
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.Total, resBody.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.svcRes.Total, resBody.Total))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestChangeAlarmStatus(t *testing.T) {
+	as, svc, authn := newAlarmsServer()
+	defer as.Close()
+
+	acknowledged := alarm
+	acknowledged.Status = alarms.AcknowledgedStatus
+	cleared := alarm
+	cleared.Status = alarms.ClearedStatus
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		action   string
+		svcCall  string
+		authnRes smqauthn.Session
+		authnErr error
+		svcRes   alarms.Alarm
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "acknowledge alarm successfully",
+			token:    validToken,
+			id:       alarm.ID,
+			action:   "acknowledge",
+			svcCall:  "AcknowledgeAlarm",
+			authnRes: session,
+			svcRes:   acknowledged,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "clear alarm successfully",
+			token:    validToken,
+			id:       alarm.ID,
+			action:   "clear",
+			svcCall:  "ClearAlarm",
+			authnRes: session,
+			svcRes:   cleared,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "acknowledge alarm with invalid token",
+			token:    invalidToken,
+			id:       alarm.ID,
+			action:   "acknowledge",
+			svcCall:  "AcknowledgeAlarm",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "acknowledge cleared alarm",
+			token:    validToken,
+			id:       alarm.ID,
+			action:   "acknowledge",
+			svcCall:  "AcknowledgeAlarm",
+			authnRes: session,
+			svcErr:   svcerr.ErrConflict,
+			status:   http.StatusConflict,
+		},
+		{
+			desc:     "clear non-existing alarm",
+			token:    validToken,
+			id:       validID,
+			action:   "clear",
+			svcCall:  "ClearAlarm",
+			authnRes: session,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+		{
+			desc:     "clear alarm with service error",
+			token:    validToken,
+			id:       alarm.ID,
+			action:   "clear",
+			svcCall:  "ClearAlarm",
+			authnRes: session,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On(tc.svcCall, mock.Anything, tc.authnRes, tc.id).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: as.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/%s/alarms/%s/%s", as.URL, domainID, tc.id, tc.action),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody alarms.Alarm
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.Status, resBody.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, tc.svcRes.Status, resBody.Status))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestAssignAlarm(t *testing.T) {
+	as, svc, authn := newAlarmsServer()
+	defer as.Close()
+
+	assigneeID := testsutil.GenerateUUID(t)
+	assigned := alarm
+	assigned.AssigneeID = assigneeID
+
+	cases := []struct {
+		desc        string
+		token       string
+		id          string
+		data        string
+		contentType string
+		assigneeID  string
+		authnRes    smqauthn.Session
+		authnErr    error
+		svcRes      alarms.Alarm
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "assign alarm successfully",
+			token:       validToken,
+			id:          alarm.ID,
+			data:        fmt.Sprintf(`{"assignee_id": "%s"}`, assigneeID),
+			contentType: contentType,
+			assigneeID:  assigneeID,
+			authnRes:    session,
+			svcRes:      assigned,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "assign alarm with invalid token",
+			token:       invalidToken,
+			id:          alarm.ID,
+			data:        fmt.Sprintf(`{"assignee_id": "%s"}`, assigneeID),
+			contentType: contentType,
+			assigneeID:  assigneeID,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "assign alarm without assignee",
+			token:       validToken,
+			id:          alarm.ID,
+			data:        `{}`,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "assign alarm with invalid content type",
+			token:       validToken,
+			id:          alarm.ID,
+			data:        fmt.Sprintf(`{"assignee_id": "%s"}`, assigneeID),
+			contentType: "text/plain",
+			authnRes:    session,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "assign alarm with malformed body",
+			token:       validToken,
+			id:          alarm.ID,
+			data:        `{"assignee_id": `,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "assign cleared alarm",
+			token:       validToken,
+			id:          alarm.ID,
+			data:        fmt.Sprintf(`{"assignee_id": "%s"}`, assigneeID),
+			contentType: contentType,
+			assigneeID:  assigneeID,
+			authnRes:    session,
+			svcErr:      svcerr.ErrConflict,
+			status:      http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("AssignAlarm", mock.Anything, tc.authnRes, tc.id, tc.assigneeID).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client:      as.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/alarms/%s/assign", as.URL, domainID, tc.id),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody alarms.Alarm
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.AssigneeID, resBody.AssigneeID, fmt.Sprintf("%s: expected assignee %s got %s", tc.desc, tc.svcRes.AssigneeID, resBody.AssigneeID))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestRemoveAlarm(t *testing.T) {
+	as, svc, authn := newAlarmsServer()
+	defer as.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnRes smqauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "remove alarm successfully",
+			token:    validToken,
+			id:       alarm.ID,
+			authnRes: session,
+			status:   http.StatusNoContent,
+		},
+		{
+			desc:     "remove alarm with invalid token",
+			token:    invalidToken,
+			id:       alarm.ID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "remove non-existing alarm",
+			token:    validToken,
+			id:       validID,
+			authnRes: session,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+		{
+			desc:     "remove alarm with service error",
+			token:    validToken,
+			id:       alarm.ID,
+			authnRes: session,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("RemoveAlarm", mock.Anything, tc.authnRes, tc.id).Return(tc.svcErr)
+			req := testRequest{
+				client: as.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/%s/alarms/%s", as.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/absmach/supermq/alarms"
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+)
+
+type viewAlarmReq struct {
+	id string
+}
+
+func (req viewAlarmReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listAlarmsReq struct {
+	pm alarms.PageMeta
+}
+
+func (req listAlarmsReq) validate() error {
+	if req.pm.Limit > api.MaxLimitSize || req.pm.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type assignAlarmReq struct {
+	id         string
+	AssigneeID string `json:"assignee_id"`
+}
+
+func (req assignAlarmReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.AssigneeID == "" {
+		return apiutil.ErrMissingUserID
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/alarms"
+)
+
+var (
+	_ supermq.Response = (*alarmRes)(nil)
+	_ supermq.Response = (*alarmsPageRes)(nil)
+	_ supermq.Response = (*removeAlarmRes)(nil)
+)
+
+type alarmRes struct {
+	alarms.Alarm `json:",inline"`
+}
+
+func (res alarmRes) Code() int {
+	return http.StatusOK
+}
+
+func (res alarmRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res alarmRes) Empty() bool {
+	return false
+}
+
+type alarmsPageRes struct {
+	alarms.AlarmsPage `json:",inline"`
+}
+
+func (res alarmsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res alarmsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res alarmsPageRes) Empty() bool {
+	return false
+}
+
+type removeAlarmRes struct{}
+
+func (res removeAlarmRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeAlarmRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeAlarmRes) Empty() bool {
+	return true
+}
@@ -0,0 +1,156 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/alarms"
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	channelIDKey  = "channel_id"
+	clientIDKey   = "client_id"
+	ruleIDKey     = "rule_id"
+	assigneeIDKey = "assignee_id"
+)
+
+// MakeHandler returns a HTTP API handler with health check and metrics.
+func MakeHandler(svc alarms.Service, authn smqauthn.Authentication, logger *slog.Logger, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Route("/{domainID}/alarms", func(r chi.Router) {
+		r.Use(api.AuthenticateMiddleware(authn, true))
+
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listAlarmsEndpoint(svc),
+			decodeListAlarmsReq,
+			api.EncodeResponse,
+			opts...,
+		), "list_alarms").ServeHTTP)
+
+		r.Route("/{alarmID}", func(r chi.Router) {
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				viewAlarmEndpoint(svc),
+				decodeViewAlarmReq,
+				api.EncodeResponse,
+				opts...,
+			), "view_alarm").ServeHTTP)
+
+			r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+				removeAlarmEndpoint(svc),
+				decodeViewAlarmReq,
+				api.EncodeResponse,
+				opts...,
+			), "remove_alarm").ServeHTTP)
+
+			r.Post("/acknowledge", otelhttp.NewHandler(kithttp.NewServer(
+				acknowledgeAlarmEndpoint(svc),
+				decodeViewAlarmReq,
+				api.EncodeResponse,
+				opts...,
+			), "acknowledge_alarm").ServeHTTP)
+
+			r.Post("/clear", otelhttp.NewHandler(kithttp.NewServer(
+				clearAlarmEndpoint(svc),
+				decodeViewAlarmReq,
+				api.EncodeResponse,
+				opts...,
+			), "clear_alarm").ServeHTTP)
+
+			r.Post("/assign", otelhttp.NewHandler(kithttp.NewServer(
+				assignAlarmEndpoint(svc),
+				decodeAssignAlarmReq,
+				api.EncodeResponse,
+				opts...,
+			), "assign_alarm").ServeHTTP)
+		})
+	})
+
+	mux.Get("/health", supermq.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeViewAlarmReq(_ context.Context, r *http.Request) (interface{}, error) {
+	return viewAlarmReq{id: chi.URLParam(r, "alarmID")}, nil
+}
+
+func decodeListAlarmsReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	chID, err := apiutil.ReadStringQuery(r, channelIDKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	clientID, err := apiutil.ReadStringQuery(r, clientIDKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	ruleID, err := apiutil.ReadStringQuery(r, ruleIDKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	assigneeID, err := apiutil.ReadStringQuery(r, assigneeIDKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	s, err := apiutil.ReadStringQuery(r, api.StatusKey, alarms.All)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	status, err := alarms.ToStatus(s)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listAlarmsReq{
+		pm: alarms.PageMeta{
+			Offset:     offset,
+			Limit:      limit,
+			ChannelID:  chID,
+			ClientID:   clientID,
+			RuleID:     ruleID,
+			AssigneeID: assigneeID,
+			Status:     status,
+		},
+	}, nil
+}
+
+func decodeAssignAlarmReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := assignAlarmReq{id: chi.URLParam(r, "alarmID")}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
@@ -0,0 +1,8 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package alarms contains the alarms service.
+// This service turns the alarm messages published by clients and by the
+// rules engine into tracked alarms, deduplicates the repeated ones and
+// keeps track of their lifecycle.
+package alarms
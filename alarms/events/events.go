@@ -0,0 +1,122 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"time"
+
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/events"
+)
+
+const (
+	alarmPrefix      = "alarm."
+	alarmCreate      = alarmPrefix + "create"
+	alarmRepeat      = alarmPrefix + "repeat"
+	alarmAcknowledge = alarmPrefix + "acknowledge"
+	alarmClear       = alarmPrefix + "clear"
+	alarmAssign      = alarmPrefix + "assign"
+	alarmRemove      = alarmPrefix + "remove"
+)
+
+var (
+	_ events.Event = (*createAlarmEvent)(nil)
+	_ events.Event = (*updateAlarmEvent)(nil)
+	_ events.Event = (*removeAlarmEvent)(nil)
+)
+
+type createAlarmEvent struct {
+	alarms.Alarm
+}
+
+func (cae createAlarmEvent) Encode() (map[string]interface{}, error) {
+	// Repeated reports update the open alarm instead of creating a new one.
+	operation := alarmCreate
+	if cae.Count > 1 {
+		operation = alarmRepeat
+	}
+	val := encodeAlarm(cae.Alarm)
+	val["operation"] = operation
+
+	return val, nil
+}
+
+type updateAlarmEvent struct {
+	operation string
+	alarms.Alarm
+	authn.Session
+}
+
+func (uae updateAlarmEvent) Encode() (map[string]interface{}, error) {
+	val := encodeAlarm(uae.Alarm)
+	val["operation"] = uae.operation
+	val["user_id"] = uae.UserID
+	val["token_type"] = uae.Type.String()
+	val["super_admin"] = uae.SuperAdmin
+
+	return val, nil
+}
+
+type removeAlarmEvent struct {
+	id string
+	authn.Session
+}
+
+func (rae removeAlarmEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   alarmRemove,
+		"id":          rae.id,
+		"domain":      rae.DomainID,
+		"user_id":     rae.UserID,
+		"token_type":  rae.Type.String(),
+		"super_admin": rae.SuperAdmin,
+	}, nil
+}
+
+func encodeAlarm(a alarms.Alarm) map[string]interface{} {
+	val := map[string]interface{}{
+		"id":         a.ID,
+		"domain":     a.DomainID,
+		"channel_id": a.ChannelID,
+		"status":     a.Status.String(),
+		"severity":   a.Severity,
+		"count":      a.Count,
+		"created_at": a.CreatedAt,
+		"updated_at": a.UpdatedAt,
+	}
+	if a.ClientID != "" {
+		val["client_id"] = a.ClientID
+	}
+	if a.RuleID != "" {
+		val["rule_id"] = a.RuleID
+	}
+	if a.Measurement != "" {
+		val["measurement"] = a.Measurement
+	}
+	if a.Value != "" {
+		val["value"] = a.Value
+	}
+	if a.Threshold != "" {
+		val["threshold"] = a.Threshold
+	}
+	if a.Cause != "" {
+		val["cause"] = a.Cause
+	}
+	if a.AssigneeID != "" {
+		val["assignee_id"] = a.AssigneeID
+		val["assigned_by"] = a.AssignedBy
+		val["assigned_at"] = a.AssignedAt.Format(time.RFC3339Nano)
+	}
+	if a.AcknowledgedBy != "" {
+		val["acknowledged_by"] = a.AcknowledgedBy
+		val["acknowledged_at"] = a.AcknowledgedAt.Format(time.RFC3339Nano)
+	}
+	if a.ClearedBy != "" {
+		val["cleared_by"] = a.ClearedBy
+		val["cleared_at"] = a.ClearedAt.Format(time.RFC3339Nano)
+	}
+
+	return val
+}
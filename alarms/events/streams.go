@@ -0,0 +1,111 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+)
+
+const streamID = "supermq.alarms"
+
+var _ alarms.Service = (*eventStore)(nil)
+
+type eventStore struct {
+	events.Publisher
+	svc alarms.Service
+}
+
+// NewEventStoreMiddleware returns wrapper around alarms service that sends
+// alarm lifecycle events to event store.
+func NewEventStoreMiddleware(ctx context.Context, svc alarms.Service, url string) (alarms.Service, error) {
+	publisher, err := store.NewPublisher(ctx, url, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventStore{
+		svc:       svc,
+		Publisher: publisher,
+	}, nil
+}
+
+func (es *eventStore) CreateAlarm(ctx context.Context, a alarms.Alarm) (alarms.Alarm, error) {
+	saved, err := es.svc.CreateAlarm(ctx, a)
+	if err != nil {
+		return saved, err
+	}
+
+	if err := es.Publish(ctx, createAlarmEvent{Alarm: saved}); err != nil {
+		return saved, err
+	}
+
+	return saved, nil
+}
+
+func (es *eventStore) ViewAlarm(ctx context.Context, session authn.Session, id string) (alarms.Alarm, error) {
+	return es.svc.ViewAlarm(ctx, session, id)
+}
+
+func (es *eventStore) ListAlarms(ctx context.Context, session authn.Session, pm alarms.PageMeta) (alarms.AlarmsPage, error) {
+	return es.svc.ListAlarms(ctx, session, pm)
+}
+
+func (es *eventStore) AcknowledgeAlarm(ctx context.Context, session authn.Session, id string) (alarms.Alarm, error) {
+	a, err := es.svc.AcknowledgeAlarm(ctx, session, id)
+	if err != nil {
+		return a, err
+	}
+
+	return es.update(ctx, alarmAcknowledge, session, a)
+}
+
+func (es *eventStore) ClearAlarm(ctx context.Context, session authn.Session, id string) (alarms.Alarm, error) {
+	a, err := es.svc.ClearAlarm(ctx, session, id)
+	if err != nil {
+		return a, err
+	}
+
+	return es.update(ctx, alarmClear, session, a)
+}
+
+func (es *eventStore) AssignAlarm(ctx context.Context, session authn.Session, id, assigneeID string) (alarms.Alarm, error) {
+	a, err := es.svc.AssignAlarm(ctx, session, id, assigneeID)
+	if err != nil {
+		return a, err
+	}
+
+	return es.update(ctx, alarmAssign, session, a)
+}
+
+func (es *eventStore) RemoveAlarm(ctx context.Context, session authn.Session, id string) error {
+	if err := es.svc.RemoveAlarm(ctx, session, id); err != nil {
+		return err
+	}
+
+	event := removeAlarmEvent{
+		id:      id,
+		Session: session,
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) update(ctx context.Context, operation string, session authn.Session, a alarms.Alarm) (alarms.Alarm, error) {
+	event := updateAlarmEvent{
+		operation: operation,
+		Alarm:     a,
+		Session:   session,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return a, err
+	}
+
+	return a, nil
+}
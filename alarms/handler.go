@@ -0,0 +1,64 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/messaging/brokers"
+)
+
+var _ messaging.MessageHandler = (*handler)(nil)
+
+type handler struct {
+	ctx context.Context
+	svc Service
+}
+
+// Subscribe subscribes the service to the alarm reports published
+// to the alarms subtopic of any channel.
+func Subscribe(ctx context.Context, id string, sub messaging.Subscriber, svc Service) error {
+	return sub.Subscribe(ctx, messaging.SubscriberConfig{
+		ID:      id,
+		Topic:   brokers.SubjectAllChannels,
+		Handler: &handler{ctx: ctx, svc: svc},
+	})
+}
+
+func (h *handler) Handle(msg *messaging.Message) error {
+	if msg.GetSubtopic() != Subtopic {
+		return nil
+	}
+
+	var r Report
+	if err := json.Unmarshal(msg.GetPayload(), &r); err != nil {
+		return errors.Wrap(ErrMalformedReport, err)
+	}
+
+	a := Alarm{
+		ChannelID:   msg.GetChannel(),
+		ClientID:    msg.GetPublisher(),
+		RuleID:      r.RuleID,
+		Severity:    r.Severity,
+		Measurement: r.Measurement,
+		Value:       r.Value,
+		Unit:        r.Unit,
+		Threshold:   r.Threshold,
+		Cause:       r.Cause,
+	}
+	if msg.GetCreated() > 0 {
+		a.CreatedAt = time.Unix(0, msg.GetCreated()).UTC()
+	}
+	_, err := h.svc.CreateAlarm(h.ctx, a)
+
+	return err
+}
+
+func (h *handler) Cancel() error {
+	return nil
+}
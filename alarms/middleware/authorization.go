@@ -0,0 +1,138 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/alarms"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/policies"
+)
+
+var (
+	_ alarms.Service = (*authorizationMiddleware)(nil)
+
+	readPermission   = "read_permission"
+	updatePermission = "update_permission"
+)
+
+type authorizationMiddleware struct {
+	svc   alarms.Service
+	repo  alarms.Repository
+	authz smqauthz.Authorization
+}
+
+// AuthorizationMiddleware adds authorization to the alarms service.
+// Viewing alarms requires read permission on the alarm channel, while
+// acknowledging, clearing, assigning and removing them requires update
+// permission. Listing alarms of the whole domain requires domain admin
+// permission and alarms can be assigned to the domain members only.
+func AuthorizationMiddleware(svc alarms.Service, repo alarms.Repository, authz smqauthz.Authorization) alarms.Service {
+	return &authorizationMiddleware{
+		svc:   svc,
+		repo:  repo,
+		authz: authz,
+	}
+}
+
+func (am *authorizationMiddleware) CreateAlarm(ctx context.Context, a alarms.Alarm) (alarms.Alarm, error) {
+	return am.svc.CreateAlarm(ctx, a)
+}
+
+func (am *authorizationMiddleware) ViewAlarm(ctx context.Context, session smqauthn.Session, id string) (alarms.Alarm, error) {
+	if err := am.authorizeID(ctx, session, id, readPermission); err != nil {
+		return alarms.Alarm{}, err
+	}
+
+	return am.svc.ViewAlarm(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListAlarms(ctx context.Context, session smqauthn.Session, pm alarms.PageMeta) (alarms.AlarmsPage, error) {
+	if pm.ChannelID != "" {
+		if err := am.authorizeChannel(ctx, session, pm.ChannelID, readPermission); err != nil {
+			return alarms.AlarmsPage{}, err
+		}
+
+		return am.svc.ListAlarms(ctx, session, pm)
+	}
+
+	if err := am.authorizeDomain(ctx, session.DomainID, session.DomainUserID, policies.AdminPermission); err != nil {
+		return alarms.AlarmsPage{}, err
+	}
+
+	return am.svc.ListAlarms(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) AcknowledgeAlarm(ctx context.Context, session smqauthn.Session, id string) (alarms.Alarm, error) {
+	if err := am.authorizeID(ctx, session, id, updatePermission); err != nil {
+		return alarms.Alarm{}, err
+	}
+
+	return am.svc.AcknowledgeAlarm(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ClearAlarm(ctx context.Context, session smqauthn.Session, id string) (alarms.Alarm, error) {
+	if err := am.authorizeID(ctx, session, id, updatePermission); err != nil {
+		return alarms.Alarm{}, err
+	}
+
+	return am.svc.ClearAlarm(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) AssignAlarm(ctx context.Context, session smqauthn.Session, id, assigneeID string) (alarms.Alarm, error) {
+	if err := am.authorizeID(ctx, session, id, updatePermission); err != nil {
+		return alarms.Alarm{}, err
+	}
+	assignee := policies.EncodeDomainUserID(session.DomainID, assigneeID)
+	if err := am.authorizeDomain(ctx, session.DomainID, assignee, policies.MembershipPermission); err != nil {
+		return alarms.Alarm{}, err
+	}
+
+	return am.svc.AssignAlarm(ctx, session, id, assigneeID)
+}
+
+func (am *authorizationMiddleware) RemoveAlarm(ctx context.Context, session smqauthn.Session, id string) error {
+	if err := am.authorizeID(ctx, session, id, updatePermission); err != nil {
+		return err
+	}
+
+	return am.svc.RemoveAlarm(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) authorizeID(ctx context.Context, session smqauthn.Session, id, permission string) error {
+	a, err := am.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return am.authorizeChannel(ctx, session, a.ChannelID, permission)
+}
+
+func (am *authorizationMiddleware) authorizeChannel(ctx context.Context, session smqauthn.Session, channelID, permission string) error {
+	return am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  permission,
+		ObjectType:  policies.ChannelType,
+		Object:      channelID,
+	})
+}
+
+func (am *authorizationMiddleware) authorizeDomain(ctx context.Context, domainID, subject, permission string) error {
+	return am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      domainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     subject,
+		Permission:  permission,
+		ObjectType:  policies.DomainType,
+		Object:      domainID,
+	})
+}
@@ -0,0 +1,169 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/alarms"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+)
+
+var _ alarms.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    alarms.Service
+}
+
+// LoggingMiddleware adds logging facilities to the alarms service.
+func LoggingMiddleware(svc alarms.Service, logger *slog.Logger) alarms.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm *loggingMiddleware) CreateAlarm(ctx context.Context, a alarms.Alarm) (res alarms.Alarm, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("alarm",
+				slog.String("id", res.ID),
+				slog.String("domain_id", res.DomainID),
+				slog.String("channel_id", a.ChannelID),
+				slog.String("client_id", a.ClientID),
+				slog.String("rule_id", a.RuleID),
+				slog.Uint64("count", res.Count),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create alarm failed", args...)
+			return
+		}
+		lm.logger.Info("Create alarm completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateAlarm(ctx, a)
+}
+
+func (lm *loggingMiddleware) ViewAlarm(ctx context.Context, session smqauthn.Session, id string) (res alarms.Alarm, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("alarm_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View alarm failed", args...)
+			return
+		}
+		lm.logger.Info("View alarm completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewAlarm(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListAlarms(ctx context.Context, session smqauthn.Session, pm alarms.PageMeta) (res alarms.AlarmsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("page",
+				slog.String("channel_id", pm.ChannelID),
+				slog.String("client_id", pm.ClientID),
+				slog.String("rule_id", pm.RuleID),
+				slog.String("status", pm.Status.String()),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", res.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List alarms failed", args...)
+			return
+		}
+		lm.logger.Info("List alarms completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListAlarms(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) AcknowledgeAlarm(ctx context.Context, session smqauthn.Session, id string) (res alarms.Alarm, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("alarm_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Acknowledge alarm failed", args...)
+			return
+		}
+		lm.logger.Info("Acknowledge alarm completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.AcknowledgeAlarm(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ClearAlarm(ctx context.Context, session smqauthn.Session, id string) (res alarms.Alarm, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("alarm_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Clear alarm failed", args...)
+			return
+		}
+		lm.logger.Info("Clear alarm completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ClearAlarm(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) AssignAlarm(ctx context.Context, session smqauthn.Session, id, assigneeID string) (res alarms.Alarm, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("alarm_id", id),
+			slog.String("assignee_id", assigneeID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Assign alarm failed", args...)
+			return
+		}
+		lm.logger.Info("Assign alarm completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.AssignAlarm(ctx, session, id, assigneeID)
+}
+
+func (lm *loggingMiddleware) RemoveAlarm(ctx context.Context, session smqauthn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("alarm_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove alarm failed", args...)
+			return
+		}
+		lm.logger.Info("Remove alarm completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveAlarm(ctx, session, id)
+}
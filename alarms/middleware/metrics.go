@@ -0,0 +1,93 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/alarms"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ alarms.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     alarms.Service
+}
+
+// MetricsMiddleware instruments the alarms service by tracking request count and latency.
+func MetricsMiddleware(svc alarms.Service, counter metrics.Counter, latency metrics.Histogram) alarms.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) CreateAlarm(ctx context.Context, a alarms.Alarm) (alarms.Alarm, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_alarm").Add(1)
+		mm.latency.With("method", "create_alarm").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateAlarm(ctx, a)
+}
+
+func (mm *metricsMiddleware) ViewAlarm(ctx context.Context, session smqauthn.Session, id string) (alarms.Alarm, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_alarm").Add(1)
+		mm.latency.With("method", "view_alarm").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewAlarm(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListAlarms(ctx context.Context, session smqauthn.Session, pm alarms.PageMeta) (alarms.AlarmsPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_alarms").Add(1)
+		mm.latency.With("method", "list_alarms").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListAlarms(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) AcknowledgeAlarm(ctx context.Context, session smqauthn.Session, id string) (alarms.Alarm, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "acknowledge_alarm").Add(1)
+		mm.latency.With("method", "acknowledge_alarm").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.AcknowledgeAlarm(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ClearAlarm(ctx context.Context, session smqauthn.Session, id string) (alarms.Alarm, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "clear_alarm").Add(1)
+		mm.latency.With("method", "clear_alarm").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ClearAlarm(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) AssignAlarm(ctx context.Context, session smqauthn.Session, id, assigneeID string) (alarms.Alarm, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "assign_alarm").Add(1)
+		mm.latency.With("method", "assign_alarm").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.AssignAlarm(ctx, session, id, assigneeID)
+}
+
+func (mm *metricsMiddleware) RemoveAlarm(ctx context.Context, session smqauthn.Session, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_alarm").Add(1)
+		mm.latency.With("method", "remove_alarm").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveAlarm(ctx, session, id)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/alarms"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ alarms.Service = (*tracing)(nil)
+
+type tracing struct {
+	tracer trace.Tracer
+	svc    alarms.Service
+}
+
+// Tracing adds tracing to the alarms service.
+func Tracing(svc alarms.Service, tracer trace.Tracer) alarms.Service {
+	return &tracing{tracer, svc}
+}
+
+func (tm *tracing) CreateAlarm(ctx context.Context, a alarms.Alarm) (alarms.Alarm, error) {
+	ctx, span := tm.tracer.Start(ctx, "create_alarm", trace.WithAttributes(
+		attribute.String("channel_id", a.ChannelID),
+		attribute.String("client_id", a.ClientID),
+		attribute.String("rule_id", a.RuleID),
+	))
+	defer span.End()
+
+	return tm.svc.CreateAlarm(ctx, a)
+}
+
+func (tm *tracing) ViewAlarm(ctx context.Context, session smqauthn.Session, id string) (alarms.Alarm, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_alarm", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewAlarm(ctx, session, id)
+}
+
+func (tm *tracing) ListAlarms(ctx context.Context, session smqauthn.Session, pm alarms.PageMeta) (alarms.AlarmsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_alarms", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("channel_id", pm.ChannelID),
+		attribute.String("status", pm.Status.String()),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListAlarms(ctx, session, pm)
+}
+
+func (tm *tracing) AcknowledgeAlarm(ctx context.Context, session smqauthn.Session, id string) (alarms.Alarm, error) {
+	ctx, span := tm.tracer.Start(ctx, "acknowledge_alarm", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.AcknowledgeAlarm(ctx, session, id)
+}
+
+func (tm *tracing) ClearAlarm(ctx context.Context, session smqauthn.Session, id string) (alarms.Alarm, error) {
+	ctx, span := tm.tracer.Start(ctx, "clear_alarm", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ClearAlarm(ctx, session, id)
+}
+
+func (tm *tracing) AssignAlarm(ctx context.Context, session smqauthn.Session, id, assigneeID string) (alarms.Alarm, error) {
+	ctx, span := tm.tracer.Start(ctx, "assign_alarm", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("id", id),
+		attribute.String("assignee_id", assigneeID),
+	))
+	defer span.End()
+
+	return tm.svc.AssignAlarm(ctx, session, id, assigneeID)
+}
+
+func (tm *tracing) RemoveAlarm(ctx context.Context, session smqauthn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "remove_alarm", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.RemoveAlarm(ctx, session, id)
+}
@@ -0,0 +1,263 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/alarms"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/jmoiron/sqlx"
+)
+
+const alarmColumns = `id, domain_id, channel_id, client_id, rule_id, status, severity, measurement, value, unit, threshold,
+	cause, count, assignee_id, assigned_by, assigned_at, acknowledged_by, acknowledged_at, cleared_by, cleared_at,
+	created_at, updated_at`
+
+type repository struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of alarms repository.
+func NewRepository(db postgres.Database) alarms.Repository {
+	return &repository{db: db}
+}
+
+func (repo *repository) Save(ctx context.Context, a alarms.Alarm) (alarms.Alarm, error) {
+	// Conflicting with the open alarm index means the alarm is a repetition
+	// of the open one, so the open one is updated instead.
+	q := fmt.Sprintf(`INSERT INTO alarms AS a (%s)
+		VALUES (:id, :domain_id, :channel_id, :client_id, :rule_id, :status, :severity, :measurement, :value, :unit,
+		:threshold, :cause, :count, :assignee_id, :assigned_by, :assigned_at, :acknowledged_by, :acknowledged_at,
+		:cleared_by, :cleared_at, :created_at, :updated_at)
+		ON CONFLICT (channel_id, client_id, rule_id) WHERE status <> %d
+		DO UPDATE SET count = a.count + 1, severity = EXCLUDED.severity, measurement = EXCLUDED.measurement,
+		value = EXCLUDED.value, unit = EXCLUDED.unit, threshold = EXCLUDED.threshold, cause = EXCLUDED.cause,
+		updated_at = EXCLUDED.updated_at
+		RETURNING %s`, alarmColumns, alarms.ClearedStatus, alarmColumns)
+
+	return repo.namedQueryOne(ctx, q, toDBAlarm(a), repoerr.ErrCreateEntity)
+}
+
+func (repo *repository) RetrieveByID(ctx context.Context, id string) (alarms.Alarm, error) {
+	q := fmt.Sprintf(`SELECT %s FROM alarms WHERE id = :id`, alarmColumns)
+
+	return repo.namedQueryOne(ctx, q, dbAlarm{ID: id}, repoerr.ErrViewEntity)
+}
+
+func (repo *repository) RetrieveAll(ctx context.Context, pm alarms.PageMeta) (alarms.AlarmsPage, error) {
+	query := alarmsQuery(pm)
+	q := fmt.Sprintf(`SELECT %s FROM alarms %s ORDER BY updated_at DESC LIMIT :limit OFFSET :offset`, alarmColumns, query)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return alarms.AlarmsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	items, err := scanAlarms(rows)
+	if err != nil {
+		return alarms.AlarmsPage{}, err
+	}
+
+	tq := fmt.Sprintf(`SELECT COUNT(*) FROM alarms %s`, query)
+	total, err := postgres.Total(ctx, repo.db, tq, pm)
+	if err != nil {
+		return alarms.AlarmsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return alarms.AlarmsPage{
+		Total:  total,
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		Alarms: items,
+	}, nil
+}
+
+func (repo *repository) UpdateStatus(ctx context.Context, a alarms.Alarm) (alarms.Alarm, error) {
+	q := fmt.Sprintf(`UPDATE alarms SET status = :status, acknowledged_by = :acknowledged_by, acknowledged_at = :acknowledged_at,
+		cleared_by = :cleared_by, cleared_at = :cleared_at
+		WHERE id = :id RETURNING %s`, alarmColumns)
+
+	return repo.namedQueryOne(ctx, q, toDBAlarm(a), repoerr.ErrUpdateEntity)
+}
+
+func (repo *repository) UpdateAssignee(ctx context.Context, a alarms.Alarm) (alarms.Alarm, error) {
+	q := fmt.Sprintf(`UPDATE alarms SET assignee_id = :assignee_id, assigned_by = :assigned_by, assigned_at = :assigned_at
+		WHERE id = :id RETURNING %s`, alarmColumns)
+
+	return repo.namedQueryOne(ctx, q, toDBAlarm(a), repoerr.ErrUpdateEntity)
+}
+
+func (repo *repository) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM alarms WHERE id = $1`
+
+	result, err := repo.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) namedQueryOne(ctx context.Context, q string, arg interface{}, wrapper error) (alarms.Alarm, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, arg)
+	if err != nil {
+		return alarms.Alarm{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return alarms.Alarm{}, repoerr.ErrNotFound
+	}
+	var dba dbAlarm
+	if err := rows.StructScan(&dba); err != nil {
+		return alarms.Alarm{}, postgres.HandleError(wrapper, err)
+	}
+
+	return toAlarm(dba), nil
+}
+
+func scanAlarms(rows *sqlx.Rows) ([]alarms.Alarm, error) {
+	var items []alarms.Alarm
+	for rows.Next() {
+		var dba dbAlarm
+		if err := rows.StructScan(&dba); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		items = append(items, toAlarm(dba))
+	}
+
+	return items, nil
+}
+
+func alarmsQuery(pm alarms.PageMeta) string {
+	var query []string
+	if pm.DomainID != "" {
+		query = append(query, "domain_id = :domain_id")
+	}
+	if pm.ChannelID != "" {
+		query = append(query, "channel_id = :channel_id")
+	}
+	if pm.ClientID != "" {
+		query = append(query, "client_id = :client_id")
+	}
+	if pm.RuleID != "" {
+		query = append(query, "rule_id = :rule_id")
+	}
+	if pm.AssigneeID != "" {
+		query = append(query, "assignee_id = :assignee_id")
+	}
+	if pm.Status != alarms.AllStatus {
+		query = append(query, "status = :status")
+	}
+	if len(query) > 0 {
+		return fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+	}
+
+	return ""
+}
+
+type dbAlarm struct {
+	ID             string         `db:"id"`
+	DomainID       string         `db:"domain_id"`
+	ChannelID      string         `db:"channel_id"`
+	ClientID       string         `db:"client_id"`
+	RuleID         string         `db:"rule_id"`
+	Status         alarms.Status  `db:"status"`
+	Severity       uint8          `db:"severity"`
+	Measurement    sql.NullString `db:"measurement"`
+	Value          sql.NullString `db:"value"`
+	Unit           sql.NullString `db:"unit"`
+	Threshold      sql.NullString `db:"threshold"`
+	Cause          sql.NullString `db:"cause"`
+	Count          uint64         `db:"count"`
+	AssigneeID     sql.NullString `db:"assignee_id"`
+	AssignedBy     sql.NullString `db:"assigned_by"`
+	AssignedAt     sql.NullTime   `db:"assigned_at"`
+	AcknowledgedBy sql.NullString `db:"acknowledged_by"`
+	AcknowledgedAt sql.NullTime   `db:"acknowledged_at"`
+	ClearedBy      sql.NullString `db:"cleared_by"`
+	ClearedAt      sql.NullTime   `db:"cleared_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+func toDBAlarm(a alarms.Alarm) dbAlarm {
+	return dbAlarm{
+		ID:             a.ID,
+		DomainID:       a.DomainID,
+		ChannelID:      a.ChannelID,
+		ClientID:       a.ClientID,
+		RuleID:         a.RuleID,
+		Status:         a.Status,
+		Severity:       a.Severity,
+		Measurement:    toNullString(a.Measurement),
+		Value:          toNullString(a.Value),
+		Unit:           toNullString(a.Unit),
+		Threshold:      toNullString(a.Threshold),
+		Cause:          toNullString(a.Cause),
+		Count:          a.Count,
+		AssigneeID:     toNullString(a.AssigneeID),
+		AssignedBy:     toNullString(a.AssignedBy),
+		AssignedAt:     toNullTime(a.AssignedAt),
+		AcknowledgedBy: toNullString(a.AcknowledgedBy),
+		AcknowledgedAt: toNullTime(a.AcknowledgedAt),
+		ClearedBy:      toNullString(a.ClearedBy),
+		ClearedAt:      toNullTime(a.ClearedAt),
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+	}
+}
+
+func toAlarm(dba dbAlarm) alarms.Alarm {
+	return alarms.Alarm{
+		ID:             dba.ID,
+		DomainID:       dba.DomainID,
+		ChannelID:      dba.ChannelID,
+		ClientID:       dba.ClientID,
+		RuleID:         dba.RuleID,
+		Status:         dba.Status,
+		Severity:       dba.Severity,
+		Measurement:    dba.Measurement.String,
+		Value:          dba.Value.String,
+		Unit:           dba.Unit.String,
+		Threshold:      dba.Threshold.String,
+		Cause:          dba.Cause.String,
+		Count:          dba.Count,
+		AssigneeID:     dba.AssigneeID.String,
+		AssignedBy:     dba.AssignedBy.String,
+		AssignedAt:     fromNullTime(dba.AssignedAt),
+		AcknowledgedBy: dba.AcknowledgedBy.String,
+		AcknowledgedAt: fromNullTime(dba.AcknowledgedAt),
+		ClearedBy:      dba.ClearedBy.String,
+		ClearedAt:      fromNullTime(dba.ClearedAt),
+		CreatedAt:      dba.CreatedAt.UTC(),
+		UpdatedAt:      dba.UpdatedAt.UTC(),
+	}
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func toNullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func fromNullTime(t sql.NullTime) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+
+	return t.Time.UTC()
+}
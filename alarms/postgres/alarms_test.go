@@ -0,0 +1,423 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/alarms/postgres"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var invalidID = strings.Repeat("a", 37)
+
+func cleanUp(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+}
+
+func newAlarm(t *testing.T, domainID, channelID, clientID string) alarms.Alarm {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	return alarms.Alarm{
+		ID:          testsutil.GenerateUUID(t),
+		DomainID:    domainID,
+		ChannelID:   channelID,
+		ClientID:    clientID,
+		RuleID:      testsutil.GenerateUUID(t),
+		Status:      alarms.ActiveStatus,
+		Severity:    2,
+		Measurement: "temperature",
+		Value:       "35",
+		Unit:        "C",
+		Threshold:   "30",
+		Cause:       "temperature too high",
+		Count:       1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func TestSave(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	a := newAlarm(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+
+	repeated := a
+	repeated.ID = testsutil.GenerateUUID(t)
+	repeated.Severity = 4
+	repeated.Value = "40"
+	repeated.UpdatedAt = a.UpdatedAt.Add(time.Second)
+
+	repeatedRes := a
+	repeatedRes.Severity = repeated.Severity
+	repeatedRes.Value = repeated.Value
+	repeatedRes.Count = 2
+	repeatedRes.UpdatedAt = repeated.UpdatedAt
+
+	duplicateID := newAlarm(t, a.DomainID, testsutil.GenerateUUID(t), a.ClientID)
+	duplicateID.ID = a.ID
+
+	cases := []struct {
+		desc  string
+		alarm alarms.Alarm
+		resp  alarms.Alarm
+		err   error
+	}{
+		{
+			desc:  "save alarm successfully",
+			alarm: a,
+			resp:  a,
+			err:   nil,
+		},
+		{
+			desc:  "save repeated alarm updates open alarm",
+			alarm: repeated,
+			resp:  repeatedRes,
+			err:   nil,
+		},
+		{
+			desc:  "save alarm with existing ID",
+			alarm: duplicateID,
+			err:   repoerr.ErrConflict,
+		},
+		{
+			desc: "save alarm with invalid domain ID",
+			alarm: alarms.Alarm{
+				ID:        testsutil.GenerateUUID(t),
+				DomainID:  invalidID,
+				ChannelID: testsutil.GenerateUUID(t),
+				Count:     1,
+				CreatedAt: time.Now().UTC(),
+				UpdatedAt: time.Now().UTC(),
+			},
+			err: repoerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.Save(context.Background(), tc.alarm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, saved, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, saved))
+		})
+	}
+}
+
+func TestSaveAfterClear(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	a := newAlarm(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), a)
+	require.Nil(t, err, fmt.Sprintf("save alarm unexpected error: %s", err))
+
+	cleared := a
+	cleared.Status = alarms.ClearedStatus
+	cleared.ClearedBy = testsutil.GenerateUUID(t)
+	cleared.ClearedAt = time.Now().UTC().Truncate(time.Microsecond)
+	_, err = repo.UpdateStatus(context.Background(), cleared)
+	require.Nil(t, err, fmt.Sprintf("clear alarm unexpected error: %s", err))
+
+	repeated := a
+	repeated.ID = testsutil.GenerateUUID(t)
+	saved, err := repo.Save(context.Background(), repeated)
+	assert.Nil(t, err, fmt.Sprintf("save alarm after clear unexpected error: %s", err))
+	assert.Equal(t, repeated, saved, fmt.Sprintf("expected new alarm %v got %v\n", repeated, saved))
+
+	old, err := repo.RetrieveByID(context.Background(), a.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve cleared alarm unexpected error: %s", err))
+	assert.Equal(t, cleared, old, fmt.Sprintf("expected cleared alarm %v got %v\n", cleared, old))
+}
+
+func TestRetrieveByID(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	a := newAlarm(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), a)
+	require.Nil(t, err, fmt.Sprintf("save alarm unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		resp alarms.Alarm
+		err  error
+	}{
+		{
+			desc: "retrieve alarm successfully",
+			id:   a.ID,
+			resp: a,
+			err:  nil,
+		},
+		{
+			desc: "retrieve non-existing alarm",
+			id:   testsutil.GenerateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+		{
+			desc: "retrieve alarm with empty ID",
+			id:   "",
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveByID(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+	clientID := testsutil.GenerateUUID(t)
+	assigneeID := testsutil.GenerateUUID(t)
+	num := 10
+	var items, channelItems, clientItems, assignedItems, acknowledgedItems []alarms.Alarm
+	for i := 0; i < num; i++ {
+		chID := testsutil.GenerateUUID(t)
+		if i%2 == 0 {
+			chID = channelID
+		}
+		cliID := testsutil.GenerateUUID(t)
+		if i%3 == 0 {
+			cliID = clientID
+		}
+		a := newAlarm(t, domainID, chID, cliID)
+		a.UpdatedAt = a.UpdatedAt.Add(time.Duration(i) * time.Second)
+		if i%4 == 0 {
+			a.Status = alarms.AcknowledgedStatus
+		}
+		if i%5 == 0 {
+			a.AssigneeID = assigneeID
+		}
+		saved, err := repo.Save(context.Background(), a)
+		require.Nil(t, err, fmt.Sprintf("save alarm unexpected error: %s", err))
+		// Alarms are listed from the most recently updated.
+		items = append([]alarms.Alarm{saved}, items...)
+		if saved.ChannelID == channelID {
+			channelItems = append([]alarms.Alarm{saved}, channelItems...)
+		}
+		if saved.ClientID == clientID {
+			clientItems = append([]alarms.Alarm{saved}, clientItems...)
+		}
+		if saved.AssigneeID == assigneeID {
+			assignedItems = append([]alarms.Alarm{saved}, assignedItems...)
+		}
+		if saved.Status == alarms.AcknowledgedStatus {
+			acknowledgedItems = append([]alarms.Alarm{saved}, acknowledgedItems...)
+		}
+	}
+	_, err := repo.Save(context.Background(), newAlarm(t, testsutil.GenerateUUID(t), channelID, clientID))
+	require.Nil(t, err, fmt.Sprintf("save alarm unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		pm   alarms.PageMeta
+		resp alarms.AlarmsPage
+	}{
+		{
+			desc: "retrieve alarms of domain",
+			pm:   alarms.PageMeta{DomainID: domainID, Status: alarms.AllStatus, Limit: 10},
+			resp: alarms.AlarmsPage{Total: uint64(num), Limit: 10, Alarms: items},
+		},
+		{
+			desc: "retrieve alarms of domain with offset and limit",
+			pm:   alarms.PageMeta{DomainID: domainID, Status: alarms.AllStatus, Offset: 2, Limit: 3},
+			resp: alarms.AlarmsPage{Total: uint64(num), Offset: 2, Limit: 3, Alarms: items[2:5]},
+		},
+		{
+			desc: "retrieve alarms of domain channel",
+			pm:   alarms.PageMeta{DomainID: domainID, ChannelID: channelID, Status: alarms.AllStatus, Limit: 10},
+			resp: alarms.AlarmsPage{Total: uint64(len(channelItems)), Limit: 10, Alarms: channelItems},
+		},
+		{
+			desc: "retrieve alarms of domain client",
+			pm:   alarms.PageMeta{DomainID: domainID, ClientID: clientID, Status: alarms.AllStatus, Limit: 10},
+			resp: alarms.AlarmsPage{Total: uint64(len(clientItems)), Limit: 10, Alarms: clientItems},
+		},
+		{
+			desc: "retrieve alarms of assignee",
+			pm:   alarms.PageMeta{DomainID: domainID, AssigneeID: assigneeID, Status: alarms.AllStatus, Limit: 10},
+			resp: alarms.AlarmsPage{Total: uint64(len(assignedItems)), Limit: 10, Alarms: assignedItems},
+		},
+		{
+			desc: "retrieve acknowledged alarms of domain",
+			pm:   alarms.PageMeta{DomainID: domainID, Status: alarms.AcknowledgedStatus, Limit: 10},
+			resp: alarms.AlarmsPage{Total: uint64(len(acknowledgedItems)), Limit: 10, Alarms: acknowledgedItems},
+		},
+		{
+			desc: "retrieve alarms of domain without alarms",
+			pm:   alarms.PageMeta{DomainID: testsutil.GenerateUUID(t), Status: alarms.AllStatus, Limit: 10},
+			resp: alarms.AlarmsPage{Limit: 10},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	a := newAlarm(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), a)
+	require.Nil(t, err, fmt.Sprintf("save alarm unexpected error: %s", err))
+
+	acknowledged := a
+	acknowledged.Status = alarms.AcknowledgedStatus
+	acknowledged.AcknowledgedBy = testsutil.GenerateUUID(t)
+	acknowledged.AcknowledgedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	cleared := acknowledged
+	cleared.Status = alarms.ClearedStatus
+	cleared.ClearedBy = testsutil.GenerateUUID(t)
+	cleared.ClearedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	cases := []struct {
+		desc  string
+		alarm alarms.Alarm
+		resp  alarms.Alarm
+		err   error
+	}{
+		{
+			desc:  "acknowledge alarm successfully",
+			alarm: acknowledged,
+			resp:  acknowledged,
+			err:   nil,
+		},
+		{
+			desc:  "clear alarm successfully",
+			alarm: cleared,
+			resp:  cleared,
+			err:   nil,
+		},
+		{
+			desc: "update status of non-existing alarm",
+			alarm: alarms.Alarm{
+				ID:     testsutil.GenerateUUID(t),
+				Status: alarms.ClearedStatus,
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.UpdateStatus(context.Background(), tc.alarm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestUpdateAssignee(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	a := newAlarm(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), a)
+	require.Nil(t, err, fmt.Sprintf("save alarm unexpected error: %s", err))
+
+	assigned := a
+	assigned.AssigneeID = testsutil.GenerateUUID(t)
+	assigned.AssignedBy = testsutil.GenerateUUID(t)
+	assigned.AssignedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	cases := []struct {
+		desc  string
+		alarm alarms.Alarm
+		resp  alarms.Alarm
+		err   error
+	}{
+		{
+			desc:  "assign alarm successfully",
+			alarm: assigned,
+			resp:  assigned,
+			err:   nil,
+		},
+		{
+			desc: "assign alarm with invalid assignee ID",
+			alarm: alarms.Alarm{
+				ID:         a.ID,
+				AssigneeID: invalidID,
+			},
+			err: repoerr.ErrMalformedEntity,
+		},
+		{
+			desc: "assign non-existing alarm",
+			alarm: alarms.Alarm{
+				ID:         testsutil.GenerateUUID(t),
+				AssigneeID: testsutil.GenerateUUID(t),
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.UpdateAssignee(context.Background(), tc.alarm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	a := newAlarm(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), a)
+	require.Nil(t, err, fmt.Sprintf("save alarm unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove alarm successfully",
+			id:   a.ID,
+			err:  nil,
+		},
+		{
+			desc: "remove removed alarm",
+			id:   a.ID,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Remove(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "alarms_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS alarms (
+						id              VARCHAR(36) PRIMARY KEY,
+						domain_id       VARCHAR(36) NOT NULL,
+						channel_id      VARCHAR(36) NOT NULL,
+						client_id       VARCHAR(36) NOT NULL DEFAULT '',
+						rule_id         VARCHAR(36) NOT NULL DEFAULT '',
+						status          SMALLINT NOT NULL DEFAULT 0 CHECK (status >= 0),
+						severity        SMALLINT NOT NULL DEFAULT 0 CHECK (severity >= 0),
+						measurement     VARCHAR(1024),
+						value           VARCHAR(1024),
+						unit            VARCHAR(254),
+						threshold       VARCHAR(1024),
+						cause           VARCHAR(1024),
+						count           BIGINT NOT NULL DEFAULT 1,
+						assignee_id     VARCHAR(36),
+						assigned_by     VARCHAR(254),
+						assigned_at     TIMESTAMP,
+						acknowledged_by VARCHAR(254),
+						acknowledged_at TIMESTAMP,
+						cleared_by      VARCHAR(254),
+						cleared_at      TIMESTAMP,
+						created_at      TIMESTAMP NOT NULL,
+						updated_at      TIMESTAMP NOT NULL
+					)`,
+					// At most one alarm per client, channel and rule is open at a time.
+					`CREATE UNIQUE INDEX IF NOT EXISTS idx_alarms_open ON alarms(channel_id, client_id, rule_id) WHERE status <> 2;`,
+					`CREATE INDEX IF NOT EXISTS idx_alarms_domain ON alarms(domain_id, status);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS alarms`,
+				},
+			},
+		},
+	}
+}
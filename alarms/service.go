@@ -0,0 +1,157 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq"
+	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+)
+
+type service struct {
+	idProvider supermq.IDProvider
+	repo       Repository
+	channels   grpcChannelsV1.ChannelsServiceClient
+}
+
+// New instantiates the alarms service implementation.
+func New(idp supermq.IDProvider, repo Repository, channels grpcChannelsV1.ChannelsServiceClient) Service {
+	return &service{
+		idProvider: idp,
+		repo:       repo,
+		channels:   channels,
+	}
+}
+
+func (svc *service) CreateAlarm(ctx context.Context, a Alarm) (Alarm, error) {
+	if a.ChannelID == "" {
+		return Alarm{}, svcerr.ErrMalformedEntity
+	}
+	// Alarm reports come from the message broker, so the domain
+	// is resolved from the channel the report was published to.
+	res, err := svc.channels.RetrieveEntity(ctx, &grpcCommonV1.RetrieveEntityReq{Id: a.ChannelID})
+	if err != nil {
+		return Alarm{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return Alarm{}, err
+	}
+	a.ID = id
+	a.DomainID = res.GetEntity().GetDomainId()
+	a.Status = ActiveStatus
+	a.Count = 1
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = time.Now().UTC()
+	}
+	a.UpdatedAt = a.CreatedAt
+
+	saved, err := svc.repo.Save(ctx, a)
+	if err != nil {
+		return Alarm{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return saved, nil
+}
+
+func (svc *service) ViewAlarm(ctx context.Context, session smqauthn.Session, id string) (Alarm, error) {
+	a, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return Alarm{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if a.DomainID != session.DomainID {
+		return Alarm{}, svcerr.ErrNotFound
+	}
+
+	return a, nil
+}
+
+func (svc *service) ListAlarms(ctx context.Context, session smqauthn.Session, pm PageMeta) (AlarmsPage, error) {
+	pm.DomainID = session.DomainID
+	page, err := svc.repo.RetrieveAll(ctx, pm)
+	if err != nil {
+		return AlarmsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc *service) AcknowledgeAlarm(ctx context.Context, session smqauthn.Session, id string) (Alarm, error) {
+	a, err := svc.ViewAlarm(ctx, session, id)
+	if err != nil {
+		return Alarm{}, err
+	}
+	switch a.Status {
+	case AcknowledgedStatus:
+		return Alarm{}, errors.ErrStatusAlreadyAssigned
+	case ClearedStatus:
+		return Alarm{}, errors.Wrap(svcerr.ErrConflict, ErrInvalidTransition)
+	}
+	a.Status = AcknowledgedStatus
+	a.AcknowledgedBy = session.UserID
+	a.AcknowledgedAt = time.Now().UTC()
+
+	return svc.updateStatus(ctx, a)
+}
+
+func (svc *service) ClearAlarm(ctx context.Context, session smqauthn.Session, id string) (Alarm, error) {
+	a, err := svc.ViewAlarm(ctx, session, id)
+	if err != nil {
+		return Alarm{}, err
+	}
+	if a.Status == ClearedStatus {
+		return Alarm{}, errors.ErrStatusAlreadyAssigned
+	}
+	a.Status = ClearedStatus
+	a.ClearedBy = session.UserID
+	a.ClearedAt = time.Now().UTC()
+
+	return svc.updateStatus(ctx, a)
+}
+
+func (svc *service) AssignAlarm(ctx context.Context, session smqauthn.Session, id, assigneeID string) (Alarm, error) {
+	a, err := svc.ViewAlarm(ctx, session, id)
+	if err != nil {
+		return Alarm{}, err
+	}
+	if a.Status == ClearedStatus {
+		return Alarm{}, errors.Wrap(svcerr.ErrConflict, ErrInvalidTransition)
+	}
+	a.AssigneeID = assigneeID
+	a.AssignedBy = session.UserID
+	a.AssignedAt = time.Now().UTC()
+
+	updated, err := svc.repo.UpdateAssignee(ctx, a)
+	if err != nil {
+		return Alarm{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return updated, nil
+}
+
+func (svc *service) RemoveAlarm(ctx context.Context, session smqauthn.Session, id string) error {
+	if _, err := svc.ViewAlarm(ctx, session, id); err != nil {
+		return err
+	}
+	if err := svc.repo.Remove(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *service) updateStatus(ctx context.Context, a Alarm) (Alarm, error) {
+	updated, err := svc.repo.UpdateStatus(ctx, a)
+	if err != nil {
+		return Alarm{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,265 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/alarms"
+	amocks "github.com/absmach/supermq/alarms/mocks"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	chmocks "github.com/absmach/supermq/channels/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	pubsubmocks "github.com/absmach/supermq/pkg/messaging/mocks"
+	"github.com/absmach/supermq/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	channelID = testsutil.GenerateUUID(&testing.T{})
+	clientID  = testsutil.GenerateUUID(&testing.T{})
+	session   = smqauthn.Session{DomainID: testsutil.GenerateUUID(&testing.T{}), UserID: testsutil.GenerateUUID(&testing.T{})}
+)
+
+func newService() (alarms.Service, *amocks.Repository, *chmocks.ChannelsServiceClient) {
+	repo := new(amocks.Repository)
+	channels := new(chmocks.ChannelsServiceClient)
+
+	return alarms.New(uuid.NewMock(), repo, channels), repo, channels
+}
+
+func TestCreateAlarm(t *testing.T) {
+	cases := []struct {
+		desc       string
+		alarm      alarms.Alarm
+		channelErr error
+		repoErr    error
+		err        error
+	}{
+		{
+			desc:  "create alarm successfully",
+			alarm: alarms.Alarm{ChannelID: channelID, ClientID: clientID, Severity: 3, Measurement: "temperature", Value: "35"},
+		},
+		{
+			desc:  "create alarm without channel",
+			alarm: alarms.Alarm{ClientID: clientID},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:       "create alarm for unknown channel",
+			alarm:      alarms.Alarm{ChannelID: channelID, ClientID: clientID},
+			channelErr: svcerr.ErrNotFound,
+			err:        svcerr.ErrViewEntity,
+		},
+		{
+			desc:    "create alarm with repo error",
+			alarm:   alarms.Alarm{ChannelID: channelID, ClientID: clientID},
+			repoErr: repoerr.ErrCreateEntity,
+			err:     svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, channels := newService()
+			res := &grpcCommonV1.RetrieveEntityRes{Entity: &grpcCommonV1.EntityBasic{Id: channelID, DomainId: session.DomainID}}
+			channels.On("RetrieveEntity", context.Background(), &grpcCommonV1.RetrieveEntityReq{Id: tc.alarm.ChannelID}).Return(res, tc.channelErr)
+			repo.On("Save", context.Background(), mock.MatchedBy(func(a alarms.Alarm) bool {
+				return a.DomainID == session.DomainID && a.Status == alarms.ActiveStatus && a.Count == 1 && !a.CreatedAt.IsZero()
+			})).Return(tc.alarm, tc.repoErr)
+			_, err := svc.CreateAlarm(context.Background(), tc.alarm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestAcknowledgeAlarm(t *testing.T) {
+	active := alarms.Alarm{ID: testsutil.GenerateUUID(t), DomainID: session.DomainID, ChannelID: channelID, Status: alarms.ActiveStatus}
+	acknowledged := active
+	acknowledged.Status = alarms.AcknowledgedStatus
+	cleared := active
+	cleared.Status = alarms.ClearedStatus
+
+	cases := []struct {
+		desc     string
+		repoResp alarms.Alarm
+		err      error
+	}{
+		{
+			desc:     "acknowledge active alarm",
+			repoResp: active,
+		},
+		{
+			desc:     "acknowledge acknowledged alarm",
+			repoResp: acknowledged,
+			err:      errors.ErrStatusAlreadyAssigned,
+		},
+		{
+			desc:     "acknowledge cleared alarm",
+			repoResp: cleared,
+			err:      alarms.ErrInvalidTransition,
+		},
+		{
+			desc:     "acknowledge alarm from another domain",
+			repoResp: alarms.Alarm{ID: active.ID, DomainID: testsutil.GenerateUUID(t)},
+			err:      svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, _ := newService()
+			repo.On("RetrieveByID", context.Background(), active.ID).Return(tc.repoResp, nil)
+			repo.On("UpdateStatus", context.Background(), mock.MatchedBy(func(a alarms.Alarm) bool {
+				return a.Status == alarms.AcknowledgedStatus && a.AcknowledgedBy == session.UserID && !a.AcknowledgedAt.IsZero()
+			})).Return(acknowledged, nil)
+			res, err := svc.AcknowledgeAlarm(context.Background(), session, active.ID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, alarms.AcknowledgedStatus, res.Status)
+			}
+		})
+	}
+}
+
+func TestClearAlarm(t *testing.T) {
+	acknowledged := alarms.Alarm{ID: testsutil.GenerateUUID(t), DomainID: session.DomainID, ChannelID: channelID, Status: alarms.AcknowledgedStatus}
+	cleared := acknowledged
+	cleared.Status = alarms.ClearedStatus
+
+	cases := []struct {
+		desc     string
+		repoResp alarms.Alarm
+		err      error
+	}{
+		{
+			desc:     "clear acknowledged alarm",
+			repoResp: acknowledged,
+		},
+		{
+			desc:     "clear cleared alarm",
+			repoResp: cleared,
+			err:      errors.ErrStatusAlreadyAssigned,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, _ := newService()
+			repo.On("RetrieveByID", context.Background(), acknowledged.ID).Return(tc.repoResp, nil)
+			repo.On("UpdateStatus", context.Background(), mock.MatchedBy(func(a alarms.Alarm) bool {
+				return a.Status == alarms.ClearedStatus && a.ClearedBy == session.UserID && !a.ClearedAt.IsZero()
+			})).Return(cleared, nil)
+			_, err := svc.ClearAlarm(context.Background(), session, acknowledged.ID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestAssignAlarm(t *testing.T) {
+	assignee := testsutil.GenerateUUID(t)
+	active := alarms.Alarm{ID: testsutil.GenerateUUID(t), DomainID: session.DomainID, ChannelID: channelID, Status: alarms.ActiveStatus}
+	cleared := active
+	cleared.Status = alarms.ClearedStatus
+
+	cases := []struct {
+		desc     string
+		repoResp alarms.Alarm
+		err      error
+	}{
+		{
+			desc:     "assign active alarm",
+			repoResp: active,
+		},
+		{
+			desc:     "assign cleared alarm",
+			repoResp: cleared,
+			err:      alarms.ErrInvalidTransition,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, _ := newService()
+			repo.On("RetrieveByID", context.Background(), active.ID).Return(tc.repoResp, nil)
+			repo.On("UpdateAssignee", context.Background(), mock.MatchedBy(func(a alarms.Alarm) bool {
+				return a.AssigneeID == assignee && a.AssignedBy == session.UserID && !a.AssignedAt.IsZero()
+			})).Return(active, nil)
+			_, err := svc.AssignAlarm(context.Background(), session, active.ID, assignee)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestHandle(t *testing.T) {
+	cases := []struct {
+		desc   string
+		msg    *messaging.Message
+		called bool
+		err    error
+	}{
+		{
+			desc: "handle alarm report",
+			msg: &messaging.Message{
+				Channel:   channelID,
+				Subtopic:  alarms.Subtopic,
+				Publisher: clientID,
+				Payload:   []byte(`{"rule_id":"rule","measurement":"temperature","value":"35","severity":3}`),
+			},
+			called: true,
+		},
+		{
+			desc: "handle message published to another subtopic",
+			msg: &messaging.Message{
+				Channel:   channelID,
+				Subtopic:  "room1",
+				Publisher: clientID,
+				Payload:   []byte(`{"severity":3}`),
+			},
+		},
+		{
+			desc: "handle malformed alarm report",
+			msg: &messaging.Message{
+				Channel:   channelID,
+				Subtopic:  alarms.Subtopic,
+				Publisher: clientID,
+				Payload:   []byte(`{"severity":`),
+			},
+			err: alarms.ErrMalformedReport,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := new(amocks.Service)
+			pubsub := new(pubsubmocks.PubSub)
+			var handler messaging.MessageHandler
+			subCall := pubsub.On("Subscribe", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				handler = args.Get(1).(messaging.SubscriberConfig).Handler
+			}).Return(nil)
+			err := alarms.Subscribe(context.Background(), "alarms", pubsub, svc)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected subscribe error %s", tc.desc, err))
+
+			svc.On("CreateAlarm", context.Background(), mock.MatchedBy(func(a alarms.Alarm) bool {
+				return a.ChannelID == channelID && a.ClientID == clientID && a.RuleID == "rule" && a.Severity == 3
+			})).Return(alarms.Alarm{}, nil)
+			err = handler.Handle(tc.msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.called {
+				svc.AssertNumberOfCalls(t, "CreateAlarm", 1)
+			} else {
+				svc.AssertNotCalled(t, "CreateAlarm", mock.Anything, mock.Anything)
+			}
+			subCall.Unset()
+		})
+	}
+}
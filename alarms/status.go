@@ -0,0 +1,82 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import (
+	"encoding/json"
+	"strings"
+
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+)
+
+// Status represents Alarm status.
+type Status uint8
+
+// Possible Alarm status values.
+const (
+	// ActiveStatus represents raised Alarm which nobody acknowledged yet.
+	ActiveStatus Status = iota
+	// AcknowledgedStatus represents Alarm acknowledged by a user.
+	AcknowledgedStatus
+	// ClearedStatus represents resolved Alarm.
+	ClearedStatus
+
+	// AllStatus is used for querying purposes to list alarms irrespective
+	// of their status. It is never stored in the database as the actual
+	// Alarm status and should always be the largest value in this enumeration.
+	AllStatus
+)
+
+// String representation of the possible status values.
+const (
+	Active       = "active"
+	Acknowledged = "acknowledged"
+	Cleared      = "cleared"
+	All          = "all"
+	Unknown      = "unknown"
+)
+
+// String converts alarm status to string literal.
+func (s Status) String() string {
+	switch s {
+	case ActiveStatus:
+		return Active
+	case AcknowledgedStatus:
+		return Acknowledged
+	case ClearedStatus:
+		return Cleared
+	case AllStatus:
+		return All
+	default:
+		return Unknown
+	}
+}
+
+// ToStatus converts string value to a valid Alarm status.
+func ToStatus(status string) (Status, error) {
+	switch status {
+	case "", Active:
+		return ActiveStatus, nil
+	case Acknowledged:
+		return AcknowledgedStatus, nil
+	case Cleared:
+		return ClearedStatus, nil
+	case All:
+		return AllStatus, nil
+	}
+	return Status(0), svcerr.ErrInvalidStatus
+}
+
+// Custom Marshaller for Status.
+func (s Status) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// Custom Unmarshaler for Status.
+func (s *Status) UnmarshalJSON(data []byte) error {
+	str := strings.Trim(string(data), "\"")
+	val, err := ToStatus(str)
+	*s = val
+	return err
+}
@@ -27,6 +27,8 @@ const (
 	ChannelsService_RemoveClientConnections_FullMethodName      = "/channels.v1.ChannelsService/RemoveClientConnections"
 	ChannelsService_UnsetParentGroupFromChannels_FullMethodName = "/channels.v1.ChannelsService/UnsetParentGroupFromChannels"
 	ChannelsService_RetrieveEntity_FullMethodName               = "/channels.v1.ChannelsService/RetrieveEntity"
+	ChannelsService_RetrieveIDByName_FullMethodName             = "/channels.v1.ChannelsService/RetrieveIDByName"
+	ChannelsService_MoveParentGroupChannels_FullMethodName      = "/channels.v1.ChannelsService/MoveParentGroupChannels"
 )
 
 // ChannelsServiceClient is the client API for ChannelsService service.
@@ -37,6 +39,11 @@ type ChannelsServiceClient interface {
 	RemoveClientConnections(ctx context.Context, in *RemoveClientConnectionsReq, opts ...grpc.CallOption) (*RemoveClientConnectionsRes, error)
 	UnsetParentGroupFromChannels(ctx context.Context, in *UnsetParentGroupFromChannelsReq, opts ...grpc.CallOption) (*UnsetParentGroupFromChannelsRes, error)
 	RetrieveEntity(ctx context.Context, in *v1.RetrieveEntityReq, opts ...grpc.CallOption) (*v1.RetrieveEntityRes, error)
+	RetrieveIDByName(ctx context.Context, in *RetrieveIDByNameReq, opts ...grpc.CallOption) (*RetrieveIDByNameRes, error)
+	// MoveParentGroupChannels moves the channels of the given parent groups to
+	// the domain and removes their connections and the connections of the
+	// given clients.
+	MoveParentGroupChannels(ctx context.Context, in *MoveParentGroupChannelsReq, opts ...grpc.CallOption) (*MoveParentGroupChannelsRes, error)
 }
 
 type channelsServiceClient struct {
@@ -87,6 +94,26 @@ func (c *channelsServiceClient) RetrieveEntity(ctx context.Context, in *v1.Retri
 	return out, nil
 }
 
+func (c *channelsServiceClient) RetrieveIDByName(ctx context.Context, in *RetrieveIDByNameReq, opts ...grpc.CallOption) (*RetrieveIDByNameRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetrieveIDByNameRes)
+	err := c.cc.Invoke(ctx, ChannelsService_RetrieveIDByName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelsServiceClient) MoveParentGroupChannels(ctx context.Context, in *MoveParentGroupChannelsReq, opts ...grpc.CallOption) (*MoveParentGroupChannelsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveParentGroupChannelsRes)
+	err := c.cc.Invoke(ctx, ChannelsService_MoveParentGroupChannels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChannelsServiceServer is the server API for ChannelsService service.
 // All implementations must embed UnimplementedChannelsServiceServer
 // for forward compatibility.
@@ -95,6 +122,11 @@ type ChannelsServiceServer interface {
 	RemoveClientConnections(context.Context, *RemoveClientConnectionsReq) (*RemoveClientConnectionsRes, error)
 	UnsetParentGroupFromChannels(context.Context, *UnsetParentGroupFromChannelsReq) (*UnsetParentGroupFromChannelsRes, error)
 	RetrieveEntity(context.Context, *v1.RetrieveEntityReq) (*v1.RetrieveEntityRes, error)
+	RetrieveIDByName(context.Context, *RetrieveIDByNameReq) (*RetrieveIDByNameRes, error)
+	// MoveParentGroupChannels moves the channels of the given parent groups to
+	// the domain and removes their connections and the connections of the
+	// given clients.
+	MoveParentGroupChannels(context.Context, *MoveParentGroupChannelsReq) (*MoveParentGroupChannelsRes, error)
 	mustEmbedUnimplementedChannelsServiceServer()
 }
 
@@ -117,6 +149,12 @@ func (UnimplementedChannelsServiceServer) UnsetParentGroupFromChannels(context.C
 func (UnimplementedChannelsServiceServer) RetrieveEntity(context.Context, *v1.RetrieveEntityReq) (*v1.RetrieveEntityRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetrieveEntity not implemented")
 }
+func (UnimplementedChannelsServiceServer) RetrieveIDByName(context.Context, *RetrieveIDByNameReq) (*RetrieveIDByNameRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveIDByName not implemented")
+}
+func (UnimplementedChannelsServiceServer) MoveParentGroupChannels(context.Context, *MoveParentGroupChannelsReq) (*MoveParentGroupChannelsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveParentGroupChannels not implemented")
+}
 func (UnimplementedChannelsServiceServer) mustEmbedUnimplementedChannelsServiceServer() {}
 func (UnimplementedChannelsServiceServer) testEmbeddedByValue()                         {}
 
@@ -210,6 +248,42 @@ func _ChannelsService_RetrieveEntity_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChannelsService_RetrieveIDByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetrieveIDByNameReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelsServiceServer).RetrieveIDByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelsService_RetrieveIDByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelsServiceServer).RetrieveIDByName(ctx, req.(*RetrieveIDByNameReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelsService_MoveParentGroupChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveParentGroupChannelsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelsServiceServer).MoveParentGroupChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelsService_MoveParentGroupChannels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelsServiceServer).MoveParentGroupChannels(ctx, req.(*MoveParentGroupChannelsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChannelsService_ServiceDesc is the grpc.ServiceDesc for ChannelsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -233,6 +307,14 @@ var ChannelsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RetrieveEntity",
 			Handler:    _ChannelsService_RetrieveEntity_Handler,
 		},
+		{
+			MethodName: "RetrieveIDByName",
+			Handler:    _ChannelsService_RetrieveIDByName_Handler,
+		},
+		{
+			MethodName: "MoveParentGroupChannels",
+			Handler:    _ChannelsService_MoveParentGroupChannels_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "channels/v1/channels.proto",
@@ -30,6 +30,7 @@ const (
 	ClientsService_RemoveConnections_FullMethodName          = "/clients.v1.ClientsService/RemoveConnections"
 	ClientsService_RemoveChannelConnections_FullMethodName   = "/clients.v1.ClientsService/RemoveChannelConnections"
 	ClientsService_UnsetParentGroupFromClient_FullMethodName = "/clients.v1.ClientsService/UnsetParentGroupFromClient"
+	ClientsService_MoveParentGroupClients_FullMethodName     = "/clients.v1.ClientsService/MoveParentGroupClients"
 )
 
 // ClientsServiceClient is the client API for ClientsService service.
@@ -47,6 +48,9 @@ type ClientsServiceClient interface {
 	RemoveConnections(ctx context.Context, in *v1.RemoveConnectionsReq, opts ...grpc.CallOption) (*v1.RemoveConnectionsRes, error)
 	RemoveChannelConnections(ctx context.Context, in *RemoveChannelConnectionsReq, opts ...grpc.CallOption) (*RemoveChannelConnectionsRes, error)
 	UnsetParentGroupFromClient(ctx context.Context, in *UnsetParentGroupFromClientReq, opts ...grpc.CallOption) (*UnsetParentGroupFromClientRes, error)
+	// MoveParentGroupClients moves the clients of the given parent groups
+	// to the domain and returns their IDs.
+	MoveParentGroupClients(ctx context.Context, in *MoveParentGroupClientsReq, opts ...grpc.CallOption) (*MoveParentGroupClientsRes, error)
 }
 
 type clientsServiceClient struct {
@@ -127,6 +131,16 @@ func (c *clientsServiceClient) UnsetParentGroupFromClient(ctx context.Context, i
 	return out, nil
 }
 
+func (c *clientsServiceClient) MoveParentGroupClients(ctx context.Context, in *MoveParentGroupClientsReq, opts ...grpc.CallOption) (*MoveParentGroupClientsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveParentGroupClientsRes)
+	err := c.cc.Invoke(ctx, ClientsService_MoveParentGroupClients_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ClientsServiceServer is the server API for ClientsService service.
 // All implementations must embed UnimplementedClientsServiceServer
 // for forward compatibility.
@@ -142,6 +156,9 @@ type ClientsServiceServer interface {
 	RemoveConnections(context.Context, *v1.RemoveConnectionsReq) (*v1.RemoveConnectionsRes, error)
 	RemoveChannelConnections(context.Context, *RemoveChannelConnectionsReq) (*RemoveChannelConnectionsRes, error)
 	UnsetParentGroupFromClient(context.Context, *UnsetParentGroupFromClientReq) (*UnsetParentGroupFromClientRes, error)
+	// MoveParentGroupClients moves the clients of the given parent groups
+	// to the domain and returns their IDs.
+	MoveParentGroupClients(context.Context, *MoveParentGroupClientsReq) (*MoveParentGroupClientsRes, error)
 	mustEmbedUnimplementedClientsServiceServer()
 }
 
@@ -173,6 +190,9 @@ func (UnimplementedClientsServiceServer) RemoveChannelConnections(context.Contex
 func (UnimplementedClientsServiceServer) UnsetParentGroupFromClient(context.Context, *UnsetParentGroupFromClientReq) (*UnsetParentGroupFromClientRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UnsetParentGroupFromClient not implemented")
 }
+func (UnimplementedClientsServiceServer) MoveParentGroupClients(context.Context, *MoveParentGroupClientsReq) (*MoveParentGroupClientsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveParentGroupClients not implemented")
+}
 func (UnimplementedClientsServiceServer) mustEmbedUnimplementedClientsServiceServer() {}
 func (UnimplementedClientsServiceServer) testEmbeddedByValue()                        {}
 
@@ -320,6 +340,24 @@ func _ClientsService_UnsetParentGroupFromClient_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClientsService_MoveParentGroupClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveParentGroupClientsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).MoveParentGroupClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientsService_MoveParentGroupClients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).MoveParentGroupClients(ctx, req.(*MoveParentGroupClientsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ClientsService_ServiceDesc is the grpc.ServiceDesc for ClientsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -355,6 +393,10 @@ var ClientsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UnsetParentGroupFromClient",
 			Handler:    _ClientsService_UnsetParentGroupFromClient_Handler,
 		},
+		{
+			MethodName: "MoveParentGroupClients",
+			Handler:    _ClientsService_MoveParentGroupClients_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "clients/v1/clients.proto",
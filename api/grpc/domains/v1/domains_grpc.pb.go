@@ -25,6 +25,9 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	DomainsService_DeleteUserFromDomains_FullMethodName = "/domains.v1.DomainsService/DeleteUserFromDomains"
 	DomainsService_RetrieveEntity_FullMethodName        = "/domains.v1.DomainsService/RetrieveEntity"
+	DomainsService_RequiresMFA_FullMethodName           = "/domains.v1.DomainsService/RequiresMFA"
+	DomainsService_RetrieveSettings_FullMethodName      = "/domains.v1.DomainsService/RetrieveSettings"
+	DomainsService_RetrieveIDByAlias_FullMethodName     = "/domains.v1.DomainsService/RetrieveIDByAlias"
 )
 
 // DomainsServiceClient is the client API for DomainsService service.
@@ -36,6 +39,9 @@ const (
 type DomainsServiceClient interface {
 	DeleteUserFromDomains(ctx context.Context, in *DeleteUserReq, opts ...grpc.CallOption) (*DeleteUserRes, error)
 	RetrieveEntity(ctx context.Context, in *v1.RetrieveEntityReq, opts ...grpc.CallOption) (*v1.RetrieveEntityRes, error)
+	RequiresMFA(ctx context.Context, in *RequiresMFAReq, opts ...grpc.CallOption) (*RequiresMFARes, error)
+	RetrieveSettings(ctx context.Context, in *RetrieveSettingsReq, opts ...grpc.CallOption) (*RetrieveSettingsRes, error)
+	RetrieveIDByAlias(ctx context.Context, in *RetrieveIDByAliasReq, opts ...grpc.CallOption) (*RetrieveIDByAliasRes, error)
 }
 
 type domainsServiceClient struct {
@@ -66,6 +72,36 @@ func (c *domainsServiceClient) RetrieveEntity(ctx context.Context, in *v1.Retrie
 	return out, nil
 }
 
+func (c *domainsServiceClient) RequiresMFA(ctx context.Context, in *RequiresMFAReq, opts ...grpc.CallOption) (*RequiresMFARes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequiresMFARes)
+	err := c.cc.Invoke(ctx, DomainsService_RequiresMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *domainsServiceClient) RetrieveSettings(ctx context.Context, in *RetrieveSettingsReq, opts ...grpc.CallOption) (*RetrieveSettingsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetrieveSettingsRes)
+	err := c.cc.Invoke(ctx, DomainsService_RetrieveSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *domainsServiceClient) RetrieveIDByAlias(ctx context.Context, in *RetrieveIDByAliasReq, opts ...grpc.CallOption) (*RetrieveIDByAliasRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetrieveIDByAliasRes)
+	err := c.cc.Invoke(ctx, DomainsService_RetrieveIDByAlias_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DomainsServiceServer is the server API for DomainsService service.
 // All implementations must embed UnimplementedDomainsServiceServer
 // for forward compatibility.
@@ -75,6 +111,9 @@ func (c *domainsServiceClient) RetrieveEntity(ctx context.Context, in *v1.Retrie
 type DomainsServiceServer interface {
 	DeleteUserFromDomains(context.Context, *DeleteUserReq) (*DeleteUserRes, error)
 	RetrieveEntity(context.Context, *v1.RetrieveEntityReq) (*v1.RetrieveEntityRes, error)
+	RequiresMFA(context.Context, *RequiresMFAReq) (*RequiresMFARes, error)
+	RetrieveSettings(context.Context, *RetrieveSettingsReq) (*RetrieveSettingsRes, error)
+	RetrieveIDByAlias(context.Context, *RetrieveIDByAliasReq) (*RetrieveIDByAliasRes, error)
 	mustEmbedUnimplementedDomainsServiceServer()
 }
 
@@ -91,6 +130,15 @@ func (UnimplementedDomainsServiceServer) DeleteUserFromDomains(context.Context,
 func (UnimplementedDomainsServiceServer) RetrieveEntity(context.Context, *v1.RetrieveEntityReq) (*v1.RetrieveEntityRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetrieveEntity not implemented")
 }
+func (UnimplementedDomainsServiceServer) RequiresMFA(context.Context, *RequiresMFAReq) (*RequiresMFARes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequiresMFA not implemented")
+}
+func (UnimplementedDomainsServiceServer) RetrieveSettings(context.Context, *RetrieveSettingsReq) (*RetrieveSettingsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveSettings not implemented")
+}
+func (UnimplementedDomainsServiceServer) RetrieveIDByAlias(context.Context, *RetrieveIDByAliasReq) (*RetrieveIDByAliasRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveIDByAlias not implemented")
+}
 func (UnimplementedDomainsServiceServer) mustEmbedUnimplementedDomainsServiceServer() {}
 func (UnimplementedDomainsServiceServer) testEmbeddedByValue()                        {}
 
@@ -148,6 +196,60 @@ func _DomainsService_RetrieveEntity_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DomainsService_RequiresMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequiresMFAReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DomainsServiceServer).RequiresMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DomainsService_RequiresMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DomainsServiceServer).RequiresMFA(ctx, req.(*RequiresMFAReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DomainsService_RetrieveSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetrieveSettingsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DomainsServiceServer).RetrieveSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DomainsService_RetrieveSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DomainsServiceServer).RetrieveSettings(ctx, req.(*RetrieveSettingsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DomainsService_RetrieveIDByAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetrieveIDByAliasReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DomainsServiceServer).RetrieveIDByAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DomainsService_RetrieveIDByAlias_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DomainsServiceServer).RetrieveIDByAlias(ctx, req.(*RetrieveIDByAliasReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DomainsService_ServiceDesc is the grpc.ServiceDesc for DomainsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -163,6 +265,18 @@ var DomainsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RetrieveEntity",
 			Handler:    _DomainsService_RetrieveEntity_Handler,
 		},
+		{
+			MethodName: "RequiresMFA",
+			Handler:    _DomainsService_RequiresMFA_Handler,
+		},
+		{
+			MethodName: "RetrieveSettings",
+			Handler:    _DomainsService_RetrieveSettings_Handler,
+		},
+		{
+			MethodName: "RetrieveIDByAlias",
+			Handler:    _DomainsService_RetrieveIDByAlias_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "domains/v1/domains.proto",
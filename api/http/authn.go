@@ -25,11 +25,15 @@ func AuthenticateMiddleware(authn smqauthn.Authentication, domainCheck bool) fun
 				EncodeError(r.Context(), apiutil.ErrBearerToken, w)
 				return
 			}
-			resp, err := authn.Authenticate(r.Context(), token)
+			ip := apiutil.ClientIP(r)
+			resp, err := authn.Authenticate(smqauthn.WithClientIP(r.Context(), ip), token)
 			if err != nil {
 				EncodeError(r.Context(), err, w)
 				return
 			}
+			if resp.Type == smqauthn.PersonalAccessToken {
+				resp.IP = ip
+			}
 
 			if domainCheck {
 				domain := chi.URLParam(r, "domainID")
@@ -13,6 +13,7 @@ import (
 	"github.com/absmach/supermq/certs"
 	"github.com/absmach/supermq/clients"
 	"github.com/absmach/supermq/groups"
+	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/users"
@@ -46,6 +47,8 @@ const (
 	TreeKey       = "tree"
 	ParentKey     = "parent_id"
 	LevelKey      = "level"
+	DryRunKey     = "dry_run"
+	EffectiveKey  = "effective_metadata"
 
 	TokenKey   = "token"
 	SubjectKey = "subject"
@@ -124,13 +127,16 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, svcerr.ErrAuthorization),
 		errors.Contains(err, svcerr.ErrDomainAuthorization),
-		errors.Contains(err, svcerr.ErrUnauthorizedPAT):
+		errors.Contains(err, svcerr.ErrUnauthorizedPAT),
+		errors.Contains(err, svcerr.ErrMFAEnrolmentRequired):
 		err = unwrap(err)
 		w.WriteHeader(http.StatusForbidden)
 
 	case errors.Contains(err, svcerr.ErrAuthentication),
 		errors.Contains(err, apiutil.ErrBearerToken),
 		errors.Contains(err, svcerr.ErrLogin),
+		errors.Contains(err, svcerr.ErrMFARequired),
+		errors.Contains(err, svcerr.ErrInvalidMFACode),
 		errors.Contains(err, apiutil.ErrUnsupportedTokenType):
 		err = unwrap(err)
 		w.WriteHeader(http.StatusUnauthorized)
@@ -158,7 +164,11 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 		errors.Contains(err, apiutil.ErrValidation),
 		errors.Contains(err, apiutil.ErrMissingPass),
 		errors.Contains(err, apiutil.ErrMissingConfPass),
+		errors.Contains(err, apiutil.ErrMissingMFACode),
+		errors.Contains(err, apiutil.ErrMissingMFAChallenge),
+		errors.Contains(err, apiutil.ErrMissingVerificationToken),
 		errors.Contains(err, apiutil.ErrPasswordFormat),
+		errors.Contains(err, svcerr.ErrPasswordPolicy),
 		errors.Contains(err, svcerr.ErrInvalidRole),
 		errors.Contains(err, svcerr.ErrInvalidPolicy),
 		errors.Contains(err, apiutil.ErrInvitationState),
@@ -178,7 +188,11 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 		errors.Contains(err, apiutil.ErrEmptySearchQuery),
 		errors.Contains(err, apiutil.ErrLenSearchQuery),
 		errors.Contains(err, apiutil.ErrMissingDomainID),
+		errors.Contains(err, apiutil.ErrMissingClientID),
+		errors.Contains(err, apiutil.ErrMissingChannelID),
 		errors.Contains(err, certs.ErrFailedReadFromPKI),
+		errors.Contains(err, connections.ErrInvalidSubtopic),
+		errors.Contains(err, connections.ErrInvalidExpiration),
 		errors.Contains(err, apiutil.ErrMissingUserID),
 		errors.Contains(err, apiutil.ErrMissingPATID),
 		errors.Contains(err, apiutil.ErrMissingUsername),
@@ -214,10 +228,20 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 	case errors.Contains(err, errors.ErrStatusAlreadyAssigned),
 		errors.Contains(err, svcerr.ErrInvitationAlreadyRejected),
 		errors.Contains(err, svcerr.ErrInvitationAlreadyAccepted),
+		errors.Contains(err, svcerr.ErrInvitationExpired),
 		errors.Contains(err, svcerr.ErrConflict):
 		err = unwrap(err)
 		w.WriteHeader(http.StatusConflict)
 
+	case errors.Contains(err, svcerr.ErrAccountLocked):
+		err = unwrap(err)
+		w.WriteHeader(http.StatusLocked)
+
+	case errors.Contains(err, svcerr.ErrQuotaExceeded),
+		errors.Contains(err, svcerr.ErrTooManyRequests):
+		err = unwrap(err)
+		w.WriteHeader(http.StatusTooManyRequests)
+
 	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
 		err = unwrap(err)
 		w.WriteHeader(http.StatusUnsupportedMediaType)
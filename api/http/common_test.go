@@ -234,7 +234,9 @@ func TestEncodeError(t *testing.T) {
 				apiutil.ErrMissingMemberKind,
 				apiutil.ErrLimitSize,
 				apiutil.ErrNameSize,
+				apiutil.ErrMissingVerificationToken,
 				svcerr.ErrViewEntity,
+				svcerr.ErrPasswordPolicy,
 			},
 			code: http.StatusBadRequest,
 		},
@@ -270,6 +272,13 @@ func TestEncodeError(t *testing.T) {
 			},
 			code: http.StatusNotFound,
 		},
+		{
+			desc: "Locked",
+			errs: []error{
+				svcerr.ErrAccountLocked,
+			},
+			code: http.StatusLocked,
+		},
 		{
 			desc: "Conflict",
 			errs: []error{
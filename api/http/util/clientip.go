@@ -0,0 +1,124 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// ErrInvalidProxy indicates malformed trusted proxy address.
+var ErrInvalidProxy = errors.New("invalid trusted proxy address")
+
+type clientIPKey struct{}
+
+// TrustedProxies is a set of addresses of the reverse proxies whose
+// X-Forwarded-For and X-Real-IP headers are trusted.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies parses the list of trusted proxy IP addresses and
+// CIDR ranges.
+func ParseTrustedProxies(proxies []string) (TrustedProxies, error) {
+	var tp TrustedProxies
+	for _, p := range proxies {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !strings.Contains(p, "/") {
+			ip := net.ParseIP(p)
+			if ip == nil {
+				return nil, errors.Wrap(ErrInvalidProxy, errors.New(p))
+			}
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			tp = append(tp, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidProxy, err)
+		}
+		tp = append(tp, ipNet)
+	}
+
+	return tp, nil
+}
+
+func (tp TrustedProxies) trusted(addr string) bool {
+	ip := net.ParseIP(strings.TrimSpace(addr))
+	if ip == nil {
+		return false
+	}
+	for _, n := range tp {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClientIPMiddleware resolves the client IP address for ClientIP. The
+// X-Forwarded-For and X-Real-IP headers are used only if the request comes
+// from a trusted proxy, in which case the client IP is the rightmost
+// X-Forwarded-For address that is not a trusted proxy.
+func ClientIPMiddleware(tp TrustedProxies) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), clientIPKey{}, tp.clientIP(r))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func (tp TrustedProxies) clientIP(r *http.Request) string {
+	ip := remoteIP(r)
+	if !tp.trusted(ip) {
+		return ip
+	}
+	if fwd := r.Header.Values("X-Forwarded-For"); len(fwd) > 0 {
+		hops := strings.Split(strings.Join(fwd, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if hop != "" && !tp.trusted(hop) {
+				return hop
+			}
+		}
+		if hop := strings.TrimSpace(hops[0]); hop != "" {
+			return hop
+		}
+	}
+	if rip := r.Header.Get("X-Real-IP"); rip != "" {
+		return rip
+	}
+
+	return ip
+}
+
+// ClientIP returns the IP address of the client resolved by the
+// ClientIPMiddleware, or the remote address of the request if the
+// middleware is not used.
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+
+	return remoteIP(r)
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
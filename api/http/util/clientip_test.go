@@ -0,0 +1,104 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	cases := []struct {
+		desc    string
+		proxies []string
+		err     error
+	}{
+		{
+			desc:    "parse IP addresses and CIDR ranges",
+			proxies: []string{"10.0.0.1", "172.16.0.0/12", "::1", ""},
+		},
+		{
+			desc:    "parse invalid IP address",
+			proxies: []string{"10.0.0"},
+			err:     apiutil.ErrInvalidProxy,
+		},
+		{
+			desc:    "parse invalid CIDR range",
+			proxies: []string{"10.0.0.0/33"},
+			err:     apiutil.ErrInvalidProxy,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := apiutil.ParseTrustedProxies(tc.proxies)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	proxies, err := apiutil.ParseTrustedProxies([]string{"10.0.0.0/8"})
+	require.Nil(t, err)
+
+	cases := []struct {
+		desc       string
+		remoteAddr string
+		headers    map[string]string
+		ip         string
+	}{
+		{
+			desc:       "request without proxy headers",
+			remoteAddr: "203.0.113.7:5000",
+			ip:         "203.0.113.7",
+		},
+		{
+			desc:       "forwarded request from untrusted address",
+			remoteAddr: "203.0.113.7:5000",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1", "X-Real-IP": "198.51.100.1"},
+			ip:         "203.0.113.7",
+		},
+		{
+			desc:       "forwarded request from trusted proxy",
+			remoteAddr: "10.0.0.2:5000",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1"},
+			ip:         "198.51.100.1",
+		},
+		{
+			desc:       "forwarded request with spoofed address through trusted proxies",
+			remoteAddr: "10.0.0.2:5000",
+			headers:    map[string]string{"X-Forwarded-For": "192.0.2.9, 198.51.100.1, 10.0.0.3"},
+			ip:         "198.51.100.1",
+		},
+		{
+			desc:       "real IP request from trusted proxy",
+			remoteAddr: "10.0.0.2:5000",
+			headers:    map[string]string{"X-Real-IP": "198.51.100.1"},
+			ip:         "198.51.100.1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var ip string
+			h := apiutil.ClientIPMiddleware(proxies)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				ip = apiutil.ClientIP(r)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tc.ip, ip, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.ip, ip))
+		})
+	}
+}
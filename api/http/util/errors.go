@@ -135,6 +135,15 @@ var (
 	// ErrMissingConfPass indicates missing conf password.
 	ErrMissingConfPass = errors.New("missing conf password")
 
+	// ErrMissingMFACode indicates missing multi-factor authentication code.
+	ErrMissingMFACode = errors.New("missing multi-factor authentication code")
+
+	// ErrMissingMFAChallenge indicates missing multi-factor authentication challenge.
+	ErrMissingMFAChallenge = errors.New("missing multi-factor authentication challenge")
+
+	// ErrMissingVerificationToken indicates missing email verification token.
+	ErrMissingVerificationToken = errors.New("missing email verification token")
+
 	// ErrInvalidResetPass indicates an invalid reset password.
 	ErrInvalidResetPass = errors.New("invalid reset password")
 
@@ -80,7 +80,7 @@ func (client authGrpcClient) Authenticate(ctx context.Context, token *grpcAuthV1
 
 func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(authenticateReq)
-	return &grpcAuthV1.AuthNReq{Token: req.token}, nil
+	return &grpcAuthV1.AuthNReq{Token: req.token, Ip: req.ip}, nil
 }
 
 func decodeIdentifyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
@@ -92,7 +92,7 @@ func (client authGrpcClient) AuthenticatePAT(ctx context.Context, token *grpcAut
 	ctx, cancel := context.WithTimeout(ctx, client.timeout)
 	defer cancel()
 
-	res, err := client.authenticatePAT(ctx, authenticateReq{token: token.GetToken()})
+	res, err := client.authenticatePAT(ctx, authenticateReq{token: token.GetToken(), ip: token.GetIp()})
 	if err != nil {
 		return &grpcAuthV1.AuthNRes{}, grpcapi.DecodeError(err)
 	}
@@ -153,6 +153,7 @@ func (client authGrpcClient) AuthorizePAT(ctx context.Context, req *grpcAuthV1.A
 	res, err := client.authorizePAT(ctx, authPATReq{
 		userID:                   req.GetUserId(),
 		patID:                    req.GetPatId(),
+		ip:                       req.GetIp(),
 		platformEntityType:       auth.PlatformEntityType(req.GetPlatformEntityType()),
 		optionalDomainID:         req.GetOptionalDomainId(),
 		optionalDomainEntityType: auth.DomainEntityType(req.GetOptionalDomainEntityType()),
@@ -172,6 +173,7 @@ func encodeAuthorizePATRequest(_ context.Context, grpcReq interface{}) (interfac
 	return &grpcAuthV1.AuthZPatReq{
 		UserId:                   req.userID,
 		PatId:                    req.patID,
+		Ip:                       req.ip,
 		PlatformEntityType:       uint32(req.platformEntityType),
 		OptionalDomainId:         req.optionalDomainID,
 		OptionalDomainEntityType: uint32(req.optionalDomainEntityType),
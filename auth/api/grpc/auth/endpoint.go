@@ -34,7 +34,7 @@ func authenticatePATEndpoint(svc auth.Service) endpoint.Endpoint {
 			return authenticateRes{}, err
 		}
 
-		pat, err := svc.IdentifyPAT(ctx, req.token)
+		pat, err := svc.IdentifyPAT(ctx, req.token, req.ip)
 		if err != nil {
 			return authenticateRes{}, err
 		}
@@ -74,7 +74,7 @@ func authorizePATEndpoint(svc auth.Service) endpoint.Endpoint {
 		if err := req.validate(); err != nil {
 			return authorizeRes{}, err
 		}
-		err := svc.AuthorizePAT(ctx, req.userID, req.patID, req.platformEntityType, req.optionalDomainID, req.optionalDomainEntityType, req.operation, req.entityIDs...)
+		err := svc.AuthorizePAT(ctx, req.userID, req.patID, req.ip, req.platformEntityType, req.optionalDomainID, req.optionalDomainEntityType, req.operation, req.entityIDs...)
 		if err != nil {
 			return authorizeRes{authorized: false}, err
 		}
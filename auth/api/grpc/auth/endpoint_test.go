@@ -273,8 +273,8 @@ func TestIdentifyPAT(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			svcCall := svc.On("IdentifyPAT", mock.Anything, tc.token).Return(auth.PAT{ID: id, User: clientID, IssuedAt: time.Now()}, tc.svcErr)
-			idt, err := grpcClient.AuthenticatePAT(context.Background(), &grpcAuthV1.AuthNReq{Token: tc.token})
+			svcCall := svc.On("IdentifyPAT", mock.Anything, tc.token, "10.0.0.1").Return(auth.PAT{ID: id, User: clientID, IssuedAt: time.Now()}, tc.svcErr)
+			idt, err := grpcClient.AuthenticatePAT(context.Background(), &grpcAuthV1.AuthNReq{Token: tc.token, Ip: "10.0.0.1"})
 			if idt != nil {
 				assert.Equal(t, tc.idt, idt, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.idt, idt))
 			}
@@ -362,6 +362,7 @@ func TestAuthorizePAT(t *testing.T) {
 				mock.Anything,
 				tc.authRequest.UserId,
 				tc.authRequest.PatId,
+				tc.authRequest.Ip,
 				mock.Anything,
 				tc.authRequest.OptionalDomainId,
 				mock.Anything,
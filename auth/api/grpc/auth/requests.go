@@ -10,6 +10,7 @@ import (
 
 type authenticateReq struct {
 	token string
+	ip    string
 }
 
 func (req authenticateReq) validate() error {
@@ -54,6 +55,7 @@ func (req authReq) validate() error {
 type authPATReq struct {
 	userID                   string
 	patID                    string
+	ip                       string
 	platformEntityType       auth.PlatformEntityType
 	optionalDomainID         string
 	optionalDomainEntityType auth.DomainEntityType
@@ -77,7 +77,7 @@ func (s *authGrpcServer) Authorize(ctx context.Context, req *grpcAuthV1.AuthZReq
 
 func decodeAuthenticateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*grpcAuthV1.AuthNReq)
-	return authenticateReq{token: req.GetToken()}, nil
+	return authenticateReq{token: req.GetToken(), ip: req.GetIp()}, nil
 }
 
 func encodeAuthenticateResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
@@ -114,6 +114,7 @@ func decodeAuthorizePATRequest(_ context.Context, grpcReq interface{}) (interfac
 	return authPATReq{
 		userID:                   req.GetUserId(),
 		patID:                    req.GetPatId(),
+		ip:                       req.GetIp(),
 		platformEntityType:       auth.PlatformEntityType(req.GetPlatformEntityType()),
 		optionalDomainID:         req.GetOptionalDomainId(),
 		optionalDomainEntityType: auth.DomainEntityType(req.GetOptionalDomainEntityType()),
@@ -18,9 +18,10 @@ import (
 const tokenSvcName = "token.v1.TokenService"
 
 type tokenGrpcClient struct {
-	issue   endpoint.Endpoint
-	refresh endpoint.Endpoint
-	timeout time.Duration
+	issue          endpoint.Endpoint
+	refresh        endpoint.Endpoint
+	revokeSessions endpoint.Endpoint
+	timeout        time.Duration
 }
 
 var _ grpcTokenV1.TokenServiceClient = (*tokenGrpcClient)(nil)
@@ -44,6 +45,14 @@ func NewTokenClient(conn *grpc.ClientConn, timeout time.Duration) grpcTokenV1.To
 			decodeRefreshResponse,
 			grpcTokenV1.Token{},
 		).Endpoint(),
+		revokeSessions: kitgrpc.NewClient(
+			conn,
+			tokenSvcName,
+			"RevokeSessions",
+			encodeRevokeSessionsRequest,
+			decodeRevokeSessionsResponse,
+			grpcTokenV1.RevokeSessionsRes{},
+		).Endpoint(),
 		timeout: timeout,
 	}
 }
@@ -55,6 +64,8 @@ func (client tokenGrpcClient) Issue(ctx context.Context, req *grpcTokenV1.IssueR
 	res, err := client.issue(ctx, issueReq{
 		userID:  req.GetUserId(),
 		keyType: auth.KeyType(req.GetType()),
+		device:  req.GetDevice(),
+		ip:      req.GetIp(),
 	})
 	if err != nil {
 		return &grpcTokenV1.Token{}, grpcapi.DecodeError(err)
@@ -67,6 +78,8 @@ func encodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, er
 	return &grpcTokenV1.IssueReq{
 		UserId: req.userID,
 		Type:   uint32(req.keyType),
+		Device: req.device,
+		Ip:     req.ip,
 	}, nil
 }
 
@@ -93,3 +106,23 @@ func encodeRefreshRequest(_ context.Context, grpcReq interface{}) (interface{},
 func decodeRefreshResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	return grpcRes, nil
 }
+
+func (client tokenGrpcClient) RevokeSessions(ctx context.Context, req *grpcTokenV1.RevokeSessionsReq, _ ...grpc.CallOption) (*grpcTokenV1.RevokeSessionsRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.revokeSessions(ctx, revokeSessionsReq{userID: req.GetUserId()})
+	if err != nil {
+		return &grpcTokenV1.RevokeSessionsRes{}, grpcapi.DecodeError(err)
+	}
+	return res.(*grpcTokenV1.RevokeSessionsRes), nil
+}
+
+func encodeRevokeSessionsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(revokeSessionsReq)
+	return &grpcTokenV1.RevokeSessionsReq{UserId: req.userID}, nil
+}
+
+func decodeRevokeSessionsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return grpcRes, nil
+}
@@ -18,8 +18,10 @@ func issueEndpoint(svc auth.Service) endpoint.Endpoint {
 		}
 
 		key := auth.Key{
-			Type: req.keyType,
-			User: req.userID,
+			Type:   req.keyType,
+			User:   req.userID,
+			Device: req.device,
+			IP:     req.ip,
 		}
 		tkn, err := svc.Issue(ctx, "", key)
 		if err != nil {
@@ -54,3 +56,18 @@ func refreshEndpoint(svc auth.Service) endpoint.Endpoint {
 		return ret, nil
 	}
 }
+
+func revokeSessionsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(revokeSessionsReq)
+		if err := req.validate(); err != nil {
+			return revokeSessionsRes{}, err
+		}
+
+		if err := svc.RevokeUserSessions(ctx, req.userID); err != nil {
+			return revokeSessionsRes{}, err
+		}
+
+		return revokeSessionsRes{revoked: true}, nil
+	}
+}
@@ -167,3 +167,43 @@ func TestRefresh(t *testing.T) {
 		svcCall.Unset()
 	}
 }
+
+func TestRevokeSessions(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer conn.Close()
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	grpcClient := grpcapi.NewTokenClient(conn, time.Second)
+
+	cases := []struct {
+		desc    string
+		userID  string
+		revoked bool
+		svcErr  error
+		err     error
+	}{
+		{
+			desc:    "revoke sessions of user",
+			userID:  validID,
+			revoked: true,
+		},
+		{
+			desc:   "revoke sessions with empty user ID",
+			userID: "",
+			err:    apiutil.ErrMissingUserID,
+		},
+		{
+			desc:   "revoke sessions with failed to revoke",
+			userID: validID,
+			svcErr: svcerr.ErrUpdateEntity,
+			err:    svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("RevokeUserSessions", mock.Anything, tc.userID).Return(tc.svcErr)
+		res, err := grpcClient.RevokeSessions(context.Background(), &grpcTokenV1.RevokeSessionsReq{UserId: tc.userID})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.revoked, res.GetRevoked(), fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.revoked, res.GetRevoked()))
+		svcCall.Unset()
+	}
+}
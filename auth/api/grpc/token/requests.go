@@ -11,6 +11,8 @@ import (
 type issueReq struct {
 	userID  string
 	keyType auth.KeyType
+	device  string
+	ip      string
 }
 
 func (req issueReq) validate() error {
@@ -35,3 +37,15 @@ func (req refreshReq) validate() error {
 
 	return nil
 }
+
+type revokeSessionsReq struct {
+	userID string
+}
+
+func (req revokeSessionsReq) validate() error {
+	if req.userID == "" {
+		return apiutil.ErrMissingUserID
+	}
+
+	return nil
+}
@@ -8,3 +8,7 @@ type issueRes struct {
 	refreshToken string
 	accessType   string
 }
+
+type revokeSessionsRes struct {
+	revoked bool
+}
@@ -16,8 +16,9 @@ var _ grpcTokenV1.TokenServiceServer = (*tokenGrpcServer)(nil)
 
 type tokenGrpcServer struct {
 	grpcTokenV1.UnimplementedTokenServiceServer
-	issue   kitgrpc.Handler
-	refresh kitgrpc.Handler
+	issue          kitgrpc.Handler
+	refresh        kitgrpc.Handler
+	revokeSessions kitgrpc.Handler
 }
 
 // NewAuthServer returns new AuthnServiceServer instance.
@@ -33,6 +34,11 @@ func NewTokenServer(svc auth.Service) grpcTokenV1.TokenServiceServer {
 			decodeRefreshRequest,
 			encodeIssueResponse,
 		),
+		revokeSessions: kitgrpc.NewServer(
+			(revokeSessionsEndpoint(svc)),
+			decodeRevokeSessionsRequest,
+			encodeRevokeSessionsResponse,
+		),
 	}
 }
 
@@ -52,11 +58,21 @@ func (s *tokenGrpcServer) Refresh(ctx context.Context, req *grpcTokenV1.RefreshR
 	return res.(*grpcTokenV1.Token), nil
 }
 
+func (s *tokenGrpcServer) RevokeSessions(ctx context.Context, req *grpcTokenV1.RevokeSessionsReq) (*grpcTokenV1.RevokeSessionsRes, error) {
+	_, res, err := s.revokeSessions.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcapi.EncodeError(err)
+	}
+	return res.(*grpcTokenV1.RevokeSessionsRes), nil
+}
+
 func decodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*grpcTokenV1.IssueReq)
 	return issueReq{
 		userID:  req.GetUserId(),
 		keyType: auth.KeyType(req.GetType()),
+		device:  req.GetDevice(),
+		ip:      req.GetIp(),
 	}, nil
 }
 
@@ -74,3 +90,13 @@ func encodeIssueResponse(_ context.Context, grpcRes interface{}) (interface{}, e
 		AccessType:   res.accessType,
 	}, nil
 }
+
+func decodeRevokeSessionsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*grpcTokenV1.RevokeSessionsReq)
+	return revokeSessionsReq{userID: req.GetUserId()}, nil
+}
+
+func encodeRevokeSessionsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(revokeSessionsRes)
+	return &grpcTokenV1.RevokeSessionsRes{Revoked: res.revoked}, nil
+}
@@ -22,6 +22,7 @@ func EncodeError(err error) error {
 		errors.Contains(err, svcerr.ErrInvalidPolicy),
 		err == apiutil.ErrInvalidAuthKey,
 		err == apiutil.ErrMissingID,
+		err == apiutil.ErrMissingAlias,
 		err == apiutil.ErrMissingMemberType,
 		err == apiutil.ErrMissingPolicySub,
 		err == apiutil.ErrMissingPolicyObj,
@@ -70,13 +70,16 @@ func (tr testRequest) make() (*http.Response, error) {
 func newService() (auth.Service, *mocks.KeyRepository) {
 	krepo := new(mocks.KeyRepository)
 	pRepo := new(mocks.PATSRepository)
+	sRepo := new(mocks.SessionRepository)
+	sRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	sRepo.On("Retrieve", mock.Anything, mock.Anything).Return(auth.Session{}, nil)
 	hash := new(mocks.Hasher)
 	idProvider := uuid.NewMock()
 	pService := new(policymocks.Service)
 	pEvaluator := new(policymocks.Evaluator)
 	t := jwt.New([]byte(secret))
 
-	return auth.New(krepo, pRepo, hash, idProvider, t, pEvaluator, pService, loginDuration, refreshDuration, invalidDuration), krepo
+	return auth.New(krepo, pRepo, sRepo, new(mocks.PATUsageRepository), new(mocks.PATNotifier), hash, idProvider, t, pEvaluator, pService, loginDuration, refreshDuration, invalidDuration), krepo
 }
 
 func newServer(svc auth.Service) *httptest.Server {
@@ -336,3 +339,52 @@ func TestRevoke(t *testing.T) {
 		repocall.Unset()
 	}
 }
+
+func TestJWKS(t *testing.T) {
+	krepo := new(mocks.KeyRepository)
+	km, err := jwt.NewKeyManager("ES256", "", time.Hour)
+	assert.Nil(t, err, fmt.Sprintf("Creating key manager expected to succeed: %s", err))
+	svc := auth.New(krepo, new(mocks.PATSRepository), new(mocks.SessionRepository), new(mocks.PATUsageRepository), new(mocks.PATNotifier), new(mocks.Hasher), uuid.NewMock(), km, new(policymocks.Evaluator), new(policymocks.Service), loginDuration, refreshDuration, invalidDuration)
+	symSvc, _ := newService()
+
+	cases := []struct {
+		desc   string
+		svc    auth.Service
+		keys   int
+		status int
+	}{
+		{
+			desc:   "retrieve JWKS of asymmetric tokenizer",
+			svc:    svc,
+			keys:   1,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "retrieve JWKS of symmetric tokenizer",
+			svc:    symSvc,
+			keys:   0,
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newServer(tc.svc)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/.well-known/jwks.json", ts.URL),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var body struct {
+			Keys []auth.PublicKeyInfo `json:"keys"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Len(t, body.Keys, tc.keys, fmt.Sprintf("%s: expected %d keys got %d", tc.desc, tc.keys, len(body.Keys)))
+		res.Body.Close()
+		ts.Close()
+	}
+}
@@ -14,6 +14,7 @@ import (
 var (
 	_ supermq.Response = (*issueKeyRes)(nil)
 	_ supermq.Response = (*revokeKeyRes)(nil)
+	_ supermq.Response = (*jwksRes)(nil)
 )
 
 type issueKeyRes struct {
@@ -69,3 +70,21 @@ func (res revokeKeyRes) Headers() map[string]string {
 func (res revokeKeyRes) Empty() bool {
 	return true
 }
+
+type jwksRes struct {
+	Keys []auth.PublicKeyInfo `json:"keys"`
+}
+
+func (res jwksRes) Code() int {
+	return http.StatusOK
+}
+
+func (res jwksRes) Headers() map[string]string {
+	return map[string]string{
+		"Cache-Control": "public, max-age=300",
+	}
+}
+
+func (res jwksRes) Empty() bool {
+	return false
+}
@@ -94,6 +94,46 @@ func listPATSEndpoint(svc auth.Service) endpoint.Endpoint {
 	}
 }
 
+func listPATUsageEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listPatUsageReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := auth.PATUsagePageMeta{
+			Limit:  req.limit,
+			Offset: req.offset,
+		}
+		page, err := svc.ListPATUsage(ctx, req.token, req.id, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := listPatUsageRes{
+			Total:  page.Total,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+			Usage:  []patUsageRes{},
+		}
+		for _, u := range page.Usage {
+			res.Usage = append(res.Usage, patUsageRes{
+				ID:                 u.ID,
+				PlatformEntityType: u.PlatformEntityType.String(),
+				DomainID:           u.DomainID,
+				DomainEntityType:   u.DomainEntityType.String(),
+				Operation:          u.Operation.String(),
+				EntityIDs:          u.EntityIDs,
+				IP:                 u.IP,
+				Allowed:            u.Allowed,
+				CreatedAt:          u.CreatedAt,
+			})
+		}
+
+		return res, nil
+	}
+}
+
 func deletePATEndpoint(svc auth.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(deletePatReq)
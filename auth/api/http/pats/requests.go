@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/auth"
 )
@@ -119,6 +120,26 @@ func (req listPatsReq) validate() (err error) {
 	return nil
 }
 
+type listPatUsageReq struct {
+	token  string
+	id     string
+	offset uint64
+	limit  uint64
+}
+
+func (req listPatUsageReq) validate() (err error) {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.limit > api.MaxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+	return nil
+}
+
 type deletePatReq struct {
 	token string
 	id    string
@@ -5,6 +5,7 @@ package pats
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/absmach/supermq"
 	"github.com/absmach/supermq/auth"
@@ -15,6 +16,7 @@ var (
 	_ supermq.Response = (*retrievePatRes)(nil)
 	_ supermq.Response = (*updatePatNameRes)(nil)
 	_ supermq.Response = (*updatePatDescriptionRes)(nil)
+	_ supermq.Response = (*listPatUsageRes)(nil)
 	_ supermq.Response = (*deletePatRes)(nil)
 	_ supermq.Response = (*resetPatSecretRes)(nil)
 	_ supermq.Response = (*revokePatSecretRes)(nil)
@@ -103,6 +105,37 @@ func (res listPatsRes) Empty() bool {
 	return false
 }
 
+type patUsageRes struct {
+	ID                 string    `json:"id"`
+	PlatformEntityType string    `json:"platform_entity_type"`
+	DomainID           string    `json:"domain_id,omitempty"`
+	DomainEntityType   string    `json:"domain_entity_type,omitempty"`
+	Operation          string    `json:"operation"`
+	EntityIDs          []string  `json:"entity_ids,omitempty"`
+	IP                 string    `json:"ip,omitempty"`
+	Allowed            bool      `json:"allowed"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+type listPatUsageRes struct {
+	Total  uint64        `json:"total"`
+	Offset uint64        `json:"offset"`
+	Limit  uint64        `json:"limit"`
+	Usage  []patUsageRes `json:"usage"`
+}
+
+func (res listPatUsageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listPatUsageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listPatUsageRes) Empty() bool {
+	return false
+}
+
 type deletePatRes struct{}
 
 func (res deletePatRes) Code() int {
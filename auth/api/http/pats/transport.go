@@ -52,6 +52,13 @@ func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger) *chi.Mux {
 				opts...,
 			).ServeHTTP)
 
+			r.Get("/usage", kithttp.NewServer(
+				listPATUsageEndpoint(svc),
+				decodeListPATUsageRequest,
+				api.EncodeResponse,
+				opts...,
+			).ServeHTTP)
+
 			r.Patch("/name", kithttp.NewServer(
 				updatePATNameEndpoint(svc),
 				decodeUpdatePATNameRequest,
@@ -202,6 +209,28 @@ func decodeListPATSRequest(_ context.Context, r *http.Request) (interface{}, err
 	return req, nil
 }
 
+func decodeListPATUsageRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	l, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	o, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	token := apiutil.ExtractBearerToken(r)
+	if strings.HasPrefix(token, patPrefix) {
+		return nil, apiutil.ErrUnsupportedTokenType
+	}
+	req := listPatUsageReq{
+		token:  token,
+		id:     chi.URLParam(r, "id"),
+		limit:  l,
+		offset: o,
+	}
+	return req, nil
+}
+
 func decodeDeletePATRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	token := apiutil.ExtractBearerToken(r)
 	if strings.HasPrefix(token, patPrefix) {
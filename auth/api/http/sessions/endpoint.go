@@ -0,0 +1,61 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func listSessionsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listSessionsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := auth.SessionsPageMeta{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		page, err := svc.ListSessions(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		return listSessionsRes{page}, nil
+	}
+}
+
+func revokeSessionEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(revokeSessionReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeSession(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return revokeSessionRes{}, nil
+	}
+}
+
+func revokeSessionsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(revokeSessionsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeSessions(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return revokeSessionRes{}, nil
+	}
+}
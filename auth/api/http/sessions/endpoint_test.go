@@ -0,0 +1,186 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/auth"
+	httpapi "github.com/absmach/supermq/auth/api/http"
+	"github.com/absmach/supermq/auth/mocks"
+	smqlog "github.com/absmach/supermq/logger"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	validToken = "valid"
+	sessionID  = "123e4567-e89b-12d3-a456-000000000001"
+)
+
+type testRequest struct {
+	client *http.Client
+	method string
+	url    string
+	token  string
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	return tr.client.Do(req)
+}
+
+func newServer() (*httptest.Server, *mocks.Service) {
+	svc := new(mocks.Service)
+	mux := httpapi.MakeHandler(svc, smqlog.NewMock(), "")
+	return httptest.NewServer(mux), svc
+}
+
+func TestListSessions(t *testing.T) {
+	ts, svc := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		query  string
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "list sessions successfully",
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list sessions with empty token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list sessions with invalid token",
+			token:  "invalid",
+			svcErr: svcerr.ErrAuthentication,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list sessions with invalid limit",
+			token:  validToken,
+			query:  "?limit=1000",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ListSessions", mock.Anything, tc.token, mock.Anything).Return(auth.SessionsPage{}, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/sessions%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	ts, svc := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "revoke session successfully",
+			token:  validToken,
+			id:     sessionID,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "revoke session with empty token",
+			id:     sessionID,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "revoke non-existing session",
+			token:  validToken,
+			id:     sessionID,
+			svcErr: svcerr.ErrNotFound,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("RevokeSession", mock.Anything, tc.token, tc.id).Return(tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/sessions/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	ts, svc := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "revoke all sessions successfully",
+			token:  validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "revoke all sessions with empty token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "revoke all sessions with invalid token",
+			token:  "invalid",
+			svcErr: svcerr.ErrAuthentication,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("RevokeSessions", mock.Anything, tc.token).Return(tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/sessions", ts.URL),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"net/http"
+
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/auth"
+)
+
+var (
+	_ supermq.Response = (*listSessionsRes)(nil)
+	_ supermq.Response = (*revokeSessionRes)(nil)
+)
+
+type listSessionsRes struct {
+	auth.SessionsPage
+}
+
+func (res listSessionsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listSessionsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listSessionsRes) Empty() bool {
+	return false
+}
+
+type revokeSessionRes struct{}
+
+func (res revokeSessionRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res revokeSessionRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res revokeSessionRes) Empty() bool {
+	return true
+}
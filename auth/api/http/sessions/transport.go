@@ -0,0 +1,79 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger) *chi.Mux {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+	mux.Route("/sessions", func(r chi.Router) {
+		r.Get("/", kithttp.NewServer(
+			listSessionsEndpoint(svc),
+			decodeListSessionsRequest,
+			api.EncodeResponse,
+			opts...,
+		).ServeHTTP)
+
+		r.Delete("/", kithttp.NewServer(
+			revokeSessionsEndpoint(svc),
+			decodeRevokeSessionsRequest,
+			api.EncodeResponse,
+			opts...,
+		).ServeHTTP)
+
+		r.Delete("/{id}", kithttp.NewServer(
+			revokeSessionEndpoint(svc),
+			decodeRevokeSessionRequest,
+			api.EncodeResponse,
+			opts...,
+		).ServeHTTP)
+	})
+	return mux
+}
+
+func decodeListSessionsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	l, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	o, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	req := listSessionsReq{
+		token:  apiutil.ExtractBearerToken(r),
+		limit:  l,
+		offset: o,
+	}
+	return req, nil
+}
+
+func decodeRevokeSessionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := revokeSessionReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    chi.URLParam(r, "id"),
+	}
+	return req, nil
+}
+
+func decodeRevokeSessionsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := revokeSessionsReq{
+		token: apiutil.ExtractBearerToken(r),
+	}
+	return req, nil
+}
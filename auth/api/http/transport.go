@@ -10,6 +10,7 @@ import (
 	"github.com/absmach/supermq/auth"
 	"github.com/absmach/supermq/auth/api/http/keys"
 	"github.com/absmach/supermq/auth/api/http/pats"
+	"github.com/absmach/supermq/auth/api/http/sessions"
 	"github.com/go-chi/chi/v5"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
@@ -20,6 +21,7 @@ func MakeHandler(svc auth.Service, logger *slog.Logger, instanceID string) http.
 
 	mux = keys.MakeHandler(svc, mux, logger)
 	mux = pats.MakeHandler(svc, mux, logger)
+	mux = sessions.MakeHandler(svc, mux, logger)
 
 	mux.Get("/health", supermq.Health("auth", instanceID))
 	mux.Handle("/metrics", promhttp.Handler())
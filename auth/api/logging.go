@@ -100,6 +100,18 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, token string) (id aut
 	return lm.svc.Identify(ctx, token)
 }
 
+func (lm *loggingMiddleware) RetrieveJWKS(ctx context.Context) (keys []auth.PublicKeyInfo) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("keys", len(keys)),
+		}
+		lm.logger.Info("Retrieve JWKS completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RetrieveJWKS(ctx)
+}
+
 func (lm *loggingMiddleware) Authorize(ctx context.Context, pr policies.Policy) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -211,6 +223,41 @@ func (lm *loggingMiddleware) ListPATS(ctx context.Context, token string, pm auth
 	return lm.svc.ListPATS(ctx, token, pm)
 }
 
+func (lm *loggingMiddleware) ListPATUsage(ctx context.Context, token, patID string, pm auth.PATUsagePageMeta) (pp auth.PATUsagePage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("pat_id", patID),
+			slog.Uint64("limit", pm.Limit),
+			slog.Uint64("offset", pm.Offset),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List PAT usage failed", args...)
+			return
+		}
+		lm.logger.Info("List PAT usage completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListPATUsage(ctx, token, patID, pm)
+}
+
+func (lm *loggingMiddleware) ExpireUnusedPATs(ctx context.Context, unusedFor time.Duration) (expired uint64, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("unused_for", unusedFor.String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Expire unused PATs failed", args...)
+			return
+		}
+		args = append(args, slog.Uint64("expired", expired))
+		lm.logger.Info("Expire unused PATs completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ExpireUnusedPATs(ctx, unusedFor)
+}
+
 func (lm *loggingMiddleware) DeletePAT(ctx context.Context, token, patID string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -318,7 +365,7 @@ func (lm *loggingMiddleware) ClearPATAllScopeEntry(ctx context.Context, token, p
 	return lm.svc.ClearPATAllScopeEntry(ctx, token, patID)
 }
 
-func (lm *loggingMiddleware) IdentifyPAT(ctx context.Context, paToken string) (pa auth.PAT, err error) {
+func (lm *loggingMiddleware) IdentifyPAT(ctx context.Context, paToken, ip string) (pa auth.PAT, err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
@@ -330,10 +377,10 @@ func (lm *loggingMiddleware) IdentifyPAT(ctx context.Context, paToken string) (p
 		}
 		lm.logger.Info("Identify PAT completed successfully", args...)
 	}(time.Now())
-	return lm.svc.IdentifyPAT(ctx, paToken)
+	return lm.svc.IdentifyPAT(ctx, paToken, ip)
 }
 
-func (lm *loggingMiddleware) AuthorizePAT(ctx context.Context, userID, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) (err error) {
+func (lm *loggingMiddleware) AuthorizePAT(ctx context.Context, userID, patID, ip string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
@@ -342,6 +389,7 @@ func (lm *loggingMiddleware) AuthorizePAT(ctx context.Context, userID, patID str
 			slog.String("optional_domain_entity_type", optionalDomainEntityType.String()),
 			slog.String("operation", operation.String()),
 			slog.Any("entities", entityIDs),
+			slog.String("ip", ip),
 		}
 		if err != nil {
 			args = append(args, slog.Any("error", err))
@@ -350,7 +398,7 @@ func (lm *loggingMiddleware) AuthorizePAT(ctx context.Context, userID, patID str
 		}
 		lm.logger.Info("Authorize PAT completed successfully", args...)
 	}(time.Now())
-	return lm.svc.AuthorizePAT(ctx, userID, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
+	return lm.svc.AuthorizePAT(ctx, userID, patID, ip, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
 }
 
 func (lm *loggingMiddleware) CheckPAT(ctx context.Context, userID, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) (err error) {
@@ -374,3 +422,83 @@ func (lm *loggingMiddleware) CheckPAT(ctx context.Context, userID, patID string,
 	}(time.Now())
 	return lm.svc.CheckPAT(ctx, userID, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
 }
+
+func (lm *loggingMiddleware) ListSessions(ctx context.Context, token string, pm auth.SessionsPageMeta) (sp auth.SessionsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Uint64("limit", pm.Limit),
+			slog.Uint64("offset", pm.Offset),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List sessions failed", args...)
+			return
+		}
+		lm.logger.Info("List sessions completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListSessions(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) RevokeSession(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("session_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Revoke session failed", args...)
+			return
+		}
+		lm.logger.Info("Revoke session completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RevokeSession(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) RevokeSessions(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Revoke sessions failed", args...)
+			return
+		}
+		lm.logger.Info("Revoke sessions completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RevokeSessions(ctx, token)
+}
+
+func (lm *loggingMiddleware) RevokeUserSessions(ctx context.Context, userID string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", userID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Revoke user sessions failed", args...)
+			return
+		}
+		lm.logger.Info("Revoke user sessions completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RevokeUserSessions(ctx, userID)
+}
+
+func (lm *loggingMiddleware) PurgeExpiredSessions(ctx context.Context) (purged uint64, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Purge expired sessions failed", args...)
+			return
+		}
+		args = append(args, slog.Uint64("purged", purged))
+		lm.logger.Info("Purge expired sessions completed successfully", args...)
+	}(time.Now())
+	return lm.svc.PurgeExpiredSessions(ctx)
+}
@@ -67,6 +67,15 @@ func (ms *metricsMiddleware) Identify(ctx context.Context, token string) (auth.K
 	return ms.svc.Identify(ctx, token)
 }
 
+func (ms *metricsMiddleware) RetrieveJWKS(ctx context.Context) []auth.PublicKeyInfo {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_jwks").Add(1)
+		ms.latency.With("method", "retrieve_jwks").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RetrieveJWKS(ctx)
+}
+
 func (ms *metricsMiddleware) Authorize(ctx context.Context, pr policies.Policy) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "authorize").Add(1)
@@ -115,6 +124,22 @@ func (ms *metricsMiddleware) ListPATS(ctx context.Context, token string, pm auth
 	return ms.svc.ListPATS(ctx, token, pm)
 }
 
+func (ms *metricsMiddleware) ListPATUsage(ctx context.Context, token, patID string, pm auth.PATUsagePageMeta) (auth.PATUsagePage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_pat_usage").Add(1)
+		ms.latency.With("method", "list_pat_usage").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListPATUsage(ctx, token, patID, pm)
+}
+
+func (ms *metricsMiddleware) ExpireUnusedPATs(ctx context.Context, unusedFor time.Duration) (uint64, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "expire_unused_pats").Add(1)
+		ms.latency.With("method", "expire_unused_pats").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ExpireUnusedPATs(ctx, unusedFor)
+}
+
 func (ms *metricsMiddleware) DeletePAT(ctx context.Context, token, patID string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "delete_pat").Add(1)
@@ -163,20 +188,20 @@ func (ms *metricsMiddleware) ClearPATAllScopeEntry(ctx context.Context, token, p
 	return ms.svc.ClearPATAllScopeEntry(ctx, token, patID)
 }
 
-func (ms *metricsMiddleware) IdentifyPAT(ctx context.Context, paToken string) (auth.PAT, error) {
+func (ms *metricsMiddleware) IdentifyPAT(ctx context.Context, paToken, ip string) (auth.PAT, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "identify_pat").Add(1)
 		ms.latency.With("method", "identify_pat").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.IdentifyPAT(ctx, paToken)
+	return ms.svc.IdentifyPAT(ctx, paToken, ip)
 }
 
-func (ms *metricsMiddleware) AuthorizePAT(ctx context.Context, userID, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) error {
+func (ms *metricsMiddleware) AuthorizePAT(ctx context.Context, userID, patID, ip string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "authorize_pat").Add(1)
 		ms.latency.With("method", "authorize_pat").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.AuthorizePAT(ctx, userID, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
+	return ms.svc.AuthorizePAT(ctx, userID, patID, ip, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
 }
 
 func (ms *metricsMiddleware) CheckPAT(ctx context.Context, userID, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) error {
@@ -186,3 +211,43 @@ func (ms *metricsMiddleware) CheckPAT(ctx context.Context, userID, patID string,
 	}(time.Now())
 	return ms.svc.CheckPAT(ctx, userID, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
 }
+
+func (ms *metricsMiddleware) ListSessions(ctx context.Context, token string, pm auth.SessionsPageMeta) (auth.SessionsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_sessions").Add(1)
+		ms.latency.With("method", "list_sessions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListSessions(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) RevokeSession(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_session").Add(1)
+		ms.latency.With("method", "revoke_session").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RevokeSession(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) RevokeSessions(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_sessions").Add(1)
+		ms.latency.With("method", "revoke_sessions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RevokeSessions(ctx, token)
+}
+
+func (ms *metricsMiddleware) RevokeUserSessions(ctx context.Context, userID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_user_sessions").Add(1)
+		ms.latency.With("method", "revoke_user_sessions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RevokeUserSessions(ctx, userID)
+}
+
+func (ms *metricsMiddleware) PurgeExpiredSessions(ctx context.Context) (uint64, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "purge_expired_sessions").Add(1)
+		ms.latency.With("method", "purge_expired_sessions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.PurgeExpiredSessions(ctx)
+}
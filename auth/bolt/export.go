@@ -0,0 +1,64 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bolt
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ExportPATs calls fn for every PAT stored in the given bucket, including
+// the hashed secret. It is used to migrate PATs to another repository.
+func ExportPATs(ctx context.Context, db *bolt.DB, bucketName string, fn func(auth.PAT) error) error {
+	pr := &patRepo{
+		db:         db,
+		bucketName: bucketName,
+	}
+
+	type patIndex struct {
+		userID string
+		patID  string
+	}
+	var idxs []patIndex
+	if err := db.View(func(tx *bolt.Tx) error {
+		rb, err := pr.retrieveRootBucket(tx)
+		if err != nil {
+			return errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		idx := []byte(keySeparator + patKey + keySeparator)
+		c := rb.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			// Nested user buckets have nil values.
+			if v == nil || !bytes.Contains(k, idx) {
+				continue
+			}
+			idxs = append(idxs, patIndex{userID: string(k[:bytes.Index(k, idx)]), patID: string(v)})
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, i := range idxs {
+		pat, err := pr.Retrieve(ctx, i.userID, i.patID)
+		if err != nil {
+			return err
+		}
+		secret, _, _, err := pr.RetrieveSecretAndRevokeStatus(ctx, i.userID, i.patID)
+		if err != nil {
+			return err
+		}
+		pat.Secret = secret
+		if err := fn(pat); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
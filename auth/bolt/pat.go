@@ -286,6 +286,111 @@ func (pr *patRepo) Remove(ctx context.Context, userID, patID string) error {
 	return nil
 }
 
+func (pr *patRepo) UpdateLastUsedAt(ctx context.Context, userID, patID string, lastUsedAt time.Time) error {
+	return pr.db.Update(func(tx *bolt.Tx) error {
+		b, err := pr.retrieveUserBucket(tx, userID, patID, repoerr.ErrUpdateEntity)
+		if err != nil {
+			return err
+		}
+		if err := b.Put([]byte(patID+keySeparator+lastUsedAtKey), timeToBytes(lastUsedAt)); err != nil {
+			return errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		return nil
+	})
+}
+
+func (pr *patRepo) ExpireUnused(ctx context.Context, unusedSince time.Time) (uint64, error) {
+	var expired uint64
+	now := time.Now()
+	if err := pr.db.Update(func(tx *bolt.Tx) error {
+		rb, err := pr.retrieveRootBucket(tx)
+		if err != nil {
+			return errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		idx := []byte(keySeparator + patKey + keySeparator)
+		c := rb.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			// Nested user buckets have nil values.
+			if v == nil || !bytes.Contains(k, idx) {
+				continue
+			}
+			userID := string(k[:bytes.Index(k, idx)])
+			patID := string(v)
+			b := rb.Bucket([]byte(userID))
+			if b == nil {
+				continue
+			}
+			if bytesToBoolean(b.Get([]byte(patID + keySeparator + revokedKey))) {
+				continue
+			}
+			if !bytesToTime(b.Get([]byte(patID + keySeparator + expiresAtKey))).After(now) {
+				continue
+			}
+			lastUsedAt := bytesToTime(b.Get([]byte(patID + keySeparator + lastUsedAtKey)))
+			if lastUsedAt.IsZero() {
+				lastUsedAt = bytesToTime(b.Get([]byte(patID + keySeparator + issuedAtKey)))
+			}
+			if !lastUsedAt.Before(unusedSince) {
+				continue
+			}
+			if err := b.Put([]byte(patID+keySeparator+expiresAtKey), timeToBytes(now)); err != nil {
+				return errors.Wrap(repoerr.ErrUpdateEntity, err)
+			}
+			if err := b.Put([]byte(patID+keySeparator+updatedAtKey), timeToBytes(now)); err != nil {
+				return errors.Wrap(repoerr.ErrUpdateEntity, err)
+			}
+			expired++
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return expired, nil
+}
+
+func (pr *patRepo) RevokeDomainPATs(ctx context.Context, domainID string) (uint64, error) {
+	var revoked uint64
+	now := time.Now()
+	if err := pr.db.Update(func(tx *bolt.Tx) error {
+		rb, err := pr.retrieveRootBucket(tx)
+		if err != nil {
+			return errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		idx := []byte(keySeparator + patKey + keySeparator)
+		c := rb.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			// Nested user buckets have nil values.
+			if v == nil || !bytes.Contains(k, idx) {
+				continue
+			}
+			userID := string(k[:bytes.Index(k, idx)])
+			patID := string(v)
+			b := rb.Bucket([]byte(userID))
+			if b == nil {
+				continue
+			}
+			if bytesToBoolean(b.Get([]byte(patID + keySeparator + revokedKey))) {
+				continue
+			}
+			prefix := []byte(patID + keySeparator + scopeKey + keySeparator + auth.PlatformDomainsScope.String() + keySeparator + domainID + keySeparator)
+			if sk, _ := b.Cursor().Seek(prefix); sk == nil || !bytes.HasPrefix(sk, prefix) {
+				continue
+			}
+			if err := b.Put([]byte(patID+keySeparator+revokedKey), revokedValue); err != nil {
+				return errors.Wrap(repoerr.ErrUpdateEntity, err)
+			}
+			if err := b.Put([]byte(patID+keySeparator+revokedAtKey), timeToBytes(now)); err != nil {
+				return errors.Wrap(repoerr.ErrUpdateEntity, err)
+			}
+			revoked++
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return revoked, nil
+}
+
 func (pr *patRepo) AddScopeEntry(ctx context.Context, userID, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) (auth.Scope, error) {
 	prefix := []byte(patID + keySeparator + scopeKey)
 	rKV := make(map[string][]byte)
@@ -0,0 +1,94 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+const (
+	stream = "events.supermq.domains"
+
+	domainPurge = "domain.purge"
+)
+
+var (
+	errNoOperationKey     = errors.New("operation key is not found in event message")
+	errDomainPurgeEvent   = errors.New("failed to consume domain purge event")
+	errMissingEventDomain = errors.New("missing domain id in event message")
+)
+
+type eventHandler struct {
+	pats   auth.PATSRepository
+	logger *slog.Logger
+}
+
+// DomainsEventsSubscribe subscribes to the domains events and revokes
+// the personal access tokens scoped to the purged domains. The tokens are
+// kept while the deleted domain can still be restored.
+func DomainsEventsSubscribe(ctx context.Context, pats auth.PATSRepository, esURL, esConsumerName string, logger *slog.Logger) error {
+	subscriber, err := store.NewSubscriber(ctx, esURL, logger)
+	if err != nil {
+		return err
+	}
+
+	subConfig := events.SubscriberConfig{
+		Stream:         stream,
+		Consumer:       esConsumerName,
+		Handler:        NewEventHandler(pats, logger),
+		DeliveryPolicy: messaging.DeliverNewPolicy,
+		Ordered:        false,
+	}
+	return subscriber.Subscribe(ctx, subConfig)
+}
+
+// NewEventHandler returns new event store handler.
+func NewEventHandler(pats auth.PATSRepository, logger *slog.Logger) events.EventHandler {
+	return &eventHandler{
+		pats:   pats,
+		logger: logger,
+	}
+}
+
+func (es *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	op, ok := msg["operation"]
+	if !ok {
+		return errNoOperationKey
+	}
+	if op == domainPurge {
+		return es.domainPurgeHandler(ctx, msg)
+	}
+
+	return nil
+}
+
+func (es *eventHandler) domainPurgeHandler(ctx context.Context, data map[string]interface{}) error {
+	domainID, ok := data["id"].(string)
+	if !ok || domainID == "" {
+		return errors.Wrap(errDomainPurgeEvent, errMissingEventDomain)
+	}
+
+	revoked, err := es.pats.RevokeDomainPATs(ctx, domainID)
+	if err != nil {
+		return errors.Wrap(errDomainPurgeEvent, err)
+	}
+	if revoked > 0 {
+		es.logger.Info(fmt.Sprintf("revoked %d PATs scoped to purged domain %s", revoked, domainID))
+	}
+
+	return nil
+}
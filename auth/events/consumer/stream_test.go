@@ -0,0 +1,108 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/auth/events/consumer"
+	"github.com/absmach/supermq/auth/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqlog "github.com/absmach/supermq/logger"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEvent struct {
+	data map[string]interface{}
+	err  error
+}
+
+func (e testEvent) Encode() (map[string]interface{}, error) {
+	return e.data, e.err
+}
+
+func TestHandle(t *testing.T) {
+	pats := new(mocks.PATSRepository)
+	handler := consumer.NewEventHandler(pats, smqlog.NewMock())
+
+	domainID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc      string
+		event     map[string]interface{}
+		encodeErr error
+		revoked   uint64
+		revokeErr error
+		called    bool
+		err       error
+	}{
+		{
+			desc: "handle domain purge event successfully",
+			event: map[string]interface{}{
+				"operation": "domain.purge",
+				"id":        domainID,
+			},
+			revoked: 2,
+			called:  true,
+		},
+		{
+			desc: "handle domain purge event without PATs",
+			event: map[string]interface{}{
+				"operation": "domain.purge",
+				"id":        domainID,
+			},
+			called: true,
+		},
+		{
+			desc: "handle domain purge event without domain id",
+			event: map[string]interface{}{
+				"operation": "domain.purge",
+			},
+			err: errors.New("missing domain id in event message"),
+		},
+		{
+			desc: "handle domain purge event with failed to revoke PATs",
+			event: map[string]interface{}{
+				"operation": "domain.purge",
+				"id":        domainID,
+			},
+			revokeErr: repoerr.ErrUpdateEntity,
+			called:    true,
+			err:       repoerr.ErrUpdateEntity,
+		},
+		{
+			desc: "handle unknown event",
+			event: map[string]interface{}{
+				"operation": "domain.create",
+				"id":        domainID,
+			},
+		},
+		{
+			desc:  "handle event without operation",
+			event: map[string]interface{}{},
+			err:   errors.New("operation key is not found in event message"),
+		},
+		{
+			desc:      "handle event with encode error",
+			encodeErr: errors.New("failed to encode"),
+			err:       errors.New("failed to encode"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := pats.On("RevokeDomainPATs", context.Background(), domainID).Return(tc.revoked, tc.revokeErr)
+			err := handler.Handle(context.Background(), testEvent{data: tc.event, err: tc.encodeErr})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.called {
+				pats.AssertCalled(t, "RevokeDomainPATs", context.Background(), domainID)
+			}
+			repoCall.Unset()
+		})
+	}
+}
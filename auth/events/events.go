@@ -0,0 +1,42 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/events"
+)
+
+const (
+	patPrefix = "pat."
+	// PATRevokedUse is the operation of the event published when revoked PAT is presented.
+	PATRevokedUse = patPrefix + "revoked_use"
+)
+
+var _ events.Event = (*patRevokedUseEvent)(nil)
+
+type patRevokedUseEvent struct {
+	auth.PAT
+	ip         string
+	occurredAt time.Time
+}
+
+func (pre patRevokedUseEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation":   PATRevokedUse,
+		"user_id":     pre.User,
+		"pat_id":      pre.ID,
+		"pat_name":    pre.Name,
+		"revoked_at":  pre.RevokedAt,
+		"occurred_at": pre.occurredAt,
+	}
+
+	if pre.ip != "" {
+		val["ip"] = pre.ip
+	}
+
+	return val, nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+)
+
+const streamID = "supermq.auth"
+
+var _ auth.PATNotifier = (*notifier)(nil)
+
+type notifier struct {
+	events.Publisher
+}
+
+// NewPATNotifier returns PAT notifier that publishes the PAT usage
+// notifications to the event store, so the owner can be notified.
+func NewPATNotifier(ctx context.Context, url string) (auth.PATNotifier, error) {
+	publisher, err := store.NewPublisher(ctx, url, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &notifier{
+		Publisher: publisher,
+	}, nil
+}
+
+func (n *notifier) NotifyRevokedUse(ctx context.Context, pat auth.PAT, ip string) error {
+	event := patRevokedUseEvent{
+		PAT:        pat,
+		ip:         ip,
+		occurredAt: time.Now().UTC(),
+	}
+
+	return n.Publish(ctx, event)
+}
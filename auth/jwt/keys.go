@@ -0,0 +1,409 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+const (
+	rsaKeySize     = 2048
+	keyUse         = "sig"
+	pemExt         = ".pem"
+	tmpExt         = ".tmp"
+	pemType        = "PRIVATE KEY"
+	kidFormat      = "20060102T150405.000000000Z"
+	reloadInterval = time.Second
+)
+
+var (
+	// ErrUnsupportedAlgorithm indicates an unsupported JWT signing algorithm.
+	ErrUnsupportedAlgorithm = errors.New("unsupported jwt signing algorithm")
+	// ErrLoadKeys indicates a failure to load the JWT signing keys.
+	ErrLoadKeys = errors.New("failed to load jwt signing keys")
+	// ErrGenerateKey indicates a failure to generate the JWT signing key.
+	ErrGenerateKey = errors.New("failed to generate jwt signing key")
+	// ErrSaveKey indicates a failure to store the JWT signing key.
+	ErrSaveKey = errors.New("failed to save jwt signing key")
+
+	errKeyType = errors.New("key type does not match the signing algorithm")
+
+	keyTypes = map[jwa.SignatureAlgorithm]jwa.KeyType{
+		jwa.RS256: jwa.RSA,
+		jwa.ES256: jwa.EC,
+		jwa.EdDSA: jwa.OKP,
+	}
+)
+
+// KeyManager is a tokenizer that signs tokens using asymmetric keys
+// identified by the key ID (kid). The most recent key signs the tokens,
+// while the retired keys are kept for verification during the overlap
+// window, so that the tokens issued before the rotation remain valid.
+type KeyManager interface {
+	auth.Tokenizer
+
+	// Rotate generates and stores a new signing key and retires the current one.
+	Rotate() error
+
+	// Refresh reloads the keys from the keys directory, so that the keys
+	// rotated by the other instances are used, and removes the keys retired
+	// before the overlap window. If rotation is positive and the signing
+	// key is older than rotation, the keys are rotated.
+	Refresh(rotation time.Duration) error
+}
+
+type signingKey struct {
+	key       jwk.Key
+	createdAt time.Time
+	retiredAt time.Time
+}
+
+type keyManager struct {
+	mu       sync.RWMutex
+	alg      jwa.SignatureAlgorithm
+	dir      string
+	overlap  time.Duration
+	keys     []signingKey
+	loadedAt time.Time
+}
+
+var _ KeyManager = (*keyManager)(nil)
+
+// NewKeyManager instantiates an asymmetric tokenizer using the RS256, ES256
+// or EdDSA algorithm. The PEM encoded private keys are loaded from keysDir
+// using the file names as key IDs, and the last key in lexical order signs
+// the tokens. If keysDir contains no keys, a new key is generated and stored
+// to keysDir, so that the instances sharing keysDir use the same keys. If
+// keysDir is empty, the keys are kept in memory only.
+func NewKeyManager(alg, keysDir string, overlap time.Duration) (KeyManager, error) {
+	sa := jwa.SignatureAlgorithm(alg)
+	if _, ok := keyTypes[sa]; !ok {
+		return nil, errors.Wrap(ErrUnsupportedAlgorithm, errors.New(alg))
+	}
+	km := &keyManager{
+		alg:     sa,
+		dir:     keysDir,
+		overlap: overlap,
+	}
+
+	if keysDir != "" {
+		if err := os.MkdirAll(keysDir, 0o700); err != nil {
+			return nil, errors.Wrap(ErrLoadKeys, err)
+		}
+	}
+	now := time.Now()
+	if err := km.load(now); err != nil {
+		return nil, errors.Wrap(ErrLoadKeys, err)
+	}
+	if len(km.keys) == 0 {
+		if err := km.rotate(now); err != nil {
+			return nil, err
+		}
+	}
+
+	return km, nil
+}
+
+func (km *keyManager) Issue(key auth.Key) (string, error) {
+	tkn, err := buildToken(key)
+	if err != nil {
+		return "", err
+	}
+
+	km.mu.RLock()
+	current := km.keys[len(km.keys)-1].key
+	km.mu.RUnlock()
+
+	signedTkn, err := jwt.Sign(tkn, jwt.WithKey(km.alg, current))
+	if err != nil {
+		return "", errors.Wrap(ErrSignJWT, err)
+	}
+	return string(signedTkn), nil
+}
+
+func (km *keyManager) Parse(token string) (auth.Key, error) {
+	key, err := km.parse(token)
+	// The token may be signed with a key rotated by another instance.
+	if err != nil && km.reload() {
+		return km.parse(token)
+	}
+
+	return key, err
+}
+
+func (km *keyManager) RetrieveJWKS() []auth.PublicKeyInfo {
+	keys := []auth.PublicKeyInfo{}
+	set, err := km.publicKeys()
+	if err != nil {
+		return keys
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		return keys
+	}
+	var jwks struct {
+		Keys []auth.PublicKeyInfo `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return keys
+	}
+
+	return append(keys, jwks.Keys...)
+}
+
+func (km *keyManager) Rotate() error {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	return km.rotate(time.Now())
+}
+
+func (km *keyManager) Refresh(rotation time.Duration) error {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	now := time.Now()
+	if err := km.load(now); err != nil {
+		return errors.Wrap(ErrLoadKeys, err)
+	}
+	if rotation > 0 && now.Sub(km.keys[len(km.keys)-1].createdAt) >= rotation {
+		return km.rotate(now)
+	}
+
+	return km.purge(now)
+}
+
+func (km *keyManager) parse(token string) (auth.Key, error) {
+	set, err := km.publicKeys()
+	if err != nil {
+		return auth.Key{}, errors.Wrap(ErrValidateJWTToken, err)
+	}
+
+	return parseToken(token, jwt.WithKeySet(set))
+}
+
+// reload reloads the keys from the keys directory at most once per reload
+// interval, and reports whether the keys are reloaded.
+func (km *keyManager) reload() bool {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	now := time.Now()
+	if km.dir == "" || now.Sub(km.loadedAt) < reloadInterval {
+		return false
+	}
+
+	return km.load(now) == nil
+}
+
+// load replaces the keys with the ones stored in the keys directory. Each
+// key is retired when the key following it in lexical order is created.
+func (km *keyManager) load(now time.Time) error {
+	if km.dir == "" {
+		return nil
+	}
+	keys, err := loadKeys(km.alg, km.dir)
+	if err != nil {
+		return err
+	}
+	km.loadedAt = now
+	if len(keys) == 0 {
+		return nil
+	}
+	for i := 0; i < len(keys)-1; i++ {
+		keys[i].retiredAt = keys[i+1].createdAt
+	}
+	km.keys = keys
+
+	return nil
+}
+
+func (km *keyManager) rotate(now time.Time) error {
+	key, err := generateKey(km.alg, now.UTC().Format(kidFormat))
+	if err != nil {
+		return err
+	}
+	if err := saveKey(km.dir, key); err != nil {
+		return errors.Wrap(ErrSaveKey, err)
+	}
+	if len(km.keys) > 0 {
+		km.keys[len(km.keys)-1].retiredAt = now
+	}
+	km.keys = append(km.keys, signingKey{key: key, createdAt: now})
+
+	return km.purge(now)
+}
+
+// purge removes the keys retired before the overlap window.
+func (km *keyManager) purge(now time.Time) error {
+	var keys []signingKey
+	for _, sk := range km.keys {
+		if km.expired(sk, now) {
+			if err := removeKey(km.dir, sk.key.KeyID()); err != nil {
+				return err
+			}
+			continue
+		}
+		keys = append(keys, sk)
+	}
+	km.keys = keys
+
+	return nil
+}
+
+func (km *keyManager) expired(sk signingKey, now time.Time) bool {
+	return !sk.retiredAt.IsZero() && now.Sub(sk.retiredAt) > km.overlap
+}
+
+// publicKeys returns the public keys of the keys which are not retired
+// before the overlap window, regardless of whether they are purged yet.
+func (km *keyManager) publicKeys() (jwk.Set, error) {
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+
+	now := time.Now()
+	set := jwk.NewSet()
+	for _, sk := range km.keys {
+		if km.expired(sk, now) {
+			continue
+		}
+		pub, err := jwk.PublicKeyOf(sk.key)
+		if err != nil {
+			return nil, err
+		}
+		if err := set.AddKey(pub); err != nil {
+			return nil, err
+		}
+	}
+
+	return set, nil
+}
+
+func loadKeys(alg jwa.SignatureAlgorithm, dir string) ([]signingKey, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+pemExt))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var keys []signingKey
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		key, err := jwk.ParseKey(data, jwk.WithPEM(true))
+		if err != nil {
+			return nil, errors.Wrap(errors.New(file), err)
+		}
+		if key.KeyType() != keyTypes[alg] {
+			return nil, errors.Wrap(errors.New(file), errKeyType)
+		}
+		kid := strings.TrimSuffix(filepath.Base(file), pemExt)
+		if err := setKeyFields(key, alg, kid); err != nil {
+			return nil, err
+		}
+		keys = append(keys, signingKey{key: key, createdAt: info.ModTime()})
+	}
+
+	return keys, nil
+}
+
+// saveKey stores the PEM encoded private key to the keys directory. The key
+// is written to a temporary file first, so that the other instances never
+// load a partially written key.
+func saveKey(dir string, key jwk.Key) error {
+	if dir == "" {
+		return nil
+	}
+	var raw interface{}
+	if err := key.Raw(&raw); err != nil {
+		return err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(raw)
+	if err != nil {
+		return err
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: der})
+
+	file := filepath.Join(dir, key.KeyID()+pemExt)
+	if err := os.WriteFile(file+tmpExt, data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(file+tmpExt, file)
+}
+
+func removeKey(dir, kid string) error {
+	if dir == "" {
+		return nil
+	}
+	if err := os.Remove(filepath.Join(dir, kid+pemExt)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func generateKey(alg jwa.SignatureAlgorithm, kid string) (jwk.Key, error) {
+	var raw interface{}
+	var err error
+	switch alg {
+	case jwa.RS256:
+		raw, err = rsa.GenerateKey(rand.Reader, rsaKeySize)
+	case jwa.ES256:
+		raw, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case jwa.EdDSA:
+		_, raw, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+	if err != nil {
+		return nil, errors.Wrap(ErrGenerateKey, err)
+	}
+
+	key, err := jwk.FromRaw(raw)
+	if err != nil {
+		return nil, errors.Wrap(ErrGenerateKey, err)
+	}
+	if err := setKeyFields(key, alg, kid); err != nil {
+		return nil, errors.Wrap(ErrGenerateKey, err)
+	}
+
+	return key, nil
+}
+
+func setKeyFields(key jwk.Key, alg jwa.SignatureAlgorithm, kid string) error {
+	if err := key.Set(jwk.KeyIDKey, kid); err != nil {
+		return err
+	}
+	if err := key.Set(jwk.AlgorithmKey, alg); err != nil {
+		return err
+	}
+
+	return key.Set(jwk.KeyUsageKey, keyUse)
+}
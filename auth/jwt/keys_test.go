@@ -0,0 +1,230 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	authjwt "github.com/absmach/supermq/auth/jwt"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyManagerIssueParse(t *testing.T) {
+	cases := []struct {
+		desc string
+		alg  string
+		err  error
+	}{
+		{
+			desc: "issue and parse token with RS256",
+			alg:  "RS256",
+		},
+		{
+			desc: "issue and parse token with ES256",
+			alg:  "ES256",
+		},
+		{
+			desc: "issue and parse token with EdDSA",
+			alg:  "EdDSA",
+		},
+		{
+			desc: "create key manager with unsupported algorithm",
+			alg:  "HS512",
+			err:  authjwt.ErrUnsupportedAlgorithm,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			km, err := authjwt.NewKeyManager(tc.alg, "", time.Hour)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err != nil {
+				return
+			}
+
+			k := key()
+			tkn, err := km.Issue(k)
+			require.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+
+			msg, err := jws.Parse([]byte(tkn))
+			require.Nil(t, err)
+			jwks := km.RetrieveJWKS()
+			require.Len(t, jwks, 1)
+			header := msg.Signatures()[0].ProtectedHeaders()
+			assert.Equal(t, jwks[0].KeyID, header.KeyID(), fmt.Sprintf("%s: expected kid %s got %s", tc.desc, jwks[0].KeyID, header.KeyID()))
+			assert.Equal(t, tc.alg, jwks[0].Algorithm)
+			assert.Equal(t, tc.alg, header.Algorithm().String())
+
+			parsed, err := km.Parse(tkn)
+			require.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+			assert.Equal(t, k, parsed, fmt.Sprintf("%s: expected %v got %v", tc.desc, k, parsed))
+		})
+	}
+}
+
+func TestKeyManagerParseSymmetricToken(t *testing.T) {
+	km, err := authjwt.NewKeyManager("ES256", "", time.Hour)
+	require.Nil(t, err)
+
+	tkn, err := authjwt.New([]byte(secret)).Issue(key())
+	require.Nil(t, err)
+
+	_, err = km.Parse(tkn)
+	assert.True(t, errors.Contains(err, svcerr.ErrAuthentication), fmt.Sprintf("expected %s got %s", svcerr.ErrAuthentication, err))
+	assert.Empty(t, authjwt.New([]byte(secret)).RetrieveJWKS())
+}
+
+func TestKeyManagerRotate(t *testing.T) {
+	cases := []struct {
+		desc    string
+		overlap time.Duration
+		keys    int
+		err     error
+	}{
+		{
+			desc:    "parse token signed with retired key within overlap window",
+			overlap: time.Hour,
+			keys:    3,
+		},
+		{
+			desc:    "parse token signed with key retired before overlap window",
+			overlap: 0,
+			keys:    1,
+			err:     svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			km, err := authjwt.NewKeyManager("EdDSA", "", tc.overlap)
+			require.Nil(t, err)
+
+			tkn, err := km.Issue(key())
+			require.Nil(t, err)
+
+			require.Nil(t, km.Rotate())
+			time.Sleep(time.Millisecond)
+			require.Nil(t, km.Rotate())
+			assert.Len(t, km.RetrieveJWKS(), tc.keys)
+
+			_, err = km.Parse(tkn)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+			newTkn, err := km.Issue(key())
+			require.Nil(t, err)
+			_, err = km.Parse(newTkn)
+			assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		})
+	}
+}
+
+func TestKeyManagerLoadKeys(t *testing.T) {
+	dir := t.TempDir()
+	for _, kid := range []string{"2024-01", "2024-02"} {
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.Nil(t, err)
+		der, err := x509.MarshalPKCS8PrivateKey(priv)
+		require.Nil(t, err)
+		data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+		require.Nil(t, os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0o600))
+	}
+
+	km, err := authjwt.NewKeyManager("ES256", dir, time.Hour)
+	require.Nil(t, err)
+	jwks := km.RetrieveJWKS()
+	require.Len(t, jwks, 2)
+	assert.Equal(t, "2024-01", jwks[0].KeyID)
+	assert.Equal(t, "2024-02", jwks[1].KeyID)
+	assert.Equal(t, "EC", jwks[1].KeyType)
+	assert.Equal(t, "sig", jwks[1].Use)
+
+	tkn, err := km.Issue(key())
+	require.Nil(t, err)
+	msg, err := jws.Parse([]byte(tkn))
+	require.Nil(t, err)
+	assert.Equal(t, "2024-02", msg.Signatures()[0].ProtectedHeaders().KeyID())
+
+	set, err := jwk.Parse([]byte(fmt.Sprintf(`{"keys":[{"kty":"EC","kid":"%s","crv":"%s","x":"%s","y":"%s"}]}`, jwks[1].KeyID, jwks[1].Curve, jwks[1].X, jwks[1].Y)))
+	require.Nil(t, err)
+	_, err = jws.Verify([]byte(tkn), jws.WithKeySet(set, jws.WithInferAlgorithmFromKey(true)))
+	assert.Nil(t, err, fmt.Sprintf("expected token verified with published key got %s", err))
+
+	_, err = authjwt.NewKeyManager("RS256", dir, time.Hour)
+	assert.True(t, errors.Contains(err, authjwt.ErrLoadKeys), fmt.Sprintf("expected %s got %s", authjwt.ErrLoadKeys, err))
+}
+
+func TestKeyManagerSharedKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	km1, err := authjwt.NewKeyManager("ES256", dir, time.Hour)
+	require.Nil(t, err)
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	require.Nil(t, err)
+	require.Len(t, files, 1)
+
+	km2, err := authjwt.NewKeyManager("ES256", dir, time.Hour)
+	require.Nil(t, err)
+	assert.Equal(t, km1.RetrieveJWKS(), km2.RetrieveJWKS(), "expected instances sharing the keys directory to publish the same keys")
+
+	tkn, err := km1.Issue(key())
+	require.Nil(t, err)
+	_, err = km2.Parse(tkn)
+	assert.Nil(t, err, fmt.Sprintf("expected token issued by another instance to be valid got %s", err))
+
+	require.Nil(t, km1.Rotate())
+	require.Nil(t, km2.Refresh(0))
+	assert.Len(t, km2.RetrieveJWKS(), 2)
+	assert.Equal(t, km1.RetrieveJWKS(), km2.RetrieveJWKS(), "expected rotated key to be loaded by another instance")
+
+	newTkn, err := km2.Issue(key())
+	require.Nil(t, err)
+	_, err = km1.Parse(newTkn)
+	assert.Nil(t, err, fmt.Sprintf("expected token signed with rotated key to be valid got %s", err))
+
+	restarted, err := authjwt.NewKeyManager("ES256", dir, time.Hour)
+	require.Nil(t, err)
+	_, err = restarted.Parse(tkn)
+	assert.Nil(t, err, fmt.Sprintf("expected token to be valid after restart got %s", err))
+}
+
+func TestKeyManagerRefresh(t *testing.T) {
+	dir := t.TempDir()
+	overlap := 50 * time.Millisecond
+
+	km, err := authjwt.NewKeyManager("EdDSA", dir, overlap)
+	require.Nil(t, err)
+	tkn, err := km.Issue(key())
+	require.Nil(t, err)
+
+	require.Nil(t, km.Refresh(time.Hour))
+	assert.Len(t, km.RetrieveJWKS(), 1, "expected no rotation before the rotation period")
+
+	time.Sleep(time.Millisecond)
+	require.Nil(t, km.Refresh(time.Millisecond))
+	assert.Len(t, km.RetrieveJWKS(), 2, "expected rotation after the rotation period")
+
+	time.Sleep(2 * overlap)
+	_, err = km.Parse(tkn)
+	assert.True(t, errors.Contains(err, svcerr.ErrAuthentication), fmt.Sprintf("expected %s got %s", svcerr.ErrAuthentication, err))
+	assert.Len(t, km.RetrieveJWKS(), 1, "expected retired key removed after the overlap window")
+
+	require.Nil(t, km.Refresh(0))
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	require.Nil(t, err)
+	assert.Len(t, files, 1, "expected retired key file removed after the overlap window")
+}
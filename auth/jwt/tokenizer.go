@@ -54,6 +54,26 @@ func New(secret []byte) auth.Tokenizer {
 }
 
 func (tok *tokenizer) Issue(key auth.Key) (string, error) {
+	tkn, err := buildToken(key)
+	if err != nil {
+		return "", err
+	}
+	signedTkn, err := jwt.Sign(tkn, jwt.WithKey(jwa.HS512, tok.secret))
+	if err != nil {
+		return "", errors.Wrap(ErrSignJWT, err)
+	}
+	return string(signedTkn), nil
+}
+
+func (tok *tokenizer) Parse(token string) (auth.Key, error) {
+	return parseToken(token, jwt.WithKey(jwa.HS512, tok.secret))
+}
+
+func (tok *tokenizer) RetrieveJWKS() []auth.PublicKeyInfo {
+	return []auth.PublicKeyInfo{}
+}
+
+func buildToken(key auth.Key) (jwt.Token, error) {
 	builder := jwt.NewBuilder()
 	builder.
 		Issuer(issuerName).
@@ -69,17 +89,13 @@ func (tok *tokenizer) Issue(key auth.Key) (string, error) {
 	}
 	tkn, err := builder.Build()
 	if err != nil {
-		return "", errors.Wrap(svcerr.ErrAuthentication, err)
-	}
-	signedTkn, err := jwt.Sign(tkn, jwt.WithKey(jwa.HS512, tok.secret))
-	if err != nil {
-		return "", errors.Wrap(ErrSignJWT, err)
+		return nil, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
-	return string(signedTkn), nil
+	return tkn, nil
 }
 
-func (tok *tokenizer) Parse(token string) (auth.Key, error) {
-	tkn, err := tok.validateToken(token)
+func parseToken(token string, opts ...jwt.ParseOption) (auth.Key, error) {
+	tkn, err := validateToken(token, opts...)
 	if err != nil {
 		return auth.Key{}, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
@@ -92,11 +108,10 @@ func (tok *tokenizer) Parse(token string) (auth.Key, error) {
 	return key, nil
 }
 
-func (tok *tokenizer) validateToken(token string) (jwt.Token, error) {
+func validateToken(token string, opts ...jwt.ParseOption) (jwt.Token, error) {
 	tkn, err := jwt.Parse(
 		[]byte(token),
-		jwt.WithValidate(true),
-		jwt.WithKey(jwa.HS512, tok.secret),
+		append([]jwt.ParseOption{jwt.WithValidate(true)}, opts...)...,
 	)
 	if err != nil {
 		if errors.Contains(err, errJWTExpiryKey) {
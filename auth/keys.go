@@ -67,6 +67,10 @@ type Key struct {
 	Domain    string    `json:"domain,omitempty"` // domain user ID
 	IssuedAt  time.Time `json:"issued_at,omitempty"`
 	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// Device and IP describe the client the login session is created for,
+	// and are not part of the issued token.
+	Device string `json:"-"`
+	IP     string `json:"-"`
 }
 
 func (key Key) String() string {
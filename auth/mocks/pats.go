@@ -54,14 +54,14 @@ func (_m *PATS) AddPATScopeEntry(ctx context.Context, token string, patID string
 	return r0, r1
 }
 
-// AuthorizePAT provides a mock function with given fields: ctx, userID, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs
-func (_m *PATS) AuthorizePAT(ctx context.Context, userID string, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) error {
+// AuthorizePAT provides a mock function with given fields: ctx, userID, patID, ip, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs
+func (_m *PATS) AuthorizePAT(ctx context.Context, userID string, patID string, ip string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) error {
 	_va := make([]interface{}, len(entityIDs))
 	for _i := range entityIDs {
 		_va[_i] = entityIDs[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, userID, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation)
+	_ca = append(_ca, ctx, userID, patID, ip, platformEntityType, optionalDomainID, optionalDomainEntityType, operation)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
@@ -70,8 +70,8 @@ func (_m *PATS) AuthorizePAT(ctx context.Context, userID string, patID string, p
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, auth.PlatformEntityType, string, auth.DomainEntityType, auth.OperationType, ...string) error); ok {
-		r0 = rf(ctx, userID, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, auth.PlatformEntityType, string, auth.DomainEntityType, auth.OperationType, ...string) error); ok {
+		r0 = rf(ctx, userID, patID, ip, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -168,9 +168,37 @@ func (_m *PATS) DeletePAT(ctx context.Context, token string, patID string) error
 	return r0
 }
 
-// IdentifyPAT provides a mock function with given fields: ctx, paToken
-func (_m *PATS) IdentifyPAT(ctx context.Context, paToken string) (auth.PAT, error) {
-	ret := _m.Called(ctx, paToken)
+// ExpireUnusedPATs provides a mock function with given fields: ctx, unusedFor
+func (_m *PATS) ExpireUnusedPATs(ctx context.Context, unusedFor time.Duration) (uint64, error) {
+	ret := _m.Called(ctx, unusedFor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireUnusedPATs")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (uint64, error)); ok {
+		return rf(ctx, unusedFor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) uint64); ok {
+		r0 = rf(ctx, unusedFor)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, unusedFor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentifyPAT provides a mock function with given fields: ctx, paToken, ip
+func (_m *PATS) IdentifyPAT(ctx context.Context, paToken string, ip string) (auth.PAT, error) {
+	ret := _m.Called(ctx, paToken, ip)
 
 	if len(ret) == 0 {
 		panic("no return value specified for IdentifyPAT")
@@ -178,17 +206,17 @@ func (_m *PATS) IdentifyPAT(ctx context.Context, paToken string) (auth.PAT, erro
 
 	var r0 auth.PAT
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (auth.PAT, error)); ok {
-		return rf(ctx, paToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (auth.PAT, error)); ok {
+		return rf(ctx, paToken, ip)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) auth.PAT); ok {
-		r0 = rf(ctx, paToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) auth.PAT); ok {
+		r0 = rf(ctx, paToken, ip)
 	} else {
 		r0 = ret.Get(0).(auth.PAT)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, paToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, paToken, ip)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -224,6 +252,34 @@ func (_m *PATS) ListPATS(ctx context.Context, token string, pm auth.PATSPageMeta
 	return r0, r1
 }
 
+// ListPATUsage provides a mock function with given fields: ctx, token, patID, pm
+func (_m *PATS) ListPATUsage(ctx context.Context, token string, patID string, pm auth.PATUsagePageMeta) (auth.PATUsagePage, error) {
+	ret := _m.Called(ctx, token, patID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPATUsage")
+	}
+
+	var r0 auth.PATUsagePage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, auth.PATUsagePageMeta) (auth.PATUsagePage, error)); ok {
+		return rf(ctx, token, patID, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, auth.PATUsagePageMeta) auth.PATUsagePage); ok {
+		r0 = rf(ctx, token, patID, pm)
+	} else {
+		r0 = ret.Get(0).(auth.PATUsagePage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, auth.PATUsagePageMeta) error); ok {
+		r1 = rf(ctx, token, patID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemovePATScopeEntry provides a mock function with given fields: ctx, token, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs
 func (_m *PATS) RemovePATScopeEntry(ctx context.Context, token string, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) (auth.Scope, error) {
 	_va := make([]interface{}, len(entityIDs))
@@ -79,6 +79,34 @@ func (_m *PATSRepository) CheckScopeEntry(ctx context.Context, userID string, pa
 	return r0
 }
 
+// ExpireUnused provides a mock function with given fields: ctx, unusedSince
+func (_m *PATSRepository) ExpireUnused(ctx context.Context, unusedSince time.Time) (uint64, error) {
+	ret := _m.Called(ctx, unusedSince)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireUnused")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (uint64, error)); ok {
+		return rf(ctx, unusedSince)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) uint64); ok {
+		r0 = rf(ctx, unusedSince)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, unusedSince)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Reactivate provides a mock function with given fields: ctx, userID, patID
 func (_m *PATSRepository) Reactivate(ctx context.Context, userID string, patID string) error {
 	ret := _m.Called(ctx, userID, patID)
@@ -284,6 +312,34 @@ func (_m *PATSRepository) Revoke(ctx context.Context, userID string, patID strin
 	return r0
 }
 
+// RevokeDomainPATs provides a mock function with given fields: ctx, domainID
+func (_m *PATSRepository) RevokeDomainPATs(ctx context.Context, domainID string) (uint64, error) {
+	ret := _m.Called(ctx, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeDomainPATs")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (uint64, error)); ok {
+		return rf(ctx, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) uint64); ok {
+		r0 = rf(ctx, domainID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: ctx, pat
 func (_m *PATSRepository) Save(ctx context.Context, pat auth.PAT) error {
 	ret := _m.Called(ctx, pat)
@@ -330,6 +386,24 @@ func (_m *PATSRepository) UpdateDescription(ctx context.Context, userID string,
 	return r0, r1
 }
 
+// UpdateLastUsedAt provides a mock function with given fields: ctx, userID, patID, lastUsedAt
+func (_m *PATSRepository) UpdateLastUsedAt(ctx context.Context, userID string, patID string, lastUsedAt time.Time) error {
+	ret := _m.Called(ctx, userID, patID, lastUsedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLastUsedAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, userID, patID, lastUsedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateName provides a mock function with given fields: ctx, userID, patID, name
 func (_m *PATSRepository) UpdateName(ctx context.Context, userID string, patID string, name string) (auth.PAT, error) {
 	ret := _m.Called(ctx, userID, patID, name)
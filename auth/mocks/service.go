@@ -74,14 +74,14 @@ func (_m *Service) Authorize(ctx context.Context, pr policies.Policy) error {
 	return r0
 }
 
-// AuthorizePAT provides a mock function with given fields: ctx, userID, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs
-func (_m *Service) AuthorizePAT(ctx context.Context, userID string, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) error {
+// AuthorizePAT provides a mock function with given fields: ctx, userID, patID, ip, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs
+func (_m *Service) AuthorizePAT(ctx context.Context, userID string, patID string, ip string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) error {
 	_va := make([]interface{}, len(entityIDs))
 	for _i := range entityIDs {
 		_va[_i] = entityIDs[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, userID, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation)
+	_ca = append(_ca, ctx, userID, patID, ip, platformEntityType, optionalDomainID, optionalDomainEntityType, operation)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
@@ -90,8 +90,8 @@ func (_m *Service) AuthorizePAT(ctx context.Context, userID string, patID string
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, auth.PlatformEntityType, string, auth.DomainEntityType, auth.OperationType, ...string) error); ok {
-		r0 = rf(ctx, userID, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, auth.PlatformEntityType, string, auth.DomainEntityType, auth.OperationType, ...string) error); ok {
+		r0 = rf(ctx, userID, patID, ip, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -188,6 +188,34 @@ func (_m *Service) DeletePAT(ctx context.Context, token string, patID string) er
 	return r0
 }
 
+// ExpireUnusedPATs provides a mock function with given fields: ctx, unusedFor
+func (_m *Service) ExpireUnusedPATs(ctx context.Context, unusedFor time.Duration) (uint64, error) {
+	ret := _m.Called(ctx, unusedFor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireUnusedPATs")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (uint64, error)); ok {
+		return rf(ctx, unusedFor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) uint64); ok {
+		r0 = rf(ctx, unusedFor)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, unusedFor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Identify provides a mock function with given fields: ctx, token
 func (_m *Service) Identify(ctx context.Context, token string) (auth.Key, error) {
 	ret := _m.Called(ctx, token)
@@ -216,9 +244,9 @@ func (_m *Service) Identify(ctx context.Context, token string) (auth.Key, error)
 	return r0, r1
 }
 
-// IdentifyPAT provides a mock function with given fields: ctx, paToken
-func (_m *Service) IdentifyPAT(ctx context.Context, paToken string) (auth.PAT, error) {
-	ret := _m.Called(ctx, paToken)
+// IdentifyPAT provides a mock function with given fields: ctx, paToken, ip
+func (_m *Service) IdentifyPAT(ctx context.Context, paToken string, ip string) (auth.PAT, error) {
+	ret := _m.Called(ctx, paToken, ip)
 
 	if len(ret) == 0 {
 		panic("no return value specified for IdentifyPAT")
@@ -226,17 +254,17 @@ func (_m *Service) IdentifyPAT(ctx context.Context, paToken string) (auth.PAT, e
 
 	var r0 auth.PAT
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (auth.PAT, error)); ok {
-		return rf(ctx, paToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (auth.PAT, error)); ok {
+		return rf(ctx, paToken, ip)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) auth.PAT); ok {
-		r0 = rf(ctx, paToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) auth.PAT); ok {
+		r0 = rf(ctx, paToken, ip)
 	} else {
 		r0 = ret.Get(0).(auth.PAT)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, paToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, paToken, ip)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -300,6 +328,90 @@ func (_m *Service) ListPATS(ctx context.Context, token string, pm auth.PATSPageM
 	return r0, r1
 }
 
+// ListPATUsage provides a mock function with given fields: ctx, token, patID, pm
+func (_m *Service) ListPATUsage(ctx context.Context, token string, patID string, pm auth.PATUsagePageMeta) (auth.PATUsagePage, error) {
+	ret := _m.Called(ctx, token, patID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPATUsage")
+	}
+
+	var r0 auth.PATUsagePage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, auth.PATUsagePageMeta) (auth.PATUsagePage, error)); ok {
+		return rf(ctx, token, patID, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, auth.PATUsagePageMeta) auth.PATUsagePage); ok {
+		r0 = rf(ctx, token, patID, pm)
+	} else {
+		r0 = ret.Get(0).(auth.PATUsagePage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, auth.PATUsagePageMeta) error); ok {
+		r1 = rf(ctx, token, patID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSessions provides a mock function with given fields: ctx, token, pm
+func (_m *Service) ListSessions(ctx context.Context, token string, pm auth.SessionsPageMeta) (auth.SessionsPage, error) {
+	ret := _m.Called(ctx, token, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 auth.SessionsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.SessionsPageMeta) (auth.SessionsPage, error)); ok {
+		return rf(ctx, token, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.SessionsPageMeta) auth.SessionsPage); ok {
+		r0 = rf(ctx, token, pm)
+	} else {
+		r0 = ret.Get(0).(auth.SessionsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, auth.SessionsPageMeta) error); ok {
+		r1 = rf(ctx, token, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeExpiredSessions provides a mock function with given fields: ctx
+func (_m *Service) PurgeExpiredSessions(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpiredSessions")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemovePATScopeEntry provides a mock function with given fields: ctx, token, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs
 func (_m *Service) RemovePATScopeEntry(ctx context.Context, token string, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) (auth.Scope, error) {
 	_va := make([]interface{}, len(entityIDs))
@@ -363,6 +475,26 @@ func (_m *Service) ResetPATSecret(ctx context.Context, token string, patID strin
 	return r0, r1
 }
 
+// RetrieveJWKS provides a mock function with given fields: ctx
+func (_m *Service) RetrieveJWKS(ctx context.Context) []auth.PublicKeyInfo {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveJWKS")
+	}
+
+	var r0 []auth.PublicKeyInfo
+	if rf, ok := ret.Get(0).(func(context.Context) []auth.PublicKeyInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.PublicKeyInfo)
+		}
+	}
+
+	return r0
+}
+
 // RetrieveKey provides a mock function with given fields: ctx, token, id
 func (_m *Service) RetrieveKey(ctx context.Context, token string, id string) (auth.Key, error) {
 	ret := _m.Called(ctx, token, id)
@@ -455,6 +587,60 @@ func (_m *Service) RevokePATSecret(ctx context.Context, token string, patID stri
 	return r0
 }
 
+// RevokeSession provides a mock function with given fields: ctx, token, id
+func (_m *Service) RevokeSession(ctx context.Context, token string, id string) error {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSessions provides a mock function with given fields: ctx, token
+func (_m *Service) RevokeSessions(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserSessions provides a mock function with given fields: ctx, userID
+func (_m *Service) RevokeUserSessions(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdatePATDescription provides a mock function with given fields: ctx, token, patID, description
 func (_m *Service) UpdatePATDescription(ctx context.Context, token string, patID string, description string) (auth.PAT, error) {
 	ret := _m.Called(ctx, token, patID, description)
@@ -177,6 +177,80 @@ func (_c *TokenServiceClient_Refresh_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// RevokeSessions provides a mock function with given fields: ctx, in, opts
+func (_m *TokenServiceClient) RevokeSessions(ctx context.Context, in *v1.RevokeSessionsReq, opts ...grpc.CallOption) (*v1.RevokeSessionsRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSessions")
+	}
+
+	var r0 *v1.RevokeSessionsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.RevokeSessionsReq, ...grpc.CallOption) (*v1.RevokeSessionsRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.RevokeSessionsReq, ...grpc.CallOption) *v1.RevokeSessionsRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.RevokeSessionsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.RevokeSessionsReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenServiceClient_RevokeSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSessions'
+type TokenServiceClient_RevokeSessions_Call struct {
+	*mock.Call
+}
+
+// RevokeSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *v1.RevokeSessionsReq
+//   - opts ...grpc.CallOption
+func (_e *TokenServiceClient_Expecter) RevokeSessions(ctx interface{}, in interface{}, opts ...interface{}) *TokenServiceClient_RevokeSessions_Call {
+	return &TokenServiceClient_RevokeSessions_Call{Call: _e.mock.On("RevokeSessions",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *TokenServiceClient_RevokeSessions_Call) Run(run func(ctx context.Context, in *v1.RevokeSessionsReq, opts ...grpc.CallOption)) *TokenServiceClient_RevokeSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*v1.RevokeSessionsReq), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *TokenServiceClient_RevokeSessions_Call) Return(_a0 *v1.RevokeSessionsRes, _a1 error) *TokenServiceClient_RevokeSessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TokenServiceClient_RevokeSessions_Call) RunAndReturn(run func(context.Context, *v1.RevokeSessionsReq, ...grpc.CallOption) (*v1.RevokeSessionsRes, error)) *TokenServiceClient_RevokeSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewTokenServiceClient creates a new instance of TokenServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTokenServiceClient(t interface {
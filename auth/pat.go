@@ -12,7 +12,13 @@ import (
 	"github.com/absmach/supermq/pkg/errors"
 )
 
-var errAddEntityToAnyIDs = errors.New("could not add entity id to any ID scope value")
+var (
+	errAddEntityToAnyIDs = errors.New("could not add entity id to any ID scope value")
+
+	// ErrInvalidMessagingScope indicates that the publish or subscribe operation
+	// is used outside of the domain channels scope.
+	ErrInvalidMessagingScope = errors.New("publish and subscribe operations are allowed only in domain channels scope")
+)
 
 // Define OperationType.
 type OperationType uint32
@@ -455,6 +461,9 @@ func (ds *DomainScope) Add(domainEntityType DomainEntityType, operation Operatio
 	if domainEntityType < DomainManagementScope || domainEntityType > DomainClientsScope {
 		return fmt.Errorf("failed to add domain %d scope: invalid domain entity type", domainEntityType)
 	}
+	if err := checkMessagingOperation(domainEntityType, operation); err != nil {
+		return fmt.Errorf("failed to add domain %s scope: %w", domainEntityType.String(), err)
+	}
 	if domainEntityType == DomainManagementScope {
 		if err := ds.DomainManagement.Add(operation, entityIDs...); err != nil {
 			return fmt.Errorf("failed to delete domain management scope: %w", err)
@@ -477,6 +486,28 @@ func (ds *DomainScope) Add(domainEntityType DomainEntityType, operation Operatio
 	return nil
 }
 
+// Validate checks that the messaging operations are used only in the
+// domain channels scope.
+func (ds DomainScope) Validate() error {
+	for domainEntityType, os := range ds.Entities {
+		for operation := range os {
+			if err := checkMessagingOperation(domainEntityType, operation); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkMessagingOperation allows publish and subscribe operations only on
+// channels, where the entity IDs are the channel IDs to publish or subscribe to.
+func checkMessagingOperation(domainEntityType DomainEntityType, operation OperationType) error {
+	if (operation == PublishOp || operation == SubscribeOp) && domainEntityType != DomainChannelsScope {
+		return ErrInvalidMessagingScope
+	}
+	return nil
+}
+
 // Delete entry in Domain scope.
 func (ds *DomainScope) Delete(domainEntityType DomainEntityType, operation OperationType, entityIDs ...string) error {
 	if ds == nil {
@@ -607,6 +638,16 @@ func (s *Scope) Add(platformEntityType PlatformEntityType, optionalDomainID stri
 	return nil
 }
 
+// Validate checks the scope entries of all domains.
+func (s Scope) Validate() error {
+	for domainID, ds := range s.Domains {
+		if err := ds.Validate(); err != nil {
+			return fmt.Errorf("invalid platform %s id %s scope: %w", PlatformDomainsScope.String(), domainID, err)
+		}
+	}
+	return nil
+}
+
 // Delete entry in Domain scope.
 func (s *Scope) Delete(platformEntityType PlatformEntityType, optionalDomainID string, optionalDomainEntityType DomainEntityType, operation OperationType, entityIDs ...string) error {
 	if s == nil {
@@ -750,14 +791,23 @@ type PATS interface {
 	// ClearAllScope function removes all scope entry.
 	ClearPATAllScopeEntry(ctx context.Context, token, patID string) error
 
-	// IdentifyPAT function will valid the secret.
-	IdentifyPAT(ctx context.Context, paToken string) (PAT, error)
+	// IdentifyPAT function will valid the secret. The source IP is reported
+	// to the owner if the revoked PAT is presented.
+	IdentifyPAT(ctx context.Context, paToken, ip string) (PAT, error)
 
 	// AuthorizePAT function will valid the secret and check the given scope exists.
-	AuthorizePAT(ctx context.Context, userID, patID string, platformEntityType PlatformEntityType, optionalDomainID string, optionalDomainEntityType DomainEntityType, operation OperationType, entityIDs ...string) error
+	// The decision is recorded in the PAT usage audit together with the source IP.
+	AuthorizePAT(ctx context.Context, userID, patID, ip string, platformEntityType PlatformEntityType, optionalDomainID string, optionalDomainEntityType DomainEntityType, operation OperationType, entityIDs ...string) error
 
 	// CheckPAT function will check the given scope exists.
 	CheckPAT(ctx context.Context, userID, patID string, platformEntityType PlatformEntityType, optionalDomainID string, optionalDomainEntityType DomainEntityType, operation OperationType, entityIDs ...string) error
+
+	// ListPATUsage lists the recorded authorization decisions of the PAT.
+	ListPATUsage(ctx context.Context, token, patID string, pm PATUsagePageMeta) (PATUsagePage, error)
+
+	// ExpireUnusedPATs expires the PATs that have not been used for the given
+	// duration and returns the number of expired PATs.
+	ExpireUnusedPATs(ctx context.Context, unusedFor time.Duration) (uint64, error)
 }
 
 // PATSRepository specifies PATS persistence API.
@@ -794,6 +844,17 @@ type PATSRepository interface {
 	// Remove removes Key with provided ID.
 	Remove(ctx context.Context, userID, patID string) error
 
+	// UpdateLastUsedAt updates the time the PAT was last used.
+	UpdateLastUsedAt(ctx context.Context, userID, patID string, lastUsedAt time.Time) error
+
+	// ExpireUnused expires active PATs last used, or issued if never used,
+	// before the given time and returns the number of expired PATs.
+	ExpireUnused(ctx context.Context, unusedSince time.Time) (uint64, error)
+
+	// RevokeDomainPATs revokes the active PATs that have a scope in the
+	// given domain and returns the number of revoked PATs.
+	RevokeDomainPATs(ctx context.Context, domainID string) (uint64, error)
+
 	AddScopeEntry(ctx context.Context, userID, patID string, platformEntityType PlatformEntityType, optionalDomainID string, optionalDomainEntityType DomainEntityType, operation OperationType, entityIDs ...string) (Scope, error)
 
 	RemoveScopeEntry(ctx context.Context, userID, patID string, platformEntityType PlatformEntityType, optionalDomainID string, optionalDomainEntityType DomainEntityType, operation OperationType, entityIDs ...string) (Scope, error)
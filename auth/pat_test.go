@@ -0,0 +1,111 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopeMessaging(t *testing.T) {
+	domainID := "domainID"
+	channelID := "channelID"
+
+	cases := []struct {
+		desc       string
+		entityType auth.DomainEntityType
+		operation  auth.OperationType
+		err        error
+	}{
+		{
+			desc:       "add publish to channels scope",
+			entityType: auth.DomainChannelsScope,
+			operation:  auth.PublishOp,
+		},
+		{
+			desc:       "add subscribe to channels scope",
+			entityType: auth.DomainChannelsScope,
+			operation:  auth.SubscribeOp,
+		},
+		{
+			desc:       "add publish to clients scope",
+			entityType: auth.DomainClientsScope,
+			operation:  auth.PublishOp,
+			err:        auth.ErrInvalidMessagingScope,
+		},
+		{
+			desc:       "add subscribe to groups scope",
+			entityType: auth.DomainGroupsScope,
+			operation:  auth.SubscribeOp,
+			err:        auth.ErrInvalidMessagingScope,
+		},
+		{
+			desc:       "add read to clients scope",
+			entityType: auth.DomainClientsScope,
+			operation:  auth.ReadOp,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			scope := auth.Scope{}
+			err := scope.Add(auth.PlatformDomainsScope, domainID, tc.entityType, tc.operation, channelID)
+			assert.True(t, errors.Is(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err != nil {
+				return
+			}
+			assert.True(t, scope.Check(auth.PlatformDomainsScope, domainID, tc.entityType, tc.operation, channelID), fmt.Sprintf("%s: expected scope entry to be present\n", tc.desc))
+			assert.Nil(t, scope.Validate(), fmt.Sprintf("%s: expected valid scope\n", tc.desc))
+		})
+	}
+}
+
+func TestScopeValidate(t *testing.T) {
+	cases := []struct {
+		desc  string
+		scope auth.Scope
+		err   error
+	}{
+		{
+			desc: "validate channels messaging scope",
+			scope: auth.Scope{
+				Domains: map[string]auth.DomainScope{
+					"domainID": {
+						Entities: map[auth.DomainEntityType]auth.OperationScope{
+							auth.DomainChannelsScope: {auth.PublishOp: &auth.AnyIDs{}},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "validate clients messaging scope",
+			scope: auth.Scope{
+				Domains: map[string]auth.DomainScope{
+					"domainID": {
+						Entities: map[auth.DomainEntityType]auth.OperationScope{
+							auth.DomainClientsScope: {auth.SubscribeOp: &auth.AnyIDs{}},
+						},
+					},
+				},
+			},
+			err: auth.ErrInvalidMessagingScope,
+		},
+		{
+			desc:  "validate empty scope",
+			scope: auth.Scope{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.scope.Validate()
+			assert.True(t, errors.Is(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// revokedUseInterval is the minimal interval between the notifications
+// about the same revoked PAT.
+const revokedUseInterval = time.Hour
+
+// PATUsage represents the single authorization decision made for the PAT.
+// Both allowed and denied requests are recorded.
+type PATUsage struct {
+	ID                 string
+	PATID              string
+	UserID             string
+	PlatformEntityType PlatformEntityType
+	DomainID           string
+	DomainEntityType   DomainEntityType
+	Operation          OperationType
+	EntityIDs          []string
+	IP                 string
+	Allowed            bool
+	CreatedAt          time.Time
+}
+
+// PATUsagePageMeta contains page metadata that helps navigation.
+type PATUsagePageMeta struct {
+	Offset uint64 `json:"offset" db:"offset"`
+	Limit  uint64 `json:"limit" db:"limit"`
+	UserID string `json:"user_id" db:"user_id"`
+	PATID  string `json:"pat_id" db:"pat_id"`
+}
+
+// PATUsagePage contains page related metadata as well as list of PAT usage records.
+type PATUsagePage struct {
+	Total  uint64     `json:"total"`
+	Offset uint64     `json:"offset"`
+	Limit  uint64     `json:"limit"`
+	Usage  []PATUsage `json:"usage"`
+}
+
+// PATUsageRepository specifies PAT usage audit persistence API.
+//
+//go:generate mockery --name PATUsageRepository --output=./mocks --filename patusage.go --quiet --note "Copyright (c) Abstract Machines"
+type PATUsageRepository interface {
+	// Save persists the PAT usage record.
+	Save(ctx context.Context, usage PATUsage) error
+
+	// RetrieveAll retrieves the usage records of the PAT, newest first.
+	RetrieveAll(ctx context.Context, pm PATUsagePageMeta) (PATUsagePage, error)
+}
+
+// PATNotifier notifies the PAT owner about the suspicious PAT usage.
+//
+//go:generate mockery --name PATNotifier --output=./mocks --filename patnotifier.go --quiet --note "Copyright (c) Abstract Machines"
+type PATNotifier interface {
+	// NotifyRevokedUse notifies the owner that the revoked PAT is still presented.
+	NotifyRevokedUse(ctx context.Context, pat PAT, ip string) error
+}
+
+// noticeThrottle deduplicates the notifications about the same PAT, so
+// the owner is not flooded while the revoked PAT keeps being presented.
+type noticeThrottle struct {
+	mu       sync.Mutex
+	interval time.Duration
+	sent     map[string]time.Time
+}
+
+func newNoticeThrottle(interval time.Duration) *noticeThrottle {
+	return &noticeThrottle{
+		interval: interval,
+		sent:     make(map[string]time.Time),
+	}
+}
+
+// allow returns true if no notification about the PAT was sent within the
+// interval before the given time. The entries older than the interval are
+// evicted.
+func (nt *noticeThrottle) allow(patID string, t time.Time) bool {
+	nt.mu.Lock()
+	defer nt.mu.Unlock()
+
+	for id, sent := range nt.sent {
+		if t.Sub(sent) >= nt.interval {
+			delete(nt.sent, id)
+		}
+	}
+	if _, ok := nt.sent[patID]; ok {
+		return false
+	}
+	nt.sent[patID] = t
+
+	return true
+}
@@ -65,6 +65,79 @@ func Migration() *migrate.MemoryMigrationSource {
                     `,
 				},
 			},
+			{
+				Id: "auth_4",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS sessions (
+                        id              VARCHAR(36) PRIMARY KEY,
+                        user_id         VARCHAR(36) NOT NULL,
+                        device          TEXT,
+                        ip              VARCHAR(254),
+                        issued_at       TIMESTAMP NOT NULL,
+                        last_refresh    TIMESTAMP,
+                        expires_at      TIMESTAMP NOT NULL,
+                        revoked         BOOLEAN NOT NULL DEFAULT FALSE
+                    )`,
+					`CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions (user_id)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS sessions`,
+				},
+			},
+			{
+				Id: "auth_5",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS pat_usage (
+                        id                      VARCHAR(36) PRIMARY KEY,
+                        pat_id                  VARCHAR(36) NOT NULL,
+                        user_id                 VARCHAR(36) NOT NULL,
+                        platform_entity_type    SMALLINT NOT NULL,
+                        domain_id               VARCHAR(36),
+                        domain_entity_type      SMALLINT NOT NULL,
+                        operation               SMALLINT NOT NULL,
+                        entity_ids              TEXT[],
+                        ip                      VARCHAR(254),
+                        allowed                 BOOLEAN NOT NULL,
+                        created_at              TIMESTAMP NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS pat_usage_user_pat_created_idx ON pat_usage (user_id, pat_id, created_at)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS pat_usage`,
+				},
+			},
+			{
+				Id: "auth_6",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS pats (
+                        id              VARCHAR(36) PRIMARY KEY,
+                        user_id         VARCHAR(36) NOT NULL,
+                        name            VARCHAR(254) NOT NULL,
+                        description     TEXT NOT NULL DEFAULT '',
+                        secret          TEXT NOT NULL,
+                        issued_at       TIMESTAMP NOT NULL,
+                        expires_at      TIMESTAMP NOT NULL,
+                        updated_at      TIMESTAMP,
+                        last_used_at    TIMESTAMP,
+                        revoked         BOOLEAN NOT NULL DEFAULT FALSE,
+                        revoked_at      TIMESTAMP
+                    )`,
+					`CREATE INDEX IF NOT EXISTS pats_user_id_idx ON pats (user_id)`,
+					`CREATE TABLE IF NOT EXISTS pat_scopes (
+                        pat_id                  VARCHAR(36) NOT NULL REFERENCES pats (id) ON DELETE CASCADE,
+                        platform_entity_type    SMALLINT NOT NULL,
+                        domain_id               VARCHAR(36) NOT NULL DEFAULT '',
+                        domain_entity_type      SMALLINT NOT NULL,
+                        operation               SMALLINT NOT NULL,
+                        entity_id               VARCHAR(254) NOT NULL,
+                        PRIMARY KEY (pat_id, platform_entity_type, domain_id, domain_entity_type, operation, entity_id)
+                    )`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS pat_scopes`,
+					`DROP TABLE IF EXISTS pats`,
+				},
+			},
 		},
 	}
 }
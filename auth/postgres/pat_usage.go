@@ -0,0 +1,137 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/jackc/pgtype"
+)
+
+const patUsageColumns = `id, pat_id, user_id, platform_entity_type, domain_id, domain_entity_type, operation, entity_ids, ip, allowed, created_at`
+
+var _ auth.PATUsageRepository = (*patUsageRepository)(nil)
+
+type patUsageRepository struct {
+	db postgres.Database
+}
+
+// NewPATUsageRepository instantiates a PostgreSQL implementation of PAT usage repository.
+func NewPATUsageRepository(db postgres.Database) auth.PATUsageRepository {
+	return &patUsageRepository{
+		db: db,
+	}
+}
+
+func (pr *patUsageRepository) Save(ctx context.Context, usage auth.PATUsage) error {
+	q := `INSERT INTO pat_usage (` + patUsageColumns + `)
+		VALUES (:id, :pat_id, :user_id, :platform_entity_type, :domain_id, :domain_entity_type, :operation, :entity_ids, :ip, :allowed, :created_at)`
+
+	dbu, err := toDBPATUsage(usage)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := pr.db.NamedExecContext(ctx, q, dbu); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (pr *patUsageRepository) RetrieveAll(ctx context.Context, pm auth.PATUsagePageMeta) (auth.PATUsagePage, error) {
+	query := `FROM pat_usage WHERE user_id = :user_id AND pat_id = :pat_id`
+	q := `SELECT ` + patUsageColumns + ` ` + query + ` ORDER BY created_at DESC LIMIT :limit OFFSET :offset`
+
+	rows, err := pr.db.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return auth.PATUsagePage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	usage := []auth.PATUsage{}
+	for rows.Next() {
+		var dbu dbPATUsage
+		if err := rows.StructScan(&dbu); err != nil {
+			return auth.PATUsagePage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		usage = append(usage, toPATUsage(dbu))
+	}
+
+	total, err := postgres.Total(ctx, pr.db, `SELECT COUNT(*) `+query, pm)
+	if err != nil {
+		return auth.PATUsagePage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return auth.PATUsagePage{
+		Total:  total,
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		Usage:  usage,
+	}, nil
+}
+
+type dbPATUsage struct {
+	ID                 string           `db:"id"`
+	PATID              string           `db:"pat_id"`
+	UserID             string           `db:"user_id"`
+	PlatformEntityType uint32           `db:"platform_entity_type"`
+	DomainID           sql.NullString   `db:"domain_id"`
+	DomainEntityType   uint32           `db:"domain_entity_type"`
+	Operation          uint32           `db:"operation"`
+	EntityIDs          pgtype.TextArray `db:"entity_ids"`
+	IP                 sql.NullString   `db:"ip"`
+	Allowed            bool             `db:"allowed"`
+	CreatedAt          time.Time        `db:"created_at"`
+}
+
+func toDBPATUsage(u auth.PATUsage) (dbPATUsage, error) {
+	ids := u.EntityIDs
+	if ids == nil {
+		ids = []string{}
+	}
+	var entityIDs pgtype.TextArray
+	if err := entityIDs.Set(ids); err != nil {
+		return dbPATUsage{}, err
+	}
+
+	return dbPATUsage{
+		ID:                 u.ID,
+		PATID:              u.PATID,
+		UserID:             u.UserID,
+		PlatformEntityType: uint32(u.PlatformEntityType),
+		DomainID:           sql.NullString{String: u.DomainID, Valid: u.DomainID != ""},
+		DomainEntityType:   uint32(u.DomainEntityType),
+		Operation:          uint32(u.Operation),
+		EntityIDs:          entityIDs,
+		IP:                 sql.NullString{String: u.IP, Valid: u.IP != ""},
+		Allowed:            u.Allowed,
+		CreatedAt:          u.CreatedAt,
+	}, nil
+}
+
+func toPATUsage(dbu dbPATUsage) auth.PATUsage {
+	var entityIDs []string
+	for _, e := range dbu.EntityIDs.Elements {
+		entityIDs = append(entityIDs, e.String)
+	}
+
+	return auth.PATUsage{
+		ID:                 dbu.ID,
+		PATID:              dbu.PATID,
+		UserID:             dbu.UserID,
+		PlatformEntityType: auth.PlatformEntityType(dbu.PlatformEntityType),
+		DomainID:           dbu.DomainID.String,
+		DomainEntityType:   auth.DomainEntityType(dbu.DomainEntityType),
+		Operation:          auth.OperationType(dbu.Operation),
+		EntityIDs:          entityIDs,
+		IP:                 dbu.IP.String,
+		Allowed:            dbu.Allowed,
+		CreatedAt:          dbu.CreatedAt.UTC(),
+	}
+}
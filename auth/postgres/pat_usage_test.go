@@ -0,0 +1,110 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/auth/postgres"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPATUsage(t *testing.T, userID, patID string, allowed bool, createdAt time.Time) auth.PATUsage {
+	return auth.PATUsage{
+		ID:                 generateID(t),
+		PATID:              patID,
+		UserID:             userID,
+		PlatformEntityType: auth.PlatformDomainsScope,
+		DomainID:           generateID(t),
+		DomainEntityType:   auth.DomainClientsScope,
+		Operation:          auth.ReadOp,
+		EntityIDs:          []string{generateID(t)},
+		IP:                 "127.0.0.1",
+		Allowed:            allowed,
+		CreatedAt:          createdAt.UTC().Round(time.Millisecond),
+	}
+}
+
+func TestPATUsageSave(t *testing.T) {
+	repo := postgres.NewPATUsageRepository(database)
+	usage := newPATUsage(t, generateID(t), generateID(t), true, time.Now())
+
+	cases := []struct {
+		desc  string
+		usage auth.PATUsage
+		err   error
+	}{
+		{
+			desc:  "save a new PAT usage",
+			usage: usage,
+			err:   nil,
+		},
+		{
+			desc:  "save PAT usage with duplicate id",
+			usage: usage,
+			err:   repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.usage)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestPATUsageRetrieveAll(t *testing.T) {
+	repo := postgres.NewPATUsageRepository(database)
+	userID := generateID(t)
+	patID := generateID(t)
+	now := time.Now()
+
+	var saved []auth.PATUsage
+	for i := 0; i < 5; i++ {
+		u := newPATUsage(t, userID, patID, i%2 == 0, now.Add(time.Duration(i)*time.Second))
+		require.Nil(t, repo.Save(context.Background(), u), fmt.Sprintf("unexpected error saving PAT usage: %s", u.ID))
+		saved = append(saved, u)
+	}
+	other := newPATUsage(t, userID, generateID(t), true, now)
+	require.Nil(t, repo.Save(context.Background(), other), fmt.Sprintf("unexpected error saving PAT usage: %s", other.ID))
+
+	cases := []struct {
+		desc     string
+		pm       auth.PATUsagePageMeta
+		total    uint64
+		response []auth.PATUsage
+	}{
+		{
+			desc:     "retrieve all usage of the PAT",
+			pm:       auth.PATUsagePageMeta{UserID: userID, PATID: patID, Limit: 10},
+			total:    5,
+			response: []auth.PATUsage{saved[4], saved[3], saved[2], saved[1], saved[0]},
+		},
+		{
+			desc:     "retrieve usage of the PAT with offset and limit",
+			pm:       auth.PATUsagePageMeta{UserID: userID, PATID: patID, Offset: 1, Limit: 2},
+			total:    5,
+			response: []auth.PATUsage{saved[3], saved[2]},
+		},
+		{
+			desc:     "retrieve usage of the PAT of another user",
+			pm:       auth.PATUsagePageMeta{UserID: generateID(t), PATID: patID, Limit: 10},
+			total:    0,
+			response: []auth.PATUsage{},
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+		assert.Equal(t, tc.response, page.Usage, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.response, page.Usage))
+	}
+}
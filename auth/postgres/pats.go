@@ -0,0 +1,608 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+)
+
+const (
+	patColumns   = `id, user_id, name, description, issued_at, expires_at, updated_at, last_used_at, revoked, revoked_at`
+	scopeColumns = `pat_id, platform_entity_type, domain_id, domain_entity_type, operation, entity_id`
+	scopeRoot    = `pat_id = :pat_id AND platform_entity_type = :platform_entity_type AND domain_id = :domain_id AND domain_entity_type = :domain_entity_type AND operation = :operation`
+
+	anyID = "*"
+)
+
+var _ auth.PATSRepository = (*patRepository)(nil)
+
+type patRepository struct {
+	db postgres.Database
+}
+
+// NewPATSRepository instantiates a PostgreSQL implementation of PAT repository.
+func NewPATSRepository(db postgres.Database) auth.PATSRepository {
+	return &patRepository{
+		db: db,
+	}
+}
+
+func (pr *patRepository) Save(ctx context.Context, pat auth.PAT) (retErr error) {
+	entries, err := scopeToDBEntries(pat.ID, pat.Scope)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollBack := tx.Rollback(); errRollBack != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollBack))
+			}
+		}
+	}()
+
+	q := `INSERT INTO pats (id, user_id, name, description, secret, issued_at, expires_at, updated_at, last_used_at, revoked, revoked_at)
+		VALUES (:id, :user_id, :name, :description, :secret, :issued_at, :expires_at, :updated_at, :last_used_at, :revoked, :revoked_at)`
+	if _, err := tx.NamedExecContext(ctx, q, toDBPAT(pat)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	if len(entries) > 0 {
+		q := `INSERT INTO pat_scopes (` + scopeColumns + `)
+			VALUES (:pat_id, :platform_entity_type, :domain_id, :domain_entity_type, :operation, :entity_id)`
+		if _, err := tx.NamedExecContext(ctx, q, entries); err != nil {
+			return postgres.HandleError(repoerr.ErrCreateEntity, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (pr *patRepository) Retrieve(ctx context.Context, userID, patID string) (auth.PAT, error) {
+	q := `SELECT ` + patColumns + ` FROM pats WHERE user_id = $1 AND id = $2`
+
+	var dbp dbPAT
+	if err := pr.db.QueryRowxContext(ctx, q, userID, patID).StructScan(&dbp); err != nil {
+		if err == sql.ErrNoRows {
+			return auth.PAT{}, repoerr.ErrNotFound
+		}
+		return auth.PAT{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return pr.withScope(ctx, dbp)
+}
+
+func (pr *patRepository) RetrieveSecretAndRevokeStatus(ctx context.Context, userID, patID string) (string, bool, bool, error) {
+	q := `SELECT secret, revoked, expires_at FROM pats WHERE user_id = $1 AND id = $2`
+
+	var (
+		secret    string
+		revoked   bool
+		expiresAt time.Time
+	)
+	if err := pr.db.QueryRowxContext(ctx, q, userID, patID).Scan(&secret, &revoked, &expiresAt); err != nil {
+		if err == sql.ErrNoRows {
+			return "", true, true, repoerr.ErrNotFound
+		}
+		return "", true, true, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return secret, revoked, time.Now().After(expiresAt), nil
+}
+
+func (pr *patRepository) UpdateName(ctx context.Context, userID, patID, name string) (auth.PAT, error) {
+	q := `UPDATE pats SET name = $3, updated_at = $4 WHERE user_id = $1 AND id = $2 RETURNING ` + patColumns
+
+	return pr.update(ctx, q, userID, patID, name, time.Now().UTC())
+}
+
+func (pr *patRepository) UpdateDescription(ctx context.Context, userID, patID, description string) (auth.PAT, error) {
+	q := `UPDATE pats SET description = $3, updated_at = $4 WHERE user_id = $1 AND id = $2 RETURNING ` + patColumns
+
+	return pr.update(ctx, q, userID, patID, description, time.Now().UTC())
+}
+
+func (pr *patRepository) UpdateTokenHash(ctx context.Context, userID, patID, tokenHash string, expiryAt time.Time) (auth.PAT, error) {
+	q := `UPDATE pats SET secret = $3, expires_at = $4, updated_at = $5 WHERE user_id = $1 AND id = $2 RETURNING ` + patColumns
+
+	return pr.update(ctx, q, userID, patID, tokenHash, expiryAt.UTC(), time.Now().UTC())
+}
+
+func (pr *patRepository) RetrieveAll(ctx context.Context, userID string, pm auth.PATSPageMeta) (auth.PATSPage, error) {
+	q := `SELECT ` + patColumns + ` FROM pats WHERE user_id = $1 ORDER BY issued_at DESC LIMIT $2 OFFSET $3`
+
+	rows, err := pr.db.QueryxContext(ctx, q, userID, pm.Limit, pm.Offset)
+	if err != nil {
+		return auth.PATSPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var dbps []dbPAT
+	for rows.Next() {
+		var dbp dbPAT
+		if err := rows.StructScan(&dbp); err != nil {
+			return auth.PATSPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		dbps = append(dbps, dbp)
+	}
+
+	pats := []auth.PAT{}
+	for _, dbp := range dbps {
+		pat, err := pr.withScope(ctx, dbp)
+		if err != nil {
+			return auth.PATSPage{}, err
+		}
+		pats = append(pats, pat)
+	}
+
+	var total uint64
+	if err := pr.db.QueryRowxContext(ctx, `SELECT COUNT(*) FROM pats WHERE user_id = $1`, userID).Scan(&total); err != nil {
+		return auth.PATSPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return auth.PATSPage{
+		Total:  total,
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		PATS:   pats,
+	}, nil
+}
+
+func (pr *patRepository) Revoke(ctx context.Context, userID, patID string) error {
+	q := `UPDATE pats SET revoked = TRUE, revoked_at = $3 WHERE user_id = $1 AND id = $2`
+
+	return pr.exec(ctx, repoerr.ErrUpdateEntity, q, userID, patID, time.Now().UTC())
+}
+
+func (pr *patRepository) Reactivate(ctx context.Context, userID, patID string) error {
+	q := `UPDATE pats SET revoked = FALSE, revoked_at = NULL WHERE user_id = $1 AND id = $2`
+
+	return pr.exec(ctx, repoerr.ErrUpdateEntity, q, userID, patID)
+}
+
+func (pr *patRepository) Remove(ctx context.Context, userID, patID string) error {
+	q := `DELETE FROM pats WHERE user_id = $1 AND id = $2`
+
+	return pr.exec(ctx, repoerr.ErrRemoveEntity, q, userID, patID)
+}
+
+func (pr *patRepository) UpdateLastUsedAt(ctx context.Context, userID, patID string, lastUsedAt time.Time) error {
+	q := `UPDATE pats SET last_used_at = $3 WHERE user_id = $1 AND id = $2`
+
+	return pr.exec(ctx, repoerr.ErrUpdateEntity, q, userID, patID, lastUsedAt.UTC())
+}
+
+func (pr *patRepository) ExpireUnused(ctx context.Context, unusedSince time.Time) (uint64, error) {
+	q := `UPDATE pats SET expires_at = $2, updated_at = $2
+		WHERE revoked = FALSE AND expires_at > $2 AND COALESCE(last_used_at, issued_at) < $1`
+
+	result, err := pr.db.ExecContext(ctx, q, unusedSince.UTC(), time.Now().UTC())
+	if err != nil {
+		return 0, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	expired, err := result.RowsAffected()
+	if err != nil {
+		return 0, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return uint64(expired), nil
+}
+
+func (pr *patRepository) RevokeDomainPATs(ctx context.Context, domainID string) (uint64, error) {
+	q := `UPDATE pats SET revoked = TRUE, revoked_at = $3
+		WHERE revoked = FALSE AND id IN (SELECT pat_id FROM pat_scopes WHERE platform_entity_type = $1 AND domain_id = $2)`
+
+	result, err := pr.db.ExecContext(ctx, q, uint32(auth.PlatformDomainsScope), domainID, time.Now().UTC())
+	if err != nil {
+		return 0, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		return 0, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return uint64(revoked), nil
+}
+
+func (pr *patRepository) AddScopeEntry(ctx context.Context, userID, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) (scope auth.Scope, retErr error) {
+	entries, err := toDBScopeEntries(patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
+	if err != nil {
+		return auth.Scope{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if err := pr.checkOwner(ctx, userID, patID); err != nil {
+		return auth.Scope{}, err
+	}
+
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return auth.Scope{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollBack := tx.Rollback(); errRollBack != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollBack))
+			}
+		}
+	}()
+
+	// Any IDs and selected IDs of the same operation replace each other.
+	q := `DELETE FROM pat_scopes WHERE ` + scopeRoot + ` AND entity_id <> :entity_id`
+	if entries[0].EntityID != anyID {
+		q = `DELETE FROM pat_scopes WHERE ` + scopeRoot + ` AND entity_id = '` + anyID + `'`
+	}
+	if _, err := tx.NamedExecContext(ctx, q, entries[0]); err != nil {
+		return auth.Scope{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	q = `INSERT INTO pat_scopes (` + scopeColumns + `)
+		VALUES (:pat_id, :platform_entity_type, :domain_id, :domain_entity_type, :operation, :entity_id)
+		ON CONFLICT DO NOTHING`
+	if _, err := tx.NamedExecContext(ctx, q, entries); err != nil {
+		return auth.Scope{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return auth.Scope{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return pr.retrieveScope(ctx, patID)
+}
+
+func (pr *patRepository) RemoveScopeEntry(ctx context.Context, userID, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) (auth.Scope, error) {
+	entries, err := toDBScopeEntries(patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
+	if err != nil {
+		return auth.Scope{}, errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+	if err := pr.checkOwner(ctx, userID, patID); err != nil {
+		return auth.Scope{}, err
+	}
+
+	q := `DELETE FROM pat_scopes WHERE ` + scopeRoot + ` AND entity_id = :entity_id`
+	if _, err := pr.db.NamedExecContext(ctx, q, entries); err != nil {
+		return auth.Scope{}, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return pr.retrieveScope(ctx, patID)
+}
+
+func (pr *patRepository) CheckScopeEntry(ctx context.Context, userID, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) error {
+	root, err := toDBScopeEntries(patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, anyID)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	if err := pr.checkOwner(ctx, userID, patID); err != nil {
+		return errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	q := `SELECT entity_id FROM pat_scopes WHERE pat_id = $1 AND platform_entity_type = $2 AND domain_id = $3 AND domain_entity_type = $4 AND operation = $5
+		AND (entity_id = '` + anyID + `' OR entity_id = ANY($6))`
+	e := root[0]
+	rows, err := pr.db.QueryxContext(ctx, q, e.PATID, e.PlatformEntityType, e.DomainID, e.DomainEntityType, e.Operation, entityIDs)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	found := map[string]struct{}{}
+	for rows.Next() {
+		var entityID string
+		if err := rows.Scan(&entityID); err != nil {
+			return postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		if entityID == anyID {
+			return nil
+		}
+		found[entityID] = struct{}{}
+	}
+	for _, entityID := range entityIDs {
+		if _, ok := found[entityID]; !ok {
+			return repoerr.ErrNotFound
+		}
+	}
+
+	return nil
+}
+
+func (pr *patRepository) RemoveAllScopeEntry(ctx context.Context, userID, patID string) error {
+	if err := pr.checkOwner(ctx, userID, patID); err != nil {
+		return err
+	}
+
+	if _, err := pr.db.ExecContext(ctx, `DELETE FROM pat_scopes WHERE pat_id = $1`, patID); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (pr *patRepository) update(ctx context.Context, q string, args ...interface{}) (auth.PAT, error) {
+	var dbp dbPAT
+	if err := pr.db.QueryRowxContext(ctx, q, args...).StructScan(&dbp); err != nil {
+		if err == sql.ErrNoRows {
+			return auth.PAT{}, repoerr.ErrNotFound
+		}
+		return auth.PAT{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return pr.withScope(ctx, dbp)
+}
+
+func (pr *patRepository) exec(ctx context.Context, wrap error, q string, args ...interface{}) error {
+	result, err := pr.db.ExecContext(ctx, q, args...)
+	if err != nil {
+		return postgres.HandleError(wrap, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (pr *patRepository) checkOwner(ctx context.Context, userID, patID string) error {
+	var exists bool
+	q := `SELECT EXISTS (SELECT 1 FROM pats WHERE user_id = $1 AND id = $2)`
+	if err := pr.db.QueryRowxContext(ctx, q, userID, patID).Scan(&exists); err != nil {
+		return postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	if !exists {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (pr *patRepository) withScope(ctx context.Context, dbp dbPAT) (auth.PAT, error) {
+	scope, err := pr.retrieveScope(ctx, dbp.ID)
+	if err != nil {
+		return auth.PAT{}, err
+	}
+	pat := toPAT(dbp)
+	pat.Scope = scope
+
+	return pat, nil
+}
+
+func (pr *patRepository) retrieveScope(ctx context.Context, patID string) (auth.Scope, error) {
+	q := `SELECT ` + scopeColumns + ` FROM pat_scopes WHERE pat_id = $1`
+
+	rows, err := pr.db.QueryxContext(ctx, q, patID)
+	if err != nil {
+		return auth.Scope{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var entries []dbScopeEntry
+	for rows.Next() {
+		var e dbScopeEntry
+		if err := rows.StructScan(&e); err != nil {
+			return auth.Scope{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		entries = append(entries, e)
+	}
+
+	return toScope(entries), nil
+}
+
+type dbPAT struct {
+	ID          string       `db:"id"`
+	UserID      string       `db:"user_id"`
+	Name        string       `db:"name"`
+	Description string       `db:"description"`
+	Secret      string       `db:"secret"`
+	IssuedAt    time.Time    `db:"issued_at"`
+	ExpiresAt   time.Time    `db:"expires_at"`
+	UpdatedAt   sql.NullTime `db:"updated_at"`
+	LastUsedAt  sql.NullTime `db:"last_used_at"`
+	Revoked     bool         `db:"revoked"`
+	RevokedAt   sql.NullTime `db:"revoked_at"`
+}
+
+func toDBPAT(pat auth.PAT) dbPAT {
+	return dbPAT{
+		ID:          pat.ID,
+		UserID:      pat.User,
+		Name:        pat.Name,
+		Description: pat.Description,
+		Secret:      pat.Secret,
+		IssuedAt:    pat.IssuedAt.UTC(),
+		ExpiresAt:   pat.ExpiresAt.UTC(),
+		UpdatedAt:   toNullTime(pat.UpdatedAt),
+		LastUsedAt:  toNullTime(pat.LastUsedAt),
+		Revoked:     pat.Revoked,
+		RevokedAt:   toNullTime(pat.RevokedAt),
+	}
+}
+
+func toPAT(dbp dbPAT) auth.PAT {
+	return auth.PAT{
+		ID:          dbp.ID,
+		User:        dbp.UserID,
+		Name:        dbp.Name,
+		Description: dbp.Description,
+		IssuedAt:    dbp.IssuedAt.UTC(),
+		ExpiresAt:   dbp.ExpiresAt.UTC(),
+		UpdatedAt:   fromNullTime(dbp.UpdatedAt),
+		LastUsedAt:  fromNullTime(dbp.LastUsedAt),
+		Revoked:     dbp.Revoked,
+		RevokedAt:   fromNullTime(dbp.RevokedAt),
+	}
+}
+
+func toNullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t.UTC(), Valid: !t.IsZero()}
+}
+
+func fromNullTime(t sql.NullTime) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+	return t.Time.UTC()
+}
+
+// dbScopeEntry is a single entity of the PAT scope. The scope
+// of any entity is stored as a single entry with the "*" entity ID.
+type dbScopeEntry struct {
+	PATID              string `db:"pat_id"`
+	PlatformEntityType uint32 `db:"platform_entity_type"`
+	DomainID           string `db:"domain_id"`
+	DomainEntityType   uint32 `db:"domain_entity_type"`
+	Operation          uint32 `db:"operation"`
+	EntityID           string `db:"entity_id"`
+}
+
+func toDBScopeEntries(patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) ([]dbScopeEntry, error) {
+	if len(entityIDs) == 0 {
+		return nil, repoerr.ErrMalformedEntity
+	}
+	if _, err := operation.ValidString(); err != nil {
+		return nil, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+
+	switch platformEntityType {
+	case auth.PlatformUsersScope, auth.PlatformDashBoardScope, auth.PlatformMesagingScope:
+		optionalDomainID = ""
+		optionalDomainEntityType = auth.DomainNullScope
+	case auth.PlatformDomainsScope:
+		if optionalDomainID == "" {
+			return nil, errors.Wrap(repoerr.ErrMalformedEntity, fmt.Errorf("invalid platform %s scope: invalid domain id", platformEntityType.String()))
+		}
+		if _, err := auth.ParseDomainEntityType(optionalDomainEntityType.String()); err != nil {
+			return nil, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+	default:
+		return nil, errors.Wrap(repoerr.ErrMalformedEntity, fmt.Errorf("invalid platform entity type %s", platformEntityType.String()))
+	}
+
+	entries := []dbScopeEntry{}
+	for _, entityID := range entityIDs {
+		if entityID == anyID && len(entityIDs) > 1 {
+			return nil, repoerr.ErrMalformedEntity
+		}
+		entries = append(entries, dbScopeEntry{
+			PATID:              patID,
+			PlatformEntityType: uint32(platformEntityType),
+			DomainID:           optionalDomainID,
+			DomainEntityType:   uint32(optionalDomainEntityType),
+			Operation:          uint32(operation),
+			EntityID:           entityID,
+		})
+	}
+
+	return entries, nil
+}
+
+func scopeToDBEntries(patID string, scope auth.Scope) ([]dbScopeEntry, error) {
+	var entries []dbScopeEntry
+	add := func(platformEntityType auth.PlatformEntityType, domainID string, domainEntityType auth.DomainEntityType, ops auth.OperationScope) error {
+		for op, value := range ops {
+			es, err := toDBScopeEntries(patID, platformEntityType, domainID, domainEntityType, op, value.Values()...)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, es...)
+		}
+		return nil
+	}
+
+	if err := add(auth.PlatformUsersScope, "", auth.DomainNullScope, scope.Users); err != nil {
+		return nil, err
+	}
+	if err := add(auth.PlatformDashBoardScope, "", auth.DomainNullScope, scope.Dashboard); err != nil {
+		return nil, err
+	}
+	if err := add(auth.PlatformMesagingScope, "", auth.DomainNullScope, scope.Messaging); err != nil {
+		return nil, err
+	}
+	for domainID, ds := range scope.Domains {
+		if err := add(auth.PlatformDomainsScope, domainID, auth.DomainManagementScope, ds.DomainManagement); err != nil {
+			return nil, err
+		}
+		for entityType, ops := range ds.Entities {
+			if err := add(auth.PlatformDomainsScope, domainID, entityType, ops); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+func toScope(entries []dbScopeEntry) auth.Scope {
+	scope := auth.Scope{
+		Domains: make(map[string]auth.DomainScope),
+	}
+	for _, e := range entries {
+		var ops auth.OperationScope
+		switch auth.PlatformEntityType(e.PlatformEntityType) {
+		case auth.PlatformUsersScope:
+			if scope.Users == nil {
+				scope.Users = auth.OperationScope{}
+			}
+			ops = scope.Users
+		case auth.PlatformDashBoardScope:
+			if scope.Dashboard == nil {
+				scope.Dashboard = auth.OperationScope{}
+			}
+			ops = scope.Dashboard
+		case auth.PlatformMesagingScope:
+			if scope.Messaging == nil {
+				scope.Messaging = auth.OperationScope{}
+			}
+			ops = scope.Messaging
+		case auth.PlatformDomainsScope:
+			ds := scope.Domains[e.DomainID]
+			switch det := auth.DomainEntityType(e.DomainEntityType); det {
+			case auth.DomainManagementScope:
+				if ds.DomainManagement == nil {
+					ds.DomainManagement = auth.OperationScope{}
+				}
+				ops = ds.DomainManagement
+			default:
+				if ds.Entities == nil {
+					ds.Entities = make(map[auth.DomainEntityType]auth.OperationScope)
+				}
+				if ds.Entities[det] == nil {
+					ds.Entities[det] = auth.OperationScope{}
+				}
+				ops = ds.Entities[det]
+			}
+			scope.Domains[e.DomainID] = ds
+		default:
+			continue
+		}
+		addScopeValue(ops, auth.OperationType(e.Operation), e.EntityID)
+	}
+
+	return scope
+}
+
+func addScopeValue(ops auth.OperationScope, op auth.OperationType, entityID string) {
+	if entityID == anyID {
+		ops[op] = &auth.AnyIDs{}
+		return
+	}
+	if _, ok := ops[op].(*auth.AnyIDs); ok {
+		return
+	}
+	ids, ok := ops[op].(*auth.SelectedIDs)
+	if !ok {
+		ids = &auth.SelectedIDs{}
+		ops[op] = ids
+	}
+	(*ids)[entityID] = struct{}{}
+}
@@ -0,0 +1,311 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/auth/postgres"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPAT(t *testing.T, userID string, issuedAt time.Time) auth.PAT {
+	domainID := generateID(t)
+	return auth.PAT{
+		ID:          generateID(t),
+		User:        userID,
+		Name:        "pat",
+		Description: "pat description",
+		Secret:      "hashed secret",
+		Scope: auth.Scope{
+			Users: auth.OperationScope{auth.ReadOp: &auth.AnyIDs{}},
+			Domains: map[string]auth.DomainScope{
+				domainID: {
+					Entities: map[auth.DomainEntityType]auth.OperationScope{
+						auth.DomainClientsScope: {auth.ReadOp: &auth.SelectedIDs{"client": {}}},
+					},
+				},
+			},
+		},
+		IssuedAt:  issuedAt.UTC().Round(time.Millisecond),
+		ExpiresAt: issuedAt.Add(time.Hour).UTC().Round(time.Millisecond),
+	}
+}
+
+func TestPATSave(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+	pat := newPAT(t, generateID(t), time.Now())
+
+	invalid := newPAT(t, generateID(t), time.Now())
+	invalid.Scope.Users = auth.OperationScope{auth.OperationType(100): &auth.AnyIDs{}}
+
+	cases := []struct {
+		desc string
+		pat  auth.PAT
+		err  error
+	}{
+		{
+			desc: "save a new PAT",
+			pat:  pat,
+			err:  nil,
+		},
+		{
+			desc: "save PAT with duplicate id",
+			pat:  pat,
+			err:  repoerr.ErrConflict,
+		},
+		{
+			desc: "save PAT with invalid scope",
+			pat:  invalid,
+			err:  repoerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.pat)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestPATRetrieve(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+	pat := newPAT(t, generateID(t), time.Now())
+	require.Nil(t, repo.Save(context.Background(), pat), fmt.Sprintf("unexpected error saving PAT: %s", pat.ID))
+
+	expected := pat
+	expected.Secret = ""
+
+	cases := []struct {
+		desc   string
+		userID string
+		patID  string
+		pat    auth.PAT
+		err    error
+	}{
+		{
+			desc:   "retrieve existing PAT",
+			userID: pat.User,
+			patID:  pat.ID,
+			pat:    expected,
+			err:    nil,
+		},
+		{
+			desc:   "retrieve PAT of another user",
+			userID: generateID(t),
+			patID:  pat.ID,
+			err:    repoerr.ErrNotFound,
+		},
+		{
+			desc:   "retrieve non-existing PAT",
+			userID: pat.User,
+			patID:  generateID(t),
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		p, err := repo.Retrieve(context.Background(), tc.userID, tc.patID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.pat, p, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.pat, p))
+		}
+	}
+}
+
+func TestPATRetrieveSecretAndRevokeStatus(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+	pat := newPAT(t, generateID(t), time.Now())
+	require.Nil(t, repo.Save(context.Background(), pat), fmt.Sprintf("unexpected error saving PAT: %s", pat.ID))
+	require.Nil(t, repo.Revoke(context.Background(), pat.User, pat.ID), fmt.Sprintf("unexpected error revoking PAT: %s", pat.ID))
+
+	secret, revoked, expired, err := repo.RetrieveSecretAndRevokeStatus(context.Background(), pat.User, pat.ID)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, pat.Secret, secret)
+	assert.True(t, revoked)
+	assert.False(t, expired)
+
+	require.Nil(t, repo.Reactivate(context.Background(), pat.User, pat.ID), fmt.Sprintf("unexpected error reactivating PAT: %s", pat.ID))
+	_, revoked, _, err = repo.RetrieveSecretAndRevokeStatus(context.Background(), pat.User, pat.ID)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.False(t, revoked)
+
+	_, _, _, err = repo.RetrieveSecretAndRevokeStatus(context.Background(), pat.User, generateID(t))
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s\n", repoerr.ErrNotFound, err))
+}
+
+func TestPATRetrieveAll(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+	userID := generateID(t)
+	now := time.Now()
+
+	var saved []auth.PAT
+	for i := 0; i < 5; i++ {
+		p := newPAT(t, userID, now.Add(time.Duration(i)*time.Second))
+		require.Nil(t, repo.Save(context.Background(), p), fmt.Sprintf("unexpected error saving PAT: %s", p.ID))
+		saved = append(saved, p)
+	}
+
+	cases := []struct {
+		desc  string
+		pm    auth.PATSPageMeta
+		total uint64
+		ids   []string
+	}{
+		{
+			desc:  "retrieve all PATs",
+			pm:    auth.PATSPageMeta{Limit: 10},
+			total: 5,
+			ids:   []string{saved[4].ID, saved[3].ID, saved[2].ID, saved[1].ID, saved[0].ID},
+		},
+		{
+			desc:  "retrieve PATs with offset and limit",
+			pm:    auth.PATSPageMeta{Offset: 1, Limit: 2},
+			total: 5,
+			ids:   []string{saved[3].ID, saved[2].ID},
+		},
+		{
+			desc:  "retrieve PATs with offset out of range",
+			pm:    auth.PATSPageMeta{Offset: 10, Limit: 2},
+			total: 5,
+			ids:   []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), userID, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		ids := []string{}
+		for _, p := range page.PATS {
+			ids = append(ids, p.ID)
+		}
+		assert.Equal(t, tc.ids, ids, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.ids, ids))
+	}
+}
+
+func TestPATScopeEntries(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+	pat := newPAT(t, generateID(t), time.Now())
+	pat.Scope = auth.Scope{}
+	require.Nil(t, repo.Save(context.Background(), pat), fmt.Sprintf("unexpected error saving PAT: %s", pat.ID))
+	domainID := generateID(t)
+
+	_, err := repo.AddScopeEntry(context.Background(), pat.User, pat.ID, auth.PlatformDomainsScope, domainID, auth.DomainChannelsScope, auth.ReadOp, "ch1", "ch2")
+	require.Nil(t, err, fmt.Sprintf("unexpected error adding scope entry: %s", err))
+
+	cases := []struct {
+		desc      string
+		userID    string
+		platform  auth.PlatformEntityType
+		domainID  string
+		entity    auth.DomainEntityType
+		op        auth.OperationType
+		entityIDs []string
+		err       error
+	}{
+		{
+			desc:      "check scope entry with selected IDs",
+			userID:    pat.User,
+			platform:  auth.PlatformDomainsScope,
+			domainID:  domainID,
+			entity:    auth.DomainChannelsScope,
+			op:        auth.ReadOp,
+			entityIDs: []string{"ch1", "ch2"},
+			err:       nil,
+		},
+		{
+			desc:      "check scope entry with missing ID",
+			userID:    pat.User,
+			platform:  auth.PlatformDomainsScope,
+			domainID:  domainID,
+			entity:    auth.DomainChannelsScope,
+			op:        auth.ReadOp,
+			entityIDs: []string{"ch1", "ch3"},
+			err:       repoerr.ErrNotFound,
+		},
+		{
+			desc:      "check scope entry with another operation",
+			userID:    pat.User,
+			platform:  auth.PlatformDomainsScope,
+			domainID:  domainID,
+			entity:    auth.DomainChannelsScope,
+			op:        auth.UpdateOp,
+			entityIDs: []string{"ch1"},
+			err:       repoerr.ErrNotFound,
+		},
+		{
+			desc:      "check scope entry without domain ID",
+			userID:    pat.User,
+			platform:  auth.PlatformDomainsScope,
+			entity:    auth.DomainChannelsScope,
+			op:        auth.ReadOp,
+			entityIDs: []string{"ch1"},
+			err:       repoerr.ErrMalformedEntity,
+		},
+		{
+			desc:      "check scope entry of another user",
+			userID:    generateID(t),
+			platform:  auth.PlatformDomainsScope,
+			domainID:  domainID,
+			entity:    auth.DomainChannelsScope,
+			op:        auth.ReadOp,
+			entityIDs: []string{"ch1"},
+			err:       repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.CheckScopeEntry(context.Background(), tc.userID, pat.ID, tc.platform, tc.domainID, tc.entity, tc.op, tc.entityIDs...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	scope, err := repo.AddScopeEntry(context.Background(), pat.User, pat.ID, auth.PlatformDomainsScope, domainID, auth.DomainChannelsScope, auth.ReadOp, "*")
+	require.Nil(t, err, fmt.Sprintf("unexpected error adding scope entry: %s", err))
+	assert.IsType(t, &auth.AnyIDs{}, scope.Domains[domainID].Entities[auth.DomainChannelsScope][auth.ReadOp])
+	err = repo.CheckScopeEntry(context.Background(), pat.User, pat.ID, auth.PlatformDomainsScope, domainID, auth.DomainChannelsScope, auth.ReadOp, "ch3")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error checking any IDs scope: %s", err))
+
+	scope, err = repo.RemoveScopeEntry(context.Background(), pat.User, pat.ID, auth.PlatformDomainsScope, domainID, auth.DomainChannelsScope, auth.ReadOp, "*")
+	require.Nil(t, err, fmt.Sprintf("unexpected error removing scope entry: %s", err))
+	assert.Empty(t, scope.Domains, "expected empty scope after removal")
+
+	_, err = repo.AddScopeEntry(context.Background(), pat.User, pat.ID, auth.PlatformUsersScope, "", auth.DomainNullScope, auth.ReadOp, "*")
+	require.Nil(t, err, fmt.Sprintf("unexpected error adding scope entry: %s", err))
+	require.Nil(t, repo.RemoveAllScopeEntry(context.Background(), pat.User, pat.ID), "unexpected error removing all scope entries")
+	p, err := repo.Retrieve(context.Background(), pat.User, pat.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error retrieving PAT: %s", err))
+	assert.Empty(t, p.Scope.Users, "expected empty users scope after removing all entries")
+}
+
+func TestPATExpireUnused(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+	userID := generateID(t)
+	now := time.Now()
+
+	unused := newPAT(t, userID, now.Add(-48*time.Hour))
+	unused.ExpiresAt = now.Add(time.Hour)
+	used := newPAT(t, userID, now.Add(-48*time.Hour))
+	used.ExpiresAt = now.Add(time.Hour)
+	for _, p := range []auth.PAT{unused, used} {
+		require.Nil(t, repo.Save(context.Background(), p), fmt.Sprintf("unexpected error saving PAT: %s", p.ID))
+	}
+	require.Nil(t, repo.UpdateLastUsedAt(context.Background(), userID, used.ID, now), "unexpected error updating last used at")
+
+	_, err := repo.ExpireUnused(context.Background(), now.Add(-24*time.Hour))
+	require.Nil(t, err, fmt.Sprintf("unexpected error expiring unused PATs: %s", err))
+
+	_, _, expired, err := repo.RetrieveSecretAndRevokeStatus(context.Background(), userID, unused.ID)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, expired, "expected unused PAT to be expired")
+	_, _, expired, err = repo.RetrieveSecretAndRevokeStatus(context.Background(), userID, used.ID)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.False(t, expired, "expected used PAT not to be expired")
+}
@@ -0,0 +1,176 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+)
+
+const sessionColumns = `id, user_id, device, ip, issued_at, last_refresh, expires_at, revoked`
+
+var _ auth.SessionRepository = (*sessionRepository)(nil)
+
+type sessionRepository struct {
+	db postgres.Database
+}
+
+// NewSessionRepository instantiates a PostgreSQL implementation of session repository.
+func NewSessionRepository(db postgres.Database) auth.SessionRepository {
+	return &sessionRepository{
+		db: db,
+	}
+}
+
+func (sr *sessionRepository) Save(ctx context.Context, session auth.Session) error {
+	q := `INSERT INTO sessions (id, user_id, device, ip, issued_at, last_refresh, expires_at, revoked)
+		VALUES (:id, :user_id, :device, :ip, :issued_at, :last_refresh, :expires_at, :revoked)`
+
+	if _, err := sr.db.NamedExecContext(ctx, q, toDBSession(session)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (sr *sessionRepository) Retrieve(ctx context.Context, id string) (auth.Session, error) {
+	q := `SELECT ` + sessionColumns + ` FROM sessions WHERE id = $1`
+
+	var dbs dbSession
+	if err := sr.db.QueryRowxContext(ctx, q, id).StructScan(&dbs); err != nil {
+		if err == sql.ErrNoRows {
+			return auth.Session{}, repoerr.ErrNotFound
+		}
+		return auth.Session{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toSession(dbs), nil
+}
+
+func (sr *sessionRepository) RetrieveAll(ctx context.Context, pm auth.SessionsPageMeta) (auth.SessionsPage, error) {
+	query := `FROM sessions WHERE user_id = :user_id AND revoked = FALSE AND expires_at > (NOW() AT TIME ZONE 'UTC')`
+	q := `SELECT ` + sessionColumns + ` ` + query + ` ORDER BY issued_at DESC LIMIT :limit OFFSET :offset`
+
+	rows, err := sr.db.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return auth.SessionsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	sessions := []auth.Session{}
+	for rows.Next() {
+		var dbs dbSession
+		if err := rows.StructScan(&dbs); err != nil {
+			return auth.SessionsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		sessions = append(sessions, toSession(dbs))
+	}
+
+	total, err := postgres.Total(ctx, sr.db, `SELECT COUNT(*) `+query, pm)
+	if err != nil {
+		return auth.SessionsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return auth.SessionsPage{
+		Total:    total,
+		Offset:   pm.Offset,
+		Limit:    pm.Limit,
+		Sessions: sessions,
+	}, nil
+}
+
+func (sr *sessionRepository) UpdateLastRefresh(ctx context.Context, id string, lastRefresh, expiresAt time.Time) error {
+	q := `UPDATE sessions SET last_refresh = $2, expires_at = $3 WHERE id = $1`
+
+	result, err := sr.db.ExecContext(ctx, q, id, lastRefresh, expiresAt)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (sr *sessionRepository) Revoke(ctx context.Context, userID, id string) error {
+	q := `UPDATE sessions SET revoked = TRUE WHERE user_id = $1 AND id = $2`
+
+	result, err := sr.db.ExecContext(ctx, q, userID, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (sr *sessionRepository) RevokeAll(ctx context.Context, userID string) error {
+	q := `UPDATE sessions SET revoked = TRUE WHERE user_id = $1 AND revoked = FALSE`
+
+	if _, err := sr.db.ExecContext(ctx, q, userID); err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (sr *sessionRepository) RemoveExpired(ctx context.Context, before time.Time) (uint64, error) {
+	q := `DELETE FROM sessions WHERE expires_at < $1`
+
+	result, err := sr.db.ExecContext(ctx, q, before)
+	if err != nil {
+		return 0, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return uint64(rows), nil
+}
+
+type dbSession struct {
+	ID          string         `db:"id"`
+	UserID      string         `db:"user_id"`
+	Device      sql.NullString `db:"device"`
+	IP          sql.NullString `db:"ip"`
+	IssuedAt    time.Time      `db:"issued_at"`
+	LastRefresh sql.NullTime   `db:"last_refresh"`
+	ExpiresAt   time.Time      `db:"expires_at"`
+	Revoked     bool           `db:"revoked"`
+}
+
+func toDBSession(s auth.Session) dbSession {
+	return dbSession{
+		ID:          s.ID,
+		UserID:      s.UserID,
+		Device:      sql.NullString{String: s.Device, Valid: s.Device != ""},
+		IP:          sql.NullString{String: s.IP, Valid: s.IP != ""},
+		IssuedAt:    s.IssuedAt,
+		LastRefresh: sql.NullTime{Time: s.LastRefresh, Valid: !s.LastRefresh.IsZero()},
+		ExpiresAt:   s.ExpiresAt,
+		Revoked:     s.Revoked,
+	}
+}
+
+func toSession(dbs dbSession) auth.Session {
+	return auth.Session{
+		ID:          dbs.ID,
+		UserID:      dbs.UserID,
+		Device:      dbs.Device.String,
+		IP:          dbs.IP.String,
+		IssuedAt:    dbs.IssuedAt.UTC(),
+		LastRefresh: dbs.LastRefresh.Time.UTC(),
+		ExpiresAt:   dbs.ExpiresAt.UTC(),
+		Revoked:     dbs.Revoked,
+	}
+}
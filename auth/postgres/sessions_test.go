@@ -0,0 +1,112 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/auth/postgres"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSession(t *testing.T, userID string) auth.Session {
+	return auth.Session{
+		ID:        generateID(t),
+		UserID:    userID,
+		Device:    "Mozilla/5.0",
+		IP:        "127.0.0.1",
+		IssuedAt:  time.Now().UTC().Round(time.Millisecond),
+		ExpiresAt: time.Now().UTC().Add(time.Hour).Round(time.Millisecond),
+	}
+}
+
+func TestSessionSave(t *testing.T) {
+	repo := postgres.NewSessionRepository(database)
+	session := newSession(t, generateID(t))
+
+	cases := []struct {
+		desc    string
+		session auth.Session
+		err     error
+	}{
+		{
+			desc:    "save a new session",
+			session: session,
+			err:     nil,
+		},
+		{
+			desc:    "save session with duplicate id",
+			session: session,
+			err:     repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.session)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestSessionRevoke(t *testing.T) {
+	repo := postgres.NewSessionRepository(database)
+	userID := generateID(t)
+	first := newSession(t, userID)
+	second := newSession(t, userID)
+	for _, s := range []auth.Session{first, second} {
+		require.Nil(t, repo.Save(context.Background(), s), fmt.Sprintf("unexpected error saving session: %s", s.ID))
+	}
+
+	err := repo.UpdateLastRefresh(context.Background(), first.ID, time.Now().UTC(), first.ExpiresAt.Add(time.Hour))
+	assert.Nil(t, err, fmt.Sprintf("update last refresh: unexpected error %s", err))
+
+	page, err := repo.RetrieveAll(context.Background(), auth.SessionsPageMeta{UserID: userID, Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("retrieve sessions: unexpected error %s", err))
+	assert.Equal(t, uint64(2), page.Total)
+
+	err = repo.Revoke(context.Background(), generateID(t), first.ID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("revoke session of another user: expected %s got %s", repoerr.ErrNotFound, err))
+
+	err = repo.Revoke(context.Background(), userID, first.ID)
+	assert.Nil(t, err, fmt.Sprintf("revoke session: unexpected error %s", err))
+	s, err := repo.Retrieve(context.Background(), first.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve session: unexpected error %s", err))
+	assert.True(t, s.Revoked, "revoked session expected to be marked as revoked")
+
+	page, err = repo.RetrieveAll(context.Background(), auth.SessionsPageMeta{UserID: userID, Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("retrieve sessions: unexpected error %s", err))
+	assert.Equal(t, uint64(1), page.Total)
+
+	err = repo.RevokeAll(context.Background(), userID)
+	assert.Nil(t, err, fmt.Sprintf("revoke all sessions: unexpected error %s", err))
+	s, err = repo.Retrieve(context.Background(), second.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve session: unexpected error %s", err))
+	assert.True(t, s.Revoked, "revoked session expected to be marked as revoked")
+}
+
+func TestSessionRemoveExpired(t *testing.T) {
+	repo := postgres.NewSessionRepository(database)
+	userID := generateID(t)
+	active := newSession(t, userID)
+	expired := newSession(t, userID)
+	expired.ExpiresAt = time.Now().UTC().Add(-time.Hour).Round(time.Millisecond)
+	for _, s := range []auth.Session{active, expired} {
+		require.Nil(t, repo.Save(context.Background(), s), fmt.Sprintf("unexpected error saving session: %s", s.ID))
+	}
+
+	removed, err := repo.RemoveExpired(context.Background(), time.Now().UTC())
+	assert.Nil(t, err, fmt.Sprintf("remove expired sessions: unexpected error %s", err))
+	assert.GreaterOrEqual(t, removed, uint64(1))
+
+	_, err = repo.Retrieve(context.Background(), expired.ID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve expired session: expected %s got %s", repoerr.ErrNotFound, err))
+	_, err = repo.Retrieve(context.Background(), active.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve active session: unexpected error %s", err))
+}
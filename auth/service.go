@@ -46,6 +46,15 @@ var (
 	errDeletePAT           = errors.New("failed to delete PAT")
 	errRevokePAT           = errors.New("failed to revoke PAT")
 	errClearAllScope       = errors.New("failed to clear all entry in scope")
+	errCreateSession       = errors.New("failed to create session")
+	errRetrieveSessions    = errors.New("failed to retrieve sessions")
+	errRevokeSession       = errors.New("failed to revoke session")
+	errPurgeSessions       = errors.New("failed to purge expired sessions")
+	errRecordPATUsage      = errors.New("failed to record PAT usage")
+	errExpirePATs          = errors.New("failed to expire unused PATs")
+
+	// ErrRevokedSession indicates that the session of the token is revoked.
+	ErrRevokedSession = errors.New("session is revoked")
 )
 
 // Authz represents a authorization service. It exposes
@@ -81,6 +90,10 @@ type Authn interface {
 	// is returned. If token is invalid, or invocation failed for some
 	// other reason, non-nil error value is returned in response.
 	Identify(ctx context.Context, token string) (Key, error)
+
+	// RetrieveJWKS returns the public keys used to verify the tokens
+	// issued by the service, so that the tokens can be validated offline.
+	RetrieveJWKS(ctx context.Context) []PublicKeyInfo
 }
 
 // Service specifies an API that must be fulfilled by the domain service
@@ -93,6 +106,7 @@ type Service interface {
 	Authn
 	Authz
 	PATS
+	Sessions
 }
 
 var _ Service = (*service)(nil)
@@ -100,6 +114,10 @@ var _ Service = (*service)(nil)
 type service struct {
 	keys               KeyRepository
 	pats               PATSRepository
+	sessions           SessionRepository
+	patUsage           PATUsageRepository
+	patNotifier        PATNotifier
+	revokedUse         *noticeThrottle
 	hasher             Hasher
 	idProvider         supermq.IDProvider
 	evaluator          policies.Evaluator
@@ -111,11 +129,15 @@ type service struct {
 }
 
 // New instantiates the auth service implementation.
-func New(keys KeyRepository, pats PATSRepository, hasher Hasher, idp supermq.IDProvider, tokenizer Tokenizer, policyEvaluator policies.Evaluator, policyService policies.Service, loginDuration, refreshDuration, invitationDuration time.Duration) Service {
+func New(keys KeyRepository, pats PATSRepository, sessions SessionRepository, patUsage PATUsageRepository, patNotifier PATNotifier, hasher Hasher, idp supermq.IDProvider, tokenizer Tokenizer, policyEvaluator policies.Evaluator, policyService policies.Service, loginDuration, refreshDuration, invitationDuration time.Duration) Service {
 	return &service{
 		tokenizer:          tokenizer,
 		keys:               keys,
 		pats:               pats,
+		sessions:           sessions,
+		patUsage:           patUsage,
+		patNotifier:        patNotifier,
+		revokedUse:         newNoticeThrottle(revokedUseInterval),
 		hasher:             hasher,
 		idProvider:         idp,
 		evaluator:          policyEvaluator,
@@ -177,7 +199,12 @@ func (svc service) Identify(ctx context.Context, token string) (Key, error) {
 	}
 
 	switch key.Type {
-	case RecoveryKey, AccessKey, InvitationKey, RefreshKey:
+	case AccessKey, RefreshKey:
+		if err := svc.checkSession(ctx, key); err != nil {
+			return Key{}, err
+		}
+		return key, nil
+	case RecoveryKey, InvitationKey:
 		return key, nil
 	case APIKey:
 		_, err := svc.keys.Retrieve(ctx, key.Issuer, key.ID)
@@ -190,6 +217,10 @@ func (svc service) Identify(ctx context.Context, token string) (Key, error) {
 	}
 }
 
+func (svc service) RetrieveJWKS(ctx context.Context) []PublicKeyInfo {
+	return svc.tokenizer.RetrieveJWKS()
+}
+
 func (svc service) Authorize(ctx context.Context, pr policies.Policy) error {
 	if err := svc.PolicyValidation(pr); err != nil {
 		return errors.Wrap(svcerr.ErrMalformedEntity, err)
@@ -275,6 +306,23 @@ func (svc service) accessKey(ctx context.Context, key Key) (Token, error) {
 		return Token{}, errors.Wrap(svcerr.ErrAuthorization, err)
 	}
 
+	// The access and refresh tokens share the ID of the session they are issued for.
+	key.ID, err = svc.idProvider.ID()
+	if err != nil {
+		return Token{}, errors.Wrap(errCreateSession, err)
+	}
+	session := Session{
+		ID:        key.ID,
+		UserID:    key.User,
+		Device:    key.Device,
+		IP:        key.IP,
+		IssuedAt:  key.IssuedAt,
+		ExpiresAt: time.Now().UTC().Add(svc.refreshDuration),
+	}
+	if err := svc.sessions.Save(ctx, session); err != nil {
+		return Token{}, errors.Wrap(errCreateSession, err)
+	}
+
 	access, err := svc.tokenizer.Issue(key)
 	if err != nil {
 		return Token{}, errors.Wrap(errIssueTmp, err)
@@ -316,6 +364,9 @@ func (svc service) refreshKey(ctx context.Context, token string, key Key) (Token
 	if k.Type != RefreshKey {
 		return Token{}, errIssueUser
 	}
+	if err := svc.checkSession(ctx, k); err != nil {
+		return Token{}, errors.Wrap(errRetrieve, err)
+	}
 	key.ID = k.ID
 	if key.Domain == "" {
 		key.Domain = k.Domain
@@ -341,9 +392,33 @@ func (svc service) refreshKey(ctx context.Context, token string, key Key) (Token
 		return Token{}, errors.Wrap(errIssueTmp, err)
 	}
 
+	if key.ID != "" {
+		if err := svc.sessions.UpdateLastRefresh(ctx, key.ID, key.IssuedAt, key.ExpiresAt.UTC()); err != nil {
+			return Token{}, errors.Wrap(errIssueTmp, err)
+		}
+	}
+
 	return Token{AccessToken: access, RefreshToken: refresh}, nil
 }
 
+// checkSession verifies that the session of the access or refresh token
+// is not revoked. Tokens issued before the sessions were introduced carry
+// no session ID and remain valid until they expire.
+func (svc service) checkSession(ctx context.Context, key Key) error {
+	if key.ID == "" {
+		return nil
+	}
+	session, err := svc.sessions.Retrieve(ctx, key.ID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if session.Revoked {
+		return errors.Wrap(svcerr.ErrAuthentication, ErrRevokedSession)
+	}
+
+	return nil
+}
+
 func (svc service) checkUserDomain(ctx context.Context, key Key) (subject string, err error) {
 	if key.Domain != "" {
 		// Check user is platform admin.
@@ -462,6 +537,9 @@ func (svc service) CreatePAT(ctx context.Context, token, name, description strin
 	if err != nil {
 		return PAT{}, err
 	}
+	if err := scope.Validate(); err != nil {
+		return PAT{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
 
 	id, err := svc.idProvider.ID()
 	if err != nil {
@@ -588,6 +666,11 @@ func (svc service) AddPATScopeEntry(ctx context.Context, token, patID string, pl
 	if err != nil {
 		return Scope{}, err
 	}
+	if platformEntityType == PlatformDomainsScope {
+		if err := checkMessagingOperation(optionalDomainEntityType, operation); err != nil {
+			return Scope{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+	}
 	scope, err := svc.pats.AddScopeEntry(ctx, key.User, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
 	if err != nil {
 		return Scope{}, errors.Wrap(errRevokePAT, err)
@@ -618,7 +701,7 @@ func (svc service) ClearPATAllScopeEntry(ctx context.Context, token, patID strin
 	return nil
 }
 
-func (svc service) IdentifyPAT(ctx context.Context, secret string) (PAT, error) {
+func (svc service) IdentifyPAT(ctx context.Context, secret, ip string) (PAT, error) {
 	parts := strings.Split(secret, patSecretSeparator)
 	if len(parts) != 3 && parts[0] != patPrefix {
 		return PAT{}, errors.Wrap(svcerr.ErrAuthentication, errMalformedPAT)
@@ -631,29 +714,117 @@ func (svc service) IdentifyPAT(ctx context.Context, secret string) (PAT, error)
 	if err != nil {
 		return PAT{}, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
+	if err := svc.hasher.Compare(secret, secretHash); err != nil {
+		return PAT{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
 	if revoked {
+		svc.notifyRevokedUse(ctx, userID.String(), patID.String(), ip)
 		return PAT{}, errors.Wrap(svcerr.ErrAuthentication, errRevokedPAT)
 	}
 	if expired {
 		return PAT{}, errors.Wrap(svcerr.ErrAuthentication, ErrExpiry)
 	}
-	if err := svc.hasher.Compare(secret, secretHash); err != nil {
-		return PAT{}, errors.Wrap(svcerr.ErrAuthentication, err)
-	}
 	return PAT{ID: patID.String(), User: userID.String()}, nil
 }
 
-func (svc service) AuthorizePAT(ctx context.Context, userID, patID string, platformEntityType PlatformEntityType, optionalDomainID string, optionalDomainEntityType DomainEntityType, operation OperationType, entityIDs ...string) error {
+func (svc service) AuthorizePAT(ctx context.Context, userID, patID, ip string, platformEntityType PlatformEntityType, optionalDomainID string, optionalDomainEntityType DomainEntityType, operation OperationType, entityIDs ...string) error {
+	authzErr := svc.authorizePAT(ctx, userID, patID, ip, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
+
+	usage := PATUsage{
+		PATID:              patID,
+		UserID:             userID,
+		PlatformEntityType: platformEntityType,
+		DomainID:           optionalDomainID,
+		DomainEntityType:   optionalDomainEntityType,
+		Operation:          operation,
+		EntityIDs:          entityIDs,
+		IP:                 ip,
+		Allowed:            authzErr == nil,
+		CreatedAt:          time.Now().UTC(),
+	}
+	// The decision is denied if it can not be audited.
+	if err := svc.recordPATUsage(ctx, usage); err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+
+	return authzErr
+}
+
+func (svc service) authorizePAT(ctx context.Context, userID, patID, ip string, platformEntityType PlatformEntityType, optionalDomainID string, optionalDomainEntityType DomainEntityType, operation OperationType, entityIDs ...string) error {
 	res, err := svc.RetrievePAT(ctx, userID, patID)
 	if err != nil {
 		return err
 	}
+	if res.Revoked {
+		svc.notifyRevokedUse(ctx, userID, patID, ip)
+		return errors.Wrap(svcerr.ErrAuthentication, errRevokedPAT)
+	}
+	if res.Expired() {
+		return errors.Wrap(svcerr.ErrAuthentication, ErrExpiry)
+	}
 	if err := svc.pats.CheckScopeEntry(ctx, res.User, res.ID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...); err != nil {
 		return errors.Wrap(svcerr.ErrAuthorization, err)
 	}
 	return nil
 }
 
+func (svc service) recordPATUsage(ctx context.Context, usage PATUsage) error {
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return errors.Wrap(errRecordPATUsage, err)
+	}
+	usage.ID = id
+	if err := svc.patUsage.Save(ctx, usage); err != nil {
+		return errors.Wrap(errRecordPATUsage, err)
+	}
+	if !usage.Allowed {
+		return nil
+	}
+	if err := svc.pats.UpdateLastUsedAt(ctx, usage.UserID, usage.PATID, usage.CreatedAt); err != nil {
+		return errors.Wrap(errRecordPATUsage, err)
+	}
+	return nil
+}
+
+// notifyRevokedUse notifies the owner of the revoked PAT at most once per
+// revokedUseInterval. The notification is best effort and does not change
+// the outcome of the request.
+func (svc service) notifyRevokedUse(ctx context.Context, userID, patID, ip string) {
+	if !svc.revokedUse.allow(patID, time.Now()) {
+		return
+	}
+	pat, err := svc.pats.Retrieve(ctx, userID, patID)
+	if err != nil {
+		return
+	}
+	_ = svc.patNotifier.NotifyRevokedUse(ctx, pat, ip)
+}
+
+func (svc service) ListPATUsage(ctx context.Context, token, patID string, pm PATUsagePageMeta) (PATUsagePage, error) {
+	key, err := svc.Identify(ctx, token)
+	if err != nil {
+		return PATUsagePage{}, err
+	}
+	if _, err := svc.pats.Retrieve(ctx, key.User, patID); err != nil {
+		return PATUsagePage{}, errors.Wrap(errRetrievePAT, err)
+	}
+	pm.UserID = key.User
+	pm.PATID = patID
+	page, err := svc.patUsage.RetrieveAll(ctx, pm)
+	if err != nil {
+		return PATUsagePage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	return page, nil
+}
+
+func (svc service) ExpireUnusedPATs(ctx context.Context, unusedFor time.Duration) (uint64, error) {
+	expired, err := svc.pats.ExpireUnused(ctx, time.Now().Add(-unusedFor))
+	if err != nil {
+		return 0, errors.Wrap(errExpirePATs, err)
+	}
+	return expired, nil
+}
+
 func (svc service) CheckPAT(ctx context.Context, userID, patID string, platformEntityType PlatformEntityType, optionalDomainID string, optionalDomainEntityType DomainEntityType, operation OperationType, entityIDs ...string) error {
 	if err := svc.pats.CheckScopeEntry(ctx, userID, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...); err != nil {
 		return errors.Wrap(svcerr.ErrAuthorization, err)
@@ -707,3 +878,66 @@ func generateRandomString(n int) string {
 	}
 	return string(b)
 }
+
+func (svc service) ListSessions(ctx context.Context, token string, pm SessionsPageMeta) (SessionsPage, error) {
+	key, err := svc.identifyUser(ctx, token)
+	if err != nil {
+		return SessionsPage{}, err
+	}
+
+	pm.UserID = key.User
+	sp, err := svc.sessions.RetrieveAll(ctx, pm)
+	if err != nil {
+		return SessionsPage{}, errors.Wrap(errRetrieveSessions, err)
+	}
+	return sp, nil
+}
+
+func (svc service) RevokeSession(ctx context.Context, token, id string) error {
+	key, err := svc.identifyUser(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.sessions.Revoke(ctx, key.User, id); err != nil {
+		return errors.Wrap(errRevokeSession, err)
+	}
+	return nil
+}
+
+func (svc service) RevokeSessions(ctx context.Context, token string) error {
+	key, err := svc.identifyUser(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	return svc.RevokeUserSessions(ctx, key.User)
+}
+
+func (svc service) RevokeUserSessions(ctx context.Context, userID string) error {
+	if err := svc.sessions.RevokeAll(ctx, userID); err != nil {
+		return errors.Wrap(errRevokeSession, err)
+	}
+	return nil
+}
+
+func (svc service) PurgeExpiredSessions(ctx context.Context) (uint64, error) {
+	purged, err := svc.sessions.RemoveExpired(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, errors.Wrap(errPurgeSessions, err)
+	}
+	return purged, nil
+}
+
+// identifyUser identifies the user of the session access token.
+func (svc service) identifyUser(ctx context.Context, token string) (Key, error) {
+	key, err := svc.Identify(ctx, token)
+	if err != nil {
+		return Key{}, err
+	}
+	if key.Type != AccessKey || key.User == "" {
+		return Key{}, svcerr.ErrAuthentication
+	}
+
+	return key, nil
+}
@@ -50,6 +50,9 @@ var (
 	pService   *policymocks.Service
 	pEvaluator *policymocks.Evaluator
 	patsrepo   *mocks.PATSRepository
+	srepo      *mocks.SessionRepository
+	urepo      *mocks.PATUsageRepository
+	notifier   *mocks.PATNotifier
 	hasher     *mocks.Hasher
 )
 
@@ -58,6 +61,9 @@ func newService() (auth.Service, string) {
 	pService = new(policymocks.Service)
 	pEvaluator = new(policymocks.Evaluator)
 	patsrepo = new(mocks.PATSRepository)
+	srepo = new(mocks.SessionRepository)
+	urepo = new(mocks.PATUsageRepository)
+	notifier = new(mocks.PATNotifier)
 	hasher = new(mocks.Hasher)
 	idProvider := uuid.NewMock()
 
@@ -72,7 +78,7 @@ func newService() (auth.Service, string) {
 	}
 	token, _ := t.Issue(key)
 
-	return auth.New(krepo, patsrepo, hasher, idProvider, t, pEvaluator, pService, loginDuration, refreshDuration, invalidDuration), token
+	return auth.New(krepo, patsrepo, srepo, urepo, notifier, hasher, idProvider, t, pEvaluator, pService, loginDuration, refreshDuration, invalidDuration), token
 }
 
 func TestIssue(t *testing.T) {
@@ -136,6 +142,7 @@ func TestIssue(t *testing.T) {
 		checkPolicyErr         error
 		checkPolicyErr1        error
 		retreiveByIDErr        error
+		sessionErr             error
 		err                    error
 	}{
 		{
@@ -289,9 +296,31 @@ func TestIssue(t *testing.T) {
 			checkPolicyErr1: svcerr.ErrAuthorization,
 			err:             svcerr.ErrAuthorization,
 		},
+		{
+			desc: "issue access key with failed to save session",
+			key: auth.Key{
+				Type:     auth.AccessKey,
+				IssuedAt: time.Now(),
+			},
+			checkPolicyRequest: policies.Policy{
+				SubjectType: policies.UserType,
+				Object:      policies.SuperMQObject,
+				ObjectType:  policies.PlatformType,
+				Permission:  policies.AdminPermission,
+			},
+			checkDomainPolicyReq: policies.Policy{
+				SubjectType: policies.UserType,
+				ObjectType:  policies.DomainType,
+				Permission:  policies.MembershipPermission,
+			},
+			token:      accessToken,
+			sessionErr: repoerr.ErrCreateEntity,
+			err:        repoerr.ErrCreateEntity,
+		},
 	}
 	for _, tc := range cases2 {
 		repoCall := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, tc.saveErr)
+		sessionCall := srepo.On("Save", mock.Anything, mock.Anything).Return(tc.sessionErr)
 		repoCall1 := pEvaluator.On("CheckPolicy", mock.Anything, tc.checkPolicyRequest).Return(tc.checkPolicyErr)
 		repoCall2 := pEvaluator.On("CheckPolicy", mock.Anything, tc.checkPlatformPolicyReq).Return(tc.checkPolicyErr1)
 		repoCall4 := pEvaluator.On("CheckPolicy", mock.Anything, tc.checkDomainPolicyReq).Return(tc.checkPolicyErr)
@@ -301,6 +330,7 @@ func TestIssue(t *testing.T) {
 		repoCall1.Unset()
 		repoCall2.Unset()
 		repoCall4.Unset()
+		sessionCall.Unset()
 	}
 
 	cases3 := []struct {
@@ -616,6 +646,8 @@ func TestIssue(t *testing.T) {
 
 func TestRevoke(t *testing.T) {
 	svc, _ := newService()
+	srepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	srepo.On("Retrieve", mock.Anything, mock.Anything).Return(auth.Session{}, nil)
 	repocall := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, errIssueUser)
 	secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, IssuedAt: time.Now(), Subject: id})
 	repocall.Unset()
@@ -669,6 +701,8 @@ func TestRevoke(t *testing.T) {
 
 func TestRetrieve(t *testing.T) {
 	svc, _ := newService()
+	srepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	srepo.On("Retrieve", mock.Anything, mock.Anything).Return(auth.Session{}, nil)
 	repocall := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
 	secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, IssuedAt: time.Now(), Subject: id})
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
@@ -739,6 +773,8 @@ func TestRetrieve(t *testing.T) {
 
 func TestIdentify(t *testing.T) {
 	svc, _ := newService()
+	srepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	srepo.On("Retrieve", mock.Anything, mock.Anything).Return(auth.Session{}, nil)
 
 	repocall := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
 	repocall1 := pEvaluator.On("CheckPolicy", mock.Anything, mock.Anything).Return(nil)
@@ -844,6 +880,8 @@ func TestIdentify(t *testing.T) {
 
 func TestAuthorize(t *testing.T) {
 	svc, accessToken := newService()
+	srepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	srepo.On("Retrieve", mock.Anything, mock.Anything).Return(auth.Session{}, nil)
 
 	repocall := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
 	repocall1 := pEvaluator.On("CheckPolicy", mock.Anything, mock.Anything).Return(nil)
@@ -1121,6 +1159,383 @@ func TestAuthorize(t *testing.T) {
 	}
 }
 
+func TestListSessions(t *testing.T) {
+	svc, accessToken := newService()
+
+	cases := []struct {
+		desc    string
+		token   string
+		pm      auth.SessionsPageMeta
+		page    auth.SessionsPage
+		repoErr error
+		err     error
+	}{
+		{
+			desc:  "list sessions successfully",
+			token: accessToken,
+			pm:    auth.SessionsPageMeta{Limit: 10},
+			page: auth.SessionsPage{
+				Total:    1,
+				Limit:    10,
+				Sessions: []auth.Session{{ID: validID, UserID: userID}},
+			},
+		},
+		{
+			desc:  "list sessions with invalid token",
+			token: inValidToken,
+			pm:    auth.SessionsPageMeta{Limit: 10},
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "list sessions with failed to retrieve sessions",
+			token:   accessToken,
+			pm:      auth.SessionsPageMeta{Limit: 10},
+			repoErr: repoerr.ErrViewEntity,
+			err:     repoerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		pm := tc.pm
+		pm.UserID = userID
+		repoCall := srepo.On("RetrieveAll", mock.Anything, pm).Return(tc.page, tc.repoErr)
+		page, err := svc.ListSessions(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.page, page, fmt.Sprintf("%s expected %v got %v\n", tc.desc, tc.page, page))
+		repoCall.Unset()
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	svc, accessToken := newService()
+
+	cases := []struct {
+		desc    string
+		token   string
+		id      string
+		repoErr error
+		err     error
+	}{
+		{
+			desc:  "revoke session successfully",
+			token: accessToken,
+			id:    validID,
+		},
+		{
+			desc:  "revoke session with invalid token",
+			token: inValidToken,
+			id:    validID,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "revoke non-existing session",
+			token:   accessToken,
+			id:      validID,
+			repoErr: repoerr.ErrNotFound,
+			err:     repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := srepo.On("Revoke", mock.Anything, userID, tc.id).Return(tc.repoErr)
+		err := svc.RevokeSession(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	svc, accessToken := newService()
+
+	cases := []struct {
+		desc    string
+		token   string
+		repoErr error
+		err     error
+	}{
+		{
+			desc:  "revoke all sessions successfully",
+			token: accessToken,
+		},
+		{
+			desc:  "revoke all sessions with invalid token",
+			token: inValidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "revoke all sessions with failed to revoke",
+			token:   accessToken,
+			repoErr: repoerr.ErrUpdateEntity,
+			err:     repoerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := srepo.On("RevokeAll", mock.Anything, userID).Return(tc.repoErr)
+		err := svc.RevokeSessions(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+	}
+}
+
+func TestPurgeExpiredSessions(t *testing.T) {
+	svc, _ := newService()
+
+	cases := []struct {
+		desc    string
+		purged  uint64
+		repoErr error
+		err     error
+	}{
+		{
+			desc:   "purge expired sessions successfully",
+			purged: 2,
+		},
+		{
+			desc:    "purge expired sessions with failed to remove",
+			repoErr: repoerr.ErrRemoveEntity,
+			err:     repoerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := srepo.On("RemoveExpired", mock.Anything, mock.Anything).Return(tc.purged, tc.repoErr)
+		purged, err := svc.PurgeExpiredSessions(context.Background())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.purged, purged, fmt.Sprintf("%s expected %d got %d\n", tc.desc, tc.purged, purged))
+		repoCall.Unset()
+	}
+}
+
+func TestIdentifySession(t *testing.T) {
+	svc, _ := newService()
+
+	te := jwt.New([]byte(secret))
+	sessionToken, err := te.Issue(auth.Key{
+		ID:        validID,
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(loginDuration),
+		Subject:   id,
+		Type:      auth.AccessKey,
+		User:      userID,
+	})
+	assert.Nil(t, err, fmt.Sprintf("issuing session token expected to succeed: %s", err))
+
+	cases := []struct {
+		desc    string
+		session auth.Session
+		repoErr error
+		err     error
+	}{
+		{
+			desc:    "identify token of active session",
+			session: auth.Session{ID: validID, UserID: userID},
+		},
+		{
+			desc:    "identify token of revoked session",
+			session: auth.Session{ID: validID, UserID: userID, Revoked: true},
+			err:     auth.ErrRevokedSession,
+		},
+		{
+			desc:    "identify token of non-existing session",
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := srepo.On("Retrieve", mock.Anything, validID).Return(tc.session, tc.repoErr)
+		_, err := svc.Identify(context.Background(), sessionToken)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+	}
+}
+
+func TestAuthorizePAT(t *testing.T) {
+	svc, _ := newService()
+
+	patID := testsutil.GenerateUUID(t)
+	entityID := testsutil.GenerateUUID(t)
+	ip := "192.168.0.1"
+	activePAT := auth.PAT{ID: patID, User: userID, Name: "pat", ExpiresAt: time.Now().Add(time.Hour)}
+	revokedPAT := auth.PAT{ID: patID, User: userID, Name: "pat", ExpiresAt: time.Now().Add(time.Hour), Revoked: true}
+	expiredPAT := auth.PAT{ID: patID, User: userID, Name: "pat", ExpiresAt: time.Now().Add(-time.Hour)}
+
+	cases := []struct {
+		desc        string
+		pat         auth.PAT
+		retrieveErr error
+		scopeErr    error
+		allowed     bool
+		saveErr     error
+		notify      bool
+		err         error
+	}{
+		{
+			desc:    "authorize PAT successfully",
+			pat:     activePAT,
+			allowed: true,
+		},
+		{
+			desc:     "authorize PAT with missing scope",
+			pat:      activePAT,
+			scopeErr: repoerr.ErrNotFound,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "authorize revoked PAT",
+			pat:    revokedPAT,
+			notify: true,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc: "authorize revoked PAT within the notification interval",
+			pat:  revokedPAT,
+			err:  svcerr.ErrAuthentication,
+		},
+		{
+			desc: "authorize expired PAT",
+			pat:  expiredPAT,
+			err:  svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "authorize non-existing PAT",
+			retrieveErr: repoerr.ErrNotFound,
+			err:         repoerr.ErrNotFound,
+		},
+		{
+			desc:    "authorize PAT with failed to record usage",
+			pat:     activePAT,
+			allowed: true,
+			saveErr: repoerr.ErrCreateEntity,
+			err:     svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		retrieveCall := patsrepo.On("Retrieve", mock.Anything, userID, patID).Return(tc.pat, tc.retrieveErr)
+		scopeCall := patsrepo.On("CheckScopeEntry", mock.Anything, userID, patID, auth.PlatformDomainsScope, domainID, auth.DomainClientsScope, auth.ReadOp, entityID).Return(tc.scopeErr)
+		var usage auth.PATUsage
+		saveCall := urepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			usage = args.Get(1).(auth.PATUsage)
+		}).Return(tc.saveErr)
+		lastUsedCall := patsrepo.On("UpdateLastUsedAt", mock.Anything, userID, patID, mock.Anything).Return(nil)
+		notifyCall := notifier.On("NotifyRevokedUse", mock.Anything, tc.pat, ip).Return(nil)
+		notifier.Calls = nil
+		err := svc.AuthorizePAT(context.Background(), userID, patID, ip, auth.PlatformDomainsScope, domainID, auth.DomainClientsScope, auth.ReadOp, entityID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.allowed, usage.Allowed, fmt.Sprintf("%s: expected recorded decision %t got %t\n", tc.desc, tc.allowed, usage.Allowed))
+		assert.Equal(t, ip, usage.IP, fmt.Sprintf("%s: expected recorded ip %s got %s\n", tc.desc, ip, usage.IP))
+		if tc.saveErr == nil && tc.allowed {
+			ok := patsrepo.AssertCalled(t, "UpdateLastUsedAt", mock.Anything, userID, patID, mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("%s: expected last used time to be updated\n", tc.desc))
+		}
+		if tc.notify {
+			ok := notifier.AssertCalled(t, "NotifyRevokedUse", mock.Anything, tc.pat, ip)
+			assert.True(t, ok, fmt.Sprintf("%s: expected owner to be notified\n", tc.desc))
+		} else {
+			ok := notifier.AssertNotCalled(t, "NotifyRevokedUse", mock.Anything, mock.Anything, mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("%s: expected owner not to be notified\n", tc.desc))
+		}
+		retrieveCall.Unset()
+		scopeCall.Unset()
+		saveCall.Unset()
+		lastUsedCall.Unset()
+		notifyCall.Unset()
+	}
+}
+
+func TestListPATUsage(t *testing.T) {
+	svc, accessToken := newService()
+
+	patID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc        string
+		token       string
+		pm          auth.PATUsagePageMeta
+		page        auth.PATUsagePage
+		retrieveErr error
+		repoErr     error
+		err         error
+	}{
+		{
+			desc:  "list PAT usage successfully",
+			token: accessToken,
+			pm:    auth.PATUsagePageMeta{Limit: 10},
+			page: auth.PATUsagePage{
+				Total: 1,
+				Limit: 10,
+				Usage: []auth.PATUsage{{ID: validID, PATID: patID, UserID: userID, Allowed: true}},
+			},
+		},
+		{
+			desc:  "list PAT usage with invalid token",
+			token: inValidToken,
+			pm:    auth.PATUsagePageMeta{Limit: 10},
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "list usage of PAT of another user",
+			token:       accessToken,
+			pm:          auth.PATUsagePageMeta{Limit: 10},
+			retrieveErr: repoerr.ErrNotFound,
+			err:         repoerr.ErrNotFound,
+		},
+		{
+			desc:    "list PAT usage with failed to retrieve usage",
+			token:   accessToken,
+			pm:      auth.PATUsagePageMeta{Limit: 10},
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		pm := tc.pm
+		pm.UserID = userID
+		pm.PATID = patID
+		retrieveCall := patsrepo.On("Retrieve", mock.Anything, userID, patID).Return(auth.PAT{ID: patID, User: userID}, tc.retrieveErr)
+		repoCall := urepo.On("RetrieveAll", mock.Anything, pm).Return(tc.page, tc.repoErr)
+		page, err := svc.ListPATUsage(context.Background(), tc.token, patID, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.page, page, fmt.Sprintf("%s expected %v got %v\n", tc.desc, tc.page, page))
+		retrieveCall.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestExpireUnusedPATs(t *testing.T) {
+	svc, _ := newService()
+
+	unusedFor := 30 * 24 * time.Hour
+
+	cases := []struct {
+		desc    string
+		expired uint64
+		repoErr error
+		err     error
+	}{
+		{
+			desc:    "expire unused PATs successfully",
+			expired: 2,
+		},
+		{
+			desc:    "expire unused PATs with failed to update PATs",
+			repoErr: repoerr.ErrUpdateEntity,
+			err:     repoerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := patsrepo.On("ExpireUnused", mock.Anything, mock.Anything).Return(tc.expired, tc.repoErr)
+		expired, err := svc.ExpireUnusedPATs(context.Background(), unusedFor)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.expired, expired, fmt.Sprintf("%s expected %d got %d\n", tc.desc, tc.expired, expired))
+		repoCall.Unset()
+	}
+}
+
 func TestSwitchToPermission(t *testing.T) {
 	cases := []struct {
 		desc     string
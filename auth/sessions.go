@@ -0,0 +1,85 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Session represents the user login session. The session is created on
+// login and identified by the ID of the access and refresh tokens issued
+// for it, so revoking the session invalidates all of its tokens.
+type Session struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	Device      string    `json:"device,omitempty"`
+	IP          string    `json:"ip,omitempty"`
+	IssuedAt    time.Time `json:"issued_at"`
+	LastRefresh time.Time `json:"last_refresh,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Revoked     bool      `json:"revoked"`
+}
+
+// SessionsPageMeta contains page metadata that helps navigation.
+type SessionsPageMeta struct {
+	Offset uint64 `json:"offset" db:"offset"`
+	Limit  uint64 `json:"limit" db:"limit"`
+	UserID string `json:"user_id" db:"user_id"`
+}
+
+// SessionsPage contains page related metadata as well as list of sessions.
+type SessionsPage struct {
+	Total    uint64    `json:"total"`
+	Offset   uint64    `json:"offset"`
+	Limit    uint64    `json:"limit"`
+	Sessions []Session `json:"sessions"`
+}
+
+// Sessions specifies the API for managing the user login sessions.
+type Sessions interface {
+	// ListSessions lists the active sessions of the user identified by the token.
+	ListSessions(ctx context.Context, token string, pm SessionsPageMeta) (SessionsPage, error)
+
+	// RevokeSession revokes the session of the user identified by the token.
+	RevokeSession(ctx context.Context, token, id string) error
+
+	// RevokeSessions revokes all the sessions of the user identified by the token.
+	RevokeSessions(ctx context.Context, token string) error
+
+	// RevokeUserSessions revokes all the sessions of the user with the given ID.
+	RevokeUserSessions(ctx context.Context, userID string) error
+
+	// PurgeExpiredSessions removes the expired sessions and returns their count.
+	PurgeExpiredSessions(ctx context.Context) (uint64, error)
+}
+
+// SessionRepository specifies session persistence API. Revoked sessions
+// are kept until they expire and act as a denylist for their tokens, and
+// expired sessions are removed periodically.
+//
+//go:generate mockery --name SessionRepository --output=./mocks --filename sessions.go --quiet --note "Copyright (c) Abstract Machines"
+type SessionRepository interface {
+	// Save persists the session.
+	Save(ctx context.Context, session Session) error
+
+	// Retrieve retrieves the session by its ID.
+	Retrieve(ctx context.Context, id string) (Session, error)
+
+	// RetrieveAll retrieves the active sessions of the user.
+	RetrieveAll(ctx context.Context, pm SessionsPageMeta) (SessionsPage, error)
+
+	// UpdateLastRefresh updates the last refresh and the expiration time of the session.
+	UpdateLastRefresh(ctx context.Context, id string, lastRefresh, expiresAt time.Time) error
+
+	// Revoke revokes the session of the user.
+	Revoke(ctx context.Context, userID, id string) error
+
+	// RevokeAll revokes all the sessions of the user.
+	RevokeAll(ctx context.Context, userID string) error
+
+	// RemoveExpired removes the sessions that expired before the given time
+	// and returns their count.
+	RemoveExpired(ctx context.Context, before time.Time) (uint64, error)
+}
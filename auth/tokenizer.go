@@ -3,6 +3,24 @@
 
 package auth
 
+// PublicKeyInfo represents a public JSON Web Key used to verify the
+// tokens issued by the Auth service.
+type PublicKeyInfo struct {
+	KeyID     string `json:"kid"`
+	KeyType   string `json:"kty"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use,omitempty"`
+
+	// RSA keys.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC and OKP keys.
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
 // Tokenizer specifies API for encoding and decoding between string and Key.
 type Tokenizer interface {
 	// Issue converts API Key to its string representation.
@@ -10,4 +28,8 @@ type Tokenizer interface {
 
 	// Parse extracts API Key data from string token.
 	Parse(token string) (key Key, err error)
+
+	// RetrieveJWKS returns the public keys used to verify the issued tokens.
+	// Tokenizers signing with a shared secret return no keys.
+	RetrieveJWKS() []PublicKeyInfo
 }
@@ -61,6 +61,13 @@ func (tm *tracingMiddleware) Identify(ctx context.Context, token string) (auth.K
 	return tm.svc.Identify(ctx, token)
 }
 
+func (tm *tracingMiddleware) RetrieveJWKS(ctx context.Context) []auth.PublicKeyInfo {
+	ctx, span := tm.tracer.Start(ctx, "retrieve_jwks")
+	defer span.End()
+
+	return tm.svc.RetrieveJWKS(ctx)
+}
+
 func (tm *tracingMiddleware) Authorize(ctx context.Context, pr policies.Policy) error {
 	ctx, span := tm.tracer.Start(ctx, "authorize", trace.WithAttributes(
 		attribute.String("subject", pr.Subject),
@@ -122,6 +129,24 @@ func (tm *tracingMiddleware) ListPATS(ctx context.Context, token string, pm auth
 	return tm.svc.ListPATS(ctx, token, pm)
 }
 
+func (tm *tracingMiddleware) ListPATUsage(ctx context.Context, token, patID string, pm auth.PATUsagePageMeta) (auth.PATUsagePage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_pat_usage", trace.WithAttributes(
+		attribute.String("pat_id", patID),
+		attribute.Int64("limit", int64(pm.Limit)),
+		attribute.Int64("offset", int64(pm.Offset)),
+	))
+	defer span.End()
+	return tm.svc.ListPATUsage(ctx, token, patID, pm)
+}
+
+func (tm *tracingMiddleware) ExpireUnusedPATs(ctx context.Context, unusedFor time.Duration) (uint64, error) {
+	ctx, span := tm.tracer.Start(ctx, "expire_unused_pats", trace.WithAttributes(
+		attribute.String("unused_for", unusedFor.String()),
+	))
+	defer span.End()
+	return tm.svc.ExpireUnusedPATs(ctx, unusedFor)
+}
+
 func (tm *tracingMiddleware) DeletePAT(ctx context.Context, token, patID string) error {
 	ctx, span := tm.tracer.Start(ctx, "delete_pat", trace.WithAttributes(
 		attribute.String("pat_id", patID),
@@ -181,13 +206,13 @@ func (tm *tracingMiddleware) ClearPATAllScopeEntry(ctx context.Context, token, p
 	return tm.svc.ClearPATAllScopeEntry(ctx, token, patID)
 }
 
-func (tm *tracingMiddleware) IdentifyPAT(ctx context.Context, paToken string) (auth.PAT, error) {
+func (tm *tracingMiddleware) IdentifyPAT(ctx context.Context, paToken, ip string) (auth.PAT, error) {
 	ctx, span := tm.tracer.Start(ctx, "identity_pat")
 	defer span.End()
-	return tm.svc.IdentifyPAT(ctx, paToken)
+	return tm.svc.IdentifyPAT(ctx, paToken, ip)
 }
 
-func (tm *tracingMiddleware) AuthorizePAT(ctx context.Context, userID, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) error {
+func (tm *tracingMiddleware) AuthorizePAT(ctx context.Context, userID, patID, ip string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) error {
 	ctx, span := tm.tracer.Start(ctx, "authorize_pat", trace.WithAttributes(
 		attribute.String("pat_id", patID),
 		attribute.String("platform_entity", platformEntityType.String()),
@@ -195,9 +220,10 @@ func (tm *tracingMiddleware) AuthorizePAT(ctx context.Context, userID, patID str
 		attribute.String("optional_domain_entity", optionalDomainEntityType.String()),
 		attribute.String("operation", operation.String()),
 		attribute.StringSlice("entities", entityIDs),
+		attribute.String("ip", ip),
 	))
 	defer span.End()
-	return tm.svc.AuthorizePAT(ctx, userID, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
+	return tm.svc.AuthorizePAT(ctx, userID, patID, ip, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
 }
 
 func (tm *tracingMiddleware) CheckPAT(ctx context.Context, userID, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) error {
@@ -213,3 +239,40 @@ func (tm *tracingMiddleware) CheckPAT(ctx context.Context, userID, patID string,
 	defer span.End()
 	return tm.svc.CheckPAT(ctx, userID, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
 }
+
+func (tm *tracingMiddleware) ListSessions(ctx context.Context, token string, pm auth.SessionsPageMeta) (auth.SessionsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_sessions", trace.WithAttributes(
+		attribute.Int64("limit", int64(pm.Limit)),
+		attribute.Int64("offset", int64(pm.Offset)),
+	))
+	defer span.End()
+	return tm.svc.ListSessions(ctx, token, pm)
+}
+
+func (tm *tracingMiddleware) RevokeSession(ctx context.Context, token, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "revoke_session", trace.WithAttributes(
+		attribute.String("session_id", id),
+	))
+	defer span.End()
+	return tm.svc.RevokeSession(ctx, token, id)
+}
+
+func (tm *tracingMiddleware) RevokeSessions(ctx context.Context, token string) error {
+	ctx, span := tm.tracer.Start(ctx, "revoke_sessions")
+	defer span.End()
+	return tm.svc.RevokeSessions(ctx, token)
+}
+
+func (tm *tracingMiddleware) RevokeUserSessions(ctx context.Context, userID string) error {
+	ctx, span := tm.tracer.Start(ctx, "revoke_user_sessions", trace.WithAttributes(
+		attribute.String("user_id", userID),
+	))
+	defer span.End()
+	return tm.svc.RevokeUserSessions(ctx, userID)
+}
+
+func (tm *tracingMiddleware) PurgeExpiredSessions(ctx context.Context) (uint64, error) {
+	ctx, span := tm.tracer.Start(ctx, "purge_expired_sessions")
+	defer span.End()
+	return tm.svc.PurgeExpiredSessions(ctx)
+}
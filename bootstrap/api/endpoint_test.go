@@ -0,0 +1,653 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/bootstrap"
+	"github.com/absmach/supermq/bootstrap/api"
+	"github.com/absmach/supermq/bootstrap/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	validToken   = "valid"
+	invalidToken = "invalid"
+	contentType  = "application/json"
+	instanceID   = "5de9b29a-feb9-11ed-be56-0242ac120002"
+	externalID   = "external-id"
+	externalKey  = "external-key"
+	encKey       = "v7aT0HGxJxt2gULzr3RHwf4WIf6DusPp"
+)
+
+var (
+	validID   = testsutil.GenerateUUID(&testing.T{})
+	domainID  = testsutil.GenerateUUID(&testing.T{})
+	channelID = testsutil.GenerateUUID(&testing.T{})
+	session   = smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID}
+	config    = bootstrap.Config{
+		ClientID:     testsutil.GenerateUUID(&testing.T{}),
+		ClientSecret: testsutil.GenerateUUID(&testing.T{}),
+		DomainID:     domainID,
+		Name:         "config",
+		ExternalID:   externalID,
+		Channels:     []bootstrap.Channel{{ID: channelID}},
+		Content:      `{"server": "localhost"}`,
+	}
+	configData = fmt.Sprintf(`{"name": "config", "external_id": "%s", "external_key": "%s", "channels": ["%s"]}`, externalID, externalKey, channelID)
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	token       string
+	key         string
+	contentType string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+
+	if tr.key != "" {
+		req.Header.Set("Authorization", apiutil.ClientPrefix+tr.key)
+	}
+
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+func newBootstrapServer(t *testing.T) (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	reader, err := bootstrap.NewConfigReader([]byte(encKey))
+	require.Nil(t, err, fmt.Sprintf("create config reader unexpected error: %s", err))
+	mux := api.MakeHandler(svc, reader, authn, smqlog.NewMock(), "bootstrap", instanceID)
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func TestAdd(t *testing.T) {
+	bs, svc, authn := newBootstrapServer(t)
+	defer bs.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		certTTL     string
+		authnRes    smqauthn.Session
+		authnErr    error
+		svcRes      bootstrap.Config
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "add config successfully",
+			token:       validToken,
+			data:        configData,
+			contentType: contentType,
+			authnRes:    session,
+			svcRes:      config,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "add config with certificate",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"external_id": "%s", "external_key": "%s", "cert_ttl": "24h"}`, externalID, externalKey),
+			contentType: contentType,
+			certTTL:     "24h",
+			authnRes:    session,
+			svcRes:      config,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "add config with invalid token",
+			token:       invalidToken,
+			data:        configData,
+			contentType: contentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "add config with empty token",
+			data:        configData,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "add config without external ID",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"external_key": "%s"}`, externalKey),
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add config without external key",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"external_id": "%s"}`, externalID),
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add config with too long name",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"name": "%s", "external_id": "%s", "external_key": "%s"}`, strings.Repeat("a", 1025), externalID, externalKey),
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add config with empty channel ID",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"external_id": "%s", "external_key": "%s", "channels": [""]}`, externalID, externalKey),
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add config with invalid content type",
+			token:       validToken,
+			data:        configData,
+			contentType: "text/plain",
+			authnRes:    session,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "add config with malformed body",
+			token:       validToken,
+			data:        `{"external_id": `,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add config with existing external ID",
+			token:       validToken,
+			data:        configData,
+			contentType: contentType,
+			authnRes:    session,
+			svcErr:      svcerr.ErrConflict,
+			status:      http.StatusConflict,
+		},
+		{
+			desc:        "add config with service error",
+			token:       validToken,
+			data:        configData,
+			contentType: contentType,
+			authnRes:    session,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("Add", mock.Anything, tc.authnRes, tc.token, mock.Anything, tc.certTTL).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client:      bs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/clients/configs/", bs.URL, domainID),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusCreated {
+				location := fmt.Sprintf("/%s/clients/configs/%s", tc.svcRes.DomainID, tc.svcRes.ClientID)
+				assert.Equal(t, location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, location, res.Header.Get("Location")))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	bs, svc, authn := newBootstrapServer(t)
+	defer bs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnRes smqauthn.Session
+		authnErr error
+		svcRes   bootstrap.Config
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "view config successfully",
+			token:    validToken,
+			id:       config.ClientID,
+			authnRes: session,
+			svcRes:   config,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "view config with invalid token",
+			token:    invalidToken,
+			id:       config.ClientID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "view non-existing config",
+			token:    validToken,
+			id:       validID,
+			authnRes: session,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+		{
+			desc:     "view config with service error",
+			token:    validToken,
+			id:       config.ClientID,
+			authnRes: session,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("View", mock.Anything, tc.authnRes, tc.id).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/clients/configs/%s", bs.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody bootstrap.Config
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.ClientID, resBody.ClientID, fmt.Sprintf("%s: expected config %s got %s", tc.desc, tc.svcRes.ClientID, resBody.ClientID))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	bs, svc, authn := newBootstrapServer(t)
+	defer bs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		authnRes smqauthn.Session
+		authnErr error
+		pm       bootstrap.PageMeta
+		svcRes   bootstrap.ConfigsPage
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list configs successfully",
+			token:    validToken,
+			authnRes: session,
+			pm:       bootstrap.PageMeta{Limit: 10},
+			svcRes:   bootstrap.ConfigsPage{Total: 1, Limit: 10, Configs: []bootstrap.Config{config}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list configs by name",
+			token:    validToken,
+			query:    "name=config",
+			authnRes: session,
+			pm:       bootstrap.PageMeta{Limit: 10, Name: "config"},
+			svcRes:   bootstrap.ConfigsPage{Total: 1, Limit: 10, Configs: []bootstrap.Config{config}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list configs with offset and limit",
+			token:    validToken,
+			query:    "offset=1&limit=5",
+			authnRes: session,
+			pm:       bootstrap.PageMeta{Offset: 1, Limit: 5},
+			svcRes:   bootstrap.ConfigsPage{Total: 1, Offset: 1, Limit: 5},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list configs with invalid token",
+			token:    invalidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list configs with invalid offset",
+			token:    validToken,
+			query:    "offset=invalid",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list configs with limit exceeding maximum",
+			token:    validToken,
+			query:    "limit=1000",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list configs with service error",
+			token:    validToken,
+			authnRes: session,
+			pm:       bootstrap.PageMeta{Limit: 10},
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("List", mock.Anything, tc.authnRes, tc.pm).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/clients/configs/?%s", bs.URL, domainID, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody bootstrap.ConfigsPage
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.Total, resBody.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.svcRes.Total, resBody.Total))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	bs, svc, authn := newBootstrapServer(t)
+	defer bs.Close()
+
+	updated := config
+	updated.Name = "updated"
+	updated.Content = "updated"
+
+	cases := []struct {
+		desc        string
+		token       string
+		id          string
+		data        string
+		contentType string
+		authnRes    smqauthn.Session
+		authnErr    error
+		cfg         bootstrap.Config
+		svcRes      bootstrap.Config
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "update config successfully",
+			token:       validToken,
+			id:          config.ClientID,
+			data:        `{"name": "updated", "content": "updated"}`,
+			contentType: contentType,
+			authnRes:    session,
+			cfg:         bootstrap.Config{ClientID: config.ClientID, Name: "updated", Content: "updated"},
+			svcRes:      updated,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update config with invalid token",
+			token:       invalidToken,
+			id:          config.ClientID,
+			data:        `{"name": "updated"}`,
+			contentType: contentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "update config with too long name",
+			token:       validToken,
+			id:          config.ClientID,
+			data:        fmt.Sprintf(`{"name": "%s"}`, strings.Repeat("a", 1025)),
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update config with invalid content type",
+			token:       validToken,
+			id:          config.ClientID,
+			data:        `{"name": "updated"}`,
+			contentType: "text/plain",
+			authnRes:    session,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "update config with malformed body",
+			token:       validToken,
+			id:          config.ClientID,
+			data:        `{"name": `,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update non-existing config",
+			token:       validToken,
+			id:          validID,
+			data:        `{"name": "updated"}`,
+			contentType: contentType,
+			authnRes:    session,
+			cfg:         bootstrap.Config{ClientID: validID, Name: "updated"},
+			svcErr:      svcerr.ErrNotFound,
+			status:      http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("Update", mock.Anything, tc.authnRes, tc.cfg).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client:      bs.Client(),
+				method:      http.MethodPut,
+				url:         fmt.Sprintf("%s/%s/clients/configs/%s", bs.URL, domainID, tc.id),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody bootstrap.Config
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.Name, resBody.Name, fmt.Sprintf("%s: expected name %s got %s", tc.desc, tc.svcRes.Name, resBody.Name))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	bs, svc, authn := newBootstrapServer(t)
+	defer bs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnRes smqauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "remove config successfully",
+			token:    validToken,
+			id:       config.ClientID,
+			authnRes: session,
+			status:   http.StatusNoContent,
+		},
+		{
+			desc:     "remove config with invalid token",
+			token:    invalidToken,
+			id:       config.ClientID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "remove non-existing config",
+			token:    validToken,
+			id:       validID,
+			authnRes: session,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+		{
+			desc:     "remove config with service error",
+			token:    validToken,
+			id:       config.ClientID,
+			authnRes: session,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("Remove", mock.Anything, tc.authnRes, tc.id).Return(tc.svcErr)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/%s/clients/configs/%s", bs.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestBootstrap(t *testing.T) {
+	bs, svc, _ := newBootstrapServer(t)
+	defer bs.Close()
+
+	cases := []struct {
+		desc        string
+		key         string
+		path        string
+		svcRes      bootstrap.Config
+		svcErr      error
+		status      int
+		contentType string
+	}{
+		{
+			desc:        "bootstrap successfully",
+			key:         externalKey,
+			path:        externalID,
+			svcRes:      config,
+			status:      http.StatusOK,
+			contentType: contentType,
+		},
+		{
+			desc:        "bootstrap securely",
+			key:         externalKey,
+			path:        "secure/" + externalID,
+			svcRes:      config,
+			status:      http.StatusOK,
+			contentType: "application/octet-stream",
+		},
+		{
+			desc:   "bootstrap without external key",
+			path:   externalID,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "bootstrap with invalid external key",
+			key:    "invalid",
+			path:   externalID,
+			svcErr: svcerr.ErrAuthentication,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "bootstrap non-existing config",
+			key:    externalKey,
+			path:   externalID,
+			svcErr: svcerr.ErrNotFound,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("Bootstrap", mock.Anything, tc.key, externalID).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/clients/bootstrap/%s", bs.URL, tc.path),
+				key:    tc.key,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				assert.True(t, strings.Contains(res.Header.Get("Content-Type"), tc.contentType), fmt.Sprintf("%s: expected content type %s got %s", tc.desc, tc.contentType, res.Header.Get("Content-Type")))
+			}
+			if tc.contentType == contentType {
+				var resBody bootstrap.DeviceConfig
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.ClientSecret, resBody.ClientSecret, fmt.Sprintf("%s: expected client secret %s got %s", tc.desc, tc.svcRes.ClientSecret, resBody.ClientSecret))
+			}
+			svcCall.Unset()
+		})
+	}
+}
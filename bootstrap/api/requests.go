@@ -0,0 +1,123 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/bootstrap"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+var (
+	errMissingExternalID  = errors.New("missing external id")
+	errMissingExternalKey = errors.New("missing external key")
+)
+
+type addReq struct {
+	token       string
+	ClientID    string   `json:"client_id,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	ExternalID  string   `json:"external_id"`
+	ExternalKey string   `json:"external_key"`
+	Channels    []string `json:"channels,omitempty"`
+	Content     string   `json:"content,omitempty"`
+	CertTTL     string   `json:"cert_ttl,omitempty"`
+}
+
+func (req addReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.ExternalID == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, errMissingExternalID)
+	}
+	if req.ExternalKey == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, errMissingExternalKey)
+	}
+	if len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	for _, ch := range req.Channels {
+		if ch == "" {
+			return apiutil.ErrMissingChannelID
+		}
+	}
+
+	return nil
+}
+
+func (req addReq) config() bootstrap.Config {
+	channels := make([]bootstrap.Channel, len(req.Channels))
+	for i, ch := range req.Channels {
+		channels[i] = bootstrap.Channel{ID: ch}
+	}
+
+	return bootstrap.Config{
+		ClientID:    req.ClientID,
+		Name:        req.Name,
+		ExternalID:  req.ExternalID,
+		ExternalKey: req.ExternalKey,
+		Channels:    channels,
+		Content:     req.Content,
+	}
+}
+
+type viewReq struct {
+	id string
+}
+
+func (req viewReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listReq struct {
+	pm bootstrap.PageMeta
+}
+
+func (req listReq) validate() error {
+	if req.pm.Limit > api.MaxLimitSize || req.pm.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type updateReq struct {
+	id      string
+	Name    string `json:"name,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+func (req updateReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
+type bootstrapReq struct {
+	key    string
+	id     string
+	secure bool
+}
+
+func (req bootstrapReq) validate() error {
+	if req.key == "" {
+		return apiutil.ErrBearerKey
+	}
+	if req.id == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, errMissingExternalID)
+	}
+
+	return nil
+}
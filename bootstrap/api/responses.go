@@ -0,0 +1,92 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/bootstrap"
+)
+
+var (
+	_ supermq.Response = (*configRes)(nil)
+	_ supermq.Response = (*configsPageRes)(nil)
+	_ supermq.Response = (*removeRes)(nil)
+	_ supermq.Response = (*bootstrapRes)(nil)
+)
+
+type configRes struct {
+	bootstrap.Config `json:",inline"`
+	created          bool
+}
+
+func (res configRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res configRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/%s/clients/configs/%s", res.DomainID, res.ClientID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res configRes) Empty() bool {
+	return false
+}
+
+type configsPageRes struct {
+	bootstrap.ConfigsPage `json:",inline"`
+}
+
+func (res configsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res configsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res configsPageRes) Empty() bool {
+	return false
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
+
+type bootstrapRes struct {
+	bootstrap.DeviceConfig `json:",inline"`
+}
+
+func (res bootstrapRes) Code() int {
+	return http.StatusOK
+}
+
+func (res bootstrapRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res bootstrapRes) Empty() bool {
+	return false
+}
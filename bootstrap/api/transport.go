@@ -0,0 +1,171 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/supermq"
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/bootstrap"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const byteContentType = "application/octet-stream"
+
+// MakeHandler returns a HTTP API handler with health check and metrics.
+func MakeHandler(svc bootstrap.Service, reader bootstrap.ConfigReader, authn smqauthn.Authentication, logger *slog.Logger, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Route("/{domainID}/clients/configs", func(r chi.Router) {
+		r.Use(api.AuthenticateMiddleware(authn, true))
+
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			addEndpoint(svc),
+			decodeAddReq,
+			api.EncodeResponse,
+			opts...,
+		), "add").ServeHTTP)
+
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listEndpoint(svc),
+			decodeListReq,
+			api.EncodeResponse,
+			opts...,
+		), "list").ServeHTTP)
+
+		r.Route("/{configID}", func(r chi.Router) {
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				viewEndpoint(svc),
+				decodeViewReq,
+				api.EncodeResponse,
+				opts...,
+			), "view").ServeHTTP)
+
+			r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+				updateEndpoint(svc),
+				decodeUpdateReq,
+				api.EncodeResponse,
+				opts...,
+			), "update").ServeHTTP)
+
+			r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+				removeEndpoint(svc),
+				decodeViewReq,
+				api.EncodeResponse,
+				opts...,
+			), "remove").ServeHTTP)
+		})
+	})
+
+	mux.Route("/clients/bootstrap", func(r chi.Router) {
+		r.Get("/{externalID}", otelhttp.NewHandler(kithttp.NewServer(
+			bootstrapEndpoint(svc, reader),
+			decodeBootstrapReq(false),
+			api.EncodeResponse,
+			opts...,
+		), "bootstrap").ServeHTTP)
+
+		r.Get("/secure/{externalID}", otelhttp.NewHandler(kithttp.NewServer(
+			bootstrapEndpoint(svc, reader),
+			decodeBootstrapReq(true),
+			encodeSecureRes,
+			opts...,
+		), "bootstrap_secure").ServeHTTP)
+	})
+
+	mux.Get("/health", supermq.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeAddReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := addReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeViewReq(_ context.Context, r *http.Request) (interface{}, error) {
+	return viewReq{id: chi.URLParam(r, "configID")}, nil
+}
+
+func decodeListReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	name, err := apiutil.ReadStringQuery(r, api.NameKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listReq{
+		pm: bootstrap.PageMeta{
+			Offset: offset,
+			Limit:  limit,
+			Name:   name,
+		},
+	}, nil
+}
+
+func decodeUpdateReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := updateReq{id: chi.URLParam(r, "configID")}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeBootstrapReq(secure bool) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		return bootstrapReq{
+			key:    apiutil.ExtractClientSecret(r),
+			id:     chi.URLParam(r, "externalID"),
+			secure: secure,
+		}, nil
+	}
+}
+
+func encodeSecureRes(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", byteContentType)
+	w.WriteHeader(http.StatusOK)
+	if b, ok := response.([]byte); ok {
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+var (
+	// ErrClients indicates failure to create, retrieve or connect the config client.
+	ErrClients = errors.New("failed to set up bootstrap client")
+
+	// ErrCerts indicates failure to issue the config client certificate.
+	ErrCerts = errors.New("failed to issue bootstrap client certificate")
+
+	// ErrExternalKey indicates that the external key doesn't match the config one.
+	ErrExternalKey = errors.New("invalid external key")
+)
+
+// Config represents the bootstrap configuration of a device. The config is
+// identified by the ID of the client it's bound to, and fetched by the device
+// using the external ID and key flashed to the device.
+type Config struct {
+	ClientID     string    `json:"client_id"`
+	ClientSecret string    `json:"client_secret,omitempty"`
+	DomainID     string    `json:"domain_id"`
+	Name         string    `json:"name,omitempty"`
+	ExternalID   string    `json:"external_id"`
+	ExternalKey  string    `json:"external_key,omitempty"`
+	Channels     []Channel `json:"channels,omitempty"`
+	Content      string    `json:"content,omitempty"`
+	ClientCert   string    `json:"client_cert,omitempty"`
+	ClientKey    string    `json:"client_key,omitempty"`
+	CreatedBy    string    `json:"created_by,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedBy    string    `json:"updated_by,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+}
+
+// Channel represents the channel the config client is connected to.
+type Channel struct {
+	ID       string                 `json:"id"`
+	Name     string                 `json:"name,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// ConfigsPage represents a page of configs.
+type ConfigsPage struct {
+	Total   uint64   `json:"total"`
+	Offset  uint64   `json:"offset"`
+	Limit   uint64   `json:"limit"`
+	Configs []Config `json:"configs"`
+}
+
+func (page ConfigsPage) MarshalJSON() ([]byte, error) {
+	type Alias ConfigsPage
+	a := struct {
+		Alias
+	}{
+		Alias: Alias(page),
+	}
+
+	if a.Configs == nil {
+		a.Configs = make([]Config, 0)
+	}
+
+	return json.Marshal(a)
+}
+
+// PageMeta is used to filter configs.
+type PageMeta struct {
+	Offset   uint64 `json:"offset" db:"offset"`
+	Limit    uint64 `json:"limit" db:"limit"`
+	DomainID string `json:"domain_id,omitempty" db:"domain_id"`
+	Name     string `json:"name,omitempty" db:"name"`
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// Add adds the config to the session domain. If the config has no client
+	// ID, a new client is created, otherwise the existing client is bound to
+	// the config. The client is connected to the config channels, and if the
+	// certificate TTL is set, the client certificate is issued. The token is
+	// used to call the clients, channels and certs services on behalf of the user.
+	Add(ctx context.Context, session smqauthn.Session, token string, cfg Config, certTTL string) (Config, error)
+
+	// View retrieves the config bound to the client with the given ID.
+	// The client secret and key and the external key are not returned.
+	View(ctx context.Context, session smqauthn.Session, id string) (Config, error)
+
+	// List retrieves the configs of the session domain filtered by the page
+	// metadata. The client secrets and keys and the external keys are not returned.
+	List(ctx context.Context, session smqauthn.Session, pm PageMeta) (ConfigsPage, error)
+
+	// Update updates the config name and content.
+	Update(ctx context.Context, session smqauthn.Session, cfg Config) (Config, error)
+
+	// Remove removes the config bound to the client with the given ID.
+	// The client and its connections are kept.
+	Remove(ctx context.Context, session smqauthn.Session, id string) error
+
+	// Bootstrap retrieves the config having the external ID if the external
+	// key matches the config one.
+	Bootstrap(ctx context.Context, externalKey, externalID string) (Config, error)
+}
+
+// ConfigRepository specifies a config persistence API.
+//
+//go:generate mockery --name ConfigRepository --output=./mocks --filename config_repository.go --quiet --note "Copyright (c) Abstract Machines"
+type ConfigRepository interface {
+	// Save persists the config.
+	Save(ctx context.Context, cfg Config) (Config, error)
+
+	// RetrieveByID retrieves the config bound to the client with the given ID.
+	RetrieveByID(ctx context.Context, id string) (Config, error)
+
+	// RetrieveByExternalID retrieves the config having the external ID.
+	RetrieveByExternalID(ctx context.Context, externalID string) (Config, error)
+
+	// RetrieveAll retrieves configs filtered by the page metadata.
+	RetrieveAll(ctx context.Context, pm PageMeta) (ConfigsPage, error)
+
+	// Update updates the config name and content.
+	Update(ctx context.Context, cfg Config) (Config, error)
+
+	// Remove removes the config bound to the client with the given ID.
+	Remove(ctx context.Context, id string) error
+}
@@ -0,0 +1,9 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bootstrap contains the bootstrap service.
+// This service stores the bootstrap configurations of the devices and
+// serves them to the devices identified by their external ID and key,
+// so that the devices can fetch their client credentials, channels and
+// certificates on the first boot.
+package bootstrap
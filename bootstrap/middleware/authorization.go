@@ -0,0 +1,98 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/bootstrap"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	"github.com/absmach/supermq/pkg/policies"
+)
+
+var (
+	_ bootstrap.Service = (*authorizationMiddleware)(nil)
+
+	readPermission   = "read_permission"
+	updatePermission = "update_permission"
+	deletePermission = "delete_permission"
+)
+
+type authorizationMiddleware struct {
+	svc   bootstrap.Service
+	authz smqauthz.Authorization
+}
+
+// AuthorizationMiddleware adds authorization to the bootstrap service.
+// Configs share the permissions of the clients they are bound to, while
+// listing the configs of the domain requires domain admin permission.
+// Adding a config is authorized by the clients, channels and certs
+// services called on behalf of the user.
+func AuthorizationMiddleware(svc bootstrap.Service, authz smqauthz.Authorization) bootstrap.Service {
+	return &authorizationMiddleware{
+		svc:   svc,
+		authz: authz,
+	}
+}
+
+func (am *authorizationMiddleware) Add(ctx context.Context, session smqauthn.Session, token string, cfg bootstrap.Config, certTTL string) (bootstrap.Config, error) {
+	return am.svc.Add(ctx, session, token, cfg, certTTL)
+}
+
+func (am *authorizationMiddleware) View(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Config, error) {
+	if err := am.authorizeClient(ctx, session, id, readPermission); err != nil {
+		return bootstrap.Config{}, err
+	}
+
+	return am.svc.View(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) List(ctx context.Context, session smqauthn.Session, pm bootstrap.PageMeta) (bootstrap.ConfigsPage, error) {
+	if err := am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  policies.AdminPermission,
+		ObjectType:  policies.DomainType,
+		Object:      session.DomainID,
+	}); err != nil {
+		return bootstrap.ConfigsPage{}, err
+	}
+
+	return am.svc.List(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) Update(ctx context.Context, session smqauthn.Session, cfg bootstrap.Config) (bootstrap.Config, error) {
+	if err := am.authorizeClient(ctx, session, cfg.ClientID, updatePermission); err != nil {
+		return bootstrap.Config{}, err
+	}
+
+	return am.svc.Update(ctx, session, cfg)
+}
+
+func (am *authorizationMiddleware) Remove(ctx context.Context, session smqauthn.Session, id string) error {
+	if err := am.authorizeClient(ctx, session, id, deletePermission); err != nil {
+		return err
+	}
+
+	return am.svc.Remove(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) Bootstrap(ctx context.Context, externalKey, externalID string) (bootstrap.Config, error) {
+	return am.svc.Bootstrap(ctx, externalKey, externalID)
+}
+
+func (am *authorizationMiddleware) authorizeClient(ctx context.Context, session smqauthn.Session, clientID, permission string) error {
+	return am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  permission,
+		ObjectType:  policies.ClientType,
+		Object:      clientID,
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/bootstrap"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+)
+
+var _ bootstrap.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    bootstrap.Service
+}
+
+// LoggingMiddleware adds logging facilities to the bootstrap service.
+func LoggingMiddleware(svc bootstrap.Service, logger *slog.Logger) bootstrap.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm *loggingMiddleware) Add(ctx context.Context, session smqauthn.Session, token string, cfg bootstrap.Config, certTTL string) (res bootstrap.Config, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("config",
+				slog.String("client_id", res.ClientID),
+				slog.String("external_id", cfg.ExternalID),
+				slog.Int("channels", len(cfg.Channels)),
+				slog.Bool("issue_cert", certTTL != ""),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Add bootstrap config failed", args...)
+			return
+		}
+		lm.logger.Info("Add bootstrap config completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Add(ctx, session, token, cfg, certTTL)
+}
+
+func (lm *loggingMiddleware) View(ctx context.Context, session smqauthn.Session, id string) (res bootstrap.Config, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("client_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View bootstrap config failed", args...)
+			return
+		}
+		lm.logger.Info("View bootstrap config completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.View(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) List(ctx context.Context, session smqauthn.Session, pm bootstrap.PageMeta) (res bootstrap.ConfigsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("page",
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", res.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List bootstrap configs failed", args...)
+			return
+		}
+		lm.logger.Info("List bootstrap configs completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.List(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) Update(ctx context.Context, session smqauthn.Session, cfg bootstrap.Config) (res bootstrap.Config, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("config",
+				slog.String("client_id", cfg.ClientID),
+				slog.String("name", cfg.Name),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update bootstrap config failed", args...)
+			return
+		}
+		lm.logger.Info("Update bootstrap config completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Update(ctx, session, cfg)
+}
+
+func (lm *loggingMiddleware) Remove(ctx context.Context, session smqauthn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("client_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove bootstrap config failed", args...)
+			return
+		}
+		lm.logger.Info("Remove bootstrap config completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Remove(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) Bootstrap(ctx context.Context, externalKey, externalID string) (res bootstrap.Config, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("external_id", externalID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Bootstrap failed", args...)
+			return
+		}
+		lm.logger.Info("Bootstrap completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Bootstrap(ctx, externalKey, externalID)
+}
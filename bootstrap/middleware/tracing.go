@@ -0,0 +1,86 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/bootstrap"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ bootstrap.Service = (*tracing)(nil)
+
+type tracing struct {
+	tracer trace.Tracer
+	svc    bootstrap.Service
+}
+
+// Tracing adds tracing to the bootstrap service.
+func Tracing(svc bootstrap.Service, tracer trace.Tracer) bootstrap.Service {
+	return &tracing{tracer, svc}
+}
+
+func (tm *tracing) Add(ctx context.Context, session smqauthn.Session, token string, cfg bootstrap.Config, certTTL string) (bootstrap.Config, error) {
+	ctx, span := tm.tracer.Start(ctx, "add", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("client_id", cfg.ClientID),
+		attribute.String("external_id", cfg.ExternalID),
+	))
+	defer span.End()
+
+	return tm.svc.Add(ctx, session, token, cfg, certTTL)
+}
+
+func (tm *tracing) View(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Config, error) {
+	ctx, span := tm.tracer.Start(ctx, "view", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("client_id", id),
+	))
+	defer span.End()
+
+	return tm.svc.View(ctx, session, id)
+}
+
+func (tm *tracing) List(ctx context.Context, session smqauthn.Session, pm bootstrap.PageMeta) (bootstrap.ConfigsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.List(ctx, session, pm)
+}
+
+func (tm *tracing) Update(ctx context.Context, session smqauthn.Session, cfg bootstrap.Config) (bootstrap.Config, error) {
+	ctx, span := tm.tracer.Start(ctx, "update", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("client_id", cfg.ClientID),
+	))
+	defer span.End()
+
+	return tm.svc.Update(ctx, session, cfg)
+}
+
+func (tm *tracing) Remove(ctx context.Context, session smqauthn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "remove", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("client_id", id),
+	))
+	defer span.End()
+
+	return tm.svc.Remove(ctx, session, id)
+}
+
+func (tm *tracing) Bootstrap(ctx context.Context, externalKey, externalID string) (bootstrap.Config, error) {
+	ctx, span := tm.tracer.Start(ctx, "bootstrap", trace.WithAttributes(
+		attribute.String("external_id", externalID),
+	))
+	defer span.End()
+
+	return tm.svc.Bootstrap(ctx, externalKey, externalID)
+}
@@ -0,0 +1,235 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/bootstrap"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/jmoiron/sqlx"
+)
+
+const configColumns = `client_id, client_secret, domain_id, name, external_id, external_key, channels, content,
+	client_cert, client_key, created_by, created_at, updated_by, updated_at`
+
+type configRepository struct {
+	db postgres.Database
+}
+
+// NewConfigRepository instantiates a PostgreSQL implementation of config repository.
+func NewConfigRepository(db postgres.Database) bootstrap.ConfigRepository {
+	return &configRepository{db: db}
+}
+
+func (repo *configRepository) Save(ctx context.Context, cfg bootstrap.Config) (bootstrap.Config, error) {
+	q := fmt.Sprintf(`INSERT INTO configs (%s)
+		VALUES (:client_id, :client_secret, :domain_id, :name, :external_id, :external_key, :channels, :content,
+		:client_cert, :client_key, :created_by, :created_at, :updated_by, :updated_at)
+		RETURNING %s`, configColumns, configColumns)
+
+	dbc, err := toDBConfig(cfg)
+	if err != nil {
+		return bootstrap.Config{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return repo.namedQueryOne(ctx, q, dbc, repoerr.ErrCreateEntity)
+}
+
+func (repo *configRepository) RetrieveByID(ctx context.Context, id string) (bootstrap.Config, error) {
+	q := fmt.Sprintf(`SELECT %s FROM configs WHERE client_id = :client_id`, configColumns)
+
+	return repo.namedQueryOne(ctx, q, dbConfig{ClientID: id}, repoerr.ErrViewEntity)
+}
+
+func (repo *configRepository) RetrieveByExternalID(ctx context.Context, externalID string) (bootstrap.Config, error) {
+	q := fmt.Sprintf(`SELECT %s FROM configs WHERE external_id = :external_id`, configColumns)
+
+	return repo.namedQueryOne(ctx, q, dbConfig{ExternalID: externalID}, repoerr.ErrViewEntity)
+}
+
+func (repo *configRepository) RetrieveAll(ctx context.Context, pm bootstrap.PageMeta) (bootstrap.ConfigsPage, error) {
+	query := configsQuery(pm)
+	q := fmt.Sprintf(`SELECT %s FROM configs %s ORDER BY created_at LIMIT :limit OFFSET :offset`, configColumns, query)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return bootstrap.ConfigsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	items, err := scanConfigs(rows)
+	if err != nil {
+		return bootstrap.ConfigsPage{}, err
+	}
+
+	tq := fmt.Sprintf(`SELECT COUNT(*) FROM configs %s`, query)
+	total, err := postgres.Total(ctx, repo.db, tq, pm)
+	if err != nil {
+		return bootstrap.ConfigsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return bootstrap.ConfigsPage{
+		Total:   total,
+		Offset:  pm.Offset,
+		Limit:   pm.Limit,
+		Configs: items,
+	}, nil
+}
+
+func (repo *configRepository) Update(ctx context.Context, cfg bootstrap.Config) (bootstrap.Config, error) {
+	q := fmt.Sprintf(`UPDATE configs SET name = :name, content = :content, updated_by = :updated_by, updated_at = :updated_at
+		WHERE client_id = :client_id RETURNING %s`, configColumns)
+
+	dbc, err := toDBConfig(cfg)
+	if err != nil {
+		return bootstrap.Config{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return repo.namedQueryOne(ctx, q, dbc, repoerr.ErrUpdateEntity)
+}
+
+func (repo *configRepository) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM configs WHERE client_id = $1`
+
+	result, err := repo.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *configRepository) namedQueryOne(ctx context.Context, q string, arg interface{}, wrapper error) (bootstrap.Config, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, arg)
+	if err != nil {
+		return bootstrap.Config{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return bootstrap.Config{}, repoerr.ErrNotFound
+	}
+	var dbc dbConfig
+	if err := rows.StructScan(&dbc); err != nil {
+		return bootstrap.Config{}, postgres.HandleError(wrapper, err)
+	}
+
+	return toConfig(dbc)
+}
+
+func scanConfigs(rows *sqlx.Rows) ([]bootstrap.Config, error) {
+	var items []bootstrap.Config
+	for rows.Next() {
+		var dbc dbConfig
+		if err := rows.StructScan(&dbc); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		cfg, err := toConfig(dbc)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, cfg)
+	}
+
+	return items, nil
+}
+
+func configsQuery(pm bootstrap.PageMeta) string {
+	var query []string
+	if pm.DomainID != "" {
+		query = append(query, "domain_id = :domain_id")
+	}
+	if pm.Name != "" {
+		query = append(query, "name ILIKE '%' || :name || '%'")
+	}
+	if len(query) > 0 {
+		return fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+	}
+
+	return ""
+}
+
+type dbConfig struct {
+	ClientID     string         `db:"client_id"`
+	ClientSecret string         `db:"client_secret"`
+	DomainID     string         `db:"domain_id"`
+	Name         sql.NullString `db:"name"`
+	ExternalID   string         `db:"external_id"`
+	ExternalKey  string         `db:"external_key"`
+	Channels     []byte         `db:"channels"`
+	Content      sql.NullString `db:"content"`
+	ClientCert   sql.NullString `db:"client_cert"`
+	ClientKey    sql.NullString `db:"client_key"`
+	CreatedBy    sql.NullString `db:"created_by"`
+	CreatedAt    time.Time      `db:"created_at"`
+	UpdatedBy    sql.NullString `db:"updated_by"`
+	UpdatedAt    sql.NullTime   `db:"updated_at"`
+}
+
+func toDBConfig(cfg bootstrap.Config) (dbConfig, error) {
+	var channels []byte
+	if len(cfg.Channels) > 0 {
+		var err error
+		if channels, err = json.Marshal(cfg.Channels); err != nil {
+			return dbConfig{}, err
+		}
+	}
+
+	return dbConfig{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		DomainID:     cfg.DomainID,
+		Name:         toNullString(cfg.Name),
+		ExternalID:   cfg.ExternalID,
+		ExternalKey:  cfg.ExternalKey,
+		Channels:     channels,
+		Content:      toNullString(cfg.Content),
+		ClientCert:   toNullString(cfg.ClientCert),
+		ClientKey:    toNullString(cfg.ClientKey),
+		CreatedBy:    toNullString(cfg.CreatedBy),
+		CreatedAt:    cfg.CreatedAt,
+		UpdatedBy:    toNullString(cfg.UpdatedBy),
+		UpdatedAt:    sql.NullTime{Time: cfg.UpdatedAt, Valid: !cfg.UpdatedAt.IsZero()},
+	}, nil
+}
+
+func toConfig(dbc dbConfig) (bootstrap.Config, error) {
+	cfg := bootstrap.Config{
+		ClientID:     dbc.ClientID,
+		ClientSecret: dbc.ClientSecret,
+		DomainID:     dbc.DomainID,
+		Name:         dbc.Name.String,
+		ExternalID:   dbc.ExternalID,
+		ExternalKey:  dbc.ExternalKey,
+		Content:      dbc.Content.String,
+		ClientCert:   dbc.ClientCert.String,
+		ClientKey:    dbc.ClientKey.String,
+		CreatedBy:    dbc.CreatedBy.String,
+		CreatedAt:    dbc.CreatedAt.UTC(),
+		UpdatedBy:    dbc.UpdatedBy.String,
+		UpdatedAt:    dbc.UpdatedAt.Time.UTC(),
+	}
+	if len(dbc.Channels) > 0 {
+		if err := json.Unmarshal(dbc.Channels, &cfg.Channels); err != nil {
+			return bootstrap.Config{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+	}
+
+	return cfg, nil
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
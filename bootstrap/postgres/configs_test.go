@@ -0,0 +1,336 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/bootstrap"
+	"github.com/absmach/supermq/bootstrap/postgres"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var invalidID = strings.Repeat("a", 37)
+
+func cleanUp(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM configs")
+		require.Nil(t, err, fmt.Sprintf("clean configs unexpected error: %s", err))
+	})
+}
+
+func newConfig(t *testing.T, domainID string) bootstrap.Config {
+	return bootstrap.Config{
+		ClientID:     testsutil.GenerateUUID(t),
+		ClientSecret: testsutil.GenerateUUID(t),
+		DomainID:     domainID,
+		Name:         "config",
+		ExternalID:   testsutil.GenerateUUID(t),
+		ExternalKey:  testsutil.GenerateUUID(t),
+		Channels: []bootstrap.Channel{
+			{ID: testsutil.GenerateUUID(t), Name: "channel", Metadata: map[string]interface{}{"type": "telemetry"}},
+		},
+		Content:    `{"server": "localhost"}`,
+		ClientCert: "cert",
+		ClientKey:  "key",
+		CreatedBy:  testsutil.GenerateUUID(t),
+		CreatedAt:  time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+func TestSave(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewConfigRepository(database)
+
+	cfg := newConfig(t, testsutil.GenerateUUID(t))
+	noChannels := newConfig(t, cfg.DomainID)
+	noChannels.Channels = nil
+
+	duplicateExternalID := newConfig(t, cfg.DomainID)
+	duplicateExternalID.ExternalID = cfg.ExternalID
+
+	cases := []struct {
+		desc   string
+		config bootstrap.Config
+		err    error
+	}{
+		{
+			desc:   "save config successfully",
+			config: cfg,
+			err:    nil,
+		},
+		{
+			desc:   "save config without channels successfully",
+			config: noChannels,
+			err:    nil,
+		},
+		{
+			desc:   "save config with existing client ID",
+			config: cfg,
+			err:    repoerr.ErrConflict,
+		},
+		{
+			desc:   "save config with existing external ID",
+			config: duplicateExternalID,
+			err:    repoerr.ErrConflict,
+		},
+		{
+			desc: "save config with invalid domain ID",
+			config: bootstrap.Config{
+				ClientID:    testsutil.GenerateUUID(t),
+				DomainID:    invalidID,
+				ExternalID:  testsutil.GenerateUUID(t),
+				ExternalKey: testsutil.GenerateUUID(t),
+				CreatedAt:   time.Now().UTC(),
+			},
+			err: repoerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.Save(context.Background(), tc.config)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.config, saved, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.config, saved))
+			}
+		})
+	}
+}
+
+func TestRetrieveByID(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewConfigRepository(database)
+
+	cfg := newConfig(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), cfg)
+	require.Nil(t, err, fmt.Sprintf("save config unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		resp bootstrap.Config
+		err  error
+	}{
+		{
+			desc: "retrieve config successfully",
+			id:   cfg.ClientID,
+			resp: cfg,
+			err:  nil,
+		},
+		{
+			desc: "retrieve non-existing config",
+			id:   testsutil.GenerateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+		{
+			desc: "retrieve config with empty ID",
+			id:   "",
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveByID(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestRetrieveByExternalID(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewConfigRepository(database)
+
+	cfg := newConfig(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), cfg)
+	require.Nil(t, err, fmt.Sprintf("save config unexpected error: %s", err))
+
+	cases := []struct {
+		desc       string
+		externalID string
+		resp       bootstrap.Config
+		err        error
+	}{
+		{
+			desc:       "retrieve config by external ID successfully",
+			externalID: cfg.ExternalID,
+			resp:       cfg,
+			err:        nil,
+		},
+		{
+			desc:       "retrieve config by non-existing external ID",
+			externalID: testsutil.GenerateUUID(t),
+			err:        repoerr.ErrNotFound,
+		},
+		{
+			desc:       "retrieve config by client ID",
+			externalID: cfg.ClientID,
+			err:        repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveByExternalID(context.Background(), tc.externalID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewConfigRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	num := 10
+	var items, named []bootstrap.Config
+	for i := 0; i < num; i++ {
+		cfg := newConfig(t, domainID)
+		cfg.CreatedAt = cfg.CreatedAt.Add(time.Duration(i) * time.Second)
+		cfg.Name = fmt.Sprintf("config-%d", i)
+		if i%2 == 0 {
+			cfg.Name = fmt.Sprintf("gateway-%d", i)
+		}
+		saved, err := repo.Save(context.Background(), cfg)
+		require.Nil(t, err, fmt.Sprintf("save config unexpected error: %s", err))
+		items = append(items, saved)
+		if strings.HasPrefix(saved.Name, "gateway") {
+			named = append(named, saved)
+		}
+	}
+	_, err := repo.Save(context.Background(), newConfig(t, testsutil.GenerateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("save config unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		pm   bootstrap.PageMeta
+		resp bootstrap.ConfigsPage
+	}{
+		{
+			desc: "retrieve configs of domain",
+			pm:   bootstrap.PageMeta{DomainID: domainID, Limit: 10},
+			resp: bootstrap.ConfigsPage{Total: uint64(num), Limit: 10, Configs: items},
+		},
+		{
+			desc: "retrieve configs of domain with offset and limit",
+			pm:   bootstrap.PageMeta{DomainID: domainID, Offset: 2, Limit: 3},
+			resp: bootstrap.ConfigsPage{Total: uint64(num), Offset: 2, Limit: 3, Configs: items[2:5]},
+		},
+		{
+			desc: "retrieve configs of domain by partial name",
+			pm:   bootstrap.PageMeta{DomainID: domainID, Name: "GATEWAY", Limit: 10},
+			resp: bootstrap.ConfigsPage{Total: uint64(len(named)), Limit: 10, Configs: named},
+		},
+		{
+			desc: "retrieve configs of domain without configs",
+			pm:   bootstrap.PageMeta{DomainID: testsutil.GenerateUUID(t), Limit: 10},
+			resp: bootstrap.ConfigsPage{Limit: 10},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewConfigRepository(database)
+
+	cfg := newConfig(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), cfg)
+	require.Nil(t, err, fmt.Sprintf("save config unexpected error: %s", err))
+
+	updated := cfg
+	updated.Name = "updated"
+	updated.Content = "updated"
+	updated.UpdatedBy = testsutil.GenerateUUID(t)
+	updated.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	// Only the name and the content are updated, so the other fields of the
+	// config passed to the repository are ignored.
+	req := updated
+	req.ExternalID = testsutil.GenerateUUID(t)
+	req.ExternalKey = testsutil.GenerateUUID(t)
+	req.Channels = nil
+
+	cases := []struct {
+		desc   string
+		config bootstrap.Config
+		resp   bootstrap.Config
+		err    error
+	}{
+		{
+			desc:   "update config successfully",
+			config: req,
+			resp:   updated,
+			err:    nil,
+		},
+		{
+			desc: "update non-existing config",
+			config: bootstrap.Config{
+				ClientID:  testsutil.GenerateUUID(t),
+				Name:      "updated",
+				UpdatedAt: time.Now().UTC(),
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.Update(context.Background(), tc.config)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewConfigRepository(database)
+
+	cfg := newConfig(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), cfg)
+	require.Nil(t, err, fmt.Sprintf("save config unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove config successfully",
+			id:   cfg.ClientID,
+			err:  nil,
+		},
+		{
+			desc: "remove removed config",
+			id:   cfg.ClientID,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Remove(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "bootstrap_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS configs (
+						client_id     VARCHAR(36) PRIMARY KEY,
+						client_secret VARCHAR(4096) NOT NULL,
+						domain_id     VARCHAR(36) NOT NULL,
+						name          VARCHAR(1024),
+						external_id   VARCHAR(254) UNIQUE NOT NULL,
+						external_key  VARCHAR(254) NOT NULL,
+						channels      JSONB,
+						content       TEXT,
+						client_cert   TEXT,
+						client_key    TEXT,
+						created_by    VARCHAR(254),
+						created_at    TIMESTAMP NOT NULL,
+						updated_by    VARCHAR(254),
+						updated_at    TIMESTAMP
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_configs_domain ON configs(domain_id);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS configs`,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// ErrEncryptionKey indicates that the encryption key is not a valid AES key.
+var ErrEncryptionKey = errors.New("encryption key must be 16, 24 or 32 bytes long")
+
+// DeviceConfig is the bootstrap config as served to the device.
+type DeviceConfig struct {
+	ClientID     string    `json:"client_id"`
+	ClientSecret string    `json:"client_secret"`
+	Channels     []Channel `json:"channels"`
+	Content      string    `json:"content,omitempty"`
+	ClientCert   string    `json:"client_cert,omitempty"`
+	ClientKey    string    `json:"client_key,omitempty"`
+}
+
+// ConfigReader is used to parse the config into the format served to the device.
+type ConfigReader interface {
+	// ReadConfig returns the config response. If secure is set, the response
+	// is encrypted and returned as bytes.
+	ReadConfig(cfg Config, secure bool) (interface{}, error)
+}
+
+type reader struct {
+	aead cipher.AEAD
+}
+
+// NewConfigReader returns the config reader which encrypts the secure
+// responses using AES-GCM with the given key. The encrypted response is the
+// GCM nonce followed by the sealed JSON config.
+func NewConfigReader(encKey []byte) (ConfigReader, error) {
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, errors.Wrap(ErrEncryptionKey, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return reader{aead: aead}, nil
+}
+
+func (r reader) ReadConfig(cfg Config, secure bool) (interface{}, error) {
+	res := DeviceConfig{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		Channels:     cfg.Channels,
+		Content:      cfg.Content,
+		ClientCert:   cfg.ClientCert,
+		ClientKey:    cfg.ClientKey,
+	}
+	if res.Channels == nil {
+		res.Channels = []Channel{}
+	}
+	if !secure {
+		return res, nil
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.encrypt(b)
+}
+
+func (r reader) encrypt(in []byte) ([]byte, error) {
+	nonce := make([]byte, r.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return r.aead.Seal(nonce, nonce, in, nil), nil
+}
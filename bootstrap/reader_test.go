@@ -0,0 +1,61 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/bootstrap"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConfig(t *testing.T) {
+	key := []byte("v7aT0HGxJxt2gULzr3RHwf4WIf6DusPp")
+	reader, err := bootstrap.NewConfigReader(key)
+	require.Nil(t, err)
+
+	cfg := bootstrap.Config{
+		ClientID:     client.ID,
+		ClientSecret: client.Credentials.Secret,
+		ExternalKey:  externalKey,
+		Channels:     []bootstrap.Channel{{ID: channel.ID, Name: channel.Name}},
+		Content:      "config",
+	}
+	want := bootstrap.DeviceConfig{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		Channels:     cfg.Channels,
+		Content:      cfg.Content,
+	}
+
+	res, err := reader.ReadConfig(cfg, false)
+	assert.Nil(t, err)
+	assert.Equal(t, want, res)
+
+	res, err = reader.ReadConfig(cfg, true)
+	assert.Nil(t, err)
+	enc, ok := res.([]byte)
+	require.True(t, ok)
+
+	block, err := aes.NewCipher(key)
+	require.Nil(t, err)
+	aead, err := cipher.NewGCM(block)
+	require.Nil(t, err)
+	nonce, sealed := enc[:aead.NonceSize()], enc[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, nil)
+	require.Nil(t, err)
+
+	var got bootstrap.DeviceConfig
+	assert.Nil(t, json.Unmarshal(plain, &got))
+	assert.Equal(t, want, got)
+
+	_, err = bootstrap.NewConfigReader([]byte("short"))
+	assert.True(t, errors.Contains(err, bootstrap.ErrEncryptionKey), fmt.Sprintf("expected %s got %s", bootstrap.ErrEncryptionKey, err))
+}
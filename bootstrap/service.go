@@ -0,0 +1,186 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"context"
+	"crypto/subtle"
+	"time"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
+)
+
+var connectionTypes = []string{"publish", "subscribe"}
+
+type service struct {
+	repo ConfigRepository
+	sdk  mgsdk.SDK
+}
+
+// New instantiates the bootstrap service implementation. The SDK is used
+// to create and connect the config clients and to issue their certificates.
+func New(repo ConfigRepository, sdk mgsdk.SDK) Service {
+	return &service{
+		repo: repo,
+		sdk:  sdk,
+	}
+}
+
+func (svc *service) Add(ctx context.Context, session smqauthn.Session, token string, cfg Config, certTTL string) (Config, error) {
+	client, created, err := svc.client(cfg, session.DomainID, token)
+	if err != nil {
+		return Config{}, errors.Wrap(ErrClients, err)
+	}
+
+	saved, err := svc.add(ctx, session, token, client, cfg, certTTL)
+	if err != nil && created {
+		if rerr := svc.sdk.DeleteClient(client.ID, session.DomainID, token); rerr != nil {
+			err = errors.Wrap(err, rerr)
+		}
+	}
+
+	return saved, err
+}
+
+func (svc *service) add(ctx context.Context, session smqauthn.Session, token string, client mgsdk.Client, cfg Config, certTTL string) (Config, error) {
+	channels := make([]Channel, len(cfg.Channels))
+	ids := make([]string, len(cfg.Channels))
+	for i, c := range cfg.Channels {
+		ch, err := svc.sdk.Channel(c.ID, session.DomainID, token)
+		if err != nil {
+			return Config{}, errors.Wrap(ErrClients, err)
+		}
+		channels[i] = Channel{ID: ch.ID, Name: ch.Name, Metadata: ch.Metadata}
+		ids[i] = ch.ID
+	}
+	if len(ids) > 0 {
+		conn := mgsdk.Connection{
+			ClientIDs:  []string{client.ID},
+			ChannelIDs: ids,
+			Types:      connectionTypes,
+		}
+		if err := svc.sdk.Connect(conn, session.DomainID, token); err != nil {
+			return Config{}, errors.Wrap(ErrClients, err)
+		}
+	}
+
+	if certTTL != "" {
+		cert, err := svc.sdk.IssueCert(client.ID, certTTL, session.DomainID, token)
+		if err != nil {
+			return Config{}, errors.Wrap(ErrCerts, err)
+		}
+		cfg.ClientCert = cert.Certificate
+		cfg.ClientKey = cert.Key
+	}
+
+	cfg.ClientID = client.ID
+	cfg.ClientSecret = client.Credentials.Secret
+	cfg.DomainID = session.DomainID
+	cfg.Channels = channels
+	cfg.CreatedBy = session.UserID
+	cfg.CreatedAt = time.Now().UTC()
+
+	saved, err := svc.repo.Save(ctx, cfg)
+	if err != nil {
+		return Config{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return saved, nil
+}
+
+// client creates a new client for the config without a client ID and
+// retrieves the existing one otherwise. It reports whether the client is created.
+func (svc *service) client(cfg Config, domainID, token string) (mgsdk.Client, bool, error) {
+	if cfg.ClientID != "" {
+		client, err := svc.sdk.Client(cfg.ClientID, domainID, token)
+		return client, false, err
+	}
+
+	client, err := svc.sdk.CreateClient(mgsdk.Client{Name: cfg.Name}, domainID, token)
+	if err != nil {
+		return mgsdk.Client{}, false, err
+	}
+
+	return client, true, nil
+}
+
+func (svc *service) View(ctx context.Context, session smqauthn.Session, id string) (Config, error) {
+	cfg, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return Config{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if cfg.DomainID != session.DomainID {
+		return Config{}, svcerr.ErrNotFound
+	}
+
+	return redact(cfg), nil
+}
+
+func (svc *service) List(ctx context.Context, session smqauthn.Session, pm PageMeta) (ConfigsPage, error) {
+	pm.DomainID = session.DomainID
+	page, err := svc.repo.RetrieveAll(ctx, pm)
+	if err != nil {
+		return ConfigsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for i := range page.Configs {
+		page.Configs[i] = redact(page.Configs[i])
+	}
+
+	return page, nil
+}
+
+func (svc *service) Update(ctx context.Context, session smqauthn.Session, cfg Config) (Config, error) {
+	if _, err := svc.View(ctx, session, cfg.ClientID); err != nil {
+		return Config{}, err
+	}
+	cfg.UpdatedBy = session.UserID
+	cfg.UpdatedAt = time.Now().UTC()
+
+	updated, err := svc.repo.Update(ctx, cfg)
+	if err != nil {
+		return Config{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return redact(updated), nil
+}
+
+func (svc *service) Remove(ctx context.Context, session smqauthn.Session, id string) error {
+	if _, err := svc.View(ctx, session, id); err != nil {
+		return err
+	}
+	if err := svc.repo.Remove(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *service) Bootstrap(ctx context.Context, externalKey, externalID string) (Config, error) {
+	cfg, err := svc.repo.RetrieveByExternalID(ctx, externalID)
+	if err != nil {
+		if errors.Contains(err, repoerr.ErrNotFound) {
+			return Config{}, errors.Wrap(svcerr.ErrNotFound, err)
+		}
+		return Config{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if subtle.ConstantTimeCompare([]byte(cfg.ExternalKey), []byte(externalKey)) != 1 {
+		return Config{}, errors.Wrap(svcerr.ErrAuthentication, ErrExternalKey)
+	}
+
+	return cfg, nil
+}
+
+// redact removes the client credentials and the external key from the config.
+// They are returned only to the device on bootstrap.
+func redact(cfg Config) Config {
+	cfg.ClientSecret = ""
+	cfg.ClientKey = ""
+	cfg.ExternalKey = ""
+
+	return cfg
+}
@@ -0,0 +1,207 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/absmach/supermq/bootstrap"
+	"github.com/absmach/supermq/bootstrap/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
+	sdkmocks "github.com/absmach/supermq/pkg/sdk/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	token       = "token"
+	externalID  = "external-id"
+	externalKey = "external-key"
+)
+
+var (
+	session   = smqauthn.Session{DomainID: testsutil.GenerateUUID(&testing.T{}), UserID: testsutil.GenerateUUID(&testing.T{})}
+	client    = mgsdk.Client{ID: testsutil.GenerateUUID(&testing.T{}), Credentials: mgsdk.ClientCredentials{Secret: "secret"}}
+	channel   = mgsdk.Channel{ID: testsutil.GenerateUUID(&testing.T{}), Name: "channel"}
+	sdkErr    = errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)
+	configReq = bootstrap.Config{
+		ExternalID:  externalID,
+		ExternalKey: externalKey,
+		Channels:    []bootstrap.Channel{{ID: channel.ID}},
+	}
+)
+
+func newService() (bootstrap.Service, *mocks.ConfigRepository, *sdkmocks.SDK) {
+	repo := new(mocks.ConfigRepository)
+	sdk := new(sdkmocks.SDK)
+
+	return bootstrap.New(repo, sdk), repo, sdk
+}
+
+func TestAdd(t *testing.T) {
+	cases := []struct {
+		desc       string
+		cfg        bootstrap.Config
+		certTTL    string
+		createErr  errors.SDKError
+		channelErr errors.SDKError
+		connectErr errors.SDKError
+		certErr    errors.SDKError
+		repoErr    error
+		deleted    bool
+		err        error
+	}{
+		{
+			desc: "add config with new client",
+			cfg:  configReq,
+		},
+		{
+			desc:    "add config with existing client and certificate",
+			cfg:     bootstrap.Config{ClientID: client.ID, ExternalID: externalID, ExternalKey: externalKey, Channels: configReq.Channels},
+			certTTL: "24h",
+		},
+		{
+			desc:      "add config with failed client creation",
+			cfg:       configReq,
+			createErr: sdkErr,
+			err:       bootstrap.ErrClients,
+		},
+		{
+			desc:       "add config with inaccessible channel",
+			cfg:        configReq,
+			channelErr: sdkErr,
+			deleted:    true,
+			err:        bootstrap.ErrClients,
+		},
+		{
+			desc:       "add config with failed connection",
+			cfg:        configReq,
+			connectErr: sdkErr,
+			deleted:    true,
+			err:        bootstrap.ErrClients,
+		},
+		{
+			desc:    "add config with failed certificate issue",
+			cfg:     configReq,
+			certTTL: "24h",
+			certErr: sdkErr,
+			deleted: true,
+			err:     bootstrap.ErrCerts,
+		},
+		{
+			desc:    "add config with existing external ID",
+			cfg:     configReq,
+			repoErr: repoerr.ErrConflict,
+			deleted: true,
+			err:     svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, sdk := newService()
+			sdk.On("CreateClient", mgsdk.Client{Name: tc.cfg.Name}, session.DomainID, token).Return(client, tc.createErr)
+			sdk.On("Client", client.ID, session.DomainID, token).Return(client, nil)
+			sdk.On("Channel", channel.ID, session.DomainID, token).Return(channel, tc.channelErr)
+			sdk.On("Connect", mgsdk.Connection{ClientIDs: []string{client.ID}, ChannelIDs: []string{channel.ID}, Types: []string{"publish", "subscribe"}}, session.DomainID, token).Return(tc.connectErr)
+			sdk.On("IssueCert", client.ID, tc.certTTL, session.DomainID, token).Return(mgsdk.Cert{Certificate: "cert", Key: "key"}, tc.certErr)
+			sdk.On("DeleteClient", client.ID, session.DomainID, token).Return(nil)
+			repo.On("Save", context.Background(), mock.MatchedBy(func(cfg bootstrap.Config) bool {
+				return cfg.ClientID == client.ID && cfg.ClientSecret == client.Credentials.Secret && cfg.DomainID == session.DomainID &&
+					len(cfg.Channels) == 1 && cfg.Channels[0].Name == channel.Name && (tc.certTTL == "") == (cfg.ClientCert == "")
+			})).Return(bootstrap.Config{ClientID: client.ID}, tc.repoErr)
+
+			_, err := svc.Add(context.Background(), session, token, tc.cfg, tc.certTTL)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.deleted {
+				sdk.AssertCalled(t, "DeleteClient", client.ID, session.DomainID, token)
+			} else {
+				sdk.AssertNotCalled(t, "DeleteClient", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	cases := []struct {
+		desc     string
+		repoResp bootstrap.Config
+		repoErr  error
+		err      error
+	}{
+		{
+			desc:     "view config successfully",
+			repoResp: bootstrap.Config{ClientID: client.ID, DomainID: session.DomainID, ClientSecret: client.Credentials.Secret, ExternalKey: externalKey, ClientKey: "key"},
+		},
+		{
+			desc:     "view config of another domain",
+			repoResp: bootstrap.Config{ClientID: client.ID, DomainID: testsutil.GenerateUUID(t)},
+			err:      svcerr.ErrNotFound,
+		},
+		{
+			desc:    "view non-existing config",
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, _ := newService()
+			repo.On("RetrieveByID", context.Background(), client.ID).Return(tc.repoResp, tc.repoErr)
+			cfg, err := svc.View(context.Background(), session, client.ID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Empty(t, cfg.ClientSecret, "client secret should not be exposed")
+			assert.Empty(t, cfg.ClientKey, "client key should not be exposed")
+			assert.Empty(t, cfg.ExternalKey, "external key should not be exposed")
+		})
+	}
+}
+
+func TestBootstrap(t *testing.T) {
+	cfg := bootstrap.Config{ClientID: client.ID, ExternalID: externalID, ExternalKey: externalKey}
+
+	cases := []struct {
+		desc    string
+		key     string
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "bootstrap successfully",
+			key:  externalKey,
+		},
+		{
+			desc: "bootstrap with invalid external key",
+			key:  "invalid",
+			err:  bootstrap.ErrExternalKey,
+		},
+		{
+			desc:    "bootstrap with unknown external ID",
+			key:     externalKey,
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, _ := newService()
+			repo.On("RetrieveByExternalID", context.Background(), externalID).Return(cfg, tc.repoErr)
+			res, err := svc.Bootstrap(context.Background(), tc.key, externalID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, cfg, res)
+			}
+		})
+	}
+}
@@ -30,6 +30,8 @@ type grpcClient struct {
 	removeClientConnections      endpoint.Endpoint
 	unsetParentGroupFromChannels endpoint.Endpoint
 	retrieveEntity               endpoint.Endpoint
+	retrieveIDByName             endpoint.Endpoint
+	moveParentGroupChannels      endpoint.Endpoint
 }
 
 // NewClient returns new gRPC client instance.
@@ -67,6 +69,22 @@ func NewClient(conn *grpc.ClientConn, timeout time.Duration) grpcChannelsV1.Chan
 			decodeRetrieveEntityResponse,
 			grpcCommonV1.RetrieveEntityRes{},
 		).Endpoint(),
+		retrieveIDByName: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveIDByName",
+			encodeRetrieveIDByNameRequest,
+			decodeRetrieveIDByNameResponse,
+			grpcChannelsV1.RetrieveIDByNameRes{},
+		).Endpoint(),
+		moveParentGroupChannels: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"MoveParentGroupChannels",
+			encodeMoveParentGroupChannelsRequest,
+			decodeMoveParentGroupChannelsResponse,
+			grpcChannelsV1.MoveParentGroupChannelsRes{},
+		).Endpoint(),
 		timeout: timeout,
 	}
 }
@@ -81,6 +99,8 @@ func (client grpcClient) Authorize(ctx context.Context, req *grpcChannelsV1.Auth
 		clientType: req.GetClientType(),
 		channelID:  req.GetChannelId(),
 		connType:   connections.ConnType(req.GetType()),
+		protocol:   req.GetProtocol(),
+		subtopic:   req.GetSubtopic(),
 	})
 	if err != nil {
 		return &grpcChannelsV1.AuthzRes{}, decodeError(err)
@@ -100,6 +120,8 @@ func encodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}
 		ClientType: req.clientType,
 		ChannelId:  req.channelID,
 		Type:       uint32(req.connType),
+		Protocol:   req.protocol,
+		Subtopic:   req.subtopic,
 	}, nil
 }
 
@@ -167,6 +189,68 @@ func decodeRetrieveEntityResponse(_ context.Context, grpcRes interface{}) (inter
 	return grpcRes.(*grpcCommonV1.RetrieveEntityRes), nil
 }
 
+func (client grpcClient) RetrieveIDByName(ctx context.Context, req *grpcChannelsV1.RetrieveIDByNameReq, _ ...grpc.CallOption) (*grpcChannelsV1.RetrieveIDByNameRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.retrieveIDByName(ctx, retrieveIDByNameReq{
+		domainID: req.GetDomainId(),
+		name:     req.GetName(),
+	})
+	if err != nil {
+		return &grpcChannelsV1.RetrieveIDByNameRes{}, decodeError(err)
+	}
+
+	rir := res.(retrieveIDByNameRes)
+	return &grpcChannelsV1.RetrieveIDByNameRes{Id: rir.id}, nil
+}
+
+func encodeRetrieveIDByNameRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(retrieveIDByNameReq)
+	return &grpcChannelsV1.RetrieveIDByNameReq{
+		DomainId: req.domainID,
+		Name:     req.name,
+	}, nil
+}
+
+func decodeRetrieveIDByNameResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*grpcChannelsV1.RetrieveIDByNameRes)
+	return retrieveIDByNameRes{id: res.GetId()}, nil
+}
+
+func (client grpcClient) MoveParentGroupChannels(ctx context.Context, req *grpcChannelsV1.MoveParentGroupChannelsReq, _ ...grpc.CallOption) (*grpcChannelsV1.MoveParentGroupChannelsRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.moveParentGroupChannels(ctx, moveParentGroupChannelsReq{
+		parentGroupIDs: req.GetParentGroupIds(),
+		clientIDs:      req.GetClientIds(),
+		domainID:       req.GetDomainId(),
+		updatedBy:      req.GetUpdatedBy(),
+	})
+	if err != nil {
+		return &grpcChannelsV1.MoveParentGroupChannelsRes{}, decodeError(err)
+	}
+
+	mr := res.(moveParentGroupChannelsRes)
+	return &grpcChannelsV1.MoveParentGroupChannelsRes{Ids: mr.ids}, nil
+}
+
+func encodeMoveParentGroupChannelsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(moveParentGroupChannelsReq)
+	return &grpcChannelsV1.MoveParentGroupChannelsReq{
+		ParentGroupIds: req.parentGroupIDs,
+		ClientIds:      req.clientIDs,
+		DomainId:       req.domainID,
+		UpdatedBy:      req.updatedBy,
+	}, nil
+}
+
+func decodeMoveParentGroupChannelsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*grpcChannelsV1.MoveParentGroupChannelsRes)
+	return moveParentGroupChannelsRes{ids: res.GetIds()}, nil
+}
+
 func decodeError(err error) error {
 	if st, ok := status.FromError(err); ok {
 		switch st.Code() {
@@ -182,6 +266,8 @@ func decodeError(err error) error {
 			return errors.Wrap(svcerr.ErrNotFound, errors.New(st.Message()))
 		case codes.AlreadyExists:
 			return errors.Wrap(svcerr.ErrConflict, errors.New(st.Message()))
+		case codes.ResourceExhausted:
+			return errors.Wrap(svcerr.ErrQuotaExceeded, errors.New(st.Message()))
 		case codes.OK:
 			if msg := st.Message(); msg != "" {
 				return errors.Wrap(errors.ErrUnidentified, errors.New(msg))
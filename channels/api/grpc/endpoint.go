@@ -24,6 +24,8 @@ func authorizeEndpoint(svc channels.Service) endpoint.Endpoint {
 			ClientType: req.clientType,
 			ChannelID:  req.channelID,
 			Type:       req.connType,
+			Protocol:   req.protocol,
+			Subtopic:   req.subtopic,
 		}); err != nil {
 			return authorizeRes{}, err
 		}
@@ -67,3 +69,35 @@ func retrieveEntityEndpoint(svc channels.Service) endpoint.Endpoint {
 		return retrieveEntityRes{id: channel.ID, domain: channel.Domain, parentGroup: channel.ParentGroup, status: uint8(channel.Status)}, nil
 	}
 }
+
+func retrieveIDByNameEndpoint(svc channels.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retrieveIDByNameReq)
+		if err := req.validate(); err != nil {
+			return retrieveIDByNameRes{}, err
+		}
+
+		id, err := svc.RetrieveIDByName(ctx, req.domainID, req.name)
+		if err != nil {
+			return retrieveIDByNameRes{}, err
+		}
+
+		return retrieveIDByNameRes{id: id}, nil
+	}
+}
+
+func moveParentGroupChannelsEndpoint(svc channels.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(moveParentGroupChannelsReq)
+		if err := req.validate(); err != nil {
+			return moveParentGroupChannelsRes{}, err
+		}
+
+		ids, err := svc.MoveParentGroupChannels(ctx, req.parentGroupIDs, req.clientIDs, req.domainID, req.updatedBy)
+		if err != nil {
+			return moveParentGroupChannelsRes{}, err
+		}
+
+		return moveParentGroupChannelsRes{ids: ids}, nil
+	}
+}
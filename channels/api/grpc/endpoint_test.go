@@ -12,6 +12,7 @@ import (
 
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	apiutil "github.com/absmach/supermq/api/http/util"
 	ch "github.com/absmach/supermq/channels"
 	grpcapi "github.com/absmach/supermq/channels/api/grpc"
 	"github.com/absmach/supermq/channels/private/mocks"
@@ -69,6 +70,7 @@ func TestAuthorize(t *testing.T) {
 		clientType string
 		channelID  string
 		connType   connections.ConnType
+		subtopic   string
 		err        error
 		authzErr   error
 		res        *grpcChannelsV1.AuthzRes
@@ -84,6 +86,17 @@ func TestAuthorize(t *testing.T) {
 			res:        &grpcChannelsV1.AuthzRes{Authorized: true},
 			err:        nil,
 		},
+		{
+			desc:       "authorize with subtopic successfully",
+			domainID:   validID,
+			clientID:   validID,
+			clientType: policies.ClientType,
+			channelID:  validID,
+			connType:   connections.Publish,
+			subtopic:   "sensors.temp.room1",
+			res:        &grpcChannelsV1.AuthzRes{Authorized: true},
+			err:        nil,
+		},
 		{
 			desc:       "authorize with authorization  error",
 			domainID:   validID,
@@ -116,6 +129,7 @@ func TestAuthorize(t *testing.T) {
 				ClientType: tc.clientType,
 				ChannelID:  tc.channelID,
 				Type:       tc.connType,
+				Subtopic:   tc.subtopic,
 			}
 			svcCall := svc.On("Authorize", mock.Anything, authReq).Return(tc.authzErr)
 			res, err := client.Authorize(context.Background(), &grpcChannelsV1.AuthzReq{
@@ -124,6 +138,7 @@ func TestAuthorize(t *testing.T) {
 				ClientType: tc.clientType,
 				ChannelId:  tc.channelID,
 				Type:       uint32(tc.connType),
+				Subtopic:   tc.subtopic,
 			})
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
 			assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.res, res))
@@ -215,6 +230,73 @@ func TestUnsetParentGroupFromChannelsEndpoint(t *testing.T) {
 	}
 }
 
+func TestMoveParentGroupChannels(t *testing.T) {
+	svc := new(mocks.Service)
+	server := startGRPCServer(svc, port)
+	defer server.GracefulStop()
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client := grpcapi.NewClient(conn, time.Second)
+
+	parentGroupIDs := []string{validID}
+	clientIDs := []string{testsutil.GenerateUUID(t)}
+	channelIDs := []string{testsutil.GenerateUUID(t)}
+	domainID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc   string
+		req    *grpcChannelsV1.MoveParentGroupChannelsReq
+		svcRes []string
+		svcErr error
+		resp   *grpcChannelsV1.MoveParentGroupChannelsRes
+		err    error
+	}{
+		{
+			desc: "move parent group channels successfully",
+			req: &grpcChannelsV1.MoveParentGroupChannelsReq{
+				ParentGroupIds: parentGroupIDs,
+				ClientIds:      clientIDs,
+				DomainId:       domainID,
+				UpdatedBy:      validID,
+			},
+			svcRes: channelIDs,
+			resp:   &grpcChannelsV1.MoveParentGroupChannelsRes{Ids: channelIDs},
+		},
+		{
+			desc: "move parent group channels with missing domain ID",
+			req: &grpcChannelsV1.MoveParentGroupChannelsReq{
+				ParentGroupIds: parentGroupIDs,
+				ClientIds:      clientIDs,
+				UpdatedBy:      validID,
+			},
+			resp: &grpcChannelsV1.MoveParentGroupChannelsRes{},
+			err:  apiutil.ErrMissingDomainID,
+		},
+		{
+			desc: "move parent group channels with exceeded quota",
+			req: &grpcChannelsV1.MoveParentGroupChannelsReq{
+				ParentGroupIds: parentGroupIDs,
+				ClientIds:      clientIDs,
+				DomainId:       domainID,
+				UpdatedBy:      validID,
+			},
+			svcErr: svcerr.ErrQuotaExceeded,
+			resp:   &grpcChannelsV1.MoveParentGroupChannelsRes{},
+			err:    svcerr.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("MoveParentGroupChannels", mock.Anything, tc.req.ParentGroupIds, tc.req.ClientIds, tc.req.DomainId, tc.req.UpdatedBy).Return(tc.svcRes, tc.svcErr)
+			res, err := client.MoveParentGroupChannels(context.Background(), tc.req)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp.GetIds(), res.GetIds(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.resp.GetIds(), res.GetIds()))
+			svcCall.Unset()
+		})
+	}
+}
+
 func TestRetrieveEntity(t *testing.T) {
 	svc := new(mocks.Service)
 	server := startGRPCServer(svc, port)
@@ -265,3 +347,52 @@ func TestRetrieveEntity(t *testing.T) {
 		})
 	}
 }
+
+func TestRetrieveIDByName(t *testing.T) {
+	svc := new(mocks.Service)
+	server := startGRPCServer(svc, port)
+	defer server.GracefulStop()
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client := grpcapi.NewClient(conn, time.Second)
+
+	cases := []struct {
+		desc   string
+		req    *grpcChannelsV1.RetrieveIDByNameReq
+		svcRes string
+		svcErr error
+		err    error
+	}{
+		{
+			desc:   "retrieve id by name successfully",
+			req:    &grpcChannelsV1.RetrieveIDByNameReq{DomainId: validChannel.Domain, Name: "temperature"},
+			svcRes: validID,
+		},
+		{
+			desc: "retrieve id by name with missing domain id",
+			req:  &grpcChannelsV1.RetrieveIDByNameReq{Name: "temperature"},
+			err:  apiutil.ErrMissingDomainID,
+		},
+		{
+			desc: "retrieve id by name with missing name",
+			req:  &grpcChannelsV1.RetrieveIDByNameReq{DomainId: validChannel.Domain},
+			err:  apiutil.ErrMissingName,
+		},
+		{
+			desc:   "retrieve id by name with non-existing name",
+			req:    &grpcChannelsV1.RetrieveIDByNameReq{DomainId: validChannel.Domain, Name: "temperature"},
+			svcErr: svcerr.ErrNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("RetrieveIDByName", mock.Anything, tc.req.GetDomainId(), tc.req.GetName()).Return(tc.svcRes, tc.svcErr)
+			res, err := client.RetrieveIDByName(context.Background(), tc.req)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.svcRes, res.GetId(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.svcRes, res.GetId()))
+			svcCall.Unset()
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package grpc
 
 import (
+	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/policies"
@@ -17,6 +18,8 @@ type authorizeReq struct {
 	clientID   string
 	clientType string
 	connType   connections.ConnType
+	protocol   string
+	subtopic   string
 }
 
 func (req authorizeReq) validate() error {
@@ -37,3 +40,34 @@ type unsetParentGroupFromChannelsReq struct {
 type retrieveEntityReq struct {
 	Id string
 }
+
+type retrieveIDByNameReq struct {
+	domainID string
+	name     string
+}
+
+func (req retrieveIDByNameReq) validate() error {
+	if req.domainID == "" {
+		return apiutil.ErrMissingDomainID
+	}
+	if req.name == "" {
+		return apiutil.ErrMissingName
+	}
+
+	return nil
+}
+
+type moveParentGroupChannelsReq struct {
+	parentGroupIDs []string
+	clientIDs      []string
+	domainID       string
+	updatedBy      string
+}
+
+func (req moveParentGroupChannelsReq) validate() error {
+	if req.domainID == "" {
+		return apiutil.ErrMissingDomainID
+	}
+
+	return nil
+}
@@ -19,3 +19,11 @@ type channelBasic struct {
 }
 
 type retrieveEntityRes channelBasic
+
+type retrieveIDByNameRes struct {
+	id string
+}
+
+type moveParentGroupChannelsRes struct {
+	ids []string
+}
@@ -27,6 +27,8 @@ type grpcServer struct {
 	removeClientConnections      kitgrpc.Handler
 	unsetParentGroupFromChannels kitgrpc.Handler
 	retrieveEntity               kitgrpc.Handler
+	retrieveIDByName             kitgrpc.Handler
+	moveParentGroupChannels      kitgrpc.Handler
 }
 
 // NewServer returns new AuthServiceServer instance.
@@ -52,6 +54,16 @@ func NewServer(svc channels.Service) grpcChannelsV1.ChannelsServiceServer {
 			decodeRetrieveEntityRequest,
 			encodeRetrieveEntityResponse,
 		),
+		retrieveIDByName: kitgrpc.NewServer(
+			retrieveIDByNameEndpoint(svc),
+			decodeRetrieveIDByNameRequest,
+			encodeRetrieveIDByNameResponse,
+		),
+		moveParentGroupChannels: kitgrpc.NewServer(
+			moveParentGroupChannelsEndpoint(svc),
+			decodeMoveParentGroupChannelsRequest,
+			encodeMoveParentGroupChannelsResponse,
+		),
 	}
 }
 
@@ -76,6 +88,8 @@ func decodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}
 		clientType: req.GetClientType(),
 		channelID:  req.GetChannelId(),
 		connType:   connType,
+		protocol:   req.GetProtocol(),
+		subtopic:   req.GetSubtopic(),
 	}, nil
 }
 
@@ -154,6 +168,50 @@ func encodeRetrieveEntityResponse(_ context.Context, grpcRes interface{}) (inter
 	}, nil
 }
 
+func (s *grpcServer) RetrieveIDByName(ctx context.Context, req *grpcChannelsV1.RetrieveIDByNameReq) (*grpcChannelsV1.RetrieveIDByNameRes, error) {
+	_, res, err := s.retrieveIDByName.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*grpcChannelsV1.RetrieveIDByNameRes), nil
+}
+
+func decodeRetrieveIDByNameRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*grpcChannelsV1.RetrieveIDByNameReq)
+	return retrieveIDByNameReq{
+		domainID: req.GetDomainId(),
+		name:     req.GetName(),
+	}, nil
+}
+
+func encodeRetrieveIDByNameResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(retrieveIDByNameRes)
+	return &grpcChannelsV1.RetrieveIDByNameRes{Id: res.id}, nil
+}
+
+func (s *grpcServer) MoveParentGroupChannels(ctx context.Context, req *grpcChannelsV1.MoveParentGroupChannelsReq) (*grpcChannelsV1.MoveParentGroupChannelsRes, error) {
+	_, res, err := s.moveParentGroupChannels.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*grpcChannelsV1.MoveParentGroupChannelsRes), nil
+}
+
+func decodeMoveParentGroupChannelsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*grpcChannelsV1.MoveParentGroupChannelsReq)
+	return moveParentGroupChannelsReq{
+		parentGroupIDs: req.GetParentGroupIds(),
+		clientIDs:      req.GetClientIds(),
+		domainID:       req.GetDomainId(),
+		updatedBy:      req.GetUpdatedBy(),
+	}, nil
+}
+
+func encodeMoveParentGroupChannelsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(moveParentGroupChannelsRes)
+	return &grpcChannelsV1.MoveParentGroupChannelsRes{Ids: res.ids}, nil
+}
+
 func encodeError(err error) error {
 	switch {
 	case errors.Contains(err, nil):
@@ -161,6 +219,8 @@ func encodeError(err error) error {
 	case errors.Contains(err, errors.ErrMalformedEntity),
 		err == apiutil.ErrInvalidAuthKey,
 		err == apiutil.ErrMissingID,
+		err == apiutil.ErrMissingDomainID,
+		err == apiutil.ErrMissingName,
 		err == apiutil.ErrMissingMemberType,
 		err == apiutil.ErrMissingPolicySub,
 		err == apiutil.ErrMissingPolicyObj,
@@ -173,6 +233,10 @@ func encodeError(err error) error {
 		return status.Error(codes.Unauthenticated, err.Error())
 	case errors.Contains(err, svcerr.ErrAuthorization):
 		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Contains(err, svcerr.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Contains(err, svcerr.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
 	default:
 		return status.Error(codes.Internal, err.Error())
 	}
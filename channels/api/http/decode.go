@@ -17,8 +17,14 @@ import (
 )
 
 func decodeViewChannel(_ context.Context, r *http.Request) (interface{}, error) {
+	effective, err := apiutil.ReadBoolQuery(r, api.EffectiveKey, false)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
 	req := viewChannelReq{
-		id: chi.URLParam(r, "channelID"),
+		id:        chi.URLParam(r, "channelID"),
+		effective: effective,
 	}
 
 	return req, nil
@@ -207,6 +213,20 @@ func decodeRemoveChannelParentGroupStatus(_ context.Context, r *http.Request) (i
 	return req, nil
 }
 
+func decodeMoveChannel(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := moveChannelReq{
+		id: chi.URLParam(r, "channelID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+	return req, nil
+}
+
 func decodeChangeChannelStatus(_ context.Context, r *http.Request) (interface{}, error) {
 	req := changeChannelStatusReq{
 		id: chi.URLParam(r, "channelID"),
@@ -263,6 +283,32 @@ func decodeConnectRequest(_ context.Context, r *http.Request) (interface{}, erro
 	return req, nil
 }
 
+func decodeConnectGroupRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := connectGroupRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeDisconnectGroupRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := disconnectGroupRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
 func decodeDisconnectRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
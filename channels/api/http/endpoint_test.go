@@ -335,17 +335,19 @@ func TestViewChannelEndpoint(t *testing.T) {
 	defer gs.Close()
 
 	cases := []struct {
-		desc     string
-		token    string
-		id       string
-		domainID string
-		session  smqauthn.Session
-		svcResp  channels.Channel
-		svcErr   error
-		resp     channels.Channel
-		status   int
-		authnErr error
-		err      error
+		desc      string
+		token     string
+		id        string
+		domainID  string
+		query     string
+		effective bool
+		session   smqauthn.Session
+		svcResp   channels.Channel
+		svcErr    error
+		resp      channels.Channel
+		status    int
+		authnErr  error
+		err       error
 	}{
 		{
 			desc:     "view channel successfully",
@@ -358,6 +360,28 @@ func TestViewChannelEndpoint(t *testing.T) {
 			status:   http.StatusOK,
 			err:      nil,
 		},
+		{
+			desc:      "view channel with effective metadata successfully",
+			token:     validToken,
+			domainID:  validID,
+			id:        validID,
+			query:     "effective_metadata=true",
+			effective: true,
+			svcResp:   validChannelResp,
+			svcErr:    nil,
+			resp:      validChannelResp,
+			status:    http.StatusOK,
+			err:       nil,
+		},
+		{
+			desc:     "view channel with invalid effective metadata flag",
+			token:    validToken,
+			domainID: validID,
+			id:       validID,
+			query:    "effective_metadata=invalid",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
 		{
 			desc:     "view channel with invalid token",
 			token:    invalidToken,
@@ -403,14 +427,14 @@ func TestViewChannelEndpoint(t *testing.T) {
 			req := testRequest{
 				client: gs.Client(),
 				method: http.MethodGet,
-				url:    fmt.Sprintf("%s/%s/channels/%s", gs.URL, tc.domainID, tc.id),
+				url:    fmt.Sprintf("%s/%s/channels/%s?%s", gs.URL, tc.domainID, tc.id, tc.query),
 				token:  tc.token,
 			}
 			if tc.token == validToken {
 				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
 			}
 			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
-			svcCall := svc.On("ViewChannel", mock.Anything, tc.session, tc.id).Return(tc.svcResp, tc.svcErr)
+			svcCall := svc.On("ViewChannel", mock.Anything, tc.session, tc.id, tc.effective).Return(tc.svcResp, tc.svcErr)
 			res, err := req.make()
 			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 			var errRes respBody
@@ -1456,6 +1480,7 @@ func TestConnectChannelClientEndpoint(t *testing.T) {
 		id          string
 		domainID    string
 		data        string
+		scope       connections.Scope
 		session     smqauthn.Session
 		contentType string
 		svcErr      error
@@ -1474,6 +1499,38 @@ func TestConnectChannelClientEndpoint(t *testing.T) {
 			status:      http.StatusCreated,
 			err:         nil,
 		},
+		{
+			desc:        "connect channel client with subtopics successfully",
+			token:       validToken,
+			domainID:    validID,
+			id:          validID,
+			data:        fmt.Sprintf(`{"client_ids": ["%s"], "types": ["Publish"], "subtopics": ["sensors.temp.*"]}`, validID),
+			scope:       connections.Scope{Subtopics: []string{"sensors.temp.*"}},
+			contentType: contentType,
+			svcErr:      nil,
+			status:      http.StatusCreated,
+			err:         nil,
+		},
+		{
+			desc:        "connect channel client with invalid subtopic",
+			token:       validToken,
+			domainID:    validID,
+			id:          validID,
+			data:        fmt.Sprintf(`{"client_ids": ["%s"], "types": ["Publish"], "subtopics": ["sensors.temp*"]}`, validID),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         connections.ErrInvalidSubtopic,
+		},
+		{
+			desc:        "connect channel client with past expiration time",
+			token:       validToken,
+			domainID:    validID,
+			id:          validID,
+			data:        fmt.Sprintf(`{"client_ids": ["%s"], "types": ["Publish"], "expires_at": "2000-01-01T00:00:00Z"}`, validID),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         connections.ErrInvalidExpiration,
+		},
 		{
 			desc:        "connect channel client with invalid token",
 			token:       invalidToken,
@@ -1538,7 +1595,7 @@ func TestConnectChannelClientEndpoint(t *testing.T) {
 				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
 			}
 			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
-			svcCall := svc.On("Connect", mock.Anything, tc.session, []string{tc.id}, []string{validID}, []connections.ConnType{1}).Return(tc.svcErr)
+			svcCall := svc.On("Connect", mock.Anything, tc.session, []string{tc.id}, []string{validID}, []connections.ConnType{1}, tc.scope).Return(tc.svcErr)
 			res, err := req.make()
 			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
@@ -1661,6 +1718,7 @@ func TestConnectEndpoint(t *testing.T) {
 		domainID   string
 		clientIDs  []string
 		types      []connections.ConnType
+		scope      connections.Scope
 		session    smqauthn.Session
 		svcErr     error
 		status     int
@@ -1678,6 +1736,32 @@ func TestConnectEndpoint(t *testing.T) {
 			status:     http.StatusCreated,
 			err:        nil,
 		},
+		{
+			desc:       "connect with scope successfully",
+			token:      validToken,
+			domainID:   validID,
+			channelIDs: []string{validID},
+			clientIDs:  []string{validID},
+			types:      []connections.ConnType{1},
+			scope: connections.Scope{
+				ExpiresAt: time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC),
+				Subtopics: []string{"sensors.>"},
+			},
+			svcErr: nil,
+			status: http.StatusCreated,
+			err:    nil,
+		},
+		{
+			desc:       "connect with invalid subtopic",
+			token:      validToken,
+			domainID:   validID,
+			channelIDs: []string{validID},
+			clientIDs:  []string{validID},
+			types:      []connections.ConnType{1},
+			scope:      connections.Scope{Subtopics: []string{"sensors.>.temp"}},
+			status:     http.StatusBadRequest,
+			err:        connections.ErrInvalidSubtopic,
+		},
 		{
 			desc:       "connect with invalid token",
 			token:      invalidToken,
@@ -1764,13 +1848,136 @@ func TestConnectEndpoint(t *testing.T) {
 					"channel_ids": tc.channelIDs,
 					"client_ids":  tc.clientIDs,
 					"types":       tc.types,
+					"expires_at":  tc.scope.ExpiresAt,
+					"subtopics":   tc.scope.Subtopics,
+				})),
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("Connect", mock.Anything, tc.session, tc.channelIDs, tc.clientIDs, tc.types, tc.scope).Return(tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestConnectGroupEndpoint(t *testing.T) {
+	gs, svc, authn := newChannelsServer()
+	defer gs.Close()
+
+	cases := []struct {
+		desc       string
+		token      string
+		domainID   string
+		groupID    string
+		channelIDs []string
+		clientIDs  []string
+		types      []connections.ConnType
+		session    smqauthn.Session
+		svcErr     error
+		status     int
+		authnErr   error
+		err        error
+	}{
+		{
+			desc:       "connect group successfully",
+			token:      validToken,
+			domainID:   validID,
+			groupID:    validID,
+			channelIDs: []string{validID},
+			clientIDs:  []string{validID},
+			types:      []connections.ConnType{1},
+			status:     http.StatusCreated,
+			err:        nil,
+		},
+		{
+			desc:       "connect group with channels only",
+			token:      validToken,
+			domainID:   validID,
+			groupID:    validID,
+			channelIDs: []string{validID},
+			types:      []connections.ConnType{1},
+			status:     http.StatusCreated,
+			err:        nil,
+		},
+		{
+			desc:       "connect group with invalid token",
+			token:      invalidToken,
+			domainID:   validID,
+			groupID:    validID,
+			channelIDs: []string{validID},
+			types:      []connections.ConnType{1},
+			status:     http.StatusUnauthorized,
+			authnErr:   svcerr.ErrAuthentication,
+			err:        svcerr.ErrAuthentication,
+		},
+		{
+			desc:       "connect group with service error",
+			token:      validToken,
+			domainID:   validID,
+			groupID:    validID,
+			channelIDs: []string{validID},
+			types:      []connections.ConnType{1},
+			svcErr:     svcerr.ErrAuthorization,
+			status:     http.StatusForbidden,
+			err:        svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "connect group with invalid group id",
+			token:      validToken,
+			domainID:   validID,
+			groupID:    "invalid",
+			channelIDs: []string{validID},
+			types:      []connections.ConnType{1},
+			status:     http.StatusBadRequest,
+			err:        apiutil.ErrInvalidIDFormat,
+		},
+		{
+			desc:     "connect group with empty channel and client ids",
+			token:    validToken,
+			domainID: validID,
+			groupID:  validID,
+			types:    []connections.ConnType{1},
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrMissingID,
+		},
+		{
+			desc:       "connect group with empty types",
+			token:      validToken,
+			domainID:   validID,
+			groupID:    validID,
+			channelIDs: []string{validID},
+			types:      []connections.ConnType{},
+			status:     http.StatusBadRequest,
+			err:        apiutil.ErrMissingConnectionType,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      gs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/channels/groups/connect", gs.URL, tc.domainID),
+				token:       tc.token,
+				contentType: contentType,
+				body: strings.NewReader(toJSON(map[string]interface{}{
+					"group_id":    tc.groupID,
+					"channel_ids": tc.channelIDs,
+					"client_ids":  tc.clientIDs,
+					"types":       tc.types,
 				})),
 			}
 			if tc.token == validToken {
 				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
 			}
 			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
-			svcCall := svc.On("Connect", mock.Anything, tc.session, tc.channelIDs, tc.clientIDs, tc.types).Return(tc.svcErr)
+			svcCall := svc.On("ConnectGroup", mock.Anything, tc.session, tc.groupID, tc.channelIDs, tc.clientIDs, tc.types).Return(tc.svcErr)
 			res, err := req.make()
 			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
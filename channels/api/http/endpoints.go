@@ -82,7 +82,7 @@ func viewChannelEndpoint(svc channels.Service) endpoint.Endpoint {
 			return nil, svcerr.ErrAuthentication
 		}
 
-		c, err := svc.ViewChannel(ctx, session, req.id)
+		c, err := svc.ViewChannel(ctx, session, req.id, req.effective)
 		if err != nil {
 			return nil, err
 		}
@@ -240,6 +240,27 @@ func removeChannelParentGroupEndpoint(svc channels.Service) endpoint.Endpoint {
 	}
 }
 
+func moveChannelEndpoint(svc channels.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(moveChannelReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		ch, err := svc.MoveChannel(ctx, session, req.id, req.DomainID)
+		if err != nil {
+			return nil, err
+		}
+
+		return updateChannelRes{Channel: ch}, nil
+	}
+}
+
 func enableChannelEndpoint(svc channels.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(changeChannelStatusReq)
@@ -294,7 +315,7 @@ func connectChannelClientEndpoint(svc channels.Service) endpoint.Endpoint {
 			return nil, svcerr.ErrAuthentication
 		}
 
-		if err := svc.Connect(ctx, session, []string{req.channelID}, req.ClientIDs, req.Types); err != nil {
+		if err := svc.Connect(ctx, session, []string{req.channelID}, req.ClientIDs, req.Types, req.Scope); err != nil {
 			return nil, err
 		}
 
@@ -334,7 +355,27 @@ func connectEndpoint(svc channels.Service) endpoint.Endpoint {
 			return nil, svcerr.ErrAuthentication
 		}
 
-		if err := svc.Connect(ctx, session, req.ChannelIds, req.ClientIds, req.Types); err != nil {
+		if err := svc.Connect(ctx, session, req.ChannelIds, req.ClientIds, req.Types, req.Scope); err != nil {
+			return nil, err
+		}
+
+		return connectRes{}, nil
+	}
+}
+
+func connectGroupEndpoint(svc channels.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(connectGroupRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		if err := svc.ConnectGroup(ctx, session, req.GroupID, req.ChannelIds, req.ClientIds, req.Types); err != nil {
 			return nil, err
 		}
 
@@ -342,6 +383,26 @@ func connectEndpoint(svc channels.Service) endpoint.Endpoint {
 	}
 }
 
+func disconnectGroupEndpoint(svc channels.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(disconnectGroupRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		if err := svc.DisconnectGroup(ctx, session, req.GroupID, req.ChannelIds, req.ClientIds, req.Types); err != nil {
+			return nil, err
+		}
+
+		return disconnectRes{}, nil
+	}
+}
+
 func disconnectEndpoint(svc channels.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(disconnectRequest)
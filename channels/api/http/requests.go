@@ -5,6 +5,7 @@ package http
 
 import (
 	"strings"
+	"time"
 
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
@@ -53,7 +54,8 @@ func (req createChannelsReq) validate() error {
 }
 
 type viewChannelReq struct {
-	id string
+	id        string
+	effective bool
 }
 
 func (req viewChannelReq) validate() error {
@@ -153,6 +155,22 @@ func (req removeChannelParentGroupReq) validate() error {
 	return nil
 }
 
+type moveChannelReq struct {
+	id       string
+	DomainID string `json:"domain_id"`
+}
+
+func (req moveChannelReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.DomainID == "" {
+		return apiutil.ErrMissingDomainID
+	}
+
+	return nil
+}
+
 type changeChannelStatusReq struct {
 	id string
 }
@@ -169,6 +187,7 @@ type connectChannelClientsRequest struct {
 	channelID string
 	ClientIDs []string               `json:"client_ids,omitempty"`
 	Types     []connections.ConnType `json:"types,omitempty"`
+	connections.Scope
 }
 
 func (req *connectChannelClientsRequest) validate() error {
@@ -190,7 +209,7 @@ func (req *connectChannelClientsRequest) validate() error {
 		return apiutil.ErrMissingConnectionType
 	}
 
-	return nil
+	return req.Scope.Validate(time.Now())
 }
 
 type disconnectChannelClientsRequest struct {
@@ -229,6 +248,7 @@ type connectRequest struct {
 	ChannelIds []string               `json:"channel_ids,omitempty"`
 	ClientIds  []string               `json:"client_ids,omitempty"`
 	Types      []connections.ConnType `json:"types,omitempty"`
+	connections.Scope
 }
 
 func (req *connectRequest) validate() error {
@@ -255,9 +275,46 @@ func (req *connectRequest) validate() error {
 		return apiutil.ErrMissingConnectionType
 	}
 
+	return req.Scope.Validate(time.Now())
+}
+
+type connectGroupRequest struct {
+	GroupID    string                 `json:"group_id,omitempty"`
+	ChannelIds []string               `json:"channel_ids,omitempty"`
+	ClientIds  []string               `json:"client_ids,omitempty"`
+	Types      []connections.ConnType `json:"types,omitempty"`
+}
+
+func (req *connectGroupRequest) validate() error {
+	if err := api.ValidateUUID(req.GroupID); err != nil {
+		return err
+	}
+
+	if len(req.ChannelIds) == 0 && len(req.ClientIds) == 0 {
+		return apiutil.ErrMissingID
+	}
+	for _, cid := range req.ChannelIds {
+		if err := api.ValidateUUID(cid); err != nil {
+			return err
+		}
+	}
+	for _, tid := range req.ClientIds {
+		if err := api.ValidateUUID(tid); err != nil {
+			return err
+		}
+	}
+
+	if len(req.Types) == 0 {
+		return apiutil.ErrMissingConnectionType
+	}
+
 	return nil
 }
 
+type disconnectGroupRequest struct {
+	connectGroupRequest
+}
+
 type disconnectRequest struct {
 	ChannelIds []string               `json:"channel_ids,omitempty"`
 	ClientIds  []string               `json:"client_ids,omitempty"`
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
@@ -386,6 +387,28 @@ func TestConnectChannelClientsReqValidate(t *testing.T) {
 				Types:     []connections.ConnType{connections.Publish},
 			},
 			err: apiutil.ErrInvalidIDFormat,
+		}, {
+			desc: "valid request with scope",
+			req: connectChannelClientsRequest{
+				channelID: valid,
+				ClientIDs: []string{testsutil.GenerateUUID(t)},
+				Types:     []connections.ConnType{connections.Publish},
+				Scope: connections.Scope{
+					ExpiresAt: time.Now().Add(time.Hour),
+					Subtopics: []string{"sensors.temp.*"},
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "past expiration time",
+			req: connectChannelClientsRequest{
+				channelID: valid,
+				ClientIDs: []string{testsutil.GenerateUUID(t)},
+				Types:     []connections.ConnType{connections.Publish},
+				Scope:     connections.Scope{ExpiresAt: time.Now().Add(-time.Hour)},
+			},
+			err: connections.ErrInvalidExpiration,
 		},
 	}
 	for _, tc := range cases {
@@ -503,6 +526,95 @@ func TestConnectReqValidate(t *testing.T) {
 			},
 			err: apiutil.ErrMissingConnectionType,
 		},
+		{
+			desc: "valid request with scope",
+			req: connectRequest{
+				ChannelIds: []string{testsutil.GenerateUUID(t)},
+				ClientIds:  []string{testsutil.GenerateUUID(t)},
+				Types:      []connections.ConnType{connections.Publish},
+				Scope: connections.Scope{
+					ExpiresAt: time.Now().Add(time.Hour),
+					Subtopics: []string{"sensors.>"},
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "past expiration time",
+			req: connectRequest{
+				ChannelIds: []string{testsutil.GenerateUUID(t)},
+				ClientIds:  []string{testsutil.GenerateUUID(t)},
+				Types:      []connections.ConnType{connections.Publish},
+				Scope:      connections.Scope{ExpiresAt: time.Now().Add(-time.Hour)},
+			},
+			err: connections.ErrInvalidExpiration,
+		},
+	}
+	for _, tc := range cases {
+		err := tc.req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestConnectGroupReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  connectGroupRequest
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: connectGroupRequest{
+				GroupID:    testsutil.GenerateUUID(t),
+				ChannelIds: []string{testsutil.GenerateUUID(t)},
+				ClientIds:  []string{testsutil.GenerateUUID(t)},
+				Types:      []connections.ConnType{connections.Publish},
+			},
+			err: nil,
+		},
+		{
+			desc: "valid request with client IDs only",
+			req: connectGroupRequest{
+				GroupID:   testsutil.GenerateUUID(t),
+				ClientIds: []string{testsutil.GenerateUUID(t)},
+				Types:     []connections.ConnType{connections.Publish},
+			},
+			err: nil,
+		},
+		{
+			desc: "invalid group ID",
+			req: connectGroupRequest{
+				GroupID:    "invalid",
+				ChannelIds: []string{testsutil.GenerateUUID(t)},
+				Types:      []connections.ConnType{connections.Publish},
+			},
+			err: apiutil.ErrInvalidIDFormat,
+		},
+		{
+			desc: "missing channel and client IDs",
+			req: connectGroupRequest{
+				GroupID: testsutil.GenerateUUID(t),
+				Types:   []connections.ConnType{connections.Publish},
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "invalid client ID",
+			req: connectGroupRequest{
+				GroupID:   testsutil.GenerateUUID(t),
+				ClientIds: []string{"invalid"},
+				Types:     []connections.ConnType{connections.Publish},
+			},
+			err: apiutil.ErrInvalidIDFormat,
+		},
+		{
+			desc: "missing connection types",
+			req: connectGroupRequest{
+				GroupID:    testsutil.GenerateUUID(t),
+				ChannelIds: []string{testsutil.GenerateUUID(t)},
+			},
+			err: apiutil.ErrMissingConnectionType,
+		},
 	}
 	for _, tc := range cases {
 		err := tc.req.validate()
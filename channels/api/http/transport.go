@@ -64,6 +64,20 @@ func MakeHandler(svc channels.Service, authn smqauthn.Authentication, mux *chi.M
 			opts...,
 		), "disconnect").ServeHTTP)
 
+		r.Post("/groups/connect", otelhttp.NewHandler(kithttp.NewServer(
+			connectGroupEndpoint(svc),
+			decodeConnectGroupRequest,
+			api.EncodeResponse,
+			opts...,
+		), "connect_group").ServeHTTP)
+
+		r.Post("/groups/disconnect", otelhttp.NewHandler(kithttp.NewServer(
+			disconnectGroupEndpoint(svc),
+			decodeDisconnectGroupRequest,
+			api.EncodeResponse,
+			opts...,
+		), "disconnect_group").ServeHTTP)
+
 		r = roleManagerHttp.EntityAvailableActionsRouter(svc, d, r, opts)
 
 		r.Route("/{channelID}", func(r chi.Router) {
@@ -123,6 +137,13 @@ func MakeHandler(svc channels.Service, authn smqauthn.Authentication, mux *chi.M
 				opts...,
 			), "remove_channel_parent_group").ServeHTTP)
 
+			r.Post("/move", otelhttp.NewHandler(kithttp.NewServer(
+				moveChannelEndpoint(svc),
+				decodeMoveChannel,
+				api.EncodeResponse,
+				opts...,
+			), "move_channel").ServeHTTP)
+
 			r.Post("/connect", otelhttp.NewHandler(kithttp.NewServer(
 				connectChannelClientEndpoint(svc),
 				decodeConnectChannelClientRequest,
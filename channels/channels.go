@@ -74,6 +74,19 @@ type Connection struct {
 	ChannelID string
 	DomainID  string
 	Type      connections.ConnType
+	connections.Scope
+}
+
+// GroupConnection is a dynamic connection between a channel and all the clients
+// of a group, or between a client and all the channels of a group. Exactly one
+// of ChannelID and ClientID is set. The connection applies to the whole group
+// hierarchy, including entities added to it after the connection is created.
+type GroupConnection struct {
+	GroupID   string
+	ChannelID string
+	ClientID  string
+	DomainID  string
+	Type      connections.ConnType
 }
 
 type AuthzReq struct {
@@ -82,6 +95,12 @@ type AuthzReq struct {
 	ClientID   string
 	ClientType string
 	Type       connections.ConnType
+	// Protocol is the messaging protocol used by the client. If set, the
+	// protocol must be allowed in the domain settings.
+	Protocol string
+	// Subtopic is the subtopic the client publishes or subscribes to. It is
+	// checked against the subtopics the client connection is restricted to.
+	Subtopic string
 }
 
 //go:generate mockery --name Service  --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
@@ -90,8 +109,9 @@ type Service interface {
 	CreateChannels(ctx context.Context, session authn.Session, channels ...Channel) ([]Channel, []roles.RoleProvision, error)
 
 	// ViewChannel retrieves data about the channel identified by the provided
-	// ID, that belongs to the user.
-	ViewChannel(ctx context.Context, session authn.Session, id string) (Channel, error)
+	// ID, that belongs to the user. With effective set, the channel metadata is
+	// merged with metadata inherited from its parent group hierarchy.
+	ViewChannel(ctx context.Context, session authn.Session, id string, effective bool) (Channel, error)
 
 	// UpdateChannel updates the channel identified by the provided ID, that
 	// belongs to the user.
@@ -114,16 +134,27 @@ type Service interface {
 	// belongs to the user.
 	RemoveChannel(ctx context.Context, session authn.Session, id string) error
 
-	// Connect adds clients to the channels list of connected clients.
-	Connect(ctx context.Context, session authn.Session, chIDs, clIDs []string, connType []connections.ConnType) error
+	// Connect adds clients to the channels list of connected clients. The
+	// connections are restricted by the given scope, if any.
+	Connect(ctx context.Context, session authn.Session, chIDs, clIDs []string, connType []connections.ConnType, scope connections.Scope) error
 
 	// Disconnect removes clients from the channels list of connected clients.
 	Disconnect(ctx context.Context, session authn.Session, chIDs, clIDs []string, connType []connections.ConnType) error
 
+	// ConnectGroup connects the channels to all the clients of the group and the
+	// clients to all the channels of the group.
+	ConnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) error
+
+	// DisconnectGroup removes the connections created with ConnectGroup.
+	DisconnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) error
+
 	SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) error
 
 	RemoveParentGroup(ctx context.Context, session authn.Session, id string) error
 
+	// MoveChannel moves the channel with the given ID to the domain with the given ID.
+	MoveChannel(ctx context.Context, session authn.Session, id, domainID string) (Channel, error)
+
 	roles.RoleManager
 }
 
@@ -152,6 +183,9 @@ type Repository interface {
 	// RetrieveAll retrieves the subset of channels.
 	RetrieveAll(ctx context.Context, pm PageMetadata) (Page, error)
 
+	// RetrieveByName retrieves the channel having the provided name in the domain.
+	RetrieveByName(ctx context.Context, domainID, name string) (Channel, error)
+
 	// Remove removes the channel having the provided identifier
 	Remove(ctx context.Context, ids ...string) error
 
@@ -161,13 +195,55 @@ type Repository interface {
 	// RemoveParentGroup remove parent group id fr given chanel id
 	RemoveParentGroup(ctx context.Context, ch Channel) error
 
+	// ChangeDomain moves the channel to the given domain and removes its parent group.
+	ChangeDomain(ctx context.Context, ch Channel) (Channel, error)
+
+	// ChangeParentGroupsDomain moves the channels of the given parent groups to
+	// the domain of the given channel. The channels keep their parent groups.
+	// The group connections of the moved channels, of the given clients and of
+	// the given parent groups are removed.
+	ChangeParentGroupsDomain(ctx context.Context, ch Channel, parentGroupIDs, clientIDs []string) ([]Channel, error)
+
+	// RetrievePathMetadata retrieves metadata of all groups on the given
+	// path, ordered from the root group.
+	RetrievePathMetadata(ctx context.Context, path string) ([]clients.Metadata, error)
+
+	// AddConnections saves the connections. The expired connections between
+	// the same channels and clients are replaced.
 	AddConnections(ctx context.Context, conns []Connection) error
 
+	// RemoveExpiredConnections removes the expired connections and returns
+	// the number of the removed connections.
+	RemoveExpiredConnections(ctx context.Context) (uint64, error)
+
 	RemoveConnections(ctx context.Context, conns []Connection) error
 
+	// RetrieveEntitiesConnections retrieves the connections of the given
+	// channels and of the given clients.
+	RetrieveEntitiesConnections(ctx context.Context, channelIDs, clientIDs []string) ([]Connection, error)
+
 	CheckConnection(ctx context.Context, conn Connection) error
 
-	ClientAuthorize(ctx context.Context, conn Connection) error
+	// ClientAuthorize checks whether the client is connected to the channel,
+	// either directly or through a connection to a group of channels. Direct
+	// connections must not be expired and must allow the given subtopic.
+	// It returns the ID of the channel domain.
+	ClientAuthorize(ctx context.Context, conn Connection, subtopic string) (string, error)
+
+	AddGroupConnections(ctx context.Context, conns []GroupConnection) error
+
+	RemoveGroupConnections(ctx context.Context, conns []GroupConnection) error
+
+	CheckGroupConnection(ctx context.Context, conn GroupConnection) error
+
+	// DoesChannelHaveGroupConnections checks whether the channel is connected
+	// to any group of clients.
+	DoesChannelHaveGroupConnections(ctx context.Context, id string) (bool, error)
+
+	// ClientGroupAuthorize checks whether the channel is connected to the group
+	// of clients containing the given client parent group. It returns the ID
+	// of the channel domain.
+	ClientGroupAuthorize(ctx context.Context, conn Connection, parentGroupID string) (string, error)
 
 	ChannelConnectionsCount(ctx context.Context, id string) (uint64, error)
 
@@ -179,6 +255,9 @@ type Repository interface {
 
 	RetrieveParentGroupChannels(ctx context.Context, parentGroupID string) ([]Channel, error)
 
+	// UnsetParentGroupFromChannels removes the parent group from its channels
+	// and removes the group connections of the group, since the group is
+	// being deleted.
 	UnsetParentGroupFromChannels(ctx context.Context, parentGroupID string) error
 
 	roles.Repository
@@ -14,17 +14,20 @@ import (
 )
 
 const (
-	channelPrefix       = "channels."
-	channelCreate       = channelPrefix + "create"
-	channelUpdate       = channelPrefix + "update"
-	channelChangeStatus = channelPrefix + "change_status"
-	channelRemove       = channelPrefix + "remove"
-	channelView         = channelPrefix + "view"
-	channelList         = channelPrefix + "list"
-	channelConnect      = channelPrefix + "connect"
-	channelDisconnect   = channelPrefix + "disconnect"
-	channelSetParent    = channelPrefix + "set_parent"
-	channelRemoveParent = channelPrefix + "remove_parent"
+	channelPrefix          = "channels."
+	channelCreate          = channelPrefix + "create"
+	channelUpdate          = channelPrefix + "update"
+	channelChangeStatus    = channelPrefix + "change_status"
+	channelRemove          = channelPrefix + "remove"
+	channelView            = channelPrefix + "view"
+	channelList            = channelPrefix + "list"
+	channelConnect         = channelPrefix + "connect"
+	channelDisconnect      = channelPrefix + "disconnect"
+	channelConnectGroup    = channelPrefix + "connect_group"
+	channelDisconnectGroup = channelPrefix + "disconnect_group"
+	channelSetParent       = channelPrefix + "set_parent"
+	channelRemoveParent    = channelPrefix + "remove_parent"
+	channelMove            = channelPrefix + "move"
 )
 
 var (
@@ -36,6 +39,9 @@ var (
 	_ events.Event = (*removeChannelEvent)(nil)
 	_ events.Event = (*connectEvent)(nil)
 	_ events.Event = (*disconnectEvent)(nil)
+	_ events.Event = (*connectGroupEvent)(nil)
+	_ events.Event = (*disconnectGroupEvent)(nil)
+	_ events.Event = (*moveChannelEvent)(nil)
 )
 
 type createChannelEvent struct {
@@ -283,11 +289,12 @@ type connectEvent struct {
 	chIDs []string
 	thIDs []string
 	types []connections.ConnType
+	scope connections.Scope
 	authn.Session
 }
 
 func (ce connectEvent) Encode() (map[string]interface{}, error) {
-	return map[string]interface{}{
+	val := map[string]interface{}{
 		"operation":   channelConnect,
 		"client_ids":  ce.thIDs,
 		"channel_ids": ce.chIDs,
@@ -296,7 +303,15 @@ func (ce connectEvent) Encode() (map[string]interface{}, error) {
 		"user_id":     ce.UserID,
 		"token_type":  ce.Type.String(),
 		"super_admin": ce.SuperAdmin,
-	}, nil
+	}
+	if !ce.scope.ExpiresAt.IsZero() {
+		val["expires_at"] = ce.scope.ExpiresAt
+	}
+	if len(ce.scope.Subtopics) > 0 {
+		val["subtopics"] = ce.scope.Subtopics
+	}
+
+	return val, nil
 }
 
 type disconnectEvent struct {
@@ -319,6 +334,50 @@ func (de disconnectEvent) Encode() (map[string]interface{}, error) {
 	}, nil
 }
 
+type connectGroupEvent struct {
+	groupID string
+	chIDs   []string
+	clIDs   []string
+	types   []connections.ConnType
+	authn.Session
+}
+
+func (cge connectGroupEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   channelConnectGroup,
+		"group_id":    cge.groupID,
+		"client_ids":  cge.clIDs,
+		"channel_ids": cge.chIDs,
+		"types":       cge.types,
+		"domain":      cge.DomainID,
+		"user_id":     cge.UserID,
+		"token_type":  cge.Type.String(),
+		"super_admin": cge.SuperAdmin,
+	}, nil
+}
+
+type disconnectGroupEvent struct {
+	groupID string
+	chIDs   []string
+	clIDs   []string
+	types   []connections.ConnType
+	authn.Session
+}
+
+func (dge disconnectGroupEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   channelDisconnectGroup,
+		"group_id":    dge.groupID,
+		"client_ids":  dge.clIDs,
+		"channel_ids": dge.chIDs,
+		"types":       dge.types,
+		"domain":      dge.DomainID,
+		"user_id":     dge.UserID,
+		"token_type":  dge.Type.String(),
+		"super_admin": dge.SuperAdmin,
+	}, nil
+}
+
 type setParentGroupEvent struct {
 	id            string
 	parentGroupID string
@@ -352,3 +411,25 @@ func (rpge removeParentGroupEvent) Encode() (map[string]interface{}, error) {
 		"super_admin": rpge.SuperAdmin,
 	}, nil
 }
+
+type moveChannelEvent struct {
+	id        string
+	domainID  string
+	updatedAt time.Time
+	updatedBy string
+	authn.Session
+}
+
+func (mce moveChannelEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   channelMove,
+		"id":          mce.id,
+		"domain":      mce.DomainID,
+		"new_domain":  mce.domainID,
+		"updated_at":  mce.updatedAt,
+		"updated_by":  mce.updatedBy,
+		"user_id":     mce.UserID,
+		"token_type":  mce.Type.String(),
+		"super_admin": mce.SuperAdmin,
+	}, nil
+}
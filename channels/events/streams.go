@@ -93,8 +93,8 @@ func (es *eventStore) update(ctx context.Context, operation string, session auth
 	return ch, nil
 }
 
-func (es *eventStore) ViewChannel(ctx context.Context, session authn.Session, id string) (channels.Channel, error) {
-	chann, err := es.svc.ViewChannel(ctx, session, id)
+func (es *eventStore) ViewChannel(ctx context.Context, session authn.Session, id string, effective bool) (channels.Channel, error) {
+	chann, err := es.svc.ViewChannel(ctx, session, id, effective)
 	if err != nil {
 		return chann, err
 	}
@@ -193,8 +193,8 @@ func (es *eventStore) RemoveChannel(ctx context.Context, session authn.Session,
 	return nil
 }
 
-func (es *eventStore) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
-	if err := es.svc.Connect(ctx, session, chIDs, thIDs, connTypes); err != nil {
+func (es *eventStore) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType, scope connections.Scope) error {
+	if err := es.svc.Connect(ctx, session, chIDs, thIDs, connTypes, scope); err != nil {
 		return err
 	}
 
@@ -202,6 +202,7 @@ func (es *eventStore) Connect(ctx context.Context, session authn.Session, chIDs,
 		chIDs:   chIDs,
 		thIDs:   thIDs,
 		types:   connTypes,
+		scope:   scope,
 		Session: session,
 	}
 
@@ -231,6 +232,46 @@ func (es *eventStore) Disconnect(ctx context.Context, session authn.Session, chI
 	return nil
 }
 
+func (es *eventStore) ConnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) error {
+	if err := es.svc.ConnectGroup(ctx, session, groupID, chIDs, clIDs, connTypes); err != nil {
+		return err
+	}
+
+	event := connectGroupEvent{
+		groupID: groupID,
+		chIDs:   chIDs,
+		clIDs:   clIDs,
+		types:   connTypes,
+		Session: session,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (es *eventStore) DisconnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) error {
+	if err := es.svc.DisconnectGroup(ctx, session, groupID, chIDs, clIDs, connTypes); err != nil {
+		return err
+	}
+
+	event := disconnectGroupEvent{
+		groupID: groupID,
+		chIDs:   chIDs,
+		clIDs:   clIDs,
+		types:   connTypes,
+		Session: session,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (es *eventStore) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) (err error) {
 	if err := es.svc.SetParentGroup(ctx, session, parentGroupID, id); err != nil {
 		return err
@@ -265,3 +306,24 @@ func (es *eventStore) RemoveParentGroup(ctx context.Context, session authn.Sessi
 
 	return nil
 }
+
+func (es *eventStore) MoveChannel(ctx context.Context, session authn.Session, id, domainID string) (channels.Channel, error) {
+	ch, err := es.svc.MoveChannel(ctx, session, id, domainID)
+	if err != nil {
+		return ch, err
+	}
+
+	event := moveChannelEvent{
+		id:        id,
+		domainID:  domainID,
+		updatedAt: ch.UpdatedAt,
+		updatedBy: ch.UpdatedBy,
+		Session:   session,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return ch, err
+	}
+
+	return ch, nil
+}
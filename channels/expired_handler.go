@@ -0,0 +1,53 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// The ExpiredConnectionsHandler is a cron job that runs periodically to remove the connections
+// which have expired. The expired connections no longer authorize the clients, so they are
+// removed to keep them out of the connection listings and to free the storage.
+
+package channels
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type expiredHandler struct {
+	repo          Repository
+	checkInterval time.Duration
+	logger        *slog.Logger
+}
+
+func NewExpiredConnectionsHandler(ctx context.Context, repo Repository, checkInterval time.Duration, logger *slog.Logger) {
+	handler := &expiredHandler{
+		repo:          repo,
+		checkInterval: checkInterval,
+		logger:        logger,
+	}
+
+	go func() {
+		ticker := time.NewTicker(handler.checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				handler.handle(ctx)
+			}
+		}
+	}()
+}
+
+func (h *expiredHandler) handle(ctx context.Context) {
+	removed, err := h.repo.RemoveExpiredConnections(ctx)
+	if err != nil {
+		h.logger.Error("failed to remove expired connections", slog.Any("error", err))
+		return
+	}
+	if removed > 0 {
+		h.logger.Info("expired connections removed", slog.Uint64("count", removed))
+	}
+}
@@ -32,11 +32,14 @@ var (
 	errDisconnect               = errors.New("not authorized to disconnect from channel")
 	errSetParentGroup           = errors.New("not authorized to set parent group to channel")
 	errRemoveParentGroup        = errors.New("not authorized to remove parent group from channel")
+	errMove                     = errors.New("not authorized to move channel")
 	errDomainCreateChannels     = errors.New("not authorized to create channel in domain")
 	errGroupSetChildChannels    = errors.New("not authorized to set child channel for group")
 	errGroupRemoveChildChannels = errors.New("not authorized to remove child channel for group")
 	errClientDisConnectChannels = errors.New("not authorized to disconnect channel for client")
 	errClientConnectChannels    = errors.New("not authorized to connect channel for client")
+	errGroupConnectClients      = errors.New("not authorized to connect clients of group")
+	errGroupConnectChannels     = errors.New("not authorized to connect channels of group")
 )
 
 var _ channels.Service = (*authorizationMiddleware)(nil)
@@ -87,6 +90,7 @@ func (am *authorizationMiddleware) CreateChannels(ctx context.Context, session a
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainChannelsScope,
@@ -123,11 +127,12 @@ func (am *authorizationMiddleware) CreateChannels(ctx context.Context, session a
 	return am.svc.CreateChannels(ctx, session, chs...)
 }
 
-func (am *authorizationMiddleware) ViewChannel(ctx context.Context, session authn.Session, id string) (channels.Channel, error) {
+func (am *authorizationMiddleware) ViewChannel(ctx context.Context, session authn.Session, id string, effective bool) (channels.Channel, error) {
 	if session.Type == authn.PersonalAccessToken {
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainChannelsScope,
@@ -147,7 +152,7 @@ func (am *authorizationMiddleware) ViewChannel(ctx context.Context, session auth
 	}); err != nil {
 		return channels.Channel{}, errors.Wrap(err, errView)
 	}
-	return am.svc.ViewChannel(ctx, session, id)
+	return am.svc.ViewChannel(ctx, session, id, effective)
 }
 
 func (am *authorizationMiddleware) ListChannels(ctx context.Context, session authn.Session, pm channels.PageMetadata) (channels.Page, error) {
@@ -155,6 +160,7 @@ func (am *authorizationMiddleware) ListChannels(ctx context.Context, session aut
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainChannelsScope,
@@ -176,6 +182,7 @@ func (am *authorizationMiddleware) ListUserChannels(ctx context.Context, session
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainChannelsScope,
@@ -196,6 +203,7 @@ func (am *authorizationMiddleware) UpdateChannel(ctx context.Context, session au
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainChannelsScope,
@@ -223,6 +231,7 @@ func (am *authorizationMiddleware) UpdateChannelTags(ctx context.Context, sessio
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainChannelsScope,
@@ -250,6 +259,7 @@ func (am *authorizationMiddleware) EnableChannel(ctx context.Context, session au
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainChannelsScope,
@@ -277,6 +287,7 @@ func (am *authorizationMiddleware) DisableChannel(ctx context.Context, session a
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainChannelsScope,
@@ -304,6 +315,7 @@ func (am *authorizationMiddleware) RemoveChannel(ctx context.Context, session au
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainChannelsScope,
@@ -326,11 +338,12 @@ func (am *authorizationMiddleware) RemoveChannel(ctx context.Context, session au
 	return am.svc.RemoveChannel(ctx, session, id)
 }
 
-func (am *authorizationMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
+func (am *authorizationMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType, scope connections.Scope) error {
 	if session.Type == authn.PersonalAccessToken {
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainChannelsScope,
@@ -343,6 +356,7 @@ func (am *authorizationMiddleware) Connect(ctx context.Context, session authn.Se
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainClientsScope,
@@ -375,7 +389,7 @@ func (am *authorizationMiddleware) Connect(ctx context.Context, session authn.Se
 			return errors.Wrap(err, errClientConnectChannels)
 		}
 	}
-	return am.svc.Connect(ctx, session, chIDs, thIDs, connTypes)
+	return am.svc.Connect(ctx, session, chIDs, thIDs, connTypes, scope)
 }
 
 func (am *authorizationMiddleware) Disconnect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
@@ -383,6 +397,7 @@ func (am *authorizationMiddleware) Disconnect(ctx context.Context, session authn
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainChannelsScope,
@@ -395,6 +410,7 @@ func (am *authorizationMiddleware) Disconnect(ctx context.Context, session authn
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainClientsScope,
@@ -431,11 +447,190 @@ func (am *authorizationMiddleware) Disconnect(ctx context.Context, session authn
 	return am.svc.Disconnect(ctx, session, chIDs, thIDs, connTypes)
 }
 
+func (am *authorizationMiddleware) ConnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) error {
+	if session.Type == authn.PersonalAccessToken {
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainGroupsScope,
+			Operation:                auth.CreateOp,
+			EntityIDs:                []string{groupID},
+		}); err != nil {
+			return errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainChannelsScope,
+			Operation:                auth.CreateOp,
+			EntityIDs:                chIDs,
+		}); err != nil {
+			return errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainClientsScope,
+			Operation:                auth.CreateOp,
+			EntityIDs:                clIDs,
+		}); err != nil {
+			return errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+	}
+	if len(chIDs) > 0 {
+		if err := am.extAuthorize(ctx, channels.GroupsOpConnectClients, smqauthz.PolicyReq{
+			Domain:      session.DomainID,
+			SubjectType: policies.UserType,
+			Subject:     session.DomainUserID,
+			ObjectType:  policies.GroupType,
+			Object:      groupID,
+		}); err != nil {
+			return errors.Wrap(err, errGroupConnectClients)
+		}
+	}
+	for _, chID := range chIDs {
+		if err := am.authorize(ctx, channels.OpConnectClient, smqauthz.PolicyReq{
+			Domain:      session.DomainID,
+			SubjectType: policies.UserType,
+			Subject:     session.DomainUserID,
+			ObjectType:  policies.ChannelType,
+			Object:      chID,
+		}); err != nil {
+			return errors.Wrap(err, errConnect)
+		}
+	}
+
+	if len(clIDs) > 0 {
+		if err := am.extAuthorize(ctx, channels.GroupsOpConnectChannels, smqauthz.PolicyReq{
+			Domain:      session.DomainID,
+			SubjectType: policies.UserType,
+			Subject:     session.DomainUserID,
+			ObjectType:  policies.GroupType,
+			Object:      groupID,
+		}); err != nil {
+			return errors.Wrap(err, errGroupConnectChannels)
+		}
+	}
+	for _, clID := range clIDs {
+		if err := am.extAuthorize(ctx, channels.ClientsOpConnectChannel, smqauthz.PolicyReq{
+			Domain:      session.DomainID,
+			SubjectType: policies.UserType,
+			Subject:     session.DomainUserID,
+			ObjectType:  policies.ClientType,
+			Object:      clID,
+		}); err != nil {
+			return errors.Wrap(err, errClientConnectChannels)
+		}
+	}
+	return am.svc.ConnectGroup(ctx, session, groupID, chIDs, clIDs, connTypes)
+}
+
+func (am *authorizationMiddleware) DisconnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) error {
+	if session.Type == authn.PersonalAccessToken {
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainGroupsScope,
+			Operation:                auth.CreateOp,
+			EntityIDs:                []string{groupID},
+		}); err != nil {
+			return errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainChannelsScope,
+			Operation:                auth.CreateOp,
+			EntityIDs:                chIDs,
+		}); err != nil {
+			return errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainClientsScope,
+			Operation:                auth.CreateOp,
+			EntityIDs:                clIDs,
+		}); err != nil {
+			return errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+	}
+	if len(chIDs) > 0 {
+		if err := am.extAuthorize(ctx, channels.GroupsOpDisconnectClients, smqauthz.PolicyReq{
+			Domain:      session.DomainID,
+			SubjectType: policies.UserType,
+			Subject:     session.DomainUserID,
+			ObjectType:  policies.GroupType,
+			Object:      groupID,
+		}); err != nil {
+			return errors.Wrap(err, errGroupConnectClients)
+		}
+	}
+	for _, chID := range chIDs {
+		if err := am.authorize(ctx, channels.OpDisconnectClient, smqauthz.PolicyReq{
+			Domain:      session.DomainID,
+			SubjectType: policies.UserType,
+			Subject:     session.DomainUserID,
+			ObjectType:  policies.ChannelType,
+			Object:      chID,
+		}); err != nil {
+			return errors.Wrap(err, errDisconnect)
+		}
+	}
+
+	if len(clIDs) > 0 {
+		if err := am.extAuthorize(ctx, channels.GroupsOpDisconnectChannels, smqauthz.PolicyReq{
+			Domain:      session.DomainID,
+			SubjectType: policies.UserType,
+			Subject:     session.DomainUserID,
+			ObjectType:  policies.GroupType,
+			Object:      groupID,
+		}); err != nil {
+			return errors.Wrap(err, errGroupConnectChannels)
+		}
+	}
+	for _, clID := range clIDs {
+		if err := am.extAuthorize(ctx, channels.ClientsOpDisconnectChannel, smqauthz.PolicyReq{
+			Domain:      session.DomainID,
+			SubjectType: policies.UserType,
+			Subject:     session.DomainUserID,
+			ObjectType:  policies.ClientType,
+			Object:      clID,
+		}); err != nil {
+			return errors.Wrap(err, errClientDisConnectChannels)
+		}
+	}
+	return am.svc.DisconnectGroup(ctx, session, groupID, chIDs, clIDs, connTypes)
+}
+
 func (am *authorizationMiddleware) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) error {
 	if session.Type == authn.PersonalAccessToken {
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainChannelsScope,
@@ -473,6 +668,7 @@ func (am *authorizationMiddleware) RemoveParentGroup(ctx context.Context, sessio
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainChannelsScope,
@@ -512,6 +708,57 @@ func (am *authorizationMiddleware) RemoveParentGroup(ctx context.Context, sessio
 	return nil
 }
 
+func (am *authorizationMiddleware) MoveChannel(ctx context.Context, session authn.Session, id, domainID string) (channels.Channel, error) {
+	if session.Type == authn.PersonalAccessToken {
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainChannelsScope,
+			Operation:                auth.DeleteOp,
+			EntityIDs:                []string{id},
+		}); err != nil {
+			return channels.Channel{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         domainID,
+			OptionalDomainEntityType: auth.DomainChannelsScope,
+			Operation:                auth.CreateOp,
+			EntityIDs:                auth.AnyIDs{}.Values(),
+		}); err != nil {
+			return channels.Channel{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+	}
+
+	if err := am.authorize(ctx, channels.OpMoveChannel, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		ObjectType:  policies.ChannelType,
+		Object:      id,
+	}); err != nil {
+		return channels.Channel{}, errors.Wrap(err, errMove)
+	}
+
+	if err := am.extAuthorize(ctx, channels.DomainOpCreateChannel, smqauthz.PolicyReq{
+		Domain:      domainID,
+		SubjectType: policies.UserType,
+		Subject:     policies.EncodeDomainUserID(domainID, session.UserID),
+		ObjectType:  policies.DomainType,
+		Object:      domainID,
+	}); err != nil {
+		return channels.Channel{}, errors.Wrap(err, errDomainCreateChannels)
+	}
+
+	return am.svc.MoveChannel(ctx, session, id, domainID)
+}
+
 func (am *authorizationMiddleware) authorize(ctx context.Context, op svcutil.Operation, req smqauthz.PolicyReq) error {
 	perm, err := am.opp.GetPermission(op)
 	if err != nil {
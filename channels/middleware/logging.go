@@ -43,7 +43,7 @@ func (lm *loggingMiddleware) CreateChannels(ctx context.Context, session authn.S
 	return lm.svc.CreateChannels(ctx, session, clients...)
 }
 
-func (lm *loggingMiddleware) ViewChannel(ctx context.Context, session authn.Session, id string) (c channels.Channel, err error) {
+func (lm *loggingMiddleware) ViewChannel(ctx context.Context, session authn.Session, id string, effective bool) (c channels.Channel, err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
@@ -51,6 +51,7 @@ func (lm *loggingMiddleware) ViewChannel(ctx context.Context, session authn.Sess
 				slog.String("id", c.ID),
 				slog.String("name", c.Name),
 			),
+			slog.Bool("effective", effective),
 		}
 		if err != nil {
 			args = append(args, slog.String("error", err.Error()))
@@ -59,7 +60,7 @@ func (lm *loggingMiddleware) ViewChannel(ctx context.Context, session authn.Sess
 		}
 		lm.logger.Info("View channel completed successfully", args...)
 	}(time.Now())
-	return lm.svc.ViewChannel(ctx, session, id)
+	return lm.svc.ViewChannel(ctx, session, id, effective)
 }
 
 func (lm *loggingMiddleware) ListChannels(ctx context.Context, session authn.Session, pm channels.PageMetadata) (cp channels.Page, err error) {
@@ -197,13 +198,19 @@ func (lm *loggingMiddleware) RemoveChannel(ctx context.Context, session authn.Se
 	return lm.svc.RemoveChannel(ctx, session, id)
 }
 
-func (lm *loggingMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, clIDs []string, connTypes []connections.ConnType) (err error) {
+func (lm *loggingMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, clIDs []string, connTypes []connections.ConnType, scope connections.Scope) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
 			slog.Any("channel_ids", chIDs),
 			slog.Any("client_ids", clIDs),
 		}
+		if !scope.ExpiresAt.IsZero() {
+			args = append(args, slog.Time("expires_at", scope.ExpiresAt))
+		}
+		if len(scope.Subtopics) > 0 {
+			args = append(args, slog.Any("subtopics", scope.Subtopics))
+		}
 		if err != nil {
 			args = append(args, slog.String("error", err.Error()))
 			lm.logger.Warn("Connect channels and clients failed", args...)
@@ -211,7 +218,7 @@ func (lm *loggingMiddleware) Connect(ctx context.Context, session authn.Session,
 		}
 		lm.logger.Info("Connect channels and clients completed successfully", args...)
 	}(time.Now())
-	return lm.svc.Connect(ctx, session, chIDs, clIDs, connTypes)
+	return lm.svc.Connect(ctx, session, chIDs, clIDs, connTypes, scope)
 }
 
 func (lm *loggingMiddleware) Disconnect(ctx context.Context, session authn.Session, chIDs, clIDs []string, connTypes []connections.ConnType) (err error) {
@@ -231,6 +238,42 @@ func (lm *loggingMiddleware) Disconnect(ctx context.Context, session authn.Sessi
 	return lm.svc.Disconnect(ctx, session, chIDs, clIDs, connTypes)
 }
 
+func (lm *loggingMiddleware) ConnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", groupID),
+			slog.Any("channel_ids", chIDs),
+			slog.Any("client_ids", clIDs),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Connect group failed", args...)
+			return
+		}
+		lm.logger.Info("Connect group completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ConnectGroup(ctx, session, groupID, chIDs, clIDs, connTypes)
+}
+
+func (lm *loggingMiddleware) DisconnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", groupID),
+			slog.Any("channel_ids", chIDs),
+			slog.Any("client_ids", clIDs),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Disconnect group failed", args...)
+			return
+		}
+		lm.logger.Info("Disconnect group completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DisconnectGroup(ctx, session, groupID, chIDs, clIDs, connTypes)
+}
+
 func (lm *loggingMiddleware) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -263,3 +306,20 @@ func (lm *loggingMiddleware) RemoveParentGroup(ctx context.Context, session auth
 	}(time.Now())
 	return lm.svc.RemoveParentGroup(ctx, session, id)
 }
+
+func (lm *loggingMiddleware) MoveChannel(ctx context.Context, session authn.Session, id, domainID string) (ch channels.Channel, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", id),
+			slog.String("domain_id", domainID),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Move channel failed", args...)
+			return
+		}
+		lm.logger.Info("Move channel completed successfully", args...)
+	}(time.Now())
+	return lm.svc.MoveChannel(ctx, session, id, domainID)
+}
@@ -42,12 +42,12 @@ func (ms *metricsMiddleware) CreateChannels(ctx context.Context, session authn.S
 	return ms.svc.CreateChannels(ctx, session, chs...)
 }
 
-func (ms *metricsMiddleware) ViewChannel(ctx context.Context, session authn.Session, id string) (channels.Channel, error) {
+func (ms *metricsMiddleware) ViewChannel(ctx context.Context, session authn.Session, id string, effective bool) (channels.Channel, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_channel").Add(1)
 		ms.latency.With("method", "view_channel").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.ViewChannel(ctx, session, id)
+	return ms.svc.ViewChannel(ctx, session, id, effective)
 }
 
 func (ms *metricsMiddleware) ListChannels(ctx context.Context, session authn.Session, pm channels.PageMetadata) (channels.Page, error) {
@@ -106,12 +106,12 @@ func (ms *metricsMiddleware) RemoveChannel(ctx context.Context, session authn.Se
 	return ms.svc.RemoveChannel(ctx, session, id)
 }
 
-func (ms *metricsMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
+func (ms *metricsMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType, scope connections.Scope) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "connect").Add(1)
 		ms.latency.With("method", "connect").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.Connect(ctx, session, chIDs, thIDs, connTypes)
+	return ms.svc.Connect(ctx, session, chIDs, thIDs, connTypes, scope)
 }
 
 func (ms *metricsMiddleware) Disconnect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
@@ -122,6 +122,22 @@ func (ms *metricsMiddleware) Disconnect(ctx context.Context, session authn.Sessi
 	return ms.svc.Disconnect(ctx, session, chIDs, thIDs, connTypes)
 }
 
+func (ms *metricsMiddleware) ConnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "connect_group").Add(1)
+		ms.latency.With("method", "connect_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ConnectGroup(ctx, session, groupID, chIDs, clIDs, connTypes)
+}
+
+func (ms *metricsMiddleware) DisconnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "disconnect_group").Add(1)
+		ms.latency.With("method", "disconnect_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DisconnectGroup(ctx, session, groupID, chIDs, clIDs, connTypes)
+}
+
 func (ms *metricsMiddleware) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) (err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "set_parent_group").Add(1)
@@ -137,3 +153,11 @@ func (ms *metricsMiddleware) RemoveParentGroup(ctx context.Context, session auth
 	}(time.Now())
 	return ms.svc.RemoveParentGroup(ctx, session, id)
 }
+
+func (ms *metricsMiddleware) MoveChannel(ctx context.Context, session authn.Session, id, domainID string) (channels.Channel, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "move_channel").Add(1)
+		ms.latency.With("method", "move_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.MoveChannel(ctx, session, id, domainID)
+}
@@ -105,6 +105,80 @@ func (_c *ChannelsServiceClient_Authorize_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// MoveParentGroupChannels provides a mock function with given fields: ctx, in, opts
+func (_m *ChannelsServiceClient) MoveParentGroupChannels(ctx context.Context, in *v1.MoveParentGroupChannelsReq, opts ...grpc.CallOption) (*v1.MoveParentGroupChannelsRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveParentGroupChannels")
+	}
+
+	var r0 *v1.MoveParentGroupChannelsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.MoveParentGroupChannelsReq, ...grpc.CallOption) (*v1.MoveParentGroupChannelsRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.MoveParentGroupChannelsReq, ...grpc.CallOption) *v1.MoveParentGroupChannelsRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.MoveParentGroupChannelsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.MoveParentGroupChannelsReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChannelsServiceClient_MoveParentGroupChannels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveParentGroupChannels'
+type ChannelsServiceClient_MoveParentGroupChannels_Call struct {
+	*mock.Call
+}
+
+// MoveParentGroupChannels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *v1.MoveParentGroupChannelsReq
+//   - opts ...grpc.CallOption
+func (_e *ChannelsServiceClient_Expecter) MoveParentGroupChannels(ctx interface{}, in interface{}, opts ...interface{}) *ChannelsServiceClient_MoveParentGroupChannels_Call {
+	return &ChannelsServiceClient_MoveParentGroupChannels_Call{Call: _e.mock.On("MoveParentGroupChannels",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *ChannelsServiceClient_MoveParentGroupChannels_Call) Run(run func(ctx context.Context, in *v1.MoveParentGroupChannelsReq, opts ...grpc.CallOption)) *ChannelsServiceClient_MoveParentGroupChannels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*v1.MoveParentGroupChannelsReq), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ChannelsServiceClient_MoveParentGroupChannels_Call) Return(_a0 *v1.MoveParentGroupChannelsRes, _a1 error) *ChannelsServiceClient_MoveParentGroupChannels_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChannelsServiceClient_MoveParentGroupChannels_Call) RunAndReturn(run func(context.Context, *v1.MoveParentGroupChannelsReq, ...grpc.CallOption) (*v1.MoveParentGroupChannelsRes, error)) *ChannelsServiceClient_MoveParentGroupChannels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveClientConnections provides a mock function with given fields: ctx, in, opts
 func (_m *ChannelsServiceClient) RemoveClientConnections(ctx context.Context, in *v1.RemoveClientConnectionsReq, opts ...grpc.CallOption) (*v1.RemoveClientConnectionsRes, error) {
 	_va := make([]interface{}, len(opts))
@@ -253,6 +327,80 @@ func (_c *ChannelsServiceClient_RetrieveEntity_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// RetrieveIDByName provides a mock function with given fields: ctx, in, opts
+func (_m *ChannelsServiceClient) RetrieveIDByName(ctx context.Context, in *v1.RetrieveIDByNameReq, opts ...grpc.CallOption) (*v1.RetrieveIDByNameRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveIDByName")
+	}
+
+	var r0 *v1.RetrieveIDByNameRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.RetrieveIDByNameReq, ...grpc.CallOption) (*v1.RetrieveIDByNameRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.RetrieveIDByNameReq, ...grpc.CallOption) *v1.RetrieveIDByNameRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.RetrieveIDByNameRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.RetrieveIDByNameReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChannelsServiceClient_RetrieveIDByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveIDByName'
+type ChannelsServiceClient_RetrieveIDByName_Call struct {
+	*mock.Call
+}
+
+// RetrieveIDByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *v1.RetrieveIDByNameReq
+//   - opts ...grpc.CallOption
+func (_e *ChannelsServiceClient_Expecter) RetrieveIDByName(ctx interface{}, in interface{}, opts ...interface{}) *ChannelsServiceClient_RetrieveIDByName_Call {
+	return &ChannelsServiceClient_RetrieveIDByName_Call{Call: _e.mock.On("RetrieveIDByName",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *ChannelsServiceClient_RetrieveIDByName_Call) Run(run func(ctx context.Context, in *v1.RetrieveIDByNameReq, opts ...grpc.CallOption)) *ChannelsServiceClient_RetrieveIDByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*v1.RetrieveIDByNameReq), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ChannelsServiceClient_RetrieveIDByName_Call) Return(_a0 *v1.RetrieveIDByNameRes, _a1 error) *ChannelsServiceClient_RetrieveIDByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChannelsServiceClient_RetrieveIDByName_Call) RunAndReturn(run func(context.Context, *v1.RetrieveIDByNameReq, ...grpc.CallOption) (*v1.RetrieveIDByNameRes, error)) *ChannelsServiceClient_RetrieveIDByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UnsetParentGroupFromChannels provides a mock function with given fields: ctx, in, opts
 func (_m *ChannelsServiceClient) UnsetParentGroupFromChannels(ctx context.Context, in *v1.UnsetParentGroupFromChannelsReq, opts ...grpc.CallOption) (*v1.UnsetParentGroupFromChannelsRes, error) {
 	_va := make([]interface{}, len(opts))
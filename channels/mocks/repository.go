@@ -9,6 +9,8 @@ import (
 
 	channels "github.com/absmach/supermq/channels"
 
+	clients "github.com/absmach/supermq/clients"
+
 	mock "github.com/stretchr/testify/mock"
 
 	roles "github.com/absmach/supermq/pkg/roles"
@@ -37,6 +39,24 @@ func (_m *Repository) AddConnections(ctx context.Context, conns []channels.Conne
 	return r0
 }
 
+// AddGroupConnections provides a mock function with given fields: ctx, conns
+func (_m *Repository) AddGroupConnections(ctx context.Context, conns []channels.GroupConnection) error {
+	ret := _m.Called(ctx, conns)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddGroupConnections")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []channels.GroupConnection) error); ok {
+		r0 = rf(ctx, conns)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddRoles provides a mock function with given fields: ctx, rps
 func (_m *Repository) AddRoles(ctx context.Context, rps []roles.RoleProvision) ([]roles.RoleProvision, error) {
 	ret := _m.Called(ctx, rps)
@@ -67,6 +87,64 @@ func (_m *Repository) AddRoles(ctx context.Context, rps []roles.RoleProvision) (
 	return r0, r1
 }
 
+// ChangeDomain provides a mock function with given fields: ctx, ch
+func (_m *Repository) ChangeDomain(ctx context.Context, ch channels.Channel) (channels.Channel, error) {
+	ret := _m.Called(ctx, ch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeDomain")
+	}
+
+	var r0 channels.Channel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, channels.Channel) (channels.Channel, error)); ok {
+		return rf(ctx, ch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, channels.Channel) channels.Channel); ok {
+		r0 = rf(ctx, ch)
+	} else {
+		r0 = ret.Get(0).(channels.Channel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, channels.Channel) error); ok {
+		r1 = rf(ctx, ch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeParentGroupsDomain provides a mock function with given fields: ctx, ch, parentGroupIDs, clientIDs
+func (_m *Repository) ChangeParentGroupsDomain(ctx context.Context, ch channels.Channel, parentGroupIDs []string, clientIDs []string) ([]channels.Channel, error) {
+	ret := _m.Called(ctx, ch, parentGroupIDs, clientIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeParentGroupsDomain")
+	}
+
+	var r0 []channels.Channel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, channels.Channel, []string, []string) ([]channels.Channel, error)); ok {
+		return rf(ctx, ch, parentGroupIDs, clientIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, channels.Channel, []string, []string) []channels.Channel); ok {
+		r0 = rf(ctx, ch, parentGroupIDs, clientIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]channels.Channel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, channels.Channel, []string, []string) error); ok {
+		r1 = rf(ctx, ch, parentGroupIDs, clientIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ChangeStatus provides a mock function with given fields: ctx, channel
 func (_m *Repository) ChangeStatus(ctx context.Context, channel channels.Channel) (channels.Channel, error) {
 	ret := _m.Called(ctx, channel)
@@ -141,16 +219,16 @@ func (_m *Repository) CheckConnection(ctx context.Context, conn channels.Connect
 	return r0
 }
 
-// ClientAuthorize provides a mock function with given fields: ctx, conn
-func (_m *Repository) ClientAuthorize(ctx context.Context, conn channels.Connection) error {
+// CheckGroupConnection provides a mock function with given fields: ctx, conn
+func (_m *Repository) CheckGroupConnection(ctx context.Context, conn channels.GroupConnection) error {
 	ret := _m.Called(ctx, conn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ClientAuthorize")
+		panic("no return value specified for CheckGroupConnection")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, channels.Connection) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, channels.GroupConnection) error); ok {
 		r0 = rf(ctx, conn)
 	} else {
 		r0 = ret.Error(0)
@@ -159,6 +237,62 @@ func (_m *Repository) ClientAuthorize(ctx context.Context, conn channels.Connect
 	return r0
 }
 
+// ClientAuthorize provides a mock function with given fields: ctx, conn, subtopic
+func (_m *Repository) ClientAuthorize(ctx context.Context, conn channels.Connection, subtopic string) (string, error) {
+	ret := _m.Called(ctx, conn, subtopic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClientAuthorize")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, channels.Connection, string) (string, error)); ok {
+		return rf(ctx, conn, subtopic)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, channels.Connection, string) string); ok {
+		r0 = rf(ctx, conn, subtopic)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, channels.Connection, string) error); ok {
+		r1 = rf(ctx, conn, subtopic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClientGroupAuthorize provides a mock function with given fields: ctx, conn, parentGroupID
+func (_m *Repository) ClientGroupAuthorize(ctx context.Context, conn channels.Connection, parentGroupID string) (string, error) {
+	ret := _m.Called(ctx, conn, parentGroupID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClientGroupAuthorize")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, channels.Connection, string) (string, error)); ok {
+		return rf(ctx, conn, parentGroupID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, channels.Connection, string) string); ok {
+		r0 = rf(ctx, conn, parentGroupID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, channels.Connection, string) error); ok {
+		r1 = rf(ctx, conn, parentGroupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DoesChannelHaveConnections provides a mock function with given fields: ctx, id
 func (_m *Repository) DoesChannelHaveConnections(ctx context.Context, id string) (bool, error) {
 	ret := _m.Called(ctx, id)
@@ -187,6 +321,34 @@ func (_m *Repository) DoesChannelHaveConnections(ctx context.Context, id string)
 	return r0, r1
 }
 
+// DoesChannelHaveGroupConnections provides a mock function with given fields: ctx, id
+func (_m *Repository) DoesChannelHaveGroupConnections(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DoesChannelHaveGroupConnections")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListEntityMembers provides a mock function with given fields: ctx, entityID, pageQuery
 func (_m *Repository) ListEntityMembers(ctx context.Context, entityID string, pageQuery roles.MembersRolePageQuery) (roles.MembersRolePage, error) {
 	ret := _m.Called(ctx, entityID, pageQuery)
@@ -312,6 +474,52 @@ func (_m *Repository) RemoveEntityMembers(ctx context.Context, entityID string,
 	return r0
 }
 
+// RemoveExpiredConnections provides a mock function with given fields: ctx
+func (_m *Repository) RemoveExpiredConnections(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveExpiredConnections")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveGroupConnections provides a mock function with given fields: ctx, conns
+func (_m *Repository) RemoveGroupConnections(ctx context.Context, conns []channels.GroupConnection) error {
+	ret := _m.Called(ctx, conns)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveGroupConnections")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []channels.GroupConnection) error); ok {
+		r0 = rf(ctx, conns)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveMemberFromAllRoles provides a mock function with given fields: ctx, memberID
 func (_m *Repository) RemoveMemberFromAllRoles(ctx context.Context, memberID string) error {
 	ret := _m.Called(ctx, memberID)
@@ -450,6 +658,64 @@ func (_m *Repository) RetrieveByID(ctx context.Context, id string) (channels.Cha
 	return r0, r1
 }
 
+// RetrieveByName provides a mock function with given fields: ctx, domainID, name
+func (_m *Repository) RetrieveByName(ctx context.Context, domainID string, name string) (channels.Channel, error) {
+	ret := _m.Called(ctx, domainID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveByName")
+	}
+
+	var r0 channels.Channel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (channels.Channel, error)); ok {
+		return rf(ctx, domainID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) channels.Channel); ok {
+		r0 = rf(ctx, domainID, name)
+	} else {
+		r0 = ret.Get(0).(channels.Channel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, domainID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveEntitiesConnections provides a mock function with given fields: ctx, channelIDs, clientIDs
+func (_m *Repository) RetrieveEntitiesConnections(ctx context.Context, channelIDs []string, clientIDs []string) ([]channels.Connection, error) {
+	ret := _m.Called(ctx, channelIDs, clientIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveEntitiesConnections")
+	}
+
+	var r0 []channels.Connection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string) ([]channels.Connection, error)); ok {
+		return rf(ctx, channelIDs, clientIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string) []channels.Connection); ok {
+		r0 = rf(ctx, channelIDs, clientIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]channels.Connection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, []string) error); ok {
+		r1 = rf(ctx, channelIDs, clientIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveEntitiesRolesActionsMembers provides a mock function with given fields: ctx, entityIDs
 func (_m *Repository) RetrieveEntitiesRolesActionsMembers(ctx context.Context, entityIDs []string) ([]roles.EntityActionRole, []roles.EntityMemberRole, error) {
 	ret := _m.Called(ctx, entityIDs)
@@ -547,6 +813,36 @@ func (_m *Repository) RetrieveParentGroupChannels(ctx context.Context, parentGro
 	return r0, r1
 }
 
+// RetrievePathMetadata provides a mock function with given fields: ctx, path
+func (_m *Repository) RetrievePathMetadata(ctx context.Context, path string) ([]clients.Metadata, error) {
+	ret := _m.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePathMetadata")
+	}
+
+	var r0 []clients.Metadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]clients.Metadata, error)); ok {
+		return rf(ctx, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []clients.Metadata); ok {
+		r0 = rf(ctx, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]clients.Metadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveRole provides a mock function with given fields: ctx, roleID
 func (_m *Repository) RetrieveRole(ctx context.Context, roleID string) (roles.Role, error) {
 	ret := _m.Called(ctx, roleID)
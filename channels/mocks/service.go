@@ -50,17 +50,35 @@ func (_m *Service) AddRole(ctx context.Context, session authn.Session, entityID
 	return r0, r1
 }
 
-// Connect provides a mock function with given fields: ctx, session, chIDs, clIDs, connType
-func (_m *Service) Connect(ctx context.Context, session authn.Session, chIDs []string, clIDs []string, connType []connections.ConnType) error {
-	ret := _m.Called(ctx, session, chIDs, clIDs, connType)
+// Connect provides a mock function with given fields: ctx, session, chIDs, clIDs, connType, scope
+func (_m *Service) Connect(ctx context.Context, session authn.Session, chIDs []string, clIDs []string, connType []connections.ConnType, scope connections.Scope) error {
+	ret := _m.Called(ctx, session, chIDs, clIDs, connType, scope)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Connect")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, []string, []string, []connections.ConnType) error); ok {
-		r0 = rf(ctx, session, chIDs, clIDs, connType)
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, []string, []string, []connections.ConnType, connections.Scope) error); ok {
+		r0 = rf(ctx, session, chIDs, clIDs, connType, scope)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConnectGroup provides a mock function with given fields: ctx, session, groupID, chIDs, clIDs, connTypes
+func (_m *Service) ConnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs []string, clIDs []string, connTypes []connections.ConnType) error {
+	ret := _m.Called(ctx, session, groupID, chIDs, clIDs, connTypes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConnectGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, []string, []string, []connections.ConnType) error); ok {
+		r0 = rf(ctx, session, groupID, chIDs, clIDs, connTypes)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -160,6 +178,24 @@ func (_m *Service) Disconnect(ctx context.Context, session authn.Session, chIDs
 	return r0
 }
 
+// DisconnectGroup provides a mock function with given fields: ctx, session, groupID, chIDs, clIDs, connTypes
+func (_m *Service) DisconnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs []string, clIDs []string, connTypes []connections.ConnType) error {
+	ret := _m.Called(ctx, session, groupID, chIDs, clIDs, connTypes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisconnectGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, []string, []string, []connections.ConnType) error); ok {
+		r0 = rf(ctx, session, groupID, chIDs, clIDs, connTypes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EnableChannel provides a mock function with given fields: ctx, session, id
 func (_m *Service) EnableChannel(ctx context.Context, session authn.Session, id string) (channels.Channel, error) {
 	ret := _m.Called(ctx, session, id)
@@ -302,6 +338,34 @@ func (_m *Service) ListUserChannels(ctx context.Context, session authn.Session,
 	return r0, r1
 }
 
+// MoveChannel provides a mock function with given fields: ctx, session, id, domainID
+func (_m *Service) MoveChannel(ctx context.Context, session authn.Session, id string, domainID string) (channels.Channel, error) {
+	ret := _m.Called(ctx, session, id, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveChannel")
+	}
+
+	var r0 channels.Channel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) (channels.Channel, error)); ok {
+		return rf(ctx, session, id, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) channels.Channel); ok {
+		r0 = rf(ctx, session, id, domainID)
+	} else {
+		r0 = ret.Get(0).(channels.Channel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, string) error); ok {
+		r1 = rf(ctx, session, id, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveChannel provides a mock function with given fields: ctx, session, id
 func (_m *Service) RemoveChannel(ctx context.Context, session authn.Session, id string) error {
 	ret := _m.Called(ctx, session, id)
@@ -796,9 +860,9 @@ func (_m *Service) UpdateRoleName(ctx context.Context, session authn.Session, en
 	return r0, r1
 }
 
-// ViewChannel provides a mock function with given fields: ctx, session, id
-func (_m *Service) ViewChannel(ctx context.Context, session authn.Session, id string) (channels.Channel, error) {
-	ret := _m.Called(ctx, session, id)
+// ViewChannel provides a mock function with given fields: ctx, session, id, effective
+func (_m *Service) ViewChannel(ctx context.Context, session authn.Session, id string, effective bool) (channels.Channel, error) {
+	ret := _m.Called(ctx, session, id, effective)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ViewChannel")
@@ -806,17 +870,17 @@ func (_m *Service) ViewChannel(ctx context.Context, session authn.Session, id st
 
 	var r0 channels.Channel
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) (channels.Channel, error)); ok {
-		return rf(ctx, session, id)
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bool) (channels.Channel, error)); ok {
+		return rf(ctx, session, id, effective)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) channels.Channel); ok {
-		r0 = rf(ctx, session, id)
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bool) channels.Channel); ok {
+		r0 = rf(ctx, session, id, effective)
 	} else {
 		r0 = ret.Get(0).(channels.Channel)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
-		r1 = rf(ctx, session, id)
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, bool) error); ok {
+		r1 = rf(ctx, session, id, effective)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -15,6 +15,7 @@ import (
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/channels"
 	clients "github.com/absmach/supermq/clients"
+	gpostgres "github.com/absmach/supermq/groups/postgres"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
@@ -123,7 +124,9 @@ func (cr *channelRepository) ChangeStatus(ctx context.Context, channel channels.
 }
 
 func (cr *channelRepository) RetrieveByID(ctx context.Context, id string) (channels.Channel, error) {
-	q := `SELECT id, name, tags, COALESCE(domain_id, '') AS domain_id, COALESCE(parent_group_id, '') AS parent_group_id,  metadata, created_at, updated_at, updated_by, status FROM channels WHERE id = :id`
+	q := `SELECT c.id, c.name, c.tags, COALESCE(c.domain_id, '') AS domain_id, COALESCE(c.parent_group_id, '') AS parent_group_id,
+		COALESCE((SELECT path FROM groups WHERE id = c.parent_group_id), ''::::ltree) AS parent_group_path,
+		c.metadata, c.created_at, c.updated_at, c.updated_by, c.status FROM channels c WHERE c.id = :id`
 
 	dbch := dbChannel{
 		ID: id,
@@ -146,6 +149,44 @@ func (cr *channelRepository) RetrieveByID(ctx context.Context, id string) (chann
 	return channels.Channel{}, repoerr.ErrNotFound
 }
 
+func (cr *channelRepository) RetrieveByName(ctx context.Context, domainID, name string) (channels.Channel, error) {
+	q := `SELECT id, name, tags, COALESCE(domain_id, '') AS domain_id, COALESCE(parent_group_id, '') AS parent_group_id,  metadata, created_at, updated_at, updated_by, status FROM channels WHERE domain_id = :domain_id AND name = :name`
+
+	dbch := dbChannel{
+		Domain: domainID,
+		Name:   name,
+	}
+
+	row, err := cr.db.NamedQueryContext(ctx, q, dbch)
+	if err != nil {
+		return channels.Channel{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	defer row.Close()
+
+	dbch = dbChannel{}
+	if row.Next() {
+		if err := row.StructScan(&dbch); err != nil {
+			return channels.Channel{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		return toChannel(dbch)
+	}
+
+	return channels.Channel{}, repoerr.ErrNotFound
+}
+
+func (cr *channelRepository) RetrievePathMetadata(ctx context.Context, path string) ([]clients.Metadata, error) {
+	mds, err := gpostgres.RetrievePathMetadata(ctx, cr.db, path)
+	if err != nil {
+		return []clients.Metadata{}, err
+	}
+	ret := make([]clients.Metadata, len(mds))
+	for i, md := range mds {
+		ret[i] = md
+	}
+
+	return ret, nil
+}
+
 func (cr *channelRepository) RetrieveAll(ctx context.Context, pm channels.PageMetadata) (channels.Page, error) {
 	pageQuery, err := PageQuery(pm)
 	if err != nil {
@@ -693,18 +734,92 @@ func (cr *channelRepository) RemoveParentGroup(ctx context.Context, ch channels.
 	return nil
 }
 
+func (cr *channelRepository) ChangeDomain(ctx context.Context, ch channels.Channel) (channels.Channel, error) {
+	q := `WITH removed AS (
+		DELETE FROM channel_group_connections WHERE channel_id = :id
+	)
+	UPDATE channels SET domain_id = :domain_id, parent_group_id = NULL, updated_at = :updated_at, updated_by = :updated_by
+	WHERE id = :id
+	RETURNING id, name, tags, metadata, COALESCE(domain_id, '') AS domain_id, COALESCE(parent_group_id, '') AS parent_group_id, status, created_at, updated_at, updated_by`
+
+	return cr.update(ctx, ch, q)
+}
+
+func (cr *channelRepository) ChangeParentGroupsDomain(ctx context.Context, ch channels.Channel, parentGroupIDs, clientIDs []string) ([]channels.Channel, error) {
+	q := `WITH removed_channels AS (
+		DELETE FROM channel_group_connections
+		WHERE channel_id IN (SELECT id FROM channels WHERE parent_group_id = ANY(:parent_group_ids)) OR group_id = ANY(:parent_group_ids)
+	), removed_clients AS (
+		DELETE FROM client_group_connections WHERE client_id = ANY(:client_ids) OR group_id = ANY(:parent_group_ids)
+	)
+	UPDATE channels SET domain_id = :domain_id, updated_at = :updated_at, updated_by = :updated_by
+	WHERE parent_group_id = ANY(:parent_group_ids)
+	RETURNING id, name, tags, metadata, COALESCE(domain_id, '') AS domain_id, COALESCE(parent_group_id, '') AS parent_group_id, status, created_at, updated_at, updated_by`
+
+	dbch, err := toDBChannel(ch)
+	if err != nil {
+		return []channels.Channel{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	params := map[string]interface{}{
+		"domain_id":        dbch.Domain,
+		"updated_at":       dbch.UpdatedAt,
+		"updated_by":       dbch.UpdatedBy,
+		"parent_group_ids": parentGroupIDs,
+		"client_ids":       clientIDs,
+	}
+	rows, err := cr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return []channels.Channel{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	var chs []channels.Channel
+	for rows.Next() {
+		dbch := dbChannel{}
+		if err := rows.StructScan(&dbch); err != nil {
+			return []channels.Channel{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		c, err := toChannel(dbch)
+		if err != nil {
+			return []channels.Channel{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		chs = append(chs, c)
+	}
+
+	return chs, nil
+}
+
 func (cr *channelRepository) AddConnections(ctx context.Context, conns []channels.Connection) error {
 	dbConns := toDBConnections(conns)
-	q := `INSERT INTO connections (channel_id, domain_id, client_id, type)
-			VALUES (:channel_id, :domain_id, :client_id, :type );`
+	// The expired connection is replaced, so the client can be connected again.
+	q := `INSERT INTO connections (channel_id, domain_id, client_id, type, expires_at, subtopics)
+			VALUES (:channel_id, :domain_id, :client_id, :type, :expires_at, :subtopics)
+			ON CONFLICT (channel_id, domain_id, client_id, type) DO UPDATE SET expires_at = EXCLUDED.expires_at, subtopics = EXCLUDED.subtopics
+			WHERE connections.expires_at <= NOW() AT TIME ZONE 'UTC'`
 
-	if _, err := cr.db.NamedExecContext(ctx, q, dbConns); err != nil {
+	result, err := cr.db.NamedExecContext(ctx, q, dbConns)
+	if err != nil {
 		return postgres.HandleError(repoerr.ErrCreateEntity, err)
 	}
+	if rows, _ := result.RowsAffected(); rows < int64(len(dbConns)) {
+		return repoerr.ErrConflict
+	}
 
 	return nil
 }
 
+func (cr *channelRepository) RemoveExpiredConnections(ctx context.Context) (uint64, error) {
+	q := `DELETE FROM connections WHERE expires_at <= NOW() AT TIME ZONE 'UTC'`
+
+	result, err := cr.db.ExecContext(ctx, q)
+	if err != nil {
+		return 0, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	rows, _ := result.RowsAffected()
+
+	return uint64(rows), nil
+}
+
 func (cr *channelRepository) RemoveConnections(ctx context.Context, conns []channels.Connection) (retErr error) {
 	tx, err := cr.db.BeginTxx(ctx, nil)
 	if err != nil {
@@ -735,8 +850,40 @@ func (cr *channelRepository) RemoveConnections(ctx context.Context, conns []chan
 	return nil
 }
 
+func (cr *channelRepository) RetrieveEntitiesConnections(ctx context.Context, channelIDs, clientIDs []string) ([]channels.Connection, error) {
+	query := `SELECT channel_id, domain_id, client_id, type, expires_at, subtopics FROM connections
+	WHERE channel_id = ANY(:channel_ids) OR client_id = ANY(:client_ids)`
+	params := map[string]interface{}{
+		"channel_ids": channelIDs,
+		"client_ids":  clientIDs,
+	}
+	rows, err := cr.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return []channels.Connection{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	conns := []channels.Connection{}
+	for rows.Next() {
+		dbConn := dbConnection{}
+		if err := rows.StructScan(&dbConn); err != nil {
+			return []channels.Connection{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		conns = append(conns, channels.Connection{
+			ClientID:  dbConn.ClientID,
+			ChannelID: dbConn.ChannelID,
+			DomainID:  dbConn.DomainID,
+			Type:      dbConn.Type,
+			Scope:     toScope(dbConn),
+		})
+	}
+
+	return conns, nil
+}
+
 func (cr *channelRepository) CheckConnection(ctx context.Context, conn channels.Connection) error {
-	query := `SELECT 1 FROM connections WHERE channel_id = :channel_id AND domain_id = :domain_id AND client_id = :client_id AND type = :type LIMIT 1`
+	query := `SELECT 1 FROM connections WHERE channel_id = :channel_id AND domain_id = :domain_id AND client_id = :client_id AND type = :type
+		AND (expires_at IS NULL OR expires_at > NOW() AT TIME ZONE 'UTC') LIMIT 1`
 	dbConn := toDBConnection(conn)
 	rows, err := cr.db.NamedQueryContext(ctx, query, dbConn)
 	if err != nil {
@@ -750,10 +897,92 @@ func (cr *channelRepository) CheckConnection(ctx context.Context, conn channels.
 	return nil
 }
 
-func (cr *channelRepository) ClientAuthorize(ctx context.Context, conn channels.Connection) error {
-	query := `SELECT 1 FROM connections WHERE channel_id = :channel_id AND client_id = :client_id AND type = :type LIMIT 1`
+func (cr *channelRepository) ClientAuthorize(ctx context.Context, conn channels.Connection, subtopic string) (string, error) {
+	query := `SELECT domain_id, expires_at, subtopics FROM connections WHERE channel_id = :channel_id AND client_id = :client_id AND type = :type
+	UNION ALL
+	SELECT c.domain_id, NULL::TIMESTAMP, NULL::TEXT[] FROM client_group_connections cgc
+	JOIN groups g ON g.id = cgc.group_id
+	JOIN channels c ON c.id = :channel_id AND c.domain_id = cgc.domain_id
+	JOIN groups pg ON pg.id = c.parent_group_id
+	WHERE cgc.client_id = :client_id AND cgc.type = :type AND pg.path <@ g.path`
 	dbConn := toDBConnection(conn)
 	rows, err := cr.db.NamedQueryContext(ctx, query, dbConn)
+	if err != nil {
+		return "", postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	now := time.Now().UTC()
+	for rows.Next() {
+		dbs := dbConnection{}
+		if err := rows.StructScan(&dbs); err != nil {
+			return "", postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		if toScope(dbs).Allows(subtopic, now) {
+			return dbs.DomainID, nil
+		}
+	}
+
+	return "", repoerr.ErrNotFound
+}
+
+func (cr *channelRepository) AddGroupConnections(ctx context.Context, conns []channels.GroupConnection) error {
+	chConns, clConns := toDBGroupConnections(conns)
+	if len(chConns) > 0 {
+		q := `INSERT INTO channel_group_connections (channel_id, domain_id, group_id, type)
+			VALUES (:channel_id, :domain_id, :group_id, :type);`
+		if _, err := cr.db.NamedExecContext(ctx, q, chConns); err != nil {
+			return postgres.HandleError(repoerr.ErrCreateEntity, err)
+		}
+	}
+	if len(clConns) > 0 {
+		q := `INSERT INTO client_group_connections (client_id, domain_id, group_id, type)
+			VALUES (:client_id, :domain_id, :group_id, :type);`
+		if _, err := cr.db.NamedExecContext(ctx, q, clConns); err != nil {
+			return postgres.HandleError(repoerr.ErrCreateEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (cr *channelRepository) RemoveGroupConnections(ctx context.Context, conns []channels.GroupConnection) (retErr error) {
+	tx, err := cr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollBack := tx.Rollback(); errRollBack != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollBack))
+			}
+		}
+	}()
+
+	for _, conn := range conns {
+		query := `DELETE FROM client_group_connections WHERE client_id = :client_id AND domain_id = :domain_id AND group_id = :group_id`
+		if conn.ChannelID != "" {
+			query = `DELETE FROM channel_group_connections WHERE channel_id = :channel_id AND domain_id = :domain_id AND group_id = :group_id`
+		}
+		if uint8(conn.Type) > 0 {
+			query = query + " AND type = :type "
+		}
+		if _, err := tx.NamedExec(query, toDBGroupConnection(conn)); err != nil {
+			return errors.Wrap(repoerr.ErrRemoveEntity, errors.Wrap(fmt.Errorf("failed to delete group connection for group_id: %s, domain_id: %s", conn.GroupID, conn.DomainID), err))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+	return nil
+}
+
+func (cr *channelRepository) CheckGroupConnection(ctx context.Context, conn channels.GroupConnection) error {
+	query := `SELECT 1 FROM client_group_connections WHERE client_id = :client_id AND domain_id = :domain_id AND group_id = :group_id AND type = :type LIMIT 1`
+	if conn.ChannelID != "" {
+		query = `SELECT 1 FROM channel_group_connections WHERE channel_id = :channel_id AND domain_id = :domain_id AND group_id = :group_id AND type = :type LIMIT 1`
+	}
+	rows, err := cr.db.NamedQueryContext(ctx, query, toDBGroupConnection(conn))
 	if err != nil {
 		return postgres.HandleError(repoerr.ErrViewEntity, err)
 	}
@@ -765,6 +994,47 @@ func (cr *channelRepository) ClientAuthorize(ctx context.Context, conn channels.
 	return nil
 }
 
+func (cr *channelRepository) DoesChannelHaveGroupConnections(ctx context.Context, id string) (bool, error) {
+	query := `SELECT 1 FROM channel_group_connections WHERE channel_id = :channel_id LIMIT 1`
+	dbConn := dbGroupConnection{ChannelID: id}
+
+	rows, err := cr.db.NamedQueryContext(ctx, query, dbConn)
+	if err != nil {
+		return false, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	return rows.Next(), nil
+}
+
+func (cr *channelRepository) ClientGroupAuthorize(ctx context.Context, conn channels.Connection, parentGroupID string) (string, error) {
+	query := `SELECT cgc.domain_id FROM channel_group_connections cgc
+	JOIN groups g ON g.id = cgc.group_id
+	JOIN groups pg ON pg.id = :parent_group_id
+	WHERE cgc.channel_id = :channel_id AND cgc.type = :type AND pg.path <@ g.path
+	LIMIT 1`
+	params := map[string]interface{}{
+		"channel_id":      conn.ChannelID,
+		"type":            conn.Type,
+		"parent_group_id": parentGroupID,
+	}
+	rows, err := cr.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return "", postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return "", repoerr.ErrNotFound
+	}
+	var domainID string
+	if err := rows.Scan(&domainID); err != nil {
+		return "", postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return domainID, nil
+}
+
 func (cr *channelRepository) ChannelConnectionsCount(ctx context.Context, id string) (uint64, error) {
 	query := `SELECT COUNT(*) FROM connections WHERE channel_id = :channel_id`
 	dbConn := dbConnection{ChannelID: id}
@@ -790,7 +1060,10 @@ func (cr *channelRepository) DoesChannelHaveConnections(ctx context.Context, id
 }
 
 func (cr *channelRepository) RemoveClientConnections(ctx context.Context, clientID string) error {
-	query := `DELETE FROM connections WHERE client_id = :client_id`
+	query := `WITH removed AS (
+		DELETE FROM client_group_connections WHERE client_id = :client_id
+	)
+	DELETE FROM connections WHERE client_id = :client_id`
 
 	dbConn := dbConnection{ClientID: clientID}
 	if _, err := cr.db.NamedExecContext(ctx, query, dbConn); err != nil {
@@ -837,7 +1110,12 @@ func (cr *channelRepository) RetrieveParentGroupChannels(ctx context.Context, pa
 }
 
 func (cr *channelRepository) UnsetParentGroupFromChannels(ctx context.Context, parentGroupID string) error {
-	query := "UPDATE channels SET parent_group_id = NULL WHERE parent_group_id = :parent_group_id"
+	query := `WITH removed_channels AS (
+		DELETE FROM channel_group_connections WHERE group_id = :parent_group_id
+	), removed_clients AS (
+		DELETE FROM client_group_connections WHERE group_id = :parent_group_id
+	)
+	UPDATE channels SET parent_group_id = NULL WHERE parent_group_id = :parent_group_id`
 
 	if _, err := cr.db.NamedExecContext(ctx, query, dbChannel{ParentGroup: toNullString(parentGroupID)}); err != nil {
 		return errors.Wrap(repoerr.ErrRemoveEntity, err)
@@ -1137,6 +1415,47 @@ type dbConnection struct {
 	DomainID  string               `db:"domain_id"`
 	ClientID  string               `db:"client_id"`
 	Type      connections.ConnType `db:"type"`
+	ExpiresAt sql.NullTime         `db:"expires_at"`
+	Subtopics pq.StringArray       `db:"subtopics"`
+}
+
+type dbGroupConnection struct {
+	GroupID   string               `db:"group_id"`
+	ChannelID string               `db:"channel_id"`
+	ClientID  string               `db:"client_id"`
+	DomainID  string               `db:"domain_id"`
+	Type      connections.ConnType `db:"type"`
+}
+
+func toDBGroupConnections(conns []channels.GroupConnection) (chConns, clConns []dbGroupConnection) {
+	for _, conn := range conns {
+		switch conn.ChannelID {
+		case "":
+			clConns = append(clConns, toDBGroupConnection(conn))
+		default:
+			chConns = append(chConns, toDBGroupConnection(conn))
+		}
+	}
+	return chConns, clConns
+}
+
+func toDBGroupConnection(conn channels.GroupConnection) dbGroupConnection {
+	return dbGroupConnection{
+		GroupID:   conn.GroupID,
+		ChannelID: conn.ChannelID,
+		ClientID:  conn.ClientID,
+		DomainID:  conn.DomainID,
+		Type:      conn.Type,
+	}
+}
+
+func toScope(dbs dbConnection) connections.Scope {
+	scope := connections.Scope{Subtopics: dbs.Subtopics}
+	if dbs.ExpiresAt.Valid {
+		scope.ExpiresAt = dbs.ExpiresAt.Time
+	}
+
+	return scope
 }
 
 func toDBConnections(conns []channels.Connection) []dbConnection {
@@ -1148,10 +1467,17 @@ func toDBConnections(conns []channels.Connection) []dbConnection {
 }
 
 func toDBConnection(conn channels.Connection) dbConnection {
+	var expiresAt sql.NullTime
+	if !conn.ExpiresAt.IsZero() {
+		expiresAt = sql.NullTime{Time: conn.ExpiresAt.UTC(), Valid: true}
+	}
+
 	return dbConnection{
 		ClientID:  conn.ClientID,
 		ChannelID: conn.ChannelID,
 		DomainID:  conn.DomainID,
 		Type:      conn.Type,
+		ExpiresAt: expiresAt,
+		Subtopics: conn.Subtopics,
 	}
 }
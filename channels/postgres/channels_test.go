@@ -855,6 +855,18 @@ func TestAddConnection(t *testing.T) {
 	_, err := repo.Save(context.Background(), validChannel)
 	require.Nil(t, err, fmt.Sprintf("save channel unexpected error: %s", err))
 
+	expiredConnection := channels.Connection{
+		ClientID:  testsutil.GenerateUUID(t),
+		ChannelID: validChannel.ID,
+		DomainID:  validChannel.Domain,
+		Type:      connections.Publish,
+		Scope:     connections.Scope{ExpiresAt: time.Now().Add(-time.Hour)},
+	}
+	err = repo.AddConnections(context.Background(), []channels.Connection{expiredConnection})
+	require.Nil(t, err, fmt.Sprintf("add expired connection unexpected error: %s", err))
+	reconnection := expiredConnection
+	reconnection.Scope = connections.Scope{ExpiresAt: time.Now().Add(time.Hour)}
+
 	cases := []struct {
 		desc       string
 		connection channels.Connection
@@ -865,6 +877,16 @@ func TestAddConnection(t *testing.T) {
 			connection: validConnection,
 			err:        nil,
 		},
+		{
+			desc:       "add existing connection",
+			connection: validConnection,
+			err:        repoerr.ErrConflict,
+		},
+		{
+			desc:       "add connection replacing expired connection",
+			connection: reconnection,
+			err:        nil,
+		},
 		{
 			desc: "add connection with non-existent channel",
 			connection: channels.Connection{
@@ -1071,12 +1093,30 @@ func TestClientAuthorize(t *testing.T) {
 	_, err := repo.Save(context.Background(), validChannel)
 	require.Nil(t, err, fmt.Sprintf("save channel unexpected error: %s", err))
 
-	err = repo.AddConnections(context.Background(), []channels.Connection{validConnection})
+	scopedConnection := channels.Connection{
+		ClientID:  testsutil.GenerateUUID(t),
+		ChannelID: validChannel.ID,
+		DomainID:  validChannel.Domain,
+		Type:      connections.Publish,
+		Scope: connections.Scope{
+			ExpiresAt: time.Now().Add(time.Hour),
+			Subtopics: []string{"sensors.temp.*"},
+		},
+	}
+	expiredConnection := channels.Connection{
+		ClientID:  testsutil.GenerateUUID(t),
+		ChannelID: validChannel.ID,
+		DomainID:  validChannel.Domain,
+		Type:      connections.Publish,
+		Scope:     connections.Scope{ExpiresAt: time.Now().Add(-time.Hour)},
+	}
+	err = repo.AddConnections(context.Background(), []channels.Connection{validConnection, scopedConnection, expiredConnection})
 	require.Nil(t, err, fmt.Sprintf("add connection unexpected error: %s", err))
 
 	cases := []struct {
 		desc       string
 		connection channels.Connection
+		subtopic   string
 		err        error
 	}{
 		{
@@ -1084,6 +1124,34 @@ func TestClientAuthorize(t *testing.T) {
 			connection: validConnection,
 			err:        nil,
 		},
+		{
+			desc:       "authorize successfully with subtopic",
+			connection: validConnection,
+			subtopic:   "sensors.humidity",
+			err:        nil,
+		},
+		{
+			desc:       "authorize scoped connection with allowed subtopic",
+			connection: scopedConnection,
+			subtopic:   "sensors.temp.room1",
+			err:        nil,
+		},
+		{
+			desc:       "authorize scoped connection with not allowed subtopic",
+			connection: scopedConnection,
+			subtopic:   "sensors.humidity.room1",
+			err:        repoerr.ErrNotFound,
+		},
+		{
+			desc:       "authorize scoped connection without subtopic",
+			connection: scopedConnection,
+			err:        repoerr.ErrNotFound,
+		},
+		{
+			desc:       "authorize expired connection",
+			connection: expiredConnection,
+			err:        repoerr.ErrNotFound,
+		},
 		{
 			desc: "authorize with  non-existent channel",
 			connection: channels.Connection{
@@ -1108,8 +1176,11 @@ func TestClientAuthorize(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			err := repo.ClientAuthorize(context.Background(), tc.connection)
+			domainID, err := repo.ClientAuthorize(context.Background(), tc.connection, tc.subtopic)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, validChannel.Domain, domainID, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, validChannel.Domain, domainID))
+			}
 		})
 	}
 }
@@ -1273,6 +1344,37 @@ func TestRemoveClientConnections(t *testing.T) {
 	}
 }
 
+func TestRemoveExpiredConnections(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM connections")
+		require.Nil(t, err, fmt.Sprintf("clean connections unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM channels")
+		require.Nil(t, err, fmt.Sprintf("clean channels unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+
+	_, err := repo.Save(context.Background(), validChannel)
+	require.Nil(t, err, fmt.Sprintf("save channel unexpected error: %s", err))
+
+	expiredConnection := channels.Connection{
+		ClientID:  testsutil.GenerateUUID(t),
+		ChannelID: validChannel.ID,
+		DomainID:  validChannel.Domain,
+		Type:      connections.Publish,
+		Scope:     connections.Scope{ExpiresAt: time.Now().Add(-time.Hour)},
+	}
+	err = repo.AddConnections(context.Background(), []channels.Connection{validConnection, expiredConnection})
+	require.Nil(t, err, fmt.Sprintf("add connection unexpected error: %s", err))
+
+	removed, err := repo.RemoveExpiredConnections(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("remove expired connections unexpected error: %s", err))
+	assert.Equal(t, uint64(1), removed, fmt.Sprintf("expected 1 removed connection got %d", removed))
+
+	err = repo.CheckConnection(context.Background(), validConnection)
+	assert.Nil(t, err, fmt.Sprintf("check connection unexpected error: %s", err))
+}
+
 func TestRemoveChannelConnections(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM connections")
@@ -53,6 +53,41 @@ func Migration() (*migrate.MemoryMigrationSource, error) {
 					`DROP TABLE IF EXISTS connections`,
 				},
 			},
+			{
+				Id: "channels_02",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS channel_group_connections (
+						channel_id    VARCHAR(36),
+						domain_id     VARCHAR(36),
+						group_id      VARCHAR(36),
+						type          SMALLINT NOT NULL CHECK (type IN (1, 2)),
+						FOREIGN KEY   (channel_id, domain_id) REFERENCES channels (id, domain_id) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY   (channel_id, domain_id, group_id, type)
+					)`,
+					`CREATE TABLE IF NOT EXISTS client_group_connections (
+						client_id     VARCHAR(36),
+						domain_id     VARCHAR(36),
+						group_id      VARCHAR(36),
+						type          SMALLINT NOT NULL CHECK (type IN (1, 2)),
+						PRIMARY KEY   (client_id, domain_id, group_id, type)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS channel_group_connections`,
+					`DROP TABLE IF EXISTS client_group_connections`,
+				},
+			},
+			{
+				Id: "channels_03",
+				Up: []string{
+					`ALTER TABLE connections ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP`,
+					`ALTER TABLE connections ADD COLUMN IF NOT EXISTS subtopics TEXT[]`,
+				},
+				Down: []string{
+					`ALTER TABLE connections DROP COLUMN IF EXISTS expires_at`,
+					`ALTER TABLE connections DROP COLUMN IF EXISTS subtopics`,
+				},
+			},
 		},
 	}
 	channelsMigration.Migrations = append(channelsMigration.Migrations, rolesMigration.Migrations...)
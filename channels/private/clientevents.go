@@ -0,0 +1,76 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package private
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+)
+
+const (
+	clientsStream = "events.supermq.clients"
+
+	clientSetParent    = "client.set_parent"
+	clientRemoveParent = "client.remove_parent"
+	clientMove         = "client.move"
+	clientRemove       = "client.remove"
+)
+
+var (
+	errNoOperationKey = errors.New("operation key is not found in event message")
+	errClientID       = errors.New("client id is not found in event message")
+)
+
+type clientEventHandler struct {
+	groups *ClientGroups
+}
+
+// ClientsEventsSubscribe removes the cached parent groups of the clients once
+// their parent group changes or they are moved or removed. Every service
+// instance keeps its own cache, so the consumer name must be unique per instance.
+func ClientsEventsSubscribe(ctx context.Context, groups *ClientGroups, esURL, esConsumerName string, logger *slog.Logger) error {
+	subscriber, err := store.NewSubscriber(ctx, esURL, logger)
+	if err != nil {
+		return err
+	}
+
+	subConfig := events.SubscriberConfig{
+		Stream:   clientsStream,
+		Consumer: esConsumerName,
+		Handler:  NewClientEventHandler(groups),
+	}
+	return subscriber.Subscribe(ctx, subConfig)
+}
+
+// NewClientEventHandler returns the clients event handler which removes the
+// cached parent groups of the clients.
+func NewClientEventHandler(groups *ClientGroups) events.EventHandler {
+	return &clientEventHandler{groups: groups}
+}
+
+func (h *clientEventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	op, ok := msg["operation"]
+	if !ok {
+		return errNoOperationKey
+	}
+	switch op {
+	case clientSetParent, clientRemoveParent, clientMove, clientRemove:
+		id, ok := msg["id"].(string)
+		if !ok {
+			return errClientID
+		}
+		h.groups.Remove(id)
+	}
+
+	return nil
+}
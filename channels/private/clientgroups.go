@@ -0,0 +1,88 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package private
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+)
+
+type clientGroup struct {
+	parentGroupID string
+	checkedAt     time.Time
+}
+
+// ClientGroups keeps the parent groups of the clients in memory, so the
+// clients service is not called for every message published to the channel
+// connected to a group of clients. The parent group of the client is removed
+// once the client parent group changes, the client is moved or removed, and is
+// re-read once the refresh period passes in case the change is missed.
+type ClientGroups struct {
+	clients grpcClientsV1.ClientsServiceClient
+	refresh time.Duration
+	mu      sync.Mutex
+	groups  map[string]clientGroup
+	evictAt time.Time
+}
+
+// NewClientGroups returns the parent groups of the clients kept in memory.
+func NewClientGroups(clients grpcClientsV1.ClientsServiceClient, refresh time.Duration) *ClientGroups {
+	return &ClientGroups{
+		clients: clients,
+		refresh: refresh,
+		groups:  make(map[string]clientGroup),
+	}
+}
+
+// parentGroup returns the ID of the client parent group or an empty string
+// if the client has no parent group.
+func (cg *ClientGroups) parentGroup(ctx context.Context, clientID string) (string, error) {
+	cg.mu.Lock()
+	g, ok := cg.groups[clientID]
+	cg.mu.Unlock()
+	if ok && time.Since(g.checkedAt) < cg.refresh {
+		return g.parentGroupID, nil
+	}
+
+	resp, err := cg.clients.RetrieveEntity(ctx, &grpcCommonV1.RetrieveEntityReq{Id: clientID})
+	if err != nil {
+		return "", err
+	}
+	parentGroupID := resp.GetEntity().GetParentGroupId()
+
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+	cg.evict()
+	cg.groups[clientID] = clientGroup{parentGroupID: parentGroupID, checkedAt: time.Now()}
+
+	return parentGroupID, nil
+}
+
+// Remove removes the parent group of the client, so it is re-read on the
+// next authorization.
+func (cg *ClientGroups) Remove(clientID string) {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+
+	delete(cg.groups, clientID)
+}
+
+// evict removes the parent groups which are not fresh anymore, so the clients
+// which stopped publishing are not kept in memory.
+func (cg *ClientGroups) evict() {
+	now := time.Now()
+	if now.Before(cg.evictAt) {
+		return
+	}
+	for id, g := range cg.groups {
+		if now.Sub(g.checkedAt) >= cg.refresh {
+			delete(cg.groups, id)
+		}
+	}
+	cg.evictAt = now.Add(cg.refresh)
+}
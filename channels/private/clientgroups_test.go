@@ -0,0 +1,107 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package private
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	climocks "github.com/absmach/supermq/clients/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type event map[string]interface{}
+
+func (e event) Encode() (map[string]interface{}, error) {
+	return e, nil
+}
+
+func TestClientGroupsParentGroup(t *testing.T) {
+	clientID := testsutil.GenerateUUID(t)
+	groupID := testsutil.GenerateUUID(t)
+	refresh := 50 * time.Millisecond
+	entity := &grpcCommonV1.RetrieveEntityRes{Entity: &grpcCommonV1.EntityBasic{Id: clientID, ParentGroupId: groupID}}
+
+	cases := []struct {
+		desc  string
+		apply func(cg *ClientGroups)
+		reads int
+	}{
+		{
+			desc:  "retrieve cached parent group within refresh period",
+			apply: func(cg *ClientGroups) {},
+			reads: 1,
+		},
+		{
+			desc:  "retrieve parent group after refresh period",
+			apply: func(cg *ClientGroups) { time.Sleep(refresh) },
+			reads: 2,
+		},
+		{
+			desc:  "retrieve parent group after removal",
+			apply: func(cg *ClientGroups) { cg.Remove(clientID) },
+			reads: 2,
+		},
+		{
+			desc: "retrieve parent group after client parent group change event",
+			apply: func(cg *ClientGroups) {
+				err := NewClientEventHandler(cg).Handle(context.Background(), event{"operation": clientRemoveParent, "id": clientID})
+				assert.Nil(t, err, fmt.Sprintf("handle event unexpected error: %s", err))
+			},
+			reads: 2,
+		},
+		{
+			desc: "retrieve cached parent group after other client event",
+			apply: func(cg *ClientGroups) {
+				err := NewClientEventHandler(cg).Handle(context.Background(), event{"operation": "client.update", "id": clientID})
+				assert.Nil(t, err, fmt.Sprintf("handle event unexpected error: %s", err))
+			},
+			reads: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			clients := new(climocks.ClientsServiceClient)
+			clients.On("RetrieveEntity", mock.Anything, &grpcCommonV1.RetrieveEntityReq{Id: clientID}).Return(entity, nil)
+			cg := NewClientGroups(clients, refresh)
+
+			for i := 0; i < 2; i++ {
+				id, err := cg.parentGroup(context.Background(), clientID)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				assert.Equal(t, groupID, id, fmt.Sprintf("%s: expected parent group %s got %s", tc.desc, groupID, id))
+				if i == 0 {
+					tc.apply(cg)
+				}
+			}
+			clients.AssertNumberOfCalls(t, "RetrieveEntity", tc.reads)
+		})
+	}
+}
+
+func TestClientGroupsEvict(t *testing.T) {
+	refresh := 50 * time.Millisecond
+	clients := new(climocks.ClientsServiceClient)
+	clients.On("RetrieveEntity", mock.Anything, mock.Anything).Return(&grpcCommonV1.RetrieveEntityRes{Entity: &grpcCommonV1.EntityBasic{}}, nil)
+	cg := NewClientGroups(clients, refresh)
+
+	idle := testsutil.GenerateUUID(t)
+	_, err := cg.parentGroup(context.Background(), idle)
+	assert.Nil(t, err, fmt.Sprintf("retrieve parent group unexpected error: %s", err))
+	time.Sleep(refresh)
+
+	active := testsutil.GenerateUUID(t)
+	_, err = cg.parentGroup(context.Background(), active)
+	assert.Nil(t, err, fmt.Sprintf("retrieve parent group unexpected error: %s", err))
+
+	_, ok := cg.groups[idle]
+	assert.False(t, ok, "expected idle client parent group to be evicted")
+	_, ok = cg.groups[active]
+	assert.True(t, ok, "expected active client parent group to be kept")
+}
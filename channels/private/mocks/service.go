@@ -35,6 +35,36 @@ func (_m *Service) Authorize(ctx context.Context, req channels.AuthzReq) error {
 	return r0
 }
 
+// MoveParentGroupChannels provides a mock function with given fields: ctx, parentGroupIDs, clientIDs, domainID, updatedBy
+func (_m *Service) MoveParentGroupChannels(ctx context.Context, parentGroupIDs []string, clientIDs []string, domainID string, updatedBy string) ([]string, error) {
+	ret := _m.Called(ctx, parentGroupIDs, clientIDs, domainID, updatedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveParentGroupChannels")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string, string, string) ([]string, error)); ok {
+		return rf(ctx, parentGroupIDs, clientIDs, domainID, updatedBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string, string, string) []string); ok {
+		r0 = rf(ctx, parentGroupIDs, clientIDs, domainID, updatedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, []string, string, string) error); ok {
+		r1 = rf(ctx, parentGroupIDs, clientIDs, domainID, updatedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveClientConnections provides a mock function with given fields: ctx, clientID
 func (_m *Service) RemoveClientConnections(ctx context.Context, clientID string) error {
 	ret := _m.Called(ctx, clientID)
@@ -81,6 +111,34 @@ func (_m *Service) RetrieveByID(ctx context.Context, id string) (channels.Channe
 	return r0, r1
 }
 
+// RetrieveIDByName provides a mock function with given fields: ctx, domainID, name
+func (_m *Service) RetrieveIDByName(ctx context.Context, domainID string, name string) (string, error) {
+	ret := _m.Called(ctx, domainID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveIDByName")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, domainID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, domainID, name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, domainID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UnsetParentGroupFromChannels provides a mock function with given fields: ctx, parentGroupID
 func (_m *Service) UnsetParentGroupFromChannels(ctx context.Context, parentGroupID string) error {
 	ret := _m.Called(ctx, parentGroupID)
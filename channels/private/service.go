@@ -5,12 +5,21 @@ package private
 
 import (
 	"context"
+	"time"
 
+	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
 	"github.com/absmach/supermq/auth"
 	"github.com/absmach/supermq/channels"
+	smqclients "github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/domains"
+	"github.com/absmach/supermq/pkg/connections"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
 	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/roles"
 )
 
 //go:generate mockery --name Service  --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
@@ -19,26 +28,67 @@ type Service interface {
 	UnsetParentGroupFromChannels(ctx context.Context, parentGroupID string) error
 	RemoveClientConnections(ctx context.Context, clientID string) error
 	RetrieveByID(ctx context.Context, id string) (channels.Channel, error)
+	// RetrieveIDByName retrieves the ID of the channel with the given name in the domain.
+	RetrieveIDByName(ctx context.Context, domainID, name string) (string, error)
+	// MoveParentGroupChannels moves the channels of the given parent groups to
+	// the domain and removes the connections of the moved channels and of the
+	// given clients. It returns the IDs of the moved channels.
+	MoveParentGroupChannels(ctx context.Context, parentGroupIDs, clientIDs []string, domainID, updatedBy string) ([]string, error)
 }
 
 type service struct {
 	repo      channels.Repository
 	evaluator policies.Evaluator
 	policy    policies.Service
+	clients   grpcClientsV1.ClientsServiceClient
+	limiter   pkgDomains.MessageLimiter
+	settings  pkgDomains.Settings
+	quotas    pkgDomains.Quotas
+	groups    *ClientGroups
 }
 
 var _ Service = (*service)(nil)
 
-func New(repo channels.Repository, evaluator policies.Evaluator, policy policies.Service) Service {
-	return service{repo, evaluator, policy}
+func New(repo channels.Repository, evaluator policies.Evaluator, policy policies.Service, clients grpcClientsV1.ClientsServiceClient, limiter pkgDomains.MessageLimiter, settings pkgDomains.Settings, quotas pkgDomains.Quotas, groups *ClientGroups) Service {
+	return service{repo, evaluator, policy, clients, limiter, settings, quotas, groups}
 }
 
+// Authorize checks the client or user access to the channel and applies the
+// settings and quotas of the channel domain. The domain is returned by the
+// connection check, so no additional lookup of the channel is needed.
 func (svc service) Authorize(ctx context.Context, req channels.AuthzReq) error {
+	domainID, err := svc.authorize(ctx, req)
+	if err != nil {
+		return err
+	}
+	if req.Protocol == "" && req.Type != connections.Publish {
+		return nil
+	}
+
+	if req.Protocol != "" {
+		settings, err := svc.settings.RetrieveSettings(ctx, domainID)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+		if !settings.AllowsProtocol(req.Protocol) {
+			return errors.Wrap(svcerr.ErrAuthorization, domains.ErrProtocolNotAllowed)
+		}
+	}
+	if req.Type != connections.Publish {
+		return nil
+	}
+
+	// Publishing is limited by the domain messages per minute quota.
+	return svc.limiter.Allow(ctx, domainID)
+}
+
+// authorize returns the ID of the channel domain.
+func (svc service) authorize(ctx context.Context, req channels.AuthzReq) (string, error) {
 	switch req.ClientType {
 	case policies.UserType:
 		permission, err := req.Type.Permission()
 		if err != nil {
-			return err
+			return "", err
 		}
 		pr := policies.Policy{
 			Subject:     auth.EncodeDomainUserID(req.DomainID, req.ClientID),
@@ -48,25 +98,56 @@ func (svc service) Authorize(ctx context.Context, req channels.AuthzReq) error {
 			ObjectType:  policies.ChannelType,
 		}
 		if err := svc.evaluator.CheckPolicy(ctx, pr); err != nil {
-			return errors.Wrap(svcerr.ErrAuthorization, err)
+			return "", errors.Wrap(svcerr.ErrAuthorization, err)
 		}
-		return nil
+		return req.DomainID, nil
 	case policies.ClientType:
 		// Optimization: Add cache
-		if err := svc.repo.ClientAuthorize(ctx, channels.Connection{
+		conn := channels.Connection{
 			ChannelID: req.ChannelID,
 			ClientID:  req.ClientID,
 			Type:      req.Type,
-		}); err != nil {
-			return errors.Wrap(svcerr.ErrAuthorization, err)
 		}
-		return nil
+		domainID, err := svc.repo.ClientAuthorize(ctx, conn, req.Subtopic)
+		if err == repoerr.ErrNotFound {
+			domainID, err = svc.clientGroupAuthorize(ctx, conn)
+		}
+		if err != nil {
+			return "", errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+		return domainID, nil
 	default:
-		return svcerr.ErrAuthentication
+		return "", svcerr.ErrAuthentication
+	}
+}
+
+// clientGroupAuthorize checks whether the channel is connected to a group
+// containing the client. The client parent group is looked up only if the
+// channel is connected to any group of clients.
+func (svc service) clientGroupAuthorize(ctx context.Context, conn channels.Connection) (string, error) {
+	ok, err := svc.repo.DoesChannelHaveGroupConnections(ctx, conn.ChannelID)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", repoerr.ErrNotFound
+	}
+
+	parentGroupID, err := svc.groups.parentGroup(ctx, conn.ClientID)
+	if err != nil {
+		return "", err
+	}
+	if parentGroupID == "" {
+		return "", repoerr.ErrNotFound
 	}
+
+	return svc.repo.ClientGroupAuthorize(ctx, conn, parentGroupID)
 }
 
 func (svc service) RemoveClientConnections(ctx context.Context, clientID string) error {
+	// The connections are removed once the client is moved or removed.
+	svc.groups.Remove(clientID)
+
 	return svc.repo.RemoveClientConnections(ctx, clientID)
 }
 
@@ -109,3 +190,182 @@ func (svc service) UnsetParentGroupFromChannels(ctx context.Context, parentGroup
 func (svc service) RetrieveByID(ctx context.Context, id string) (channels.Channel, error) {
 	return svc.repo.RetrieveByID(ctx, id)
 }
+
+func (svc service) RetrieveIDByName(ctx context.Context, domainID, name string) (string, error) {
+	ch, err := svc.repo.RetrieveByName(ctx, domainID, name)
+	if err != nil {
+		return "", err
+	}
+
+	return ch.ID, nil
+}
+
+// MoveParentGroupChannels moves the channels of the parent groups to the domain
+// together with their roles, within the domain channels quota. The channels keep
+// their parent groups, which are moved by the groups service. The connections of
+// the moved channels and clients are removed, since they can't be connected to
+// the entities of the other domain.
+func (svc service) MoveParentGroupChannels(ctx context.Context, parentGroupIDs, clientIDs []string, domainID, updatedBy string) (retIDs []string, retErr error) {
+	var chs []channels.Channel
+	for _, id := range parentGroupIDs {
+		pgChannels, err := svc.repo.RetrieveParentGroupChannels(ctx, id)
+		if err != nil {
+			return []string{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		chs = append(chs, pgChannels...)
+	}
+
+	ids := []string{}
+	domainChannels := map[string][]string{}
+	var oldPolicies, newPolicies []policies.Policy
+	for _, ch := range chs {
+		ids = append(ids, ch.ID)
+		domainChannels[ch.Domain] = append(domainChannels[ch.Domain], ch.ID)
+		oldPolicies = append(oldPolicies, policies.Policy{
+			Domain:      ch.Domain,
+			SubjectType: policies.DomainType,
+			Subject:     ch.Domain,
+			Relation:    policies.DomainRelation,
+			ObjectType:  policies.ChannelType,
+			Object:      ch.ID,
+		})
+		newPolicies = append(newPolicies, policies.Policy{
+			Domain:      domainID,
+			SubjectType: policies.DomainType,
+			Subject:     domainID,
+			Relation:    policies.DomainRelation,
+			ObjectType:  policies.ChannelType,
+			Object:      ch.ID,
+		})
+	}
+
+	if len(chs) > 0 {
+		unlock, err := svc.lockQuota(ctx, domainID, uint64(len(chs)))
+		if err != nil {
+			return []string{}, err
+		}
+		defer unlock()
+	}
+
+	conns, err := svc.repo.RetrieveEntitiesConnections(ctx, ids, clientIDs)
+	if err != nil {
+		return []string{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if len(conns) > 0 {
+		// The moved clients are already in the new domain in the clients service.
+		movedClients := map[string]bool{}
+		for _, id := range clientIDs {
+			movedClients[id] = true
+		}
+		cliConns := []*grpcCommonV1.Connection{}
+		for _, conn := range conns {
+			cliDomainID := conn.DomainID
+			if movedClients[conn.ClientID] {
+				cliDomainID = domainID
+			}
+			var expiresAt int64
+			if !conn.ExpiresAt.IsZero() {
+				expiresAt = conn.ExpiresAt.Unix()
+			}
+			cliConns = append(cliConns, &grpcCommonV1.Connection{
+				ClientId:  conn.ClientID,
+				ChannelId: conn.ChannelID,
+				DomainId:  cliDomainID,
+				Type:      uint32(conn.Type),
+				ExpiresAt: expiresAt,
+				Subtopics: conn.Subtopics,
+			})
+		}
+
+		if err := svc.repo.RemoveConnections(ctx, conns); err != nil {
+			return []string{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
+		}
+		defer func() {
+			if retErr != nil {
+				if errRollback := svc.repo.AddConnections(ctx, conns); errRollback != nil {
+					retErr = errors.Wrap(retErr, errors.Wrap(errors.ErrRollbackTx, errRollback))
+				}
+			}
+		}()
+		if _, err := svc.clients.RemoveConnections(ctx, &grpcCommonV1.RemoveConnectionsReq{Connections: cliConns}); err != nil {
+			return []string{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
+		}
+		defer func() {
+			if retErr != nil {
+				if _, errRollback := svc.clients.AddConnections(ctx, &grpcCommonV1.AddConnectionsReq{Connections: cliConns}); errRollback != nil {
+					retErr = errors.Wrap(retErr, errors.Wrap(errors.ErrRollbackTx, errRollback))
+				}
+			}
+		}()
+	}
+
+	if len(chs) > 0 {
+		if err := svc.policy.DeletePolicies(ctx, oldPolicies); err != nil {
+			return []string{}, errors.Wrap(svcerr.ErrDeletePolicies, err)
+		}
+		defer func() {
+			if retErr != nil {
+				if errRollback := svc.policy.AddPolicies(ctx, oldPolicies); errRollback != nil {
+					retErr = errors.Wrap(retErr, errors.Wrap(errors.ErrRollbackTx, errRollback))
+				}
+			}
+		}()
+		if err := svc.policy.AddPolicies(ctx, newPolicies); err != nil {
+			return []string{}, errors.Wrap(svcerr.ErrAddPolicies, err)
+		}
+		defer func() {
+			if retErr != nil {
+				if errRollback := svc.policy.DeletePolicies(ctx, newPolicies); errRollback != nil {
+					retErr = errors.Wrap(retErr, errors.Wrap(errors.ErrRollbackTx, errRollback))
+				}
+			}
+		}()
+	}
+	for fromDomainID, chIDs := range domainChannels {
+		if err := roles.MoveEntitiesRoles(ctx, svc.repo, svc.policy, fromDomainID, domainID, chIDs); err != nil {
+			return []string{}, err
+		}
+		defer func() {
+			if retErr != nil {
+				if errRollback := roles.MoveEntitiesRoles(ctx, svc.repo, svc.policy, domainID, fromDomainID, chIDs); errRollback != nil {
+					retErr = errors.Wrap(retErr, errors.Wrap(errors.ErrRollbackTx, errRollback))
+				}
+			}
+		}()
+	}
+
+	// The group connections of the moved clients are removed even if there
+	// are no channels to move.
+	if _, err := svc.repo.ChangeParentGroupsDomain(ctx, channels.Channel{Domain: domainID, UpdatedBy: updatedBy, UpdatedAt: time.Now()}, parentGroupIDs, clientIDs); err != nil {
+		return []string{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return ids, nil
+}
+
+// lockQuota locks the domain quotas and checks that the requested channels
+// fit into the domain channels quota. The returned function unlocks the quotas.
+func (svc service) lockQuota(ctx context.Context, domainID string, requested uint64) (func(), error) {
+	q, err := svc.quotas.RetrieveQuotas(ctx, domainID)
+	if err != nil {
+		return nil, err
+	}
+	if q.Channels == 0 {
+		return func() {}, nil
+	}
+	unlock, err := svc.quotas.LockQuotas(ctx, domainID)
+	if err != nil {
+		return nil, err
+	}
+	page, err := svc.repo.RetrieveAll(ctx, channels.PageMetadata{Domain: domainID, Status: smqclients.AllStatus, Limit: 1})
+	if err != nil {
+		unlock()
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if err := domains.CheckQuota(q.Channels, page.Total, requested); err != nil {
+		unlock()
+		return nil, err
+	}
+
+	return unlock, nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package channels
+
+import (
+	"context"
+
+	smqclients "github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/domains"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/roles"
+)
+
+const purgeLimit = uint64(100)
+
+var _ domains.Purger = (*domainPurger)(nil)
+
+type domainPurger struct {
+	repo        Repository
+	provisioner roles.Provisioner
+}
+
+// NewDomainPurger returns the purger that removes the channels of the purged
+// domain, together with their roles, policies and connections.
+func NewDomainPurger(repo Repository, provisioner roles.Provisioner) domains.Purger {
+	return &domainPurger{
+		repo:        repo,
+		provisioner: provisioner,
+	}
+}
+
+func (dp *domainPurger) PurgeDomain(ctx context.Context, domainID string) error {
+	for {
+		page, err := dp.repo.RetrieveAll(ctx, PageMetadata{Domain: domainID, Status: smqclients.AllStatus, Limit: purgeLimit})
+		if err != nil {
+			return errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if len(page.Channels) == 0 {
+			return nil
+		}
+
+		ids := []string{}
+		filterDeletePolicies := []policies.Policy{}
+		for _, ch := range page.Channels {
+			ids = append(ids, ch.ID)
+			filterDeletePolicies = append(filterDeletePolicies,
+				policies.Policy{SubjectType: policies.ChannelType, Subject: ch.ID},
+				policies.Policy{ObjectType: policies.ChannelType, Object: ch.ID},
+			)
+		}
+		if err := dp.provisioner.RemoveEntitiesRoles(ctx, domainID, "", ids, filterDeletePolicies, nil); err != nil {
+			return errors.Wrap(svcerr.ErrDeletePolicies, err)
+		}
+		// Connections are removed by the database cascade.
+		if err := dp.repo.Remove(ctx, ids...); err != nil {
+			return errors.Wrap(svcerr.ErrRemoveEntity, err)
+		}
+	}
+}
@@ -21,6 +21,7 @@ const (
 	OpRemoveParentGroup
 	OpConnectClient
 	OpDisconnectClient
+	OpMoveChannel
 )
 
 var expectedOperations = []svcutil.Operation{
@@ -34,6 +35,7 @@ var expectedOperations = []svcutil.Operation{
 	OpRemoveParentGroup,
 	OpConnectClient,
 	OpDisconnectClient,
+	OpMoveChannel,
 }
 
 var operationNames = []string{
@@ -47,6 +49,7 @@ var operationNames = []string{
 	"OpRemoveParentGroup",
 	"OpConnectClient",
 	"OpDisconnectClient",
+	"OpMoveChannel",
 }
 
 func NewOperationPerm() svcutil.OperationPerm {
@@ -61,6 +64,10 @@ const (
 	GroupsOpRemoveChildChannel
 	ClientsOpConnectChannel
 	ClientsOpDisconnectChannel
+	GroupsOpConnectClients
+	GroupsOpDisconnectClients
+	GroupsOpConnectChannels
+	GroupsOpDisconnectChannels
 )
 
 var expectedExternalOperations = []svcutil.ExternalOperation{
@@ -70,6 +77,10 @@ var expectedExternalOperations = []svcutil.ExternalOperation{
 	GroupsOpRemoveChildChannel,
 	ClientsOpConnectChannel,
 	ClientsOpDisconnectChannel,
+	GroupsOpConnectClients,
+	GroupsOpDisconnectClients,
+	GroupsOpConnectChannels,
+	GroupsOpDisconnectChannels,
 }
 
 var externalOperationNames = []string{
@@ -79,6 +90,10 @@ var externalOperationNames = []string{
 	"GroupsOpRemoveChildChannel",
 	"ClientsOpConnectChannel",
 	"ClientsOpDisconnectChannel",
+	"GroupsOpConnectClients",
+	"GroupsOpDisconnectClients",
+	"GroupsOpConnectChannels",
+	"GroupsOpDisconnectChannels",
 }
 
 func NewExternalOperationPerm() svcutil.ExternalOperationPerm {
@@ -112,6 +127,7 @@ func NewOperationPermissionMap() map[svcutil.Operation]svcutil.Permission {
 		OpRemoveParentGroup: setParentGroupPermission,
 		OpConnectClient:     connectToClientPermission,
 		OpDisconnectClient:  connectToClientPermission,
+		OpMoveChannel:       deletePermission,
 	}
 	return opPerm
 }
@@ -148,6 +164,11 @@ const (
 	// Client.
 	clientsConnectChannelPermission    = "connect_to_channel_permission"
 	clientsDisconnectChannelPermission = "connect_to_channel_permission"
+	// Group connections.
+	groupsConnectClientsPermission     = "client_connect_to_channel_permission"
+	groupsDisconnectClientsPermission  = "client_connect_to_channel_permission"
+	groupsConnectChannelsPermission    = "channel_connect_to_client_permission"
+	groupsDisconnectChannelsPermission = "channel_connect_to_client_permission"
 )
 
 func NewExternalOperationPermissionMap() map[svcutil.ExternalOperation]svcutil.Permission {
@@ -158,6 +179,10 @@ func NewExternalOperationPermissionMap() map[svcutil.ExternalOperation]svcutil.P
 		GroupsOpRemoveChildChannel: groupRemoveChildChannelPermission,
 		ClientsOpConnectChannel:    clientsConnectChannelPermission,
 		ClientsOpDisconnectChannel: clientsDisconnectChannelPermission,
+		GroupsOpConnectClients:     groupsConnectClientsPermission,
+		GroupsOpDisconnectClients:  groupsDisconnectClientsPermission,
+		GroupsOpConnectChannels:    groupsConnectChannelsPermission,
+		GroupsOpDisconnectChannels: groupsDisconnectChannelsPermission,
 	}
 	return extOpPerm
 }
@@ -14,11 +14,14 @@ import (
 	grpcGroupsV1 "github.com/absmach/supermq/api/grpc/groups/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	smqclients "github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/domains"
 	"github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/connections"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	pkgGroups "github.com/absmach/supermq/pkg/groups"
 	"github.com/absmach/supermq/pkg/policies"
 	"github.com/absmach/supermq/pkg/roles"
 )
@@ -27,6 +30,7 @@ var (
 	errAddConnectionsClients    = errors.New("failed to add connections in clients service")
 	errRemoveConnectionsClients = errors.New("failed to remove connections from clients service")
 	errSetParentGroup           = errors.New("channel already have parent")
+	errSameDomain               = errors.New("channel already belongs to the domain")
 )
 
 type service struct {
@@ -35,12 +39,13 @@ type service struct {
 	idProvider supermq.IDProvider
 	clients    grpcClientsV1.ClientsServiceClient
 	groups     grpcGroupsV1.GroupsServiceClient
+	quotas     pkgDomains.Quotas
 	roles.ProvisionManageService
 }
 
 var _ Service = (*service)(nil)
 
-func New(repo Repository, policy policies.Service, idProvider supermq.IDProvider, clients grpcClientsV1.ClientsServiceClient, groups grpcGroupsV1.GroupsServiceClient, sidProvider supermq.IDProvider, availableActions []roles.Action, builtInRoles map[roles.BuiltInRoleName][]roles.Action) (Service, error) {
+func New(repo Repository, policy policies.Service, idProvider supermq.IDProvider, clients grpcClientsV1.ClientsServiceClient, groups grpcGroupsV1.GroupsServiceClient, quotas pkgDomains.Quotas, sidProvider supermq.IDProvider, availableActions []roles.Action, builtInRoles map[roles.BuiltInRoleName][]roles.Action) (Service, error) {
 	rpms, err := roles.NewProvisionManageService(policies.ChannelType, repo, policy, sidProvider, availableActions, builtInRoles)
 	if err != nil {
 		return nil, err
@@ -52,6 +57,7 @@ func New(repo Repository, policy policies.Service, idProvider supermq.IDProvider
 		idProvider:             idProvider,
 		clients:                clients,
 		groups:                 groups,
+		quotas:                 quotas,
 		ProvisionManageService: rpms,
 	}, nil
 }
@@ -75,7 +81,12 @@ func (svc service) CreateChannels(ctx context.Context, session authn.Session, ch
 		reChs = append(reChs, c)
 	}
 
+	unlock, err := svc.lockQuota(ctx, session.DomainID, uint64(len(reChs)))
+	if err != nil {
+		return []Channel{}, []roles.RoleProvision{}, err
+	}
 	savedChs, err := svc.repo.Save(ctx, reChs...)
+	unlock()
 	if err != nil {
 		return []Channel{}, []roles.RoleProvision{}, errors.Wrap(svcerr.ErrCreateEntity, err)
 	}
@@ -173,11 +184,22 @@ func (svc service) DisableChannel(ctx context.Context, session authn.Session, id
 	return ch, nil
 }
 
-func (svc service) ViewChannel(ctx context.Context, session authn.Session, id string) (Channel, error) {
+func (svc service) ViewChannel(ctx context.Context, session authn.Session, id string, effective bool) (Channel, error) {
 	channel, err := svc.repo.RetrieveByID(ctx, id)
 	if err != nil {
 		return Channel{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
+	if effective && channel.ParentGroupPath != "" {
+		mds, err := svc.repo.RetrievePathMetadata(ctx, channel.ParentGroupPath)
+		if err != nil {
+			return Channel{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		ancestors := make([]map[string]interface{}, len(mds))
+		for i, md := range mds {
+			ancestors[i] = md
+		}
+		channel.Metadata = pkgGroups.InheritMetadata(ancestors, channel.Metadata)
+	}
 	return channel, nil
 }
 
@@ -264,7 +286,7 @@ func (svc service) RemoveChannel(ctx context.Context, session authn.Session, id
 	return nil
 }
 
-func (svc service) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) (retErr error) {
+func (svc service) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType, scope connections.Scope) (retErr error) {
 	for _, chID := range chIDs {
 		c, err := svc.repo.RetrieveByID(ctx, chID)
 		if err != nil {
@@ -291,6 +313,11 @@ func (svc service) Connect(ctx context.Context, session authn.Session, chIDs, th
 		}
 	}
 
+	var expiresAt int64
+	if !scope.ExpiresAt.IsZero() {
+		expiresAt = scope.ExpiresAt.Unix()
+	}
+
 	conns := []Connection{}
 	cliConns := []*grpcCommonV1.Connection{}
 	for _, chID := range chIDs {
@@ -301,12 +328,15 @@ func (svc service) Connect(ctx context.Context, session authn.Session, chIDs, th
 					ChannelID: chID,
 					DomainID:  session.DomainID,
 					Type:      connType,
+					Scope:     scope,
 				})
 				cliConns = append(cliConns, &grpcCommonV1.Connection{
 					ClientId:  thID,
 					ChannelId: chID,
 					DomainId:  session.DomainID,
 					Type:      uint32(connType),
+					ExpiresAt: expiresAt,
+					Subtopics: scope.Subtopics,
 				})
 			}
 		}
@@ -386,6 +416,114 @@ func (svc service) Disconnect(ctx context.Context, session authn.Session, chIDs,
 	return nil
 }
 
+func (svc service) ConnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) error {
+	if err := svc.validateGroup(ctx, session, groupID); err != nil {
+		return errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	for _, chID := range chIDs {
+		c, err := svc.repo.RetrieveByID(ctx, chID)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+		if c.Status != smqclients.EnabledStatus {
+			return errors.Wrap(svcerr.ErrCreateEntity, fmt.Errorf("channel id %s is not in enabled state", chID))
+		}
+		if c.Domain != session.DomainID {
+			return errors.Wrap(svcerr.ErrCreateEntity, fmt.Errorf("channel id %s has invalid domain id", chID))
+		}
+	}
+
+	for _, clID := range clIDs {
+		resp, err := svc.clients.RetrieveEntity(ctx, &grpcCommonV1.RetrieveEntityReq{Id: clID})
+		if err != nil {
+			return errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+		if resp.GetEntity().GetStatus() != uint32(smqclients.EnabledStatus) {
+			return errors.Wrap(svcerr.ErrCreateEntity, fmt.Errorf("client id %s is not in enabled state", clID))
+		}
+		if resp.GetEntity().GetDomainId() != session.DomainID {
+			return errors.Wrap(svcerr.ErrCreateEntity, fmt.Errorf("client id %s has invalid domain id", clID))
+		}
+	}
+
+	conns := groupConnections(session.DomainID, groupID, chIDs, clIDs, connTypes)
+	for _, conn := range conns {
+		err := svc.repo.CheckGroupConnection(ctx, conn)
+
+		switch {
+		case err == nil:
+			return errors.Wrap(svcerr.ErrConflict, fmt.Errorf("group %s is already connected for type %s in domain %s", conn.GroupID, conn.Type.String(), conn.DomainID))
+		case err != repoerr.ErrNotFound:
+			return errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+	}
+
+	if err := svc.repo.AddGroupConnections(ctx, conns); err != nil {
+		return errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (svc service) DisconnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) error {
+	for _, chID := range chIDs {
+		c, err := svc.repo.RetrieveByID(ctx, chID)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrRemoveEntity, err)
+		}
+		if c.Domain != session.DomainID {
+			return errors.Wrap(svcerr.ErrRemoveEntity, fmt.Errorf("channel id %s has invalid domain id", chID))
+		}
+	}
+
+	conns := groupConnections(session.DomainID, groupID, chIDs, clIDs, connTypes)
+	if err := svc.repo.RemoveGroupConnections(ctx, conns); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc service) validateGroup(ctx context.Context, session authn.Session, groupID string) error {
+	resp, err := svc.groups.RetrieveEntity(ctx, &grpcCommonV1.RetrieveEntityReq{Id: groupID})
+	if err != nil {
+		return err
+	}
+	if resp.GetEntity().GetDomainId() != session.DomainID {
+		return fmt.Errorf("group id %s has invalid domain id", groupID)
+	}
+	if resp.GetEntity().GetStatus() != uint32(smqclients.EnabledStatus) {
+		return fmt.Errorf("group id %s is not in enabled state", groupID)
+	}
+
+	return nil
+}
+
+func groupConnections(domainID, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) []GroupConnection {
+	conns := []GroupConnection{}
+	for _, connType := range connTypes {
+		for _, chID := range chIDs {
+			conns = append(conns, GroupConnection{
+				GroupID:   groupID,
+				ChannelID: chID,
+				DomainID:  domainID,
+				Type:      connType,
+			})
+		}
+		for _, clID := range clIDs {
+			conns = append(conns, GroupConnection{
+				GroupID:  groupID,
+				ClientID: clID,
+				DomainID: domainID,
+				Type:     connType,
+			})
+		}
+	}
+
+	return conns
+}
+
 func (svc service) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) (retErr error) {
 	ch, err := svc.repo.RetrieveByID(ctx, id)
 	if err != nil {
@@ -472,6 +610,138 @@ func (svc service) RemoveParentGroup(ctx context.Context, session authn.Session,
 	return nil
 }
 
+// MoveChannel moves the channel to the target domain. The channel is
+// disconnected from the clients and detached from the parent group of the
+// source domain.
+func (svc service) MoveChannel(ctx context.Context, session authn.Session, id, domainID string) (retCh Channel, retErr error) {
+	ch, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return Channel{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if ch.Domain == domainID {
+		return Channel{}, errors.Wrap(svcerr.ErrMalformedEntity, errSameDomain)
+	}
+	unlock, err := svc.lockQuota(ctx, domainID, 1)
+	if err != nil {
+		return Channel{}, err
+	}
+	defer unlock()
+
+	conns, err := svc.repo.RetrieveEntitiesConnections(ctx, []string{id}, nil)
+	if err != nil {
+		return Channel{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	oldPolicies := []policies.Policy{
+		{
+			Domain:      ch.Domain,
+			SubjectType: policies.DomainType,
+			Subject:     ch.Domain,
+			Relation:    policies.DomainRelation,
+			ObjectType:  policies.ChannelType,
+			Object:      id,
+		},
+	}
+	if ch.ParentGroup != "" {
+		oldPolicies = append(oldPolicies, policies.Policy{
+			Domain:      ch.Domain,
+			SubjectType: policies.GroupType,
+			Subject:     ch.ParentGroup,
+			Relation:    policies.ParentGroupRelation,
+			ObjectType:  policies.ChannelType,
+			Object:      id,
+		})
+	}
+	newPolicies := []policies.Policy{
+		{
+			Domain:      domainID,
+			SubjectType: policies.DomainType,
+			Subject:     domainID,
+			Relation:    policies.DomainRelation,
+			ObjectType:  policies.ChannelType,
+			Object:      id,
+		},
+	}
+
+	// The channel can be related to a single domain only, so the old
+	// relations are removed before the new ones are added.
+	if err := svc.policy.DeletePolicies(ctx, oldPolicies); err != nil {
+		return Channel{}, errors.Wrap(svcerr.ErrDeletePolicies, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.policy.AddPolicies(ctx, oldPolicies); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+	if err := svc.policy.AddPolicies(ctx, newPolicies); err != nil {
+		return Channel{}, errors.Wrap(svcerr.ErrAddPolicies, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.policy.DeletePolicies(ctx, newPolicies); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+	if err := svc.MoveEntitiesRoles(ctx, ch.Domain, domainID, []string{id}); err != nil {
+		return Channel{}, err
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.MoveEntitiesRoles(ctx, domainID, ch.Domain, []string{id}); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+
+	moved, err := svc.repo.ChangeDomain(ctx, Channel{ID: id, Domain: domainID, UpdatedBy: session.UserID, UpdatedAt: time.Now()})
+	if err != nil {
+		return Channel{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.restoreDomain(ctx, ch); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+
+	// Connections are removed last. The clients service is called after the
+	// local connections are removed, so they can be restored if it fails.
+	if len(conns) > 0 {
+		if err := svc.repo.RemoveChannelConnections(ctx, id); err != nil {
+			return Channel{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+		defer func() {
+			if retErr != nil {
+				if errRollback := svc.repo.AddConnections(ctx, conns); errRollback != nil {
+					retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+				}
+			}
+		}()
+		if _, err := svc.clients.RemoveChannelConnections(ctx, &grpcClientsV1.RemoveChannelConnectionsReq{ChannelId: id}); err != nil {
+			return Channel{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+
+	return moved, nil
+}
+
+// restoreDomain moves the channel back to the domain of the given channel and
+// restores its parent group.
+func (svc service) restoreDomain(ctx context.Context, ch Channel) error {
+	if _, err := svc.repo.ChangeDomain(ctx, Channel{ID: ch.ID, Domain: ch.Domain, UpdatedBy: ch.UpdatedBy, UpdatedAt: ch.UpdatedAt}); err != nil {
+		return err
+	}
+	if ch.ParentGroup == "" {
+		return nil
+	}
+
+	return svc.repo.SetParentGroup(ctx, Channel{ID: ch.ID, ParentGroup: ch.ParentGroup, UpdatedBy: ch.UpdatedBy, UpdatedAt: ch.UpdatedAt})
+}
+
 func (svc service) changeChannelStatus(ctx context.Context, userID string, channel Channel) (Channel, error) {
 	dbchannel, err := svc.repo.RetrieveByID(ctx, channel.ID)
 	if err != nil {
@@ -489,3 +759,31 @@ func (svc service) changeChannelStatus(ctx context.Context, userID string, chann
 	}
 	return channel, nil
 }
+
+// lockQuota locks the domain quotas and checks that the requested channels fit
+// into the domain channels quota. The returned unlock function is called once
+// the channels are saved, so the concurrent requests can't exceed the quota.
+func (svc service) lockQuota(ctx context.Context, domainID string, requested uint64) (func(), error) {
+	q, err := svc.quotas.RetrieveQuotas(ctx, domainID)
+	if err != nil {
+		return nil, err
+	}
+	if q.Channels == 0 {
+		return func() {}, nil
+	}
+	unlock, err := svc.quotas.LockQuotas(ctx, domainID)
+	if err != nil {
+		return nil, err
+	}
+	page, err := svc.repo.RetrieveAll(ctx, PageMetadata{Domain: domainID, Status: smqclients.AllStatus, Limit: 1})
+	if err != nil {
+		unlock()
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if err := domains.CheckQuota(q.Channels, page.Total, requested); err != nil {
+		unlock()
+		return nil, err
+	}
+
+	return unlock, nil
+}
@@ -18,11 +18,13 @@ import (
 	"github.com/absmach/supermq/clients"
 	smqclients "github.com/absmach/supermq/clients"
 	clmocks "github.com/absmach/supermq/clients/mocks"
+	"github.com/absmach/supermq/domains"
 	gpmocks "github.com/absmach/supermq/groups/mocks"
 	"github.com/absmach/supermq/internal/testsutil"
 	"github.com/absmach/supermq/pkg/authn"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/connections"
+	dmocks "github.com/absmach/supermq/pkg/domains/mocks"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
@@ -58,6 +60,7 @@ var (
 	policies   *policymocks.Service
 	clientsSvc *clmocks.ClientsServiceClient
 	groupsSvc  *gpmocks.GroupsServiceClient
+	dquotas    *dmocks.Quotas
 )
 
 func newService(t *testing.T) channels.Service {
@@ -65,11 +68,13 @@ func newService(t *testing.T) channels.Service {
 	policies = new(policymocks.Service)
 	clientsSvc = new(clmocks.ClientsServiceClient)
 	groupsSvc = new(gpmocks.GroupsServiceClient)
+	dquotas = new(dmocks.Quotas)
+	dquotas.On("RetrieveQuotas", mock.Anything, mock.Anything).Return(domains.Quotas{}, nil).Maybe()
 	availableActions := []roles.Action{}
 	builtInRoles := map[roles.BuiltInRoleName][]roles.Action{
 		clients.BuiltInRoleAdmin: availableActions,
 	}
-	svc, err := channels.New(repo, policies, idProvider, clientsSvc, groupsSvc, idProvider, availableActions, builtInRoles)
+	svc, err := channels.New(repo, policies, idProvider, clientsSvc, groupsSvc, dquotas, idProvider, availableActions, builtInRoles)
 	assert.Nil(t, err, fmt.Sprintf(" Unexpected error  while creating service %v", err))
 	return svc
 }
@@ -208,20 +213,139 @@ func TestCreateChannel(t *testing.T) {
 	}
 }
 
+func TestCreateChannelQuota(t *testing.T) {
+	svc := newService(t)
+	dquotas.ExpectedCalls = nil
+
+	cases := []struct {
+		desc           string
+		channels       []channels.Channel
+		quotas         domains.Quotas
+		quotasErr      error
+		lockErr        error
+		total          uint64
+		retrieveAllErr error
+		err            error
+	}{
+		{
+			desc:     "create channels within quota",
+			channels: []channels.Channel{validChannel},
+			quotas:   domains.Quotas{Channels: 2},
+			total:    1,
+			err:      nil,
+		},
+		{
+			desc:     "create channels exceeding quota",
+			channels: []channels.Channel{validChannel, validChannel},
+			quotas:   domains.Quotas{Channels: 2},
+			total:    1,
+			err:      svcerr.ErrQuotaExceeded,
+		},
+		{
+			desc:     "create channels with quota already reached",
+			channels: []channels.Channel{validChannel},
+			quotas:   domains.Quotas{Channels: 1},
+			total:    1,
+			err:      svcerr.ErrQuotaExceeded,
+		},
+		{
+			desc:      "create channels with failed to retrieve quotas",
+			channels:  []channels.Channel{validChannel},
+			quotasErr: svcerr.ErrViewEntity,
+			err:       svcerr.ErrViewEntity,
+		},
+		{
+			desc:           "create channels with failed to count channels",
+			channels:       []channels.Channel{validChannel},
+			quotas:         domains.Quotas{Channels: 2},
+			retrieveAllErr: repoerr.ErrViewEntity,
+			err:            svcerr.ErrViewEntity,
+		},
+		{
+			desc:     "create channels with failed to lock quotas",
+			channels: []channels.Channel{validChannel},
+			quotas:   domains.Quotas{Channels: 2},
+			lockErr:  svcerr.ErrViewEntity,
+			err:      svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			unlocked := false
+			quotasCall := dquotas.On("RetrieveQuotas", context.Background(), validSession.DomainID).Return(tc.quotas, tc.quotasErr)
+			lockCall := dquotas.On("LockQuotas", context.Background(), validSession.DomainID).Return(func() { unlocked = true }, tc.lockErr)
+			repoCall := repo.On("RetrieveAll", context.Background(), mock.Anything).Return(channels.Page{PageMetadata: channels.PageMetadata{Total: tc.total}}, tc.retrieveAllErr)
+			repoCall1 := repo.On("Save", context.Background(), mock.Anything).Return(tc.channels, nil)
+			policyCall := policies.On("AddPolicies", context.Background(), mock.Anything).Return(nil)
+			repoCall2 := repo.On("AddRoles", context.Background(), mock.Anything).Return([]roles.RoleProvision{}, nil)
+			_, _, err := svc.CreateChannels(context.Background(), validSession, tc.channels...)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v but got %v", tc.err, err))
+			if tc.quotas.Channels > 0 && tc.lockErr == nil {
+				assert.True(t, unlocked, "expected domain quotas to be unlocked")
+			}
+			quotasCall.Unset()
+			lockCall.Unset()
+			repoCall.Unset()
+			repoCall1.Unset()
+			policyCall.Unset()
+			repoCall2.Unset()
+		})
+	}
+}
+
 func TestViewChannel(t *testing.T) {
 	svc := newService(t)
 
+	parentPath := testsutil.GenerateUUID(t) + "." + testsutil.GenerateUUID(t)
+	nestedChannel := validChannel
+	nestedChannel.ParentGroupPath = parentPath
+	nestedChannel.Metadata = clients.Metadata{"qos": 1, "retention": nil}
+	effectiveChannel := nestedChannel
+	effectiveChannel.Metadata = clients.Metadata{"qos": 1, "timezone": "UTC"}
+	pathMetadata := []clients.Metadata{
+		{"timezone": "UTC", "retention": "7d", "qos": 0},
+		{},
+	}
+
 	cases := []struct {
-		desc     string
-		id       string
-		repoResp channels.Channel
-		repoErr  error
-		err      error
+		desc            string
+		id              string
+		effective       bool
+		repoResp        channels.Channel
+		repoErr         error
+		pathMetadata    []clients.Metadata
+		pathMetadataErr error
+		resp            channels.Channel
+		err             error
 	}{
 		{
 			desc:     "view channel successfully",
 			id:       validChannel.ID,
 			repoResp: validChannel,
+			resp:     validChannel,
+		},
+		{
+			desc:     "view channel with raw metadata successfully",
+			id:       nestedChannel.ID,
+			repoResp: nestedChannel,
+			resp:     nestedChannel,
+		},
+		{
+			desc:         "view channel with effective metadata successfully",
+			id:           nestedChannel.ID,
+			effective:    true,
+			repoResp:     nestedChannel,
+			pathMetadata: pathMetadata,
+			resp:         effectiveChannel,
+		},
+		{
+			desc:            "view channel with effective metadata with failed to retrieve path metadata",
+			id:              nestedChannel.ID,
+			effective:       true,
+			repoResp:        nestedChannel,
+			pathMetadataErr: repoerr.ErrViewEntity,
+			err:             svcerr.ErrViewEntity,
 		},
 		{
 			desc:    "view channel with failed to retrieve",
@@ -234,14 +358,16 @@ func TestViewChannel(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			repoCall := repo.On("RetrieveByID", context.Background(), tc.id).Return(tc.repoResp, tc.repoErr)
-			got, err := svc.ViewChannel(context.Background(), validSession, tc.id)
+			repoCall1 := repo.On("RetrievePathMetadata", context.Background(), parentPath).Return(tc.pathMetadata, tc.pathMetadataErr)
+			got, err := svc.ViewChannel(context.Background(), validSession, tc.id, tc.effective)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
 			if err == nil {
-				assert.Equal(t, tc.repoResp, got)
+				assert.Equal(t, tc.resp, got)
 				ok := repo.AssertCalled(t, "RetrieveByID", context.Background(), tc.id)
 				assert.True(t, ok, fmt.Sprintf("RetrieveByID was not called on %s", tc.desc))
 			}
 			repoCall.Unset()
+			repoCall1.Unset()
 		})
 	}
 }
@@ -759,11 +885,17 @@ func TestConnect(t *testing.T) {
 	disabledChannel := validChannel
 	disabledChannel.Status = clients.DisabledStatus
 
+	scope := connections.Scope{
+		ExpiresAt: time.Now().Add(time.Hour),
+		Subtopics: []string{"sensors.temp.*"},
+	}
+
 	cases := []struct {
 		desc                     string
 		channelIDs               []string
 		thingIDs                 []string
 		connTypes                []connections.ConnType
+		scope                    connections.Scope
 		repoConn                 channels.Connection
 		clientsConn              []*grpcCommonV1.Connection
 		retrieveByIDRes          channels.Channel
@@ -805,6 +937,40 @@ func TestConnect(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc:            "connect successfully with scope",
+			channelIDs:      []string{validChannel.ID},
+			thingIDs:        []string{validID},
+			connTypes:       []connections.ConnType{connections.Publish},
+			scope:           scope,
+			retrieveByIDRes: validDomainChannel,
+			retrieveEntityRes: &grpcCommonV1.RetrieveEntityRes{
+				Entity: &grpcCommonV1.EntityBasic{
+					Id:       validID,
+					DomainId: validID,
+					Status:   uint32(clients.EnabledStatus),
+				},
+			},
+			checkConnErr: repoerr.ErrNotFound,
+			repoConn: channels.Connection{
+				ClientID:  validID,
+				ChannelID: validChannel.ID,
+				DomainID:  validID,
+				Type:      connections.Publish,
+				Scope:     scope,
+			},
+			clientsConn: []*grpcCommonV1.Connection{
+				{
+					ClientId:  validID,
+					ChannelId: validChannel.ID,
+					DomainId:  validID,
+					Type:      uint32(connections.Publish),
+					ExpiresAt: scope.ExpiresAt.Unix(),
+					Subtopics: scope.Subtopics,
+				},
+			},
+			err: nil,
+		},
 		{
 			desc:            "connect with failed to retrieve channel",
 			channelIDs:      []string{validChannel.ID},
@@ -979,7 +1145,7 @@ func TestConnect(t *testing.T) {
 			repoCall1 := repo.On("CheckConnection", context.Background(), tc.repoConn).Return(tc.checkConnErr)
 			clientsCall1 := clientsSvc.On("AddConnections", context.Background(), &grpcCommonV1.AddConnectionsReq{Connections: tc.clientsConn}).Return(&grpcCommonV1.AddConnectionsRes{}, tc.addClientConnectionsErr)
 			repoCall2 := repo.On("AddConnections", context.Background(), []channels.Connection{tc.repoConn}).Return(tc.addChannelConnectionsErr)
-			err := svc.Connect(context.Background(), validSession, tc.channelIDs, tc.thingIDs, tc.connTypes)
+			err := svc.Connect(context.Background(), validSession, tc.channelIDs, tc.thingIDs, tc.connTypes, tc.scope)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", tc.err, err))
 			repoCall.Unset()
 			clientsCall.Unset()
@@ -1156,6 +1322,288 @@ func TestDisconnect(t *testing.T) {
 	}
 }
 
+func TestConnectGroup(t *testing.T) {
+	svc := newService(t)
+
+	validDomainChannel := validChannel
+	validDomainChannel.Domain = validID
+
+	disabledChannel := validDomainChannel
+	disabledChannel.Status = clients.DisabledStatus
+
+	groupID := testsutil.GenerateUUID(t)
+	clientID := testsutil.GenerateUUID(t)
+	validGroupRes := &grpcCommonV1.RetrieveEntityRes{
+		Entity: &grpcCommonV1.EntityBasic{
+			Id:       groupID,
+			DomainId: validID,
+			Status:   uint32(clients.EnabledStatus),
+		},
+	}
+	validClientRes := &grpcCommonV1.RetrieveEntityRes{
+		Entity: &grpcCommonV1.EntityBasic{
+			Id:       clientID,
+			DomainId: validID,
+			Status:   uint32(clients.EnabledStatus),
+		},
+	}
+	channelConn := channels.GroupConnection{
+		GroupID:   groupID,
+		ChannelID: validChannel.ID,
+		DomainID:  validID,
+		Type:      connections.Publish,
+	}
+	clientConn := channels.GroupConnection{
+		GroupID:  groupID,
+		ClientID: clientID,
+		DomainID: validID,
+		Type:     connections.Publish,
+	}
+
+	cases := []struct {
+		desc              string
+		channelIDs        []string
+		clientIDs         []string
+		connTypes         []connections.ConnType
+		retrieveGroupRes  *grpcCommonV1.RetrieveEntityRes
+		retrieveGroupErr  error
+		retrieveByIDRes   channels.Channel
+		retrieveByIDErr   error
+		retrieveClientRes *grpcCommonV1.RetrieveEntityRes
+		retrieveClientErr error
+		conns             []channels.GroupConnection
+		checkConnErr      error
+		addConnsErr       error
+		err               error
+	}{
+		{
+			desc:              "connect channel and client to group successfully",
+			channelIDs:        []string{validChannel.ID},
+			clientIDs:         []string{clientID},
+			connTypes:         []connections.ConnType{connections.Publish},
+			retrieveGroupRes:  validGroupRes,
+			retrieveByIDRes:   validDomainChannel,
+			retrieveClientRes: validClientRes,
+			conns:             []channels.GroupConnection{channelConn, clientConn},
+			checkConnErr:      repoerr.ErrNotFound,
+			err:               nil,
+		},
+		{
+			desc:             "connect channel to group successfully",
+			channelIDs:       []string{validChannel.ID},
+			connTypes:        []connections.ConnType{connections.Publish},
+			retrieveGroupRes: validGroupRes,
+			retrieveByIDRes:  validDomainChannel,
+			conns:            []channels.GroupConnection{channelConn},
+			checkConnErr:     repoerr.ErrNotFound,
+			err:              nil,
+		},
+		{
+			desc:             "connect with failed to retrieve group",
+			channelIDs:       []string{validChannel.ID},
+			connTypes:        []connections.ConnType{connections.Publish},
+			retrieveGroupRes: &grpcCommonV1.RetrieveEntityRes{},
+			retrieveGroupErr: svcerr.ErrNotFound,
+			err:              svcerr.ErrCreateEntity,
+		},
+		{
+			desc:       "connect with group from different domain",
+			channelIDs: []string{validChannel.ID},
+			connTypes:  []connections.ConnType{connections.Publish},
+			retrieveGroupRes: &grpcCommonV1.RetrieveEntityRes{
+				Entity: &grpcCommonV1.EntityBasic{
+					Id:       groupID,
+					DomainId: testsutil.GenerateUUID(t),
+					Status:   uint32(clients.EnabledStatus),
+				},
+			},
+			err: svcerr.ErrCreateEntity,
+		},
+		{
+			desc:       "connect with disabled group",
+			channelIDs: []string{validChannel.ID},
+			connTypes:  []connections.ConnType{connections.Publish},
+			retrieveGroupRes: &grpcCommonV1.RetrieveEntityRes{
+				Entity: &grpcCommonV1.EntityBasic{
+					Id:       groupID,
+					DomainId: validID,
+					Status:   uint32(clients.DisabledStatus),
+				},
+			},
+			err: svcerr.ErrCreateEntity,
+		},
+		{
+			desc:             "connect with failed to retrieve channel",
+			channelIDs:       []string{validChannel.ID},
+			connTypes:        []connections.ConnType{connections.Publish},
+			retrieveGroupRes: validGroupRes,
+			retrieveByIDErr:  repoerr.ErrNotFound,
+			err:              svcerr.ErrCreateEntity,
+		},
+		{
+			desc:             "connect with disabled channel",
+			channelIDs:       []string{validChannel.ID},
+			connTypes:        []connections.ConnType{connections.Publish},
+			retrieveGroupRes: validGroupRes,
+			retrieveByIDRes:  disabledChannel,
+			err:              svcerr.ErrCreateEntity,
+		},
+		{
+			desc:             "connect with channel from different domain",
+			channelIDs:       []string{validChannel.ID},
+			connTypes:        []connections.ConnType{connections.Publish},
+			retrieveGroupRes: validGroupRes,
+			retrieveByIDRes:  validChannel,
+			err:              svcerr.ErrCreateEntity,
+		},
+		{
+			desc:              "connect with failed to retrieve client",
+			clientIDs:         []string{clientID},
+			connTypes:         []connections.ConnType{connections.Publish},
+			retrieveGroupRes:  validGroupRes,
+			retrieveClientRes: &grpcCommonV1.RetrieveEntityRes{},
+			retrieveClientErr: svcerr.ErrNotFound,
+			err:               svcerr.ErrCreateEntity,
+		},
+		{
+			desc:             "connect with disabled client",
+			clientIDs:        []string{clientID},
+			connTypes:        []connections.ConnType{connections.Publish},
+			retrieveGroupRes: validGroupRes,
+			retrieveClientRes: &grpcCommonV1.RetrieveEntityRes{
+				Entity: &grpcCommonV1.EntityBasic{
+					Id:       clientID,
+					DomainId: validID,
+					Status:   uint32(clients.DisabledStatus),
+				},
+			},
+			err: svcerr.ErrCreateEntity,
+		},
+		{
+			desc:              "connect with existing connection",
+			clientIDs:         []string{clientID},
+			connTypes:         []connections.ConnType{connections.Publish},
+			retrieveGroupRes:  validGroupRes,
+			retrieveClientRes: validClientRes,
+			conns:             []channels.GroupConnection{clientConn},
+			checkConnErr:      nil,
+			err:               svcerr.ErrConflict,
+		},
+		{
+			desc:              "connect with failed to check connection",
+			clientIDs:         []string{clientID},
+			connTypes:         []connections.ConnType{connections.Publish},
+			retrieveGroupRes:  validGroupRes,
+			retrieveClientRes: validClientRes,
+			conns:             []channels.GroupConnection{clientConn},
+			checkConnErr:      repoerr.ErrViewEntity,
+			err:               svcerr.ErrCreateEntity,
+		},
+		{
+			desc:              "connect with failed to add connections",
+			clientIDs:         []string{clientID},
+			connTypes:         []connections.ConnType{connections.Publish},
+			retrieveGroupRes:  validGroupRes,
+			retrieveClientRes: validClientRes,
+			conns:             []channels.GroupConnection{clientConn},
+			checkConnErr:      repoerr.ErrNotFound,
+			addConnsErr:       repoerr.ErrCreateEntity,
+			err:               svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			groupsCall := groupsSvc.On("RetrieveEntity", context.Background(), &grpcCommonV1.RetrieveEntityReq{Id: groupID}).Return(tc.retrieveGroupRes, tc.retrieveGroupErr)
+			repoCall := repo.On("RetrieveByID", context.Background(), validChannel.ID).Return(tc.retrieveByIDRes, tc.retrieveByIDErr)
+			clientsCall := clientsSvc.On("RetrieveEntity", context.Background(), &grpcCommonV1.RetrieveEntityReq{Id: clientID}).Return(tc.retrieveClientRes, tc.retrieveClientErr)
+			repoCall1 := repo.On("CheckGroupConnection", context.Background(), mock.Anything).Return(tc.checkConnErr)
+			repoCall2 := repo.On("AddGroupConnections", context.Background(), tc.conns).Return(tc.addConnsErr)
+			err := svc.ConnectGroup(context.Background(), validSession, groupID, tc.channelIDs, tc.clientIDs, tc.connTypes)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			if tc.err == nil {
+				ok := repo.AssertCalled(t, "AddGroupConnections", context.Background(), tc.conns)
+				assert.True(t, ok, fmt.Sprintf("AddGroupConnections was not called on %s", tc.desc))
+			}
+			groupsCall.Unset()
+			repoCall.Unset()
+			clientsCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+		})
+	}
+}
+
+func TestDisconnectGroup(t *testing.T) {
+	svc := newService(t)
+
+	validDomainChannel := validChannel
+	validDomainChannel.Domain = validID
+
+	groupID := testsutil.GenerateUUID(t)
+	clientID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc            string
+		channelIDs      []string
+		clientIDs       []string
+		connTypes       []connections.ConnType
+		retrieveByIDRes channels.Channel
+		retrieveByIDErr error
+		conns           []channels.GroupConnection
+		removeConnsErr  error
+		err             error
+	}{
+		{
+			desc:            "disconnect channel and client from group successfully",
+			channelIDs:      []string{validChannel.ID},
+			clientIDs:       []string{clientID},
+			connTypes:       []connections.ConnType{connections.Subscribe},
+			retrieveByIDRes: validDomainChannel,
+			conns: []channels.GroupConnection{
+				{GroupID: groupID, ChannelID: validChannel.ID, DomainID: validID, Type: connections.Subscribe},
+				{GroupID: groupID, ClientID: clientID, DomainID: validID, Type: connections.Subscribe},
+			},
+			err: nil,
+		},
+		{
+			desc:            "disconnect with failed to retrieve channel",
+			channelIDs:      []string{validChannel.ID},
+			connTypes:       []connections.ConnType{connections.Subscribe},
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:            "disconnect with channel from different domain",
+			channelIDs:      []string{validChannel.ID},
+			connTypes:       []connections.ConnType{connections.Subscribe},
+			retrieveByIDRes: validChannel,
+			err:             svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:      "disconnect with failed to remove connections",
+			clientIDs: []string{clientID},
+			connTypes: []connections.ConnType{connections.Subscribe},
+			conns: []channels.GroupConnection{
+				{GroupID: groupID, ClientID: clientID, DomainID: validID, Type: connections.Subscribe},
+			},
+			removeConnsErr: repoerr.ErrRemoveEntity,
+			err:            svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByID", context.Background(), validChannel.ID).Return(tc.retrieveByIDRes, tc.retrieveByIDErr)
+			repoCall1 := repo.On("RemoveGroupConnections", context.Background(), tc.conns).Return(tc.removeConnsErr)
+			err := svc.DisconnectGroup(context.Background(), validSession, groupID, tc.channelIDs, tc.clientIDs, tc.connTypes)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
 func TestSetParentGroup(t *testing.T) {
 	svc := newService(t)
 
@@ -1410,3 +1858,129 @@ func TestRemoveParentGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestMoveChannel(t *testing.T) {
+	svc := newService(t)
+
+	domainID := testsutil.GenerateUUID(t)
+	sourceChannel := validChannel
+	sourceChannel.ParentGroup = parentGroupID
+	movedChannel := validChannel
+	movedChannel.Domain = domainID
+	memberRoles := []roles.EntityMemberRole{{EntityID: validChannel.ID, MemberID: validID, RoleID: testsutil.GenerateUUID(t)}}
+	conns := []channels.Connection{{ChannelID: validChannel.ID, ClientID: testsutil.GenerateUUID(t), DomainID: validChannel.Domain, Type: connections.Publish}}
+
+	cases := []struct {
+		desc                 string
+		id                   string
+		domainID             string
+		retrieveByIDResp     channels.Channel
+		retrieveByIDErr      error
+		conns                []channels.Connection
+		removeConnectionsErr error
+		addPoliciesErr       error
+		deletePoliciesErr    error
+		changeDomainResp     channels.Channel
+		changeDomainErr      error
+		connectionsRemoved   bool
+		connectionsRestored  bool
+		resp                 channels.Channel
+		err                  error
+	}{
+		{
+			desc:               "move channel successfully",
+			id:                 validChannel.ID,
+			domainID:           domainID,
+			retrieveByIDResp:   sourceChannel,
+			conns:              conns,
+			changeDomainResp:   movedChannel,
+			connectionsRemoved: true,
+			resp:               movedChannel,
+			err:                nil,
+		},
+		{
+			desc:             "move channel to the same domain",
+			id:               validChannel.ID,
+			domainID:         validChannel.Domain,
+			retrieveByIDResp: sourceChannel,
+			err:              svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:            "move non-existing channel",
+			id:              testsutil.GenerateUUID(t),
+			domainID:        domainID,
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrViewEntity,
+		},
+		{
+			desc:                 "move channel with failed to remove connections",
+			id:                   validChannel.ID,
+			domainID:             domainID,
+			retrieveByIDResp:     sourceChannel,
+			conns:                conns,
+			changeDomainResp:     movedChannel,
+			removeConnectionsErr: svcerr.ErrRemoveEntity,
+			connectionsRemoved:   true,
+			connectionsRestored:  true,
+			err:                  svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:             "move channel with failed to add policies",
+			id:               validChannel.ID,
+			domainID:         domainID,
+			retrieveByIDResp: sourceChannel,
+			addPoliciesErr:   svcerr.ErrAuthorization,
+			err:              svcerr.ErrAddPolicies,
+		},
+		{
+			desc:              "move channel with failed to delete policies",
+			id:                validChannel.ID,
+			domainID:          domainID,
+			retrieveByIDResp:  sourceChannel,
+			deletePoliciesErr: svcerr.ErrAuthorization,
+			err:               svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:             "move channel with failed to change domain",
+			id:               validChannel.ID,
+			domainID:         domainID,
+			retrieveByIDResp: sourceChannel,
+			conns:            conns,
+			changeDomainErr:  repoerr.ErrNotFound,
+			err:              svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByID", context.Background(), tc.id).Return(tc.retrieveByIDResp, tc.retrieveByIDErr)
+			repoCall1 := repo.On("RetrieveAll", context.Background(), mock.Anything).Return(channels.Page{}, nil)
+			repoCall2 := repo.On("RetrieveEntitiesConnections", context.Background(), []string{tc.id}, []string(nil)).Return(tc.conns, nil)
+			clientsCall := clientsSvc.On("RemoveChannelConnections", context.Background(), &grpcClientsV1.RemoveChannelConnectionsReq{ChannelId: tc.id}).Return(&grpcClientsV1.RemoveChannelConnectionsRes{}, tc.removeConnectionsErr)
+			removed, restored := false, false
+			repoCall3 := repo.On("RemoveChannelConnections", context.Background(), tc.id).Return(nil).Run(func(mock.Arguments) { removed = true })
+			repoCall6 := repo.On("AddConnections", context.Background(), tc.conns).Return(nil).Run(func(mock.Arguments) { restored = true })
+			repoCall7 := repo.On("SetParentGroup", context.Background(), mock.Anything).Return(nil)
+			policyCall := policies.On("AddPolicies", context.Background(), mock.Anything).Return(tc.addPoliciesErr)
+			policyCall1 := policies.On("DeletePolicies", context.Background(), mock.Anything).Return(tc.deletePoliciesErr)
+			repoCall4 := repo.On("RetrieveEntitiesRolesActionsMembers", context.Background(), []string{tc.id}).Return([]roles.EntityActionRole{}, memberRoles, nil)
+			repoCall5 := repo.On("ChangeDomain", context.Background(), mock.Anything).Return(tc.changeDomainResp, tc.changeDomainErr)
+			ch, err := svc.MoveChannel(context.Background(), validSession, tc.id, tc.domainID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, ch, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, ch))
+			assert.Equal(t, tc.connectionsRemoved, removed, fmt.Sprintf("%s: expected connections removed %t got %t\n", tc.desc, tc.connectionsRemoved, removed))
+			assert.Equal(t, tc.connectionsRestored, restored, fmt.Sprintf("%s: expected connections restored %t got %t\n", tc.desc, tc.connectionsRestored, restored))
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			clientsCall.Unset()
+			repoCall3.Unset()
+			policyCall.Unset()
+			policyCall1.Unset()
+			repoCall4.Unset()
+			repoCall5.Unset()
+			repoCall6.Unset()
+			repoCall7.Unset()
+		})
+	}
+}
@@ -37,10 +37,13 @@ func (tm *tracingMiddleware) CreateChannels(ctx context.Context, session authn.S
 }
 
 // ViewChannel traces the "ViewChannel" operation of the wrapped policies.Service.
-func (tm *tracingMiddleware) ViewChannel(ctx context.Context, session authn.Session, id string) (channels.Channel, error) {
-	ctx, span := tm.tracer.Start(ctx, "svc_view_channel", trace.WithAttributes(attribute.String("id", id)))
+func (tm *tracingMiddleware) ViewChannel(ctx context.Context, session authn.Session, id string, effective bool) (channels.Channel, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_channel", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.Bool("effective", effective),
+	))
 	defer span.End()
-	return tm.svc.ViewChannel(ctx, session, id)
+	return tm.svc.ViewChannel(ctx, session, id, effective)
 }
 
 // ListChannels traces the "ListChannels" operation of the wrapped policies.Service.
@@ -98,13 +101,14 @@ func (tm *tracingMiddleware) RemoveChannel(ctx context.Context, session authn.Se
 	return tm.svc.RemoveChannel(ctx, session, id)
 }
 
-func (tm *tracingMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
+func (tm *tracingMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType, scope connections.Scope) error {
 	ctx, span := tm.tracer.Start(ctx, "connect", trace.WithAttributes(
 		attribute.StringSlice("channel_ids", chIDs),
 		attribute.StringSlice("client_ids", thIDs),
+		attribute.StringSlice("subtopics", scope.Subtopics),
 	))
 	defer span.End()
-	return tm.svc.Connect(ctx, session, chIDs, thIDs, connTypes)
+	return tm.svc.Connect(ctx, session, chIDs, thIDs, connTypes, scope)
 }
 
 func (tm *tracingMiddleware) Disconnect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
@@ -116,6 +120,26 @@ func (tm *tracingMiddleware) Disconnect(ctx context.Context, session authn.Sessi
 	return tm.svc.Disconnect(ctx, session, chIDs, thIDs, connTypes)
 }
 
+func (tm *tracingMiddleware) ConnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) error {
+	ctx, span := tm.tracer.Start(ctx, "connect_group", trace.WithAttributes(
+		attribute.String("group_id", groupID),
+		attribute.StringSlice("channel_ids", chIDs),
+		attribute.StringSlice("client_ids", clIDs),
+	))
+	defer span.End()
+	return tm.svc.ConnectGroup(ctx, session, groupID, chIDs, clIDs, connTypes)
+}
+
+func (tm *tracingMiddleware) DisconnectGroup(ctx context.Context, session authn.Session, groupID string, chIDs, clIDs []string, connTypes []connections.ConnType) error {
+	ctx, span := tm.tracer.Start(ctx, "disconnect_group", trace.WithAttributes(
+		attribute.String("group_id", groupID),
+		attribute.StringSlice("channel_ids", chIDs),
+		attribute.StringSlice("client_ids", clIDs),
+	))
+	defer span.End()
+	return tm.svc.DisconnectGroup(ctx, session, groupID, chIDs, clIDs, connTypes)
+}
+
 func (tm *tracingMiddleware) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) error {
 	ctx, span := tm.tracer.Start(ctx, "set_parent_group", trace.WithAttributes(
 		attribute.String("parent_group_id", parentGroupID),
@@ -132,3 +156,12 @@ func (tm *tracingMiddleware) RemoveParentGroup(ctx context.Context, session auth
 	defer span.End()
 	return tm.svc.RemoveParentGroup(ctx, session, id)
 }
+
+func (tm *tracingMiddleware) MoveChannel(ctx context.Context, session authn.Session, id, domainID string) (channels.Channel, error) {
+	ctx, span := tm.tracer.Start(ctx, "move_channel", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.String("domain_id", domainID),
+	))
+	defer span.End()
+	return tm.svc.MoveChannel(ctx, session, id, domainID)
+}
@@ -54,3 +54,9 @@ const (
 	acceptCmd = "accept"
 	rejectCmd = "reject"
 )
+
+// Domains commands
+const (
+	exportCmd = "export"
+	importCmd = "import"
+)
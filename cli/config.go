@@ -28,6 +28,8 @@ const (
 	defInvitationsURL  string = defURL + ":9020"
 	defHTTPURL         string = defURL + ":8008"
 	defJournalURL      string = defURL + ":9021"
+	defRulesURL        string = defURL + ":9023"
+	defTwinsURL        string = defURL + ":9018"
 	defTLSVerification bool   = false
 	defOffset          string = "0"
 	defLimit           string = "10"
@@ -45,6 +47,8 @@ type remotes struct {
 	CertsURL        string `toml:"certs_url"`
 	InvitationsURL  string `toml:"invitations_url"`
 	JournalURL      string `toml:"journal_url"`
+	RulesURL        string `toml:"rules_url"`
+	TwinsURL        string `toml:"twins_url"`
 	HostURL         string `toml:"host_url"`
 	TLSVerification bool   `toml:"tls_verification"`
 }
@@ -116,6 +120,8 @@ func ParseConfig(sdkConf smqsdk.Config) (smqsdk.Config, error) {
 				CertsURL:        defCertsURL,
 				InvitationsURL:  defInvitationsURL,
 				JournalURL:      defJournalURL,
+				RulesURL:        defRulesURL,
+				TwinsURL:        defTwinsURL,
 				HostURL:         defURL,
 				TLSVerification: defTLSVerification,
 			},
@@ -207,6 +213,14 @@ func ParseConfig(sdkConf smqsdk.Config) (smqsdk.Config, error) {
 		sdkConf.JournalURL = config.Remotes.JournalURL
 	}
 
+	if sdkConf.RulesURL == "" && config.Remotes.RulesURL != "" {
+		sdkConf.RulesURL = config.Remotes.RulesURL
+	}
+
+	if sdkConf.TwinsURL == "" && config.Remotes.TwinsURL != "" {
+		sdkConf.TwinsURL = config.Remotes.TwinsURL
+	}
+
 	if sdkConf.HostURL == "" && config.Remotes.HostURL != "" {
 		sdkConf.HostURL = config.Remotes.HostURL
 	}
@@ -5,6 +5,9 @@ package cli
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 
 	smqsdk "github.com/absmach/supermq/pkg/sdk"
 	"github.com/spf13/cobra"
@@ -549,12 +552,86 @@ func NewDomainsCmd() *cobra.Command {
 	for i := range cmdDomainRoles {
 		rolesCmd.AddCommand(&cmdDomainRoles[i])
 	}
+	exportCmd := cobra.Command{
+		Use:   "export <domain_id> <file> <user_auth_token>",
+		Short: "Export domain",
+		Long: "Export domain groups, clients, channels, connections and roles to a JSON or YAML bundle file.\n" +
+			"The bundle format is chosen by the file extension, client secrets are not exported.\n" +
+			"For example:\n" +
+			"\tsupermq-cli domains export 39f97daf-d6b6-40f4-b229-2697be8006ef staging.yaml $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			bundle, err := sdk.ExportDomain(args[0], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			data, eerr := smqsdk.EncodeBundle(bundle, bundleFormat(args[1]))
+			if eerr != nil {
+				logErrorCmd(*cmd, eerr)
+				return
+			}
+			if err := os.WriteFile(args[1], data, 0o600); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			logOKCmd(*cmd)
+		},
+	}
+
+	var dryRun bool
+	var conflict string
+	importCmd := cobra.Command{
+		Use:   "import <file> <domain_id> <user_auth_token> [--dry-run] [--conflict=fail|skip|overwrite|duplicate]",
+		Short: "Import domain",
+		Long: "Import domain bundle file to the domain, the bundle entity IDs are remapped to the new entity IDs.\n" +
+			"The entities with the same name as the bundle entities are handled by the conflict strategy.\n" +
+			"If the import fails, the created entities are removed.\n" +
+			"For example:\n" +
+			"\tsupermq-cli domains import staging.yaml 4ef09eff-d500-4d56-b04f-d23a512d6f2a $USER_AUTH_TOKEN --dry-run --conflict=skip\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			bundle, err := smqsdk.DecodeBundle(data, bundleFormat(args[0]))
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			opts := smqsdk.ImportOptions{
+				DryRun:   dryRun,
+				Conflict: smqsdk.ConflictStrategy(conflict),
+			}
+			res, serr := sdk.ImportDomain(bundle, args[1], opts, args[2])
+			if serr != nil {
+				logErrorCmd(*cmd, serr)
+				return
+			}
+			logJSONCmd(*cmd, res)
+		},
+	}
+	importCmd.Flags().BoolVar(&dryRun, "dry-run", false, "show the import changes without applying them")
+	importCmd.Flags().StringVar(&conflict, "conflict", string(smqsdk.ConflictFail), "strategy for the existing entities: fail, skip, overwrite or duplicate")
+
 	cmd := cobra.Command{
-		Use:   "domains [create | get | update | enable | disable | enable | users | assign | unassign]",
+		Use:   "domains [create | get | update | enable | disable | enable | users | assign | unassign | export | import]",
 		Short: "Domains management",
 		Long:  `Domains management: create, update, retrieve domains , assign/unassign users to domains and list users of domain"`,
 	}
 	cmd.AddCommand(&rolesCmd)
+	cmd.AddCommand(&exportCmd)
+	cmd.AddCommand(&importCmd)
 
 	for i := range cmdDomains {
 		cmd.AddCommand(&cmdDomains[i])
@@ -562,3 +639,14 @@ func NewDomainsCmd() *cobra.Command {
 
 	return &cmd
 }
+
+// bundleFormat returns the domain bundle format of the file, JSON unless
+// the file has a YAML extension.
+func bundleFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return smqsdk.BundleYAML
+	default:
+		return smqsdk.BundleJSON
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -1310,3 +1312,167 @@ func TestDeleteDomainRoleMembersCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestExportDomainCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	domainCmd := cli.NewDomainsCmd()
+	rootCmd := setFlags(domainCmd)
+
+	dir := t.TempDir()
+	bundle := smqsdk.DomainBundle{
+		Version: smqsdk.BundleVersion,
+		Domain:  smqsdk.BundleDomain{ID: domain.ID, Name: domain.Name, Alias: domain.Alias},
+		Groups:  []smqsdk.BundleGroup{{ID: testsutil.GenerateUUID(t), Name: "group"}},
+	}
+
+	cases := []struct {
+		desc          string
+		args          []string
+		bundle        smqsdk.DomainBundle
+		sdkErr        errors.SDKError
+		errLogMessage string
+		logType       outputLog
+	}{
+		{
+			desc:    "export domain to JSON successfully",
+			args:    []string{domain.ID, filepath.Join(dir, "bundle.json"), validToken},
+			bundle:  bundle,
+			logType: okLog,
+		},
+		{
+			desc:    "export domain to YAML successfully",
+			args:    []string{domain.ID, filepath.Join(dir, "bundle.yaml"), validToken},
+			bundle:  bundle,
+			logType: okLog,
+		},
+		{
+			desc:    "export domain with invalid args",
+			args:    []string{domain.ID, filepath.Join(dir, "bundle.json"), validToken, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "export domain with invalid token",
+			args:          []string{domain.ID, filepath.Join(dir, "invalid.json"), invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("ExportDomain", mock.Anything, mock.Anything).Return(tc.bundle, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{exportCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+				data, err := os.ReadFile(tc.args[1])
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error reading bundle: %s", tc.desc, err))
+				format := smqsdk.BundleJSON
+				if filepath.Ext(tc.args[1]) == ".yaml" {
+					format = smqsdk.BundleYAML
+				}
+				b, err := smqsdk.DecodeBundle(data, format)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding bundle: %s", tc.desc, err))
+				assert.Equal(t, tc.bundle, b, fmt.Sprintf("%s unexpected bundle: expected %v got %v", tc.desc, tc.bundle, b))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestImportDomainCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	domainCmd := cli.NewDomainsCmd()
+	rootCmd := setFlags(domainCmd)
+
+	dir := t.TempDir()
+	bundle := smqsdk.DomainBundle{
+		Version: smqsdk.BundleVersion,
+		Domain:  smqsdk.BundleDomain{ID: domain.ID, Name: domain.Name},
+	}
+	data, err := smqsdk.EncodeBundle(bundle, smqsdk.BundleYAML)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error encoding bundle: %s", err))
+	bundleFile := filepath.Join(dir, "bundle.yaml")
+	err = os.WriteFile(bundleFile, data, 0o600)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error writing bundle: %s", err))
+	invalidFile := filepath.Join(dir, "invalid.json")
+	err = os.WriteFile(invalidFile, []byte(`{"version":"0"}`), 0o600)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error writing bundle: %s", err))
+
+	targetID := testsutil.GenerateUUID(t)
+	result := smqsdk.ImportResult{
+		DryRun:  true,
+		Changes: []smqsdk.ImportChange{},
+		IDs:     map[string]string{domain.ID: targetID},
+	}
+
+	cases := []struct {
+		desc          string
+		args          []string
+		opts          smqsdk.ImportOptions
+		result        smqsdk.ImportResult
+		sdkErr        errors.SDKError
+		errLogMessage string
+		logType       outputLog
+	}{
+		{
+			desc:    "import domain successfully",
+			args:    []string{bundleFile, targetID, validToken},
+			opts:    smqsdk.ImportOptions{Conflict: smqsdk.ConflictFail},
+			result:  result,
+			logType: entityLog,
+		},
+		{
+			desc:    "import domain with dry run and conflict strategy",
+			args:    []string{bundleFile, targetID, validToken, "--dry-run", "--conflict=skip"},
+			opts:    smqsdk.ImportOptions{DryRun: true, Conflict: smqsdk.ConflictSkip},
+			result:  result,
+			logType: entityLog,
+		},
+		{
+			desc:    "import domain with invalid args",
+			args:    []string{bundleFile, targetID},
+			logType: usageLog,
+		},
+		{
+			desc:          "import domain with missing bundle file",
+			args:          []string{filepath.Join(dir, "missing.json"), targetID, validToken},
+			errLogMessage: "open",
+			logType:       errLog,
+		},
+		{
+			desc:          "import domain with unsupported bundle version",
+			args:          []string{invalidFile, targetID, validToken},
+			errLogMessage: "unsupported bundle version",
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("ImportDomain", bundle, tc.args[1], tc.opts, validToken).Return(tc.result, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{importCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				var res smqsdk.ImportResult
+				err := json.Unmarshal([]byte(out), &res)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.result, res, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.result, res))
+			case errLog:
+				assert.True(t, strings.Contains(out, tc.errLogMessage), fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
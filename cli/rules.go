@@ -0,0 +1,173 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+
+	smqsdk "github.com/absmach/supermq/pkg/sdk"
+	"github.com/spf13/cobra"
+)
+
+var cmdRules = []cobra.Command{
+	{
+		Use:   "create <JSON_rule> <domain_id> <user_auth_token>",
+		Short: "Create rule",
+		Long: "Creates new rules engine rule\n" +
+			"Usage:\n" +
+			"\tsupermq-cli rules create '{\"name\":\"overheat\",\"input_channel\":\"<channel_id>\",\"conditions\":[{\"name\":\"temperature\",\"field\":\"value\",\"operator\":\"gt\",\"threshold\":30}],\"actions\":[{\"type\":\"publish\",\"channel\":\"<channel_id>\"}]}' $DOMAINID $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			var rule smqsdk.Rule
+			if err := json.Unmarshal([]byte(args[0]), &rule); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			rule, err := sdk.CreateRule(rule, args[1], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, rule)
+		},
+	},
+	{
+		Use:   "get [all | <rule_id>] <domain_id> <user_auth_token>",
+		Short: "Get rule",
+		Long: `Get all rules or get rule by id. Rules can be filtered by status.
+		all - lists all rules
+		<rule_id> - shows rule with provided <rule_id>`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			pageMetadata := smqsdk.PageMetadata{
+				Offset: Offset,
+				Limit:  Limit,
+				Status: Status,
+			}
+
+			if args[0] == all {
+				l, err := sdk.Rules(pageMetadata, args[1], args[2])
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logJSONCmd(*cmd, l)
+				return
+			}
+			r, err := sdk.Rule(args[0], args[1], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, r)
+		},
+	},
+	{
+		Use:   "update <rule_id> <JSON_rule> <domain_id> <user_auth_token>",
+		Short: "Update rule",
+		Long:  `Updates rule name, input, conditions, window and actions`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 4 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			var rule smqsdk.Rule
+			if err := json.Unmarshal([]byte(args[1]), &rule); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			rule.ID = args[0]
+			rule, err := sdk.UpdateRule(rule, args[2], args[3])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, rule)
+		},
+	},
+	{
+		Use:   "enable <rule_id> <domain_id> <user_auth_token>",
+		Short: "Change rule status to enabled",
+		Long:  `Change rule status to enabled`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			rule, err := sdk.EnableRule(args[0], args[1], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, rule)
+		},
+	},
+	{
+		Use:   "disable <rule_id> <domain_id> <user_auth_token>",
+		Short: "Change rule status to disabled",
+		Long:  `Change rule status to disabled`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			rule, err := sdk.DisableRule(args[0], args[1], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, rule)
+		},
+	},
+	{
+		Use:   "delete <rule_id> <domain_id> <user_auth_token>",
+		Short: "Delete rule",
+		Long: "Delete rule by id.\n" +
+			"Usage:\n" +
+			"\tsupermq-cli rules delete <rule_id> $DOMAINID $USERTOKEN - delete the given rule ID\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			if err := sdk.DeleteRule(args[0], args[1], args[2]); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			logOKCmd(*cmd)
+		},
+	},
+}
+
+// NewRulesCmd returns rules command.
+func NewRulesCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "rules [create | get | update | enable | disable | delete]",
+		Short: "Rules engine management",
+		Long:  `Rules engine management: create, get, update, enable, disable or delete rules`,
+	}
+
+	for i := range cmdRules {
+		cmd.AddCommand(&cmdRules[i])
+	}
+
+	return &cmd
+}
@@ -0,0 +1,332 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/absmach/supermq/cli"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
+	sdkmocks "github.com/absmach/supermq/pkg/sdk/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var rule = mgsdk.Rule{
+	ID:           testsutil.GenerateUUID(&testing.T{}),
+	Name:         "testrule",
+	InputChannel: testsutil.GenerateUUID(&testing.T{}),
+	Status:       "enabled",
+}
+
+func TestCreateRuleCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	ruleJson := "{\"name\":\"testrule\", \"input_channel\":\"" + rule.InputChannel + "\"}"
+	rulesCmd := cli.NewRulesCmd()
+	rootCmd := setFlags(rulesCmd)
+
+	var r mgsdk.Rule
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		rule          mgsdk.Rule
+		sdkErr        errors.SDKError
+		errLogMessage string
+	}{
+		{
+			desc: "create rule successfully",
+			args: []string{
+				ruleJson,
+				domainID,
+				token,
+			},
+			rule:    rule,
+			logType: entityLog,
+		},
+		{
+			desc: "create rule with invalid args",
+			args: []string{
+				ruleJson,
+				domainID,
+				token,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "create rule with invalid json",
+			args: []string{
+				"{\"name\":\"testrule\"",
+				domainID,
+				token,
+			},
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.New("unexpected end of JSON input")),
+			logType:       errLog,
+		},
+		{
+			desc: "create rule with invalid token",
+			args: []string{
+				ruleJson,
+				domainID,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("CreateRule", mock.Anything, tc.args[1], tc.args[2]).Return(tc.rule, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{createCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &r)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.rule, r, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.rule, r))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestGetRulesCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	rulesCmd := cli.NewRulesCmd()
+	rootCmd := setFlags(rulesCmd)
+
+	var r mgsdk.Rule
+	var page mgsdk.RulesPage
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		page          mgsdk.RulesPage
+		rule          mgsdk.Rule
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc: "get all rules successfully",
+			args: []string{
+				all,
+				domainID,
+				token,
+			},
+			page: mgsdk.RulesPage{
+				Rules: []mgsdk.Rule{rule},
+			},
+			logType: entityLog,
+		},
+		{
+			desc: "get rule with id",
+			args: []string{
+				rule.ID,
+				domainID,
+				token,
+			},
+			rule:    rule,
+			logType: entityLog,
+		},
+		{
+			desc: "get rules with invalid args",
+			args: []string{
+				all,
+				domainID,
+				token,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "get rule with invalid id",
+			args: []string{
+				invalidID,
+				domainID,
+				token,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("Rules", mock.Anything, tc.args[1], tc.args[2]).Return(tc.page, tc.sdkErr)
+			sdkCall1 := sdkMock.On("Rule", tc.args[0], tc.args[1], tc.args[2]).Return(tc.rule, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{getCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				if tc.args[0] == all {
+					err := json.Unmarshal([]byte(out), &page)
+					assert.Nil(t, err)
+					assert.Equal(t, tc.page, page, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.page, page))
+					break
+				}
+				err := json.Unmarshal([]byte(out), &r)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.rule, r, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.rule, r))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			}
+			sdkCall.Unset()
+			sdkCall1.Unset()
+		})
+	}
+}
+
+func TestDisableRuleCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	rulesCmd := cli.NewRulesCmd()
+	rootCmd := setFlags(rulesCmd)
+
+	var r mgsdk.Rule
+	disabled := rule
+	disabled.Status = "disabled"
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		rule          mgsdk.Rule
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc: "disable rule successfully",
+			args: []string{
+				rule.ID,
+				domainID,
+				token,
+			},
+			rule:    disabled,
+			logType: entityLog,
+		},
+		{
+			desc: "disable rule with invalid args",
+			args: []string{
+				rule.ID,
+				domainID,
+				token,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "disable rule with invalid token",
+			args: []string{
+				rule.ID,
+				domainID,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("DisableRule", tc.args[0], tc.args[1], tc.args[2]).Return(tc.rule, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{disableCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &r)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.rule, r, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.rule, r))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestDeleteRuleCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	rulesCmd := cli.NewRulesCmd()
+	rootCmd := setFlags(rulesCmd)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc: "delete rule successfully",
+			args: []string{
+				rule.ID,
+				domainID,
+				token,
+			},
+			logType: okLog,
+		},
+		{
+			desc: "delete rule with invalid args",
+			args: []string{
+				rule.ID,
+				domainID,
+				token,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "delete rule with invalid rule id",
+			args: []string{
+				invalidID,
+				domainID,
+				token,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("DeleteRule", tc.args[0], tc.args[1], tc.args[2]).Return(tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{delCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+
+	smqsdk "github.com/absmach/supermq/pkg/sdk"
+	"github.com/spf13/cobra"
+)
+
+var cmdTwins = []cobra.Command{
+	{
+		Use:   "create <JSON_twin> <user_auth_token>",
+		Short: "Create twin",
+		Long: "Creates new digital twin\n" +
+			"Usage:\n" +
+			"\tsupermq-cli twins create '{\"name\":\"thermostat\",\"definition\":{\"attributes\":[{\"name\":\"temperature\",\"channel\":\"<channel_id>\",\"subtopic\":\"room1\",\"persist_state\":true}]}}' $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			var twin smqsdk.Twin
+			if err := json.Unmarshal([]byte(args[0]), &twin); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			twin, err := sdk.CreateTwin(twin, args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, twin)
+		},
+	},
+	{
+		Use:   "get [all | <twin_id>] <user_auth_token>",
+		Short: "Get twin",
+		Long: `Get all twins or get twin by id. Twins can be filtered by name or metadata.
+		all - lists all twins
+		<twin_id> - shows twin with provided <twin_id>`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			metadata, err := convertMetadata(Metadata)
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			pageMetadata := smqsdk.PageMetadata{
+				Name:     Name,
+				Offset:   Offset,
+				Limit:    Limit,
+				Metadata: metadata,
+			}
+
+			if args[0] == all {
+				l, err := sdk.Twins(pageMetadata, args[1])
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logJSONCmd(*cmd, l)
+				return
+			}
+			t, err := sdk.Twin(args[0], args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, t)
+		},
+	},
+	{
+		Use:   "update <twin_id> <JSON_twin> <user_auth_token>",
+		Short: "Update twin",
+		Long:  `Updates twin name and metadata. If definition is provided, it is added as the new twin definition`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			var twin smqsdk.Twin
+			if err := json.Unmarshal([]byte(args[1]), &twin); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			twin.ID = args[0]
+			twin, err := sdk.UpdateTwin(twin, args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, twin)
+		},
+	},
+	{
+		Use:   "delete <twin_id> <user_auth_token>",
+		Short: "Delete twin",
+		Long: "Delete twin and its states by id.\n" +
+			"Usage:\n" +
+			"\tsupermq-cli twins delete <twin_id> $USERTOKEN - delete the given twin ID\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			if err := sdk.DeleteTwin(args[0], args[1]); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			logOKCmd(*cmd)
+		},
+	},
+	{
+		Use:   "states <twin_id> <user_auth_token>",
+		Short: "List twin states",
+		Long: "List states of the twin, ordered by state id.\n" +
+			"Usage:\n" +
+			"\tsupermq-cli twins states <twin_id> $USERTOKEN --offset 0 --limit 10\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			pageMetadata := smqsdk.PageMetadata{
+				Offset: Offset,
+				Limit:  Limit,
+			}
+
+			sp, err := sdk.TwinStates(args[0], pageMetadata, args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, sp)
+		},
+	},
+}
+
+// NewTwinsCmd returns twins command.
+func NewTwinsCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "twins [create | get | update | delete | states]",
+		Short: "Digital twins management",
+		Long:  `Digital twins management: create, get, update or delete twins and list twin states`,
+	}
+
+	for i := range cmdTwins {
+		cmd.AddCommand(&cmdTwins[i])
+	}
+
+	return &cmd
+}
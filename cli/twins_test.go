@@ -0,0 +1,171 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/absmach/supermq/cli"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
+	sdkmocks "github.com/absmach/supermq/pkg/sdk/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const statesCmd = "states"
+
+var twin = mgsdk.Twin{
+	ID:   testsutil.GenerateUUID(&testing.T{}),
+	Name: "testtwin",
+}
+
+func TestCreateTwinCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	twinJson := "{\"name\":\"testtwin\"}"
+	twinsCmd := cli.NewTwinsCmd()
+	rootCmd := setFlags(twinsCmd)
+
+	var tw mgsdk.Twin
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		twin          mgsdk.Twin
+		sdkErr        errors.SDKError
+		errLogMessage string
+	}{
+		{
+			desc: "create twin successfully",
+			args: []string{
+				twinJson,
+				token,
+			},
+			twin:    twin,
+			logType: entityLog,
+		},
+		{
+			desc: "create twin with invalid args",
+			args: []string{
+				twinJson,
+				token,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "create twin with invalid json",
+			args: []string{
+				"{\"name\":\"testtwin\"",
+				token,
+			},
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.New("unexpected end of JSON input")),
+			logType:       errLog,
+		},
+		{
+			desc: "create twin with invalid token",
+			args: []string{
+				twinJson,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("CreateTwin", mock.Anything, tc.args[1]).Return(tc.twin, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{createCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &tw)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.twin, tw, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.twin, tw))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestTwinStatesCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	twinsCmd := cli.NewTwinsCmd()
+	rootCmd := setFlags(twinsCmd)
+
+	var sp mgsdk.TwinStatesPage
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		page          mgsdk.TwinStatesPage
+		sdkErr        errors.SDKError
+		errLogMessage string
+	}{
+		{
+			desc: "list twin states successfully",
+			args: []string{
+				twin.ID,
+				token,
+			},
+			page: mgsdk.TwinStatesPage{
+				Total:  1,
+				States: []mgsdk.TwinState{{TwinID: twin.ID, Payload: map[string]interface{}{"temperature": 25.0}}},
+			},
+			logType: entityLog,
+		},
+		{
+			desc: "list twin states with invalid args",
+			args: []string{
+				twin.ID,
+				token,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "list twin states with invalid token",
+			args: []string{
+				twin.ID,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("TwinStates", tc.args[0], mock.Anything, tc.args[1]).Return(tc.page, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{statesCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &sp)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.page, sp, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.page, sp))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
@@ -34,6 +34,7 @@ type grpcClient struct {
 	removeConnections          endpoint.Endpoint
 	removeChannelConnections   endpoint.Endpoint
 	unsetParentGroupFromClient endpoint.Endpoint
+	moveParentGroupClients     endpoint.Endpoint
 }
 
 // NewClient returns new gRPC client instance.
@@ -101,6 +102,14 @@ func NewClient(conn *grpc.ClientConn, timeout time.Duration) grpcClientsV1.Clien
 			decodeUnsetParentGroupFromClientResponse,
 			grpcClientsV1.UnsetParentGroupFromClientRes{},
 		).Endpoint(),
+		moveParentGroupClients: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"MoveParentGroupClients",
+			encodeMoveParentGroupClientsRequest,
+			decodeMoveParentGroupClientsResponse,
+			grpcClientsV1.MoveParentGroupClientsRes{},
+		).Endpoint(),
 
 		timeout: timeout,
 	}
@@ -223,6 +232,7 @@ func (client grpcClient) AddConnections(ctx context.Context, req *grpcCommonV1.A
 			ChannelID: c.GetChannelId(),
 			DomainID:  c.GetDomainId(),
 			Type:      connections.ConnType(c.GetType()),
+			Scope:     decodeScope(c),
 		})
 	}
 
@@ -247,6 +257,8 @@ func encodeAddConnectionsRequest(_ context.Context, grpcReq interface{}) (interf
 			ChannelId: r.ChannelID,
 			DomainId:  r.DomainID,
 			Type:      uint32(r.Type),
+			ExpiresAt: encodeExpiresAt(r.Scope),
+			Subtopics: r.Subtopics,
 		})
 	}
 	return &grpcCommonV1.AddConnectionsReq{
@@ -346,6 +358,26 @@ func decodeUnsetParentGroupFromClientResponse(_ context.Context, grpcRes interfa
 	return grpcRes.(*grpcClientsV1.UnsetParentGroupFromClientRes), nil
 }
 
+func (client grpcClient) MoveParentGroupClients(ctx context.Context, req *grpcClientsV1.MoveParentGroupClientsReq, _ ...grpc.CallOption) (r *grpcClientsV1.MoveParentGroupClientsRes, err error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.moveParentGroupClients(ctx, req)
+	if err != nil {
+		return &grpcClientsV1.MoveParentGroupClientsRes{}, decodeError(err)
+	}
+
+	return res.(*grpcClientsV1.MoveParentGroupClientsRes), nil
+}
+
+func encodeMoveParentGroupClientsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return grpcReq.(*grpcClientsV1.MoveParentGroupClientsReq), nil
+}
+
+func decodeMoveParentGroupClientsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return grpcRes.(*grpcClientsV1.MoveParentGroupClientsRes), nil
+}
+
 func decodeError(err error) error {
 	if st, ok := status.FromError(err); ok {
 		switch st.Code() {
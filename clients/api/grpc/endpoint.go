@@ -69,6 +69,7 @@ func addConnectionsEndpoint(svc pClients.Service) endpoint.Endpoint {
 				ChannelID: c.channelID,
 				DomainID:  c.domainID,
 				Type:      c.connType,
+				Scope:     c.scope,
 			})
 		}
 
@@ -125,3 +126,16 @@ func UnsetParentGroupFromClientEndpoint(svc pClients.Service) endpoint.Endpoint
 		return UnsetParentGroupFromClientRes{}, nil
 	}
 }
+
+func moveParentGroupClientsEndpoint(svc pClients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(moveParentGroupClientsReq)
+
+		ids, err := svc.MoveParentGroupClients(ctx, req.parentGroupIDs, req.domainID, req.updatedBy)
+		if err != nil {
+			return moveParentGroupClientsRes{}, err
+		}
+
+		return moveParentGroupClientsRes{ids: ids}, nil
+	}
+}
@@ -236,9 +236,12 @@ func TestAddConnections(t *testing.T) {
 	conn, _ := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	client := grpcapi.NewClient(conn, time.Second)
 
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
 	cases := []struct {
 		desc   string
 		req    *grpcCommonV1.AddConnectionsReq
+		conns  []clients.Connection
 		svcErr error
 		err    error
 	}{
@@ -254,6 +257,42 @@ func TestAddConnections(t *testing.T) {
 					},
 				},
 			},
+			conns: []clients.Connection{
+				{
+					ClientID:  validID,
+					ChannelID: validID,
+					DomainID:  validID,
+					Type:      connections.Publish,
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "add connections with scope successfully",
+			req: &grpcCommonV1.AddConnectionsReq{
+				Connections: []*grpcCommonV1.Connection{
+					{
+						ClientId:  validID,
+						ChannelId: validID,
+						DomainId:  validID,
+						Type:      uint32(connections.Publish),
+						ExpiresAt: expiresAt.Unix(),
+						Subtopics: []string{"sensors.temp.*"},
+					},
+				},
+			},
+			conns: []clients.Connection{
+				{
+					ClientID:  validID,
+					ChannelID: validID,
+					DomainID:  validID,
+					Type:      connections.Publish,
+					Scope: connections.Scope{
+						ExpiresAt: expiresAt,
+						Subtopics: []string{"sensors.temp.*"},
+					},
+				},
+			},
 			err: nil,
 		},
 		{
@@ -268,6 +307,11 @@ func TestAddConnections(t *testing.T) {
 					},
 				},
 			},
+			conns: []clients.Connection{
+				{
+					Type: connections.Publish,
+				},
+			},
 			svcErr: svcerr.ErrCreateEntity,
 			err:    svcerr.ErrCreateEntity,
 		},
@@ -275,7 +319,7 @@ func TestAddConnections(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			svcCall := svc.On("AddConnections", mock.Anything, mock.Anything).Return(tc.svcErr)
+			svcCall := svc.On("AddConnections", mock.Anything, tc.conns).Return(tc.svcErr)
 			_, err := client.AddConnections(context.Background(), tc.req)
 			assert.True(t, errors.Contains(err, tc.err))
 			svcCall.Unset()
@@ -419,3 +463,52 @@ func TestUnsetParentGroupFromClient(t *testing.T) {
 		})
 	}
 }
+
+func TestMoveParentGroupClients(t *testing.T) {
+	svc := new(mocks.Service)
+	server := startGRPCServer(svc, port)
+	defer server.GracefulStop()
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client := grpcapi.NewClient(conn, time.Second)
+
+	clientIDs := []string{testsutil.GenerateUUID(t)}
+
+	cases := []struct {
+		desc   string
+		req    *grpcClientsV1.MoveParentGroupClientsReq
+		svcRes []string
+		svcErr error
+		err    error
+	}{
+		{
+			desc: "move parent group clients successfully",
+			req: &grpcClientsV1.MoveParentGroupClientsReq{
+				ParentGroupIds: []string{validID},
+				DomainId:       testsutil.GenerateUUID(t),
+				UpdatedBy:      validID,
+			},
+			svcRes: clientIDs,
+		},
+		{
+			desc: "move parent group clients with exceeded quota",
+			req: &grpcClientsV1.MoveParentGroupClientsReq{
+				ParentGroupIds: []string{validID},
+				DomainId:       testsutil.GenerateUUID(t),
+				UpdatedBy:      validID,
+			},
+			svcErr: svcerr.ErrQuotaExceeded,
+			err:    svcerr.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("MoveParentGroupClients", mock.Anything, tc.req.ParentGroupIds, tc.req.DomainId, tc.req.UpdatedBy).Return(tc.svcRes, tc.svcErr)
+			res, err := client.MoveParentGroupClients(context.Background(), tc.req)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.svcRes, res.GetIds(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.svcRes, res.GetIds()))
+			svcCall.Unset()
+		})
+	}
+}
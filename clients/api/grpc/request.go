@@ -23,3 +23,9 @@ type removeChannelConnectionsReq struct {
 type UnsetParentGroupFromClientReq struct {
 	parentGroupID string
 }
+
+type moveParentGroupClientsReq struct {
+	parentGroupIDs []string
+	domainID       string
+	updatedBy      string
+}
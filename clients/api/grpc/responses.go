@@ -35,6 +35,7 @@ type connection struct {
 	channelID string
 	domainID  string
 	connType  connections.ConnType
+	scope     connections.Scope
 }
 type connectionsRes struct {
 	ok bool
@@ -43,3 +44,7 @@ type connectionsRes struct {
 type removeChannelConnectionsRes struct{}
 
 type UnsetParentGroupFromClientRes struct{}
+
+type moveParentGroupClientsRes struct {
+	ids []string
+}
@@ -5,6 +5,7 @@ package grpc
 
 import (
 	"context"
+	"time"
 
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
@@ -30,6 +31,7 @@ type grpcServer struct {
 	removeConnections          kitgrpc.Handler
 	removeChannelConnections   kitgrpc.Handler
 	unsetParentGroupFromClient kitgrpc.Handler
+	moveParentGroupClients     kitgrpc.Handler
 }
 
 // NewServer returns new AuthServiceServer instance.
@@ -70,6 +72,11 @@ func NewServer(svc clients.Service) grpcClientsV1.ClientsServiceServer {
 			decodeUnsetParentGroupFromClientRequest,
 			encodeUnsetParentGroupFromClientResponse,
 		),
+		moveParentGroupClients: kitgrpc.NewServer(
+			moveParentGroupClientsEndpoint(svc),
+			decodeMoveParentGroupClientsRequest,
+			encodeMoveParentGroupClientsResponse,
+		),
 	}
 }
 
@@ -174,6 +181,7 @@ func decodeAddConnectionsRequest(_ context.Context, grpcReq interface{}) (interf
 			channelID: c.GetChannelId(),
 			domainID:  c.GetDomainId(),
 			connType:  connType,
+			scope:     decodeScope(c),
 		})
 	}
 	return connectionsReq{
@@ -264,6 +272,29 @@ func encodeUnsetParentGroupFromClientResponse(_ context.Context, grpcRes interfa
 	return &grpcClientsV1.UnsetParentGroupFromClientRes{}, nil
 }
 
+func (s *grpcServer) MoveParentGroupClients(ctx context.Context, req *grpcClientsV1.MoveParentGroupClientsReq) (*grpcClientsV1.MoveParentGroupClientsRes, error) {
+	_, res, err := s.moveParentGroupClients.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*grpcClientsV1.MoveParentGroupClientsRes), nil
+}
+
+func decodeMoveParentGroupClientsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*grpcClientsV1.MoveParentGroupClientsReq)
+
+	return moveParentGroupClientsReq{
+		parentGroupIDs: req.GetParentGroupIds(),
+		domainID:       req.GetDomainId(),
+		updatedBy:      req.GetUpdatedBy(),
+	}, nil
+}
+
+func encodeMoveParentGroupClientsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(moveParentGroupClientsRes)
+	return &grpcClientsV1.MoveParentGroupClientsRes{Ids: res.ids}, nil
+}
+
 func encodeError(err error) error {
 	switch {
 	case errors.Contains(err, nil):
@@ -287,3 +318,20 @@ func encodeError(err error) error {
 		return status.Error(codes.Internal, err.Error())
 	}
 }
+
+func decodeScope(c *grpcCommonV1.Connection) connections.Scope {
+	scope := connections.Scope{Subtopics: c.GetSubtopics()}
+	if c.GetExpiresAt() != 0 {
+		scope.ExpiresAt = time.Unix(c.GetExpiresAt(), 0)
+	}
+
+	return scope
+}
+
+func encodeExpiresAt(scope connections.Scope) int64 {
+	if scope.ExpiresAt.IsZero() {
+		return 0
+	}
+
+	return scope.ExpiresAt.Unix()
+}
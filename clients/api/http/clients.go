@@ -106,6 +106,13 @@ func clientsHandler(svc clients.Service, authn smqauthn.Authentication, r *chi.M
 					opts...,
 				), "remove_client_parent_group").ServeHTTP)
 
+				r.Post("/move", otelhttp.NewHandler(kithttp.NewServer(
+					moveClientEndpoint(svc),
+					decodeMoveClientReq,
+					api.EncodeResponse,
+					opts...,
+				), "move_client").ServeHTTP)
+
 				r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
 					deleteClientEndpoint(svc),
 					decodeDeleteClientReq,
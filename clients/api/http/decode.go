@@ -19,8 +19,14 @@ import (
 const clientID = "clientID"
 
 func decodeViewClient(_ context.Context, r *http.Request) (interface{}, error) {
+	effective, err := apiutil.ReadBoolQuery(r, api.EffectiveKey, false)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
 	req := viewClientReq{
-		id: chi.URLParam(r, clientID),
+		id:        chi.URLParam(r, clientID),
+		effective: effective,
 	}
 
 	return req, nil
@@ -241,6 +247,20 @@ func decodeRemoveClientParentGroupStatus(_ context.Context, r *http.Request) (in
 	return req, nil
 }
 
+func decodeMoveClientReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := moveClientReq{
+		id: chi.URLParam(r, clientID),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+	return req, nil
+}
+
 func decodeDeleteClientReq(_ context.Context, r *http.Request) (interface{}, error) {
 	req := deleteClientReq{
 		id: chi.URLParam(r, clientID),
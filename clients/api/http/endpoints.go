@@ -82,7 +82,7 @@ func viewClientEndpoint(svc clients.Service) endpoint.Endpoint {
 			return nil, svcerr.ErrAuthentication
 		}
 
-		c, err := svc.View(ctx, session, req.id)
+		c, err := svc.View(ctx, session, req.id, req.effective)
 		if err != nil {
 			return nil, err
 		}
@@ -301,6 +301,27 @@ func removeClientParentGroupEndpoint(svc clients.Service) endpoint.Endpoint {
 	}
 }
 
+func moveClientEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(moveClientReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		client, err := svc.Move(ctx, session, req.id, req.DomainID)
+		if err != nil {
+			return nil, err
+		}
+
+		return updateClientRes{Client: client}, nil
+	}
+}
+
 func deleteClientEndpoint(svc clients.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(deleteClientReq)
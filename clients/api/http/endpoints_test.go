@@ -766,14 +766,16 @@ func TestViewClient(t *testing.T) {
 	defer ts.Close()
 
 	cases := []struct {
-		desc     string
-		domainID string
-		token    string
-		id       string
-		status   int
-		authnRes smqauthn.Session
-		authnErr error
-		err      error
+		desc      string
+		domainID  string
+		token     string
+		id        string
+		query     string
+		effective bool
+		status    int
+		authnRes  smqauthn.Session
+		authnErr  error
+		err       error
 	}{
 		{
 			desc:     "view client with valid token",
@@ -785,6 +787,27 @@ func TestViewClient(t *testing.T) {
 
 			err: nil,
 		},
+		{
+			desc:      "view client with effective metadata",
+			domainID:  domainID,
+			token:     validToken,
+			authnRes:  smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID},
+			id:        client.ID,
+			query:     "effective_metadata=true",
+			effective: true,
+			status:    http.StatusOK,
+			err:       nil,
+		},
+		{
+			desc:     "view client with invalid effective metadata flag",
+			domainID: domainID,
+			token:    validToken,
+			authnRes: smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID},
+			id:       client.ID,
+			query:    "effective_metadata=invalid",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
 		{
 			desc:     "view client with invalid token",
 			domainID: domainID,
@@ -819,12 +842,12 @@ func TestViewClient(t *testing.T) {
 			req := testRequest{
 				client: ts.Client(),
 				method: http.MethodGet,
-				url:    fmt.Sprintf("%s/%s/clients/%s", ts.URL, tc.domainID, tc.id),
+				url:    fmt.Sprintf("%s/%s/clients/%s?%s", ts.URL, tc.domainID, tc.id, tc.query),
 				token:  tc.token,
 			}
 
 			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
-			svcCall := svc.On("View", mock.Anything, tc.authnRes, tc.id).Return(clients.Client{}, tc.err)
+			svcCall := svc.On("View", mock.Anything, tc.authnRes, tc.id, tc.effective).Return(clients.Client{}, tc.err)
 			res, err := req.make()
 			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 			var errRes respBody
@@ -47,7 +47,8 @@ func (req createClientsReq) validate() error {
 }
 
 type viewClientReq struct {
-	id string
+	id        string
+	effective bool
 }
 
 func (req viewClientReq) validate() error {
@@ -201,6 +202,21 @@ func (req removeClientParentGroupReq) validate() error {
 	return nil
 }
 
+type moveClientReq struct {
+	id       string
+	DomainID string `json:"domain_id"`
+}
+
+func (req moveClientReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.DomainID == "" {
+		return apiutil.ErrMissingDomainID
+	}
+	return nil
+}
+
 type deleteClientReq struct {
 	id string
 }
@@ -18,6 +18,7 @@ type Connection struct {
 	ChannelID string
 	DomainID  string
 	Type      connections.ConnType
+	connections.Scope
 }
 
 type ClientRepository struct {
@@ -69,8 +70,14 @@ type Repository interface {
 	// RetrieveBySecret retrieves a client based on the secret (key).
 	RetrieveBySecret(ctx context.Context, key string) (Client, error)
 
+	// AddConnections saves the connections. The expired connections between
+	// the same channels and clients are replaced.
 	AddConnections(ctx context.Context, conns []Connection) error
 
+	// RemoveExpiredConnections removes the expired connections and returns
+	// the number of the removed connections.
+	RemoveExpiredConnections(ctx context.Context) (uint64, error)
+
 	RemoveConnections(ctx context.Context, conns []Connection) error
 
 	ClientConnectionsCount(ctx context.Context, id string) (uint64, error)
@@ -87,6 +94,17 @@ type Repository interface {
 	// RemoveParentGroup remove parent group id fr given chanel id
 	RemoveParentGroup(ctx context.Context, cli Client) error
 
+	// ChangeDomain moves the client to the given domain and removes its parent group.
+	ChangeDomain(ctx context.Context, cli Client) (Client, error)
+
+	// ChangeParentGroupsDomain moves the clients of the given parent groups to
+	// the domain of the given client. The clients keep their parent groups.
+	ChangeParentGroupsDomain(ctx context.Context, cli Client, parentGroupIDs []string) ([]Client, error)
+
+	// RetrievePathMetadata retrieves metadata of all groups on the given
+	// path, ordered from the root group.
+	RetrievePathMetadata(ctx context.Context, path string) ([]Metadata, error)
+
 	RetrieveParentGroupClients(ctx context.Context, parentGroupID string) ([]Client, error)
 
 	UnsetParentGroupFromClient(ctx context.Context, parentGroupID string) error
@@ -104,7 +122,9 @@ type Service interface {
 	CreateClients(ctx context.Context, session authn.Session, client ...Client) ([]Client, []roles.RoleProvision, error)
 
 	// View retrieves client info for a given client ID and an authorized token.
-	View(ctx context.Context, session authn.Session, id string) (Client, error)
+	// With effective set, the client metadata is merged with metadata inherited
+	// from its parent group hierarchy.
+	View(ctx context.Context, session authn.Session, id string, effective bool) (Client, error)
 
 	// ListClients retrieves clients list for given page query.
 	ListClients(ctx context.Context, session authn.Session, pm Page) (ClientsPage, error)
@@ -134,6 +154,9 @@ type Service interface {
 
 	RemoveParentGroup(ctx context.Context, session authn.Session, id string) error
 
+	// Move moves the client with the given ID to the domain with the given ID.
+	Move(ctx context.Context, session authn.Session, id, domainID string) (Client, error)
+
 	roles.RoleManager
 }
 
@@ -26,6 +26,7 @@ const (
 	clientAuthorize    = clientPrefix + "authorize"
 	clientSetParent    = clientPrefix + "set_parent"
 	clientRemoveParent = clientPrefix + "remove_parent"
+	clientMove         = clientPrefix + "move"
 )
 
 var (
@@ -40,6 +41,7 @@ var (
 	_ events.Event = (*authorizeClientEvent)(nil)
 	_ events.Event = (*shareClientEvent)(nil)
 	_ events.Event = (*removeClientEvent)(nil)
+	_ events.Event = (*moveClientEvent)(nil)
 )
 
 type createClientEvent struct {
@@ -452,3 +454,25 @@ func (rpge removeParentGroupEvent) Encode() (map[string]interface{}, error) {
 		"super_admin": rpge.SuperAdmin,
 	}, nil
 }
+
+type moveClientEvent struct {
+	id        string
+	domainID  string
+	updatedAt time.Time
+	updatedBy string
+	authn.Session
+}
+
+func (mce moveClientEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   clientMove,
+		"id":          mce.id,
+		"domain":      mce.DomainID,
+		"new_domain":  mce.domainID,
+		"updated_at":  mce.updatedAt,
+		"updated_by":  mce.updatedBy,
+		"user_id":     mce.UserID,
+		"token_type":  mce.Type.String(),
+		"super_admin": mce.SuperAdmin,
+	}, nil
+}
@@ -101,8 +101,8 @@ func (es *eventStore) update(ctx context.Context, session authn.Session, operati
 	return client, nil
 }
 
-func (es *eventStore) View(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
-	cli, err := es.svc.View(ctx, session, id)
+func (es *eventStore) View(ctx context.Context, session authn.Session, id string, effective bool) (clients.Client, error) {
+	cli, err := es.svc.View(ctx, session, id, effective)
 	if err != nil {
 		return cli, err
 	}
@@ -235,3 +235,24 @@ func (es *eventStore) RemoveParentGroup(ctx context.Context, session authn.Sessi
 
 	return nil
 }
+
+func (es *eventStore) Move(ctx context.Context, session authn.Session, id, domainID string) (clients.Client, error) {
+	cli, err := es.svc.Move(ctx, session, id, domainID)
+	if err != nil {
+		return cli, err
+	}
+
+	event := moveClientEvent{
+		id:        id,
+		domainID:  domainID,
+		updatedAt: cli.UpdatedAt,
+		updatedBy: cli.UpdatedBy,
+		Session:   session,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return cli, err
+	}
+
+	return cli, nil
+}
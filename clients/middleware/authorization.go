@@ -28,6 +28,7 @@ var (
 	errDelete                  = errors.New("not authorized to delete thing")
 	errSetParentGroup          = errors.New("not authorized to set parent group to thing")
 	errRemoveParentGroup       = errors.New("not authorized to remove parent group from thing")
+	errMove                    = errors.New("not authorized to move thing")
 	errDomainCreateClients     = errors.New("not authorized to create thing in domain")
 	errGroupSetChildClients    = errors.New("not authorized to set child thing for group")
 	errGroupRemoveChildClients = errors.New("not authorized to remove child thing for group")
@@ -79,6 +80,7 @@ func (am *authorizationMiddleware) CreateClients(ctx context.Context, session au
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainClientsScope,
@@ -102,11 +104,12 @@ func (am *authorizationMiddleware) CreateClients(ctx context.Context, session au
 	return am.svc.CreateClients(ctx, session, client...)
 }
 
-func (am *authorizationMiddleware) View(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
+func (am *authorizationMiddleware) View(ctx context.Context, session authn.Session, id string, effective bool) (clients.Client, error) {
 	if session.Type == authn.PersonalAccessToken {
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainClientsScope,
@@ -126,7 +129,7 @@ func (am *authorizationMiddleware) View(ctx context.Context, session authn.Sessi
 	}); err != nil {
 		return clients.Client{}, errors.Wrap(err, errView)
 	}
-	return am.svc.View(ctx, session, id)
+	return am.svc.View(ctx, session, id, effective)
 }
 
 func (am *authorizationMiddleware) ListClients(ctx context.Context, session authn.Session, pm clients.Page) (clients.ClientsPage, error) {
@@ -134,6 +137,7 @@ func (am *authorizationMiddleware) ListClients(ctx context.Context, session auth
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainClientsScope,
@@ -156,6 +160,7 @@ func (am *authorizationMiddleware) ListUserClients(ctx context.Context, session
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainClientsScope,
@@ -178,6 +183,7 @@ func (am *authorizationMiddleware) Update(ctx context.Context, session authn.Ses
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainClientsScope,
@@ -206,6 +212,7 @@ func (am *authorizationMiddleware) UpdateTags(ctx context.Context, session authn
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainClientsScope,
@@ -234,6 +241,7 @@ func (am *authorizationMiddleware) UpdateSecret(ctx context.Context, session aut
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainClientsScope,
@@ -261,6 +269,7 @@ func (am *authorizationMiddleware) Enable(ctx context.Context, session authn.Ses
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainClientsScope,
@@ -289,6 +298,7 @@ func (am *authorizationMiddleware) Disable(ctx context.Context, session authn.Se
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainClientsScope,
@@ -316,6 +326,7 @@ func (am *authorizationMiddleware) Delete(ctx context.Context, session authn.Ses
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainClientsScope,
@@ -343,6 +354,7 @@ func (am *authorizationMiddleware) SetParentGroup(ctx context.Context, session a
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -380,6 +392,7 @@ func (am *authorizationMiddleware) RemoveParentGroup(ctx context.Context, sessio
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -420,6 +433,57 @@ func (am *authorizationMiddleware) RemoveParentGroup(ctx context.Context, sessio
 	return nil
 }
 
+func (am *authorizationMiddleware) Move(ctx context.Context, session authn.Session, id, domainID string) (clients.Client, error) {
+	if session.Type == authn.PersonalAccessToken {
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainClientsScope,
+			Operation:                auth.DeleteOp,
+			EntityIDs:                []string{id},
+		}); err != nil {
+			return clients.Client{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         domainID,
+			OptionalDomainEntityType: auth.DomainClientsScope,
+			Operation:                auth.CreateOp,
+			EntityIDs:                auth.AnyIDs{}.Values(),
+		}); err != nil {
+			return clients.Client{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+	}
+
+	if err := am.authorize(ctx, clients.OpMoveClient, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		ObjectType:  policies.ClientType,
+		Object:      id,
+	}); err != nil {
+		return clients.Client{}, errors.Wrap(err, errMove)
+	}
+
+	if err := am.extAuthorize(ctx, clients.DomainOpCreateClient, smqauthz.PolicyReq{
+		Domain:      domainID,
+		SubjectType: policies.UserType,
+		Subject:     policies.EncodeDomainUserID(domainID, session.UserID),
+		ObjectType:  policies.DomainType,
+		Object:      domainID,
+	}); err != nil {
+		return clients.Client{}, errors.Wrap(err, errDomainCreateClients)
+	}
+
+	return am.svc.Move(ctx, session, id, domainID)
+}
+
 func (am *authorizationMiddleware) authorize(ctx context.Context, op svcutil.Operation, req smqauthz.PolicyReq) error {
 	perm, err := am.opp.GetPermission(op)
 	if err != nil {
@@ -46,7 +46,7 @@ func (lm *loggingMiddleware) CreateClients(ctx context.Context, session authn.Se
 	return lm.svc.CreateClients(ctx, session, clients...)
 }
 
-func (lm *loggingMiddleware) View(ctx context.Context, session authn.Session, id string) (c clients.Client, err error) {
+func (lm *loggingMiddleware) View(ctx context.Context, session authn.Session, id string, effective bool) (c clients.Client, err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
@@ -54,6 +54,7 @@ func (lm *loggingMiddleware) View(ctx context.Context, session authn.Session, id
 				slog.String("id", c.ID),
 				slog.String("name", c.Name),
 			),
+			slog.Bool("effective", effective),
 		}
 		if err != nil {
 			args = append(args, slog.String("error", err.Error()))
@@ -62,7 +63,7 @@ func (lm *loggingMiddleware) View(ctx context.Context, session authn.Session, id
 		}
 		lm.logger.Info("View client completed successfully", args...)
 	}(time.Now())
-	return lm.svc.View(ctx, session, id)
+	return lm.svc.View(ctx, session, id, effective)
 }
 
 func (lm *loggingMiddleware) ListClients(ctx context.Context, session authn.Session, pm clients.Page) (cp clients.ClientsPage, err error) {
@@ -251,3 +252,20 @@ func (lm *loggingMiddleware) RemoveParentGroup(ctx context.Context, session auth
 	}(time.Now())
 	return lm.svc.RemoveParentGroup(ctx, session, id)
 }
+
+func (lm *loggingMiddleware) Move(ctx context.Context, session authn.Session, id, domainID string) (c clients.Client, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("client_id", id),
+			slog.String("domain_id", domainID),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Move client failed", args...)
+			return
+		}
+		lm.logger.Info("Move client completed successfully", args...)
+	}(time.Now())
+	return lm.svc.Move(ctx, session, id, domainID)
+}
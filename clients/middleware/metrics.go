@@ -41,12 +41,12 @@ func (ms *metricsMiddleware) CreateClients(ctx context.Context, session authn.Se
 	return ms.svc.CreateClients(ctx, session, clients...)
 }
 
-func (ms *metricsMiddleware) View(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
+func (ms *metricsMiddleware) View(ctx context.Context, session authn.Session, id string, effective bool) (clients.Client, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_client").Add(1)
 		ms.latency.With("method", "view_client").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.View(ctx, session, id)
+	return ms.svc.View(ctx, session, id, effective)
 }
 
 func (ms *metricsMiddleware) ListClients(ctx context.Context, session authn.Session, pm clients.Page) (clients.ClientsPage, error) {
@@ -128,3 +128,11 @@ func (ms *metricsMiddleware) RemoveParentGroup(ctx context.Context, session auth
 	}(time.Now())
 	return ms.svc.RemoveParentGroup(ctx, session, id)
 }
+
+func (ms *metricsMiddleware) Move(ctx context.Context, session authn.Session, id, domainID string) (clients.Client, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "move_client").Add(1)
+		ms.latency.With("method", "move_client").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Move(ctx, session, id, domainID)
+}
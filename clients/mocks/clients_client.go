@@ -179,6 +179,80 @@ func (_c *ClientsServiceClient_Authenticate_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// MoveParentGroupClients provides a mock function with given fields: ctx, in, opts
+func (_m *ClientsServiceClient) MoveParentGroupClients(ctx context.Context, in *clientsv1.MoveParentGroupClientsReq, opts ...grpc.CallOption) (*clientsv1.MoveParentGroupClientsRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveParentGroupClients")
+	}
+
+	var r0 *clientsv1.MoveParentGroupClientsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *clientsv1.MoveParentGroupClientsReq, ...grpc.CallOption) (*clientsv1.MoveParentGroupClientsRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *clientsv1.MoveParentGroupClientsReq, ...grpc.CallOption) *clientsv1.MoveParentGroupClientsRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*clientsv1.MoveParentGroupClientsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *clientsv1.MoveParentGroupClientsReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClientsServiceClient_MoveParentGroupClients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveParentGroupClients'
+type ClientsServiceClient_MoveParentGroupClients_Call struct {
+	*mock.Call
+}
+
+// MoveParentGroupClients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *clientsv1.MoveParentGroupClientsReq
+//   - opts ...grpc.CallOption
+func (_e *ClientsServiceClient_Expecter) MoveParentGroupClients(ctx interface{}, in interface{}, opts ...interface{}) *ClientsServiceClient_MoveParentGroupClients_Call {
+	return &ClientsServiceClient_MoveParentGroupClients_Call{Call: _e.mock.On("MoveParentGroupClients",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *ClientsServiceClient_MoveParentGroupClients_Call) Run(run func(ctx context.Context, in *clientsv1.MoveParentGroupClientsReq, opts ...grpc.CallOption)) *ClientsServiceClient_MoveParentGroupClients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*clientsv1.MoveParentGroupClientsReq), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ClientsServiceClient_MoveParentGroupClients_Call) Return(_a0 *clientsv1.MoveParentGroupClientsRes, _a1 error) *ClientsServiceClient_MoveParentGroupClients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClientsServiceClient_MoveParentGroupClients_Call) RunAndReturn(run func(context.Context, *clientsv1.MoveParentGroupClientsReq, ...grpc.CallOption) (*clientsv1.MoveParentGroupClientsRes, error)) *ClientsServiceClient_MoveParentGroupClients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveChannelConnections provides a mock function with given fields: ctx, in, opts
 func (_m *ClientsServiceClient) RemoveChannelConnections(ctx context.Context, in *clientsv1.RemoveChannelConnectionsReq, opts ...grpc.CallOption) (*clientsv1.RemoveChannelConnectionsRes, error) {
 	_va := make([]interface{}, len(opts))
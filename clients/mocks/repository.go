@@ -67,6 +67,64 @@ func (_m *Repository) AddRoles(ctx context.Context, rps []roles.RoleProvision) (
 	return r0, r1
 }
 
+// ChangeDomain provides a mock function with given fields: ctx, cli
+func (_m *Repository) ChangeDomain(ctx context.Context, cli clients.Client) (clients.Client, error) {
+	ret := _m.Called(ctx, cli)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeDomain")
+	}
+
+	var r0 clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, clients.Client) (clients.Client, error)); ok {
+		return rf(ctx, cli)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, clients.Client) clients.Client); ok {
+		r0 = rf(ctx, cli)
+	} else {
+		r0 = ret.Get(0).(clients.Client)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, clients.Client) error); ok {
+		r1 = rf(ctx, cli)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeParentGroupsDomain provides a mock function with given fields: ctx, cli, parentGroupIDs
+func (_m *Repository) ChangeParentGroupsDomain(ctx context.Context, cli clients.Client, parentGroupIDs []string) ([]clients.Client, error) {
+	ret := _m.Called(ctx, cli, parentGroupIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeParentGroupsDomain")
+	}
+
+	var r0 []clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, clients.Client, []string) ([]clients.Client, error)); ok {
+		return rf(ctx, cli, parentGroupIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, clients.Client, []string) []clients.Client); ok {
+		r0 = rf(ctx, cli, parentGroupIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]clients.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, clients.Client, []string) error); ok {
+		r1 = rf(ctx, cli, parentGroupIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ChangeStatus provides a mock function with given fields: ctx, client
 func (_m *Repository) ChangeStatus(ctx context.Context, client clients.Client) (clients.Client, error) {
 	ret := _m.Called(ctx, client)
@@ -276,6 +334,34 @@ func (_m *Repository) RemoveEntityMembers(ctx context.Context, entityID string,
 	return r0
 }
 
+// RemoveExpiredConnections provides a mock function with given fields: ctx
+func (_m *Repository) RemoveExpiredConnections(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveExpiredConnections")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveMemberFromAllRoles provides a mock function with given fields: ctx, memberID
 func (_m *Repository) RemoveMemberFromAllRoles(ctx context.Context, memberID string) error {
 	ret := _m.Called(ctx, memberID)
@@ -567,6 +653,36 @@ func (_m *Repository) RetrieveParentGroupClients(ctx context.Context, parentGrou
 	return r0, r1
 }
 
+// RetrievePathMetadata provides a mock function with given fields: ctx, path
+func (_m *Repository) RetrievePathMetadata(ctx context.Context, path string) ([]clients.Metadata, error) {
+	ret := _m.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePathMetadata")
+	}
+
+	var r0 []clients.Metadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]clients.Metadata, error)); ok {
+		return rf(ctx, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []clients.Metadata); ok {
+		r0 = rf(ctx, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]clients.Metadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveRole provides a mock function with given fields: ctx, roleID
 func (_m *Repository) RetrieveRole(ctx context.Context, roleID string) (roles.Role, error) {
 	ret := _m.Called(ctx, roleID)
@@ -282,6 +282,34 @@ func (_m *Service) ListUserClients(ctx context.Context, session authn.Session, u
 	return r0, r1
 }
 
+// Move provides a mock function with given fields: ctx, session, id, domainID
+func (_m *Service) Move(ctx context.Context, session authn.Session, id string, domainID string) (clients.Client, error) {
+	ret := _m.Called(ctx, session, id, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Move")
+	}
+
+	var r0 clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) (clients.Client, error)); ok {
+		return rf(ctx, session, id, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) clients.Client); ok {
+		r0 = rf(ctx, session, id, domainID)
+	} else {
+		r0 = ret.Get(0).(clients.Client)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, string) error); ok {
+		r1 = rf(ctx, session, id, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveEntityMembers provides a mock function with given fields: ctx, session, entityID, members
 func (_m *Service) RemoveEntityMembers(ctx context.Context, session authn.Session, entityID string, members []string) error {
 	ret := _m.Called(ctx, session, entityID, members)
@@ -786,9 +814,9 @@ func (_m *Service) UpdateTags(ctx context.Context, session authn.Session, client
 	return r0, r1
 }
 
-// View provides a mock function with given fields: ctx, session, id
-func (_m *Service) View(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
-	ret := _m.Called(ctx, session, id)
+// View provides a mock function with given fields: ctx, session, id, effective
+func (_m *Service) View(ctx context.Context, session authn.Session, id string, effective bool) (clients.Client, error) {
+	ret := _m.Called(ctx, session, id, effective)
 
 	if len(ret) == 0 {
 		panic("no return value specified for View")
@@ -796,17 +824,17 @@ func (_m *Service) View(ctx context.Context, session authn.Session, id string) (
 
 	var r0 clients.Client
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) (clients.Client, error)); ok {
-		return rf(ctx, session, id)
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bool) (clients.Client, error)); ok {
+		return rf(ctx, session, id, effective)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) clients.Client); ok {
-		r0 = rf(ctx, session, id)
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bool) clients.Client); ok {
+		r0 = rf(ctx, session, id, effective)
 	} else {
 		r0 = ret.Get(0).(clients.Client)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
-		r1 = rf(ctx, session, id)
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, bool) error); ok {
+		r1 = rf(ctx, session, id, effective)
 	} else {
 		r1 = ret.Error(1)
 	}
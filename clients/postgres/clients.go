@@ -14,6 +14,7 @@ import (
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/clients"
+	gpostgres "github.com/absmach/supermq/groups/postgres"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
@@ -171,8 +172,10 @@ func (repo *clientRepo) ChangeStatus(ctx context.Context, client clients.Client)
 }
 
 func (repo *clientRepo) RetrieveByID(ctx context.Context, id string) (clients.Client, error) {
-	q := `SELECT id, name, tags, COALESCE(domain_id, '') AS domain_id, COALESCE(parent_group_id, '') AS parent_group_id, identity, secret, metadata, created_at, updated_at, updated_by, status
-        FROM clients WHERE id = :id`
+	q := `SELECT c.id, c.name, c.tags, COALESCE(c.domain_id, '') AS domain_id, COALESCE(c.parent_group_id, '') AS parent_group_id,
+		COALESCE((SELECT path FROM groups WHERE id = c.parent_group_id), ''::::ltree) AS parent_group_path,
+		c.identity, c.secret, c.metadata, c.created_at, c.updated_at, c.updated_by, c.status
+        FROM clients c WHERE c.id = :id`
 
 	dbc := DBClient{
 		ID: id,
@@ -196,6 +199,19 @@ func (repo *clientRepo) RetrieveByID(ctx context.Context, id string) (clients.Cl
 	return clients.Client{}, repoerr.ErrNotFound
 }
 
+func (repo *clientRepo) RetrievePathMetadata(ctx context.Context, path string) ([]clients.Metadata, error) {
+	mds, err := gpostgres.RetrievePathMetadata(ctx, repo.DB, path)
+	if err != nil {
+		return []clients.Metadata{}, err
+	}
+	ret := make([]clients.Metadata, len(mds))
+	for i, md := range mds {
+		ret[i] = md
+	}
+
+	return ret, nil
+}
+
 func (repo *clientRepo) RetrieveAll(ctx context.Context, pm clients.Page) (clients.ClientsPage, error) {
 	pageQuery, err := PageQuery(pm)
 	if err != nil {
@@ -1123,15 +1139,34 @@ func (repo *clientRepo) RetrieveByIds(ctx context.Context, ids []string) (client
 
 func (repo *clientRepo) AddConnections(ctx context.Context, conns []clients.Connection) error {
 	dbConns := toDBConnections(conns)
-	q := `INSERT INTO connections (channel_id, domain_id, client_id, type)
-			VALUES (:channel_id, :domain_id, :client_id, :type);`
-	if _, err := repo.DB.NamedExecContext(ctx, q, dbConns); err != nil {
+	// The expired connection is replaced, so the client can be connected again.
+	q := `INSERT INTO connections (channel_id, domain_id, client_id, type, expires_at, subtopics)
+			VALUES (:channel_id, :domain_id, :client_id, :type, :expires_at, :subtopics)
+			ON CONFLICT (channel_id, domain_id, client_id, type) DO UPDATE SET expires_at = EXCLUDED.expires_at, subtopics = EXCLUDED.subtopics
+			WHERE connections.expires_at <= NOW() AT TIME ZONE 'UTC'`
+	result, err := repo.DB.NamedExecContext(ctx, q, dbConns)
+	if err != nil {
 		return postgres.HandleError(repoerr.ErrCreateEntity, err)
 	}
+	if rows, _ := result.RowsAffected(); rows < int64(len(dbConns)) {
+		return repoerr.ErrConflict
+	}
 
 	return nil
 }
 
+func (repo *clientRepo) RemoveExpiredConnections(ctx context.Context) (uint64, error) {
+	q := `DELETE FROM connections WHERE expires_at <= NOW() AT TIME ZONE 'UTC'`
+
+	result, err := repo.DB.ExecContext(ctx, q)
+	if err != nil {
+		return 0, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	rows, _ := result.RowsAffected()
+
+	return uint64(rows), nil
+}
+
 func (repo *clientRepo) RemoveConnections(ctx context.Context, conns []clients.Connection) (retErr error) {
 	tx, err := repo.DB.BeginTxx(ctx, nil)
 	if err != nil {
@@ -1195,6 +1230,51 @@ func (repo *clientRepo) RemoveParentGroup(ctx context.Context, cli clients.Clien
 	return nil
 }
 
+func (repo *clientRepo) ChangeDomain(ctx context.Context, cli clients.Client) (clients.Client, error) {
+	q := `UPDATE clients SET domain_id = :domain_id, parent_group_id = NULL, updated_at = :updated_at, updated_by = :updated_by
+        WHERE id = :id
+        RETURNING id, name, tags, identity, metadata, COALESCE(domain_id, '') AS domain_id, COALESCE(parent_group_id, '') AS parent_group_id, status, created_at, updated_at, updated_by`
+
+	return repo.update(ctx, cli, q)
+}
+
+func (repo *clientRepo) ChangeParentGroupsDomain(ctx context.Context, cli clients.Client, parentGroupIDs []string) ([]clients.Client, error) {
+	q := `UPDATE clients SET domain_id = :domain_id, updated_at = :updated_at, updated_by = :updated_by
+        WHERE parent_group_id = ANY(:parent_group_ids)
+        RETURNING id, name, tags, identity, metadata, COALESCE(domain_id, '') AS domain_id, COALESCE(parent_group_id, '') AS parent_group_id, status, created_at, updated_at, updated_by`
+
+	dbc, err := ToDBClient(cli)
+	if err != nil {
+		return []clients.Client{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	params := map[string]interface{}{
+		"domain_id":        dbc.Domain,
+		"updated_at":       dbc.UpdatedAt,
+		"updated_by":       dbc.UpdatedBy,
+		"parent_group_ids": parentGroupIDs,
+	}
+	rows, err := repo.DB.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return []clients.Client{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	var clis []clients.Client
+	for rows.Next() {
+		dbCli := DBClient{}
+		if err := rows.StructScan(&dbCli); err != nil {
+			return []clients.Client{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		c, err := ToClient(dbCli)
+		if err != nil {
+			return []clients.Client{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		clis = append(clis, c)
+	}
+
+	return clis, nil
+}
+
 func (repo *clientRepo) ClientConnectionsCount(ctx context.Context, id string) (uint64, error) {
 	query := `SELECT COUNT(*) FROM connections WHERE client_id = :client_id`
 	dbConn := dbConnection{ClientID: id}
@@ -1280,6 +1360,8 @@ type dbConnection struct {
 	ChannelID string               `db:"channel_id"`
 	DomainID  string               `db:"domain_id"`
 	Type      connections.ConnType `db:"type"`
+	ExpiresAt sql.NullTime         `db:"expires_at"`
+	Subtopics pq.StringArray       `db:"subtopics"`
 }
 
 func toDBConnections(conns []clients.Connection) []dbConnection {
@@ -1291,10 +1373,17 @@ func toDBConnections(conns []clients.Connection) []dbConnection {
 }
 
 func toDBConnection(conn clients.Connection) dbConnection {
+	var expiresAt sql.NullTime
+	if !conn.ExpiresAt.IsZero() {
+		expiresAt = sql.NullTime{Time: conn.ExpiresAt.UTC(), Valid: true}
+	}
+
 	return dbConnection{
 		ClientID:  conn.ClientID,
 		ChannelID: conn.ChannelID,
 		DomainID:  conn.DomainID,
 		Type:      conn.Type,
+		ExpiresAt: expiresAt,
+		Subtopics: conn.Subtopics,
 	}
 }
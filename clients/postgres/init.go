@@ -56,6 +56,17 @@ func Migration() (*migrate.MemoryMigrationSource, error) {
 					`DROP TABLE IF EXISTS connections`,
 				},
 			},
+			{
+				Id: "clients_02",
+				Up: []string{
+					`ALTER TABLE connections ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP`,
+					`ALTER TABLE connections ADD COLUMN IF NOT EXISTS subtopics TEXT[]`,
+				},
+				Down: []string{
+					`ALTER TABLE connections DROP COLUMN IF EXISTS expires_at`,
+					`ALTER TABLE connections DROP COLUMN IF EXISTS subtopics`,
+				},
+			},
 		},
 	}
 
@@ -63,6 +63,36 @@ func (_m *Service) Authenticate(ctx context.Context, key string) (string, error)
 	return r0, r1
 }
 
+// MoveParentGroupClients provides a mock function with given fields: ctx, parentGroupIDs, domainID, updatedBy
+func (_m *Service) MoveParentGroupClients(ctx context.Context, parentGroupIDs []string, domainID string, updatedBy string) ([]string, error) {
+	ret := _m.Called(ctx, parentGroupIDs, domainID, updatedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveParentGroupClients")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string, string) ([]string, error)); ok {
+		return rf(ctx, parentGroupIDs, domainID, updatedBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string, string) []string); ok {
+		r0 = rf(ctx, parentGroupIDs, domainID, updatedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string, string) error); ok {
+		r1 = rf(ctx, parentGroupIDs, domainID, updatedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveChannelConnections provides a mock function with given fields: ctx, channelID
 func (_m *Service) RemoveChannelConnections(ctx context.Context, channelID string) error {
 	ret := _m.Called(ctx, channelID)
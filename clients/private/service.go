@@ -5,11 +5,15 @@ package private
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/domains"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/roles"
 )
 
 //go:generate mockery --name Service  --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
@@ -28,16 +32,21 @@ type Service interface {
 	RemoveChannelConnections(ctx context.Context, channelID string) error
 
 	UnsetParentGroupFromClient(ctx context.Context, parentGroupID string) error
+
+	// MoveParentGroupClients moves the clients of the given parent groups to
+	// the domain and returns their IDs.
+	MoveParentGroupClients(ctx context.Context, parentGroupIDs []string, domainID, updatedBy string) ([]string, error)
 }
 
 var _ Service = (*service)(nil)
 
-func New(repo clients.Repository, cache clients.Cache, evaluator policies.Evaluator, policy policies.Service) Service {
+func New(repo clients.Repository, cache clients.Cache, evaluator policies.Evaluator, policy policies.Service, quotas pkgDomains.Quotas) Service {
 	return service{
 		repo:      repo,
 		cache:     cache,
 		evaluator: evaluator,
 		policy:    policy,
+		quotas:    quotas,
 	}
 }
 
@@ -46,6 +55,7 @@ type service struct {
 	cache     clients.Cache
 	evaluator policies.Evaluator
 	policy    policies.Service
+	quotas    pkgDomains.Quotas
 }
 
 func (svc service) Authenticate(ctx context.Context, key string) (string, error) {
@@ -120,3 +130,118 @@ func (svc service) UnsetParentGroupFromClient(ctx context.Context, parentGroupID
 	}
 	return nil
 }
+
+// MoveParentGroupClients moves the clients of the parent groups to the domain
+// together with their roles, within the domain clients quota. The clients keep
+// their parent groups, which are moved by the groups service.
+func (svc service) MoveParentGroupClients(ctx context.Context, parentGroupIDs []string, domainID, updatedBy string) (retIDs []string, retErr error) {
+	var clis []clients.Client
+	for _, id := range parentGroupIDs {
+		pgClients, err := svc.repo.RetrieveParentGroupClients(ctx, id)
+		if err != nil {
+			return []string{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		clis = append(clis, pgClients...)
+	}
+	if len(clis) == 0 {
+		return []string{}, nil
+	}
+
+	unlock, err := svc.lockQuota(ctx, domainID, uint64(len(clis)))
+	if err != nil {
+		return []string{}, err
+	}
+	defer unlock()
+
+	ids := []string{}
+	domainClients := map[string][]string{}
+	var oldPolicies, newPolicies []policies.Policy
+	for _, c := range clis {
+		ids = append(ids, c.ID)
+		domainClients[c.Domain] = append(domainClients[c.Domain], c.ID)
+		oldPolicies = append(oldPolicies, policies.Policy{
+			Domain:      c.Domain,
+			SubjectType: policies.DomainType,
+			Subject:     c.Domain,
+			Relation:    policies.DomainRelation,
+			ObjectType:  policies.ClientType,
+			Object:      c.ID,
+		})
+		newPolicies = append(newPolicies, policies.Policy{
+			Domain:      domainID,
+			SubjectType: policies.DomainType,
+			Subject:     domainID,
+			Relation:    policies.DomainRelation,
+			ObjectType:  policies.ClientType,
+			Object:      c.ID,
+		})
+	}
+
+	// The client can be related to a single domain only, so the old
+	// relations are removed before the new ones are added.
+	if err := svc.policy.DeletePolicies(ctx, oldPolicies); err != nil {
+		return []string{}, errors.Wrap(svcerr.ErrDeletePolicies, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.policy.AddPolicies(ctx, oldPolicies); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(errors.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+	if err := svc.policy.AddPolicies(ctx, newPolicies); err != nil {
+		return []string{}, errors.Wrap(svcerr.ErrAddPolicies, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.policy.DeletePolicies(ctx, newPolicies); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(errors.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+	for fromDomainID, clientIDs := range domainClients {
+		if err := roles.MoveEntitiesRoles(ctx, svc.repo, svc.policy, fromDomainID, domainID, clientIDs); err != nil {
+			return []string{}, err
+		}
+		defer func() {
+			if retErr != nil {
+				if errRollback := roles.MoveEntitiesRoles(ctx, svc.repo, svc.policy, domainID, fromDomainID, clientIDs); errRollback != nil {
+					retErr = errors.Wrap(retErr, errors.Wrap(errors.ErrRollbackTx, errRollback))
+				}
+			}
+		}()
+	}
+
+	if _, err := svc.repo.ChangeParentGroupsDomain(ctx, clients.Client{Domain: domainID, UpdatedBy: updatedBy, UpdatedAt: time.Now()}, parentGroupIDs); err != nil {
+		return []string{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return ids, nil
+}
+
+// lockQuota locks the domain quotas and checks that the requested clients
+// fit into the domain clients quota. The returned function unlocks the quotas.
+func (svc service) lockQuota(ctx context.Context, domainID string, requested uint64) (func(), error) {
+	q, err := svc.quotas.RetrieveQuotas(ctx, domainID)
+	if err != nil {
+		return nil, err
+	}
+	if q.Clients == 0 {
+		return func() {}, nil
+	}
+	unlock, err := svc.quotas.LockQuotas(ctx, domainID)
+	if err != nil {
+		return nil, err
+	}
+	page, err := svc.repo.RetrieveAll(ctx, clients.Page{Domain: domainID, Status: clients.AllStatus, Limit: 1})
+	if err != nil {
+		unlock()
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if err := domains.CheckQuota(q.Clients, page.Total, requested); err != nil {
+		unlock()
+		return nil, err
+	}
+
+	return unlock, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/domains"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/roles"
+)
+
+const purgeLimit = uint64(100)
+
+var _ domains.Purger = (*domainPurger)(nil)
+
+type domainPurger struct {
+	repo        Repository
+	cache       Cache
+	provisioner roles.Provisioner
+}
+
+// NewDomainPurger returns the purger that removes the clients of the purged
+// domain, together with their roles, policies and connections.
+func NewDomainPurger(repo Repository, cache Cache, provisioner roles.Provisioner) domains.Purger {
+	return &domainPurger{
+		repo:        repo,
+		cache:       cache,
+		provisioner: provisioner,
+	}
+}
+
+func (dp *domainPurger) PurgeDomain(ctx context.Context, domainID string) error {
+	for {
+		page, err := dp.repo.RetrieveAll(ctx, Page{Domain: domainID, Status: AllStatus, Limit: purgeLimit})
+		if err != nil {
+			return errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if len(page.Clients) == 0 {
+			return nil
+		}
+
+		ids := []string{}
+		filterDeletePolicies := []policies.Policy{}
+		for _, c := range page.Clients {
+			ids = append(ids, c.ID)
+			filterDeletePolicies = append(filterDeletePolicies,
+				policies.Policy{SubjectType: policies.ClientType, Subject: c.ID},
+				policies.Policy{ObjectType: policies.ClientType, Object: c.ID},
+			)
+			if err := dp.cache.Remove(ctx, c.ID); err != nil {
+				return errors.Wrap(svcerr.ErrRemoveEntity, err)
+			}
+		}
+		if err := dp.provisioner.RemoveEntitiesRoles(ctx, domainID, "", ids, filterDeletePolicies, nil); err != nil {
+			return errors.Wrap(svcerr.ErrDeletePolicies, err)
+		}
+		// Connections are removed by the database cascade.
+		if err := dp.repo.Delete(ctx, ids...); err != nil {
+			return errors.Wrap(svcerr.ErrRemoveEntity, err)
+		}
+	}
+}
@@ -22,6 +22,7 @@ const (
 	OpRemoveParentGroup
 	OpConnectToChannel
 	OpDisconnectFromChannel
+	OpMoveClient
 )
 
 var expectedOperations = []svcutil.Operation{
@@ -36,6 +37,7 @@ var expectedOperations = []svcutil.Operation{
 	OpRemoveParentGroup,
 	OpConnectToChannel,
 	OpDisconnectFromChannel,
+	OpMoveClient,
 }
 
 var operationNames = []string{
@@ -50,6 +52,7 @@ var operationNames = []string{
 	"OpRemoveParentGroup",
 	"OpConnectToChannel",
 	"OpDisconnectFromChannel",
+	"OpMoveClient",
 }
 
 func NewOperationPerm() svcutil.OperationPerm {
@@ -116,6 +119,7 @@ func NewOperationPermissionMap() map[svcutil.Operation]svcutil.Permission {
 		OpRemoveParentGroup:     setParentGroupPermission,
 		OpConnectToChannel:      connectToChannelPermission,
 		OpDisconnectFromChannel: connectToChannelPermission,
+		OpMoveClient:            deletePermission,
 	}
 	return opPerm
 }
@@ -12,9 +12,12 @@ import (
 	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
 	grpcGroupsV1 "github.com/absmach/supermq/api/grpc/groups/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/domains"
 	"github.com/absmach/supermq/pkg/authn"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	pkgGroups "github.com/absmach/supermq/pkg/groups"
 	"github.com/absmach/supermq/pkg/policies"
 	"github.com/absmach/supermq/pkg/roles"
 )
@@ -22,6 +25,7 @@ import (
 var (
 	errRollbackRepo   = errors.New("failed to rollback repo")
 	errSetParentGroup = errors.New("client already have parent")
+	errSameDomain     = errors.New("client already belongs to the domain")
 )
 var _ Service = (*service)(nil)
 
@@ -30,13 +34,15 @@ type service struct {
 	policy     policies.Service
 	channels   grpcChannelsV1.ChannelsServiceClient
 	groups     grpcGroupsV1.GroupsServiceClient
+	quotas     pkgDomains.Quotas
+	settings   pkgDomains.Settings
 	cache      Cache
 	idProvider smq.IDProvider
 	roles.ProvisionManageService
 }
 
 // NewService returns a new Clients service implementation.
-func NewService(repo Repository, policy policies.Service, cache Cache, channels grpcChannelsV1.ChannelsServiceClient, groups grpcGroupsV1.GroupsServiceClient, idProvider smq.IDProvider, sIDProvider smq.IDProvider, availableActions []roles.Action, builtInRoles map[roles.BuiltInRoleName][]roles.Action) (Service, error) {
+func NewService(repo Repository, policy policies.Service, cache Cache, channels grpcChannelsV1.ChannelsServiceClient, groups grpcGroupsV1.GroupsServiceClient, quotas pkgDomains.Quotas, settings pkgDomains.Settings, idProvider smq.IDProvider, sIDProvider smq.IDProvider, availableActions []roles.Action, builtInRoles map[roles.BuiltInRoleName][]roles.Action) (Service, error) {
 	rpms, err := roles.NewProvisionManageService(policies.ClientType, repo, policy, sIDProvider, availableActions, builtInRoles)
 	if err != nil {
 		return service{}, err
@@ -46,6 +52,8 @@ func NewService(repo Repository, policy policies.Service, cache Cache, channels
 		policy:                 policy,
 		channels:               channels,
 		groups:                 groups,
+		quotas:                 quotas,
+		settings:               settings,
 		cache:                  cache,
 		idProvider:             idProvider,
 		ProvisionManageService: rpms,
@@ -68,6 +76,8 @@ func (svc service) CreateClients(ctx context.Context, session authn.Session, cls
 				return []Client{}, []roles.RoleProvision{}, err
 			}
 			c.Credentials.Secret = key
+		} else if err := svc.checkSecret(ctx, session.DomainID, c.Credentials.Secret); err != nil {
+			return []Client{}, []roles.RoleProvision{}, err
 		}
 		if c.Status != DisabledStatus && c.Status != EnabledStatus {
 			return []Client{}, []roles.RoleProvision{}, svcerr.ErrInvalidStatus
@@ -77,7 +87,12 @@ func (svc service) CreateClients(ctx context.Context, session authn.Session, cls
 		clients = append(clients, c)
 	}
 
+	unlock, err := svc.lockQuota(ctx, session.DomainID, uint64(len(clients)))
+	if err != nil {
+		return []Client{}, []roles.RoleProvision{}, err
+	}
 	newClients, err := svc.repo.Save(ctx, clients...)
+	unlock()
 	if err != nil {
 		return []Client{}, []roles.RoleProvision{}, errors.Wrap(svcerr.ErrCreateEntity, err)
 	}
@@ -121,11 +136,22 @@ func (svc service) CreateClients(ctx context.Context, session authn.Session, cls
 	return newClients, nrps, nil
 }
 
-func (svc service) View(ctx context.Context, session authn.Session, id string) (Client, error) {
+func (svc service) View(ctx context.Context, session authn.Session, id string, effective bool) (Client, error) {
 	client, err := svc.repo.RetrieveByID(ctx, id)
 	if err != nil {
 		return Client{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
+	if effective && client.ParentGroupPath != "" {
+		mds, err := svc.repo.RetrievePathMetadata(ctx, client.ParentGroupPath)
+		if err != nil {
+			return Client{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		ancestors := make([]map[string]interface{}, len(mds))
+		for i, md := range mds {
+			ancestors[i] = md
+		}
+		client.Metadata = pkgGroups.InheritMetadata(ancestors, client.Metadata)
+	}
 	return client, nil
 }
 
@@ -184,6 +210,9 @@ func (svc service) UpdateTags(ctx context.Context, session authn.Session, cli Cl
 }
 
 func (svc service) UpdateSecret(ctx context.Context, session authn.Session, id, key string) (Client, error) {
+	if err := svc.checkSecret(ctx, session.DomainID, key); err != nil {
+		return Client{}, err
+	}
 	client := Client{
 		ID: id,
 		Credentials: Credentials{
@@ -373,6 +402,135 @@ func (svc service) Delete(ctx context.Context, session authn.Session, id string)
 	return nil
 }
 
+// Move moves the client to the target domain. The client is disconnected from
+// the channels and groups of channels and detached from the parent group of the
+// source domain.
+func (svc service) Move(ctx context.Context, session authn.Session, id, domainID string) (retClient Client, retErr error) {
+	cli, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return Client{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if cli.Domain == domainID {
+		return Client{}, errors.Wrap(svcerr.ErrMalformedEntity, errSameDomain)
+	}
+	unlock, err := svc.lockQuota(ctx, domainID, 1)
+	if err != nil {
+		return Client{}, err
+	}
+	defer unlock()
+
+	oldPolicies := []policies.Policy{
+		{
+			Domain:      cli.Domain,
+			SubjectType: policies.DomainType,
+			Subject:     cli.Domain,
+			Relation:    policies.DomainRelation,
+			ObjectType:  policies.ClientType,
+			Object:      id,
+		},
+	}
+	if cli.ParentGroup != "" {
+		oldPolicies = append(oldPolicies, policies.Policy{
+			Domain:      cli.Domain,
+			SubjectType: policies.GroupType,
+			Subject:     cli.ParentGroup,
+			Relation:    policies.ParentGroupRelation,
+			ObjectType:  policies.ClientType,
+			Object:      id,
+		})
+	}
+	newPolicies := []policies.Policy{
+		{
+			Domain:      domainID,
+			SubjectType: policies.DomainType,
+			Subject:     domainID,
+			Relation:    policies.DomainRelation,
+			ObjectType:  policies.ClientType,
+			Object:      id,
+		},
+	}
+
+	// The client can be related to a single domain only, so the old
+	// relations are removed before the new ones are added.
+	if err := svc.policy.DeletePolicies(ctx, oldPolicies); err != nil {
+		return Client{}, errors.Wrap(svcerr.ErrDeletePolicies, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.policy.AddPolicies(ctx, oldPolicies); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+	if err := svc.policy.AddPolicies(ctx, newPolicies); err != nil {
+		return Client{}, errors.Wrap(svcerr.ErrAddPolicies, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.policy.DeletePolicies(ctx, newPolicies); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+	if err := svc.MoveEntitiesRoles(ctx, cli.Domain, domainID, []string{id}); err != nil {
+		return Client{}, err
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.MoveEntitiesRoles(ctx, domainID, cli.Domain, []string{id}); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+
+	moved, err := svc.repo.ChangeDomain(ctx, Client{ID: id, Domain: domainID, UpdatedBy: session.UserID, UpdatedAt: time.Now()})
+	if err != nil {
+		return Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.restoreDomain(ctx, cli); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+	if err := svc.cache.Remove(ctx, id); err != nil {
+		return Client{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	// Connections are removed last, since they can't be restored once the
+	// move fails. Group connections are kept only by the channels service,
+	// so the channels service is called even if the client has no direct
+	// connections.
+	if _, err := svc.channels.RemoveClientConnections(ctx, &grpcChannelsV1.RemoveClientConnectionsReq{ClientId: id}); err != nil {
+		return Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	ok, err := svc.repo.DoesClientHaveConnections(ctx, id)
+	if err != nil {
+		return Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	if ok {
+		if err := svc.repo.RemoveClientConnections(ctx, id); err != nil {
+			return Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+
+	return moved, nil
+}
+
+// restoreDomain moves the client back to the domain of the given client and
+// restores its parent group.
+func (svc service) restoreDomain(ctx context.Context, cli Client) error {
+	if _, err := svc.repo.ChangeDomain(ctx, Client{ID: cli.ID, Domain: cli.Domain, UpdatedBy: cli.UpdatedBy, UpdatedAt: cli.UpdatedAt}); err != nil {
+		return err
+	}
+	if cli.ParentGroup == "" {
+		return nil
+	}
+
+	return svc.repo.SetParentGroup(ctx, Client{ID: cli.ID, ParentGroup: cli.ParentGroup, UpdatedBy: cli.UpdatedBy, UpdatedAt: cli.UpdatedAt})
+}
+
 func (svc service) changeClientStatus(ctx context.Context, session authn.Session, client Client) (Client, error) {
 	dbClient, err := svc.repo.RetrieveByID(ctx, client.ID)
 	if err != nil {
@@ -390,3 +548,45 @@ func (svc service) changeClientStatus(ctx context.Context, session authn.Session
 	}
 	return client, nil
 }
+
+// lockQuota locks the domain quotas and checks that the requested clients fit
+// into the domain clients quota. The returned unlock function is called once
+// the clients are saved, so the concurrent requests can't exceed the quota.
+func (svc service) lockQuota(ctx context.Context, domainID string, requested uint64) (func(), error) {
+	q, err := svc.quotas.RetrieveQuotas(ctx, domainID)
+	if err != nil {
+		return nil, err
+	}
+	if q.Clients == 0 {
+		return func() {}, nil
+	}
+	unlock, err := svc.quotas.LockQuotas(ctx, domainID)
+	if err != nil {
+		return nil, err
+	}
+	page, err := svc.repo.RetrieveAll(ctx, Page{Domain: domainID, Status: AllStatus, Limit: 1})
+	if err != nil {
+		unlock()
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if err := domains.CheckQuota(q.Clients, page.Total, requested); err != nil {
+		unlock()
+		return nil, err
+	}
+
+	return unlock, nil
+}
+
+// checkSecret checks the client secret set by the user against the domain
+// client secret policy.
+func (svc service) checkSecret(ctx context.Context, domainID, secret string) error {
+	s, err := svc.settings.RetrieveSettings(ctx, domainID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if err := s.CheckSecret(secret); err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	return nil
+}
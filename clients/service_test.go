@@ -14,9 +14,11 @@ import (
 	chmocks "github.com/absmach/supermq/channels/mocks"
 	"github.com/absmach/supermq/clients"
 	climocks "github.com/absmach/supermq/clients/mocks"
+	"github.com/absmach/supermq/domains"
 	gpmocks "github.com/absmach/supermq/groups/mocks"
 	"github.com/absmach/supermq/internal/testsutil"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
+	dmocks "github.com/absmach/supermq/pkg/domains/mocks"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
@@ -51,9 +53,15 @@ var (
 	repo         *climocks.Repository
 	chgRPCClient *chmocks.ChannelsServiceClient
 	gpgRPCClient *gpmocks.GroupsServiceClient
+	dquotas      *dmocks.Quotas
+	dsettings    *dmocks.Settings
 )
 
 func newService() clients.Service {
+	return newServiceWithSettings(domains.Settings{})
+}
+
+func newServiceWithSettings(settings domains.Settings) clients.Service {
 	pService = new(policymocks.Service)
 	cache = new(climocks.Cache)
 	idProvider := uuid.NewMock()
@@ -61,11 +69,15 @@ func newService() clients.Service {
 	repo = new(climocks.Repository)
 	chgRPCClient = new(chmocks.ChannelsServiceClient)
 	gpgRPCClient = new(gpmocks.GroupsServiceClient)
+	dquotas = new(dmocks.Quotas)
+	dquotas.On("RetrieveQuotas", mock.Anything, mock.Anything).Return(domains.Quotas{}, nil).Maybe()
+	dsettings = new(dmocks.Settings)
+	dsettings.On("RetrieveSettings", mock.Anything, mock.Anything).Return(settings, nil).Maybe()
 	availableActions := []roles.Action{}
 	builtInRoles := map[roles.BuiltInRoleName][]roles.Action{
 		clients.BuiltInRoleAdmin: availableActions,
 	}
-	tsv, _ := clients.NewService(repo, pService, cache, chgRPCClient, gpgRPCClient, idProvider, sidProvider, availableActions, builtInRoles)
+	tsv, _ := clients.NewService(repo, pService, cache, chgRPCClient, gpgRPCClient, dquotas, dsettings, idProvider, sidProvider, availableActions, builtInRoles)
 	return tsv
 }
 
@@ -303,22 +315,144 @@ func TestCreateClients(t *testing.T) {
 	}
 }
 
+func TestCreateClientsQuota(t *testing.T) {
+	svc := newService()
+	dquotas.ExpectedCalls = nil
+
+	cases := []struct {
+		desc           string
+		clients        []clients.Client
+		quotas         domains.Quotas
+		quotasErr      error
+		lockErr        error
+		total          uint64
+		retrieveAllErr error
+		err            error
+	}{
+		{
+			desc:    "create clients within quota",
+			clients: []clients.Client{client},
+			quotas:  domains.Quotas{Clients: 2},
+			total:   1,
+			err:     nil,
+		},
+		{
+			desc:    "create clients exceeding quota",
+			clients: []clients.Client{client, client},
+			quotas:  domains.Quotas{Clients: 2},
+			total:   1,
+			err:     svcerr.ErrQuotaExceeded,
+		},
+		{
+			desc:    "create clients with quota already reached",
+			clients: []clients.Client{client},
+			quotas:  domains.Quotas{Clients: 1},
+			total:   1,
+			err:     svcerr.ErrQuotaExceeded,
+		},
+		{
+			desc:      "create clients with failed to retrieve quotas",
+			clients:   []clients.Client{client},
+			quotasErr: svcerr.ErrViewEntity,
+			err:       svcerr.ErrViewEntity,
+		},
+		{
+			desc:           "create clients with failed to count clients",
+			clients:        []clients.Client{client},
+			quotas:         domains.Quotas{Clients: 2},
+			retrieveAllErr: repoerr.ErrViewEntity,
+			err:            svcerr.ErrViewEntity,
+		},
+		{
+			desc:    "create clients with failed to lock quotas",
+			clients: []clients.Client{client},
+			quotas:  domains.Quotas{Clients: 2},
+			lockErr: svcerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		unlocked := false
+		quotasCall := dquotas.On("RetrieveQuotas", context.Background(), validID).Return(tc.quotas, tc.quotasErr)
+		lockCall := dquotas.On("LockQuotas", context.Background(), validID).Return(func() { unlocked = true }, tc.lockErr)
+		repoCall := repo.On("RetrieveAll", context.Background(), mock.Anything).Return(clients.ClientsPage{Page: clients.Page{Total: tc.total}}, tc.retrieveAllErr)
+		repoCall1 := repo.On("Save", context.Background(), mock.Anything).Return(tc.clients, nil)
+		policyCall := pService.On("AddPolicies", context.Background(), mock.Anything).Return(nil)
+		repoCall2 := repo.On("AddRoles", context.Background(), mock.Anything).Return([]roles.RoleProvision{}, nil)
+		_, _, err := svc.CreateClients(context.Background(), smqauthn.Session{DomainID: validID}, tc.clients...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.quotas.Clients > 0 && tc.lockErr == nil {
+			assert.True(t, unlocked, "expected domain quotas to be unlocked")
+		}
+		quotasCall.Unset()
+		lockCall.Unset()
+		repoCall.Unset()
+		repoCall1.Unset()
+		policyCall.Unset()
+		repoCall2.Unset()
+	}
+}
+
 func TestViewClient(t *testing.T) {
 	svc := newService()
 
+	parentPath := testsutil.GenerateUUID(t) + "." + testsutil.GenerateUUID(t)
+	nestedClient := client
+	nestedClient.ParentGroupPath = parentPath
+	nestedClient.Metadata = clients.Metadata{"firmware": "beta", "location": map[string]interface{}{"room": "101"}}
+	effectiveClient := nestedClient
+	effectiveClient.Metadata = clients.Metadata{
+		"firmware": "beta",
+		"timezone": "UTC",
+		"location": map[string]interface{}{"building": "A", "room": "101"},
+	}
+	pathMetadata := []clients.Metadata{
+		{"firmware": "stable", "timezone": "UTC"},
+		{"location": map[string]interface{}{"building": "A"}},
+	}
+
 	cases := []struct {
-		desc        string
-		clientID    string
-		response    clients.Client
-		retrieveErr error
-		err         error
+		desc            string
+		clientID        string
+		effective       bool
+		response        clients.Client
+		retrieveErr     error
+		pathMetadata    []clients.Metadata
+		pathMetadataErr error
+		resp            clients.Client
+		err             error
 	}{
 		{
 			desc:     "view client successfully",
 			response: client,
 			clientID: client.ID,
+			resp:     client,
 			err:      nil,
 		},
+		{
+			desc:     "view client with raw metadata successfully",
+			response: nestedClient,
+			clientID: nestedClient.ID,
+			resp:     nestedClient,
+		},
+		{
+			desc:         "view client with effective metadata successfully",
+			response:     nestedClient,
+			clientID:     nestedClient.ID,
+			effective:    true,
+			pathMetadata: pathMetadata,
+			resp:         effectiveClient,
+		},
+		{
+			desc:            "view client with effective metadata with failed to retrieve path metadata",
+			response:        nestedClient,
+			clientID:        nestedClient.ID,
+			effective:       true,
+			pathMetadataErr: repoerr.ErrViewEntity,
+			resp:            clients.Client{},
+			err:             svcerr.ErrViewEntity,
+		},
 		{
 			desc:     "view client with an invalid token",
 			response: clients.Client{},
@@ -341,10 +475,16 @@ func TestViewClient(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		repoCall1 := repo.On("RetrieveByID", context.Background(), mock.Anything).Return(tc.response, tc.err)
-		rClient, err := svc.View(context.Background(), smqauthn.Session{}, tc.clientID)
+		repoCall := repo.On("RetrieveByID", context.Background(), mock.Anything).Return(tc.response, tc.retrieveErr)
+		repoCall1 := repo.On("RetrievePathMetadata", context.Background(), parentPath).Return(tc.pathMetadata, tc.pathMetadataErr)
+		if tc.retrieveErr == nil && tc.err != nil && tc.pathMetadataErr == nil {
+			repoCall.Unset()
+			repoCall = repo.On("RetrieveByID", context.Background(), mock.Anything).Return(tc.response, tc.err)
+		}
+		rClient, err := svc.View(context.Background(), smqauthn.Session{}, tc.clientID, tc.effective)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		assert.Equal(t, tc.response, rClient, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, rClient))
+		assert.Equal(t, tc.resp, rClient, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, rClient))
+		repoCall.Unset()
 		repoCall1.Unset()
 	}
 }
@@ -673,6 +813,28 @@ func TestUpdateSecret(t *testing.T) {
 	}
 }
 
+func TestSecretPolicy(t *testing.T) {
+	svc := newServiceWithSettings(domains.Settings{ClientSecretPolicy: domains.SecretPolicy{MinLength: 16}})
+
+	session := smqauthn.Session{UserID: validID, DomainID: validID}
+
+	_, _, err := svc.CreateClients(context.Background(), session, clients.Client{
+		Credentials: clients.Credentials{Secret: "short"},
+		Status:      clients.EnabledStatus,
+	})
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("create client with short secret: expected %s got %s\n", svcerr.ErrMalformedEntity, err))
+
+	_, err = svc.UpdateSecret(context.Background(), session, client.ID, "short")
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("update client with short secret: expected %s got %s\n", svcerr.ErrMalformedEntity, err))
+
+	updated := clients.Client{ID: client.ID, Credentials: clients.Credentials{Secret: "long-enough-secret"}}
+	repoCall := repo.On("UpdateSecret", context.Background(), mock.Anything).Return(updated, nil)
+	res, err := svc.UpdateSecret(context.Background(), session, client.ID, updated.Credentials.Secret)
+	assert.Nil(t, err, fmt.Sprintf("update client with valid secret: expected nil got %s\n", err))
+	assert.Equal(t, updated, res)
+	repoCall.Unset()
+}
+
 func TestEnable(t *testing.T) {
 	svc := newService()
 
@@ -1178,3 +1340,142 @@ func TestRemoveParentGroup(t *testing.T) {
 		policyCall1.Unset()
 	}
 }
+
+func TestMove(t *testing.T) {
+	svc := newService()
+
+	domainID := testsutil.GenerateUUID(t)
+	sourceClient := client
+	sourceClient.Domain = validID
+	sourceClient.ParentGroup = testsutil.GenerateUUID(t)
+	movedClient := client
+	movedClient.Domain = domainID
+	memberRoles := []roles.EntityMemberRole{{EntityID: client.ID, MemberID: validID, RoleID: testsutil.GenerateUUID(t)}}
+
+	cases := []struct {
+		desc                 string
+		clientID             string
+		domainID             string
+		session              smqauthn.Session
+		retrieveByIDResp     clients.Client
+		retrieveByIDErr      error
+		hasConnections       bool
+		removeConnectionsErr error
+		addPoliciesErr       error
+		deletePoliciesErr    error
+		changeDomainResp     clients.Client
+		changeDomainErr      error
+		removeCacheErr       error
+		connectionsRemoved   bool
+		resp                 clients.Client
+		err                  error
+	}{
+		{
+			desc:               "move client successfully",
+			clientID:           client.ID,
+			domainID:           domainID,
+			session:            smqauthn.Session{UserID: validID, DomainID: validID, DomainUserID: validID + "_" + validID},
+			retrieveByIDResp:   sourceClient,
+			hasConnections:     true,
+			changeDomainResp:   movedClient,
+			connectionsRemoved: true,
+			resp:               movedClient,
+			err:                nil,
+		},
+		{
+			desc:             "move client to the same domain",
+			clientID:         client.ID,
+			domainID:         validID,
+			session:          smqauthn.Session{UserID: validID, DomainID: validID, DomainUserID: validID + "_" + validID},
+			retrieveByIDResp: sourceClient,
+			err:              svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:            "move non-existing client",
+			clientID:        wrongID,
+			domainID:        domainID,
+			session:         smqauthn.Session{UserID: validID, DomainID: validID, DomainUserID: validID + "_" + validID},
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrViewEntity,
+		},
+		{
+			desc:                 "move client with failed to remove connections",
+			clientID:             client.ID,
+			domainID:             domainID,
+			session:              smqauthn.Session{UserID: validID, DomainID: validID, DomainUserID: validID + "_" + validID},
+			retrieveByIDResp:     sourceClient,
+			hasConnections:       true,
+			changeDomainResp:     movedClient,
+			removeConnectionsErr: svcerr.ErrRemoveEntity,
+			err:                  svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:             "move client with failed to add policies",
+			clientID:         client.ID,
+			domainID:         domainID,
+			session:          smqauthn.Session{UserID: validID, DomainID: validID, DomainUserID: validID + "_" + validID},
+			retrieveByIDResp: sourceClient,
+			addPoliciesErr:   svcerr.ErrAuthorization,
+			err:              svcerr.ErrAddPolicies,
+		},
+		{
+			desc:              "move client with failed to delete policies",
+			clientID:          client.ID,
+			domainID:          domainID,
+			session:           smqauthn.Session{UserID: validID, DomainID: validID, DomainUserID: validID + "_" + validID},
+			retrieveByIDResp:  sourceClient,
+			deletePoliciesErr: svcerr.ErrAuthorization,
+			err:               svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:             "move client with failed to change domain",
+			clientID:         client.ID,
+			domainID:         domainID,
+			session:          smqauthn.Session{UserID: validID, DomainID: validID, DomainUserID: validID + "_" + validID},
+			retrieveByIDResp: sourceClient,
+			hasConnections:   true,
+			changeDomainErr:  repoerr.ErrNotFound,
+			err:              svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:             "move client with failed to remove client from cache",
+			clientID:         client.ID,
+			domainID:         domainID,
+			session:          smqauthn.Session{UserID: validID, DomainID: validID, DomainUserID: validID + "_" + validID},
+			retrieveByIDResp: sourceClient,
+			changeDomainResp: movedClient,
+			removeCacheErr:   svcerr.ErrRemoveEntity,
+			err:              svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByID", context.Background(), tc.clientID).Return(tc.retrieveByIDResp, tc.retrieveByIDErr)
+			repoCall1 := repo.On("DoesClientHaveConnections", context.Background(), tc.clientID).Return(tc.hasConnections, nil)
+			channelsCall := chgRPCClient.On("RemoveClientConnections", context.Background(), &grpcChannelsV1.RemoveClientConnectionsReq{ClientId: tc.clientID}).Return(&grpcChannelsV1.RemoveClientConnectionsRes{}, tc.removeConnectionsErr)
+			removed := false
+			repoCall2 := repo.On("RemoveClientConnections", context.Background(), tc.clientID).Return(nil).Run(func(mock.Arguments) { removed = true })
+			policyCall := pService.On("AddPolicies", context.Background(), mock.Anything).Return(tc.addPoliciesErr)
+			policyCall1 := pService.On("DeletePolicies", context.Background(), mock.Anything).Return(tc.deletePoliciesErr)
+			repoCall3 := repo.On("RetrieveEntitiesRolesActionsMembers", context.Background(), []string{tc.clientID}).Return([]roles.EntityActionRole{}, memberRoles, nil)
+			repoCall4 := repo.On("ChangeDomain", context.Background(), mock.Anything).Return(tc.changeDomainResp, tc.changeDomainErr)
+			repoCall5 := repo.On("SetParentGroup", context.Background(), mock.Anything).Return(nil)
+			cacheCall := cache.On("Remove", context.Background(), tc.clientID).Return(tc.removeCacheErr)
+			cli, err := svc.Move(context.Background(), tc.session, tc.clientID, tc.domainID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, cli, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, cli))
+			assert.Equal(t, tc.connectionsRemoved, removed, fmt.Sprintf("%s: expected connections removed %t got %t\n", tc.desc, tc.connectionsRemoved, removed))
+			repoCall.Unset()
+			repoCall1.Unset()
+			channelsCall.Unset()
+			repoCall2.Unset()
+			policyCall.Unset()
+			policyCall1.Unset()
+			repoCall3.Unset()
+			repoCall4.Unset()
+			repoCall5.Unset()
+			cacheCall.Unset()
+		})
+	}
+}
@@ -40,10 +40,13 @@ func (tm *tracingMiddleware) CreateClients(ctx context.Context, session authn.Se
 }
 
 // View traces the "View" operation of the wrapped clients.Service.
-func (tm *tracingMiddleware) View(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
-	ctx, span := tm.tracer.Start(ctx, "svc_view_client", trace.WithAttributes(attribute.String("id", id)))
+func (tm *tracingMiddleware) View(ctx context.Context, session authn.Session, id string, effective bool) (clients.Client, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_client", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.Bool("effective", effective),
+	))
 	defer span.End()
-	return tm.svc.View(ctx, session, id)
+	return tm.svc.View(ctx, session, id, effective)
 }
 
 // ListClients traces the "ListClients" operation of the wrapped clients.Service.
@@ -123,3 +126,12 @@ func (tm *tracingMiddleware) RemoveParentGroup(ctx context.Context, session auth
 	defer span.End()
 	return tm.svc.RemoveParentGroup(ctx, session, id)
 }
+
+func (tm *tracingMiddleware) Move(ctx context.Context, session authn.Session, id, domainID string) (clients.Client, error) {
+	ctx, span := tm.tracer.Start(ctx, "move_client", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.String("domain_id", domainID),
+	))
+	defer span.End()
+	return tm.svc.Move(ctx, session, id, domainID)
+}
@@ -22,12 +22,16 @@ import (
 	tokengrpcapi "github.com/absmach/supermq/auth/api/grpc/token"
 	httpapi "github.com/absmach/supermq/auth/api/http"
 	"github.com/absmach/supermq/auth/bolt"
+	"github.com/absmach/supermq/auth/events"
+	"github.com/absmach/supermq/auth/events/consumer"
 	"github.com/absmach/supermq/auth/hasher"
 	"github.com/absmach/supermq/auth/jwt"
 	apostgres "github.com/absmach/supermq/auth/postgres"
 	"github.com/absmach/supermq/auth/tracing"
 	boltclient "github.com/absmach/supermq/internal/clients/bolt"
 	smqlog "github.com/absmach/supermq/logger"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	"github.com/absmach/supermq/pkg/jaeger"
 	"github.com/absmach/supermq/pkg/policies/spicedb"
 	pgclient "github.com/absmach/supermq/pkg/postgres"
@@ -43,6 +47,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	"go.etcd.io/bbolt"
 	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -50,14 +55,20 @@ import (
 )
 
 const (
-	svcName        = "auth"
-	envPrefixHTTP  = "SMQ_AUTH_HTTP_"
-	envPrefixGrpc  = "SMQ_AUTH_GRPC_"
-	envPrefixDB    = "SMQ_AUTH_DB_"
-	envPrefixPATDB = "SMQ_AUTH_PAT_DB_"
-	defDB          = "auth"
-	defSvcHTTPPort = "8189"
-	defSvcGRPCPort = "8181"
+	svcName         = "auth"
+	envPrefixHTTP   = "SMQ_AUTH_HTTP_"
+	envPrefixGrpc   = "SMQ_AUTH_GRPC_"
+	envPrefixDB     = "SMQ_AUTH_DB_"
+	envPrefixPATDB  = "SMQ_AUTH_PAT_DB_"
+	defDB           = "auth"
+	defSvcHTTPPort  = "8189"
+	defSvcGRPCPort  = "8181"
+	defJWTAlgorithm = "HS512"
+	jwtKeysRefresh  = time.Minute
+
+	boltPATRepository     = "bolt"
+	postgresPATRepository = "postgres"
+	migratePATsCmd        = "migrate-pats"
 )
 
 type config struct {
@@ -75,6 +86,15 @@ type config struct {
 	SpicedbPreSharedKey string        `env:"SMQ_SPICEDB_PRE_SHARED_KEY"       envDefault:"12345678"`
 	TraceRatio          float64       `env:"SMQ_JAEGER_TRACE_RATIO"           envDefault:"1.0"`
 	ESURL               string        `env:"SMQ_ES_URL"                       envDefault:"nats://localhost:4222"`
+	JWTAlgorithm        string        `env:"SMQ_AUTH_JWT_ALGORITHM"           envDefault:"HS512"`
+	JWTKeysDir          string        `env:"SMQ_AUTH_JWT_KEYS_DIR"            envDefault:""`
+	JWTKeyRotation      time.Duration `env:"SMQ_AUTH_JWT_KEY_ROTATION"        envDefault:"720h"`
+	JWTKeyOverlap       time.Duration `env:"SMQ_AUTH_JWT_KEY_OVERLAP"         envDefault:"24h"`
+	PATUnusedExpiry     time.Duration `env:"SMQ_AUTH_PAT_UNUSED_EXPIRY"       envDefault:"0"`
+	PATExpiryInterval   time.Duration `env:"SMQ_AUTH_PAT_EXPIRY_INTERVAL"     envDefault:"24h"`
+	PATRepository       string        `env:"SMQ_AUTH_PAT_REPOSITORY"          envDefault:"bolt"`
+	SessionPurge        time.Duration `env:"SMQ_AUTH_SESSION_PURGE_INTERVAL"  envDefault:"1h"`
+	ESConsumerName      string        `env:"SMQ_AUTH_EVENT_CONSUMER"          envDefault:"auth"`
 }
 
 func main() {
@@ -116,6 +136,36 @@ func main() {
 	}
 	defer db.Close()
 
+	migrate := len(os.Args) > 1 && os.Args[1] == migratePATsCmd
+	var (
+		bClient      *bbolt.DB
+		boltDBConfig = boltclient.Config{}
+	)
+	if cfg.PATRepository == boltPATRepository || migrate {
+		if err := env.ParseWithOptions(&boltDBConfig, env.Options{Prefix: envPrefixPATDB}); err != nil {
+			logger.Error(fmt.Sprintf("failed to parse bolt db config : %s\n", err.Error()))
+			exitCode = 1
+			return
+		}
+
+		bClient, err = boltclient.Connect(boltDBConfig, bolt.Init)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to connect to bolt db : %s\n", err.Error()))
+			exitCode = 1
+			return
+		}
+		defer bClient.Close()
+	}
+
+	if migrate {
+		database := pgclient.NewDatabase(db, dbConfig, noop.NewTracerProvider().Tracer(svcName))
+		if err := migratePATs(ctx, bClient, boltDBConfig.Bucket, apostgres.NewPATSRepository(database), logger); err != nil {
+			logger.Error(fmt.Sprintf("failed to migrate PATs: %s", err))
+			exitCode = 1
+		}
+		return
+	}
+
 	tp, err := jaeger.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
@@ -136,22 +186,34 @@ func main() {
 		return
 	}
 
-	boltDBConfig := boltclient.Config{}
-	if err := env.ParseWithOptions(&boltDBConfig, env.Options{Prefix: envPrefixPATDB}); err != nil {
-		logger.Error(fmt.Sprintf("failed to parse bolt db config : %s\n", err.Error()))
+	tokenizer, err := newTokenizer(cfg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create tokenizer: %s", err))
 		exitCode = 1
 		return
 	}
+	if km, ok := tokenizer.(jwt.KeyManager); ok {
+		g.Go(func() error {
+			return refreshKeys(ctx, km, cfg.JWTKeyRotation, logger)
+		})
+	}
 
-	bClient, err := boltclient.Connect(boltDBConfig, bolt.Init)
+	svc, err := newService(ctx, db, tracer, cfg, dbConfig, logger, spicedbclient, bClient, boltDBConfig, tokenizer)
 	if err != nil {
-		logger.Error(fmt.Sprintf("failed to connect to bolt db : %s\n", err.Error()))
+		logger.Error(fmt.Sprintf("failed to create service: %s", err))
 		exitCode = 1
 		return
 	}
-	defer bClient.Close()
-
-	svc := newService(ctx, db, tracer, cfg, dbConfig, logger, spicedbclient, bClient, boltDBConfig)
+	if cfg.PATUnusedExpiry > 0 {
+		g.Go(func() error {
+			return expireUnusedPATs(ctx, svc, cfg.PATUnusedExpiry, cfg.PATExpiryInterval, logger)
+		})
+	}
+	if cfg.SessionPurge > 0 {
+		g.Go(func() error {
+			return purgeExpiredSessions(ctx, svc, cfg.SessionPurge, logger)
+		})
+	}
 
 	grpcServerConfig := server.Config{Port: defSvcGRPCPort}
 	if err := env.ParseWithOptions(&grpcServerConfig, env.Options{Prefix: envPrefixGrpc}); err != nil {
@@ -231,23 +293,129 @@ func initSchema(ctx context.Context, client *authzed.ClientWithExperimental, sch
 	return nil
 }
 
-func newService(_ context.Context, db *sqlx.DB, tracer trace.Tracer, cfg config, dbConfig pgclient.Config, logger *slog.Logger, spicedbClient *authzed.ClientWithExperimental, bClient *bbolt.DB, bConfig boltclient.Config) auth.Service {
+func newService(ctx context.Context, db *sqlx.DB, tracer trace.Tracer, cfg config, dbConfig pgclient.Config, logger *slog.Logger, spicedbClient *authzed.ClientWithExperimental, bClient *bbolt.DB, bConfig boltclient.Config, t auth.Tokenizer) (auth.Service, error) {
 	database := pgclient.NewDatabase(db, dbConfig, tracer)
 	keysRepo := apostgres.New(database)
-	patsRepo := bolt.NewPATSRepository(bClient, bConfig.Bucket)
+	var patsRepo auth.PATSRepository
+	switch cfg.PATRepository {
+	case boltPATRepository:
+		patsRepo = bolt.NewPATSRepository(bClient, bConfig.Bucket)
+	case postgresPATRepository:
+		patsRepo = apostgres.NewPATSRepository(database)
+	default:
+		return nil, fmt.Errorf("unsupported PAT repository %q", cfg.PATRepository)
+	}
+	sessionsRepo := apostgres.NewSessionRepository(database)
+	patUsageRepo := apostgres.NewPATUsageRepository(database)
 	hasher := hasher.New()
 	idProvider := uuid.New()
 
 	pEvaluator := spicedb.NewPolicyEvaluator(spicedbClient, logger)
 	pService := spicedb.NewPolicyService(spicedbClient, logger)
 
-	t := jwt.New([]byte(cfg.SecretKey))
+	patNotifier, err := events.NewPATNotifier(ctx, cfg.ESURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := consumer.DomainsEventsSubscribe(ctx, patsRepo, cfg.ESURL, cfg.ESConsumerName, logger); err != nil {
+		return nil, err
+	}
 
-	svc := auth.New(keysRepo, patsRepo, hasher, idProvider, t, pEvaluator, pService, cfg.AccessDuration, cfg.RefreshDuration, cfg.InvitationDuration)
+	svc := auth.New(keysRepo, patsRepo, sessionsRepo, patUsageRepo, patNotifier, hasher, idProvider, t, pEvaluator, pService, cfg.AccessDuration, cfg.RefreshDuration, cfg.InvitationDuration)
 	svc = api.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics("auth", "api")
 	svc = api.MetricsMiddleware(svc, counter, latency)
 	svc = tracing.New(svc, tracer)
 
-	return svc
+	return svc, nil
+}
+
+// migratePATs copies all PATs from the Bolt store to Postgres.
+// PATs that already exist in Postgres are skipped, so it is safe to re-run.
+func migratePATs(ctx context.Context, bClient *bbolt.DB, bucket string, repo auth.PATSRepository, logger *slog.Logger) error {
+	var migrated, skipped int
+	err := bolt.ExportPATs(ctx, bClient, bucket, func(pat auth.PAT) error {
+		if err := repo.Save(ctx, pat); err != nil {
+			if errors.Contains(err, repoerr.ErrConflict) {
+				skipped++
+				return nil
+			}
+			return fmt.Errorf("failed to save PAT %s: %w", pat.ID, err)
+		}
+		migrated++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	logger.Info(fmt.Sprintf("migrated %d PATs to postgres, skipped %d existing", migrated, skipped))
+
+	return nil
+}
+
+func newTokenizer(cfg config) (auth.Tokenizer, error) {
+	if cfg.JWTAlgorithm == defJWTAlgorithm {
+		return jwt.New([]byte(cfg.SecretKey)), nil
+	}
+
+	return jwt.NewKeyManager(cfg.JWTAlgorithm, cfg.JWTKeysDir, cfg.JWTKeyOverlap)
+}
+
+func refreshKeys(ctx context.Context, km jwt.KeyManager, rotation time.Duration, logger *slog.Logger) error {
+	ticker := time.NewTicker(jwtKeysRefresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := km.Refresh(rotation); err != nil {
+				logger.Error(fmt.Sprintf("failed to refresh jwt signing keys: %s", err))
+			}
+		}
+	}
+}
+
+func expireUnusedPATs(ctx context.Context, svc auth.Service, unusedFor, interval time.Duration, logger *slog.Logger) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			expired, err := svc.ExpireUnusedPATs(ctx, unusedFor)
+			if err != nil {
+				logger.Error(fmt.Sprintf("failed to expire unused PATs: %s", err))
+				continue
+			}
+			if expired > 0 {
+				logger.Info(fmt.Sprintf("Expired %d unused PATs", expired))
+			}
+		}
+	}
+}
+
+func purgeExpiredSessions(ctx context.Context, svc auth.Service, interval time.Duration, logger *slog.Logger) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			purged, err := svc.PurgeExpiredSessions(ctx)
+			if err != nil {
+				logger.Error(fmt.Sprintf("failed to purge expired sessions: %s", err))
+				continue
+			}
+			if purged > 0 {
+				logger.Info(fmt.Sprintf("Purged %d expired sessions", purged))
+			}
+		}
+	}
 }
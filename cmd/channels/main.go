@@ -11,6 +11,7 @@ import (
 	"log/slog"
 	"net/url"
 	"os"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/supermq"
@@ -25,14 +26,18 @@ import (
 	"github.com/absmach/supermq/channels/postgres"
 	pChannels "github.com/absmach/supermq/channels/private"
 	"github.com/absmach/supermq/channels/tracing"
+	"github.com/absmach/supermq/domains"
 	dpostgres "github.com/absmach/supermq/domains/postgres"
 	gpostgres "github.com/absmach/supermq/groups/postgres"
 	smqlog "github.com/absmach/supermq/logger"
 	authsvcAuthn "github.com/absmach/supermq/pkg/authn/authsvc"
 	smqauthz "github.com/absmach/supermq/pkg/authz"
 	authsvcAuthz "github.com/absmach/supermq/pkg/authz/authsvc"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
 	dconsumer "github.com/absmach/supermq/pkg/domains/events/consumer"
 	domainsAuthz "github.com/absmach/supermq/pkg/domains/grpcclient"
+	"github.com/absmach/supermq/pkg/domains/quotas"
+	"github.com/absmach/supermq/pkg/domains/settings"
 	gconsumer "github.com/absmach/supermq/pkg/groups/events/consumer"
 	"github.com/absmach/supermq/pkg/grpcclient"
 	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
@@ -75,17 +80,21 @@ const (
 )
 
 type config struct {
-	LogLevel            string  `env:"SMQ_CHANNELS_LOG_LEVEL"           envDefault:"info"`
-	InstanceID          string  `env:"SMQ_CHANNELS_INSTANCE_ID"         envDefault:""`
-	JaegerURL           url.URL `env:"SMQ_JAEGER_URL"                   envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry       bool    `env:"SMQ_SEND_TELEMETRY"               envDefault:"true"`
-	ESURL               string  `env:"SMQ_ES_URL"                       envDefault:"nats://localhost:4222"`
-	ESConsumerName      string  `env:"SMQ_CHANNELS_EVENT_CONSUMER"      envDefault:"channels"`
-	TraceRatio          float64 `env:"SMQ_JAEGER_TRACE_RATIO"           envDefault:"1.0"`
-	SpicedbHost         string  `env:"SMQ_SPICEDB_HOST"                 envDefault:"localhost"`
-	SpicedbPort         string  `env:"SMQ_SPICEDB_PORT"                 envDefault:"50051"`
-	SpicedbPreSharedKey string  `env:"SMQ_SPICEDB_PRE_SHARED_KEY"       envDefault:"12345678"`
-	SpicedbSchemaFile   string  `env:"SMQ_SPICEDB_SCHEMA_FILE"          envDefault:"schema.zed"`
+	LogLevel            string        `env:"SMQ_CHANNELS_LOG_LEVEL"           envDefault:"info"`
+	InstanceID          string        `env:"SMQ_CHANNELS_INSTANCE_ID"         envDefault:""`
+	JaegerURL           url.URL       `env:"SMQ_JAEGER_URL"                   envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry       bool          `env:"SMQ_SEND_TELEMETRY"               envDefault:"true"`
+	ESURL               string        `env:"SMQ_ES_URL"                       envDefault:"nats://localhost:4222"`
+	ESConsumerName      string        `env:"SMQ_CHANNELS_EVENT_CONSUMER"      envDefault:"channels"`
+	TraceRatio          float64       `env:"SMQ_JAEGER_TRACE_RATIO"           envDefault:"1.0"`
+	SpicedbHost         string        `env:"SMQ_SPICEDB_HOST"                 envDefault:"localhost"`
+	SpicedbPort         string        `env:"SMQ_SPICEDB_PORT"                 envDefault:"50051"`
+	SpicedbPreSharedKey string        `env:"SMQ_SPICEDB_PRE_SHARED_KEY"       envDefault:"12345678"`
+	SpicedbSchemaFile   string        `env:"SMQ_SPICEDB_SCHEMA_FILE"          envDefault:"schema.zed"`
+	QuotaRefresh        time.Duration `env:"SMQ_CHANNELS_QUOTA_REFRESH"   envDefault:"1m"`
+	SettingsRefresh     time.Duration `env:"SMQ_CHANNELS_SETTINGS_REFRESH" envDefault:"1m"`
+	ClientGroupRefresh  time.Duration `env:"SMQ_CHANNELS_CLIENT_GROUP_REFRESH" envDefault:"1m"`
+	ExpiredInterval     time.Duration `env:"SMQ_CHANNELS_EXPIRED_CONNECTIONS_INTERVAL" envDefault:"1h"`
 }
 
 func main() {
@@ -178,7 +187,7 @@ func main() {
 		exitCode = 1
 		return
 	}
-	domAuthz, _, domainsHandler, err := domainsAuthz.NewAuthorization(ctx, domsGrpcCfg)
+	domAuthz, domainsClient, domainsHandler, err := domainsAuthz.NewAuthorization(ctx, domsGrpcCfg)
 	if err != nil {
 		logger.Error(err.Error())
 		exitCode = 1
@@ -225,17 +234,25 @@ func main() {
 	defer groupsHandler.Close()
 	logger.Info("Groups gRPC client successfully connected to groups gRPC server " + groupsHandler.Secure())
 
-	svc, psvc, err := newService(ctx, db, dbConfig, authz, policyEvaluator, policyService, cfg, tracer, clientsClient, groupsClient, logger)
+	ddatabase := pg.NewDatabase(db, dbConfig, tracer)
+	drepo := dpostgres.New(ddatabase)
+
+	clientGroups := pChannels.NewClientGroups(clientsClient, cfg.ClientGroupRefresh)
+	svc, psvc, err := newService(ctx, db, dbConfig, authz, policyEvaluator, policyService, cfg, tracer, clientsClient, groupsClient, quotas.NewReplica(drepo), domainsAuthz.NewSettings(domainsClient), clientGroups, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create services: %s", err))
 		exitCode = 1
 		return
 	}
 
-	ddatabase := pg.NewDatabase(db, dbConfig, tracer)
-	drepo := dpostgres.New(ddatabase)
+	purger, err := newDomainPurger(db, dbConfig, policyService, tracer)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create domain purger : %s", err))
+		exitCode = 1
+		return
+	}
 
-	if err := dconsumer.DomainsEventsSubscribe(ctx, drepo, cfg.ESURL, cfg.ESConsumerName, logger); err != nil {
+	if err := dconsumer.DomainsEventsSubscribe(ctx, drepo, cfg.ESURL, cfg.ESConsumerName, logger, purger); err != nil {
 		logger.Error(fmt.Sprintf("failed to create domains event store : %s", err))
 		exitCode = 1
 		return
@@ -250,6 +267,13 @@ func main() {
 		return
 	}
 
+	// Every instance keeps its own client parent groups, so every instance consumes the clients events.
+	if err := pChannels.ClientsEventsSubscribe(ctx, clientGroups, cfg.ESURL, fmt.Sprintf("%s-%s", cfg.ESConsumerName, cfg.InstanceID), logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to create clients event store : %s", err))
+		exitCode = 1
+		return
+	}
+
 	grpcServerConfig := server.Config{Port: defSvcGRPCPort}
 	if err := env.ParseWithOptions(&grpcServerConfig, env.Options{Prefix: envPrefixGRPC}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load %s gRPC server configuration : %s", svcName, err))
@@ -297,7 +321,7 @@ func main() {
 
 func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authz smqauthz.Authorization,
 	pe policies.Evaluator, ps policies.Service, cfg config, tracer trace.Tracer, clientsClient grpcClientsV1.ClientsServiceClient,
-	groupsClient grpcGroupsV1.GroupsServiceClient, logger *slog.Logger,
+	groupsClient grpcGroupsV1.GroupsServiceClient, dq pkgDomains.Quotas, ds pkgDomains.Settings, clientGroups *pChannels.ClientGroups, logger *slog.Logger,
 ) (channels.Service, pChannels.Service, error) {
 	database := pg.NewDatabase(db, dbConfig, tracer)
 	repo := postgres.NewRepository(database)
@@ -313,7 +337,7 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth
 		return nil, nil, err
 	}
 
-	svc, err := channels.New(repo, ps, idp, clientsClient, groupsClient, sidp, availableActions, buildInRoles)
+	svc, err := channels.New(repo, ps, idp, clientsClient, groupsClient, dq, sidp, availableActions, buildInRoles)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -334,10 +358,26 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth
 	}
 	svc = middleware.LoggingMiddleware(svc, logger)
 
-	psvc := pChannels.New(repo, pe, ps)
+	channels.NewExpiredConnectionsHandler(ctx, repo, cfg.ExpiredInterval, logger)
+
+	psvc := pChannels.New(repo, pe, ps, clientsClient, quotas.NewMessageLimiter(dq, cfg.QuotaRefresh), settings.NewCache(ds, cfg.SettingsRefresh), dq, clientGroups)
 	return svc, psvc, err
 }
 
+// newDomainPurger creates the purger of the channels of the purged domains.
+// Only the roles removal is used, so neither ID provider nor actions are needed.
+func newDomainPurger(db *sqlx.DB, dbConfig pgclient.Config, ps policies.Service, tracer trace.Tracer) (domains.Purger, error) {
+	database := pg.NewDatabase(db, dbConfig, tracer)
+	repo := postgres.NewRepository(database)
+
+	provisioner, err := roles.NewProvisionManageService(policies.ChannelType, repo, ps, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return channels.NewDomainPurger(repo, provisioner), nil
+}
+
 func newSpiceDBPolicyServiceEvaluator(cfg config, logger *slog.Logger) (policies.Evaluator, policies.Service, error) {
 	client, err := authzed.NewClientWithExperimentalAPIs(
 		fmt.Sprintf("%s:%s", cfg.SpicedbHost, cfg.SpicedbPort),
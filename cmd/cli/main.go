@@ -45,6 +45,8 @@ func main() {
 	configCmd := cli.NewConfigCmd()
 	invitationsCmd := cli.NewInvitationsCmd()
 	journalCmd := cli.NewJournalCmd()
+	rulesCmd := cli.NewRulesCmd()
+	twinsCmd := cli.NewTwinsCmd()
 
 	// Root Commands
 	rootCmd.AddCommand(healthCmd)
@@ -58,6 +60,8 @@ func main() {
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(invitationsCmd)
 	rootCmd.AddCommand(journalCmd)
+	rootCmd.AddCommand(rulesCmd)
+	rootCmd.AddCommand(twinsCmd)
 
 	// Root Flags
 	rootCmd.PersistentFlags().StringVarP(
@@ -116,6 +120,22 @@ func main() {
 		"Journal Log URL",
 	)
 
+	rootCmd.PersistentFlags().StringVarP(
+		&sdkConf.RulesURL,
+		"rules-url",
+		"R",
+		sdkConf.RulesURL,
+		"Rules engine URL",
+	)
+
+	rootCmd.PersistentFlags().StringVarP(
+		&sdkConf.TwinsURL,
+		"twins-url",
+		"w",
+		sdkConf.TwinsURL,
+		"Twins service URL",
+	)
+
 	rootCmd.PersistentFlags().StringVarP(
 		&sdkConf.HostURL,
 		"host-url",
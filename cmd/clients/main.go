@@ -27,6 +27,7 @@ import (
 	"github.com/absmach/supermq/clients/postgres"
 	pClients "github.com/absmach/supermq/clients/private"
 	"github.com/absmach/supermq/clients/tracing"
+	"github.com/absmach/supermq/domains"
 	dpostgres "github.com/absmach/supermq/domains/postgres"
 	gpostgres "github.com/absmach/supermq/groups/postgres"
 	redisclient "github.com/absmach/supermq/internal/clients/redis"
@@ -34,8 +35,11 @@ import (
 	authsvcAuthn "github.com/absmach/supermq/pkg/authn/authsvc"
 	smqauthz "github.com/absmach/supermq/pkg/authz"
 	authsvcAuthz "github.com/absmach/supermq/pkg/authz/authsvc"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
 	dconsumer "github.com/absmach/supermq/pkg/domains/events/consumer"
 	domainsAuthz "github.com/absmach/supermq/pkg/domains/grpcclient"
+	"github.com/absmach/supermq/pkg/domains/quotas"
+	"github.com/absmach/supermq/pkg/domains/settings"
 	gconsumer "github.com/absmach/supermq/pkg/groups/events/consumer"
 	"github.com/absmach/supermq/pkg/grpcclient"
 	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
@@ -94,6 +98,8 @@ type config struct {
 	SpicedbPort         string        `env:"SMQ_SPICEDB_PORT"               envDefault:"50051"`
 	SpicedbPreSharedKey string        `env:"SMQ_SPICEDB_PRE_SHARED_KEY"     envDefault:"12345678"`
 	SpicedbSchemaFile   string        `env:"SMQ_SPICEDB_SCHEMA_FILE"        envDefault:"schema.zed"`
+	SettingsRefresh     time.Duration `env:"SMQ_CLIENTS_SETTINGS_REFRESH"   envDefault:"1m"`
+	ExpiredInterval     time.Duration `env:"SMQ_CLIENTS_EXPIRED_CONNECTIONS_INTERVAL" envDefault:"1h"`
 }
 
 func main() {
@@ -195,7 +201,7 @@ func main() {
 		exitCode = 1
 		return
 	}
-	domAuthz, _, domainsHandler, err := domainsAuthz.NewAuthorization(ctx, domsGrpcCfg)
+	domAuthz, domainsClient, domainsHandler, err := domainsAuthz.NewAuthorization(ctx, domsGrpcCfg)
 	if err != nil {
 		logger.Error(err.Error())
 		exitCode = 1
@@ -242,17 +248,24 @@ func main() {
 	defer groupsHandler.Close()
 	logger.Info("Groups gRPC client successfully connected to groups gRPC server " + groupsHandler.Secure())
 
-	svc, psvc, err := newService(ctx, db, dbConfig, authz, policyEvaluator, policyService, cacheclient, cfg, channelsgRPC, groupsClient, tracer, logger)
+	ddatabase := pg.NewDatabase(db, dbConfig, tracer)
+	drepo := dpostgres.New(ddatabase)
+
+	svc, psvc, err := newService(ctx, db, dbConfig, authz, policyEvaluator, policyService, cacheclient, cfg, channelsgRPC, groupsClient, quotas.NewReplica(drepo), domainsAuthz.NewSettings(domainsClient), tracer, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create services: %s", err))
 		exitCode = 1
 		return
 	}
 
-	ddatabase := pg.NewDatabase(db, dbConfig, tracer)
-	drepo := dpostgres.New(ddatabase)
+	purger, err := newDomainPurger(db, dbConfig, policyService, cacheclient, cfg, tracer)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create domain purger : %s", err))
+		exitCode = 1
+		return
+	}
 
-	if err := dconsumer.DomainsEventsSubscribe(ctx, drepo, cfg.ESURL, cfg.ESConsumerName, logger); err != nil {
+	if err := dconsumer.DomainsEventsSubscribe(ctx, drepo, cfg.ESURL, cfg.ESConsumerName, logger, purger); err != nil {
 		logger.Error(fmt.Sprintf("failed to create domains event store : %s", err))
 		exitCode = 1
 		return
@@ -312,7 +325,7 @@ func main() {
 	}
 }
 
-func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authz smqauthz.Authorization, pe policies.Evaluator, ps policies.Service, cacheClient *redis.Client, cfg config, channels grpcChannelsV1.ChannelsServiceClient, groups grpcGroupsV1.GroupsServiceClient, tracer trace.Tracer, logger *slog.Logger) (clients.Service, pClients.Service, error) {
+func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authz smqauthz.Authorization, pe policies.Evaluator, ps policies.Service, cacheClient *redis.Client, cfg config, channels grpcChannelsV1.ChannelsServiceClient, groups grpcGroupsV1.GroupsServiceClient, dq pkgDomains.Quotas, ds pkgDomains.Settings, tracer trace.Tracer, logger *slog.Logger) (clients.Service, pClients.Service, error) {
 	database := pg.NewDatabase(db, dbConfig, tracer)
 	repo := postgres.NewRepository(database)
 
@@ -330,7 +343,7 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth
 		return nil, nil, err
 	}
 
-	csvc, err := clients.NewService(repo, ps, cache, channels, groups, idp, sidp, availableActions, builtInRoles)
+	csvc, err := clients.NewService(repo, ps, cache, channels, groups, dq, settings.NewCache(ds, cfg.SettingsRefresh), idp, sidp, availableActions, builtInRoles)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -352,11 +365,27 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth
 	}
 	csvc = middleware.LoggingMiddleware(csvc, logger)
 
-	isvc := pClients.New(repo, cache, pe, ps)
+	clients.NewExpiredConnectionsHandler(ctx, repo, cfg.ExpiredInterval, logger)
+
+	isvc := pClients.New(repo, cache, pe, ps, dq)
 
 	return csvc, isvc, err
 }
 
+// newDomainPurger creates the purger of the clients of the purged domains.
+// Only the roles removal is used, so neither ID provider nor actions are needed.
+func newDomainPurger(db *sqlx.DB, dbConfig pgclient.Config, ps policies.Service, cacheClient *redis.Client, cfg config, tracer trace.Tracer) (domains.Purger, error) {
+	database := pg.NewDatabase(db, dbConfig, tracer)
+	repo := postgres.NewRepository(database)
+
+	provisioner, err := roles.NewProvisionManageService(policies.ClientType, repo, ps, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return clients.NewDomainPurger(repo, cache.NewCache(cacheClient, cfg.CacheKeyDuration), provisioner), nil
+}
+
 func newSpiceDBPolicyServiceEvaluator(cfg config, logger *slog.Logger) (policies.Evaluator, policies.Service, error) {
 	client, err := authzed.NewClientWithExperimentalAPIs(
 		fmt.Sprintf("%s:%s", cfg.SpicedbHost, cfg.SpicedbPort),
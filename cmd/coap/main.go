@@ -10,6 +10,7 @@ import (
 	"log"
 	"net/url"
 	"os"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/supermq"
@@ -22,6 +23,7 @@ import (
 	"github.com/absmach/supermq/pkg/messaging/brokers"
 	brokerstracing "github.com/absmach/supermq/pkg/messaging/brokers/tracing"
 	msgevents "github.com/absmach/supermq/pkg/messaging/events"
+	"github.com/absmach/supermq/pkg/messaging/topics"
 	"github.com/absmach/supermq/pkg/prometheus"
 	"github.com/absmach/supermq/pkg/server"
 	coapserver "github.com/absmach/supermq/pkg/server/coap"
@@ -37,18 +39,20 @@ const (
 	envPrefixHTTP     = "SMQ_COAP_ADAPTER_HTTP_"
 	envPrefixClients  = "SMQ_CLIENTS_AUTH_GRPC_"
 	envPrefixChannels = "SMQ_CHANNELS_GRPC_"
+	envPrefixDomains  = "SMQ_DOMAINS_GRPC_"
 	defSvcHTTPPort    = "5683"
 	defSvcCoAPPort    = "5683"
 )
 
 type config struct {
-	LogLevel      string  `env:"SMQ_COAP_ADAPTER_LOG_LEVEL"   envDefault:"info"`
-	BrokerURL     string  `env:"SMQ_MESSAGE_BROKER_URL"       envDefault:"nats://localhost:4222"`
-	JaegerURL     url.URL `env:"SMQ_JAEGER_URL"               envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry bool    `env:"SMQ_SEND_TELEMETRY"           envDefault:"true"`
-	InstanceID    string  `env:"SMQ_COAP_ADAPTER_INSTANCE_ID" envDefault:""`
-	TraceRatio    float64 `env:"SMQ_JAEGER_TRACE_RATIO"       envDefault:"1.0"`
-	ESURL         string  `env:"SMQ_ES_URL"                   envDefault:"nats://localhost:4222"`
+	LogLevel      string        `env:"SMQ_COAP_ADAPTER_LOG_LEVEL"      envDefault:"info"`
+	BrokerURL     string        `env:"SMQ_MESSAGE_BROKER_URL"          envDefault:"nats://localhost:4222"`
+	JaegerURL     url.URL       `env:"SMQ_JAEGER_URL"                  envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry bool          `env:"SMQ_SEND_TELEMETRY"              envDefault:"true"`
+	InstanceID    string        `env:"SMQ_COAP_ADAPTER_INSTANCE_ID"    envDefault:""`
+	TraceRatio    float64       `env:"SMQ_JAEGER_TRACE_RATIO"          envDefault:"1.0"`
+	ESURL         string        `env:"SMQ_ES_URL"                      envDefault:"nats://localhost:4222"`
+	TopicsRefresh time.Duration `env:"SMQ_COAP_ADAPTER_TOPICS_REFRESH" envDefault:"1m"`
 }
 
 func main() {
@@ -123,6 +127,22 @@ func main() {
 	defer channelsHandler.Close()
 	logger.Info("Channels service gRPC client successfully connected to channels gRPC server " + channelsHandler.Secure())
 
+	domainsClientCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&domainsClientCfg, env.Options{Prefix: envPrefixDomains}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load domains gRPC client configuration : %s", err))
+		exitCode = 1
+		return
+	}
+
+	domainsClient, domainsHandler, err := grpcclient.SetupDomainsClient(ctx, domainsClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer domainsHandler.Close()
+	logger.Info("Domains service gRPC client successfully connected to domains gRPC server " + domainsHandler.Secure())
+
 	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
@@ -163,7 +183,7 @@ func main() {
 
 	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(cfg.InstanceID), logger)
 
-	cs := coapserver.NewServer(ctx, cancel, svcName, coapServerConfig, httpapi.MakeCoAPHandler(svc, logger), logger)
+	cs := coapserver.NewServer(ctx, cancel, svcName, coapServerConfig, httpapi.MakeCoAPHandler(svc, topics.NewResolver(domainsClient, channelsClient, cfg.TopicsRefresh), logger), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, supermq.Version, logger, cancel)
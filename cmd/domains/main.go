@@ -80,6 +80,9 @@ type config struct {
 	SpicedbPreSharedKey string        `env:"SMQ_SPICEDB_PRE_SHARED_KEY"       envDefault:"12345678"`
 	TraceRatio          float64       `env:"SMQ_JAEGER_TRACE_RATIO"           envDefault:"1.0"`
 	ESURL               string        `env:"SMQ_ES_URL"                       envDefault:"nats://localhost:4222"`
+	DeleteInterval      time.Duration `env:"SMQ_DOMAINS_DELETE_INTERVAL"      envDefault:"24h"`
+	DeleteAfter         time.Duration `env:"SMQ_DOMAINS_DELETE_AFTER"         envDefault:"720h"`
+	SenderDomains       []string      `env:"SMQ_DOMAINS_SMTP_SENDER_DOMAINS"  envDefault:""`
 }
 
 func main() {
@@ -254,7 +257,7 @@ func newDomainService(ctx context.Context, domainsRepo domainsSvc.Repository, ca
 		return nil, err
 	}
 
-	svc, err := domainsSvc.New(domainsRepo, cache, policiessvc, idProvider, sidProvider, availableActions, builtInRoles)
+	svc, err := domainsSvc.New(domainsRepo, cache, policiessvc, idProvider, sidProvider, availableActions, builtInRoles, cfg.SenderDomains)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init domain service: %w", err)
 	}
@@ -274,6 +277,13 @@ func newDomainService(ctx context.Context, domainsRepo domainsSvc.Repository, ca
 	svc = dmw.LoggingMiddleware(svc, logger)
 
 	svc = dtracing.New(svc, tracer)
+
+	notifier, err := events.NewPurgeNotifier(ctx, cfg.ESURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init domain purge notifier: %w", err)
+	}
+	domainsSvc.NewDeleteHandler(ctx, domainsRepo, policiessvc, notifier, cfg.DeleteInterval, cfg.DeleteAfter, logger)
+
 	return svc, nil
 }
 
@@ -17,6 +17,7 @@ import (
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	grpcGroupsV1 "github.com/absmach/supermq/api/grpc/groups/v1"
+	"github.com/absmach/supermq/domains"
 	dpostgres "github.com/absmach/supermq/domains/postgres"
 	"github.com/absmach/supermq/groups"
 	gpsvc "github.com/absmach/supermq/groups"
@@ -31,8 +32,10 @@ import (
 	authsvcAuthn "github.com/absmach/supermq/pkg/authn/authsvc"
 	smqauthz "github.com/absmach/supermq/pkg/authz"
 	authsvcAuthz "github.com/absmach/supermq/pkg/authz/authsvc"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
 	dconsumer "github.com/absmach/supermq/pkg/domains/events/consumer"
 	domainsAuthz "github.com/absmach/supermq/pkg/domains/grpcclient"
+	"github.com/absmach/supermq/pkg/domains/quotas"
 	"github.com/absmach/supermq/pkg/grpcclient"
 	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
 	"github.com/absmach/supermq/pkg/policies"
@@ -222,17 +225,24 @@ func main() {
 	defer clientsHandler.Close()
 	logger.Info("Clients gRPC client successfully connected to clients gRPC server " + clientsHandler.Secure())
 
-	svc, psvc, err := newService(ctx, authz, policyService, db, dbConfig, channelsClient, clientsClient, tracer, logger, cfg)
+	ddatabase := pg.NewDatabase(db, dbConfig, tracer)
+	drepo := dpostgres.New(ddatabase)
+
+	svc, psvc, err := newService(ctx, authz, policyService, db, dbConfig, channelsClient, clientsClient, quotas.NewReplica(drepo), tracer, logger, cfg)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to setup service: %s", err))
 		exitCode = 1
 		return
 	}
 
-	ddatabase := pg.NewDatabase(db, dbConfig, tracer)
-	drepo := dpostgres.New(ddatabase)
+	purger, err := newDomainPurger(db, dbConfig, policyService, tracer)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create domain purger : %s", err))
+		exitCode = 1
+		return
+	}
 
-	if err := dconsumer.DomainsEventsSubscribe(ctx, drepo, cfg.ESURL, cfg.ESConsumerName, logger); err != nil {
+	if err := dconsumer.DomainsEventsSubscribe(ctx, drepo, cfg.ESURL, cfg.ESConsumerName, logger, purger); err != nil {
 		logger.Error(fmt.Sprintf("failed to create domains event store : %s", err))
 		exitCode = 1
 		return
@@ -283,7 +293,7 @@ func main() {
 	}
 }
 
-func newService(ctx context.Context, authz smqauthz.Authorization, policy policies.Service, db *sqlx.DB, dbConfig pgclient.Config, channels grpcChannelsV1.ChannelsServiceClient, clients grpcClientsV1.ClientsServiceClient, tracer trace.Tracer, logger *slog.Logger, c config) (groups.Service, pgroups.Service, error) {
+func newService(ctx context.Context, authz smqauthz.Authorization, policy policies.Service, db *sqlx.DB, dbConfig pgclient.Config, channels grpcChannelsV1.ChannelsServiceClient, clients grpcClientsV1.ClientsServiceClient, dq pkgDomains.Quotas, tracer trace.Tracer, logger *slog.Logger, c config) (groups.Service, pgroups.Service, error) {
 	database := pg.NewDatabase(db, dbConfig, tracer)
 	idp := uuid.New()
 	sid, err := sid.New()
@@ -298,7 +308,7 @@ func newService(ctx context.Context, authz smqauthz.Authorization, policy polici
 
 	// Creating groups service
 	repo := postgres.New(database)
-	svc, err := gpsvc.NewService(repo, policy, idp, channels, clients, sid, availableActions, builtInRoles)
+	svc, err := gpsvc.NewService(repo, policy, idp, channels, clients, dq, sid, availableActions, builtInRoles)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -321,6 +331,20 @@ func newService(ctx context.Context, authz smqauthz.Authorization, policy polici
 	return svc, psvc, err
 }
 
+// newDomainPurger creates the purger of the groups of the purged domains.
+// Only the roles removal is used, so neither ID provider nor actions are needed.
+func newDomainPurger(db *sqlx.DB, dbConfig pgclient.Config, ps policies.Service, tracer trace.Tracer) (domains.Purger, error) {
+	database := pg.NewDatabase(db, dbConfig, tracer)
+	repo := postgres.New(database)
+
+	provisioner, err := roles.NewProvisionManageService(policies.GroupType, repo, ps, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups.NewDomainPurger(repo, provisioner), nil
+}
+
 func newPolicyService(cfg config, logger *slog.Logger) (policies.Service, error) {
 	client, err := authzed.NewClientWithExperimentalAPIs(
 		fmt.Sprintf("%s:%s", cfg.SpicedbHost, cfg.SpicedbPort),
@@ -6,13 +6,13 @@ package main
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 	"log"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/mgate"
@@ -21,11 +21,15 @@ import (
 	"github.com/absmach/supermq"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	apiutil "github.com/absmach/supermq/api/http/util"
 	adapter "github.com/absmach/supermq/http"
 	httpapi "github.com/absmach/supermq/http/api"
 	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/authn/authsvc"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	authsvcAuthz "github.com/absmach/supermq/pkg/authz/authsvc"
+	domainsAuthz "github.com/absmach/supermq/pkg/domains/grpcclient"
 	"github.com/absmach/supermq/pkg/grpcclient"
 	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
 	"github.com/absmach/supermq/pkg/messaging"
@@ -33,6 +37,7 @@ import (
 	brokerstracing "github.com/absmach/supermq/pkg/messaging/brokers/tracing"
 	msgevents "github.com/absmach/supermq/pkg/messaging/events"
 	"github.com/absmach/supermq/pkg/messaging/handler"
+	"github.com/absmach/supermq/pkg/messaging/topics"
 	"github.com/absmach/supermq/pkg/prometheus"
 	"github.com/absmach/supermq/pkg/server"
 	httpserver "github.com/absmach/supermq/pkg/server/http"
@@ -48,19 +53,21 @@ const (
 	envPrefixClients  = "SMQ_CLIENTS_AUTH_GRPC_"
 	envPrefixChannels = "SMQ_CHANNELS_GRPC_"
 	envPrefixAuth     = "SMQ_AUTH_GRPC_"
+	envPrefixDomains  = "SMQ_DOMAINS_GRPC_"
 	defSvcHTTPPort    = "80"
 	targetHTTPPort    = "81"
 	targetHTTPHost    = "http://localhost"
 )
 
 type config struct {
-	LogLevel      string  `env:"SMQ_HTTP_ADAPTER_LOG_LEVEL"   envDefault:"info"`
-	BrokerURL     string  `env:"SMQ_MESSAGE_BROKER_URL"       envDefault:"nats://localhost:4222"`
-	JaegerURL     url.URL `env:"SMQ_JAEGER_URL"               envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry bool    `env:"SMQ_SEND_TELEMETRY"           envDefault:"true"`
-	InstanceID    string  `env:"SMQ_HTTP_ADAPTER_INSTANCE_ID" envDefault:""`
-	TraceRatio    float64 `env:"SMQ_JAEGER_TRACE_RATIO"       envDefault:"1.0"`
-	ESURL         string  `env:"SMQ_ES_URL"                   envDefault:"nats://localhost:4222"`
+	LogLevel      string        `env:"SMQ_HTTP_ADAPTER_LOG_LEVEL"      envDefault:"info"`
+	BrokerURL     string        `env:"SMQ_MESSAGE_BROKER_URL"          envDefault:"nats://localhost:4222"`
+	JaegerURL     url.URL       `env:"SMQ_JAEGER_URL"                  envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry bool          `env:"SMQ_SEND_TELEMETRY"              envDefault:"true"`
+	InstanceID    string        `env:"SMQ_HTTP_ADAPTER_INSTANCE_ID"    envDefault:""`
+	TraceRatio    float64       `env:"SMQ_JAEGER_TRACE_RATIO"          envDefault:"1.0"`
+	ESURL         string        `env:"SMQ_ES_URL"                      envDefault:"nats://localhost:4222"`
+	TopicsRefresh time.Duration `env:"SMQ_HTTP_ADAPTER_TOPICS_REFRESH" envDefault:"1m"`
 }
 
 func main() {
@@ -143,6 +150,29 @@ func main() {
 	defer authnHandler.Close()
 	logger.Info("authn successfully connected to auth gRPC server " + authnHandler.Secure())
 
+	domsGrpcCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&domsGrpcCfg, env.Options{Prefix: envPrefixDomains}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load domains gRPC client configuration : %s", err))
+		exitCode = 1
+		return
+	}
+	domAuthz, domainsClient, domainsHandler, err := domainsAuthz.NewAuthorization(ctx, domsGrpcCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer domainsHandler.Close()
+
+	authz, authzHandler, err := authsvcAuthz.NewAuthorization(ctx, authnCfg, domAuthz)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authzHandler.Close()
+	logger.Info("authz successfully connected to auth gRPC server " + authzHandler.Secure())
+
 	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
@@ -172,7 +202,8 @@ func main() {
 		return
 	}
 
-	svc := newService(pub, authn, clientsClient, channelsClient, logger, tracer)
+	resolver := topics.NewResolver(domainsClient, channelsClient, cfg.TopicsRefresh)
+	svc := newService(pub, authn, authz, clientsClient, channelsClient, resolver, logger, tracer)
 	targetServerCfg := server.Config{Port: targetHTTPPort}
 
 	hs := httpserver.NewServer(ctx, cancel, svcName, targetServerCfg, httpapi.MakeHandler(logger, cfg.InstanceID), logger)
@@ -199,8 +230,8 @@ func main() {
 	}
 }
 
-func newService(pub messaging.Publisher, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, logger *slog.Logger, tracer trace.Tracer) session.Handler {
-	svc := adapter.NewHandler(pub, authn, clients, channels, logger)
+func newService(pub messaging.Publisher, authn smqauthn.Authentication, authz smqauthz.Authorization, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, resolver topics.Resolver, logger *slog.Logger, tracer trace.Tracer) session.Handler {
+	svc := adapter.NewHandler(pub, authn, authz, clients, channels, resolver, logger)
 	svc = handler.NewTracing(tracer, svc)
 	svc = handler.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics(svcName, "api")
@@ -214,31 +245,31 @@ func proxyHTTP(ctx context.Context, cfg server.Config, logger *slog.Logger, sess
 		Target:     fmt.Sprintf("%s:%s", targetHTTPHost, targetHTTPPort),
 		PathPrefix: "/",
 	}
-	if cfg.CertFile != "" || cfg.KeyFile != "" {
-		tlsCert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
-		if err != nil {
-			return err
-		}
-		config.TLSConfig = &tls.Config{
-			Certificates: []tls.Certificate{tlsCert},
-		}
-	}
 	mp, err := mgatehttp.NewProxy(config, sessionHandler, logger)
 	if err != nil {
 		return err
 	}
-	http.HandleFunc("/", mp.ServeHTTP)
+	proxies, err := apiutil.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return err
+	}
+	// The proxy is served directly instead of using its listener, so the
+	// client IP reaches the session handler through the request context.
+	srv := &http.Server{
+		Addr:    config.Address,
+		Handler: apiutil.ClientIPMiddleware(proxies)(withClientIP(mp)),
+	}
 
 	errCh := make(chan error)
 	switch {
 	case cfg.CertFile != "" || cfg.KeyFile != "":
 		go func() {
-			errCh <- mp.Listen(ctx)
+			errCh <- srv.ListenAndServeTLS(cfg.CertFile, cfg.KeyFile)
 		}()
 		logger.Info(fmt.Sprintf("%s service HTTPS server listening at %s:%s with TLS cert %s and key %s", svcName, cfg.Host, cfg.Port, cfg.CertFile, cfg.KeyFile))
 	default:
 		go func() {
-			errCh <- mp.Listen(ctx)
+			errCh <- srv.ListenAndServe()
 		}()
 		logger.Info(fmt.Sprintf("%s service HTTP server listening at %s:%s without TLS", svcName, cfg.Host, cfg.Port))
 	}
@@ -246,8 +277,17 @@ func proxyHTTP(ctx context.Context, cfg server.Config, logger *slog.Logger, sess
 	select {
 	case <-ctx.Done():
 		logger.Info(fmt.Sprintf("proxy HTTP shutdown at %s", config.Target))
-		return nil
+		return srv.Shutdown(context.Background())
 	case err := <-errCh:
 		return err
 	}
 }
+
+// withClientIP stores the client IP address in the request context, so it
+// is reported when the personal access token is used to publish.
+func withClientIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := smqauthn.WithClientIP(r.Context(), apiutil.ClientIP(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -12,12 +12,14 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"strings"
 	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/supermq"
 	grpcDomainsV1 "github.com/absmach/supermq/api/grpc/domains/v1"
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
+	smqauth "github.com/absmach/supermq/auth"
 	"github.com/absmach/supermq/internal/email"
 	smqlog "github.com/absmach/supermq/logger"
 	authsvcAuthn "github.com/absmach/supermq/pkg/authn/authsvc"
@@ -28,11 +30,15 @@ import (
 	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
 	"github.com/absmach/supermq/pkg/oauth2"
 	googleoauth "github.com/absmach/supermq/pkg/oauth2/google"
+	"github.com/absmach/supermq/pkg/oauth2/oidc"
 	"github.com/absmach/supermq/pkg/policies"
 	"github.com/absmach/supermq/pkg/policies/spicedb"
 	pg "github.com/absmach/supermq/pkg/postgres"
 	pgclient "github.com/absmach/supermq/pkg/postgres"
 	"github.com/absmach/supermq/pkg/prometheus"
+	"github.com/absmach/supermq/pkg/roles"
+	rolesdk "github.com/absmach/supermq/pkg/roles/rolemanager/sdk"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
 	"github.com/absmach/supermq/pkg/server"
 	httpserver "github.com/absmach/supermq/pkg/server/http"
 	"github.com/absmach/supermq/pkg/uuid"
@@ -40,9 +46,12 @@ import (
 	httpapi "github.com/absmach/supermq/users/api"
 	"github.com/absmach/supermq/users/emailer"
 	"github.com/absmach/supermq/users/events"
+	"github.com/absmach/supermq/users/events/consumer"
 	"github.com/absmach/supermq/users/hasher"
+	"github.com/absmach/supermq/users/ldap"
 	"github.com/absmach/supermq/users/middleware"
 	"github.com/absmach/supermq/users/postgres"
+	"github.com/absmach/supermq/users/pwned"
 	"github.com/absmach/supermq/users/tracing"
 	"github.com/authzed/authzed-go/v1"
 	"github.com/authzed/grpcutil"
@@ -62,6 +71,12 @@ const (
 	envPrefixAuth    = "SMQ_AUTH_GRPC_"
 	envPrefixDomains = "SMQ_DOMAINS_GRPC_"
 	envPrefixGoogle  = "SMQ_GOOGLE_"
+	envPrefixOIDC    = "SMQ_OIDC_"
+	envPrefixLDAP    = "SMQ_USERS_LDAP_"
+	envPrefixPass    = "SMQ_USERS_PASSWORD_"
+	envPrefixPwned   = "SMQ_USERS_PWNED_"
+	envPrefixLockout = "SMQ_USERS_LOCKOUT_"
+	envPrefixVerify  = "SMQ_USERS_VERIFICATION_"
 	defDB            = "users"
 	defSvcHTTPPort   = "9002"
 )
@@ -75,14 +90,18 @@ type config struct {
 	AdminLastName       string        `env:"SMQ_USERS_ADMIN_LAST_NAME"     envDefault:"admin"`
 	PassRegexText       string        `env:"SMQ_USERS_PASS_REGEX"          envDefault:"^.{8,}$"`
 	ResetURL            string        `env:"SMQ_TOKEN_RESET_ENDPOINT"      envDefault:"/reset-request"`
+	VerificationURL     string        `env:"SMQ_USERS_VERIFICATION_URL"    envDefault:"http://localhost:9095/verify-email"`
 	JaegerURL           url.URL       `env:"SMQ_JAEGER_URL"                envDefault:"http://localhost:4318/v1/traces"`
 	SendTelemetry       bool          `env:"SMQ_SEND_TELEMETRY"            envDefault:"true"`
 	InstanceID          string        `env:"SMQ_USERS_INSTANCE_ID"         envDefault:""`
 	ESURL               string        `env:"SMQ_ES_URL"                    envDefault:"nats://localhost:4222"`
+	ESConsumerName      string        `env:"SMQ_USERS_EVENT_CONSUMER"      envDefault:"users"`
 	TraceRatio          float64       `env:"SMQ_JAEGER_TRACE_RATIO"        envDefault:"1.0"`
 	SelfRegister        bool          `env:"SMQ_USERS_ALLOW_SELF_REGISTER" envDefault:"false"`
 	OAuthUIRedirectURL  string        `env:"SMQ_OAUTH_UI_REDIRECT_URL"     envDefault:"http://localhost:9095/domains"`
 	OAuthUIErrorURL     string        `env:"SMQ_OAUTH_UI_ERROR_URL"        envDefault:"http://localhost:9095/error"`
+	OIDCProviders       []string      `env:"SMQ_OIDC_PROVIDERS"            envDefault:"" envSeparator:","`
+	DomainsURL          string        `env:"SMQ_DOMAINS_URL"               envDefault:"http://localhost:9003"`
 	DeleteInterval      time.Duration `env:"SMQ_USERS_DELETE_INTERVAL"     envDefault:"24h"`
 	DeleteAfter         time.Duration `env:"SMQ_USERS_DELETE_AFTER"        envDefault:"720h"`
 	SpicedbHost         string        `env:"SMQ_SPICEDB_HOST"              envDefault:"localhost"`
@@ -233,10 +252,25 @@ func main() {
 		exitCode = 1
 		return
 	}
-	oauthProvider := googleoauth.NewProvider(oauthConfig, cfg.OAuthUIRedirectURL, cfg.OAuthUIErrorURL)
+	oauthProviders := []oauth2.Provider{googleoauth.NewProvider(oauthConfig, cfg.OAuthUIRedirectURL, cfg.OAuthUIErrorURL)}
+
+	for _, name := range cfg.OIDCProviders {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		oidcConfig := oidc.Config{}
+		prefix := envPrefixOIDC + strings.ToUpper(name) + "_"
+		if err := env.ParseWithOptions(&oidcConfig, env.Options{Prefix: prefix}); err != nil {
+			logger.Error(fmt.Sprintf("failed to load %s OpenID Connect %s configuration : %s", svcName, name, err.Error()))
+			exitCode = 1
+			return
+		}
+		oauthProviders = append(oauthProviders, oidc.NewProvider(ctx, name, oidcConfig, cfg.OAuthUIRedirectURL, cfg.OAuthUIErrorURL))
+	}
 
 	mux := chi.NewRouter()
-	httpSrv := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(csvc, authn, tokenClient, cfg.SelfRegister, mux, logger, cfg.InstanceID, cfg.PassRegex, oauthProvider), logger)
+	httpSrv := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(csvc, authn, cfg.SelfRegister, mux, logger, cfg.InstanceID, cfg.PassRegex, oauthProviders...), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, supermq.Version, logger, cancel)
@@ -263,17 +297,50 @@ func newService(ctx context.Context, authz smqauthz.Authorization, token grpcTok
 
 	// Creating users service
 	repo := postgres.NewRepository(database)
-	emailerClient, err := emailer.New(c.ResetURL, &ec)
+	emailerClient, err := emailer.New(c.ResetURL, c.VerificationURL, &ec)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to configure e-mailing util: %s", err.Error()))
 	}
 
-	svc := users.NewService(token, repo, policyService, emailerClient, hsr, idp)
+	directory, domainRoles, err := newDirectory(c, repo, token)
+	if err != nil {
+		return nil, err
+	}
+	if directory != nil {
+		logger.Info("LDAP directory authentication enabled")
+	}
+
+	passwords := users.PasswordPolicy{}
+	if err := env.ParseWithOptions(&passwords, env.Options{Prefix: envPrefixPass}); err != nil {
+		return nil, fmt.Errorf("failed to load %s password policy configuration : %w", svcName, err)
+	}
+	lockout := users.LockoutPolicy{}
+	if err := env.ParseWithOptions(&lockout, env.Options{Prefix: envPrefixLockout}); err != nil {
+		return nil, fmt.Errorf("failed to load %s lockout policy configuration : %w", svcName, err)
+	}
+	pwnedConfig := pwned.Config{}
+	if err := env.ParseWithOptions(&pwnedConfig, env.Options{Prefix: envPrefixPwned}); err != nil {
+		return nil, fmt.Errorf("failed to load %s Pwned Passwords configuration : %w", svcName, err)
+	}
+	verification := users.VerificationPolicy{}
+	if err := env.ParseWithOptions(&verification, env.Options{Prefix: envPrefixVerify}); err != nil {
+		return nil, fmt.Errorf("failed to load %s email verification configuration : %w", svcName, err)
+	}
+	var breaches users.BreachChecker
+	if pwnedConfig.Enabled {
+		breaches = pwned.NewChecker(pwnedConfig)
+		logger.Info("Pwned Passwords breach checks enabled")
+	}
+
+	svc := users.NewService(token, domainsClient, repo, policyService, emailerClient, hsr, idp, directory, domainRoles, passwords, breaches, lockout, verification)
 
 	svc, err = events.NewEventStoreMiddleware(ctx, svc, c.ESURL)
 	if err != nil {
 		return nil, err
 	}
+	if err := consumer.AuthEventsSubscribe(ctx, repo, emailerClient, c.ESURL, c.ESConsumerName, logger); err != nil {
+		return nil, fmt.Errorf("failed to subscribe to auth events : %w", err)
+	}
 	svc = middleware.AuthorizationMiddleware(svc, authz, c.SelfRegister)
 
 	svc = tracing.New(svc, tracer)
@@ -294,6 +361,42 @@ func newService(ctx context.Context, authz smqauthz.Authorization, token grpcTok
 	return svc, err
 }
 
+// newDirectory creates the LDAP directory and the domain role manager used
+// to synchronize the directory groups with the domain roles. The role
+// manager calls the domains service on behalf of the admin user.
+func newDirectory(c config, repo users.Repository, token grpcTokenV1.TokenServiceClient) (users.Directory, roles.RoleManager, error) {
+	ldapConfig := ldap.Config{}
+	if err := env.ParseWithOptions(&ldapConfig, env.Options{Prefix: envPrefixLDAP}); err != nil {
+		return nil, nil, fmt.Errorf("failed to load %s LDAP configuration : %w", svcName, err)
+	}
+	if !ldap.IsEnabled(ldapConfig) {
+		return nil, nil, nil
+	}
+
+	directory, err := ldap.NewDirectory(ldapConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(directory.Roles()) == 0 {
+		return directory, nil, nil
+	}
+
+	sdk := mgsdk.NewSDK(mgsdk.Config{DomainsURL: c.DomainsURL})
+	adminToken := func(ctx context.Context) (string, error) {
+		admin, err := repo.RetrieveByUsername(ctx, c.AdminUsername)
+		if err != nil {
+			return "", err
+		}
+		t, err := token.Issue(ctx, &grpcTokenV1.IssueReq{UserId: admin.ID, Type: uint32(smqauth.AccessKey)})
+		if err != nil {
+			return "", err
+		}
+		return t.GetAccessToken(), nil
+	}
+
+	return directory, rolesdk.NewDomainsRoleManager(sdk, adminToken), nil
+}
+
 func createAdmin(ctx context.Context, c config, repo users.Repository, hsr users.Hasher, svc users.Service) (string, error) {
 	id, err := uuid.New().ID()
 	if err != nil {
@@ -330,7 +433,7 @@ func createAdmin(ctx context.Context, c config, repo users.Repository, hsr users
 	if _, err = repo.Save(ctx, user); err != nil {
 		return "", err
 	}
-	if _, err = svc.IssueToken(ctx, c.AdminUsername, c.AdminPassword); err != nil {
+	if _, err = svc.IssueToken(ctx, c.AdminUsername, c.AdminPassword, "", "", ""); err != nil {
 		return "", err
 	}
 	return user.ID, nil
@@ -12,6 +12,7 @@ import (
 
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	"github.com/absmach/supermq/domains"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
@@ -76,6 +77,8 @@ func (svc *adapterService) Publish(ctx context.Context, key string, msg *messagi
 		ClientType: policies.ClientType,
 		Type:       uint32(connections.Publish),
 		ChannelId:  msg.GetChannel(),
+		Protocol:   domains.CoAPProtocol,
+		Subtopic:   msg.GetSubtopic(),
 	})
 	if err != nil {
 		return errors.Wrap(svcerr.ErrAuthorization, err)
@@ -106,6 +109,8 @@ func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic
 		ClientType: policies.ClientType,
 		Type:       uint32(connections.Subscribe),
 		ChannelId:  chanID,
+		Protocol:   domains.CoAPProtocol,
+		Subtopic:   subtopic,
 	})
 	if err != nil {
 		return errors.Wrap(svcerr.ErrAuthorization, err)
@@ -146,6 +151,8 @@ func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopi
 		ClientType: policies.ClientType,
 		Type:       uint32(connections.Subscribe),
 		ChannelId:  chanID,
+		Protocol:   domains.CoAPProtocol,
+		Subtopic:   subtopic,
 	})
 	if err != nil {
 		return errors.Wrap(svcerr.ErrAuthorization, err)
@@ -192,7 +199,7 @@ func newAuthzClient(clientID, channelID, subTopic string, channels grpcChannelsV
 }
 
 func (a ac) Handle(m *messaging.Message) error {
-	res, err := a.channels.Authorize(context.Background(), &grpcChannelsV1.AuthzReq{ClientId: a.clientID, ClientType: policies.ClientType, ChannelId: a.channelID, Type: uint32(connections.Subscribe)})
+	res, err := a.channels.Authorize(context.Background(), &grpcChannelsV1.AuthzReq{ClientId: a.clientID, ClientType: policies.ClientType, ChannelId: a.channelID, Type: uint32(connections.Subscribe), Protocol: domains.CoAPProtocol, Subtopic: a.subTopic})
 	if err != nil {
 		if disErr := a.Cancel(); disErr != nil {
 			return errors.Wrap(err, errors.Wrap(errFailedToDisconnectClient, disErr))
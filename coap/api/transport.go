@@ -19,6 +19,7 @@ import (
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/messaging/topics"
 	"github.com/go-chi/chi/v5"
 	"github.com/plgd-dev/go-coap/v3/message"
 	"github.com/plgd-dev/go-coap/v3/message/codes"
@@ -47,8 +48,9 @@ var (
 )
 
 var (
-	logger  *slog.Logger
-	service coap.Service
+	logger   *slog.Logger
+	service  coap.Service
+	resolver topics.Resolver
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
@@ -61,9 +63,10 @@ func MakeHandler(instanceID string) http.Handler {
 }
 
 // MakeCoAPHandler creates handler for CoAP messages.
-func MakeCoAPHandler(svc coap.Service, l *slog.Logger) mux.HandlerFunc {
+func MakeCoAPHandler(svc coap.Service, r topics.Resolver, l *slog.Logger) mux.HandlerFunc {
 	logger = l
 	service = svc
+	resolver = r
 
 	return handler
 }
@@ -147,6 +150,10 @@ func decodeMessage(msg *mux.Message) (*messaging.Message, error) {
 	if err != nil {
 		return &messaging.Message{}, err
 	}
+	path, err = resolver.Resolve(msg.Context(), path)
+	if err != nil {
+		return &messaging.Message{}, err
+	}
 	channelParts := channelPartRegExp.FindStringSubmatch(path)
 	if len(channelParts) < numGroups {
 		return &messaging.Message{}, errMalformedSubtopic
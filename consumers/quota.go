@@ -0,0 +1,109 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	"github.com/absmach/supermq/domains"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
+	"github.com/absmach/supermq/pkg/messaging"
+	mgjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+)
+
+// ErrUnknownMessages indicates the messages of the type the consumer can't store.
+var ErrUnknownMessages = errors.New("unknown messages type")
+
+var _ BlockingConsumer = (*storageQuota)(nil)
+
+type storageQuota struct {
+	consumer BlockingConsumer
+	repo     StorageRepository
+	channels grpcChannelsV1.ChannelsServiceClient
+	quotas   pkgDomains.Quotas
+}
+
+// NewStorageQuota returns the consumer which stores the messages only if the
+// channel domain storage quota is not exceeded and counts the size of the
+// stored messages in the domain storage usage. The usage is checked before the
+// messages are stored, so the concurrent writes may exceed the quota by the size
+// of the messages written at the same time.
+func NewStorageQuota(consumer BlockingConsumer, repo StorageRepository, channels grpcChannelsV1.ChannelsServiceClient, quotas pkgDomains.Quotas) BlockingConsumer {
+	return &storageQuota{
+		consumer: consumer,
+		repo:     repo,
+		channels: channels,
+		quotas:   quotas,
+	}
+}
+
+func (sq *storageQuota) ConsumeBlocking(ctx context.Context, messages interface{}) error {
+	chID, size, err := messagesSize(messages)
+	if err != nil {
+		return err
+	}
+	if size == 0 {
+		return sq.consumer.ConsumeBlocking(ctx, messages)
+	}
+
+	res, err := sq.channels.RetrieveEntity(ctx, &grpcCommonV1.RetrieveEntityReq{Id: chID})
+	if err != nil {
+		return err
+	}
+	domainID := res.GetEntity().GetDomainId()
+
+	q, err := sq.quotas.RetrieveQuotas(ctx, domainID)
+	if err != nil {
+		return err
+	}
+	if q.Storage > 0 {
+		used, err := sq.repo.Usage(ctx, domainID)
+		if err != nil {
+			return err
+		}
+		if err := domains.CheckQuota(q.Storage, used, size); err != nil {
+			return err
+		}
+	}
+
+	if err := sq.consumer.ConsumeBlocking(ctx, messages); err != nil {
+		return err
+	}
+
+	return sq.repo.UpdateUsage(ctx, domainID, int64(size))
+}
+
+// messagesSize returns the channel and the size in bytes of the messages
+// transformed from a single message.
+func messagesSize(messages interface{}) (string, uint64, error) {
+	switch m := messages.(type) {
+	case *messaging.Message:
+		return m.GetChannel(), uint64(len(m.GetPayload())), nil
+	case []senml.Message:
+		if len(m) == 0 {
+			return "", 0, nil
+		}
+		data, err := json.Marshal(m)
+		if err != nil {
+			return "", 0, err
+		}
+		return m[0].Channel, uint64(len(data)), nil
+	case mgjson.Messages:
+		if len(m.Data) == 0 {
+			return "", 0, nil
+		}
+		data, err := json.Marshal(m.Data)
+		if err != nil {
+			return "", 0, err
+		}
+		return m.Data[0].Channel, uint64(len(data)), nil
+	default:
+		return "", 0, ErrUnknownMessages
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	chmocks "github.com/absmach/supermq/channels/mocks"
+	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/consumers/mocks"
+	"github.com/absmach/supermq/domains"
+	"github.com/absmach/supermq/internal/testsutil"
+	dmocks "github.com/absmach/supermq/pkg/domains/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type consumer struct {
+	consumed int
+}
+
+func (c *consumer) ConsumeBlocking(_ context.Context, _ interface{}) error {
+	c.consumed++
+	return nil
+}
+
+func TestStorageQuotaConsumeBlocking(t *testing.T) {
+	chanID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	entity := &grpcCommonV1.RetrieveEntityRes{Entity: &grpcCommonV1.EntityBasic{Id: chanID, DomainId: domainID}}
+	msg := &messaging.Message{Channel: chanID, Payload: []byte(`{"v":1}`)}
+	size := int64(len(msg.GetPayload()))
+
+	cases := []struct {
+		desc      string
+		messages  interface{}
+		quotas    domains.Quotas
+		usage     uint64
+		entityErr error
+		consumed  int
+		err       error
+	}{
+		{
+			desc:     "consume messages without storage quota",
+			messages: msg,
+			quotas:   domains.Quotas{},
+			consumed: 1,
+		},
+		{
+			desc:     "consume messages within storage quota",
+			messages: msg,
+			quotas:   domains.Quotas{Storage: 100},
+			usage:    50,
+			consumed: 1,
+		},
+		{
+			desc:     "consume messages exceeding storage quota",
+			messages: msg,
+			quotas:   domains.Quotas{Storage: 100},
+			usage:    99,
+			err:      svcerr.ErrQuotaExceeded,
+		},
+		{
+			desc:      "consume messages with failed channel retrieval",
+			messages:  msg,
+			entityErr: svcerr.ErrNotFound,
+			err:       svcerr.ErrNotFound,
+		},
+		{
+			desc:     "consume messages of unknown type",
+			messages: "message",
+			err:      consumers.ErrUnknownMessages,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := &consumer{}
+			repo := new(mocks.StorageRepository)
+			channels := new(chmocks.ChannelsServiceClient)
+			quotas := new(dmocks.Quotas)
+			sq := consumers.NewStorageQuota(c, repo, channels, quotas)
+
+			channels.On("RetrieveEntity", mock.Anything, &grpcCommonV1.RetrieveEntityReq{Id: chanID}).Return(entity, tc.entityErr)
+			quotas.On("RetrieveQuotas", mock.Anything, domainID).Return(tc.quotas, nil)
+			repo.On("Usage", mock.Anything, domainID).Return(tc.usage, nil)
+			repo.On("UpdateUsage", mock.Anything, domainID, size).Return(nil)
+
+			err := sq.ConsumeBlocking(context.Background(), tc.messages)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.consumed, c.consumed, fmt.Sprintf("%s: expected %d consumed messages got %d\n", tc.desc, tc.consumed, c.consumed))
+			if tc.consumed > 0 {
+				repo.AssertCalled(t, "UpdateUsage", mock.Anything, domainID, size)
+			}
+		})
+	}
+}
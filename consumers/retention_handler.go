@@ -0,0 +1,89 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// The RetentionHandler is a cron job that runs periodically to remove the stored messages
+// older than the message retention of the channel domain. The domains without the message
+// retention keep their messages until they are removed.
+
+package consumers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
+)
+
+type retentionHandler struct {
+	repo          StorageRepository
+	channels      grpcChannelsV1.ChannelsServiceClient
+	settings      pkgDomains.Settings
+	checkInterval time.Duration
+	logger        *slog.Logger
+}
+
+func NewRetentionHandler(ctx context.Context, repo StorageRepository, channels grpcChannelsV1.ChannelsServiceClient, settings pkgDomains.Settings, checkInterval time.Duration, logger *slog.Logger) {
+	handler := &retentionHandler{
+		repo:          repo,
+		channels:      channels,
+		settings:      settings,
+		checkInterval: checkInterval,
+		logger:        logger,
+	}
+
+	go func() {
+		ticker := time.NewTicker(handler.checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				handler.handle(ctx)
+			}
+		}
+	}()
+}
+
+func (h *retentionHandler) handle(ctx context.Context) {
+	chIDs, err := h.repo.Channels(ctx)
+	if err != nil {
+		h.logger.Error("failed to retrieve channels with stored messages", slog.Any("error", err))
+		return
+	}
+
+	for _, chID := range chIDs {
+		res, err := h.channels.RetrieveEntity(ctx, &grpcCommonV1.RetrieveEntityReq{Id: chID})
+		if err != nil {
+			h.logger.Error("failed to retrieve channel", slog.String("channel_id", chID), slog.Any("error", err))
+			continue
+		}
+		domainID := res.GetEntity().GetDomainId()
+		s, err := h.settings.RetrieveSettings(ctx, domainID)
+		if err != nil {
+			h.logger.Error("failed to retrieve domain settings", slog.String("domain_id", domainID), slog.Any("error", err))
+			continue
+		}
+		retention := s.MessageRetention()
+		if retention == 0 {
+			continue
+		}
+
+		size, err := h.repo.RemoveBefore(ctx, chID, time.Now().Add(-retention))
+		if err != nil {
+			h.logger.Error("failed to remove expired messages", slog.String("channel_id", chID), slog.Any("error", err))
+			continue
+		}
+		if size == 0 {
+			continue
+		}
+		h.logger.Info("expired messages removed", slog.String("channel_id", chID), slog.Uint64("size", size))
+		if err := h.repo.UpdateUsage(ctx, domainID, -int64(size)); err != nil {
+			h.logger.Error("failed to update domain storage usage", slog.String("domain_id", domainID), slog.Any("error", err))
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"context"
+	"time"
+)
+
+// StorageRepository specifies the API of the message storage used to enforce
+// the domain storage quota and message retention.
+//
+//go:generate mockery --name StorageRepository --output=./mocks --filename storage.go --quiet --note "Copyright (c) Abstract Machines"
+type StorageRepository interface {
+	// Channels returns the IDs of the channels with the stored messages.
+	Channels(ctx context.Context) ([]string, error)
+
+	// RemoveBefore removes the messages of the channel created before the
+	// given time and returns the size of the removed messages in bytes.
+	RemoveBefore(ctx context.Context, channelID string, before time.Time) (uint64, error)
+
+	// Usage returns the size of the messages stored in the domain in bytes.
+	Usage(ctx context.Context, domainID string) (uint64, error)
+
+	// UpdateUsage changes the size of the messages stored in the domain by
+	// the given number of bytes.
+	UpdateUsage(ctx context.Context, domainID string, delta int64) error
+}
@@ -0,0 +1,157 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/consumers/webhooks"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func createWebhookEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createWebhookReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		wh := webhooks.Webhook{
+			ChannelID: req.ChannelID,
+			Name:      req.Name,
+			URL:       req.URL,
+			Headers:   req.Headers,
+			Secret:    req.Secret,
+			Template:  req.Template,
+		}
+		saved, err := svc.CreateWebhook(ctx, session, wh)
+		if err != nil {
+			return nil, err
+		}
+
+		return webhookRes{Webhook: saved, created: true}, nil
+	}
+}
+
+func viewWebhookEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewWebhookReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		wh, err := svc.ViewWebhook(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return webhookRes{Webhook: wh}, nil
+	}
+}
+
+func listWebhooksEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listWebhooksReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListWebhooks(ctx, session, req.pm)
+		if err != nil {
+			return nil, err
+		}
+
+		return webhooksPageRes{WebhooksPage: page}, nil
+	}
+}
+
+func updateWebhookEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateWebhookReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		wh := webhooks.Webhook{
+			ID:       req.id,
+			Name:     req.Name,
+			URL:      req.URL,
+			Headers:  req.Headers,
+			Secret:   req.Secret,
+			Template: req.Template,
+		}
+		updated, err := svc.UpdateWebhook(ctx, session, wh)
+		if err != nil {
+			return nil, err
+		}
+
+		return webhookRes{Webhook: updated}, nil
+	}
+}
+
+func removeWebhookEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewWebhookReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		if err := svc.RemoveWebhook(ctx, session, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeWebhookRes{}, nil
+	}
+}
+
+func listDeliveriesEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listDeliveriesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListDeliveries(ctx, session, req.webhookID, req.pm)
+		if err != nil {
+			return nil, err
+		}
+
+		return deliveriesPageRes{DeliveriesPage: page}, nil
+	}
+}
@@ -0,0 +1,621 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/consumers/webhooks"
+	"github.com/absmach/supermq/consumers/webhooks/api"
+	"github.com/absmach/supermq/consumers/webhooks/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	validToken   = "valid"
+	invalidToken = "invalid"
+	contentType  = "application/json"
+	instanceID   = "5de9b29a-feb9-11ed-be56-0242ac120002"
+)
+
+var (
+	validID   = testsutil.GenerateUUID(&testing.T{})
+	domainID  = testsutil.GenerateUUID(&testing.T{})
+	channelID = testsutil.GenerateUUID(&testing.T{})
+	session   = smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID}
+	webhook   = webhooks.Webhook{
+		ID:        testsutil.GenerateUUID(&testing.T{}),
+		DomainID:  domainID,
+		ChannelID: channelID,
+		Name:      "webhook",
+		URL:       "https://example.com/hook",
+	}
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	token       string
+	contentType string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+func newWebhooksServer() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	mux := api.MakeHandler(svc, authn, smqlog.NewMock(), "webhooks", instanceID)
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func toJSON(data interface{}) string {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return string(jsonData)
+}
+
+func TestCreateWebhook(t *testing.T) {
+	ws, svc, authn := newWebhooksServer()
+	defer ws.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		authnRes    smqauthn.Session
+		authnErr    error
+		svcRes      webhooks.Webhook
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "create webhook successfully",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"channel_id": "%s", "name": "webhook", "url": "https://example.com/hook"}`, channelID),
+			contentType: contentType,
+			authnRes:    session,
+			svcRes:      webhook,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create webhook with invalid token",
+			token:       invalidToken,
+			data:        fmt.Sprintf(`{"channel_id": "%s", "url": "https://example.com/hook"}`, channelID),
+			contentType: contentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create webhook with empty token",
+			data:        fmt.Sprintf(`{"channel_id": "%s", "url": "https://example.com/hook"}`, channelID),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create webhook without channel ID",
+			token:       validToken,
+			data:        `{"url": "https://example.com/hook"}`,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook without URL",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"channel_id": "%s"}`, channelID),
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook with too long name",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"channel_id": "%s", "name": "%s", "url": "https://example.com/hook"}`, channelID, strings.Repeat("a", 1025)),
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook with invalid content type",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"channel_id": "%s", "url": "https://example.com/hook"}`, channelID),
+			contentType: "text/plain",
+			authnRes:    session,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create webhook with malformed body",
+			token:       validToken,
+			data:        `{"channel_id": `,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook with service error",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"channel_id": "%s", "url": "https://example.com/hook"}`, channelID),
+			contentType: contentType,
+			authnRes:    session,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("CreateWebhook", mock.Anything, tc.authnRes, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client:      ws.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/webhooks/", ws.URL, domainID),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusCreated {
+				location := fmt.Sprintf("/%s/webhooks/%s", tc.svcRes.DomainID, tc.svcRes.ID)
+				assert.Equal(t, location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, location, res.Header.Get("Location")))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestViewWebhook(t *testing.T) {
+	ws, svc, authn := newWebhooksServer()
+	defer ws.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnRes smqauthn.Session
+		authnErr error
+		svcRes   webhooks.Webhook
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "view webhook successfully",
+			token:    validToken,
+			id:       webhook.ID,
+			authnRes: session,
+			svcRes:   webhook,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "view webhook with invalid token",
+			token:    invalidToken,
+			id:       webhook.ID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "view non-existing webhook",
+			token:    validToken,
+			id:       validID,
+			authnRes: session,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+		{
+			desc:     "view webhook with service error",
+			token:    validToken,
+			id:       webhook.ID,
+			authnRes: session,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ViewWebhook", mock.Anything, tc.authnRes, tc.id).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: ws.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/webhooks/%s", ws.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody webhooks.Webhook
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.ID, resBody.ID, fmt.Sprintf("%s: expected webhook %s got %s", tc.desc, tc.svcRes.ID, resBody.ID))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListWebhooks(t *testing.T) {
+	ws, svc, authn := newWebhooksServer()
+	defer ws.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		authnRes smqauthn.Session
+		authnErr error
+		pm       webhooks.PageMeta
+		svcRes   webhooks.WebhooksPage
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list webhooks successfully",
+			token:    validToken,
+			authnRes: session,
+			pm:       webhooks.PageMeta{Limit: 10},
+			svcRes:   webhooks.WebhooksPage{Total: 1, Limit: 10, Webhooks: []webhooks.Webhook{webhook}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list webhooks of channel",
+			token:    validToken,
+			query:    "channel_id=" + channelID,
+			authnRes: session,
+			pm:       webhooks.PageMeta{Limit: 10, ChannelID: channelID},
+			svcRes:   webhooks.WebhooksPage{Total: 1, Limit: 10, Webhooks: []webhooks.Webhook{webhook}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list webhooks with offset and limit",
+			token:    validToken,
+			query:    "offset=1&limit=5",
+			authnRes: session,
+			pm:       webhooks.PageMeta{Offset: 1, Limit: 5},
+			svcRes:   webhooks.WebhooksPage{Total: 1, Offset: 1, Limit: 5},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list webhooks with invalid token",
+			token:    invalidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list webhooks with invalid offset",
+			token:    validToken,
+			query:    "offset=invalid",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list webhooks with invalid limit",
+			token:    validToken,
+			query:    "limit=invalid",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list webhooks with limit exceeding maximum",
+			token:    validToken,
+			query:    "limit=1000",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list webhooks with service error",
+			token:    validToken,
+			authnRes: session,
+			pm:       webhooks.PageMeta{Limit: 10},
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ListWebhooks", mock.Anything, tc.authnRes, tc.pm).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: ws.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/webhooks/?%s", ws.URL, domainID, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody webhooks.WebhooksPage
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.Total, resBody.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.svcRes.Total, resBody.Total))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	ws, svc, authn := newWebhooksServer()
+	defer ws.Close()
+
+	updated := webhook
+	updated.URL = "https://example.com/updated"
+
+	cases := []struct {
+		desc        string
+		token       string
+		id          string
+		data        string
+		contentType string
+		authnRes    smqauthn.Session
+		authnErr    error
+		svcRes      webhooks.Webhook
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "update webhook successfully",
+			token:       validToken,
+			id:          webhook.ID,
+			data:        toJSON(map[string]string{"url": updated.URL}),
+			contentType: contentType,
+			authnRes:    session,
+			svcRes:      updated,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update webhook with invalid token",
+			token:       invalidToken,
+			id:          webhook.ID,
+			data:        toJSON(map[string]string{"url": updated.URL}),
+			contentType: contentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "update webhook without URL",
+			token:       validToken,
+			id:          webhook.ID,
+			data:        `{"name": "webhook"}`,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update webhook with invalid content type",
+			token:       validToken,
+			id:          webhook.ID,
+			data:        toJSON(map[string]string{"url": updated.URL}),
+			contentType: "text/plain",
+			authnRes:    session,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "update webhook with malformed body",
+			token:       validToken,
+			id:          webhook.ID,
+			data:        `{"url": `,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update non-existing webhook",
+			token:       validToken,
+			id:          validID,
+			data:        toJSON(map[string]string{"url": updated.URL}),
+			contentType: contentType,
+			authnRes:    session,
+			svcErr:      svcerr.ErrNotFound,
+			status:      http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("UpdateWebhook", mock.Anything, tc.authnRes, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client:      ws.Client(),
+				method:      http.MethodPut,
+				url:         fmt.Sprintf("%s/%s/webhooks/%s", ws.URL, domainID, tc.id),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestRemoveWebhook(t *testing.T) {
+	ws, svc, authn := newWebhooksServer()
+	defer ws.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnRes smqauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "remove webhook successfully",
+			token:    validToken,
+			id:       webhook.ID,
+			authnRes: session,
+			status:   http.StatusNoContent,
+		},
+		{
+			desc:     "remove webhook with invalid token",
+			token:    invalidToken,
+			id:       webhook.ID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "remove webhook with service error",
+			token:    validToken,
+			id:       webhook.ID,
+			authnRes: session,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("RemoveWebhook", mock.Anything, tc.authnRes, tc.id).Return(tc.svcErr)
+			req := testRequest{
+				client: ws.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/%s/webhooks/%s", ws.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListDeliveries(t *testing.T) {
+	ws, svc, authn := newWebhooksServer()
+	defer ws.Close()
+
+	delivery := webhooks.Delivery{ID: validID, WebhookID: webhook.ID, DomainID: domainID, ChannelID: channelID, Status: webhooks.FailedStatus, Attempts: 3}
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		authnRes smqauthn.Session
+		authnErr error
+		pm       webhooks.PageMeta
+		svcRes   webhooks.DeliveriesPage
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list deliveries successfully",
+			token:    validToken,
+			authnRes: session,
+			pm:       webhooks.PageMeta{Limit: 10, Status: webhooks.AllStatus},
+			svcRes:   webhooks.DeliveriesPage{Total: 1, Limit: 10, Deliveries: []webhooks.Delivery{delivery}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list failed deliveries",
+			token:    validToken,
+			query:    "status=failed",
+			authnRes: session,
+			pm:       webhooks.PageMeta{Limit: 10, Status: webhooks.FailedStatus},
+			svcRes:   webhooks.DeliveriesPage{Total: 1, Limit: 10, Deliveries: []webhooks.Delivery{delivery}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list deliveries with invalid status",
+			token:    validToken,
+			query:    "status=invalid",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list deliveries with invalid limit",
+			token:    validToken,
+			query:    "limit=0",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list deliveries with invalid token",
+			token:    invalidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list deliveries with service error",
+			token:    validToken,
+			authnRes: session,
+			pm:       webhooks.PageMeta{Limit: 10, Status: webhooks.AllStatus},
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ListDeliveries", mock.Anything, tc.authnRes, webhook.ID, tc.pm).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: ws.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/webhooks/%s/deliveries?%s", ws.URL, domainID, webhook.ID, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody webhooks.DeliveriesPage
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.Deliveries, resBody.Deliveries, fmt.Sprintf("%s: expected deliveries %v got %v", tc.desc, tc.svcRes.Deliveries, resBody.Deliveries))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
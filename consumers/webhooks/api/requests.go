@@ -0,0 +1,97 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/consumers/webhooks"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+type createWebhookReq struct {
+	ChannelID string            `json:"channel_id"`
+	Name      string            `json:"name,omitempty"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Secret    string            `json:"secret,omitempty"`
+	Template  string            `json:"payload_template,omitempty"`
+}
+
+func (req createWebhookReq) validate() error {
+	if req.ChannelID == "" {
+		return apiutil.ErrMissingChannelID
+	}
+	if len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	if req.URL == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, webhooks.ErrInvalidURL)
+	}
+
+	return nil
+}
+
+type viewWebhookReq struct {
+	id string
+}
+
+func (req viewWebhookReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listWebhooksReq struct {
+	pm webhooks.PageMeta
+}
+
+func (req listWebhooksReq) validate() error {
+	if req.pm.Limit > api.MaxLimitSize || req.pm.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type updateWebhookReq struct {
+	id       string
+	Name     string            `json:"name,omitempty"`
+	URL      string            `json:"url"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Secret   string            `json:"secret,omitempty"`
+	Template string            `json:"payload_template,omitempty"`
+}
+
+func (req updateWebhookReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	if req.URL == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, webhooks.ErrInvalidURL)
+	}
+
+	return nil
+}
+
+type listDeliveriesReq struct {
+	webhookID string
+	pm        webhooks.PageMeta
+}
+
+func (req listDeliveriesReq) validate() error {
+	if req.webhookID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.pm.Limit > api.MaxLimitSize || req.pm.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/consumers/webhooks"
+)
+
+var (
+	_ supermq.Response = (*webhookRes)(nil)
+	_ supermq.Response = (*webhooksPageRes)(nil)
+	_ supermq.Response = (*removeWebhookRes)(nil)
+	_ supermq.Response = (*deliveriesPageRes)(nil)
+)
+
+type webhookRes struct {
+	webhooks.Webhook `json:",inline"`
+	created          bool
+}
+
+func (res webhookRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res webhookRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/%s/webhooks/%s", res.DomainID, res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res webhookRes) Empty() bool {
+	return false
+}
+
+type webhooksPageRes struct {
+	webhooks.WebhooksPage `json:",inline"`
+}
+
+func (res webhooksPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res webhooksPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res webhooksPageRes) Empty() bool {
+	return false
+}
+
+type removeWebhookRes struct{}
+
+func (res removeWebhookRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeWebhookRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeWebhookRes) Empty() bool {
+	return true
+}
+
+type deliveriesPageRes struct {
+	webhooks.DeliveriesPage `json:",inline"`
+}
+
+func (res deliveriesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res deliveriesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deliveriesPageRes) Empty() bool {
+	return false
+}
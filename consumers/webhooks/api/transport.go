@@ -0,0 +1,171 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/supermq"
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/consumers/webhooks"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	channelIDKey = "channel_id"
+	statusKey    = "status"
+)
+
+// MakeHandler returns a HTTP API handler with health check and metrics.
+func MakeHandler(svc webhooks.Service, authn smqauthn.Authentication, logger *slog.Logger, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Route("/{domainID}/webhooks", func(r chi.Router) {
+		r.Use(api.AuthenticateMiddleware(authn, true))
+
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			createWebhookEndpoint(svc),
+			decodeCreateWebhookReq,
+			api.EncodeResponse,
+			opts...,
+		), "create_webhook").ServeHTTP)
+
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listWebhooksEndpoint(svc),
+			decodeListWebhooksReq,
+			api.EncodeResponse,
+			opts...,
+		), "list_webhooks").ServeHTTP)
+
+		r.Route("/{webhookID}", func(r chi.Router) {
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				viewWebhookEndpoint(svc),
+				decodeViewWebhookReq,
+				api.EncodeResponse,
+				opts...,
+			), "view_webhook").ServeHTTP)
+
+			r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+				updateWebhookEndpoint(svc),
+				decodeUpdateWebhookReq,
+				api.EncodeResponse,
+				opts...,
+			), "update_webhook").ServeHTTP)
+
+			r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+				removeWebhookEndpoint(svc),
+				decodeViewWebhookReq,
+				api.EncodeResponse,
+				opts...,
+			), "remove_webhook").ServeHTTP)
+
+			r.Get("/deliveries", otelhttp.NewHandler(kithttp.NewServer(
+				listDeliveriesEndpoint(svc),
+				decodeListDeliveriesReq,
+				api.EncodeResponse,
+				opts...,
+			), "list_webhook_deliveries").ServeHTTP)
+		})
+	})
+
+	mux.Get("/health", supermq.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeCreateWebhookReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := createWebhookReq{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeViewWebhookReq(_ context.Context, r *http.Request) (interface{}, error) {
+	return viewWebhookReq{id: chi.URLParam(r, "webhookID")}, nil
+}
+
+func decodeListWebhooksReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	chID, err := apiutil.ReadStringQuery(r, channelIDKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listWebhooksReq{
+		pm: webhooks.PageMeta{
+			Offset:    offset,
+			Limit:     limit,
+			ChannelID: chID,
+		},
+	}, nil
+}
+
+func decodeUpdateWebhookReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := updateWebhookReq{id: chi.URLParam(r, "webhookID")}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeListDeliveriesReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	s, err := apiutil.ReadStringQuery(r, statusKey, webhooks.All)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	status, err := webhooks.ToDeliveryStatus(s)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listDeliveriesReq{
+		webhookID: chi.URLParam(r, "webhookID"),
+		pm: webhooks.PageMeta{
+			Offset: offset,
+			Limit:  limit,
+			Status: status,
+		},
+	}, nil
+}
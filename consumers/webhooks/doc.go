@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhooks contains the webhooks consumer service.
+// This service forwards messages received from the message broker to
+// per-channel HTTP endpoints and keeps a history of delivery attempts.
+package webhooks
@@ -0,0 +1,129 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/consumers/webhooks"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/policies"
+)
+
+var (
+	_ webhooks.Service = (*authorizationMiddleware)(nil)
+
+	readPermission   = "read_permission"
+	updatePermission = "update_permission"
+)
+
+type authorizationMiddleware struct {
+	svc   webhooks.Service
+	repo  webhooks.Repository
+	authz smqauthz.Authorization
+}
+
+// AuthorizationMiddleware adds authorization to the webhooks service.
+// Managing webhooks requires update permission on the channel, while
+// viewing them and their delivery history requires read permission.
+// Listing webhooks of the whole domain requires domain admin permission.
+func AuthorizationMiddleware(svc webhooks.Service, repo webhooks.Repository, authz smqauthz.Authorization) webhooks.Service {
+	return &authorizationMiddleware{
+		svc:   svc,
+		repo:  repo,
+		authz: authz,
+	}
+}
+
+func (am *authorizationMiddleware) ConsumeBlocking(ctx context.Context, messages interface{}) error {
+	return am.svc.ConsumeBlocking(ctx, messages)
+}
+
+func (am *authorizationMiddleware) CreateWebhook(ctx context.Context, session smqauthn.Session, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	if err := am.authorizeChannel(ctx, session, wh.ChannelID, updatePermission); err != nil {
+		return webhooks.Webhook{}, err
+	}
+
+	return am.svc.CreateWebhook(ctx, session, wh)
+}
+
+func (am *authorizationMiddleware) ViewWebhook(ctx context.Context, session smqauthn.Session, id string) (webhooks.Webhook, error) {
+	if err := am.authorizeWebhook(ctx, session, id, readPermission); err != nil {
+		return webhooks.Webhook{}, err
+	}
+
+	return am.svc.ViewWebhook(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListWebhooks(ctx context.Context, session smqauthn.Session, pm webhooks.PageMeta) (webhooks.WebhooksPage, error) {
+	if pm.ChannelID != "" {
+		if err := am.authorizeChannel(ctx, session, pm.ChannelID, readPermission); err != nil {
+			return webhooks.WebhooksPage{}, err
+		}
+
+		return am.svc.ListWebhooks(ctx, session, pm)
+	}
+
+	if err := am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  policies.AdminPermission,
+		ObjectType:  policies.DomainType,
+		Object:      session.DomainID,
+	}); err != nil {
+		return webhooks.WebhooksPage{}, err
+	}
+
+	return am.svc.ListWebhooks(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) UpdateWebhook(ctx context.Context, session smqauthn.Session, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	if err := am.authorizeWebhook(ctx, session, wh.ID, updatePermission); err != nil {
+		return webhooks.Webhook{}, err
+	}
+
+	return am.svc.UpdateWebhook(ctx, session, wh)
+}
+
+func (am *authorizationMiddleware) RemoveWebhook(ctx context.Context, session smqauthn.Session, id string) error {
+	if err := am.authorizeWebhook(ctx, session, id, updatePermission); err != nil {
+		return err
+	}
+
+	return am.svc.RemoveWebhook(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListDeliveries(ctx context.Context, session smqauthn.Session, webhookID string, pm webhooks.PageMeta) (webhooks.DeliveriesPage, error) {
+	if err := am.authorizeWebhook(ctx, session, webhookID, readPermission); err != nil {
+		return webhooks.DeliveriesPage{}, err
+	}
+
+	return am.svc.ListDeliveries(ctx, session, webhookID, pm)
+}
+
+func (am *authorizationMiddleware) authorizeWebhook(ctx context.Context, session smqauthn.Session, id, permission string) error {
+	wh, err := am.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return am.authorizeChannel(ctx, session, wh.ChannelID, permission)
+}
+
+func (am *authorizationMiddleware) authorizeChannel(ctx context.Context, session smqauthn.Session, channelID, permission string) error {
+	return am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  permission,
+		ObjectType:  policies.ChannelType,
+		Object:      channelID,
+	})
+}
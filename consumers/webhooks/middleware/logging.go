@@ -0,0 +1,166 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/consumers/webhooks"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+)
+
+var _ webhooks.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    webhooks.Service
+}
+
+// LoggingMiddleware adds logging facilities to the webhooks service.
+func LoggingMiddleware(svc webhooks.Service, logger *slog.Logger) webhooks.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm *loggingMiddleware) ConsumeBlocking(ctx context.Context, msgs interface{}) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Blocking consumer failed to forward messages to webhooks", args...)
+			return
+		}
+		lm.logger.Info("Blocking consumer forwarded messages to webhooks successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ConsumeBlocking(ctx, msgs)
+}
+
+func (lm *loggingMiddleware) CreateWebhook(ctx context.Context, session smqauthn.Session, wh webhooks.Webhook) (res webhooks.Webhook, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("webhook",
+				slog.String("id", res.ID),
+				slog.String("channel_id", wh.ChannelID),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create webhook failed", args...)
+			return
+		}
+		lm.logger.Info("Create webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateWebhook(ctx, session, wh)
+}
+
+func (lm *loggingMiddleware) ViewWebhook(ctx context.Context, session smqauthn.Session, id string) (res webhooks.Webhook, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("webhook_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View webhook failed", args...)
+			return
+		}
+		lm.logger.Info("View webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewWebhook(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListWebhooks(ctx context.Context, session smqauthn.Session, pm webhooks.PageMeta) (res webhooks.WebhooksPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("page",
+				slog.String("channel_id", pm.ChannelID),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", res.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List webhooks failed", args...)
+			return
+		}
+		lm.logger.Info("List webhooks completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListWebhooks(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) UpdateWebhook(ctx context.Context, session smqauthn.Session, wh webhooks.Webhook) (res webhooks.Webhook, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("webhook_id", wh.ID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update webhook failed", args...)
+			return
+		}
+		lm.logger.Info("Update webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UpdateWebhook(ctx, session, wh)
+}
+
+func (lm *loggingMiddleware) RemoveWebhook(ctx context.Context, session smqauthn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("webhook_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove webhook failed", args...)
+			return
+		}
+		lm.logger.Info("Remove webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveWebhook(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListDeliveries(ctx context.Context, session smqauthn.Session, webhookID string, pm webhooks.PageMeta) (res webhooks.DeliveriesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("webhook_id", webhookID),
+			slog.Group("page",
+				slog.String("status", pm.Status.String()),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", res.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List webhook deliveries failed", args...)
+			return
+		}
+		lm.logger.Info("List webhook deliveries completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListDeliveries(ctx, session, webhookID, pm)
+}
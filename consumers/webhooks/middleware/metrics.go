@@ -0,0 +1,93 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/consumers/webhooks"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ webhooks.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     webhooks.Service
+}
+
+// MetricsMiddleware instruments the webhooks service by tracking request count and latency.
+func MetricsMiddleware(svc webhooks.Service, counter metrics.Counter, latency metrics.Histogram) webhooks.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) ConsumeBlocking(ctx context.Context, msgs interface{}) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "consume").Add(1)
+		mm.latency.With("method", "consume").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ConsumeBlocking(ctx, msgs)
+}
+
+func (mm *metricsMiddleware) CreateWebhook(ctx context.Context, session smqauthn.Session, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_webhook").Add(1)
+		mm.latency.With("method", "create_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateWebhook(ctx, session, wh)
+}
+
+func (mm *metricsMiddleware) ViewWebhook(ctx context.Context, session smqauthn.Session, id string) (webhooks.Webhook, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_webhook").Add(1)
+		mm.latency.With("method", "view_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewWebhook(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListWebhooks(ctx context.Context, session smqauthn.Session, pm webhooks.PageMeta) (webhooks.WebhooksPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_webhooks").Add(1)
+		mm.latency.With("method", "list_webhooks").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListWebhooks(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) UpdateWebhook(ctx context.Context, session smqauthn.Session, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_webhook").Add(1)
+		mm.latency.With("method", "update_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UpdateWebhook(ctx, session, wh)
+}
+
+func (mm *metricsMiddleware) RemoveWebhook(ctx context.Context, session smqauthn.Session, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_webhook").Add(1)
+		mm.latency.With("method", "remove_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveWebhook(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListDeliveries(ctx context.Context, session smqauthn.Session, webhookID string, pm webhooks.PageMeta) (webhooks.DeliveriesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_deliveries").Add(1)
+		mm.latency.With("method", "list_deliveries").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListDeliveries(ctx, session, webhookID, pm)
+}
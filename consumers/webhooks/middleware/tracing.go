@@ -0,0 +1,97 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/consumers/webhooks"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ webhooks.Service = (*tracing)(nil)
+
+type tracing struct {
+	tracer trace.Tracer
+	svc    webhooks.Service
+}
+
+// Tracing adds tracing to the webhooks service.
+func Tracing(svc webhooks.Service, tracer trace.Tracer) webhooks.Service {
+	return &tracing{tracer, svc}
+}
+
+func (tm *tracing) ConsumeBlocking(ctx context.Context, msgs interface{}) error {
+	ctx, span := tm.tracer.Start(ctx, "consume_blocking")
+	defer span.End()
+
+	return tm.svc.ConsumeBlocking(ctx, msgs)
+}
+
+func (tm *tracing) CreateWebhook(ctx context.Context, session smqauthn.Session, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	ctx, span := tm.tracer.Start(ctx, "create_webhook", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("channel_id", wh.ChannelID),
+	))
+	defer span.End()
+
+	return tm.svc.CreateWebhook(ctx, session, wh)
+}
+
+func (tm *tracing) ViewWebhook(ctx context.Context, session smqauthn.Session, id string) (webhooks.Webhook, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_webhook", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewWebhook(ctx, session, id)
+}
+
+func (tm *tracing) ListWebhooks(ctx context.Context, session smqauthn.Session, pm webhooks.PageMeta) (webhooks.WebhooksPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_webhooks", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("channel_id", pm.ChannelID),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListWebhooks(ctx, session, pm)
+}
+
+func (tm *tracing) UpdateWebhook(ctx context.Context, session smqauthn.Session, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	ctx, span := tm.tracer.Start(ctx, "update_webhook", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("id", wh.ID),
+	))
+	defer span.End()
+
+	return tm.svc.UpdateWebhook(ctx, session, wh)
+}
+
+func (tm *tracing) RemoveWebhook(ctx context.Context, session smqauthn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "remove_webhook", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.RemoveWebhook(ctx, session, id)
+}
+
+func (tm *tracing) ListDeliveries(ctx context.Context, session smqauthn.Session, webhookID string, pm webhooks.PageMeta) (webhooks.DeliveriesPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_deliveries", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("webhook_id", webhookID),
+		attribute.String("status", pm.Status.String()),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListDeliveries(ctx, session, webhookID, pm)
+}
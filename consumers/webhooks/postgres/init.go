@@ -0,0 +1,54 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "webhooks_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS webhooks (
+						id          VARCHAR(36) PRIMARY KEY,
+						domain_id   VARCHAR(36) NOT NULL,
+						channel_id  VARCHAR(36) NOT NULL,
+						name        VARCHAR(1024),
+						url         TEXT NOT NULL,
+						headers     JSONB,
+						secret      TEXT,
+						template    TEXT,
+						created_by  VARCHAR(254),
+						created_at  TIMESTAMP NOT NULL,
+						updated_by  VARCHAR(254),
+						updated_at  TIMESTAMP
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_webhooks_channel ON webhooks(channel_id);`,
+					`CREATE TABLE IF NOT EXISTS deliveries (
+						id            VARCHAR(36) PRIMARY KEY,
+						webhook_id    VARCHAR(36) NOT NULL,
+						domain_id     VARCHAR(36) NOT NULL,
+						channel_id    VARCHAR(36) NOT NULL,
+						subtopic      VARCHAR(1024),
+						status        SMALLINT NOT NULL,
+						attempts      BIGINT NOT NULL DEFAULT 0,
+						response_code INTEGER,
+						error         TEXT,
+						created_at    TIMESTAMP NOT NULL,
+						FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE ON UPDATE CASCADE
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_deliveries_webhook ON deliveries(webhook_id, created_at DESC);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS deliveries`,
+					`DROP TABLE IF EXISTS webhooks`,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/consumers/webhooks"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/jmoiron/sqlx"
+)
+
+const webhookColumns = "id, domain_id, channel_id, name, url, headers, secret, template, created_by, created_at, updated_by, updated_at"
+
+type repository struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of webhooks repository.
+func NewRepository(db postgres.Database) webhooks.Repository {
+	return &repository{db: db}
+}
+
+func (repo *repository) Save(ctx context.Context, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	q := fmt.Sprintf(`INSERT INTO webhooks (%s)
+		VALUES (:id, :domain_id, :channel_id, :name, :url, :headers, :secret, :template, :created_by, :created_at, :updated_by, :updated_at)
+		RETURNING %s`, webhookColumns, webhookColumns)
+
+	dbwh, err := toDBWebhook(wh)
+	if err != nil {
+		return webhooks.Webhook{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return repo.namedQueryOne(ctx, q, dbwh, repoerr.ErrCreateEntity)
+}
+
+func (repo *repository) RetrieveByID(ctx context.Context, id string) (webhooks.Webhook, error) {
+	q := fmt.Sprintf(`SELECT %s FROM webhooks WHERE id = :id`, webhookColumns)
+
+	return repo.namedQueryOne(ctx, q, dbWebhook{ID: id}, repoerr.ErrViewEntity)
+}
+
+func (repo *repository) RetrieveByChannel(ctx context.Context, channelID string) ([]webhooks.Webhook, error) {
+	q := fmt.Sprintf(`SELECT %s FROM webhooks WHERE channel_id = :channel_id ORDER BY created_at`, webhookColumns)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbWebhook{ChannelID: channelID})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	return scanWebhooks(rows)
+}
+
+func (repo *repository) RetrieveAll(ctx context.Context, pm webhooks.PageMeta) (webhooks.WebhooksPage, error) {
+	query := webhooksQuery(pm)
+	q := fmt.Sprintf(`SELECT %s FROM webhooks %s ORDER BY created_at LIMIT :limit OFFSET :offset`, webhookColumns, query)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return webhooks.WebhooksPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	items, err := scanWebhooks(rows)
+	if err != nil {
+		return webhooks.WebhooksPage{}, err
+	}
+
+	tq := fmt.Sprintf(`SELECT COUNT(*) FROM webhooks %s`, query)
+	total, err := postgres.Total(ctx, repo.db, tq, pm)
+	if err != nil {
+		return webhooks.WebhooksPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return webhooks.WebhooksPage{
+		Total:    total,
+		Offset:   pm.Offset,
+		Limit:    pm.Limit,
+		Webhooks: items,
+	}, nil
+}
+
+func (repo *repository) Update(ctx context.Context, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	q := fmt.Sprintf(`UPDATE webhooks SET name = :name, url = :url, headers = :headers, secret = COALESCE(:secret, secret),
+		template = :template, updated_by = :updated_by, updated_at = :updated_at
+		WHERE id = :id RETURNING %s`, webhookColumns)
+
+	dbwh, err := toDBWebhook(wh)
+	if err != nil {
+		return webhooks.Webhook{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return repo.namedQueryOne(ctx, q, dbwh, repoerr.ErrUpdateEntity)
+}
+
+func (repo *repository) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM webhooks WHERE id = $1`
+
+	result, err := repo.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) SaveDelivery(ctx context.Context, d webhooks.Delivery) error {
+	q := `INSERT INTO deliveries (id, webhook_id, domain_id, channel_id, subtopic, status, attempts, response_code, error, created_at)
+		VALUES (:id, :webhook_id, :domain_id, :channel_id, :subtopic, :status, :attempts, :response_code, :error, :created_at)`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBDelivery(d)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveDeliveries(ctx context.Context, pm webhooks.PageMeta) (webhooks.DeliveriesPage, error) {
+	query := deliveriesQuery(pm)
+	q := fmt.Sprintf(`SELECT id, webhook_id, domain_id, channel_id, subtopic, status, attempts, response_code, error, created_at
+		FROM deliveries %s ORDER BY created_at DESC LIMIT :limit OFFSET :offset`, query)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return webhooks.DeliveriesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []webhooks.Delivery
+	for rows.Next() {
+		var dbd dbDelivery
+		if err := rows.StructScan(&dbd); err != nil {
+			return webhooks.DeliveriesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		items = append(items, toDelivery(dbd))
+	}
+
+	tq := fmt.Sprintf(`SELECT COUNT(*) FROM deliveries %s`, query)
+	total, err := postgres.Total(ctx, repo.db, tq, pm)
+	if err != nil {
+		return webhooks.DeliveriesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return webhooks.DeliveriesPage{
+		Total:      total,
+		Offset:     pm.Offset,
+		Limit:      pm.Limit,
+		Deliveries: items,
+	}, nil
+}
+
+func (repo *repository) namedQueryOne(ctx context.Context, q string, arg interface{}, wrapper error) (webhooks.Webhook, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, arg)
+	if err != nil {
+		return webhooks.Webhook{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return webhooks.Webhook{}, repoerr.ErrNotFound
+	}
+	var dbwh dbWebhook
+	if err := rows.StructScan(&dbwh); err != nil {
+		return webhooks.Webhook{}, postgres.HandleError(wrapper, err)
+	}
+
+	return toWebhook(dbwh)
+}
+
+func scanWebhooks(rows *sqlx.Rows) ([]webhooks.Webhook, error) {
+	var items []webhooks.Webhook
+	for rows.Next() {
+		var dbwh dbWebhook
+		if err := rows.StructScan(&dbwh); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		wh, err := toWebhook(dbwh)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, wh)
+	}
+
+	return items, nil
+}
+
+func webhooksQuery(pm webhooks.PageMeta) string {
+	var query []string
+	if pm.DomainID != "" {
+		query = append(query, "domain_id = :domain_id")
+	}
+	if pm.ChannelID != "" {
+		query = append(query, "channel_id = :channel_id")
+	}
+	if len(query) > 0 {
+		return fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+	}
+
+	return ""
+}
+
+func deliveriesQuery(pm webhooks.PageMeta) string {
+	var query []string
+	if pm.WebhookID != "" {
+		query = append(query, "webhook_id = :webhook_id")
+	}
+	if pm.ChannelID != "" {
+		query = append(query, "channel_id = :channel_id")
+	}
+	if pm.Status != webhooks.AllStatus {
+		query = append(query, "status = :status")
+	}
+	if len(query) > 0 {
+		return fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+	}
+
+	return ""
+}
+
+type dbWebhook struct {
+	ID        string         `db:"id"`
+	DomainID  string         `db:"domain_id"`
+	ChannelID string         `db:"channel_id"`
+	Name      sql.NullString `db:"name"`
+	URL       string         `db:"url"`
+	Headers   []byte         `db:"headers"`
+	Secret    sql.NullString `db:"secret"`
+	Template  sql.NullString `db:"template"`
+	CreatedBy sql.NullString `db:"created_by"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedBy sql.NullString `db:"updated_by"`
+	UpdatedAt sql.NullTime   `db:"updated_at"`
+}
+
+func toDBWebhook(wh webhooks.Webhook) (dbWebhook, error) {
+	var headers []byte
+	if len(wh.Headers) > 0 {
+		b, err := json.Marshal(wh.Headers)
+		if err != nil {
+			return dbWebhook{}, err
+		}
+		headers = b
+	}
+
+	return dbWebhook{
+		ID:        wh.ID,
+		DomainID:  wh.DomainID,
+		ChannelID: wh.ChannelID,
+		Name:      toNullString(wh.Name),
+		URL:       wh.URL,
+		Headers:   headers,
+		Secret:    toNullString(wh.Secret),
+		Template:  toNullString(wh.Template),
+		CreatedBy: toNullString(wh.CreatedBy),
+		CreatedAt: wh.CreatedAt,
+		UpdatedBy: toNullString(wh.UpdatedBy),
+		UpdatedAt: sql.NullTime{Time: wh.UpdatedAt, Valid: !wh.UpdatedAt.IsZero()},
+	}, nil
+}
+
+func toWebhook(dbwh dbWebhook) (webhooks.Webhook, error) {
+	var headers map[string]string
+	if len(dbwh.Headers) > 0 {
+		if err := json.Unmarshal(dbwh.Headers, &headers); err != nil {
+			return webhooks.Webhook{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+	}
+
+	return webhooks.Webhook{
+		ID:        dbwh.ID,
+		DomainID:  dbwh.DomainID,
+		ChannelID: dbwh.ChannelID,
+		Name:      dbwh.Name.String,
+		URL:       dbwh.URL,
+		Headers:   headers,
+		Secret:    dbwh.Secret.String,
+		Template:  dbwh.Template.String,
+		CreatedBy: dbwh.CreatedBy.String,
+		CreatedAt: dbwh.CreatedAt.UTC(),
+		UpdatedBy: dbwh.UpdatedBy.String,
+		UpdatedAt: dbwh.UpdatedAt.Time.UTC(),
+	}, nil
+}
+
+type dbDelivery struct {
+	ID           string                  `db:"id"`
+	WebhookID    string                  `db:"webhook_id"`
+	DomainID     string                  `db:"domain_id"`
+	ChannelID    string                  `db:"channel_id"`
+	Subtopic     sql.NullString          `db:"subtopic"`
+	Status       webhooks.DeliveryStatus `db:"status"`
+	Attempts     uint64                  `db:"attempts"`
+	ResponseCode sql.NullInt64           `db:"response_code"`
+	Error        sql.NullString          `db:"error"`
+	CreatedAt    time.Time               `db:"created_at"`
+}
+
+func toDBDelivery(d webhooks.Delivery) dbDelivery {
+	return dbDelivery{
+		ID:           d.ID,
+		WebhookID:    d.WebhookID,
+		DomainID:     d.DomainID,
+		ChannelID:    d.ChannelID,
+		Subtopic:     toNullString(d.Subtopic),
+		Status:       d.Status,
+		Attempts:     d.Attempts,
+		ResponseCode: sql.NullInt64{Int64: int64(d.ResponseCode), Valid: d.ResponseCode != 0},
+		Error:        toNullString(d.Error),
+		CreatedAt:    d.CreatedAt,
+	}
+}
+
+func toDelivery(dbd dbDelivery) webhooks.Delivery {
+	return webhooks.Delivery{
+		ID:           dbd.ID,
+		WebhookID:    dbd.WebhookID,
+		DomainID:     dbd.DomainID,
+		ChannelID:    dbd.ChannelID,
+		Subtopic:     dbd.Subtopic.String,
+		Status:       dbd.Status,
+		Attempts:     dbd.Attempts,
+		ResponseCode: int(dbd.ResponseCode.Int64),
+		Error:        dbd.Error.String,
+		CreatedAt:    dbd.CreatedAt.UTC(),
+	}
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
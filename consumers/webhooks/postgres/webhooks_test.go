@@ -0,0 +1,424 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/consumers/webhooks"
+	"github.com/absmach/supermq/consumers/webhooks/postgres"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var invalidID = strings.Repeat("a", 37)
+
+func cleanUp(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM deliveries")
+		require.Nil(t, err, fmt.Sprintf("clean deliveries unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM webhooks")
+		require.Nil(t, err, fmt.Sprintf("clean webhooks unexpected error: %s", err))
+	})
+}
+
+func newWebhook(t *testing.T, domainID, channelID string) webhooks.Webhook {
+	return webhooks.Webhook{
+		ID:        testsutil.GenerateUUID(t),
+		DomainID:  domainID,
+		ChannelID: channelID,
+		Name:      "webhook",
+		URL:       "https://example.com/hook",
+		Headers:   map[string]string{"X-Key": "value"},
+		Secret:    "secret",
+		Template:  `{"value": {{json .Payload}}}`,
+		CreatedBy: testsutil.GenerateUUID(t),
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+func TestSave(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+
+	cases := []struct {
+		desc    string
+		webhook webhooks.Webhook
+		err     error
+	}{
+		{
+			desc:    "save webhook successfully",
+			webhook: wh,
+			err:     nil,
+		},
+		{
+			desc:    "save webhook with existing ID",
+			webhook: wh,
+			err:     repoerr.ErrConflict,
+		},
+		{
+			desc: "save webhook with invalid domain ID",
+			webhook: webhooks.Webhook{
+				ID:        testsutil.GenerateUUID(t),
+				DomainID:  invalidID,
+				ChannelID: testsutil.GenerateUUID(t),
+				URL:       "https://example.com/hook",
+				CreatedAt: time.Now().UTC(),
+			},
+			err: repoerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.Save(context.Background(), tc.webhook)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.webhook, saved, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.webhook, saved))
+			}
+		})
+	}
+}
+
+func TestRetrieveByID(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		resp webhooks.Webhook
+		err  error
+	}{
+		{
+			desc: "retrieve webhook successfully",
+			id:   wh.ID,
+			resp: wh,
+			err:  nil,
+		},
+		{
+			desc: "retrieve non-existing webhook",
+			id:   testsutil.GenerateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+		{
+			desc: "retrieve webhook with empty ID",
+			id:   "",
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveByID(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestRetrieveByChannel(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+	var items []webhooks.Webhook
+	for i := 0; i < 3; i++ {
+		wh := newWebhook(t, domainID, channelID)
+		wh.CreatedAt = wh.CreatedAt.Add(time.Duration(i) * time.Second)
+		saved, err := repo.Save(context.Background(), wh)
+		require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+		items = append(items, saved)
+	}
+	_, err := repo.Save(context.Background(), newWebhook(t, domainID, testsutil.GenerateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	cases := []struct {
+		desc      string
+		channelID string
+		resp      []webhooks.Webhook
+	}{
+		{
+			desc:      "retrieve webhooks of channel",
+			channelID: channelID,
+			resp:      items,
+		},
+		{
+			desc:      "retrieve webhooks of channel without webhooks",
+			channelID: testsutil.GenerateUUID(t),
+			resp:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveByChannel(context.Background(), tc.channelID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+	num := 10
+	var items, channelItems []webhooks.Webhook
+	for i := 0; i < num; i++ {
+		chID := testsutil.GenerateUUID(t)
+		if i%2 == 0 {
+			chID = channelID
+		}
+		wh := newWebhook(t, domainID, chID)
+		wh.CreatedAt = wh.CreatedAt.Add(time.Duration(i) * time.Second)
+		saved, err := repo.Save(context.Background(), wh)
+		require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+		items = append(items, saved)
+		if chID == channelID {
+			channelItems = append(channelItems, saved)
+		}
+	}
+	_, err := repo.Save(context.Background(), newWebhook(t, testsutil.GenerateUUID(t), channelID))
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		pm   webhooks.PageMeta
+		resp webhooks.WebhooksPage
+	}{
+		{
+			desc: "retrieve webhooks of domain",
+			pm:   webhooks.PageMeta{DomainID: domainID, Limit: 10},
+			resp: webhooks.WebhooksPage{Total: uint64(num), Limit: 10, Webhooks: items},
+		},
+		{
+			desc: "retrieve webhooks of domain with offset and limit",
+			pm:   webhooks.PageMeta{DomainID: domainID, Offset: 2, Limit: 3},
+			resp: webhooks.WebhooksPage{Total: uint64(num), Offset: 2, Limit: 3, Webhooks: items[2:5]},
+		},
+		{
+			desc: "retrieve webhooks of domain channel",
+			pm:   webhooks.PageMeta{DomainID: domainID, ChannelID: channelID, Limit: 10},
+			resp: webhooks.WebhooksPage{Total: uint64(len(channelItems)), Limit: 10, Webhooks: channelItems},
+		},
+		{
+			desc: "retrieve webhooks of domain without webhooks",
+			pm:   webhooks.PageMeta{DomainID: testsutil.GenerateUUID(t), Limit: 10},
+			resp: webhooks.WebhooksPage{Limit: 10},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	updatedAt := time.Now().UTC().Truncate(time.Microsecond)
+	updatedBy := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc    string
+		webhook webhooks.Webhook
+		secret  string
+		err     error
+	}{
+		{
+			desc: "update webhook with new secret",
+			webhook: webhooks.Webhook{
+				ID:        wh.ID,
+				Name:      "updated",
+				URL:       "https://example.com/updated",
+				Headers:   map[string]string{"X-Key": "updated"},
+				Secret:    "updated",
+				UpdatedBy: updatedBy,
+				UpdatedAt: updatedAt,
+			},
+			secret: "updated",
+			err:    nil,
+		},
+		{
+			desc: "update webhook without secret",
+			webhook: webhooks.Webhook{
+				ID:        wh.ID,
+				Name:      "updated",
+				URL:       "https://example.com/updated",
+				UpdatedBy: updatedBy,
+				UpdatedAt: updatedAt,
+			},
+			secret: "updated",
+			err:    nil,
+		},
+		{
+			desc: "update non-existing webhook",
+			webhook: webhooks.Webhook{
+				ID:        testsutil.GenerateUUID(t),
+				URL:       "https://example.com/updated",
+				UpdatedAt: updatedAt,
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			updated, err := repo.Update(context.Background(), tc.webhook)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.webhook.URL, updated.URL, fmt.Sprintf("%s: expected URL %s got %s\n", tc.desc, tc.webhook.URL, updated.URL))
+				assert.Equal(t, tc.webhook.Headers, updated.Headers, fmt.Sprintf("%s: expected headers %v got %v\n", tc.desc, tc.webhook.Headers, updated.Headers))
+				assert.Equal(t, tc.secret, updated.Secret, fmt.Sprintf("%s: expected secret %s got %s\n", tc.desc, tc.secret, updated.Secret))
+				assert.Equal(t, wh.ChannelID, updated.ChannelID, fmt.Sprintf("%s: expected channel %s got %s\n", tc.desc, wh.ChannelID, updated.ChannelID))
+				assert.Equal(t, tc.webhook.UpdatedAt, updated.UpdatedAt, fmt.Sprintf("%s: expected updated at %s got %s\n", tc.desc, tc.webhook.UpdatedAt, updated.UpdatedAt))
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+	err = repo.SaveDelivery(context.Background(), webhooks.Delivery{
+		ID:        testsutil.GenerateUUID(t),
+		WebhookID: wh.ID,
+		DomainID:  wh.DomainID,
+		ChannelID: wh.ChannelID,
+		CreatedAt: time.Now().UTC(),
+	})
+	require.Nil(t, err, fmt.Sprintf("save delivery unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove webhook with deliveries successfully",
+			id:   wh.ID,
+			err:  nil,
+		},
+		{
+			desc: "remove removed webhook",
+			id:   wh.ID,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Remove(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+
+	page, err := repo.RetrieveDeliveries(context.Background(), webhooks.PageMeta{WebhookID: wh.ID, Status: webhooks.AllStatus, Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("retrieve deliveries unexpected error: %s", err))
+	assert.Equal(t, uint64(0), page.Total, "expected deliveries of removed webhook to be removed")
+}
+
+func TestDeliveries(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	num := 6
+	var all, failed []webhooks.Delivery
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	for i := 0; i < num; i++ {
+		d := webhooks.Delivery{
+			ID:           testsutil.GenerateUUID(t),
+			WebhookID:    wh.ID,
+			DomainID:     wh.DomainID,
+			ChannelID:    wh.ChannelID,
+			Subtopic:     "temperature",
+			Status:       webhooks.DeliveredStatus,
+			Attempts:     1,
+			ResponseCode: 200,
+			CreatedAt:    createdAt.Add(-time.Duration(i) * time.Second),
+		}
+		if i%2 == 1 {
+			d.Status = webhooks.FailedStatus
+			d.Attempts = 3
+			d.ResponseCode = 500
+			d.Error = webhooks.ErrDelivery.Error()
+			failed = append(failed, d)
+		}
+		err := repo.SaveDelivery(context.Background(), d)
+		require.Nil(t, err, fmt.Sprintf("save delivery unexpected error: %s", err))
+		all = append(all, d)
+	}
+
+	err = repo.SaveDelivery(context.Background(), webhooks.Delivery{ID: testsutil.GenerateUUID(t), WebhookID: testsutil.GenerateUUID(t), CreatedAt: createdAt})
+	assert.True(t, errors.Contains(err, repoerr.ErrCreateEntity), fmt.Sprintf("save delivery of non-existing webhook: expected %s got %s\n", repoerr.ErrCreateEntity, err))
+
+	cases := []struct {
+		desc string
+		pm   webhooks.PageMeta
+		resp webhooks.DeliveriesPage
+	}{
+		{
+			desc: "retrieve all deliveries of webhook",
+			pm:   webhooks.PageMeta{WebhookID: wh.ID, Status: webhooks.AllStatus, Limit: 10},
+			resp: webhooks.DeliveriesPage{Total: uint64(num), Limit: 10, Deliveries: all},
+		},
+		{
+			desc: "retrieve failed deliveries of webhook",
+			pm:   webhooks.PageMeta{WebhookID: wh.ID, Status: webhooks.FailedStatus, Limit: 10},
+			resp: webhooks.DeliveriesPage{Total: uint64(len(failed)), Limit: 10, Deliveries: failed},
+		},
+		{
+			desc: "retrieve deliveries of webhook with offset and limit",
+			pm:   webhooks.PageMeta{WebhookID: wh.ID, Status: webhooks.AllStatus, Offset: 1, Limit: 2},
+			resp: webhooks.DeliveriesPage{Total: uint64(num), Offset: 1, Limit: 2, Deliveries: all[1:3]},
+		},
+		{
+			desc: "retrieve deliveries of webhook without deliveries",
+			pm:   webhooks.PageMeta{WebhookID: testsutil.GenerateUUID(t), Status: webhooks.AllStatus, Limit: 10},
+			resp: webhooks.DeliveriesPage{Limit: 10},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveDeliveries(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the hex encoded HMAC-SHA256 signature of the
+	// timestamp and payload, computed with the webhook secret.
+	SignatureHeader = "X-SMQ-Signature"
+	// TimestampHeader carries the Unix time at which the payload was signed.
+	TimestampHeader = "X-SMQ-Timestamp"
+	// DeliveryHeader carries the delivery ID, which is the same for all retries.
+	DeliveryHeader = "X-SMQ-Delivery"
+
+	signaturePrefix = "sha256="
+	contentType     = "application/json"
+)
+
+var _ Sender = (*httpSender)(nil)
+
+type httpSender struct {
+	client *http.Client
+}
+
+// NewSender returns a Sender which sends payloads using the HTTP client.
+func NewSender(client *http.Client) Sender {
+	return &httpSender{client: client}
+}
+
+func (hs *httpSender) Send(ctx context.Context, wh Webhook, deliveryID string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range wh.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set(DeliveryHeader, deliveryID)
+	if wh.Secret != "" {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, ts)
+		req.Header.Set(SignatureHeader, signaturePrefix+Sign(wh.Secret, ts, payload))
+	}
+
+	resp, err := hs.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of the timestamp and
+// payload joined by a dot. Receivers can use it to verify both the origin
+// and the freshness of the request.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
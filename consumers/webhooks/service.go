@@ -0,0 +1,335 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/supermq"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/cenkalti/backoff/v4"
+)
+
+// headerMask replaces webhook header values in responses, since headers
+// commonly carry credentials of the receiving endpoint.
+const headerMask = "*****"
+
+var errUnsupportedMessage = errors.New("unsupported message type")
+
+// RetryConfig configures exponential backoff used for webhook delivery.
+type RetryConfig struct {
+	InitialInterval time.Duration `env:"INITIAL_INTERVAL" envDefault:"500ms"`
+	MaxInterval     time.Duration `env:"MAX_INTERVAL"     envDefault:"30s"`
+	MaxElapsedTime  time.Duration `env:"MAX_ELAPSED_TIME" envDefault:"2m"`
+	MaxRetries      uint64        `env:"MAX_RETRIES"      envDefault:"5"`
+}
+
+// Payload is the data passed to the webhook payload template.
+type Payload struct {
+	DomainID  string      `json:"domain_id"`
+	ChannelID string      `json:"channel_id"`
+	Subtopic  string      `json:"subtopic,omitempty"`
+	Publisher string      `json:"publisher,omitempty"`
+	Protocol  string      `json:"protocol,omitempty"`
+	Messages  interface{} `json:"messages"`
+}
+
+type service struct {
+	idProvider supermq.IDProvider
+	repo       Repository
+	sender     Sender
+	retry      RetryConfig
+}
+
+// New instantiates the webhooks service implementation.
+func New(idp supermq.IDProvider, repo Repository, sender Sender, retry RetryConfig) Service {
+	return &service{
+		idProvider: idp,
+		repo:       repo,
+		sender:     sender,
+		retry:      retry,
+	}
+}
+
+func (svc *service) CreateWebhook(ctx context.Context, session smqauthn.Session, wh Webhook) (Webhook, error) {
+	if err := wh.Validate(); err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return Webhook{}, err
+	}
+	wh.ID = id
+	wh.DomainID = session.DomainID
+	wh.CreatedBy = session.UserID
+	wh.CreatedAt = time.Now().UTC()
+
+	saved, err := svc.repo.Save(ctx, wh)
+	if err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return saved, nil
+}
+
+func (svc *service) ViewWebhook(ctx context.Context, session smqauthn.Session, id string) (Webhook, error) {
+	wh, err := svc.retrieve(ctx, session, id)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	return redact(wh), nil
+}
+
+func (svc *service) ListWebhooks(ctx context.Context, session smqauthn.Session, pm PageMeta) (WebhooksPage, error) {
+	pm.DomainID = session.DomainID
+	page, err := svc.repo.RetrieveAll(ctx, pm)
+	if err != nil {
+		return WebhooksPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for i := range page.Webhooks {
+		page.Webhooks[i] = redact(page.Webhooks[i])
+	}
+
+	return page, nil
+}
+
+func (svc *service) UpdateWebhook(ctx context.Context, session smqauthn.Session, wh Webhook) (Webhook, error) {
+	if err := wh.Validate(); err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	existing, err := svc.retrieve(ctx, session, wh.ID)
+	if err != nil {
+		return Webhook{}, err
+	}
+	wh.Headers = keepHeaders(wh.Headers, existing.Headers)
+	wh.UpdatedBy = session.UserID
+	wh.UpdatedAt = time.Now().UTC()
+
+	updated, err := svc.repo.Update(ctx, wh)
+	if err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return redact(updated), nil
+}
+
+func (svc *service) RemoveWebhook(ctx context.Context, session smqauthn.Session, id string) error {
+	if _, err := svc.retrieve(ctx, session, id); err != nil {
+		return err
+	}
+	if err := svc.repo.Remove(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *service) ListDeliveries(ctx context.Context, session smqauthn.Session, webhookID string, pm PageMeta) (DeliveriesPage, error) {
+	if _, err := svc.retrieve(ctx, session, webhookID); err != nil {
+		return DeliveriesPage{}, err
+	}
+	pm.WebhookID = webhookID
+	page, err := svc.repo.RetrieveDeliveries(ctx, pm)
+	if err != nil {
+		return DeliveriesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc *service) ConsumeBlocking(ctx context.Context, messages interface{}) error {
+	payloads, err := toPayloads(messages)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range payloads {
+		whs, err := svc.repo.RetrieveByChannel(ctx, p.ChannelID)
+		if err != nil {
+			return err
+		}
+		for _, wh := range whs {
+			if err := svc.deliver(ctx, wh, p); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// deliver sends the payload to the webhook retrying with exponential backoff
+// and records the outcome. Only failure to record the outcome is returned,
+// since undelivered messages are tracked in the delivery history.
+func (svc *service) deliver(ctx context.Context, wh Webhook, p Payload) error {
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return err
+	}
+	d := Delivery{
+		ID:        id,
+		WebhookID: wh.ID,
+		DomainID:  wh.DomainID,
+		ChannelID: p.ChannelID,
+		Subtopic:  p.Subtopic,
+		Status:    DeliveredStatus,
+		CreatedAt: time.Now().UTC(),
+	}
+	p.DomainID = wh.DomainID
+
+	body, err := render(wh, p)
+	if err != nil {
+		d.Status = FailedStatus
+		d.Error = err.Error()
+		return svc.repo.SaveDelivery(ctx, d)
+	}
+
+	op := func() error {
+		d.Attempts++
+		code, err := svc.sender.Send(ctx, wh, d.ID, body)
+		d.ResponseCode = code
+		if err != nil && code >= 400 && code < 500 && code != 429 {
+			// Client errors other than rate limiting won't be fixed by retrying.
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+	if err := backoff.Retry(op, backoff.WithContext(svc.newBackOff(), ctx)); err != nil {
+		d.Status = FailedStatus
+		d.Error = errors.Wrap(ErrDelivery, err).Error()
+	}
+
+	return svc.repo.SaveDelivery(ctx, d)
+}
+
+// retrieve returns the unredacted webhook if it belongs to the session domain.
+func (svc *service) retrieve(ctx context.Context, session smqauthn.Session, id string) (Webhook, error) {
+	wh, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if wh.DomainID != session.DomainID {
+		return Webhook{}, svcerr.ErrNotFound
+	}
+
+	return wh, nil
+}
+
+func (svc *service) newBackOff() backoff.BackOff {
+	eb := backoff.NewExponentialBackOff()
+	eb.InitialInterval = svc.retry.InitialInterval
+	eb.MaxInterval = svc.retry.MaxInterval
+	eb.MaxElapsedTime = svc.retry.MaxElapsedTime
+
+	return backoff.WithMaxRetries(eb, svc.retry.MaxRetries)
+}
+
+func render(wh Webhook, p Payload) ([]byte, error) {
+	if wh.Template == "" {
+		return json.Marshal(p)
+	}
+	tmpl, err := parseTemplate(wh.Template)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, p); err != nil {
+		return nil, errors.Wrap(ErrInvalidTemplate, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// redact hides the webhook secret and masks its header values.
+func redact(wh Webhook) Webhook {
+	wh.Secret = ""
+	if len(wh.Headers) > 0 {
+		headers := make(map[string]string, len(wh.Headers))
+		for k := range wh.Headers {
+			headers[k] = headerMask
+		}
+		wh.Headers = headers
+	}
+
+	return wh
+}
+
+// keepHeaders restores the existing values of headers that are sent back
+// masked, so that updating a viewed webhook does not overwrite them.
+func keepHeaders(headers, existing map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return headers
+	}
+	ret := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if old, ok := existing[k]; ok && v == headerMask {
+			v = old
+		}
+		ret[k] = v
+	}
+
+	return ret
+}
+
+// toPayloads groups received messages by channel and subtopic, preserving
+// the order in which they were first seen.
+func toPayloads(messages interface{}) ([]Payload, error) {
+	type topic struct {
+		channel  string
+		subtopic string
+	}
+	var payloads []Payload
+	idx := make(map[topic]int)
+	add := func(channel, subtopic, publisher, protocol string, msg interface{}) {
+		t := topic{channel: channel, subtopic: subtopic}
+		i, ok := idx[t]
+		if !ok {
+			payloads = append(payloads, Payload{
+				ChannelID: channel,
+				Subtopic:  subtopic,
+				Publisher: publisher,
+				Protocol:  protocol,
+				Messages:  []interface{}{},
+			})
+			i = len(payloads) - 1
+			idx[t] = i
+		}
+		payloads[i].Messages = append(payloads[i].Messages.([]interface{}), msg)
+	}
+
+	switch m := messages.(type) {
+	case []senml.Message:
+		for _, msg := range m {
+			add(msg.Channel, msg.Subtopic, msg.Publisher, msg.Protocol, msg)
+		}
+	case smqjson.Messages:
+		for _, msg := range m.Data {
+			add(msg.Channel, msg.Subtopic, msg.Publisher, msg.Protocol, msg)
+		}
+	case *messaging.Message:
+		var payload interface{}
+		if err := json.Unmarshal(m.GetPayload(), &payload); err != nil {
+			payload = m.GetPayload()
+		}
+		add(m.GetChannel(), m.GetSubtopic(), m.GetPublisher(), m.GetProtocol(), payload)
+	default:
+		return nil, errUnsupportedMessage
+	}
+
+	return payloads, nil
+}
@@ -0,0 +1,347 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/consumers/webhooks"
+	"github.com/absmach/supermq/consumers/webhooks/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqerrors "github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/absmach/supermq/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var retryCfg = webhooks.RetryConfig{
+	InitialInterval: time.Millisecond,
+	MaxInterval:     time.Millisecond,
+	MaxElapsedTime:  time.Second,
+	MaxRetries:      2,
+}
+
+func newService() (webhooks.Service, *mocks.Repository, *mocks.Sender) {
+	repo := new(mocks.Repository)
+	sender := new(mocks.Sender)
+
+	return webhooks.New(uuid.NewMock(), repo, sender, retryCfg), repo, sender
+}
+
+func TestCreateWebhook(t *testing.T) {
+	svc, repo, _ := newService()
+
+	session := smqauthn.Session{DomainID: testsutil.GenerateUUID(t), UserID: testsutil.GenerateUUID(t)}
+	wh := webhooks.Webhook{
+		ChannelID: testsutil.GenerateUUID(t),
+		URL:       "https://example.com/hook",
+		Template:  `{"value": {{ json .Messages }}}`,
+	}
+
+	cases := []struct {
+		desc    string
+		webhook webhooks.Webhook
+		repoErr error
+		err     error
+	}{
+		{
+			desc:    "create webhook successfully",
+			webhook: wh,
+		},
+		{
+			desc:    "create webhook with invalid url",
+			webhook: webhooks.Webhook{ChannelID: wh.ChannelID, URL: "ftp://example.com"},
+			err:     webhooks.ErrInvalidURL,
+		},
+		{
+			desc:    "create webhook with invalid template",
+			webhook: webhooks.Webhook{ChannelID: wh.ChannelID, URL: wh.URL, Template: "{{ .Messages "},
+			err:     webhooks.ErrInvalidTemplate,
+		},
+		{
+			desc:    "create webhook with repo error",
+			webhook: wh,
+			repoErr: repoerr.ErrCreateEntity,
+			err:     svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("Save", context.Background(), mock.Anything).Return(tc.webhook, tc.repoErr)
+			_, err := svc.CreateWebhook(context.Background(), session, tc.webhook)
+			assert.True(t, smqerrors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestViewWebhook(t *testing.T) {
+	svc, repo, _ := newService()
+
+	session := smqauthn.Session{DomainID: testsutil.GenerateUUID(t)}
+	wh := webhooks.Webhook{
+		ID:        testsutil.GenerateUUID(t),
+		DomainID:  session.DomainID,
+		ChannelID: testsutil.GenerateUUID(t),
+		URL:       "https://example.com/hook",
+		Headers:   map[string]string{"Authorization": "Bearer token"},
+		Secret:    "secret",
+	}
+
+	cases := []struct {
+		desc     string
+		id       string
+		repoResp webhooks.Webhook
+		repoErr  error
+		err      error
+	}{
+		{
+			desc:     "view webhook successfully",
+			id:       wh.ID,
+			repoResp: wh,
+		},
+		{
+			desc:     "view webhook from another domain",
+			id:       wh.ID,
+			repoResp: webhooks.Webhook{ID: wh.ID, DomainID: testsutil.GenerateUUID(t)},
+			err:      svcerr.ErrNotFound,
+		},
+		{
+			desc:    "view non-existing webhook",
+			id:      wh.ID,
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByID", context.Background(), tc.id).Return(tc.repoResp, tc.repoErr)
+			res, err := svc.ViewWebhook(context.Background(), session, tc.id)
+			assert.True(t, smqerrors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Empty(t, res.Secret, "webhook secret should not be exposed")
+				assert.Equal(t, map[string]string{"Authorization": "*****"}, res.Headers, "webhook header values should be masked")
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	svc, repo, _ := newService()
+
+	session := smqauthn.Session{DomainID: testsutil.GenerateUUID(t), UserID: testsutil.GenerateUUID(t)}
+	wh := webhooks.Webhook{
+		ID:        testsutil.GenerateUUID(t),
+		DomainID:  session.DomainID,
+		ChannelID: testsutil.GenerateUUID(t),
+		URL:       "https://example.com/hook",
+		Headers:   map[string]string{"Authorization": "Bearer token", "X-Env": "dev"},
+	}
+
+	cases := []struct {
+		desc    string
+		webhook webhooks.Webhook
+		headers map[string]string
+		repoErr error
+		err     error
+	}{
+		{
+			desc:    "update webhook with new header values",
+			webhook: webhooks.Webhook{ID: wh.ID, URL: wh.URL, Headers: map[string]string{"Authorization": "Bearer new", "X-Env": "prod"}},
+			headers: map[string]string{"Authorization": "Bearer new", "X-Env": "prod"},
+		},
+		{
+			desc:    "update webhook with masked header values",
+			webhook: webhooks.Webhook{ID: wh.ID, URL: wh.URL, Headers: map[string]string{"Authorization": "*****", "X-Env": "prod"}},
+			headers: map[string]string{"Authorization": "Bearer token", "X-Env": "prod"},
+		},
+		{
+			desc:    "update webhook with masked value of new header",
+			webhook: webhooks.Webhook{ID: wh.ID, URL: wh.URL, Headers: map[string]string{"X-New": "*****"}},
+			headers: map[string]string{"X-New": "*****"},
+		},
+		{
+			desc:    "update webhook with invalid URL",
+			webhook: webhooks.Webhook{ID: wh.ID, URL: "invalid"},
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "update webhook with repo error",
+			webhook: webhooks.Webhook{ID: wh.ID, URL: wh.URL},
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			retrieveCall := repo.On("RetrieveByID", context.Background(), wh.ID).Return(wh, nil)
+			var saved map[string]string
+			updateCall := repo.On("Update", context.Background(), mock.Anything).Return(webhooks.Webhook{ID: wh.ID, DomainID: wh.DomainID, URL: wh.URL, Headers: tc.headers}, tc.repoErr).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(webhooks.Webhook).Headers
+			})
+			res, err := svc.UpdateWebhook(context.Background(), session, tc.webhook)
+			assert.True(t, smqerrors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.headers, saved, fmt.Sprintf("%s: expected headers %v got %v\n", tc.desc, tc.headers, saved))
+				for k, v := range res.Headers {
+					assert.Equal(t, "*****", v, fmt.Sprintf("%s: header %s should be masked", tc.desc, k))
+				}
+			}
+			retrieveCall.Unset()
+			updateCall.Unset()
+		})
+	}
+}
+
+func TestConsumeBlocking(t *testing.T) {
+	channelID := testsutil.GenerateUUID(t)
+	wh := webhooks.Webhook{
+		ID:        testsutil.GenerateUUID(t),
+		DomainID:  testsutil.GenerateUUID(t),
+		ChannelID: channelID,
+		URL:       "https://example.com/hook",
+	}
+	value := 1.0
+	msgs := []senml.Message{{Channel: channelID, Name: "temp", Value: &value}}
+
+	cases := []struct {
+		desc       string
+		msgs       interface{}
+		webhooks   []webhooks.Webhook
+		retrErr    error
+		sendCode   int
+		sendErr    error
+		sendCalls  int
+		status     webhooks.DeliveryStatus
+		err        error
+		saveCalled bool
+	}{
+		{
+			desc:       "consume senml messages successfully",
+			msgs:       msgs,
+			webhooks:   []webhooks.Webhook{wh},
+			sendCode:   http.StatusOK,
+			sendCalls:  1,
+			status:     webhooks.DeliveredStatus,
+			saveCalled: true,
+		},
+		{
+			desc:       "consume raw message successfully",
+			msgs:       &messaging.Message{Channel: channelID, Payload: []byte(`{"temp": 1}`)},
+			webhooks:   []webhooks.Webhook{wh},
+			sendCode:   http.StatusOK,
+			sendCalls:  1,
+			status:     webhooks.DeliveredStatus,
+			saveCalled: true,
+		},
+		{
+			desc:       "consume messages with retries exhausted",
+			msgs:       msgs,
+			webhooks:   []webhooks.Webhook{wh},
+			sendCode:   http.StatusServiceUnavailable,
+			sendErr:    errors.New("unavailable"),
+			sendCalls:  int(retryCfg.MaxRetries) + 1,
+			status:     webhooks.FailedStatus,
+			saveCalled: true,
+		},
+		{
+			desc:       "consume messages with permanent client error",
+			msgs:       msgs,
+			webhooks:   []webhooks.Webhook{wh},
+			sendCode:   http.StatusBadRequest,
+			sendErr:    errors.New("bad request"),
+			sendCalls:  1,
+			status:     webhooks.FailedStatus,
+			saveCalled: true,
+		},
+		{
+			desc:     "consume messages for channel without webhooks",
+			msgs:     msgs,
+			webhooks: []webhooks.Webhook{},
+		},
+		{
+			desc:    "consume messages with repo error",
+			msgs:    msgs,
+			retrErr: repoerr.ErrViewEntity,
+			err:     repoerr.ErrViewEntity,
+		},
+		{
+			desc: "consume unsupported message",
+			msgs: "invalid",
+			err:  smqerrors.New("unsupported message type"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, sender := newService()
+			repo.On("RetrieveByChannel", context.Background(), channelID).Return(tc.webhooks, tc.retrErr)
+			sender.On("Send", mock.Anything, wh, mock.Anything, mock.Anything).Return(tc.sendCode, tc.sendErr)
+			repo.On("SaveDelivery", context.Background(), mock.MatchedBy(func(d webhooks.Delivery) bool {
+				return d.WebhookID == wh.ID && d.Status == tc.status && d.Attempts == uint64(tc.sendCalls)
+			})).Return(nil)
+			err := svc.ConsumeBlocking(context.Background(), tc.msgs)
+			assert.True(t, smqerrors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			sender.AssertNumberOfCalls(t, "Send", tc.sendCalls)
+			if tc.saveCalled {
+				repo.AssertNumberOfCalls(t, "SaveDelivery", 1)
+			}
+		})
+	}
+}
+
+func TestConsumeBlockingSubtopics(t *testing.T) {
+	svc, repo, sender := newService()
+
+	channelID := testsutil.GenerateUUID(t)
+	wh := webhooks.Webhook{
+		ID:        testsutil.GenerateUUID(t),
+		DomainID:  testsutil.GenerateUUID(t),
+		ChannelID: channelID,
+		URL:       "https://example.com/hook",
+	}
+	value := 1.0
+	msgs := []senml.Message{
+		{Channel: channelID, Subtopic: "temperature", Name: "t1", Value: &value},
+		{Channel: channelID, Subtopic: "humidity", Name: "h1", Value: &value},
+		{Channel: channelID, Subtopic: "temperature", Name: "t2", Value: &value},
+	}
+
+	var subtopics []string
+	repo.On("RetrieveByChannel", context.Background(), channelID).Return([]webhooks.Webhook{wh}, nil)
+	sender.On("Send", mock.Anything, wh, mock.Anything, mock.Anything).Return(http.StatusOK, nil).Run(func(args mock.Arguments) {
+		var p webhooks.Payload
+		err := json.Unmarshal(args.Get(3).([]byte), &p)
+		assert.Nil(t, err, fmt.Sprintf("unexpected payload error: %s", err))
+		subtopics = append(subtopics, p.Subtopic)
+		assert.Len(t, p.Messages, map[string]int{"temperature": 2, "humidity": 1}[p.Subtopic], fmt.Sprintf("unexpected number of %s messages", p.Subtopic))
+	})
+	repo.On("SaveDelivery", context.Background(), mock.Anything).Return(nil)
+
+	err := svc.ConsumeBlocking(context.Background(), msgs)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, []string{"temperature", "humidity"}, subtopics, "messages should be delivered per subtopic")
+}
+
+func TestSign(t *testing.T) {
+	sig := webhooks.Sign("secret", "1700000000", []byte(`{"a":1}`))
+	assert.Len(t, sig, 64, "signature should be hex encoded SHA-256")
+	assert.Equal(t, sig, webhooks.Sign("secret", "1700000000", []byte(`{"a":1}`)))
+	assert.NotEqual(t, sig, webhooks.Sign("other", "1700000000", []byte(`{"a":1}`)))
+}
@@ -0,0 +1,272 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"text/template"
+	"time"
+
+	"github.com/absmach/supermq/consumers"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+var (
+	// ErrInvalidURL indicates that the webhook URL is not a valid HTTP(S) URL.
+	ErrInvalidURL = errors.New("invalid webhook url")
+
+	// ErrInvalidTemplate indicates that the webhook payload template can't be parsed.
+	ErrInvalidTemplate = errors.New("invalid webhook payload template")
+
+	// ErrDelivery indicates that the message couldn't be delivered to the webhook.
+	ErrDelivery = errors.New("failed to deliver message to webhook")
+)
+
+// Webhook represents an HTTP endpoint that receives messages
+// published to a channel.
+type Webhook struct {
+	ID        string            `json:"id"`
+	DomainID  string            `json:"domain_id"`
+	ChannelID string            `json:"channel_id"`
+	Name      string            `json:"name,omitempty"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Secret    string            `json:"secret,omitempty"`
+	Template  string            `json:"payload_template,omitempty"`
+	CreatedBy string            `json:"created_by,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at,omitempty"`
+	UpdatedBy string            `json:"updated_by,omitempty"`
+}
+
+// Validate checks the webhook URL and payload template.
+func (wh Webhook) Validate() error {
+	u, err := url.ParseRequestURI(wh.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	if wh.Template != "" {
+		if _, err := parseTemplate(wh.Template); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseTemplate parses the payload template. Besides the standard template
+// functions, templates can use "json" to encode a value as JSON.
+func parseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("payload").Funcs(template.FuncMap{"json": toJSON}).Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidTemplate, err)
+	}
+
+	return tmpl, nil
+}
+
+// WebhooksPage represents a page of webhooks.
+type WebhooksPage struct {
+	Total    uint64    `json:"total"`
+	Offset   uint64    `json:"offset"`
+	Limit    uint64    `json:"limit"`
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+func (page WebhooksPage) MarshalJSON() ([]byte, error) {
+	type Alias WebhooksPage
+	a := struct {
+		Alias
+	}{
+		Alias: Alias(page),
+	}
+
+	if a.Webhooks == nil {
+		a.Webhooks = make([]Webhook, 0)
+	}
+
+	return json.Marshal(a)
+}
+
+// DeliveryStatus represents the outcome of a webhook delivery.
+type DeliveryStatus uint8
+
+const (
+	// DeliveredStatus represents a successful delivery.
+	DeliveredStatus DeliveryStatus = iota
+	// FailedStatus represents a delivery that failed after all retries.
+	FailedStatus
+	// AllStatus is used for querying purposes to list deliveries irrespective
+	// of their status.
+	AllStatus
+)
+
+// String representation of the possible delivery status values.
+const (
+	Delivered = "delivered"
+	Failed    = "failed"
+	All       = "all"
+	Unknown   = "unknown"
+)
+
+// String converts delivery status to string literal.
+func (s DeliveryStatus) String() string {
+	switch s {
+	case DeliveredStatus:
+		return Delivered
+	case FailedStatus:
+		return Failed
+	case AllStatus:
+		return All
+	default:
+		return Unknown
+	}
+}
+
+// ToDeliveryStatus converts string value to a valid delivery status.
+func ToDeliveryStatus(status string) (DeliveryStatus, error) {
+	switch status {
+	case Delivered:
+		return DeliveredStatus, nil
+	case Failed:
+		return FailedStatus, nil
+	case "", All:
+		return AllStatus, nil
+	}
+	return DeliveryStatus(0), errors.ErrMalformedEntity
+}
+
+func (s DeliveryStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s *DeliveryStatus) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	val, err := ToDeliveryStatus(str)
+	*s = val
+	return err
+}
+
+// Delivery represents a single attempt to forward a batch of
+// channel messages to a webhook, including all of its retries.
+type Delivery struct {
+	ID           string         `json:"id"`
+	WebhookID    string         `json:"webhook_id"`
+	DomainID     string         `json:"domain_id"`
+	ChannelID    string         `json:"channel_id"`
+	Subtopic     string         `json:"subtopic,omitempty"`
+	Status       DeliveryStatus `json:"status"`
+	Attempts     uint64         `json:"attempts"`
+	ResponseCode int            `json:"response_code,omitempty"`
+	Error        string         `json:"error,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// DeliveriesPage represents a page of webhook deliveries.
+type DeliveriesPage struct {
+	Total      uint64     `json:"total"`
+	Offset     uint64     `json:"offset"`
+	Limit      uint64     `json:"limit"`
+	Deliveries []Delivery `json:"deliveries"`
+}
+
+func (page DeliveriesPage) MarshalJSON() ([]byte, error) {
+	type Alias DeliveriesPage
+	a := struct {
+		Alias
+	}{
+		Alias: Alias(page),
+	}
+
+	if a.Deliveries == nil {
+		a.Deliveries = make([]Delivery, 0)
+	}
+
+	return json.Marshal(a)
+}
+
+// PageMeta is used to filter webhooks and deliveries.
+type PageMeta struct {
+	Offset    uint64         `json:"offset" db:"offset"`
+	Limit     uint64         `json:"limit" db:"limit"`
+	DomainID  string         `json:"domain_id,omitempty" db:"domain_id"`
+	ChannelID string         `json:"channel_id,omitempty" db:"channel_id"`
+	WebhookID string         `json:"webhook_id,omitempty" db:"webhook_id"`
+	Status    DeliveryStatus `json:"status,omitempty" db:"status"`
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+// Service also acts as a blocking consumer which forwards received
+// messages to the webhooks configured for the message channel.
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	consumers.BlockingConsumer
+
+	// CreateWebhook creates a webhook for the channel in the session domain.
+	CreateWebhook(ctx context.Context, session smqauthn.Session, wh Webhook) (Webhook, error)
+
+	// ViewWebhook retrieves the webhook identified by the given ID.
+	ViewWebhook(ctx context.Context, session smqauthn.Session, id string) (Webhook, error)
+
+	// ListWebhooks retrieves the webhooks of the session domain filtered by the page metadata.
+	ListWebhooks(ctx context.Context, session smqauthn.Session, pm PageMeta) (WebhooksPage, error)
+
+	// UpdateWebhook updates the webhook URL, headers, secret and payload template.
+	// Empty secret keeps the existing one.
+	UpdateWebhook(ctx context.Context, session smqauthn.Session, wh Webhook) (Webhook, error)
+
+	// RemoveWebhook removes the webhook identified by the given ID.
+	RemoveWebhook(ctx context.Context, session smqauthn.Session, id string) error
+
+	// ListDeliveries retrieves the delivery history of the webhook identified by the given ID.
+	ListDeliveries(ctx context.Context, session smqauthn.Session, webhookID string, pm PageMeta) (DeliveriesPage, error)
+}
+
+// Repository specifies a webhook persistence API.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save persists the webhook.
+	Save(ctx context.Context, wh Webhook) (Webhook, error)
+
+	// RetrieveByID retrieves the webhook having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Webhook, error)
+
+	// RetrieveByChannel retrieves all webhooks configured for the channel.
+	RetrieveByChannel(ctx context.Context, channelID string) ([]Webhook, error)
+
+	// RetrieveAll retrieves webhooks filtered by the page metadata.
+	RetrieveAll(ctx context.Context, pm PageMeta) (WebhooksPage, error)
+
+	// Update updates the webhook URL, headers, secret and payload template.
+	// Empty secret keeps the stored one.
+	Update(ctx context.Context, wh Webhook) (Webhook, error)
+
+	// Remove removes the webhook having the provided identifier.
+	Remove(ctx context.Context, id string) error
+
+	// SaveDelivery persists the delivery outcome.
+	SaveDelivery(ctx context.Context, d Delivery) error
+
+	// RetrieveDeliveries retrieves deliveries filtered by the page metadata.
+	RetrieveDeliveries(ctx context.Context, pm PageMeta) (DeliveriesPage, error)
+}
+
+// Sender sends payloads to webhooks.
+//
+//go:generate mockery --name Sender --output=./mocks --filename sender.go --quiet --note "Copyright (c) Abstract Machines"
+type Sender interface {
+	// Send sends the payload to the webhook and returns the HTTP response
+	// status code. An error is returned if the request can't be made or
+	// the endpoint doesn't respond with a 2xx status code.
+	Send(ctx context.Context, wh Webhook, deliveryID string, payload []byte) (int, error)
+}
@@ -10,6 +10,7 @@ import (
 	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
 	grpcDomainsV1 "github.com/absmach/supermq/api/grpc/domains/v1"
 	grpcapi "github.com/absmach/supermq/auth/api/grpc"
+	"github.com/absmach/supermq/domains"
 	"github.com/go-kit/kit/endpoint"
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
 	"google.golang.org/grpc"
@@ -22,6 +23,9 @@ var _ grpcDomainsV1.DomainsServiceClient = (*domainsGrpcClient)(nil)
 type domainsGrpcClient struct {
 	deleteUserFromDomains endpoint.Endpoint
 	retrieveEntity        endpoint.Endpoint
+	requiresMFA           endpoint.Endpoint
+	retrieveSettings      endpoint.Endpoint
+	retrieveIDByAlias     endpoint.Endpoint
 	timeout               time.Duration
 }
 
@@ -44,6 +48,30 @@ func NewDomainsClient(conn *grpc.ClientConn, timeout time.Duration) grpcDomainsV
 			decodeRetrieveEntityResponse,
 			grpcCommonV1.RetrieveEntityRes{},
 		).Endpoint(),
+		requiresMFA: kitgrpc.NewClient(
+			conn,
+			domainsSvcName,
+			"RequiresMFA",
+			encodeRequiresMFARequest,
+			decodeRequiresMFAResponse,
+			grpcDomainsV1.RequiresMFARes{},
+		).Endpoint(),
+		retrieveSettings: kitgrpc.NewClient(
+			conn,
+			domainsSvcName,
+			"RetrieveSettings",
+			encodeRetrieveSettingsRequest,
+			decodeRetrieveSettingsResponse,
+			grpcDomainsV1.RetrieveSettingsRes{},
+		).Endpoint(),
+		retrieveIDByAlias: kitgrpc.NewClient(
+			conn,
+			domainsSvcName,
+			"RetrieveIDByAlias",
+			encodeRetrieveIDByAliasRequest,
+			decodeRetrieveIDByAliasResponse,
+			grpcDomainsV1.RetrieveIDByAliasRes{},
+		).Endpoint(),
 		timeout: timeout,
 	}
 }
@@ -106,3 +134,104 @@ func encodeRetrieveEntityRequest(_ context.Context, grpcReq interface{}) (interf
 		Id: req.ID,
 	}, nil
 }
+
+func (client domainsGrpcClient) RequiresMFA(ctx context.Context, in *grpcDomainsV1.RequiresMFAReq, opts ...grpc.CallOption) (*grpcDomainsV1.RequiresMFARes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.requiresMFA(ctx, requiresMFAReq{userID: in.GetUserId()})
+	if err != nil {
+		return &grpcDomainsV1.RequiresMFARes{}, grpcapi.DecodeError(err)
+	}
+
+	rmr := res.(requiresMFARes)
+	return &grpcDomainsV1.RequiresMFARes{Required: rmr.required}, nil
+}
+
+func decodeRequiresMFAResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*grpcDomainsV1.RequiresMFARes)
+	return requiresMFARes{required: res.GetRequired()}, nil
+}
+
+func encodeRequiresMFARequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(requiresMFAReq)
+	return &grpcDomainsV1.RequiresMFAReq{
+		UserId: req.userID,
+	}, nil
+}
+
+func (client domainsGrpcClient) RetrieveSettings(ctx context.Context, in *grpcDomainsV1.RetrieveSettingsReq, opts ...grpc.CallOption) (*grpcDomainsV1.RetrieveSettingsRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.retrieveSettings(ctx, retrieveSettingsReq{domainID: in.GetDomainId()})
+	if err != nil {
+		return &grpcDomainsV1.RetrieveSettingsRes{}, grpcapi.DecodeError(err)
+	}
+
+	s := res.(retrieveSettingsRes).settings
+	return &grpcDomainsV1.RetrieveSettingsRes{
+		Version:               s.Version,
+		AllowedProtocols:      s.AllowedProtocols,
+		ClientSecretMinLength: s.ClientSecretPolicy.MinLength,
+		MessageRetentionDays:  s.MessageRetentionDays,
+		InvitationExpiryHours: s.InvitationExpiryHours,
+		SmtpFromAddress:       s.SMTP.FromAddress,
+		SmtpFromName:          s.SMTP.FromName,
+		LogoUrl:               s.Branding.LogoURL,
+		PrimaryColor:          s.Branding.PrimaryColor,
+		FooterText:            s.Branding.FooterText,
+	}, nil
+}
+
+func decodeRetrieveSettingsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*grpcDomainsV1.RetrieveSettingsRes)
+	return retrieveSettingsRes{settings: domains.Settings{
+		Version:               res.GetVersion(),
+		AllowedProtocols:      res.GetAllowedProtocols(),
+		ClientSecretPolicy:    domains.SecretPolicy{MinLength: res.GetClientSecretMinLength()},
+		MessageRetentionDays:  res.GetMessageRetentionDays(),
+		InvitationExpiryHours: res.GetInvitationExpiryHours(),
+		SMTP: domains.SMTP{
+			FromAddress: res.GetSmtpFromAddress(),
+			FromName:    res.GetSmtpFromName(),
+		},
+		Branding: domains.Branding{
+			LogoURL:      res.GetLogoUrl(),
+			PrimaryColor: res.GetPrimaryColor(),
+			FooterText:   res.GetFooterText(),
+		},
+	}}, nil
+}
+
+func encodeRetrieveSettingsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(retrieveSettingsReq)
+	return &grpcDomainsV1.RetrieveSettingsReq{
+		DomainId: req.domainID,
+	}, nil
+}
+
+func (client domainsGrpcClient) RetrieveIDByAlias(ctx context.Context, in *grpcDomainsV1.RetrieveIDByAliasReq, opts ...grpc.CallOption) (*grpcDomainsV1.RetrieveIDByAliasRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.retrieveIDByAlias(ctx, retrieveIDByAliasReq{alias: in.GetAlias()})
+	if err != nil {
+		return &grpcDomainsV1.RetrieveIDByAliasRes{}, grpcapi.DecodeError(err)
+	}
+
+	rir := res.(retrieveIDByAliasRes)
+	return &grpcDomainsV1.RetrieveIDByAliasRes{Id: rir.id}, nil
+}
+
+func decodeRetrieveIDByAliasResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*grpcDomainsV1.RetrieveIDByAliasRes)
+	return retrieveIDByAliasRes{id: res.GetId()}, nil
+}
+
+func encodeRetrieveIDByAliasRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(retrieveIDByAliasReq)
+	return &grpcDomainsV1.RetrieveIDByAliasReq{
+		Alias: req.alias,
+	}, nil
+}
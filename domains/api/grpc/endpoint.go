@@ -43,3 +43,51 @@ func retrieveEntityEndpoint(svc domains.Service) endpoint.Endpoint {
 		}, nil
 	}
 }
+
+func requiresMFAEndpoint(svc domains.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(requiresMFAReq)
+		if err := req.validate(); err != nil {
+			return requiresMFARes{}, err
+		}
+
+		required, err := svc.RequiresMFA(ctx, req.userID)
+		if err != nil {
+			return requiresMFARes{}, err
+		}
+
+		return requiresMFARes{required: required}, nil
+	}
+}
+
+func retrieveSettingsEndpoint(svc domains.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retrieveSettingsReq)
+		if err := req.validate(); err != nil {
+			return retrieveSettingsRes{}, err
+		}
+
+		settings, err := svc.RetrieveSettings(ctx, req.domainID)
+		if err != nil {
+			return retrieveSettingsRes{}, err
+		}
+
+		return retrieveSettingsRes{settings: settings}, nil
+	}
+}
+
+func retrieveIDByAliasEndpoint(svc domains.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retrieveIDByAliasReq)
+		if err := req.validate(); err != nil {
+			return retrieveIDByAliasRes{}, err
+		}
+
+		id, err := svc.RetrieveIDByAlias(ctx, req.alias)
+		if err != nil {
+			return retrieveIDByAliasRes{}, err
+		}
+
+		return retrieveIDByAliasRes{id: id}, nil
+	}
+}
@@ -12,9 +12,11 @@ import (
 
 	grpcDomainsV1 "github.com/absmach/supermq/api/grpc/domains/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
+	smqdomains "github.com/absmach/supermq/domains"
 	grpcapi "github.com/absmach/supermq/domains/api/grpc"
 	domains "github.com/absmach/supermq/domains/private"
 	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
@@ -101,3 +103,131 @@ func TestDeleteUserFromDomains(t *testing.T) {
 		repoCall.Unset()
 	}
 }
+
+func TestRequiresMFA(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	grpcClient := grpcapi.NewDomainsClient(conn, time.Second)
+
+	cases := []struct {
+		desc     string
+		req      *grpcDomainsV1.RequiresMFAReq
+		required bool
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "check user of domain requiring MFA",
+			req:      &grpcDomainsV1.RequiresMFAReq{UserId: id},
+			required: true,
+		},
+		{
+			desc:     "check user of domains not requiring MFA",
+			req:      &grpcDomainsV1.RequiresMFAReq{UserId: id},
+			required: false,
+		},
+		{
+			desc: "check with missing user id",
+			req:  &grpcDomainsV1.RequiresMFAReq{},
+			err:  apiutil.ErrMissingID,
+		},
+		{
+			desc:   "check with failed to list domains",
+			req:    &grpcDomainsV1.RequiresMFAReq{UserId: id},
+			svcErr: svcerr.ErrViewEntity,
+			err:    svcerr.ErrViewEntity,
+		},
+	}
+	for _, tc := range cases {
+		svcCall := svc.On("RequiresMFA", mock.Anything, tc.req.GetUserId()).Return(tc.required, tc.svcErr)
+		res, err := grpcClient.RequiresMFA(context.Background(), tc.req)
+		assert.Equal(t, tc.required, res.GetRequired(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.required, res.GetRequired()))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		svcCall.Unset()
+	}
+}
+
+func TestRetrieveSettings(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	grpcClient := grpcapi.NewDomainsClient(conn, time.Second)
+
+	settings := smqdomains.Settings{
+		Version:            2,
+		AllowedProtocols:   []string{smqdomains.MQTTProtocol},
+		ClientSecretPolicy: smqdomains.SecretPolicy{MinLength: 16},
+		SMTP:               smqdomains.SMTP{FromAddress: email},
+	}
+
+	cases := []struct {
+		desc     string
+		req      *grpcDomainsV1.RetrieveSettingsReq
+		settings smqdomains.Settings
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "retrieve settings successfully",
+			req:      &grpcDomainsV1.RetrieveSettingsReq{DomainId: id},
+			settings: settings,
+		},
+		{
+			desc: "retrieve settings with missing domain id",
+			req:  &grpcDomainsV1.RetrieveSettingsReq{},
+			err:  apiutil.ErrMissingID,
+		},
+		{
+			desc:   "retrieve settings with failed to retrieve",
+			req:    &grpcDomainsV1.RetrieveSettingsReq{DomainId: id},
+			svcErr: svcerr.ErrViewEntity,
+			err:    svcerr.ErrViewEntity,
+		},
+	}
+	for _, tc := range cases {
+		svcCall := svc.On("RetrieveSettings", mock.Anything, tc.req.GetDomainId()).Return(tc.settings, tc.svcErr)
+		res, err := grpcClient.RetrieveSettings(context.Background(), tc.req)
+		assert.Equal(t, tc.settings.Version, res.GetVersion(), fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.settings.Version, res.GetVersion()))
+		assert.Equal(t, tc.settings.AllowedProtocols, res.GetAllowedProtocols(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.settings.AllowedProtocols, res.GetAllowedProtocols()))
+		assert.Equal(t, tc.settings.SMTP.FromAddress, res.GetSmtpFromAddress(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.settings.SMTP.FromAddress, res.GetSmtpFromAddress()))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		svcCall.Unset()
+	}
+}
+
+func TestRetrieveIDByAlias(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	grpcClient := grpcapi.NewDomainsClient(conn, time.Second)
+
+	cases := []struct {
+		desc   string
+		req    *grpcDomainsV1.RetrieveIDByAliasReq
+		id     string
+		svcErr error
+		err    error
+	}{
+		{
+			desc: "retrieve id by alias successfully",
+			req:  &grpcDomainsV1.RetrieveIDByAliasReq{Alias: groupName},
+			id:   id,
+		},
+		{
+			desc: "retrieve id by alias with missing alias",
+			req:  &grpcDomainsV1.RetrieveIDByAliasReq{},
+			err:  apiutil.ErrMissingAlias,
+		},
+		{
+			desc:   "retrieve id by alias with non-existing alias",
+			req:    &grpcDomainsV1.RetrieveIDByAliasReq{Alias: groupName},
+			svcErr: svcerr.ErrNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		svcCall := svc.On("RetrieveIDByAlias", mock.Anything, tc.req.GetAlias()).Return(tc.id, tc.svcErr)
+		res, err := grpcClient.RetrieveIDByAlias(context.Background(), tc.req)
+		assert.Equal(t, tc.id, res.GetId(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.id, res.GetId()))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		svcCall.Unset()
+	}
+}
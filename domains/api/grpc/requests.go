@@ -30,3 +30,39 @@ func (req retrieveEntityReq) validate() error {
 
 	return nil
 }
+
+type requiresMFAReq struct {
+	userID string
+}
+
+func (req requiresMFAReq) validate() error {
+	if req.userID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type retrieveSettingsReq struct {
+	domainID string
+}
+
+func (req retrieveSettingsReq) validate() error {
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type retrieveIDByAliasReq struct {
+	alias string
+}
+
+func (req retrieveIDByAliasReq) validate() error {
+	if req.alias == "" {
+		return apiutil.ErrMissingAlias
+	}
+
+	return nil
+}
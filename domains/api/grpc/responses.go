@@ -3,6 +3,8 @@
 
 package grpc
 
+import "github.com/absmach/supermq/domains"
+
 type deleteUserRes struct {
 	deleted bool
 }
@@ -11,3 +13,15 @@ type retrieveEntityRes struct {
 	id     string
 	status uint8
 }
+
+type requiresMFARes struct {
+	required bool
+}
+
+type retrieveSettingsRes struct {
+	settings domains.Settings
+}
+
+type retrieveIDByAliasRes struct {
+	id string
+}
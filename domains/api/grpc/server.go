@@ -19,6 +19,9 @@ type domainsGrpcServer struct {
 	grpcDomainsV1.UnimplementedDomainsServiceServer
 	deleteUserFromDomains kitgrpc.Handler
 	retrieveEntity        kitgrpc.Handler
+	requiresMFA           kitgrpc.Handler
+	retrieveSettings      kitgrpc.Handler
+	retrieveIDByAlias     kitgrpc.Handler
 }
 
 func NewDomainsServer(svc domains.Service) grpcDomainsV1.DomainsServiceServer {
@@ -33,6 +36,21 @@ func NewDomainsServer(svc domains.Service) grpcDomainsV1.DomainsServiceServer {
 			decodeRetrieveEntityRequest,
 			encodeRetrieveEntityResponse,
 		),
+		requiresMFA: kitgrpc.NewServer(
+			requiresMFAEndpoint(svc),
+			decodeRequiresMFARequest,
+			encodeRequiresMFAResponse,
+		),
+		retrieveSettings: kitgrpc.NewServer(
+			retrieveSettingsEndpoint(svc),
+			decodeRetrieveSettingsRequest,
+			encodeRetrieveSettingsResponse,
+		),
+		retrieveIDByAlias: kitgrpc.NewServer(
+			retrieveIDByAliasEndpoint(svc),
+			decodeRetrieveIDByAliasRequest,
+			encodeRetrieveIDByAliasResponse,
+		),
 	}
 }
 
@@ -83,3 +101,78 @@ func (s *domainsGrpcServer) RetrieveEntity(ctx context.Context, req *grpcCommonV
 
 	return res.(*grpcCommonV1.RetrieveEntityRes), nil
 }
+
+func decodeRequiresMFARequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*grpcDomainsV1.RequiresMFAReq)
+
+	return requiresMFAReq{userID: req.GetUserId()}, nil
+}
+
+func encodeRequiresMFAResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(requiresMFARes)
+
+	return &grpcDomainsV1.RequiresMFARes{Required: res.required}, nil
+}
+
+func (s *domainsGrpcServer) RequiresMFA(ctx context.Context, req *grpcDomainsV1.RequiresMFAReq) (*grpcDomainsV1.RequiresMFARes, error) {
+	_, res, err := s.requiresMFA.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcapi.EncodeError(err)
+	}
+
+	return res.(*grpcDomainsV1.RequiresMFARes), nil
+}
+
+func decodeRetrieveSettingsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*grpcDomainsV1.RetrieveSettingsReq)
+
+	return retrieveSettingsReq{domainID: req.GetDomainId()}, nil
+}
+
+func encodeRetrieveSettingsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(retrieveSettingsRes)
+	s := res.settings
+
+	return &grpcDomainsV1.RetrieveSettingsRes{
+		Version:               s.Version,
+		AllowedProtocols:      s.AllowedProtocols,
+		ClientSecretMinLength: s.ClientSecretPolicy.MinLength,
+		MessageRetentionDays:  s.MessageRetentionDays,
+		InvitationExpiryHours: s.InvitationExpiryHours,
+		SmtpFromAddress:       s.SMTP.FromAddress,
+		SmtpFromName:          s.SMTP.FromName,
+		LogoUrl:               s.Branding.LogoURL,
+		PrimaryColor:          s.Branding.PrimaryColor,
+		FooterText:            s.Branding.FooterText,
+	}, nil
+}
+
+func (s *domainsGrpcServer) RetrieveSettings(ctx context.Context, req *grpcDomainsV1.RetrieveSettingsReq) (*grpcDomainsV1.RetrieveSettingsRes, error) {
+	_, res, err := s.retrieveSettings.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcapi.EncodeError(err)
+	}
+
+	return res.(*grpcDomainsV1.RetrieveSettingsRes), nil
+}
+
+func decodeRetrieveIDByAliasRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*grpcDomainsV1.RetrieveIDByAliasReq)
+
+	return retrieveIDByAliasReq{alias: req.GetAlias()}, nil
+}
+
+func encodeRetrieveIDByAliasResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(retrieveIDByAliasRes)
+
+	return &grpcDomainsV1.RetrieveIDByAliasRes{Id: res.id}, nil
+}
+
+func (s *domainsGrpcServer) RetrieveIDByAlias(ctx context.Context, req *grpcDomainsV1.RetrieveIDByAliasReq) (*grpcDomainsV1.RetrieveIDByAliasRes, error) {
+	_, res, err := s.retrieveIDByAlias.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcapi.EncodeError(err)
+	}
+
+	return res.(*grpcDomainsV1.RetrieveIDByAliasRes), nil
+}
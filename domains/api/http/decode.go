@@ -51,6 +51,54 @@ func decodeUpdateDomainRequest(_ context.Context, r *http.Request) (interface{},
 	return req, nil
 }
 
+func decodeUpdateDomainQuotasRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := updateDomainQuotasReq{
+		domainID: chi.URLParam(r, "domainID"),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Quotas); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeTransferDomainRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := transferDomainReq{
+		domainID: chi.URLParam(r, "domainID"),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeUpdateDomainSettingsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := updateDomainSettingsReq{
+		domainID: chi.URLParam(r, "domainID"),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Settings); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
 func decodeListDomainRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	page, err := decodePageRequest(ctx, r)
 	if err != nil {
@@ -84,6 +132,20 @@ func decodeFreezeDomainRequest(_ context.Context, r *http.Request) (interface{},
 	return req, nil
 }
 
+func decodeDeleteDomainRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := deleteDomainReq{
+		domainID: chi.URLParam(r, "domainID"),
+	}
+	return req, nil
+}
+
+func decodeRestoreDomainRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := restoreDomainReq{
+		domainID: chi.URLParam(r, "domainID"),
+	}
+	return req, nil
+}
+
 func decodePageRequest(_ context.Context, r *http.Request) (page, error) {
 	s, err := apiutil.ReadStringQuery(r, api.StatusKey, api.DefClientStatus)
 	if err != nil {
@@ -28,10 +28,11 @@ func createDomainEndpoint(svc domains.Service) endpoint.Endpoint {
 		}
 
 		d := domains.Domain{
-			Name:     req.Name,
-			Metadata: req.Metadata,
-			Tags:     req.Tags,
-			Alias:    req.Alias,
+			Name:       req.Name,
+			Metadata:   req.Metadata,
+			Tags:       req.Tags,
+			Alias:      req.Alias,
+			RequireMFA: req.RequireMFA,
 		}
 		domain, _, err := svc.CreateDomain(ctx, session, d)
 		if err != nil {
@@ -79,10 +80,11 @@ func updateDomainEndpoint(svc domains.Service) endpoint.Endpoint {
 			metadata = *req.Metadata
 		}
 		d := domains.DomainReq{
-			Name:     req.Name,
-			Metadata: &metadata,
-			Tags:     req.Tags,
-			Alias:    req.Alias,
+			Name:       req.Name,
+			Metadata:   &metadata,
+			Tags:       req.Tags,
+			Alias:      req.Alias,
+			RequireMFA: req.RequireMFA,
 		}
 		domain, err := svc.UpdateDomain(ctx, session, req.domainID, d)
 		if err != nil {
@@ -182,3 +184,125 @@ func freezeDomainEndpoint(svc domains.Service) endpoint.Endpoint {
 		return freezeDomainRes{}, nil
 	}
 }
+
+func deleteDomainEndpoint(svc domains.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deleteDomainReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		if _, err := svc.DeleteDomain(ctx, session, req.domainID); err != nil {
+			return nil, err
+		}
+		return deleteDomainRes{}, nil
+	}
+}
+
+func restoreDomainEndpoint(svc domains.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(restoreDomainReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		if _, err := svc.RestoreDomain(ctx, session, req.domainID); err != nil {
+			return nil, err
+		}
+		return restoreDomainRes{}, nil
+	}
+}
+
+func updateDomainQuotasEndpoint(svc domains.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateDomainQuotasReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		domain, err := svc.UpdateDomainQuotas(ctx, session, req.domainID, req.Quotas)
+		if err != nil {
+			return nil, err
+		}
+
+		return updateDomainRes{domain}, nil
+	}
+}
+
+func transferDomainEndpoint(svc domains.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transferDomainReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		domain, err := svc.TransferDomainOwnership(ctx, session, req.domainID, req.NewOwnerID)
+		if err != nil {
+			return nil, err
+		}
+
+		return updateDomainRes{domain}, nil
+	}
+}
+
+func retrieveDomainSettingsEndpoint(svc domains.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retrieveDomainRequest)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		settings, err := svc.RetrieveDomainSettings(ctx, session, req.domainID)
+		if err != nil {
+			return nil, err
+		}
+
+		return domainSettingsRes{settings}, nil
+	}
+}
+
+func updateDomainSettingsEndpoint(svc domains.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateDomainSettingsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		settings, err := svc.UpdateDomainSettings(ctx, session, req.domainID, req.Settings)
+		if err != nil {
+			return nil, err
+		}
+
+		return domainSettingsRes{settings}, nil
+	}
+}
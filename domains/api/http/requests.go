@@ -23,10 +23,11 @@ type page struct {
 }
 
 type createDomainReq struct {
-	Name     string                 `json:"name"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
-	Tags     []string               `json:"tags,omitempty"`
-	Alias    string                 `json:"alias"`
+	Name       string                 `json:"name"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	Tags       []string               `json:"tags,omitempty"`
+	Alias      string                 `json:"alias"`
+	RequireMFA bool                   `json:"require_mfa,omitempty"`
 }
 
 func (req createDomainReq) validate() error {
@@ -54,11 +55,12 @@ func (req retrieveDomainRequest) validate() error {
 }
 
 type updateDomainReq struct {
-	domainID string
-	Name     *string                 `json:"name,omitempty"`
-	Metadata *map[string]interface{} `json:"metadata,omitempty"`
-	Tags     *[]string               `json:"tags,omitempty"`
-	Alias    *string                 `json:"alias,omitempty"`
+	domainID   string
+	Name       *string                 `json:"name,omitempty"`
+	Metadata   *map[string]interface{} `json:"metadata,omitempty"`
+	Tags       *[]string               `json:"tags,omitempty"`
+	Alias      *string                 `json:"alias,omitempty"`
+	RequireMFA *bool                   `json:"require_mfa,omitempty"`
 }
 
 func (req updateDomainReq) validate() error {
@@ -112,3 +114,69 @@ func (req freezeDomainReq) validate() error {
 
 	return nil
 }
+
+type deleteDomainReq struct {
+	domainID string
+}
+
+func (req deleteDomainReq) validate() error {
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type restoreDomainReq struct {
+	domainID string
+}
+
+func (req restoreDomainReq) validate() error {
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type updateDomainQuotasReq struct {
+	domainID string
+	domains.Quotas
+}
+
+func (req updateDomainQuotasReq) validate() error {
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type transferDomainReq struct {
+	domainID   string
+	NewOwnerID string `json:"new_owner_id"`
+}
+
+func (req transferDomainReq) validate() error {
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.NewOwnerID == "" {
+		return apiutil.ErrMissingUserID
+	}
+
+	return nil
+}
+
+type updateDomainSettingsReq struct {
+	domainID string
+	domains.Settings
+}
+
+func (req updateDomainSettingsReq) validate() error {
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
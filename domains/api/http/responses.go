@@ -14,6 +14,7 @@ var (
 	_ supermq.Response = (*createDomainRes)(nil)
 	_ supermq.Response = (*retrieveDomainRes)(nil)
 	_ supermq.Response = (*listDomainsRes)(nil)
+	_ supermq.Response = (*domainSettingsRes)(nil)
 )
 
 type createDomainRes struct {
@@ -121,3 +122,47 @@ func (res freezeDomainRes) Headers() map[string]string {
 func (res freezeDomainRes) Empty() bool {
 	return true
 }
+
+type deleteDomainRes struct{}
+
+func (res deleteDomainRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res deleteDomainRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deleteDomainRes) Empty() bool {
+	return true
+}
+
+type restoreDomainRes struct{}
+
+func (res restoreDomainRes) Code() int {
+	return http.StatusOK
+}
+
+func (res restoreDomainRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res restoreDomainRes) Empty() bool {
+	return true
+}
+
+type domainSettingsRes struct {
+	domains.Settings
+}
+
+func (res domainSettingsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res domainSettingsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res domainSettingsRes) Empty() bool {
+	return false
+}
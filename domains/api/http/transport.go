@@ -80,6 +80,48 @@ func MakeHandler(svc domains.Service, authn authn.Authentication, mux *chi.Mux,
 				api.EncodeResponse,
 				opts...,
 			), "freeze_domain").ServeHTTP)
+
+			r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+				deleteDomainEndpoint(svc),
+				decodeDeleteDomainRequest,
+				api.EncodeResponse,
+				opts...,
+			), "delete_domain").ServeHTTP)
+
+			r.Post("/restore", otelhttp.NewHandler(kithttp.NewServer(
+				restoreDomainEndpoint(svc),
+				decodeRestoreDomainRequest,
+				api.EncodeResponse,
+				opts...,
+			), "restore_domain").ServeHTTP)
+
+			r.Patch("/quotas", otelhttp.NewHandler(kithttp.NewServer(
+				updateDomainQuotasEndpoint(svc),
+				decodeUpdateDomainQuotasRequest,
+				api.EncodeResponse,
+				opts...,
+			), "update_domain_quotas").ServeHTTP)
+
+			r.Post("/transfer", otelhttp.NewHandler(kithttp.NewServer(
+				transferDomainEndpoint(svc),
+				decodeTransferDomainRequest,
+				api.EncodeResponse,
+				opts...,
+			), "transfer_domain").ServeHTTP)
+
+			r.Get("/settings", otelhttp.NewHandler(kithttp.NewServer(
+				retrieveDomainSettingsEndpoint(svc),
+				decodeRetrieveDomainRequest,
+				api.EncodeResponse,
+				opts...,
+			), "view_domain_settings").ServeHTTP)
+
+			r.Put("/settings", otelhttp.NewHandler(kithttp.NewServer(
+				updateDomainSettingsEndpoint(svc),
+				decodeUpdateDomainSettingsRequest,
+				api.EncodeResponse,
+				opts...,
+			), "update_domain_settings").ServeHTTP)
 			roleManagerHttp.EntityRoleMangerRouter(svc, d, r, opts)
 		})
 	})
@@ -0,0 +1,149 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// The DeleteHandler is a cron job that runs periodically to purge domains that have been marked as deleted
+// for a certain period of time. Until the period passes, the domain can be restored.
+// Once it passes, the handler notifies the services owning the domain entities to remove them,
+// deletes the domain roles and relations from the policy service and deletes the domain from the database.
+
+package domains
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/pkg/policies"
+)
+
+const defLimit = uint64(100)
+
+type handler struct {
+	domains       Repository
+	policies      policies.Service
+	notifier      PurgeNotifier
+	checkInterval time.Duration
+	deleteAfter   time.Duration
+	logger        *slog.Logger
+}
+
+func NewDeleteHandler(ctx context.Context, domains Repository, policyService policies.Service, notifier PurgeNotifier, defCheckInterval, deleteAfter time.Duration, logger *slog.Logger) {
+	handler := &handler{
+		domains:       domains,
+		policies:      policyService,
+		notifier:      notifier,
+		checkInterval: defCheckInterval,
+		deleteAfter:   deleteAfter,
+		logger:        logger,
+	}
+
+	go func() {
+		ticker := time.NewTicker(handler.checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				handler.handle(ctx)
+			}
+		}
+	}()
+}
+
+func (h *handler) handle(ctx context.Context) {
+	pm := Page{Limit: defLimit, Offset: 0, Status: DeletedStatus}
+
+	for {
+		dp, err := h.domains.ListDomains(ctx, pm)
+		if err != nil {
+			h.logger.Error("failed to retrieve domains", slog.Any("error", err))
+			return
+		}
+
+		var purged uint64
+		for _, d := range dp.Domains {
+			deletedAt := d.DeletedAt
+			if deletedAt.IsZero() {
+				deletedAt = d.UpdatedAt
+			}
+			if time.Since(deletedAt) < h.deleteAfter {
+				continue
+			}
+			if err := h.purge(ctx, d); err != nil {
+				h.logger.Error("failed to purge domain", slog.String("id", d.ID), slog.Any("error", err))
+				continue
+			}
+			purged++
+
+			h.logger.Info("domain purged", slog.Group("domain",
+				slog.String("id", d.ID),
+				slog.String("name", d.Name),
+				slog.String("alias", d.Alias),
+			))
+		}
+
+		// Purged domains are no longer listed, so the next page starts
+		// after the domains that are kept.
+		pm.Offset += uint64(len(dp.Domains)) - purged
+		if len(dp.Domains) == 0 || pm.Offset >= dp.Total {
+			return
+		}
+	}
+}
+
+func (h *handler) purge(ctx context.Context, d Domain) error {
+	if err := h.notifier.NotifyPurge(ctx, d.ID); err != nil {
+		return err
+	}
+
+	ears, emrs, err := h.domains.RetrieveEntitiesRolesActionsMembers(ctx, []string{d.ID})
+	if err != nil {
+		return err
+	}
+	deletePolicies := []policies.Policy{}
+	for _, ear := range ears {
+		deletePolicies = append(deletePolicies, policies.Policy{
+			Subject:         ear.RoleID,
+			SubjectRelation: policies.MemberRelation,
+			SubjectType:     policies.RoleType,
+			Relation:        ear.Action,
+			ObjectType:      policies.DomainType,
+			Object:          ear.EntityID,
+		})
+	}
+	for _, emr := range emrs {
+		deletePolicies = append(deletePolicies, policies.Policy{
+			Subject:     policies.EncodeDomainUserID(d.ID, emr.MemberID),
+			SubjectType: policies.UserType,
+			Relation:    policies.MemberRelation,
+			ObjectType:  policies.RoleType,
+			Object:      emr.RoleID,
+		})
+	}
+	if len(deletePolicies) > 0 {
+		if err := h.policies.DeletePolicies(ctx, deletePolicies); err != nil {
+			return err
+		}
+	}
+
+	filterDeletePolicies := []policies.Policy{
+		{
+			SubjectType: policies.DomainType,
+			Subject:     d.ID,
+		},
+		{
+			ObjectType: policies.DomainType,
+			Object:     d.ID,
+		},
+	}
+	for _, filter := range filterDeletePolicies {
+		if err := h.policies.DeletePolicyFilter(ctx, filter); err != nil {
+			return err
+		}
+	}
+
+	// Domain roles, their actions and members are removed by the database cascade.
+	return h.domains.Delete(ctx, d.ID)
+}
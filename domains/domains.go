@@ -96,29 +96,65 @@ func (s *Status) UnmarshalJSON(data []byte) error {
 // Metadata represents arbitrary JSON.
 type Metadata map[string]interface{}
 
+// Quotas represents the domain resource limits. Zero value of a limit
+// means that the resource is not limited.
+type Quotas struct {
+	Clients           uint64 `json:"clients,omitempty"`
+	Channels          uint64 `json:"channels,omitempty"`
+	Groups            uint64 `json:"groups,omitempty"`
+	Members           uint64 `json:"members,omitempty"`
+	MessagesPerMinute uint64 `json:"messages_per_minute,omitempty"`
+	// Storage is the limit of the stored messages size in bytes,
+	// enforced by the message storage consumers.
+	Storage uint64 `json:"storage,omitempty"`
+}
+
+// Usage represents the domain resource usage.
+type Usage struct {
+	Clients  uint64 `json:"clients"`
+	Channels uint64 `json:"channels"`
+	Groups   uint64 `json:"groups"`
+	Members  uint64 `json:"members"`
+}
+
+// CheckQuota returns ErrQuotaExceeded if the requested resources together
+// with the used ones exceed the limit. Zero limit means no limit.
+func CheckQuota(limit, used, requested uint64) error {
+	if limit == 0 || used+requested <= limit {
+		return nil
+	}
+	return svcerr.ErrQuotaExceeded
+}
+
 type DomainReq struct {
-	Name      *string    `json:"name,omitempty"`
-	Metadata  *Metadata  `json:"metadata,omitempty"`
-	Tags      *[]string  `json:"tags,omitempty"`
-	Alias     *string    `json:"alias,omitempty"`
-	Status    *Status    `json:"status,omitempty"`
-	UpdatedBy *string    `json:"updated_by,omitempty"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	Name       *string    `json:"name,omitempty"`
+	Metadata   *Metadata  `json:"metadata,omitempty"`
+	Tags       *[]string  `json:"tags,omitempty"`
+	Alias      *string    `json:"alias,omitempty"`
+	Status     *Status    `json:"status,omitempty"`
+	RequireMFA *bool      `json:"require_mfa,omitempty"`
+	Quotas     *Quotas    `json:"quotas,omitempty"`
+	UpdatedBy  *string    `json:"updated_by,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
 }
 type Domain struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Metadata  Metadata  `json:"metadata,omitempty"`
-	Tags      []string  `json:"tags,omitempty"`
-	Alias     string    `json:"alias,omitempty"`
-	Status    Status    `json:"status"`
-	RoleID    string    `json:"role_id,omitempty"`
-	RoleName  string    `json:"role_name,omitempty"`
-	Actions   []string  `json:"actions,omitempty"`
-	CreatedBy string    `json:"created_by,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedBy string    `json:"updated_by,omitempty"`
-	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Metadata   Metadata  `json:"metadata,omitempty"`
+	Tags       []string  `json:"tags,omitempty"`
+	Alias      string    `json:"alias,omitempty"`
+	Status     Status    `json:"status"`
+	RequireMFA bool      `json:"require_mfa"`
+	Quotas     Quotas    `json:"quotas"`
+	Usage      *Usage    `json:"usage,omitempty"`
+	RoleID     string    `json:"role_id,omitempty"`
+	RoleName   string    `json:"role_name,omitempty"`
+	Actions    []string  `json:"actions,omitempty"`
+	CreatedBy  string    `json:"created_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedBy  string    `json:"updated_by,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty"`
+	DeletedAt  time.Time `json:"deleted_at,omitempty"`
 }
 
 type Page struct {
@@ -170,6 +206,22 @@ type Service interface {
 	EnableDomain(ctx context.Context, sesssion authn.Session, id string) (Domain, error)
 	DisableDomain(ctx context.Context, sesssion authn.Session, id string) (Domain, error)
 	FreezeDomain(ctx context.Context, sesssion authn.Session, id string) (Domain, error)
+	// DeleteDomain marks the domain as deleted. The domain and its entities
+	// are purged once the grace period passes, until then it can be restored.
+	DeleteDomain(ctx context.Context, sesssion authn.Session, id string) (Domain, error)
+	// RestoreDomain restores the domain marked as deleted.
+	RestoreDomain(ctx context.Context, sesssion authn.Session, id string) (Domain, error)
+	// UpdateDomainQuotas sets the domain resource quotas. Only the super
+	// admin can update the quotas.
+	UpdateDomainQuotas(ctx context.Context, sesssion authn.Session, id string, q Quotas) (Domain, error)
+	// TransferDomainOwnership makes the new owner the only member of the
+	// domain built-in admin role.
+	TransferDomainOwnership(ctx context.Context, sesssion authn.Session, id, newOwnerID string) (Domain, error)
+	// RetrieveDomainSettings retrieves the domain settings.
+	RetrieveDomainSettings(ctx context.Context, sesssion authn.Session, id string) (Settings, error)
+	// UpdateDomainSettings validates and replaces the domain settings. If
+	// the settings version is set, it must match the current version.
+	UpdateDomainSettings(ctx context.Context, sesssion authn.Session, id string, s Settings) (Settings, error)
 	ListDomains(ctx context.Context, sesssion authn.Session, page Page) (DomainsPage, error)
 	roles.RoleManager
 }
@@ -184,6 +236,9 @@ type Repository interface {
 	// RetrieveByID retrieves Domain by its unique ID.
 	RetrieveByID(ctx context.Context, id string) (Domain, error)
 
+	// RetrieveByAlias retrieves Domain by its unique alias.
+	RetrieveByAlias(ctx context.Context, alias string) (Domain, error)
+
 	RetrieveByUserAndID(ctx context.Context, userID, id string) (Domain, error)
 
 	// RetrieveAllByIDs retrieves for given Domain IDs.
@@ -195,12 +250,47 @@ type Repository interface {
 	// Delete
 	Delete(ctx context.Context, id string) error
 
+	// SoftDelete marks the domain as deleted and keeps its current status
+	// to be restored. The already deleted domain is not found, so the grace
+	// period is not restarted.
+	SoftDelete(ctx context.Context, id, deletedBy string, deletedAt time.Time) (Domain, error)
+
+	// Restore restores the status the deleted domain had before the deletion.
+	Restore(ctx context.Context, id, restoredBy string, restoredAt time.Time) (Domain, error)
+
 	// ListDomains list all the domains
 	ListDomains(ctx context.Context, pm Page) (DomainsPage, error)
 
+	// RetrieveSettings retrieves the domain settings.
+	RetrieveSettings(ctx context.Context, domainID string) (Settings, error)
+
+	// UpdateSettings replaces the domain settings and increments their version.
+	UpdateSettings(ctx context.Context, domainID string, s Settings) (Settings, error)
+
+	// LockQuotas locks the quotas of the domain for all the service instances
+	// that use the same database, until the returned unlock function is called.
+	// The quota usage is counted and changed while the lock is held.
+	LockQuotas(ctx context.Context, domainID string) (func(), error)
+
 	roles.Repository
 }
 
+// PurgeNotifier notifies the other services that the domain has been purged,
+// so that they remove the entities that belong to it.
+//
+//go:generate mockery --name PurgeNotifier --output=./mocks --filename purgenotifier.go --quiet --note "Copyright (c) Abstract Machines"
+type PurgeNotifier interface {
+	// NotifyPurge publishes the purge of the domain with the given ID.
+	NotifyPurge(ctx context.Context, domainID string) error
+}
+
+// Purger removes the entities of the purged domain from the service
+// that owns them.
+type Purger interface {
+	// PurgeDomain removes all the entities of the domain with the given ID.
+	PurgeDomain(ctx context.Context, domainID string) error
+}
+
 // Cache contains domains caching interface.
 //
 //go:generate mockery --name Cache --output=./mocks --filename cache.go --quiet --note "Copyright (c) Abstract Machines"
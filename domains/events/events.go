@@ -21,6 +21,12 @@ const (
 	domainEnable         = domainPrefix + "enable"
 	domainDisable        = domainPrefix + "disable"
 	domainFreeze         = domainPrefix + "freeze"
+	domainDelete         = domainPrefix + "delete"
+	domainRestore        = domainPrefix + "restore"
+	domainPurge          = domainPrefix + "purge"
+	domainUpdateQuotas   = domainPrefix + "update_quotas"
+	domainTransfer       = domainPrefix + "transfer"
+	domainUpdateSettings = domainPrefix + "update_settings"
 	domainList           = domainPrefix + "list"
 	domainUserDelete     = domainPrefix + "user_delete"
 )
@@ -33,6 +39,12 @@ var (
 	_ events.Event = (*enableDomainEvent)(nil)
 	_ events.Event = (*disableDomainEvent)(nil)
 	_ events.Event = (*freezeDomainEvent)(nil)
+	_ events.Event = (*deleteDomainEvent)(nil)
+	_ events.Event = (*restoreDomainEvent)(nil)
+	_ events.Event = (*updateDomainQuotasEvent)(nil)
+	_ events.Event = (*transferDomainEvent)(nil)
+	_ events.Event = (*updateDomainSettingsEvent)(nil)
+	_ events.Event = (*purgeDomainEvent)(nil)
 	_ events.Event = (*listDomainsEvent)(nil)
 )
 
@@ -48,6 +60,7 @@ func (cde createDomainEvent) Encode() (map[string]interface{}, error) {
 		"id":                cde.ID,
 		"alias":             cde.Alias,
 		"status":            cde.Status.String(),
+		"require_mfa":       cde.RequireMFA,
 		"created_at":        cde.CreatedAt,
 		"created_by":        cde.CreatedBy,
 		"roles_provisioned": cde.rolesProvisioned,
@@ -135,6 +148,7 @@ func (ude updateDomainEvent) Encode() (map[string]interface{}, error) {
 		"id":          ude.ID,
 		"alias":       ude.Alias,
 		"status":      ude.Status.String(),
+		"require_mfa": ude.RequireMFA,
 		"created_at":  ude.CreatedAt,
 		"created_by":  ude.CreatedBy,
 		"updated_at":  ude.UpdatedAt,
@@ -214,6 +228,125 @@ func (cdse freezeDomainEvent) Encode() (map[string]interface{}, error) {
 	}, nil
 }
 
+type deleteDomainEvent struct {
+	domainID  string
+	updatedAt time.Time
+	updatedBy string
+	authn.Session
+}
+
+func (cdse deleteDomainEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   domainDelete,
+		"id":          cdse.domainID,
+		"updated_at":  cdse.updatedAt,
+		"updated_by":  cdse.updatedBy,
+		"user_id":     cdse.UserID,
+		"token_type":  cdse.Type.String(),
+		"super_admin": cdse.SuperAdmin,
+	}, nil
+}
+
+type restoreDomainEvent struct {
+	domainID  string
+	updatedAt time.Time
+	updatedBy string
+	authn.Session
+}
+
+func (cdse restoreDomainEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   domainRestore,
+		"id":          cdse.domainID,
+		"updated_at":  cdse.updatedAt,
+		"updated_by":  cdse.updatedBy,
+		"user_id":     cdse.UserID,
+		"token_type":  cdse.Type.String(),
+		"super_admin": cdse.SuperAdmin,
+	}, nil
+}
+
+type transferDomainEvent struct {
+	domainID   string
+	newOwnerID string
+	updatedAt  time.Time
+	updatedBy  string
+	authn.Session
+}
+
+func (tde transferDomainEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":    domainTransfer,
+		"id":           tde.domainID,
+		"new_owner_id": tde.newOwnerID,
+		"updated_at":   tde.updatedAt,
+		"updated_by":   tde.updatedBy,
+		"user_id":      tde.UserID,
+		"token_type":   tde.Type.String(),
+		"super_admin":  tde.SuperAdmin,
+	}, nil
+}
+
+type purgeDomainEvent struct {
+	domainID string
+	purgedAt time.Time
+}
+
+func (pde purgeDomainEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": domainPurge,
+		"id":        pde.domainID,
+		"purged_at": pde.purgedAt,
+	}, nil
+}
+
+type updateDomainQuotasEvent struct {
+	domainID  string
+	quotas    domains.Quotas
+	updatedAt time.Time
+	updatedBy string
+	authn.Session
+}
+
+func (uqe updateDomainQuotasEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": domainUpdateQuotas,
+		"id":        uqe.domainID,
+		"quotas": map[string]interface{}{
+			"clients":             uqe.quotas.Clients,
+			"channels":            uqe.quotas.Channels,
+			"groups":              uqe.quotas.Groups,
+			"members":             uqe.quotas.Members,
+			"messages_per_minute": uqe.quotas.MessagesPerMinute,
+			"storage":             uqe.quotas.Storage,
+		},
+		"updated_at":  uqe.updatedAt,
+		"updated_by":  uqe.updatedBy,
+		"user_id":     uqe.UserID,
+		"token_type":  uqe.Type.String(),
+		"super_admin": uqe.SuperAdmin,
+	}, nil
+}
+
+type updateDomainSettingsEvent struct {
+	domainID string
+	settings domains.Settings
+	authn.Session
+}
+
+func (use updateDomainSettingsEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   domainUpdateSettings,
+		"id":          use.domainID,
+		"version":     use.settings.Version,
+		"updated_at":  use.settings.UpdatedAt,
+		"updated_by":  use.settings.UpdatedBy,
+		"user_id":     use.UserID,
+		"token_type":  use.Type.String(),
+		"super_admin": use.SuperAdmin,
+	}, nil
+}
+
 type listDomainsEvent struct {
 	domains.Page
 	total      uint64
@@ -0,0 +1,41 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/domains"
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+)
+
+var _ domains.PurgeNotifier = (*notifier)(nil)
+
+type notifier struct {
+	events.Publisher
+}
+
+// NewPurgeNotifier returns purge notifier that publishes the domain purge
+// events to the event store, so the services remove the domain entities.
+func NewPurgeNotifier(ctx context.Context, url string) (domains.PurgeNotifier, error) {
+	publisher, err := store.NewPublisher(ctx, url, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &notifier{
+		Publisher: publisher,
+	}, nil
+}
+
+func (n *notifier) NotifyPurge(ctx context.Context, domainID string) error {
+	event := purgeDomainEvent{
+		domainID: domainID,
+		purgedAt: time.Now().UTC(),
+	}
+
+	return n.Publish(ctx, event)
+}
@@ -156,6 +156,111 @@ func (es *eventStore) FreezeDomain(ctx context.Context, session authn.Session, i
 	return domain, nil
 }
 
+func (es *eventStore) DeleteDomain(ctx context.Context, session authn.Session, id string) (domains.Domain, error) {
+	domain, err := es.svc.DeleteDomain(ctx, session, id)
+	if err != nil {
+		return domain, err
+	}
+
+	event := deleteDomainEvent{
+		domainID:  id,
+		updatedAt: domain.UpdatedAt,
+		updatedBy: domain.UpdatedBy,
+		Session:   session,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return domain, err
+	}
+
+	return domain, nil
+}
+
+func (es *eventStore) RestoreDomain(ctx context.Context, session authn.Session, id string) (domains.Domain, error) {
+	domain, err := es.svc.RestoreDomain(ctx, session, id)
+	if err != nil {
+		return domain, err
+	}
+
+	event := restoreDomainEvent{
+		domainID:  id,
+		updatedAt: domain.UpdatedAt,
+		updatedBy: domain.UpdatedBy,
+		Session:   session,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return domain, err
+	}
+
+	return domain, nil
+}
+
+func (es *eventStore) UpdateDomainQuotas(ctx context.Context, session authn.Session, id string, q domains.Quotas) (domains.Domain, error) {
+	domain, err := es.svc.UpdateDomainQuotas(ctx, session, id, q)
+	if err != nil {
+		return domain, err
+	}
+
+	event := updateDomainQuotasEvent{
+		domainID:  id,
+		quotas:    domain.Quotas,
+		updatedAt: domain.UpdatedAt,
+		updatedBy: domain.UpdatedBy,
+		Session:   session,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return domain, err
+	}
+
+	return domain, nil
+}
+
+func (es *eventStore) TransferDomainOwnership(ctx context.Context, session authn.Session, id, newOwnerID string) (domains.Domain, error) {
+	domain, err := es.svc.TransferDomainOwnership(ctx, session, id, newOwnerID)
+	if err != nil {
+		return domain, err
+	}
+
+	event := transferDomainEvent{
+		domainID:   id,
+		newOwnerID: newOwnerID,
+		updatedAt:  domain.UpdatedAt,
+		updatedBy:  domain.UpdatedBy,
+		Session:    session,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return domain, err
+	}
+
+	return domain, nil
+}
+
+func (es *eventStore) RetrieveDomainSettings(ctx context.Context, session authn.Session, id string) (domains.Settings, error) {
+	return es.svc.RetrieveDomainSettings(ctx, session, id)
+}
+
+func (es *eventStore) UpdateDomainSettings(ctx context.Context, session authn.Session, id string, s domains.Settings) (domains.Settings, error) {
+	settings, err := es.svc.UpdateDomainSettings(ctx, session, id, s)
+	if err != nil {
+		return settings, err
+	}
+
+	event := updateDomainSettingsEvent{
+		domainID: id,
+		settings: settings,
+		Session:  session,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return settings, err
+	}
+
+	return settings, nil
+}
+
 func (es *eventStore) ListDomains(ctx context.Context, session authn.Session, p domains.Page) (domains.DomainsPage, error) {
 	dp, err := es.svc.ListDomains(ctx, session, p)
 	if err != nil {
@@ -120,6 +120,91 @@ func (am *authorizationMiddleware) FreezeDomain(ctx context.Context, session aut
 	return am.svc.FreezeDomain(ctx, session, id)
 }
 
+func (am *authorizationMiddleware) UpdateDomainQuotas(ctx context.Context, session authn.Session, id string, q domains.Quotas) (domains.Domain, error) {
+	// Only SuperAdmin can update the domain quotas
+	if err := am.authz.Authorize(ctx, authz.PolicyReq{
+		Subject:     session.UserID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Permission:  policies.AdminPermission,
+		Object:      policies.SuperMQObject,
+		ObjectType:  policies.PlatformType,
+	}); err != nil {
+		return domains.Domain{}, err
+	}
+	return am.svc.UpdateDomainQuotas(ctx, session, id, q)
+}
+
+func (am *authorizationMiddleware) DeleteDomain(ctx context.Context, session authn.Session, id string) (domains.Domain, error) {
+	if err := am.authorize(ctx, domains.OpDeleteDomain, authz.PolicyReq{
+		Subject:     session.DomainUserID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Object:      id,
+		ObjectType:  policies.DomainType,
+	}); err != nil {
+		return domains.Domain{}, err
+	}
+
+	return am.svc.DeleteDomain(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) RestoreDomain(ctx context.Context, session authn.Session, id string) (domains.Domain, error) {
+	if err := am.authorize(ctx, domains.OpRestoreDomain, authz.PolicyReq{
+		Subject:     session.DomainUserID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Object:      id,
+		ObjectType:  policies.DomainType,
+	}); err != nil {
+		return domains.Domain{}, err
+	}
+
+	return am.svc.RestoreDomain(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) TransferDomainOwnership(ctx context.Context, session authn.Session, id, newOwnerID string) (domains.Domain, error) {
+	if err := am.authorize(ctx, domains.OpTransferDomain, authz.PolicyReq{
+		Subject:     session.DomainUserID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Object:      id,
+		ObjectType:  policies.DomainType,
+	}); err != nil {
+		return domains.Domain{}, err
+	}
+
+	return am.svc.TransferDomainOwnership(ctx, session, id, newOwnerID)
+}
+
+func (am *authorizationMiddleware) RetrieveDomainSettings(ctx context.Context, session authn.Session, id string) (domains.Settings, error) {
+	if err := am.authorize(ctx, domains.OpRetrieveDomainSettings, authz.PolicyReq{
+		Subject:     session.DomainUserID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Object:      id,
+		ObjectType:  policies.DomainType,
+	}); err != nil {
+		return domains.Settings{}, err
+	}
+
+	return am.svc.RetrieveDomainSettings(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) UpdateDomainSettings(ctx context.Context, session authn.Session, id string, s domains.Settings) (domains.Settings, error) {
+	if err := am.authorize(ctx, domains.OpUpdateDomainSettings, authz.PolicyReq{
+		Subject:     session.DomainUserID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Object:      id,
+		ObjectType:  policies.DomainType,
+	}); err != nil {
+		return domains.Settings{}, err
+	}
+
+	return am.svc.UpdateDomainSettings(ctx, session, id, s)
+}
+
 func (am *authorizationMiddleware) ListDomains(ctx context.Context, session authn.Session, page domains.Page) (domains.DomainsPage, error) {
 	if err := am.authz.Authorize(ctx, authz.PolicyReq{
 		Subject:     session.UserID,
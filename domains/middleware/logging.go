@@ -145,6 +145,121 @@ func (lm *loggingMiddleware) FreezeDomain(ctx context.Context, session authn.Ses
 	return lm.svc.FreezeDomain(ctx, session, id)
 }
 
+func (lm *loggingMiddleware) UpdateDomainQuotas(ctx context.Context, session authn.Session, id string, q domains.Quotas) (do domains.Domain, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("domain",
+				slog.String("id", id),
+				slog.String("name", do.Name),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Update domain quotas failed", args...)
+			return
+		}
+		lm.logger.Info("Update domain quotas completed successfully", args...)
+	}(time.Now())
+	return lm.svc.UpdateDomainQuotas(ctx, session, id, q)
+}
+
+func (lm *loggingMiddleware) DeleteDomain(ctx context.Context, session authn.Session, id string) (do domains.Domain, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("domain",
+				slog.String("id", id),
+				slog.String("name", do.Name),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Delete domain failed", args...)
+			return
+		}
+		lm.logger.Info("Delete domain completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteDomain(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) RestoreDomain(ctx context.Context, session authn.Session, id string) (do domains.Domain, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("domain",
+				slog.String("id", id),
+				slog.String("name", do.Name),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Restore domain failed", args...)
+			return
+		}
+		lm.logger.Info("Restore domain completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RestoreDomain(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) TransferDomainOwnership(ctx context.Context, session authn.Session, id, newOwnerID string) (do domains.Domain, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("domain",
+				slog.String("id", id),
+				slog.String("name", do.Name),
+			),
+			slog.String("new_owner_id", newOwnerID),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Transfer domain ownership failed", args...)
+			return
+		}
+		lm.logger.Info("Transfer domain ownership completed successfully", args...)
+	}(time.Now())
+	return lm.svc.TransferDomainOwnership(ctx, session, id, newOwnerID)
+}
+
+func (lm *loggingMiddleware) RetrieveDomainSettings(ctx context.Context, session authn.Session, id string) (s domains.Settings, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("domain",
+				slog.String("id", id),
+			),
+			slog.Uint64("version", s.Version),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Retrieve domain settings failed", args...)
+			return
+		}
+		lm.logger.Info("Retrieve domain settings completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RetrieveDomainSettings(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) UpdateDomainSettings(ctx context.Context, session authn.Session, id string, settings domains.Settings) (s domains.Settings, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("domain",
+				slog.String("id", id),
+			),
+			slog.Uint64("version", s.Version),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Update domain settings failed", args...)
+			return
+		}
+		lm.logger.Info("Update domain settings completed successfully", args...)
+	}(time.Now())
+	return lm.svc.UpdateDomainSettings(ctx, session, id, settings)
+}
+
 func (lm *loggingMiddleware) ListDomains(ctx context.Context, session authn.Session, page domains.Page) (do domains.DomainsPage, err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -85,6 +85,54 @@ func (ms *metricsMiddleware) FreezeDomain(ctx context.Context, session authn.Ses
 	return ms.svc.FreezeDomain(ctx, session, id)
 }
 
+func (ms *metricsMiddleware) UpdateDomainQuotas(ctx context.Context, session authn.Session, id string, q domains.Quotas) (domains.Domain, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_domain_quotas").Add(1)
+		ms.latency.With("method", "update_domain_quotas").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.UpdateDomainQuotas(ctx, session, id, q)
+}
+
+func (ms *metricsMiddleware) DeleteDomain(ctx context.Context, session authn.Session, id string) (domains.Domain, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_domain").Add(1)
+		ms.latency.With("method", "delete_domain").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteDomain(ctx, session, id)
+}
+
+func (ms *metricsMiddleware) RestoreDomain(ctx context.Context, session authn.Session, id string) (domains.Domain, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "restore_domain").Add(1)
+		ms.latency.With("method", "restore_domain").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RestoreDomain(ctx, session, id)
+}
+
+func (ms *metricsMiddleware) TransferDomainOwnership(ctx context.Context, session authn.Session, id, newOwnerID string) (domains.Domain, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "transfer_domain_ownership").Add(1)
+		ms.latency.With("method", "transfer_domain_ownership").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.TransferDomainOwnership(ctx, session, id, newOwnerID)
+}
+
+func (ms *metricsMiddleware) RetrieveDomainSettings(ctx context.Context, session authn.Session, id string) (domains.Settings, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_domain_settings").Add(1)
+		ms.latency.With("method", "retrieve_domain_settings").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RetrieveDomainSettings(ctx, session, id)
+}
+
+func (ms *metricsMiddleware) UpdateDomainSettings(ctx context.Context, session authn.Session, id string, s domains.Settings) (domains.Settings, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_domain_settings").Add(1)
+		ms.latency.With("method", "update_domain_settings").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.UpdateDomainSettings(ctx, session, id, s)
+}
+
 func (ms *metricsMiddleware) ListDomains(ctx context.Context, session authn.Session, page domains.Page) (domains.DomainsPage, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_domains").Add(1)
@@ -11,6 +11,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	roles "github.com/absmach/supermq/pkg/roles"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -66,6 +68,36 @@ func (_m *Repository) Delete(ctx context.Context, id string) error {
 	return r0
 }
 
+// LockQuotas provides a mock function with given fields: ctx, domainID
+func (_m *Repository) LockQuotas(ctx context.Context, domainID string) (func(), error) {
+	ret := _m.Called(ctx, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockQuotas")
+	}
+
+	var r0 func()
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (func(), error)); ok {
+		return rf(ctx, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) func()); ok {
+		r0 = rf(ctx, domainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListDomains provides a mock function with given fields: ctx, pm
 func (_m *Repository) ListDomains(ctx context.Context, pm domains.Page) (domains.DomainsPage, error) {
 	ret := _m.Called(ctx, pm)
@@ -176,6 +208,34 @@ func (_m *Repository) RemoveRoles(ctx context.Context, roleIDs []string) error {
 	return r0
 }
 
+// Restore provides a mock function with given fields: ctx, id, restoredBy, restoredAt
+func (_m *Repository) Restore(ctx context.Context, id string, restoredBy string, restoredAt time.Time) (domains.Domain, error) {
+	ret := _m.Called(ctx, id, restoredBy, restoredAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 domains.Domain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (domains.Domain, error)); ok {
+		return rf(ctx, id, restoredBy, restoredAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) domains.Domain); ok {
+		r0 = rf(ctx, id, restoredBy, restoredAt)
+	} else {
+		r0 = ret.Get(0).(domains.Domain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, id, restoredBy, restoredAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveAllByIDs provides a mock function with given fields: ctx, pm
 func (_m *Repository) RetrieveAllByIDs(ctx context.Context, pm domains.Page) (domains.DomainsPage, error) {
 	ret := _m.Called(ctx, pm)
@@ -232,6 +292,34 @@ func (_m *Repository) RetrieveAllRoles(ctx context.Context, entityID string, lim
 	return r0, r1
 }
 
+// RetrieveByAlias provides a mock function with given fields: ctx, alias
+func (_m *Repository) RetrieveByAlias(ctx context.Context, alias string) (domains.Domain, error) {
+	ret := _m.Called(ctx, alias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveByAlias")
+	}
+
+	var r0 domains.Domain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domains.Domain, error)); ok {
+		return rf(ctx, alias)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domains.Domain); ok {
+		r0 = rf(ctx, alias)
+	} else {
+		r0 = ret.Get(0).(domains.Domain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, alias)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveByID provides a mock function with given fields: ctx, id
 func (_m *Repository) RetrieveByID(ctx context.Context, id string) (domains.Domain, error) {
 	ret := _m.Called(ctx, id)
@@ -383,6 +471,34 @@ func (_m *Repository) RetrieveRole(ctx context.Context, roleID string) (roles.Ro
 	return r0, r1
 }
 
+// RetrieveSettings provides a mock function with given fields: ctx, domainID
+func (_m *Repository) RetrieveSettings(ctx context.Context, domainID string) (domains.Settings, error) {
+	ret := _m.Called(ctx, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveSettings")
+	}
+
+	var r0 domains.Settings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domains.Settings, error)); ok {
+		return rf(ctx, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domains.Settings); ok {
+		r0 = rf(ctx, domainID)
+	} else {
+		r0 = ret.Get(0).(domains.Settings)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RoleAddActions provides a mock function with given fields: ctx, role, actions
 func (_m *Repository) RoleAddActions(ctx context.Context, role roles.Role, actions []string) ([]string, error) {
 	ret := _m.Called(ctx, role, actions)
@@ -657,6 +773,34 @@ func (_m *Repository) Save(ctx context.Context, d domains.Domain) (domains.Domai
 	return r0, r1
 }
 
+// SoftDelete provides a mock function with given fields: ctx, id, deletedBy, deletedAt
+func (_m *Repository) SoftDelete(ctx context.Context, id string, deletedBy string, deletedAt time.Time) (domains.Domain, error) {
+	ret := _m.Called(ctx, id, deletedBy, deletedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SoftDelete")
+	}
+
+	var r0 domains.Domain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (domains.Domain, error)); ok {
+		return rf(ctx, id, deletedBy, deletedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) domains.Domain); ok {
+		r0 = rf(ctx, id, deletedBy, deletedAt)
+	} else {
+		r0 = ret.Get(0).(domains.Domain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, id, deletedBy, deletedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, id, d
 func (_m *Repository) Update(ctx context.Context, id string, d domains.DomainReq) (domains.Domain, error) {
 	ret := _m.Called(ctx, id, d)
@@ -713,6 +857,34 @@ func (_m *Repository) UpdateRole(ctx context.Context, ro roles.Role) (roles.Role
 	return r0, r1
 }
 
+// UpdateSettings provides a mock function with given fields: ctx, domainID, s
+func (_m *Repository) UpdateSettings(ctx context.Context, domainID string, s domains.Settings) (domains.Settings, error) {
+	ret := _m.Called(ctx, domainID, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSettings")
+	}
+
+	var r0 domains.Settings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domains.Settings) (domains.Settings, error)); ok {
+		return rf(ctx, domainID, s)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domains.Settings) domains.Settings); ok {
+		r0 = rf(ctx, domainID, s)
+	} else {
+		r0 = ret.Get(0).(domains.Settings)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domains.Settings) error); ok {
+		r1 = rf(ctx, domainID, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepository(t interface {
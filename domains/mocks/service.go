@@ -86,6 +86,34 @@ func (_m *Service) CreateDomain(ctx context.Context, sesssion authn.Session, d d
 	return r0, r1, r2
 }
 
+// DeleteDomain provides a mock function with given fields: ctx, sesssion, id
+func (_m *Service) DeleteDomain(ctx context.Context, sesssion authn.Session, id string) (domains.Domain, error) {
+	ret := _m.Called(ctx, sesssion, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDomain")
+	}
+
+	var r0 domains.Domain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) (domains.Domain, error)); ok {
+		return rf(ctx, sesssion, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) domains.Domain); ok {
+		r0 = rf(ctx, sesssion, id)
+	} else {
+		r0 = ret.Get(0).(domains.Domain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = rf(ctx, sesssion, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DisableDomain provides a mock function with given fields: ctx, sesssion, id
 func (_m *Service) DisableDomain(ctx context.Context, sesssion authn.Session, id string) (domains.Domain, error) {
 	ret := _m.Called(ctx, sesssion, id)
@@ -310,6 +338,34 @@ func (_m *Service) RemoveRole(ctx context.Context, session authn.Session, entity
 	return r0
 }
 
+// RestoreDomain provides a mock function with given fields: ctx, sesssion, id
+func (_m *Service) RestoreDomain(ctx context.Context, sesssion authn.Session, id string) (domains.Domain, error) {
+	ret := _m.Called(ctx, sesssion, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreDomain")
+	}
+
+	var r0 domains.Domain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) (domains.Domain, error)); ok {
+		return rf(ctx, sesssion, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) domains.Domain); ok {
+		r0 = rf(ctx, sesssion, id)
+	} else {
+		r0 = ret.Get(0).(domains.Domain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = rf(ctx, sesssion, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveAllRoles provides a mock function with given fields: ctx, session, entityID, limit, offset
 func (_m *Service) RetrieveAllRoles(ctx context.Context, session authn.Session, entityID string, limit uint64, offset uint64) (roles.RolePage, error) {
 	ret := _m.Called(ctx, session, entityID, limit, offset)
@@ -366,6 +422,34 @@ func (_m *Service) RetrieveDomain(ctx context.Context, sesssion authn.Session, i
 	return r0, r1
 }
 
+// RetrieveDomainSettings provides a mock function with given fields: ctx, sesssion, id
+func (_m *Service) RetrieveDomainSettings(ctx context.Context, sesssion authn.Session, id string) (domains.Settings, error) {
+	ret := _m.Called(ctx, sesssion, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveDomainSettings")
+	}
+
+	var r0 domains.Settings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) (domains.Settings, error)); ok {
+		return rf(ctx, sesssion, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) domains.Settings); ok {
+		r0 = rf(ctx, sesssion, id)
+	} else {
+		r0 = ret.Get(0).(domains.Settings)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = rf(ctx, sesssion, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveRole provides a mock function with given fields: ctx, session, entityID, roleID
 func (_m *Service) RetrieveRole(ctx context.Context, session authn.Session, entityID string, roleID string) (roles.Role, error) {
 	ret := _m.Called(ctx, session, entityID, roleID)
@@ -640,6 +724,34 @@ func (_m *Service) RoleRemoveMembers(ctx context.Context, session authn.Session,
 	return r0
 }
 
+// TransferDomainOwnership provides a mock function with given fields: ctx, sesssion, id, newOwnerID
+func (_m *Service) TransferDomainOwnership(ctx context.Context, sesssion authn.Session, id string, newOwnerID string) (domains.Domain, error) {
+	ret := _m.Called(ctx, sesssion, id, newOwnerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferDomainOwnership")
+	}
+
+	var r0 domains.Domain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) (domains.Domain, error)); ok {
+		return rf(ctx, sesssion, id, newOwnerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) domains.Domain); ok {
+		r0 = rf(ctx, sesssion, id, newOwnerID)
+	} else {
+		r0 = ret.Get(0).(domains.Domain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, string) error); ok {
+		r1 = rf(ctx, sesssion, id, newOwnerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateDomain provides a mock function with given fields: ctx, sesssion, id, d
 func (_m *Service) UpdateDomain(ctx context.Context, sesssion authn.Session, id string, d domains.DomainReq) (domains.Domain, error) {
 	ret := _m.Called(ctx, sesssion, id, d)
@@ -668,6 +780,62 @@ func (_m *Service) UpdateDomain(ctx context.Context, sesssion authn.Session, id
 	return r0, r1
 }
 
+// UpdateDomainQuotas provides a mock function with given fields: ctx, sesssion, id, q
+func (_m *Service) UpdateDomainQuotas(ctx context.Context, sesssion authn.Session, id string, q domains.Quotas) (domains.Domain, error) {
+	ret := _m.Called(ctx, sesssion, id, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDomainQuotas")
+	}
+
+	var r0 domains.Domain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, domains.Quotas) (domains.Domain, error)); ok {
+		return rf(ctx, sesssion, id, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, domains.Quotas) domains.Domain); ok {
+		r0 = rf(ctx, sesssion, id, q)
+	} else {
+		r0 = ret.Get(0).(domains.Domain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, domains.Quotas) error); ok {
+		r1 = rf(ctx, sesssion, id, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateDomainSettings provides a mock function with given fields: ctx, sesssion, id, s
+func (_m *Service) UpdateDomainSettings(ctx context.Context, sesssion authn.Session, id string, s domains.Settings) (domains.Settings, error) {
+	ret := _m.Called(ctx, sesssion, id, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDomainSettings")
+	}
+
+	var r0 domains.Settings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, domains.Settings) (domains.Settings, error)); ok {
+		return rf(ctx, sesssion, id, s)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, domains.Settings) domains.Settings); ok {
+		r0 = rf(ctx, sesssion, id, s)
+	} else {
+		r0 = ret.Get(0).(domains.Settings)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, domains.Settings) error); ok {
+		r1 = rf(ctx, sesssion, id, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateRoleName provides a mock function with given fields: ctx, session, entityID, roleID, newRoleName
 func (_m *Service) UpdateRoleName(ctx context.Context, session authn.Session, entityID string, roleID string, newRoleName string) (roles.Role, error) {
 	ret := _m.Called(ctx, session, entityID, roleID, newRoleName)
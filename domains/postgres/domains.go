@@ -29,6 +29,7 @@ const (
 	rolesTableNamePrefix = "domains"
 	entityTableName      = "domains"
 	entityIDColumnName   = "id"
+	quotasLockPrefix     = "domain_quotas:"
 )
 
 type domainRepo struct {
@@ -47,9 +48,9 @@ func New(db postgres.Database) domains.Repository {
 }
 
 func (repo domainRepo) Save(ctx context.Context, d domains.Domain) (dd domains.Domain, err error) {
-	q := `INSERT INTO domains (id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status)
-	VALUES (:id, :name, :tags, :alias, :metadata, :created_at, :updated_at, :updated_by, :created_by, :status)
-	RETURNING id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status;`
+	q := `INSERT INTO domains (id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status, require_mfa, quotas)
+	VALUES (:id, :name, :tags, :alias, :metadata, :created_at, :updated_at, :updated_by, :created_by, :status, :require_mfa, :quotas)
+	RETURNING id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status, require_mfa, quotas;`
 
 	dbd, err := toDBDomain(d)
 	if err != nil {
@@ -78,7 +79,7 @@ func (repo domainRepo) Save(ctx context.Context, d domains.Domain) (dd domains.D
 
 // RetrieveByID retrieves Domain by its unique ID.
 func (repo domainRepo) RetrieveByID(ctx context.Context, id string) (domains.Domain, error) {
-	q := `SELECT d.id as id, d.name as name, d.tags as tags,  d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status
+	q := `SELECT d.id as id, d.name as name, d.tags as tags,  d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status, d.require_mfa as require_mfa, d.quotas as quotas, d.deleted_at as deleted_at
         FROM domains d WHERE d.id = :id`
 
 	dbdp := dbDomainsPage{
@@ -107,6 +108,37 @@ func (repo domainRepo) RetrieveByID(ctx context.Context, id string) (domains.Dom
 	return domains.Domain{}, repoerr.ErrNotFound
 }
 
+// RetrieveByAlias retrieves Domain by its unique alias.
+func (repo domainRepo) RetrieveByAlias(ctx context.Context, alias string) (domains.Domain, error) {
+	q := `SELECT d.id as id, d.name as name, d.tags as tags,  d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status, d.require_mfa as require_mfa, d.quotas as quotas
+        FROM domains d WHERE d.alias = :alias`
+
+	dbd := dbDomain{
+		Alias: &alias,
+	}
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbd)
+	if err != nil {
+		return domains.Domain{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	dbd = dbDomain{}
+	if rows.Next() {
+		if err = rows.StructScan(&dbd); err != nil {
+			return domains.Domain{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+
+		domain, err := toDomain(dbd)
+		if err != nil {
+			return domains.Domain{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
+		}
+
+		return domain, nil
+	}
+	return domains.Domain{}, repoerr.ErrNotFound
+}
+
 func (repo domainRepo) RetrieveByUserAndID(ctx context.Context, userID, id string) (domains.Domain, error) {
 	q := repo.userDomainsBaseQuery() +
 		`SELECT
@@ -116,6 +148,8 @@ func (repo domainRepo) RetrieveByUserAndID(ctx context.Context, userID, id strin
 			d.alias as alias,
 			d.metadata as metadata,
 			d.status as status,
+			d.require_mfa as require_mfa,
+			d.quotas as quotas,
 			d.role_id AS role_id,
 			d.role_name AS role_name,
 			d.actions AS actions,
@@ -166,7 +200,7 @@ func (repo domainRepo) RetrieveAllByIDs(ctx context.Context, pm domains.Page) (d
 		return domains.DomainsPage{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
 	}
 
-	q = `SELECT d.id as id, d.name as name, d.tags as tags, d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status
+	q = `SELECT d.id as id, d.name as name, d.tags as tags, d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status, d.require_mfa as require_mfa, d.quotas as quotas
 	FROM domains d`
 	q = fmt.Sprintf("%s %s  LIMIT %d OFFSET %d;", q, query, pm.Limit, pm.Offset)
 
@@ -222,7 +256,10 @@ func (repo domainRepo) ListDomains(ctx context.Context, pm domains.Page) (domain
 			d.updated_at as updated_at,
 			d.updated_by as updated_by,
 			d.created_by as created_by,
-			d.status as status
+			d.status as status,
+			d.require_mfa as require_mfa,
+			d.quotas as quotas,
+			d.deleted_at as deleted_at
 		FROM
 			domains as d
 		%s
@@ -238,6 +275,8 @@ func (repo domainRepo) ListDomains(ctx context.Context, pm domains.Page) (domain
 				d.alias as alias,
 				d.metadata as metadata,
 				d.status as status,
+				d.require_mfa as require_mfa,
+				d.quotas as quotas,
 				d.role_id AS role_id,
 				d.role_name AS role_name,
 				d.actions AS actions,
@@ -319,6 +358,14 @@ func (repo domainRepo) Update(ctx context.Context, id string, dr domains.DomainR
 		query = append(query, "alias = :alias")
 		d.Alias = *dr.Alias
 	}
+	if dr.RequireMFA != nil {
+		query = append(query, "require_mfa = :require_mfa")
+		d.RequireMFA = *dr.RequireMFA
+	}
+	if dr.Quotas != nil {
+		query = append(query, "quotas = :quotas")
+		d.Quotas = *dr.Quotas
+	}
 	d.UpdatedAt = time.Now()
 	if dr.UpdatedAt != nil {
 		query = append(query, "updated_at = :updated_at")
@@ -333,7 +380,7 @@ func (repo domainRepo) Update(ctx context.Context, id string, dr domains.DomainR
 	}
 	q := fmt.Sprintf(`UPDATE domains SET %s
         WHERE id = :id
-        RETURNING id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status;`,
+        RETURNING id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status, require_mfa, quotas;`,
 		upq)
 
 	dbd, err := toDBDomain(d)
@@ -360,6 +407,44 @@ func (repo domainRepo) Update(ctx context.Context, id string, dr domains.DomainR
 	return domain, nil
 }
 
+func (repo domainRepo) SoftDelete(ctx context.Context, id, deletedBy string, deletedAt time.Time) (domains.Domain, error) {
+	q := `UPDATE domains SET restore_status = status, status = $2, deleted_at = $3, updated_at = $3, updated_by = $4
+        WHERE id = $1 AND status <> $2
+        RETURNING id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status, require_mfa, quotas, deleted_at;`
+
+	return repo.updateStatus(ctx, q, id, domains.DeletedStatus, deletedAt, deletedBy)
+}
+
+func (repo domainRepo) Restore(ctx context.Context, id, restoredBy string, restoredAt time.Time) (domains.Domain, error) {
+	q := `UPDATE domains SET status = COALESCE(restore_status, $5), restore_status = NULL, deleted_at = NULL, updated_at = $3, updated_by = $4
+        WHERE id = $1 AND status = $2
+        RETURNING id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status, require_mfa, quotas, deleted_at;`
+
+	return repo.updateStatus(ctx, q, id, domains.DeletedStatus, restoredAt, restoredBy, domains.EnabledStatus)
+}
+
+func (repo domainRepo) updateStatus(ctx context.Context, q string, args ...interface{}) (domains.Domain, error) {
+	rows, err := repo.db.QueryxContext(ctx, q, args...)
+	if err != nil {
+		return domains.Domain{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return domains.Domain{}, repoerr.ErrNotFound
+	}
+	dbd := dbDomain{}
+	if err := rows.StructScan(&dbd); err != nil {
+		return domains.Domain{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
+	}
+	domain, err := toDomain(dbd)
+	if err != nil {
+		return domains.Domain{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
+	}
+
+	return domain, nil
+}
+
 // Delete delete domain from database.
 func (repo domainRepo) Delete(ctx context.Context, id string) error {
 	q := "DELETE FROM domains WHERE id = $1;"
@@ -375,6 +460,79 @@ func (repo domainRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// RetrieveSettings retrieves the domain settings. The zero value settings
+// are returned if the settings of the domain have never been updated.
+func (repo domainRepo) RetrieveSettings(ctx context.Context, domainID string) (domains.Settings, error) {
+	q := `SELECT domain_id, settings, version, updated_at, updated_by FROM domain_settings WHERE domain_id = :domain_id`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbSettings{DomainID: domainID})
+	if err != nil {
+		return domains.Settings{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return domains.Settings{}, nil
+	}
+	dbs := dbSettings{}
+	if err := rows.StructScan(&dbs); err != nil {
+		return domains.Settings{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toSettings(dbs)
+}
+
+// UpdateSettings replaces the domain settings and increments their version.
+// If the version of the given settings is not zero, the update succeeds only
+// if it matches the stored version.
+func (repo domainRepo) UpdateSettings(ctx context.Context, domainID string, s domains.Settings) (domains.Settings, error) {
+	q := `INSERT INTO domain_settings (domain_id, settings, version, updated_at, updated_by)
+	VALUES (:domain_id, :settings, 1, :updated_at, :updated_by)
+	ON CONFLICT (domain_id) DO UPDATE SET
+		settings = EXCLUDED.settings,
+		version = domain_settings.version + 1,
+		updated_at = EXCLUDED.updated_at,
+		updated_by = EXCLUDED.updated_by
+	WHERE :version = 0 OR domain_settings.version = :version
+	RETURNING domain_id, settings, version, updated_at, updated_by;`
+
+	dbs, err := toDBSettings(domainID, s)
+	if err != nil {
+		return domains.Settings{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbs)
+	if err != nil {
+		return domains.Settings{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return domains.Settings{}, errors.Wrap(repoerr.ErrConflict, domains.ErrSettingsVersion)
+	}
+	dbs = dbSettings{}
+	if err := rows.StructScan(&dbs); err != nil {
+		return domains.Settings{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
+	}
+
+	return toSettings(dbs)
+}
+
+// LockQuotas takes the transaction level advisory lock of the domain quotas.
+// The transaction is rolled back on unlock, since it is used only to hold the lock.
+func (repo domainRepo) LockQuotas(ctx context.Context, domainID string) (func(), error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrFailedOpDB, err)
+	}
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, quotasLockPrefix+domainID); err != nil {
+		_ = tx.Rollback()
+		return nil, postgres.HandleError(repoerr.ErrFailedOpDB, err)
+	}
+
+	return func() { _ = tx.Rollback() }, nil
+}
+
 func (repo domainRepo) userDomainsBaseQuery() string {
 	return `
 		with domains AS (
@@ -389,6 +547,8 @@ func (repo domainRepo) userDomainsBaseQuery() string {
 				d.updated_by as updated_by,
 				d.created_by as created_by,
 				d.status as status,
+				d.require_mfa as require_mfa,
+				d.quotas as quotas,
 				dr.entity_id AS entity_id,
 				drm.member_id AS member_id,
 				dr.id AS role_id,
@@ -437,19 +597,22 @@ func applyOrdering(emq string, pm domains.Page) string {
 }
 
 type dbDomain struct {
-	ID        string           `db:"id"`
-	Name      string           `db:"name"`
-	Metadata  []byte           `db:"metadata,omitempty"`
-	Tags      pgtype.TextArray `db:"tags,omitempty"`
-	Alias     *string          `db:"alias,omitempty"`
-	Status    domains.Status   `db:"status"`
-	RoleID    string           `db:"role_id"`
-	RoleName  string           `db:"role_name"`
-	Actions   pq.StringArray   `db:"actions"`
-	CreatedBy string           `db:"created_by"`
-	CreatedAt time.Time        `db:"created_at"`
-	UpdatedBy *string          `db:"updated_by,omitempty"`
-	UpdatedAt sql.NullTime     `db:"updated_at,omitempty"`
+	ID         string           `db:"id"`
+	Name       string           `db:"name"`
+	Metadata   []byte           `db:"metadata,omitempty"`
+	Tags       pgtype.TextArray `db:"tags,omitempty"`
+	Alias      *string          `db:"alias,omitempty"`
+	Status     domains.Status   `db:"status"`
+	RequireMFA bool             `db:"require_mfa"`
+	Quotas     []byte           `db:"quotas"`
+	RoleID     string           `db:"role_id"`
+	RoleName   string           `db:"role_name"`
+	Actions    pq.StringArray   `db:"actions"`
+	CreatedBy  string           `db:"created_by"`
+	CreatedAt  time.Time        `db:"created_at"`
+	UpdatedBy  *string          `db:"updated_by,omitempty"`
+	UpdatedAt  sql.NullTime     `db:"updated_at,omitempty"`
+	DeletedAt  sql.NullTime     `db:"deleted_at,omitempty"`
 }
 
 func toDBDomain(d domains.Domain) (dbDomain, error) {
@@ -461,6 +624,10 @@ func toDBDomain(d domains.Domain) (dbDomain, error) {
 		}
 		data = b
 	}
+	quotas, err := json.Marshal(d.Quotas)
+	if err != nil {
+		return dbDomain{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
 	var tags pgtype.TextArray
 	if err := tags.Set(d.Tags); err != nil {
 		return dbDomain{}, err
@@ -480,17 +647,19 @@ func toDBDomain(d domains.Domain) (dbDomain, error) {
 	}
 
 	return dbDomain{
-		ID:        d.ID,
-		Name:      d.Name,
-		Metadata:  data,
-		Tags:      tags,
-		Alias:     alias,
-		Status:    d.Status,
-		RoleID:    d.RoleID,
-		CreatedBy: d.CreatedBy,
-		CreatedAt: d.CreatedAt,
-		UpdatedBy: updatedBy,
-		UpdatedAt: updatedAt,
+		ID:         d.ID,
+		Name:       d.Name,
+		Metadata:   data,
+		Tags:       tags,
+		Alias:      alias,
+		Status:     d.Status,
+		RequireMFA: d.RequireMFA,
+		Quotas:     quotas,
+		RoleID:     d.RoleID,
+		CreatedBy:  d.CreatedBy,
+		CreatedAt:  d.CreatedAt,
+		UpdatedBy:  updatedBy,
+		UpdatedAt:  updatedAt,
 	}, nil
 }
 
@@ -501,6 +670,12 @@ func toDomain(d dbDomain) (domains.Domain, error) {
 			return domains.Domain{}, errors.Wrap(errors.ErrMalformedEntity, err)
 		}
 	}
+	var quotas domains.Quotas
+	if d.Quotas != nil {
+		if err := json.Unmarshal(d.Quotas, &quotas); err != nil {
+			return domains.Domain{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
 	var tags []string
 	for _, e := range d.Tags.Elements {
 		tags = append(tags, e.String)
@@ -517,24 +692,82 @@ func toDomain(d dbDomain) (domains.Domain, error) {
 	if d.UpdatedAt.Valid {
 		updatedAt = d.UpdatedAt.Time
 	}
+	var deletedAt time.Time
+	if d.DeletedAt.Valid {
+		deletedAt = d.DeletedAt.Time
+	}
 
 	return domains.Domain{
-		ID:        d.ID,
-		Name:      d.Name,
-		Metadata:  metadata,
-		Tags:      tags,
-		Alias:     alias,
-		RoleID:    d.RoleID,
-		RoleName:  d.RoleName,
-		Actions:   d.Actions,
-		Status:    d.Status,
-		CreatedBy: d.CreatedBy,
-		CreatedAt: d.CreatedAt,
-		UpdatedBy: updatedBy,
+		ID:         d.ID,
+		Name:       d.Name,
+		Metadata:   metadata,
+		Tags:       tags,
+		Alias:      alias,
+		RoleID:     d.RoleID,
+		RoleName:   d.RoleName,
+		Actions:    d.Actions,
+		Status:     d.Status,
+		RequireMFA: d.RequireMFA,
+		Quotas:     quotas,
+		CreatedBy:  d.CreatedBy,
+		CreatedAt:  d.CreatedAt,
+		UpdatedBy:  updatedBy,
+		UpdatedAt:  updatedAt,
+		DeletedAt:  deletedAt,
+	}, nil
+}
+
+type dbSettings struct {
+	DomainID  string       `db:"domain_id"`
+	Settings  []byte       `db:"settings"`
+	Version   uint64       `db:"version"`
+	UpdatedAt sql.NullTime `db:"updated_at,omitempty"`
+	UpdatedBy *string      `db:"updated_by,omitempty"`
+}
+
+func toDBSettings(domainID string, s domains.Settings) (dbSettings, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return dbSettings{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	var updatedBy *string
+	if s.UpdatedBy != "" {
+		updatedBy = &s.UpdatedBy
+	}
+	var updatedAt sql.NullTime
+	if s.UpdatedAt != (time.Time{}) {
+		updatedAt = sql.NullTime{Time: s.UpdatedAt, Valid: true}
+	}
+
+	return dbSettings{
+		DomainID:  domainID,
+		Settings:  data,
+		Version:   s.Version,
 		UpdatedAt: updatedAt,
+		UpdatedBy: updatedBy,
 	}, nil
 }
 
+func toSettings(dbs dbSettings) (domains.Settings, error) {
+	var s domains.Settings
+	if dbs.Settings != nil {
+		if err := json.Unmarshal(dbs.Settings, &s); err != nil {
+			return domains.Settings{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+	s.Version = dbs.Version
+	s.UpdatedAt = time.Time{}
+	if dbs.UpdatedAt.Valid {
+		s.UpdatedAt = dbs.UpdatedAt.Time
+	}
+	s.UpdatedBy = ""
+	if dbs.UpdatedBy != nil {
+		s.UpdatedBy = *dbs.UpdatedBy
+	}
+
+	return s, nil
+}
+
 type dbDomainsPage struct {
 	Total    uint64         `db:"total"`
 	Limit    uint64         `db:"limit"`
@@ -40,6 +40,50 @@ func Migration() (*migrate.MemoryMigrationSource, error) {
 					`DROP TABLE IF EXISTS domains`,
 				},
 			},
+			{
+				Id: "domain_2",
+				Up: []string{
+					`ALTER TABLE domains ADD COLUMN IF NOT EXISTS require_mfa BOOLEAN NOT NULL DEFAULT FALSE;`,
+				},
+				Down: []string{
+					`ALTER TABLE domains DROP COLUMN IF EXISTS require_mfa;`,
+				},
+			},
+			{
+				Id: "domain_3",
+				Up: []string{
+					`ALTER TABLE domains ADD COLUMN IF NOT EXISTS quotas JSONB NOT NULL DEFAULT '{}';`,
+				},
+				Down: []string{
+					`ALTER TABLE domains DROP COLUMN IF EXISTS quotas;`,
+				},
+			},
+			{
+				Id: "domain_4",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS domain_settings (
+                        domain_id   VARCHAR(36) PRIMARY KEY REFERENCES domains (id) ON DELETE CASCADE,
+                        settings    JSONB NOT NULL DEFAULT '{}',
+                        version     BIGINT NOT NULL DEFAULT 0 CHECK (version >= 0),
+                        updated_at  TIMESTAMP,
+                        updated_by  VARCHAR(254)
+                    );`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS domain_settings;`,
+				},
+			},
+			{
+				Id: "domain_5",
+				Up: []string{
+					`ALTER TABLE domains ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;`,
+					`ALTER TABLE domains ADD COLUMN IF NOT EXISTS restore_status SMALLINT;`,
+				},
+				Down: []string{
+					`ALTER TABLE domains DROP COLUMN IF EXISTS deleted_at;`,
+					`ALTER TABLE domains DROP COLUMN IF EXISTS restore_status;`,
+				},
+			},
 		},
 	}
 
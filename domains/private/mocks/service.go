@@ -34,6 +34,34 @@ func (_m *Service) DeleteUserFromDomains(ctx context.Context, id string) error {
 	return r0
 }
 
+// RequiresMFA provides a mock function with given fields: ctx, userID
+func (_m *Service) RequiresMFA(ctx context.Context, userID string) (bool, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequiresMFA")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveEntity provides a mock function with given fields: ctx, id
 func (_m *Service) RetrieveEntity(ctx context.Context, id string) (domains.Domain, error) {
 	ret := _m.Called(ctx, id)
@@ -62,6 +90,62 @@ func (_m *Service) RetrieveEntity(ctx context.Context, id string) (domains.Domai
 	return r0, r1
 }
 
+// RetrieveIDByAlias provides a mock function with given fields: ctx, alias
+func (_m *Service) RetrieveIDByAlias(ctx context.Context, alias string) (string, error) {
+	ret := _m.Called(ctx, alias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveIDByAlias")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, alias)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, alias)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, alias)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveSettings provides a mock function with given fields: ctx, domainID
+func (_m *Service) RetrieveSettings(ctx context.Context, domainID string) (domains.Settings, error) {
+	ret := _m.Called(ctx, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveSettings")
+	}
+
+	var r0 domains.Settings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domains.Settings, error)); ok {
+		return rf(ctx, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domains.Settings); ok {
+		r0 = rf(ctx, domainID)
+	} else {
+		r0 = ret.Get(0).(domains.Settings)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
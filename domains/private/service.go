@@ -17,6 +17,13 @@ const defLimit = 100
 type Service interface {
 	RetrieveEntity(ctx context.Context, id string) (domains.Domain, error)
 	DeleteUserFromDomains(ctx context.Context, id string) error
+	// RequiresMFA checks whether any of the enabled domains the user is
+	// a member of requires multi-factor authentication.
+	RequiresMFA(ctx context.Context, userID string) (bool, error)
+	// RetrieveSettings retrieves the domain settings.
+	RetrieveSettings(ctx context.Context, domainID string) (domains.Settings, error)
+	// RetrieveIDByAlias retrieves the ID of the domain with the given alias.
+	RetrieveIDByAlias(ctx context.Context, alias string) (string, error)
 }
 
 var _ Service = (*service)(nil)
@@ -69,3 +76,38 @@ func (svc service) DeleteUserFromDomains(ctx context.Context, id string) (err er
 
 	return nil
 }
+
+func (svc service) RequiresMFA(ctx context.Context, userID string) (bool, error) {
+	for offset := uint64(0); ; offset += defLimit {
+		dp, err := svc.repo.ListDomains(ctx, domains.Page{UserID: userID, Offset: offset, Limit: defLimit})
+		if err != nil {
+			return false, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		for _, d := range dp.Domains {
+			if d.RequireMFA {
+				return true, nil
+			}
+		}
+		if offset+defLimit >= dp.Total {
+			return false, nil
+		}
+	}
+}
+
+func (svc service) RetrieveSettings(ctx context.Context, domainID string) (domains.Settings, error) {
+	settings, err := svc.repo.RetrieveSettings(ctx, domainID)
+	if err != nil {
+		return domains.Settings{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return settings, nil
+}
+
+func (svc service) RetrieveIDByAlias(ctx context.Context, alias string) (string, error) {
+	dom, err := svc.repo.RetrieveByAlias(ctx, alias)
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return dom.ID, nil
+}
@@ -13,6 +13,11 @@ const (
 	OpRetrieveDomain
 	OpEnableDomain
 	OpDisableDomain
+	OpDeleteDomain
+	OpRestoreDomain
+	OpTransferDomain
+	OpRetrieveDomainSettings
+	OpUpdateDomainSettings
 )
 
 var expectedOperations = []svcutil.Operation{
@@ -20,6 +25,11 @@ var expectedOperations = []svcutil.Operation{
 	OpUpdateDomain,
 	OpEnableDomain,
 	OpDisableDomain,
+	OpDeleteDomain,
+	OpRestoreDomain,
+	OpTransferDomain,
+	OpRetrieveDomainSettings,
+	OpUpdateDomainSettings,
 }
 
 var operationNames = []string{
@@ -27,6 +37,11 @@ var operationNames = []string{
 	"OpUpdateDomain",
 	"OpEnableDomain",
 	"OpDisableDomain",
+	"OpDeleteDomain",
+	"OpRestoreDomain",
+	"OpTransferDomain",
+	"OpRetrieveDomainSettings",
+	"OpUpdateDomainSettings",
 }
 
 func NewOperationPerm() svcutil.OperationPerm {
@@ -55,10 +70,15 @@ const (
 
 func NewOperationPermissionMap() map[svcutil.Operation]svcutil.Permission {
 	opPerm := map[svcutil.Operation]svcutil.Permission{
-		OpRetrieveDomain: readPermission,
-		OpUpdateDomain:   updatePermission,
-		OpEnableDomain:   enablePermission,
-		OpDisableDomain:  disablePermission,
+		OpRetrieveDomain:         readPermission,
+		OpUpdateDomain:           updatePermission,
+		OpEnableDomain:           enablePermission,
+		OpDisableDomain:          disablePermission,
+		OpDeleteDomain:           deletePermission,
+		OpRestoreDomain:          deletePermission,
+		OpTransferDomain:         deletePermission,
+		OpRetrieveDomainSettings: readPermission,
+		OpUpdateDomainSettings:   updatePermission,
 	}
 	return opPerm
 }
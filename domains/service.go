@@ -18,19 +18,22 @@ import (
 var (
 	errCreateDomainPolicy = errors.New("failed to create domain policy")
 	errRollbackRepo       = errors.New("failed to rollback repo")
+	errDomainNotDeleted   = errors.New("domain is not deleted")
+	errAdminRoleNotFound  = errors.New("domain admin role not found")
 )
 
 type service struct {
-	repo       Repository
-	cache      Cache
-	policy     policies.Service
-	idProvider supermq.IDProvider
+	repo          Repository
+	cache         Cache
+	policy        policies.Service
+	idProvider    supermq.IDProvider
+	senderDomains []string
 	roles.ProvisionManageService
 }
 
 var _ Service = (*service)(nil)
 
-func New(repo Repository, cache Cache, policy policies.Service, idProvider supermq.IDProvider, sidProvider supermq.IDProvider, availableActions []roles.Action, builtInRoles map[roles.BuiltInRoleName][]roles.Action) (Service, error) {
+func New(repo Repository, cache Cache, policy policies.Service, idProvider supermq.IDProvider, sidProvider supermq.IDProvider, availableActions []roles.Action, builtInRoles map[roles.BuiltInRoleName][]roles.Action, senderDomains []string) (Service, error) {
 	rpms, err := roles.NewProvisionManageService(policies.DomainType, repo, policy, sidProvider, availableActions, builtInRoles)
 	if err != nil {
 		return nil, err
@@ -41,6 +44,7 @@ func New(repo Repository, cache Cache, policy policies.Service, idProvider super
 		cache:                  cache,
 		policy:                 policy,
 		idProvider:             idProvider,
+		senderDomains:          senderDomains,
 		ProvisionManageService: rpms,
 	}, nil
 }
@@ -107,6 +111,12 @@ func (svc service) RetrieveDomain(ctx context.Context, session authn.Session, id
 	if err != nil {
 		return Domain{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
+	usage, err := svc.usage(ctx, id)
+	if err != nil {
+		return Domain{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	domain.Usage = &usage
+
 	return domain, nil
 }
 
@@ -164,6 +174,234 @@ func (svc service) FreezeDomain(ctx context.Context, session authn.Session, id s
 	return dom, nil
 }
 
+func (svc service) DeleteDomain(ctx context.Context, session authn.Session, id string) (Domain, error) {
+	dom, err := svc.repo.SoftDelete(ctx, id, session.UserID, time.Now())
+	if err != nil {
+		return Domain{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	if err := svc.cache.Remove(ctx, id); err != nil {
+		return dom, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return dom, nil
+}
+
+// The domain can be restored until it is purged by the delete handler.
+// The domain gets the status it had before the deletion.
+func (svc service) RestoreDomain(ctx context.Context, session authn.Session, id string) (Domain, error) {
+	dom, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return Domain{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if dom.Status != DeletedStatus {
+		return Domain{}, errors.Wrap(svcerr.ErrUpdateEntity, errDomainNotDeleted)
+	}
+
+	dom, err = svc.repo.Restore(ctx, id, session.UserID, time.Now())
+	if err != nil {
+		return Domain{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	if err := svc.cache.Remove(ctx, id); err != nil {
+		return dom, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return dom, nil
+}
+
+// Only SuperAdmin can update the domain quotas.
+func (svc service) UpdateDomainQuotas(ctx context.Context, session authn.Session, id string, q Quotas) (Domain, error) {
+	updatedAt := time.Now()
+	dom, err := svc.repo.Update(ctx, id, DomainReq{Quotas: &q, UpdatedBy: &session.UserID, UpdatedAt: &updatedAt})
+	if err != nil {
+		return Domain{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return dom, nil
+}
+
+func (svc service) RetrieveDomainSettings(ctx context.Context, session authn.Session, id string) (Settings, error) {
+	settings, err := svc.repo.RetrieveSettings(ctx, id)
+	if err != nil {
+		return Settings{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return settings, nil
+}
+
+func (svc service) UpdateDomainSettings(ctx context.Context, session authn.Session, id string, s Settings) (Settings, error) {
+	if err := s.Validate(); err != nil {
+		return Settings{}, err
+	}
+	if err := s.CheckSender(svc.senderDomains); err != nil {
+		return Settings{}, err
+	}
+	s.UpdatedAt = time.Now()
+	s.UpdatedBy = session.UserID
+	settings, err := svc.repo.UpdateSettings(ctx, id, s)
+	if err != nil {
+		if errors.Contains(err, ErrSettingsVersion) {
+			return Settings{}, errors.Wrap(svcerr.ErrConflict, ErrSettingsVersion)
+		}
+		return Settings{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return settings, nil
+}
+
+// TransferDomainOwnership hands the domain over to the new owner by making
+// the new owner the only member of the built-in admin role.
+func (svc service) TransferDomainOwnership(ctx context.Context, session authn.Session, id, newOwnerID string) (retDom Domain, retErr error) {
+	role, err := svc.adminRole(ctx, id)
+	if err != nil {
+		return Domain{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	var owners []string
+	isMember := false
+	for offset := uint64(0); ; offset += defLimit {
+		page, err := svc.repo.RoleListMembers(ctx, role.ID, defLimit, offset)
+		if err != nil {
+			return Domain{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		for _, m := range page.Members {
+			if m == newOwnerID {
+				isMember = true
+				continue
+			}
+			owners = append(owners, m)
+		}
+		if len(page.Members) == 0 || offset+defLimit >= page.Total {
+			break
+		}
+	}
+
+	if !isMember {
+		if _, err := svc.RoleAddMembers(ctx, session, id, role.ID, []string{newOwnerID}); err != nil {
+			return Domain{}, err
+		}
+		defer func() {
+			if retErr != nil {
+				if errRollback := svc.ProvisionManageService.RoleRemoveMembers(ctx, session, id, role.ID, []string{newOwnerID}); errRollback != nil {
+					retErr = errors.Wrap(retErr, errors.Wrap(errRollbackRepo, errRollback))
+				}
+			}
+		}()
+	}
+	if len(owners) > 0 {
+		if err := svc.ProvisionManageService.RoleRemoveMembers(ctx, session, id, role.ID, owners); err != nil {
+			return Domain{}, err
+		}
+	}
+
+	updatedAt := time.Now()
+	dom, err := svc.repo.Update(ctx, id, DomainReq{UpdatedBy: &session.UserID, UpdatedAt: &updatedAt})
+	if err != nil {
+		return Domain{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return dom, nil
+}
+
+// adminRole returns the built-in admin role of the domain.
+func (svc service) adminRole(ctx context.Context, domainID string) (roles.Role, error) {
+	for offset := uint64(0); ; offset += defLimit {
+		page, err := svc.repo.RetrieveAllRoles(ctx, domainID, defLimit, offset)
+		if err != nil {
+			return roles.Role{}, err
+		}
+		for _, r := range page.Roles {
+			if r.Name == BuiltInRoleAdmin.String() {
+				return r, nil
+			}
+		}
+		if len(page.Roles) == 0 || offset+defLimit >= page.Total {
+			return roles.Role{}, errAdminRoleNotFound
+		}
+	}
+}
+
+// RoleAddMembers adds the members to the domain role, within the domain members quota.
+// The domain quotas are locked while the members are counted and added.
+func (svc service) RoleAddMembers(ctx context.Context, session authn.Session, entityID, roleID string, members []string) ([]string, error) {
+	dom, err := svc.repo.RetrieveByID(ctx, entityID)
+	if err != nil {
+		return []string{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if dom.Quotas.Members > 0 {
+		unlock, err := svc.repo.LockQuotas(ctx, entityID)
+		if err != nil {
+			return []string{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		defer unlock()
+		used, requested, err := svc.countMembers(ctx, entityID, members)
+		if err != nil {
+			return []string{}, err
+		}
+		if err := CheckQuota(dom.Quotas.Members, used, requested); err != nil {
+			return []string{}, err
+		}
+	}
+
+	return svc.ProvisionManageService.RoleAddMembers(ctx, session, entityID, roleID, members)
+}
+
+// countMembers returns the number of the domain members and the number of
+// the given members that are not domain members yet.
+func (svc service) countMembers(ctx context.Context, domainID string, members []string) (uint64, uint64, error) {
+	existing := map[string]bool{}
+	var used, requested uint64
+	for offset := uint64(0); ; offset += defLimit {
+		page, err := svc.repo.ListEntityMembers(ctx, domainID, roles.MembersRolePageQuery{Offset: offset, Limit: defLimit})
+		if err != nil {
+			return 0, 0, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		for _, m := range page.Members {
+			existing[m.MemberID] = true
+		}
+		used = page.Total
+		if len(page.Members) == 0 || offset+defLimit >= page.Total {
+			break
+		}
+	}
+	for _, m := range members {
+		if !existing[m] {
+			existing[m] = true
+			requested++
+		}
+	}
+
+	return used, requested, nil
+}
+
+// usage counts the domain members and the domain entities.
+func (svc service) usage(ctx context.Context, domainID string) (Usage, error) {
+	mp, err := svc.repo.ListEntityMembers(ctx, domainID, roles.MembersRolePageQuery{Limit: 1})
+	if err != nil {
+		return Usage{}, err
+	}
+	usage := Usage{Members: mp.Total}
+
+	counts := map[string]*uint64{
+		policies.ClientType:  &usage.Clients,
+		policies.ChannelType: &usage.Channels,
+		policies.GroupType:   &usage.Groups,
+	}
+	for entityType, count := range counts {
+		c, err := svc.policy.CountObjects(ctx, policies.Policy{
+			SubjectType: policies.DomainType,
+			Subject:     domainID,
+			Permission:  policies.DomainRelation,
+			ObjectType:  entityType,
+		})
+		if err != nil {
+			return Usage{}, err
+		}
+		*count = c
+	}
+
+	return usage, nil
+}
+
 func (svc service) ListDomains(ctx context.Context, session authn.Session, p Page) (DomainsPage, error) {
 	p.UserID = session.UserID
 	if session.SuperAdmin {
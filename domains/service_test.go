@@ -73,7 +73,7 @@ func newService() domains.Service {
 	builtInRoles := map[roles.BuiltInRoleName][]roles.Action{
 		groups.BuiltInRoleAdmin: availableActions,
 	}
-	ds, _ := domains.New(drepo, dcache, policy, idProvider, sidProvider, availableActions, builtInRoles)
+	ds, _ := domains.New(drepo, dcache, policy, idProvider, sidProvider, availableActions, builtInRoles, nil)
 	return ds
 }
 
@@ -188,12 +188,20 @@ func TestRetrieveDomain(t *testing.T) {
 	superAdminSession := validSession
 	superAdminSession.SuperAdmin = true
 
+	domainWithUsage := domain
+	domainWithUsage.Usage = &domains.Usage{Clients: 3, Channels: 3, Groups: 3, Members: 2}
+
 	cases := []struct {
 		desc              string
 		session           authn.Session
 		domainID          string
 		retrieveDomainRes domains.Domain
 		retrieveDomainErr error
+		listMembersRes    roles.MembersRolePage
+		listMembersErr    error
+		countRes          uint64
+		countErr          error
+		resp              domains.Domain
 		err               error
 	}{
 		{
@@ -201,6 +209,9 @@ func TestRetrieveDomain(t *testing.T) {
 			session:           superAdminSession,
 			domainID:          validID,
 			retrieveDomainRes: domain,
+			listMembersRes:    roles.MembersRolePage{Total: 2},
+			countRes:          3,
+			resp:              domainWithUsage,
 			err:               nil,
 		},
 		{
@@ -208,6 +219,9 @@ func TestRetrieveDomain(t *testing.T) {
 			session:           validSession,
 			domainID:          validID,
 			retrieveDomainRes: domain,
+			listMembersRes:    roles.MembersRolePage{Total: 2},
+			countRes:          3,
+			resp:              domainWithUsage,
 			err:               nil,
 		},
 		{
@@ -224,17 +238,38 @@ func TestRetrieveDomain(t *testing.T) {
 			retrieveDomainErr: repoerr.ErrNotFound,
 			err:               svcerr.ErrViewEntity,
 		},
+		{
+			desc:              "retrieve domain with failed to count members",
+			session:           validSession,
+			domainID:          validID,
+			retrieveDomainRes: domain,
+			listMembersErr:    repoerr.ErrViewEntity,
+			err:               svcerr.ErrViewEntity,
+		},
+		{
+			desc:              "retrieve domain with failed to count entities",
+			session:           validSession,
+			domainID:          validID,
+			retrieveDomainRes: domain,
+			listMembersRes:    roles.MembersRolePage{Total: 2},
+			countErr:          svcerr.ErrAuthorization,
+			err:               svcerr.ErrViewEntity,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			repoCall := drepo.On("RetrieveByID", context.Background(), tc.domainID).Return(tc.retrieveDomainRes, tc.retrieveDomainErr)
 			repoCall1 := drepo.On("RetrieveByUserAndID", context.Background(), tc.session.UserID, tc.domainID).Return(tc.retrieveDomainRes, tc.retrieveDomainErr)
+			repoCall2 := drepo.On("ListEntityMembers", context.Background(), tc.domainID, mock.Anything).Return(tc.listMembersRes, tc.listMembersErr)
+			policyCall := policy.On("CountObjects", context.Background(), mock.Anything).Return(tc.countRes, tc.countErr)
 			domain, err := svc.RetrieveDomain(context.Background(), tc.session, tc.domainID)
 			assert.True(t, errors.Contains(err, tc.err))
-			assert.Equal(t, tc.retrieveDomainRes, domain)
+			assert.Equal(t, tc.resp, domain)
 			repoCall.Unset()
 			repoCall1.Unset()
+			repoCall2.Unset()
+			policyCall.Unset()
 		})
 	}
 }
@@ -496,6 +531,484 @@ func TestFreezeDomain(t *testing.T) {
 	}
 }
 
+func TestDeleteDomain(t *testing.T) {
+	svc := newService()
+
+	deletedDomain := domain
+	deletedDomain.Status = domains.DeletedStatus
+
+	cases := []struct {
+		desc      string
+		session   authn.Session
+		domainID  string
+		deleteRes domains.Domain
+		deleteErr error
+		cacheErr  error
+		resp      domains.Domain
+		err       error
+	}{
+		{
+			desc:      "delete domain successfully",
+			session:   validSession,
+			domainID:  domain.ID,
+			deleteRes: deletedDomain,
+			resp:      deletedDomain,
+			err:       nil,
+		},
+		{
+			desc:      "delete non-existing domain",
+			session:   validSession,
+			domainID:  inValid,
+			deleteErr: repoerr.ErrNotFound,
+			resp:      domains.Domain{},
+			err:       svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:      "delete domain with failed to remove cache",
+			session:   validSession,
+			domainID:  domain.ID,
+			deleteRes: deletedDomain,
+			cacheErr:  errors.ErrMalformedEntity,
+			resp:      deletedDomain,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := drepo.On("SoftDelete", context.Background(), tc.domainID, tc.session.UserID, mock.Anything).Return(tc.deleteRes, tc.deleteErr)
+			cacheCall := dcache.On("Remove", context.Background(), tc.domainID).Return(tc.cacheErr)
+			domain, err := svc.DeleteDomain(context.Background(), tc.session, tc.domainID)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
+			assert.Equal(t, tc.resp, domain)
+			repoCall.Unset()
+			cacheCall.Unset()
+		})
+	}
+}
+
+func TestRestoreDomain(t *testing.T) {
+	svc := newService()
+
+	deletedDomain := domain
+	deletedDomain.Status = domains.DeletedStatus
+	enabledDomain := domain
+	enabledDomain.Status = domains.EnabledStatus
+	frozenDomain := domain
+	frozenDomain.Status = domains.FreezeStatus
+
+	cases := []struct {
+		desc        string
+		session     authn.Session
+		domainID    string
+		retrieveRes domains.Domain
+		retrieveErr error
+		restoreRes  domains.Domain
+		restoreErr  error
+		cacheErr    error
+		resp        domains.Domain
+		err         error
+	}{
+		{
+			desc:        "restore domain successfully",
+			session:     validSession,
+			domainID:    domain.ID,
+			retrieveRes: deletedDomain,
+			restoreRes:  enabledDomain,
+			resp:        enabledDomain,
+			err:         nil,
+		},
+		{
+			desc:        "restore domain to its previous status",
+			session:     validSession,
+			domainID:    domain.ID,
+			retrieveRes: deletedDomain,
+			restoreRes:  frozenDomain,
+			resp:        frozenDomain,
+			err:         nil,
+		},
+		{
+			desc:        "restore non-existing domain",
+			session:     validSession,
+			domainID:    inValid,
+			retrieveErr: repoerr.ErrNotFound,
+			resp:        domains.Domain{},
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:        "restore domain that is not deleted",
+			session:     validSession,
+			domainID:    domain.ID,
+			retrieveRes: enabledDomain,
+			resp:        domains.Domain{},
+			err:         svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:        "restore domain with failed to update",
+			session:     validSession,
+			domainID:    domain.ID,
+			retrieveRes: deletedDomain,
+			restoreErr:  errors.ErrMalformedEntity,
+			resp:        domains.Domain{},
+			err:         svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:        "restore domain with failed to remove cache",
+			session:     validSession,
+			domainID:    domain.ID,
+			retrieveRes: deletedDomain,
+			restoreRes:  enabledDomain,
+			cacheErr:    errors.ErrMalformedEntity,
+			resp:        enabledDomain,
+			err:         svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			retrieveCall := drepo.On("RetrieveByID", context.Background(), tc.domainID).Return(tc.retrieveRes, tc.retrieveErr)
+			repoCall := drepo.On("Restore", context.Background(), tc.domainID, tc.session.UserID, mock.Anything).Return(tc.restoreRes, tc.restoreErr)
+			cacheCall := dcache.On("Remove", context.Background(), tc.domainID).Return(tc.cacheErr)
+			domain, err := svc.RestoreDomain(context.Background(), tc.session, tc.domainID)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
+			assert.Equal(t, tc.resp, domain)
+			retrieveCall.Unset()
+			repoCall.Unset()
+			cacheCall.Unset()
+		})
+	}
+}
+
+func TestUpdateDomainQuotas(t *testing.T) {
+	svc := newService()
+
+	quotas := domains.Quotas{Clients: 10, Channels: 10, Groups: 5, Members: 3, MessagesPerMinute: 600}
+	updatedDomain := domain
+	updatedDomain.Quotas = quotas
+
+	cases := []struct {
+		desc      string
+		session   authn.Session
+		domainID  string
+		quotas    domains.Quotas
+		updateRes domains.Domain
+		updateErr error
+		resp      domains.Domain
+		err       error
+	}{
+		{
+			desc:      "update domain quotas successfully",
+			session:   validSession,
+			domainID:  domain.ID,
+			quotas:    quotas,
+			updateRes: updatedDomain,
+			resp:      updatedDomain,
+			err:       nil,
+		},
+		{
+			desc:      "update quotas of non-existing domain",
+			session:   validSession,
+			domainID:  inValid,
+			quotas:    quotas,
+			updateErr: repoerr.ErrNotFound,
+			resp:      domains.Domain{},
+			err:       svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := drepo.On("Update", context.Background(), tc.domainID, mock.Anything).Return(tc.updateRes, tc.updateErr)
+			domain, err := svc.UpdateDomainQuotas(context.Background(), tc.session, tc.domainID, tc.quotas)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
+			assert.Equal(t, tc.resp, domain)
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestRetrieveDomainSettings(t *testing.T) {
+	svc := newService()
+
+	settings := domains.Settings{Version: 2, AllowedProtocols: []string{domains.MQTTProtocol}}
+
+	cases := []struct {
+		desc        string
+		domainID    string
+		retrieveRes domains.Settings
+		retrieveErr error
+		resp        domains.Settings
+		err         error
+	}{
+		{
+			desc:        "retrieve domain settings successfully",
+			domainID:    domain.ID,
+			retrieveRes: settings,
+			resp:        settings,
+			err:         nil,
+		},
+		{
+			desc:        "retrieve domain settings with failed to retrieve settings",
+			domainID:    domain.ID,
+			retrieveErr: repoerr.ErrViewEntity,
+			resp:        domains.Settings{},
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := drepo.On("RetrieveSettings", context.Background(), tc.domainID).Return(tc.retrieveRes, tc.retrieveErr)
+			resp, err := svc.RetrieveDomainSettings(context.Background(), validSession, tc.domainID)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
+			assert.Equal(t, tc.resp, resp)
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestUpdateDomainSettings(t *testing.T) {
+	svc := newService()
+
+	settings := domains.Settings{
+		Version:            1,
+		AllowedProtocols:   []string{domains.MQTTProtocol},
+		ClientSecretPolicy: domains.SecretPolicy{MinLength: 16},
+	}
+	updated := settings
+	updated.Version = 2
+
+	cases := []struct {
+		desc      string
+		domainID  string
+		settings  domains.Settings
+		updateRes domains.Settings
+		updateErr error
+		resp      domains.Settings
+		err       error
+	}{
+		{
+			desc:      "update domain settings successfully",
+			domainID:  domain.ID,
+			settings:  settings,
+			updateRes: updated,
+			resp:      updated,
+			err:       nil,
+		},
+		{
+			desc:     "update domain settings with invalid settings",
+			domainID: domain.ID,
+			settings: domains.Settings{AllowedProtocols: []string{"amqp"}},
+			resp:     domains.Settings{},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "update domain settings with not allowed sender address",
+			domainID: domain.ID,
+			settings: domains.Settings{SMTP: domains.SMTP{FromAddress: "noreply@example.com"}},
+			resp:     domains.Settings{},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:      "update domain settings with stale version",
+			domainID:  domain.ID,
+			settings:  settings,
+			updateErr: errors.Wrap(repoerr.ErrConflict, domains.ErrSettingsVersion),
+			resp:      domains.Settings{},
+			err:       svcerr.ErrConflict,
+		},
+		{
+			desc:      "update domain settings with failed to update settings",
+			domainID:  domain.ID,
+			settings:  settings,
+			updateErr: repoerr.ErrUpdateEntity,
+			resp:      domains.Settings{},
+			err:       svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := drepo.On("UpdateSettings", context.Background(), tc.domainID, mock.Anything).Return(tc.updateRes, tc.updateErr)
+			resp, err := svc.UpdateDomainSettings(context.Background(), validSession, tc.domainID, tc.settings)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
+			assert.Equal(t, tc.resp, resp)
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestTransferDomainOwnership(t *testing.T) {
+	svc := newService()
+
+	newOwnerID := testsutil.GenerateUUID(t)
+	adminRole := roles.Role{ID: testsutil.GenerateUUID(t), Name: domains.BuiltInRoleAdmin.String(), EntityID: domain.ID}
+	rolesPage := roles.RolePage{Total: 1, Roles: []roles.Role{adminRole}}
+	membersPage := roles.MembersPage{Total: 1, Members: []string{userID}}
+
+	cases := []struct {
+		desc              string
+		newOwnerID        string
+		rolesRes          roles.RolePage
+		rolesErr          error
+		membersRes        roles.MembersPage
+		addPoliciesErr    error
+		deletePoliciesErr error
+		updateRes         domains.Domain
+		updateErr         error
+		resp              domains.Domain
+		err               error
+	}{
+		{
+			desc:       "transfer domain ownership successfully",
+			newOwnerID: newOwnerID,
+			rolesRes:   rolesPage,
+			membersRes: membersPage,
+			updateRes:  domain,
+			resp:       domain,
+			err:        nil,
+		},
+		{
+			desc:       "transfer domain ownership to current owner",
+			newOwnerID: userID,
+			rolesRes:   rolesPage,
+			membersRes: membersPage,
+			updateRes:  domain,
+			resp:       domain,
+			err:        nil,
+		},
+		{
+			desc:       "transfer domain ownership without admin role",
+			newOwnerID: newOwnerID,
+			rolesRes:   roles.RolePage{},
+			err:        svcerr.ErrViewEntity,
+		},
+		{
+			desc:       "transfer domain ownership with failed to retrieve roles",
+			newOwnerID: newOwnerID,
+			rolesErr:   repoerr.ErrNotFound,
+			err:        svcerr.ErrViewEntity,
+		},
+		{
+			desc:           "transfer domain ownership with failed to add new owner",
+			newOwnerID:     newOwnerID,
+			rolesRes:       rolesPage,
+			membersRes:     membersPage,
+			addPoliciesErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrAddPolicies,
+		},
+		{
+			desc:              "transfer domain ownership with failed to remove previous owner",
+			newOwnerID:        newOwnerID,
+			rolesRes:          rolesPage,
+			membersRes:        membersPage,
+			deletePoliciesErr: svcerr.ErrAuthorization,
+			err:               svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:       "transfer domain ownership with failed to update domain",
+			newOwnerID: newOwnerID,
+			rolesRes:   rolesPage,
+			membersRes: membersPage,
+			updateErr:  repoerr.ErrNotFound,
+			err:        svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := drepo.On("RetrieveAllRoles", context.Background(), domain.ID, mock.Anything, mock.Anything).Return(tc.rolesRes, tc.rolesErr)
+			repoCall1 := drepo.On("RoleListMembers", context.Background(), adminRole.ID, mock.Anything, mock.Anything).Return(tc.membersRes, nil)
+			repoCall2 := drepo.On("RetrieveByID", context.Background(), domain.ID).Return(domain, nil)
+			repoCall3 := drepo.On("RetrieveEntityRole", context.Background(), domain.ID, adminRole.ID).Return(adminRole, nil)
+			policyCall := policy.On("AddPolicies", context.Background(), mock.Anything).Return(tc.addPoliciesErr)
+			repoCall4 := drepo.On("RoleAddMembers", context.Background(), mock.Anything, []string{tc.newOwnerID}).Return([]string{tc.newOwnerID}, nil)
+			policyCall1 := policy.On("DeletePolicies", context.Background(), mock.Anything).Return(tc.deletePoliciesErr)
+			repoCall5 := drepo.On("RoleRemoveMembers", context.Background(), mock.Anything, mock.Anything).Return(nil)
+			repoCall6 := drepo.On("Update", context.Background(), domain.ID, mock.Anything).Return(tc.updateRes, tc.updateErr)
+			dom, err := svc.TransferDomainOwnership(context.Background(), validSession, domain.ID, tc.newOwnerID)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
+			assert.Equal(t, tc.resp, dom)
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			policyCall.Unset()
+			repoCall4.Unset()
+			policyCall1.Unset()
+			repoCall5.Unset()
+			repoCall6.Unset()
+		})
+	}
+}
+
+func TestRoleAddMembersQuota(t *testing.T) {
+	svc := newService()
+
+	limitedDomain := domain
+	limitedDomain.Quotas = domains.Quotas{Members: 2}
+	membersPage := roles.MembersRolePage{
+		Total:   2,
+		Members: []roles.MemberRoles{{MemberID: validID}, {MemberID: userID}},
+	}
+
+	cases := []struct {
+		desc           string
+		members        []string
+		retrieveRes    domains.Domain
+		retrieveErr    error
+		lockErr        error
+		listMembersRes roles.MembersRolePage
+		listMembersErr error
+		err            error
+	}{
+		{
+			desc:           "add members exceeding members quota",
+			members:        []string{testsutil.GenerateUUID(t)},
+			retrieveRes:    limitedDomain,
+			listMembersRes: membersPage,
+			err:            svcerr.ErrQuotaExceeded,
+		},
+		{
+			desc:        "add members to non-existing domain",
+			members:     []string{testsutil.GenerateUUID(t)},
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:           "add members with failed to list domain members",
+			members:        []string{testsutil.GenerateUUID(t)},
+			retrieveRes:    limitedDomain,
+			listMembersErr: repoerr.ErrViewEntity,
+			err:            svcerr.ErrViewEntity,
+		},
+		{
+			desc:        "add members with failed to lock domain quotas",
+			members:     []string{testsutil.GenerateUUID(t)},
+			retrieveRes: limitedDomain,
+			lockErr:     repoerr.ErrFailedOpDB,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			unlocked := false
+			repoCall := drepo.On("RetrieveByID", context.Background(), domain.ID).Return(tc.retrieveRes, tc.retrieveErr)
+			repoCall1 := drepo.On("LockQuotas", context.Background(), domain.ID).Return(func() { unlocked = true }, tc.lockErr)
+			repoCall2 := drepo.On("ListEntityMembers", context.Background(), domain.ID, mock.Anything).Return(tc.listMembersRes, tc.listMembersErr)
+			_, err := svc.RoleAddMembers(context.Background(), validSession, domain.ID, testsutil.GenerateUUID(t), tc.members)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
+			if tc.retrieveErr == nil && tc.lockErr == nil {
+				assert.True(t, unlocked, "expected domain quotas to be unlocked")
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+		})
+	}
+}
+
 func TestListDomains(t *testing.T) {
 	svc := newService()
 
@@ -0,0 +1,189 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package domains
+
+import (
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+)
+
+// Messaging protocols that can be allowed in the domain settings.
+const (
+	HTTPProtocol = "http"
+	MQTTProtocol = "mqtt"
+	CoAPProtocol = "coap"
+	WSProtocol   = "ws"
+)
+
+const (
+	// MaxSecretMinLength is the largest client secret minimum length, so that
+	// the generated client secrets always satisfy the policy.
+	MaxSecretMinLength      = 32
+	maxMessageRetentionDays = 3650
+	maxInvitationExpiryHrs  = 30 * 24
+	maxFooterLength         = 256
+)
+
+var (
+	// ErrProtocolNotAllowed indicates that the messaging protocol is not allowed in the domain.
+	ErrProtocolNotAllowed = errors.New("messaging protocol is not allowed in the domain")
+
+	// ErrSecretPolicy indicates that the client secret does not satisfy the domain secret policy.
+	ErrSecretPolicy = errors.New("client secret does not satisfy the domain secret policy")
+
+	// ErrSettingsVersion indicates that the domain settings have been updated in the meantime.
+	ErrSettingsVersion = errors.New("domain settings version mismatch")
+
+	errProtocol     = errors.New("unsupported messaging protocol")
+	errSecretLength = errors.New("invalid client secret minimum length")
+	errRetention    = errors.New("invalid message retention")
+	errInvitation   = errors.New("invalid invitation expiry")
+	errFromAddress  = errors.New("invalid SMTP sender address")
+	errFromDomain   = errors.New("SMTP sender address domain is not allowed")
+	errLogoURL      = errors.New("invalid logo URL")
+	errColor        = errors.New("invalid primary color")
+	errFooter       = errors.New("footer text is too long")
+
+	colorRegexp = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+	protocols = map[string]bool{
+		HTTPProtocol: true,
+		MQTTProtocol: true,
+		CoAPProtocol: true,
+		WSProtocol:   true,
+	}
+)
+
+// Settings represents the domain settings. Zero value of a setting means
+// that the platform default applies. Version is incremented on every
+// update of the settings.
+type Settings struct {
+	Version               uint64       `json:"version"`
+	AllowedProtocols      []string     `json:"allowed_protocols,omitempty"`
+	ClientSecretPolicy    SecretPolicy `json:"client_secret_policy"`
+	MessageRetentionDays  uint32       `json:"message_retention_days,omitempty"`
+	InvitationExpiryHours uint32       `json:"invitation_expiry_hours,omitempty"`
+	SMTP                  SMTP         `json:"smtp"`
+	Branding              Branding     `json:"branding"`
+	UpdatedAt             time.Time    `json:"updated_at,omitempty"`
+	UpdatedBy             string       `json:"updated_by,omitempty"`
+}
+
+// SecretPolicy represents the policy of the client secrets set by the users.
+type SecretPolicy struct {
+	MinLength uint32 `json:"min_length,omitempty"`
+}
+
+// SMTP represents the sender of the emails sent on behalf of the domain.
+type SMTP struct {
+	FromAddress string `json:"from_address,omitempty"`
+	FromName    string `json:"from_name,omitempty"`
+}
+
+// Branding represents the domain branding used in the emails.
+type Branding struct {
+	LogoURL      string `json:"logo_url,omitempty"`
+	PrimaryColor string `json:"primary_color,omitempty"`
+	FooterText   string `json:"footer_text,omitempty"`
+}
+
+// Validate returns ErrMalformedEntity if any of the settings is invalid.
+func (s Settings) Validate() error {
+	for _, p := range s.AllowedProtocols {
+		if !protocols[p] {
+			return errors.Wrap(svcerr.ErrMalformedEntity, errProtocol)
+		}
+	}
+	if s.ClientSecretPolicy.MinLength > MaxSecretMinLength {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errSecretLength)
+	}
+	if s.MessageRetentionDays > maxMessageRetentionDays {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errRetention)
+	}
+	if s.InvitationExpiryHours > maxInvitationExpiryHrs {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errInvitation)
+	}
+	if s.SMTP.FromAddress != "" {
+		addr, err := mail.ParseAddress(s.SMTP.FromAddress)
+		if err != nil || addr.Address != s.SMTP.FromAddress {
+			return errors.Wrap(svcerr.ErrMalformedEntity, errFromAddress)
+		}
+	}
+	if s.Branding.LogoURL != "" {
+		u, err := url.Parse(s.Branding.LogoURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Wrap(svcerr.ErrMalformedEntity, errLogoURL)
+		}
+	}
+	if s.Branding.PrimaryColor != "" && !colorRegexp.MatchString(s.Branding.PrimaryColor) {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errColor)
+	}
+	if len(s.Branding.FooterText) > maxFooterLength {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errFooter)
+	}
+
+	return nil
+}
+
+// CheckSender returns ErrMalformedEntity if the SMTP sender address is not
+// in one of the given e-mail domains. The sender address can't be set if no
+// domain is given, so the e-mails can't be sent on behalf of the domains the
+// platform doesn't own.
+func (s Settings) CheckSender(senderDomains []string) error {
+	if s.SMTP.FromAddress == "" {
+		return nil
+	}
+	i := strings.LastIndex(s.SMTP.FromAddress, "@")
+	host := s.SMTP.FromAddress[i+1:]
+	for _, d := range senderDomains {
+		if strings.EqualFold(host, d) {
+			return nil
+		}
+	}
+
+	return errors.Wrap(svcerr.ErrMalformedEntity, errFromDomain)
+}
+
+// AllowsProtocol checks whether the messaging protocol is allowed in the
+// domain. All the protocols are allowed if none is listed.
+func (s Settings) AllowsProtocol(protocol string) bool {
+	if len(s.AllowedProtocols) == 0 {
+		return true
+	}
+	for _, p := range s.AllowedProtocols {
+		if p == protocol {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckSecret returns ErrSecretPolicy if the client secret does not
+// satisfy the domain client secret policy.
+func (s Settings) CheckSecret(secret string) error {
+	if uint32(len(secret)) < s.ClientSecretPolicy.MinLength {
+		return ErrSecretPolicy
+	}
+
+	return nil
+}
+
+// MessageRetention returns the period the domain messages are stored for.
+// Zero means that the messages are stored until they are removed.
+func (s Settings) MessageRetention() time.Duration {
+	return time.Duration(s.MessageRetentionDays) * 24 * time.Hour
+}
+
+// InvitationExpiry returns the period after which the domain invitations
+// expire. Zero means that the invitations do not expire.
+func (s Settings) InvitationExpiry() time.Duration {
+	return time.Duration(s.InvitationExpiryHours) * time.Hour
+}
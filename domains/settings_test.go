@@ -0,0 +1,167 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package domains_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/domains"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettingsValidate(t *testing.T) {
+	cases := []struct {
+		desc     string
+		settings domains.Settings
+		err      error
+	}{
+		{
+			desc:     "empty settings",
+			settings: domains.Settings{},
+			err:      nil,
+		},
+		{
+			desc: "valid settings",
+			settings: domains.Settings{
+				AllowedProtocols:      []string{domains.MQTTProtocol, domains.HTTPProtocol},
+				ClientSecretPolicy:    domains.SecretPolicy{MinLength: 16},
+				MessageRetentionDays:  30,
+				InvitationExpiryHours: 72,
+				SMTP:                  domains.SMTP{FromAddress: "noreply@example.com", FromName: "Example"},
+				Branding:              domains.Branding{LogoURL: "https://example.com/logo.png", PrimaryColor: "#1a2b3c", FooterText: "Example Inc."},
+			},
+			err: nil,
+		},
+		{
+			desc:     "unknown protocol",
+			settings: domains.Settings{AllowedProtocols: []string{"amqp"}},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "too long client secret minimum length",
+			settings: domains.Settings{ClientSecretPolicy: domains.SecretPolicy{MinLength: domains.MaxSecretMinLength + 1}},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "too long message retention",
+			settings: domains.Settings{MessageRetentionDays: 3651},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "too long invitation expiry",
+			settings: domains.Settings{InvitationExpiryHours: 721},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "invalid SMTP sender address",
+			settings: domains.Settings{SMTP: domains.SMTP{FromAddress: "invalid"}},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "SMTP sender address with display name",
+			settings: domains.Settings{SMTP: domains.SMTP{FromAddress: "Example <noreply@example.com>"}},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "invalid logo URL",
+			settings: domains.Settings{Branding: domains.Branding{LogoURL: "ftp://example.com/logo.png"}},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "invalid primary color",
+			settings: domains.Settings{Branding: domains.Branding{PrimaryColor: "blue"}},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "too long footer text",
+			settings: domains.Settings{Branding: domains.Branding{FooterText: strings.Repeat("a", 257)}},
+			err:      svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.settings.Validate()
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestSettingsPolicies(t *testing.T) {
+	s := domains.Settings{
+		AllowedProtocols:      []string{domains.MQTTProtocol},
+		ClientSecretPolicy:    domains.SecretPolicy{MinLength: 8},
+		MessageRetentionDays:  7,
+		InvitationExpiryHours: 24,
+	}
+
+	assert.True(t, s.AllowsProtocol(domains.MQTTProtocol), "expected mqtt to be allowed")
+	assert.False(t, s.AllowsProtocol(domains.HTTPProtocol), "expected http not to be allowed")
+	assert.True(t, domains.Settings{}.AllowsProtocol(domains.HTTPProtocol), "expected all protocols to be allowed by default")
+	assert.Equal(t, domains.ErrSecretPolicy, s.CheckSecret("short"))
+	assert.Nil(t, s.CheckSecret("long enough"))
+	assert.Equal(t, 24*time.Hour, s.InvitationExpiry())
+	assert.Equal(t, 7*24*time.Hour, s.MessageRetention())
+}
+
+func TestSettingsCheckSender(t *testing.T) {
+	senderDomains := []string{"example.com"}
+
+	cases := []struct {
+		desc          string
+		fromAddress   string
+		senderDomains []string
+		err           error
+	}{
+		{
+			desc:          "empty sender address",
+			fromAddress:   "",
+			senderDomains: nil,
+			err:           nil,
+		},
+		{
+			desc:          "sender address in allowed domain",
+			fromAddress:   "noreply@example.com",
+			senderDomains: senderDomains,
+			err:           nil,
+		},
+		{
+			desc:          "sender address in allowed domain with different case",
+			fromAddress:   "noreply@Example.COM",
+			senderDomains: senderDomains,
+			err:           nil,
+		},
+		{
+			desc:          "sender address in not allowed domain",
+			fromAddress:   "ceo@bank.com",
+			senderDomains: senderDomains,
+			err:           svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:          "sender address in subdomain of allowed domain",
+			fromAddress:   "noreply@mail.example.com",
+			senderDomains: senderDomains,
+			err:           svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:          "sender address without allowed domains",
+			fromAddress:   "noreply@example.com",
+			senderDomains: nil,
+			err:           svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := domains.Settings{SMTP: domains.SMTP{FromAddress: tc.fromAddress}}
+			err := s.CheckSender(tc.senderDomains)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
@@ -75,6 +75,56 @@ func (tm *tracingMiddleware) FreezeDomain(ctx context.Context, session authn.Ses
 	return tm.svc.FreezeDomain(ctx, session, id)
 }
 
+func (tm *tracingMiddleware) UpdateDomainQuotas(ctx context.Context, session authn.Session, id string, q domains.Quotas) (domains.Domain, error) {
+	ctx, span := tm.tracer.Start(ctx, "update_domain_quotas", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+	return tm.svc.UpdateDomainQuotas(ctx, session, id, q)
+}
+
+func (tm *tracingMiddleware) DeleteDomain(ctx context.Context, session authn.Session, id string) (domains.Domain, error) {
+	ctx, span := tm.tracer.Start(ctx, "delete_domain", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+	return tm.svc.DeleteDomain(ctx, session, id)
+}
+
+func (tm *tracingMiddleware) RestoreDomain(ctx context.Context, session authn.Session, id string) (domains.Domain, error) {
+	ctx, span := tm.tracer.Start(ctx, "restore_domain", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+	return tm.svc.RestoreDomain(ctx, session, id)
+}
+
+func (tm *tracingMiddleware) TransferDomainOwnership(ctx context.Context, session authn.Session, id, newOwnerID string) (domains.Domain, error) {
+	ctx, span := tm.tracer.Start(ctx, "transfer_domain_ownership", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.String("new_owner_id", newOwnerID),
+	))
+	defer span.End()
+	return tm.svc.TransferDomainOwnership(ctx, session, id, newOwnerID)
+}
+
+func (tm *tracingMiddleware) RetrieveDomainSettings(ctx context.Context, session authn.Session, id string) (domains.Settings, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_domain_settings", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+	return tm.svc.RetrieveDomainSettings(ctx, session, id)
+}
+
+func (tm *tracingMiddleware) UpdateDomainSettings(ctx context.Context, session authn.Session, id string, s domains.Settings) (domains.Settings, error) {
+	ctx, span := tm.tracer.Start(ctx, "update_domain_settings", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.Int64("version", int64(s.Version)),
+	))
+	defer span.End()
+	return tm.svc.UpdateDomainSettings(ctx, session, id, s)
+}
+
 func (tm *tracingMiddleware) ListDomains(ctx context.Context, session authn.Session, p domains.Page) (domains.DomainsPage, error) {
 	ctx, span := tm.tracer.Start(ctx, "list_domains")
 	defer span.End()
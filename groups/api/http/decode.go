@@ -68,6 +68,19 @@ func DecodeGroupUpdate(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+func decodeViewGroupRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	effective, err := apiutil.ReadBoolQuery(r, api.EffectiveKey, false)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := groupReq{
+		id:        chi.URLParam(r, "groupID"),
+		effective: effective,
+	}
+	return req, nil
+}
+
 func DecodeGroupRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := groupReq{
 		id: chi.URLParam(r, "groupID"),
@@ -116,6 +129,47 @@ func decodeRemoveParentGroupRequest(_ context.Context, r *http.Request) (interfa
 	return req, nil
 }
 
+func decodeMoveGroupRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := moveGroupReq{
+		id: chi.URLParam(r, "groupID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+	return req, nil
+}
+
+func decodeChangeParentGroupRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := changeParentGroupReq{
+		id: chi.URLParam(r, "groupID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+	return req, nil
+}
+
+func decodeGroupHierarchyRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	dryRun, err := apiutil.ReadBoolQuery(r, api.DryRunKey, false)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := groupHierarchyReq{
+		id:     chi.URLParam(r, "groupID"),
+		dryRun: dryRun,
+	}
+	return req, nil
+}
+
 func decodeAddChildrenGroupsRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
@@ -230,17 +230,19 @@ func TestViewGroupEndpoint(t *testing.T) {
 	defer gs.Close()
 
 	cases := []struct {
-		desc     string
-		token    string
-		id       string
-		domainID string
-		session  smqauthn.Session
-		svcResp  groups.Group
-		svcErr   error
-		resp     groups.Group
-		status   int
-		authnErr error
-		err      error
+		desc      string
+		token     string
+		id        string
+		domainID  string
+		query     string
+		effective bool
+		session   smqauthn.Session
+		svcResp   groups.Group
+		svcErr    error
+		resp      groups.Group
+		status    int
+		authnErr  error
+		err       error
 	}{
 		{
 			desc:     "view group successfully",
@@ -253,6 +255,26 @@ func TestViewGroupEndpoint(t *testing.T) {
 			status:   http.StatusOK,
 			err:      nil,
 		},
+		{
+			desc:      "view group with effective metadata successfully",
+			token:     validToken,
+			domainID:  validID,
+			id:        validID,
+			query:     "effective_metadata=true",
+			effective: true,
+			svcResp:   validGroupResp,
+			resp:      validGroupResp,
+			status:    http.StatusOK,
+		},
+		{
+			desc:     "view group with invalid effective metadata flag",
+			token:    validToken,
+			domainID: validID,
+			id:       validID,
+			query:    "effective_metadata=invalid",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
 		{
 			desc:     "view group with invalid token",
 			token:    invalidToken,
@@ -298,14 +320,14 @@ func TestViewGroupEndpoint(t *testing.T) {
 			req := testRequest{
 				client: gs.Client(),
 				method: http.MethodGet,
-				url:    fmt.Sprintf("%s/%s/groups/%s", gs.URL, tc.domainID, tc.id),
+				url:    fmt.Sprintf("%s/%s/groups/%s?%s", gs.URL, tc.domainID, tc.id, tc.query),
 				token:  tc.token,
 			}
 			if tc.token == validToken {
 				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
 			}
 			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
-			svcCall := svc.On("ViewGroup", mock.Anything, tc.session, tc.id).Return(tc.svcResp, tc.svcErr)
+			svcCall := svc.On("ViewGroup", mock.Anything, tc.session, tc.id, tc.effective).Return(tc.svcResp, tc.svcErr)
 			res, err := req.make()
 			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 			var errRes respBody
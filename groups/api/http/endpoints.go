@@ -48,7 +48,7 @@ func ViewGroupEndpoint(svc groups.Service) endpoint.Endpoint {
 			return viewGroupRes{}, svcerr.ErrAuthentication
 		}
 
-		group, err := svc.ViewGroup(ctx, session, req.id)
+		group, err := svc.ViewGroup(ctx, session, req.id, req.effective)
 		if err != nil {
 			return viewGroupRes{}, err
 		}
@@ -250,6 +250,106 @@ func removeParentGroupEndpoint(svc groups.Service) endpoint.Endpoint {
 	}
 }
 
+func moveGroupEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(moveGroupReq)
+		if err := req.validate(); err != nil {
+			return updateGroupRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return updateGroupRes{}, svcerr.ErrAuthentication
+		}
+
+		group, err := svc.MoveGroup(ctx, session, req.id, req.DomainID)
+		if err != nil {
+			return updateGroupRes{}, err
+		}
+		return updateGroupRes{Group: group}, nil
+	}
+}
+
+func changeParentGroupEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(changeParentGroupReq)
+		if err := req.validate(); err != nil {
+			return updateGroupRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return updateGroupRes{}, svcerr.ErrAuthentication
+		}
+
+		group, err := svc.ChangeParentGroup(ctx, session, req.id, req.ParentID)
+		if err != nil {
+			return updateGroupRes{}, err
+		}
+		return updateGroupRes{Group: group}, nil
+	}
+}
+
+func enableGroupHierarchyEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupHierarchyReq)
+		if err := req.validate(); err != nil {
+			return hierarchyChangeRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return hierarchyChangeRes{}, svcerr.ErrAuthentication
+		}
+
+		hc, err := svc.EnableGroupHierarchy(ctx, session, req.id, req.dryRun)
+		if err != nil {
+			return hierarchyChangeRes{}, err
+		}
+		return hierarchyChangeRes{HierarchyChange: hc}, nil
+	}
+}
+
+func disableGroupHierarchyEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupHierarchyReq)
+		if err := req.validate(); err != nil {
+			return hierarchyChangeRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return hierarchyChangeRes{}, svcerr.ErrAuthentication
+		}
+
+		hc, err := svc.DisableGroupHierarchy(ctx, session, req.id, req.dryRun)
+		if err != nil {
+			return hierarchyChangeRes{}, err
+		}
+		return hierarchyChangeRes{HierarchyChange: hc}, nil
+	}
+}
+
+func deleteGroupHierarchyEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupHierarchyReq)
+		if err := req.validate(); err != nil {
+			return hierarchyChangeRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return hierarchyChangeRes{}, svcerr.ErrAuthentication
+		}
+
+		hc, err := svc.DeleteGroupHierarchy(ctx, session, req.id, req.dryRun)
+		if err != nil {
+			return hierarchyChangeRes{}, err
+		}
+		return hierarchyChangeRes{HierarchyChange: hc}, nil
+	}
+}
+
 func addChildrenGroupsEndpoint(svc groups.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(addChildrenGroupsReq)
@@ -56,7 +56,8 @@ func (req listGroupsReq) validate() error {
 }
 
 type groupReq struct {
-	id string
+	id        string
+	effective bool
 }
 
 func (req groupReq) validate() error {
@@ -112,6 +113,51 @@ func (req addParentGroupReq) validate() error {
 	return nil
 }
 
+type moveGroupReq struct {
+	id       string
+	DomainID string `json:"domain_id"`
+}
+
+func (req moveGroupReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.DomainID == "" {
+		return apiutil.ErrMissingDomainID
+	}
+	return nil
+}
+
+type changeParentGroupReq struct {
+	id       string
+	ParentID string `json:"parent_id"`
+}
+
+func (req changeParentGroupReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if err := api.ValidateUUID(req.ParentID); err != nil {
+		return err
+	}
+	if req.id == req.ParentID {
+		return apiutil.ErrSelfParentingNotAllowed
+	}
+	return nil
+}
+
+type groupHierarchyReq struct {
+	id     string
+	dryRun bool
+}
+
+func (req groupHierarchyReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	return nil
+}
+
 type removeParentGroupReq struct {
 	id string
 }
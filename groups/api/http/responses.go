@@ -24,6 +24,7 @@ var (
 	_ supermq.Response = (*removeChildrenGroupsRes)(nil)
 	_ supermq.Response = (*removeAllChildrenGroupsRes)(nil)
 	_ supermq.Response = (*listChildrenGroupsRes)(nil)
+	_ supermq.Response = (*hierarchyChangeRes)(nil)
 )
 
 type viewGroupRes struct {
@@ -248,3 +249,19 @@ func (res listChildrenGroupsRes) Headers() map[string]string {
 func (res listChildrenGroupsRes) Empty() bool {
 	return false
 }
+
+type hierarchyChangeRes struct {
+	groups.HierarchyChange `json:",inline"`
+}
+
+func (res hierarchyChangeRes) Code() int {
+	return http.StatusOK
+}
+
+func (res hierarchyChangeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res hierarchyChangeRes) Empty() bool {
+	return false
+}
@@ -45,7 +45,7 @@ func MakeHandler(svc groups.Service, authn authn.Authentication, mux *chi.Mux, l
 		r.Route("/{groupID}", func(r chi.Router) {
 			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
 				ViewGroupEndpoint(svc),
-				DecodeGroupRequest,
+				decodeViewGroupRequest,
 				api.EncodeResponse,
 				opts...,
 			), "view_group").ServeHTTP)
@@ -80,12 +80,35 @@ func MakeHandler(svc groups.Service, authn authn.Authentication, mux *chi.Mux, l
 
 			r = roleManagerHttp.EntityRoleMangerRouter(svc, d, r, opts)
 
-			r.Get("/hierarchy", otelhttp.NewHandler(kithttp.NewServer(
-				retrieveGroupHierarchyEndpoint(svc),
-				decodeRetrieveGroupHierarchy,
-				api.EncodeResponse,
-				opts...,
-			), "retrieve_group_hierarchy").ServeHTTP)
+			r.Route("/hierarchy", func(r chi.Router) {
+				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+					retrieveGroupHierarchyEndpoint(svc),
+					decodeRetrieveGroupHierarchy,
+					api.EncodeResponse,
+					opts...,
+				), "retrieve_group_hierarchy").ServeHTTP)
+
+				r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+					deleteGroupHierarchyEndpoint(svc),
+					decodeGroupHierarchyRequest,
+					api.EncodeResponse,
+					opts...,
+				), "delete_group_hierarchy").ServeHTTP)
+
+				r.Post("/enable", otelhttp.NewHandler(kithttp.NewServer(
+					enableGroupHierarchyEndpoint(svc),
+					decodeGroupHierarchyRequest,
+					api.EncodeResponse,
+					opts...,
+				), "enable_group_hierarchy").ServeHTTP)
+
+				r.Post("/disable", otelhttp.NewHandler(kithttp.NewServer(
+					disableGroupHierarchyEndpoint(svc),
+					decodeGroupHierarchyRequest,
+					api.EncodeResponse,
+					opts...,
+				), "disable_group_hierarchy").ServeHTTP)
+			})
 
 			r.Route("/parent", func(r chi.Router) {
 				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
@@ -101,8 +124,22 @@ func MakeHandler(svc groups.Service, authn authn.Authentication, mux *chi.Mux, l
 					api.EncodeResponse,
 					opts...,
 				), "remove_parent_group").ServeHTTP)
+
+				r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+					changeParentGroupEndpoint(svc),
+					decodeChangeParentGroupRequest,
+					api.EncodeResponse,
+					opts...,
+				), "change_parent_group").ServeHTTP)
 			})
 
+			r.Post("/move", otelhttp.NewHandler(kithttp.NewServer(
+				moveGroupEndpoint(svc),
+				decodeMoveGroupRequest,
+				api.EncodeResponse,
+				opts...,
+			), "move_group").ServeHTTP)
+
 			r.Route("/children", func(r chi.Router) {
 				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
 					addChildrenGroupsEndpoint(svc),
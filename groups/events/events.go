@@ -29,6 +29,10 @@ var (
 	groupRemoveChildrenGroups    = groupPrefix + "remove_children_groups"
 	groupRemoveAllChildrenGroups = groupPrefix + "remove_all_children_groups"
 	groupListChildrenGroups      = groupPrefix + "list_children_groups"
+	groupMove                    = groupPrefix + "move"
+	groupChangeParentGroup       = groupPrefix + "change_parent_group"
+	groupChangeHierarchyStatus   = groupPrefix + "change_hierarchy_status"
+	groupRemoveHierarchy         = groupPrefix + "remove_hierarchy"
 )
 
 var (
@@ -41,6 +45,10 @@ var (
 	_ events.Event = (*listGroupEvent)(nil)
 	_ events.Event = (*addParentGroupEvent)(nil)
 	_ events.Event = (*removeParentGroupEvent)(nil)
+	_ events.Event = (*moveGroupEvent)(nil)
+	_ events.Event = (*changeParentGroupEvent)(nil)
+	_ events.Event = (*changeHierarchyStatusEvent)(nil)
+	_ events.Event = (*deleteHierarchyEvent)(nil)
 	_ events.Event = (*viewParentGroupEvent)(nil)
 	_ events.Event = (*addChildrenGroupsEvent)(nil)
 	_ events.Event = (*removeChildrenGroupsEvent)(nil)
@@ -336,6 +344,97 @@ func (rpge removeParentGroupEvent) Encode() (map[string]interface{}, error) {
 	}, nil
 }
 
+type moveGroupEvent struct {
+	id        string
+	domainID  string
+	updatedAt time.Time
+	updatedBy string
+	authn.Session
+}
+
+func (mge moveGroupEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   groupMove,
+		"id":          mge.id,
+		"domain":      mge.DomainID,
+		"new_domain":  mge.domainID,
+		"updated_at":  mge.updatedAt,
+		"updated_by":  mge.updatedBy,
+		"user_id":     mge.UserID,
+		"token_type":  mge.Type.String(),
+		"super_admin": mge.SuperAdmin,
+	}, nil
+}
+
+type changeParentGroupEvent struct {
+	id        string
+	parentID  string
+	path      string
+	updatedAt time.Time
+	updatedBy string
+	authn.Session
+}
+
+func (cpge changeParentGroupEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   groupChangeParentGroup,
+		"id":          cpge.id,
+		"parent_id":   cpge.parentID,
+		"path":        cpge.path,
+		"updated_at":  cpge.updatedAt,
+		"updated_by":  cpge.updatedBy,
+		"domain":      cpge.DomainID,
+		"user_id":     cpge.UserID,
+		"token_type":  cpge.Type.String(),
+		"super_admin": cpge.SuperAdmin,
+	}, nil
+}
+
+type changeHierarchyStatusEvent struct {
+	id        string
+	status    string
+	updatedAt time.Time
+	groups.HierarchyChange
+	authn.Session
+}
+
+func (chse changeHierarchyStatusEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   groupChangeHierarchyStatus,
+		"id":          chse.id,
+		"status":      chse.status,
+		"updated_at":  chse.updatedAt,
+		"updated_by":  chse.UserID,
+		"groups":      chse.Groups,
+		"clients":     chse.Clients,
+		"channels":    chse.Channels,
+		"domain":      chse.DomainID,
+		"user_id":     chse.UserID,
+		"token_type":  chse.Type.String(),
+		"super_admin": chse.SuperAdmin,
+	}, nil
+}
+
+type deleteHierarchyEvent struct {
+	id string
+	groups.HierarchyChange
+	authn.Session
+}
+
+func (dhe deleteHierarchyEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   groupRemoveHierarchy,
+		"id":          dhe.id,
+		"groups":      dhe.Groups,
+		"clients":     dhe.Clients,
+		"channels":    dhe.Channels,
+		"domain":      dhe.DomainID,
+		"user_id":     dhe.UserID,
+		"token_type":  dhe.Type.String(),
+		"super_admin": dhe.SuperAdmin,
+	}, nil
+}
+
 type viewParentGroupEvent struct {
 	id       string
 	domainID string
@@ -5,6 +5,7 @@ package events
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/supermq/groups"
 	"github.com/absmach/supermq/pkg/authn"
@@ -77,8 +78,8 @@ func (es eventStore) UpdateGroup(ctx context.Context, session authn.Session, gro
 	return group, nil
 }
 
-func (es eventStore) ViewGroup(ctx context.Context, session authn.Session, id string) (groups.Group, error) {
-	group, err := es.svc.ViewGroup(ctx, session, id)
+func (es eventStore) ViewGroup(ctx context.Context, session authn.Session, id string, effective bool) (groups.Group, error) {
+	group, err := es.svc.ViewGroup(ctx, session, id, effective)
 	if err != nil {
 		return group, err
 	}
@@ -212,6 +213,61 @@ func (es eventStore) RemoveParentGroup(ctx context.Context, session authn.Sessio
 	return nil
 }
 
+func (es eventStore) MoveGroup(ctx context.Context, session authn.Session, id, domainID string) (groups.Group, error) {
+	g, err := es.svc.MoveGroup(ctx, session, id, domainID)
+	if err != nil {
+		return g, err
+	}
+	if err := es.Publish(ctx, moveGroupEvent{id: id, domainID: domainID, updatedAt: g.UpdatedAt, updatedBy: g.UpdatedBy, Session: session}); err != nil {
+		return g, err
+	}
+	return g, nil
+}
+
+func (es eventStore) ChangeParentGroup(ctx context.Context, session authn.Session, id, parentID string) (groups.Group, error) {
+	g, err := es.svc.ChangeParentGroup(ctx, session, id, parentID)
+	if err != nil {
+		return g, err
+	}
+	if err := es.Publish(ctx, changeParentGroupEvent{id: id, parentID: parentID, path: g.Path, updatedAt: g.UpdatedAt, updatedBy: g.UpdatedBy, Session: session}); err != nil {
+		return g, err
+	}
+	return g, nil
+}
+
+func (es eventStore) EnableGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	hc, err := es.svc.EnableGroupHierarchy(ctx, session, id, dryRun)
+	if err != nil || dryRun {
+		return hc, err
+	}
+	if err := es.Publish(ctx, changeHierarchyStatusEvent{id: id, status: groups.EnabledStatus.String(), updatedAt: time.Now(), HierarchyChange: hc, Session: session}); err != nil {
+		return hc, err
+	}
+	return hc, nil
+}
+
+func (es eventStore) DisableGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	hc, err := es.svc.DisableGroupHierarchy(ctx, session, id, dryRun)
+	if err != nil || dryRun {
+		return hc, err
+	}
+	if err := es.Publish(ctx, changeHierarchyStatusEvent{id: id, status: groups.DisabledStatus.String(), updatedAt: time.Now(), HierarchyChange: hc, Session: session}); err != nil {
+		return hc, err
+	}
+	return hc, nil
+}
+
+func (es eventStore) DeleteGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	hc, err := es.svc.DeleteGroupHierarchy(ctx, session, id, dryRun)
+	if err != nil || dryRun {
+		return hc, err
+	}
+	if err := es.Publish(ctx, deleteHierarchyEvent{id: id, HierarchyChange: hc, Session: session}); err != nil {
+		return hc, err
+	}
+	return hc, nil
+}
+
 func (es eventStore) AddChildrenGroups(ctx context.Context, session authn.Session, id string, childrenGroupIDs []string) error {
 	if err := es.svc.AddChildrenGroups(ctx, session, id, childrenGroupIDs); err != nil {
 		return err
@@ -81,6 +81,16 @@ type HierarchyPage struct {
 	Groups []Group
 }
 
+// HierarchyChange lists the entities affected by an operation applied to
+// the whole group hierarchy. With DryRun set, nothing has been changed and
+// the entities are the ones the operation would affect.
+type HierarchyChange struct {
+	DryRun   bool     `json:"dry_run"`
+	Groups   []string `json:"groups"`
+	Clients  []string `json:"clients"`
+	Channels []string `json:"channels"`
+}
+
 // Repository specifies a group persistence API.
 //
 //go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines" --unroll-variadic=false
@@ -128,6 +138,22 @@ type Repository interface {
 	// Delete a group
 	Delete(ctx context.Context, groupID string) error
 
+	// ChangeDomain moves the group subtree rooted at the given group to the
+	// given domain, detaching the root from its parent group.
+	ChangeDomain(ctx context.Context, g Group) (Group, error)
+
+	// ChangeParentGroup moves the group subtree rooted at the given group
+	// under the given parent group, updating the paths of all descendants.
+	ChangeParentGroup(ctx context.Context, g Group) (Group, error)
+
+	// ChangeHierarchyStatus changes the status of the group and all of its
+	// descendants.
+	ChangeHierarchyStatus(ctx context.Context, g Group) ([]Group, error)
+
+	// RetrievePathMetadata retrieves metadata of all groups on the given
+	// path, ordered from the root group.
+	RetrievePathMetadata(ctx context.Context, path string) ([]Metadata, error)
+
 	roles.Repository
 }
 
@@ -139,8 +165,9 @@ type Service interface {
 	// UpdateGroup updates the group identified by the provided ID.
 	UpdateGroup(ctx context.Context, session authn.Session, g Group) (Group, error)
 
-	// ViewGroup retrieves data about the group identified by ID.
-	ViewGroup(ctx context.Context, session authn.Session, id string) (Group, error)
+	// ViewGroup retrieves data about the group identified by ID. With effective
+	// set, the group metadata is merged with metadata inherited from its ancestors.
+	ViewGroup(ctx context.Context, session authn.Session, id string, effective bool) (Group, error)
 
 	// ListGroups retrieves groups for given filters.
 	ListGroups(ctx context.Context, session authn.Session, pm PageMeta) (Page, error)
@@ -163,6 +190,21 @@ type Service interface {
 
 	RemoveParentGroup(ctx context.Context, session authn.Session, id string) error
 
+	// MoveGroup moves the group and its descendants to the domain with the given ID.
+	MoveGroup(ctx context.Context, session authn.Session, id, domainID string) (Group, error)
+
+	// ChangeParentGroup moves the group and its descendants under the parent group with the given ID.
+	ChangeParentGroup(ctx context.Context, session authn.Session, id, parentID string) (Group, error)
+
+	// EnableGroupHierarchy enables the group and all of its descendants.
+	EnableGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (HierarchyChange, error)
+
+	// DisableGroupHierarchy disables the group and all of its descendants.
+	DisableGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (HierarchyChange, error)
+
+	// DeleteGroupHierarchy deletes the group and all of its descendants.
+	DeleteGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (HierarchyChange, error)
+
 	AddChildrenGroups(ctx context.Context, session authn.Session, id string, childrenGroupIDs []string) error
 
 	RemoveChildrenGroups(ctx context.Context, session authn.Session, id string, childrenGroupIDs []string) error
@@ -25,6 +25,7 @@ var (
 	errEnable                      = errors.New("not authorized to enable group")
 	errDisable                     = errors.New("not authorized to disable group")
 	errDelete                      = errors.New("not authorized to delete group")
+	errMove                        = errors.New("not authorized to move group")
 	errViewHierarchy               = errors.New("not authorized to view group parent/children hierarchy")
 	errListChildrenGroups          = errors.New("not authorized to view chidden groups of group")
 	errSetParentGroup              = errors.New("not authorized to set parent group to group")
@@ -86,6 +87,7 @@ func (am *authorizationMiddleware) CreateGroup(ctx context.Context, session auth
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -127,6 +129,7 @@ func (am *authorizationMiddleware) UpdateGroup(ctx context.Context, session auth
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -151,11 +154,12 @@ func (am *authorizationMiddleware) UpdateGroup(ctx context.Context, session auth
 	return am.svc.UpdateGroup(ctx, session, g)
 }
 
-func (am *authorizationMiddleware) ViewGroup(ctx context.Context, session authn.Session, id string) (groups.Group, error) {
+func (am *authorizationMiddleware) ViewGroup(ctx context.Context, session authn.Session, id string, effective bool) (groups.Group, error) {
 	if session.Type == authn.PersonalAccessToken {
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -177,7 +181,7 @@ func (am *authorizationMiddleware) ViewGroup(ctx context.Context, session authn.
 		return groups.Group{}, errors.Wrap(errView, err)
 	}
 
-	return am.svc.ViewGroup(ctx, session, id)
+	return am.svc.ViewGroup(ctx, session, id, effective)
 }
 
 func (am *authorizationMiddleware) ListGroups(ctx context.Context, session authn.Session, gm groups.PageMeta) (groups.Page, error) {
@@ -185,6 +189,7 @@ func (am *authorizationMiddleware) ListGroups(ctx context.Context, session authn
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -237,6 +242,7 @@ func (am *authorizationMiddleware) EnableGroup(ctx context.Context, session auth
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -265,6 +271,7 @@ func (am *authorizationMiddleware) DisableGroup(ctx context.Context, session aut
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -293,6 +300,7 @@ func (am *authorizationMiddleware) DeleteGroup(ctx context.Context, session auth
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -321,6 +329,7 @@ func (am *authorizationMiddleware) RetrieveGroupHierarchy(ctx context.Context, s
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -348,6 +357,7 @@ func (am *authorizationMiddleware) AddParentGroup(ctx context.Context, session a
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -385,6 +395,7 @@ func (am *authorizationMiddleware) RemoveParentGroup(ctx context.Context, sessio
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -424,11 +435,234 @@ func (am *authorizationMiddleware) RemoveParentGroup(ctx context.Context, sessio
 	return am.svc.RemoveParentGroup(ctx, session, id)
 }
 
+func (am *authorizationMiddleware) MoveGroup(ctx context.Context, session authn.Session, id, domainID string) (groups.Group, error) {
+	if session.Type == authn.PersonalAccessToken {
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainGroupsScope,
+			Operation:                auth.DeleteOp,
+			EntityIDs:                []string{id},
+		}); err != nil {
+			return groups.Group{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         domainID,
+			OptionalDomainEntityType: auth.DomainGroupsScope,
+			Operation:                auth.CreateOp,
+			EntityIDs:                auth.AnyIDs{}.Values(),
+		}); err != nil {
+			return groups.Group{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+	}
+
+	if err := am.authorize(ctx, groups.OpMoveGroup, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		Object:      id,
+		ObjectType:  policies.GroupType,
+	}); err != nil {
+		return groups.Group{}, errors.Wrap(errMove, err)
+	}
+
+	group, err := am.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return groups.Group{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	if group.Parent != "" {
+		if err := am.authorize(ctx, groups.OpRemoveChildrenGroups, smqauthz.PolicyReq{
+			Domain:      session.DomainID,
+			SubjectType: policies.UserType,
+			Subject:     session.DomainUserID,
+			Object:      group.Parent,
+			ObjectType:  policies.GroupType,
+		}); err != nil {
+			return groups.Group{}, errors.Wrap(errParentGroupRemoveChildGroup, err)
+		}
+	}
+
+	if err := am.extAuthorize(ctx, groups.DomainOpCreateGroup, smqauthz.PolicyReq{
+		Domain:      domainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     policies.EncodeDomainUserID(domainID, session.UserID),
+		Object:      domainID,
+		ObjectType:  policies.DomainType,
+	}); err != nil {
+		return groups.Group{}, errors.Wrap(errDomainCreateGroups, err)
+	}
+
+	return am.svc.MoveGroup(ctx, session, id, domainID)
+}
+
+func (am *authorizationMiddleware) ChangeParentGroup(ctx context.Context, session authn.Session, id, parentID string) (groups.Group, error) {
+	if session.Type == authn.PersonalAccessToken {
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainGroupsScope,
+			Operation:                auth.UpdateOp,
+			EntityIDs:                []string{id},
+		}); err != nil {
+			return groups.Group{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+	}
+
+	if err := am.authorize(ctx, groups.OpAddParentGroup, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		Object:      id,
+		ObjectType:  policies.GroupType,
+	}); err != nil {
+		return groups.Group{}, errors.Wrap(errSetParentGroup, err)
+	}
+
+	if err := am.authorize(ctx, groups.OpRemoveParentGroup, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		Object:      id,
+		ObjectType:  policies.GroupType,
+	}); err != nil {
+		return groups.Group{}, errors.Wrap(errRemoveParentGroup, err)
+	}
+
+	if err := am.authorize(ctx, groups.OpAddChildrenGroups, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		Object:      parentID,
+		ObjectType:  policies.GroupType,
+	}); err != nil {
+		return groups.Group{}, errors.Wrap(errParentGroupSetChildGroup, err)
+	}
+
+	group, err := am.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return groups.Group{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	if group.Parent != "" {
+		if err := am.authorize(ctx, groups.OpRemoveChildrenGroups, smqauthz.PolicyReq{
+			Domain:      session.DomainID,
+			SubjectType: policies.UserType,
+			Subject:     session.DomainUserID,
+			Object:      group.Parent,
+			ObjectType:  policies.GroupType,
+		}); err != nil {
+			return groups.Group{}, errors.Wrap(errParentGroupRemoveChildGroup, err)
+		}
+	}
+
+	return am.svc.ChangeParentGroup(ctx, session, id, parentID)
+}
+
+func (am *authorizationMiddleware) EnableGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	if session.Type == authn.PersonalAccessToken {
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainGroupsScope,
+			Operation:                auth.UpdateOp,
+			EntityIDs:                []string{id},
+		}); err != nil {
+			return groups.HierarchyChange{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+	}
+
+	if err := am.authorize(ctx, groups.OpEnableGroup, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		Object:      id,
+		ObjectType:  policies.GroupType,
+	}); err != nil {
+		return groups.HierarchyChange{}, errors.Wrap(errEnable, err)
+	}
+
+	return am.svc.EnableGroupHierarchy(ctx, session, id, dryRun)
+}
+
+func (am *authorizationMiddleware) DisableGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	if session.Type == authn.PersonalAccessToken {
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainGroupsScope,
+			Operation:                auth.UpdateOp,
+			EntityIDs:                []string{id},
+		}); err != nil {
+			return groups.HierarchyChange{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+	}
+
+	if err := am.authorize(ctx, groups.OpDisableGroup, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		Object:      id,
+		ObjectType:  policies.GroupType,
+	}); err != nil {
+		return groups.HierarchyChange{}, errors.Wrap(errDisable, err)
+	}
+
+	return am.svc.DisableGroupHierarchy(ctx, session, id, dryRun)
+}
+
+func (am *authorizationMiddleware) DeleteGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	if session.Type == authn.PersonalAccessToken {
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainGroupsScope,
+			Operation:                auth.DeleteOp,
+			EntityIDs:                []string{id},
+		}); err != nil {
+			return groups.HierarchyChange{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+	}
+
+	if err := am.authorize(ctx, groups.OpDeleteGroup, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		Object:      id,
+		ObjectType:  policies.GroupType,
+	}); err != nil {
+		return groups.HierarchyChange{}, errors.Wrap(errDelete, err)
+	}
+
+	return am.svc.DeleteGroupHierarchy(ctx, session, id, dryRun)
+}
+
 func (am *authorizationMiddleware) AddChildrenGroups(ctx context.Context, session authn.Session, id string, childrenGroupIDs []string) error {
 	if session.Type == authn.PersonalAccessToken {
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -469,6 +703,7 @@ func (am *authorizationMiddleware) RemoveChildrenGroups(ctx context.Context, ses
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -497,6 +732,7 @@ func (am *authorizationMiddleware) RemoveAllChildrenGroups(ctx context.Context,
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -525,6 +761,7 @@ func (am *authorizationMiddleware) ListChildrenGroups(ctx context.Context, sessi
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       auth.PlatformDomainsScope,
 			OptionalDomainID:         session.DomainID,
 			OptionalDomainEntityType: auth.DomainGroupsScope,
@@ -72,7 +72,7 @@ func (lm *loggingMiddleware) UpdateGroup(ctx context.Context, session authn.Sess
 
 // ViewGroup logs the view_group request. It logs the group name, id and the time it took to complete the request.
 // If the request fails, it logs the error.
-func (lm *loggingMiddleware) ViewGroup(ctx context.Context, session authn.Session, id string) (g groups.Group, err error) {
+func (lm *loggingMiddleware) ViewGroup(ctx context.Context, session authn.Session, id string, effective bool) (g groups.Group, err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
@@ -80,6 +80,7 @@ func (lm *loggingMiddleware) ViewGroup(ctx context.Context, session authn.Sessio
 				slog.String("id", g.ID),
 				slog.String("name", g.Name),
 			),
+			slog.Bool("effective", effective),
 		}
 		if err != nil {
 			args = append(args, slog.String("error", err.Error()))
@@ -88,7 +89,7 @@ func (lm *loggingMiddleware) ViewGroup(ctx context.Context, session authn.Sessio
 		}
 		lm.logger.Info("View group completed successfully", args...)
 	}(time.Now())
-	return lm.svc.ViewGroup(ctx, session, id)
+	return lm.svc.ViewGroup(ctx, session, id, effective)
 }
 
 // ListGroups logs the list_groups request. It logs the page metadata and the time it took to complete the request.
@@ -246,6 +247,94 @@ func (lm *loggingMiddleware) RemoveParentGroup(ctx context.Context, session auth
 	return lm.svc.RemoveParentGroup(ctx, session, id)
 }
 
+func (lm *loggingMiddleware) MoveGroup(ctx context.Context, session authn.Session, id, domainID string) (g groups.Group, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", id),
+			slog.String("domain_id", domainID),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Move group failed", args...)
+			return
+		}
+		lm.logger.Info("Move group completed successfully", args...)
+	}(time.Now())
+	return lm.svc.MoveGroup(ctx, session, id, domainID)
+}
+
+func (lm *loggingMiddleware) ChangeParentGroup(ctx context.Context, session authn.Session, id, parentID string) (g groups.Group, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", id),
+			slog.String("parent_id", parentID),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Change parent group failed", args...)
+			return
+		}
+		lm.logger.Info("Change parent group completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ChangeParentGroup(ctx, session, id, parentID)
+}
+
+func (lm *loggingMiddleware) EnableGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (hc groups.HierarchyChange, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", id),
+			slog.Bool("dry_run", dryRun),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Enable group hierarchy failed", args...)
+			return
+		}
+		args = append(args, slog.Int("groups", len(hc.Groups)), slog.Int("clients", len(hc.Clients)), slog.Int("channels", len(hc.Channels)))
+		lm.logger.Info("Enable group hierarchy completed successfully", args...)
+	}(time.Now())
+	return lm.svc.EnableGroupHierarchy(ctx, session, id, dryRun)
+}
+
+func (lm *loggingMiddleware) DisableGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (hc groups.HierarchyChange, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", id),
+			slog.Bool("dry_run", dryRun),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Disable group hierarchy failed", args...)
+			return
+		}
+		args = append(args, slog.Int("groups", len(hc.Groups)), slog.Int("clients", len(hc.Clients)), slog.Int("channels", len(hc.Channels)))
+		lm.logger.Info("Disable group hierarchy completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DisableGroupHierarchy(ctx, session, id, dryRun)
+}
+
+func (lm *loggingMiddleware) DeleteGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (hc groups.HierarchyChange, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", id),
+			slog.Bool("dry_run", dryRun),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Delete group hierarchy failed", args...)
+			return
+		}
+		args = append(args, slog.Int("groups", len(hc.Groups)), slog.Int("clients", len(hc.Clients)), slog.Int("channels", len(hc.Channels)))
+		lm.logger.Info("Delete group hierarchy completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteGroupHierarchy(ctx, session, id, dryRun)
+}
+
 func (lm *loggingMiddleware) AddChildrenGroups(ctx context.Context, session authn.Session, id string, childrenGroupIDs []string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
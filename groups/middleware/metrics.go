@@ -53,12 +53,12 @@ func (ms *metricsMiddleware) UpdateGroup(ctx context.Context, session authn.Sess
 }
 
 // ViewGroup instruments ViewGroup method with metrics.
-func (ms *metricsMiddleware) ViewGroup(ctx context.Context, session authn.Session, id string) (g groups.Group, err error) {
+func (ms *metricsMiddleware) ViewGroup(ctx context.Context, session authn.Session, id string, effective bool) (g groups.Group, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_group").Add(1)
 		ms.latency.With("method", "view_group").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.ViewGroup(ctx, session, id)
+	return ms.svc.ViewGroup(ctx, session, id, effective)
 }
 
 // ListGroups instruments ListGroups method with metrics.
@@ -128,6 +128,46 @@ func (ms *metricsMiddleware) RemoveParentGroup(ctx context.Context, session auth
 	return ms.svc.RemoveParentGroup(ctx, session, id)
 }
 
+func (ms *metricsMiddleware) MoveGroup(ctx context.Context, session authn.Session, id, domainID string) (groups.Group, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "move_group").Add(1)
+		ms.latency.With("method", "move_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.MoveGroup(ctx, session, id, domainID)
+}
+
+func (ms *metricsMiddleware) ChangeParentGroup(ctx context.Context, session authn.Session, id, parentID string) (groups.Group, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "change_parent_group").Add(1)
+		ms.latency.With("method", "change_parent_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ChangeParentGroup(ctx, session, id, parentID)
+}
+
+func (ms *metricsMiddleware) EnableGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "enable_group_hierarchy").Add(1)
+		ms.latency.With("method", "enable_group_hierarchy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.EnableGroupHierarchy(ctx, session, id, dryRun)
+}
+
+func (ms *metricsMiddleware) DisableGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "disable_group_hierarchy").Add(1)
+		ms.latency.With("method", "disable_group_hierarchy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DisableGroupHierarchy(ctx, session, id, dryRun)
+}
+
+func (ms *metricsMiddleware) DeleteGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_group_hierarchy").Add(1)
+		ms.latency.With("method", "delete_group_hierarchy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteGroupHierarchy(ctx, session, id, dryRun)
+}
+
 func (ms *metricsMiddleware) AddChildrenGroups(ctx context.Context, session authn.Session, id string, childrenGroupIDs []string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "add_children_groups").Add(1)
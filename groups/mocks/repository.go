@@ -66,6 +66,92 @@ func (_m *Repository) AssignParentGroup(ctx context.Context, parentGroupID strin
 	return r0
 }
 
+// ChangeDomain provides a mock function with given fields: ctx, g
+func (_m *Repository) ChangeDomain(ctx context.Context, g groups.Group) (groups.Group, error) {
+	ret := _m.Called(ctx, g)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeDomain")
+	}
+
+	var r0 groups.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, groups.Group) (groups.Group, error)); ok {
+		return rf(ctx, g)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, groups.Group) groups.Group); ok {
+		r0 = rf(ctx, g)
+	} else {
+		r0 = ret.Get(0).(groups.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, groups.Group) error); ok {
+		r1 = rf(ctx, g)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeHierarchyStatus provides a mock function with given fields: ctx, g
+func (_m *Repository) ChangeHierarchyStatus(ctx context.Context, g groups.Group) ([]groups.Group, error) {
+	ret := _m.Called(ctx, g)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeHierarchyStatus")
+	}
+
+	var r0 []groups.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, groups.Group) ([]groups.Group, error)); ok {
+		return rf(ctx, g)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, groups.Group) []groups.Group); ok {
+		r0 = rf(ctx, g)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]groups.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, groups.Group) error); ok {
+		r1 = rf(ctx, g)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeParentGroup provides a mock function with given fields: ctx, g
+func (_m *Repository) ChangeParentGroup(ctx context.Context, g groups.Group) (groups.Group, error) {
+	ret := _m.Called(ctx, g)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeParentGroup")
+	}
+
+	var r0 groups.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, groups.Group) (groups.Group, error)); ok {
+		return rf(ctx, g)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, groups.Group) groups.Group); ok {
+		r0 = rf(ctx, g)
+	} else {
+		r0 = ret.Get(0).(groups.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, groups.Group) error); ok {
+		r1 = rf(ctx, g)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ChangeStatus provides a mock function with given fields: ctx, group
 func (_m *Repository) ChangeStatus(ctx context.Context, group groups.Group) (groups.Group, error) {
 	ret := _m.Called(ctx, group)
@@ -485,6 +571,36 @@ func (_m *Repository) RetrieveHierarchy(ctx context.Context, id string, hm group
 	return r0, r1
 }
 
+// RetrievePathMetadata provides a mock function with given fields: ctx, path
+func (_m *Repository) RetrievePathMetadata(ctx context.Context, path string) ([]groups.Metadata, error) {
+	ret := _m.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePathMetadata")
+	}
+
+	var r0 []groups.Metadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]groups.Metadata, error)); ok {
+		return rf(ctx, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []groups.Metadata); ok {
+		r0 = rf(ctx, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]groups.Metadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveRole provides a mock function with given fields: ctx, roleID
 func (_m *Repository) RetrieveRole(ctx context.Context, roleID string) (roles.Role, error) {
 	ret := _m.Called(ctx, roleID)
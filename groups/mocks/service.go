@@ -85,6 +85,34 @@ func (_m *Service) AddRole(ctx context.Context, session authn.Session, entityID
 	return r0, r1
 }
 
+// ChangeParentGroup provides a mock function with given fields: ctx, session, id, parentID
+func (_m *Service) ChangeParentGroup(ctx context.Context, session authn.Session, id string, parentID string) (groups.Group, error) {
+	ret := _m.Called(ctx, session, id, parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeParentGroup")
+	}
+
+	var r0 groups.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) (groups.Group, error)); ok {
+		return rf(ctx, session, id, parentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) groups.Group); ok {
+		r0 = rf(ctx, session, id, parentID)
+	} else {
+		r0 = ret.Get(0).(groups.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, string) error); ok {
+		r1 = rf(ctx, session, id, parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateGroup provides a mock function with given fields: ctx, session, g
 func (_m *Service) CreateGroup(ctx context.Context, session authn.Session, g groups.Group) (groups.Group, []roles.RoleProvision, error) {
 	ret := _m.Called(ctx, session, g)
@@ -140,6 +168,34 @@ func (_m *Service) DeleteGroup(ctx context.Context, session authn.Session, id st
 	return r0
 }
 
+// DeleteGroupHierarchy provides a mock function with given fields: ctx, session, id, dryRun
+func (_m *Service) DeleteGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	ret := _m.Called(ctx, session, id, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGroupHierarchy")
+	}
+
+	var r0 groups.HierarchyChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bool) (groups.HierarchyChange, error)); ok {
+		return rf(ctx, session, id, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bool) groups.HierarchyChange); ok {
+		r0 = rf(ctx, session, id, dryRun)
+	} else {
+		r0 = ret.Get(0).(groups.HierarchyChange)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, bool) error); ok {
+		r1 = rf(ctx, session, id, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DisableGroup provides a mock function with given fields: ctx, session, id
 func (_m *Service) DisableGroup(ctx context.Context, session authn.Session, id string) (groups.Group, error) {
 	ret := _m.Called(ctx, session, id)
@@ -168,6 +224,34 @@ func (_m *Service) DisableGroup(ctx context.Context, session authn.Session, id s
 	return r0, r1
 }
 
+// DisableGroupHierarchy provides a mock function with given fields: ctx, session, id, dryRun
+func (_m *Service) DisableGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	ret := _m.Called(ctx, session, id, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableGroupHierarchy")
+	}
+
+	var r0 groups.HierarchyChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bool) (groups.HierarchyChange, error)); ok {
+		return rf(ctx, session, id, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bool) groups.HierarchyChange); ok {
+		r0 = rf(ctx, session, id, dryRun)
+	} else {
+		r0 = ret.Get(0).(groups.HierarchyChange)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, bool) error); ok {
+		r1 = rf(ctx, session, id, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EnableGroup provides a mock function with given fields: ctx, session, id
 func (_m *Service) EnableGroup(ctx context.Context, session authn.Session, id string) (groups.Group, error) {
 	ret := _m.Called(ctx, session, id)
@@ -196,6 +280,34 @@ func (_m *Service) EnableGroup(ctx context.Context, session authn.Session, id st
 	return r0, r1
 }
 
+// EnableGroupHierarchy provides a mock function with given fields: ctx, session, id, dryRun
+func (_m *Service) EnableGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	ret := _m.Called(ctx, session, id, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableGroupHierarchy")
+	}
+
+	var r0 groups.HierarchyChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bool) (groups.HierarchyChange, error)); ok {
+		return rf(ctx, session, id, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bool) groups.HierarchyChange); ok {
+		r0 = rf(ctx, session, id, dryRun)
+	} else {
+		r0 = ret.Get(0).(groups.HierarchyChange)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, bool) error); ok {
+		r1 = rf(ctx, session, id, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListAvailableActions provides a mock function with given fields: ctx, session
 func (_m *Service) ListAvailableActions(ctx context.Context, session authn.Session) ([]string, error) {
 	ret := _m.Called(ctx, session)
@@ -338,6 +450,34 @@ func (_m *Service) ListUserGroups(ctx context.Context, session authn.Session, us
 	return r0, r1
 }
 
+// MoveGroup provides a mock function with given fields: ctx, session, id, domainID
+func (_m *Service) MoveGroup(ctx context.Context, session authn.Session, id string, domainID string) (groups.Group, error) {
+	ret := _m.Called(ctx, session, id, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveGroup")
+	}
+
+	var r0 groups.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) (groups.Group, error)); ok {
+		return rf(ctx, session, id, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) groups.Group); ok {
+		r0 = rf(ctx, session, id, domainID)
+	} else {
+		r0 = ret.Get(0).(groups.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, string) error); ok {
+		r1 = rf(ctx, session, id, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveAllChildrenGroups provides a mock function with given fields: ctx, session, id
 func (_m *Service) RemoveAllChildrenGroups(ctx context.Context, session authn.Session, id string) error {
 	ret := _m.Called(ctx, session, id)
@@ -832,9 +972,9 @@ func (_m *Service) UpdateRoleName(ctx context.Context, session authn.Session, en
 	return r0, r1
 }
 
-// ViewGroup provides a mock function with given fields: ctx, session, id
-func (_m *Service) ViewGroup(ctx context.Context, session authn.Session, id string) (groups.Group, error) {
-	ret := _m.Called(ctx, session, id)
+// ViewGroup provides a mock function with given fields: ctx, session, id, effective
+func (_m *Service) ViewGroup(ctx context.Context, session authn.Session, id string, effective bool) (groups.Group, error) {
+	ret := _m.Called(ctx, session, id, effective)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ViewGroup")
@@ -842,17 +982,17 @@ func (_m *Service) ViewGroup(ctx context.Context, session authn.Session, id stri
 
 	var r0 groups.Group
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) (groups.Group, error)); ok {
-		return rf(ctx, session, id)
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bool) (groups.Group, error)); ok {
+		return rf(ctx, session, id, effective)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) groups.Group); ok {
-		r0 = rf(ctx, session, id)
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bool) groups.Group); ok {
+		r0 = rf(ctx, session, id, effective)
 	} else {
 		r0 = ret.Get(0).(groups.Group)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
-		r1 = rf(ctx, session, id)
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, bool) error); ok {
+		r1 = rf(ctx, session, id, effective)
 	} else {
 		r1 = ret.Error(1)
 	}
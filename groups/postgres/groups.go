@@ -568,6 +568,157 @@ func (repo groupRepository) Delete(ctx context.Context, groupID string) error {
 	return nil
 }
 
+func (repo groupRepository) ChangeDomain(ctx context.Context, g groups.Group) (groups.Group, error) {
+	q := `WITH root AS (
+			SELECT path FROM groups WHERE id = :id
+		)
+		UPDATE groups AS g SET
+			domain_id = :domain_id,
+			path = subpath(g.path, nlevel(root.path) - 1),
+			parent_id = CASE WHEN g.id = :id THEN NULL ELSE g.parent_id END,
+			updated_at = :updated_at,
+			updated_by = :updated_by
+		FROM root
+		WHERE g.path <@ root.path
+		RETURNING g.id, g.name, g.description, g.domain_id, COALESCE(g.parent_id, '') AS parent_id, g.metadata, g.created_at, g.updated_at, g.updated_by, g.status, g.path`
+
+	dbg, err := toDBGroup(g)
+	if err != nil {
+		return groups.Group{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbg)
+	if err != nil {
+		return groups.Group{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	items, err := repo.processRows(rows)
+	if err != nil {
+		return groups.Group{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	for _, item := range items {
+		if item.ID == g.ID {
+			return item, nil
+		}
+	}
+
+	return groups.Group{}, repoerr.ErrNotFound
+}
+
+func (repo groupRepository) ChangeParentGroup(ctx context.Context, g groups.Group) (groups.Group, error) {
+	q := `WITH root AS (
+			SELECT path FROM groups WHERE id = :id
+		), parent AS (
+			SELECT path FROM groups WHERE id = :parent_id AND domain_id = (SELECT domain_id FROM groups WHERE id = :id)
+		)
+		UPDATE groups AS g SET
+			path = parent.path || subpath(g.path, nlevel(root.path) - 1),
+			parent_id = CASE WHEN g.id = :id THEN :parent_id ELSE g.parent_id END,
+			updated_at = :updated_at,
+			updated_by = :updated_by
+		FROM root, parent
+		WHERE g.path <@ root.path AND NOT parent.path <@ root.path
+		RETURNING g.id, g.name, g.description, g.domain_id, COALESCE(g.parent_id, '') AS parent_id, g.metadata, g.created_at, g.updated_at, g.updated_by, g.status, g.path, nlevel(g.path) AS level`
+
+	dbg, err := toDBGroup(g)
+	if err != nil {
+		return groups.Group{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbg)
+	if err != nil {
+		return groups.Group{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	items, err := repo.processRows(rows)
+	if err != nil {
+		return groups.Group{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	for _, item := range items {
+		if item.ID == g.ID {
+			return item, nil
+		}
+	}
+
+	return groups.Group{}, repoerr.ErrNotFound
+}
+
+func (repo groupRepository) ChangeHierarchyStatus(ctx context.Context, g groups.Group) ([]groups.Group, error) {
+	q := `UPDATE groups AS g SET
+			status = :status,
+			updated_at = :updated_at,
+			updated_by = :updated_by
+		WHERE g.path <@ (SELECT path FROM groups WHERE id = :id) AND g.status <> :status AND g.status <> :deleted_status
+		RETURNING g.id, g.name, g.description, g.domain_id, COALESCE(g.parent_id, '') AS parent_id, g.metadata, g.created_at, g.updated_at, g.updated_by, g.status, g.path, nlevel(g.path) AS level`
+
+	dbg, err := toDBGroup(g)
+	if err != nil {
+		return []groups.Group{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	params := map[string]interface{}{
+		"id":             dbg.ID,
+		"status":         dbg.Status,
+		"updated_at":     dbg.UpdatedAt,
+		"updated_by":     dbg.UpdatedBy,
+		"deleted_status": groups.DeletedStatus,
+	}
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return []groups.Group{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	items, err := repo.processRows(rows)
+	if err != nil {
+		return []groups.Group{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return items, nil
+}
+
+func (repo groupRepository) RetrievePathMetadata(ctx context.Context, path string) ([]groups.Metadata, error) {
+	mds, err := RetrievePathMetadata(ctx, repo.db, path)
+	if err != nil {
+		return []groups.Metadata{}, err
+	}
+	ret := make([]groups.Metadata, len(mds))
+	for i, md := range mds {
+		ret[i] = md
+	}
+
+	return ret, nil
+}
+
+// RetrievePathMetadata retrieves metadata of all groups on the given path,
+// ordered from the root group. Services that keep a replica of the groups
+// table use it to resolve metadata inherited from the group hierarchy.
+func RetrievePathMetadata(ctx context.Context, db postgres.Database, path string) ([]map[string]interface{}, error) {
+	q := `SELECT metadata FROM groups WHERE path @> text2ltree(:path) ORDER BY nlevel(path)`
+
+	rows, err := db.NamedQueryContext(ctx, q, map[string]interface{}{"path": path})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	mds := []map[string]interface{}{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		md := map[string]interface{}{}
+		if data != nil {
+			if err := json.Unmarshal(data, &md); err != nil {
+				return nil, errors.Wrap(repoerr.ErrMalformedEntity, err)
+			}
+		}
+		mds = append(mds, md)
+	}
+
+	return mds, nil
+}
+
 func (repo groupRepository) RetrieveAllParentGroups(ctx context.Context, domainID, userID, groupID string, pm groups.PageMeta) (groups.Page, error) {
 	cGroup, err := repo.RetrieveByID(ctx, groupID)
 	if err != nil {
@@ -1894,6 +1894,286 @@ func TestRetrieveChildrenGroups(t *testing.T) {
 	}
 }
 
+func TestChangeParentGroup(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	a := saveGroup(t, repo, domainID, "")
+	b := saveGroup(t, repo, domainID, a.ID)
+	c := saveGroup(t, repo, domainID, b.ID)
+	s := saveGroup(t, repo, domainID, a.ID)
+	other := saveGroup(t, repo, testsutil.GenerateUUID(t), "")
+
+	cases := []struct {
+		desc     string
+		id       string
+		parentID string
+		paths    map[string]string
+		err      error
+	}{
+		{
+			desc:     "move group under sibling",
+			id:       b.ID,
+			parentID: s.ID,
+			paths: map[string]string{
+				b.ID: joinPath(a.ID, s.ID, b.ID),
+				c.ID: joinPath(a.ID, s.ID, b.ID, c.ID),
+				s.ID: joinPath(a.ID, s.ID),
+			},
+			err: nil,
+		},
+		{
+			desc:     "move group into its descendant",
+			id:       s.ID,
+			parentID: c.ID,
+			paths: map[string]string{
+				s.ID: joinPath(a.ID, s.ID),
+				c.ID: joinPath(a.ID, s.ID, b.ID, c.ID),
+			},
+			err: repoerr.ErrNotFound,
+		},
+		{
+			desc:     "move group under itself",
+			id:       b.ID,
+			parentID: b.ID,
+			paths: map[string]string{
+				b.ID: joinPath(a.ID, s.ID, b.ID),
+			},
+			err: repoerr.ErrNotFound,
+		},
+		{
+			desc:     "move group under group of another domain",
+			id:       b.ID,
+			parentID: other.ID,
+			paths: map[string]string{
+				b.ID: joinPath(a.ID, s.ID, b.ID),
+			},
+			err: repoerr.ErrNotFound,
+		},
+		{
+			desc:     "move non-existing group",
+			id:       testsutil.GenerateUUID(t),
+			parentID: a.ID,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := groups.Group{
+				ID:        tc.id,
+				Parent:    tc.parentID,
+				UpdatedBy: testsutil.GenerateUUID(t),
+				UpdatedAt: validTimestamp,
+			}
+			resp, err := repo.ChangeParentGroup(context.Background(), g)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.parentID, resp.Parent, fmt.Sprintf("%s: expected parent %s got %s\n", tc.desc, tc.parentID, resp.Parent))
+				assert.Equal(t, tc.paths[tc.id], resp.Path, fmt.Sprintf("%s: expected path %s got %s\n", tc.desc, tc.paths[tc.id], resp.Path))
+			}
+			for id, path := range tc.paths {
+				g, err := repo.RetrieveByID(context.Background(), id)
+				require.Nil(t, err, fmt.Sprintf("retrieve group unexpected error: %s", err))
+				assert.Equal(t, path, g.Path, fmt.Sprintf("%s: expected path %s got %s\n", tc.desc, path, g.Path))
+			}
+		})
+	}
+}
+
+func TestChangeDomain(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	newDomainID := testsutil.GenerateUUID(t)
+	a := saveGroup(t, repo, domainID, "")
+	b := saveGroup(t, repo, domainID, a.ID)
+	c := saveGroup(t, repo, domainID, b.ID)
+	s := saveGroup(t, repo, domainID, a.ID)
+
+	type location struct {
+		domain string
+		parent string
+		path   string
+	}
+
+	cases := []struct {
+		desc      string
+		id        string
+		domainID  string
+		locations map[string]location
+		err       error
+	}{
+		{
+			desc:     "move group to root",
+			id:       b.ID,
+			domainID: domainID,
+			locations: map[string]location{
+				b.ID: {domain: domainID, path: joinPath(b.ID)},
+				c.ID: {domain: domainID, parent: b.ID, path: joinPath(b.ID, c.ID)},
+				a.ID: {domain: domainID, path: joinPath(a.ID)},
+			},
+			err: nil,
+		},
+		{
+			desc:     "move group across domains",
+			id:       a.ID,
+			domainID: newDomainID,
+			locations: map[string]location{
+				a.ID: {domain: newDomainID, path: joinPath(a.ID)},
+				s.ID: {domain: newDomainID, parent: a.ID, path: joinPath(a.ID, s.ID)},
+				b.ID: {domain: domainID, path: joinPath(b.ID)},
+				c.ID: {domain: domainID, parent: b.ID, path: joinPath(b.ID, c.ID)},
+			},
+			err: nil,
+		},
+		{
+			desc:     "move child group across domains",
+			id:       c.ID,
+			domainID: newDomainID,
+			locations: map[string]location{
+				c.ID: {domain: newDomainID, path: joinPath(c.ID)},
+				b.ID: {domain: domainID, path: joinPath(b.ID)},
+			},
+			err: nil,
+		},
+		{
+			desc:     "move non-existing group",
+			id:       testsutil.GenerateUUID(t),
+			domainID: newDomainID,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := groups.Group{
+				ID:        tc.id,
+				Domain:    tc.domainID,
+				UpdatedBy: testsutil.GenerateUUID(t),
+				UpdatedAt: validTimestamp,
+			}
+			resp, err := repo.ChangeDomain(context.Background(), g)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.domainID, resp.Domain, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, tc.domainID, resp.Domain))
+				assert.Empty(t, resp.Parent, fmt.Sprintf("%s: moved group should not have parent", tc.desc))
+			}
+			for id, loc := range tc.locations {
+				g, err := repo.RetrieveByID(context.Background(), id)
+				require.Nil(t, err, fmt.Sprintf("retrieve group unexpected error: %s", err))
+				got := location{domain: g.Domain, parent: g.Parent, path: g.Path}
+				assert.Equal(t, loc, got, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, loc, got))
+			}
+		})
+	}
+}
+
+func TestChangeHierarchyStatus(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	a := saveGroup(t, repo, domainID, "")
+	b := saveGroup(t, repo, domainID, a.ID)
+	c := saveGroup(t, repo, domainID, b.ID)
+	d := saveGroup(t, repo, domainID, a.ID)
+	_, err := repo.ChangeStatus(context.Background(), groups.Group{ID: d.ID, Status: groups.DeletedStatus, UpdatedAt: validTimestamp})
+	require.Nil(t, err, fmt.Sprintf("change group status unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		status groups.Status
+		ids    []string
+	}{
+		{
+			desc:   "disable group hierarchy",
+			id:     a.ID,
+			status: groups.DisabledStatus,
+			ids:    []string{a.ID, b.ID, c.ID},
+		},
+		{
+			desc:   "disable disabled group hierarchy",
+			id:     a.ID,
+			status: groups.DisabledStatus,
+			ids:    nil,
+		},
+		{
+			desc:   "enable child group hierarchy",
+			id:     b.ID,
+			status: groups.EnabledStatus,
+			ids:    []string{b.ID, c.ID},
+		},
+		{
+			desc:   "enable partially enabled group hierarchy",
+			id:     a.ID,
+			status: groups.EnabledStatus,
+			ids:    []string{a.ID},
+		},
+		{
+			desc:   "change status of non-existing group hierarchy",
+			id:     testsutil.GenerateUUID(t),
+			status: groups.DisabledStatus,
+			ids:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := groups.Group{
+				ID:        tc.id,
+				Status:    tc.status,
+				UpdatedBy: testsutil.GenerateUUID(t),
+				UpdatedAt: validTimestamp,
+			}
+			resp, err := repo.ChangeHierarchyStatus(context.Background(), g)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.ElementsMatch(t, tc.ids, getIDs(resp), fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.ids, getIDs(resp)))
+			for _, g := range resp {
+				assert.Equal(t, tc.status, g.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, g.Status))
+			}
+		})
+	}
+
+	deleted, err := repo.RetrieveByID(context.Background(), d.ID)
+	require.Nil(t, err, fmt.Sprintf("retrieve group unexpected error: %s", err))
+	assert.Equal(t, groups.DeletedStatus, deleted.Status, "deleted group status should not change")
+}
+
+func saveGroup(t *testing.T, repo groups.Repository, domainID, parentID string) groups.Group {
+	g, err := repo.Save(context.Background(), groups.Group{
+		ID:        testsutil.GenerateUUID(t),
+		Domain:    domainID,
+		Parent:    parentID,
+		Name:      namegen.Generate(),
+		Metadata:  map[string]interface{}{},
+		CreatedAt: validTimestamp,
+		Status:    groups.EnabledStatus,
+	})
+	require.Nil(t, err, fmt.Sprintf("save group unexpected error: %s", err))
+
+	return g
+}
+
+func joinPath(ids ...string) string {
+	return strings.Join(ids, ".")
+}
+
 func getIDs(groups []groups.Group) []string {
 	var ids []string
 	for _, group := range groups {
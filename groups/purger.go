@@ -0,0 +1,62 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/domains"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/roles"
+)
+
+const purgeLimit = uint64(100)
+
+var _ domains.Purger = (*domainPurger)(nil)
+
+type domainPurger struct {
+	repo        Repository
+	provisioner roles.Provisioner
+}
+
+// NewDomainPurger returns the purger that removes the groups of the purged
+// domain, together with their roles and policies.
+func NewDomainPurger(repo Repository, provisioner roles.Provisioner) domains.Purger {
+	return &domainPurger{
+		repo:        repo,
+		provisioner: provisioner,
+	}
+}
+
+func (dp *domainPurger) PurgeDomain(ctx context.Context, domainID string) error {
+	for {
+		page, err := dp.repo.RetrieveAll(ctx, PageMeta{DomainID: domainID, Status: AllStatus, Limit: purgeLimit})
+		if err != nil {
+			return errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if len(page.Groups) == 0 {
+			return nil
+		}
+
+		ids := []string{}
+		filterDeletePolicies := []policies.Policy{}
+		for _, g := range page.Groups {
+			ids = append(ids, g.ID)
+			filterDeletePolicies = append(filterDeletePolicies,
+				policies.Policy{SubjectType: policies.GroupType, Subject: g.ID},
+				policies.Policy{ObjectType: policies.GroupType, Object: g.ID},
+			)
+		}
+		if err := dp.provisioner.RemoveEntitiesRoles(ctx, domainID, "", ids, filterDeletePolicies, nil); err != nil {
+			return errors.Wrap(svcerr.ErrDeletePolicies, err)
+		}
+		for _, id := range ids {
+			if err := dp.repo.Delete(ctx, id); err != nil {
+				return errors.Wrap(svcerr.ErrRemoveEntity, err)
+			}
+		}
+	}
+}
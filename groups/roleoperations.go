@@ -23,6 +23,7 @@ const (
 	OpRemoveAllChildrenGroups
 	OpListChildrenGroups
 	OpDeleteGroup
+	OpMoveGroup
 )
 
 var expectedOperations = []svcutil.Operation{
@@ -38,6 +39,7 @@ var expectedOperations = []svcutil.Operation{
 	OpRemoveAllChildrenGroups,
 	OpListChildrenGroups,
 	OpDeleteGroup,
+	OpMoveGroup,
 }
 
 var operationNames = []string{
@@ -53,6 +55,7 @@ var operationNames = []string{
 	"OpRemoveAllChildrenGroups",
 	"OpListChildrenGroups",
 	"OpDeleteGroup",
+	"OpMoveGroup",
 }
 
 func NewOperationPerm() svcutil.OperationPerm {
@@ -117,6 +120,7 @@ func NewOperationPermissionMap() map[svcutil.Operation]svcutil.Permission {
 		OpRemoveAllChildrenGroups: setChildPermission,
 		OpListChildrenGroups:      readPermission,
 		OpDeleteGroup:             deletePermission,
+		OpMoveGroup:               deletePermission,
 	}
 	return opPerm
 }
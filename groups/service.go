@@ -6,20 +6,32 @@ package groups
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/absmach/supermq"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/domains"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	pkgGroups "github.com/absmach/supermq/pkg/groups"
 	"github.com/absmach/supermq/pkg/policies"
 	"github.com/absmach/supermq/pkg/roles"
 )
 
-var ErrGroupIDs = errors.New("invalid group ids")
+var (
+	ErrGroupIDs        = errors.New("invalid group ids")
+	errSameDomain      = errors.New("group already belongs to the domain")
+	errDifferentDomain = errors.New("parent group belongs to a different domain")
+	errCyclicHierarchy = errors.New("parent group is a descendant of the group")
+	errMaxDepth        = errors.New("reached max nested depth")
+)
 
 type service struct {
 	repo       Repository
@@ -27,12 +39,13 @@ type service struct {
 	idProvider supermq.IDProvider
 	channels   grpcChannelsV1.ChannelsServiceClient
 	clients    grpcClientsV1.ClientsServiceClient
+	quotas     pkgDomains.Quotas
 
 	roles.ProvisionManageService
 }
 
 // NewService returns a new groups service implementation.
-func NewService(repo Repository, policy policies.Service, idp supermq.IDProvider, channels grpcChannelsV1.ChannelsServiceClient, clients grpcClientsV1.ClientsServiceClient, sidProvider supermq.IDProvider, availableActions []roles.Action, builtInRoles map[roles.BuiltInRoleName][]roles.Action) (Service, error) {
+func NewService(repo Repository, policy policies.Service, idp supermq.IDProvider, channels grpcChannelsV1.ChannelsServiceClient, clients grpcClientsV1.ClientsServiceClient, quotas pkgDomains.Quotas, sidProvider supermq.IDProvider, availableActions []roles.Action, builtInRoles map[roles.BuiltInRoleName][]roles.Action) (Service, error) {
 	rpms, err := roles.NewProvisionManageService(policies.GroupType, repo, policy, sidProvider, availableActions, builtInRoles)
 	if err != nil {
 		return service{}, err
@@ -43,6 +56,7 @@ func NewService(repo Repository, policy policies.Service, idp supermq.IDProvider
 		idProvider:             idp,
 		channels:               channels,
 		clients:                clients,
+		quotas:                 quotas,
 		ProvisionManageService: rpms,
 	}, nil
 }
@@ -60,7 +74,12 @@ func (svc service) CreateGroup(ctx context.Context, session smqauthn.Session, g
 	g.CreatedAt = time.Now()
 	g.Domain = session.DomainID
 
+	unlock, err := svc.lockQuota(ctx, session.DomainID, 1)
+	if err != nil {
+		return Group{}, []roles.RoleProvision{}, err
+	}
 	saved, err := svc.repo.Save(ctx, g)
+	unlock()
 	if err != nil {
 		return Group{}, []roles.RoleProvision{}, errors.Wrap(svcerr.ErrCreateEntity, err)
 	}
@@ -105,11 +124,23 @@ func (svc service) CreateGroup(ctx context.Context, session smqauthn.Session, g
 	return saved, nrps, nil
 }
 
-func (svc service) ViewGroup(ctx context.Context, session smqauthn.Session, id string) (Group, error) {
+func (svc service) ViewGroup(ctx context.Context, session smqauthn.Session, id string, effective bool) (Group, error) {
 	group, err := svc.repo.RetrieveByIDAndUser(ctx, session.DomainID, session.UserID, id)
 	if err != nil {
 		return Group{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
+	if effective && group.Parent != "" {
+		path := strings.Split(group.Path, ".")
+		mds, err := svc.repo.RetrievePathMetadata(ctx, strings.Join(path[:len(path)-1], "."))
+		if err != nil {
+			return Group{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		ancestors := make([]map[string]interface{}, len(mds))
+		for i, md := range mds {
+			ancestors[i] = md
+		}
+		group.Metadata = pkgGroups.InheritMetadata(ancestors, group.Metadata)
+	}
 
 	return group, nil
 }
@@ -465,6 +496,317 @@ func (svc service) DeleteGroup(ctx context.Context, session smqauthn.Session, id
 	return nil
 }
 
+// MoveGroup moves the group and all of its descendants to the target domain,
+// together with the clients and channels of the moved groups. The group is
+// detached from its parent group, and the connections of the moved clients and
+// channels are removed, since they can't connect to the source domain entities.
+func (svc service) MoveGroup(ctx context.Context, session smqauthn.Session, id, domainID string) (retGr Group, retErr error) {
+	group, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return Group{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if group.Domain == domainID {
+		return Group{}, errors.Wrap(svcerr.ErrMalformedEntity, errSameDomain)
+	}
+
+	hp, err := svc.repo.RetrieveHierarchy(ctx, id, HierarchyPageMeta{Direction: -1})
+	if err != nil {
+		return Group{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	ids := svc.getGroupIDs(hp.Groups)
+	unlock, err := svc.lockQuota(ctx, domainID, uint64(len(ids)))
+	if err != nil {
+		return Group{}, err
+	}
+	defer unlock()
+
+	var oldPolicies, newPolicies []policies.Policy
+	for _, gid := range ids {
+		oldPolicies = append(oldPolicies, policies.Policy{
+			Domain:      group.Domain,
+			SubjectType: policies.DomainType,
+			Subject:     group.Domain,
+			Relation:    policies.DomainRelation,
+			ObjectType:  policies.GroupType,
+			Object:      gid,
+		})
+		newPolicies = append(newPolicies, policies.Policy{
+			Domain:      domainID,
+			SubjectType: policies.DomainType,
+			Subject:     domainID,
+			Relation:    policies.DomainRelation,
+			ObjectType:  policies.GroupType,
+			Object:      gid,
+		})
+	}
+	if group.Parent != "" {
+		oldPolicies = append(oldPolicies, policies.Policy{
+			Domain:      group.Domain,
+			SubjectType: policies.GroupType,
+			Subject:     group.Parent,
+			Relation:    policies.ParentGroupRelation,
+			ObjectType:  policies.GroupType,
+			Object:      id,
+		})
+	}
+
+	// The group can be related to a single domain only, so the old
+	// relations are removed before the new ones are added.
+	if err := svc.policy.DeletePolicies(ctx, oldPolicies); err != nil {
+		return Group{}, errors.Wrap(svcerr.ErrDeletePolicies, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.policy.AddPolicies(ctx, oldPolicies); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+	if err := svc.policy.AddPolicies(ctx, newPolicies); err != nil {
+		return Group{}, errors.Wrap(svcerr.ErrAddPolicies, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.policy.DeletePolicies(ctx, newPolicies); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+	if err := svc.MoveEntitiesRoles(ctx, group.Domain, domainID, ids); err != nil {
+		return Group{}, err
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.MoveEntitiesRoles(ctx, domainID, group.Domain, ids); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+
+	moved, err := svc.repo.ChangeDomain(ctx, Group{ID: id, Domain: domainID, UpdatedBy: session.UserID, UpdatedAt: time.Now()})
+	if err != nil {
+		return Group{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.restoreDomain(ctx, group); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+
+	cliRes, err := svc.clients.MoveParentGroupClients(ctx, &grpcClientsV1.MoveParentGroupClientsReq{ParentGroupIds: ids, DomainId: domainID, UpdatedBy: session.UserID})
+	if err != nil {
+		return Group{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if _, errRollback := svc.clients.MoveParentGroupClients(ctx, &grpcClientsV1.MoveParentGroupClientsReq{ParentGroupIds: ids, DomainId: group.Domain, UpdatedBy: session.UserID}); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+
+	// Channels are moved last, since the connections of the moved clients
+	// are removed together with the connections of the moved channels.
+	if _, err := svc.channels.MoveParentGroupChannels(ctx, &grpcChannelsV1.MoveParentGroupChannelsReq{ParentGroupIds: ids, ClientIds: cliRes.GetIds(), DomainId: domainID, UpdatedBy: session.UserID}); err != nil {
+		return Group{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return moved, nil
+}
+
+// restoreDomain moves the group subtree back to the domain of the given group
+// and restores the link to its parent group.
+func (svc service) restoreDomain(ctx context.Context, g Group) error {
+	if _, err := svc.repo.ChangeDomain(ctx, Group{ID: g.ID, Domain: g.Domain, UpdatedBy: g.UpdatedBy, UpdatedAt: g.UpdatedAt}); err != nil {
+		return err
+	}
+	if g.Parent == "" {
+		return nil
+	}
+	_, err := svc.repo.ChangeParentGroup(ctx, Group{ID: g.ID, Parent: g.Parent, UpdatedBy: g.UpdatedBy, UpdatedAt: g.UpdatedAt})
+
+	return err
+}
+
+// ChangeParentGroup moves the group together with all of its descendants
+// under the new parent group of the same domain.
+func (svc service) ChangeParentGroup(ctx context.Context, session smqauthn.Session, id, parentID string) (retGr Group, retErr error) {
+	group, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return Group{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if group.Parent == parentID {
+		return Group{}, errors.Wrap(svcerr.ErrConflict, fmt.Errorf("%s group already have parent %s", group.ID, parentID))
+	}
+	parent, err := svc.repo.RetrieveByID(ctx, parentID)
+	if err != nil {
+		return Group{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if parent.Domain != group.Domain {
+		return Group{}, errors.Wrap(svcerr.ErrMalformedEntity, errDifferentDomain)
+	}
+	parentPath := strings.Split(parent.Path, ".")
+	if slices.Contains(parentPath, group.ID) {
+		return Group{}, errors.Wrap(svcerr.ErrMalformedEntity, errCyclicHierarchy)
+	}
+
+	hp, err := svc.repo.RetrieveHierarchy(ctx, id, HierarchyPageMeta{Direction: -1})
+	if err != nil {
+		return Group{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	groupDepth := len(strings.Split(group.Path, "."))
+	for _, g := range hp.Groups {
+		depth := len(parentPath) + len(strings.Split(g.Path, ".")) - groupDepth + 1
+		if depth > MaxPathLength {
+			return Group{}, errors.Wrap(svcerr.ErrMalformedEntity, errMaxDepth)
+		}
+	}
+
+	newPolicies := []policies.Policy{{
+		Domain:      group.Domain,
+		SubjectType: policies.GroupType,
+		Subject:     parentID,
+		Relation:    policies.ParentGroupRelation,
+		ObjectType:  policies.GroupType,
+		Object:      id,
+	}}
+	if err := svc.policy.AddPolicies(ctx, newPolicies); err != nil {
+		return Group{}, errors.Wrap(svcerr.ErrAddPolicies, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := svc.policy.DeletePolicies(ctx, newPolicies); errRollback != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+	if group.Parent != "" {
+		oldPolicies := []policies.Policy{{
+			Domain:      group.Domain,
+			SubjectType: policies.GroupType,
+			Subject:     group.Parent,
+			Relation:    policies.ParentGroupRelation,
+			ObjectType:  policies.GroupType,
+			Object:      id,
+		}}
+		if err := svc.policy.DeletePolicies(ctx, oldPolicies); err != nil {
+			return Group{}, errors.Wrap(svcerr.ErrDeletePolicies, err)
+		}
+		defer func() {
+			if retErr != nil {
+				if errRollback := svc.policy.AddPolicies(ctx, oldPolicies); errRollback != nil {
+					retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+				}
+			}
+		}()
+	}
+
+	group, err = svc.repo.ChangeParentGroup(ctx, Group{ID: id, Parent: parentID, UpdatedBy: session.UserID, UpdatedAt: time.Now()})
+	if err != nil {
+		return Group{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return group, nil
+}
+
+func (svc service) EnableGroupHierarchy(ctx context.Context, session smqauthn.Session, id string, dryRun bool) (HierarchyChange, error) {
+	return svc.changeHierarchyStatus(ctx, session, id, EnabledStatus, dryRun)
+}
+
+func (svc service) DisableGroupHierarchy(ctx context.Context, session smqauthn.Session, id string, dryRun bool) (HierarchyChange, error) {
+	return svc.changeHierarchyStatus(ctx, session, id, DisabledStatus, dryRun)
+}
+
+// DeleteGroupHierarchy deletes the group and all of its descendants, starting
+// from the deepest ones.
+func (svc service) DeleteGroupHierarchy(ctx context.Context, session smqauthn.Session, id string, dryRun bool) (HierarchyChange, error) {
+	hp, err := svc.repo.RetrieveHierarchy(ctx, id, HierarchyPageMeta{Direction: -1})
+	if err != nil {
+		return HierarchyChange{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	gps := hp.Groups
+	sort.SliceStable(gps, func(i, j int) bool {
+		return gps[i].Level > gps[j].Level
+	})
+	hc, err := svc.hierarchyChange(ctx, gps, dryRun)
+	if err != nil {
+		return HierarchyChange{}, err
+	}
+	if dryRun {
+		return hc, nil
+	}
+
+	for _, gid := range hc.Groups {
+		if err := svc.DeleteGroup(ctx, session, gid); err != nil {
+			return HierarchyChange{}, err
+		}
+	}
+
+	return hc, nil
+}
+
+func (svc service) changeHierarchyStatus(ctx context.Context, session smqauthn.Session, id string, status Status, dryRun bool) (HierarchyChange, error) {
+	hp, err := svc.repo.RetrieveHierarchy(ctx, id, HierarchyPageMeta{Direction: -1})
+	if err != nil {
+		return HierarchyChange{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	var gps []Group
+	for _, g := range hp.Groups {
+		if g.Status != status && g.Status != DeletedStatus {
+			gps = append(gps, g)
+		}
+	}
+	if len(gps) == 0 {
+		return HierarchyChange{}, errors.ErrStatusAlreadyAssigned
+	}
+	if !dryRun {
+		gps, err = svc.repo.ChangeHierarchyStatus(ctx, Group{ID: id, Status: status, UpdatedBy: session.UserID, UpdatedAt: time.Now()})
+		if err != nil {
+			return HierarchyChange{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+
+	return svc.hierarchyChange(ctx, gps, dryRun)
+}
+
+// hierarchyChange lists the groups and the clients and channels that belong
+// to them.
+func (svc service) hierarchyChange(ctx context.Context, gps []Group, dryRun bool) (HierarchyChange, error) {
+	hc := HierarchyChange{
+		DryRun:   dryRun,
+		Groups:   []string{},
+		Clients:  []string{},
+		Channels: []string{},
+	}
+	for _, g := range gps {
+		hc.Groups = append(hc.Groups, g.ID)
+		clients, err := svc.policy.ListAllObjects(ctx, policies.Policy{
+			SubjectType: policies.GroupType,
+			Subject:     g.ID,
+			Permission:  policies.ParentGroupRelation,
+			ObjectType:  policies.ClientType,
+		})
+		if err != nil {
+			return HierarchyChange{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		hc.Clients = append(hc.Clients, clients.Policies...)
+		channels, err := svc.policy.ListAllObjects(ctx, policies.Policy{
+			SubjectType: policies.GroupType,
+			Subject:     g.ID,
+			Permission:  policies.ParentGroupRelation,
+			ObjectType:  policies.ChannelType,
+		})
+		if err != nil {
+			return HierarchyChange{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		hc.Channels = append(hc.Channels, channels.Policies...)
+	}
+
+	return hc, nil
+}
+
 func (svc service) filterAllowedGroupIDsOfUserID(ctx context.Context, userID, permission string, groupIDs []string) ([]string, error) {
 	var ids []string
 	allowedIDs, err := svc.listAllGroupsOfUserID(ctx, userID, permission)
@@ -507,3 +849,31 @@ func (svc service) changeGroupStatus(ctx context.Context, session smqauthn.Sessi
 	group.UpdatedBy = session.UserID
 	return svc.repo.ChangeStatus(ctx, group)
 }
+
+// lockQuota locks the domain quotas and checks that the requested groups fit
+// into the domain groups quota. The returned unlock function is called once
+// the groups are saved, so the concurrent requests can't exceed the quota.
+func (svc service) lockQuota(ctx context.Context, domainID string, requested uint64) (func(), error) {
+	q, err := svc.quotas.RetrieveQuotas(ctx, domainID)
+	if err != nil {
+		return nil, err
+	}
+	if q.Groups == 0 {
+		return func() {}, nil
+	}
+	unlock, err := svc.quotas.LockQuotas(ctx, domainID)
+	if err != nil {
+		return nil, err
+	}
+	page, err := svc.repo.RetrieveAll(ctx, PageMeta{DomainID: domainID, Status: AllStatus, Limit: 1})
+	if err != nil {
+		unlock()
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if err := domains.CheckQuota(q.Groups, page.Total, requested); err != nil {
+		unlock()
+		return nil, err
+	}
+
+	return unlock, nil
+}
@@ -6,6 +6,7 @@ package groups_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,11 +16,13 @@ import (
 	apiutil "github.com/absmach/supermq/api/http/util"
 	chmocks "github.com/absmach/supermq/channels/mocks"
 	climocks "github.com/absmach/supermq/clients/mocks"
+	"github.com/absmach/supermq/domains"
 	"github.com/absmach/supermq/groups"
 	"github.com/absmach/supermq/groups/mocks"
 	"github.com/absmach/supermq/internal/testsutil"
 	"github.com/absmach/supermq/pkg/authn"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
+	dmocks "github.com/absmach/supermq/pkg/domains/mocks"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
@@ -76,6 +79,7 @@ var (
 	policies *policymocks.Service
 	channels *chmocks.ChannelsServiceClient
 	clients  *climocks.ClientsServiceClient
+	dquotas  *dmocks.Quotas
 )
 
 func newService(t *testing.T) groups.Service {
@@ -83,11 +87,13 @@ func newService(t *testing.T) groups.Service {
 	policies = new(policymocks.Service)
 	channels = new(chmocks.ChannelsServiceClient)
 	clients = new(climocks.ClientsServiceClient)
+	dquotas = new(dmocks.Quotas)
+	dquotas.On("RetrieveQuotas", mock.Anything, mock.Anything).Return(domains.Quotas{}, nil).Maybe()
 	availableActions := []roles.Action{}
 	builtInRoles := map[roles.BuiltInRoleName][]roles.Action{
 		groups.BuiltInRoleAdmin: availableActions,
 	}
-	svc, err := groups.NewService(repo, policies, idProvider, channels, clients, idProvider, availableActions, builtInRoles)
+	svc, err := groups.NewService(repo, policies, idProvider, channels, clients, dquotas, idProvider, availableActions, builtInRoles)
 	assert.Nil(t, err, fmt.Sprintf(" Unexpected error  while creating service %v", err))
 	return svc
 }
@@ -222,22 +228,144 @@ func TestCreateGroup(t *testing.T) {
 	}
 }
 
+func TestCreateGroupQuota(t *testing.T) {
+	svc := newService(t)
+	dquotas.ExpectedCalls = nil
+
+	cases := []struct {
+		desc           string
+		quotas         domains.Quotas
+		quotasErr      error
+		lockErr        error
+		total          uint64
+		retrieveAllErr error
+		err            error
+	}{
+		{
+			desc:   "create group within quota",
+			quotas: domains.Quotas{Groups: 2},
+			total:  1,
+			err:    nil,
+		},
+		{
+			desc:   "create group with quota already reached",
+			quotas: domains.Quotas{Groups: 1},
+			total:  1,
+			err:    svcerr.ErrQuotaExceeded,
+		},
+		{
+			desc:      "create group with failed to retrieve quotas",
+			quotasErr: svcerr.ErrViewEntity,
+			err:       svcerr.ErrViewEntity,
+		},
+		{
+			desc:           "create group with failed to count groups",
+			quotas:         domains.Quotas{Groups: 2},
+			retrieveAllErr: repoerr.ErrViewEntity,
+			err:            svcerr.ErrViewEntity,
+		},
+		{
+			desc:    "create group with failed to lock quotas",
+			quotas:  domains.Quotas{Groups: 2},
+			lockErr: svcerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			unlocked := false
+			quotasCall := dquotas.On("RetrieveQuotas", context.Background(), validSession.DomainID).Return(tc.quotas, tc.quotasErr)
+			lockCall := dquotas.On("LockQuotas", context.Background(), validSession.DomainID).Return(func() { unlocked = true }, tc.lockErr)
+			repoCall := repo.On("RetrieveAll", context.Background(), mock.Anything).Return(groups.Page{PageMeta: groups.PageMeta{Total: tc.total}}, tc.retrieveAllErr)
+			repoCall1 := repo.On("Save", context.Background(), mock.Anything).Return(validGroup, nil)
+			policyCall := policies.On("AddPolicies", context.Background(), mock.Anything).Return(nil)
+			repoCall2 := repo.On("AddRoles", context.Background(), mock.Anything).Return([]roles.RoleProvision{}, nil)
+			_, _, err := svc.CreateGroup(context.Background(), validSession, validGroup)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v but got %v", tc.err, err))
+			if tc.quotas.Groups > 0 && tc.lockErr == nil {
+				assert.True(t, unlocked, "expected domain quotas to be unlocked")
+			}
+			quotasCall.Unset()
+			lockCall.Unset()
+			repoCall.Unset()
+			repoCall1.Unset()
+			policyCall.Unset()
+			repoCall2.Unset()
+		})
+	}
+}
+
 func TestViewGroup(t *testing.T) {
 	svc := newService(t)
 
+	rootID := testsutil.GenerateUUID(t)
+	nestedGroup := validGroup
+	nestedGroup.Parent = parentGroupID
+	nestedGroup.Path = rootID + "." + parentGroupID + "." + validGroup.ID
+	nestedGroup.Metadata = groups.Metadata{"location": "room", "site": map[string]interface{}{"floor": 2}, "firmware": nil}
+	effectiveGroup := nestedGroup
+	effectiveGroup.Metadata = groups.Metadata{
+		"location": "room",
+		"timezone": "UTC",
+		"site":     map[string]interface{}{"name": "plant", "floor": 2},
+	}
+	pathMetadata := []groups.Metadata{
+		{"location": "site", "timezone": "UTC", "firmware": "stable"},
+		{"site": map[string]interface{}{"name": "plant", "floor": 1}},
+	}
+
 	cases := []struct {
-		desc     string
-		session  smqauthn.Session
-		id       string
-		repoResp groups.Group
-		repoErr  error
-		err      error
+		desc            string
+		session         smqauthn.Session
+		id              string
+		effective       bool
+		repoResp        groups.Group
+		repoErr         error
+		pathMetadata    []groups.Metadata
+		pathMetadataErr error
+		resp            groups.Group
+		err             error
 	}{
 		{
 			desc:     "view group successfully",
 			id:       validGroup.ID,
 			session:  validSession,
 			repoResp: validGroup,
+			resp:     validGroup,
+		},
+		{
+			desc:     "view group with raw metadata successfully",
+			id:       nestedGroup.ID,
+			session:  validSession,
+			repoResp: nestedGroup,
+			resp:     nestedGroup,
+		},
+		{
+			desc:         "view group with effective metadata successfully",
+			id:           nestedGroup.ID,
+			session:      validSession,
+			effective:    true,
+			repoResp:     nestedGroup,
+			pathMetadata: pathMetadata,
+			resp:         effectiveGroup,
+		},
+		{
+			desc:      "view root group with effective metadata successfully",
+			id:        validGroup.ID,
+			session:   validSession,
+			effective: true,
+			repoResp:  validGroup,
+			resp:      validGroup,
+		},
+		{
+			desc:            "view group with effective metadata with failed to retrieve path metadata",
+			id:              nestedGroup.ID,
+			session:         validSession,
+			effective:       true,
+			repoResp:        nestedGroup,
+			pathMetadataErr: repoerr.ErrViewEntity,
+			err:             svcerr.ErrViewEntity,
 		},
 		{
 			desc:    "view group with failed to retrieve",
@@ -251,14 +379,16 @@ func TestViewGroup(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			repoCall := repo.On("RetrieveByIDAndUser", context.Background(), tc.session.DomainID, tc.session.UserID, tc.id).Return(tc.repoResp, tc.repoErr)
-			got, err := svc.ViewGroup(context.Background(), validSession, tc.id)
+			repoCall1 := repo.On("RetrievePathMetadata", context.Background(), rootID+"."+parentGroupID).Return(tc.pathMetadata, tc.pathMetadataErr)
+			got, err := svc.ViewGroup(context.Background(), validSession, tc.id, tc.effective)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
 			if err == nil {
-				assert.Equal(t, tc.repoResp, got)
+				assert.Equal(t, tc.resp, got)
 				ok := repo.AssertCalled(t, "RetrieveByIDAndUser", context.Background(), tc.session.DomainID, tc.session.UserID, tc.id)
 				assert.True(t, ok, fmt.Sprintf("RetrieveByIDAndUser was not called on %s", tc.desc))
 			}
 			repoCall.Unset()
+			repoCall1.Unset()
 		})
 	}
 }
@@ -1279,3 +1409,486 @@ func TestDeleteGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestMoveGroup(t *testing.T) {
+	svc := newService(t)
+
+	domainID := testsutil.GenerateUUID(t)
+	sourceGroup := validGroup
+	sourceGroup.Domain = validID
+	sourceGroup.Parent = parentGroupID
+	movedGroup := validGroup
+	movedGroup.Domain = domainID
+	subtree := []groups.Group{sourceGroup, {ID: childGroupID, Parent: sourceGroup.ID, Domain: validID}}
+	subtreeIDs := []string{sourceGroup.ID, childGroupID}
+
+	cases := []struct {
+		desc              string
+		id                string
+		domainID          string
+		retrieveByIDResp  groups.Group
+		retrieveByIDErr   error
+		hierarchyResp     groups.HierarchyPage
+		hierarchyErr      error
+		addPoliciesErr    error
+		deletePoliciesErr error
+		changeDomainResp  groups.Group
+		changeDomainErr   error
+		moveClientsErr    error
+		moveChannelsErr   error
+		resp              groups.Group
+		err               error
+	}{
+		{
+			desc:             "move group successfully",
+			id:               sourceGroup.ID,
+			domainID:         domainID,
+			retrieveByIDResp: sourceGroup,
+			hierarchyResp:    groups.HierarchyPage{Groups: subtree},
+			changeDomainResp: movedGroup,
+			resp:             movedGroup,
+		},
+		{
+			desc:             "move group to the same domain",
+			id:               sourceGroup.ID,
+			domainID:         validID,
+			retrieveByIDResp: sourceGroup,
+			err:              svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:            "move non-existing group",
+			id:              testsutil.GenerateUUID(t),
+			domainID:        domainID,
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrViewEntity,
+		},
+		{
+			desc:             "move group with failed to retrieve hierarchy",
+			id:               sourceGroup.ID,
+			domainID:         domainID,
+			retrieveByIDResp: sourceGroup,
+			hierarchyErr:     repoerr.ErrViewEntity,
+			err:              svcerr.ErrViewEntity,
+		},
+		{
+			desc:              "move group with failed to delete policies",
+			id:                sourceGroup.ID,
+			domainID:          domainID,
+			retrieveByIDResp:  sourceGroup,
+			hierarchyResp:     groups.HierarchyPage{Groups: subtree},
+			deletePoliciesErr: svcerr.ErrAuthorization,
+			err:               svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:             "move group with failed to add policies",
+			id:               sourceGroup.ID,
+			domainID:         domainID,
+			retrieveByIDResp: sourceGroup,
+			hierarchyResp:    groups.HierarchyPage{Groups: subtree},
+			addPoliciesErr:   svcerr.ErrAuthorization,
+			err:              svcerr.ErrAddPolicies,
+		},
+		{
+			desc:             "move group with failed to change domain",
+			id:               sourceGroup.ID,
+			domainID:         domainID,
+			retrieveByIDResp: sourceGroup,
+			hierarchyResp:    groups.HierarchyPage{Groups: subtree},
+			changeDomainErr:  repoerr.ErrNotFound,
+			err:              svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:             "move group with failed to move clients",
+			id:               sourceGroup.ID,
+			domainID:         domainID,
+			retrieveByIDResp: sourceGroup,
+			hierarchyResp:    groups.HierarchyPage{Groups: subtree},
+			changeDomainResp: movedGroup,
+			moveClientsErr:   svcerr.ErrQuotaExceeded,
+			err:              svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:             "move group with failed to move channels",
+			id:               sourceGroup.ID,
+			domainID:         domainID,
+			retrieveByIDResp: sourceGroup,
+			hierarchyResp:    groups.HierarchyPage{Groups: subtree},
+			changeDomainResp: movedGroup,
+			moveChannelsErr:  svcerr.ErrQuotaExceeded,
+			err:              svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByID", context.Background(), tc.id).Return(tc.retrieveByIDResp, tc.retrieveByIDErr)
+			repoCall1 := repo.On("RetrieveHierarchy", context.Background(), tc.id, groups.HierarchyPageMeta{Direction: -1}).Return(tc.hierarchyResp, tc.hierarchyErr)
+			repoCall2 := repo.On("RetrieveAll", context.Background(), mock.Anything).Return(groups.Page{}, nil)
+			policyCall := policies.On("AddPolicies", context.Background(), mock.Anything).Return(tc.addPoliciesErr)
+			policyCall1 := policies.On("DeletePolicies", context.Background(), mock.Anything).Return(tc.deletePoliciesErr)
+			repoCall3 := repo.On("RetrieveEntitiesRolesActionsMembers", context.Background(), subtreeIDs).Return([]roles.EntityActionRole{}, []roles.EntityMemberRole{}, nil)
+			repoCall4 := repo.On("ChangeDomain", context.Background(), mock.Anything).Return(tc.changeDomainResp, tc.changeDomainErr)
+			repoCall5 := repo.On("ChangeParentGroup", context.Background(), mock.Anything).Return(sourceGroup, nil)
+			svcCall := clients.On("MoveParentGroupClients", context.Background(), mock.Anything).Return(&grpcClientsV1.MoveParentGroupClientsRes{}, tc.moveClientsErr)
+			svcCall1 := channels.On("MoveParentGroupChannels", context.Background(), mock.Anything).Return(&grpcChannelsV1.MoveParentGroupChannelsRes{}, tc.moveChannelsErr)
+			g, err := svc.MoveGroup(context.Background(), validSession, tc.id, tc.domainID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, g, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, g))
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			svcCall.Unset()
+			svcCall1.Unset()
+			policyCall.Unset()
+			policyCall1.Unset()
+			repoCall3.Unset()
+			repoCall4.Unset()
+			repoCall5.Unset()
+		})
+	}
+}
+
+func TestChangeParentGroup(t *testing.T) {
+	svc := newService(t)
+
+	oldParentID := testsutil.GenerateUUID(t)
+	newParentID := testsutil.GenerateUUID(t)
+	group := validGroup
+	group.Domain = validID
+	group.Parent = oldParentID
+	group.Path = oldParentID + "." + group.ID
+	rootGroup := group
+	rootGroup.Parent = ""
+	rootGroup.Path = group.ID
+	newParent := groups.Group{ID: newParentID, Domain: validID, Path: newParentID}
+	descendant := groups.Group{ID: childGroupID, Parent: group.ID, Domain: validID, Path: group.Path + "." + childGroupID}
+	movedGroup := group
+	movedGroup.Parent = newParentID
+	movedGroup.Path = newParentID + "." + group.ID
+
+	deepPath := strings.Repeat(newParentID+".", groups.MaxPathLength-1) + newParentID
+
+	cases := []struct {
+		desc               string
+		id                 string
+		parentID           string
+		retrieveByIDResp   groups.Group
+		retrieveByIDErr    error
+		retrieveParentResp groups.Group
+		retrieveParentErr  error
+		hierarchyResp      groups.HierarchyPage
+		hierarchyErr       error
+		addPoliciesErr     error
+		deletePoliciesErr  error
+		changeParentResp   groups.Group
+		changeParentErr    error
+		resp               groups.Group
+		err                error
+	}{
+		{
+			desc:               "change parent group successfully",
+			id:                 group.ID,
+			parentID:           newParentID,
+			retrieveByIDResp:   group,
+			retrieveParentResp: newParent,
+			hierarchyResp:      groups.HierarchyPage{Groups: []groups.Group{group, descendant}},
+			changeParentResp:   movedGroup,
+			resp:               movedGroup,
+		},
+		{
+			desc:               "change parent group of root group successfully",
+			id:                 rootGroup.ID,
+			parentID:           newParentID,
+			retrieveByIDResp:   rootGroup,
+			retrieveParentResp: newParent,
+			hierarchyResp:      groups.HierarchyPage{Groups: []groups.Group{rootGroup}},
+			changeParentResp:   movedGroup,
+			resp:               movedGroup,
+		},
+		{
+			desc:             "change parent group to the current parent group",
+			id:               group.ID,
+			parentID:         oldParentID,
+			retrieveByIDResp: group,
+			err:              svcerr.ErrConflict,
+		},
+		{
+			desc:            "change parent group of non-existing group",
+			id:              group.ID,
+			parentID:        newParentID,
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrViewEntity,
+		},
+		{
+			desc:              "change parent group to non-existing parent group",
+			id:                group.ID,
+			parentID:          newParentID,
+			retrieveByIDResp:  group,
+			retrieveParentErr: repoerr.ErrNotFound,
+			err:               svcerr.ErrViewEntity,
+		},
+		{
+			desc:               "change parent group to parent group from another domain",
+			id:                 group.ID,
+			parentID:           newParentID,
+			retrieveByIDResp:   group,
+			retrieveParentResp: groups.Group{ID: newParentID, Domain: testsutil.GenerateUUID(t), Path: newParentID},
+			err:                svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:               "change parent group to descendant group",
+			id:                 group.ID,
+			parentID:           newParentID,
+			retrieveByIDResp:   group,
+			retrieveParentResp: groups.Group{ID: newParentID, Domain: validID, Path: group.Path + "." + newParentID},
+			err:                svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:               "change parent group with exceeded max depth",
+			id:                 group.ID,
+			parentID:           newParentID,
+			retrieveByIDResp:   group,
+			retrieveParentResp: groups.Group{ID: newParentID, Domain: validID, Path: deepPath},
+			hierarchyResp:      groups.HierarchyPage{Groups: []groups.Group{group, descendant}},
+			err:                svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:               "change parent group with failed to retrieve hierarchy",
+			id:                 group.ID,
+			parentID:           newParentID,
+			retrieveByIDResp:   group,
+			retrieveParentResp: newParent,
+			hierarchyErr:       repoerr.ErrViewEntity,
+			err:                svcerr.ErrViewEntity,
+		},
+		{
+			desc:               "change parent group with failed to add policies",
+			id:                 group.ID,
+			parentID:           newParentID,
+			retrieveByIDResp:   group,
+			retrieveParentResp: newParent,
+			hierarchyResp:      groups.HierarchyPage{Groups: []groups.Group{group, descendant}},
+			addPoliciesErr:     svcerr.ErrAuthorization,
+			err:                svcerr.ErrAddPolicies,
+		},
+		{
+			desc:               "change parent group with failed to delete policies",
+			id:                 group.ID,
+			parentID:           newParentID,
+			retrieveByIDResp:   group,
+			retrieveParentResp: newParent,
+			hierarchyResp:      groups.HierarchyPage{Groups: []groups.Group{group, descendant}},
+			deletePoliciesErr:  svcerr.ErrAuthorization,
+			err:                svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:               "change parent group with failed to change parent group",
+			id:                 group.ID,
+			parentID:           newParentID,
+			retrieveByIDResp:   group,
+			retrieveParentResp: newParent,
+			hierarchyResp:      groups.HierarchyPage{Groups: []groups.Group{group, descendant}},
+			changeParentErr:    repoerr.ErrNotFound,
+			err:                svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByID", context.Background(), tc.id).Return(tc.retrieveByIDResp, tc.retrieveByIDErr)
+			repoCall1 := repo.On("RetrieveByID", context.Background(), tc.parentID).Return(tc.retrieveParentResp, tc.retrieveParentErr)
+			repoCall2 := repo.On("RetrieveHierarchy", context.Background(), tc.id, groups.HierarchyPageMeta{Direction: -1}).Return(tc.hierarchyResp, tc.hierarchyErr)
+			policyCall := policies.On("AddPolicies", context.Background(), mock.Anything).Return(tc.addPoliciesErr)
+			policyCall1 := policies.On("DeletePolicies", context.Background(), mock.Anything).Return(tc.deletePoliciesErr)
+			repoCall3 := repo.On("ChangeParentGroup", context.Background(), mock.Anything).Return(tc.changeParentResp, tc.changeParentErr)
+			g, err := svc.ChangeParentGroup(context.Background(), validSession, tc.id, tc.parentID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, g, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, g))
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			policyCall.Unset()
+			policyCall1.Unset()
+			repoCall3.Unset()
+		})
+	}
+}
+
+func TestDisableGroupHierarchy(t *testing.T) {
+	svc := newService(t)
+
+	entityID := testsutil.GenerateUUID(t)
+	root := validGroup
+	descendant := groups.Group{ID: childGroupID, Parent: root.ID, Status: groups.EnabledStatus}
+	disabledRoot := root
+	disabledRoot.Status = groups.DisabledStatus
+	disabledDescendant := descendant
+	disabledDescendant.Status = groups.DisabledStatus
+
+	cases := []struct {
+		desc             string
+		id               string
+		dryRun           bool
+		hierarchyResp    groups.HierarchyPage
+		hierarchyErr     error
+		changeStatusResp []groups.Group
+		changeStatusErr  error
+		listObjectsErr   error
+		resp             groups.HierarchyChange
+		err              error
+	}{
+		{
+			desc:             "disable group hierarchy successfully",
+			id:               root.ID,
+			hierarchyResp:    groups.HierarchyPage{Groups: []groups.Group{root, descendant}},
+			changeStatusResp: []groups.Group{disabledRoot, disabledDescendant},
+			resp: groups.HierarchyChange{
+				Groups:   []string{root.ID, childGroupID},
+				Clients:  []string{entityID, entityID},
+				Channels: []string{entityID, entityID},
+			},
+		},
+		{
+			desc:          "preview disable group hierarchy successfully",
+			id:            root.ID,
+			dryRun:        true,
+			hierarchyResp: groups.HierarchyPage{Groups: []groups.Group{root, disabledDescendant}},
+			resp: groups.HierarchyChange{
+				DryRun:   true,
+				Groups:   []string{root.ID},
+				Clients:  []string{entityID},
+				Channels: []string{entityID},
+			},
+		},
+		{
+			desc:          "disable already disabled group hierarchy",
+			id:            root.ID,
+			hierarchyResp: groups.HierarchyPage{Groups: []groups.Group{disabledRoot, disabledDescendant}},
+			err:           errors.ErrStatusAlreadyAssigned,
+		},
+		{
+			desc:         "disable group hierarchy with failed to retrieve hierarchy",
+			id:           root.ID,
+			hierarchyErr: repoerr.ErrViewEntity,
+			err:          svcerr.ErrViewEntity,
+		},
+		{
+			desc:            "disable group hierarchy with failed to change status",
+			id:              root.ID,
+			hierarchyResp:   groups.HierarchyPage{Groups: []groups.Group{root, descendant}},
+			changeStatusErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:             "disable group hierarchy with failed to list entities",
+			id:               root.ID,
+			hierarchyResp:    groups.HierarchyPage{Groups: []groups.Group{root, descendant}},
+			changeStatusResp: []groups.Group{disabledRoot, disabledDescendant},
+			listObjectsErr:   svcerr.ErrAuthorization,
+			err:              svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveHierarchy", context.Background(), tc.id, groups.HierarchyPageMeta{Direction: -1}).Return(tc.hierarchyResp, tc.hierarchyErr)
+			repoCall1 := repo.On("ChangeHierarchyStatus", context.Background(), mock.Anything).Return(tc.changeStatusResp, tc.changeStatusErr)
+			policyCall := policies.On("ListAllObjects", context.Background(), mock.Anything).Return(policysvc.PolicyPage{Policies: []string{entityID}}, tc.listObjectsErr)
+			hc, err := svc.DisableGroupHierarchy(context.Background(), validSession, tc.id, tc.dryRun)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, hc, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, hc))
+			repoCall.Unset()
+			repoCall1.Unset()
+			policyCall.Unset()
+		})
+	}
+}
+
+func TestDeleteGroupHierarchy(t *testing.T) {
+	svc := newService(t)
+
+	root := validGroup
+	root.Level = 1
+	descendant := groups.Group{ID: childGroupID, Parent: root.ID, Level: 2}
+
+	cases := []struct {
+		desc              string
+		id                string
+		dryRun            bool
+		hierarchyResp     groups.HierarchyPage
+		hierarchyErr      error
+		unsetFromChannels error
+		deleteErr         error
+		resp              groups.HierarchyChange
+		err               error
+	}{
+		{
+			desc:          "delete group hierarchy successfully",
+			id:            root.ID,
+			hierarchyResp: groups.HierarchyPage{Groups: []groups.Group{root, descendant}},
+			resp: groups.HierarchyChange{
+				Groups:   []string{childGroupID, root.ID},
+				Clients:  []string{},
+				Channels: []string{},
+			},
+		},
+		{
+			desc:          "preview delete group hierarchy successfully",
+			id:            root.ID,
+			dryRun:        true,
+			hierarchyResp: groups.HierarchyPage{Groups: []groups.Group{root, descendant}},
+			resp: groups.HierarchyChange{
+				DryRun:   true,
+				Groups:   []string{childGroupID, root.ID},
+				Clients:  []string{},
+				Channels: []string{},
+			},
+		},
+		{
+			desc:         "delete group hierarchy with failed to retrieve hierarchy",
+			id:           root.ID,
+			hierarchyErr: repoerr.ErrViewEntity,
+			err:          svcerr.ErrViewEntity,
+		},
+		{
+			desc:              "delete group hierarchy with failed to delete group",
+			id:                root.ID,
+			hierarchyResp:     groups.HierarchyPage{Groups: []groups.Group{root, descendant}},
+			unsetFromChannels: svcerr.ErrRemoveEntity,
+			err:               svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:          "delete group hierarchy with failed to delete",
+			id:            root.ID,
+			hierarchyResp: groups.HierarchyPage{Groups: []groups.Group{root, descendant}},
+			deleteErr:     repoerr.ErrNotFound,
+			err:           repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveHierarchy", context.Background(), tc.id, groups.HierarchyPageMeta{Direction: -1}).Return(tc.hierarchyResp, tc.hierarchyErr)
+			policyCall := policies.On("ListAllObjects", context.Background(), mock.Anything).Return(policysvc.PolicyPage{}, nil)
+			svcCall := channels.On("UnsetParentGroupFromChannels", context.Background(), mock.Anything).Return(&grpcChannelsV1.UnsetParentGroupFromChannelsRes{}, tc.unsetFromChannels)
+			svcCall1 := clients.On("UnsetParentGroupFromClient", context.Background(), mock.Anything).Return(&grpcClientsV1.UnsetParentGroupFromClientRes{}, nil)
+			repoCall1 := repo.On("ChangeStatus", context.Background(), mock.Anything).Return(groups.Group{}, nil)
+			repoCall2 := repo.On("RetrieveEntitiesRolesActionsMembers", context.Background(), mock.Anything).Return([]roles.EntityActionRole{}, []roles.EntityMemberRole{}, nil)
+			policyCall1 := policies.On("DeletePolicyFilter", context.Background(), mock.Anything).Return(nil)
+			policyCall2 := policies.On("DeletePolicies", context.Background(), mock.Anything).Return(nil)
+			repoCall3 := repo.On("Delete", context.Background(), mock.Anything).Return(tc.deleteErr)
+			hc, err := svc.DeleteGroupHierarchy(context.Background(), validSession, tc.id, tc.dryRun)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, hc, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, hc))
+			repoCall.Unset()
+			policyCall.Unset()
+			svcCall.Unset()
+			svcCall1.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			policyCall1.Unset()
+			policyCall2.Unset()
+			repoCall3.Unset()
+		})
+	}
+}
@@ -37,11 +37,14 @@ func (tm *tracingMiddleware) CreateGroup(ctx context.Context, session authn.Sess
 }
 
 // ViewGroup traces the "ViewGroup" operation of the wrapped groups.Service.
-func (tm *tracingMiddleware) ViewGroup(ctx context.Context, session authn.Session, id string) (groups.Group, error) {
-	ctx, span := tm.tracer.Start(ctx, "svc_view_group", trace.WithAttributes(attribute.String("id", id)))
+func (tm *tracingMiddleware) ViewGroup(ctx context.Context, session authn.Session, id string, effective bool) (groups.Group, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_group", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.Bool("effective", effective),
+	))
 	defer span.End()
 
-	return tm.svc.ViewGroup(ctx, session, id)
+	return tm.svc.ViewGroup(ctx, session, id, effective)
 }
 
 // ListGroups traces the "ListGroups" operation of the wrapped groups.Service.
@@ -133,6 +136,51 @@ func (tm *tracingMiddleware) RemoveParentGroup(ctx context.Context, session auth
 	return tm.svc.RemoveParentGroup(ctx, session, id)
 }
 
+func (tm *tracingMiddleware) MoveGroup(ctx context.Context, session authn.Session, id, domainID string) (groups.Group, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_move_group", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.String("domain_id", domainID),
+	))
+	defer span.End()
+	return tm.svc.MoveGroup(ctx, session, id, domainID)
+}
+
+func (tm *tracingMiddleware) ChangeParentGroup(ctx context.Context, session authn.Session, id, parentID string) (groups.Group, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_change_parent_group", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.String("parent_id", parentID),
+	))
+	defer span.End()
+	return tm.svc.ChangeParentGroup(ctx, session, id, parentID)
+}
+
+func (tm *tracingMiddleware) EnableGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_enable_group_hierarchy", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.Bool("dry_run", dryRun),
+	))
+	defer span.End()
+	return tm.svc.EnableGroupHierarchy(ctx, session, id, dryRun)
+}
+
+func (tm *tracingMiddleware) DisableGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_disable_group_hierarchy", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.Bool("dry_run", dryRun),
+	))
+	defer span.End()
+	return tm.svc.DisableGroupHierarchy(ctx, session, id, dryRun)
+}
+
+func (tm *tracingMiddleware) DeleteGroupHierarchy(ctx context.Context, session authn.Session, id string, dryRun bool) (groups.HierarchyChange, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_group_hierarchy", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.Bool("dry_run", dryRun),
+	))
+	defer span.End()
+	return tm.svc.DeleteGroupHierarchy(ctx, session, id, dryRun)
+}
+
 func (tm *tracingMiddleware) AddChildrenGroups(ctx context.Context, session authn.Session, id string, childrenGroupIDs []string) error {
 	ctx, span := tm.tracer.Start(ctx, "svc_add_children_groups",
 		trace.WithAttributes(
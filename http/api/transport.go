@@ -46,6 +46,20 @@ func MakeHandler(logger *slog.Logger, instanceID string) http.Handler {
 		api.EncodeResponse,
 		opts...,
 	), "publish").ServeHTTP)
+
+	r.Post("/m/{domain}/c/{channel}", otelhttp.NewHandler(kithttp.NewServer(
+		sendMessageEndpoint(),
+		decodeRequest,
+		api.EncodeResponse,
+		opts...,
+	), "publish").ServeHTTP)
+
+	r.Post("/m/{domain}/c/{channel}/*", otelhttp.NewHandler(kithttp.NewServer(
+		sendMessageEndpoint(),
+		decodeRequest,
+		api.EncodeResponse,
+		opts...,
+	), "publish").ServeHTTP)
 	r.Get("/health", supermq.Health("http", instanceID))
 	r.Handle("/metrics", promhttp.Handler())
 
@@ -18,11 +18,15 @@ import (
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/domains"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/messaging/topics"
 	"github.com/absmach/supermq/pkg/policies"
 )
 
@@ -42,6 +46,7 @@ const (
 	logInfoPublished         = "published with client_type %s client_id %s to the topic %s"
 	logInfoFailedAuthNToken  = "failed to authenticate token for topic %s with error %s"
 	logInfoFailedAuthNClient = "failed to authenticate client key %s for topic %s with error %s"
+	logInfoFailedAuthZPAT    = "failed to authorize personal access token %s for topic %s with error %s"
 )
 
 // Error wrappers for MQTT errors.
@@ -62,17 +67,21 @@ type handler struct {
 	publisher messaging.Publisher
 	clients   grpcClientsV1.ClientsServiceClient
 	channels  grpcChannelsV1.ChannelsServiceClient
+	resolver  topics.Resolver
 	authn     smqauthn.Authentication
+	authz     smqauthz.Authorization
 	logger    *slog.Logger
 }
 
 // NewHandler creates new Handler entity.
-func NewHandler(publisher messaging.Publisher, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, logger *slog.Logger) session.Handler {
+func NewHandler(publisher messaging.Publisher, authn smqauthn.Authentication, authz smqauthz.Authorization, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, resolver topics.Resolver, logger *slog.Logger) session.Handler {
 	return &handler{
 		publisher: publisher,
 		authn:     authn,
+		authz:     authz,
 		clients:   clients,
 		channels:  channels,
+		resolver:  resolver,
 		logger:    logger,
 	}
 }
@@ -125,6 +134,20 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		return errors.Wrap(errFailedPublish, errClientNotInitialized)
 	}
 
+	resolved, err := h.resolver.Resolve(ctx, *topic)
+	if err != nil {
+		if errors.Contains(err, svcerr.ErrNotFound) {
+			return mgate.NewHTTPProxyError(http.StatusNotFound, err)
+		}
+		return mgate.NewHTTPProxyError(http.StatusBadRequest, err)
+	}
+	topic = &resolved
+
+	chanID, subtopic, err := parseTopic(*topic)
+	if err != nil {
+		return mgate.NewHTTPProxyError(http.StatusBadRequest, err)
+	}
+
 	var clientID, clientType string
 	switch {
 	case strings.HasPrefix(string(s.Password), "Client"):
@@ -149,15 +172,18 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		}
 		clientType = policies.UserType
 		clientID = authnSession.DomainUserID
+		if authnSession.Type == smqauthn.PersonalAccessToken {
+			authnSession.IP = smqauthn.ClientIP(ctx)
+			clientID, err = smqauthz.AuthorizeChannelPAT(ctx, h.authz, h.channels, authnSession, chanID, auth.PublishOp)
+			if err != nil {
+				h.logger.Info(fmt.Sprintf(logInfoFailedAuthZPAT, authnSession.PatID, *topic, err))
+				return mgate.NewHTTPProxyError(http.StatusForbidden, svcerr.ErrAuthorization)
+			}
+		}
 	default:
 		return mgate.NewHTTPProxyError(http.StatusUnauthorized, svcerr.ErrAuthentication)
 	}
 
-	chanID, subtopic, err := parseTopic(*topic)
-	if err != nil {
-		return mgate.NewHTTPProxyError(http.StatusBadRequest, err)
-	}
-
 	msg := messaging.Message{
 		Protocol: protocol,
 		Channel:  chanID,
@@ -171,9 +197,14 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		ClientType: clientType,
 		ChannelId:  msg.Channel,
 		Type:       uint32(connections.Publish),
+		Protocol:   domains.HTTPProtocol,
+		Subtopic:   msg.Subtopic,
 	}
 	res, err := h.channels.Authorize(ctx, ar)
 	if err != nil {
+		if errors.Contains(err, svcerr.ErrQuotaExceeded) {
+			return mgate.NewHTTPProxyError(http.StatusTooManyRequests, err)
+		}
 		return mgate.NewHTTPProxyError(http.StatusBadRequest, err)
 	}
 	if !res.GetAuthorized() {
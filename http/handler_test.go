@@ -8,22 +8,30 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	mghttp "github.com/absmach/mgate/pkg/http"
 	"github.com/absmach/mgate/pkg/session"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	grpcDomainsV1 "github.com/absmach/supermq/api/grpc/domains/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/auth"
 	chmocks "github.com/absmach/supermq/channels/mocks"
 	clmocks "github.com/absmach/supermq/clients/mocks"
+	dmocks "github.com/absmach/supermq/domains/mocks"
 	mhttp "github.com/absmach/supermq/http"
 	"github.com/absmach/supermq/internal/testsutil"
 	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	authzmocks "github.com/absmach/supermq/pkg/authz/mocks"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/messaging/mocks"
+	"github.com/absmach/supermq/pkg/messaging/topics"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -36,6 +44,10 @@ const (
 	invalidID             = "invalidID"
 	invalidValue          = "invalidValue"
 	invalidChannelIDTopic = "channels/**/messages"
+	domainID              = "123e4567-e89b-12d3-a456-000000000002"
+	domainAlias           = "factory"
+	channelName           = "temperature"
+	clientIP              = "192.168.0.1"
 )
 
 var (
@@ -44,6 +56,8 @@ var (
 	topic         = fmt.Sprintf(topicMsg, chanID)
 	subtopic      = fmt.Sprintf(subtopicMsg, chanID)
 	invalidTopic  = invalidValue
+	aliasTopic    = fmt.Sprintf("m/%s/c/%s", domainAlias, channelName)
+	unknownAlias  = fmt.Sprintf("m/%s/c/%s", invalidValue, channelName)
 	payload       = []byte("[{'n':'test-name', 'v': 1.2}]")
 	sessionClient = session.Session{
 		ID:       clientID,
@@ -63,18 +77,26 @@ var (
 var (
 	clients   = new(clmocks.ClientsServiceClient)
 	channels  = new(chmocks.ChannelsServiceClient)
+	doms      = new(dmocks.DomainsServiceClient)
 	authn     = new(authnmocks.Authentication)
+	authz     = new(authzmocks.Authorization)
 	publisher = new(mocks.PubSub)
 )
 
 func newHandler() session.Handler {
 	logger := smqlog.NewMock()
 	authn = new(authnmocks.Authentication)
+	authz = new(authzmocks.Authorization)
 	clients = new(clmocks.ClientsServiceClient)
 	channels = new(chmocks.ChannelsServiceClient)
 	publisher = new(mocks.PubSub)
+	doms = new(dmocks.DomainsServiceClient)
+	doms.On("RetrieveIDByAlias", mock.Anything, &grpcDomainsV1.RetrieveIDByAliasReq{Alias: domainAlias}).Return(&grpcDomainsV1.RetrieveIDByAliasRes{Id: domainID}, nil)
+	doms.On("RetrieveIDByAlias", mock.Anything, &grpcDomainsV1.RetrieveIDByAliasReq{Alias: invalidValue}).Return(&grpcDomainsV1.RetrieveIDByAliasRes{}, svcerr.ErrNotFound)
+	channels.On("RetrieveIDByName", mock.Anything, &grpcChannelsV1.RetrieveIDByNameReq{DomainId: domainID, Name: channelName}).Return(&grpcChannelsV1.RetrieveIDByNameRes{Id: chanID}, nil)
+	resolver := topics.NewResolver(doms, channels, time.Minute)
 
-	return mhttp.NewHandler(publisher, authn, clients, channels, logger)
+	return mhttp.NewHandler(publisher, authn, authz, clients, channels, resolver, logger)
 }
 
 func TestAuthConnect(t *testing.T) {
@@ -143,21 +165,26 @@ func TestPublish(t *testing.T) {
 	tokenSession := session.Session{
 		Password: []byte(apiutil.BearerPrefix + validToken),
 	}
+	patSession := smqauthn.Session{Type: smqauthn.PersonalAccessToken, PatID: validID, UserID: validID}
+	entityRes := &grpcCommonV1.RetrieveEntityRes{Entity: &grpcCommonV1.EntityBasic{Id: chanID, DomainId: validID}}
 	cases := []struct {
-		desc       string
-		topic      *string
-		channelID  string
-		payload    *[]byte
-		password   string
-		session    *session.Session
-		status     int
-		authNRes   *grpcClientsV1.AuthnRes
-		authNRes1  smqauthn.Session
-		authNErr   error
-		authZRes   *grpcChannelsV1.AuthzRes
-		authZErr   error
-		publishErr error
-		err        error
+		desc        string
+		topic       *string
+		channelID   string
+		payload     *[]byte
+		password    string
+		session     *session.Session
+		status      int
+		authNRes    *grpcClientsV1.AuthnRes
+		authNRes1   smqauthn.Session
+		authNErr    error
+		entityRes   *grpcCommonV1.RetrieveEntityRes
+		entityErr   error
+		authZPATErr error
+		authZRes    *grpcChannelsV1.AuthzRes
+		authZErr    error
+		publishErr  error
+		err         error
 	}{
 		{
 			desc:      "publish  with key successfully",
@@ -185,6 +212,44 @@ func TestPublish(t *testing.T) {
 			authZErr:  nil,
 			err:       nil,
 		},
+		{
+			desc:      "publish with personal access token successfully",
+			topic:     &topic,
+			payload:   &payload,
+			password:  validToken,
+			session:   &tokenSession,
+			channelID: chanID,
+			authNRes1: patSession,
+			entityRes: entityRes,
+			authZRes:  &grpcChannelsV1.AuthzRes{Authorized: true},
+			err:       nil,
+		},
+		{
+			desc:      "publish with personal access token and failed to retrieve channel",
+			topic:     &topic,
+			payload:   &payload,
+			password:  validToken,
+			session:   &tokenSession,
+			channelID: chanID,
+			status:    http.StatusForbidden,
+			authNRes1: patSession,
+			entityRes: &grpcCommonV1.RetrieveEntityRes{},
+			entityErr: svcerr.ErrNotFound,
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "publish with personal access token without channel publish scope",
+			topic:       &topic,
+			payload:     &payload,
+			password:    validToken,
+			session:     &tokenSession,
+			channelID:   chanID,
+			status:      http.StatusForbidden,
+			authNRes1:   patSession,
+			entityRes:   entityRes,
+			authZPATErr: svcerr.ErrAuthorization,
+			err:         svcerr.ErrAuthorization,
+		},
 		{
 			desc:      "publish  with key and subtopic successfully",
 			topic:     &subtopic,
@@ -198,6 +263,29 @@ func TestPublish(t *testing.T) {
 			authZErr:  nil,
 			err:       nil,
 		},
+		{
+			desc:      "publish with domain alias topic successfully",
+			topic:     &aliasTopic,
+			payload:   &payload,
+			password:  clientKey,
+			session:   &clientKeySession,
+			channelID: chanID,
+			authNRes:  &grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true},
+			authNErr:  nil,
+			authZRes:  &grpcChannelsV1.AuthzRes{Authorized: true},
+			authZErr:  nil,
+			err:       nil,
+		},
+		{
+			desc:      "publish with unknown domain alias topic",
+			topic:     &unknownAlias,
+			payload:   &payload,
+			password:  clientKey,
+			session:   &clientKeySession,
+			channelID: chanID,
+			status:    http.StatusNotFound,
+			err:       errors.Wrap(topics.ErrResolveTopic, svcerr.ErrNotFound),
+		},
 		{
 			desc:      "publish with empty topic",
 			topic:     nil,
@@ -328,12 +416,23 @@ func TestPublish(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			ctx := context.TODO()
+			ctx := smqauthn.WithClientIP(context.TODO(), clientIP)
 			if tc.session != nil {
 				ctx = session.NewContext(ctx, tc.session)
 			}
 			clientsCall := clients.On("Authenticate", ctx, &grpcClientsV1.AuthnReq{ClientSecret: tc.password}).Return(tc.authNRes, tc.authNErr)
 			authCall := authn.On("Authenticate", ctx, mock.Anything).Return(tc.authNRes1, tc.authNErr)
+			entityCall := channels.On("RetrieveEntity", ctx, &grpcCommonV1.RetrieveEntityReq{Id: tc.channelID}).Return(tc.entityRes, tc.entityErr)
+			patCall := authz.On("AuthorizePAT", ctx, smqauthz.PatReq{
+				UserID:                   tc.authNRes1.UserID,
+				PatID:                    tc.authNRes1.PatID,
+				PlatformEntityType:       auth.PlatformDomainsScope,
+				OptionalDomainID:         tc.entityRes.GetEntity().GetDomainId(),
+				OptionalDomainEntityType: auth.DomainChannelsScope,
+				Operation:                auth.PublishOp,
+				EntityIDs:                []string{tc.channelID},
+				IP:                       clientIP,
+			}).Return(tc.authZPATErr)
 			channelsCall := channels.On("Authorize", ctx, mock.Anything).Return(tc.authZRes, tc.authZErr)
 			repoCall := publisher.On("Publish", ctx, tc.channelID, mock.Anything).Return(tc.publishErr)
 			err := handler.Publish(ctx, tc.topic, tc.payload)
@@ -345,6 +444,8 @@ func TestPublish(t *testing.T) {
 			authCall.Unset()
 			repoCall.Unset()
 			clientsCall.Unset()
+			entityCall.Unset()
+			patCall.Unset()
 			channelsCall.Unset()
 		})
 	}
@@ -31,6 +31,14 @@ type email struct {
 	Content string
 	Host    string
 	Footer  string
+	Logo    string
+	Color   string
+}
+
+// Branding contains the branding the e-mail templates may render.
+type Branding struct {
+	LogoURL      string
+	PrimaryColor string
 }
 
 // Config email agent configuration.
@@ -72,6 +80,11 @@ func New(c *Config) (*Agent, error) {
 
 // Send sends e-mail.
 func (a *Agent) Send(to []string, from, subject, header, user, content, footer string) error {
+	return a.SendBranded(to, from, subject, header, user, content, footer, Branding{})
+}
+
+// SendBranded sends e-mail with the branding available to the template.
+func (a *Agent) SendBranded(to []string, from, subject, header, user, content, footer string, b Branding) error {
 	if a.tmpl == nil {
 		return errMissingEmailTemplate
 	}
@@ -86,6 +99,8 @@ func (a *Agent) Send(to []string, from, subject, header, user, content, footer s
 		Content: content,
 		Host:    strings.Split(content, "?")[0],
 		Footer:  footer,
+		Logo:    b.LogoURL,
+		Color:   b.PrimaryColor,
 	}
 	if from == "" {
 		from := mail.Address{Name: a.conf.FromName, Address: a.conf.FromAddress}
@@ -124,6 +124,9 @@ type Repository interface {
 
 	// Delete deletes an invitation.
 	Delete(ctx context.Context, userID, domainID string) (err error)
+
+	// DeleteDomainInvitations deletes all the invitations of the domain.
+	DeleteDomainInvitations(ctx context.Context, domainID string) (err error)
 }
 
 // CheckRelation checks if the given relation is valid.
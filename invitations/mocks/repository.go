@@ -52,6 +52,24 @@ func (_m *Repository) Delete(ctx context.Context, userID string, domainID string
 	return r0
 }
 
+// DeleteDomainInvitations provides a mock function with given fields: ctx, domainID
+func (_m *Repository) DeleteDomainInvitations(ctx context.Context, domainID string) error {
+	ret := _m.Called(ctx, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDomainInvitations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, domainID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Retrieve provides a mock function with given fields: ctx, userID, domainID
 func (_m *Repository) Retrieve(ctx context.Context, userID string, domainID string) (invitations.Invitation, error) {
 	ret := _m.Called(ctx, userID, domainID)
@@ -156,6 +156,16 @@ func (repo *repository) Delete(ctx context.Context, userID, domain string) (err
 	return nil
 }
 
+func (repo *repository) DeleteDomainInvitations(ctx context.Context, domainID string) (err error) {
+	q := `DELETE FROM invitations WHERE domain_id = $1`
+
+	if _, err := repo.db.ExecContext(ctx, q, domainID); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
 func pageQuery(pm invitations.Page) string {
 	var query []string
 	var emq string
@@ -0,0 +1,33 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package invitations
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/domains"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+)
+
+var _ domains.Purger = (*domainPurger)(nil)
+
+type domainPurger struct {
+	repo Repository
+}
+
+// NewDomainPurger returns the purger that removes the invitations of the purged domain.
+func NewDomainPurger(repo Repository) domains.Purger {
+	return &domainPurger{
+		repo: repo,
+	}
+}
+
+func (dp *domainPurger) PurgeDomain(ctx context.Context, domainID string) error {
+	if err := dp.repo.DeleteDomainInvitations(ctx, domainID); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
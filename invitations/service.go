@@ -10,21 +10,25 @@ import (
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	"github.com/absmach/supermq/auth"
 	"github.com/absmach/supermq/pkg/authn"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
+	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	mgsdk "github.com/absmach/supermq/pkg/sdk"
 )
 
 type service struct {
-	token grpcTokenV1.TokenServiceClient
-	repo  Repository
-	sdk   mgsdk.SDK
+	token    grpcTokenV1.TokenServiceClient
+	repo     Repository
+	sdk      mgsdk.SDK
+	settings pkgDomains.Settings
 }
 
-func NewService(token grpcTokenV1.TokenServiceClient, repo Repository, sdk mgsdk.SDK) Service {
+func NewService(token grpcTokenV1.TokenServiceClient, repo Repository, sdk mgsdk.SDK, settings pkgDomains.Settings) Service {
 	return &service{
-		token: token,
-		repo:  repo,
-		sdk:   sdk,
+		token:    token,
+		repo:     repo,
+		sdk:      sdk,
+		settings: settings,
 	}
 }
 
@@ -91,6 +95,19 @@ func (svc *service) AcceptInvitation(ctx context.Context, session authn.Session,
 		return svcerr.ErrInvitationAlreadyRejected
 	}
 
+	settings, err := svc.settings.RetrieveSettings(ctx, inv.DomainID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	// Resending the invitation renews it, so the expiry counts from the last update.
+	issuedAt := inv.CreatedAt
+	if inv.UpdatedAt.After(issuedAt) {
+		issuedAt = inv.UpdatedAt
+	}
+	if expiry := settings.InvitationExpiry(); expiry > 0 && time.Since(issuedAt) > expiry {
+		return svcerr.ErrInvitationExpired
+	}
+
 	if _, sdkerr := svc.sdk.AddDomainRoleMembers(inv.DomainID, "admin", []string{session.UserID}, inv.Token); sdkerr != nil {
 		return sdkerr
 	}
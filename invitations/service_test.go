@@ -11,10 +11,12 @@ import (
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	authmocks "github.com/absmach/supermq/auth/mocks"
+	"github.com/absmach/supermq/domains"
 	"github.com/absmach/supermq/internal/testsutil"
 	"github.com/absmach/supermq/invitations"
 	"github.com/absmach/supermq/invitations/mocks"
 	"github.com/absmach/supermq/pkg/authn"
+	dmocks "github.com/absmach/supermq/pkg/domains/mocks"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
@@ -40,7 +42,7 @@ var (
 func TestSendInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	token := new(authmocks.TokenServiceClient)
-	svc := invitations.NewService(token, repo, nil)
+	svc := invitations.NewService(token, repo, nil, nil)
 
 	cases := []struct {
 		desc        string
@@ -123,7 +125,7 @@ func TestSendInvitation(t *testing.T) {
 func TestViewInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	token := new(authmocks.TokenServiceClient)
-	svc := invitations.NewService(token, repo, nil)
+	svc := invitations.NewService(token, repo, nil, nil)
 
 	validInvitation := invitations.Invitation{
 		InvitedBy:   testsutil.GenerateUUID(t),
@@ -207,7 +209,7 @@ func TestViewInvitation(t *testing.T) {
 func TestListInvitations(t *testing.T) {
 	repo := new(mocks.Repository)
 	token := new(authmocks.TokenServiceClient)
-	svc := invitations.NewService(token, repo, nil)
+	svc := invitations.NewService(token, repo, nil, nil)
 
 	validPage := invitations.Page{
 		Offset: 0,
@@ -272,7 +274,8 @@ func TestAcceptInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	token := new(authmocks.TokenServiceClient)
 	sdksvc := new(sdkmocks.SDK)
-	svc := invitations.NewService(token, repo, sdksvc)
+	dsettings := new(dmocks.Settings)
+	svc := invitations.NewService(token, repo, sdksvc, dsettings)
 
 	userID := testsutil.GenerateUUID(t)
 
@@ -286,6 +289,7 @@ func TestAcceptInvitation(t *testing.T) {
 		repoErr  error
 		sdkErr   errors.SDKError
 		repoErr1 error
+		settings domains.Settings
 	}{
 		{
 			desc:     "accept invitation successful",
@@ -368,16 +372,51 @@ func TestAcceptInvitation(t *testing.T) {
 			err:     svcerr.ErrInvitationAlreadyRejected,
 			repoErr: nil,
 		},
+		{
+			desc:     "accept expired invitation",
+			token:    validToken,
+			session:  authn.Session{DomainUserID: validDomainUserID, DomainID: validDomainID, UserID: userID},
+			domainID: "",
+			resp: invitations.Invitation{
+				UserID:    userID,
+				DomainID:  testsutil.GenerateUUID(t),
+				Token:     validToken,
+				Relation:  policies.ContributorRelation,
+				CreatedAt: time.Now().Add(-48 * time.Hour),
+			},
+			settings: domains.Settings{InvitationExpiryHours: 24},
+			err:      svcerr.ErrInvitationExpired,
+			repoErr:  nil,
+		},
+		{
+			desc:     "accept resent invitation within expiry",
+			token:    validToken,
+			session:  authn.Session{DomainUserID: validDomainUserID, DomainID: validDomainID, UserID: userID},
+			domainID: "",
+			resp: invitations.Invitation{
+				UserID:    userID,
+				DomainID:  testsutil.GenerateUUID(t),
+				Token:     validToken,
+				Relation:  policies.ContributorRelation,
+				CreatedAt: time.Now().Add(-48 * time.Hour),
+				UpdatedAt: time.Now(),
+			},
+			settings: domains.Settings{InvitationExpiryHours: 24},
+			err:      nil,
+			repoErr:  nil,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			repocall1 := repo.On("Retrieve", context.Background(), mock.Anything, tc.domainID).Return(tc.resp, tc.repoErr)
+			settingsCall := dsettings.On("RetrieveSettings", context.Background(), tc.resp.DomainID).Return(tc.settings, nil)
 			sdkcall := sdksvc.On("AddDomainRoleMembers", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]string{}, tc.sdkErr)
 			repocall2 := repo.On("UpdateConfirmation", context.Background(), mock.Anything).Return(tc.repoErr1)
 			err := svc.AcceptInvitation(context.Background(), tc.session, tc.domainID)
 			assert.Equal(t, tc.err, err, tc.desc)
 			repocall1.Unset()
+			settingsCall.Unset()
 			sdkcall.Unset()
 			repocall2.Unset()
 		})
@@ -387,7 +426,7 @@ func TestAcceptInvitation(t *testing.T) {
 func TestDeleteInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	token := new(authmocks.TokenServiceClient)
-	svc := invitations.NewService(token, repo, nil)
+	svc := invitations.NewService(token, repo, nil, nil)
 
 	cases := []struct {
 		desc     string
@@ -450,7 +489,7 @@ func TestDeleteInvitation(t *testing.T) {
 func TestRejectInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	token := new(authmocks.TokenServiceClient)
-	svc := invitations.NewService(token, repo, nil)
+	svc := invitations.NewService(token, repo, nil, nil)
 	userID := validInvitation.UserID
 
 	cases := []struct {
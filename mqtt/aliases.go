@@ -0,0 +1,134 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/absmach/mgate/pkg/session"
+	"github.com/eclipse/paho.mqtt.golang/packets"
+)
+
+var (
+	_ session.Handler     = (*topicAliases)(nil)
+	_ session.Interceptor = (*topicAliases)(nil)
+)
+
+// topicAliases keeps the alias topics the clients subscribed with. The
+// subscriptions are forwarded to the broker in the channel ID form, so the
+// messages are delivered on the channel ID topics and are sent to the client
+// on the alias topics it subscribed with.
+type topicAliases struct {
+	session.Handler
+	mu sync.Mutex
+	// sessions maps the channel ID topic prefix to the alias topic prefix
+	// of the client subscriptions.
+	sessions map[*session.Session]map[string]string
+}
+
+// NewTopicAliases returns the handler which records the alias subscriptions
+// and the interceptor which sends the messages delivered to the client on the
+// alias topics. The client unsubscribes using the same alias topics.
+func NewTopicAliases(h session.Handler) (session.Handler, session.Interceptor) {
+	ta := &topicAliases{
+		Handler:  h,
+		sessions: make(map[*session.Session]map[string]string),
+	}
+
+	return ta, ta
+}
+
+func (ta *topicAliases) AuthSubscribe(ctx context.Context, topics *[]string) error {
+	var aliases []string
+	if topics != nil {
+		aliases = append(aliases, *topics...)
+	}
+	if err := ta.Handler.AuthSubscribe(ctx, topics); err != nil {
+		return err
+	}
+
+	s, ok := session.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	for i, topic := range *topics {
+		if topic == aliases[i] {
+			continue
+		}
+		parts := channelRegExp.FindStringSubmatch(topic)
+		if len(parts) == 0 {
+			continue
+		}
+		// The resolved topic keeps the subtopic of the alias topic.
+		n := len(parts[2]) + len(parts[3])
+		ta.save(s, topic[:len(topic)-n], aliases[i][:len(aliases[i])-n])
+	}
+
+	return nil
+}
+
+func (ta *topicAliases) Disconnect(ctx context.Context) error {
+	if s, ok := session.FromContext(ctx); ok {
+		ta.mu.Lock()
+		delete(ta.sessions, s)
+		ta.mu.Unlock()
+	}
+
+	return ta.Handler.Disconnect(ctx)
+}
+
+func (ta *topicAliases) Intercept(ctx context.Context, pkt packets.ControlPacket, dir session.Direction) (packets.ControlPacket, error) {
+	s, ok := session.FromContext(ctx)
+	if !ok {
+		return pkt, nil
+	}
+
+	switch p := pkt.(type) {
+	case *packets.PublishPacket:
+		if dir == session.Down {
+			p.TopicName = ta.replace(s, p.TopicName, true)
+		}
+	case *packets.UnsubscribePacket:
+		if dir == session.Up {
+			for i, topic := range p.Topics {
+				p.Topics[i] = ta.replace(s, topic, false)
+			}
+		}
+	}
+
+	return pkt, nil
+}
+
+func (ta *topicAliases) save(s *session.Session, prefix, alias string) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+
+	prefixes, ok := ta.sessions[s]
+	if !ok {
+		prefixes = make(map[string]string)
+		ta.sessions[s] = prefixes
+	}
+	prefixes[prefix] = alias
+}
+
+// replace replaces the channel ID topic prefix with the alias topic prefix
+// the client subscribed with, or the alias prefix with the channel ID prefix.
+func (ta *topicAliases) replace(s *session.Session, topic string, toAlias bool) string {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+
+	for prefix, alias := range ta.sessions[s] {
+		from, to := alias, prefix
+		if toAlias {
+			from, to = prefix, alias
+		}
+		if topic == from || strings.HasPrefix(topic, from+"/") {
+			return to + topic[len(from):]
+		}
+	}
+
+	return topic
+}
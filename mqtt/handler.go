@@ -15,10 +15,12 @@ import (
 	"github.com/absmach/mgate/pkg/session"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	"github.com/absmach/supermq/domains"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/messaging/topics"
 	"github.com/absmach/supermq/pkg/policies"
 )
 
@@ -65,16 +67,18 @@ type handler struct {
 	publisher messaging.Publisher
 	clients   grpcClientsV1.ClientsServiceClient
 	channels  grpcChannelsV1.ChannelsServiceClient
+	resolver  topics.Resolver
 	logger    *slog.Logger
 }
 
 // NewHandler creates new Handler entity.
-func NewHandler(publisher messaging.Publisher, logger *slog.Logger, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) session.Handler {
+func NewHandler(publisher messaging.Publisher, logger *slog.Logger, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, resolver topics.Resolver) session.Handler {
 	return &handler{
 		logger:    logger,
 		publisher: publisher,
 		clients:   clients,
 		channels:  channels,
+		resolver:  resolver,
 	}
 }
 
@@ -118,6 +122,13 @@ func (h *handler) AuthPublish(ctx context.Context, topic *string, payload *[]byt
 		return ErrClientNotInitialized
 	}
 
+	// The topic is forwarded to the broker in the channel ID form.
+	resolved, err := h.resolver.Resolve(ctx, *topic)
+	if err != nil {
+		return err
+	}
+	*topic = resolved
+
 	return h.authAccess(ctx, string(s.Username), *topic, connections.Publish)
 }
 
@@ -132,10 +143,15 @@ func (h *handler) AuthSubscribe(ctx context.Context, topics *[]string) error {
 		return ErrMissingTopicSub
 	}
 
-	for _, topic := range *topics {
+	for i, topic := range *topics {
+		topic, err := h.resolver.Resolve(ctx, topic)
+		if err != nil {
+			return err
+		}
 		if err := h.authAccess(ctx, string(s.Username), topic, connections.Subscribe); err != nil {
 			return err
 		}
+		(*topics)[i] = topic
 	}
 
 	return nil
@@ -237,11 +253,18 @@ func (h *handler) authAccess(ctx context.Context, clientID, topic string, msgTyp
 
 	chanID := channelParts[1]
 
+	subtopic, err := parseSubtopic(toSubjectWildcards(channelParts[2]))
+	if err != nil {
+		return errors.Wrap(ErrFailedParseSubtopic, err)
+	}
+
 	ar := &grpcChannelsV1.AuthzReq{
 		Type:       uint32(msgType),
 		ClientId:   clientID,
 		ClientType: policies.ClientType,
 		ChannelId:  chanID,
+		Protocol:   domains.MQTTProtocol,
+		Subtopic:   subtopic,
 	}
 	res, err := h.channels.Authorize(ctx, ar)
 	if err != nil {
@@ -254,6 +277,23 @@ func (h *handler) authAccess(ctx context.Context, clientID, topic string, msgTyp
 	return nil
 }
 
+// toSubjectWildcards replaces MQTT single and multi level wildcards with their
+// messaging subject equivalents, so that subscriptions can be checked against
+// the subtopics the connection is restricted to.
+func toSubjectWildcards(subtopic string) string {
+	elems := strings.Split(subtopic, "/")
+	for i, elem := range elems {
+		switch elem {
+		case "+":
+			elems[i] = "*"
+		case "#":
+			elems[i] = ">"
+		}
+	}
+
+	return strings.Join(elems, "/")
+}
+
 func parseSubtopic(subtopic string) (string, error) {
 	if subtopic == "" {
 		return subtopic, nil
@@ -6,15 +6,21 @@ package mqtt_test
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"fmt"
 	"log"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/absmach/mgate/pkg/session"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	grpcDomainsV1 "github.com/absmach/supermq/api/grpc/domains/v1"
 	chmocks "github.com/absmach/supermq/channels/mocks"
 	climocks "github.com/absmach/supermq/clients/mocks"
+	"github.com/absmach/supermq/domains"
+	dmocks "github.com/absmach/supermq/domains/mocks"
 	"github.com/absmach/supermq/internal/testsutil"
 	smqlog "github.com/absmach/supermq/logger"
 	"github.com/absmach/supermq/mqtt"
@@ -22,7 +28,9 @@ import (
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	msgtopics "github.com/absmach/supermq/pkg/messaging/topics"
 	"github.com/absmach/supermq/pkg/policies"
+	"github.com/eclipse/paho.mqtt.golang/packets"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -37,6 +45,9 @@ const (
 	clientID1             = "clientID1"
 	subtopic              = "testSubtopic"
 	invalidChannelIDTopic = "channels/**/messages"
+	domainID              = "123e4567-e89b-12d3-a456-000000000002"
+	domainAlias           = "factory"
+	channelName           = "temperature"
 )
 
 var (
@@ -47,6 +58,9 @@ var (
 	topics              = []string{topic}
 	invalidTopics       = []string{invalidValue}
 	invalidChanIDTopics = []string{fmt.Sprintf(topicMsg, invalidValue)}
+	aliasTopic          = fmt.Sprintf("m/%s/c/%s", domainAlias, channelName)
+	unknownAliasTopic   = fmt.Sprintf("m/%s/c/%s", invalidValue, channelName)
+	aliasTopics         = []string{aliasTopic}
 	// Test log messages for cases the handler does not provide a return value.
 	logBuffer     = bytes.Buffer{}
 	sessionClient = session.Session{
@@ -68,8 +82,9 @@ var (
 )
 
 var (
-	clients  = new(climocks.ClientsServiceClient)
-	channels = new(chmocks.ChannelsServiceClient)
+	clients   = new(climocks.ClientsServiceClient)
+	channels  = new(chmocks.ChannelsServiceClient)
+	domainsCl = new(dmocks.DomainsServiceClient)
 )
 
 func TestAuthConnect(t *testing.T) {
@@ -203,6 +218,21 @@ func TestAuthPublish(t *testing.T) {
 			authZRes: &grpcChannelsV1.AuthzRes{Authorized: false},
 			authZErr: svcerr.ErrAuthorization,
 		},
+		{
+			desc:     "publish to domain alias topic successfully",
+			session:  &sessionClient,
+			err:      nil,
+			topic:    &aliasTopic,
+			payload:  payload,
+			authZRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+		},
+		{
+			desc:    "publish to unknown domain alias topic",
+			session: &sessionClient,
+			err:     msgtopics.ErrResolveTopic,
+			topic:   &unknownAliasTopic,
+			payload: payload,
+		},
 	}
 
 	for _, tc := range cases {
@@ -216,9 +246,13 @@ func TestAuthPublish(t *testing.T) {
 				ClientId:   clientID,
 				ClientType: policies.ClientType,
 				Type:       uint32(connections.Publish),
+				Protocol:   domains.MQTTProtocol,
 			}).Return(tc.authZRes, tc.authZErr)
 			err := handler.AuthPublish(ctx, tc.topic, &tc.payload)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, topic, *tc.topic, fmt.Sprintf("%s: expected topic %s got %s\n", tc.desc, topic, *tc.topic))
+			}
 			channelsCall.Unset()
 		})
 	}
@@ -278,6 +312,14 @@ func TestAuthSubscribe(t *testing.T) {
 			authZRes:  &grpcChannelsV1.AuthzRes{Authorized: false},
 			channelID: chanID,
 		},
+		{
+			desc:      "subscribe to domain alias topic successfully",
+			session:   &sessionClientSub,
+			err:       nil,
+			topic:     &aliasTopics,
+			authZRes:  &grpcChannelsV1.AuthzRes{Authorized: true},
+			channelID: chanID,
+		},
 	}
 
 	for _, tc := range cases {
@@ -291,9 +333,204 @@ func TestAuthSubscribe(t *testing.T) {
 				ClientId:   clientID1,
 				ClientType: policies.ClientType,
 				Type:       uint32(connections.Subscribe),
+				Protocol:   domains.MQTTProtocol,
 			}).Return(tc.authZRes, tc.authZErr)
 			err := handler.AuthSubscribe(ctx, tc.topic)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, topics, *tc.topic, fmt.Sprintf("%s: expected topics %v got %v\n", tc.desc, topics, *tc.topic))
+			}
+			channelsCall.Unset()
+		})
+	}
+}
+
+func TestAliasSubscription(t *testing.T) {
+	h, interceptor := mqtt.NewTopicAliases(newHandler())
+	sess := sessionClientSub
+	ctx := session.NewContext(context.TODO(), &sess)
+
+	channels.On("Authorize", mock.Anything, &grpcChannelsV1.AuthzReq{
+		ChannelId:  chanID,
+		ClientId:   clientID1,
+		ClientType: policies.ClientType,
+		Type:       uint32(connections.Subscribe),
+		Protocol:   domains.MQTTProtocol,
+		Subtopic:   ">",
+	}).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, nil)
+
+	subTopics := []string{aliasTopic + "/#"}
+	err := h.AuthSubscribe(ctx, &subTopics)
+	assert.Nil(t, err, fmt.Sprintf("subscribe to alias topic unexpected error: %s", err))
+	assert.Equal(t, []string{topic + "/#"}, subTopics)
+
+	cases := []struct {
+		desc      string
+		topic     string
+		delivered string
+	}{
+		{
+			desc:      "deliver message on alias topic",
+			topic:     topic,
+			delivered: aliasTopic,
+		},
+		{
+			desc:      "deliver message on alias topic with subtopic",
+			topic:     topic + "/" + subtopic,
+			delivered: aliasTopic + "/" + subtopic,
+		},
+		{
+			desc:      "deliver message of channel not subscribed with alias",
+			topic:     fmt.Sprintf(topicMsg, invalidValue),
+			delivered: fmt.Sprintf(topicMsg, invalidValue),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			pub := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+			pub.TopicName = tc.topic
+			pkt, err := interceptor.Intercept(ctx, pub, session.Down)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.delivered, pkt.(*packets.PublishPacket).TopicName, fmt.Sprintf("%s: expected topic %s got %s\n", tc.desc, tc.delivered, pkt.(*packets.PublishPacket).TopicName))
+		})
+	}
+
+	unsub := packets.NewControlPacket(packets.Unsubscribe).(*packets.UnsubscribePacket)
+	unsub.Topics = []string{aliasTopic + "/#"}
+	pkt, err := interceptor.Intercept(ctx, unsub, session.Up)
+	assert.Nil(t, err, fmt.Sprintf("unsubscribe from alias topic unexpected error: %s", err))
+	assert.Equal(t, []string{topic + "/#"}, pkt.(*packets.UnsubscribePacket).Topics)
+
+	err = h.Disconnect(ctx)
+	assert.Nil(t, err, fmt.Sprintf("disconnect unexpected error: %s", err))
+	pub := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+	pub.TopicName = topic
+	pkt, err = interceptor.Intercept(ctx, pub, session.Down)
+	assert.Nil(t, err, fmt.Sprintf("deliver message after disconnect unexpected error: %s", err))
+	assert.Equal(t, topic, pkt.(*packets.PublishPacket).TopicName)
+}
+
+func TestDeliveryAfterExpiry(t *testing.T) {
+	h := newHandler()
+	clients.On("Authenticate", mock.Anything, &grpcClientsV1.AuthnReq{ClientSecret: password1}).Return(&grpcClientsV1.AuthnRes{Authenticated: true, Id: clientID1}, nil)
+	authzReq := &grpcChannelsV1.AuthzReq{
+		ChannelId:  chanID,
+		ClientId:   clientID1,
+		ClientType: policies.ClientType,
+		Type:       uint32(connections.Subscribe),
+		Protocol:   domains.MQTTProtocol,
+	}
+	// The connection expires after the first message is delivered.
+	channels.On("Authorize", mock.Anything, authzReq).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, nil).Once()
+	channels.On("Authorize", mock.Anything, authzReq).Return(&grpcChannelsV1.AuthzRes{Authorized: false}, nil)
+
+	client, proxyIn := net.Pipe()
+	proxyOut, broker := net.Pipe()
+	defer client.Close()
+	defer broker.Close()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- session.Stream(context.Background(), proxyIn, proxyOut, h, nil, x509.Certificate{})
+	}()
+
+	conn := packets.NewControlPacket(packets.Connect).(*packets.ConnectPacket)
+	conn.ProtocolName, conn.ProtocolVersion = "MQTT", 4
+	conn.ClientIdentifier, conn.Username, conn.Password = clientID1, clientID1, []byte(password1)
+	conn.UsernameFlag, conn.PasswordFlag = true, true
+	go func() {
+		_ = conn.Write(client)
+	}()
+	_, err := packets.ReadPacket(broker)
+	assert.Nil(t, err, fmt.Sprintf("forward connect unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		pkt  packets.ControlPacket
+	}{
+		{
+			desc: "deliver message to subscriber with valid connection",
+			pkt:  &packets.PublishPacket{},
+		},
+		{
+			desc: "disconnect subscriber with expired connection",
+			pkt:  &packets.DisconnectPacket{},
+		},
+	}
+
+	for _, tc := range cases {
+		pub := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+		pub.TopicName, pub.Payload = topic, payload
+		go func() {
+			_ = pub.Write(broker)
+		}()
+		pkt, err := packets.ReadPacket(client)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.IsType(t, tc.pkt, pkt, fmt.Sprintf("%s: expected %T got %T\n", tc.desc, tc.pkt, pkt))
+	}
+
+	select {
+	case err := <-errs:
+		assert.ErrorContains(t, err, svcerr.ErrAuthorization.Error(), fmt.Sprintf("expected %s got %s\n", svcerr.ErrAuthorization, err))
+	case <-time.After(time.Second):
+		t.Error("expected session to be closed after connection expiry")
+	}
+}
+
+func TestAuthSubtopic(t *testing.T) {
+	handler := newHandler()
+
+	cases := []struct {
+		desc     string
+		topic    string
+		connType connections.ConnType
+		subtopic string
+	}{
+		{
+			desc:     "publish with subtopic",
+			topic:    topic + "/sensors/temp",
+			connType: connections.Publish,
+			subtopic: "sensors.temp",
+		},
+		{
+			desc:     "publish with escaped subtopic",
+			topic:    topic + "/sensors%2Ftemp",
+			connType: connections.Publish,
+			subtopic: "sensors.temp",
+		},
+		{
+			desc:     "subscribe with single level wildcard",
+			topic:    topic + "/sensors/+/room1",
+			connType: connections.Subscribe,
+			subtopic: "sensors.*.room1",
+		},
+		{
+			desc:     "subscribe with multi level wildcard",
+			topic:    topic + "/sensors/#",
+			connType: connections.Subscribe,
+			subtopic: "sensors.>",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := session.NewContext(context.TODO(), &sessionClient)
+			channelsCall := channels.On("Authorize", mock.Anything, &grpcChannelsV1.AuthzReq{
+				ChannelId:  chanID,
+				ClientId:   clientID,
+				ClientType: policies.ClientType,
+				Type:       uint32(tc.connType),
+				Protocol:   domains.MQTTProtocol,
+				Subtopic:   tc.subtopic,
+			}).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, nil)
+			var err error
+			switch tc.connType {
+			case connections.Publish:
+				err = handler.AuthPublish(ctx, &tc.topic, &payload)
+			default:
+				err = handler.AuthSubscribe(ctx, &[]string{tc.topic})
+			}
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
 			channelsCall.Unset()
 		})
 	}
@@ -527,5 +764,10 @@ func newHandler() session.Handler {
 	}
 	clients = new(climocks.ClientsServiceClient)
 	channels = new(chmocks.ChannelsServiceClient)
-	return mqtt.NewHandler(mocks.NewPublisher(), logger, clients, channels)
+	domainsCl = new(dmocks.DomainsServiceClient)
+	domainsCl.On("RetrieveIDByAlias", mock.Anything, &grpcDomainsV1.RetrieveIDByAliasReq{Alias: domainAlias}).Return(&grpcDomainsV1.RetrieveIDByAliasRes{Id: domainID}, nil)
+	domainsCl.On("RetrieveIDByAlias", mock.Anything, &grpcDomainsV1.RetrieveIDByAliasReq{Alias: invalidValue}).Return(&grpcDomainsV1.RetrieveIDByAliasRes{}, svcerr.ErrNotFound)
+	channels.On("RetrieveIDByName", mock.Anything, &grpcChannelsV1.RetrieveIDByNameReq{DomainId: domainID, Name: channelName}).Return(&grpcChannelsV1.RetrieveIDByNameRes{Id: chanID}, nil)
+	resolver := msgtopics.NewResolver(domainsCl, channels, time.Minute)
+	return mqtt.NewHandler(mocks.NewPublisher(), logger, clients, channels, resolver)
 }
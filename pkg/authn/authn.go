@@ -34,6 +34,22 @@ type Session struct {
 	UserID       string
 	DomainID     string
 	SuperAdmin   bool
+	IP           string
+}
+
+type clientIPKey struct{}
+
+// WithClientIP returns the context carrying the IP address of the client
+// presenting the token. The address is reported to the token owner if the
+// revoked personal access token is presented.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIP returns the client IP address carried by the context.
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
 }
 
 // Authn is supermq authentication library.
@@ -42,7 +42,7 @@ func NewAuthentication(ctx context.Context, cfg grpcclient.Config) (authn.Authen
 
 func (a authentication) Authenticate(ctx context.Context, token string) (authn.Session, error) {
 	if strings.HasPrefix(token, patPrefix) {
-		res, err := a.authSvcClient.AuthenticatePAT(ctx, &grpcAuthV1.AuthNReq{Token: token})
+		res, err := a.authSvcClient.AuthenticatePAT(ctx, &grpcAuthV1.AuthNReq{Token: token, Ip: authn.ClientIP(ctx)})
 		if err != nil {
 			return authn.Session{}, errors.Wrap(errors.ErrAuthentication, err)
 		}
@@ -97,7 +97,8 @@ func (a authorization) checkDomain(ctx context.Context, subjectType, subject, do
 		})
 
 		return err
-	case domains.DisabledStatus:
+	// Deleted domains can be restored by their admins until they are purged.
+	case domains.DisabledStatus, domains.DeletedStatus:
 		_, err := a.authSvcClient.Authorize(ctx, &grpcAuthV1.AuthZReq{
 			Subject:     subject,
 			SubjectType: subjectType,
@@ -126,6 +127,7 @@ func (a authorization) AuthorizePAT(ctx context.Context, pr authz.PatReq) error
 	req := grpcAuthV1.AuthZPatReq{
 		UserId:                   pr.UserID,
 		PatId:                    pr.PatID,
+		Ip:                       pr.IP,
 		PlatformEntityType:       uint32(pr.PlatformEntityType),
 		OptionalDomainId:         pr.OptionalDomainID,
 		OptionalDomainEntityType: uint32(pr.OptionalDomainEntityType),
@@ -54,6 +54,7 @@ type PatReq struct {
 	OptionalDomainEntityType auth.DomainEntityType   `json:"optional_domain_entity_type,omitempty"` // Optional domain entity type
 	Operation                auth.OperationType      `json:"operation,omitempty"`                   // Operation
 	EntityIDs                []string                `json:"entityIDs,omitempty"`                   // EntityIDs
+	IP                       string                  `json:"ip,omitempty"`                          // Source IP
 }
 
 // Authz is supermq authorization library.
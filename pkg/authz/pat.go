@@ -0,0 +1,40 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"context"
+
+	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+)
+
+// AuthorizeChannelPAT checks that the personal access token is allowed to
+// perform the messaging operation on the channel and returns the domain user
+// ID of the token owner. It is used by the protocol adapters.
+func AuthorizeChannelPAT(ctx context.Context, authz Authorization, channels grpcChannelsV1.ChannelsServiceClient, session authn.Session, chanID string, operation auth.OperationType) (string, error) {
+	res, err := channels.RetrieveEntity(ctx, &grpcCommonV1.RetrieveEntityReq{Id: chanID})
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	domainID := res.GetEntity().GetDomainId()
+	if err := authz.AuthorizePAT(ctx, PatReq{
+		UserID:                   session.UserID,
+		PatID:                    session.PatID,
+		PlatformEntityType:       auth.PlatformDomainsScope,
+		OptionalDomainID:         domainID,
+		OptionalDomainEntityType: auth.DomainChannelsScope,
+		Operation:                operation,
+		EntityIDs:                []string{chanID},
+		IP:                       session.IP,
+	}); err != nil {
+		return "", errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+	}
+
+	return auth.EncodeDomainUserID(domainID, session.UserID), nil
+}
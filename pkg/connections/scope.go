@@ -0,0 +1,128 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package connections
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+const (
+	subtopicSep      = "."
+	singleLevelToken = "*"
+	multiLevelToken  = ">"
+)
+
+var (
+	// ErrInvalidSubtopic indicates malformed subtopic pattern.
+	ErrInvalidSubtopic = errors.New("invalid subtopic pattern")
+
+	// ErrInvalidExpiration indicates connection expiration time in the past.
+	ErrInvalidExpiration = errors.New("connection expiration time must be in the future")
+)
+
+// Scope restricts a connection in time and to a set of subtopics.
+// Zero value Scope places no restrictions on the connection.
+type Scope struct {
+	// ExpiresAt is the time after which the connection is no longer valid.
+	// Zero value means that the connection never expires. The expiry is
+	// checked when the client publishes or subscribes, so the subscriptions
+	// made before the expiry keep receiving messages until they are closed.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+
+	// Subtopics is the list of subtopic patterns the connection is
+	// restricted to. Patterns consist of dot separated tokens where "*"
+	// matches exactly one token and ">", as the last token, matches one
+	// or more tokens. Empty list means that all subtopics are allowed.
+	Subtopics []string `json:"subtopics,omitempty"`
+}
+
+// Validate checks that the expiration time is not in the past and that all
+// the subtopic patterns are well formed.
+func (s Scope) Validate(now time.Time) error {
+	if s.Expired(now) {
+		return ErrInvalidExpiration
+	}
+	for _, pattern := range s.Subtopics {
+		if err := validateSubtopic(pattern); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Expired returns true if the connection is no longer valid at the given time.
+func (s Scope) Expired(now time.Time) bool {
+	return !s.ExpiresAt.IsZero() && !s.ExpiresAt.After(now)
+}
+
+// AllowsSubtopic returns true if the subtopic is covered by any of the scope
+// subtopic patterns. The subtopic may contain wildcards itself, as it does on
+// subscribe, in which case everything it matches must be matched by a pattern.
+func (s Scope) AllowsSubtopic(subtopic string) bool {
+	if len(s.Subtopics) == 0 {
+		return true
+	}
+	if subtopic == "" {
+		return false
+	}
+	for _, pattern := range s.Subtopics {
+		if matchSubtopic(pattern, subtopic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allows returns true if the connection is valid at the given time for the
+// given subtopic.
+func (s Scope) Allows(subtopic string, now time.Time) bool {
+	return !s.Expired(now) && s.AllowsSubtopic(subtopic)
+}
+
+func validateSubtopic(pattern string) error {
+	tokens := strings.Split(pattern, subtopicSep)
+	for i, token := range tokens {
+		switch {
+		case token == "":
+			return errors.Wrap(ErrInvalidSubtopic, fmt.Errorf("%s", pattern))
+		case token == multiLevelToken && i != len(tokens)-1:
+			return errors.Wrap(ErrInvalidSubtopic, fmt.Errorf("%s", pattern))
+		case len(token) > 1 && strings.ContainsAny(token, singleLevelToken+multiLevelToken):
+			return errors.Wrap(ErrInvalidSubtopic, fmt.Errorf("%s", pattern))
+		}
+	}
+
+	return nil
+}
+
+func matchSubtopic(pattern, subtopic string) bool {
+	pts := strings.Split(pattern, subtopicSep)
+	sts := strings.Split(subtopic, subtopicSep)
+	for i, pt := range pts {
+		if pt == multiLevelToken {
+			return i < len(sts)
+		}
+		if i >= len(sts) {
+			return false
+		}
+		switch pt {
+		case singleLevelToken:
+			if sts[i] == multiLevelToken {
+				return false
+			}
+		default:
+			if pt != sts[i] {
+				return false
+			}
+		}
+	}
+
+	return len(pts) == len(sts)
+}
@@ -0,0 +1,193 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package connections_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/connections"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopeValidate(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		desc  string
+		scope connections.Scope
+		err   error
+	}{
+		{
+			desc:  "validate empty scope",
+			scope: connections.Scope{},
+			err:   nil,
+		},
+		{
+			desc:  "validate scope with future expiration",
+			scope: connections.Scope{ExpiresAt: now.Add(time.Hour)},
+			err:   nil,
+		},
+		{
+			desc:  "validate scope with past expiration",
+			scope: connections.Scope{ExpiresAt: now.Add(-time.Hour)},
+			err:   connections.ErrInvalidExpiration,
+		},
+		{
+			desc:  "validate scope with valid subtopics",
+			scope: connections.Scope{Subtopics: []string{"sensors.temp.*", "sensors.>", "*"}},
+			err:   nil,
+		},
+		{
+			desc:  "validate scope with empty token",
+			scope: connections.Scope{Subtopics: []string{"sensors..temp"}},
+			err:   connections.ErrInvalidSubtopic,
+		},
+		{
+			desc:  "validate scope with empty subtopic",
+			scope: connections.Scope{Subtopics: []string{""}},
+			err:   connections.ErrInvalidSubtopic,
+		},
+		{
+			desc:  "validate scope with multi level wildcard not at the end",
+			scope: connections.Scope{Subtopics: []string{"sensors.>.temp"}},
+			err:   connections.ErrInvalidSubtopic,
+		},
+		{
+			desc:  "validate scope with wildcard within token",
+			scope: connections.Scope{Subtopics: []string{"sensors.temp*"}},
+			err:   connections.ErrInvalidSubtopic,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.scope.Validate(now)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestScopeAllows(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		desc     string
+		scope    connections.Scope
+		subtopic string
+		allowed  bool
+	}{
+		{
+			desc:     "allow any subtopic with empty scope",
+			scope:    connections.Scope{},
+			subtopic: "sensors.temp",
+			allowed:  true,
+		},
+		{
+			desc:     "allow empty subtopic with empty scope",
+			scope:    connections.Scope{},
+			subtopic: "",
+			allowed:  true,
+		},
+		{
+			desc:     "deny expired connection",
+			scope:    connections.Scope{ExpiresAt: now.Add(-time.Minute)},
+			subtopic: "sensors.temp",
+			allowed:  false,
+		},
+		{
+			desc:     "allow connection before expiration",
+			scope:    connections.Scope{ExpiresAt: now.Add(time.Minute)},
+			subtopic: "sensors.temp",
+			allowed:  true,
+		},
+		{
+			desc:     "allow exact subtopic",
+			scope:    connections.Scope{Subtopics: []string{"sensors.temp"}},
+			subtopic: "sensors.temp",
+			allowed:  true,
+		},
+		{
+			desc:     "deny empty subtopic with restricted scope",
+			scope:    connections.Scope{Subtopics: []string{"sensors.temp"}},
+			subtopic: "",
+			allowed:  false,
+		},
+		{
+			desc:     "allow subtopic matching single level wildcard",
+			scope:    connections.Scope{Subtopics: []string{"sensors.temp.*"}},
+			subtopic: "sensors.temp.room1",
+			allowed:  true,
+		},
+		{
+			desc:     "deny subtopic deeper than single level wildcard",
+			scope:    connections.Scope{Subtopics: []string{"sensors.temp.*"}},
+			subtopic: "sensors.temp.room1.max",
+			allowed:  false,
+		},
+		{
+			desc:     "deny subtopic shorter than single level wildcard",
+			scope:    connections.Scope{Subtopics: []string{"sensors.temp.*"}},
+			subtopic: "sensors.temp",
+			allowed:  false,
+		},
+		{
+			desc:     "allow subtopic matching multi level wildcard",
+			scope:    connections.Scope{Subtopics: []string{"sensors.>"}},
+			subtopic: "sensors.temp.room1.max",
+			allowed:  true,
+		},
+		{
+			desc:     "deny subtopic shorter than multi level wildcard",
+			scope:    connections.Scope{Subtopics: []string{"sensors.>"}},
+			subtopic: "sensors",
+			allowed:  false,
+		},
+		{
+			desc:     "allow subtopic matching any of the patterns",
+			scope:    connections.Scope{Subtopics: []string{"actuators.*", "sensors.temp.*"}},
+			subtopic: "sensors.temp.room1",
+			allowed:  true,
+		},
+		{
+			desc:     "deny subtopic not matching patterns",
+			scope:    connections.Scope{Subtopics: []string{"sensors.temp.*"}},
+			subtopic: "sensors.humidity.room1",
+			allowed:  false,
+		},
+		{
+			desc:     "allow wildcard subscription covered by pattern",
+			scope:    connections.Scope{Subtopics: []string{"sensors.temp.*"}},
+			subtopic: "sensors.temp.*",
+			allowed:  true,
+		},
+		{
+			desc:     "allow wildcard subscription covered by multi level wildcard",
+			scope:    connections.Scope{Subtopics: []string{"sensors.>"}},
+			subtopic: "sensors.*.room1",
+			allowed:  true,
+		},
+		{
+			desc:     "deny multi level wildcard subscription wider than pattern",
+			scope:    connections.Scope{Subtopics: []string{"sensors.temp.*"}},
+			subtopic: "sensors.temp.>",
+			allowed:  false,
+		},
+		{
+			desc:     "deny single level wildcard subscription wider than pattern",
+			scope:    connections.Scope{Subtopics: []string{"sensors.temp.room1"}},
+			subtopic: "sensors.temp.*",
+			allowed:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			allowed := tc.scope.Allows(tc.subtopic, now)
+			assert.Equal(t, tc.allowed, allowed, fmt.Sprintf("%s: expected %t, got %t", tc.desc, tc.allowed, allowed))
+		})
+	}
+}
@@ -24,6 +24,10 @@ var (
 	errDecodeDisableDomainEvent = errors.New("failed to decode domain disable event")
 	errDecodeFreezeDomainEvent  = errors.New("failed to decode domain freeze event")
 	errDecodeRemoveDomainsEvent = errors.New("failed to decode domain remove  event")
+	errDecodeRestoreDomainEvent = errors.New("failed to decode domain restore event")
+	errDecodePurgeDomainEvent   = errors.New("failed to decode domain purge event")
+	errDecodeQuotasDomainEvent  = errors.New("failed to decode domain update quotas event")
+	errDecodeTransferEvent      = errors.New("failed to decode domain transfer event")
 
 	errID            = errors.New("missing or invalid 'id'")
 	errName          = errors.New("missing or invalid 'name'")
@@ -34,6 +38,8 @@ var (
 	errCreatedBy     = errors.New("missing or invalid 'created_by'")
 	errCreatedAt     = errors.New("failed to parse 'created_at' time")
 	errUpdatedAt     = errors.New("failed to parse 'updated_at' time")
+	errQuotas        = errors.New("missing or invalid 'quotas'")
+	errNewOwnerID    = errors.New("missing or invalid 'new_owner_id'")
 )
 
 func ToDomains(data map[string]interface{}) (domains.Domain, error) {
@@ -261,11 +267,90 @@ func decodeUserDeleteDomainEvent(_ map[string]interface{}) (domains.Domain, erro
 }
 
 func decodeDeleteDomainEvent(data map[string]interface{}) (domains.Domain, error) {
+	return decodeStatusDomainEvent(data, errDecodeRemoveDomainsEvent)
+}
+
+func decodeRestoreDomainEvent(data map[string]interface{}) (domains.Domain, error) {
+	return decodeStatusDomainEvent(data, errDecodeRestoreDomainEvent)
+}
+
+func decodeStatusDomainEvent(data map[string]interface{}, errDecode error) (domains.Domain, error) {
 	var d domains.Domain
 	id, ok := data["id"].(string)
 	if !ok {
-		return domains.Domain{}, errors.Wrap(errDecodeRemoveDomainsEvent, errID)
+		return domains.Domain{}, errors.Wrap(errDecode, errID)
 	}
 	d.ID = id
+
+	uby, ok := data["updated_by"].(string)
+	if ok {
+		d.UpdatedBy = uby
+	}
+
+	uat, ok := data["updated_at"].(string)
+	if ok {
+		ut, err := time.Parse(layout, uat)
+		if err != nil {
+			return domains.Domain{}, errors.Wrap(errDecode, errors.Wrap(errUpdatedAt, err))
+		}
+		d.UpdatedAt = ut
+	}
+
+	return d, nil
+}
+
+func decodeTransferDomainEvent(data map[string]interface{}) (domains.Domain, string, error) {
+	d, err := decodeStatusDomainEvent(data, errDecodeTransferEvent)
+	if err != nil {
+		return domains.Domain{}, "", err
+	}
+
+	newOwnerID, ok := data["new_owner_id"].(string)
+	if !ok || newOwnerID == "" {
+		return domains.Domain{}, "", errors.Wrap(errDecodeTransferEvent, errNewOwnerID)
+	}
+
+	return d, newOwnerID, nil
+}
+
+func decodePurgeDomainEvent(data map[string]interface{}) (string, error) {
+	id, ok := data["id"].(string)
+	if !ok || id == "" {
+		return "", errors.Wrap(errDecodePurgeDomainEvent, errID)
+	}
+
+	return id, nil
+}
+
+func decodeUpdateQuotasDomainEvent(data map[string]interface{}) (domains.Domain, error) {
+	d, err := decodeStatusDomainEvent(data, errDecodeQuotasDomainEvent)
+	if err != nil {
+		return domains.Domain{}, err
+	}
+
+	iq, ok := data["quotas"].(map[string]interface{})
+	if !ok {
+		return domains.Domain{}, errors.Wrap(errDecodeQuotasDomainEvent, errQuotas)
+	}
+	limits := map[string]*uint64{
+		"clients":             &d.Quotas.Clients,
+		"channels":            &d.Quotas.Channels,
+		"groups":              &d.Quotas.Groups,
+		"members":             &d.Quotas.Members,
+		"messages_per_minute": &d.Quotas.MessagesPerMinute,
+		"storage":             &d.Quotas.Storage,
+	}
+	for key, limit := range limits {
+		switch v := iq[key].(type) {
+		case nil:
+		case float64:
+			*limit = uint64(v)
+		case uint64:
+			*limit = v
+		default:
+			return domains.Domain{}, errors.Wrap(errDecodeQuotasDomainEvent, errQuotas)
+		}
+	}
+
 	return d, nil
 }
@@ -10,14 +10,17 @@ import (
 
 	"github.com/absmach/supermq/domains"
 	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	"github.com/absmach/supermq/pkg/events"
 	"github.com/absmach/supermq/pkg/events/store"
 	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/roles"
 	rconsumer "github.com/absmach/supermq/pkg/roles/rolemanager/events/consumer"
 )
 
 const (
-	stream = "events.supermq.domains"
+	stream   = "events.supermq.domains"
+	defLimit = 100
 
 	create     = "domain.create"
 	update     = "domain.update"
@@ -25,6 +28,10 @@ const (
 	disable    = "domain.disable"
 	freeze     = "domain.freeze"
 	delete     = "domain.delete"
+	restore    = "domain.restore"
+	purge      = "domain.purge"
+	quotas     = "domain.update_quotas"
+	transfer   = "domain.transfer"
 	userDelete = "domain.user_delete"
 )
 
@@ -37,14 +44,22 @@ var (
 	errFreezeDomainGroupEvent  = errors.New("failed to consume domain freeze event")
 	errUserDeleteDomainEvent   = errors.New("failed to consume domain user delete event")
 	errDeleteDomainEvent       = errors.New("failed to consume domain delete event")
+	errRestoreDomainEvent      = errors.New("failed to consume domain restore event")
+	errPurgeDomainEvent        = errors.New("failed to consume domain purge event")
+	errQuotasDomainEvent       = errors.New("failed to consume domain update quotas event")
+	errTransferDomainEvent     = errors.New("failed to consume domain transfer event")
+	errAdminRoleNotFound       = errors.New("domain admin role not found")
 )
 
 type eventHandler struct {
 	repo              domains.Repository
+	purgers           []domains.Purger
 	rolesEventHandler rconsumer.EventHandler
 }
 
-func DomainsEventsSubscribe(ctx context.Context, repo domains.Repository, esURL, esConsumerName string, logger *slog.Logger) error {
+// DomainsEventsSubscribe subscribes to the domains events and keeps the domains
+// replica up to date. The purgers remove the entities of the purged domains.
+func DomainsEventsSubscribe(ctx context.Context, repo domains.Repository, esURL, esConsumerName string, logger *slog.Logger, purgers ...domains.Purger) error {
 	subscriber, err := store.NewSubscriber(ctx, esURL, logger)
 	if err != nil {
 		return err
@@ -53,7 +68,25 @@ func DomainsEventsSubscribe(ctx context.Context, repo domains.Repository, esURL,
 	subConfig := events.SubscriberConfig{
 		Stream:         stream,
 		Consumer:       esConsumerName,
-		Handler:        NewEventHandler(repo),
+		Handler:        NewEventHandler(repo, purgers...),
+		DeliveryPolicy: messaging.DeliverNewPolicy,
+		Ordered:        true,
+	}
+	return subscriber.Subscribe(ctx, subConfig)
+}
+
+// DomainsPurgeSubscribe subscribes to the domains events and removes the
+// entities of the purged domains, for the services without domains replica.
+func DomainsPurgeSubscribe(ctx context.Context, purger domains.Purger, esURL, esConsumerName string, logger *slog.Logger) error {
+	subscriber, err := store.NewSubscriber(ctx, esURL, logger)
+	if err != nil {
+		return err
+	}
+
+	subConfig := events.SubscriberConfig{
+		Stream:         stream,
+		Consumer:       esConsumerName,
+		Handler:        NewPurgeEventHandler(purger),
 		DeliveryPolicy: messaging.DeliverNewPolicy,
 		Ordered:        true,
 	}
@@ -61,10 +94,11 @@ func DomainsEventsSubscribe(ctx context.Context, repo domains.Repository, esURL,
 }
 
 // NewEventHandler returns new event store handler.
-func NewEventHandler(repo domains.Repository) events.EventHandler {
+func NewEventHandler(repo domains.Repository, purgers ...domains.Purger) events.EventHandler {
 	reh := rconsumer.NewEventHandler("domain", repo)
 	return &eventHandler{
 		repo:              repo,
+		purgers:           purgers,
 		rolesEventHandler: reh,
 	}
 }
@@ -95,6 +129,14 @@ func (es *eventHandler) Handle(ctx context.Context, event events.Event) error {
 		return es.userDeleteDomainHandler(ctx, msg)
 	case delete:
 		return es.deleteDomainHandler(ctx, msg)
+	case restore:
+		return es.restoreDomainHandler(ctx, msg)
+	case purge:
+		return es.purgeDomainHandler(ctx, msg)
+	case quotas:
+		return es.updateQuotasDomainHandler(ctx, msg)
+	case transfer:
+		return es.transferDomainHandler(ctx, msg)
 	}
 
 	return es.rolesEventHandler.Handle(ctx, op, msg)
@@ -197,9 +239,137 @@ func (es *eventHandler) deleteDomainHandler(ctx context.Context, data map[string
 		return errors.Wrap(errDeleteDomainEvent, err)
 	}
 
-	if err := es.repo.Delete(ctx, d.ID); err != nil {
+	// The domain already deleted in the replica is not found.
+	if _, err := es.repo.SoftDelete(ctx, d.ID, d.UpdatedBy, d.UpdatedAt); err != nil && err != repoerr.ErrNotFound {
 		return errors.Wrap(errDeleteDomainEvent, err)
 	}
 
 	return nil
 }
+
+func (es *eventHandler) restoreDomainHandler(ctx context.Context, data map[string]interface{}) error {
+	d, err := decodeRestoreDomainEvent(data)
+	if err != nil {
+		return errors.Wrap(errRestoreDomainEvent, err)
+	}
+
+	// The domain gets the status it had before the deletion, the domain
+	// already restored in the replica is not found.
+	if _, err := es.repo.Restore(ctx, d.ID, d.UpdatedBy, d.UpdatedAt); err != nil && err != repoerr.ErrNotFound {
+		return errors.Wrap(errRestoreDomainEvent, err)
+	}
+
+	return nil
+}
+
+func (es *eventHandler) purgeDomainHandler(ctx context.Context, data map[string]interface{}) error {
+	id, err := decodePurgeDomainEvent(data)
+	if err != nil {
+		return errors.Wrap(errPurgeDomainEvent, err)
+	}
+
+	for _, p := range es.purgers {
+		if err := p.PurgeDomain(ctx, id); err != nil {
+			return errors.Wrap(errPurgeDomainEvent, err)
+		}
+	}
+
+	// Domain roles are removed by the database cascade.
+	if err := es.repo.Delete(ctx, id); err != nil && err != repoerr.ErrNotFound {
+		return errors.Wrap(errPurgeDomainEvent, err)
+	}
+
+	return nil
+}
+
+type purgeEventHandler struct {
+	purger domains.Purger
+}
+
+// NewPurgeEventHandler returns new event store handler that
+// handles only the domain purge events.
+func NewPurgeEventHandler(purger domains.Purger) events.EventHandler {
+	return &purgeEventHandler{
+		purger: purger,
+	}
+}
+
+func (es *purgeEventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	op, ok := msg["operation"]
+	if !ok {
+		return errNoOperationKey
+	}
+	if op != purge {
+		return nil
+	}
+
+	id, err := decodePurgeDomainEvent(msg)
+	if err != nil {
+		return errors.Wrap(errPurgeDomainEvent, err)
+	}
+	if err := es.purger.PurgeDomain(ctx, id); err != nil {
+		return errors.Wrap(errPurgeDomainEvent, err)
+	}
+
+	return nil
+}
+
+func (es *eventHandler) updateQuotasDomainHandler(ctx context.Context, data map[string]interface{}) error {
+	d, err := decodeUpdateQuotasDomainEvent(data)
+	if err != nil {
+		return errors.Wrap(errQuotasDomainEvent, err)
+	}
+
+	if _, err := es.repo.Update(ctx, d.ID, domains.DomainReq{Quotas: &d.Quotas, UpdatedBy: &d.UpdatedBy, UpdatedAt: &d.UpdatedAt}); err != nil {
+		return errors.Wrap(errQuotasDomainEvent, err)
+	}
+
+	return nil
+}
+
+// transferDomainHandler makes the new owner the only member of the domain
+// built-in admin role in the replica, as the domains service does.
+func (es *eventHandler) transferDomainHandler(ctx context.Context, data map[string]interface{}) error {
+	d, newOwnerID, err := decodeTransferDomainEvent(data)
+	if err != nil {
+		return errors.Wrap(errTransferDomainEvent, err)
+	}
+
+	role, err := es.adminRole(ctx, d.ID)
+	if err != nil {
+		return errors.Wrap(errTransferDomainEvent, err)
+	}
+	if err := es.repo.RoleRemoveAllMembers(ctx, role); err != nil {
+		return errors.Wrap(errTransferDomainEvent, err)
+	}
+	if _, err := es.repo.RoleAddMembers(ctx, role, []string{newOwnerID}); err != nil {
+		return errors.Wrap(errTransferDomainEvent, err)
+	}
+	if _, err := es.repo.Update(ctx, d.ID, domains.DomainReq{UpdatedBy: &d.UpdatedBy, UpdatedAt: &d.UpdatedAt}); err != nil {
+		return errors.Wrap(errTransferDomainEvent, err)
+	}
+
+	return nil
+}
+
+func (es *eventHandler) adminRole(ctx context.Context, domainID string) (roles.Role, error) {
+	for offset := uint64(0); ; offset += defLimit {
+		page, err := es.repo.RetrieveAllRoles(ctx, domainID, defLimit, offset)
+		if err != nil {
+			return roles.Role{}, err
+		}
+		for _, r := range page.Roles {
+			if r.Name == domains.BuiltInRoleAdmin.String() {
+				return r, nil
+			}
+		}
+		if len(page.Roles) == 0 || offset+defLimit >= page.Total {
+			return roles.Role{}, errAdminRoleNotFound
+		}
+	}
+}
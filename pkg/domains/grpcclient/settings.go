@@ -0,0 +1,47 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpcclient
+
+import (
+	"context"
+
+	grpcDomainsV1 "github.com/absmach/supermq/api/grpc/domains/v1"
+	"github.com/absmach/supermq/domains"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
+)
+
+type settings struct {
+	domainsSvcClient grpcDomainsV1.DomainsServiceClient
+}
+
+var _ pkgDomains.Settings = (*settings)(nil)
+
+// NewSettings returns the domain settings retrieved over the domains gRPC API.
+func NewSettings(client grpcDomainsV1.DomainsServiceClient) pkgDomains.Settings {
+	return settings{domainsSvcClient: client}
+}
+
+func (s settings) RetrieveSettings(ctx context.Context, domainID string) (domains.Settings, error) {
+	res, err := s.domainsSvcClient.RetrieveSettings(ctx, &grpcDomainsV1.RetrieveSettingsReq{DomainId: domainID})
+	if err != nil {
+		return domains.Settings{}, err
+	}
+
+	return domains.Settings{
+		Version:               res.GetVersion(),
+		AllowedProtocols:      res.GetAllowedProtocols(),
+		ClientSecretPolicy:    domains.SecretPolicy{MinLength: res.GetClientSecretMinLength()},
+		MessageRetentionDays:  res.GetMessageRetentionDays(),
+		InvitationExpiryHours: res.GetInvitationExpiryHours(),
+		SMTP: domains.SMTP{
+			FromAddress: res.GetSmtpFromAddress(),
+			FromName:    res.GetSmtpFromName(),
+		},
+		Branding: domains.Branding{
+			LogoURL:      res.GetLogoUrl(),
+			PrimaryColor: res.GetPrimaryColor(),
+			FooterText:   res.GetFooterText(),
+		},
+	}, nil
+}
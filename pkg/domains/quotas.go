@@ -0,0 +1,32 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package domains
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/domains"
+)
+
+// Quotas retrieves the domain quotas.
+//
+//go:generate mockery --name Quotas --output=./mocks --filename quotas.go --quiet --note "Copyright (c) Abstract Machines"
+type Quotas interface {
+	// RetrieveQuotas retrieves the quotas of the domain.
+	RetrieveQuotas(ctx context.Context, domainID string) (domains.Quotas, error)
+
+	// LockQuotas locks the quotas of the domain until the returned unlock
+	// function is called, so the concurrent requests can't exceed the quotas
+	// between counting the usage and saving the entities.
+	LockQuotas(ctx context.Context, domainID string) (func(), error)
+}
+
+// MessageLimiter limits the rate of the messages published in the domains.
+//
+//go:generate mockery --name MessageLimiter --output=./mocks --filename messagelimiter.go --quiet --note "Copyright (c) Abstract Machines"
+type MessageLimiter interface {
+	// Allow returns ErrQuotaExceeded if publishing one more message exceeds
+	// the domain messages per minute quota.
+	Allow(ctx context.Context, domainID string) error
+}
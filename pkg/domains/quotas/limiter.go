@@ -0,0 +1,106 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package quotas
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"golang.org/x/time/rate"
+)
+
+// idleLimiter is the time after which the rate limiter of the domain without
+// messages is full again, so it is removed without losing its state.
+const idleLimiter = time.Minute
+
+type domainLimiter struct {
+	perMinute uint64
+	limiter   *rate.Limiter
+	checkedAt time.Time
+}
+
+type messageLimiter struct {
+	quotas  pkgDomains.Quotas
+	refresh time.Duration
+	mu      sync.Mutex
+	domains map[string]*domainLimiter
+	evictAt time.Time
+}
+
+var _ pkgDomains.MessageLimiter = (*messageLimiter)(nil)
+
+// NewMessageLimiter returns the limiter of the messages published in the domains.
+// The domain quotas are re-read once the refresh period passes, so the quota
+// changes apply within the period. The limit is counted per service instance.
+// The limiters of the domains without messages are removed periodically.
+func NewMessageLimiter(quotas pkgDomains.Quotas, refresh time.Duration) pkgDomains.MessageLimiter {
+	return &messageLimiter{
+		quotas:  quotas,
+		refresh: refresh,
+		domains: make(map[string]*domainLimiter),
+	}
+}
+
+func (ml *messageLimiter) Allow(ctx context.Context, domainID string) error {
+	dl, err := ml.domainLimiter(ctx, domainID)
+	if err != nil {
+		return err
+	}
+	if dl.limiter == nil || dl.limiter.Allow() {
+		return nil
+	}
+
+	return svcerr.ErrQuotaExceeded
+}
+
+func (ml *messageLimiter) domainLimiter(ctx context.Context, domainID string) (*domainLimiter, error) {
+	ml.mu.Lock()
+	dl, ok := ml.domains[domainID]
+	if ok && time.Since(dl.checkedAt) < ml.refresh {
+		ml.mu.Unlock()
+		return dl, nil
+	}
+	ml.mu.Unlock()
+
+	q, err := ml.quotas.RetrieveQuotas(ctx, domainID)
+	if err != nil {
+		return nil, err
+	}
+
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+	ml.evict()
+	dl, ok = ml.domains[domainID]
+	// The rate limiter keeps its state as long as the quota does not change.
+	if !ok || dl.perMinute != q.MessagesPerMinute {
+		dl = &domainLimiter{perMinute: q.MessagesPerMinute}
+		if q.MessagesPerMinute > 0 {
+			dl.limiter = rate.NewLimiter(rate.Limit(float64(q.MessagesPerMinute)/60), int(q.MessagesPerMinute))
+		}
+		ml.domains[domainID] = dl
+	}
+	dl.checkedAt = time.Now()
+
+	return dl, nil
+}
+
+// evict removes the idle limiters. The limiter in use is refreshed at least
+// once per refresh period, so the limiter not refreshed within the refresh
+// and idle periods has not been used for the idle period.
+func (ml *messageLimiter) evict() {
+	now := time.Now()
+	if now.Before(ml.evictAt) {
+		return
+	}
+	period := ml.refresh + idleLimiter
+	for id, dl := range ml.domains {
+		if now.Sub(dl.checkedAt) > period {
+			delete(ml.domains, id)
+		}
+	}
+	ml.evictAt = now.Add(period)
+}
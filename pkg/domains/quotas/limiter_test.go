@@ -0,0 +1,83 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package quotas_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/domains"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/domains/mocks"
+	"github.com/absmach/supermq/pkg/domains/quotas"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+)
+
+var domainID = testsutil.GenerateUUID(&testing.T{})
+
+func TestAllow(t *testing.T) {
+	cases := []struct {
+		desc      string
+		quotas    domains.Quotas
+		quotasErr error
+		messages  int
+		err       error
+	}{
+		{
+			desc:     "allow messages without quota",
+			quotas:   domains.Quotas{},
+			messages: 100,
+			err:      nil,
+		},
+		{
+			desc:     "allow messages within quota",
+			quotas:   domains.Quotas{MessagesPerMinute: 10},
+			messages: 10,
+			err:      nil,
+		},
+		{
+			desc:     "allow messages exceeding quota",
+			quotas:   domains.Quotas{MessagesPerMinute: 10},
+			messages: 11,
+			err:      svcerr.ErrQuotaExceeded,
+		},
+		{
+			desc:      "allow messages with failed to retrieve quotas",
+			quotasErr: svcerr.ErrViewEntity,
+			messages:  1,
+			err:       svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dq := new(mocks.Quotas)
+			dq.On("RetrieveQuotas", context.Background(), domainID).Return(tc.quotas, tc.quotasErr)
+			limiter := quotas.NewMessageLimiter(dq, time.Minute)
+			var err error
+			for i := 0; i < tc.messages; i++ {
+				if err = limiter.Allow(context.Background(), domainID); err != nil {
+					break
+				}
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestAllowQuotaChange(t *testing.T) {
+	dq := new(mocks.Quotas)
+	dq.On("RetrieveQuotas", context.Background(), domainID).Return(domains.Quotas{MessagesPerMinute: 1}, nil).Once()
+	dq.On("RetrieveQuotas", context.Background(), domainID).Return(domains.Quotas{}, nil)
+	limiter := quotas.NewMessageLimiter(dq, 0)
+
+	err := limiter.Allow(context.Background(), domainID)
+	assert.Nil(t, err, fmt.Sprintf("expected nil got %s", err))
+	err = limiter.Allow(context.Background(), domainID)
+	assert.Nil(t, err, fmt.Sprintf("expected quota change to apply, got %s", err))
+}
@@ -0,0 +1,47 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package quotas
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/domains"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+)
+
+type replica struct {
+	repo domains.Repository
+}
+
+var _ pkgDomains.Quotas = (*replica)(nil)
+
+// NewReplica returns the quotas read from the service domains replica.
+// Domains that are not replicated yet have no quotas.
+func NewReplica(repo domains.Repository) pkgDomains.Quotas {
+	return replica{repo: repo}
+}
+
+func (r replica) RetrieveQuotas(ctx context.Context, domainID string) (domains.Quotas, error) {
+	dom, err := r.repo.RetrieveByID(ctx, domainID)
+	switch {
+	case err == nil:
+		return dom.Quotas, nil
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return domains.Quotas{}, nil
+	default:
+		return domains.Quotas{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+}
+
+func (r replica) LockQuotas(ctx context.Context, domainID string) (func(), error) {
+	unlock, err := r.repo.LockQuotas(ctx, domainID)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return unlock, nil
+}
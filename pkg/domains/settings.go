@@ -0,0 +1,18 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package domains
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/domains"
+)
+
+// Settings retrieves the domain settings.
+//
+//go:generate mockery --name Settings --output=./mocks --filename settings.go --quiet --note "Copyright (c) Abstract Machines"
+type Settings interface {
+	// RetrieveSettings retrieves the settings of the domain.
+	RetrieveSettings(ctx context.Context, domainID string) (domains.Settings, error)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package settings
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/absmach/supermq/domains"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
+)
+
+type entry struct {
+	settings  domains.Settings
+	checkedAt time.Time
+}
+
+type cache struct {
+	settings pkgDomains.Settings
+	refresh  time.Duration
+	mu       sync.Mutex
+	domains  map[string]entry
+}
+
+var _ pkgDomains.Settings = (*cache)(nil)
+
+// NewCache returns the domain settings kept in memory. The domain settings
+// are re-read once the refresh period passes, so the settings changes apply
+// within the period.
+func NewCache(settings pkgDomains.Settings, refresh time.Duration) pkgDomains.Settings {
+	return &cache{
+		settings: settings,
+		refresh:  refresh,
+		domains:  make(map[string]entry),
+	}
+}
+
+func (c *cache) RetrieveSettings(ctx context.Context, domainID string) (domains.Settings, error) {
+	c.mu.Lock()
+	e, ok := c.domains[domainID]
+	c.mu.Unlock()
+	if ok && time.Since(e.checkedAt) < c.refresh {
+		return e.settings, nil
+	}
+
+	s, err := c.settings.RetrieveSettings(ctx, domainID)
+	if err != nil {
+		return domains.Settings{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Keep the newer settings if they have been read concurrently.
+	if e, ok := c.domains[domainID]; ok && e.settings.Version > s.Version {
+		return e.settings, nil
+	}
+	c.domains[domainID] = entry{settings: s, checkedAt: time.Now()}
+
+	return s, nil
+}
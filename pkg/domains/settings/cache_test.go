@@ -0,0 +1,78 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package settings_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/domains"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/domains/mocks"
+	"github.com/absmach/supermq/pkg/domains/settings"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+)
+
+var domainID = testsutil.GenerateUUID(&testing.T{})
+
+func TestRetrieveSettings(t *testing.T) {
+	cases := []struct {
+		desc        string
+		settings    domains.Settings
+		settingsErr error
+		err         error
+	}{
+		{
+			desc:     "retrieve settings successfully",
+			settings: domains.Settings{Version: 1, AllowedProtocols: []string{domains.MQTTProtocol}},
+			err:      nil,
+		},
+		{
+			desc:        "retrieve settings with failed to retrieve settings",
+			settingsErr: svcerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ds := new(mocks.Settings)
+			ds.On("RetrieveSettings", context.Background(), domainID).Return(tc.settings, tc.settingsErr)
+			cache := settings.NewCache(ds, time.Minute)
+			s, err := cache.RetrieveSettings(context.Background(), domainID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.settings, s, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.settings, s))
+		})
+	}
+}
+
+func TestRetrieveSettingsCached(t *testing.T) {
+	ds := new(mocks.Settings)
+	ds.On("RetrieveSettings", context.Background(), domainID).Return(domains.Settings{Version: 1}, nil).Once()
+	cache := settings.NewCache(ds, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		s, err := cache.RetrieveSettings(context.Background(), domainID)
+		assert.Nil(t, err, fmt.Sprintf("expected nil got %s", err))
+		assert.Equal(t, uint64(1), s.Version, fmt.Sprintf("expected version 1 got %d", s.Version))
+	}
+	ds.AssertNumberOfCalls(t, "RetrieveSettings", 1)
+}
+
+func TestRetrieveSettingsChange(t *testing.T) {
+	ds := new(mocks.Settings)
+	ds.On("RetrieveSettings", context.Background(), domainID).Return(domains.Settings{Version: 1}, nil).Once()
+	ds.On("RetrieveSettings", context.Background(), domainID).Return(domains.Settings{Version: 2}, nil)
+	cache := settings.NewCache(ds, 0)
+
+	_, err := cache.RetrieveSettings(context.Background(), domainID)
+	assert.Nil(t, err, fmt.Sprintf("expected nil got %s", err))
+	s, err := cache.RetrieveSettings(context.Background(), domainID)
+	assert.Nil(t, err, fmt.Sprintf("expected nil got %s", err))
+	assert.Equal(t, uint64(2), s.Version, fmt.Sprintf("expected settings change to apply, got version %d", s.Version))
+}
@@ -70,6 +70,9 @@ var (
 	// ErrInvitationAlreadyAccepted indicates that the invitation is already accepted.
 	ErrInvitationAlreadyAccepted = errors.New("invitation already accepted")
 
+	// ErrInvitationExpired indicates that the invitation has expired.
+	ErrInvitationExpired = errors.New("invitation expired")
+
 	// ErrParentGroupAuthorization indicates failure occurred while authorizing the parent group.
 	ErrParentGroupAuthorization = errors.New("failed to authorize parent group")
 
@@ -87,4 +90,26 @@ var (
 
 	// ErrUnauthorizedPAT indicates failure occurred while authorizing PAT.
 	ErrUnauthorizedPAT = errors.New("failed to authorize PAT")
+
+	// ErrMFARequired indicates that the second authentication factor is missing.
+	ErrMFARequired = errors.New("multi-factor authentication code required")
+
+	// ErrInvalidMFACode indicates an invalid multi-factor authentication or recovery code.
+	ErrInvalidMFACode = errors.New("invalid multi-factor authentication code")
+
+	// ErrMFAEnrolmentRequired indicates that the user has to enrol in
+	// multi-factor authentication required by one of their domains.
+	ErrMFAEnrolmentRequired = errors.New("multi-factor authentication enrolment required")
+
+	// ErrPasswordPolicy indicates that the password does not satisfy the password policy.
+	ErrPasswordPolicy = errors.New("password does not satisfy the password policy")
+
+	// ErrAccountLocked indicates that the account is locked after too many failed login attempts.
+	ErrAccountLocked = errors.New("account is temporarily locked")
+
+	// ErrQuotaExceeded indicates that the domain resource quota is exceeded.
+	ErrQuotaExceeded = errors.New("domain quota exceeded")
+
+	// ErrTooManyRequests indicates that the request was repeated too often.
+	ErrTooManyRequests = errors.New("too many requests")
 )
@@ -21,12 +21,18 @@ var (
 	errDecodeRemoveParentGroupEvent    = errors.New("failed to decode group remove parent event")
 	errDecodeAddChildrenGroupsEvent    = errors.New("failed to decode group add children groups event")
 	errDecodeRemoveChildrenGroupsEvent = errors.New("failed to decode group remove children groups event")
+	errDecodeChangeParentGroupEvent    = errors.New("failed to decode group change parent group event")
+	errDecodeChangeHierarchyStatus     = errors.New("failed to decode group change hierarchy status event")
+	errDecodeRemoveHierarchyEvent      = errors.New("failed to decode group remove hierarchy event")
+	errDecodeMoveGroupEvent            = errors.New("failed to decode group move event")
 
 	errID            = errors.New("missing or invalid 'id'")
 	errName          = errors.New("missing or invalid 'name'")
 	errDomain        = errors.New("missing or invalid 'domain'")
 	errParent        = errors.New("missing or invalid 'parent'")
+	errNewDomain     = errors.New("missing or invalid 'new_domain'")
 	errChildrenIDs   = errors.New("missing or invalid 'children_ids'")
+	errGroups        = errors.New("missing or invalid 'groups'")
 	errStatus        = errors.New("missing or invalid 'status'")
 	errConvertStatus = errors.New("failed to convert status")
 	errCreatedAt     = errors.New("failed to parse 'created_at' time")
@@ -253,3 +259,85 @@ func decodeRemoveAllChildrenGroupEvent(data map[string]interface{}) (id string,
 
 	return id, nil
 }
+
+func decodeChangeParentGroupEvent(data map[string]interface{}) (groups.Group, error) {
+	var g groups.Group
+	id, ok := data["id"].(string)
+	if !ok {
+		return groups.Group{}, errors.Wrap(errDecodeChangeParentGroupEvent, errID)
+	}
+	g.ID = id
+
+	parent, ok := data["parent_id"].(string)
+	if !ok {
+		return groups.Group{}, errors.Wrap(errDecodeChangeParentGroupEvent, errParent)
+	}
+	g.Parent = parent
+
+	uat, ok := data["updated_at"].(string)
+	if ok {
+		ut, err := time.Parse(layout, uat)
+		if err != nil {
+			return groups.Group{}, errors.Wrap(errDecodeChangeParentGroupEvent, errors.Wrap(errUpdatedAt, err))
+		}
+		g.UpdatedAt = ut
+	}
+
+	uby, ok := data["updated_by"].(string)
+	if ok {
+		g.UpdatedBy = uby
+	}
+
+	return g, nil
+}
+
+func decodeMoveGroupEvent(data map[string]interface{}) (groups.Group, error) {
+	var g groups.Group
+	id, ok := data["id"].(string)
+	if !ok {
+		return groups.Group{}, errors.Wrap(errDecodeMoveGroupEvent, errID)
+	}
+	g.ID = id
+
+	domain, ok := data["new_domain"].(string)
+	if !ok || domain == "" {
+		return groups.Group{}, errors.Wrap(errDecodeMoveGroupEvent, errNewDomain)
+	}
+	g.Domain = domain
+
+	uat, ok := data["updated_at"].(string)
+	if ok {
+		ut, err := time.Parse(layout, uat)
+		if err != nil {
+			return groups.Group{}, errors.Wrap(errDecodeMoveGroupEvent, errors.Wrap(errUpdatedAt, err))
+		}
+		g.UpdatedAt = ut
+	}
+
+	uby, ok := data["updated_by"].(string)
+	if ok {
+		g.UpdatedBy = uby
+	}
+
+	return g, nil
+}
+
+func decodeChangeHierarchyStatusEvent(data map[string]interface{}) (groups.Group, error) {
+	g, err := ToGroupStatus(data)
+	if err != nil {
+		return groups.Group{}, errors.Wrap(errDecodeChangeHierarchyStatus, err)
+	}
+	return g, nil
+}
+
+func decodeRemoveHierarchyEvent(data map[string]interface{}) ([]string, error) {
+	gIDs, ok := data["groups"].([]interface{})
+	if !ok {
+		return []string{}, errors.Wrap(errDecodeRemoveHierarchyEvent, errGroups)
+	}
+	ids, err := rconsumer.ToStrings(gIDs)
+	if err != nil {
+		return []string{}, errors.Wrap(errDecodeRemoveHierarchyEvent, errors.Wrap(errGroups, err))
+	}
+	return ids, nil
+}
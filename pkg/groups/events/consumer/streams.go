@@ -27,6 +27,10 @@ const (
 	addChildrenGroups       = "group.add_children_groups"
 	removeChildrenGroups    = "group.remove_children_groups"
 	removeAllChildrenGroups = "group.remove_all_children_groups"
+	changeParentGroup       = "group.change_parent_group"
+	changeHierarchyStatus   = "group.change_hierarchy_status"
+	removeHierarchy         = "group.remove_hierarchy"
+	move                    = "group.move"
 )
 
 var (
@@ -40,6 +44,10 @@ var (
 	errAddChildrenGroupEvent       = errors.New("failed to consume group add children groups event")
 	errRemoveChildrenGroupEvent    = errors.New("failed to consume group remove children groups event")
 	errRemoveAllChildrenGroupEvent = errors.New("failed to consume group remove all children groups event")
+	errChangeParentGroupEvent      = errors.New("failed to consume group change parent group event")
+	errChangeHierarchyStatusEvent  = errors.New("failed to consume group change hierarchy status event")
+	errRemoveHierarchyEvent        = errors.New("failed to consume group remove hierarchy event")
+	errMoveGroupEvent              = errors.New("failed to consume group move event")
 )
 
 type eventHandler struct {
@@ -101,6 +109,14 @@ func (es *eventHandler) Handle(ctx context.Context, event events.Event) error {
 		return es.removeChildrenGroupsHandler(ctx, msg)
 	case removeAllChildrenGroups:
 		return es.removeAllChildrenGroupsHandler(ctx, msg)
+	case changeParentGroup:
+		return es.changeParentGroupHandler(ctx, msg)
+	case changeHierarchyStatus:
+		return es.changeHierarchyStatusHandler(ctx, msg)
+	case removeHierarchy:
+		return es.removeHierarchyHandler(ctx, msg)
+	case move:
+		return es.moveGroupHandler(ctx, msg)
 	}
 
 	return es.rolesEventHandler.Handle(ctx, op, msg)
@@ -221,3 +237,50 @@ func (es *eventHandler) removeAllChildrenGroupsHandler(ctx context.Context, data
 	}
 	return nil
 }
+
+func (es *eventHandler) changeParentGroupHandler(ctx context.Context, data map[string]interface{}) error {
+	g, err := decodeChangeParentGroupEvent(data)
+	if err != nil {
+		return errors.Wrap(errChangeParentGroupEvent, err)
+	}
+	if _, err := es.repo.ChangeParentGroup(ctx, g); err != nil {
+		return errors.Wrap(errChangeParentGroupEvent, err)
+	}
+	return nil
+}
+
+func (es *eventHandler) changeHierarchyStatusHandler(ctx context.Context, data map[string]interface{}) error {
+	g, err := decodeChangeHierarchyStatusEvent(data)
+	if err != nil {
+		return errors.Wrap(errChangeHierarchyStatusEvent, err)
+	}
+	if _, err := es.repo.ChangeHierarchyStatus(ctx, g); err != nil {
+		return errors.Wrap(errChangeHierarchyStatusEvent, err)
+	}
+	return nil
+}
+
+func (es *eventHandler) removeHierarchyHandler(ctx context.Context, data map[string]interface{}) error {
+	ids, err := decodeRemoveHierarchyEvent(data)
+	if err != nil {
+		return errors.Wrap(errRemoveHierarchyEvent, err)
+	}
+	for _, id := range ids {
+		if err := es.repo.Delete(ctx, id); err != nil && err != repoerr.ErrNotFound {
+			return errors.Wrap(errRemoveHierarchyEvent, err)
+		}
+	}
+	return nil
+}
+
+// moveGroupHandler moves the group subtree to the new domain in the replica.
+func (es *eventHandler) moveGroupHandler(ctx context.Context, data map[string]interface{}) error {
+	g, err := decodeMoveGroupEvent(data)
+	if err != nil {
+		return errors.Wrap(errMoveGroupEvent, err)
+	}
+	if _, err := es.repo.ChangeDomain(ctx, g); err != nil {
+		return errors.Wrap(errMoveGroupEvent, err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups
+
+// InheritMetadata returns the effective metadata of an entity placed in the
+// group hierarchy. Ancestors metadata is expected to be ordered from the root
+// group down to the closest parent group.
+//
+// The following override rules apply:
+//   - metadata of a closer group overrides metadata of a farther group, and
+//     the entity own metadata overrides all inherited metadata;
+//   - nested objects are merged key by key, while all other values, including
+//     arrays, are replaced as a whole;
+//   - a key explicitly set to null stops the inheritance of that key, so it is
+//     omitted from the effective metadata.
+func InheritMetadata(ancestors []map[string]interface{}, own map[string]interface{}) map[string]interface{} {
+	effective := map[string]interface{}{}
+	for _, md := range ancestors {
+		merge(effective, md)
+	}
+	merge(effective, own)
+
+	return effective
+}
+
+func merge(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if v == nil {
+			delete(dst, k)
+			continue
+		}
+		sm, ok := v.(map[string]interface{})
+		if !ok {
+			dst[k] = clone(v)
+			continue
+		}
+		dm, ok := dst[k].(map[string]interface{})
+		if !ok {
+			dm = map[string]interface{}{}
+		}
+		merge(dm, sm)
+		dst[k] = dm
+	}
+}
+
+// clone deep copies nested objects and arrays, so that the effective
+// metadata does not share them with the metadata it is built from.
+func clone(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, v := range val {
+			m[k] = clone(v)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(val))
+		for i, v := range val {
+			s[i] = clone(v)
+		}
+		return s
+	default:
+		return v
+	}
+}
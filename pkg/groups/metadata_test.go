@@ -0,0 +1,122 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/pkg/groups"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInheritMetadata(t *testing.T) {
+	cases := []struct {
+		desc      string
+		ancestors []map[string]interface{}
+		own       map[string]interface{}
+		resp      map[string]interface{}
+	}{
+		{
+			desc: "inherit without metadata",
+			resp: map[string]interface{}{},
+		},
+		{
+			desc:      "inherit metadata of ancestors",
+			ancestors: []map[string]interface{}{{"site": "hq"}, {"floor": 1.0}},
+			resp:      map[string]interface{}{"site": "hq", "floor": 1.0},
+		},
+		{
+			desc:      "closer group overrides farther group",
+			ancestors: []map[string]interface{}{{"site": "hq"}, {"site": "lab"}},
+			resp:      map[string]interface{}{"site": "lab"},
+		},
+		{
+			desc:      "own metadata overrides inherited metadata",
+			ancestors: []map[string]interface{}{{"site": "hq"}, {"site": "lab"}},
+			own:       map[string]interface{}{"site": "office"},
+			resp:      map[string]interface{}{"site": "office"},
+		},
+		{
+			desc:      "null stops inheritance",
+			ancestors: []map[string]interface{}{{"site": "hq", "floor": 1.0}, {"site": nil}},
+			resp:      map[string]interface{}{"floor": 1.0},
+		},
+		{
+			desc:      "inherit key after null in closer group",
+			ancestors: []map[string]interface{}{{"site": nil}, {"site": "lab"}},
+			resp:      map[string]interface{}{"site": "lab"},
+		},
+		{
+			desc:      "null in own metadata stops inheritance",
+			ancestors: []map[string]interface{}{{"site": "hq"}},
+			own:       map[string]interface{}{"site": nil},
+			resp:      map[string]interface{}{},
+		},
+		{
+			desc:      "arrays are replaced",
+			ancestors: []map[string]interface{}{{"tags": []interface{}{"a", "b"}}},
+			own:       map[string]interface{}{"tags": []interface{}{"c"}},
+			resp:      map[string]interface{}{"tags": []interface{}{"c"}},
+		},
+		{
+			desc: "nested objects are merged",
+			ancestors: []map[string]interface{}{
+				{"location": map[string]interface{}{"site": "hq", "geo": map[string]interface{}{"lat": 1.0, "lon": 2.0}}},
+				{"location": map[string]interface{}{"floor": 1.0}},
+			},
+			own: map[string]interface{}{"location": map[string]interface{}{"geo": map[string]interface{}{"lat": 3.0}}},
+			resp: map[string]interface{}{
+				"location": map[string]interface{}{"site": "hq", "floor": 1.0, "geo": map[string]interface{}{"lat": 3.0, "lon": 2.0}},
+			},
+		},
+		{
+			desc: "null stops inheritance of nested key",
+			ancestors: []map[string]interface{}{
+				{"location": map[string]interface{}{"site": "hq", "floor": 1.0}},
+			},
+			own:  map[string]interface{}{"location": map[string]interface{}{"floor": nil}},
+			resp: map[string]interface{}{"location": map[string]interface{}{"site": "hq"}},
+		},
+		{
+			desc: "object replaces scalar",
+			ancestors: []map[string]interface{}{
+				{"location": "hq"},
+			},
+			own:  map[string]interface{}{"location": map[string]interface{}{"site": "lab"}},
+			resp: map[string]interface{}{"location": map[string]interface{}{"site": "lab"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp := groups.InheritMetadata(tc.ancestors, tc.own)
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestInheritMetadataCopy(t *testing.T) {
+	ancestor := map[string]interface{}{
+		"location": map[string]interface{}{"site": "hq"},
+		"tags":     []interface{}{"a", map[string]interface{}{"b": "c"}},
+	}
+	own := map[string]interface{}{
+		"config": map[string]interface{}{"mode": "auto"},
+	}
+
+	resp := groups.InheritMetadata([]map[string]interface{}{ancestor}, own)
+	resp["location"].(map[string]interface{})["site"] = "lab"
+	resp["tags"].([]interface{})[0] = "x"
+	resp["tags"].([]interface{})[1].(map[string]interface{})["b"] = "x"
+	resp["config"].(map[string]interface{})["mode"] = "manual"
+
+	assert.Equal(t, map[string]interface{}{
+		"location": map[string]interface{}{"site": "hq"},
+		"tags":     []interface{}{"a", map[string]interface{}{"b": "c"}},
+	}, ancestor, "ancestor metadata should not be modified")
+	assert.Equal(t, map[string]interface{}{
+		"config": map[string]interface{}{"mode": "auto"},
+	}, own, "own metadata should not be modified")
+}
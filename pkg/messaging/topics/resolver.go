@@ -0,0 +1,146 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package topics resolves the messaging topics that refer to the domain
+// alias and the channel name to the topics that refer to the channel ID.
+package topics
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+
+	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
+	grpcDomainsV1 "github.com/absmach/supermq/api/grpc/domains/v1"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+var (
+	// ErrMalformedTopic indicates that the domain alias or the channel name
+	// in the topic can not be decoded.
+	ErrMalformedTopic = errors.New("malformed topic")
+
+	// ErrResolveTopic indicates that the domain alias or the channel name
+	// in the topic could not be resolved.
+	ErrResolveTopic = errors.New("failed to resolve topic")
+
+	// Topics are in the format:
+	// m/<domain_alias>/c/<channel_name>/<subtopic>/.../ct/<content_type>
+	aliasRegExp = regexp.MustCompile(`^(\/?)m\/([^\/?]+)\/c\/([^\/?]+)(\/[^?]*)?(\?.*)?$`)
+)
+
+// Resolver resolves the messaging topics that refer to the domain alias
+// and the channel name.
+type Resolver interface {
+	// Resolve converts the topic m/<domain_alias>/c/<channel_name>/<subtopic>
+	// to channels/<channel_id>/messages/<subtopic>. The topics of the other
+	// forms are returned unchanged.
+	Resolve(ctx context.Context, topic string) (string, error)
+}
+
+type entry struct {
+	id        string
+	checkedAt time.Time
+}
+
+type resolver struct {
+	domains  grpcDomainsV1.DomainsServiceClient
+	channels grpcChannelsV1.ChannelsServiceClient
+	refresh  time.Duration
+	mu       sync.Mutex
+	aliases  map[string]entry
+	names    map[string]entry
+}
+
+var _ Resolver = (*resolver)(nil)
+
+// NewResolver returns the topics resolver. The resolved domain and channel
+// IDs are kept in memory and re-read once the refresh period passes, so the
+// alias and name changes apply within the period.
+func NewResolver(domains grpcDomainsV1.DomainsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, refresh time.Duration) Resolver {
+	return &resolver{
+		domains:  domains,
+		channels: channels,
+		refresh:  refresh,
+		aliases:  make(map[string]entry),
+		names:    make(map[string]entry),
+	}
+}
+
+func (r *resolver) Resolve(ctx context.Context, topic string) (string, error) {
+	parts := aliasRegExp.FindStringSubmatch(topic)
+	if len(parts) == 0 {
+		return topic, nil
+	}
+
+	alias, err := url.PathUnescape(parts[2])
+	if err != nil {
+		return "", ErrMalformedTopic
+	}
+	name, err := url.PathUnescape(parts[3])
+	if err != nil {
+		return "", ErrMalformedTopic
+	}
+
+	domainID, err := r.domainID(ctx, alias)
+	if err != nil {
+		return "", errors.Wrap(ErrResolveTopic, err)
+	}
+	channelID, err := r.channelID(ctx, domainID, name)
+	if err != nil {
+		return "", errors.Wrap(ErrResolveTopic, err)
+	}
+
+	return fmt.Sprintf("%schannels/%s/messages%s%s", parts[1], channelID, parts[4], parts[5]), nil
+}
+
+func (r *resolver) domainID(ctx context.Context, alias string) (string, error) {
+	if id, ok := r.cached(r.aliases, alias); ok {
+		return id, nil
+	}
+
+	res, err := r.domains.RetrieveIDByAlias(ctx, &grpcDomainsV1.RetrieveIDByAliasReq{Alias: alias})
+	if err != nil {
+		return "", err
+	}
+	r.save(r.aliases, alias, res.GetId())
+
+	return res.GetId(), nil
+}
+
+func (r *resolver) channelID(ctx context.Context, domainID, name string) (string, error) {
+	key := domainID + "/" + name
+	if id, ok := r.cached(r.names, key); ok {
+		return id, nil
+	}
+
+	res, err := r.channels.RetrieveIDByName(ctx, &grpcChannelsV1.RetrieveIDByNameReq{DomainId: domainID, Name: name})
+	if err != nil {
+		return "", err
+	}
+	r.save(r.names, key, res.GetId())
+
+	return res.GetId(), nil
+}
+
+func (r *resolver) cached(ids map[string]entry, key string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := ids[key]
+	if !ok || time.Since(e.checkedAt) >= r.refresh {
+		return "", false
+	}
+
+	return e.id, true
+}
+
+func (r *resolver) save(ids map[string]entry, key, id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids[key] = entry{id: id, checkedAt: time.Now()}
+}
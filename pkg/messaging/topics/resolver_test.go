@@ -0,0 +1,125 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package topics_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
+	grpcDomainsV1 "github.com/absmach/supermq/api/grpc/domains/v1"
+	chmocks "github.com/absmach/supermq/channels/mocks"
+	dmocks "github.com/absmach/supermq/domains/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging/topics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	alias       = "factory"
+	channelName = "temperature"
+)
+
+var (
+	domainID  = testsutil.GenerateUUID(&testing.T{})
+	channelID = testsutil.GenerateUUID(&testing.T{})
+)
+
+func TestResolve(t *testing.T) {
+	cases := []struct {
+		desc       string
+		topic      string
+		alias      string
+		name       string
+		domainErr  error
+		channelErr error
+		resolved   string
+		err        error
+	}{
+		{
+			desc:     "resolve topic with channel ID",
+			topic:    fmt.Sprintf("channels/%s/messages/sub", channelID),
+			resolved: fmt.Sprintf("channels/%s/messages/sub", channelID),
+		},
+		{
+			desc:     "resolve topic with domain alias and channel name",
+			topic:    fmt.Sprintf("m/%s/c/%s", alias, channelName),
+			alias:    alias,
+			name:     channelName,
+			resolved: fmt.Sprintf("channels/%s/messages", channelID),
+		},
+		{
+			desc:     "resolve topic with subtopic and query",
+			topic:    fmt.Sprintf("/m/%s/c/%s/room/1?auth=key", alias, channelName),
+			alias:    alias,
+			name:     channelName,
+			resolved: fmt.Sprintf("/channels/%s/messages/room/1?auth=key", channelID),
+		},
+		{
+			desc:     "resolve topic with escaped channel name",
+			topic:    fmt.Sprintf("m/%s/c/%s", alias, "boiler%20room"),
+			alias:    alias,
+			name:     "boiler room",
+			resolved: fmt.Sprintf("channels/%s/messages", channelID),
+		},
+		{
+			desc:  "resolve topic with malformed channel name",
+			topic: fmt.Sprintf("m/%s/c/%s", alias, "boiler%2"),
+			err:   topics.ErrMalformedTopic,
+		},
+		{
+			desc:      "resolve topic with unknown domain alias",
+			topic:     fmt.Sprintf("m/%s/c/%s", alias, channelName),
+			alias:     alias,
+			domainErr: svcerr.ErrNotFound,
+			err:       topics.ErrResolveTopic,
+		},
+		{
+			desc:       "resolve topic with unknown channel name",
+			topic:      fmt.Sprintf("m/%s/c/%s", alias, channelName),
+			alias:      alias,
+			name:       channelName,
+			channelErr: svcerr.ErrNotFound,
+			err:        topics.ErrResolveTopic,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			domains := new(dmocks.DomainsServiceClient)
+			channels := new(chmocks.ChannelsServiceClient)
+			domains.On("RetrieveIDByAlias", mock.Anything, &grpcDomainsV1.RetrieveIDByAliasReq{Alias: tc.alias}).Return(&grpcDomainsV1.RetrieveIDByAliasRes{Id: domainID}, tc.domainErr)
+			channels.On("RetrieveIDByName", mock.Anything, &grpcChannelsV1.RetrieveIDByNameReq{DomainId: domainID, Name: tc.name}).Return(&grpcChannelsV1.RetrieveIDByNameRes{Id: channelID}, tc.channelErr)
+			r := topics.NewResolver(domains, channels, time.Minute)
+			resolved, err := r.Resolve(context.Background(), tc.topic)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resolved, resolved, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.resolved, resolved))
+		})
+	}
+}
+
+func TestResolveCached(t *testing.T) {
+	domains := new(dmocks.DomainsServiceClient)
+	channels := new(chmocks.ChannelsServiceClient)
+	domainCall := domains.On("RetrieveIDByAlias", mock.Anything, &grpcDomainsV1.RetrieveIDByAliasReq{Alias: alias}).Return(&grpcDomainsV1.RetrieveIDByAliasRes{Id: domainID}, nil).Once()
+	channelCall := channels.On("RetrieveIDByName", mock.Anything, &grpcChannelsV1.RetrieveIDByNameReq{DomainId: domainID, Name: channelName}).Return(&grpcChannelsV1.RetrieveIDByNameRes{Id: channelID}, nil).Once()
+	r := topics.NewResolver(domains, channels, time.Minute)
+
+	topic := fmt.Sprintf("m/%s/c/%s", alias, channelName)
+	expected := fmt.Sprintf("channels/%s/messages", channelID)
+	for i := 0; i < 2; i++ {
+		resolved, err := r.Resolve(context.Background(), topic)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.Equal(t, expected, resolved, fmt.Sprintf("expected %s got %s", expected, resolved))
+	}
+	domains.AssertExpectations(t)
+	channels.AssertExpectations(t)
+	domainCall.Unset()
+	channelCall.Unset()
+}
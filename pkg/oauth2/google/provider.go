@@ -84,8 +84,8 @@ func (cfg *config) Exchange(ctx context.Context, code string) (oauth2.Token, err
 	return *token, nil
 }
 
-func (cfg *config) UserInfo(accessToken string) (uclient.User, error) {
-	resp, err := http.Get(userInfoURL + url.QueryEscape(accessToken))
+func (cfg *config) UserInfo(token oauth2.Token) (uclient.User, error) {
+	resp, err := http.Get(userInfoURL + url.QueryEscape(token.AccessToken))
 	if err != nil {
 		return uclient.User{}, err
 	}
@@ -106,13 +106,14 @@ func (cfg *config) UserInfo(accessToken string) (uclient.User, error) {
 		LastName  string `json:"last_name"`
 		Username  string `json:"username"`
 		Email     string `json:"email"`
+		Verified  bool   `json:"verified_email"`
 		Picture   string `json:"picture"`
 	}
 	if err := json.Unmarshal(data, &user); err != nil {
 		return uclient.User{}, err
 	}
 
-	if user.ID == "" || user.FirstName == "" || user.LastName == "" || user.Email == "" {
+	if user.ID == "" || user.FirstName == "" || user.LastName == "" || user.Email == "" || !user.Verified {
 		return uclient.User{}, svcerr.ErrAuthentication
 	}
 
@@ -137,9 +137,9 @@ func (_m *Provider) State() string {
 	return r0
 }
 
-// UserInfo provides a mock function with given fields: accessToken
-func (_m *Provider) UserInfo(accessToken string) (users.User, error) {
-	ret := _m.Called(accessToken)
+// UserInfo provides a mock function with given fields: token
+func (_m *Provider) UserInfo(token xoauth2.Token) (users.User, error) {
+	ret := _m.Called(token)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UserInfo")
@@ -147,17 +147,17 @@ func (_m *Provider) UserInfo(accessToken string) (users.User, error) {
 
 	var r0 users.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (users.User, error)); ok {
-		return rf(accessToken)
+	if rf, ok := ret.Get(0).(func(xoauth2.Token) (users.User, error)); ok {
+		return rf(token)
 	}
-	if rf, ok := ret.Get(0).(func(string) users.User); ok {
-		r0 = rf(accessToken)
+	if rf, ok := ret.Get(0).(func(xoauth2.Token) users.User); ok {
+		r0 = rf(token)
 	} else {
 		r0 = ret.Get(0).(users.User)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(accessToken)
+	if rf, ok := ret.Get(1).(func(xoauth2.Token) error); ok {
+		r1 = rf(token)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -41,6 +41,8 @@ type Provider interface {
 	// Exchange converts an authorization code into a token.
 	Exchange(ctx context.Context, code string) (oauth2.Token, error)
 
-	// UserInfo retrieves the user's information using the access token.
-	UserInfo(accessToken string) (users.User, error)
+	// UserInfo retrieves the user's information using the token obtained
+	// from Exchange. The user ID is the provider user ID, and the user email
+	// must be verified by the provider.
+	UserInfo(token oauth2.Token) (users.User, error)
 }
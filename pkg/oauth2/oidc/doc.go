@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oidc contains the generic OpenID Connect provider used to support
+// SuperMQ services login using identity providers such as Keycloak or Azure AD.
+package oidc
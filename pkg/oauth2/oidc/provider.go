@@ -0,0 +1,347 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgoauth2 "github.com/absmach/supermq/pkg/oauth2"
+	"github.com/absmach/supermq/users"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"golang.org/x/oauth2"
+)
+
+const (
+	discoveryPath      = "/.well-known/openid-configuration"
+	idTokenKey         = "id_token"
+	subClaim           = "sub"
+	emailVerifiedClaim = "email_verified"
+	defTimeout         = 1 * time.Minute
+	clockSkew          = 1 * time.Minute
+)
+
+var (
+	// ErrDiscovery indicates a failure to retrieve the provider metadata.
+	ErrDiscovery = errors.New("failed to discover OpenID Connect provider metadata")
+
+	// ErrMissingIDToken indicates that the token response contains no ID token.
+	ErrMissingIDToken = errors.New("missing ID token")
+
+	// ErrInvalidIDToken indicates that the ID token verification failed.
+	ErrInvalidIDToken = errors.New("invalid ID token")
+
+	// ErrMissingClaims indicates that the user info misses the required claims.
+	ErrMissingClaims = errors.New("missing required user claims")
+
+	// ErrUnverifiedEmail indicates that the provider did not verify the user email.
+	ErrUnverifiedEmail = errors.New("user email is not verified")
+
+	// ErrSubjectMismatch indicates that the user info doesn't belong to the ID token subject.
+	ErrSubjectMismatch = errors.New("user info subject does not match ID token subject")
+)
+
+// ClaimMapping maps the claims of the ID token and the user info
+// response to the user fields.
+type ClaimMapping struct {
+	ID        string `env:"ID"         envDefault:"sub"`
+	Email     string `env:"EMAIL"      envDefault:"email"`
+	Username  string `env:"USERNAME"   envDefault:"preferred_username"`
+	FirstName string `env:"FIRST_NAME" envDefault:"given_name"`
+	LastName  string `env:"LAST_NAME"  envDefault:"family_name"`
+	Picture   string `env:"PICTURE"    envDefault:"picture"`
+}
+
+// Config is the configuration of the OpenID Connect provider.
+type Config struct {
+	mgoauth2.Config
+	IssuerURL  string       `env:"ISSUER_URL"  envDefault:""`
+	Scopes     []string     `env:"SCOPES"      envDefault:"openid,email,profile" envSeparator:","`
+	Claims     ClaimMapping `envPrefix:"CLAIM_"`
+	TrustEmail bool         `env:"TRUST_EMAIL" envDefault:"false"`
+}
+
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+var _ mgoauth2.Provider = (*provider)(nil)
+
+type provider struct {
+	name          string
+	cfg           Config
+	uiRedirectURL string
+	errorURL      string
+	client        *http.Client
+	cache         *jwk.Cache
+
+	mu   sync.Mutex
+	meta *metadata
+	keys jwk.Set
+}
+
+// NewProvider returns a new OpenID Connect provider with the given name.
+// The provider endpoints and the signing keys are discovered from the issuer
+// metadata on the first use, so the identity provider doesn't have to be
+// available when the service starts.
+func NewProvider(ctx context.Context, name string, cfg Config, uiRedirectURL, errorURL string) mgoauth2.Provider {
+	client := &http.Client{Timeout: defTimeout}
+	return &provider{
+		name:          name,
+		cfg:           cfg,
+		uiRedirectURL: uiRedirectURL,
+		errorURL:      errorURL,
+		client:        client,
+		cache:         jwk.NewCache(ctx),
+	}
+}
+
+func (p *provider) Name() string {
+	return p.name
+}
+
+func (p *provider) State() string {
+	return p.cfg.State
+}
+
+func (p *provider) RedirectURL() string {
+	return p.uiRedirectURL
+}
+
+func (p *provider) ErrorURL() string {
+	return p.errorURL
+}
+
+func (p *provider) IsEnabled() bool {
+	return p.cfg.IssuerURL != "" && p.cfg.ClientID != "" && p.cfg.ClientSecret != ""
+}
+
+func (p *provider) Exchange(ctx context.Context, code string) (oauth2.Token, error) {
+	meta, keys, err := p.discover(ctx)
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+
+	conf := &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  meta.AuthorizationEndpoint,
+			TokenURL: meta.TokenEndpoint,
+		},
+		RedirectURL: p.cfg.RedirectURL,
+		Scopes:      p.cfg.Scopes,
+	}
+	token, err := conf.Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.client), code)
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+
+	if _, err := p.idTokenClaims(ctx, meta, keys, *token); err != nil {
+		return oauth2.Token{}, err
+	}
+
+	return *token, nil
+}
+
+// UserInfo builds the user from the verified ID token claims, completed
+// with the claims of the user info response. The user info response must
+// belong to the ID token subject.
+func (p *provider) UserInfo(token oauth2.Token) (users.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defTimeout)
+	defer cancel()
+
+	meta, keys, err := p.discover(ctx)
+	if err != nil {
+		return users.User{}, err
+	}
+	claims, err := p.idTokenClaims(ctx, meta, keys, token)
+	if err != nil {
+		return users.User{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, meta.UserInfoEndpoint, nil)
+	if err != nil {
+		return users.User{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return users.User{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return users.User{}, svcerr.ErrAuthentication
+	}
+
+	var info map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return users.User{}, err
+	}
+	if sub, _ := info[subClaim].(string); sub != claims[subClaim] {
+		return users.User{}, errors.Wrap(svcerr.ErrAuthentication, ErrSubjectMismatch)
+	}
+	for name, value := range info {
+		claims[name] = value
+	}
+
+	return p.toUser(claims)
+}
+
+// idTokenClaims verifies the ID token of the token response and returns its claims.
+func (p *provider) idTokenClaims(ctx context.Context, meta metadata, keys jwk.Set, token oauth2.Token) (map[string]interface{}, error) {
+	idToken, ok := token.Extra(idTokenKey).(string)
+	if !ok || idToken == "" {
+		return nil, ErrMissingIDToken
+	}
+	tkn, err := p.verify(ctx, meta, keys, idToken)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := tkn.AsMap(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidIDToken, err)
+	}
+	if sub, _ := claims[subClaim].(string); sub == "" {
+		return nil, errors.Wrap(ErrInvalidIDToken, ErrMissingClaims)
+	}
+
+	return claims, nil
+}
+
+// discover retrieves and caches the provider metadata and registers
+// the provider JWKS, which is refreshed in the background.
+func (p *provider) discover(ctx context.Context) (metadata, jwk.Set, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.meta != nil {
+		return *p.meta, p.keys, nil
+	}
+
+	issuer := strings.TrimSuffix(p.cfg.IssuerURL, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+discoveryPath, nil)
+	if err != nil {
+		return metadata{}, nil, errors.Wrap(ErrDiscovery, err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return metadata{}, nil, errors.Wrap(ErrDiscovery, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return metadata{}, nil, errors.Wrap(ErrDiscovery, fmt.Errorf("unexpected status code %d", resp.StatusCode))
+	}
+
+	var meta metadata
+	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
+		return metadata{}, nil, errors.Wrap(ErrDiscovery, err)
+	}
+	if strings.TrimSuffix(meta.Issuer, "/") != issuer {
+		return metadata{}, nil, errors.Wrap(ErrDiscovery, fmt.Errorf("issuer %s does not match %s", meta.Issuer, issuer))
+	}
+	if meta.TokenEndpoint == "" || meta.UserInfoEndpoint == "" || meta.JWKSURI == "" {
+		return metadata{}, nil, errors.Wrap(ErrDiscovery, errors.New("missing provider endpoints"))
+	}
+
+	if err := p.cache.Register(meta.JWKSURI, jwk.WithHTTPClient(p.client)); err != nil {
+		return metadata{}, nil, errors.Wrap(ErrDiscovery, err)
+	}
+	if _, err := p.cache.Refresh(ctx, meta.JWKSURI); err != nil {
+		return metadata{}, nil, errors.Wrap(ErrDiscovery, err)
+	}
+
+	p.meta = &meta
+	p.keys = jwk.NewCachedSet(p.cache, meta.JWKSURI)
+
+	return meta, p.keys, nil
+}
+
+// verify verifies the ID token signature, issuer, audience and expiration.
+// Verification is retried once with the refreshed JWKS, so that the tokens
+// signed with the rotated keys are accepted before the scheduled refresh.
+func (p *provider) verify(ctx context.Context, meta metadata, keys jwk.Set, idToken string) (jwt.Token, error) {
+	parse := func() (jwt.Token, error) {
+		return jwt.Parse([]byte(idToken),
+			jwt.WithKeySet(keys, jws.WithInferAlgorithmFromKey(true)),
+			jwt.WithIssuer(meta.Issuer),
+			jwt.WithAudience(p.cfg.ClientID),
+			jwt.WithAcceptableSkew(clockSkew),
+			jwt.WithValidate(true),
+		)
+	}
+
+	if tkn, err := parse(); err == nil {
+		return tkn, nil
+	}
+	if _, err := p.cache.Refresh(ctx, meta.JWKSURI); err != nil {
+		return nil, errors.Wrap(ErrInvalidIDToken, err)
+	}
+	tkn, err := parse()
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidIDToken, err)
+	}
+
+	return tkn, nil
+}
+
+func (p *provider) toUser(claims map[string]interface{}) (users.User, error) {
+	claim := func(name string) string {
+		if name == "" {
+			return ""
+		}
+		v, _ := claims[name].(string)
+		return v
+	}
+
+	id, email := claim(p.cfg.Claims.ID), claim(p.cfg.Claims.Email)
+	if id == "" || email == "" {
+		return users.User{}, errors.Wrap(svcerr.ErrAuthentication, ErrMissingClaims)
+	}
+	if !p.emailVerified(claims) {
+		return users.User{}, errors.Wrap(svcerr.ErrAuthentication, ErrUnverifiedEmail)
+	}
+
+	return users.User{
+		ID:        id,
+		FirstName: claim(p.cfg.Claims.FirstName),
+		LastName:  claim(p.cfg.Claims.LastName),
+		Email:     email,
+		Credentials: users.Credentials{
+			Username: claim(p.cfg.Claims.Username),
+		},
+		Metadata: users.Metadata{
+			"oauth_provider":  p.name,
+			"profile_picture": claim(p.cfg.Claims.Picture),
+		},
+		Status: users.EnabledStatus,
+	}, nil
+}
+
+// emailVerified reports whether the email_verified claim is set, falling
+// back to the provider trust setting when the claim is missing.
+func (p *provider) emailVerified(claims map[string]interface{}) bool {
+	v, ok := claims[emailVerifiedClaim]
+	if !ok {
+		return p.cfg.TrustEmail
+	}
+	verified, _ := v.(bool)
+
+	return verified
+}
@@ -0,0 +1,390 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgoauth2 "github.com/absmach/supermq/pkg/oauth2"
+	"github.com/absmach/supermq/pkg/oauth2/oidc"
+	"github.com/absmach/supermq/users"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+const (
+	clientID     = "supermq"
+	clientSecret = "secret"
+	accessToken  = "access-token"
+	providerName = "keycloak"
+)
+
+// mockOIDCServer is a minimal OpenID Connect provider serving the discovery
+// metadata, the JWKS, the token and the user info endpoints.
+type mockOIDCServer struct {
+	*httptest.Server
+	issuer  string
+	key     jwk.Key
+	idToken func() string
+	claims  map[string]interface{}
+}
+
+func newMockOIDCServer(t *testing.T) *mockOIDCServer {
+	raw, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating key: %s", err))
+	key, err := jwk.FromRaw(raw)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating JWK: %s", err))
+	require.Nil(t, key.Set(jwk.KeyIDKey, "key-1"))
+	require.Nil(t, key.Set(jwk.AlgorithmKey, jwa.RS256))
+
+	s := &mockOIDCServer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":                 s.issuer,
+			"authorization_endpoint": s.URL + "/auth",
+			"token_endpoint":         s.URL + "/token",
+			"userinfo_endpoint":      s.URL + "/userinfo",
+			"jwks_uri":               s.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		pub, _ := jwk.PublicKeyOf(s.key)
+		set := jwk.NewSet()
+		_ = set.AddKey(pub)
+		writeJSON(w, set)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		res := map[string]interface{}{
+			"access_token": accessToken,
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		}
+		if tkn := s.idToken(); tkn != "" {
+			res["id_token"] = tkn
+		}
+		writeJSON(w, res)
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+accessToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, s.claims)
+	})
+	s.Server = httptest.NewServer(mux)
+	s.issuer = s.URL
+
+	return s
+}
+
+func (s *mockOIDCServer) sign(t *testing.T, key jwk.Key, issuer, audience string, expiresAt time.Time) string {
+	return s.signSubject(t, key, issuer, audience, "user-1", expiresAt)
+}
+
+func (s *mockOIDCServer) signSubject(t *testing.T, key jwk.Key, issuer, audience, subject string, expiresAt time.Time) string {
+	tkn, err := jwt.NewBuilder().
+		Issuer(issuer).
+		Audience([]string{audience}).
+		Subject(subject).
+		IssuedAt(time.Now()).
+		Expiration(expiresAt).
+		Build()
+	require.Nil(t, err, fmt.Sprintf("unexpected error building ID token: %s", err))
+	signed, err := jwt.Sign(tkn, jwt.WithKey(jwa.RS256, key))
+	require.Nil(t, err, fmt.Sprintf("unexpected error signing ID token: %s", err))
+
+	return string(signed)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newConfig(issuer string) oidc.Config {
+	return oidc.Config{
+		Config: mgoauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			State:        "state",
+			RedirectURL:  "http://localhost/oauth/callback/" + providerName,
+		},
+		IssuerURL: issuer,
+		Scopes:    []string{"openid", "email", "profile"},
+		Claims: oidc.ClaimMapping{
+			ID:        "sub",
+			Email:     "email",
+			Username:  "preferred_username",
+			FirstName: "given_name",
+			LastName:  "family_name",
+			Picture:   "picture",
+		},
+	}
+}
+
+func TestExchange(t *testing.T) {
+	s := newMockOIDCServer(t)
+	defer s.Close()
+
+	otherRaw, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating key: %s", err))
+	otherKey, err := jwk.FromRaw(otherRaw)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating JWK: %s", err))
+	require.Nil(t, otherKey.Set(jwk.KeyIDKey, "key-2"))
+
+	cases := []struct {
+		desc    string
+		idToken func() string
+		err     error
+	}{
+		{
+			desc:    "exchange code with valid ID token",
+			idToken: func() string { return s.sign(t, s.key, s.issuer, clientID, time.Now().Add(time.Hour)) },
+			err:     nil,
+		},
+		{
+			desc:    "exchange code without ID token",
+			idToken: func() string { return "" },
+			err:     oidc.ErrMissingIDToken,
+		},
+		{
+			desc:    "exchange code with ID token for another audience",
+			idToken: func() string { return s.sign(t, s.key, s.issuer, "other", time.Now().Add(time.Hour)) },
+			err:     oidc.ErrInvalidIDToken,
+		},
+		{
+			desc:    "exchange code with ID token of another issuer",
+			idToken: func() string { return s.sign(t, s.key, "http://other", clientID, time.Now().Add(time.Hour)) },
+			err:     oidc.ErrInvalidIDToken,
+		},
+		{
+			desc:    "exchange code with expired ID token",
+			idToken: func() string { return s.sign(t, s.key, s.issuer, clientID, time.Now().Add(-time.Hour)) },
+			err:     oidc.ErrInvalidIDToken,
+		},
+		{
+			desc:    "exchange code with ID token signed by unknown key",
+			idToken: func() string { return s.sign(t, otherKey, s.issuer, clientID, time.Now().Add(time.Hour)) },
+			err:     oidc.ErrInvalidIDToken,
+		},
+	}
+
+	provider := oidc.NewProvider(context.Background(), providerName, newConfig(s.URL), "", "")
+	assert.True(t, provider.IsEnabled(), "expected provider to be enabled")
+	assert.Equal(t, providerName, provider.Name())
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s.idToken = tc.idToken
+			token, err := provider.Exchange(context.Background(), "code")
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, accessToken, token.AccessToken, fmt.Sprintf("%s: expected access token %s got %s", tc.desc, accessToken, token.AccessToken))
+			}
+		})
+	}
+}
+
+func TestExchangeRotatedKey(t *testing.T) {
+	s := newMockOIDCServer(t)
+	defer s.Close()
+
+	provider := oidc.NewProvider(context.Background(), providerName, newConfig(s.URL), "", "")
+	s.idToken = func() string { return s.sign(t, s.key, s.issuer, clientID, time.Now().Add(time.Hour)) }
+	_, err := provider.Exchange(context.Background(), "code")
+	require.Nil(t, err, fmt.Sprintf("unexpected error exchanging code: %s", err))
+
+	raw, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating key: %s", err))
+	rotated, err := jwk.FromRaw(raw)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating JWK: %s", err))
+	require.Nil(t, rotated.Set(jwk.KeyIDKey, "key-2"))
+	require.Nil(t, rotated.Set(jwk.AlgorithmKey, jwa.RS256))
+	s.key = rotated
+
+	_, err = provider.Exchange(context.Background(), "code")
+	assert.Nil(t, err, fmt.Sprintf("exchange code with ID token signed by rotated key: unexpected error %s", err))
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	s := newMockOIDCServer(t)
+	defer s.Close()
+	s.issuer = "http://other"
+
+	provider := oidc.NewProvider(context.Background(), providerName, newConfig(s.URL), "", "")
+	_, err := provider.Exchange(context.Background(), "code")
+	assert.True(t, errors.Contains(err, oidc.ErrDiscovery), fmt.Sprintf("expected %s got %s", oidc.ErrDiscovery, err))
+
+	provider = oidc.NewProvider(context.Background(), providerName, newConfig("http://127.0.0.1:1"), "", "")
+	_, err = provider.UserInfo(oauth2.Token{AccessToken: accessToken})
+	assert.True(t, errors.Contains(err, oidc.ErrDiscovery), fmt.Sprintf("expected %s got %s", oidc.ErrDiscovery, err))
+}
+
+func TestUserInfo(t *testing.T) {
+	s := newMockOIDCServer(t)
+	defer s.Close()
+
+	claims := map[string]interface{}{
+		"sub":                "user-1",
+		"email":              "john@example.com",
+		"email_verified":     true,
+		"preferred_username": "john",
+		"given_name":         "John",
+		"family_name":        "Doe",
+		"picture":            "https://example.com/john.png",
+		"upn":                "jdoe@example.com",
+	}
+
+	idToken := s.sign(t, s.key, s.issuer, clientID, time.Now().Add(time.Hour))
+	otherRaw, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating key: %s", err))
+	otherKey, err := jwk.FromRaw(otherRaw)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating JWK: %s", err))
+	require.Nil(t, otherKey.Set(jwk.KeyIDKey, "key-2"))
+
+	cases := []struct {
+		desc        string
+		claims      map[string]interface{}
+		mapping     func(*oidc.ClaimMapping)
+		trustEmail  bool
+		accessToken string
+		idToken     string
+		user        users.User
+		err         error
+	}{
+		{
+			desc:        "retrieve user info with default claim mapping",
+			claims:      claims,
+			accessToken: accessToken,
+			idToken:     idToken,
+			user: users.User{
+				ID:          "user-1",
+				FirstName:   "John",
+				LastName:    "Doe",
+				Email:       "john@example.com",
+				Credentials: users.Credentials{Username: "john"},
+				Metadata:    users.Metadata{"oauth_provider": providerName, "profile_picture": "https://example.com/john.png"},
+				Status:      users.EnabledStatus,
+			},
+		},
+		{
+			desc:        "retrieve user info with custom claim mapping",
+			claims:      claims,
+			mapping:     func(m *oidc.ClaimMapping) { m.Email = "upn"; m.Username = "" },
+			accessToken: accessToken,
+			idToken:     idToken,
+			user: users.User{
+				ID:        "user-1",
+				FirstName: "John",
+				LastName:  "Doe",
+				Email:     "jdoe@example.com",
+				Metadata:  users.Metadata{"oauth_provider": providerName, "profile_picture": "https://example.com/john.png"},
+				Status:    users.EnabledStatus,
+			},
+		},
+		{
+			desc:        "retrieve user info with unverified email",
+			claims:      map[string]interface{}{"sub": "user-1", "email": "john@example.com", "email_verified": false},
+			accessToken: accessToken,
+			idToken:     idToken,
+			err:         oidc.ErrUnverifiedEmail,
+		},
+		{
+			desc:        "retrieve user info without email verification",
+			claims:      map[string]interface{}{"sub": "user-1", "email": "john@example.com"},
+			accessToken: accessToken,
+			idToken:     idToken,
+			err:         oidc.ErrUnverifiedEmail,
+		},
+		{
+			desc:        "retrieve user info without email verification from trusted provider",
+			claims:      map[string]interface{}{"sub": "user-1", "email": "john@example.com"},
+			trustEmail:  true,
+			accessToken: accessToken,
+			idToken:     idToken,
+			user: users.User{
+				ID:       "user-1",
+				Email:    "john@example.com",
+				Metadata: users.Metadata{"oauth_provider": providerName, "profile_picture": ""},
+				Status:   users.EnabledStatus,
+			},
+		},
+		{
+			desc:        "retrieve user info with unverified email from trusted provider",
+			claims:      map[string]interface{}{"sub": "user-1", "email": "john@example.com", "email_verified": false},
+			trustEmail:  true,
+			accessToken: accessToken,
+			idToken:     idToken,
+			err:         oidc.ErrUnverifiedEmail,
+		},
+		{
+			desc:        "retrieve user info of another subject",
+			claims:      map[string]interface{}{"sub": "user-2", "email": "john@example.com", "email_verified": true},
+			accessToken: accessToken,
+			idToken:     idToken,
+			err:         oidc.ErrSubjectMismatch,
+		},
+		{
+			desc:        "retrieve user info without ID token",
+			claims:      claims,
+			accessToken: accessToken,
+			err:         oidc.ErrMissingIDToken,
+		},
+		{
+			desc:        "retrieve user info with ID token signed by unknown key",
+			claims:      claims,
+			accessToken: accessToken,
+			idToken:     s.signSubject(t, otherKey, s.issuer, clientID, "user-1", time.Now().Add(time.Hour)),
+			err:         oidc.ErrInvalidIDToken,
+		},
+		{
+			desc:        "retrieve user info without email",
+			claims:      map[string]interface{}{"sub": "user-1"},
+			accessToken: accessToken,
+			idToken:     idToken,
+			err:         oidc.ErrMissingClaims,
+		},
+		{
+			desc:        "retrieve user info with invalid access token",
+			claims:      claims,
+			accessToken: "invalid",
+			idToken:     idToken,
+			err:         svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := newConfig(s.URL)
+			if tc.mapping != nil {
+				tc.mapping(&cfg.Claims)
+			}
+			cfg.TrustEmail = tc.trustEmail
+			provider := oidc.NewProvider(context.Background(), providerName, cfg, "", "")
+			s.claims = tc.claims
+			token := oauth2.Token{AccessToken: tc.accessToken}
+			if tc.idToken != "" {
+				token = *token.WithExtra(map[string]interface{}{"id_token": tc.idToken})
+			}
+			user, err := provider.UserInfo(token)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.user, user, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.user, user))
+		})
+	}
+}
@@ -48,6 +48,24 @@ func (_m *Provisioner) AddNewEntitiesRoles(ctx context.Context, domainID string,
 	return r0, r1
 }
 
+// MoveEntitiesRoles provides a mock function with given fields: ctx, fromDomainID, toDomainID, entityIDs
+func (_m *Provisioner) MoveEntitiesRoles(ctx context.Context, fromDomainID string, toDomainID string, entityIDs []string) error {
+	ret := _m.Called(ctx, fromDomainID, toDomainID, entityIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveEntitiesRoles")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, fromDomainID, toDomainID, entityIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveEntitiesRoles provides a mock function with given fields: ctx, domainID, userID, entityIDs, optionalFilterDeletePolicies, optionalDeletePolicies
 func (_m *Provisioner) RemoveEntitiesRoles(ctx context.Context, domainID string, userID string, entityIDs []string, optionalFilterDeletePolicies []policies.Policy, optionalDeletePolicies []policies.Policy) error {
 	ret := _m.Called(ctx, domainID, userID, entityIDs, optionalFilterDeletePolicies, optionalDeletePolicies)
@@ -137,6 +137,57 @@ func (r ProvisionManageService) RemoveEntitiesRoles(ctx context.Context, domainI
 	return nil
 }
 
+// MoveEntitiesRoles rewrites the role members relations of the entities from
+// the source domain to the target domain, so the entity roles keep their
+// members once the entities are moved.
+func (r ProvisionManageService) MoveEntitiesRoles(ctx context.Context, fromDomainID, toDomainID string, entityIDs []string) error {
+	return MoveEntitiesRoles(ctx, r.repo, r.policy, fromDomainID, toDomainID, entityIDs)
+}
+
+// MoveEntitiesRoles rewrites the role members relations of the entities
+// stored in the given repository, for the services that move the entities
+// without the role manager.
+func MoveEntitiesRoles(ctx context.Context, repo Repository, policy policies.Service, fromDomainID, toDomainID string, entityIDs []string) error {
+	_, emrs, err := repo.RetrieveEntitiesRolesActionsMembers(ctx, entityIDs)
+	if err != nil {
+		return err
+	}
+	if len(emrs) == 0 {
+		return nil
+	}
+
+	oldPolicies := []policies.Policy{}
+	newPolicies := []policies.Policy{}
+	for _, emr := range emrs {
+		oldPolicies = append(oldPolicies, policies.Policy{
+			Subject:     policies.EncodeDomainUserID(fromDomainID, emr.MemberID),
+			SubjectType: policies.UserType,
+			Relation:    policies.MemberRelation,
+			ObjectType:  policies.RoleType,
+			Object:      emr.RoleID,
+		})
+		newPolicies = append(newPolicies, policies.Policy{
+			Subject:     policies.EncodeDomainUserID(toDomainID, emr.MemberID),
+			SubjectType: policies.UserType,
+			Relation:    policies.MemberRelation,
+			ObjectType:  policies.RoleType,
+			Object:      emr.RoleID,
+		})
+	}
+
+	if err := policy.AddPolicies(ctx, newPolicies); err != nil {
+		return errors.Wrap(svcerr.ErrAddPolicies, err)
+	}
+	if err := policy.DeletePolicies(ctx, oldPolicies); err != nil {
+		if errRollback := policy.DeletePolicies(ctx, newPolicies); errRollback != nil {
+			err = errors.Wrap(err, errors.Wrap(errRollbackRoles, errRollback))
+		}
+		return errors.Wrap(svcerr.ErrDeletePolicies, err)
+	}
+
+	return nil
+}
+
 func (r ProvisionManageService) AddNewEntitiesRoles(ctx context.Context, domainID, userID string, entityIDs []string, optionalEntityPolicies []policies.Policy, newBuiltInRoleMembers map[BuiltInRoleName][]Member) (retRolesProvision []RoleProvision, retErr error) {
 	var newRolesProvision []RoleProvision
 	prs := []policies.Policy{}
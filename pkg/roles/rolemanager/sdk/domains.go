@@ -0,0 +1,310 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sdk contains the roles.RoleManager implementation which manages
+// the domain roles remotely using the SuperMQ SDK.
+package sdk
+
+import (
+	"context"
+	"slices"
+
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/roles"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
+)
+
+const membersPageLimit = 100
+
+var (
+	errUnsupported = errors.New("operation not supported by the remote domains role manager")
+	errToken       = errors.New("failed to obtain domains role manager token")
+)
+
+// TokenSource returns the access token used to call the domains service.
+// The token owner must be allowed to manage the domain roles, which is
+// usually the platform administrator.
+type TokenSource func(ctx context.Context) (string, error)
+
+var _ roles.RoleManager = (*domainsRoleManager)(nil)
+
+type domainsRoleManager struct {
+	sdk    mgsdk.SDK
+	tokens TokenSource
+}
+
+// NewDomainsRoleManager returns the domain role manager that calls the
+// domains service on behalf of the token source user. The entity ID of
+// all the calls is the domain ID, and the session is not used for the
+// authorization since the domains service authorizes the token owner.
+// The tokens are cached until shortly before they expire.
+func NewDomainsRoleManager(sdk mgsdk.SDK, tokens TokenSource) roles.RoleManager {
+	return &domainsRoleManager{
+		sdk:    sdk,
+		tokens: CachedTokenSource(tokens),
+	}
+}
+
+func (rm *domainsRoleManager) AddRole(ctx context.Context, _ authn.Session, entityID, roleName string, optionalActions, optionalMembers []string) (roles.RoleProvision, error) {
+	token, err := rm.token(ctx)
+	if err != nil {
+		return roles.RoleProvision{}, err
+	}
+	r, sdkErr := rm.sdk.CreateDomainRole(entityID, mgsdk.RoleReq{RoleName: roleName, OptionalActions: optionalActions, OptionalMembers: optionalMembers}, token)
+	if sdkErr != nil {
+		return roles.RoleProvision{}, sdkErr
+	}
+
+	return roles.RoleProvision{
+		Role:            toRole(r),
+		OptionalActions: r.OptionalActions,
+		OptionalMembers: r.OptionalMembers,
+	}, nil
+}
+
+func (rm *domainsRoleManager) RemoveRole(ctx context.Context, _ authn.Session, entityID, roleID string) error {
+	token, err := rm.token(ctx)
+	if err != nil {
+		return err
+	}
+	if sdkErr := rm.sdk.DeleteDomainRole(entityID, roleID, token); sdkErr != nil {
+		return sdkErr
+	}
+
+	return nil
+}
+
+func (rm *domainsRoleManager) UpdateRoleName(ctx context.Context, _ authn.Session, entityID, roleID, newRoleName string) (roles.Role, error) {
+	token, err := rm.token(ctx)
+	if err != nil {
+		return roles.Role{}, err
+	}
+	r, sdkErr := rm.sdk.UpdateDomainRole(entityID, roleID, newRoleName, token)
+	if sdkErr != nil {
+		return roles.Role{}, sdkErr
+	}
+
+	return toRole(r), nil
+}
+
+func (rm *domainsRoleManager) RetrieveRole(ctx context.Context, _ authn.Session, entityID, roleID string) (roles.Role, error) {
+	token, err := rm.token(ctx)
+	if err != nil {
+		return roles.Role{}, err
+	}
+	r, sdkErr := rm.sdk.DomainRole(entityID, roleID, token)
+	if sdkErr != nil {
+		return roles.Role{}, sdkErr
+	}
+
+	return toRole(r), nil
+}
+
+func (rm *domainsRoleManager) RetrieveAllRoles(ctx context.Context, _ authn.Session, entityID string, limit, offset uint64) (roles.RolePage, error) {
+	token, err := rm.token(ctx)
+	if err != nil {
+		return roles.RolePage{}, err
+	}
+	rp, sdkErr := rm.sdk.DomainRoles(entityID, mgsdk.PageMetadata{Offset: offset, Limit: limit}, token)
+	if sdkErr != nil {
+		return roles.RolePage{}, sdkErr
+	}
+
+	page := roles.RolePage{
+		Total:  rp.Total,
+		Offset: rp.Offset,
+		Limit:  rp.Limit,
+	}
+	for _, r := range rp.Roles {
+		page.Roles = append(page.Roles, toRole(r))
+	}
+
+	return page, nil
+}
+
+func (rm *domainsRoleManager) ListAvailableActions(ctx context.Context, _ authn.Session) ([]string, error) {
+	token, err := rm.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	actions, sdkErr := rm.sdk.AvailableDomainRoleActions(token)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	return actions, nil
+}
+
+func (rm *domainsRoleManager) RoleAddActions(ctx context.Context, _ authn.Session, entityID, roleID string, actions []string) ([]string, error) {
+	token, err := rm.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ops, sdkErr := rm.sdk.AddDomainRoleActions(entityID, roleID, actions, token)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	return ops, nil
+}
+
+func (rm *domainsRoleManager) RoleListActions(ctx context.Context, _ authn.Session, entityID, roleID string) ([]string, error) {
+	token, err := rm.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	actions, sdkErr := rm.sdk.DomainRoleActions(entityID, roleID, token)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	return actions, nil
+}
+
+func (rm *domainsRoleManager) RoleCheckActionsExists(ctx context.Context, session authn.Session, entityID, roleID string, actions []string) (bool, error) {
+	existing, err := rm.RoleListActions(ctx, session, entityID, roleID)
+	if err != nil {
+		return false, err
+	}
+
+	return containsAll(existing, actions), nil
+}
+
+func (rm *domainsRoleManager) RoleRemoveActions(ctx context.Context, _ authn.Session, entityID, roleID string, actions []string) error {
+	token, err := rm.token(ctx)
+	if err != nil {
+		return err
+	}
+	if sdkErr := rm.sdk.RemoveDomainRoleActions(entityID, roleID, actions, token); sdkErr != nil {
+		return sdkErr
+	}
+
+	return nil
+}
+
+func (rm *domainsRoleManager) RoleRemoveAllActions(ctx context.Context, _ authn.Session, entityID, roleID string) error {
+	token, err := rm.token(ctx)
+	if err != nil {
+		return err
+	}
+	if sdkErr := rm.sdk.RemoveAllDomainRoleActions(entityID, roleID, token); sdkErr != nil {
+		return sdkErr
+	}
+
+	return nil
+}
+
+func (rm *domainsRoleManager) RoleAddMembers(ctx context.Context, _ authn.Session, entityID, roleID string, members []string) ([]string, error) {
+	token, err := rm.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	added, sdkErr := rm.sdk.AddDomainRoleMembers(entityID, roleID, members, token)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	return added, nil
+}
+
+func (rm *domainsRoleManager) RoleListMembers(ctx context.Context, _ authn.Session, entityID, roleID string, limit, offset uint64) (roles.MembersPage, error) {
+	token, err := rm.token(ctx)
+	if err != nil {
+		return roles.MembersPage{}, err
+	}
+	mp, sdkErr := rm.sdk.DomainRoleMembers(entityID, roleID, mgsdk.PageMetadata{Offset: offset, Limit: limit}, token)
+	if sdkErr != nil {
+		return roles.MembersPage{}, sdkErr
+	}
+
+	return roles.MembersPage{
+		Total:   mp.Total,
+		Offset:  mp.Offset,
+		Limit:   mp.Limit,
+		Members: mp.Members,
+	}, nil
+}
+
+func (rm *domainsRoleManager) RoleCheckMembersExists(ctx context.Context, session authn.Session, entityID, roleID string, members []string) (bool, error) {
+	var existing []string
+	for offset := uint64(0); ; offset += membersPageLimit {
+		mp, err := rm.RoleListMembers(ctx, session, entityID, roleID, membersPageLimit, offset)
+		if err != nil {
+			return false, err
+		}
+		existing = append(existing, mp.Members...)
+		if len(mp.Members) == 0 || offset+membersPageLimit >= mp.Total {
+			break
+		}
+	}
+
+	return containsAll(existing, members), nil
+}
+
+func (rm *domainsRoleManager) RoleRemoveMembers(ctx context.Context, _ authn.Session, entityID, roleID string, members []string) error {
+	token, err := rm.token(ctx)
+	if err != nil {
+		return err
+	}
+	if sdkErr := rm.sdk.RemoveDomainRoleMembers(entityID, roleID, members, token); sdkErr != nil {
+		return sdkErr
+	}
+
+	return nil
+}
+
+func (rm *domainsRoleManager) RoleRemoveAllMembers(ctx context.Context, _ authn.Session, entityID, roleID string) error {
+	token, err := rm.token(ctx)
+	if err != nil {
+		return err
+	}
+	if sdkErr := rm.sdk.RemoveAllDomainRoleMembers(entityID, roleID, token); sdkErr != nil {
+		return sdkErr
+	}
+
+	return nil
+}
+
+func (rm *domainsRoleManager) ListEntityMembers(context.Context, authn.Session, string, roles.MembersRolePageQuery) (roles.MembersRolePage, error) {
+	return roles.MembersRolePage{}, errUnsupported
+}
+
+func (rm *domainsRoleManager) RemoveEntityMembers(context.Context, authn.Session, string, []string) error {
+	return errUnsupported
+}
+
+func (rm *domainsRoleManager) RemoveMemberFromAllRoles(context.Context, authn.Session, string) error {
+	return errUnsupported
+}
+
+func (rm *domainsRoleManager) token(ctx context.Context) (string, error) {
+	token, err := rm.tokens(ctx)
+	if err != nil {
+		return "", errors.Wrap(errToken, err)
+	}
+
+	return token, nil
+}
+
+func toRole(r mgsdk.Role) roles.Role {
+	return roles.Role{
+		ID:        r.ID,
+		Name:      r.Name,
+		EntityID:  r.EntityID,
+		CreatedBy: r.CreatedBy,
+		CreatedAt: r.CreatedAt,
+		UpdatedBy: r.UpdatedBy,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func containsAll(set, items []string) bool {
+	for _, item := range items {
+		if !slices.Contains(set, item) {
+			return false
+		}
+	}
+
+	return true
+}
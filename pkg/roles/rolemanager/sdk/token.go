@@ -0,0 +1,55 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+// tokenRefreshMargin is the time before the token expiry when the cached
+// token is replaced, so the token doesn't expire during the call.
+const tokenRefreshMargin = time.Minute
+
+type cachedToken struct {
+	mu        sync.Mutex
+	tokens    TokenSource
+	token     string
+	expiresAt time.Time
+}
+
+// CachedTokenSource returns the token source which reuses the token of the
+// given source until shortly before it expires. Each issued token creates a
+// new login session, so the tokens are not issued per call. Tokens without
+// the expiry claim are not cached.
+func CachedTokenSource(tokens TokenSource) TokenSource {
+	ct := &cachedToken{tokens: tokens}
+
+	return ct.get
+}
+
+func (ct *cachedToken) get(ctx context.Context) (string, error) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if ct.token != "" && time.Now().Add(tokenRefreshMargin).Before(ct.expiresAt) {
+		return ct.token, nil
+	}
+
+	token, err := ct.tokens(ctx)
+	if err != nil {
+		return "", err
+	}
+	ct.token, ct.expiresAt = token, time.Time{}
+	// The token is issued by the trusted source, so its signature is not
+	// verified here; only the expiry is read.
+	if t, err := jwt.ParseInsecure([]byte(token)); err == nil {
+		ct.expiresAt = t.Expiration()
+	}
+
+	return token, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/roles/rolemanager/sdk"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func issueToken(t *testing.T, exp time.Duration) string {
+	tkn, err := jwt.NewBuilder().Expiration(time.Now().Add(exp)).Build()
+	require.Nil(t, err, fmt.Sprintf("build token unexpected error: %s", err))
+	signed, err := jwt.Sign(tkn, jwt.WithKey(jwa.HS256, []byte("secret")))
+	require.Nil(t, err, fmt.Sprintf("sign token unexpected error: %s", err))
+
+	return string(signed)
+}
+
+func TestCachedTokenSource(t *testing.T) {
+	errIssue := errors.New("failed to issue token")
+
+	cases := []struct {
+		desc   string
+		token  string
+		err    error
+		issued int
+	}{
+		{
+			desc:   "reuse valid token",
+			token:  issueToken(t, time.Hour),
+			issued: 1,
+		},
+		{
+			desc:   "replace token expiring soon",
+			token:  issueToken(t, 30*time.Second),
+			issued: 2,
+		},
+		{
+			desc:   "replace token without expiry",
+			token:  "token",
+			issued: 2,
+		},
+		{
+			desc:   "retry failed token issue",
+			err:    errIssue,
+			issued: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			issued := 0
+			tokens := sdk.CachedTokenSource(func(context.Context) (string, error) {
+				issued++
+				return tc.token, tc.err
+			})
+
+			for i := 0; i < 2; i++ {
+				token, err := tokens(context.Background())
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+				assert.Equal(t, tc.token, token, fmt.Sprintf("%s: expected token %s got %s\n", tc.desc, tc.token, token))
+			}
+			assert.Equal(t, tc.issued, issued, fmt.Sprintf("%s: expected %d issued tokens got %d\n", tc.desc, tc.issued, issued))
+		})
+	}
+}
@@ -0,0 +1,884 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// BundleVersion is the version of the domain bundle format.
+	BundleVersion = "1"
+
+	// BundleJSON is the JSON encoding of the domain bundle.
+	BundleJSON = "json"
+	// BundleYAML is the YAML encoding of the domain bundle.
+	BundleYAML = "yaml"
+
+	bundlePageLimit = 100
+)
+
+// Entity kinds of the domain bundle.
+const (
+	BundleDomainKind     = "domain"
+	BundleGroupKind      = "group"
+	BundleClientKind     = "client"
+	BundleChannelKind    = "channel"
+	BundleConnectionKind = "connection"
+	BundleRoleKind       = "role"
+)
+
+// ConflictStrategy defines how the import handles the entities that already
+// exist in the target domain. The entities are matched by kind and name.
+type ConflictStrategy string
+
+const (
+	// ConflictFail aborts the import before any change is made.
+	ConflictFail ConflictStrategy = "fail"
+	// ConflictSkip keeps the existing entity and uses it in place of the bundle entity.
+	ConflictSkip ConflictStrategy = "skip"
+	// ConflictOverwrite updates the existing entity with the bundle entity.
+	ConflictOverwrite ConflictStrategy = "overwrite"
+	// ConflictDuplicate creates the bundle entity next to the existing one.
+	ConflictDuplicate ConflictStrategy = "duplicate"
+)
+
+// Import actions reported in the import result.
+const (
+	ImportCreate  = "create"
+	ImportUpdate  = "update"
+	ImportSkip    = "skip"
+	ImportConnect = "connect"
+	// ImportRollback reports the created entity removed after the import failure.
+	ImportRollback = "rollback"
+)
+
+var (
+	errBundleVersion    = errors.New("unsupported bundle version")
+	errBundleFormat     = errors.New("unsupported bundle format")
+	errBundleParent     = errors.New("bundle group parent not found")
+	errBundleConflict   = errors.New("entities already exist in the target domain")
+	errConflictStrategy = errors.New("invalid conflict strategy")
+	errBundleRollback   = errors.New("failed to remove imported entities")
+)
+
+// DomainBundle is a portable snapshot of the domain entities. Entity IDs are
+// the IDs from the source domain and are remapped on import. Client secrets
+// are never exported.
+type DomainBundle struct {
+	Version     string             `json:"version"`
+	ExportedAt  time.Time          `json:"exported_at"`
+	Domain      BundleDomain       `json:"domain"`
+	Groups      []BundleGroup      `json:"groups,omitempty"`
+	Clients     []BundleClient     `json:"clients,omitempty"`
+	Channels    []BundleChannel    `json:"channels,omitempty"`
+	Connections []BundleConnection `json:"connections,omitempty"`
+	Roles       []BundleRole       `json:"roles,omitempty"`
+}
+
+type BundleDomain struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name,omitempty"`
+	Alias    string   `json:"alias,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Metadata Metadata `json:"metadata,omitempty"`
+}
+
+type BundleGroup struct {
+	ID          string   `json:"id"`
+	ParentID    string   `json:"parent_id,omitempty"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Metadata    Metadata `json:"metadata,omitempty"`
+	Status      string   `json:"status,omitempty"`
+}
+
+type BundleClient struct {
+	ID          string   `json:"id"`
+	ParentGroup string   `json:"parent_group_id,omitempty"`
+	Name        string   `json:"name"`
+	Identity    string   `json:"identity,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Metadata    Metadata `json:"metadata,omitempty"`
+	Status      string   `json:"status,omitempty"`
+}
+
+type BundleChannel struct {
+	ID          string   `json:"id"`
+	ParentGroup string   `json:"parent_group_id,omitempty"`
+	Name        string   `json:"name"`
+	Tags        []string `json:"tags,omitempty"`
+	Metadata    Metadata `json:"metadata,omitempty"`
+	Status      string   `json:"status,omitempty"`
+}
+
+type BundleConnection struct {
+	ClientID  string   `json:"client_id"`
+	ChannelID string   `json:"channel_id"`
+	Types     []string `json:"types"`
+}
+
+// BundleRole is a role of the domain or of a bundle entity, identified by
+// the entity kind and the entity ID.
+type BundleRole struct {
+	EntityKind string   `json:"entity_kind"`
+	EntityID   string   `json:"entity_id"`
+	Name       string   `json:"name"`
+	Actions    []string `json:"actions,omitempty"`
+	Members    []string `json:"members,omitempty"`
+}
+
+// ImportOptions configures the domain import.
+type ImportOptions struct {
+	// DryRun computes the changes without applying them.
+	DryRun bool `json:"dry_run,omitempty"`
+	// Conflict is the strategy for the entities that already exist in the
+	// target domain, defaults to ConflictFail.
+	Conflict ConflictStrategy `json:"conflict,omitempty"`
+}
+
+// ImportChange is a single change made, or planned in the dry run, by the import.
+type ImportChange struct {
+	Kind     string `json:"kind"`
+	Action   string `json:"action"`
+	Name     string `json:"name,omitempty"`
+	SourceID string `json:"source_id,omitempty"`
+	TargetID string `json:"target_id,omitempty"`
+}
+
+// ImportResult lists the import changes and maps the bundle entity IDs to
+// the entity IDs in the target domain.
+type ImportResult struct {
+	DryRun  bool              `json:"dry_run"`
+	Changes []ImportChange    `json:"changes"`
+	IDs     map[string]string `json:"ids"`
+}
+
+// EncodeBundle encodes the domain bundle in the given format.
+func EncodeBundle(b DomainBundle, format string) ([]byte, error) {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(format) {
+	case BundleJSON:
+		return data, nil
+	case BundleYAML, "yml":
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(v)
+	default:
+		return nil, errors.Wrap(errBundleFormat, fmt.Errorf("%s", format))
+	}
+}
+
+// DecodeBundle decodes the domain bundle from the given format.
+func DecodeBundle(data []byte, format string) (DomainBundle, error) {
+	switch strings.ToLower(format) {
+	case BundleJSON:
+	case BundleYAML, "yml":
+		var v interface{}
+		if err := yaml.Unmarshal(data, &v); err != nil {
+			return DomainBundle{}, err
+		}
+		d, err := json.Marshal(v)
+		if err != nil {
+			return DomainBundle{}, err
+		}
+		data = d
+	default:
+		return DomainBundle{}, errors.Wrap(errBundleFormat, fmt.Errorf("%s", format))
+	}
+
+	var b DomainBundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return DomainBundle{}, err
+	}
+	if b.Version != BundleVersion {
+		return DomainBundle{}, errors.Wrap(errBundleVersion, fmt.Errorf("%s", b.Version))
+	}
+
+	return b, nil
+}
+
+func (sdk mgSDK) ExportDomain(domainID, token string) (DomainBundle, errors.SDKError) {
+	d, sdkerr := sdk.Domain(domainID, token)
+	if sdkerr != nil {
+		return DomainBundle{}, sdkerr
+	}
+	b := DomainBundle{
+		Version:    BundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Domain: BundleDomain{
+			ID:       d.ID,
+			Name:     d.Name,
+			Alias:    d.Alias,
+			Tags:     d.Tags,
+			Metadata: d.Metadata,
+		},
+	}
+
+	groups, sdkerr := sdk.allGroups(domainID, token)
+	if sdkerr != nil {
+		return DomainBundle{}, sdkerr
+	}
+	for _, g := range groups {
+		b.Groups = append(b.Groups, BundleGroup{
+			ID:          g.ID,
+			ParentID:    g.ParentID,
+			Name:        g.Name,
+			Description: g.Description,
+			Metadata:    g.Metadata,
+			Status:      g.Status,
+		})
+	}
+
+	clients, sdkerr := sdk.allClients(PageMetadata{}, domainID, token)
+	if sdkerr != nil {
+		return DomainBundle{}, sdkerr
+	}
+	for _, c := range clients {
+		b.Clients = append(b.Clients, BundleClient{
+			ID:          c.ID,
+			ParentGroup: c.ParentGroup,
+			Name:        c.Name,
+			Identity:    c.Credentials.Identity,
+			Tags:        c.Tags,
+			Metadata:    c.Metadata,
+			Status:      c.Status,
+		})
+	}
+
+	channels, sdkerr := sdk.allChannels(domainID, token)
+	if sdkerr != nil {
+		return DomainBundle{}, sdkerr
+	}
+	for _, ch := range channels {
+		b.Channels = append(b.Channels, BundleChannel{
+			ID:          ch.ID,
+			ParentGroup: ch.ParentGroup,
+			Name:        ch.Name,
+			Tags:        ch.Tags,
+			Metadata:    ch.Metadata,
+			Status:      ch.Status,
+		})
+		connected, sdkerr := sdk.allClients(PageMetadata{Channel: ch.ID}, domainID, token)
+		if sdkerr != nil {
+			return DomainBundle{}, sdkerr
+		}
+		for _, c := range connected {
+			b.Connections = append(b.Connections, BundleConnection{
+				ClientID:  c.ID,
+				ChannelID: ch.ID,
+				Types:     c.ConnectionTypes,
+			})
+		}
+	}
+
+	roleEntities := map[string][]string{BundleDomainKind: {domainID}}
+	for _, g := range b.Groups {
+		roleEntities[BundleGroupKind] = append(roleEntities[BundleGroupKind], g.ID)
+	}
+	for _, c := range b.Clients {
+		roleEntities[BundleClientKind] = append(roleEntities[BundleClientKind], c.ID)
+	}
+	for _, ch := range b.Channels {
+		roleEntities[BundleChannelKind] = append(roleEntities[BundleChannelKind], ch.ID)
+	}
+	for _, kind := range []string{BundleDomainKind, BundleGroupKind, BundleClientKind, BundleChannelKind} {
+		for _, id := range roleEntities[kind] {
+			roles, sdkerr := sdk.exportRoles(kind, id, domainID, token)
+			if sdkerr != nil {
+				return DomainBundle{}, sdkerr
+			}
+			b.Roles = append(b.Roles, roles...)
+		}
+	}
+
+	return b, nil
+}
+
+func (sdk mgSDK) ImportDomain(b DomainBundle, domainID string, opts ImportOptions, token string) (ImportResult, errors.SDKError) {
+	if b.Version != BundleVersion {
+		return ImportResult{}, errors.NewSDKError(errors.Wrap(errBundleVersion, fmt.Errorf("%s", b.Version)))
+	}
+	if opts.Conflict == "" {
+		opts.Conflict = ConflictFail
+	}
+	switch opts.Conflict {
+	case ConflictFail, ConflictSkip, ConflictOverwrite, ConflictDuplicate:
+	default:
+		return ImportResult{}, errors.NewSDKError(errors.Wrap(errConflictStrategy, fmt.Errorf("%s", opts.Conflict)))
+	}
+
+	groups, err := sortGroups(b.Groups)
+	if err != nil {
+		return ImportResult{}, errors.NewSDKError(err)
+	}
+
+	imp := importer{
+		sdk:      sdk,
+		domainID: domainID,
+		token:    token,
+		opts:     opts,
+		result: ImportResult{
+			DryRun:  opts.DryRun,
+			Changes: []ImportChange{},
+			IDs:     map[string]string{b.Domain.ID: domainID},
+		},
+		actions: map[string]string{b.Domain.ID: ImportUpdate},
+	}
+	if sdkerr := imp.plan(groups, b.Clients, b.Channels); sdkerr != nil {
+		return ImportResult{}, sdkerr
+	}
+	if sdkerr := imp.apply(groups, b); sdkerr != nil {
+		if rbErr := imp.rollback(); rbErr != nil {
+			return imp.result, errors.NewSDKErrorWithStatus(errors.Wrap(sdkerr, errors.Wrap(errBundleRollback, rbErr)), sdkerr.StatusCode())
+		}
+		return imp.result, sdkerr
+	}
+
+	return imp.result, nil
+}
+
+// importer applies the bundle to the target domain. The plan matches the
+// bundle entities with the existing entities before any change is made.
+type importer struct {
+	sdk      mgSDK
+	domainID string
+	token    string
+	opts     ImportOptions
+	result   ImportResult
+	// actions maps the bundle entity IDs to the planned import actions.
+	actions map[string]string
+	// created lists the created entities in the creation order.
+	created []ImportChange
+}
+
+func (imp *importer) apply(groups []BundleGroup, b DomainBundle) errors.SDKError {
+	for _, g := range groups {
+		if sdkerr := imp.importGroup(g); sdkerr != nil {
+			return sdkerr
+		}
+	}
+	for _, c := range b.Clients {
+		if sdkerr := imp.importClient(c); sdkerr != nil {
+			return sdkerr
+		}
+	}
+	for _, ch := range b.Channels {
+		if sdkerr := imp.importChannel(ch); sdkerr != nil {
+			return sdkerr
+		}
+	}
+	for _, conn := range b.Connections {
+		if sdkerr := imp.importConnection(conn); sdkerr != nil {
+			return sdkerr
+		}
+	}
+	for _, r := range b.Roles {
+		if sdkerr := imp.importRole(r); sdkerr != nil {
+			return sdkerr
+		}
+	}
+
+	return nil
+}
+
+// rollback removes the created entities in the reverse creation order, so
+// the child groups are removed before their parents. The connections and
+// roles of the created entities are removed with them. The updates of the
+// existing entities are not reverted.
+func (imp *importer) rollback() error {
+	var failed []string
+	for i := len(imp.created) - 1; i >= 0; i-- {
+		c := imp.created[i]
+		var sdkerr errors.SDKError
+		switch c.Kind {
+		case BundleGroupKind:
+			sdkerr = imp.sdk.DeleteGroup(c.TargetID, imp.domainID, imp.token)
+		case BundleClientKind:
+			sdkerr = imp.sdk.DeleteClient(c.TargetID, imp.domainID, imp.token)
+		case BundleChannelKind:
+			sdkerr = imp.sdk.DeleteChannel(c.TargetID, imp.domainID, imp.token)
+		}
+		if sdkerr != nil {
+			failed = append(failed, fmt.Sprintf("%s %s", c.Kind, c.TargetID))
+			continue
+		}
+		c.Action = ImportRollback
+		imp.result.Changes = append(imp.result.Changes, c)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+func (imp *importer) plan(groups []BundleGroup, clients []BundleClient, channels []BundleChannel) errors.SDKError {
+	existingGroups, sdkerr := imp.sdk.allGroups(imp.domainID, imp.token)
+	if sdkerr != nil {
+		return sdkerr
+	}
+	existingClients, sdkerr := imp.sdk.allClients(PageMetadata{}, imp.domainID, imp.token)
+	if sdkerr != nil {
+		return sdkerr
+	}
+	existingChannels, sdkerr := imp.sdk.allChannels(imp.domainID, imp.token)
+	if sdkerr != nil {
+		return sdkerr
+	}
+
+	groupIDs := map[string]string{}
+	for _, g := range existingGroups {
+		if _, ok := groupIDs[g.Name]; !ok {
+			groupIDs[g.Name] = g.ID
+		}
+	}
+	clientIDs := map[string]string{}
+	for _, c := range existingClients {
+		if _, ok := clientIDs[c.Name]; !ok {
+			clientIDs[c.Name] = c.ID
+		}
+	}
+	channelIDs := map[string]string{}
+	for _, ch := range existingChannels {
+		if _, ok := channelIDs[ch.Name]; !ok {
+			channelIDs[ch.Name] = ch.ID
+		}
+	}
+
+	conflicts := []string{}
+	match := func(kind, id, name string, existing map[string]string) {
+		targetID, ok := existing[name]
+		switch {
+		case !ok, imp.opts.Conflict == ConflictDuplicate:
+			imp.actions[id] = ImportCreate
+		case imp.opts.Conflict == ConflictFail:
+			conflicts = append(conflicts, fmt.Sprintf("%s %s", kind, name))
+		case imp.opts.Conflict == ConflictSkip:
+			imp.actions[id] = ImportSkip
+			imp.result.IDs[id] = targetID
+		case imp.opts.Conflict == ConflictOverwrite:
+			imp.actions[id] = ImportUpdate
+			imp.result.IDs[id] = targetID
+		}
+	}
+	for _, g := range groups {
+		match(BundleGroupKind, g.ID, g.Name, groupIDs)
+	}
+	for _, c := range clients {
+		match(BundleClientKind, c.ID, c.Name, clientIDs)
+	}
+	for _, ch := range channels {
+		match(BundleChannelKind, ch.ID, ch.Name, channelIDs)
+	}
+	if len(conflicts) > 0 {
+		return errors.NewSDKErrorWithStatus(errors.Wrap(errBundleConflict, fmt.Errorf("%s", strings.Join(conflicts, ", "))), http.StatusConflict)
+	}
+
+	return nil
+}
+
+func (imp *importer) record(kind, action, name, sourceID string) {
+	imp.result.Changes = append(imp.result.Changes, ImportChange{
+		Kind:     kind,
+		Action:   action,
+		Name:     name,
+		SourceID: sourceID,
+		TargetID: imp.result.IDs[sourceID],
+	})
+}
+
+func (imp *importer) importGroup(g BundleGroup) errors.SDKError {
+	action := imp.actions[g.ID]
+	if imp.opts.DryRun || action == ImportSkip {
+		imp.record(BundleGroupKind, action, g.Name, g.ID)
+		return nil
+	}
+	switch action {
+	case ImportCreate:
+		created, sdkerr := imp.sdk.CreateGroup(Group{
+			ParentID:    imp.result.IDs[g.ParentID],
+			Name:        g.Name,
+			Description: g.Description,
+			Metadata:    g.Metadata,
+			Status:      g.Status,
+		}, imp.domainID, imp.token)
+		if sdkerr != nil {
+			return sdkerr
+		}
+		imp.result.IDs[g.ID] = created.ID
+		imp.created = append(imp.created, ImportChange{Kind: BundleGroupKind, Name: g.Name, SourceID: g.ID, TargetID: created.ID})
+	case ImportUpdate:
+		if _, sdkerr := imp.sdk.UpdateGroup(Group{
+			ID:          imp.result.IDs[g.ID],
+			Name:        g.Name,
+			Description: g.Description,
+			Metadata:    g.Metadata,
+		}, imp.domainID, imp.token); sdkerr != nil {
+			return sdkerr
+		}
+	}
+	imp.record(BundleGroupKind, action, g.Name, g.ID)
+
+	return nil
+}
+
+func (imp *importer) importClient(c BundleClient) errors.SDKError {
+	action := imp.actions[c.ID]
+	if imp.opts.DryRun || action == ImportSkip {
+		imp.record(BundleClientKind, action, c.Name, c.ID)
+		return nil
+	}
+	switch action {
+	case ImportCreate:
+		created, sdkerr := imp.sdk.CreateClient(Client{
+			Name:        c.Name,
+			Tags:        c.Tags,
+			Credentials: ClientCredentials{Identity: c.Identity},
+			Metadata:    c.Metadata,
+			Status:      c.Status,
+		}, imp.domainID, imp.token)
+		if sdkerr != nil {
+			return sdkerr
+		}
+		imp.result.IDs[c.ID] = created.ID
+		imp.created = append(imp.created, ImportChange{Kind: BundleClientKind, Name: c.Name, SourceID: c.ID, TargetID: created.ID})
+		if parentID, ok := imp.result.IDs[c.ParentGroup]; ok && c.ParentGroup != "" {
+			if sdkerr := imp.sdk.SetClientParent(created.ID, imp.domainID, parentID, imp.token); sdkerr != nil {
+				return sdkerr
+			}
+		}
+	case ImportUpdate:
+		cli := Client{ID: imp.result.IDs[c.ID], Name: c.Name, Tags: c.Tags, Metadata: c.Metadata}
+		if _, sdkerr := imp.sdk.UpdateClient(cli, imp.domainID, imp.token); sdkerr != nil {
+			return sdkerr
+		}
+		if _, sdkerr := imp.sdk.UpdateClientTags(cli, imp.domainID, imp.token); sdkerr != nil {
+			return sdkerr
+		}
+	}
+	imp.record(BundleClientKind, action, c.Name, c.ID)
+
+	return nil
+}
+
+func (imp *importer) importChannel(ch BundleChannel) errors.SDKError {
+	action := imp.actions[ch.ID]
+	if imp.opts.DryRun || action == ImportSkip {
+		imp.record(BundleChannelKind, action, ch.Name, ch.ID)
+		return nil
+	}
+	switch action {
+	case ImportCreate:
+		created, sdkerr := imp.sdk.CreateChannel(Channel{
+			Name:     ch.Name,
+			Tags:     ch.Tags,
+			Metadata: ch.Metadata,
+			Status:   ch.Status,
+		}, imp.domainID, imp.token)
+		if sdkerr != nil {
+			return sdkerr
+		}
+		imp.result.IDs[ch.ID] = created.ID
+		imp.created = append(imp.created, ImportChange{Kind: BundleChannelKind, Name: ch.Name, SourceID: ch.ID, TargetID: created.ID})
+		if parentID, ok := imp.result.IDs[ch.ParentGroup]; ok && ch.ParentGroup != "" {
+			if sdkerr := imp.sdk.SetChannelParent(created.ID, imp.domainID, parentID, imp.token); sdkerr != nil {
+				return sdkerr
+			}
+		}
+	case ImportUpdate:
+		c := Channel{ID: imp.result.IDs[ch.ID], Name: ch.Name, Tags: ch.Tags, Metadata: ch.Metadata}
+		if _, sdkerr := imp.sdk.UpdateChannel(c, imp.domainID, imp.token); sdkerr != nil {
+			return sdkerr
+		}
+		if _, sdkerr := imp.sdk.UpdateChannelTags(c, imp.domainID, imp.token); sdkerr != nil {
+			return sdkerr
+		}
+	}
+	imp.record(BundleChannelKind, action, ch.Name, ch.ID)
+
+	return nil
+}
+
+func (imp *importer) importConnection(conn BundleConnection) errors.SDKError {
+	name := fmt.Sprintf("%s -> %s", conn.ClientID, conn.ChannelID)
+	change := ImportChange{
+		Kind:     BundleConnectionKind,
+		Action:   ImportConnect,
+		Name:     name,
+		SourceID: conn.ClientID,
+		TargetID: imp.result.IDs[conn.ClientID],
+	}
+	// The connections between the skipped entities are left as they are.
+	if imp.actions[conn.ClientID] == ImportSkip && imp.actions[conn.ChannelID] == ImportSkip {
+		change.Action = ImportSkip
+		imp.result.Changes = append(imp.result.Changes, change)
+		return nil
+	}
+	if !imp.opts.DryRun {
+		sdkerr := imp.sdk.Connect(Connection{
+			ClientIDs:  []string{imp.result.IDs[conn.ClientID]},
+			ChannelIDs: []string{imp.result.IDs[conn.ChannelID]},
+			Types:      conn.Types,
+		}, imp.domainID, imp.token)
+		switch {
+		case sdkerr != nil && sdkerr.StatusCode() == http.StatusConflict:
+			change.Action = ImportSkip
+		case sdkerr != nil:
+			return sdkerr
+		}
+	}
+	imp.result.Changes = append(imp.result.Changes, change)
+
+	return nil
+}
+
+// importRole creates the role on the target entity, or adds the missing
+// actions and members to the target entity role with the same name.
+func (imp *importer) importRole(r BundleRole) errors.SDKError {
+	entityID := imp.result.IDs[r.EntityID]
+	change := ImportChange{
+		Kind:     BundleRoleKind,
+		Action:   ImportCreate,
+		Name:     fmt.Sprintf("%s %s", r.EntityKind, r.Name),
+		SourceID: r.EntityID,
+		TargetID: entityID,
+	}
+	if imp.actions[r.EntityID] == ImportSkip {
+		change.Action = ImportSkip
+		imp.result.Changes = append(imp.result.Changes, change)
+		return nil
+	}
+	if imp.opts.DryRun && imp.actions[r.EntityID] == ImportCreate {
+		imp.result.Changes = append(imp.result.Changes, change)
+		return nil
+	}
+
+	url, endpoint, domainID := imp.sdk.roleEntity(r.EntityKind, imp.domainID)
+	roles, sdkerr := imp.sdk.allRoles(url, endpoint, entityID, domainID, imp.token)
+	if sdkerr != nil {
+		return sdkerr
+	}
+	idx := slices.IndexFunc(roles, func(role Role) bool { return role.Name == r.Name })
+	if idx < 0 {
+		if !imp.opts.DryRun {
+			rq := RoleReq{RoleName: r.Name, OptionalActions: r.Actions, OptionalMembers: r.Members}
+			if _, sdkerr := imp.sdk.createRole(url, endpoint, entityID, domainID, rq, imp.token); sdkerr != nil {
+				return sdkerr
+			}
+		}
+		imp.result.Changes = append(imp.result.Changes, change)
+		return nil
+	}
+
+	roleID := roles[idx].ID
+	actions, sdkerr := imp.sdk.listRoleActions(url, endpoint, entityID, roleID, domainID, imp.token)
+	if sdkerr != nil {
+		return sdkerr
+	}
+	members, sdkerr := imp.sdk.allRoleMembers(url, endpoint, entityID, roleID, domainID, imp.token)
+	if sdkerr != nil {
+		return sdkerr
+	}
+	newActions := missing(r.Actions, actions)
+	newMembers := missing(r.Members, members)
+	change.Action = ImportSkip
+	if len(newActions) > 0 || len(newMembers) > 0 {
+		change.Action = ImportUpdate
+	}
+	if !imp.opts.DryRun {
+		if len(newActions) > 0 {
+			if _, sdkerr := imp.sdk.addRoleActions(url, endpoint, entityID, roleID, domainID, newActions, imp.token); sdkerr != nil {
+				return sdkerr
+			}
+		}
+		if len(newMembers) > 0 {
+			if _, sdkerr := imp.sdk.addRoleMembers(url, endpoint, entityID, roleID, domainID, newMembers, imp.token); sdkerr != nil {
+				return sdkerr
+			}
+		}
+	}
+	imp.result.Changes = append(imp.result.Changes, change)
+
+	return nil
+}
+
+func (sdk mgSDK) exportRoles(kind, id, domainID, token string) ([]BundleRole, errors.SDKError) {
+	url, endpoint, domainID := sdk.roleEntity(kind, domainID)
+	roles, sdkerr := sdk.allRoles(url, endpoint, id, domainID, token)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	brs := []BundleRole{}
+	for _, r := range roles {
+		actions, sdkerr := sdk.listRoleActions(url, endpoint, id, r.ID, domainID, token)
+		if sdkerr != nil {
+			return nil, sdkerr
+		}
+		members, sdkerr := sdk.allRoleMembers(url, endpoint, id, r.ID, domainID, token)
+		if sdkerr != nil {
+			return nil, sdkerr
+		}
+		brs = append(brs, BundleRole{
+			EntityKind: kind,
+			EntityID:   id,
+			Name:       r.Name,
+			Actions:    actions,
+			Members:    members,
+		})
+	}
+
+	return brs, nil
+}
+
+// roleEntity returns the service URL, the entity endpoint and the domain ID
+// used by the role requests of the entity kind.
+func (sdk mgSDK) roleEntity(kind, domainID string) (string, string, string) {
+	switch kind {
+	case BundleGroupKind:
+		return sdk.groupsURL, groupsEndpoint, domainID
+	case BundleClientKind:
+		return sdk.clientsURL, clientsEndpoint, domainID
+	case BundleChannelKind:
+		return sdk.channelsURL, channelsEndpoint, domainID
+	default:
+		return sdk.domainsURL, domainsEndpoint, ""
+	}
+}
+
+func (sdk mgSDK) allGroups(domainID, token string) ([]Group, errors.SDKError) {
+	groups := []Group{}
+	for offset := uint64(0); ; offset += bundlePageLimit {
+		page, sdkerr := sdk.Groups(PageMetadata{Offset: offset, Limit: bundlePageLimit}, domainID, token)
+		if sdkerr != nil {
+			return nil, sdkerr
+		}
+		groups = append(groups, page.Groups...)
+		if len(page.Groups) == 0 || offset+bundlePageLimit >= page.Total {
+			return groups, nil
+		}
+	}
+}
+
+func (sdk mgSDK) allClients(pm PageMetadata, domainID, token string) ([]Client, errors.SDKError) {
+	clients := []Client{}
+	pm.Limit = bundlePageLimit
+	for pm.Offset = 0; ; pm.Offset += bundlePageLimit {
+		page, sdkerr := sdk.Clients(pm, domainID, token)
+		if sdkerr != nil {
+			return nil, sdkerr
+		}
+		clients = append(clients, page.Clients...)
+		if len(page.Clients) == 0 || pm.Offset+bundlePageLimit >= page.Total {
+			return clients, nil
+		}
+	}
+}
+
+func (sdk mgSDK) allChannels(domainID, token string) ([]Channel, errors.SDKError) {
+	channels := []Channel{}
+	for offset := uint64(0); ; offset += bundlePageLimit {
+		page, sdkerr := sdk.Channels(PageMetadata{Offset: offset, Limit: bundlePageLimit}, domainID, token)
+		if sdkerr != nil {
+			return nil, sdkerr
+		}
+		channels = append(channels, page.Channels...)
+		if len(page.Channels) == 0 || offset+bundlePageLimit >= page.Total {
+			return channels, nil
+		}
+	}
+}
+
+func (sdk mgSDK) allRoles(url, endpoint, id, domainID, token string) ([]Role, errors.SDKError) {
+	roles := []Role{}
+	for offset := uint64(0); ; offset += bundlePageLimit {
+		page, sdkerr := sdk.listRoles(url, endpoint, id, domainID, PageMetadata{Offset: offset, Limit: bundlePageLimit}, token)
+		if sdkerr != nil {
+			return nil, sdkerr
+		}
+		roles = append(roles, page.Roles...)
+		if len(page.Roles) == 0 || offset+bundlePageLimit >= page.Total {
+			return roles, nil
+		}
+	}
+}
+
+func (sdk mgSDK) allRoleMembers(url, endpoint, id, roleID, domainID, token string) ([]string, errors.SDKError) {
+	members := []string{}
+	for offset := uint64(0); ; offset += bundlePageLimit {
+		page, sdkerr := sdk.listRoleMembers(url, endpoint, id, roleID, domainID, PageMetadata{Offset: offset, Limit: bundlePageLimit}, token)
+		if sdkerr != nil {
+			return nil, sdkerr
+		}
+		members = append(members, page.Members...)
+		if len(page.Members) == 0 || offset+bundlePageLimit >= page.Total {
+			return members, nil
+		}
+	}
+}
+
+// sortGroups orders the groups so that the parents come before the children.
+// The groups with the parent outside of the bundle are imported as root groups.
+func sortGroups(groups []BundleGroup) ([]BundleGroup, error) {
+	byID := map[string]BundleGroup{}
+	for _, g := range groups {
+		byID[g.ID] = g
+	}
+
+	sorted := make([]BundleGroup, 0, len(groups))
+	visited := map[string]bool{}
+	visiting := map[string]bool{}
+	var visit func(g BundleGroup) error
+	visit = func(g BundleGroup) error {
+		if visited[g.ID] {
+			return nil
+		}
+		if visiting[g.ID] {
+			return errors.Wrap(errBundleParent, fmt.Errorf("cycle at group %s", g.ID))
+		}
+		visiting[g.ID] = true
+		if parent, ok := byID[g.ParentID]; ok && g.ParentID != "" {
+			if err := visit(parent); err != nil {
+				return err
+			}
+		}
+		visiting[g.ID] = false
+		visited[g.ID] = true
+		sorted = append(sorted, g)
+		return nil
+	}
+	for _, g := range groups {
+		if err := visit(g); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
+
+// missing returns the values that are not in the existing values.
+func missing(values, existing []string) []string {
+	ret := []string{}
+	for _, v := range values {
+		if !slices.Contains(existing, v) && !slices.Contains(ret, v) {
+			ret = append(ret, v)
+		}
+	}
+
+	return ret
+}
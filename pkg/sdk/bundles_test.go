@@ -0,0 +1,339 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/channels"
+	chmocks "github.com/absmach/supermq/channels/mocks"
+	"github.com/absmach/supermq/clients"
+	climocks "github.com/absmach/supermq/clients/mocks"
+	"github.com/absmach/supermq/domains"
+	dmocks "github.com/absmach/supermq/domains/mocks"
+	"github.com/absmach/supermq/groups"
+	gmocks "github.com/absmach/supermq/groups/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/connections"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/roles"
+	sdk "github.com/absmach/supermq/pkg/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type bundleServices struct {
+	domains  *dmocks.Service
+	groups   *gmocks.Service
+	clients  *climocks.Service
+	channels *chmocks.Service
+}
+
+func setupBundle(t *testing.T, domainID string) (sdk.SDK, bundleServices) {
+	ds, dsvc, dauth := setupDomains()
+	gs, gsvc, gauth := setupGroups()
+	cs, csvc, cauth := setupClients()
+	chs, chsvc, chauth := setupChannels()
+	t.Cleanup(func() {
+		ds.Close()
+		gs.Close()
+		cs.Close()
+		chs.Close()
+	})
+
+	session := smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+	dauth.On("Authenticate", mock.Anything, mock.Anything).Return(session, nil)
+	gauth.On("Authenticate", mock.Anything, mock.Anything).Return(session, nil)
+	cauth.On("Authenticate", mock.Anything, mock.Anything).Return(session, nil)
+	chauth.On("Authenticate", mock.Anything, mock.Anything).Return(session, nil)
+
+	conf := sdk.Config{
+		DomainsURL:  ds.URL,
+		GroupsURL:   gs.URL,
+		ClientsURL:  cs.URL,
+		ChannelsURL: chs.URL,
+	}
+
+	return sdk.NewSDK(conf), bundleServices{domains: dsvc, groups: gsvc, clients: csvc, channels: chsvc}
+}
+
+func testBundle(t *testing.T) sdk.DomainBundle {
+	groupID := testsutil.GenerateUUID(t)
+	clientID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+
+	return sdk.DomainBundle{
+		Version:    sdk.BundleVersion,
+		ExportedAt: time.Now().UTC().Truncate(time.Second),
+		Domain:     sdk.BundleDomain{ID: testsutil.GenerateUUID(t), Name: "staging", Alias: "staging"},
+		Groups:     []sdk.BundleGroup{{ID: groupID, Name: "gateways", Status: "enabled"}},
+		Clients:    []sdk.BundleClient{{ID: clientID, ParentGroup: groupID, Name: "sensor", Tags: []string{"tag"}, Status: "enabled"}},
+		Channels:   []sdk.BundleChannel{{ID: channelID, Name: "telemetry", Status: "enabled"}},
+		Connections: []sdk.BundleConnection{
+			{ClientID: clientID, ChannelID: channelID, Types: []string{"publish"}},
+		},
+		Roles: []sdk.BundleRole{
+			{EntityKind: sdk.BundleGroupKind, EntityID: groupID, Name: "admin", Actions: []string{"read"}, Members: []string{validID}},
+		},
+	}
+}
+
+func TestEncodeBundle(t *testing.T) {
+	bundle := testBundle(t)
+
+	cases := []struct {
+		desc    string
+		format  string
+		version string
+		err     error
+	}{
+		{
+			desc:   "encode and decode JSON bundle",
+			format: sdk.BundleJSON,
+		},
+		{
+			desc:   "encode and decode YAML bundle",
+			format: sdk.BundleYAML,
+		},
+		{
+			desc:   "encode bundle with unsupported format",
+			format: "xml",
+			err:    errors.New("unsupported bundle format"),
+		},
+		{
+			desc:    "decode bundle with unsupported version",
+			format:  sdk.BundleJSON,
+			version: "0",
+			err:     errors.New("unsupported bundle version"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			b := bundle
+			if tc.version != "" {
+				b.Version = tc.version
+			}
+			data, err := sdk.EncodeBundle(b, tc.format)
+			if err == nil {
+				var decoded sdk.DomainBundle
+				decoded, err = sdk.DecodeBundle(data, tc.format)
+				if err == nil {
+					assert.Equal(t, bundle, decoded, fmt.Sprintf("%s: expected %v got %v", tc.desc, bundle, decoded))
+				}
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %v got %v", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestExportDomain(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	mgsdk, svcs := setupBundle(t, domainID)
+
+	group := groups.Group{ID: testsutil.GenerateUUID(t), Name: "gateways", Domain: domainID, Status: groups.EnabledStatus}
+	client := clients.Client{
+		ID:              testsutil.GenerateUUID(t),
+		Name:            "sensor",
+		Domain:          domainID,
+		ParentGroup:     group.ID,
+		Credentials:     clients.Credentials{Identity: "sensor", Secret: secret},
+		Status:          clients.EnabledStatus,
+		ConnectionTypes: []connections.ConnType{connections.Publish},
+	}
+	channel := channels.Channel{ID: testsutil.GenerateUUID(t), Name: "telemetry", Domain: domainID, Status: clients.EnabledStatus}
+	role := roles.Role{ID: testsutil.GenerateUUID(t), Name: "admin"}
+
+	svcs.domains.On("RetrieveDomain", mock.Anything, mock.Anything, domainID).Return(domains.Domain{ID: domainID, Name: "staging", Alias: "staging"}, nil)
+	svcs.groups.On("ListGroups", mock.Anything, mock.Anything, mock.Anything).Return(groups.Page{PageMeta: groups.PageMeta{Total: 1}, Groups: []groups.Group{group}}, nil)
+	svcs.clients.On("ListClients", mock.Anything, mock.Anything, mock.Anything).Return(clients.ClientsPage{Page: clients.Page{Total: 1}, Clients: []clients.Client{client}}, nil)
+	svcs.channels.On("ListChannels", mock.Anything, mock.Anything, mock.Anything).Return(channels.Page{PageMetadata: channels.PageMetadata{Total: 1}, Channels: []channels.Channel{channel}}, nil)
+	svcs.domains.On("RetrieveAllRoles", mock.Anything, mock.Anything, domainID, mock.Anything, mock.Anything).Return(roles.RolePage{Total: 1, Roles: []roles.Role{role}}, nil)
+	svcs.domains.On("RoleListActions", mock.Anything, mock.Anything, domainID, role.ID).Return([]string{"read"}, nil)
+	svcs.domains.On("RoleListMembers", mock.Anything, mock.Anything, domainID, role.ID, mock.Anything, mock.Anything).Return(roles.MembersPage{Total: 1, Members: []string{validID}}, nil)
+	svcs.groups.On("RetrieveAllRoles", mock.Anything, mock.Anything, group.ID, mock.Anything, mock.Anything).Return(roles.RolePage{}, nil)
+	svcs.clients.On("RetrieveAllRoles", mock.Anything, mock.Anything, client.ID, mock.Anything, mock.Anything).Return(roles.RolePage{}, nil)
+	svcs.channels.On("RetrieveAllRoles", mock.Anything, mock.Anything, channel.ID, mock.Anything, mock.Anything).Return(roles.RolePage{}, nil)
+
+	bundle, err := mgsdk.ExportDomain(domainID, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %v", err))
+	assert.Equal(t, sdk.BundleVersion, bundle.Version)
+	assert.Equal(t, sdk.BundleDomain{ID: domainID, Name: "staging", Alias: "staging"}, bundle.Domain)
+	assert.Equal(t, []sdk.BundleGroup{{ID: group.ID, Name: group.Name, Status: "enabled"}}, bundle.Groups)
+	assert.Equal(t, []sdk.BundleClient{{ID: client.ID, ParentGroup: group.ID, Name: client.Name, Identity: "sensor", Status: "enabled"}}, bundle.Clients)
+	assert.Equal(t, []sdk.BundleChannel{{ID: channel.ID, Name: channel.Name}}, bundle.Channels)
+	assert.Equal(t, []sdk.BundleConnection{{ClientID: client.ID, ChannelID: channel.ID, Types: []string{"Publish"}}}, bundle.Connections)
+	assert.Equal(t, []sdk.BundleRole{{EntityKind: sdk.BundleDomainKind, EntityID: domainID, Name: "admin", Actions: []string{"read"}, Members: []string{validID}}}, bundle.Roles)
+}
+
+func TestImportDomain(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	mgsdk, svcs := setupBundle(t, domainID)
+
+	bundle := testBundle(t)
+	srcGroup, srcClient, srcChannel := bundle.Groups[0], bundle.Clients[0], bundle.Channels[0]
+	newGroupID := testsutil.GenerateUUID(t)
+	newClientID := testsutil.GenerateUUID(t)
+	newChannelID := testsutil.GenerateUUID(t)
+	existingGroup := groups.Group{ID: testsutil.GenerateUUID(t), Name: srcGroup.Name, Domain: domainID, Status: groups.EnabledStatus}
+	roleID := testsutil.GenerateUUID(t)
+	connName := fmt.Sprintf("%s -> %s", srcClient.ID, srcChannel.ID)
+
+	svcs.clients.On("ListClients", mock.Anything, mock.Anything, mock.Anything).Return(clients.ClientsPage{}, nil)
+	svcs.channels.On("ListChannels", mock.Anything, mock.Anything, mock.Anything).Return(channels.Page{}, nil)
+	svcs.groups.On("CreateGroup", mock.Anything, mock.Anything, mock.Anything).Return(groups.Group{ID: newGroupID, Name: srcGroup.Name}, []roles.RoleProvision{}, nil)
+	svcs.clients.On("CreateClients", mock.Anything, mock.Anything, mock.Anything).Return([]clients.Client{{ID: newClientID, Name: srcClient.Name}}, []roles.RoleProvision{}, nil)
+	svcs.clients.On("SetParentGroup", mock.Anything, mock.Anything, newGroupID, newClientID).Return(nil)
+	svcs.channels.On("CreateChannels", mock.Anything, mock.Anything, mock.Anything).Return([]channels.Channel{{ID: newChannelID, Name: srcChannel.Name}}, []roles.RoleProvision{}, nil)
+	svcs.channels.On("Connect", mock.Anything, mock.Anything, []string{newChannelID}, []string{newClientID}, []connections.ConnType{connections.Publish}, connections.Scope{}).Return(nil)
+	svcs.groups.On("RetrieveAllRoles", mock.Anything, mock.Anything, newGroupID, mock.Anything, mock.Anything).Return(roles.RolePage{Total: 1, Roles: []roles.Role{{ID: roleID, Name: "admin"}}}, nil)
+	svcs.groups.On("RoleListActions", mock.Anything, mock.Anything, newGroupID, roleID).Return([]string{"read"}, nil)
+	svcs.groups.On("RoleListMembers", mock.Anything, mock.Anything, newGroupID, roleID, mock.Anything, mock.Anything).Return(roles.MembersPage{}, nil)
+	svcs.groups.On("RoleAddMembers", mock.Anything, mock.Anything, newGroupID, roleID, []string{validID}).Return([]string{validID}, nil)
+
+	cases := []struct {
+		desc           string
+		bundle         sdk.DomainBundle
+		opts           sdk.ImportOptions
+		existingGroups []groups.Group
+		response       sdk.ImportResult
+		status         int
+	}{
+		{
+			desc:   "dry run import to empty domain",
+			bundle: bundle,
+			opts:   sdk.ImportOptions{DryRun: true},
+			response: sdk.ImportResult{
+				DryRun: true,
+				Changes: []sdk.ImportChange{
+					{Kind: sdk.BundleGroupKind, Action: sdk.ImportCreate, Name: srcGroup.Name, SourceID: srcGroup.ID},
+					{Kind: sdk.BundleClientKind, Action: sdk.ImportCreate, Name: srcClient.Name, SourceID: srcClient.ID},
+					{Kind: sdk.BundleChannelKind, Action: sdk.ImportCreate, Name: srcChannel.Name, SourceID: srcChannel.ID},
+					{Kind: sdk.BundleConnectionKind, Action: sdk.ImportConnect, Name: connName, SourceID: srcClient.ID},
+					{Kind: sdk.BundleRoleKind, Action: sdk.ImportCreate, Name: "group admin", SourceID: srcGroup.ID},
+				},
+				IDs: map[string]string{bundle.Domain.ID: domainID},
+			},
+		},
+		{
+			desc:           "dry run import with skip conflict strategy",
+			bundle:         bundle,
+			opts:           sdk.ImportOptions{DryRun: true, Conflict: sdk.ConflictSkip},
+			existingGroups: []groups.Group{existingGroup},
+			response: sdk.ImportResult{
+				DryRun: true,
+				Changes: []sdk.ImportChange{
+					{Kind: sdk.BundleGroupKind, Action: sdk.ImportSkip, Name: srcGroup.Name, SourceID: srcGroup.ID, TargetID: existingGroup.ID},
+					{Kind: sdk.BundleClientKind, Action: sdk.ImportCreate, Name: srcClient.Name, SourceID: srcClient.ID},
+					{Kind: sdk.BundleChannelKind, Action: sdk.ImportCreate, Name: srcChannel.Name, SourceID: srcChannel.ID},
+					{Kind: sdk.BundleConnectionKind, Action: sdk.ImportConnect, Name: connName, SourceID: srcClient.ID},
+					{Kind: sdk.BundleRoleKind, Action: sdk.ImportSkip, Name: "group admin", SourceID: srcGroup.ID, TargetID: existingGroup.ID},
+				},
+				IDs: map[string]string{bundle.Domain.ID: domainID, srcGroup.ID: existingGroup.ID},
+			},
+		},
+		{
+			desc:   "import to empty domain",
+			bundle: bundle,
+			opts:   sdk.ImportOptions{},
+			response: sdk.ImportResult{
+				Changes: []sdk.ImportChange{
+					{Kind: sdk.BundleGroupKind, Action: sdk.ImportCreate, Name: srcGroup.Name, SourceID: srcGroup.ID, TargetID: newGroupID},
+					{Kind: sdk.BundleClientKind, Action: sdk.ImportCreate, Name: srcClient.Name, SourceID: srcClient.ID, TargetID: newClientID},
+					{Kind: sdk.BundleChannelKind, Action: sdk.ImportCreate, Name: srcChannel.Name, SourceID: srcChannel.ID, TargetID: newChannelID},
+					{Kind: sdk.BundleConnectionKind, Action: sdk.ImportConnect, Name: connName, SourceID: srcClient.ID, TargetID: newClientID},
+					{Kind: sdk.BundleRoleKind, Action: sdk.ImportUpdate, Name: "group admin", SourceID: srcGroup.ID, TargetID: newGroupID},
+				},
+				IDs: map[string]string{
+					bundle.Domain.ID: domainID,
+					srcGroup.ID:      newGroupID,
+					srcClient.ID:     newClientID,
+					srcChannel.ID:    newChannelID,
+				},
+			},
+		},
+		{
+			desc:           "import with fail conflict strategy",
+			bundle:         bundle,
+			opts:           sdk.ImportOptions{Conflict: sdk.ConflictFail},
+			existingGroups: []groups.Group{existingGroup},
+			status:         http.StatusConflict,
+		},
+		{
+			desc:   "import with invalid conflict strategy",
+			bundle: bundle,
+			opts:   sdk.ImportOptions{Conflict: "invalid"},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "import with unsupported bundle version",
+			bundle: sdk.DomainBundle{Version: "0"},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svcs.groups.On("ListGroups", mock.Anything, mock.Anything, mock.Anything).Return(groups.Page{PageMeta: groups.PageMeta{Total: uint64(len(tc.existingGroups))}, Groups: tc.existingGroups}, nil)
+			resp, err := mgsdk.ImportDomain(tc.bundle, domainID, tc.opts, validToken)
+			switch tc.status {
+			case 0:
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %v", tc.desc, err))
+				assert.Equal(t, tc.response, resp)
+			default:
+				assert.NotNil(t, err, fmt.Sprintf("%s: expected error", tc.desc))
+				if err != nil && tc.status == http.StatusConflict {
+					assert.Equal(t, tc.status, err.StatusCode())
+				}
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestImportDomainRollback(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	mgsdk, svcs := setupBundle(t, domainID)
+
+	bundle := testBundle(t)
+	srcGroup, srcClient, srcChannel := bundle.Groups[0], bundle.Clients[0], bundle.Channels[0]
+	newGroupID := testsutil.GenerateUUID(t)
+	newClientID := testsutil.GenerateUUID(t)
+	newChannelID := testsutil.GenerateUUID(t)
+
+	svcs.groups.On("ListGroups", mock.Anything, mock.Anything, mock.Anything).Return(groups.Page{}, nil)
+	svcs.clients.On("ListClients", mock.Anything, mock.Anything, mock.Anything).Return(clients.ClientsPage{}, nil)
+	svcs.channels.On("ListChannels", mock.Anything, mock.Anything, mock.Anything).Return(channels.Page{}, nil)
+	svcs.groups.On("CreateGroup", mock.Anything, mock.Anything, mock.Anything).Return(groups.Group{ID: newGroupID, Name: srcGroup.Name}, []roles.RoleProvision{}, nil)
+	svcs.clients.On("CreateClients", mock.Anything, mock.Anything, mock.Anything).Return([]clients.Client{{ID: newClientID, Name: srcClient.Name}}, []roles.RoleProvision{}, nil)
+	svcs.clients.On("SetParentGroup", mock.Anything, mock.Anything, newGroupID, newClientID).Return(nil)
+	svcs.channels.On("CreateChannels", mock.Anything, mock.Anything, mock.Anything).Return([]channels.Channel{{ID: newChannelID, Name: srcChannel.Name}}, []roles.RoleProvision{}, nil)
+	svcs.channels.On("Connect", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(svcerr.ErrAuthorization)
+	svcs.channels.On("RemoveChannel", mock.Anything, mock.Anything, newChannelID).Return(nil)
+	svcs.clients.On("Delete", mock.Anything, mock.Anything, newClientID).Return(nil)
+	svcs.groups.On("DeleteGroup", mock.Anything, mock.Anything, newGroupID).Return(nil)
+
+	resp, err := mgsdk.ImportDomain(bundle, domainID, sdk.ImportOptions{}, validToken)
+	assert.NotNil(t, err, "expected import error")
+	svcs.channels.AssertCalled(t, "RemoveChannel", mock.Anything, mock.Anything, newChannelID)
+	svcs.clients.AssertCalled(t, "Delete", mock.Anything, mock.Anything, newClientID)
+	svcs.groups.AssertCalled(t, "DeleteGroup", mock.Anything, mock.Anything, newGroupID)
+	rollbacks := []sdk.ImportChange{}
+	for _, c := range resp.Changes {
+		if c.Action == sdk.ImportRollback {
+			rollbacks = append(rollbacks, c)
+		}
+	}
+	expected := []sdk.ImportChange{
+		{Kind: sdk.BundleChannelKind, Action: sdk.ImportRollback, Name: srcChannel.Name, SourceID: srcChannel.ID, TargetID: newChannelID},
+		{Kind: sdk.BundleClientKind, Action: sdk.ImportRollback, Name: srcClient.Name, SourceID: srcClient.ID, TargetID: newClientID},
+		{Kind: sdk.BundleGroupKind, Action: sdk.ImportRollback, Name: srcGroup.Name, SourceID: srcGroup.ID, TargetID: newGroupID},
+	}
+	assert.Equal(t, expected, rollbacks)
+}
@@ -719,12 +719,12 @@ func TestViewChannel(t *testing.T) {
 				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
 			}
 			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
-			svcCall := gsvc.On("ViewChannel", mock.Anything, tc.session, tc.channelID).Return(tc.svcRes, tc.svcErr)
+			svcCall := gsvc.On("ViewChannel", mock.Anything, tc.session, tc.channelID, false).Return(tc.svcRes, tc.svcErr)
 			resp, err := mgsdk.Channel(tc.channelID, tc.domainID, tc.token)
 			assert.Equal(t, tc.err, err)
 			assert.Equal(t, tc.response, resp)
 			if tc.err == nil {
-				ok := svcCall.Parent.AssertCalled(t, "ViewChannel", mock.Anything, tc.session, tc.channelID)
+				ok := svcCall.Parent.AssertCalled(t, "ViewChannel", mock.Anything, tc.session, tc.channelID, false)
 				assert.True(t, ok)
 			}
 			svcCall.Unset()
@@ -1485,6 +1485,32 @@ func TestConnect(t *testing.T) {
 			svcErr: nil,
 			err:    nil,
 		},
+		{
+			desc:     "connect with subtopics successfully",
+			domainID: domainID,
+			token:    validToken,
+			connection: sdk.Connection{
+				ChannelIDs: []string{channel.ID},
+				ClientIDs:  []string{clientID},
+				Types:      []string{"Publish"},
+				Subtopics:  []string{"sensors.temp.*"},
+			},
+			svcErr: nil,
+			err:    nil,
+		},
+		{
+			desc:     "connect with invalid subtopic",
+			domainID: domainID,
+			token:    validToken,
+			connection: sdk.Connection{
+				ChannelIDs: []string{channel.ID},
+				ClientIDs:  []string{clientID},
+				Types:      []string{"Publish"},
+				Subtopics:  []string{"sensors.>.temp"},
+			},
+			svcErr: nil,
+			err:    errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, connections.ErrInvalidSubtopic), http.StatusBadRequest),
+		},
 		{
 			desc:     "connect with invalid token",
 			domainID: domainID,
@@ -1557,11 +1583,12 @@ func TestConnect(t *testing.T) {
 				connTypes = append(connTypes, connType)
 			}
 			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
-			svcCall := gsvc.On("Connect", mock.Anything, tc.session, tc.connection.ChannelIDs, tc.connection.ClientIDs, connTypes).Return(tc.svcErr)
+			scope := connections.Scope{ExpiresAt: tc.connection.ExpiresAt, Subtopics: tc.connection.Subtopics}
+			svcCall := gsvc.On("Connect", mock.Anything, tc.session, tc.connection.ChannelIDs, tc.connection.ClientIDs, connTypes, scope).Return(tc.svcErr)
 			err := mgsdk.Connect(tc.connection, tc.domainID, tc.token)
 			assert.Equal(t, tc.err, err)
 			if tc.err == nil {
-				ok := svcCall.Parent.AssertCalled(t, "Connect", mock.Anything, tc.session, tc.connection.ChannelIDs, tc.connection.ClientIDs, connTypes)
+				ok := svcCall.Parent.AssertCalled(t, "Connect", mock.Anything, tc.session, tc.connection.ChannelIDs, tc.connection.ClientIDs, connTypes, scope)
 				assert.True(t, ok)
 			}
 			svcCall.Unset()
@@ -1781,11 +1808,11 @@ func TestConnectClients(t *testing.T) {
 			connType, err := connections.ParseConnType(tc.connType)
 			assert.Nil(t, err, fmt.Sprintf("error parsing connection type %s", tc.connType))
 			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
-			svcCall := gsvc.On("Connect", mock.Anything, tc.session, []string{tc.channelID}, []string{tc.clientID}, []connections.ConnType{connType}).Return(tc.svcErr)
+			svcCall := gsvc.On("Connect", mock.Anything, tc.session, []string{tc.channelID}, []string{tc.clientID}, []connections.ConnType{connType}, connections.Scope{}).Return(tc.svcErr)
 			err = mgsdk.ConnectClients(tc.channelID, []string{tc.clientID}, []string{tc.connType}, tc.domainID, tc.token)
 			assert.Equal(t, tc.err, err)
 			if tc.err == nil {
-				ok := svcCall.Parent.AssertCalled(t, "Connect", mock.Anything, tc.session, []string{tc.channelID}, []string{tc.clientID}, []connections.ConnType{connType})
+				ok := svcCall.Parent.AssertCalled(t, "Connect", mock.Anything, tc.session, []string{tc.channelID}, []string{tc.clientID}, []connections.ConnType{connType}, connections.Scope{})
 				assert.True(t, ok)
 			}
 			svcCall.Unset()
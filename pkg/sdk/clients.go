@@ -25,18 +25,19 @@ const (
 
 // Client represents supermq client.
 type Client struct {
-	ID          string                 `json:"id,omitempty"`
-	Name        string                 `json:"name,omitempty"`
-	Tags        []string               `json:"tags,omitempty"`
-	DomainID    string                 `json:"domain_id,omitempty"`
-	ParentGroup string                 `json:"parent_group_id,omitempty"`
-	Credentials ClientCredentials      `json:"credentials"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty"`
-	CreatedAt   time.Time              `json:"created_at,omitempty"`
-	UpdatedAt   time.Time              `json:"updated_at,omitempty"`
-	UpdatedBy   string                 `json:"updated_by,omitempty"`
-	Status      string                 `json:"status,omitempty"`
-	Permissions []string               `json:"permissions,omitempty"`
+	ID              string                 `json:"id,omitempty"`
+	Name            string                 `json:"name,omitempty"`
+	Tags            []string               `json:"tags,omitempty"`
+	DomainID        string                 `json:"domain_id,omitempty"`
+	ParentGroup     string                 `json:"parent_group_id,omitempty"`
+	Credentials     ClientCredentials      `json:"credentials"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt       time.Time              `json:"created_at,omitempty"`
+	UpdatedAt       time.Time              `json:"updated_at,omitempty"`
+	UpdatedBy       string                 `json:"updated_by,omitempty"`
+	Status          string                 `json:"status,omitempty"`
+	Permissions     []string               `json:"permissions,omitempty"`
+	ConnectionTypes []string               `json:"connection_types,omitempty"`
 }
 
 type ClientCredentials struct {
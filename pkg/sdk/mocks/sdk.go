@@ -1885,6 +1885,66 @@ func (_c *SDK_CreateGroupRole_Call) RunAndReturn(run func(string, string, sdk.Ro
 	return _c
 }
 
+// CreateRule provides a mock function with given fields: r, domainID, token
+func (_m *SDK) CreateRule(r sdk.Rule, domainID string, token string) (sdk.Rule, errors.SDKError) {
+	ret := _m.Called(r, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRule")
+	}
+
+	var r0 sdk.Rule
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Rule, string, string) (sdk.Rule, errors.SDKError)); ok {
+		return rf(r, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Rule, string, string) sdk.Rule); ok {
+		r0 = rf(r, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Rule)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Rule, string, string) errors.SDKError); ok {
+		r1 = rf(r, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_CreateRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRule'
+type SDK_CreateRule_Call struct {
+	*mock.Call
+}
+
+// CreateRule is a helper method to define mock.On call
+//   - r sdk.Rule
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) CreateRule(r interface{}, domainID interface{}, token interface{}) *SDK_CreateRule_Call {
+	return &SDK_CreateRule_Call{Call: _e.mock.On("CreateRule", r, domainID, token)}
+}
+
+func (_c *SDK_CreateRule_Call) Run(run func(r sdk.Rule, domainID string, token string)) *SDK_CreateRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(sdk.Rule), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_CreateRule_Call) Return(_a0 sdk.Rule, _a1 errors.SDKError) *SDK_CreateRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_CreateRule_Call) RunAndReturn(run func(sdk.Rule, string, string) (sdk.Rule, errors.SDKError)) *SDK_CreateRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateToken provides a mock function with given fields: lt
 func (_m *SDK) CreateToken(lt sdk.Login) (sdk.Token, errors.SDKError) {
 	ret := _m.Called(lt)
@@ -1943,6 +2003,65 @@ func (_c *SDK_CreateToken_Call) RunAndReturn(run func(sdk.Login) (sdk.Token, err
 	return _c
 }
 
+// CreateTwin provides a mock function with given fields: t, token
+func (_m *SDK) CreateTwin(t sdk.Twin, token string) (sdk.Twin, errors.SDKError) {
+	ret := _m.Called(t, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTwin")
+	}
+
+	var r0 sdk.Twin
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Twin, string) (sdk.Twin, errors.SDKError)); ok {
+		return rf(t, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Twin, string) sdk.Twin); ok {
+		r0 = rf(t, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Twin)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Twin, string) errors.SDKError); ok {
+		r1 = rf(t, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_CreateTwin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTwin'
+type SDK_CreateTwin_Call struct {
+	*mock.Call
+}
+
+// CreateTwin is a helper method to define mock.On call
+//   - t sdk.Twin
+//   - token string
+func (_e *SDK_Expecter) CreateTwin(t interface{}, token interface{}) *SDK_CreateTwin_Call {
+	return &SDK_CreateTwin_Call{Call: _e.mock.On("CreateTwin", t, token)}
+}
+
+func (_c *SDK_CreateTwin_Call) Run(run func(t sdk.Twin, token string)) *SDK_CreateTwin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(sdk.Twin), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_CreateTwin_Call) Return(_a0 sdk.Twin, _a1 errors.SDKError) *SDK_CreateTwin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_CreateTwin_Call) RunAndReturn(run func(sdk.Twin, string) (sdk.Twin, errors.SDKError)) *SDK_CreateTwin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function with given fields: user, token
 func (_m *SDK) CreateUser(user sdk.User, token string) (sdk.User, errors.SDKError) {
 	ret := _m.Called(user, token)
@@ -2352,6 +2471,105 @@ func (_c *SDK_DeleteInvitation_Call) RunAndReturn(run func(string, string, strin
 	return _c
 }
 
+// DeleteRule provides a mock function with given fields: id, domainID, token
+func (_m *SDK) DeleteRule(id string, domainID string, token string) errors.SDKError {
+	ret := _m.Called(id, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRule")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
+		r0 = rf(id, domainID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// SDK_DeleteRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRule'
+type SDK_DeleteRule_Call struct {
+	*mock.Call
+}
+
+// DeleteRule is a helper method to define mock.On call
+//   - id string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) DeleteRule(id interface{}, domainID interface{}, token interface{}) *SDK_DeleteRule_Call {
+	return &SDK_DeleteRule_Call{Call: _e.mock.On("DeleteRule", id, domainID, token)}
+}
+
+func (_c *SDK_DeleteRule_Call) Run(run func(id string, domainID string, token string)) *SDK_DeleteRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_DeleteRule_Call) Return(_a0 errors.SDKError) *SDK_DeleteRule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SDK_DeleteRule_Call) RunAndReturn(run func(string, string, string) errors.SDKError) *SDK_DeleteRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTwin provides a mock function with given fields: id, token
+func (_m *SDK) DeleteTwin(id string, token string) errors.SDKError {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTwin")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(id, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// SDK_DeleteTwin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTwin'
+type SDK_DeleteTwin_Call struct {
+	*mock.Call
+}
+
+// DeleteTwin is a helper method to define mock.On call
+//   - id string
+//   - token string
+func (_e *SDK_Expecter) DeleteTwin(id interface{}, token interface{}) *SDK_DeleteTwin_Call {
+	return &SDK_DeleteTwin_Call{Call: _e.mock.On("DeleteTwin", id, token)}
+}
+
+func (_c *SDK_DeleteTwin_Call) Run(run func(id string, token string)) *SDK_DeleteTwin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_DeleteTwin_Call) Return(_a0 errors.SDKError) *SDK_DeleteTwin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SDK_DeleteTwin_Call) RunAndReturn(run func(string, string) errors.SDKError) *SDK_DeleteTwin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteUser provides a mock function with given fields: id, token
 func (_m *SDK) DeleteUser(id string, token string) errors.SDKError {
 	ret := _m.Called(id, token)
@@ -2630,6 +2848,66 @@ func (_c *SDK_DisableGroup_Call) RunAndReturn(run func(string, string, string) (
 	return _c
 }
 
+// DisableRule provides a mock function with given fields: id, domainID, token
+func (_m *SDK) DisableRule(id string, domainID string, token string) (sdk.Rule, errors.SDKError) {
+	ret := _m.Called(id, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableRule")
+	}
+
+	var r0 sdk.Rule
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.Rule, errors.SDKError)); ok {
+		return rf(id, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.Rule); ok {
+		r0 = rf(id, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Rule)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(id, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_DisableRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableRule'
+type SDK_DisableRule_Call struct {
+	*mock.Call
+}
+
+// DisableRule is a helper method to define mock.On call
+//   - id string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) DisableRule(id interface{}, domainID interface{}, token interface{}) *SDK_DisableRule_Call {
+	return &SDK_DisableRule_Call{Call: _e.mock.On("DisableRule", id, domainID, token)}
+}
+
+func (_c *SDK_DisableRule_Call) Run(run func(id string, domainID string, token string)) *SDK_DisableRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_DisableRule_Call) Return(_a0 sdk.Rule, _a1 errors.SDKError) *SDK_DisableRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_DisableRule_Call) RunAndReturn(run func(string, string, string) (sdk.Rule, errors.SDKError)) *SDK_DisableRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DisableUser provides a mock function with given fields: id, token
 func (_m *SDK) DisableUser(id string, token string) (sdk.User, errors.SDKError) {
 	ret := _m.Called(id, token)
@@ -3381,6 +3659,66 @@ func (_c *SDK_EnableGroup_Call) RunAndReturn(run func(string, string, string) (s
 	return _c
 }
 
+// EnableRule provides a mock function with given fields: id, domainID, token
+func (_m *SDK) EnableRule(id string, domainID string, token string) (sdk.Rule, errors.SDKError) {
+	ret := _m.Called(id, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableRule")
+	}
+
+	var r0 sdk.Rule
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.Rule, errors.SDKError)); ok {
+		return rf(id, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.Rule); ok {
+		r0 = rf(id, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Rule)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(id, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_EnableRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableRule'
+type SDK_EnableRule_Call struct {
+	*mock.Call
+}
+
+// EnableRule is a helper method to define mock.On call
+//   - id string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) EnableRule(id interface{}, domainID interface{}, token interface{}) *SDK_EnableRule_Call {
+	return &SDK_EnableRule_Call{Call: _e.mock.On("EnableRule", id, domainID, token)}
+}
+
+func (_c *SDK_EnableRule_Call) Run(run func(id string, domainID string, token string)) *SDK_EnableRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_EnableRule_Call) Return(_a0 sdk.Rule, _a1 errors.SDKError) *SDK_EnableRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_EnableRule_Call) RunAndReturn(run func(string, string, string) (sdk.Rule, errors.SDKError)) *SDK_EnableRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EnableUser provides a mock function with given fields: id, token
 func (_m *SDK) EnableUser(id string, token string) (sdk.User, errors.SDKError) {
 	ret := _m.Called(id, token)
@@ -3440,26 +3778,85 @@ func (_c *SDK_EnableUser_Call) RunAndReturn(run func(string, string) (sdk.User,
 	return _c
 }
 
-// FreezeDomain provides a mock function with given fields: domainID, token
-func (_m *SDK) FreezeDomain(domainID string, token string) errors.SDKError {
+// ExportDomain provides a mock function with given fields: domainID, token
+func (_m *SDK) ExportDomain(domainID string, token string) (sdk.DomainBundle, errors.SDKError) {
 	ret := _m.Called(domainID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FreezeDomain")
+		panic("no return value specified for ExportDomain")
 	}
 
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+	var r0 sdk.DomainBundle
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.DomainBundle, errors.SDKError)); ok {
+		return rf(domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.DomainBundle); ok {
 		r0 = rf(domainID, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
-		}
+		r0 = ret.Get(0).(sdk.DomainBundle)
 	}
 
-	return r0
-}
-
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_ExportDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportDomain'
+type SDK_ExportDomain_Call struct {
+	*mock.Call
+}
+
+// ExportDomain is a helper method to define mock.On call
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ExportDomain(domainID interface{}, token interface{}) *SDK_ExportDomain_Call {
+	return &SDK_ExportDomain_Call{Call: _e.mock.On("ExportDomain", domainID, token)}
+}
+
+func (_c *SDK_ExportDomain_Call) Run(run func(domainID string, token string)) *SDK_ExportDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_ExportDomain_Call) Return(_a0 sdk.DomainBundle, _a1 errors.SDKError) *SDK_ExportDomain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_ExportDomain_Call) RunAndReturn(run func(string, string) (sdk.DomainBundle, errors.SDKError)) *SDK_ExportDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FreezeDomain provides a mock function with given fields: domainID, token
+func (_m *SDK) FreezeDomain(domainID string, token string) errors.SDKError {
+	ret := _m.Called(domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FreezeDomain")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(domainID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
 // SDK_FreezeDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FreezeDomain'
 type SDK_FreezeDomain_Call struct {
 	*mock.Call
@@ -3975,6 +4372,67 @@ func (_c *SDK_Hierarchy_Call) RunAndReturn(run func(string, string, sdk.PageMeta
 	return _c
 }
 
+// ImportDomain provides a mock function with given fields: bundle, domainID, opts, token
+func (_m *SDK) ImportDomain(bundle sdk.DomainBundle, domainID string, opts sdk.ImportOptions, token string) (sdk.ImportResult, errors.SDKError) {
+	ret := _m.Called(bundle, domainID, opts, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportDomain")
+	}
+
+	var r0 sdk.ImportResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.DomainBundle, string, sdk.ImportOptions, string) (sdk.ImportResult, errors.SDKError)); ok {
+		return rf(bundle, domainID, opts, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.DomainBundle, string, sdk.ImportOptions, string) sdk.ImportResult); ok {
+		r0 = rf(bundle, domainID, opts, token)
+	} else {
+		r0 = ret.Get(0).(sdk.ImportResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.DomainBundle, string, sdk.ImportOptions, string) errors.SDKError); ok {
+		r1 = rf(bundle, domainID, opts, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_ImportDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportDomain'
+type SDK_ImportDomain_Call struct {
+	*mock.Call
+}
+
+// ImportDomain is a helper method to define mock.On call
+//   - bundle sdk.DomainBundle
+//   - domainID string
+//   - opts sdk.ImportOptions
+//   - token string
+func (_e *SDK_Expecter) ImportDomain(bundle interface{}, domainID interface{}, opts interface{}, token interface{}) *SDK_ImportDomain_Call {
+	return &SDK_ImportDomain_Call{Call: _e.mock.On("ImportDomain", bundle, domainID, opts, token)}
+}
+
+func (_c *SDK_ImportDomain_Call) Run(run func(bundle sdk.DomainBundle, domainID string, opts sdk.ImportOptions, token string)) *SDK_ImportDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(sdk.DomainBundle), args[1].(string), args[2].(sdk.ImportOptions), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_ImportDomain_Call) Return(_a0 sdk.ImportResult, _a1 errors.SDKError) *SDK_ImportDomain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_ImportDomain_Call) RunAndReturn(run func(sdk.DomainBundle, string, sdk.ImportOptions, string) (sdk.ImportResult, errors.SDKError)) *SDK_ImportDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Invitation provides a mock function with given fields: userID, domainID, token
 func (_m *SDK) Invitation(userID string, domainID string, token string) (sdk.Invitation, error) {
 	ret := _m.Called(userID, domainID, token)
@@ -5585,6 +6043,126 @@ func (_c *SDK_RevokeCert_Call) RunAndReturn(run func(string, string, string) (ti
 	return _c
 }
 
+// Rule provides a mock function with given fields: id, domainID, token
+func (_m *SDK) Rule(id string, domainID string, token string) (sdk.Rule, errors.SDKError) {
+	ret := _m.Called(id, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rule")
+	}
+
+	var r0 sdk.Rule
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.Rule, errors.SDKError)); ok {
+		return rf(id, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.Rule); ok {
+		r0 = rf(id, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Rule)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(id, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_Rule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rule'
+type SDK_Rule_Call struct {
+	*mock.Call
+}
+
+// Rule is a helper method to define mock.On call
+//   - id string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) Rule(id interface{}, domainID interface{}, token interface{}) *SDK_Rule_Call {
+	return &SDK_Rule_Call{Call: _e.mock.On("Rule", id, domainID, token)}
+}
+
+func (_c *SDK_Rule_Call) Run(run func(id string, domainID string, token string)) *SDK_Rule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_Rule_Call) Return(_a0 sdk.Rule, _a1 errors.SDKError) *SDK_Rule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_Rule_Call) RunAndReturn(run func(string, string, string) (sdk.Rule, errors.SDKError)) *SDK_Rule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rules provides a mock function with given fields: pm, domainID, token
+func (_m *SDK) Rules(pm sdk.PageMetadata, domainID string, token string) (sdk.RulesPage, errors.SDKError) {
+	ret := _m.Called(pm, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rules")
+	}
+
+	var r0 sdk.RulesPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string, string) (sdk.RulesPage, errors.SDKError)); ok {
+		return rf(pm, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string, string) sdk.RulesPage); ok {
+		r0 = rf(pm, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string, string) errors.SDKError); ok {
+		r1 = rf(pm, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_Rules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rules'
+type SDK_Rules_Call struct {
+	*mock.Call
+}
+
+// Rules is a helper method to define mock.On call
+//   - pm sdk.PageMetadata
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) Rules(pm interface{}, domainID interface{}, token interface{}) *SDK_Rules_Call {
+	return &SDK_Rules_Call{Call: _e.mock.On("Rules", pm, domainID, token)}
+}
+
+func (_c *SDK_Rules_Call) Run(run func(pm sdk.PageMetadata, domainID string, token string)) *SDK_Rules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(sdk.PageMetadata), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_Rules_Call) Return(_a0 sdk.RulesPage, _a1 errors.SDKError) *SDK_Rules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_Rules_Call) RunAndReturn(run func(sdk.PageMetadata, string, string) (sdk.RulesPage, errors.SDKError)) *SDK_Rules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SearchUsers provides a mock function with given fields: pm, token
 func (_m *SDK) SearchUsers(pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.SDKError) {
 	ret := _m.Called(pm, token)
@@ -5942,6 +6520,184 @@ func (_c *SDK_SetGroupParent_Call) RunAndReturn(run func(string, string, string,
 	return _c
 }
 
+// Twin provides a mock function with given fields: id, token
+func (_m *SDK) Twin(id string, token string) (sdk.Twin, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Twin")
+	}
+
+	var r0 sdk.Twin
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Twin, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Twin); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Twin)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_Twin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Twin'
+type SDK_Twin_Call struct {
+	*mock.Call
+}
+
+// Twin is a helper method to define mock.On call
+//   - id string
+//   - token string
+func (_e *SDK_Expecter) Twin(id interface{}, token interface{}) *SDK_Twin_Call {
+	return &SDK_Twin_Call{Call: _e.mock.On("Twin", id, token)}
+}
+
+func (_c *SDK_Twin_Call) Run(run func(id string, token string)) *SDK_Twin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_Twin_Call) Return(_a0 sdk.Twin, _a1 errors.SDKError) *SDK_Twin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_Twin_Call) RunAndReturn(run func(string, string) (sdk.Twin, errors.SDKError)) *SDK_Twin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TwinStates provides a mock function with given fields: twinID, pm, token
+func (_m *SDK) TwinStates(twinID string, pm sdk.PageMetadata, token string) (sdk.TwinStatesPage, errors.SDKError) {
+	ret := _m.Called(twinID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TwinStates")
+	}
+
+	var r0 sdk.TwinStatesPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.TwinStatesPage, errors.SDKError)); ok {
+		return rf(twinID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.TwinStatesPage); ok {
+		r0 = rf(twinID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.TwinStatesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(twinID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_TwinStates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TwinStates'
+type SDK_TwinStates_Call struct {
+	*mock.Call
+}
+
+// TwinStates is a helper method to define mock.On call
+//   - twinID string
+//   - pm sdk.PageMetadata
+//   - token string
+func (_e *SDK_Expecter) TwinStates(twinID interface{}, pm interface{}, token interface{}) *SDK_TwinStates_Call {
+	return &SDK_TwinStates_Call{Call: _e.mock.On("TwinStates", twinID, pm, token)}
+}
+
+func (_c *SDK_TwinStates_Call) Run(run func(twinID string, pm sdk.PageMetadata, token string)) *SDK_TwinStates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(sdk.PageMetadata), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_TwinStates_Call) Return(_a0 sdk.TwinStatesPage, _a1 errors.SDKError) *SDK_TwinStates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_TwinStates_Call) RunAndReturn(run func(string, sdk.PageMetadata, string) (sdk.TwinStatesPage, errors.SDKError)) *SDK_TwinStates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Twins provides a mock function with given fields: pm, token
+func (_m *SDK) Twins(pm sdk.PageMetadata, token string) (sdk.TwinsPage, errors.SDKError) {
+	ret := _m.Called(pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Twins")
+	}
+
+	var r0 sdk.TwinsPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.TwinsPage, errors.SDKError)); ok {
+		return rf(pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.TwinsPage); ok {
+		r0 = rf(pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.TwinsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_Twins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Twins'
+type SDK_Twins_Call struct {
+	*mock.Call
+}
+
+// Twins is a helper method to define mock.On call
+//   - pm sdk.PageMetadata
+//   - token string
+func (_e *SDK_Expecter) Twins(pm interface{}, token interface{}) *SDK_Twins_Call {
+	return &SDK_Twins_Call{Call: _e.mock.On("Twins", pm, token)}
+}
+
+func (_c *SDK_Twins_Call) Run(run func(pm sdk.PageMetadata, token string)) *SDK_Twins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(sdk.PageMetadata), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_Twins_Call) Return(_a0 sdk.TwinsPage, _a1 errors.SDKError) *SDK_Twins_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_Twins_Call) RunAndReturn(run func(sdk.PageMetadata, string) (sdk.TwinsPage, errors.SDKError)) *SDK_Twins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateChannel provides a mock function with given fields: channel, domainID, token
 func (_m *SDK) UpdateChannel(channel sdk.Channel, domainID string, token string) (sdk.Channel, errors.SDKError) {
 	ret := _m.Called(channel, domainID, token)
@@ -6666,6 +7422,125 @@ func (_c *SDK_UpdateProfilePicture_Call) RunAndReturn(run func(sdk.User, string)
 	return _c
 }
 
+// UpdateRule provides a mock function with given fields: r, domainID, token
+func (_m *SDK) UpdateRule(r sdk.Rule, domainID string, token string) (sdk.Rule, errors.SDKError) {
+	ret := _m.Called(r, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRule")
+	}
+
+	var r0 sdk.Rule
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Rule, string, string) (sdk.Rule, errors.SDKError)); ok {
+		return rf(r, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Rule, string, string) sdk.Rule); ok {
+		r0 = rf(r, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Rule)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Rule, string, string) errors.SDKError); ok {
+		r1 = rf(r, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_UpdateRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRule'
+type SDK_UpdateRule_Call struct {
+	*mock.Call
+}
+
+// UpdateRule is a helper method to define mock.On call
+//   - r sdk.Rule
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) UpdateRule(r interface{}, domainID interface{}, token interface{}) *SDK_UpdateRule_Call {
+	return &SDK_UpdateRule_Call{Call: _e.mock.On("UpdateRule", r, domainID, token)}
+}
+
+func (_c *SDK_UpdateRule_Call) Run(run func(r sdk.Rule, domainID string, token string)) *SDK_UpdateRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(sdk.Rule), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_UpdateRule_Call) Return(_a0 sdk.Rule, _a1 errors.SDKError) *SDK_UpdateRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_UpdateRule_Call) RunAndReturn(run func(sdk.Rule, string, string) (sdk.Rule, errors.SDKError)) *SDK_UpdateRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTwin provides a mock function with given fields: t, token
+func (_m *SDK) UpdateTwin(t sdk.Twin, token string) (sdk.Twin, errors.SDKError) {
+	ret := _m.Called(t, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTwin")
+	}
+
+	var r0 sdk.Twin
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Twin, string) (sdk.Twin, errors.SDKError)); ok {
+		return rf(t, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Twin, string) sdk.Twin); ok {
+		r0 = rf(t, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Twin)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Twin, string) errors.SDKError); ok {
+		r1 = rf(t, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_UpdateTwin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTwin'
+type SDK_UpdateTwin_Call struct {
+	*mock.Call
+}
+
+// UpdateTwin is a helper method to define mock.On call
+//   - t sdk.Twin
+//   - token string
+func (_e *SDK_Expecter) UpdateTwin(t interface{}, token interface{}) *SDK_UpdateTwin_Call {
+	return &SDK_UpdateTwin_Call{Call: _e.mock.On("UpdateTwin", t, token)}
+}
+
+func (_c *SDK_UpdateTwin_Call) Run(run func(t sdk.Twin, token string)) *SDK_UpdateTwin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(sdk.Twin), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_UpdateTwin_Call) Return(_a0 sdk.Twin, _a1 errors.SDKError) *SDK_UpdateTwin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_UpdateTwin_Call) RunAndReturn(run func(sdk.Twin, string) (sdk.Twin, errors.SDKError)) *SDK_UpdateTwin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateUser provides a mock function with given fields: user, token
 func (_m *SDK) UpdateUser(user sdk.User, token string) (sdk.User, errors.SDKError) {
 	ret := _m.Called(user, token)
@@ -3,6 +3,8 @@
 
 package sdk
 
+import "time"
+
 // updateUserSecretReq is used to update the user secret.
 type updateUserSecretReq struct {
 	OldSecret string `json:"old_secret,omitempty"`
@@ -39,9 +41,11 @@ type UserPasswordReq struct {
 
 // Connection contains clients and channel IDs that are connected.
 type Connection struct {
-	ClientIDs  []string `json:"client_ids,omitempty"`
-	ChannelIDs []string `json:"channel_ids,omitempty"`
-	Types      []string `json:"types,omitempty"`
+	ClientIDs  []string  `json:"client_ids,omitempty"`
+	ChannelIDs []string  `json:"channel_ids,omitempty"`
+	Types      []string  `json:"types,omitempty"`
+	ExpiresAt  time.Time `json:"expires_at,omitempty"`
+	Subtopics  []string  `json:"subtopics,omitempty"`
 }
 
 type UsersRelationRequest struct {
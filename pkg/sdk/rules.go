@@ -0,0 +1,189 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+const rulesEndpoint = "rules"
+
+// Rule represents a rules engine rule.
+type Rule struct {
+	ID            string          `json:"id,omitempty"`
+	DomainID      string          `json:"domain_id,omitempty"`
+	Name          string          `json:"name,omitempty"`
+	InputChannel  string          `json:"input_channel,omitempty"`
+	InputSubtopic string          `json:"input_subtopic,omitempty"`
+	Logic         string          `json:"logic,omitempty"`
+	Conditions    []RuleCondition `json:"conditions,omitempty"`
+	Window        *RuleWindow     `json:"window,omitempty"`
+	Actions       []RuleAction    `json:"actions,omitempty"`
+	Status        string          `json:"status,omitempty"`
+	CreatedBy     string          `json:"created_by,omitempty"`
+	CreatedAt     time.Time       `json:"created_at,omitempty"`
+	UpdatedBy     string          `json:"updated_by,omitempty"`
+	UpdatedAt     time.Time       `json:"updated_at,omitempty"`
+}
+
+// RuleCondition compares a field of the SenML records to a threshold.
+type RuleCondition struct {
+	Name      string      `json:"name,omitempty"`
+	Field     string      `json:"field"`
+	Operator  string      `json:"operator"`
+	Threshold interface{} `json:"threshold"`
+}
+
+// RuleWindow aggregates the values of the numeric rule conditions.
+type RuleWindow struct {
+	Aggregation string `json:"aggregation"`
+	Size        uint64 `json:"size,omitempty"`
+	Interval    string `json:"interval,omitempty"`
+}
+
+// RuleAction represents an action executed when the rule conditions are met.
+type RuleAction struct {
+	Type       string            `json:"type"`
+	Channel    string            `json:"channel,omitempty"`
+	Subtopic   string            `json:"subtopic,omitempty"`
+	Recipients []string          `json:"recipients,omitempty"`
+	URL        string            `json:"url,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Secret     string            `json:"secret,omitempty"`
+	Severity   uint8             `json:"severity,omitempty"`
+}
+
+// RulesPage contains a page of rules.
+type RulesPage struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+	Rules  []Rule `json:"rules"`
+}
+
+func (sdk mgSDK) CreateRule(r Rule, domainID, token string) (Rule, errors.SDKError) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return Rule{}, errors.NewSDKError(err)
+	}
+	url := fmt.Sprintf("%s/%s/%s", sdk.rulesURL, domainID, rulesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return Rule{}, sdkerr
+	}
+
+	r = Rule{}
+	if err := json.Unmarshal(body, &r); err != nil {
+		return Rule{}, errors.NewSDKError(err)
+	}
+
+	return r, nil
+}
+
+func (sdk mgSDK) Rules(pm PageMetadata, domainID, token string) (RulesPage, errors.SDKError) {
+	endpoint := fmt.Sprintf("%s/%s", domainID, rulesEndpoint)
+	url, err := sdk.withQueryParams(sdk.rulesURL, endpoint, pm)
+	if err != nil {
+		return RulesPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesPage{}, sdkerr
+	}
+
+	var rp RulesPage
+	if err := json.Unmarshal(body, &rp); err != nil {
+		return RulesPage{}, errors.NewSDKError(err)
+	}
+
+	return rp, nil
+}
+
+func (sdk mgSDK) Rule(id, domainID, token string) (Rule, errors.SDKError) {
+	if id == "" {
+		return Rule{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.rulesURL, domainID, rulesEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Rule{}, sdkerr
+	}
+
+	var r Rule
+	if err := json.Unmarshal(body, &r); err != nil {
+		return Rule{}, errors.NewSDKError(err)
+	}
+
+	return r, nil
+}
+
+func (sdk mgSDK) UpdateRule(r Rule, domainID, token string) (Rule, errors.SDKError) {
+	if r.ID == "" {
+		return Rule{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.rulesURL, domainID, rulesEndpoint, r.ID)
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return Rule{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPut, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Rule{}, sdkerr
+	}
+
+	r = Rule{}
+	if err := json.Unmarshal(body, &r); err != nil {
+		return Rule{}, errors.NewSDKError(err)
+	}
+
+	return r, nil
+}
+
+func (sdk mgSDK) EnableRule(id, domainID, token string) (Rule, errors.SDKError) {
+	return sdk.changeRuleStatus(id, enableEndpoint, domainID, token)
+}
+
+func (sdk mgSDK) DisableRule(id, domainID, token string) (Rule, errors.SDKError) {
+	return sdk.changeRuleStatus(id, disableEndpoint, domainID, token)
+}
+
+func (sdk mgSDK) DeleteRule(id, domainID, token string) errors.SDKError {
+	if id == "" {
+		return errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.rulesURL, domainID, rulesEndpoint, id)
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) changeRuleStatus(id, status, domainID, token string) (Rule, errors.SDKError) {
+	if id == "" {
+		return Rule{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", sdk.rulesURL, domainID, rulesEndpoint, id, status)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Rule{}, sdkerr
+	}
+
+	var r Rule
+	if err := json.Unmarshal(body, &r); err != nil {
+		return Rule{}, errors.NewSDKError(err)
+	}
+
+	return r, nil
+}
@@ -129,6 +129,8 @@ type PageMetadata struct {
 	Tree            bool     `json:"tree,omitempty"`
 	StartLevel      int64    `json:"start_level,omitempty"`
 	EndLevel        int64    `json:"end_level,omitempty"`
+	InputChannel    string   `json:"input_channel,omitempty"`
+	Channel         string   `json:"channel,omitempty"`
 }
 
 type Role struct {
@@ -960,13 +962,15 @@ type SDK interface {
 	//  fmt.Println(err)
 	RemoveChannelParent(id, domainID, groupID, token string) errors.SDKError
 
-	// Connect bulk connects clients to channels specified by id.
+	// Connect bulk connects clients to channels specified by id. The connections
+	// can be restricted to expire at the given time and to the given subtopics.
 	//
 	// example:
 	//  conns := sdk.Connection{
 	//    ChannelIDs: []string{"channel_id_1"},
 	//    ClientIDs:  []string{"client_id_1"},
 	//    Types:   	  []string{"Publish", "Subscribe"},
+	//    Subtopics:  []string{"sensors.temp.*"},
 	//  }
 	//  err := sdk.Connect(conns, "domainID", "token")
 	//  fmt.Println(err)
@@ -1129,6 +1133,28 @@ type SDK interface {
 	//  fmt.Println(err)
 	FreezeDomain(domainID, token string) errors.SDKError
 
+	// ExportDomain exports the domain groups, clients, channels, connections
+	// and roles to a bundle. Client secrets are not exported.
+	//
+	// example:
+	//  bundle, _ := sdk.ExportDomain("domainID", "token")
+	//  data, _ := sdk.EncodeBundle(bundle, sdk.BundleYAML)
+	//  fmt.Println(string(data))
+	ExportDomain(domainID, token string) (DomainBundle, errors.SDKError)
+
+	// ImportDomain recreates the bundle entities in the given domain and
+	// returns the changes with the bundle to domain entity IDs mapping.
+	// If the import fails, the created entities are removed.
+	//
+	// example:
+	//  opts := sdk.ImportOptions{
+	//    DryRun:   true,
+	//    Conflict: sdk.ConflictSkip,
+	//  }
+	//  result, _ := sdk.ImportDomain(bundle, "domainID", opts, "token")
+	//  fmt.Println(result.Changes)
+	ImportDomain(bundle DomainBundle, domainID string, opts ImportOptions, token string) (ImportResult, errors.SDKError)
+
 	// CreateDomainRole creates new domain role and returns its id.
 	//
 	// example:
@@ -1308,6 +1334,126 @@ type SDK interface {
 	//  journals, _ := sdk.Journal("client", "clientID","domainID", PageMetadata{Offset: 0, Limit: 10, Operation: "client.create"}, "token")
 	//  fmt.Println(journals)
 	Journal(entityType, entityID, domainID string, pm PageMetadata, token string) (journal JournalsPage, err error)
+
+	// CreateRule creates new rules engine rule.
+	//
+	// example:
+	//  rule := sdk.Rule{
+	//    Name:         "overheat",
+	//    InputChannel: "channelID",
+	//    Conditions:   []sdk.RuleCondition{{Name: "temperature", Field: "value", Operator: "gt", Threshold: 30}},
+	//    Actions:      []sdk.RuleAction{{Type: "publish", Channel: "alertsChannelID"}},
+	//  }
+	//  rule, _ := sdk.CreateRule(rule, "domainID", "token")
+	//  fmt.Println(rule)
+	CreateRule(r Rule, domainID, token string) (Rule, errors.SDKError)
+
+	// Rules returns page of rules.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{
+	//    Offset:       0,
+	//    Limit:        10,
+	//    InputChannel: "channelID",
+	//  }
+	//  rules, _ := sdk.Rules(pm, "domainID", "token")
+	//  fmt.Println(rules)
+	Rules(pm PageMetadata, domainID, token string) (RulesPage, errors.SDKError)
+
+	// Rule returns rule data by id.
+	//
+	// example:
+	//  rule, _ := sdk.Rule("ruleID", "domainID", "token")
+	//  fmt.Println(rule)
+	Rule(id, domainID, token string) (Rule, errors.SDKError)
+
+	// UpdateRule updates existing rule.
+	//
+	// example:
+	//  rule.Name = "New Name"
+	//  rule, _ := sdk.UpdateRule(rule, "domainID", "token")
+	//  fmt.Println(rule)
+	UpdateRule(r Rule, domainID, token string) (Rule, errors.SDKError)
+
+	// EnableRule changes rule status to enabled.
+	//
+	// example:
+	//  rule, _ := sdk.EnableRule("ruleID", "domainID", "token")
+	//  fmt.Println(rule)
+	EnableRule(id, domainID, token string) (Rule, errors.SDKError)
+
+	// DisableRule changes rule status to disabled.
+	//
+	// example:
+	//  rule, _ := sdk.DisableRule("ruleID", "domainID", "token")
+	//  fmt.Println(rule)
+	DisableRule(id, domainID, token string) (Rule, errors.SDKError)
+
+	// DeleteRule deletes rule by id.
+	//
+	// example:
+	//  err := sdk.DeleteRule("ruleID", "domainID", "token")
+	//  fmt.Println(err)
+	DeleteRule(id, domainID, token string) errors.SDKError
+
+	// CreateTwin creates new twin owned by the user.
+	//
+	// example:
+	//  twin := sdk.Twin{
+	//    Name: "thermostat",
+	//    Definition: &sdk.TwinDefinition{
+	//      Attributes: []sdk.TwinAttribute{{Name: "temperature", Channel: "channelID", Subtopic: "temperature", PersistState: true}},
+	//    },
+	//  }
+	//  twin, _ := sdk.CreateTwin(twin, "token")
+	//  fmt.Println(twin)
+	CreateTwin(t Twin, token string) (Twin, errors.SDKError)
+
+	// Twins returns page of twins.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{
+	//    Offset: 0,
+	//    Limit:  10,
+	//    Name:   "thermostat",
+	//  }
+	//  twins, _ := sdk.Twins(pm, "token")
+	//  fmt.Println(twins)
+	Twins(pm PageMetadata, token string) (TwinsPage, errors.SDKError)
+
+	// Twin returns twin data by id.
+	//
+	// example:
+	//  twin, _ := sdk.Twin("twinID", "token")
+	//  fmt.Println(twin)
+	Twin(id, token string) (Twin, errors.SDKError)
+
+	// UpdateTwin updates existing twin. If the twin definition is set,
+	// it's added as the new twin definition.
+	//
+	// example:
+	//  twin.Name = "New Name"
+	//  twin, _ := sdk.UpdateTwin(twin, "token")
+	//  fmt.Println(twin)
+	UpdateTwin(t Twin, token string) (Twin, errors.SDKError)
+
+	// DeleteTwin deletes twin by id.
+	//
+	// example:
+	//  err := sdk.DeleteTwin("twinID", "token")
+	//  fmt.Println(err)
+	DeleteTwin(id, token string) errors.SDKError
+
+	// TwinStates returns page of the twin states.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{
+	//    Offset: 0,
+	//    Limit:  10,
+	//  }
+	//  states, _ := sdk.TwinStates("twinID", pm, "token")
+	//  fmt.Println(states)
+	TwinStates(twinID string, pm PageMetadata, token string) (TwinStatesPage, errors.SDKError)
 }
 
 type mgSDK struct {
@@ -1320,6 +1466,8 @@ type mgSDK struct {
 	domainsURL     string
 	invitationsURL string
 	journalURL     string
+	rulesURL       string
+	twinsURL       string
 	HostURL        string
 
 	msgContentType ContentType
@@ -1338,6 +1486,8 @@ type Config struct {
 	DomainsURL     string
 	InvitationsURL string
 	JournalURL     string
+	RulesURL       string
+	TwinsURL       string
 	HostURL        string
 
 	MsgContentType  ContentType
@@ -1357,6 +1507,8 @@ func NewSDK(conf Config) SDK {
 		domainsURL:     conf.DomainsURL,
 		invitationsURL: conf.InvitationsURL,
 		journalURL:     conf.JournalURL,
+		rulesURL:       conf.RulesURL,
+		twinsURL:       conf.TwinsURL,
 		HostURL:        conf.HostURL,
 
 		msgContentType: conf.MsgContentType,
@@ -1535,6 +1687,12 @@ func (pm PageMetadata) query() (string, error) {
 	if pm.Operation != "" {
 		q.Add("operation", pm.Operation)
 	}
+	if pm.InputChannel != "" {
+		q.Add("input_channel", pm.InputChannel)
+	}
+	if pm.Channel != "" {
+		q.Add("channel", pm.Channel)
+	}
 	if pm.From != 0 {
 		q.Add("from", strconv.FormatInt(pm.From, 10))
 	}
@@ -22,6 +22,7 @@ type Token struct {
 type Login struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	OTP      string `json:"otp,omitempty"`
 }
 
 func (sdk mgSDK) CreateToken(lt Login) (Token, errors.SDKError) {
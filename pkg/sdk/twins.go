@@ -0,0 +1,190 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+const (
+	twinsEndpoint  = "twins"
+	statesEndpoint = "states"
+)
+
+// Twin represents a digital twin. When the twin is created or updated,
+// Definition is used as the new twin definition.
+type Twin struct {
+	ID          string           `json:"id,omitempty"`
+	Owner       string           `json:"owner,omitempty"`
+	Name        string           `json:"name,omitempty"`
+	Revision    int              `json:"revision,omitempty"`
+	Created     time.Time        `json:"created,omitempty"`
+	Updated     time.Time        `json:"updated,omitempty"`
+	Definition  *TwinDefinition  `json:"definition,omitempty"`
+	Definitions []TwinDefinition `json:"definitions,omitempty"`
+	Metadata    Metadata         `json:"metadata,omitempty"`
+}
+
+// TwinDefinition is a revision of the twin attributes. Delta is the minimal
+// duration between two consecutive twin states.
+type TwinDefinition struct {
+	ID         int             `json:"id,omitempty"`
+	Created    time.Time       `json:"created,omitempty"`
+	Attributes []TwinAttribute `json:"attributes"`
+	Delta      time.Duration   `json:"delta,omitempty"`
+}
+
+// TwinAttribute binds a twin state field to the channel and subtopic.
+type TwinAttribute struct {
+	Name         string `json:"name"`
+	Channel      string `json:"channel"`
+	Subtopic     string `json:"subtopic,omitempty"`
+	PersistState bool   `json:"persist_state"`
+}
+
+// TwinsPage contains a page of twins.
+type TwinsPage struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+	Twins  []Twin `json:"twins"`
+}
+
+// TwinState is a snapshot of the twin attribute values.
+type TwinState struct {
+	TwinID     string                 `json:"twin_id"`
+	ID         int64                  `json:"id"`
+	Definition int                    `json:"definition"`
+	Created    time.Time              `json:"created"`
+	Payload    map[string]interface{} `json:"payload"`
+}
+
+// TwinStatesPage contains a page of twin states.
+type TwinStatesPage struct {
+	Total  uint64      `json:"total"`
+	Offset uint64      `json:"offset"`
+	Limit  uint64      `json:"limit"`
+	States []TwinState `json:"states"`
+}
+
+func (sdk mgSDK) CreateTwin(t Twin, token string) (Twin, errors.SDKError) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return Twin{}, errors.NewSDKError(err)
+	}
+	url := fmt.Sprintf("%s/%s", sdk.twinsURL, twinsEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return Twin{}, sdkerr
+	}
+
+	t = Twin{}
+	if err := json.Unmarshal(body, &t); err != nil {
+		return Twin{}, errors.NewSDKError(err)
+	}
+
+	return t, nil
+}
+
+func (sdk mgSDK) Twins(pm PageMetadata, token string) (TwinsPage, errors.SDKError) {
+	url, err := sdk.withQueryParams(sdk.twinsURL, twinsEndpoint, pm)
+	if err != nil {
+		return TwinsPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return TwinsPage{}, sdkerr
+	}
+
+	var tp TwinsPage
+	if err := json.Unmarshal(body, &tp); err != nil {
+		return TwinsPage{}, errors.NewSDKError(err)
+	}
+
+	return tp, nil
+}
+
+func (sdk mgSDK) Twin(id, token string) (Twin, errors.SDKError) {
+	if id == "" {
+		return Twin{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s", sdk.twinsURL, twinsEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Twin{}, sdkerr
+	}
+
+	var t Twin
+	if err := json.Unmarshal(body, &t); err != nil {
+		return Twin{}, errors.NewSDKError(err)
+	}
+
+	return t, nil
+}
+
+func (sdk mgSDK) UpdateTwin(t Twin, token string) (Twin, errors.SDKError) {
+	if t.ID == "" {
+		return Twin{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s", sdk.twinsURL, twinsEndpoint, t.ID)
+
+	data, err := json.Marshal(t)
+	if err != nil {
+		return Twin{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPut, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Twin{}, sdkerr
+	}
+
+	t = Twin{}
+	if err := json.Unmarshal(body, &t); err != nil {
+		return Twin{}, errors.NewSDKError(err)
+	}
+
+	return t, nil
+}
+
+func (sdk mgSDK) DeleteTwin(id, token string) errors.SDKError {
+	if id == "" {
+		return errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s", sdk.twinsURL, twinsEndpoint, id)
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) TwinStates(twinID string, pm PageMetadata, token string) (TwinStatesPage, errors.SDKError) {
+	if twinID == "" {
+		return TwinStatesPage{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	endpoint := fmt.Sprintf("%s/%s", statesEndpoint, twinID)
+	url, err := sdk.withQueryParams(sdk.twinsURL, endpoint, pm)
+	if err != nil {
+		return TwinStatesPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return TwinStatesPage{}, sdkerr
+	}
+
+	var sp TwinStatesPage
+	if err := json.Unmarshal(body, &sp); err != nil {
+		return TwinStatesPage{}, errors.NewSDKError(err)
+	}
+
+	return sp, nil
+}
@@ -0,0 +1,182 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	sdk "github.com/absmach/supermq/pkg/sdk"
+	"github.com/absmach/supermq/twins"
+	"github.com/absmach/supermq/twins/api"
+	"github.com/absmach/supermq/twins/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTwins() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	logger := smqlog.NewMock()
+	mux := api.MakeHandler(svc, authn, logger, "twins", "test")
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func TestCreateTwin(t *testing.T) {
+	ts, svc, authn := setupTwins()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{TwinsURL: ts.URL})
+
+	attr := sdk.TwinAttribute{Name: "temperature", Channel: validID, Subtopic: "room1", PersistState: true}
+	twin := sdk.Twin{
+		Name:       "thermostat",
+		Definition: &sdk.TwinDefinition{Attributes: []sdk.TwinAttribute{attr}},
+	}
+	def := twins.Definition{Attributes: []twins.Attribute{{Name: attr.Name, Channel: attr.Channel, Subtopic: attr.Subtopic, PersistState: true}}}
+	saved := twins.Twin{ID: validID, Owner: validID, Name: twin.Name, Definitions: []twins.Definition{def}}
+
+	cases := []struct {
+		desc     string
+		token    string
+		twin     sdk.Twin
+		authnErr error
+		svcErr   error
+		response sdk.Twin
+		err      errors.SDKError
+	}{
+		{
+			desc:  "create twin successfully",
+			token: validToken,
+			twin:  twin,
+			response: sdk.Twin{
+				ID:    validID,
+				Owner: validID,
+				Name:  twin.Name,
+				Definitions: []sdk.TwinDefinition{
+					{Attributes: []sdk.TwinAttribute{attr}},
+				},
+			},
+		},
+		{
+			desc:     "create twin with invalid token",
+			token:    invalidToken,
+			twin:     twin,
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:  "create twin with empty token",
+			token: "",
+			twin:  twin,
+			err:   errors.NewSDKErrorWithStatus(apiutil.ErrBearerToken, http.StatusUnauthorized),
+		},
+		{
+			desc:   "create twin with service error",
+			token:  validToken,
+			twin:   twin,
+			svcErr: svcerr.ErrCreateEntity,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrCreateEntity, http.StatusUnprocessableEntity),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			session := smqauthn.Session{}
+			if tc.token == validToken {
+				session = smqauthn.Session{UserID: validID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("AddTwin", mock.Anything, session, twins.Twin{Name: tc.twin.Name}, def).Return(saved, tc.svcErr)
+			resp, err := mgsdk.CreateTwin(tc.twin, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			authCall.Unset()
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestTwinStates(t *testing.T) {
+	ts, svc, authn := setupTwins()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{TwinsURL: ts.URL})
+
+	page := twins.StatesPage{
+		Total: 1,
+		Limit: 10,
+		States: []twins.State{
+			{TwinID: validID, ID: 0, Payload: map[string]interface{}{"temperature": 25.0}},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		twinID   string
+		pm       sdk.PageMetadata
+		svcRes   twins.StatesPage
+		svcErr   error
+		response sdk.TwinStatesPage
+		err      errors.SDKError
+	}{
+		{
+			desc:   "list twin states successfully",
+			token:  validToken,
+			twinID: validID,
+			pm:     sdk.PageMetadata{Limit: 10},
+			svcRes: page,
+			response: sdk.TwinStatesPage{
+				Total: 1,
+				Limit: 10,
+				States: []sdk.TwinState{
+					{TwinID: validID, ID: 0, Payload: map[string]interface{}{"temperature": 25.0}},
+				},
+			},
+		},
+		{
+			desc:   "list states of non-existing twin",
+			token:  validToken,
+			twinID: validID,
+			pm:     sdk.PageMetadata{Limit: 10},
+			svcErr: svcerr.ErrNotFound,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+		{
+			desc:   "list twin states with invalid limit",
+			token:  validToken,
+			twinID: validID,
+			pm:     sdk.PageMetadata{Limit: 1000},
+			err:    errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrLimitSize), http.StatusBadRequest),
+		},
+		{
+			desc:   "list states without twin ID",
+			token:  validToken,
+			twinID: "",
+			err:    errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			session := smqauthn.Session{UserID: validID}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, nil)
+			svcCall := svc.On("ListStates", mock.Anything, session, tc.twinID, tc.pm.Offset, tc.pm.Limit).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.TwinStates(tc.twinID, tc.pm, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			authCall.Unset()
+			svcCall.Unset()
+		})
+	}
+}
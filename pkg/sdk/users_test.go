@@ -13,7 +13,6 @@ import (
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
-	authmocks "github.com/absmach/supermq/auth/mocks"
 	smqlog "github.com/absmach/supermq/logger"
 	"github.com/absmach/supermq/pkg/authn"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
@@ -42,8 +41,7 @@ func setupUsers() (*httptest.Server, *umocks.Service, *authnmocks.Authentication
 	provider := new(oauth2mocks.Provider)
 	provider.On("Name").Return("test")
 	authn := new(authnmocks.Authentication)
-	token := new(authmocks.TokenServiceClient)
-	httpapi.MakeHandler(usvc, authn, token, true, mux, logger, "", passRegex, provider)
+	httpapi.MakeHandler(usvc, authn, true, mux, logger, "", passRegex, provider)
 
 	return httptest.NewServer(mux), usvc, authn
 }
@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"net/http"
 
+	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/pkg/server"
 )
 
@@ -24,9 +25,15 @@ type httpServer struct {
 
 var _ server.Server = (*httpServer)(nil)
 
+// NewServer instantiates the HTTP server. The client IP address used by the
+// handler is taken from the proxy headers only for the trusted proxies.
 func NewServer(ctx context.Context, cancel context.CancelFunc, name string, config server.Config, handler http.Handler, logger *slog.Logger) server.Server {
 	baseServer := server.NewBaseServer(ctx, cancel, name, config, logger)
-	hserver := &http.Server{Addr: baseServer.Address, Handler: handler}
+	proxies, err := apiutil.ParseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		logger.Error(fmt.Sprintf("%s service %s server ignores trusted proxies: %s", name, httpProtocol, err))
+	}
+	hserver := &http.Server{Addr: baseServer.Address, Handler: apiutil.ClientIPMiddleware(proxies)(handler)}
 
 	return &httpServer{
 		BaseServer: baseServer,
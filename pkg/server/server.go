@@ -24,12 +24,13 @@ type Server interface {
 
 // Config is a struct that contains the configuration for the server.
 type Config struct {
-	Host         string `env:"HOST"            envDefault:"localhost"`
-	Port         string `env:"PORT"            envDefault:""`
-	CertFile     string `env:"SERVER_CERT"     envDefault:""`
-	KeyFile      string `env:"SERVER_KEY"      envDefault:""`
-	ServerCAFile string `env:"SERVER_CA_CERTS" envDefault:""`
-	ClientCAFile string `env:"CLIENT_CA_CERTS" envDefault:""`
+	Host           string   `env:"HOST"            envDefault:"localhost"`
+	Port           string   `env:"PORT"            envDefault:""`
+	CertFile       string   `env:"SERVER_CERT"     envDefault:""`
+	KeyFile        string   `env:"SERVER_KEY"      envDefault:""`
+	ServerCAFile   string   `env:"SERVER_CA_CERTS" envDefault:""`
+	ClientCAFile   string   `env:"CLIENT_CA_CERTS" envDefault:""`
+	TrustedProxies []string `env:"TRUSTED_PROXIES" envDefault:""`
 }
 
 type BaseServer struct {
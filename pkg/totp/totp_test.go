@@ -0,0 +1,128 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package totp_test
+
+import (
+	"encoding/base32"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 test secret from RFC 6238.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerate(t *testing.T) {
+	cases := []struct {
+		desc string
+		time int64
+		code string
+	}{
+		{desc: "generate code at 59", time: 59, code: "287082"},
+		{desc: "generate code at 1111111109", time: 1111111109, code: "081804"},
+		{desc: "generate code at 1111111111", time: 1111111111, code: "050471"},
+		{desc: "generate code at 1234567890", time: 1234567890, code: "005924"},
+		{desc: "generate code at 2000000000", time: 2000000000, code: "279037"},
+		{desc: "generate code at 20000000000", time: 20000000000, code: "353130"},
+	}
+
+	for _, tc := range cases {
+		code, err := totp.Generate(rfcSecret, time.Unix(tc.time, 0))
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.code, code, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, code))
+	}
+
+	_, err := totp.Generate("invalid secret!", time.Now())
+	assert.Equal(t, totp.ErrInvalidSecret, err)
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating secret %s", err))
+	now := time.Now()
+	code, err := totp.Generate(secret, now)
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating code %s", err))
+
+	cases := []struct {
+		desc   string
+		secret string
+		code   string
+		time   time.Time
+		valid  bool
+	}{
+		{
+			desc:   "validate current code",
+			secret: secret,
+			code:   code,
+			time:   now,
+			valid:  true,
+		},
+		{
+			desc:   "validate code of the previous period",
+			secret: secret,
+			code:   code,
+			time:   now.Add(totp.Period),
+			valid:  true,
+		},
+		{
+			desc:   "validate expired code",
+			secret: secret,
+			code:   code,
+			time:   now.Add(3 * totp.Period),
+			valid:  false,
+		},
+		{
+			desc:   "validate code with invalid length",
+			secret: secret,
+			code:   code[1:],
+			time:   now,
+			valid:  false,
+		},
+		{
+			desc:   "validate code with invalid secret",
+			secret: "invalid secret!",
+			code:   code,
+			time:   now,
+			valid:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		valid := totp.Validate(tc.secret, tc.code, tc.time)
+		assert.Equal(t, tc.valid, valid, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.valid, valid))
+	}
+}
+
+func TestValidateStep(t *testing.T) {
+	code, err := totp.Generate(rfcSecret, time.Unix(59, 0))
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating code %s", err))
+
+	step, ok := totp.ValidateStep(rfcSecret, code, time.Unix(59, 0))
+	assert.True(t, ok, "expected current code to be valid")
+	assert.Equal(t, uint64(1), step, fmt.Sprintf("expected time step 1 got %d", step))
+
+	step, ok = totp.ValidateStep(rfcSecret, code, time.Unix(59, 0).Add(totp.Period))
+	assert.True(t, ok, "expected code of the previous period to be valid")
+	assert.Equal(t, uint64(1), step, fmt.Sprintf("expected time step of the code 1 got %d", step))
+}
+
+func TestProvisioningURI(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating secret %s", err))
+
+	uri := totp.ProvisioningURI(secret, "SuperMQ", "user@example.com")
+	u, err := url.Parse(uri)
+	require.Nil(t, err, fmt.Sprintf("unexpected error parsing uri %s", err))
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.True(t, strings.HasSuffix(u.Path, "SuperMQ:user@example.com"), fmt.Sprintf("unexpected label %s", u.Path))
+	assert.Equal(t, secret, u.Query().Get("secret"))
+	assert.Equal(t, "SuperMQ", u.Query().Get("issuer"))
+}
@@ -0,0 +1,56 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"context"
+	"time"
+
+	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	pkgDomains "github.com/absmach/supermq/pkg/domains"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+var _ MessageRepository = (*retentionRepository)(nil)
+
+type retentionRepository struct {
+	repo     MessageRepository
+	channels grpcChannelsV1.ChannelsServiceClient
+	settings pkgDomains.Settings
+}
+
+// NewRetentionRepository returns the message repository which doesn't read
+// the messages older than the message retention of the channel domain, so the
+// messages are not served once the retention passes even if the storage has
+// not removed them yet.
+func NewRetentionRepository(repo MessageRepository, channels grpcChannelsV1.ChannelsServiceClient, settings pkgDomains.Settings) MessageRepository {
+	return &retentionRepository{
+		repo:     repo,
+		channels: channels,
+		settings: settings,
+	}
+}
+
+func (rr *retentionRepository) ReadAll(chanID string, pm PageMetadata) (MessagesPage, error) {
+	ctx := context.Background()
+
+	res, err := rr.channels.RetrieveEntity(ctx, &grpcCommonV1.RetrieveEntityReq{Id: chanID})
+	if err != nil {
+		return MessagesPage{}, errors.Wrap(ErrReadMessages, err)
+	}
+	s, err := rr.settings.RetrieveSettings(ctx, res.GetEntity().GetDomainId())
+	if err != nil {
+		return MessagesPage{}, errors.Wrap(ErrReadMessages, err)
+	}
+
+	if retention := s.MessageRetention(); retention > 0 {
+		from := float64(time.Now().Add(-retention).UnixNano()) / float64(time.Second)
+		if pm.From < from {
+			pm.From = from
+		}
+	}
+
+	return rr.repo.ReadAll(chanID, pm)
+}
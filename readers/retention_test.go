@@ -0,0 +1,90 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package readers_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	chmocks "github.com/absmach/supermq/channels/mocks"
+	"github.com/absmach/supermq/domains"
+	"github.com/absmach/supermq/internal/testsutil"
+	dmocks "github.com/absmach/supermq/pkg/domains/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/readers"
+	"github.com/absmach/supermq/readers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRetentionReadAll(t *testing.T) {
+	chanID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	entity := &grpcCommonV1.RetrieveEntityRes{Entity: &grpcCommonV1.EntityBasic{Id: chanID, DomainId: domainID}}
+	old := float64(time.Now().Add(-30 * 24 * time.Hour).Unix())
+	recent := float64(time.Now().Add(-time.Hour).Unix())
+
+	cases := []struct {
+		desc        string
+		pm          readers.PageMetadata
+		entityErr   error
+		settings    domains.Settings
+		settingsErr error
+		fromAfter   float64
+		err         error
+	}{
+		{
+			desc:      "read messages without retention",
+			pm:        readers.PageMetadata{From: old},
+			settings:  domains.Settings{},
+			fromAfter: old,
+		},
+		{
+			desc:      "read messages older than retention",
+			pm:        readers.PageMetadata{From: old},
+			settings:  domains.Settings{MessageRetentionDays: 7},
+			fromAfter: float64(time.Now().Add(-7 * 24 * time.Hour).Unix()),
+		},
+		{
+			desc:      "read messages within retention",
+			pm:        readers.PageMetadata{From: recent},
+			settings:  domains.Settings{MessageRetentionDays: 7},
+			fromAfter: recent,
+		},
+		{
+			desc:      "read messages with failed channel retrieval",
+			entityErr: svcerr.ErrNotFound,
+			err:       readers.ErrReadMessages,
+		},
+		{
+			desc:        "read messages with failed settings retrieval",
+			settingsErr: svcerr.ErrViewEntity,
+			err:         readers.ErrReadMessages,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.MessageRepository)
+			channels := new(chmocks.ChannelsServiceClient)
+			settings := new(dmocks.Settings)
+			rr := readers.NewRetentionRepository(repo, channels, settings)
+
+			channels.On("RetrieveEntity", mock.Anything, &grpcCommonV1.RetrieveEntityReq{Id: chanID}).Return(entity, tc.entityErr)
+			settings.On("RetrieveSettings", mock.Anything, domainID).Return(tc.settings, tc.settingsErr)
+			repo.On("ReadAll", chanID, mock.MatchedBy(func(pm readers.PageMetadata) bool {
+				return pm.From >= tc.fromAfter
+			})).Return(readers.MessagesPage{}, nil)
+
+			_, err := rr.ReadAll(chanID, tc.pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				repo.AssertCalled(t, "ReadAll", chanID, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"time"
+
+	"github.com/absmach/supermq/consumers/webhooks"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+)
+
+// ActionType represents the kind of rule action.
+type ActionType string
+
+const (
+	// PublishAction republishes the matching SenML records to another channel.
+	PublishAction ActionType = "publish"
+	// NotifyAction sends the rule result to the recipients using the notifier.
+	NotifyAction ActionType = "notify"
+	// WebhookAction posts the rule result to an HTTP endpoint.
+	WebhookAction ActionType = "webhook"
+	// AlarmAction reports an alarm to the alarms subtopic of the input channel.
+	AlarmAction ActionType = "alarm"
+)
+
+// Action represents an action executed when the rule conditions are met.
+// Only the fields relevant to the action type are used.
+type Action struct {
+	Type       ActionType        `json:"type"`
+	Channel    string            `json:"channel,omitempty"`
+	Subtopic   string            `json:"subtopic,omitempty"`
+	Recipients []string          `json:"recipients,omitempty"`
+	URL        string            `json:"url,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Secret     string            `json:"secret,omitempty"`
+	Severity   uint8             `json:"severity,omitempty"`
+}
+
+// Validate checks that the fields required by the action type are set.
+func (a Action) Validate() error {
+	switch a.Type {
+	case PublishAction:
+		if a.Channel == "" {
+			return errors.Wrap(ErrInvalidAction, errors.New("missing publish channel"))
+		}
+	case NotifyAction:
+		if len(a.Recipients) == 0 {
+			return errors.Wrap(ErrInvalidAction, errors.New("missing notification recipients"))
+		}
+	case WebhookAction:
+		if err := a.webhook("").Validate(); err != nil {
+			return errors.Wrap(ErrInvalidAction, err)
+		}
+	case AlarmAction:
+	default:
+		return ErrInvalidAction
+	}
+
+	return nil
+}
+
+func (a Action) webhook(ruleID string) webhooks.Webhook {
+	return webhooks.Webhook{
+		ID:      ruleID,
+		URL:     a.URL,
+		Headers: a.Headers,
+		Secret:  a.Secret,
+	}
+}
+
+// redactSecrets returns the rule with webhook action secrets removed, so that
+// they are not exposed to the users with read access to the rule.
+func redactSecrets(r Rule) Rule {
+	actions := make([]Action, len(r.Actions))
+	for i, a := range r.Actions {
+		a.Secret = ""
+		actions[i] = a
+	}
+	r.Actions = actions
+
+	return r
+}
+
+// keepSecrets sets the secret of webhook actions that are updated without
+// one to the secret of the existing webhook action with the same URL.
+func keepSecrets(actions, existing []Action) []Action {
+	secrets := make(map[string]string)
+	for _, a := range existing {
+		if a.Type == WebhookAction && a.Secret != "" {
+			secrets[a.URL] = a.Secret
+		}
+	}
+	for i, a := range actions {
+		if a.Type == WebhookAction && a.Secret == "" {
+			actions[i].Secret = secrets[a.URL]
+		}
+	}
+
+	return actions
+}
+
+// Result is the payload of the notify and webhook actions.
+type Result struct {
+	RuleID      string          `json:"rule_id"`
+	RuleName    string          `json:"rule_name,omitempty"`
+	DomainID    string          `json:"domain_id"`
+	ChannelID   string          `json:"channel_id"`
+	Subtopic    string          `json:"subtopic,omitempty"`
+	Publisher   string          `json:"publisher,omitempty"`
+	Messages    []senml.Message `json:"messages"`
+	TriggeredAt time.Time       `json:"triggered_at"`
+}
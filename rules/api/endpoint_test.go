@@ -0,0 +1,661 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/rules"
+	"github.com/absmach/supermq/rules/api"
+	"github.com/absmach/supermq/rules/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	validToken   = "valid"
+	invalidToken = "invalid"
+	contentType  = "application/json"
+	instanceID   = "5de9b29a-feb9-11ed-be56-0242ac120002"
+)
+
+var (
+	validID   = testsutil.GenerateUUID(&testing.T{})
+	domainID  = testsutil.GenerateUUID(&testing.T{})
+	channelID = testsutil.GenerateUUID(&testing.T{})
+	session   = smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID}
+	rule      = rules.Rule{
+		ID:           testsutil.GenerateUUID(&testing.T{}),
+		DomainID:     domainID,
+		Name:         "rule",
+		InputChannel: channelID,
+		Conditions:   []rules.Condition{{Field: rules.ValueField, Operator: rules.GreaterOp, Threshold: 10.0}},
+		Actions:      []rules.Action{{Type: rules.AlarmAction}},
+	}
+	ruleData = fmt.Sprintf(`{"name": "rule", "input_channel": "%s", "conditions": [{"field": "value", "operator": "gt", "threshold": 10}], "actions": [{"type": "alarm"}]}`, channelID)
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	token       string
+	contentType string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+func newRulesServer() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	mux := api.MakeHandler(svc, authn, smqlog.NewMock(), "rules", instanceID)
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func TestCreateRule(t *testing.T) {
+	rs, svc, authn := newRulesServer()
+	defer rs.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		authnRes    smqauthn.Session
+		authnErr    error
+		svcRes      rules.Rule
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "create rule successfully",
+			token:       validToken,
+			data:        ruleData,
+			contentType: contentType,
+			authnRes:    session,
+			svcRes:      rule,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create rule with invalid token",
+			token:       invalidToken,
+			data:        ruleData,
+			contentType: contentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create rule with empty token",
+			data:        ruleData,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create rule without input channel",
+			token:       validToken,
+			data:        `{"conditions": [{"field": "value", "operator": "gt", "threshold": 10}], "actions": [{"type": "alarm"}]}`,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with too long name",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"name": "%s", "input_channel": "%s", "conditions": [{"field": "value", "operator": "gt", "threshold": 10}], "actions": [{"type": "alarm"}]}`, strings.Repeat("a", 1025), channelID),
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule without conditions",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"input_channel": "%s", "actions": [{"type": "alarm"}]}`, channelID),
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule without actions",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"input_channel": "%s", "conditions": [{"field": "value", "operator": "gt", "threshold": 10}]}`, channelID),
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid content type",
+			token:       validToken,
+			data:        ruleData,
+			contentType: "text/plain",
+			authnRes:    session,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create rule with malformed body",
+			token:       validToken,
+			data:        `{"input_channel": `,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid rule",
+			token:       validToken,
+			data:        ruleData,
+			contentType: contentType,
+			authnRes:    session,
+			svcErr:      svcerr.ErrMalformedEntity,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with service error",
+			token:       validToken,
+			data:        ruleData,
+			contentType: contentType,
+			authnRes:    session,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("CreateRule", mock.Anything, tc.authnRes, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client:      rs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/rules/", rs.URL, domainID),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusCreated {
+				location := fmt.Sprintf("/%s/rules/%s", tc.svcRes.DomainID, tc.svcRes.ID)
+				assert.Equal(t, location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, location, res.Header.Get("Location")))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestViewRule(t *testing.T) {
+	rs, svc, authn := newRulesServer()
+	defer rs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnRes smqauthn.Session
+		authnErr error
+		svcRes   rules.Rule
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "view rule successfully",
+			token:    validToken,
+			id:       rule.ID,
+			authnRes: session,
+			svcRes:   rule,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "view rule with invalid token",
+			token:    invalidToken,
+			id:       rule.ID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "view non-existing rule",
+			token:    validToken,
+			id:       validID,
+			authnRes: session,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+		{
+			desc:     "view rule with service error",
+			token:    validToken,
+			id:       rule.ID,
+			authnRes: session,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ViewRule", mock.Anything, tc.authnRes, tc.id).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: rs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/rules/%s", rs.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody rules.Rule
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.ID, resBody.ID, fmt.Sprintf("%s: expected rule %s got %s", tc.desc, tc.svcRes.ID, resBody.ID))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListRules(t *testing.T) {
+	rs, svc, authn := newRulesServer()
+	defer rs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		authnRes smqauthn.Session
+		authnErr error
+		pm       rules.PageMeta
+		svcRes   rules.RulesPage
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list rules successfully",
+			token:    validToken,
+			authnRes: session,
+			pm:       rules.PageMeta{Limit: 10, Status: rules.AllStatus},
+			svcRes:   rules.RulesPage{Total: 1, Limit: 10, Rules: []rules.Rule{rule}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list rules of input channel",
+			token:    validToken,
+			query:    "input_channel=" + channelID,
+			authnRes: session,
+			pm:       rules.PageMeta{Limit: 10, InputChannel: channelID, Status: rules.AllStatus},
+			svcRes:   rules.RulesPage{Total: 1, Limit: 10, Rules: []rules.Rule{rule}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list disabled rules",
+			token:    validToken,
+			query:    "status=disabled",
+			authnRes: session,
+			pm:       rules.PageMeta{Limit: 10, Status: rules.DisabledStatus},
+			svcRes:   rules.RulesPage{Limit: 10},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list rules with offset and limit",
+			token:    validToken,
+			query:    "offset=1&limit=5",
+			authnRes: session,
+			pm:       rules.PageMeta{Offset: 1, Limit: 5, Status: rules.AllStatus},
+			svcRes:   rules.RulesPage{Total: 1, Offset: 1, Limit: 5},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list rules with invalid token",
+			token:    invalidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list rules with invalid status",
+			token:    validToken,
+			query:    "status=invalid",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list rules with invalid offset",
+			token:    validToken,
+			query:    "offset=invalid",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list rules with limit exceeding maximum",
+			token:    validToken,
+			query:    "limit=1000",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list rules with service error",
+			token:    validToken,
+			authnRes: session,
+			pm:       rules.PageMeta{Limit: 10, Status: rules.AllStatus},
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ListRules", mock.Anything, tc.authnRes, tc.pm).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: rs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/rules/?%s", rs.URL, domainID, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody rules.RulesPage
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.Total, resBody.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.svcRes.Total, resBody.Total))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestUpdateRule(t *testing.T) {
+	rs, svc, authn := newRulesServer()
+	defer rs.Close()
+
+	updated := rule
+	updated.Name = "updated"
+
+	cases := []struct {
+		desc        string
+		token       string
+		id          string
+		data        string
+		contentType string
+		authnRes    smqauthn.Session
+		authnErr    error
+		svcRes      rules.Rule
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "update rule successfully",
+			token:       validToken,
+			id:          rule.ID,
+			data:        ruleData,
+			contentType: contentType,
+			authnRes:    session,
+			svcRes:      updated,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update rule with invalid token",
+			token:       invalidToken,
+			id:          rule.ID,
+			data:        ruleData,
+			contentType: contentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "update rule without conditions",
+			token:       validToken,
+			id:          rule.ID,
+			data:        fmt.Sprintf(`{"input_channel": "%s", "actions": [{"type": "alarm"}]}`, channelID),
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update rule with invalid content type",
+			token:       validToken,
+			id:          rule.ID,
+			data:        ruleData,
+			contentType: "text/plain",
+			authnRes:    session,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "update rule with malformed body",
+			token:       validToken,
+			id:          rule.ID,
+			data:        `{"input_channel": `,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update non-existing rule",
+			token:       validToken,
+			id:          validID,
+			data:        ruleData,
+			contentType: contentType,
+			authnRes:    session,
+			svcErr:      svcerr.ErrNotFound,
+			status:      http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("UpdateRule", mock.Anything, tc.authnRes, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client:      rs.Client(),
+				method:      http.MethodPut,
+				url:         fmt.Sprintf("%s/%s/rules/%s", rs.URL, domainID, tc.id),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody rules.Rule
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.Name, resBody.Name, fmt.Sprintf("%s: expected name %s got %s", tc.desc, tc.svcRes.Name, resBody.Name))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestChangeRuleStatus(t *testing.T) {
+	rs, svc, authn := newRulesServer()
+	defer rs.Close()
+
+	disabled := rule
+	disabled.Status = rules.DisabledStatus
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		action   string
+		svcCall  string
+		authnRes smqauthn.Session
+		authnErr error
+		svcRes   rules.Rule
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "enable rule successfully",
+			token:    validToken,
+			id:       rule.ID,
+			action:   "enable",
+			svcCall:  "EnableRule",
+			authnRes: session,
+			svcRes:   rule,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "disable rule successfully",
+			token:    validToken,
+			id:       rule.ID,
+			action:   "disable",
+			svcCall:  "DisableRule",
+			authnRes: session,
+			svcRes:   disabled,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "enable rule with invalid token",
+			token:    invalidToken,
+			id:       rule.ID,
+			action:   "enable",
+			svcCall:  "EnableRule",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "disable non-existing rule",
+			token:    validToken,
+			id:       validID,
+			action:   "disable",
+			svcCall:  "DisableRule",
+			authnRes: session,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+		{
+			desc:     "enable rule with service error",
+			token:    validToken,
+			id:       rule.ID,
+			action:   "enable",
+			svcCall:  "EnableRule",
+			authnRes: session,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On(tc.svcCall, mock.Anything, tc.authnRes, tc.id).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: rs.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/%s/rules/%s/%s", rs.URL, domainID, tc.id, tc.action),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody rules.Rule
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.Status, resBody.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, tc.svcRes.Status, resBody.Status))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestRemoveRule(t *testing.T) {
+	rs, svc, authn := newRulesServer()
+	defer rs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnRes smqauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "remove rule successfully",
+			token:    validToken,
+			id:       rule.ID,
+			authnRes: session,
+			status:   http.StatusNoContent,
+		},
+		{
+			desc:     "remove rule with invalid token",
+			token:    invalidToken,
+			id:       rule.ID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "remove non-existing rule",
+			token:    validToken,
+			id:       validID,
+			authnRes: session,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+		{
+			desc:     "remove rule with service error",
+			token:    validToken,
+			id:       rule.ID,
+			authnRes: session,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("RemoveRule", mock.Anything, tc.authnRes, tc.id).Return(tc.svcErr)
+			req := testRequest{
+				client: rs.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/%s/rules/%s", rs.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
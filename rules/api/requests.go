@@ -0,0 +1,95 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/rules"
+)
+
+type ruleReq struct {
+	Name          string            `json:"name,omitempty"`
+	InputChannel  string            `json:"input_channel"`
+	InputSubtopic string            `json:"input_subtopic,omitempty"`
+	Logic         rules.Logic       `json:"logic,omitempty"`
+	Conditions    []rules.Condition `json:"conditions"`
+	Window        *rules.Window     `json:"window,omitempty"`
+	Actions       []rules.Action    `json:"actions"`
+}
+
+func (req ruleReq) validate() error {
+	if req.InputChannel == "" {
+		return apiutil.ErrMissingChannelID
+	}
+	if len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	if len(req.Conditions) == 0 {
+		return errors.Wrap(errors.ErrMalformedEntity, rules.ErrInvalidCondition)
+	}
+	if len(req.Actions) == 0 {
+		return errors.Wrap(errors.ErrMalformedEntity, rules.ErrInvalidAction)
+	}
+
+	return nil
+}
+
+func (req ruleReq) rule() rules.Rule {
+	return rules.Rule{
+		Name:          req.Name,
+		InputChannel:  req.InputChannel,
+		InputSubtopic: req.InputSubtopic,
+		Logic:         req.Logic,
+		Conditions:    req.Conditions,
+		Window:        req.Window,
+		Actions:       req.Actions,
+	}
+}
+
+type createRuleReq struct {
+	ruleReq
+}
+
+func (req createRuleReq) validate() error {
+	return req.ruleReq.validate()
+}
+
+type viewRuleReq struct {
+	id string
+}
+
+func (req viewRuleReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listRulesReq struct {
+	pm rules.PageMeta
+}
+
+func (req listRulesReq) validate() error {
+	if req.pm.Limit > api.MaxLimitSize || req.pm.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type updateRuleReq struct {
+	id string
+	ruleReq
+}
+
+func (req updateRuleReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return req.ruleReq.validate()
+}
@@ -0,0 +1,156 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/supermq"
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/rules"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const inputChannelKey = "input_channel"
+
+// MakeHandler returns a HTTP API handler with health check and metrics.
+func MakeHandler(svc rules.Service, authn smqauthn.Authentication, logger *slog.Logger, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Route("/{domainID}/rules", func(r chi.Router) {
+		r.Use(api.AuthenticateMiddleware(authn, true))
+
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			createRuleEndpoint(svc),
+			decodeCreateRuleReq,
+			api.EncodeResponse,
+			opts...,
+		), "create_rule").ServeHTTP)
+
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listRulesEndpoint(svc),
+			decodeListRulesReq,
+			api.EncodeResponse,
+			opts...,
+		), "list_rules").ServeHTTP)
+
+		r.Route("/{ruleID}", func(r chi.Router) {
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				viewRuleEndpoint(svc),
+				decodeViewRuleReq,
+				api.EncodeResponse,
+				opts...,
+			), "view_rule").ServeHTTP)
+
+			r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+				updateRuleEndpoint(svc),
+				decodeUpdateRuleReq,
+				api.EncodeResponse,
+				opts...,
+			), "update_rule").ServeHTTP)
+
+			r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+				removeRuleEndpoint(svc),
+				decodeViewRuleReq,
+				api.EncodeResponse,
+				opts...,
+			), "remove_rule").ServeHTTP)
+
+			r.Post("/enable", otelhttp.NewHandler(kithttp.NewServer(
+				enableRuleEndpoint(svc),
+				decodeViewRuleReq,
+				api.EncodeResponse,
+				opts...,
+			), "enable_rule").ServeHTTP)
+
+			r.Post("/disable", otelhttp.NewHandler(kithttp.NewServer(
+				disableRuleEndpoint(svc),
+				decodeViewRuleReq,
+				api.EncodeResponse,
+				opts...,
+			), "disable_rule").ServeHTTP)
+		})
+	})
+
+	mux.Get("/health", supermq.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeCreateRuleReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := createRuleReq{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeViewRuleReq(_ context.Context, r *http.Request) (interface{}, error) {
+	return viewRuleReq{id: chi.URLParam(r, "ruleID")}, nil
+}
+
+func decodeListRulesReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	chID, err := apiutil.ReadStringQuery(r, inputChannelKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	s, err := apiutil.ReadStringQuery(r, api.StatusKey, rules.All)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	status, err := rules.ToStatus(s)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listRulesReq{
+		pm: rules.PageMeta{
+			Offset:       offset,
+			Limit:        limit,
+			InputChannel: chID,
+			Status:       status,
+		},
+	}, nil
+}
+
+func decodeUpdateRuleReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := updateRuleReq{id: chi.URLParam(r, "ruleID")}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
@@ -0,0 +1,240 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+)
+
+// Operator represents a comparison operator used in rule conditions.
+type Operator string
+
+// Supported comparison operators. String and boolean fields support
+// only equality operators.
+const (
+	EqualOp        Operator = "eq"
+	NotEqualOp     Operator = "ne"
+	GreaterOp      Operator = "gt"
+	GreaterEqualOp Operator = "ge"
+	LessOp         Operator = "lt"
+	LessEqualOp    Operator = "le"
+)
+
+// SenML record fields which can be used in rule conditions.
+const (
+	ValueField       = "value"
+	SumField         = "sum"
+	BoolValueField   = "bool_value"
+	StringValueField = "string_value"
+	DataValueField   = "data_value"
+	UnitField        = "unit"
+)
+
+// Condition compares a field of the SenML records to a threshold.
+// If the condition name is set, only records with the same name
+// are compared.
+type Condition struct {
+	Name      string      `json:"name,omitempty"`
+	Field     string      `json:"field"`
+	Operator  Operator    `json:"operator"`
+	Threshold interface{} `json:"threshold"`
+}
+
+// Validate checks that the field and the operator are supported and that
+// the threshold type matches the field type.
+func (c Condition) Validate() error {
+	switch c.Operator {
+	case EqualOp, NotEqualOp:
+	case GreaterOp, GreaterEqualOp, LessOp, LessEqualOp:
+		if !c.numeric() {
+			return errors.Wrap(ErrInvalidCondition, errors.New("ordering operators apply to numeric fields only"))
+		}
+	default:
+		return ErrInvalidCondition
+	}
+
+	var ok bool
+	switch c.Field {
+	case ValueField, SumField:
+		_, ok = toFloat(c.Threshold)
+	case BoolValueField:
+		_, ok = c.Threshold.(bool)
+	case StringValueField, DataValueField, UnitField:
+		_, ok = c.Threshold.(string)
+	}
+	if !ok {
+		return ErrInvalidCondition
+	}
+
+	return nil
+}
+
+func (c Condition) numeric() bool {
+	return c.Field == ValueField || c.Field == SumField
+}
+
+func (c Condition) applies(msg senml.Message) bool {
+	return c.Name == "" || c.Name == msg.Name
+}
+
+// number returns the numeric field of the record, if present.
+func (c Condition) number(msg senml.Message) (float64, bool) {
+	switch {
+	case c.Field == ValueField && msg.Value != nil:
+		return *msg.Value, true
+	case c.Field == SumField && msg.Sum != nil:
+		return *msg.Sum, true
+	default:
+		return 0, false
+	}
+}
+
+func (c Condition) eval(msg senml.Message) bool {
+	switch c.Field {
+	case ValueField, SumField:
+		v, ok := c.number(msg)
+		return ok && c.compare(v)
+	case BoolValueField:
+		t, _ := c.Threshold.(bool)
+		return msg.BoolValue != nil && equality(c.Operator, *msg.BoolValue == t)
+	case StringValueField:
+		return msg.StringValue != nil && c.compareString(*msg.StringValue)
+	case DataValueField:
+		return msg.DataValue != nil && c.compareString(*msg.DataValue)
+	case UnitField:
+		return c.compareString(msg.Unit)
+	default:
+		return false
+	}
+}
+
+func (c Condition) compare(v float64) bool {
+	t, _ := toFloat(c.Threshold)
+	switch c.Operator {
+	case EqualOp:
+		return v == t
+	case NotEqualOp:
+		return v != t
+	case GreaterOp:
+		return v > t
+	case GreaterEqualOp:
+		return v >= t
+	case LessOp:
+		return v < t
+	case LessEqualOp:
+		return v <= t
+	default:
+		return false
+	}
+}
+
+func (c Condition) compareString(v string) bool {
+	t, _ := c.Threshold.(string)
+	return equality(c.Operator, v == t)
+}
+
+func equality(op Operator, equal bool) bool {
+	switch op {
+	case EqualOp:
+		return equal
+	case NotEqualOp:
+		return !equal
+	default:
+		return false
+	}
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// Aggregation represents a function applied to the values of a window.
+type Aggregation string
+
+// Supported window aggregations.
+const (
+	AvgAggregation   Aggregation = "avg"
+	MinAggregation   Aggregation = "min"
+	MaxAggregation   Aggregation = "max"
+	SumAggregation   Aggregation = "sum"
+	CountAggregation Aggregation = "count"
+)
+
+// Window makes numeric conditions compare the aggregation of the values
+// received within the window instead of the value of a single record.
+// A window is bounded by the number of values, time interval or both.
+type Window struct {
+	Aggregation Aggregation `json:"aggregation"`
+	Size        uint64      `json:"size,omitempty"`
+	Interval    string      `json:"interval,omitempty"`
+}
+
+// Validate checks the window aggregation and bounds.
+func (w Window) Validate() error {
+	switch w.Aggregation {
+	case AvgAggregation, MinAggregation, MaxAggregation, SumAggregation, CountAggregation:
+	default:
+		return ErrInvalidWindow
+	}
+	if w.Size == 0 && w.Interval == "" {
+		return ErrInvalidWindow
+	}
+	if w.Interval != "" {
+		d, err := time.ParseDuration(w.Interval)
+		if err != nil || d <= 0 {
+			return ErrInvalidWindow
+		}
+	}
+
+	return nil
+}
+
+func (w Window) duration() time.Duration {
+	d, _ := time.ParseDuration(w.Interval)
+	return d
+}
+
+func (w Window) aggregate(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	res := values[0]
+	switch w.Aggregation {
+	case CountAggregation:
+		return float64(len(values))
+	case MinAggregation:
+		for _, v := range values[1:] {
+			res = min(res, v)
+		}
+	case MaxAggregation:
+		for _, v := range values[1:] {
+			res = max(res, v)
+		}
+	case SumAggregation, AvgAggregation:
+		for _, v := range values[1:] {
+			res += v
+		}
+		if w.Aggregation == AvgAggregation {
+			res /= float64(len(values))
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,8 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rules contains the rules engine service.
+// This service evaluates per-domain rules against SenML messages received
+// from the message broker and executes the rule actions when the rule
+// conditions are met.
+package rules
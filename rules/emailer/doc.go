@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package emailer contains an e-mail implementation of the notifier
+// used by the rules engine notify action.
+package emailer
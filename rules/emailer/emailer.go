@@ -0,0 +1,71 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package emailer
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+
+	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/internal/email"
+	"github.com/absmach/supermq/pkg/domains"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/rules"
+)
+
+const footer = "Sent by SuperMQ rules engine"
+
+var (
+	_ consumers.Notifier   = (*emailer)(nil)
+	_ rules.DomainNotifier = (*emailer)(nil)
+)
+
+type emailer struct {
+	agent    *email.Agent
+	settings domains.Settings
+}
+
+// New instantiates an e-mail notifier which sends the rule result
+// as the e-mail content. The e-mails are sent with the SMTP sender
+// and the branding set in the rule domain settings.
+func New(agent *email.Agent, settings domains.Settings) consumers.Notifier {
+	return &emailer{agent: agent, settings: settings}
+}
+
+func (e *emailer) Notify(from string, to []string, msg *messaging.Message) error {
+	subject, header := e.content(from, msg)
+
+	return e.agent.Send(to, "", subject, header, "", string(msg.GetPayload()), footer)
+}
+
+func (e *emailer) NotifyDomain(ctx context.Context, domainID, from string, to []string, msg *messaging.Message) error {
+	s, err := e.settings.RetrieveSettings(ctx, domainID)
+	if err != nil {
+		return err
+	}
+
+	sender := ""
+	if s.SMTP.FromAddress != "" {
+		sender = (&mail.Address{Name: s.SMTP.FromName, Address: s.SMTP.FromAddress}).String()
+	}
+	f := footer
+	if s.Branding.FooterText != "" {
+		f = s.Branding.FooterText
+	}
+	b := email.Branding{
+		LogoURL:      s.Branding.LogoURL,
+		PrimaryColor: s.Branding.PrimaryColor,
+	}
+	subject, header := e.content(from, msg)
+
+	return e.agent.SendBranded(to, sender, subject, header, "", string(msg.GetPayload()), f, b)
+}
+
+func (e *emailer) content(from string, msg *messaging.Message) (string, string) {
+	subject := fmt.Sprintf("Rule %s triggered", from)
+	header := fmt.Sprintf("Rule %s was triggered by a message published to channel %s", from, msg.GetChannel())
+
+	return subject, header
+}
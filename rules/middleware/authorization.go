@@ -0,0 +1,161 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/rules"
+)
+
+var (
+	_ rules.Service = (*authorizationMiddleware)(nil)
+
+	readPermission    = "read_permission"
+	updatePermission  = "update_permission"
+	publishPermission = "publish_permission"
+)
+
+type authorizationMiddleware struct {
+	svc   rules.Service
+	repo  rules.Repository
+	authz smqauthz.Authorization
+}
+
+// AuthorizationMiddleware adds authorization to the rules service.
+// Managing rules requires update permission on the input channel and
+// publish permission on the channels used by publish actions, while
+// viewing them requires read permission on the input channel.
+// Listing rules of the whole domain requires domain admin permission.
+func AuthorizationMiddleware(svc rules.Service, repo rules.Repository, authz smqauthz.Authorization) rules.Service {
+	return &authorizationMiddleware{
+		svc:   svc,
+		repo:  repo,
+		authz: authz,
+	}
+}
+
+func (am *authorizationMiddleware) CreateRule(ctx context.Context, session smqauthn.Session, r rules.Rule) (rules.Rule, error) {
+	if err := am.authorizeRule(ctx, session, r); err != nil {
+		return rules.Rule{}, err
+	}
+
+	return am.svc.CreateRule(ctx, session, r)
+}
+
+func (am *authorizationMiddleware) ViewRule(ctx context.Context, session smqauthn.Session, id string) (rules.Rule, error) {
+	if err := am.authorizeID(ctx, session, id, readPermission); err != nil {
+		return rules.Rule{}, err
+	}
+
+	return am.svc.ViewRule(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListRules(ctx context.Context, session smqauthn.Session, pm rules.PageMeta) (rules.RulesPage, error) {
+	if pm.InputChannel != "" {
+		if err := am.authorizeChannel(ctx, session, pm.InputChannel, readPermission); err != nil {
+			return rules.RulesPage{}, err
+		}
+
+		return am.svc.ListRules(ctx, session, pm)
+	}
+
+	if err := am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  policies.AdminPermission,
+		ObjectType:  policies.DomainType,
+		Object:      session.DomainID,
+	}); err != nil {
+		return rules.RulesPage{}, err
+	}
+
+	return am.svc.ListRules(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) UpdateRule(ctx context.Context, session smqauthn.Session, r rules.Rule) (rules.Rule, error) {
+	if err := am.authorizeID(ctx, session, r.ID, updatePermission); err != nil {
+		return rules.Rule{}, err
+	}
+	if err := am.authorizeRule(ctx, session, r); err != nil {
+		return rules.Rule{}, err
+	}
+
+	return am.svc.UpdateRule(ctx, session, r)
+}
+
+func (am *authorizationMiddleware) EnableRule(ctx context.Context, session smqauthn.Session, id string) (rules.Rule, error) {
+	if err := am.authorizeID(ctx, session, id, updatePermission); err != nil {
+		return rules.Rule{}, err
+	}
+
+	return am.svc.EnableRule(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) DisableRule(ctx context.Context, session smqauthn.Session, id string) (rules.Rule, error) {
+	if err := am.authorizeID(ctx, session, id, updatePermission); err != nil {
+		return rules.Rule{}, err
+	}
+
+	return am.svc.DisableRule(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) RemoveRule(ctx context.Context, session smqauthn.Session, id string) error {
+	if err := am.authorizeID(ctx, session, id, updatePermission); err != nil {
+		return err
+	}
+
+	return am.svc.RemoveRule(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) Evaluate(ctx context.Context, msg *messaging.Message) error {
+	return am.svc.Evaluate(ctx, msg)
+}
+
+// authorizeRule checks the permissions on the rule input channel
+// and the channels the rule publishes to.
+func (am *authorizationMiddleware) authorizeRule(ctx context.Context, session smqauthn.Session, r rules.Rule) error {
+	if err := am.authorizeChannel(ctx, session, r.InputChannel, updatePermission); err != nil {
+		return err
+	}
+	for _, a := range r.Actions {
+		if a.Type != rules.PublishAction {
+			continue
+		}
+		if err := am.authorizeChannel(ctx, session, a.Channel, publishPermission); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (am *authorizationMiddleware) authorizeID(ctx context.Context, session smqauthn.Session, id, permission string) error {
+	r, err := am.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return am.authorizeChannel(ctx, session, r.InputChannel, permission)
+}
+
+func (am *authorizationMiddleware) authorizeChannel(ctx context.Context, session smqauthn.Session, channelID, permission string) error {
+	return am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  permission,
+		ObjectType:  policies.ChannelType,
+		Object:      channelID,
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/rules"
+)
+
+var _ rules.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    rules.Service
+}
+
+// LoggingMiddleware adds logging facilities to the rules service.
+func LoggingMiddleware(svc rules.Service, logger *slog.Logger) rules.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm *loggingMiddleware) CreateRule(ctx context.Context, session smqauthn.Session, r rules.Rule) (res rules.Rule, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("rule",
+				slog.String("id", res.ID),
+				slog.String("name", r.Name),
+				slog.String("input_channel", r.InputChannel),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create rule failed", args...)
+			return
+		}
+		lm.logger.Info("Create rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateRule(ctx, session, r)
+}
+
+func (lm *loggingMiddleware) ViewRule(ctx context.Context, session smqauthn.Session, id string) (res rules.Rule, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View rule failed", args...)
+			return
+		}
+		lm.logger.Info("View rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewRule(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListRules(ctx context.Context, session smqauthn.Session, pm rules.PageMeta) (res rules.RulesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("page",
+				slog.String("input_channel", pm.InputChannel),
+				slog.String("status", pm.Status.String()),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", res.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List rules failed", args...)
+			return
+		}
+		lm.logger.Info("List rules completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListRules(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) UpdateRule(ctx context.Context, session smqauthn.Session, r rules.Rule) (res rules.Rule, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", r.ID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update rule failed", args...)
+			return
+		}
+		lm.logger.Info("Update rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UpdateRule(ctx, session, r)
+}
+
+func (lm *loggingMiddleware) EnableRule(ctx context.Context, session smqauthn.Session, id string) (res rules.Rule, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Enable rule failed", args...)
+			return
+		}
+		lm.logger.Info("Enable rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.EnableRule(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) DisableRule(ctx context.Context, session smqauthn.Session, id string) (res rules.Rule, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Disable rule failed", args...)
+			return
+		}
+		lm.logger.Info("Disable rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DisableRule(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) RemoveRule(ctx context.Context, session smqauthn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove rule failed", args...)
+			return
+		}
+		lm.logger.Info("Remove rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveRule(ctx, session, id)
+}
+
+// Evaluate logs successful evaluations at debug level since
+// it is called for every message published to the broker.
+func (lm *loggingMiddleware) Evaluate(ctx context.Context, msg *messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", msg.GetChannel()),
+			slog.String("subtopic", msg.GetSubtopic()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Evaluate rules failed", args...)
+			return
+		}
+		lm.logger.Debug("Evaluate rules completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Evaluate(ctx, msg)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/rules"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ rules.Service = (*tracing)(nil)
+
+type tracing struct {
+	tracer trace.Tracer
+	svc    rules.Service
+}
+
+// Tracing adds tracing to the rules service.
+func Tracing(svc rules.Service, tracer trace.Tracer) rules.Service {
+	return &tracing{tracer, svc}
+}
+
+func (tm *tracing) CreateRule(ctx context.Context, session smqauthn.Session, r rules.Rule) (rules.Rule, error) {
+	ctx, span := tm.tracer.Start(ctx, "create_rule", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("input_channel", r.InputChannel),
+	))
+	defer span.End()
+
+	return tm.svc.CreateRule(ctx, session, r)
+}
+
+func (tm *tracing) ViewRule(ctx context.Context, session smqauthn.Session, id string) (rules.Rule, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_rule", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewRule(ctx, session, id)
+}
+
+func (tm *tracing) ListRules(ctx context.Context, session smqauthn.Session, pm rules.PageMeta) (rules.RulesPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_rules", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("input_channel", pm.InputChannel),
+		attribute.String("status", pm.Status.String()),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListRules(ctx, session, pm)
+}
+
+func (tm *tracing) UpdateRule(ctx context.Context, session smqauthn.Session, r rules.Rule) (rules.Rule, error) {
+	ctx, span := tm.tracer.Start(ctx, "update_rule", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("id", r.ID),
+	))
+	defer span.End()
+
+	return tm.svc.UpdateRule(ctx, session, r)
+}
+
+func (tm *tracing) EnableRule(ctx context.Context, session smqauthn.Session, id string) (rules.Rule, error) {
+	ctx, span := tm.tracer.Start(ctx, "enable_rule", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.EnableRule(ctx, session, id)
+}
+
+func (tm *tracing) DisableRule(ctx context.Context, session smqauthn.Session, id string) (rules.Rule, error) {
+	ctx, span := tm.tracer.Start(ctx, "disable_rule", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.DisableRule(ctx, session, id)
+}
+
+func (tm *tracing) RemoveRule(ctx context.Context, session smqauthn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "remove_rule", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.RemoveRule(ctx, session, id)
+}
+
+func (tm *tracing) Evaluate(ctx context.Context, msg *messaging.Message) error {
+	ctx, span := tm.tracer.Start(ctx, "evaluate", trace.WithAttributes(
+		attribute.String("channel_id", msg.GetChannel()),
+		attribute.String("subtopic", msg.GetSubtopic()),
+	))
+	defer span.End()
+
+	return tm.svc.Evaluate(ctx, msg)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "rules_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS rules (
+						id             VARCHAR(36) PRIMARY KEY,
+						domain_id      VARCHAR(36) NOT NULL,
+						name           VARCHAR(1024),
+						input_channel  VARCHAR(36) NOT NULL,
+						input_subtopic VARCHAR(1024),
+						logic          VARCHAR(16),
+						conditions     JSONB NOT NULL,
+						window_config  JSONB,
+						actions        JSONB NOT NULL,
+						status         SMALLINT NOT NULL DEFAULT 0 CHECK (status >= 0),
+						created_by     VARCHAR(254),
+						created_at     TIMESTAMP NOT NULL,
+						updated_by     VARCHAR(254),
+						updated_at     TIMESTAMP
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_rules_input_channel ON rules(input_channel, status);`,
+					`CREATE INDEX IF NOT EXISTS idx_rules_domain ON rules(domain_id);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS rules`,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/absmach/supermq/rules"
+	"github.com/jmoiron/sqlx"
+)
+
+const ruleColumns = `id, domain_id, name, input_channel, input_subtopic, logic, conditions, window_config, actions,
+	status, created_by, created_at, updated_by, updated_at`
+
+type repository struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of rules repository.
+func NewRepository(db postgres.Database) rules.Repository {
+	return &repository{db: db}
+}
+
+func (repo *repository) Save(ctx context.Context, r rules.Rule) (rules.Rule, error) {
+	q := fmt.Sprintf(`INSERT INTO rules (%s)
+		VALUES (:id, :domain_id, :name, :input_channel, :input_subtopic, :logic, :conditions, :window_config, :actions,
+		:status, :created_by, :created_at, :updated_by, :updated_at)
+		RETURNING %s`, ruleColumns, ruleColumns)
+
+	dbr, err := toDBRule(r)
+	if err != nil {
+		return rules.Rule{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return repo.namedQueryOne(ctx, q, dbr, repoerr.ErrCreateEntity)
+}
+
+func (repo *repository) RetrieveByID(ctx context.Context, id string) (rules.Rule, error) {
+	q := fmt.Sprintf(`SELECT %s FROM rules WHERE id = :id`, ruleColumns)
+
+	return repo.namedQueryOne(ctx, q, dbRule{ID: id}, repoerr.ErrViewEntity)
+}
+
+func (repo *repository) RetrieveByChannel(ctx context.Context, channelID string) ([]rules.Rule, error) {
+	q := fmt.Sprintf(`SELECT %s FROM rules WHERE input_channel = :input_channel AND status = :status ORDER BY created_at`, ruleColumns)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbRule{InputChannel: channelID, Status: rules.EnabledStatus})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	return scanRules(rows)
+}
+
+func (repo *repository) RetrieveAll(ctx context.Context, pm rules.PageMeta) (rules.RulesPage, error) {
+	query := rulesQuery(pm)
+	q := fmt.Sprintf(`SELECT %s FROM rules %s ORDER BY created_at LIMIT :limit OFFSET :offset`, ruleColumns, query)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return rules.RulesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	items, err := scanRules(rows)
+	if err != nil {
+		return rules.RulesPage{}, err
+	}
+
+	tq := fmt.Sprintf(`SELECT COUNT(*) FROM rules %s`, query)
+	total, err := postgres.Total(ctx, repo.db, tq, pm)
+	if err != nil {
+		return rules.RulesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return rules.RulesPage{
+		Total:  total,
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		Rules:  items,
+	}, nil
+}
+
+func (repo *repository) Update(ctx context.Context, r rules.Rule) (rules.Rule, error) {
+	q := fmt.Sprintf(`UPDATE rules SET name = :name, input_channel = :input_channel, input_subtopic = :input_subtopic,
+		logic = :logic, conditions = :conditions, window_config = :window_config, actions = :actions,
+		updated_by = :updated_by, updated_at = :updated_at
+		WHERE id = :id RETURNING %s`, ruleColumns)
+
+	dbr, err := toDBRule(r)
+	if err != nil {
+		return rules.Rule{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return repo.namedQueryOne(ctx, q, dbr, repoerr.ErrUpdateEntity)
+}
+
+func (repo *repository) UpdateStatus(ctx context.Context, r rules.Rule) (rules.Rule, error) {
+	q := fmt.Sprintf(`UPDATE rules SET status = :status, updated_by = :updated_by, updated_at = :updated_at
+		WHERE id = :id RETURNING %s`, ruleColumns)
+
+	dbr, err := toDBRule(r)
+	if err != nil {
+		return rules.Rule{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return repo.namedQueryOne(ctx, q, dbr, repoerr.ErrUpdateEntity)
+}
+
+func (repo *repository) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM rules WHERE id = $1`
+
+	result, err := repo.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) namedQueryOne(ctx context.Context, q string, arg interface{}, wrapper error) (rules.Rule, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, arg)
+	if err != nil {
+		return rules.Rule{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return rules.Rule{}, repoerr.ErrNotFound
+	}
+	var dbr dbRule
+	if err := rows.StructScan(&dbr); err != nil {
+		return rules.Rule{}, postgres.HandleError(wrapper, err)
+	}
+
+	return toRule(dbr)
+}
+
+func scanRules(rows *sqlx.Rows) ([]rules.Rule, error) {
+	var items []rules.Rule
+	for rows.Next() {
+		var dbr dbRule
+		if err := rows.StructScan(&dbr); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		r, err := toRule(dbr)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, r)
+	}
+
+	return items, nil
+}
+
+func rulesQuery(pm rules.PageMeta) string {
+	var query []string
+	if pm.DomainID != "" {
+		query = append(query, "domain_id = :domain_id")
+	}
+	if pm.InputChannel != "" {
+		query = append(query, "input_channel = :input_channel")
+	}
+	if pm.Status != rules.AllStatus {
+		query = append(query, "status = :status")
+	}
+	if len(query) > 0 {
+		return fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+	}
+
+	return ""
+}
+
+type dbRule struct {
+	ID            string         `db:"id"`
+	DomainID      string         `db:"domain_id"`
+	Name          sql.NullString `db:"name"`
+	InputChannel  string         `db:"input_channel"`
+	InputSubtopic sql.NullString `db:"input_subtopic"`
+	Logic         sql.NullString `db:"logic"`
+	Conditions    []byte         `db:"conditions"`
+	Window        []byte         `db:"window_config"`
+	Actions       []byte         `db:"actions"`
+	Status        rules.Status   `db:"status"`
+	CreatedBy     sql.NullString `db:"created_by"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedBy     sql.NullString `db:"updated_by"`
+	UpdatedAt     sql.NullTime   `db:"updated_at"`
+}
+
+func toDBRule(r rules.Rule) (dbRule, error) {
+	conditions, err := json.Marshal(r.Conditions)
+	if err != nil {
+		return dbRule{}, err
+	}
+	actions, err := json.Marshal(r.Actions)
+	if err != nil {
+		return dbRule{}, err
+	}
+	var window []byte
+	if r.Window != nil {
+		if window, err = json.Marshal(r.Window); err != nil {
+			return dbRule{}, err
+		}
+	}
+
+	return dbRule{
+		ID:            r.ID,
+		DomainID:      r.DomainID,
+		Name:          toNullString(r.Name),
+		InputChannel:  r.InputChannel,
+		InputSubtopic: toNullString(r.InputSubtopic),
+		Logic:         toNullString(string(r.Logic)),
+		Conditions:    conditions,
+		Window:        window,
+		Actions:       actions,
+		Status:        r.Status,
+		CreatedBy:     toNullString(r.CreatedBy),
+		CreatedAt:     r.CreatedAt,
+		UpdatedBy:     toNullString(r.UpdatedBy),
+		UpdatedAt:     sql.NullTime{Time: r.UpdatedAt, Valid: !r.UpdatedAt.IsZero()},
+	}, nil
+}
+
+func toRule(dbr dbRule) (rules.Rule, error) {
+	r := rules.Rule{
+		ID:            dbr.ID,
+		DomainID:      dbr.DomainID,
+		Name:          dbr.Name.String,
+		InputChannel:  dbr.InputChannel,
+		InputSubtopic: dbr.InputSubtopic.String,
+		Logic:         rules.Logic(dbr.Logic.String),
+		Status:        dbr.Status,
+		CreatedBy:     dbr.CreatedBy.String,
+		CreatedAt:     dbr.CreatedAt.UTC(),
+		UpdatedBy:     dbr.UpdatedBy.String,
+		UpdatedAt:     dbr.UpdatedAt.Time.UTC(),
+	}
+	if err := json.Unmarshal(dbr.Conditions, &r.Conditions); err != nil {
+		return rules.Rule{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+	if err := json.Unmarshal(dbr.Actions, &r.Actions); err != nil {
+		return rules.Rule{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+	if len(dbr.Window) > 0 {
+		r.Window = &rules.Window{}
+		if err := json.Unmarshal(dbr.Window, r.Window); err != nil {
+			return rules.Rule{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+	}
+
+	return r, nil
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,414 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/rules"
+	"github.com/absmach/supermq/rules/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var invalidID = strings.Repeat("a", 37)
+
+func cleanUp(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		require.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+}
+
+func newRule(t *testing.T, domainID, channelID string) rules.Rule {
+	return rules.Rule{
+		ID:            testsutil.GenerateUUID(t),
+		DomainID:      domainID,
+		Name:          "rule",
+		InputChannel:  channelID,
+		InputSubtopic: "temperature",
+		Logic:         rules.AnyLogic,
+		Conditions: []rules.Condition{
+			{Name: "temp", Field: rules.ValueField, Operator: rules.GreaterOp, Threshold: 30.0},
+			{Field: rules.UnitField, Operator: rules.EqualOp, Threshold: "C"},
+		},
+		Window: &rules.Window{Aggregation: rules.AvgAggregation, Size: 5},
+		Actions: []rules.Action{
+			{Type: rules.AlarmAction, Severity: 3},
+			{Type: rules.WebhookAction, URL: "https://example.com/hook", Headers: map[string]string{"X-Key": "value"}, Secret: "secret"},
+		},
+		Status:    rules.EnabledStatus,
+		CreatedBy: testsutil.GenerateUUID(t),
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+func TestSave(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	r := newRule(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	noWindow := newRule(t, r.DomainID, r.InputChannel)
+	noWindow.Window = nil
+
+	cases := []struct {
+		desc string
+		rule rules.Rule
+		err  error
+	}{
+		{
+			desc: "save rule successfully",
+			rule: r,
+			err:  nil,
+		},
+		{
+			desc: "save rule without window successfully",
+			rule: noWindow,
+			err:  nil,
+		},
+		{
+			desc: "save rule with existing ID",
+			rule: r,
+			err:  repoerr.ErrConflict,
+		},
+		{
+			desc: "save rule with invalid domain ID",
+			rule: rules.Rule{
+				ID:           testsutil.GenerateUUID(t),
+				DomainID:     invalidID,
+				InputChannel: testsutil.GenerateUUID(t),
+				CreatedAt:    time.Now().UTC(),
+			},
+			err: repoerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.Save(context.Background(), tc.rule)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.rule, saved, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.rule, saved))
+			}
+		})
+	}
+}
+
+func TestRetrieveByID(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	r := newRule(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), r)
+	require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		resp rules.Rule
+		err  error
+	}{
+		{
+			desc: "retrieve rule successfully",
+			id:   r.ID,
+			resp: r,
+			err:  nil,
+		},
+		{
+			desc: "retrieve non-existing rule",
+			id:   testsutil.GenerateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+		{
+			desc: "retrieve rule with empty ID",
+			id:   "",
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveByID(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestRetrieveByChannel(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+	var items []rules.Rule
+	for i := 0; i < 3; i++ {
+		r := newRule(t, domainID, channelID)
+		r.CreatedAt = r.CreatedAt.Add(time.Duration(i) * time.Second)
+		saved, err := repo.Save(context.Background(), r)
+		require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+		items = append(items, saved)
+	}
+	disabled := newRule(t, domainID, channelID)
+	disabled.Status = rules.DisabledStatus
+	_, err := repo.Save(context.Background(), disabled)
+	require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+	_, err = repo.Save(context.Background(), newRule(t, domainID, testsutil.GenerateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+
+	cases := []struct {
+		desc      string
+		channelID string
+		resp      []rules.Rule
+	}{
+		{
+			desc:      "retrieve enabled rules of channel",
+			channelID: channelID,
+			resp:      items,
+		},
+		{
+			desc:      "retrieve rules of channel without rules",
+			channelID: testsutil.GenerateUUID(t),
+			resp:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveByChannel(context.Background(), tc.channelID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+	num := 10
+	var items, channelItems, enabledItems, disabledItems []rules.Rule
+	for i := 0; i < num; i++ {
+		chID := testsutil.GenerateUUID(t)
+		if i%2 == 0 {
+			chID = channelID
+		}
+		r := newRule(t, domainID, chID)
+		r.CreatedAt = r.CreatedAt.Add(time.Duration(i) * time.Second)
+		if i%3 == 0 {
+			r.Status = rules.DisabledStatus
+		}
+		saved, err := repo.Save(context.Background(), r)
+		require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+		items = append(items, saved)
+		if chID == channelID {
+			channelItems = append(channelItems, saved)
+		}
+		switch saved.Status {
+		case rules.EnabledStatus:
+			enabledItems = append(enabledItems, saved)
+		case rules.DisabledStatus:
+			disabledItems = append(disabledItems, saved)
+		}
+	}
+	_, err := repo.Save(context.Background(), newRule(t, testsutil.GenerateUUID(t), channelID))
+	require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		pm   rules.PageMeta
+		resp rules.RulesPage
+	}{
+		{
+			desc: "retrieve rules of domain",
+			pm:   rules.PageMeta{DomainID: domainID, Status: rules.AllStatus, Limit: 10},
+			resp: rules.RulesPage{Total: uint64(num), Limit: 10, Rules: items},
+		},
+		{
+			desc: "retrieve rules of domain with offset and limit",
+			pm:   rules.PageMeta{DomainID: domainID, Status: rules.AllStatus, Offset: 2, Limit: 3},
+			resp: rules.RulesPage{Total: uint64(num), Offset: 2, Limit: 3, Rules: items[2:5]},
+		},
+		{
+			desc: "retrieve rules of domain input channel",
+			pm:   rules.PageMeta{DomainID: domainID, InputChannel: channelID, Status: rules.AllStatus, Limit: 10},
+			resp: rules.RulesPage{Total: uint64(len(channelItems)), Limit: 10, Rules: channelItems},
+		},
+		{
+			desc: "retrieve enabled rules of domain",
+			pm:   rules.PageMeta{DomainID: domainID, Status: rules.EnabledStatus, Limit: 10},
+			resp: rules.RulesPage{Total: uint64(len(enabledItems)), Limit: 10, Rules: enabledItems},
+		},
+		{
+			desc: "retrieve disabled rules of domain",
+			pm:   rules.PageMeta{DomainID: domainID, Status: rules.DisabledStatus, Limit: 10},
+			resp: rules.RulesPage{Total: uint64(len(disabledItems)), Limit: 10, Rules: disabledItems},
+		},
+		{
+			desc: "retrieve rules of domain without rules",
+			pm:   rules.PageMeta{DomainID: testsutil.GenerateUUID(t), Status: rules.AllStatus, Limit: 10},
+			resp: rules.RulesPage{Limit: 10},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	r := newRule(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), r)
+	require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+
+	updatedAt := time.Now().UTC().Truncate(time.Microsecond)
+	updatedBy := testsutil.GenerateUUID(t)
+
+	updated := r
+	updated.Name = "updated"
+	updated.InputChannel = testsutil.GenerateUUID(t)
+	updated.InputSubtopic = ""
+	updated.Logic = rules.AllLogic
+	updated.Conditions = []rules.Condition{{Field: rules.BoolValueField, Operator: rules.EqualOp, Threshold: true}}
+	updated.Window = nil
+	updated.Actions = []rules.Action{{Type: rules.NotifyAction, Recipients: []string{"user@example.com"}}}
+	updated.UpdatedBy = updatedBy
+	updated.UpdatedAt = updatedAt
+
+	cases := []struct {
+		desc string
+		rule rules.Rule
+		resp rules.Rule
+		err  error
+	}{
+		{
+			desc: "update rule successfully",
+			rule: updated,
+			resp: updated,
+			err:  nil,
+		},
+		{
+			desc: "update non-existing rule",
+			rule: rules.Rule{
+				ID:           testsutil.GenerateUUID(t),
+				InputChannel: testsutil.GenerateUUID(t),
+				UpdatedAt:    updatedAt,
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.Update(context.Background(), tc.rule)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	r := newRule(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), r)
+	require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+
+	updatedAt := time.Now().UTC().Truncate(time.Microsecond)
+	updatedBy := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc   string
+		id     string
+		status rules.Status
+		err    error
+	}{
+		{
+			desc:   "disable rule successfully",
+			id:     r.ID,
+			status: rules.DisabledStatus,
+			err:    nil,
+		},
+		{
+			desc:   "enable rule successfully",
+			id:     r.ID,
+			status: rules.EnabledStatus,
+			err:    nil,
+		},
+		{
+			desc:   "update status of non-existing rule",
+			id:     testsutil.GenerateUUID(t),
+			status: rules.DisabledStatus,
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.UpdateStatus(context.Background(), rules.Rule{
+				ID:        tc.id,
+				Status:    tc.status,
+				UpdatedBy: updatedBy,
+				UpdatedAt: updatedAt,
+			})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.status, resp.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, resp.Status))
+				assert.Equal(t, r.Conditions, resp.Conditions, fmt.Sprintf("%s: expected conditions %v got %v\n", tc.desc, r.Conditions, resp.Conditions))
+				assert.Equal(t, updatedBy, resp.UpdatedBy, fmt.Sprintf("%s: expected updated by %s got %s\n", tc.desc, updatedBy, resp.UpdatedBy))
+				assert.Equal(t, updatedAt, resp.UpdatedAt, fmt.Sprintf("%s: expected updated at %s got %s\n", tc.desc, updatedAt, resp.UpdatedAt))
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewRepository(database)
+
+	r := newRule(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), r)
+	require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove rule successfully",
+			id:   r.ID,
+			err:  nil,
+		},
+		{
+			desc: "remove removed rule",
+			id:   r.ID,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Remove(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
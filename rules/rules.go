@@ -0,0 +1,193 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+var (
+	// ErrInvalidCondition indicates that the rule has no conditions or a malformed condition.
+	ErrInvalidCondition = errors.New("invalid rule condition")
+
+	// ErrInvalidWindow indicates that the rule window is malformed.
+	ErrInvalidWindow = errors.New("invalid rule window")
+
+	// ErrInvalidAction indicates that the rule has no actions or a malformed action.
+	ErrInvalidAction = errors.New("invalid rule action")
+
+	// ErrAction indicates that one or more rule actions failed.
+	ErrAction = errors.New("failed to execute rule action")
+)
+
+// Logic specifies how the rule conditions are combined.
+type Logic string
+
+const (
+	// AllLogic requires all the conditions to be met. This is the default.
+	AllLogic Logic = "all"
+	// AnyLogic requires at least one condition to be met.
+	AnyLogic Logic = "any"
+)
+
+// Rule represents a set of conditions evaluated against the SenML
+// messages published to the input channel, and a set of actions
+// executed when the conditions are met.
+type Rule struct {
+	ID            string      `json:"id"`
+	DomainID      string      `json:"domain_id"`
+	Name          string      `json:"name,omitempty"`
+	InputChannel  string      `json:"input_channel"`
+	InputSubtopic string      `json:"input_subtopic,omitempty"`
+	Logic         Logic       `json:"logic,omitempty"`
+	Conditions    []Condition `json:"conditions"`
+	Window        *Window     `json:"window,omitempty"`
+	Actions       []Action    `json:"actions"`
+	Status        Status      `json:"status"`
+	CreatedBy     string      `json:"created_by,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at,omitempty"`
+	UpdatedBy     string      `json:"updated_by,omitempty"`
+}
+
+// Validate checks the rule logic, conditions, window and actions.
+func (r Rule) Validate() error {
+	switch r.Logic {
+	case "", AllLogic, AnyLogic:
+	default:
+		return ErrInvalidCondition
+	}
+	if len(r.Conditions) == 0 {
+		return ErrInvalidCondition
+	}
+	for _, c := range r.Conditions {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+		if r.Window != nil && !c.numeric() {
+			return errors.Wrap(ErrInvalidWindow, errors.New("windows apply to numeric fields only"))
+		}
+	}
+	if r.Window != nil {
+		if err := r.Window.Validate(); err != nil {
+			return err
+		}
+	}
+	if len(r.Actions) == 0 {
+		return ErrInvalidAction
+	}
+	for _, a := range r.Actions {
+		if err := a.Validate(); err != nil {
+			return err
+		}
+		if a.Type == PublishAction && a.Channel == r.InputChannel && a.Subtopic == r.InputSubtopic {
+			return errors.Wrap(ErrInvalidAction, errors.New("rule can't publish to its input"))
+		}
+	}
+
+	return nil
+}
+
+// RulesPage represents a page of rules.
+type RulesPage struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+	Rules  []Rule `json:"rules"`
+}
+
+func (page RulesPage) MarshalJSON() ([]byte, error) {
+	type Alias RulesPage
+	a := struct {
+		Alias
+	}{
+		Alias: Alias(page),
+	}
+
+	if a.Rules == nil {
+		a.Rules = make([]Rule, 0)
+	}
+
+	return json.Marshal(a)
+}
+
+// PageMeta is used to filter rules.
+type PageMeta struct {
+	Offset       uint64 `json:"offset" db:"offset"`
+	Limit        uint64 `json:"limit" db:"limit"`
+	DomainID     string `json:"domain_id,omitempty" db:"domain_id"`
+	InputChannel string `json:"input_channel,omitempty" db:"input_channel"`
+	Status       Status `json:"status,omitempty" db:"status"`
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// CreateRule creates a rule in the session domain.
+	CreateRule(ctx context.Context, session smqauthn.Session, r Rule) (Rule, error)
+
+	// ViewRule retrieves the rule identified by the given ID.
+	ViewRule(ctx context.Context, session smqauthn.Session, id string) (Rule, error)
+
+	// ListRules retrieves the rules of the session domain filtered by the page metadata.
+	ListRules(ctx context.Context, session smqauthn.Session, pm PageMeta) (RulesPage, error)
+
+	// UpdateRule updates the rule name, input, conditions, window and actions.
+	UpdateRule(ctx context.Context, session smqauthn.Session, r Rule) (Rule, error)
+
+	// EnableRule enables the rule identified by the given ID.
+	EnableRule(ctx context.Context, session smqauthn.Session, id string) (Rule, error)
+
+	// DisableRule disables the rule identified by the given ID.
+	// Disabled rules are not evaluated.
+	DisableRule(ctx context.Context, session smqauthn.Session, id string) (Rule, error)
+
+	// RemoveRule removes the rule identified by the given ID.
+	RemoveRule(ctx context.Context, session smqauthn.Session, id string) error
+
+	// Evaluate evaluates the enabled rules of the message channel against
+	// the message and executes the actions of the rules whose conditions are met.
+	Evaluate(ctx context.Context, msg *messaging.Message) error
+}
+
+// DomainNotifier is implemented by the notifiers that send the rule results
+// on behalf of the rule domain, using the domain sender and branding.
+type DomainNotifier interface {
+	// NotifyDomain sends the message to the recipients on behalf of the domain.
+	NotifyDomain(ctx context.Context, domainID, from string, to []string, msg *messaging.Message) error
+}
+
+// Repository specifies a rule persistence API.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save persists the rule.
+	Save(ctx context.Context, r Rule) (Rule, error)
+
+	// RetrieveByID retrieves the rule having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Rule, error)
+
+	// RetrieveByChannel retrieves the enabled rules having the channel as input.
+	RetrieveByChannel(ctx context.Context, channelID string) ([]Rule, error)
+
+	// RetrieveAll retrieves rules filtered by the page metadata.
+	RetrieveAll(ctx context.Context, pm PageMeta) (RulesPage, error)
+
+	// Update updates the rule name, input, conditions, window and actions.
+	Update(ctx context.Context, r Rule) (Rule, error)
+
+	// UpdateStatus updates the rule status.
+	UpdateStatus(ctx context.Context, r Rule) (Rule, error)
+
+	// Remove removes the rule having the provided identifier.
+	Remove(ctx context.Context, id string) error
+}
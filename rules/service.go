@@ -0,0 +1,436 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/absmach/senml"
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/consumers/webhooks"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers"
+	smqsenml "github.com/absmach/supermq/pkg/transformers/senml"
+)
+
+// Protocol is set on the messages published by the rules engine.
+const Protocol = "rules"
+
+var errMissingNotifier = errors.New("notifier is not configured")
+
+type service struct {
+	idProvider  supermq.IDProvider
+	repo        Repository
+	publisher   messaging.Publisher
+	notifier    consumers.Notifier
+	sender      webhooks.Sender
+	transformer transformers.Transformer
+	windows     *windows
+}
+
+// New instantiates the rules service implementation. Rule windows are kept
+// in memory, so each rules service instance aggregates only the messages
+// it receives.
+func New(idp supermq.IDProvider, repo Repository, publisher messaging.Publisher, notifier consumers.Notifier, sender webhooks.Sender) Service {
+	return &service{
+		idProvider:  idp,
+		repo:        repo,
+		publisher:   publisher,
+		notifier:    notifier,
+		sender:      sender,
+		transformer: smqsenml.New(smqsenml.JSON),
+		windows:     newWindows(),
+	}
+}
+
+func (svc *service) CreateRule(ctx context.Context, session smqauthn.Session, r Rule) (Rule, error) {
+	if err := r.Validate(); err != nil {
+		return Rule{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return Rule{}, err
+	}
+	r.ID = id
+	r.DomainID = session.DomainID
+	r.Status = EnabledStatus
+	r.CreatedBy = session.UserID
+	r.CreatedAt = time.Now().UTC()
+
+	saved, err := svc.repo.Save(ctx, r)
+	if err != nil {
+		return Rule{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return saved, nil
+}
+
+func (svc *service) ViewRule(ctx context.Context, session smqauthn.Session, id string) (Rule, error) {
+	r, err := svc.retrieve(ctx, session, id)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	return redactSecrets(r), nil
+}
+
+func (svc *service) ListRules(ctx context.Context, session smqauthn.Session, pm PageMeta) (RulesPage, error) {
+	pm.DomainID = session.DomainID
+	page, err := svc.repo.RetrieveAll(ctx, pm)
+	if err != nil {
+		return RulesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for i := range page.Rules {
+		page.Rules[i] = redactSecrets(page.Rules[i])
+	}
+
+	return page, nil
+}
+
+func (svc *service) UpdateRule(ctx context.Context, session smqauthn.Session, r Rule) (Rule, error) {
+	if err := r.Validate(); err != nil {
+		return Rule{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	existing, err := svc.retrieve(ctx, session, r.ID)
+	if err != nil {
+		return Rule{}, err
+	}
+	r.Actions = keepSecrets(r.Actions, existing.Actions)
+	r.UpdatedBy = session.UserID
+	r.UpdatedAt = time.Now().UTC()
+
+	updated, err := svc.repo.Update(ctx, r)
+	if err != nil {
+		return Rule{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	svc.windows.reset(r.ID)
+
+	return redactSecrets(updated), nil
+}
+
+func (svc *service) EnableRule(ctx context.Context, session smqauthn.Session, id string) (Rule, error) {
+	return svc.changeStatus(ctx, session, id, EnabledStatus)
+}
+
+func (svc *service) DisableRule(ctx context.Context, session smqauthn.Session, id string) (Rule, error) {
+	return svc.changeStatus(ctx, session, id, DisabledStatus)
+}
+
+func (svc *service) RemoveRule(ctx context.Context, session smqauthn.Session, id string) error {
+	if _, err := svc.retrieve(ctx, session, id); err != nil {
+		return err
+	}
+	if err := svc.repo.Remove(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	svc.windows.reset(id)
+
+	return nil
+}
+
+func (svc *service) Evaluate(ctx context.Context, msg *messaging.Message) error {
+	// Messages published by rules are not evaluated to prevent loops.
+	if msg.GetProtocol() == Protocol {
+		return nil
+	}
+	rules, err := svc.repo.RetrieveByChannel(ctx, msg.GetChannel())
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	// Rules are evaluated against SenML only, other messages are ignored.
+	m, err := svc.transformer.Transform(msg)
+	if err != nil {
+		return nil
+	}
+	records := m.([]smqsenml.Message)
+
+	var ret error
+	for _, r := range rules {
+		if r.InputSubtopic != "" && r.InputSubtopic != msg.GetSubtopic() {
+			continue
+		}
+		matched, ok := svc.match(r, records)
+		if !ok {
+			continue
+		}
+		if err := svc.execute(ctx, r, msg, matched); err != nil {
+			ret = err
+		}
+	}
+
+	return ret
+}
+
+func (svc *service) changeStatus(ctx context.Context, session smqauthn.Session, id string, status Status) (Rule, error) {
+	r, err := svc.retrieve(ctx, session, id)
+	if err != nil {
+		return Rule{}, err
+	}
+	if r.Status == status {
+		return Rule{}, errors.ErrStatusAlreadyAssigned
+	}
+	r.Status = status
+	r.UpdatedBy = session.UserID
+	r.UpdatedAt = time.Now().UTC()
+
+	updated, err := svc.repo.UpdateStatus(ctx, r)
+	if err != nil {
+		return Rule{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	svc.windows.reset(id)
+
+	return redactSecrets(updated), nil
+}
+
+func (svc *service) retrieve(ctx context.Context, session smqauthn.Session, id string) (Rule, error) {
+	r, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return Rule{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if r.DomainID != session.DomainID {
+		return Rule{}, svcerr.ErrNotFound
+	}
+
+	return r, nil
+}
+
+// match evaluates the rule conditions against the records and returns the
+// records which met the conditions. A condition is met if any of the records
+// it applies to meets it or, for windowed rules, if the aggregation of the
+// window meets it. Conditions that apply to none of the records are not met.
+func (svc *service) match(r Rule, records []smqsenml.Message) ([]smqsenml.Message, bool) {
+	var matched []smqsenml.Message
+	seen := make(map[int]bool)
+	met := 0
+	for i, c := range r.Conditions {
+		var ok bool
+		var idxs []int
+		if r.Window == nil {
+			for j, rec := range records {
+				if c.applies(rec) && c.eval(rec) {
+					ok = true
+					idxs = append(idxs, j)
+				}
+			}
+		} else {
+			var values []float64
+			for j, rec := range records {
+				if !c.applies(rec) {
+					continue
+				}
+				v, has := c.number(rec)
+				if !has {
+					continue
+				}
+				values = svc.windows.add(r.ID, i, *r.Window, recordTime(rec), v)
+				idxs = append(idxs, j)
+			}
+			ok = len(idxs) > 0 && c.compare(r.Window.aggregate(values))
+		}
+		if !ok {
+			continue
+		}
+		met++
+		for _, j := range idxs {
+			seen[j] = true
+		}
+	}
+
+	switch r.Logic {
+	case AnyLogic:
+		if met == 0 {
+			return nil, false
+		}
+	default:
+		if met < len(r.Conditions) {
+			return nil, false
+		}
+	}
+
+	for j, rec := range records {
+		if seen[j] {
+			matched = append(matched, rec)
+		}
+	}
+
+	return matched, true
+}
+
+// execute runs all the rule actions. Failure of an action doesn't prevent
+// execution of the remaining ones, and the last failure is returned.
+func (svc *service) execute(ctx context.Context, r Rule, msg *messaging.Message, records []smqsenml.Message) error {
+	res := Result{
+		RuleID:      r.ID,
+		RuleName:    r.Name,
+		DomainID:    r.DomainID,
+		ChannelID:   msg.GetChannel(),
+		Subtopic:    msg.GetSubtopic(),
+		Publisher:   msg.GetPublisher(),
+		Messages:    records,
+		TriggeredAt: time.Now().UTC(),
+	}
+	payload, err := json.Marshal(res)
+	if err != nil {
+		return errors.Wrap(ErrAction, err)
+	}
+
+	var ret error
+	for _, a := range r.Actions {
+		var err error
+		switch a.Type {
+		case PublishAction:
+			err = svc.publish(ctx, a, msg, records)
+		case NotifyAction:
+			err = svc.notify(ctx, r, a, msg, payload)
+		case WebhookAction:
+			err = svc.callWebhook(ctx, r, a, payload)
+		case AlarmAction:
+			err = svc.raiseAlarm(ctx, r, a, msg, records)
+		}
+		if err != nil {
+			ret = errors.Wrap(ErrAction, err)
+		}
+	}
+
+	return ret
+}
+
+func (svc *service) publish(ctx context.Context, a Action, msg *messaging.Message, records []smqsenml.Message) error {
+	pack := senml.Pack{Records: make([]senml.Record, len(records))}
+	for i, rec := range records {
+		pack.Records[i] = senml.Record{
+			Name:        rec.Name,
+			Unit:        rec.Unit,
+			Time:        rec.Time,
+			UpdateTime:  rec.UpdateTime,
+			Value:       rec.Value,
+			StringValue: rec.StringValue,
+			DataValue:   rec.DataValue,
+			BoolValue:   rec.BoolValue,
+			Sum:         rec.Sum,
+		}
+	}
+	payload, err := senml.Encode(pack, senml.JSON)
+	if err != nil {
+		return err
+	}
+
+	out := &messaging.Message{
+		Channel:   a.Channel,
+		Subtopic:  a.Subtopic,
+		Publisher: msg.GetPublisher(),
+		Protocol:  Protocol,
+		Payload:   payload,
+		Created:   time.Now().UnixNano(),
+	}
+
+	return svc.publisher.Publish(ctx, a.Channel, out)
+}
+
+func (svc *service) notify(ctx context.Context, r Rule, a Action, msg *messaging.Message, payload []byte) error {
+	if svc.notifier == nil {
+		return errMissingNotifier
+	}
+	from := r.Name
+	if from == "" {
+		from = r.ID
+	}
+	out := &messaging.Message{
+		Channel:   msg.GetChannel(),
+		Subtopic:  msg.GetSubtopic(),
+		Publisher: msg.GetPublisher(),
+		Protocol:  Protocol,
+		Payload:   payload,
+		Created:   time.Now().UnixNano(),
+	}
+
+	if dn, ok := svc.notifier.(DomainNotifier); ok {
+		return dn.NotifyDomain(ctx, r.DomainID, from, a.Recipients, out)
+	}
+
+	return svc.notifier.Notify(from, a.Recipients, out)
+}
+
+func (svc *service) callWebhook(ctx context.Context, r Rule, a Action, payload []byte) error {
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return err
+	}
+	_, err = svc.sender.Send(ctx, a.webhook(r.ID), id, payload)
+
+	return err
+}
+
+// raiseAlarm reports the last of the matching records to the alarms
+// subtopic of the input channel on behalf of the message publisher.
+func (svc *service) raiseAlarm(ctx context.Context, r Rule, a Action, msg *messaging.Message, records []smqsenml.Message) error {
+	rec := records[len(records)-1]
+	rep := alarms.Report{
+		RuleID:      r.ID,
+		Measurement: rec.Name,
+		Value:       recordValue(rec),
+		Unit:        rec.Unit,
+		Cause:       r.Name,
+		Severity:    a.Severity,
+	}
+	for _, c := range r.Conditions {
+		if c.applies(rec) {
+			rep.Threshold = fmt.Sprint(c.Threshold)
+			break
+		}
+	}
+	payload, err := json.Marshal(rep)
+	if err != nil {
+		return err
+	}
+
+	out := &messaging.Message{
+		Channel:   msg.GetChannel(),
+		Subtopic:  alarms.Subtopic,
+		Publisher: msg.GetPublisher(),
+		Protocol:  Protocol,
+		Payload:   payload,
+		Created:   time.Now().UnixNano(),
+	}
+
+	return svc.publisher.Publish(ctx, msg.GetChannel(), out)
+}
+
+func recordValue(rec smqsenml.Message) string {
+	switch {
+	case rec.Value != nil:
+		return strconv.FormatFloat(*rec.Value, 'f', -1, 64)
+	case rec.Sum != nil:
+		return strconv.FormatFloat(*rec.Sum, 'f', -1, 64)
+	case rec.BoolValue != nil:
+		return strconv.FormatBool(*rec.BoolValue)
+	case rec.StringValue != nil:
+		return *rec.StringValue
+	case rec.DataValue != nil:
+		return *rec.DataValue
+	default:
+		return ""
+	}
+}
+
+func recordTime(rec smqsenml.Message) time.Time {
+	if rec.Time == 0 {
+		return time.Now()
+	}
+
+	return time.Unix(0, int64(rec.Time))
+}
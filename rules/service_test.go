@@ -0,0 +1,454 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package rules_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/consumers/mocks"
+	whmocks "github.com/absmach/supermq/consumers/webhooks/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	pubsubmocks "github.com/absmach/supermq/pkg/messaging/mocks"
+	"github.com/absmach/supermq/pkg/uuid"
+	"github.com/absmach/supermq/rules"
+	rmocks "github.com/absmach/supermq/rules/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	inputChannel  = testsutil.GenerateUUID(&testing.T{})
+	outputChannel = testsutil.GenerateUUID(&testing.T{})
+	session       = smqauthn.Session{DomainID: testsutil.GenerateUUID(&testing.T{}), UserID: testsutil.GenerateUUID(&testing.T{})}
+	validRule     = rules.Rule{
+		Name:         "overheat",
+		InputChannel: inputChannel,
+		Conditions: []rules.Condition{
+			{Name: "temperature", Field: rules.ValueField, Operator: rules.GreaterOp, Threshold: 30.0},
+		},
+		Actions: []rules.Action{
+			{Type: rules.PublishAction, Channel: outputChannel},
+		},
+	}
+)
+
+type mocksSet struct {
+	repo     *rmocks.Repository
+	pubsub   *pubsubmocks.PubSub
+	notifier *mocks.Notifier
+	sender   *whmocks.Sender
+}
+
+func newService() (rules.Service, mocksSet) {
+	m := mocksSet{
+		repo:     new(rmocks.Repository),
+		pubsub:   new(pubsubmocks.PubSub),
+		notifier: new(mocks.Notifier),
+		sender:   new(whmocks.Sender),
+	}
+
+	return rules.New(uuid.NewMock(), m.repo, m.pubsub, m.notifier, m.sender), m
+}
+
+func TestCreateRule(t *testing.T) {
+	svc, m := newService()
+
+	cases := []struct {
+		desc    string
+		rule    rules.Rule
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "create rule successfully",
+			rule: validRule,
+		},
+		{
+			desc: "create rule without conditions",
+			rule: rules.Rule{InputChannel: inputChannel, Actions: validRule.Actions},
+			err:  rules.ErrInvalidCondition,
+		},
+		{
+			desc: "create rule with string threshold for numeric field",
+			rule: rules.Rule{
+				InputChannel: inputChannel,
+				Conditions:   []rules.Condition{{Field: rules.ValueField, Operator: rules.GreaterOp, Threshold: "30"}},
+				Actions:      validRule.Actions,
+			},
+			err: rules.ErrInvalidCondition,
+		},
+		{
+			desc: "create rule with ordering operator for string field",
+			rule: rules.Rule{
+				InputChannel: inputChannel,
+				Conditions:   []rules.Condition{{Field: rules.StringValueField, Operator: rules.GreaterOp, Threshold: "on"}},
+				Actions:      validRule.Actions,
+			},
+			err: rules.ErrInvalidCondition,
+		},
+		{
+			desc: "create rule with invalid window",
+			rule: rules.Rule{
+				InputChannel: inputChannel,
+				Conditions:   validRule.Conditions,
+				Window:       &rules.Window{Aggregation: rules.AvgAggregation},
+				Actions:      validRule.Actions,
+			},
+			err: rules.ErrInvalidWindow,
+		},
+		{
+			desc: "create rule without actions",
+			rule: rules.Rule{InputChannel: inputChannel, Conditions: validRule.Conditions},
+			err:  rules.ErrInvalidAction,
+		},
+		{
+			desc: "create rule publishing to its input",
+			rule: rules.Rule{
+				InputChannel: inputChannel,
+				Conditions:   validRule.Conditions,
+				Actions:      []rules.Action{{Type: rules.PublishAction, Channel: inputChannel}},
+			},
+			err: rules.ErrInvalidAction,
+		},
+		{
+			desc: "create rule with invalid webhook url",
+			rule: rules.Rule{
+				InputChannel: inputChannel,
+				Conditions:   validRule.Conditions,
+				Actions:      []rules.Action{{Type: rules.WebhookAction, URL: "invalid"}},
+			},
+			err: rules.ErrInvalidAction,
+		},
+		{
+			desc:    "create rule with repo error",
+			rule:    validRule,
+			repoErr: repoerr.ErrCreateEntity,
+			err:     svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := m.repo.On("Save", context.Background(), mock.Anything).Return(tc.rule, tc.repoErr)
+			_, err := svc.CreateRule(context.Background(), session, tc.rule)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestDisableRule(t *testing.T) {
+	svc, m := newService()
+
+	enabled := validRule
+	enabled.ID = testsutil.GenerateUUID(t)
+	enabled.DomainID = session.DomainID
+	disabled := enabled
+	disabled.Status = rules.DisabledStatus
+
+	cases := []struct {
+		desc     string
+		repoResp rules.Rule
+		err      error
+	}{
+		{
+			desc:     "disable enabled rule",
+			repoResp: enabled,
+		},
+		{
+			desc:     "disable disabled rule",
+			repoResp: disabled,
+			err:      errors.ErrStatusAlreadyAssigned,
+		},
+		{
+			desc:     "disable rule from another domain",
+			repoResp: rules.Rule{ID: enabled.ID, DomainID: testsutil.GenerateUUID(t)},
+			err:      svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := m.repo.On("RetrieveByID", context.Background(), enabled.ID).Return(tc.repoResp, nil)
+			repoCall1 := m.repo.On("UpdateStatus", context.Background(), mock.Anything).Return(disabled, nil)
+			res, err := svc.DisableRule(context.Background(), session, enabled.ID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, rules.DisabledStatus, res.Status)
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestUpdateRule(t *testing.T) {
+	svc, m := newService()
+
+	hookURL := "https://example.com/hooks/overheat"
+	existing := validRule
+	existing.ID = testsutil.GenerateUUID(t)
+	existing.DomainID = session.DomainID
+	existing.Actions = []rules.Action{{Type: rules.WebhookAction, URL: hookURL, Secret: "secret"}}
+
+	cases := []struct {
+		desc   string
+		secret string
+		stored string
+	}{
+		{
+			desc:   "update rule without webhook secret",
+			secret: "",
+			stored: "secret",
+		},
+		{
+			desc:   "update rule with new webhook secret",
+			secret: "new-secret",
+			stored: "new-secret",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			update := existing
+			update.Actions = []rules.Action{{Type: rules.WebhookAction, URL: hookURL, Secret: tc.secret}}
+			stored := existing
+			stored.Actions = []rules.Action{{Type: rules.WebhookAction, URL: hookURL, Secret: tc.stored}}
+			repoCall := m.repo.On("RetrieveByID", context.Background(), existing.ID).Return(existing, nil)
+			var saved string
+			repoCall1 := m.repo.On("Update", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(rules.Rule).Actions[0].Secret
+			}).Return(stored, nil)
+			res, err := svc.UpdateRule(context.Background(), session, update)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.stored, saved, fmt.Sprintf("%s: expected stored secret %s got %s", tc.desc, tc.stored, saved))
+			assert.Empty(t, res.Actions[0].Secret, "webhook action secret should not be exposed")
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestViewRule(t *testing.T) {
+	svc, m := newService()
+
+	r := validRule
+	r.ID = testsutil.GenerateUUID(t)
+	r.DomainID = session.DomainID
+	r.Actions = []rules.Action{{Type: rules.WebhookAction, URL: "https://example.com/hooks/overheat", Secret: "secret"}}
+
+	repoCall := m.repo.On("RetrieveByID", context.Background(), r.ID).Return(r, nil)
+	res, err := svc.ViewRule(context.Background(), session, r.ID)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Empty(t, res.Actions[0].Secret, "webhook action secret should not be exposed")
+	repoCall.Unset()
+
+	repoCall = m.repo.On("RetrieveAll", context.Background(), mock.Anything).Return(rules.RulesPage{Rules: []rules.Rule{r}}, nil)
+	page, err := svc.ListRules(context.Background(), session, rules.PageMeta{})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Empty(t, page.Rules[0].Actions[0].Secret, "webhook action secret should not be exposed")
+	repoCall.Unset()
+}
+
+func TestEvaluate(t *testing.T) {
+	publish := []rules.Action{{Type: rules.PublishAction, Channel: outputChannel}}
+	cond := func(name string, op rules.Operator, threshold interface{}) rules.Condition {
+		return rules.Condition{Name: name, Field: rules.ValueField, Operator: op, Threshold: threshold}
+	}
+	msg := func(payload string) *messaging.Message {
+		return &messaging.Message{Channel: inputChannel, Subtopic: "room1", Payload: []byte(payload)}
+	}
+
+	cases := []struct {
+		desc     string
+		rule     rules.Rule
+		msgs     []*messaging.Message
+		publish  int
+		notify   int
+		webhook  int
+		retrErr  error
+		sendCode int
+		sendErr  error
+		err      error
+	}{
+		{
+			desc:    "evaluate message meeting threshold",
+			rule:    rules.Rule{Conditions: []rules.Condition{cond("temperature", rules.GreaterOp, 30.0)}, Actions: publish},
+			msgs:    []*messaging.Message{msg(`[{"n":"temperature","v":35}]`)},
+			publish: 1,
+		},
+		{
+			desc: "evaluate message below threshold",
+			rule: rules.Rule{Conditions: []rules.Condition{cond("temperature", rules.GreaterOp, 30.0)}, Actions: publish},
+			msgs: []*messaging.Message{msg(`[{"n":"temperature","v":25}]`)},
+		},
+		{
+			desc: "evaluate message with other measurement",
+			rule: rules.Rule{Conditions: []rules.Condition{cond("temperature", rules.GreaterOp, 30.0)}, Actions: publish},
+			msgs: []*messaging.Message{msg(`[{"n":"humidity","v":35}]`)},
+		},
+		{
+			desc: "evaluate message from other subtopic",
+			rule: rules.Rule{InputSubtopic: "room2", Conditions: []rules.Condition{cond("temperature", rules.GreaterOp, 30.0)}, Actions: publish},
+			msgs: []*messaging.Message{msg(`[{"n":"temperature","v":35}]`)},
+		},
+		{
+			desc: "evaluate non-SenML message",
+			rule: rules.Rule{Conditions: []rules.Condition{cond("temperature", rules.GreaterOp, 30.0)}, Actions: publish},
+			msgs: []*messaging.Message{msg(`{"temperature": 35}`)},
+		},
+		{
+			desc: "evaluate message published by rules",
+			rule: rules.Rule{Conditions: []rules.Condition{cond("temperature", rules.GreaterOp, 30.0)}, Actions: publish},
+			msgs: []*messaging.Message{{Channel: inputChannel, Protocol: rules.Protocol, Payload: []byte(`[{"n":"temperature","v":35}]`)}},
+		},
+		{
+			desc: "evaluate message meeting one of all conditions",
+			rule: rules.Rule{
+				Conditions: []rules.Condition{
+					cond("temperature", rules.GreaterOp, 30.0),
+					{Name: "door", Field: rules.BoolValueField, Operator: rules.EqualOp, Threshold: true},
+				},
+				Actions: publish,
+			},
+			msgs: []*messaging.Message{msg(`[{"n":"temperature","v":35},{"n":"door","vb":false}]`)},
+		},
+		{
+			desc: "evaluate message meeting all conditions",
+			rule: rules.Rule{
+				Conditions: []rules.Condition{
+					cond("temperature", rules.GreaterOp, 30.0),
+					{Name: "door", Field: rules.BoolValueField, Operator: rules.EqualOp, Threshold: true},
+				},
+				Actions: publish,
+			},
+			msgs:    []*messaging.Message{msg(`[{"n":"temperature","v":35},{"n":"door","vb":true}]`)},
+			publish: 1,
+		},
+		{
+			desc: "evaluate message meeting one of any conditions",
+			rule: rules.Rule{
+				Logic: rules.AnyLogic,
+				Conditions: []rules.Condition{
+					cond("temperature", rules.GreaterOp, 30.0),
+					{Name: "status", Field: rules.StringValueField, Operator: rules.EqualOp, Threshold: "alarm"},
+				},
+				Actions: publish,
+			},
+			msgs:    []*messaging.Message{msg(`[{"n":"temperature","v":20},{"n":"status","vs":"alarm"}]`)},
+			publish: 1,
+		},
+		{
+			desc: "evaluate messages with window average",
+			rule: rules.Rule{
+				Conditions: []rules.Condition{cond("temperature", rules.GreaterEqualOp, 30.0)},
+				Window:     &rules.Window{Aggregation: rules.AvgAggregation, Size: 3},
+				Actions:    publish,
+			},
+			msgs: []*messaging.Message{
+				msg(`[{"n":"temperature","v":20}]`),
+				msg(`[{"n":"temperature","v":30}]`),
+				msg(`[{"n":"temperature","v":40}]`),
+				msg(`[{"n":"temperature","v":50}]`),
+			},
+			publish: 2,
+		},
+		{
+			desc: "evaluate messages with window count",
+			rule: rules.Rule{
+				Conditions: []rules.Condition{cond("temperature", rules.GreaterOp, 2)},
+				Window:     &rules.Window{Aggregation: rules.CountAggregation, Interval: "1h"},
+				Actions:    publish,
+			},
+			msgs: []*messaging.Message{
+				msg(`[{"n":"temperature","v":20}]`),
+				msg(`[{"n":"temperature","v":30}]`),
+				msg(`[{"n":"temperature","v":40}]`),
+			},
+			publish: 1,
+		},
+		{
+			desc: "evaluate message with notify and webhook actions",
+			rule: rules.Rule{
+				Conditions: []rules.Condition{cond("temperature", rules.GreaterOp, 30.0)},
+				Actions: []rules.Action{
+					{Type: rules.NotifyAction, Recipients: []string{"admin@example.com"}},
+					{Type: rules.WebhookAction, URL: "https://example.com/hook"},
+				},
+			},
+			msgs:     []*messaging.Message{msg(`[{"n":"temperature","v":35}]`)},
+			notify:   1,
+			webhook:  1,
+			sendCode: http.StatusOK,
+		},
+		{
+			desc: "evaluate message with failing webhook action",
+			rule: rules.Rule{
+				Conditions: []rules.Condition{cond("temperature", rules.GreaterOp, 30.0)},
+				Actions: []rules.Action{
+					{Type: rules.WebhookAction, URL: "https://example.com/hook"},
+					{Type: rules.PublishAction, Channel: outputChannel},
+				},
+			},
+			msgs:     []*messaging.Message{msg(`[{"n":"temperature","v":35}]`)},
+			publish:  1,
+			webhook:  1,
+			sendCode: http.StatusInternalServerError,
+			sendErr:  errors.New("internal server error"),
+			err:      rules.ErrAction,
+		},
+		{
+			desc: "evaluate message with alarm action",
+			rule: rules.Rule{
+				Conditions: []rules.Condition{cond("temperature", rules.GreaterOp, 30.0)},
+				Actions:    []rules.Action{{Type: rules.AlarmAction, Severity: 3}},
+			},
+			msgs:    []*messaging.Message{msg(`[{"n":"temperature","v":35}]`)},
+			publish: 1,
+		},
+		{
+			desc:    "evaluate message with repo error",
+			msgs:    []*messaging.Message{msg(`[{"n":"temperature","v":35}]`)},
+			retrErr: repoerr.ErrViewEntity,
+			err:     repoerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, m := newService()
+			tc.rule.ID = testsutil.GenerateUUID(t)
+			tc.rule.InputChannel = inputChannel
+			m.repo.On("RetrieveByChannel", context.Background(), inputChannel).Return([]rules.Rule{tc.rule}, tc.retrErr)
+			m.pubsub.On("Publish", context.Background(), outputChannel, mock.Anything).Return(nil)
+			m.pubsub.On("Publish", context.Background(), inputChannel, mock.MatchedBy(func(msg *messaging.Message) bool {
+				var rep alarms.Report
+				if err := json.Unmarshal(msg.GetPayload(), &rep); err != nil {
+					return false
+				}
+				return msg.GetSubtopic() == alarms.Subtopic && rep.RuleID == tc.rule.ID && rep.Value == "35" && rep.Threshold == "30" && rep.Severity == 3
+			})).Return(nil)
+			m.notifier.On("Notify", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			m.sender.On("Send", context.Background(), mock.Anything, mock.Anything, mock.Anything).Return(tc.sendCode, tc.sendErr)
+			var err error
+			for _, msg := range tc.msgs {
+				err = svc.Evaluate(context.Background(), msg)
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			m.pubsub.AssertNumberOfCalls(t, "Publish", tc.publish)
+			m.notifier.AssertNumberOfCalls(t, "Notify", tc.notify)
+			m.sender.AssertNumberOfCalls(t, "Send", tc.webhook)
+		})
+	}
+}
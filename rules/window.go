@@ -0,0 +1,70 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"sync"
+	"time"
+)
+
+type sample struct {
+	at    time.Time
+	value float64
+}
+
+type windowKey struct {
+	ruleID    string
+	condition int
+}
+
+// windows keeps the values of windowed rule conditions.
+type windows struct {
+	mu      sync.Mutex
+	samples map[windowKey][]sample
+}
+
+func newWindows() *windows {
+	return &windows{samples: make(map[windowKey][]sample)}
+}
+
+// add appends the value to the window of the rule condition, evicts the
+// values which fell out of the window and returns the remaining values.
+func (ws *windows) add(ruleID string, condition int, w Window, at time.Time, value float64) []float64 {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	key := windowKey{ruleID: ruleID, condition: condition}
+	samples := append(ws.samples[key], sample{at: at, value: value})
+	if d := w.duration(); d > 0 {
+		from := at.Add(-d)
+		i := 0
+		for i < len(samples) && samples[i].at.Before(from) {
+			i++
+		}
+		samples = samples[i:]
+	}
+	if w.Size > 0 && uint64(len(samples)) > w.Size {
+		samples = samples[uint64(len(samples))-w.Size:]
+	}
+	ws.samples[key] = samples
+
+	values := make([]float64, len(samples))
+	for i, s := range samples {
+		values[i] = s.value
+	}
+
+	return values
+}
+
+// reset drops the windows of all the rule conditions.
+func (ws *windows) reset(ruleID string) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	for key := range ws.samples {
+		if key.ruleID == ruleID {
+			delete(ws.samples, key)
+		}
+	}
+}
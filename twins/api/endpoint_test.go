@@ -0,0 +1,669 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/twins"
+	"github.com/absmach/supermq/twins/api"
+	"github.com/absmach/supermq/twins/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	validToken   = "valid"
+	invalidToken = "invalid"
+	contentType  = "application/json"
+	instanceID   = "5de9b29a-feb9-11ed-be56-0242ac120002"
+)
+
+var (
+	validID   = testsutil.GenerateUUID(&testing.T{})
+	channelID = testsutil.GenerateUUID(&testing.T{})
+	session   = smqauthn.Session{UserID: validID}
+	twin      = twins.Twin{
+		Owner:    validID,
+		ID:       testsutil.GenerateUUID(&testing.T{}),
+		Name:     "twin",
+		Metadata: twins.Metadata{"location": "lab"},
+	}
+	twinData = fmt.Sprintf(`{"name": "twin", "definition": {"attributes": [{"name": "temperature", "channel": "%s", "subtopic": ">", "persist_state": true}]}}`, channelID)
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	token       string
+	contentType string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+func newTwinsServer() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	mux := api.MakeHandler(svc, authn, smqlog.NewMock(), "twins", instanceID)
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func TestAddTwin(t *testing.T) {
+	ts, svc, authn := newTwinsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		authnRes    smqauthn.Session
+		authnErr    error
+		svcRes      twins.Twin
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "add twin successfully",
+			token:       validToken,
+			data:        twinData,
+			contentType: contentType,
+			authnRes:    session,
+			svcRes:      twin,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "add twin without definition",
+			token:       validToken,
+			data:        `{"name": "twin"}`,
+			contentType: contentType,
+			authnRes:    session,
+			svcRes:      twin,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "add twin with invalid token",
+			token:       invalidToken,
+			data:        twinData,
+			contentType: contentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "add twin with empty token",
+			data:        twinData,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "add twin with too long name",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"name": "%s"}`, strings.Repeat("a", 1025)),
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add twin with attribute without channel",
+			token:       validToken,
+			data:        `{"definition": {"attributes": [{"name": "temperature"}]}}`,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add twin with duplicate attribute names",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"definition": {"attributes": [{"name": "temperature", "channel": "%s"}, {"name": "temperature", "channel": "%s"}]}}`, channelID, channelID),
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add twin with negative delta",
+			token:       validToken,
+			data:        `{"definition": {"delta": -1}}`,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add twin with invalid content type",
+			token:       validToken,
+			data:        twinData,
+			contentType: "text/plain",
+			authnRes:    session,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "add twin with malformed body",
+			token:       validToken,
+			data:        `{"name": `,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add twin with service error",
+			token:       validToken,
+			data:        twinData,
+			contentType: contentType,
+			authnRes:    session,
+			svcErr:      svcerr.ErrCreateEntity,
+			status:      http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("AddTwin", mock.Anything, tc.authnRes, mock.Anything, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/twins/", ts.URL),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusCreated {
+				location := fmt.Sprintf("/twins/%s", tc.svcRes.ID)
+				assert.Equal(t, location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, location, res.Header.Get("Location")))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestViewTwin(t *testing.T) {
+	ts, svc, authn := newTwinsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnRes smqauthn.Session
+		authnErr error
+		svcRes   twins.Twin
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "view twin successfully",
+			token:    validToken,
+			id:       twin.ID,
+			authnRes: session,
+			svcRes:   twin,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "view twin with invalid token",
+			token:    invalidToken,
+			id:       twin.ID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "view non-existing twin",
+			token:    validToken,
+			id:       validID,
+			authnRes: session,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+		{
+			desc:     "view twin of another user",
+			token:    validToken,
+			id:       twin.ID,
+			authnRes: session,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ViewTwin", mock.Anything, tc.authnRes, tc.id).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/twins/%s", ts.URL, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody twins.Twin
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.ID, resBody.ID, fmt.Sprintf("%s: expected twin %s got %s", tc.desc, tc.svcRes.ID, resBody.ID))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListTwins(t *testing.T) {
+	ts, svc, authn := newTwinsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		authnRes smqauthn.Session
+		authnErr error
+		pm       twins.PageMeta
+		svcRes   twins.TwinsPage
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list twins successfully",
+			token:    validToken,
+			authnRes: session,
+			pm:       twins.PageMeta{Limit: 10},
+			svcRes:   twins.TwinsPage{Total: 1, Limit: 10, Twins: []twins.Twin{twin}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list twins by name",
+			token:    validToken,
+			query:    "name=twin",
+			authnRes: session,
+			pm:       twins.PageMeta{Limit: 10, Name: "twin"},
+			svcRes:   twins.TwinsPage{Total: 1, Limit: 10, Twins: []twins.Twin{twin}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list twins by metadata",
+			token:    validToken,
+			query:    "metadata=%7B%22location%22%3A%22lab%22%7D",
+			authnRes: session,
+			pm:       twins.PageMeta{Limit: 10, Metadata: twins.Metadata{"location": "lab"}},
+			svcRes:   twins.TwinsPage{Total: 1, Limit: 10, Twins: []twins.Twin{twin}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list twins with offset and limit",
+			token:    validToken,
+			query:    "offset=1&limit=5",
+			authnRes: session,
+			pm:       twins.PageMeta{Offset: 1, Limit: 5},
+			svcRes:   twins.TwinsPage{Total: 1, Offset: 1, Limit: 5},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list twins with invalid token",
+			token:    invalidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list twins with invalid metadata",
+			token:    validToken,
+			query:    "metadata=invalid",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list twins with too long name",
+			token:    validToken,
+			query:    "name=" + strings.Repeat("a", 1025),
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list twins with limit exceeding maximum",
+			token:    validToken,
+			query:    "limit=1000",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list twins with service error",
+			token:    validToken,
+			authnRes: session,
+			pm:       twins.PageMeta{Limit: 10},
+			svcErr:   svcerr.ErrViewEntity,
+			status:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ListTwins", mock.Anything, tc.authnRes, tc.pm).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/twins/?%s", ts.URL, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody twins.TwinsPage
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.Total, resBody.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.svcRes.Total, resBody.Total))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestUpdateTwin(t *testing.T) {
+	ts, svc, authn := newTwinsServer()
+	defer ts.Close()
+
+	updated := twin
+	updated.Name = "updated"
+	updated.Revision = 1
+
+	cases := []struct {
+		desc        string
+		token       string
+		id          string
+		data        string
+		contentType string
+		authnRes    smqauthn.Session
+		authnErr    error
+		svcRes      twins.Twin
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "update twin successfully",
+			token:       validToken,
+			id:          twin.ID,
+			data:        twinData,
+			contentType: contentType,
+			authnRes:    session,
+			svcRes:      updated,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update twin with invalid token",
+			token:       invalidToken,
+			id:          twin.ID,
+			data:        twinData,
+			contentType: contentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "update twin with invalid definition",
+			token:       validToken,
+			id:          twin.ID,
+			data:        `{"definition": {"attributes": [{"channel": "channel"}]}}`,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update twin with invalid content type",
+			token:       validToken,
+			id:          twin.ID,
+			data:        twinData,
+			contentType: "text/plain",
+			authnRes:    session,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "update twin with malformed body",
+			token:       validToken,
+			id:          twin.ID,
+			data:        `{"name": `,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update non-existing twin",
+			token:       validToken,
+			id:          validID,
+			data:        twinData,
+			contentType: contentType,
+			authnRes:    session,
+			svcErr:      svcerr.ErrNotFound,
+			status:      http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("UpdateTwin", mock.Anything, tc.authnRes, mock.Anything, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPut,
+				url:         fmt.Sprintf("%s/twins/%s", ts.URL, tc.id),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody twins.Twin
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes.Revision, resBody.Revision, fmt.Sprintf("%s: expected revision %d got %d", tc.desc, tc.svcRes.Revision, resBody.Revision))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestRemoveTwin(t *testing.T) {
+	ts, svc, authn := newTwinsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnRes smqauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "remove twin successfully",
+			token:    validToken,
+			id:       twin.ID,
+			authnRes: session,
+			status:   http.StatusNoContent,
+		},
+		{
+			desc:     "remove twin with invalid token",
+			token:    invalidToken,
+			id:       twin.ID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "remove non-existing twin",
+			token:    validToken,
+			id:       validID,
+			authnRes: session,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+		{
+			desc:     "remove twin of another user",
+			token:    validToken,
+			id:       twin.ID,
+			authnRes: session,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("RemoveTwin", mock.Anything, tc.authnRes, tc.id).Return(tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/twins/%s", ts.URL, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListStates(t *testing.T) {
+	ts, svc, authn := newTwinsServer()
+	defer ts.Close()
+
+	states := twins.StatesPage{
+		Total: 1,
+		Limit: 10,
+		States: []twins.State{
+			{TwinID: twin.ID, Payload: map[string]interface{}{"temperature": 21.5}},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		query    string
+		offset   uint64
+		limit    uint64
+		authnRes smqauthn.Session
+		authnErr error
+		svcRes   twins.StatesPage
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list states successfully",
+			token:    validToken,
+			id:       twin.ID,
+			limit:    10,
+			authnRes: session,
+			svcRes:   states,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list states with offset and limit",
+			token:    validToken,
+			id:       twin.ID,
+			query:    "offset=1&limit=5",
+			offset:   1,
+			limit:    5,
+			authnRes: session,
+			svcRes:   twins.StatesPage{Total: 1, Offset: 1, Limit: 5},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list states with invalid token",
+			token:    invalidToken,
+			id:       twin.ID,
+			limit:    10,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list states with invalid offset",
+			token:    validToken,
+			id:       twin.ID,
+			query:    "offset=invalid",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list states with limit exceeding maximum",
+			token:    validToken,
+			id:       twin.ID,
+			query:    "limit=1000",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list states of non-existing twin",
+			token:    validToken,
+			id:       validID,
+			limit:    10,
+			authnRes: session,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ListStates", mock.Anything, tc.authnRes, tc.id, tc.offset, tc.limit).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/states/%s?%s", ts.URL, tc.id, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resBody twins.StatesPage
+				err := json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+				assert.Equal(t, len(tc.svcRes.States), len(resBody.States), fmt.Sprintf("%s: expected %d states got %d", tc.desc, len(tc.svcRes.States), len(resBody.States)))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/twins"
+)
+
+type twinReq struct {
+	Name       string            `json:"name,omitempty"`
+	Metadata   twins.Metadata    `json:"metadata,omitempty"`
+	Definition *twins.Definition `json:"definition,omitempty"`
+}
+
+func (req twinReq) validate() error {
+	if len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	if req.Definition != nil {
+		if err := req.Definition.Validate(); err != nil {
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (req twinReq) twin() twins.Twin {
+	return twins.Twin{
+		Name:     req.Name,
+		Metadata: req.Metadata,
+	}
+}
+
+type addTwinReq struct {
+	twinReq
+}
+
+func (req addTwinReq) validate() error {
+	return req.twinReq.validate()
+}
+
+func (req addTwinReq) definition() twins.Definition {
+	if req.Definition == nil {
+		return twins.Definition{}
+	}
+
+	return *req.Definition
+}
+
+type updateTwinReq struct {
+	id string
+	twinReq
+}
+
+func (req updateTwinReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return req.twinReq.validate()
+}
+
+type viewTwinReq struct {
+	id string
+}
+
+func (req viewTwinReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listTwinsReq struct {
+	pm twins.PageMeta
+}
+
+func (req listTwinsReq) validate() error {
+	if req.pm.Limit > api.MaxLimitSize || req.pm.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+	if len(req.pm.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
+type listStatesReq struct {
+	twinID string
+	offset uint64
+	limit  uint64
+}
+
+func (req listStatesReq) validate() error {
+	if req.twinID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.limit > api.MaxLimitSize || req.limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/twins"
+)
+
+var (
+	_ supermq.Response = (*twinRes)(nil)
+	_ supermq.Response = (*twinsPageRes)(nil)
+	_ supermq.Response = (*statesPageRes)(nil)
+	_ supermq.Response = (*removeTwinRes)(nil)
+)
+
+type twinRes struct {
+	twins.Twin `json:",inline"`
+	created    bool
+}
+
+func (res twinRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res twinRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/twins/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res twinRes) Empty() bool {
+	return false
+}
+
+type twinsPageRes struct {
+	twins.TwinsPage `json:",inline"`
+}
+
+func (res twinsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res twinsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res twinsPageRes) Empty() bool {
+	return false
+}
+
+type statesPageRes struct {
+	twins.StatesPage `json:",inline"`
+}
+
+func (res statesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res statesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res statesPageRes) Empty() bool {
+	return false
+}
+
+type removeTwinRes struct{}
+
+func (res removeTwinRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeTwinRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeTwinRes) Empty() bool {
+	return true
+}
@@ -0,0 +1,160 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/supermq"
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/twins"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// MakeHandler returns a HTTP API handler with health check and metrics.
+func MakeHandler(svc twins.Service, authn smqauthn.Authentication, logger *slog.Logger, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Route("/twins", func(r chi.Router) {
+		r.Use(api.AuthenticateMiddleware(authn, false))
+
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			addTwinEndpoint(svc),
+			decodeAddTwinReq,
+			api.EncodeResponse,
+			opts...,
+		), "add_twin").ServeHTTP)
+
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listTwinsEndpoint(svc),
+			decodeListTwinsReq,
+			api.EncodeResponse,
+			opts...,
+		), "list_twins").ServeHTTP)
+
+		r.Route("/{twinID}", func(r chi.Router) {
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				viewTwinEndpoint(svc),
+				decodeViewTwinReq,
+				api.EncodeResponse,
+				opts...,
+			), "view_twin").ServeHTTP)
+
+			r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+				updateTwinEndpoint(svc),
+				decodeUpdateTwinReq,
+				api.EncodeResponse,
+				opts...,
+			), "update_twin").ServeHTTP)
+
+			r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+				removeTwinEndpoint(svc),
+				decodeViewTwinReq,
+				api.EncodeResponse,
+				opts...,
+			), "remove_twin").ServeHTTP)
+		})
+	})
+
+	mux.With(api.AuthenticateMiddleware(authn, false)).Get("/states/{twinID}", otelhttp.NewHandler(kithttp.NewServer(
+		listStatesEndpoint(svc),
+		decodeListStatesReq,
+		api.EncodeResponse,
+		opts...,
+	), "list_states").ServeHTTP)
+
+	mux.Get("/health", supermq.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeAddTwinReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := addTwinReq{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeViewTwinReq(_ context.Context, r *http.Request) (interface{}, error) {
+	return viewTwinReq{id: chi.URLParam(r, "twinID")}, nil
+}
+
+func decodeListTwinsReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	name, err := apiutil.ReadStringQuery(r, api.NameKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	metadata, err := apiutil.ReadMetadataQuery(r, api.MetadataKey, nil)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listTwinsReq{
+		pm: twins.PageMeta{
+			Offset:   offset,
+			Limit:    limit,
+			Name:     name,
+			Metadata: metadata,
+		},
+	}, nil
+}
+
+func decodeUpdateTwinReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := updateTwinReq{id: chi.URLParam(r, "twinID")}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeListStatesReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listStatesReq{
+		twinID: chi.URLParam(r, "twinID"),
+		offset: offset,
+		limit:  limit,
+	}, nil
+}
@@ -0,0 +1,8 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package twins contains the digital twins service.
+// This service keeps the definitions of user owned digital twins and
+// computes the twin states from the SenML messages published to the
+// channels the twin attributes are bound to.
+package twins
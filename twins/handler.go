@@ -0,0 +1,35 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/messaging/brokers"
+)
+
+var _ messaging.MessageHandler = (*handler)(nil)
+
+type handler struct {
+	ctx context.Context
+	svc Service
+}
+
+// Subscribe subscribes the service to the messages published to all channels.
+func Subscribe(ctx context.Context, id string, sub messaging.Subscriber, svc Service) error {
+	return sub.Subscribe(ctx, messaging.SubscriberConfig{
+		ID:      id,
+		Topic:   brokers.SubjectAllChannels,
+		Handler: &handler{ctx: ctx, svc: svc},
+	})
+}
+
+func (h *handler) Handle(msg *messaging.Message) error {
+	return h.svc.SaveStates(h.ctx, msg)
+}
+
+func (h *handler) Cancel() error {
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/twins"
+)
+
+var (
+	_ twins.Service = (*authorizationMiddleware)(nil)
+
+	subscribePermission = "subscribe_permission"
+)
+
+type authorizationMiddleware struct {
+	svc      twins.Service
+	authz    smqauthz.Authorization
+	channels grpcChannelsV1.ChannelsServiceClient
+}
+
+// AuthorizationMiddleware adds authorization to the twins service.
+// Twins are owned by the users who created them, so only the twin
+// attributes are authorized: binding an attribute to a channel requires
+// subscribe permission on that channel in the channel domain.
+func AuthorizationMiddleware(svc twins.Service, authz smqauthz.Authorization, channels grpcChannelsV1.ChannelsServiceClient) twins.Service {
+	return &authorizationMiddleware{
+		svc:      svc,
+		authz:    authz,
+		channels: channels,
+	}
+}
+
+func (am *authorizationMiddleware) AddTwin(ctx context.Context, session smqauthn.Session, tw twins.Twin, def twins.Definition) (twins.Twin, error) {
+	if err := am.authorizeDefinition(ctx, session, def); err != nil {
+		return twins.Twin{}, err
+	}
+
+	return am.svc.AddTwin(ctx, session, tw, def)
+}
+
+func (am *authorizationMiddleware) UpdateTwin(ctx context.Context, session smqauthn.Session, tw twins.Twin, def *twins.Definition) (twins.Twin, error) {
+	if def != nil {
+		if err := am.authorizeDefinition(ctx, session, *def); err != nil {
+			return twins.Twin{}, err
+		}
+	}
+
+	return am.svc.UpdateTwin(ctx, session, tw, def)
+}
+
+func (am *authorizationMiddleware) ViewTwin(ctx context.Context, session smqauthn.Session, id string) (twins.Twin, error) {
+	return am.svc.ViewTwin(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListTwins(ctx context.Context, session smqauthn.Session, pm twins.PageMeta) (twins.TwinsPage, error) {
+	return am.svc.ListTwins(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) RemoveTwin(ctx context.Context, session smqauthn.Session, id string) error {
+	return am.svc.RemoveTwin(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListStates(ctx context.Context, session smqauthn.Session, twinID string, offset, limit uint64) (twins.StatesPage, error) {
+	return am.svc.ListStates(ctx, session, twinID, offset, limit)
+}
+
+func (am *authorizationMiddleware) SaveStates(ctx context.Context, msg *messaging.Message) error {
+	return am.svc.SaveStates(ctx, msg)
+}
+
+func (am *authorizationMiddleware) authorizeDefinition(ctx context.Context, session smqauthn.Session, def twins.Definition) error {
+	authorized := make(map[string]bool)
+	for _, a := range def.Attributes {
+		if authorized[a.Channel] {
+			continue
+		}
+		if err := am.authorizeChannel(ctx, session, a.Channel); err != nil {
+			return err
+		}
+		authorized[a.Channel] = true
+	}
+
+	return nil
+}
+
+func (am *authorizationMiddleware) authorizeChannel(ctx context.Context, session smqauthn.Session, channelID string) error {
+	// Twins don't belong to a domain, so the domain is resolved
+	// from the channel the attribute is bound to.
+	res, err := am.channels.RetrieveEntity(ctx, &grpcCommonV1.RetrieveEntityReq{Id: channelID})
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	domainID := res.GetEntity().GetDomainId()
+
+	return am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      domainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     policies.EncodeDomainUserID(domainID, session.UserID),
+		Permission:  subscribePermission,
+		ObjectType:  policies.ChannelType,
+		Object:      channelID,
+	})
+}
@@ -0,0 +1,174 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/twins"
+)
+
+var _ twins.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    twins.Service
+}
+
+// LoggingMiddleware adds logging facilities to the twins service.
+func LoggingMiddleware(svc twins.Service, logger *slog.Logger) twins.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm *loggingMiddleware) AddTwin(ctx context.Context, session smqauthn.Session, tw twins.Twin, def twins.Definition) (res twins.Twin, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", session.UserID),
+			slog.Group("twin",
+				slog.String("id", res.ID),
+				slog.String("name", tw.Name),
+				slog.Int("attributes", len(def.Attributes)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Add twin failed", args...)
+			return
+		}
+		lm.logger.Info("Add twin completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.AddTwin(ctx, session, tw, def)
+}
+
+func (lm *loggingMiddleware) UpdateTwin(ctx context.Context, session smqauthn.Session, tw twins.Twin, def *twins.Definition) (res twins.Twin, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", session.UserID),
+			slog.Group("twin",
+				slog.String("id", tw.ID),
+				slog.String("name", tw.Name),
+				slog.Int("revision", res.Revision),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update twin failed", args...)
+			return
+		}
+		lm.logger.Info("Update twin completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UpdateTwin(ctx, session, tw, def)
+}
+
+func (lm *loggingMiddleware) ViewTwin(ctx context.Context, session smqauthn.Session, id string) (res twins.Twin, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", session.UserID),
+			slog.String("twin_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View twin failed", args...)
+			return
+		}
+		lm.logger.Info("View twin completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewTwin(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListTwins(ctx context.Context, session smqauthn.Session, pm twins.PageMeta) (res twins.TwinsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", session.UserID),
+			slog.Group("page",
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", res.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List twins failed", args...)
+			return
+		}
+		lm.logger.Info("List twins completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListTwins(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) RemoveTwin(ctx context.Context, session smqauthn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", session.UserID),
+			slog.String("twin_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove twin failed", args...)
+			return
+		}
+		lm.logger.Info("Remove twin completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveTwin(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListStates(ctx context.Context, session smqauthn.Session, twinID string, offset, limit uint64) (res twins.StatesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", session.UserID),
+			slog.String("twin_id", twinID),
+			slog.Group("page",
+				slog.Uint64("offset", offset),
+				slog.Uint64("limit", limit),
+				slog.Uint64("total", res.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List states failed", args...)
+			return
+		}
+		lm.logger.Info("List states completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListStates(ctx, session, twinID, offset, limit)
+}
+
+// SaveStates logs successful state updates at debug level since
+// it is called for every message published to the broker.
+func (lm *loggingMiddleware) SaveStates(ctx context.Context, msg *messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", msg.GetChannel()),
+			slog.String("subtopic", msg.GetSubtopic()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Save states failed", args...)
+			return
+		}
+		lm.logger.Debug("Save states completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.SaveStates(ctx, msg)
+}
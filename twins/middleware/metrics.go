@@ -0,0 +1,94 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/twins"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ twins.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     twins.Service
+}
+
+// MetricsMiddleware instruments the twins service by tracking request count and latency.
+func MetricsMiddleware(svc twins.Service, counter metrics.Counter, latency metrics.Histogram) twins.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) AddTwin(ctx context.Context, session smqauthn.Session, tw twins.Twin, def twins.Definition) (twins.Twin, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "add_twin").Add(1)
+		mm.latency.With("method", "add_twin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.AddTwin(ctx, session, tw, def)
+}
+
+func (mm *metricsMiddleware) UpdateTwin(ctx context.Context, session smqauthn.Session, tw twins.Twin, def *twins.Definition) (twins.Twin, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_twin").Add(1)
+		mm.latency.With("method", "update_twin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UpdateTwin(ctx, session, tw, def)
+}
+
+func (mm *metricsMiddleware) ViewTwin(ctx context.Context, session smqauthn.Session, id string) (twins.Twin, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_twin").Add(1)
+		mm.latency.With("method", "view_twin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewTwin(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListTwins(ctx context.Context, session smqauthn.Session, pm twins.PageMeta) (twins.TwinsPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_twins").Add(1)
+		mm.latency.With("method", "list_twins").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListTwins(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) RemoveTwin(ctx context.Context, session smqauthn.Session, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_twin").Add(1)
+		mm.latency.With("method", "remove_twin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveTwin(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListStates(ctx context.Context, session smqauthn.Session, twinID string, offset, limit uint64) (twins.StatesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_states").Add(1)
+		mm.latency.With("method", "list_states").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListStates(ctx, session, twinID, offset, limit)
+}
+
+func (mm *metricsMiddleware) SaveStates(ctx context.Context, msg *messaging.Message) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "save_states").Add(1)
+		mm.latency.With("method", "save_states").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SaveStates(ctx, msg)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/twins"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ twins.Service = (*tracing)(nil)
+
+type tracing struct {
+	tracer trace.Tracer
+	svc    twins.Service
+}
+
+// Tracing adds tracing to the twins service.
+func Tracing(svc twins.Service, tracer trace.Tracer) twins.Service {
+	return &tracing{tracer, svc}
+}
+
+func (tm *tracing) AddTwin(ctx context.Context, session smqauthn.Session, tw twins.Twin, def twins.Definition) (twins.Twin, error) {
+	ctx, span := tm.tracer.Start(ctx, "add_twin", trace.WithAttributes(
+		attribute.String("user_id", session.UserID),
+		attribute.String("name", tw.Name),
+	))
+	defer span.End()
+
+	return tm.svc.AddTwin(ctx, session, tw, def)
+}
+
+func (tm *tracing) UpdateTwin(ctx context.Context, session smqauthn.Session, tw twins.Twin, def *twins.Definition) (twins.Twin, error) {
+	ctx, span := tm.tracer.Start(ctx, "update_twin", trace.WithAttributes(
+		attribute.String("user_id", session.UserID),
+		attribute.String("twin_id", tw.ID),
+	))
+	defer span.End()
+
+	return tm.svc.UpdateTwin(ctx, session, tw, def)
+}
+
+func (tm *tracing) ViewTwin(ctx context.Context, session smqauthn.Session, id string) (twins.Twin, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_twin", trace.WithAttributes(
+		attribute.String("user_id", session.UserID),
+		attribute.String("twin_id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewTwin(ctx, session, id)
+}
+
+func (tm *tracing) ListTwins(ctx context.Context, session smqauthn.Session, pm twins.PageMeta) (twins.TwinsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_twins", trace.WithAttributes(
+		attribute.String("user_id", session.UserID),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListTwins(ctx, session, pm)
+}
+
+func (tm *tracing) RemoveTwin(ctx context.Context, session smqauthn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "remove_twin", trace.WithAttributes(
+		attribute.String("user_id", session.UserID),
+		attribute.String("twin_id", id),
+	))
+	defer span.End()
+
+	return tm.svc.RemoveTwin(ctx, session, id)
+}
+
+func (tm *tracing) ListStates(ctx context.Context, session smqauthn.Session, twinID string, offset, limit uint64) (twins.StatesPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_states", trace.WithAttributes(
+		attribute.String("user_id", session.UserID),
+		attribute.String("twin_id", twinID),
+		attribute.Int64("offset", int64(offset)),
+		attribute.Int64("limit", int64(limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListStates(ctx, session, twinID, offset, limit)
+}
+
+func (tm *tracing) SaveStates(ctx context.Context, msg *messaging.Message) error {
+	ctx, span := tm.tracer.Start(ctx, "save_states", trace.WithAttributes(
+		attribute.String("channel_id", msg.GetChannel()),
+		attribute.String("subtopic", msg.GetSubtopic()),
+	))
+	defer span.End()
+
+	return tm.svc.SaveStates(ctx, msg)
+}
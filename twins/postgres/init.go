@@ -0,0 +1,44 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "twins_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS twins (
+						id          VARCHAR(36) PRIMARY KEY,
+						owner       VARCHAR(36) NOT NULL,
+						name        VARCHAR(1024),
+						revision    INTEGER NOT NULL DEFAULT 0,
+						definitions JSONB NOT NULL,
+						metadata    JSONB,
+						created     TIMESTAMP NOT NULL,
+						updated     TIMESTAMP NOT NULL
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_twins_owner ON twins(owner);`,
+					`CREATE TABLE IF NOT EXISTS states (
+						twin_id    VARCHAR(36) NOT NULL REFERENCES twins(id) ON DELETE CASCADE,
+						id         BIGINT NOT NULL,
+						definition INTEGER NOT NULL,
+						created    TIMESTAMP NOT NULL,
+						payload    JSONB NOT NULL,
+						PRIMARY KEY (twin_id, id)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS states`,
+					`DROP TABLE IF EXISTS twins`,
+				},
+			},
+		},
+	}
+}
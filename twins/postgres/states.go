@@ -0,0 +1,157 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/absmach/supermq/twins"
+)
+
+const stateColumns = `twin_id, id, definition, created, payload`
+
+type stateRepository struct {
+	db postgres.Database
+}
+
+// NewStateRepository instantiates a PostgreSQL implementation of states repository.
+func NewStateRepository(db postgres.Database) twins.StateRepository {
+	return &stateRepository{db: db}
+}
+
+func (repo *stateRepository) Save(ctx context.Context, st twins.State) error {
+	q := `INSERT INTO states (twin_id, id, definition, created, payload)
+		VALUES (:twin_id, :id, :definition, :created, :payload)`
+
+	dbs, err := toDBState(st)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbs); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *stateRepository) Update(ctx context.Context, st twins.State) error {
+	q := `UPDATE states SET payload = :payload WHERE twin_id = :twin_id AND id = :id`
+
+	dbs, err := toDBState(st)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	result, err := repo.db.NamedExecContext(ctx, q, dbs)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *stateRepository) RetrieveAll(ctx context.Context, twinID string, offset, limit uint64) (twins.StatesPage, error) {
+	q := `SELECT ` + stateColumns + ` FROM states WHERE twin_id = :twin_id ORDER BY id LIMIT :limit OFFSET :offset`
+
+	params := map[string]interface{}{
+		"twin_id": twinID,
+		"limit":   limit,
+		"offset":  offset,
+	}
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return twins.StatesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []twins.State
+	for rows.Next() {
+		var dbs dbState
+		if err := rows.StructScan(&dbs); err != nil {
+			return twins.StatesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		st, err := toState(dbs)
+		if err != nil {
+			return twins.StatesPage{}, err
+		}
+		items = append(items, st)
+	}
+
+	tq := `SELECT COUNT(*) FROM states WHERE twin_id = :twin_id`
+	total, err := postgres.Total(ctx, repo.db, tq, params)
+	if err != nil {
+		return twins.StatesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return twins.StatesPage{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+		States: items,
+	}, nil
+}
+
+func (repo *stateRepository) RetrieveLast(ctx context.Context, twinID string) (twins.State, error) {
+	q := `SELECT ` + stateColumns + ` FROM states WHERE twin_id = :twin_id ORDER BY id DESC LIMIT 1`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbState{TwinID: twinID})
+	if err != nil {
+		return twins.State{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return twins.State{}, repoerr.ErrNotFound
+	}
+	var dbs dbState
+	if err := rows.StructScan(&dbs); err != nil {
+		return twins.State{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toState(dbs)
+}
+
+type dbState struct {
+	TwinID     string    `db:"twin_id"`
+	ID         int64     `db:"id"`
+	Definition int       `db:"definition"`
+	Created    time.Time `db:"created"`
+	Payload    []byte    `db:"payload"`
+}
+
+func toDBState(st twins.State) (dbState, error) {
+	payload, err := json.Marshal(st.Payload)
+	if err != nil {
+		return dbState{}, err
+	}
+
+	return dbState{
+		TwinID:     st.TwinID,
+		ID:         st.ID,
+		Definition: st.Definition,
+		Created:    st.Created,
+		Payload:    payload,
+	}, nil
+}
+
+func toState(dbs dbState) (twins.State, error) {
+	st := twins.State{
+		TwinID:     dbs.TwinID,
+		ID:         dbs.ID,
+		Definition: dbs.Definition,
+		Created:    dbs.Created.UTC(),
+	}
+	if err := json.Unmarshal(dbs.Payload, &st.Payload); err != nil {
+		return twins.State{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+
+	return st, nil
+}
@@ -0,0 +1,211 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/twins"
+	"github.com/absmach/supermq/twins/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func saveTwin(t *testing.T) twins.Twin {
+	tw, err := postgres.NewTwinRepository(database).Save(context.Background(), newTwin(t, testsutil.GenerateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("save twin unexpected error: %s", err))
+
+	return tw
+}
+
+func newState(twinID string, id int64) twins.State {
+	return twins.State{
+		TwinID:  twinID,
+		ID:      id,
+		Created: time.Now().UTC().Truncate(time.Microsecond).Add(time.Duration(id) * time.Second),
+		Payload: map[string]interface{}{"temperature": 21.5, "unit": "C"},
+	}
+}
+
+func TestSaveState(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewStateRepository(database)
+
+	tw := saveTwin(t)
+
+	cases := []struct {
+		desc  string
+		state twins.State
+		err   error
+	}{
+		{
+			desc:  "save state successfully",
+			state: newState(tw.ID, 0),
+			err:   nil,
+		},
+		{
+			desc:  "save next state successfully",
+			state: newState(tw.ID, 1),
+			err:   nil,
+		},
+		{
+			desc:  "save state with existing ID",
+			state: newState(tw.ID, 1),
+			err:   repoerr.ErrConflict,
+		},
+		{
+			desc:  "save state of non-existing twin",
+			state: newState(testsutil.GenerateUUID(t), 0),
+			err:   repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Save(context.Background(), tc.state)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestUpdateState(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewStateRepository(database)
+
+	tw := saveTwin(t)
+	st := newState(tw.ID, 0)
+	err := repo.Save(context.Background(), st)
+	require.Nil(t, err, fmt.Sprintf("save state unexpected error: %s", err))
+
+	updated := st
+	updated.Payload = map[string]interface{}{"temperature": 22.5, "unit": "C", "humidity": 40.0}
+
+	cases := []struct {
+		desc  string
+		state twins.State
+		err   error
+	}{
+		{
+			desc:  "update state successfully",
+			state: updated,
+			err:   nil,
+		},
+		{
+			desc:  "update non-existing state",
+			state: newState(tw.ID, 1),
+			err:   repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Update(context.Background(), tc.state)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+
+	last, err := repo.RetrieveLast(context.Background(), tw.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve last state unexpected error: %s", err))
+	assert.Equal(t, updated, last, fmt.Sprintf("expected %v got %v\n", updated, last))
+}
+
+func TestRetrieveAllStates(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewStateRepository(database)
+
+	tw := saveTwin(t)
+	num := 10
+	var items []twins.State
+	for i := 0; i < num; i++ {
+		st := newState(tw.ID, int64(i))
+		err := repo.Save(context.Background(), st)
+		require.Nil(t, err, fmt.Sprintf("save state unexpected error: %s", err))
+		items = append(items, st)
+	}
+	other := saveTwin(t)
+	err := repo.Save(context.Background(), newState(other.ID, 0))
+	require.Nil(t, err, fmt.Sprintf("save state unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		twinID string
+		offset uint64
+		limit  uint64
+		resp   twins.StatesPage
+	}{
+		{
+			desc:   "retrieve states of twin",
+			twinID: tw.ID,
+			limit:  10,
+			resp:   twins.StatesPage{Total: uint64(num), Limit: 10, States: items},
+		},
+		{
+			desc:   "retrieve states of twin with offset and limit",
+			twinID: tw.ID,
+			offset: 2,
+			limit:  3,
+			resp:   twins.StatesPage{Total: uint64(num), Offset: 2, Limit: 3, States: items[2:5]},
+		},
+		{
+			desc:   "retrieve states of twin without states",
+			twinID: testsutil.GenerateUUID(t),
+			limit:  10,
+			resp:   twins.StatesPage{Limit: 10},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveAll(context.Background(), tc.twinID, tc.offset, tc.limit)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestRetrieveLastState(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewStateRepository(database)
+
+	tw := saveTwin(t)
+	var last twins.State
+	for i := 0; i < 3; i++ {
+		last = newState(tw.ID, int64(i))
+		err := repo.Save(context.Background(), last)
+		require.Nil(t, err, fmt.Sprintf("save state unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		twinID string
+		resp   twins.State
+		err    error
+	}{
+		{
+			desc:   "retrieve last state successfully",
+			twinID: tw.ID,
+			resp:   last,
+			err:    nil,
+		},
+		{
+			desc:   "retrieve last state of twin without states",
+			twinID: testsutil.GenerateUUID(t),
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveLast(context.Background(), tc.twinID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
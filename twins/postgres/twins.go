@@ -0,0 +1,265 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/absmach/supermq/twins"
+	"github.com/jmoiron/sqlx"
+)
+
+const twinColumns = `id, owner, name, revision, definitions, metadata, created, updated`
+
+type twinRepository struct {
+	db postgres.Database
+}
+
+// NewTwinRepository instantiates a PostgreSQL implementation of twins repository.
+func NewTwinRepository(db postgres.Database) twins.TwinRepository {
+	return &twinRepository{db: db}
+}
+
+func (repo *twinRepository) Save(ctx context.Context, tw twins.Twin) (twins.Twin, error) {
+	q := fmt.Sprintf(`INSERT INTO twins (%s)
+		VALUES (:id, :owner, :name, :revision, :definitions, :metadata, :created, :updated)
+		RETURNING %s`, twinColumns, twinColumns)
+
+	dbt, err := toDBTwin(tw)
+	if err != nil {
+		return twins.Twin{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return repo.namedQueryOne(ctx, q, dbt, repoerr.ErrCreateEntity)
+}
+
+func (repo *twinRepository) Update(ctx context.Context, tw twins.Twin) (twins.Twin, error) {
+	q := fmt.Sprintf(`UPDATE twins SET name = :name, revision = :revision, definitions = :definitions,
+		metadata = :metadata, updated = :updated
+		WHERE id = :id RETURNING %s`, twinColumns)
+
+	dbt, err := toDBTwin(tw)
+	if err != nil {
+		return twins.Twin{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return repo.namedQueryOne(ctx, q, dbt, repoerr.ErrUpdateEntity)
+}
+
+func (repo *twinRepository) RetrieveByID(ctx context.Context, id string) (twins.Twin, error) {
+	q := fmt.Sprintf(`SELECT %s FROM twins WHERE id = :id`, twinColumns)
+
+	return repo.namedQueryOne(ctx, q, dbTwin{ID: id}, repoerr.ErrViewEntity)
+}
+
+func (repo *twinRepository) RetrieveByAttribute(ctx context.Context, channel, subtopic string) ([]twins.Twin, error) {
+	// Only the attributes of the definition in use, which is the last one, are matched.
+	q := fmt.Sprintf(`SELECT %s FROM twins t WHERE EXISTS (
+		SELECT 1 FROM jsonb_array_elements(t.definitions->-1->'attributes') a
+		WHERE a->>'channel' = :channel AND COALESCE(a->>'subtopic', '') IN (:subtopic, :wildcard)
+	) ORDER BY created`, twinColumns)
+
+	params := map[string]interface{}{
+		"channel":  channel,
+		"subtopic": subtopic,
+		"wildcard": twins.SubtopicWildcard,
+	}
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	return scanTwins(rows)
+}
+
+func (repo *twinRepository) RetrieveAll(ctx context.Context, pm twins.PageMeta) (twins.TwinsPage, error) {
+	dbpm, err := toDBPageMeta(pm)
+	if err != nil {
+		return twins.TwinsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	query := twinsQuery(pm)
+	q := fmt.Sprintf(`SELECT %s FROM twins %s ORDER BY created LIMIT :limit OFFSET :offset`, twinColumns, query)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbpm)
+	if err != nil {
+		return twins.TwinsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	items, err := scanTwins(rows)
+	if err != nil {
+		return twins.TwinsPage{}, err
+	}
+
+	tq := fmt.Sprintf(`SELECT COUNT(*) FROM twins %s`, query)
+	total, err := postgres.Total(ctx, repo.db, tq, dbpm)
+	if err != nil {
+		return twins.TwinsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return twins.TwinsPage{
+		Total:  total,
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		Twins:  items,
+	}, nil
+}
+
+func (repo *twinRepository) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM twins WHERE id = $1`
+
+	result, err := repo.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *twinRepository) namedQueryOne(ctx context.Context, q string, arg interface{}, wrapper error) (twins.Twin, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, arg)
+	if err != nil {
+		return twins.Twin{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return twins.Twin{}, repoerr.ErrNotFound
+	}
+	var dbt dbTwin
+	if err := rows.StructScan(&dbt); err != nil {
+		return twins.Twin{}, postgres.HandleError(wrapper, err)
+	}
+
+	return toTwin(dbt)
+}
+
+func scanTwins(rows *sqlx.Rows) ([]twins.Twin, error) {
+	var items []twins.Twin
+	for rows.Next() {
+		var dbt dbTwin
+		if err := rows.StructScan(&dbt); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		tw, err := toTwin(dbt)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, tw)
+	}
+
+	return items, nil
+}
+
+func twinsQuery(pm twins.PageMeta) string {
+	var query []string
+	if pm.Owner != "" {
+		query = append(query, "owner = :owner")
+	}
+	if pm.Name != "" {
+		query = append(query, "name ILIKE '%' || :name || '%'")
+	}
+	if len(pm.Metadata) > 0 {
+		query = append(query, "metadata @> :metadata")
+	}
+	if len(query) > 0 {
+		return fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+	}
+
+	return ""
+}
+
+type dbPageMeta struct {
+	Offset   uint64 `db:"offset"`
+	Limit    uint64 `db:"limit"`
+	Owner    string `db:"owner"`
+	Name     string `db:"name"`
+	Metadata []byte `db:"metadata"`
+}
+
+func toDBPageMeta(pm twins.PageMeta) (dbPageMeta, error) {
+	dbpm := dbPageMeta{
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		Owner:  pm.Owner,
+		Name:   pm.Name,
+	}
+	if len(pm.Metadata) > 0 {
+		metadata, err := json.Marshal(pm.Metadata)
+		if err != nil {
+			return dbPageMeta{}, err
+		}
+		dbpm.Metadata = metadata
+	}
+
+	return dbpm, nil
+}
+
+type dbTwin struct {
+	ID          string         `db:"id"`
+	Owner       string         `db:"owner"`
+	Name        sql.NullString `db:"name"`
+	Revision    int            `db:"revision"`
+	Definitions []byte         `db:"definitions"`
+	Metadata    []byte         `db:"metadata"`
+	Created     time.Time      `db:"created"`
+	Updated     time.Time      `db:"updated"`
+}
+
+func toDBTwin(tw twins.Twin) (dbTwin, error) {
+	definitions, err := json.Marshal(tw.Definitions)
+	if err != nil {
+		return dbTwin{}, err
+	}
+	var metadata []byte
+	if len(tw.Metadata) > 0 {
+		if metadata, err = json.Marshal(tw.Metadata); err != nil {
+			return dbTwin{}, err
+		}
+	}
+
+	return dbTwin{
+		ID:          tw.ID,
+		Owner:       tw.Owner,
+		Name:        sql.NullString{String: tw.Name, Valid: tw.Name != ""},
+		Revision:    tw.Revision,
+		Definitions: definitions,
+		Metadata:    metadata,
+		Created:     tw.Created,
+		Updated:     tw.Updated,
+	}, nil
+}
+
+func toTwin(dbt dbTwin) (twins.Twin, error) {
+	tw := twins.Twin{
+		ID:       dbt.ID,
+		Owner:    dbt.Owner,
+		Name:     dbt.Name.String,
+		Revision: dbt.Revision,
+		Created:  dbt.Created.UTC(),
+		Updated:  dbt.Updated.UTC(),
+	}
+	if err := json.Unmarshal(dbt.Definitions, &tw.Definitions); err != nil {
+		return twins.Twin{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+	if len(dbt.Metadata) > 0 {
+		if err := json.Unmarshal(dbt.Metadata, &tw.Metadata); err != nil {
+			return twins.Twin{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+	}
+
+	return tw, nil
+}
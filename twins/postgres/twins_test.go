@@ -0,0 +1,368 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/twins"
+	"github.com/absmach/supermq/twins/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var invalidID = strings.Repeat("a", 37)
+
+func cleanUp(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM states")
+		require.Nil(t, err, fmt.Sprintf("clean states unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM twins")
+		require.Nil(t, err, fmt.Sprintf("clean twins unexpected error: %s", err))
+	})
+}
+
+func newTwin(t *testing.T, owner string, attrs ...twins.Attribute) twins.Twin {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	return twins.Twin{
+		ID:      testsutil.GenerateUUID(t),
+		Owner:   owner,
+		Name:    "twin",
+		Created: now,
+		Updated: now,
+		Definitions: []twins.Definition{
+			{Created: now, Attributes: attrs, Delta: time.Second},
+		},
+		Metadata: twins.Metadata{"location": "lab"},
+	}
+}
+
+func TestSaveTwin(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewTwinRepository(database)
+
+	tw := newTwin(t, testsutil.GenerateUUID(t))
+	noMetadata := newTwin(t, tw.Owner)
+	noMetadata.Metadata = nil
+
+	cases := []struct {
+		desc string
+		twin twins.Twin
+		err  error
+	}{
+		{
+			desc: "save twin successfully",
+			twin: tw,
+			err:  nil,
+		},
+		{
+			desc: "save twin without metadata successfully",
+			twin: noMetadata,
+			err:  nil,
+		},
+		{
+			desc: "save twin with existing ID",
+			twin: tw,
+			err:  repoerr.ErrConflict,
+		},
+		{
+			desc: "save twin with invalid owner",
+			twin: twins.Twin{
+				ID:      testsutil.GenerateUUID(t),
+				Owner:   invalidID,
+				Created: time.Now().UTC(),
+				Updated: time.Now().UTC(),
+			},
+			err: repoerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.Save(context.Background(), tc.twin)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.twin, saved, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.twin, saved))
+			}
+		})
+	}
+}
+
+func TestUpdateTwin(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewTwinRepository(database)
+
+	tw := newTwin(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), tw)
+	require.Nil(t, err, fmt.Sprintf("save twin unexpected error: %s", err))
+
+	updated := tw
+	updated.Name = "updated"
+	updated.Revision = 1
+	updated.Updated = tw.Updated.Add(time.Second)
+	updated.Metadata = twins.Metadata{"location": "field"}
+	updated.Definitions = append(tw.Definitions, twins.Definition{
+		ID:         1,
+		Created:    updated.Updated,
+		Attributes: []twins.Attribute{{Name: "temperature", Channel: testsutil.GenerateUUID(t), PersistState: true}},
+	})
+
+	cases := []struct {
+		desc string
+		twin twins.Twin
+		resp twins.Twin
+		err  error
+	}{
+		{
+			desc: "update twin successfully",
+			twin: updated,
+			resp: updated,
+			err:  nil,
+		},
+		{
+			desc: "update non-existing twin",
+			twin: twins.Twin{
+				ID:      testsutil.GenerateUUID(t),
+				Updated: time.Now().UTC(),
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.Update(context.Background(), tc.twin)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestRetrieveTwinByID(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewTwinRepository(database)
+
+	tw := newTwin(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), tw)
+	require.Nil(t, err, fmt.Sprintf("save twin unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		resp twins.Twin
+		err  error
+	}{
+		{
+			desc: "retrieve twin successfully",
+			id:   tw.ID,
+			resp: tw,
+			err:  nil,
+		},
+		{
+			desc: "retrieve non-existing twin",
+			id:   testsutil.GenerateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+		{
+			desc: "retrieve twin with empty ID",
+			id:   "",
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveByID(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestRetrieveTwinsByAttribute(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewTwinRepository(database)
+
+	owner := testsutil.GenerateUUID(t)
+	channel := testsutil.GenerateUUID(t)
+
+	exact := newTwin(t, owner, twins.Attribute{Name: "temperature", Channel: channel, Subtopic: "temperature"})
+	wildcard := newTwin(t, owner, twins.Attribute{Name: "humidity", Channel: channel, Subtopic: twins.SubtopicWildcard})
+	wildcard.Created = exact.Created.Add(time.Second)
+	noSubtopic := newTwin(t, owner, twins.Attribute{Name: "pressure", Channel: channel})
+	noSubtopic.Created = exact.Created.Add(2 * time.Second)
+	// Only the definition in use is matched, so the twin whose previous
+	// definition is bound to the channel is not retrieved.
+	redefined := newTwin(t, owner, twins.Attribute{Name: "temperature", Channel: testsutil.GenerateUUID(t)})
+	redefined.Definitions = append([]twins.Definition{
+		{Attributes: []twins.Attribute{{Name: "temperature", Channel: channel, Subtopic: twins.SubtopicWildcard}}},
+	}, redefined.Definitions...)
+	for _, tw := range []twins.Twin{exact, wildcard, noSubtopic, redefined} {
+		_, err := repo.Save(context.Background(), tw)
+		require.Nil(t, err, fmt.Sprintf("save twin unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc     string
+		channel  string
+		subtopic string
+		resp     []twins.Twin
+	}{
+		{
+			desc:     "retrieve twins by channel and subtopic",
+			channel:  channel,
+			subtopic: "temperature",
+			resp:     []twins.Twin{exact, wildcard},
+		},
+		{
+			desc:     "retrieve twins by channel without subtopic",
+			channel:  channel,
+			subtopic: "",
+			resp:     []twins.Twin{wildcard, noSubtopic},
+		},
+		{
+			desc:     "retrieve twins by channel and other subtopic",
+			channel:  channel,
+			subtopic: "other",
+			resp:     []twins.Twin{wildcard},
+		},
+		{
+			desc:     "retrieve twins by channel without twins",
+			channel:  testsutil.GenerateUUID(t),
+			subtopic: "temperature",
+			resp:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveByAttribute(context.Background(), tc.channel, tc.subtopic)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestRetrieveAllTwins(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewTwinRepository(database)
+
+	owner := testsutil.GenerateUUID(t)
+	num := 10
+	var items, named, labItems []twins.Twin
+	for i := 0; i < num; i++ {
+		tw := newTwin(t, owner)
+		tw.Created = tw.Created.Add(time.Duration(i) * time.Second)
+		tw.Name = fmt.Sprintf("twin-%d", i)
+		if i%2 == 0 {
+			tw.Name = fmt.Sprintf("pump-%d", i)
+		}
+		if i%3 == 0 {
+			tw.Metadata = twins.Metadata{"location": "field"}
+		}
+		saved, err := repo.Save(context.Background(), tw)
+		require.Nil(t, err, fmt.Sprintf("save twin unexpected error: %s", err))
+		items = append(items, saved)
+		if strings.HasPrefix(saved.Name, "pump") {
+			named = append(named, saved)
+		}
+		if saved.Metadata["location"] == "lab" {
+			labItems = append(labItems, saved)
+		}
+	}
+	_, err := repo.Save(context.Background(), newTwin(t, testsutil.GenerateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("save twin unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		pm   twins.PageMeta
+		resp twins.TwinsPage
+	}{
+		{
+			desc: "retrieve twins of owner",
+			pm:   twins.PageMeta{Owner: owner, Limit: 10},
+			resp: twins.TwinsPage{Total: uint64(num), Limit: 10, Twins: items},
+		},
+		{
+			desc: "retrieve twins of owner with offset and limit",
+			pm:   twins.PageMeta{Owner: owner, Offset: 2, Limit: 3},
+			resp: twins.TwinsPage{Total: uint64(num), Offset: 2, Limit: 3, Twins: items[2:5]},
+		},
+		{
+			desc: "retrieve twins of owner by partial name",
+			pm:   twins.PageMeta{Owner: owner, Name: "PUMP", Limit: 10},
+			resp: twins.TwinsPage{Total: uint64(len(named)), Limit: 10, Twins: named},
+		},
+		{
+			desc: "retrieve twins of owner by metadata",
+			pm:   twins.PageMeta{Owner: owner, Metadata: twins.Metadata{"location": "lab"}, Limit: 10},
+			resp: twins.TwinsPage{Total: uint64(len(labItems)), Limit: 10, Twins: labItems},
+		},
+		{
+			desc: "retrieve twins of owner without twins",
+			pm:   twins.PageMeta{Owner: testsutil.GenerateUUID(t), Limit: 10},
+			resp: twins.TwinsPage{Limit: 10},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+		})
+	}
+}
+
+func TestRemoveTwin(t *testing.T) {
+	cleanUp(t)
+	repo := postgres.NewTwinRepository(database)
+	stateRepo := postgres.NewStateRepository(database)
+
+	tw := newTwin(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), tw)
+	require.Nil(t, err, fmt.Sprintf("save twin unexpected error: %s", err))
+	err = stateRepo.Save(context.Background(), twins.State{
+		TwinID:  tw.ID,
+		Created: time.Now().UTC(),
+		Payload: map[string]interface{}{"temperature": 21.5},
+	})
+	require.Nil(t, err, fmt.Sprintf("save state unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove twin with states successfully",
+			id:   tw.ID,
+			err:  nil,
+		},
+		{
+			desc: "remove removed twin",
+			id:   tw.ID,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Remove(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+
+	page, err := stateRepo.RetrieveAll(context.Background(), tw.ID, 0, 10)
+	assert.Nil(t, err, fmt.Sprintf("retrieve states unexpected error: %s", err))
+	assert.Equal(t, uint64(0), page.Total, "expected states of removed twin to be removed")
+}
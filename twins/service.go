@@ -0,0 +1,213 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers"
+	smqsenml "github.com/absmach/supermq/pkg/transformers/senml"
+)
+
+type service struct {
+	idProvider  supermq.IDProvider
+	twins       TwinRepository
+	states      StateRepository
+	transformer transformers.Transformer
+}
+
+// New instantiates the twins service implementation.
+func New(idp supermq.IDProvider, twins TwinRepository, states StateRepository) Service {
+	return &service{
+		idProvider:  idp,
+		twins:       twins,
+		states:      states,
+		transformer: smqsenml.New(smqsenml.JSON),
+	}
+}
+
+func (svc *service) AddTwin(ctx context.Context, session smqauthn.Session, tw Twin, def Definition) (Twin, error) {
+	if err := def.Validate(); err != nil {
+		return Twin{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return Twin{}, err
+	}
+	now := time.Now().UTC()
+	def.ID = 0
+	def.Created = now
+
+	tw.ID = id
+	tw.Owner = session.UserID
+	tw.Revision = 0
+	tw.Created = now
+	tw.Updated = now
+	tw.Definitions = []Definition{def}
+
+	saved, err := svc.twins.Save(ctx, tw)
+	if err != nil {
+		return Twin{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return saved, nil
+}
+
+func (svc *service) UpdateTwin(ctx context.Context, session smqauthn.Session, tw Twin, def *Definition) (Twin, error) {
+	current, err := svc.ViewTwin(ctx, session, tw.ID)
+	if err != nil {
+		return Twin{}, err
+	}
+	now := time.Now().UTC()
+	current.Name = tw.Name
+	current.Metadata = tw.Metadata
+	if def != nil {
+		if err := def.Validate(); err != nil {
+			return Twin{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		def.ID = current.Definition().ID + 1
+		def.Created = now
+		current.Definitions = append(current.Definitions, *def)
+	}
+	current.Revision++
+	current.Updated = now
+
+	updated, err := svc.twins.Update(ctx, current)
+	if err != nil {
+		return Twin{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return updated, nil
+}
+
+func (svc *service) ViewTwin(ctx context.Context, session smqauthn.Session, id string) (Twin, error) {
+	tw, err := svc.twins.RetrieveByID(ctx, id)
+	if err != nil {
+		return Twin{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if tw.Owner != session.UserID {
+		return Twin{}, svcerr.ErrNotFound
+	}
+
+	return tw, nil
+}
+
+func (svc *service) ListTwins(ctx context.Context, session smqauthn.Session, pm PageMeta) (TwinsPage, error) {
+	pm.Owner = session.UserID
+	page, err := svc.twins.RetrieveAll(ctx, pm)
+	if err != nil {
+		return TwinsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc *service) RemoveTwin(ctx context.Context, session smqauthn.Session, id string) error {
+	if _, err := svc.ViewTwin(ctx, session, id); err != nil {
+		return err
+	}
+	if err := svc.twins.Remove(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *service) ListStates(ctx context.Context, session smqauthn.Session, twinID string, offset, limit uint64) (StatesPage, error) {
+	if _, err := svc.ViewTwin(ctx, session, twinID); err != nil {
+		return StatesPage{}, err
+	}
+	page, err := svc.states.RetrieveAll(ctx, twinID, offset, limit)
+	if err != nil {
+		return StatesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc *service) SaveStates(ctx context.Context, msg *messaging.Message) error {
+	twins, err := svc.twins.RetrieveByAttribute(ctx, msg.GetChannel(), msg.GetSubtopic())
+	if err != nil {
+		return err
+	}
+	if len(twins) == 0 {
+		return nil
+	}
+
+	// States are computed from SenML only, other messages are ignored.
+	m, err := svc.transformer.Transform(msg)
+	if err != nil {
+		return nil
+	}
+	records := m.([]smqsenml.Message)
+	if len(records) == 0 {
+		return nil
+	}
+
+	var ret error
+	for _, tw := range twins {
+		if err := svc.saveState(ctx, tw, msg, records); err != nil {
+			ret = err
+		}
+	}
+
+	return ret
+}
+
+// saveState sets the values of the twin attributes bound to the message
+// channel and subtopic. The latest state is updated in place, unless the
+// twin definition has changed since, or a persisted attribute is set and
+// at least delta has passed since the latest state was created.
+func (svc *service) saveState(ctx context.Context, tw Twin, msg *messaging.Message, records []smqsenml.Message) error {
+	def := tw.Definition()
+	last, err := svc.states.RetrieveLast(ctx, tw.ID)
+	exists := err == nil
+	if err != nil && !errors.Contains(err, repoerr.ErrNotFound) {
+		return err
+	}
+
+	payload := make(map[string]interface{}, len(def.Attributes))
+	for _, a := range def.Attributes {
+		if v, ok := last.Payload[a.Name]; ok {
+			payload[a.Name] = v
+		}
+	}
+	var matched, persist bool
+	for _, a := range def.Attributes {
+		if !a.matches(msg.GetChannel(), msg.GetSubtopic()) {
+			continue
+		}
+		payload[a.Name] = attributeValue(a, records)
+		matched = true
+		persist = persist || a.PersistState
+	}
+	if !matched {
+		return nil
+	}
+
+	created := messageTime(msg.GetCreated())
+	if exists && last.Definition == def.ID && (!persist || created.Sub(last.Created) < def.Delta) {
+		last.Payload = payload
+		return svc.states.Update(ctx, last)
+	}
+
+	st := State{
+		TwinID:     tw.ID,
+		Definition: def.ID,
+		Created:    created,
+		Payload:    payload,
+	}
+	if exists {
+		st.ID = last.ID + 1
+	}
+
+	return svc.states.Save(ctx, st)
+}
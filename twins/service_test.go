@@ -0,0 +1,279 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/uuid"
+	"github.com/absmach/supermq/twins"
+	"github.com/absmach/supermq/twins/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	channelID = testsutil.GenerateUUID(&testing.T{})
+	session   = smqauthn.Session{UserID: testsutil.GenerateUUID(&testing.T{})}
+	attribute = twins.Attribute{Name: "temperature", Channel: channelID, Subtopic: "room1", PersistState: true}
+)
+
+func newService() (twins.Service, *mocks.TwinRepository, *mocks.StateRepository) {
+	twinsRepo := new(mocks.TwinRepository)
+	statesRepo := new(mocks.StateRepository)
+
+	return twins.New(uuid.NewMock(), twinsRepo, statesRepo), twinsRepo, statesRepo
+}
+
+func TestAddTwin(t *testing.T) {
+	cases := []struct {
+		desc    string
+		def     twins.Definition
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "add twin successfully",
+			def:  twins.Definition{Attributes: []twins.Attribute{attribute}},
+		},
+		{
+			desc: "add twin without definition",
+		},
+		{
+			desc: "add twin with attribute without channel",
+			def:  twins.Definition{Attributes: []twins.Attribute{{Name: "temperature"}}},
+			err:  twins.ErrMalformedDefinition,
+		},
+		{
+			desc: "add twin with duplicate attributes",
+			def:  twins.Definition{Attributes: []twins.Attribute{attribute, attribute}},
+			err:  twins.ErrMalformedDefinition,
+		},
+		{
+			desc: "add twin with negative delta",
+			def:  twins.Definition{Delta: -time.Second},
+			err:  twins.ErrMalformedDefinition,
+		},
+		{
+			desc:    "add twin with repo error",
+			def:     twins.Definition{Attributes: []twins.Attribute{attribute}},
+			repoErr: repoerr.ErrCreateEntity,
+			err:     svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, _ := newService()
+			repo.On("Save", context.Background(), mock.MatchedBy(func(tw twins.Twin) bool {
+				return tw.Owner == session.UserID && len(tw.Definitions) == 1 && tw.Definitions[0].ID == 0 && !tw.Created.IsZero()
+			})).Return(twins.Twin{}, tc.repoErr)
+			_, err := svc.AddTwin(context.Background(), session, twins.Twin{Name: "twin"}, tc.def)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestUpdateTwin(t *testing.T) {
+	tw := twins.Twin{
+		ID:          testsutil.GenerateUUID(t),
+		Owner:       session.UserID,
+		Definitions: []twins.Definition{{ID: 0}},
+	}
+
+	cases := []struct {
+		desc     string
+		def      *twins.Definition
+		repoResp twins.Twin
+		defs     int
+		err      error
+	}{
+		{
+			desc:     "update twin without definition",
+			repoResp: tw,
+			defs:     1,
+		},
+		{
+			desc:     "update twin with definition",
+			def:      &twins.Definition{Attributes: []twins.Attribute{attribute}},
+			repoResp: tw,
+			defs:     2,
+		},
+		{
+			desc:     "update twin with malformed definition",
+			def:      &twins.Definition{Attributes: []twins.Attribute{{Channel: channelID}}},
+			repoResp: tw,
+			err:      twins.ErrMalformedDefinition,
+		},
+		{
+			desc:     "update twin of another user",
+			repoResp: twins.Twin{ID: tw.ID, Owner: testsutil.GenerateUUID(t)},
+			err:      svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, _ := newService()
+			repo.On("RetrieveByID", context.Background(), tw.ID).Return(tc.repoResp, nil)
+			repo.On("Update", context.Background(), mock.MatchedBy(func(updated twins.Twin) bool {
+				return updated.Revision == 1 && len(updated.Definitions) == tc.defs && updated.Definition().ID == tc.defs-1
+			})).Return(tw, nil)
+			_, err := svc.UpdateTwin(context.Background(), session, twins.Twin{ID: tw.ID, Name: "updated"}, tc.def)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestListStates(t *testing.T) {
+	tw := twins.Twin{ID: testsutil.GenerateUUID(t), Owner: session.UserID}
+
+	cases := []struct {
+		desc     string
+		repoResp twins.Twin
+		repoErr  error
+		err      error
+	}{
+		{
+			desc:     "list states of owned twin",
+			repoResp: tw,
+		},
+		{
+			desc:     "list states of twin of another user",
+			repoResp: twins.Twin{ID: tw.ID, Owner: testsutil.GenerateUUID(t)},
+			err:      svcerr.ErrNotFound,
+		},
+		{
+			desc:    "list states of non-existing twin",
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, states := newService()
+			repo.On("RetrieveByID", context.Background(), tw.ID).Return(tc.repoResp, tc.repoErr)
+			states.On("RetrieveAll", context.Background(), tw.ID, uint64(0), uint64(10)).Return(twins.StatesPage{}, nil)
+			_, err := svc.ListStates(context.Background(), session, tw.ID, 0, 10)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestSaveStates(t *testing.T) {
+	now := time.Now().UTC()
+	tw := twins.Twin{
+		ID:    testsutil.GenerateUUID(t),
+		Owner: session.UserID,
+		Definitions: []twins.Definition{{
+			ID:         1,
+			Attributes: []twins.Attribute{attribute, {Name: "humidity", Channel: channelID, Subtopic: twins.SubtopicWildcard}},
+			Delta:      time.Minute,
+		}},
+	}
+	msg := &messaging.Message{
+		Channel:  channelID,
+		Subtopic: "room1",
+		Created:  now.UnixNano(),
+		Payload:  []byte(`[{"n":"temperature","v":25},{"n":"humidity","v":40}]`),
+	}
+	humidity := &messaging.Message{
+		Channel:  channelID,
+		Subtopic: "room2",
+		Created:  now.UnixNano(),
+		Payload:  []byte(`[{"n":"humidity","v":45}]`),
+	}
+
+	cases := []struct {
+		desc    string
+		msg     *messaging.Message
+		last    twins.State
+		lastErr error
+		save    bool
+		update  bool
+		id      int64
+		payload map[string]interface{}
+	}{
+		{
+			desc:    "save first state",
+			msg:     msg,
+			lastErr: repoerr.ErrNotFound,
+			save:    true,
+			id:      0,
+			payload: map[string]interface{}{"temperature": 25.0, "humidity": 40.0},
+		},
+		{
+			desc:    "save new state after delta",
+			msg:     msg,
+			last:    twins.State{TwinID: tw.ID, ID: 3, Definition: 1, Created: now.Add(-2 * time.Minute), Payload: map[string]interface{}{"humidity": 30.0}},
+			save:    true,
+			id:      4,
+			payload: map[string]interface{}{"temperature": 25.0, "humidity": 40.0},
+		},
+		{
+			desc:    "update state within delta",
+			msg:     msg,
+			last:    twins.State{TwinID: tw.ID, ID: 3, Definition: 1, Created: now.Add(-time.Second), Payload: map[string]interface{}{"humidity": 30.0}},
+			update:  true,
+			id:      3,
+			payload: map[string]interface{}{"temperature": 25.0, "humidity": 40.0},
+		},
+		{
+			desc:    "update state with attribute which doesn't persist state",
+			msg:     humidity,
+			last:    twins.State{TwinID: tw.ID, ID: 3, Definition: 1, Created: now.Add(-2 * time.Minute), Payload: map[string]interface{}{"temperature": 20.0}},
+			update:  true,
+			id:      3,
+			payload: map[string]interface{}{"temperature": 20.0, "humidity": 45.0},
+		},
+		{
+			desc:    "save new state after definition change",
+			msg:     humidity,
+			last:    twins.State{TwinID: tw.ID, ID: 3, Definition: 0, Created: now, Payload: map[string]interface{}{"pressure": 1.0}},
+			save:    true,
+			id:      4,
+			payload: map[string]interface{}{"humidity": 45.0},
+		},
+		{
+			desc: "ignore non-SenML message",
+			msg:  &messaging.Message{Channel: channelID, Subtopic: "room1", Payload: []byte(`{"temperature":25}`)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, states := newService()
+			repo.On("RetrieveByAttribute", context.Background(), channelID, tc.msg.Subtopic).Return([]twins.Twin{tw}, nil)
+			states.On("RetrieveLast", context.Background(), tw.ID).Return(tc.last, tc.lastErr)
+			matches := mock.MatchedBy(func(st twins.State) bool {
+				return st.TwinID == tw.ID && st.ID == tc.id && st.Definition == 1 && assert.ObjectsAreEqual(tc.payload, st.Payload)
+			})
+			states.On("Save", context.Background(), matches).Return(nil)
+			states.On("Update", context.Background(), matches).Return(nil)
+
+			err := svc.SaveStates(context.Background(), tc.msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.save {
+				states.AssertCalled(t, "Save", context.Background(), matches)
+			} else {
+				states.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+			}
+			if tc.update {
+				states.AssertCalled(t, "Update", context.Background(), matches)
+			} else {
+				states.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
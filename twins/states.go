@@ -0,0 +1,96 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	smqsenml "github.com/absmach/supermq/pkg/transformers/senml"
+)
+
+// State is a snapshot of the twin attribute values. States of a twin are
+// numbered sequentially, starting from zero.
+type State struct {
+	TwinID     string                 `json:"twin_id"`
+	ID         int64                  `json:"id"`
+	Definition int                    `json:"definition"`
+	Created    time.Time              `json:"created"`
+	Payload    map[string]interface{} `json:"payload"`
+}
+
+// StatesPage represents a page of twin states.
+type StatesPage struct {
+	Total  uint64  `json:"total"`
+	Offset uint64  `json:"offset"`
+	Limit  uint64  `json:"limit"`
+	States []State `json:"states"`
+}
+
+func (page StatesPage) MarshalJSON() ([]byte, error) {
+	type Alias StatesPage
+	a := struct {
+		Alias
+	}{
+		Alias: Alias(page),
+	}
+
+	if a.States == nil {
+		a.States = make([]State, 0)
+	}
+
+	return json.Marshal(a)
+}
+
+// StateRepository specifies a twin state persistence API.
+//
+//go:generate mockery --name StateRepository --output=./mocks --filename state_repository.go --quiet --note "Copyright (c) Abstract Machines"
+type StateRepository interface {
+	// Save persists the state.
+	Save(ctx context.Context, st State) error
+
+	// Update updates the state payload.
+	Update(ctx context.Context, st State) error
+
+	// RetrieveAll retrieves the page of the twin states, oldest first.
+	RetrieveAll(ctx context.Context, twinID string, offset, limit uint64) (StatesPage, error)
+
+	// RetrieveLast retrieves the latest state of the twin.
+	RetrieveLast(ctx context.Context, twinID string) (State, error)
+}
+
+// attributeValue returns the value of the record named as the attribute
+// or, if there is no such record, the value of the last record.
+func attributeValue(a Attribute, records []smqsenml.Message) interface{} {
+	rec := records[len(records)-1]
+	for _, r := range records {
+		if r.Name == a.Name {
+			rec = r
+		}
+	}
+
+	switch {
+	case rec.Value != nil:
+		return *rec.Value
+	case rec.Sum != nil:
+		return *rec.Sum
+	case rec.BoolValue != nil:
+		return *rec.BoolValue
+	case rec.StringValue != nil:
+		return *rec.StringValue
+	case rec.DataValue != nil:
+		return *rec.DataValue
+	default:
+		return nil
+	}
+}
+
+func messageTime(created int64) time.Time {
+	if created == 0 {
+		return time.Now().UTC()
+	}
+
+	return time.Unix(0, created).UTC()
+}
@@ -0,0 +1,174 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+// SubtopicWildcard binds the attribute to all the subtopics of its channel.
+const SubtopicWildcard = ">"
+
+// ErrMalformedDefinition indicates that the twin definition is malformed.
+var ErrMalformedDefinition = errors.New("malformed twin definition")
+
+// Metadata represents arbitrary JSON.
+type Metadata map[string]interface{}
+
+// Attribute binds a twin state field to the messages published to the
+// channel and subtopic.
+type Attribute struct {
+	Name         string `json:"name"`
+	Channel      string `json:"channel"`
+	Subtopic     string `json:"subtopic,omitempty"`
+	PersistState bool   `json:"persist_state"`
+}
+
+func (a Attribute) matches(channel, subtopic string) bool {
+	return a.Channel == channel && (a.Subtopic == SubtopicWildcard || a.Subtopic == subtopic)
+}
+
+// Definition is a revision of the twin attributes. Delta is the minimal
+// duration, in nanoseconds, between two consecutive twin states.
+type Definition struct {
+	ID         int           `json:"id"`
+	Created    time.Time     `json:"created"`
+	Attributes []Attribute   `json:"attributes"`
+	Delta      time.Duration `json:"delta"`
+}
+
+// Validate checks that the definition delta isn't negative and that
+// the attributes are named uniquely and bound to channels.
+func (def Definition) Validate() error {
+	if def.Delta < 0 {
+		return errors.Wrap(ErrMalformedDefinition, errors.New("negative delta"))
+	}
+	names := make(map[string]bool, len(def.Attributes))
+	for _, a := range def.Attributes {
+		if a.Name == "" || a.Channel == "" {
+			return errors.Wrap(ErrMalformedDefinition, errors.New("attribute name and channel are required"))
+		}
+		if names[a.Name] {
+			return errors.Wrap(ErrMalformedDefinition, errors.New("duplicate attribute name"))
+		}
+		names[a.Name] = true
+	}
+
+	return nil
+}
+
+// Twin is a digital representation of a physical device or system. The
+// last of the twin definitions is the one in use, while the previous
+// ones are kept as history.
+type Twin struct {
+	Owner       string       `json:"owner"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name,omitempty"`
+	Revision    int          `json:"revision"`
+	Created     time.Time    `json:"created"`
+	Updated     time.Time    `json:"updated"`
+	Definitions []Definition `json:"definitions"`
+	Metadata    Metadata     `json:"metadata,omitempty"`
+}
+
+// Definition returns the definition in use.
+func (tw Twin) Definition() Definition {
+	if len(tw.Definitions) == 0 {
+		return Definition{}
+	}
+
+	return tw.Definitions[len(tw.Definitions)-1]
+}
+
+// TwinsPage represents a page of twins.
+type TwinsPage struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+	Twins  []Twin `json:"twins"`
+}
+
+func (page TwinsPage) MarshalJSON() ([]byte, error) {
+	type Alias TwinsPage
+	a := struct {
+		Alias
+	}{
+		Alias: Alias(page),
+	}
+
+	if a.Twins == nil {
+		a.Twins = make([]Twin, 0)
+	}
+
+	return json.Marshal(a)
+}
+
+// PageMeta is used to filter twins.
+type PageMeta struct {
+	Offset   uint64   `json:"offset"`
+	Limit    uint64   `json:"limit"`
+	Owner    string   `json:"owner,omitempty"`
+	Name     string   `json:"name,omitempty"`
+	Metadata Metadata `json:"metadata,omitempty"`
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// AddTwin adds the twin owned by the session user, using the
+	// definition as the initial twin definition.
+	AddTwin(ctx context.Context, session smqauthn.Session, tw Twin, def Definition) (Twin, error)
+
+	// UpdateTwin updates the twin name and metadata. If the definition
+	// is provided, it's added as the new twin definition.
+	UpdateTwin(ctx context.Context, session smqauthn.Session, tw Twin, def *Definition) (Twin, error)
+
+	// ViewTwin retrieves the twin identified by the given ID.
+	ViewTwin(ctx context.Context, session smqauthn.Session, id string) (Twin, error)
+
+	// ListTwins retrieves the twins of the session user filtered by the page metadata.
+	ListTwins(ctx context.Context, session smqauthn.Session, pm PageMeta) (TwinsPage, error)
+
+	// RemoveTwin removes the twin along with its states.
+	RemoveTwin(ctx context.Context, session smqauthn.Session, id string) error
+
+	// ListStates retrieves the states of the twin, oldest first.
+	ListStates(ctx context.Context, session smqauthn.Session, twinID string, offset, limit uint64) (StatesPage, error)
+
+	// SaveStates updates the states of the twins with attributes
+	// bound to the message channel and subtopic.
+	SaveStates(ctx context.Context, msg *messaging.Message) error
+}
+
+// TwinRepository specifies a twin persistence API.
+//
+//go:generate mockery --name TwinRepository --output=./mocks --filename twin_repository.go --quiet --note "Copyright (c) Abstract Machines"
+type TwinRepository interface {
+	// Save persists the twin.
+	Save(ctx context.Context, tw Twin) (Twin, error)
+
+	// Update updates the twin name, metadata, revision and definitions.
+	Update(ctx context.Context, tw Twin) (Twin, error)
+
+	// RetrieveByID retrieves the twin having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Twin, error)
+
+	// RetrieveByAttribute retrieves the twins whose definition in use has
+	// an attribute bound to the channel and subtopic.
+	RetrieveByAttribute(ctx context.Context, channel, subtopic string) ([]Twin, error)
+
+	// RetrieveAll retrieves twins filtered by the page metadata.
+	RetrieveAll(ctx context.Context, pm PageMeta) (TwinsPage, error)
+
+	// Remove removes the twin having the provided identifier.
+	Remove(ctx context.Context, id string) error
+}
@@ -16,7 +16,6 @@ import (
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
-	authmocks "github.com/absmach/supermq/auth/mocks"
 	"github.com/absmach/supermq/internal/testsutil"
 	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
@@ -92,8 +91,7 @@ func newUsersServer() (*httptest.Server, *mocks.Service, *authnmocks.Authenticat
 	provider := new(oauth2mocks.Provider)
 	provider.On("Name").Return("test")
 	authn := new(authnmocks.Authentication)
-	token := new(authmocks.TokenServiceClient)
-	usersapi.MakeHandler(svc, authn, token, true, mux, logger, "", passRegex, provider)
+	usersapi.MakeHandler(svc, authn, true, mux, logger, "", passRegex, provider)
 
 	return httptest.NewServer(mux), svc, authn
 }
@@ -1741,6 +1739,148 @@ func TestPasswordResetRequest(t *testing.T) {
 	}
 }
 
+func TestVerifyEmail(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	token := "verification-token"
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		svcRes      users.User
+		svcErr      error
+		status      int
+		err         error
+	}{
+		{
+			desc:        "verify email with valid token",
+			data:        fmt.Sprintf(`{"token": "%s"}`, token),
+			contentType: contentType,
+			svcRes:      user,
+			status:      http.StatusOK,
+			err:         nil,
+		},
+		{
+			desc:        "verify email with empty token",
+			data:        `{"token": ""}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "verify email with invalid token",
+			data:        fmt.Sprintf(`{"token": "%s"}`, token),
+			contentType: contentType,
+			svcErr:      errors.Wrap(users.ErrInvalidVerificationToken, svcerr.ErrAuthentication),
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "verify email with malformed data",
+			data:        fmt.Sprintf(`{"token": %s}`, token),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "verify email with invalid content type",
+			data:        fmt.Sprintf(`{"token": "%s"}`, token),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:        us.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/users/verify-email", us.URL),
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			svcCall := svc.On("VerifyEmail", mock.Anything, token).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	testemail := "test@example.com"
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		svcErr      error
+		status      int
+		err         error
+	}{
+		{
+			desc:        "resend verification with valid email",
+			data:        fmt.Sprintf(`{"email": "%s"}`, testemail),
+			contentType: contentType,
+			status:      http.StatusCreated,
+			err:         nil,
+		},
+		{
+			desc:        "resend verification with empty email",
+			data:        `{"email": ""}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "resend verification without pending verification",
+			data:        fmt.Sprintf(`{"email": "%s"}`, testemail),
+			contentType: contentType,
+			svcErr:      svcerr.ErrNotFound,
+			status:      http.StatusNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+		{
+			desc:        "resend verification with malformed data",
+			data:        fmt.Sprintf(`{"email": %s}`, testemail),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "resend verification with invalid content type",
+			data:        fmt.Sprintf(`{"email": "%s"}`, testemail),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:        us.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/users/verify-email/resend", us.URL),
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			svcCall := svc.On("ResendVerification", mock.Anything, testemail).Return(tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
+
 func TestPasswordReset(t *testing.T) {
 	us, svc, authn := newUsersServer()
 	defer us.Close()
@@ -2170,6 +2310,27 @@ func TestIssueToken(t *testing.T) {
 			status:      http.StatusUnsupportedMediaType,
 			err:         apiutil.ErrValidation,
 		},
+		{
+			desc:        "issue token for user with MFA without code",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrMFARequired,
+		},
+		{
+			desc:        "issue token for user without MFA in domain requiring MFA",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret),
+			contentType: contentType,
+			status:      http.StatusForbidden,
+			err:         svcerr.ErrMFAEnrolmentRequired,
+		},
+		{
+			desc:        "issue token for locked user",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret),
+			contentType: contentType,
+			status:      http.StatusLocked,
+			err:         svcerr.ErrAccountLocked,
+		},
 	}
 
 	for _, tc := range cases {
@@ -2182,7 +2343,250 @@ func TestIssueToken(t *testing.T) {
 				body:        strings.NewReader(tc.data),
 			}
 
-			svcCall := svc.On("IssueToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&grpcTokenV1.Token{AccessToken: validToken}, tc.err)
+			svcCall := svc.On("IssueToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&grpcTokenV1.Token{AccessToken: validToken}, tc.err)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.err != nil {
+				var resBody respBody
+				err = json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				if resBody.Err != "" || resBody.Message != "" {
+					err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+				}
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestEnrollMFA(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	validUsername := "valid"
+	dataFormat := `{"username": "%s", "password": "%s"}`
+	enrolment := users.MFAEnrolment{Secret: "JBSWY3DPEHPK3PXP", ProvisioningURI: "otpauth://totp/SuperMQ:valid?secret=JBSWY3DPEHPK3PXP"}
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		svcErr      error
+		status      int
+		err         error
+	}{
+		{
+			desc:        "enroll MFA with valid credentials",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret),
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "enroll MFA with empty identity",
+			data:        fmt.Sprintf(dataFormat, "", secret),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "enroll MFA with empty secret",
+			data:        fmt.Sprintf(dataFormat, validUsername, ""),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "enroll MFA with wrong secret",
+			data:        fmt.Sprintf(dataFormat, validUsername, "wrong"),
+			contentType: contentType,
+			svcErr:      svcerr.ErrLogin,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrLogin,
+		},
+		{
+			desc:        "enroll MFA with enabled MFA",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret),
+			contentType: contentType,
+			svcErr:      errors.Wrap(svcerr.ErrConflict, users.ErrMFAEnabled),
+			status:      http.StatusConflict,
+			err:         svcerr.ErrConflict,
+		},
+		{
+			desc:        "enroll MFA with invalid content type",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:        us.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/users/mfa/enroll", us.URL),
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+
+			svcCall := svc.On("EnrollMFA", mock.Anything, mock.Anything, mock.Anything).Return(enrolment, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.err != nil {
+				var resBody respBody
+				err = json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				if resBody.Err != "" || resBody.Message != "" {
+					err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+				}
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestVerifyMFA(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	validUsername := "valid"
+	dataFormat := `{"username": "%s", "password": "%s", "code": "%s"}`
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		svcErr      error
+		status      int
+		err         error
+	}{
+		{
+			desc:        "verify MFA with valid code",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret, "123456"),
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "verify MFA with empty code",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret, ""),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingMFACode,
+		},
+		{
+			desc:        "verify MFA with empty identity",
+			data:        fmt.Sprintf(dataFormat, "", secret, "123456"),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "verify MFA with invalid code",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret, "000000"),
+			contentType: contentType,
+			svcErr:      svcerr.ErrInvalidMFACode,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrInvalidMFACode,
+		},
+		{
+			desc:        "verify MFA without enrolment",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret, "123456"),
+			contentType: contentType,
+			svcErr:      errors.Wrap(svcerr.ErrNotFound, users.ErrMFANotEnrolled),
+			status:      http.StatusNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:        us.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/users/mfa/verify", us.URL),
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+
+			svcCall := svc.On("VerifyMFA", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]string{"abcdefgh"}, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.err != nil {
+				var resBody respBody
+				err = json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				if resBody.Err != "" || resBody.Message != "" {
+					err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+				}
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestDisableMFA(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	validUsername := "valid"
+	dataFormat := `{"username": "%s", "password": "%s", "code": "%s"}`
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		svcErr      error
+		status      int
+		err         error
+	}{
+		{
+			desc:        "disable MFA with valid code",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret, "123456"),
+			contentType: contentType,
+			status:      http.StatusNoContent,
+		},
+		{
+			desc:        "disable MFA with empty code",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret, ""),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingMFACode,
+		},
+		{
+			desc:        "disable MFA with invalid code",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret, "000000"),
+			contentType: contentType,
+			svcErr:      svcerr.ErrInvalidMFACode,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrInvalidMFACode,
+		},
+		{
+			desc:        "disable MFA with invalid content type",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret, "123456"),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:        us.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/users/mfa/disable", us.URL),
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+
+			svcCall := svc.On("DisableMFA", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.svcErr)
 			res, err := req.make()
 			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 			if tc.err != nil {
@@ -2565,6 +2969,94 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestUnlock(t *testing.T) {
+	us, svc, authn := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc     string
+		user     users.User
+		response users.User
+		token    string
+		authnRes smqauthn.Session
+		authnErr error
+		status   int
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "unlock user as admin with valid token",
+			user:     user,
+			response: user,
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, DomainID: domainID},
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:     "unlock user with invalid token",
+			user:     user,
+			token:    inValidToken,
+			status:   http.StatusUnauthorized,
+			authnErr: svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc: "unlock user with empty id",
+			user: users.User{
+				ID: "",
+			},
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, DomainID: domainID},
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrMissingID,
+		},
+		{
+			desc:     "unlock user as normal user",
+			user:     user,
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, DomainID: domainID},
+			status:   http.StatusForbidden,
+			svcErr:   svcerr.ErrAuthorization,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "unlock user with service error",
+			user:     user,
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, DomainID: domainID},
+			status:   http.StatusUnprocessableEntity,
+			svcErr:   svcerr.ErrUpdateEntity,
+			err:      svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:   us.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/users/%s/unlock", us.URL, tc.user.ID),
+				token:  tc.token,
+			}
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("Unlock", mock.Anything, tc.authnRes, tc.user.ID).Return(tc.response, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var resBody respBody
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
 type respBody struct {
 	Err     string       `json:"error"`
 	Message string       `json:"message"`
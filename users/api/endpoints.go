@@ -264,6 +264,37 @@ func passwordResetRequestEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func verifyEmailEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(verifyEmailReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		user, err := svc.VerifyEmail(ctx, req.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewUserRes{User: user}, nil
+	}
+}
+
+func resendVerificationEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(resendVerificationReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.ResendVerification(ctx, req.Email); err != nil {
+			return nil, err
+		}
+
+		return passwResetReqRes{Msg: VerificationSent}, nil
+	}
+}
+
 // This is endpoint that actually sets new password in password reset flow.
 // When user clicks on a link in email finally ends on this endpoint as explained in
 // the comment above.
@@ -386,7 +417,7 @@ func issueTokenEndpoint(svc users.Service) endpoint.Endpoint {
 			return nil, errors.Wrap(apiutil.ErrValidation, err)
 		}
 
-		token, err := svc.IssueToken(ctx, req.Username, req.Password)
+		token, err := svc.IssueToken(ctx, req.Username, req.Password, req.OTP, req.device, req.ip)
 		if err != nil {
 			return nil, err
 		}
@@ -399,6 +430,73 @@ func issueTokenEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func issueMFATokenEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(mfaTokenReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		token, err := svc.IssueMFAToken(ctx, req.Challenge, req.OTP, req.device, req.ip)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{
+			AccessToken:  token.GetAccessToken(),
+			RefreshToken: token.GetRefreshToken(),
+			AccessType:   token.GetAccessType(),
+		}, nil
+	}
+}
+
+func enrollMFAEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(enrollMFAReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		enrolment, err := svc.EnrollMFA(ctx, req.Username, req.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		return enrollMFARes{MFAEnrolment: enrolment}, nil
+	}
+}
+
+func verifyMFAEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(mfaCodeReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		codes, err := svc.VerifyMFA(ctx, req.Username, req.Password, req.Code)
+		if err != nil {
+			return nil, err
+		}
+
+		return verifyMFARes{RecoveryCodes: codes}, nil
+	}
+}
+
+func disableMFAEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(mfaCodeReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.DisableMFA(ctx, req.Username, req.Password, req.Code); err != nil {
+			return nil, err
+		}
+
+		return disableMFARes{}, nil
+	}
+}
+
 func refreshTokenEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(tokenReq)
@@ -466,6 +564,27 @@ func disableEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func unlockEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(changeUserStatusReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		user, err := svc.Unlock(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return changeUserStatusRes{User: user}, nil
+	}
+}
+
 func deleteEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(changeUserStatusReq)
@@ -244,6 +244,9 @@ func (req changeUserStatusReq) validate() error {
 type loginUserReq struct {
 	Username string `json:"username,omitempty"`
 	Password string `json:"password,omitempty"`
+	OTP      string `json:"otp,omitempty"`
+	device   string
+	ip       string
 }
 
 func (req loginUserReq) validate() error {
@@ -257,6 +260,60 @@ func (req loginUserReq) validate() error {
 	return nil
 }
 
+type mfaTokenReq struct {
+	Challenge string `json:"challenge,omitempty"`
+	OTP       string `json:"otp,omitempty"`
+	device    string
+	ip        string
+}
+
+func (req mfaTokenReq) validate() error {
+	if req.Challenge == "" {
+		return apiutil.ErrMissingMFAChallenge
+	}
+	if req.OTP == "" {
+		return apiutil.ErrMissingMFACode
+	}
+
+	return nil
+}
+
+type enrollMFAReq struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+func (req enrollMFAReq) validate() error {
+	if req.Username == "" {
+		return apiutil.ErrMissingIdentity
+	}
+	if req.Password == "" {
+		return apiutil.ErrMissingPass
+	}
+
+	return nil
+}
+
+type mfaCodeReq struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Code     string `json:"code,omitempty"`
+}
+
+func (req mfaCodeReq) validate() error {
+	if req.Username == "" {
+		return apiutil.ErrMissingIdentity
+	}
+	if req.Password == "" {
+		return apiutil.ErrMissingPass
+	}
+	if req.Code == "" {
+		return apiutil.ErrMissingMFACode
+	}
+
+	return nil
+}
+
 type tokenReq struct {
 	RefreshToken string `json:"refresh_token,omitempty"`
 }
@@ -285,6 +342,30 @@ func (req passwResetReq) validate() error {
 	return nil
 }
 
+type verifyEmailReq struct {
+	Token string `json:"token"`
+}
+
+func (req verifyEmailReq) validate() error {
+	if req.Token == "" {
+		return apiutil.ErrMissingVerificationToken
+	}
+
+	return nil
+}
+
+type resendVerificationReq struct {
+	Email string `json:"email"`
+}
+
+func (req resendVerificationReq) validate() error {
+	if req.Email == "" {
+		return apiutil.ErrMissingEmail
+	}
+
+	return nil
+}
+
 type resetTokenReq struct {
 	Token    string `json:"token"`
 	Password string `json:"password"`
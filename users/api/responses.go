@@ -11,8 +11,13 @@ import (
 	"github.com/absmach/supermq/users"
 )
 
-// MailSent message response when link is sent.
-const MailSent = "Email with reset link is sent"
+const (
+	// MailSent message response when link is sent.
+	MailSent = "Email with reset link is sent"
+
+	// VerificationSent message response when verification link is sent.
+	VerificationSent = "Email with verification link is sent"
+)
 
 var (
 	_ supermq.Response = (*tokenRes)(nil)
@@ -79,6 +84,52 @@ func (res tokenRes) Empty() bool {
 	return res.AccessToken == "" || res.RefreshToken == ""
 }
 
+type enrollMFARes struct {
+	users.MFAEnrolment `json:",inline"`
+}
+
+func (res enrollMFARes) Code() int {
+	return http.StatusCreated
+}
+
+func (res enrollMFARes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res enrollMFARes) Empty() bool {
+	return false
+}
+
+type verifyMFARes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (res verifyMFARes) Code() int {
+	return http.StatusOK
+}
+
+func (res verifyMFARes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res verifyMFARes) Empty() bool {
+	return false
+}
+
+type disableMFARes struct{}
+
+func (res disableMFARes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res disableMFARes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res disableMFARes) Empty() bool {
+	return true
+}
+
 type updateUserRes struct {
 	users.User `json:",inline"`
 }
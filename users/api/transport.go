@@ -9,7 +9,6 @@ import (
 	"regexp"
 
 	"github.com/absmach/supermq"
-	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/oauth2"
 	"github.com/absmach/supermq/users"
@@ -18,8 +17,8 @@ import (
 )
 
 // MakeHandler returns a HTTP handler for Users and Groups API endpoints.
-func MakeHandler(cls users.Service, authn smqauthn.Authentication, tokensvc grpcTokenV1.TokenServiceClient, selfRegister bool, mux *chi.Mux, logger *slog.Logger, instanceID string, pr *regexp.Regexp, providers ...oauth2.Provider) http.Handler {
-	mux = usersHandler(cls, authn, tokensvc, selfRegister, mux, logger, pr, providers...)
+func MakeHandler(cls users.Service, authn smqauthn.Authentication, selfRegister bool, mux *chi.Mux, logger *slog.Logger, instanceID string, pr *regexp.Regexp, providers ...oauth2.Provider) http.Handler {
+	mux = usersHandler(cls, authn, selfRegister, mux, logger, pr, providers...)
 
 	mux.Get("/health", supermq.Health("users", instanceID))
 	mux.Handle("/metrics", promhttp.Handler())
@@ -8,13 +8,12 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 
-	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
-	smqauth "github.com/absmach/supermq/auth"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/oauth2"
@@ -27,7 +26,7 @@ import (
 var passRegex = regexp.MustCompile("^.{8,}$")
 
 // usersHandler returns a HTTP handler for API endpoints.
-func usersHandler(svc users.Service, authn smqauthn.Authentication, tokenClient grpcTokenV1.TokenServiceClient, selfRegister bool, r *chi.Mux, logger *slog.Logger, pr *regexp.Regexp, providers ...oauth2.Provider) *chi.Mux {
+func usersHandler(svc users.Service, authn smqauthn.Authentication, selfRegister bool, r *chi.Mux, logger *slog.Logger, pr *regexp.Regexp, providers ...oauth2.Provider) *chi.Mux {
 	passRegex = pr
 
 	opts := []kithttp.ServerOption{
@@ -146,6 +145,13 @@ func usersHandler(svc users.Service, authn smqauthn.Authentication, tokenClient
 				opts...,
 			), "disable_user").ServeHTTP)
 
+			r.Post("/{id}/unlock", otelhttp.NewHandler(kithttp.NewServer(
+				unlockEndpoint(svc),
+				decodeChangeUserStatus,
+				api.EncodeResponse,
+				opts...,
+			), "unlock_user").ServeHTTP)
+
 			r.Delete("/{id}", otelhttp.NewHandler(kithttp.NewServer(
 				deleteEndpoint(svc),
 				decodeChangeUserStatus,
@@ -179,6 +185,36 @@ func usersHandler(svc users.Service, authn smqauthn.Authentication, tokenClient
 		opts...,
 	), "issue_token").ServeHTTP)
 
+	r.Post("/users/tokens/mfa", otelhttp.NewHandler(kithttp.NewServer(
+		issueMFATokenEndpoint(svc),
+		decodeMFAToken,
+		api.EncodeResponse,
+		opts...,
+	), "issue_mfa_token").ServeHTTP)
+
+	r.Route("/users/mfa", func(r chi.Router) {
+		r.Post("/enroll", otelhttp.NewHandler(kithttp.NewServer(
+			enrollMFAEndpoint(svc),
+			decodeEnrollMFA,
+			api.EncodeResponse,
+			opts...,
+		), "enroll_mfa").ServeHTTP)
+
+		r.Post("/verify", otelhttp.NewHandler(kithttp.NewServer(
+			verifyMFAEndpoint(svc),
+			decodeMFACode,
+			api.EncodeResponse,
+			opts...,
+		), "verify_mfa").ServeHTTP)
+
+		r.Post("/disable", otelhttp.NewHandler(kithttp.NewServer(
+			disableMFAEndpoint(svc),
+			decodeMFACode,
+			api.EncodeResponse,
+			opts...,
+		), "disable_mfa").ServeHTTP)
+	})
+
 	r.Post("/password/reset-request", otelhttp.NewHandler(kithttp.NewServer(
 		passwordResetRequestEndpoint(svc),
 		decodePasswordResetRequest,
@@ -186,8 +222,24 @@ func usersHandler(svc users.Service, authn smqauthn.Authentication, tokenClient
 		opts...,
 	), "password_reset_req").ServeHTTP)
 
+	r.Route("/users/verify-email", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			verifyEmailEndpoint(svc),
+			decodeVerifyEmail,
+			api.EncodeResponse,
+			opts...,
+		), "verify_email").ServeHTTP)
+
+		r.Post("/resend", otelhttp.NewHandler(kithttp.NewServer(
+			resendVerificationEndpoint(svc),
+			decodeResendVerification,
+			api.EncodeResponse,
+			opts...,
+		), "resend_verification").ServeHTTP)
+	})
+
 	for _, provider := range providers {
-		r.HandleFunc("/oauth/callback/"+provider.Name(), oauth2CallbackHandler(provider, svc, tokenClient))
+		r.HandleFunc("/oauth/callback/"+provider.Name(), oauth2CallbackHandler(provider, svc))
 	}
 
 	return r
@@ -435,6 +487,32 @@ func decodePasswordResetRequest(_ context.Context, r *http.Request) (interface{}
 	return req, nil
 }
 
+func decodeVerifyEmail(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req verifyEmailReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeResendVerification(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req resendVerificationReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
 func decodePasswordReset(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
@@ -473,6 +551,49 @@ func decodeCredentials(_ context.Context, r *http.Request) (interface{}, error)
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
 	}
+	req.device = r.UserAgent()
+	req.ip = apiutil.ClientIP(r)
+
+	return req, nil
+}
+
+func decodeMFAToken(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := mfaTokenReq{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+	req.device = r.UserAgent()
+	req.ip = apiutil.ClientIP(r)
+
+	return req, nil
+}
+
+func decodeEnrollMFA(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := enrollMFAReq{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeMFACode(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := mfaCodeReq{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
 
 	return req, nil
 }
@@ -508,7 +629,9 @@ func decodeChangeUserStatus(_ context.Context, r *http.Request) (interface{}, er
 }
 
 // oauth2CallbackHandler is a http.HandlerFunc that handles OAuth2 callbacks.
-func oauth2CallbackHandler(oauth oauth2.Provider, svc users.Service, tokenClient grpcTokenV1.TokenServiceClient) http.HandlerFunc {
+// Users with enabled MFA are redirected with the MFA challenge instead of
+// the tokens, which are issued once the challenge is completed.
+func oauth2CallbackHandler(oauth oauth2.Provider, svc users.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if !oauth.IsEnabled() {
 			http.Redirect(w, r, oauth.ErrorURL()+"?error=oauth%20provider%20is%20disabled", http.StatusSeeOther)
@@ -527,7 +650,7 @@ func oauth2CallbackHandler(oauth oauth2.Provider, svc users.Service, tokenClient
 				return
 			}
 
-			user, err := oauth.UserInfo(token.AccessToken)
+			user, err := oauth.UserInfo(token)
 			if err != nil {
 				http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
 				return
@@ -543,14 +666,15 @@ func oauth2CallbackHandler(oauth oauth2.Provider, svc users.Service, tokenClient
 				return
 			}
 
-			jwt, err := tokenClient.Issue(r.Context(), &grpcTokenV1.IssueReq{
-				UserId: user.ID,
-				Type:   uint32(smqauth.AccessKey),
-			})
+			jwt, challenge, err := svc.OAuthIssueToken(r.Context(), user.ID, r.UserAgent(), apiutil.ClientIP(r))
 			if err != nil {
 				http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
 				return
 			}
+			if challenge != "" {
+				http.Redirect(w, r, oauth.RedirectURL()+"?mfa_challenge="+url.QueryEscape(challenge), http.StatusFound)
+				return
+			}
 
 			http.SetCookie(w, &http.Cookie{
 				Name:     "access_token",
@@ -0,0 +1,56 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+var (
+	// ErrDirectoryUserNotFound indicates that the user does not exist in the directory.
+	ErrDirectoryUserNotFound = errors.New("user not found in directory")
+
+	// ErrInvalidDirectoryCredentials indicates that the directory rejected the user credentials.
+	ErrInvalidDirectoryCredentials = errors.New("invalid directory credentials")
+
+	// ErrLocalAccount indicates that the directory user conflicts with the local account
+	// with the same username.
+	ErrLocalAccount = errors.New("username belongs to local account")
+)
+
+// DirectoryProvider is the identity provider name of the directory users.
+const DirectoryProvider = "ldap"
+
+// DirectoryRole represents the domain role granted to the members of a
+// directory group.
+type DirectoryRole struct {
+	DomainID string
+	RoleID   string
+}
+
+// DirectoryEntry represents the user entry retrieved from the directory.
+// The user attributes are mapped from the entry attributes, and the roles
+// are resolved from the groups the user is member of.
+type DirectoryEntry struct {
+	User  User
+	Roles []DirectoryRole
+}
+
+// Directory specifies an API for authenticating users against an external
+// directory service, such as LDAP or Active Directory.
+//
+//go:generate mockery --name Directory --output=./mocks --filename directory.go --quiet --note "Copyright (c) Abstract Machines"
+type Directory interface {
+	// Authenticate verifies the user credentials against the directory and
+	// returns the user entry. If the user is found, but the password is
+	// invalid, the entry with the username is returned together with
+	// ErrInvalidDirectoryCredentials, so that the failed login is counted.
+	Authenticate(ctx context.Context, identity, secret string) (DirectoryEntry, error)
+
+	// Roles returns all the domain roles whose membership is managed by the
+	// directory groups.
+	Roles() []DirectoryRole
+}
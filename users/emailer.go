@@ -9,4 +9,10 @@ package users
 type Emailer interface {
 	// SendPasswordReset sends an email to the user with a link to reset the password.
 	SendPasswordReset(To []string, host, user, token string) error
+
+	// SendVerification sends an email to the user with a link to verify the email.
+	SendVerification(To []string, user, token string) error
+
+	// SendPATRevokedUse notifies the user that the revoked personal access token is still in use.
+	SendPATRevokedUse(To []string, user, patName, ip string) error
 }
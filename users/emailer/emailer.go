@@ -10,20 +10,40 @@ import (
 	"github.com/absmach/supermq/users"
 )
 
+const (
+	resetHeader  = "We have received a request to reset your password. To proceed with resetting your password, please click on the link below:"
+	verifyHeader = "Please confirm your email address by clicking on the link below:"
+	patHeader    = "A revoked personal access token was presented to SuperMQ. If you do not recognize this activity, review the applications that still use the token:"
+)
+
 var _ users.Emailer = (*emailer)(nil)
 
 type emailer struct {
-	resetURL string
-	agent    *email.Agent
+	resetURL  string
+	verifyURL string
+	agent     *email.Agent
 }
 
 // New creates new emailer utility.
-func New(url string, c *email.Config) (users.Emailer, error) {
+func New(resetURL, verifyURL string, c *email.Config) (users.Emailer, error) {
 	e, err := email.New(c)
-	return &emailer{resetURL: url, agent: e}, err
+	return &emailer{resetURL: resetURL, verifyURL: verifyURL, agent: e}, err
 }
 
 func (e *emailer) SendPasswordReset(to []string, host, user, token string) error {
 	url := fmt.Sprintf("%s%s?token=%s", host, e.resetURL, token)
-	return e.agent.Send(to, "", "Password Reset Request", "", user, url, "")
+	return e.agent.Send(to, "", "Password Reset Request", resetHeader, user, url, "")
+}
+
+func (e *emailer) SendVerification(to []string, user, token string) error {
+	url := fmt.Sprintf("%s?token=%s", e.verifyURL, token)
+	return e.agent.Send(to, "", "Email Verification", verifyHeader, user, url, "")
+}
+
+func (e *emailer) SendPATRevokedUse(to []string, user, patName, ip string) error {
+	content := fmt.Sprintf("Token: %s", patName)
+	if ip != "" {
+		content = fmt.Sprintf("%s, source IP: %s", content, ip)
+	}
+	return e.agent.Send(to, "", "Revoked Personal Access Token Used", patHeader, user, content, "")
 }
@@ -0,0 +1,101 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/users"
+)
+
+const (
+	stream = "events.supermq.auth"
+
+	patRevokedUse = "pat.revoked_use"
+)
+
+var (
+	errNoOperationKey      = errors.New("operation key is not found in event message")
+	errPATRevokedUseEvent  = errors.New("failed to consume PAT revoked use event")
+	errMissingEventUserID  = errors.New("missing user id in event message")
+	errMissingEventPATName = errors.New("missing PAT name in event message")
+)
+
+type eventHandler struct {
+	repo    users.Repository
+	emailer users.Emailer
+}
+
+// AuthEventsSubscribe subscribes to the auth service events and notifies
+// the users about the activity of their personal access tokens.
+func AuthEventsSubscribe(ctx context.Context, repo users.Repository, emailer users.Emailer, esURL, esConsumerName string, logger *slog.Logger) error {
+	subscriber, err := store.NewSubscriber(ctx, esURL, logger)
+	if err != nil {
+		return err
+	}
+
+	subConfig := events.SubscriberConfig{
+		Stream:         stream,
+		Consumer:       esConsumerName,
+		Handler:        NewEventHandler(repo, emailer),
+		DeliveryPolicy: messaging.DeliverNewPolicy,
+		Ordered:        false,
+	}
+	return subscriber.Subscribe(ctx, subConfig)
+}
+
+// NewEventHandler returns new event store handler.
+func NewEventHandler(repo users.Repository, emailer users.Emailer) events.EventHandler {
+	return &eventHandler{
+		repo:    repo,
+		emailer: emailer,
+	}
+}
+
+func (es *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	op, ok := msg["operation"]
+	if !ok {
+		return errNoOperationKey
+	}
+	if op == patRevokedUse {
+		return es.patRevokedUseHandler(ctx, msg)
+	}
+
+	return nil
+}
+
+func (es *eventHandler) patRevokedUseHandler(ctx context.Context, data map[string]interface{}) error {
+	userID, ok := data["user_id"].(string)
+	if !ok || userID == "" {
+		return errors.Wrap(errPATRevokedUseEvent, errMissingEventUserID)
+	}
+	patName, ok := data["pat_name"].(string)
+	if !ok {
+		return errors.Wrap(errPATRevokedUseEvent, errMissingEventPATName)
+	}
+	ip, _ := data["ip"].(string)
+
+	user, err := es.repo.RetrieveByID(ctx, userID)
+	if err != nil {
+		return errors.Wrap(errPATRevokedUseEvent, err)
+	}
+	if user.Email == "" {
+		return nil
+	}
+	if err := es.emailer.SendPATRevokedUse([]string{user.Email}, user.Credentials.Username, patName, ip); err != nil {
+		return errors.Wrap(errPATRevokedUseEvent, err)
+	}
+
+	return nil
+}
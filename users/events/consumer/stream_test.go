@@ -0,0 +1,136 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/users"
+	"github.com/absmach/supermq/users/events/consumer"
+	"github.com/absmach/supermq/users/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEvent struct {
+	data map[string]interface{}
+	err  error
+}
+
+func (e testEvent) Encode() (map[string]interface{}, error) {
+	return e.data, e.err
+}
+
+func TestHandle(t *testing.T) {
+	repo := new(mocks.Repository)
+	emailer := new(mocks.Emailer)
+	handler := consumer.NewEventHandler(repo, emailer)
+
+	userID := testsutil.GenerateUUID(t)
+	user := users.User{
+		ID:          userID,
+		Email:       "user@example.com",
+		Credentials: users.Credentials{Username: "user"},
+	}
+	errEmail := errors.New("failed to send email")
+
+	cases := []struct {
+		desc        string
+		event       map[string]interface{}
+		encodeErr   error
+		user        users.User
+		retrieveErr error
+		sendErr     error
+		sent        bool
+		err         error
+	}{
+		{
+			desc: "handle PAT revoked use event successfully",
+			event: map[string]interface{}{
+				"operation": "pat.revoked_use",
+				"user_id":   userID,
+				"pat_id":    testsutil.GenerateUUID(t),
+				"pat_name":  "ci-token",
+				"ip":        "192.168.0.1",
+			},
+			user: user,
+			sent: true,
+		},
+		{
+			desc: "handle PAT revoked use event without ip",
+			event: map[string]interface{}{
+				"operation": "pat.revoked_use",
+				"user_id":   userID,
+				"pat_name":  "ci-token",
+			},
+			user: user,
+			sent: true,
+		},
+		{
+			desc: "handle PAT revoked use event without user id",
+			event: map[string]interface{}{
+				"operation": "pat.revoked_use",
+				"pat_name":  "ci-token",
+			},
+			err: errors.New("missing user id in event message"),
+		},
+		{
+			desc: "handle PAT revoked use event of non-existing user",
+			event: map[string]interface{}{
+				"operation": "pat.revoked_use",
+				"user_id":   userID,
+				"pat_name":  "ci-token",
+			},
+			retrieveErr: repoerr.ErrNotFound,
+			err:         repoerr.ErrNotFound,
+		},
+		{
+			desc: "handle PAT revoked use event with failed to send email",
+			event: map[string]interface{}{
+				"operation": "pat.revoked_use",
+				"user_id":   userID,
+				"pat_name":  "ci-token",
+			},
+			user:    user,
+			sendErr: errEmail,
+			sent:    true,
+			err:     errEmail,
+		},
+		{
+			desc: "handle unknown event",
+			event: map[string]interface{}{
+				"operation": "pat.create",
+			},
+		},
+		{
+			desc:  "handle event without operation",
+			event: map[string]interface{}{},
+			err:   errors.New("operation key is not found in event message"),
+		},
+		{
+			desc:      "handle event with encode error",
+			encodeErr: errors.New("failed to encode"),
+			err:       errors.New("failed to encode"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ip, _ := tc.event["ip"].(string)
+			repoCall := repo.On("RetrieveByID", context.Background(), userID).Return(tc.user, tc.retrieveErr)
+			emailCall := emailer.On("SendPATRevokedUse", []string{user.Email}, user.Credentials.Username, "ci-token", ip).Return(tc.sendErr)
+			err := handler.Handle(context.Background(), testEvent{data: tc.event, err: tc.encodeErr})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.sent {
+				emailer.AssertCalled(t, "SendPATRevokedUse", []string{user.Email}, user.Credentials.Username, "ci-token", ip)
+			}
+			repoCall.Unset()
+			emailCall.Unset()
+		})
+	}
+}
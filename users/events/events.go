@@ -32,6 +32,13 @@ const (
 	deleteUser               = userPrefix + "delete"
 	userUpdateUsername       = userPrefix + "update_username"
 	userUpdateProfilePicture = userPrefix + "update_profile_picture"
+	enrollMFA                = userPrefix + "enroll_mfa"
+	verifyMFA                = userPrefix + "verify_mfa"
+	disableMFA               = userPrefix + "disable_mfa"
+	userLock                 = userPrefix + "lock"
+	userUnlock               = userPrefix + "unlock"
+	verifyEmail              = userPrefix + "verify_email"
+	resendVerification       = userPrefix + "resend_verification"
 )
 
 var (
@@ -48,11 +55,16 @@ var (
 	_ events.Event = (*generateResetTokenEvent)(nil)
 	_ events.Event = (*issueTokenEvent)(nil)
 	_ events.Event = (*refreshTokenEvent)(nil)
+	_ events.Event = (*mfaEvent)(nil)
 	_ events.Event = (*resetSecretEvent)(nil)
 	_ events.Event = (*sendPasswordResetEvent)(nil)
 	_ events.Event = (*oauthCallbackEvent)(nil)
 	_ events.Event = (*deleteUserEvent)(nil)
 	_ events.Event = (*addUserPolicyEvent)(nil)
+	_ events.Event = (*lockUserEvent)(nil)
+	_ events.Event = (*unlockUserEvent)(nil)
+	_ events.Event = (*verifyEmailEvent)(nil)
+	_ events.Event = (*resendVerificationEvent)(nil)
 )
 
 type createUserEvent struct {
@@ -423,6 +435,18 @@ func (ite issueTokenEvent) Encode() (map[string]interface{}, error) {
 	}, nil
 }
 
+type mfaEvent struct {
+	operation string
+	username  string
+}
+
+func (me mfaEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": me.operation,
+		"username":  me.username,
+	}, nil
+}
+
 type refreshTokenEvent struct{}
 
 func (rte refreshTokenEvent) Encode() (map[string]interface{}, error) {
@@ -479,6 +503,62 @@ func (dce deleteUserEvent) Encode() (map[string]interface{}, error) {
 	}, nil
 }
 
+type lockUserEvent struct {
+	id       string
+	username string
+}
+
+func (lue lockUserEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": userLock,
+		"id":        lue.id,
+	}
+	if lue.username != "" {
+		val["username"] = lue.username
+	}
+
+	return val, nil
+}
+
+type unlockUserEvent struct {
+	users.User
+	authn.Session
+}
+
+func (uue unlockUserEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   userUnlock,
+		"id":          uue.ID,
+		"token_type":  uue.Type.String(),
+		"super_admin": uue.SuperAdmin,
+	}, nil
+}
+
+type verifyEmailEvent struct {
+	users.User
+}
+
+func (vee verifyEmailEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":  verifyEmail,
+		"id":         vee.ID,
+		"email":      vee.Email,
+		"status":     vee.Status.String(),
+		"updated_at": vee.UpdatedAt,
+	}, nil
+}
+
+type resendVerificationEvent struct {
+	email string
+}
+
+func (rve resendVerificationEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": resendVerification,
+		"email":     rve.email,
+	}, nil
+}
+
 type addUserPolicyEvent struct {
 	id   string
 	role string
@@ -8,6 +8,7 @@ import (
 
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/events"
 	"github.com/absmach/supermq/pkg/events/store"
 	"github.com/absmach/supermq/users"
@@ -135,6 +136,35 @@ func (es *eventStore) UpdateEmail(ctx context.Context, session authn.Session, id
 	return es.update(ctx, session, "email", user)
 }
 
+func (es *eventStore) VerifyEmail(ctx context.Context, token string) (users.User, error) {
+	user, err := es.svc.VerifyEmail(ctx, token)
+	if err != nil {
+		return user, err
+	}
+
+	event := verifyEmailEvent{
+		user,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return user, err
+	}
+
+	return user, nil
+}
+
+func (es *eventStore) ResendVerification(ctx context.Context, email string) error {
+	if err := es.svc.ResendVerification(ctx, email); err != nil {
+		return err
+	}
+
+	event := resendVerificationEvent{
+		email: email,
+	}
+
+	return es.Publish(ctx, event)
+}
+
 func (es *eventStore) update(ctx context.Context, session authn.Session, operation string, user users.User) (users.User, error) {
 	event := updateUserEvent{
 		user, operation, session,
@@ -281,10 +311,10 @@ func (es *eventStore) GenerateResetToken(ctx context.Context, email, host string
 	return es.Publish(ctx, event)
 }
 
-func (es *eventStore) IssueToken(ctx context.Context, username, secret string) (*grpcTokenV1.Token, error) {
-	token, err := es.svc.IssueToken(ctx, username, secret)
+func (es *eventStore) IssueToken(ctx context.Context, username, secret, otp, device, ip string) (*grpcTokenV1.Token, error) {
+	token, err := es.svc.IssueToken(ctx, username, secret, otp, device, ip)
 	if err != nil {
-		return token, err
+		return token, es.lock(ctx, username, err)
 	}
 
 	event := issueTokenEvent{
@@ -298,6 +328,63 @@ func (es *eventStore) IssueToken(ctx context.Context, username, secret string) (
 	return token, nil
 }
 
+func (es *eventStore) IssueMFAToken(ctx context.Context, challenge, otp, device, ip string) (*grpcTokenV1.Token, error) {
+	token, err := es.svc.IssueMFAToken(ctx, challenge, otp, device, ip)
+	if err != nil {
+		return token, es.lock(ctx, "", err)
+	}
+
+	return token, nil
+}
+
+func (es *eventStore) EnrollMFA(ctx context.Context, username, secret string) (users.MFAEnrolment, error) {
+	enrolment, err := es.svc.EnrollMFA(ctx, username, secret)
+	if err != nil {
+		return enrolment, es.lock(ctx, username, err)
+	}
+
+	if err := es.Publish(ctx, mfaEvent{operation: enrollMFA, username: username}); err != nil {
+		return enrolment, err
+	}
+
+	return enrolment, nil
+}
+
+func (es *eventStore) VerifyMFA(ctx context.Context, username, secret, code string) ([]string, error) {
+	codes, err := es.svc.VerifyMFA(ctx, username, secret, code)
+	if err != nil {
+		return codes, es.lock(ctx, username, err)
+	}
+
+	if err := es.Publish(ctx, mfaEvent{operation: verifyMFA, username: username}); err != nil {
+		return codes, err
+	}
+
+	return codes, nil
+}
+
+func (es *eventStore) DisableMFA(ctx context.Context, username, secret, code string) error {
+	if err := es.svc.DisableMFA(ctx, username, secret, code); err != nil {
+		return es.lock(ctx, username, err)
+	}
+
+	return es.Publish(ctx, mfaEvent{operation: disableMFA, username: username})
+}
+
+// lock publishes the lock event if the failed login attempt locked the
+// user account, and returns the original error.
+func (es *eventStore) lock(ctx context.Context, username string, err error) error {
+	id, ok := users.LockedUserID(err)
+	if !ok {
+		return err
+	}
+	if perr := es.Publish(ctx, lockUserEvent{id: id, username: username}); perr != nil {
+		return errors.Wrap(err, perr)
+	}
+
+	return err
+}
+
 func (es *eventStore) RefreshToken(ctx context.Context, session authn.Session, refreshToken string) (*grpcTokenV1.Token, error) {
 	token, err := es.svc.RefreshToken(ctx, session, refreshToken)
 	if err != nil {
@@ -367,6 +454,28 @@ func (es *eventStore) Delete(ctx context.Context, session authn.Session, id stri
 	return es.Publish(ctx, event)
 }
 
+func (es *eventStore) Unlock(ctx context.Context, session authn.Session, id string) (users.User, error) {
+	user, err := es.svc.Unlock(ctx, session, id)
+	if err != nil {
+		return user, err
+	}
+
+	event := unlockUserEvent{
+		user,
+		session,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return user, err
+	}
+
+	return user, nil
+}
+
+func (es *eventStore) OAuthIssueToken(ctx context.Context, userID, device, ip string) (*grpcTokenV1.Token, string, error) {
+	return es.svc.OAuthIssueToken(ctx, userID, device, ip)
+}
+
 func (es *eventStore) OAuthAddUserPolicy(ctx context.Context, user users.User) error {
 	if err := es.svc.OAuthAddUserPolicy(ctx, user); err != nil {
 		return err
@@ -0,0 +1,16 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import "time"
+
+// Identity links the user to the account of the external identity provider,
+// such as the OAuth provider. The account is identified by the subject,
+// which is unique and stable within the provider, unlike the email.
+type Identity struct {
+	Provider  string
+	Subject   string
+	UserID    string
+	CreatedAt time.Time
+}
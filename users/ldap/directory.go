@@ -0,0 +1,245 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/users"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	identityPlaceholder = "%s"
+	groupRoleSeparator  = "|"
+)
+
+var (
+	errDial           = errors.New("failed to connect to LDAP server")
+	errServiceBind    = errors.New("failed to bind LDAP service account")
+	errSearch         = errors.New("failed to search LDAP user")
+	errUserBind       = errors.New("failed to bind LDAP user")
+	errAmbiguousUser  = errors.New("LDAP user filter matched multiple entries")
+	errMissingAttr    = errors.New("missing LDAP user attribute")
+	errInvalidGroup   = errors.New("invalid LDAP group role mapping")
+	errInvalidGroupDN = errors.New("invalid LDAP group DN")
+)
+
+// AttributeMapping maps the LDAP entry attributes to the user fields. The
+// defaults match the inetOrgPerson schema. Active Directory deployments
+// usually map the username to sAMAccountName.
+type AttributeMapping struct {
+	Username  string `env:"USERNAME"   envDefault:"uid"`
+	Email     string `env:"EMAIL"      envDefault:"mail"`
+	FirstName string `env:"FIRST_NAME" envDefault:"givenName"`
+	LastName  string `env:"LAST_NAME"  envDefault:"sn"`
+	Groups    string `env:"GROUPS"     envDefault:"memberOf"`
+}
+
+// Config represents the LDAP directory configuration.
+//
+// UserFilter is the search filter used to find the user entry, where every
+// %s is replaced with the escaped login identity. GroupRoles maps the
+// directory groups to the domain roles, with each mapping in the
+// "<group DN>|<domain ID>|<role ID>" format.
+type Config struct {
+	URL           string           `env:"URL"             envDefault:""`
+	StartTLS      bool             `env:"START_TLS"       envDefault:"false"`
+	SkipTLSVerify bool             `env:"SKIP_TLS_VERIFY" envDefault:"false"`
+	BindDN        string           `env:"BIND_DN"         envDefault:""`
+	BindPassword  string           `env:"BIND_PASSWORD"   envDefault:""`
+	BaseDN        string           `env:"BASE_DN"         envDefault:""`
+	UserFilter    string           `env:"USER_FILTER"     envDefault:"(|(uid=%s)(mail=%s))"`
+	Timeout       time.Duration    `env:"TIMEOUT"         envDefault:"10s"`
+	GroupRoles    []string         `env:"GROUP_ROLES"     envDefault:"" envSeparator:";"`
+	Attributes    AttributeMapping `envPrefix:"ATTR_"`
+}
+
+type groupRole struct {
+	group *ldap.DN
+	role  users.DirectoryRole
+}
+
+type directory struct {
+	cfg        Config
+	groupRoles []groupRole
+	roles      []users.DirectoryRole
+}
+
+var _ users.Directory = (*directory)(nil)
+
+// NewDirectory returns the LDAP implementation of the users directory.
+func NewDirectory(cfg Config) (users.Directory, error) {
+	d := &directory{cfg: cfg}
+	seen := make(map[users.DirectoryRole]bool)
+	for _, m := range cfg.GroupRoles {
+		m = strings.TrimSpace(m)
+		if m == "" {
+			continue
+		}
+		parts := strings.Split(m, groupRoleSeparator)
+		if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+			return nil, errors.Wrap(errInvalidGroup, errors.New(m))
+		}
+		group, err := ldap.ParseDN(parts[0])
+		if err != nil || len(group.RDNs) == 0 {
+			return nil, errors.Wrap(errInvalidGroupDN, errors.New(parts[0]))
+		}
+		role := users.DirectoryRole{DomainID: parts[1], RoleID: parts[2]}
+		d.groupRoles = append(d.groupRoles, groupRole{group: group, role: role})
+		if !seen[role] {
+			seen[role] = true
+			d.roles = append(d.roles, role)
+		}
+	}
+
+	return d, nil
+}
+
+// IsEnabled checks if the LDAP directory is configured.
+func IsEnabled(cfg Config) bool {
+	return cfg.URL != "" && cfg.BaseDN != ""
+}
+
+func (d *directory) Authenticate(_ context.Context, identity, secret string) (users.DirectoryEntry, error) {
+	// LDAP servers accept the bind with the empty password as the
+	// unauthenticated bind, so it must never be treated as valid.
+	if identity == "" || secret == "" {
+		return users.DirectoryEntry{}, users.ErrInvalidDirectoryCredentials
+	}
+
+	conn, err := d.dial()
+	if err != nil {
+		return users.DirectoryEntry{}, err
+	}
+	defer conn.Close()
+
+	if d.cfg.BindDN != "" {
+		if err := conn.Bind(d.cfg.BindDN, d.cfg.BindPassword); err != nil {
+			return users.DirectoryEntry{}, errors.Wrap(errServiceBind, err)
+		}
+	}
+
+	entry, err := d.search(conn, identity)
+	if err != nil {
+		return users.DirectoryEntry{}, err
+	}
+
+	if err := conn.Bind(entry.DN, secret); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			failed := users.DirectoryEntry{
+				User: users.User{Credentials: users.Credentials{Username: entry.GetEqualFoldAttributeValue(d.cfg.Attributes.Username)}},
+			}
+			return failed, users.ErrInvalidDirectoryCredentials
+		}
+		return users.DirectoryEntry{}, errors.Wrap(errUserBind, err)
+	}
+
+	return d.toEntry(entry)
+}
+
+func (d *directory) Roles() []users.DirectoryRole {
+	return d.roles
+}
+
+func (d *directory) dial() (*ldap.Conn, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: d.cfg.SkipTLSVerify}
+	conn, err := ldap.DialURL(d.cfg.URL, ldap.DialWithDialer(&net.Dialer{Timeout: d.cfg.Timeout}), ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, errors.Wrap(errDial, err)
+	}
+	conn.SetTimeout(d.cfg.Timeout)
+
+	if d.cfg.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, errors.Wrap(errDial, err)
+		}
+	}
+
+	return conn, nil
+}
+
+func (d *directory) search(conn *ldap.Conn, identity string) (*ldap.Entry, error) {
+	attrs := d.cfg.Attributes
+	filter := strings.ReplaceAll(d.cfg.UserFilter, identityPlaceholder, ldap.EscapeFilter(identity))
+	req := ldap.NewSearchRequest(
+		d.cfg.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2,
+		int(d.cfg.Timeout.Seconds()),
+		false,
+		filter,
+		[]string{attrs.Username, attrs.Email, attrs.FirstName, attrs.LastName, attrs.Groups},
+		nil,
+	)
+
+	res, err := conn.Search(req)
+	switch {
+	case ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded):
+		return nil, errAmbiguousUser
+	case ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject):
+		return nil, users.ErrDirectoryUserNotFound
+	case err != nil:
+		return nil, errors.Wrap(errSearch, err)
+	}
+
+	switch len(res.Entries) {
+	case 0:
+		return nil, users.ErrDirectoryUserNotFound
+	case 1:
+		return res.Entries[0], nil
+	default:
+		return nil, errAmbiguousUser
+	}
+}
+
+func (d *directory) toEntry(entry *ldap.Entry) (users.DirectoryEntry, error) {
+	attrs := d.cfg.Attributes
+	user := users.User{
+		FirstName: entry.GetEqualFoldAttributeValue(attrs.FirstName),
+		LastName:  entry.GetEqualFoldAttributeValue(attrs.LastName),
+		Email:     entry.GetEqualFoldAttributeValue(attrs.Email),
+		Credentials: users.Credentials{
+			Username: entry.GetEqualFoldAttributeValue(attrs.Username),
+		},
+	}
+	if user.Credentials.Username == "" {
+		return users.DirectoryEntry{}, errors.Wrap(errMissingAttr, errors.New(attrs.Username))
+	}
+	if user.Email == "" {
+		return users.DirectoryEntry{}, errors.Wrap(errMissingAttr, errors.New(attrs.Email))
+	}
+
+	return users.DirectoryEntry{
+		User:  user,
+		Roles: d.resolveRoles(entry.GetEqualFoldAttributeValues(attrs.Groups)),
+	}, nil
+}
+
+func (d *directory) resolveRoles(groups []string) []users.DirectoryRole {
+	var roles []users.DirectoryRole
+	seen := make(map[users.DirectoryRole]bool)
+	for _, g := range groups {
+		dn, err := ldap.ParseDN(g)
+		if err != nil {
+			continue
+		}
+		for _, gr := range d.groupRoles {
+			if seen[gr.role] || !gr.group.EqualFold(dn) {
+				continue
+			}
+			seen[gr.role] = true
+			roles = append(roles, gr.role)
+		}
+	}
+
+	return roles
+}
@@ -0,0 +1,322 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package ldap_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/users"
+	"github.com/absmach/supermq/users/ldap"
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	baseDN        = "dc=example,dc=org"
+	adminDN       = "cn=admin,dc=example,dc=org"
+	adminPassword = "admin-secret"
+	aliceDN       = "uid=alice,ou=people,dc=example,dc=org"
+	alicePassword = "alice-secret"
+	opsGroupDN    = "cn=ops,ou=groups,dc=example,dc=org"
+	devGroupDN    = "cn=dev,ou=groups,dc=example,dc=org"
+	domainID      = "domain-id"
+	adminRoleID   = "admin-role-id"
+	memberRoleID  = "member-role-id"
+
+	appBindRequest   = 0
+	appBindResponse  = 1
+	appUnbindRequest = 2
+	appSearchRequest = 3
+	appSearchEntry   = 4
+	appSearchDone    = 5
+)
+
+type ldapEntry struct {
+	dn       string
+	password string
+	attrs    map[string][]string
+}
+
+var entries = []ldapEntry{
+	{dn: adminDN, password: adminPassword},
+	{
+		dn:       aliceDN,
+		password: alicePassword,
+		attrs: map[string][]string{
+			"uid":       {"alice"},
+			"mail":      {"alice@example.org"},
+			"givenName": {"Alice"},
+			"sn":        {"Smith"},
+			"memberOf":  {"CN=ops, OU=groups, DC=example, DC=org", "cn=other,ou=groups,dc=example,dc=org"},
+		},
+	},
+}
+
+// startServer starts the minimal LDAP server supporting the simple bind and
+// the equality filter search.
+func startServer(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err, fmt.Sprintf("unexpected error starting LDAP server: %s", err))
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serve(conn)
+		}
+	}()
+
+	return "ldap://" + l.Addr().String()
+}
+
+func serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		req, err := ber.ReadPacket(conn)
+		if err != nil || len(req.Children) < 2 {
+			return
+		}
+		id := req.Children[0].Value.(int64)
+		op := req.Children[1]
+		switch op.Tag {
+		case appBindRequest:
+			code := uint16(goldap.LDAPResultInvalidCredentials)
+			name := op.Children[1].Value.(string)
+			password := op.Children[2].Data.String()
+			for _, e := range entries {
+				if strings.EqualFold(e.dn, name) && e.password == password {
+					code = goldap.LDAPResultSuccess
+				}
+			}
+			write(conn, id, result(appBindResponse, code))
+		case appSearchRequest:
+			filter, err := goldap.DecompileFilter(op.Children[6])
+			if err != nil {
+				write(conn, id, result(appSearchDone, goldap.LDAPResultOperationsError))
+				continue
+			}
+			for _, e := range entries {
+				if matches(e, filter) {
+					write(conn, id, searchEntry(e))
+				}
+			}
+			write(conn, id, result(appSearchDone, goldap.LDAPResultSuccess))
+		case appUnbindRequest:
+			return
+		}
+	}
+}
+
+func matches(e ldapEntry, filter string) bool {
+	for attr, vals := range e.attrs {
+		for _, v := range vals {
+			if strings.Contains(filter, fmt.Sprintf("(%s=%s)", attr, goldap.EscapeFilter(v))) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func write(conn net.Conn, id int64, op *ber.Packet) {
+	p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "Message ID"))
+	p.AppendChild(op)
+	_, _ = conn.Write(p.Bytes())
+}
+
+func result(tag ber.Tag, code uint16) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+
+	return op
+}
+
+func searchEntry(e ldapEntry) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, appSearchEntry, nil, "Search Result Entry")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "Object Name"))
+	attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for name, vals := range e.attrs {
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, v := range vals {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+		}
+		attr.AppendChild(set)
+		attrs.AppendChild(attr)
+	}
+	op.AppendChild(attrs)
+
+	return op
+}
+
+func newConfig(url string) ldap.Config {
+	return ldap.Config{
+		URL:          url,
+		BindDN:       adminDN,
+		BindPassword: adminPassword,
+		BaseDN:       baseDN,
+		UserFilter:   "(|(uid=%s)(mail=%s))",
+		Timeout:      5 * time.Second,
+		GroupRoles: []string{
+			opsGroupDN + "|" + domainID + "|" + adminRoleID,
+			devGroupDN + "|" + domainID + "|" + memberRoleID,
+		},
+		Attributes: ldap.AttributeMapping{
+			Username:  "uid",
+			Email:     "mail",
+			FirstName: "givenName",
+			LastName:  "sn",
+			Groups:    "memberOf",
+		},
+	}
+}
+
+func TestNewDirectory(t *testing.T) {
+	cases := []struct {
+		desc       string
+		groupRoles []string
+		roles      []users.DirectoryRole
+		err        bool
+	}{
+		{
+			desc:       "new directory with valid group roles",
+			groupRoles: []string{opsGroupDN + "|" + domainID + "|" + adminRoleID, " ", "cn=admins,dc=example,dc=org|" + domainID + "|" + adminRoleID},
+			roles:      []users.DirectoryRole{{DomainID: domainID, RoleID: adminRoleID}},
+		},
+		{
+			desc:       "new directory with missing role ID",
+			groupRoles: []string{opsGroupDN + "|" + domainID},
+			err:        true,
+		},
+		{
+			desc:       "new directory with empty domain ID",
+			groupRoles: []string{opsGroupDN + "||" + adminRoleID},
+			err:        true,
+		},
+		{
+			desc:       "new directory with invalid group DN",
+			groupRoles: []string{"invalid|" + domainID + "|" + adminRoleID},
+			err:        true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := newConfig("ldap://localhost")
+			cfg.GroupRoles = tc.groupRoles
+			dir, err := ldap.NewDirectory(cfg)
+			if tc.err {
+				assert.NotNil(t, err, fmt.Sprintf("%s: expected error, got nil", tc.desc))
+				return
+			}
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.roles, dir.Roles(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.roles, dir.Roles()))
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	url := startServer(t)
+
+	alice := users.DirectoryEntry{
+		User: users.User{
+			FirstName: "Alice",
+			LastName:  "Smith",
+			Email:     "alice@example.org",
+			Credentials: users.Credentials{
+				Username: "alice",
+			},
+		},
+		Roles: []users.DirectoryRole{{DomainID: domainID, RoleID: adminRoleID}},
+	}
+
+	cases := []struct {
+		desc         string
+		bindPassword string
+		identity     string
+		secret       string
+		entry        users.DirectoryEntry
+		err          error
+	}{
+		{
+			desc:         "authenticate with username",
+			bindPassword: adminPassword,
+			identity:     "alice",
+			secret:       alicePassword,
+			entry:        alice,
+		},
+		{
+			desc:         "authenticate with email",
+			bindPassword: adminPassword,
+			identity:     "alice@example.org",
+			secret:       alicePassword,
+			entry:        alice,
+		},
+		{
+			desc:         "authenticate with invalid password",
+			bindPassword: adminPassword,
+			identity:     "alice",
+			secret:       "invalid",
+			entry:        users.DirectoryEntry{User: users.User{Credentials: users.Credentials{Username: "alice"}}},
+			err:          users.ErrInvalidDirectoryCredentials,
+		},
+		{
+			desc:         "authenticate with empty password",
+			bindPassword: adminPassword,
+			identity:     "alice",
+			secret:       "",
+			err:          users.ErrInvalidDirectoryCredentials,
+		},
+		{
+			desc:         "authenticate unknown user",
+			bindPassword: adminPassword,
+			identity:     "bob",
+			secret:       alicePassword,
+			err:          users.ErrDirectoryUserNotFound,
+		},
+		{
+			desc:         "authenticate with filter injection",
+			bindPassword: adminPassword,
+			identity:     "*",
+			secret:       alicePassword,
+			err:          users.ErrDirectoryUserNotFound,
+		},
+		{
+			desc:         "authenticate with invalid service account password",
+			bindPassword: "invalid",
+			identity:     "alice",
+			secret:       alicePassword,
+			err:          errors.New("failed to bind LDAP service account"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := newConfig(url)
+			cfg.BindPassword = tc.bindPassword
+			dir, err := ldap.NewDirectory(cfg)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+			entry, err := dir.Authenticate(context.Background(), tc.identity, tc.secret)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.entry, entry, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.entry, entry))
+		})
+	}
+}
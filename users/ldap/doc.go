@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ldap contains the LDAP and Active Directory implementation of
+// the users directory, which authenticates users using the LDAP bind
+// operation and maps the directory groups to the domain roles.
+package ldap
@@ -0,0 +1,54 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+var (
+	// ErrMFAEnabled indicates that multi-factor authentication is already enabled.
+	ErrMFAEnabled = errors.New("multi-factor authentication already enabled")
+
+	// ErrMFANotEnrolled indicates that the user has not enrolled in multi-factor authentication.
+	ErrMFANotEnrolled = errors.New("multi-factor authentication not enrolled")
+
+	// ErrInvalidMFAChallenge indicates that the MFA challenge is unknown, used or expired.
+	ErrInvalidMFAChallenge = errors.New("invalid or expired multi-factor authentication challenge")
+)
+
+// MFA represents the user TOTP multi-factor authentication settings.
+// The secret is kept pending until the enrolment is verified with a
+// valid code, and the recovery codes are stored hashed. LastStep is the
+// TOTP time step of the last used code, so that the code is not reused.
+type MFA struct {
+	UserID        string
+	Secret        string
+	Enabled       bool
+	RecoveryCodes []string
+	LastStep      uint64
+}
+
+// MFAChallenge represents the pending MFA step of the user signed in with
+// the OAuth provider. Only the hash of the challenge token is stored, and
+// the challenge is removed once used.
+type MFAChallenge struct {
+	UserID    string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+// Expired returns true if the challenge expired at the given time.
+func (c MFAChallenge) Expired(t time.Time) bool {
+	return !c.ExpiresAt.After(t)
+}
+
+// MFAEnrolment contains the TOTP secret and the provisioning URI that
+// is shown to the user as a QR code in the authenticator app.
+type MFAEnrolment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
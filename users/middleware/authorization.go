@@ -48,6 +48,7 @@ func (am *authorizationMiddleware) View(ctx context.Context, session authn.Sessi
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       smqauth.PlatformUsersScope,
 			OptionalDomainEntityType: smqauth.DomainNullScope,
 			Operation:                smqauth.ReadOp,
@@ -69,6 +70,7 @@ func (am *authorizationMiddleware) ViewProfile(ctx context.Context, session auth
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       smqauth.PlatformUsersScope,
 			OptionalDomainEntityType: smqauth.DomainNullScope,
 			Operation:                smqauth.ReadOp,
@@ -85,6 +87,7 @@ func (am *authorizationMiddleware) ListUsers(ctx context.Context, session authn.
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       smqauth.PlatformUsersScope,
 			OptionalDomainEntityType: smqauth.DomainNullScope,
 			Operation:                smqauth.ListOp,
@@ -109,6 +112,7 @@ func (am *authorizationMiddleware) Update(ctx context.Context, session authn.Ses
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       smqauth.PlatformUsersScope,
 			OptionalDomainEntityType: smqauth.DomainNullScope,
 			Operation:                smqauth.UpdateOp,
@@ -130,6 +134,7 @@ func (am *authorizationMiddleware) UpdateTags(ctx context.Context, session authn
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       smqauth.PlatformUsersScope,
 			OptionalDomainEntityType: smqauth.DomainNullScope,
 			Operation:                smqauth.UpdateOp,
@@ -151,6 +156,7 @@ func (am *authorizationMiddleware) UpdateEmail(ctx context.Context, session auth
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       smqauth.PlatformUsersScope,
 			OptionalDomainEntityType: smqauth.DomainNullScope,
 			Operation:                smqauth.UpdateOp,
@@ -166,11 +172,20 @@ func (am *authorizationMiddleware) UpdateEmail(ctx context.Context, session auth
 	return am.svc.UpdateEmail(ctx, session, id, email)
 }
 
+func (am *authorizationMiddleware) VerifyEmail(ctx context.Context, token string) (users.User, error) {
+	return am.svc.VerifyEmail(ctx, token)
+}
+
+func (am *authorizationMiddleware) ResendVerification(ctx context.Context, email string) error {
+	return am.svc.ResendVerification(ctx, email)
+}
+
 func (am *authorizationMiddleware) UpdateUsername(ctx context.Context, session authn.Session, id, username string) (users.User, error) {
 	if session.Type == authn.PersonalAccessToken {
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       smqauth.PlatformUsersScope,
 			OptionalDomainEntityType: smqauth.DomainNullScope,
 			Operation:                smqauth.UpdateOp,
@@ -192,6 +207,7 @@ func (am *authorizationMiddleware) UpdateProfilePicture(ctx context.Context, ses
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       smqauth.PlatformUsersScope,
 			OptionalDomainEntityType: smqauth.DomainNullScope,
 			Operation:                smqauth.UpdateOp,
@@ -217,6 +233,7 @@ func (am *authorizationMiddleware) UpdateSecret(ctx context.Context, session aut
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       smqauth.PlatformUsersScope,
 			OptionalDomainEntityType: smqauth.DomainNullScope,
 			Operation:                smqauth.UpdateOp,
@@ -242,6 +259,7 @@ func (am *authorizationMiddleware) UpdateRole(ctx context.Context, session authn
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       smqauth.PlatformUsersScope,
 			OptionalDomainEntityType: smqauth.DomainNullScope,
 			Operation:                smqauth.UpdateOp,
@@ -267,6 +285,7 @@ func (am *authorizationMiddleware) Enable(ctx context.Context, session authn.Ses
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       smqauth.PlatformUsersScope,
 			OptionalDomainEntityType: smqauth.DomainNullScope,
 			Operation:                smqauth.UpdateOp,
@@ -288,6 +307,7 @@ func (am *authorizationMiddleware) Disable(ctx context.Context, session authn.Se
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       smqauth.PlatformUsersScope,
 			OptionalDomainEntityType: smqauth.DomainNullScope,
 			Operation:                smqauth.UpdateOp,
@@ -309,6 +329,7 @@ func (am *authorizationMiddleware) Delete(ctx context.Context, session authn.Ses
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
 			UserID:                   session.UserID,
 			PatID:                    session.PatID,
+			IP:                       session.IP,
 			PlatformEntityType:       smqauth.PlatformUsersScope,
 			OptionalDomainEntityType: smqauth.DomainNullScope,
 			Operation:                smqauth.DeleteOp,
@@ -325,12 +346,50 @@ func (am *authorizationMiddleware) Delete(ctx context.Context, session authn.Ses
 	return am.svc.Delete(ctx, session, id)
 }
 
+func (am *authorizationMiddleware) Unlock(ctx context.Context, session authn.Session, id string) (users.User, error) {
+	if session.Type == authn.PersonalAccessToken {
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			IP:                       session.IP,
+			PlatformEntityType:       smqauth.PlatformUsersScope,
+			OptionalDomainEntityType: smqauth.DomainNullScope,
+			Operation:                smqauth.UpdateOp,
+			EntityIDs:                []string{id},
+		}); err != nil {
+			return users.User{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+	}
+
+	if err := am.checkSuperAdmin(ctx, session.UserID); err == nil {
+		session.SuperAdmin = true
+	}
+
+	return am.svc.Unlock(ctx, session, id)
+}
+
 func (am *authorizationMiddleware) Identify(ctx context.Context, session authn.Session) (string, error) {
 	return am.svc.Identify(ctx, session)
 }
 
-func (am *authorizationMiddleware) IssueToken(ctx context.Context, username, secret string) (*grpcTokenV1.Token, error) {
-	return am.svc.IssueToken(ctx, username, secret)
+func (am *authorizationMiddleware) IssueToken(ctx context.Context, username, secret, otp, device, ip string) (*grpcTokenV1.Token, error) {
+	return am.svc.IssueToken(ctx, username, secret, otp, device, ip)
+}
+
+func (am *authorizationMiddleware) IssueMFAToken(ctx context.Context, challenge, otp, device, ip string) (*grpcTokenV1.Token, error) {
+	return am.svc.IssueMFAToken(ctx, challenge, otp, device, ip)
+}
+
+func (am *authorizationMiddleware) EnrollMFA(ctx context.Context, username, secret string) (users.MFAEnrolment, error) {
+	return am.svc.EnrollMFA(ctx, username, secret)
+}
+
+func (am *authorizationMiddleware) VerifyMFA(ctx context.Context, username, secret, code string) ([]string, error) {
+	return am.svc.VerifyMFA(ctx, username, secret, code)
+}
+
+func (am *authorizationMiddleware) DisableMFA(ctx context.Context, username, secret, code string) error {
+	return am.svc.DisableMFA(ctx, username, secret, code)
 }
 
 func (am *authorizationMiddleware) RefreshToken(ctx context.Context, session authn.Session, refreshToken string) (*grpcTokenV1.Token, error) {
@@ -341,6 +400,10 @@ func (am *authorizationMiddleware) OAuthCallback(ctx context.Context, user users
 	return am.svc.OAuthCallback(ctx, user)
 }
 
+func (am *authorizationMiddleware) OAuthIssueToken(ctx context.Context, userID, device, ip string) (*grpcTokenV1.Token, string, error) {
+	return am.svc.OAuthIssueToken(ctx, userID, device, ip)
+}
+
 func (am *authorizationMiddleware) OAuthAddUserPolicy(ctx context.Context, user users.User) error {
 	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, user.ID, policies.MembershipPermission, policies.PlatformType, policies.SuperMQObject); err == nil {
 		return nil
@@ -50,7 +50,7 @@ func (lm *loggingMiddleware) Register(ctx context.Context, session authn.Session
 
 // IssueToken logs the issue_token request. It logs the username type and the time it took to complete the request.
 // If the request fails, it logs the error.
-func (lm *loggingMiddleware) IssueToken(ctx context.Context, username, secret string) (t *grpcTokenV1.Token, err error) {
+func (lm *loggingMiddleware) IssueToken(ctx context.Context, username, secret, otp, device, ip string) (t *grpcTokenV1.Token, err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
@@ -65,7 +65,75 @@ func (lm *loggingMiddleware) IssueToken(ctx context.Context, username, secret st
 		}
 		lm.logger.Info("Issue token completed successfully", args...)
 	}(time.Now())
-	return lm.svc.IssueToken(ctx, username, secret)
+	return lm.svc.IssueToken(ctx, username, secret, otp, device, ip)
+}
+
+// IssueMFAToken logs the issue_mfa_token request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) IssueMFAToken(ctx context.Context, challenge, otp, device, ip string) (t *grpcTokenV1.Token, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Issue MFA token failed", args...)
+			return
+		}
+		lm.logger.Info("Issue MFA token completed successfully", args...)
+	}(time.Now())
+	return lm.svc.IssueMFAToken(ctx, challenge, otp, device, ip)
+}
+
+// EnrollMFA logs the enroll_mfa request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) EnrollMFA(ctx context.Context, username, secret string) (e users.MFAEnrolment, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Enroll MFA failed", args...)
+			return
+		}
+		lm.logger.Info("Enroll MFA completed successfully", args...)
+	}(time.Now())
+	return lm.svc.EnrollMFA(ctx, username, secret)
+}
+
+// VerifyMFA logs the verify_mfa request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) VerifyMFA(ctx context.Context, username, secret, code string) (codes []string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Verify MFA failed", args...)
+			return
+		}
+		lm.logger.Info("Verify MFA completed successfully", args...)
+	}(time.Now())
+	return lm.svc.VerifyMFA(ctx, username, secret, code)
+}
+
+// DisableMFA logs the disable_mfa request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) DisableMFA(ctx context.Context, username, secret, code string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Disable MFA failed", args...)
+			return
+		}
+		lm.logger.Info("Disable MFA completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DisableMFA(ctx, username, secret, code)
 }
 
 // RefreshToken logs the refresh_token request. It logs the refreshtoken, token type and the time it took to complete the request.
@@ -238,6 +306,44 @@ func (lm *loggingMiddleware) UpdateEmail(ctx context.Context, session authn.Sess
 	return lm.svc.UpdateEmail(ctx, session, id, email)
 }
 
+// VerifyEmail logs the verify_email request. It logs the user id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) VerifyEmail(ctx context.Context, token string) (c users.User, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("user",
+				slog.String("id", c.ID),
+				slog.String("email", c.Email),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Verify user email failed", args...)
+			return
+		}
+		lm.logger.Info("Verify user email completed successfully", args...)
+	}(time.Now())
+	return lm.svc.VerifyEmail(ctx, token)
+}
+
+// ResendVerification logs the resend_verification request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ResendVerification(ctx context.Context, email string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Resend email verification failed", args...)
+			return
+		}
+		lm.logger.Info("Resend email verification completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ResendVerification(ctx, email)
+}
+
 // UpdateSecret logs the update_user_secret request. It logs the user id and the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) UpdateSecret(ctx context.Context, session authn.Session, oldSecret, newSecret string) (c users.User, err error) {
@@ -464,6 +570,43 @@ func (lm *loggingMiddleware) Delete(ctx context.Context, session authn.Session,
 	return lm.svc.Delete(ctx, session, id)
 }
 
+// Unlock logs the unlock_user request. It logs the user id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Unlock(ctx context.Context, session authn.Session, id string) (u users.User, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Unlock user failed", args...)
+			return
+		}
+		lm.logger.Info("Unlock user completed successfully", args...)
+	}(time.Now())
+	return lm.svc.Unlock(ctx, session, id)
+}
+
+// OAuthIssueToken logs the oauth_issue_token request. It logs the user id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) OAuthIssueToken(ctx context.Context, userID, device, ip string) (t *grpcTokenV1.Token, challenge string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", userID),
+			slog.Bool("mfa_challenge", challenge != ""),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("OAuth issue token failed", args...)
+			return
+		}
+		lm.logger.Info("OAuth issue token completed successfully", args...)
+	}(time.Now())
+	return lm.svc.OAuthIssueToken(ctx, userID, device, ip)
+}
+
 // OAuthAddUserPolicy logs the add_user_policy request. It logs the user id and the time it took to complete the request.
 func (lm *loggingMiddleware) OAuthAddUserPolicy(ctx context.Context, user users.User) (err error) {
 	defer func(begin time.Time) {
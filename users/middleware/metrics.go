@@ -40,12 +40,48 @@ func (ms *metricsMiddleware) Register(ctx context.Context, session authn.Session
 }
 
 // IssueToken instruments IssueToken method with metrics.
-func (ms *metricsMiddleware) IssueToken(ctx context.Context, username, secret string) (*grpcTokenV1.Token, error) {
+func (ms *metricsMiddleware) IssueToken(ctx context.Context, username, secret, otp, device, ip string) (*grpcTokenV1.Token, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "issue_token").Add(1)
 		ms.latency.With("method", "issue_token").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.IssueToken(ctx, username, secret)
+	return ms.svc.IssueToken(ctx, username, secret, otp, device, ip)
+}
+
+// IssueMFAToken instruments IssueMFAToken method with metrics.
+func (ms *metricsMiddleware) IssueMFAToken(ctx context.Context, challenge, otp, device, ip string) (*grpcTokenV1.Token, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "issue_mfa_token").Add(1)
+		ms.latency.With("method", "issue_mfa_token").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.IssueMFAToken(ctx, challenge, otp, device, ip)
+}
+
+// EnrollMFA instruments EnrollMFA method with metrics.
+func (ms *metricsMiddleware) EnrollMFA(ctx context.Context, username, secret string) (users.MFAEnrolment, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "enroll_mfa").Add(1)
+		ms.latency.With("method", "enroll_mfa").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.EnrollMFA(ctx, username, secret)
+}
+
+// VerifyMFA instruments VerifyMFA method with metrics.
+func (ms *metricsMiddleware) VerifyMFA(ctx context.Context, username, secret, code string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "verify_mfa").Add(1)
+		ms.latency.With("method", "verify_mfa").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.VerifyMFA(ctx, username, secret, code)
+}
+
+// DisableMFA instruments DisableMFA method with metrics.
+func (ms *metricsMiddleware) DisableMFA(ctx context.Context, username, secret, code string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "disable_mfa").Add(1)
+		ms.latency.With("method", "disable_mfa").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DisableMFA(ctx, username, secret, code)
 }
 
 // RefreshToken instruments RefreshToken method with metrics.
@@ -120,6 +156,24 @@ func (ms *metricsMiddleware) UpdateEmail(ctx context.Context, session authn.Sess
 	return ms.svc.UpdateEmail(ctx, session, id, email)
 }
 
+// VerifyEmail instruments VerifyEmail method with metrics.
+func (ms *metricsMiddleware) VerifyEmail(ctx context.Context, token string) (users.User, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "verify_email").Add(1)
+		ms.latency.With("method", "verify_email").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.VerifyEmail(ctx, token)
+}
+
+// ResendVerification instruments ResendVerification method with metrics.
+func (ms *metricsMiddleware) ResendVerification(ctx context.Context, email string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "resend_verification").Add(1)
+		ms.latency.With("method", "resend_verification").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ResendVerification(ctx, email)
+}
+
 // UpdateSecret instruments UpdateSecret method with metrics.
 func (ms *metricsMiddleware) UpdateSecret(ctx context.Context, session authn.Session, oldSecret, newSecret string) (users.User, error) {
 	defer func(begin time.Time) {
@@ -228,6 +282,24 @@ func (ms *metricsMiddleware) Delete(ctx context.Context, session authn.Session,
 	return ms.svc.Delete(ctx, session, id)
 }
 
+// Unlock instruments Unlock method with metrics.
+func (ms *metricsMiddleware) Unlock(ctx context.Context, session authn.Session, id string) (users.User, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unlock_user").Add(1)
+		ms.latency.With("method", "unlock_user").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Unlock(ctx, session, id)
+}
+
+// OAuthIssueToken instruments OAuthIssueToken method with metrics.
+func (ms *metricsMiddleware) OAuthIssueToken(ctx context.Context, userID, device, ip string) (*grpcTokenV1.Token, string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "oauth_issue_token").Add(1)
+		ms.latency.With("method", "oauth_issue_token").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.OAuthIssueToken(ctx, userID, device, ip)
+}
+
 // OAuthAddUserPolicy instruments OAuthAddUserPolicy method with metrics.
 func (ms *metricsMiddleware) OAuthAddUserPolicy(ctx context.Context, user users.User) error {
 	defer func(begin time.Time) {
@@ -16,6 +16,24 @@ type Repository struct {
 	mock.Mock
 }
 
+// AddSecretHistory provides a mock function with given fields: ctx, userID, secret, keep
+func (_m *Repository) AddSecretHistory(ctx context.Context, userID string, secret string, keep uint) error {
+	ret := _m.Called(ctx, userID, secret, keep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSecretHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint) error); ok {
+		r0 = rf(ctx, userID, secret, keep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ChangeStatus provides a mock function with given fields: ctx, user
 func (_m *Repository) ChangeStatus(ctx context.Context, user users.User) (users.User, error) {
 	ret := _m.Called(ctx, user)
@@ -80,6 +98,116 @@ func (_m *Repository) Delete(ctx context.Context, id string) error {
 	return r0
 }
 
+// IncrementLoginAttempts provides a mock function with given fields: ctx, userID
+func (_m *Repository) IncrementLoginAttempts(ctx context.Context, userID string) (users.LoginAttempts, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementLoginAttempts")
+	}
+
+	var r0 users.LoginAttempts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (users.LoginAttempts, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) users.LoginAttempts); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(users.LoginAttempts)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveLoginAttempts provides a mock function with given fields: ctx, userID
+func (_m *Repository) RemoveLoginAttempts(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveLoginAttempts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveMFA provides a mock function with given fields: ctx, userID
+func (_m *Repository) RemoveMFA(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveMFAChallenge provides a mock function with given fields: ctx, tokenHash
+func (_m *Repository) RemoveMFAChallenge(ctx context.Context, tokenHash string) (users.MFAChallenge, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMFAChallenge")
+	}
+
+	var r0 users.MFAChallenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (users.MFAChallenge, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) users.MFAChallenge); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(users.MFAChallenge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveVerification provides a mock function with given fields: ctx, userID
+func (_m *Repository) RemoveVerification(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RetrieveAll provides a mock function with given fields: ctx, pm
 func (_m *Repository) RetrieveAll(ctx context.Context, pm users.Page) (users.UsersPage, error) {
 	ret := _m.Called(ctx, pm)
@@ -192,6 +320,34 @@ func (_m *Repository) RetrieveByID(ctx context.Context, id string) (users.User,
 	return r0, r1
 }
 
+// RetrieveByIdentity provides a mock function with given fields: ctx, provider, subject
+func (_m *Repository) RetrieveByIdentity(ctx context.Context, provider string, subject string) (users.User, error) {
+	ret := _m.Called(ctx, provider, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveByIdentity")
+	}
+
+	var r0 users.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (users.User, error)); ok {
+		return rf(ctx, provider, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) users.User); ok {
+		r0 = rf(ctx, provider, subject)
+	} else {
+		r0 = ret.Get(0).(users.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveByUsername provides a mock function with given fields: ctx, username
 func (_m *Repository) RetrieveByUsername(ctx context.Context, username string) (users.User, error) {
 	ret := _m.Called(ctx, username)
@@ -220,6 +376,148 @@ func (_m *Repository) RetrieveByUsername(ctx context.Context, username string) (
 	return r0, r1
 }
 
+// RetrieveLoginAttempts provides a mock function with given fields: ctx, userID
+func (_m *Repository) RetrieveLoginAttempts(ctx context.Context, userID string) (users.LoginAttempts, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveLoginAttempts")
+	}
+
+	var r0 users.LoginAttempts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (users.LoginAttempts, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) users.LoginAttempts); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(users.LoginAttempts)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveMFA provides a mock function with given fields: ctx, userID
+func (_m *Repository) RetrieveMFA(ctx context.Context, userID string) (users.MFA, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveMFA")
+	}
+
+	var r0 users.MFA
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (users.MFA, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) users.MFA); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(users.MFA)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveSecretHistory provides a mock function with given fields: ctx, userID, limit
+func (_m *Repository) RetrieveSecretHistory(ctx context.Context, userID string, limit uint) ([]string, error) {
+	ret := _m.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveSecretHistory")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) ([]string, error)); ok {
+		return rf(ctx, userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) []string); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint) error); ok {
+		r1 = rf(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveVerification provides a mock function with given fields: ctx, tokenHash
+func (_m *Repository) RetrieveVerification(ctx context.Context, tokenHash string) (users.Verification, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveVerification")
+	}
+
+	var r0 users.Verification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (users.Verification, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) users.Verification); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(users.Verification)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveVerificationByEmail provides a mock function with given fields: ctx, email
+func (_m *Repository) RetrieveVerificationByEmail(ctx context.Context, email string) (users.Verification, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveVerificationByEmail")
+	}
+
+	var r0 users.Verification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (users.Verification, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) users.Verification); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(users.Verification)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: ctx, user
 func (_m *Repository) Save(ctx context.Context, user users.User) (users.User, error) {
 	ret := _m.Called(ctx, user)
@@ -248,6 +546,96 @@ func (_m *Repository) Save(ctx context.Context, user users.User) (users.User, er
 	return r0, r1
 }
 
+// SaveIdentity provides a mock function with given fields: ctx, identity
+func (_m *Repository) SaveIdentity(ctx context.Context, identity users.Identity) error {
+	ret := _m.Called(ctx, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, users.Identity) error); ok {
+		r0 = rf(ctx, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveLoginAttempts provides a mock function with given fields: ctx, attempts
+func (_m *Repository) SaveLoginAttempts(ctx context.Context, attempts users.LoginAttempts) error {
+	ret := _m.Called(ctx, attempts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveLoginAttempts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, users.LoginAttempts) error); ok {
+		r0 = rf(ctx, attempts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveMFA provides a mock function with given fields: ctx, mfa
+func (_m *Repository) SaveMFA(ctx context.Context, mfa users.MFA) error {
+	ret := _m.Called(ctx, mfa)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, users.MFA) error); ok {
+		r0 = rf(ctx, mfa)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveMFAChallenge provides a mock function with given fields: ctx, challenge
+func (_m *Repository) SaveMFAChallenge(ctx context.Context, challenge users.MFAChallenge) error {
+	ret := _m.Called(ctx, challenge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveMFAChallenge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, users.MFAChallenge) error); ok {
+		r0 = rf(ctx, challenge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveVerification provides a mock function with given fields: ctx, verification
+func (_m *Repository) SaveVerification(ctx context.Context, verification users.Verification) error {
+	ret := _m.Called(ctx, verification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, users.Verification) error); ok {
+		r0 = rf(ctx, verification)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SearchUsers provides a mock function with given fields: ctx, pm
 func (_m *Repository) SearchUsers(ctx context.Context, pm users.Page) (users.UsersPage, error) {
 	ret := _m.Called(ctx, pm)
@@ -304,6 +692,42 @@ func (_m *Repository) Update(ctx context.Context, user users.User) (users.User,
 	return r0, r1
 }
 
+// UpdateMFA provides a mock function with given fields: ctx, mfa
+func (_m *Repository) UpdateMFA(ctx context.Context, mfa users.MFA) error {
+	ret := _m.Called(ctx, mfa)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, users.MFA) error); ok {
+		r0 = rf(ctx, mfa)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMFAStep provides a mock function with given fields: ctx, userID, step
+func (_m *Repository) UpdateMFAStep(ctx context.Context, userID string, step uint64) error {
+	ret := _m.Called(ctx, userID, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMFAStep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) error); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateSecret provides a mock function with given fields: ctx, user
 func (_m *Repository) UpdateSecret(ctx context.Context, user users.User) (users.User, error) {
 	ret := _m.Called(ctx, user)
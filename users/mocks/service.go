@@ -67,6 +67,24 @@ func (_m *Service) Disable(ctx context.Context, session authn.Session, id string
 	return r0, r1
 }
 
+// DisableMFA provides a mock function with given fields: ctx, identity, secret, code
+func (_m *Service) DisableMFA(ctx context.Context, identity string, secret string, code string) error {
+	ret := _m.Called(ctx, identity, secret, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, identity, secret, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Enable provides a mock function with given fields: ctx, session, id
 func (_m *Service) Enable(ctx context.Context, session authn.Session, id string) (users.User, error) {
 	ret := _m.Called(ctx, session, id)
@@ -95,6 +113,34 @@ func (_m *Service) Enable(ctx context.Context, session authn.Session, id string)
 	return r0, r1
 }
 
+// EnrollMFA provides a mock function with given fields: ctx, identity, secret
+func (_m *Service) EnrollMFA(ctx context.Context, identity string, secret string) (users.MFAEnrolment, error) {
+	ret := _m.Called(ctx, identity, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollMFA")
+	}
+
+	var r0 users.MFAEnrolment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (users.MFAEnrolment, error)); ok {
+		return rf(ctx, identity, secret)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) users.MFAEnrolment); ok {
+		r0 = rf(ctx, identity, secret)
+	} else {
+		r0 = ret.Get(0).(users.MFAEnrolment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, identity, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GenerateResetToken provides a mock function with given fields: ctx, email, host
 func (_m *Service) GenerateResetToken(ctx context.Context, email string, host string) error {
 	ret := _m.Called(ctx, email, host)
@@ -141,9 +187,39 @@ func (_m *Service) Identify(ctx context.Context, session authn.Session) (string,
 	return r0, r1
 }
 
-// IssueToken provides a mock function with given fields: ctx, identity, secret
-func (_m *Service) IssueToken(ctx context.Context, identity string, secret string) (*v1.Token, error) {
-	ret := _m.Called(ctx, identity, secret)
+// IssueMFAToken provides a mock function with given fields: ctx, challenge, otp, device, ip
+func (_m *Service) IssueMFAToken(ctx context.Context, challenge string, otp string, device string, ip string) (*v1.Token, error) {
+	ret := _m.Called(ctx, challenge, otp, device, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueMFAToken")
+	}
+
+	var r0 *v1.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*v1.Token, error)); ok {
+		return rf(ctx, challenge, otp, device, ip)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *v1.Token); ok {
+		r0 = rf(ctx, challenge, otp, device, ip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, challenge, otp, device, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueToken provides a mock function with given fields: ctx, identity, secret, otp, device, ip
+func (_m *Service) IssueToken(ctx context.Context, identity string, secret string, otp string, device string, ip string) (*v1.Token, error) {
+	ret := _m.Called(ctx, identity, secret, otp, device, ip)
 
 	if len(ret) == 0 {
 		panic("no return value specified for IssueToken")
@@ -151,19 +227,19 @@ func (_m *Service) IssueToken(ctx context.Context, identity string, secret strin
 
 	var r0 *v1.Token
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v1.Token, error)); ok {
-		return rf(ctx, identity, secret)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) (*v1.Token, error)); ok {
+		return rf(ctx, identity, secret, otp, device, ip)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1.Token); ok {
-		r0 = rf(ctx, identity, secret)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) *v1.Token); ok {
+		r0 = rf(ctx, identity, secret, otp, device, ip)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*v1.Token)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, identity, secret)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, string) error); ok {
+		r1 = rf(ctx, identity, secret, otp, device, ip)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -245,6 +321,43 @@ func (_m *Service) OAuthCallback(ctx context.Context, user users.User) (users.Us
 	return r0, r1
 }
 
+// OAuthIssueToken provides a mock function with given fields: ctx, userID, device, ip
+func (_m *Service) OAuthIssueToken(ctx context.Context, userID string, device string, ip string) (*v1.Token, string, error) {
+	ret := _m.Called(ctx, userID, device, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OAuthIssueToken")
+	}
+
+	var r0 *v1.Token
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*v1.Token, string, error)); ok {
+		return rf(ctx, userID, device, ip)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *v1.Token); ok {
+		r0 = rf(ctx, userID, device, ip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) string); ok {
+		r1 = rf(ctx, userID, device, ip)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string) error); ok {
+		r2 = rf(ctx, userID, device, ip)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // RefreshToken provides a mock function with given fields: ctx, session, refreshToken
 func (_m *Service) RefreshToken(ctx context.Context, session authn.Session, refreshToken string) (*v1.Token, error) {
 	ret := _m.Called(ctx, session, refreshToken)
@@ -303,6 +416,24 @@ func (_m *Service) Register(ctx context.Context, session authn.Session, user use
 	return r0, r1
 }
 
+// ResendVerification provides a mock function with given fields: ctx, email
+func (_m *Service) ResendVerification(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetSecret provides a mock function with given fields: ctx, session, secret
 func (_m *Service) ResetSecret(ctx context.Context, session authn.Session, secret string) error {
 	ret := _m.Called(ctx, session, secret)
@@ -367,6 +498,34 @@ func (_m *Service) SendPasswordReset(ctx context.Context, host string, email str
 	return r0
 }
 
+// Unlock provides a mock function with given fields: ctx, session, id
+func (_m *Service) Unlock(ctx context.Context, session authn.Session, id string) (users.User, error) {
+	ret := _m.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unlock")
+	}
+
+	var r0 users.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) (users.User, error)); ok {
+		return rf(ctx, session, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) users.User); ok {
+		r0 = rf(ctx, session, id)
+	} else {
+		r0 = ret.Get(0).(users.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = rf(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, session, user
 func (_m *Service) Update(ctx context.Context, session authn.Session, user users.User) (users.User, error) {
 	ret := _m.Called(ctx, session, user)
@@ -563,6 +722,64 @@ func (_m *Service) UpdateUsername(ctx context.Context, session authn.Session, id
 	return r0, r1
 }
 
+// VerifyEmail provides a mock function with given fields: ctx, token
+func (_m *Service) VerifyEmail(ctx context.Context, token string) (users.User, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 users.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (users.User, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) users.User); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(users.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyMFA provides a mock function with given fields: ctx, identity, secret, code
+func (_m *Service) VerifyMFA(ctx context.Context, identity string, secret string, code string) ([]string, error) {
+	ret := _m.Called(ctx, identity, secret, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMFA")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]string, error)); ok {
+		return rf(ctx, identity, secret, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []string); ok {
+		r0 = rf(ctx, identity, secret, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, identity, secret, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // View provides a mock function with given fields: ctx, session, id
 func (_m *Service) View(ctx context.Context, session authn.Session, id string) (users.User, error) {
 	ret := _m.Called(ctx, session, id)
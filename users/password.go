@@ -0,0 +1,165 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+	"unicode"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+var (
+	// ErrPasswordTooShort indicates that the password is shorter than required.
+	ErrPasswordTooShort = errors.New("password is too short")
+
+	// ErrPasswordComplexity indicates that the password lacks the required character classes.
+	ErrPasswordComplexity = errors.New("password does not contain the required characters")
+
+	// ErrPasswordReused indicates that the password matches one of the previous passwords.
+	ErrPasswordReused = errors.New("password was used recently")
+
+	// ErrPasswordBreached indicates that the password appeared in a known data breach.
+	ErrPasswordBreached = errors.New("password appeared in a data breach")
+
+	// ErrTooManyLoginAttempts indicates that the failed login attempt locked the account.
+	ErrTooManyLoginAttempts = errors.New("too many failed login attempts")
+)
+
+var _ errors.Error = (*LockedError)(nil)
+
+// LockedError indicates that the failed login attempt locked the account
+// of the user with the given ID. It matches ErrTooManyLoginAttempts.
+type LockedError struct {
+	UserID string
+}
+
+func (le *LockedError) Error() string {
+	return le.Msg()
+}
+
+func (le *LockedError) Msg() string {
+	return ErrTooManyLoginAttempts.Msg()
+}
+
+func (le *LockedError) Err() errors.Error {
+	return nil
+}
+
+func (le *LockedError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Err string `json:"error"`
+		Msg string `json:"message"`
+	}{
+		Msg: le.Msg(),
+	})
+}
+
+// LockedUserID returns the ID of the user whose account got locked by the
+// failed login attempt that caused the given error.
+func LockedUserID(err error) (string, bool) {
+	for err != nil {
+		if le, ok := err.(*LockedError); ok {
+			return le.UserID, true
+		}
+		ce, ok := err.(errors.Error)
+		if !ok {
+			return "", false
+		}
+		next := ce.Err()
+		if next == nil {
+			return "", false
+		}
+		err = next
+	}
+
+	return "", false
+}
+
+// PasswordPolicy specifies the rules the user secrets have to satisfy.
+// Zero values disable the corresponding rule.
+type PasswordPolicy struct {
+	MinLength    int  `env:"MIN_LENGTH"    envDefault:"8"`
+	MinUppercase int  `env:"MIN_UPPERCASE" envDefault:"0"`
+	MinLowercase int  `env:"MIN_LOWERCASE" envDefault:"0"`
+	MinDigits    int  `env:"MIN_DIGITS"    envDefault:"0"`
+	MinSpecial   int  `env:"MIN_SPECIAL"   envDefault:"0"`
+	History      uint `env:"HISTORY"       envDefault:"0"`
+}
+
+// Validate checks the secret length and character classes.
+func (p PasswordPolicy) Validate(secret string) error {
+	var length, upper, lower, digits, special int
+	for _, r := range secret {
+		length++
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		case unicode.IsDigit(r):
+			digits++
+		case unicode.IsPunct(r), unicode.IsSymbol(r), unicode.IsSpace(r):
+			special++
+		}
+	}
+
+	if length < p.MinLength {
+		return ErrPasswordTooShort
+	}
+	if upper < p.MinUppercase || lower < p.MinLowercase || digits < p.MinDigits || special < p.MinSpecial {
+		return ErrPasswordComplexity
+	}
+
+	return nil
+}
+
+// BreachChecker checks whether the password is known to be compromised.
+//
+//go:generate mockery --name BreachChecker --output=./mocks --filename breaches.go --quiet --note "Copyright (c) Abstract Machines"
+type BreachChecker interface {
+	// Breached returns true if the password appeared in a data breach.
+	Breached(ctx context.Context, password string) (bool, error)
+}
+
+// LockoutPolicy specifies the account lockout after the failed login
+// attempts. Each consecutive lockout doubles the lockout duration up
+// to the maximum duration, and zero MaxDuration keeps the duration
+// constant. Zero MaxAttempts disables the lockout.
+type LockoutPolicy struct {
+	MaxAttempts uint          `env:"MAX_ATTEMPTS" envDefault:"5"`
+	Duration    time.Duration `env:"DURATION"     envDefault:"5m"`
+	MaxDuration time.Duration `env:"MAX_DURATION" envDefault:"24h"`
+}
+
+// LockDuration returns the duration of the lockout with the given
+// number of previous lockouts.
+func (p LockoutPolicy) LockDuration(lockouts uint) time.Duration {
+	d := p.Duration
+	for i := uint(0); i < lockouts && d < p.MaxDuration; i++ {
+		d *= 2
+	}
+	if p.MaxDuration > 0 && d > p.MaxDuration {
+		d = p.MaxDuration
+	}
+
+	return d
+}
+
+// LoginAttempts tracks the failed login attempts of the user. Failed is
+// reset when the account gets locked, while Lockouts counts consecutive
+// lockouts until the next successful login or the admin unlock.
+type LoginAttempts struct {
+	UserID      string
+	Failed      uint
+	Lockouts    uint
+	LockedUntil time.Time
+}
+
+// Locked returns true if the account is locked at the given time.
+func (la LoginAttempts) Locked(t time.Time) bool {
+	return la.LockedUntil.After(t)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package users_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/users"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	policy := users.PasswordPolicy{MinLength: 10, MinUppercase: 1, MinLowercase: 2, MinDigits: 2, MinSpecial: 1}
+
+	cases := []struct {
+		desc   string
+		policy users.PasswordPolicy
+		secret string
+		err    error
+	}{
+		{
+			desc:   "valid password",
+			policy: policy,
+			secret: "Passw0rd-12",
+			err:    nil,
+		},
+		{
+			desc:   "valid password with non-ASCII characters",
+			policy: policy,
+			secret: "Lösenord€42",
+			err:    nil,
+		},
+		{
+			desc:   "too short password",
+			policy: policy,
+			secret: "Pa0-1",
+			err:    users.ErrPasswordTooShort,
+		},
+		{
+			desc:   "too short multi-byte password",
+			policy: users.PasswordPolicy{MinLength: 6},
+			secret: "ääääa",
+			err:    users.ErrPasswordTooShort,
+		},
+		{
+			desc:   "password without uppercase letters",
+			policy: policy,
+			secret: "passw0rd-12",
+			err:    users.ErrPasswordComplexity,
+		},
+		{
+			desc:   "password without enough lowercase letters",
+			policy: policy,
+			secret: "PASSW0RD-1a",
+			err:    users.ErrPasswordComplexity,
+		},
+		{
+			desc:   "password without enough digits",
+			policy: policy,
+			secret: "Passw0rd-ab",
+			err:    users.ErrPasswordComplexity,
+		},
+		{
+			desc:   "password without special characters",
+			policy: policy,
+			secret: "Passw0rd123",
+			err:    users.ErrPasswordComplexity,
+		},
+		{
+			desc:   "any password with empty policy",
+			policy: users.PasswordPolicy{},
+			secret: "a",
+			err:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.policy.Validate(tc.secret)
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestLockoutPolicyLockDuration(t *testing.T) {
+	policy := users.LockoutPolicy{MaxAttempts: 5, Duration: time.Minute, MaxDuration: 10 * time.Minute}
+
+	cases := []struct {
+		desc     string
+		policy   users.LockoutPolicy
+		lockouts uint
+		duration time.Duration
+	}{
+		{
+			desc:     "first lockout",
+			policy:   policy,
+			lockouts: 0,
+			duration: time.Minute,
+		},
+		{
+			desc:     "second lockout",
+			policy:   policy,
+			lockouts: 1,
+			duration: 2 * time.Minute,
+		},
+		{
+			desc:     "fourth lockout",
+			policy:   policy,
+			lockouts: 3,
+			duration: 8 * time.Minute,
+		},
+		{
+			desc:     "lockout capped at maximum duration",
+			policy:   policy,
+			lockouts: 4,
+			duration: 10 * time.Minute,
+		},
+		{
+			desc:     "lockout after many lockouts",
+			policy:   policy,
+			lockouts: 1000,
+			duration: 10 * time.Minute,
+		},
+		{
+			desc:     "lockout without maximum duration",
+			policy:   users.LockoutPolicy{MaxAttempts: 5, Duration: time.Minute},
+			lockouts: 3,
+			duration: time.Minute,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			duration := tc.policy.LockDuration(tc.lockouts)
+			assert.Equal(t, tc.duration, duration, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.duration, duration))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/absmach/supermq/users"
+)
+
+type dbIdentity struct {
+	Provider  string    `db:"provider"`
+	Subject   string    `db:"subject"`
+	UserID    string    `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (repo *userRepo) SaveIdentity(ctx context.Context, identity users.Identity) error {
+	q := `INSERT INTO users_identities (provider, subject, user_id, created_at)
+		VALUES (:provider, :subject, :user_id, :created_at)`
+
+	if _, err := repo.Repository.DB.NamedExecContext(ctx, q, dbIdentity(identity)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *userRepo) RetrieveByIdentity(ctx context.Context, provider, subject string) (users.User, error) {
+	q := `SELECT u.id, u.tags, u.email, u.secret, u.metadata, u.created_at, u.updated_at, u.updated_by, u.status, u.role, u.first_name, u.last_name, u.username
+		FROM users u JOIN users_identities i ON i.user_id = u.id
+		WHERE i.provider = :provider AND i.subject = :subject`
+
+	row, err := repo.Repository.DB.NamedQueryContext(ctx, q, dbIdentity{Provider: provider, Subject: subject})
+	if err != nil {
+		return users.User{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer row.Close()
+
+	dbu := DBUser{}
+	if row.Next() {
+		if err := row.StructScan(&dbu); err != nil {
+			return users.User{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+
+		return ToUser(dbu)
+	}
+
+	return users.User{}, repoerr.ErrNotFound
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/users"
+	cpostgres "github.com/absmach/supermq/users/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentities(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM users")
+		require.Nil(t, err, fmt.Sprintf("clean users unexpected error: %s", err))
+	})
+
+	repo := cpostgres.NewRepository(database)
+	user := saveTestUser(t, repo)
+	identity := users.Identity{
+		Provider:  "google",
+		Subject:   "provider-user-id",
+		UserID:    user.ID,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	_, err := repo.RetrieveByIdentity(context.Background(), identity.Provider, identity.Subject)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve user by unknown identity: expected %s got %s", repoerr.ErrNotFound, err))
+
+	err = repo.SaveIdentity(context.Background(), identity)
+	assert.Nil(t, err, fmt.Sprintf("save identity unexpected error: %s", err))
+
+	err = repo.SaveIdentity(context.Background(), identity)
+	assert.True(t, errors.Contains(err, repoerr.ErrConflict), fmt.Sprintf("save existing identity: expected %s got %s", repoerr.ErrConflict, err))
+
+	err = repo.SaveIdentity(context.Background(), users.Identity{Provider: "google", Subject: "other", UserID: testsutil.GenerateUUID(t), CreatedAt: time.Now().UTC()})
+	assert.NotNil(t, err, "save identity of non-existing user: expected error")
+
+	ruser, err := repo.RetrieveByIdentity(context.Background(), identity.Provider, identity.Subject)
+	assert.Nil(t, err, fmt.Sprintf("retrieve user by identity unexpected error: %s", err))
+	assert.Equal(t, user.ID, ruser.ID)
+
+	_, err = repo.RetrieveByIdentity(context.Background(), "keycloak", identity.Subject)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve user by identity of another provider: expected %s got %s", repoerr.ErrNotFound, err))
+}
@@ -86,6 +86,88 @@ func Migration() *migrate.MemoryMigrationSource {
 					`ALTER TABLE users ALTER COLUMN last_name SET DEFAULT ''`,
 				},
 			},
+			{
+				Id: "clients_06",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS users_mfa (
+						user_id         VARCHAR(36) PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+						secret          TEXT NOT NULL,
+						enabled         BOOLEAN NOT NULL DEFAULT FALSE,
+						recovery_codes  TEXT[] NOT NULL DEFAULT '{}'
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS users_mfa`,
+				},
+			},
+			{
+				Id: "clients_07",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS users_login_attempts (
+						user_id         VARCHAR(36) PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+						failed          INTEGER NOT NULL DEFAULT 0 CHECK (failed >= 0),
+						lockouts        INTEGER NOT NULL DEFAULT 0 CHECK (lockouts >= 0),
+						locked_until    TIMESTAMP
+					)`,
+					`CREATE TABLE IF NOT EXISTS users_secret_history (
+						user_id         VARCHAR(36) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+						secret          TEXT NOT NULL,
+						created_at      TIMESTAMP NOT NULL
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_users_secret_history_user_id ON users_secret_history (user_id, created_at DESC)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS users_secret_history`,
+					`DROP TABLE IF EXISTS users_login_attempts`,
+				},
+			},
+			{
+				Id: "clients_08",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS users_verifications (
+						user_id         VARCHAR(36) PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+						email           VARCHAR(254) NOT NULL,
+						token_hash      CHAR(64) NOT NULL UNIQUE,
+						created_at      TIMESTAMP NOT NULL,
+						expires_at      TIMESTAMP NOT NULL
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_users_verifications_email ON users_verifications (email, created_at DESC)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS users_verifications`,
+				},
+			},
+			{
+				Id: "clients_09",
+				Up: []string{
+					`ALTER TABLE users_mfa ADD COLUMN IF NOT EXISTS last_step BIGINT NOT NULL DEFAULT 0`,
+					`CREATE TABLE IF NOT EXISTS users_mfa_challenges (
+						token_hash      CHAR(64) PRIMARY KEY,
+						user_id         VARCHAR(36) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+						expires_at      TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS users_mfa_challenges`,
+					`ALTER TABLE users_mfa DROP COLUMN IF EXISTS last_step`,
+				},
+			},
+			{
+				Id: "clients_10",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS users_identities (
+						provider        VARCHAR(254) NOT NULL,
+						subject         VARCHAR(1024) NOT NULL,
+						user_id         VARCHAR(36) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+						created_at      TIMESTAMP NOT NULL,
+						PRIMARY KEY (provider, subject)
+					)`,
+					`CREATE INDEX IF NOT EXISTS users_identities_user_id_idx ON users_identities (user_id)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS users_identities`,
+				},
+			},
 		},
 	}
 }
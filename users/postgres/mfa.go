@@ -0,0 +1,174 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/absmach/supermq/users"
+	"github.com/jackc/pgtype"
+)
+
+type dbMFA struct {
+	UserID        string           `db:"user_id"`
+	Secret        string           `db:"secret"`
+	Enabled       bool             `db:"enabled"`
+	RecoveryCodes pgtype.TextArray `db:"recovery_codes"`
+	LastStep      int64            `db:"last_step"`
+}
+
+type dbMFAChallenge struct {
+	UserID    string    `db:"user_id"`
+	TokenHash string    `db:"token_hash"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+func (repo *userRepo) SaveMFA(ctx context.Context, mfa users.MFA) error {
+	q := `INSERT INTO users_mfa (user_id, secret, enabled, recovery_codes, last_step)
+		VALUES (:user_id, :secret, :enabled, :recovery_codes, :last_step)
+		ON CONFLICT (user_id) DO UPDATE SET
+			secret = EXCLUDED.secret, enabled = EXCLUDED.enabled, recovery_codes = EXCLUDED.recovery_codes,
+			last_step = EXCLUDED.last_step`
+
+	dbm, err := toDBMFA(mfa)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.Repository.DB.NamedExecContext(ctx, q, dbm); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *userRepo) RetrieveMFA(ctx context.Context, userID string) (users.MFA, error) {
+	q := `SELECT user_id, secret, enabled, recovery_codes, last_step FROM users_mfa WHERE user_id = :user_id`
+
+	rows, err := repo.Repository.DB.NamedQueryContext(ctx, q, dbMFA{UserID: userID})
+	if err != nil {
+		return users.MFA{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return users.MFA{}, repoerr.ErrNotFound
+	}
+	var dbm dbMFA
+	if err := rows.StructScan(&dbm); err != nil {
+		return users.MFA{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toMFA(dbm), nil
+}
+
+func (repo *userRepo) UpdateMFA(ctx context.Context, mfa users.MFA) error {
+	q := `UPDATE users_mfa SET enabled = :enabled, recovery_codes = :recovery_codes WHERE user_id = :user_id`
+
+	dbm, err := toDBMFA(mfa)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	result, err := repo.Repository.DB.NamedExecContext(ctx, q, dbm)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *userRepo) RemoveMFA(ctx context.Context, userID string) error {
+	q := `DELETE FROM users_mfa WHERE user_id = $1`
+
+	result, err := repo.Repository.DB.ExecContext(ctx, q, userID)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *userRepo) UpdateMFAStep(ctx context.Context, userID string, step uint64) error {
+	q := `UPDATE users_mfa SET last_step = $2 WHERE user_id = $1 AND last_step < $2`
+
+	result, err := repo.Repository.DB.ExecContext(ctx, q, userID, int64(step))
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *userRepo) SaveMFAChallenge(ctx context.Context, challenge users.MFAChallenge) error {
+	if _, err := repo.Repository.DB.ExecContext(ctx, `DELETE FROM users_mfa_challenges WHERE expires_at < $1`, time.Now().UTC()); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	q := `INSERT INTO users_mfa_challenges (token_hash, user_id, expires_at) VALUES (:token_hash, :user_id, :expires_at)`
+	if _, err := repo.Repository.DB.NamedExecContext(ctx, q, dbMFAChallenge(challenge)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *userRepo) RemoveMFAChallenge(ctx context.Context, tokenHash string) (users.MFAChallenge, error) {
+	q := `DELETE FROM users_mfa_challenges WHERE token_hash = $1 RETURNING token_hash, user_id, expires_at`
+
+	var dbc dbMFAChallenge
+	if err := repo.Repository.DB.QueryRowxContext(ctx, q, tokenHash).StructScan(&dbc); err != nil {
+		if err == sql.ErrNoRows {
+			return users.MFAChallenge{}, repoerr.ErrNotFound
+		}
+		return users.MFAChallenge{}, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return users.MFAChallenge(dbc), nil
+}
+
+func toDBMFA(mfa users.MFA) (dbMFA, error) {
+	codes := mfa.RecoveryCodes
+	if codes == nil {
+		codes = []string{}
+	}
+	var rc pgtype.TextArray
+	if err := rc.Set(codes); err != nil {
+		return dbMFA{}, err
+	}
+
+	return dbMFA{
+		UserID:        mfa.UserID,
+		Secret:        mfa.Secret,
+		Enabled:       mfa.Enabled,
+		RecoveryCodes: rc,
+		LastStep:      int64(mfa.LastStep),
+	}, nil
+}
+
+func toMFA(dbm dbMFA) users.MFA {
+	var codes []string
+	for _, e := range dbm.RecoveryCodes.Elements {
+		codes = append(codes, e.String)
+	}
+
+	return users.MFA{
+		UserID:        dbm.UserID,
+		Secret:        dbm.Secret,
+		Enabled:       dbm.Enabled,
+		RecoveryCodes: codes,
+		LastStep:      uint64(dbm.LastStep),
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/users"
+	cpostgres "github.com/absmach/supermq/users/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMFA(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM users")
+		require.Nil(t, err, fmt.Sprintf("clean users unexpected error: %s", err))
+	})
+
+	repo := cpostgres.NewRepository(database)
+	name := namesgen.Generate()
+	user, err := repo.Save(context.Background(), users.User{
+		ID:          testsutil.GenerateUUID(t),
+		FirstName:   name,
+		LastName:    name,
+		Email:       name + emailSuffix,
+		Credentials: users.Credentials{Username: name, Secret: password},
+		Metadata:    users.Metadata{},
+		Status:      users.EnabledStatus,
+	})
+	require.Nil(t, err, fmt.Sprintf("save user unexpected error: %s", err))
+
+	_, err = repo.RetrieveMFA(context.Background(), user.ID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve MFA of not enrolled user: expected %s got %s", repoerr.ErrNotFound, err))
+
+	pending := users.MFA{UserID: user.ID, Secret: "JBSWY3DPEHPK3PXP"}
+	err = repo.SaveMFA(context.Background(), pending)
+	assert.Nil(t, err, fmt.Sprintf("save MFA unexpected error: %s", err))
+	mfa, err := repo.RetrieveMFA(context.Background(), user.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve MFA unexpected error: %s", err))
+	assert.Equal(t, pending, mfa)
+
+	pending.Secret = "GEZDGNBVGY3TQOJQ"
+	err = repo.SaveMFA(context.Background(), pending)
+	assert.Nil(t, err, fmt.Sprintf("save MFA again unexpected error: %s", err))
+
+	enabled := users.MFA{UserID: user.ID, Secret: pending.Secret, Enabled: true, RecoveryCodes: []string{"hash1", "hash2"}}
+	err = repo.UpdateMFA(context.Background(), enabled)
+	assert.Nil(t, err, fmt.Sprintf("update MFA unexpected error: %s", err))
+	mfa, err = repo.RetrieveMFA(context.Background(), user.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve MFA unexpected error: %s", err))
+	assert.Equal(t, enabled, mfa)
+
+	err = repo.UpdateMFA(context.Background(), users.MFA{UserID: testsutil.GenerateUUID(t)})
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("update MFA of not enrolled user: expected %s got %s", repoerr.ErrNotFound, err))
+
+	err = repo.SaveMFA(context.Background(), users.MFA{UserID: testsutil.GenerateUUID(t), Secret: pending.Secret})
+	assert.NotNil(t, err, "save MFA of non-existing user: expected error")
+
+	err = repo.RemoveMFA(context.Background(), user.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove MFA unexpected error: %s", err))
+	err = repo.RemoveMFA(context.Background(), user.ID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("remove MFA of not enrolled user: expected %s got %s", repoerr.ErrNotFound, err))
+}
+
+func TestUpdateMFAStep(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM users")
+		require.Nil(t, err, fmt.Sprintf("clean users unexpected error: %s", err))
+	})
+
+	repo := cpostgres.NewRepository(database)
+	name := namesgen.Generate()
+	user, err := repo.Save(context.Background(), users.User{
+		ID:          testsutil.GenerateUUID(t),
+		FirstName:   name,
+		LastName:    name,
+		Email:       name + emailSuffix,
+		Credentials: users.Credentials{Username: name, Secret: password},
+		Metadata:    users.Metadata{},
+		Status:      users.EnabledStatus,
+	})
+	require.Nil(t, err, fmt.Sprintf("save user unexpected error: %s", err))
+	err = repo.SaveMFA(context.Background(), users.MFA{UserID: user.ID, Secret: "JBSWY3DPEHPK3PXP", Enabled: true})
+	require.Nil(t, err, fmt.Sprintf("save MFA unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		userID string
+		step   uint64
+		err    error
+	}{
+		{
+			desc:   "update MFA step",
+			userID: user.ID,
+			step:   100,
+		},
+		{
+			desc:   "update MFA step with the same step",
+			userID: user.ID,
+			step:   100,
+			err:    repoerr.ErrNotFound,
+		},
+		{
+			desc:   "update MFA step with earlier step",
+			userID: user.ID,
+			step:   99,
+			err:    repoerr.ErrNotFound,
+		},
+		{
+			desc:   "update MFA step with later step",
+			userID: user.ID,
+			step:   101,
+		},
+		{
+			desc:   "update MFA step of not enrolled user",
+			userID: testsutil.GenerateUUID(t),
+			step:   100,
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.UpdateMFAStep(context.Background(), tc.userID, tc.step)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		})
+	}
+
+	mfa, err := repo.RetrieveMFA(context.Background(), user.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve MFA unexpected error: %s", err))
+	assert.Equal(t, uint64(101), mfa.LastStep)
+}
+
+func TestMFAChallenge(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM users")
+		require.Nil(t, err, fmt.Sprintf("clean users unexpected error: %s", err))
+	})
+
+	repo := cpostgres.NewRepository(database)
+	name := namesgen.Generate()
+	user, err := repo.Save(context.Background(), users.User{
+		ID:          testsutil.GenerateUUID(t),
+		FirstName:   name,
+		LastName:    name,
+		Email:       name + emailSuffix,
+		Credentials: users.Credentials{Username: name, Secret: password},
+		Metadata:    users.Metadata{},
+		Status:      users.EnabledStatus,
+	})
+	require.Nil(t, err, fmt.Sprintf("save user unexpected error: %s", err))
+
+	challenge := users.MFAChallenge{
+		UserID:    user.ID,
+		TokenHash: strings.Repeat("a", 64),
+		ExpiresAt: time.Now().Add(time.Minute).UTC().Truncate(time.Microsecond),
+	}
+	err = repo.SaveMFAChallenge(context.Background(), challenge)
+	assert.Nil(t, err, fmt.Sprintf("save MFA challenge unexpected error: %s", err))
+
+	err = repo.SaveMFAChallenge(context.Background(), users.MFAChallenge{UserID: testsutil.GenerateUUID(t), TokenHash: strings.Repeat("b", 64), ExpiresAt: challenge.ExpiresAt})
+	assert.NotNil(t, err, "save MFA challenge of non-existing user: expected error")
+
+	c, err := repo.RemoveMFAChallenge(context.Background(), challenge.TokenHash)
+	assert.Nil(t, err, fmt.Sprintf("remove MFA challenge unexpected error: %s", err))
+	assert.Equal(t, challenge, c)
+
+	_, err = repo.RemoveMFAChallenge(context.Background(), challenge.TokenHash)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("remove used MFA challenge: expected %s got %s", repoerr.ErrNotFound, err))
+}
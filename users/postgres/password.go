@@ -0,0 +1,159 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/absmach/supermq/users"
+)
+
+type dbLoginAttempts struct {
+	UserID      string       `db:"user_id"`
+	Failed      uint         `db:"failed"`
+	Lockouts    uint         `db:"lockouts"`
+	LockedUntil sql.NullTime `db:"locked_until"`
+}
+
+func (repo *userRepo) RetrieveLoginAttempts(ctx context.Context, userID string) (users.LoginAttempts, error) {
+	q := `SELECT user_id, failed, lockouts, locked_until FROM users_login_attempts WHERE user_id = :user_id`
+
+	rows, err := repo.Repository.DB.NamedQueryContext(ctx, q, dbLoginAttempts{UserID: userID})
+	if err != nil {
+		return users.LoginAttempts{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return users.LoginAttempts{}, repoerr.ErrNotFound
+	}
+	var dba dbLoginAttempts
+	if err := rows.StructScan(&dba); err != nil {
+		return users.LoginAttempts{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toLoginAttempts(dba), nil
+}
+
+func (repo *userRepo) IncrementLoginAttempts(ctx context.Context, userID string) (users.LoginAttempts, error) {
+	q := `INSERT INTO users_login_attempts (user_id, failed) VALUES (:user_id, 1)
+		ON CONFLICT (user_id) DO UPDATE SET failed = users_login_attempts.failed + 1
+		RETURNING user_id, failed, lockouts, locked_until`
+
+	rows, err := repo.Repository.DB.NamedQueryContext(ctx, q, dbLoginAttempts{UserID: userID})
+	if err != nil {
+		return users.LoginAttempts{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return users.LoginAttempts{}, repoerr.ErrUpdateEntity
+	}
+	var dba dbLoginAttempts
+	if err := rows.StructScan(&dba); err != nil {
+		return users.LoginAttempts{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return toLoginAttempts(dba), nil
+}
+
+func (repo *userRepo) SaveLoginAttempts(ctx context.Context, attempts users.LoginAttempts) error {
+	q := `INSERT INTO users_login_attempts (user_id, failed, lockouts, locked_until)
+		VALUES (:user_id, :failed, :lockouts, :locked_until)
+		ON CONFLICT (user_id) DO UPDATE SET
+			failed = EXCLUDED.failed, lockouts = EXCLUDED.lockouts, locked_until = EXCLUDED.locked_until`
+
+	if _, err := repo.Repository.DB.NamedExecContext(ctx, q, toDBLoginAttempts(attempts)); err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *userRepo) RemoveLoginAttempts(ctx context.Context, userID string) error {
+	q := `DELETE FROM users_login_attempts WHERE user_id = $1`
+
+	if _, err := repo.Repository.DB.ExecContext(ctx, q, userID); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *userRepo) RetrieveSecretHistory(ctx context.Context, userID string, limit uint) ([]string, error) {
+	q := `SELECT secret FROM users_secret_history WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2`
+
+	rows, err := repo.Repository.DB.QueryxContext(ctx, q, userID, limit)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var secrets []string
+	for rows.Next() {
+		var secret string
+		if err := rows.Scan(&secret); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
+
+func (repo *userRepo) AddSecretHistory(ctx context.Context, userID, secret string, keep uint) error {
+	tx, err := repo.Repository.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	q := `INSERT INTO users_secret_history (user_id, secret, created_at) VALUES ($1, $2, $3)`
+	if _, err = tx.ExecContext(ctx, q, userID, secret, time.Now().UTC()); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	q = `DELETE FROM users_secret_history WHERE user_id = $1 AND ctid NOT IN (
+			SELECT ctid FROM users_secret_history WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2
+		)`
+	if _, err = tx.ExecContext(ctx, q, userID, keep); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func toDBLoginAttempts(la users.LoginAttempts) dbLoginAttempts {
+	return dbLoginAttempts{
+		UserID:      la.UserID,
+		Failed:      la.Failed,
+		Lockouts:    la.Lockouts,
+		LockedUntil: sql.NullTime{Time: la.LockedUntil.UTC(), Valid: !la.LockedUntil.IsZero()},
+	}
+}
+
+func toLoginAttempts(dba dbLoginAttempts) users.LoginAttempts {
+	la := users.LoginAttempts{
+		UserID:   dba.UserID,
+		Failed:   dba.Failed,
+		Lockouts: dba.Lockouts,
+	}
+	if dba.LockedUntil.Valid {
+		la.LockedUntil = dba.LockedUntil.Time
+	}
+
+	return la
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/users"
+	cpostgres "github.com/absmach/supermq/users/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginAttempts(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM users")
+		require.Nil(t, err, fmt.Sprintf("clean users unexpected error: %s", err))
+	})
+
+	repo := cpostgres.NewRepository(database)
+	user := saveTestUser(t, repo)
+
+	_, err := repo.RetrieveLoginAttempts(context.Background(), user.ID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve login attempts without failures: expected %s got %s", repoerr.ErrNotFound, err))
+
+	for i := uint(1); i <= 3; i++ {
+		attempts, err := repo.IncrementLoginAttempts(context.Background(), user.ID)
+		assert.Nil(t, err, fmt.Sprintf("increment login attempts unexpected error: %s", err))
+		assert.Equal(t, users.LoginAttempts{UserID: user.ID, Failed: i}, attempts)
+	}
+
+	locked := users.LoginAttempts{
+		UserID:      user.ID,
+		Lockouts:    1,
+		LockedUntil: time.Now().Add(time.Minute).UTC().Truncate(time.Microsecond),
+	}
+	err = repo.SaveLoginAttempts(context.Background(), locked)
+	assert.Nil(t, err, fmt.Sprintf("save login attempts unexpected error: %s", err))
+	attempts, err := repo.RetrieveLoginAttempts(context.Background(), user.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve login attempts unexpected error: %s", err))
+	assert.Equal(t, locked.Lockouts, attempts.Lockouts)
+	assert.Equal(t, locked.Failed, attempts.Failed)
+	assert.True(t, locked.LockedUntil.Equal(attempts.LockedUntil), fmt.Sprintf("expected locked until %s got %s", locked.LockedUntil, attempts.LockedUntil))
+
+	_, err = repo.IncrementLoginAttempts(context.Background(), testsutil.GenerateUUID(t))
+	assert.NotNil(t, err, "increment login attempts of non-existing user: expected error")
+
+	err = repo.RemoveLoginAttempts(context.Background(), user.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove login attempts unexpected error: %s", err))
+	err = repo.RemoveLoginAttempts(context.Background(), user.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove login attempts twice unexpected error: %s", err))
+	_, err = repo.RetrieveLoginAttempts(context.Background(), user.ID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve removed login attempts: expected %s got %s", repoerr.ErrNotFound, err))
+}
+
+func TestSecretHistory(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM users")
+		require.Nil(t, err, fmt.Sprintf("clean users unexpected error: %s", err))
+	})
+
+	repo := cpostgres.NewRepository(database)
+	user := saveTestUser(t, repo)
+
+	secrets, err := repo.RetrieveSecretHistory(context.Background(), user.ID, 5)
+	assert.Nil(t, err, fmt.Sprintf("retrieve empty secret history unexpected error: %s", err))
+	assert.Empty(t, secrets)
+
+	for _, secret := range []string{"hash1", "hash2", "hash3", "hash4"} {
+		err := repo.AddSecretHistory(context.Background(), user.ID, secret, 3)
+		assert.Nil(t, err, fmt.Sprintf("add secret history unexpected error: %s", err))
+	}
+
+	secrets, err = repo.RetrieveSecretHistory(context.Background(), user.ID, 5)
+	assert.Nil(t, err, fmt.Sprintf("retrieve secret history unexpected error: %s", err))
+	assert.Equal(t, []string{"hash4", "hash3", "hash2"}, secrets)
+
+	secrets, err = repo.RetrieveSecretHistory(context.Background(), user.ID, 2)
+	assert.Nil(t, err, fmt.Sprintf("retrieve limited secret history unexpected error: %s", err))
+	assert.Equal(t, []string{"hash4", "hash3"}, secrets)
+
+	err = repo.AddSecretHistory(context.Background(), testsutil.GenerateUUID(t), "hash", 3)
+	assert.NotNil(t, err, "add secret history of non-existing user: expected error")
+}
+
+func saveTestUser(t *testing.T, repo users.Repository) users.User {
+	name := namesgen.Generate()
+	user, err := repo.Save(context.Background(), users.User{
+		ID:          testsutil.GenerateUUID(t),
+		FirstName:   name,
+		LastName:    name,
+		Email:       name + emailSuffix,
+		Credentials: users.Credentials{Username: name, Secret: password},
+		Metadata:    users.Metadata{},
+		Status:      users.EnabledStatus,
+	})
+	require.Nil(t, err, fmt.Sprintf("save user unexpected error: %s", err))
+
+	return user
+}
@@ -0,0 +1,77 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/absmach/supermq/users"
+)
+
+type dbVerification struct {
+	UserID    string    `db:"user_id"`
+	Email     string    `db:"email"`
+	TokenHash string    `db:"token_hash"`
+	CreatedAt time.Time `db:"created_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+func (repo *userRepo) SaveVerification(ctx context.Context, verification users.Verification) error {
+	q := `INSERT INTO users_verifications (user_id, email, token_hash, created_at, expires_at)
+		VALUES (:user_id, :email, :token_hash, :created_at, :expires_at)
+		ON CONFLICT (user_id) DO UPDATE SET
+			email = EXCLUDED.email, token_hash = EXCLUDED.token_hash,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at`
+
+	if _, err := repo.Repository.DB.NamedExecContext(ctx, q, dbVerification(verification)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *userRepo) RetrieveVerification(ctx context.Context, tokenHash string) (users.Verification, error) {
+	q := `SELECT user_id, email, token_hash, created_at, expires_at FROM users_verifications
+		WHERE token_hash = :token_hash`
+
+	return repo.retrieveVerification(ctx, q, dbVerification{TokenHash: tokenHash})
+}
+
+func (repo *userRepo) RetrieveVerificationByEmail(ctx context.Context, email string) (users.Verification, error) {
+	q := `SELECT user_id, email, token_hash, created_at, expires_at FROM users_verifications
+		WHERE email = :email ORDER BY created_at DESC LIMIT 1`
+
+	return repo.retrieveVerification(ctx, q, dbVerification{Email: email})
+}
+
+func (repo *userRepo) RemoveVerification(ctx context.Context, userID string) error {
+	q := `DELETE FROM users_verifications WHERE user_id = $1`
+
+	if _, err := repo.Repository.DB.ExecContext(ctx, q, userID); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *userRepo) retrieveVerification(ctx context.Context, q string, dbv dbVerification) (users.Verification, error) {
+	rows, err := repo.Repository.DB.NamedQueryContext(ctx, q, dbv)
+	if err != nil {
+		return users.Verification{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return users.Verification{}, repoerr.ErrNotFound
+	}
+	dbv = dbVerification{}
+	if err := rows.StructScan(&dbv); err != nil {
+		return users.Verification{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return users.Verification(dbv), nil
+}
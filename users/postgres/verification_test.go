@@ -0,0 +1,81 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/users"
+	cpostgres "github.com/absmach/supermq/users/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifications(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM users")
+		require.Nil(t, err, fmt.Sprintf("clean users unexpected error: %s", err))
+	})
+
+	repo := cpostgres.NewRepository(database)
+	user := saveTestUser(t, repo)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	verification := users.Verification{
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: strings.Repeat("a", 64),
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+	err := repo.SaveVerification(context.Background(), verification)
+	assert.Nil(t, err, fmt.Sprintf("save verification unexpected error: %s", err))
+
+	replaced := users.Verification{
+		UserID:    user.ID,
+		Email:     "changed@example.com",
+		TokenHash: strings.Repeat("b", 64),
+		CreatedAt: now.Add(time.Minute),
+		ExpiresAt: now.Add(time.Hour + time.Minute),
+	}
+	err = repo.SaveVerification(context.Background(), replaced)
+	assert.Nil(t, err, fmt.Sprintf("replace verification unexpected error: %s", err))
+
+	err = repo.SaveVerification(context.Background(), users.Verification{
+		UserID:    testsutil.GenerateUUID(t),
+		Email:     user.Email,
+		TokenHash: strings.Repeat("c", 64),
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	})
+	assert.NotNil(t, err, "save verification of non-existing user: expected error")
+
+	_, err = repo.RetrieveVerification(context.Background(), verification.TokenHash)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve replaced verification: expected %s got %s", repoerr.ErrNotFound, err))
+
+	got, err := repo.RetrieveVerification(context.Background(), replaced.TokenHash)
+	assert.Nil(t, err, fmt.Sprintf("retrieve verification unexpected error: %s", err))
+	assert.Equal(t, replaced.UserID, got.UserID)
+	assert.Equal(t, replaced.Email, got.Email)
+	assert.True(t, replaced.ExpiresAt.Equal(got.ExpiresAt), fmt.Sprintf("expected expires at %s got %s", replaced.ExpiresAt, got.ExpiresAt))
+
+	got, err = repo.RetrieveVerificationByEmail(context.Background(), replaced.Email)
+	assert.Nil(t, err, fmt.Sprintf("retrieve verification by email unexpected error: %s", err))
+	assert.Equal(t, replaced.TokenHash, got.TokenHash)
+
+	_, err = repo.RetrieveVerificationByEmail(context.Background(), user.Email)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve verification by unknown email: expected %s got %s", repoerr.ErrNotFound, err))
+
+	err = repo.RemoveVerification(context.Background(), user.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove verification unexpected error: %s", err))
+	_, err = repo.RetrieveVerification(context.Background(), replaced.TokenHash)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve removed verification: expected %s got %s", repoerr.ErrNotFound, err))
+}
@@ -0,0 +1,81 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package pwned
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/users"
+)
+
+const prefixLen = 5
+
+var errRangeQuery = errors.New("failed to query Pwned Passwords range")
+
+// Config represents the Pwned Passwords API configuration.
+type Config struct {
+	Enabled bool          `env:"ENABLED" envDefault:"false"`
+	URL     string        `env:"URL"     envDefault:"https://api.pwnedpasswords.com"`
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"5s"`
+}
+
+type checker struct {
+	url    string
+	client *http.Client
+}
+
+var _ users.BreachChecker = (*checker)(nil)
+
+// NewChecker returns the Pwned Passwords implementation of the breach checker.
+func NewChecker(cfg Config) users.BreachChecker {
+	return &checker{
+		url:    strings.TrimSuffix(cfg.URL, "/"),
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (c *checker) Breached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:prefixLen], hash[prefixLen:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/range/%s", c.url, prefix), nil)
+	if err != nil {
+		return false, errors.Wrap(errRangeQuery, err)
+	}
+	// Padding hides the number of the matched suffixes from the observers.
+	req.Header.Set("Add-Padding", "true")
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return false, errors.Wrap(errRangeQuery, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return false, errors.Wrap(errRangeQuery, fmt.Errorf("unexpected status code %d", res.StatusCode))
+	}
+
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		s, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(s, suffix) {
+			continue
+		}
+		// Padding entries have zero count.
+		return strings.TrimSpace(count) != "0", nil
+	}
+	if err := scanner.Err(); err != nil {
+		return false, errors.Wrap(errRangeQuery, err)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package pwned_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/users/pwned"
+	"github.com/stretchr/testify/assert"
+)
+
+// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8.
+const (
+	breachedPrefix = "5BAA6"
+	breachedSuffix = "1E4C9B93F3F0682250B6CF8331B7EE68FD8"
+)
+
+func TestBreached(t *testing.T) {
+	var status int
+	var body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/range/"+breachedPrefix {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	defer ts.Close()
+
+	checker := pwned.NewChecker(pwned.Config{URL: ts.URL, Timeout: time.Second})
+
+	cases := []struct {
+		desc     string
+		password string
+		status   int
+		body     string
+		breached bool
+		err      bool
+	}{
+		{
+			desc:     "breached password",
+			password: "password",
+			status:   http.StatusOK,
+			body:     "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n" + breachedSuffix + ":9545824\r\n",
+			breached: true,
+		},
+		{
+			desc:     "breached password with lowercase suffix",
+			password: "password",
+			status:   http.StatusOK,
+			body:     "1e4c9b93f3f0682250b6cf8331b7ee68fd8:3\r\n",
+			breached: true,
+		},
+		{
+			desc:     "password matching padding entry",
+			password: "password",
+			status:   http.StatusOK,
+			body:     breachedSuffix + ":0\r\n",
+			breached: false,
+		},
+		{
+			desc:     "not breached password",
+			password: "password",
+			status:   http.StatusOK,
+			body:     "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n",
+			breached: false,
+		},
+		{
+			desc:     "password with other prefix",
+			password: "c0rrect-h0rse-battery-staple",
+			status:   http.StatusOK,
+			breached: false,
+		},
+		{
+			desc:     "range query failure",
+			password: "password",
+			status:   http.StatusServiceUnavailable,
+			err:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			status, body = tc.status, tc.body
+			breached, err := checker.Breached(context.Background(), tc.password)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.breached, breached, fmt.Sprintf("%s: expected breached %t got %t", tc.desc, tc.breached, breached))
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pwned contains the breach checker backed by the Pwned Passwords
+// range API, which checks the passwords using k-anonymity so that neither
+// the password nor its full hash leaves the service.
+package pwned
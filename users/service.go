@@ -5,10 +5,16 @@ package users
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
 	"net/mail"
+	"strings"
 	"time"
 
 	"github.com/absmach/supermq"
+	grpcDomainsV1 "github.com/absmach/supermq/api/grpc/domains/v1"
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	smqauth "github.com/absmach/supermq/auth"
@@ -17,48 +23,94 @@ import (
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/roles"
+	"github.com/absmach/supermq/pkg/totp"
+)
+
+const (
+	mfaIssuer          = "SuperMQ"
+	recoveryCodesCount = 10
+	recoveryCodeSize   = 5
+	verifyTokenSize    = 32
+	mfaChallengeTTL    = 5 * time.Minute
+	oauthProviderKey   = "oauth_provider"
 )
 
 var (
 	errIssueToken       = errors.New("failed to issue token")
 	errRecoveryToken    = errors.New("failed to generate password recovery token")
 	errLoginDisableUser = errors.New("failed to login in disabled user")
+	errRevokeSessions   = errors.New("failed to revoke user sessions")
+	errCheckMFAPolicy   = errors.New("failed to check domains multi-factor authentication policy")
+	errMFAChallenge     = errors.New("failed to create multi-factor authentication challenge")
+	errOAuthIdentity    = errors.New("missing OAuth provider or provider user ID")
+	errDirectory        = errors.New("failed to authenticate user against directory")
+	errSyncDomainRoles  = errors.New("failed to synchronize directory groups with domain roles")
+	errCheckBreaches    = errors.New("failed to check password breaches")
+	errLoginAttempts    = errors.New("failed to update failed login attempts")
+	errSendVerification = errors.New("failed to send email verification")
 )
 
 type service struct {
 	token      grpcTokenV1.TokenServiceClient
+	domains    grpcDomainsV1.DomainsServiceClient
 	users      Repository
 	idProvider supermq.IDProvider
 	policies   policies.Service
 	hasher     Hasher
 	email      Emailer
+	directory  Directory
+	roles      roles.RoleManager
+	passwords  PasswordPolicy
+	breaches   BreachChecker
+	lockout    LockoutPolicy
+	verify     VerificationPolicy
 }
 
-// NewService returns a new Users service implementation.
-func NewService(token grpcTokenV1.TokenServiceClient, urepo Repository, policyService policies.Service, emailer Emailer, hasher Hasher, idp supermq.IDProvider) Service {
+// NewService returns a new Users service implementation. The directory
+// and the domain role manager are optional, and the directory
+// authentication is enabled only when the directory is set. The breach
+// checker is optional as well.
+func NewService(token grpcTokenV1.TokenServiceClient, domains grpcDomainsV1.DomainsServiceClient, urepo Repository, policyService policies.Service, emailer Emailer, hasher Hasher, idp supermq.IDProvider, directory Directory, domainRoles roles.RoleManager, passwords PasswordPolicy, breaches BreachChecker, lockout LockoutPolicy, verify VerificationPolicy) Service {
 	return service{
 		token:      token,
+		domains:    domains,
 		users:      urepo,
 		policies:   policyService,
 		hasher:     hasher,
 		email:      emailer,
 		idProvider: idp,
+		directory:  directory,
+		roles:      domainRoles,
+		passwords:  passwords,
+		breaches:   breaches,
+		lockout:    lockout,
+		verify:     verify,
 	}
 }
 
-func (svc service) Register(ctx context.Context, session authn.Session, u User, selfRegister bool) (uc User, err error) {
+func (svc service) Register(ctx context.Context, session authn.Session, u User, selfRegister bool) (User, error) {
 	if !selfRegister {
 		if err := svc.checkSuperAdmin(ctx, session); err != nil {
 			return User{}, err
 		}
 	}
 
+	return svc.register(ctx, u, selfRegister && svc.verify.Enabled)
+}
+
+// register saves the new user. The user stays unverified until the email
+// gets confirmed if the verification is required.
+func (svc service) register(ctx context.Context, u User, verify bool) (uc User, err error) {
 	userID, err := svc.idProvider.ID()
 	if err != nil {
 		return User{}, err
 	}
 
 	if u.Credentials.Secret != "" {
+		if err := svc.checkPassword(ctx, u.Credentials.Secret); err != nil {
+			return User{}, err
+		}
 		hash, err := svc.hasher.Hash(u.Credentials.Secret)
 		if err != nil {
 			return User{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
@@ -72,6 +124,9 @@ func (svc service) Register(ctx context.Context, session authn.Session, u User,
 	if u.Role != UserRole && u.Role != AdminRole {
 		return User{}, errors.Wrap(svcerr.ErrMalformedEntity, svcerr.ErrInvalidRole)
 	}
+	if verify {
+		u.Status = UnverifiedStatus
+	}
 	u.ID = userID
 	u.CreatedAt = time.Now()
 
@@ -89,28 +144,67 @@ func (svc service) Register(ctx context.Context, session authn.Session, u User,
 	if err != nil {
 		return User{}, errors.Wrap(svcerr.ErrCreateEntity, err)
 	}
+	if user.Status == UnverifiedStatus {
+		if err := svc.sendVerification(ctx, user.ID, user.Email, user.Credentials.Username); err != nil {
+			if errDelete := svc.users.Delete(ctx, user.ID); errDelete != nil {
+				err = errors.Wrap(errors.Wrap(apiutil.ErrRollbackTx, errDelete), err)
+			}
+			return User{}, err
+		}
+	}
 	return user, nil
 }
 
-func (svc service) IssueToken(ctx context.Context, identity, secret string) (*grpcTokenV1.Token, error) {
-	var dbUser User
-	var err error
+func (svc service) IssueToken(ctx context.Context, identity, secret, otp, device, ip string) (*grpcTokenV1.Token, error) {
+	dbUser, err := svc.authenticate(ctx, identity, secret)
+	if err != nil {
+		return &grpcTokenV1.Token{}, err
+	}
 
-	if _, parseErr := mail.ParseAddress(identity); parseErr != nil {
-		dbUser, err = svc.users.RetrieveByUsername(ctx, identity)
-	} else {
-		dbUser, err = svc.users.RetrieveByEmail(ctx, identity)
+	if err := svc.checkMFA(ctx, dbUser.ID, otp); err != nil {
+		if errors.Contains(err, svcerr.ErrInvalidMFACode) {
+			err = svc.loginFailed(ctx, dbUser.ID, err)
+		}
+		return &grpcTokenV1.Token{}, err
+	}
+	if err := svc.loginSucceeded(ctx, dbUser.ID); err != nil {
+		return &grpcTokenV1.Token{}, err
 	}
 
+	token, err := svc.token.Issue(ctx, &grpcTokenV1.IssueReq{UserId: dbUser.ID, Type: uint32(smqauth.AccessKey), Device: device, Ip: ip})
 	if err != nil {
+		return &grpcTokenV1.Token{}, errors.Wrap(errIssueToken, err)
+	}
+
+	return token, nil
+}
+
+func (svc service) IssueMFAToken(ctx context.Context, challenge, otp, device, ip string) (*grpcTokenV1.Token, error) {
+	c, err := svc.users.RemoveMFAChallenge(ctx, hashVerifyToken(challenge))
+	if err != nil {
+		if errors.Contains(err, repoerr.ErrNotFound) {
+			return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, ErrInvalidMFAChallenge)
+		}
 		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
+	if c.Expired(time.Now()) {
+		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, ErrInvalidMFAChallenge)
+	}
+	if err := svc.checkLocked(ctx, c.UserID); err != nil {
+		return &grpcTokenV1.Token{}, err
+	}
 
-	if err := svc.hasher.Compare(secret, dbUser.Credentials.Secret); err != nil {
-		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrLogin, err)
+	if err := svc.checkMFA(ctx, c.UserID, otp); err != nil {
+		if errors.Contains(err, svcerr.ErrInvalidMFACode) {
+			err = svc.loginFailed(ctx, c.UserID, err)
+		}
+		return &grpcTokenV1.Token{}, err
+	}
+	if err := svc.loginSucceeded(ctx, c.UserID); err != nil {
+		return &grpcTokenV1.Token{}, err
 	}
 
-	token, err := svc.token.Issue(ctx, &grpcTokenV1.IssueReq{UserId: dbUser.ID, Type: uint32(smqauth.AccessKey)})
+	token, err := svc.token.Issue(ctx, &grpcTokenV1.IssueReq{UserId: c.UserID, Type: uint32(smqauth.AccessKey), Device: device, Ip: ip})
 	if err != nil {
 		return &grpcTokenV1.Token{}, errors.Wrap(errIssueToken, err)
 	}
@@ -118,6 +212,96 @@ func (svc service) IssueToken(ctx context.Context, identity, secret string) (*gr
 	return token, nil
 }
 
+func (svc service) EnrollMFA(ctx context.Context, identity, secret string) (MFAEnrolment, error) {
+	dbUser, err := svc.authenticate(ctx, identity, secret)
+	if err != nil {
+		return MFAEnrolment{}, err
+	}
+
+	mfa, err := svc.users.RetrieveMFA(ctx, dbUser.ID)
+	switch {
+	case err == nil && mfa.Enabled:
+		return MFAEnrolment{}, errors.Wrap(svcerr.ErrConflict, ErrMFAEnabled)
+	case err != nil && !errors.Contains(err, repoerr.ErrNotFound):
+		return MFAEnrolment{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	key, err := totp.GenerateSecret()
+	if err != nil {
+		return MFAEnrolment{}, err
+	}
+	if err := svc.users.SaveMFA(ctx, MFA{UserID: dbUser.ID, Secret: key}); err != nil {
+		return MFAEnrolment{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	account := dbUser.Email
+	if account == "" {
+		account = dbUser.Credentials.Username
+	}
+
+	return MFAEnrolment{
+		Secret:          key,
+		ProvisioningURI: totp.ProvisioningURI(key, mfaIssuer, account),
+	}, nil
+}
+
+func (svc service) VerifyMFA(ctx context.Context, identity, secret, code string) ([]string, error) {
+	dbUser, err := svc.authenticate(ctx, identity, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	mfa, err := svc.retrieveMFA(ctx, dbUser.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.Enabled {
+		return nil, errors.Wrap(svcerr.ErrConflict, ErrMFAEnabled)
+	}
+	step, ok := totp.ValidateStep(mfa.Secret, code, time.Now())
+	if !ok {
+		return nil, svcerr.ErrInvalidMFACode
+	}
+
+	codes, hashes, err := svc.generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	mfa.Enabled = true
+	mfa.RecoveryCodes = hashes
+	if err := svc.users.UpdateMFA(ctx, mfa); err != nil {
+		return nil, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	if err := svc.users.UpdateMFAStep(ctx, mfa.UserID, step); err != nil {
+		return nil, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return codes, nil
+}
+
+func (svc service) DisableMFA(ctx context.Context, identity, secret, code string) error {
+	dbUser, err := svc.authenticate(ctx, identity, secret)
+	if err != nil {
+		return err
+	}
+
+	mfa, err := svc.retrieveMFA(ctx, dbUser.ID)
+	if err != nil {
+		return err
+	}
+	if !mfa.Enabled {
+		return errors.Wrap(svcerr.ErrNotFound, ErrMFANotEnrolled)
+	}
+	if err := svc.validateMFACode(ctx, mfa, code); err != nil {
+		return err
+	}
+	if err := svc.users.RemoveMFA(ctx, dbUser.ID); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
 func (svc service) RefreshToken(ctx context.Context, session authn.Session, refreshToken string) (*grpcTokenV1.Token, error) {
 	dbUser, err := svc.users.RetrieveByID(ctx, session.UserID)
 	if err != nil {
@@ -270,6 +454,17 @@ func (svc service) UpdateEmail(ctx context.Context, session authn.Session, userI
 		}
 	}
 
+	if svc.verify.Enabled && session.UserID == userID {
+		user, err := svc.users.RetrieveByID(ctx, userID)
+		if err != nil {
+			return User{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if err := svc.sendVerification(ctx, user.ID, email, user.Credentials.Username); err != nil {
+			return User{}, err
+		}
+		return user, nil
+	}
+
 	user := User{
 		ID:        userID,
 		Email:     email,
@@ -284,6 +479,101 @@ func (svc service) UpdateEmail(ctx context.Context, session authn.Session, userI
 	return user, nil
 }
 
+func (svc service) VerifyEmail(ctx context.Context, token string) (User, error) {
+	verification, err := svc.users.RetrieveVerification(ctx, hashVerifyToken(token))
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return User{}, errors.Wrap(ErrInvalidVerificationToken, svcerr.ErrAuthentication)
+	case err != nil:
+		return User{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	case verification.Expired(time.Now()):
+		return User{}, errors.Wrap(ErrInvalidVerificationToken, svcerr.ErrAuthentication)
+	}
+
+	user, err := svc.users.RetrieveByID(ctx, verification.UserID)
+	if err != nil {
+		return User{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if user.Status == UnverifiedStatus {
+		user, err = svc.users.ChangeStatus(ctx, User{
+			ID:        user.ID,
+			Status:    EnabledStatus,
+			UpdatedAt: time.Now(),
+			UpdatedBy: user.ID,
+		})
+		if err != nil {
+			return User{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+	if user.Email != verification.Email {
+		user, err = svc.users.Update(ctx, User{
+			ID:        user.ID,
+			Email:     verification.Email,
+			Role:      AllRole,
+			UpdatedAt: time.Now(),
+			UpdatedBy: user.ID,
+		})
+		if err != nil {
+			return User{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+	if err := svc.users.RemoveVerification(ctx, user.ID); err != nil {
+		return User{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return user, nil
+}
+
+func (svc service) ResendVerification(ctx context.Context, email string) error {
+	verification, err := svc.users.RetrieveVerificationByEmail(ctx, email)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if time.Now().Before(verification.CreatedAt.Add(svc.verify.ResendInterval)) {
+		return errors.Wrap(svcerr.ErrTooManyRequests, ErrVerificationResent)
+	}
+	user, err := svc.users.RetrieveByID(ctx, verification.UserID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return svc.sendVerification(ctx, user.ID, verification.Email, user.Credentials.Username)
+}
+
+// sendVerification replaces the pending verification of the user and
+// sends the new verification token to the email.
+func (svc service) sendVerification(ctx context.Context, userID, email, username string) error {
+	b := make([]byte, verifyTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return errors.Wrap(errSendVerification, err)
+	}
+	token := hex.EncodeToString(b)
+
+	now := time.Now()
+	verification := Verification{
+		UserID:    userID,
+		Email:     email,
+		TokenHash: hashVerifyToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(svc.verify.TokenDuration),
+	}
+	if err := svc.users.SaveVerification(ctx, verification); err != nil {
+		return errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	if err := svc.email.SendVerification([]string{email}, username, token); err != nil {
+		return errors.Wrap(errSendVerification, err)
+	}
+
+	return nil
+}
+
+// hashVerifyToken hashes the verification token. Unlike the secrets, the
+// tokens are random, so the plain SHA-256 hash allows the token lookup.
+func hashVerifyToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
 func (svc service) GenerateResetToken(ctx context.Context, email, host string) error {
 	user, err := svc.users.RetrieveByEmail(ctx, email)
 	if err != nil {
@@ -306,11 +596,15 @@ func (svc service) ResetSecret(ctx context.Context, session authn.Session, secre
 	if err != nil {
 		return errors.Wrap(svcerr.ErrViewEntity, err)
 	}
+	if err := svc.checkNewPassword(ctx, u, secret); err != nil {
+		return err
+	}
 
 	secret, err = svc.hasher.Hash(secret)
 	if err != nil {
 		return errors.Wrap(svcerr.ErrMalformedEntity, err)
 	}
+	prevSecret := u.Credentials.Secret
 	u = User{
 		ID:    u.ID,
 		Email: u.Email,
@@ -323,6 +617,12 @@ func (svc service) ResetSecret(ctx context.Context, session authn.Session, secre
 	if _, err := svc.users.UpdateSecret(ctx, u); err != nil {
 		return errors.Wrap(svcerr.ErrAuthorization, err)
 	}
+	if err := svc.addSecretHistory(ctx, u.ID, prevSecret); err != nil {
+		return err
+	}
+	if err := svc.revokeSessions(ctx, u.ID); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -331,13 +631,17 @@ func (svc service) UpdateSecret(ctx context.Context, session authn.Session, oldS
 	if err != nil {
 		return User{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
-	if _, err := svc.IssueToken(ctx, dbUser.Credentials.Username, oldSecret); err != nil {
+	if err := svc.hasher.Compare(oldSecret, dbUser.Credentials.Secret); err != nil {
+		return User{}, errors.Wrap(svcerr.ErrLogin, err)
+	}
+	if err := svc.checkNewPassword(ctx, dbUser, newSecret); err != nil {
 		return User{}, err
 	}
 	newSecret, err = svc.hasher.Hash(newSecret)
 	if err != nil {
 		return User{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
 	}
+	prevSecret := dbUser.Credentials.Secret
 	dbUser.Credentials.Secret = newSecret
 	dbUser.UpdatedAt = time.Now()
 	dbUser.UpdatedBy = session.UserID
@@ -346,10 +650,25 @@ func (svc service) UpdateSecret(ctx context.Context, session authn.Session, oldS
 	if err != nil {
 		return User{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
+	if err := svc.addSecretHistory(ctx, dbUser.ID, prevSecret); err != nil {
+		return User{}, err
+	}
+	if err := svc.revokeSessions(ctx, dbUser.ID); err != nil {
+		return User{}, err
+	}
 
 	return dbUser, nil
 }
 
+// revokeSessions revokes all the user sessions, so that the tokens
+// issued with the previous secret can no longer be used.
+func (svc service) revokeSessions(ctx context.Context, userID string) error {
+	if _, err := svc.token.RevokeSessions(ctx, &grpcTokenV1.RevokeSessionsReq{UserId: userID}); err != nil {
+		return errors.Wrap(errRevokeSessions, err)
+	}
+	return nil
+}
+
 func (svc service) UpdateUsername(ctx context.Context, session authn.Session, id, username string) (User, error) {
 	if session.UserID != id {
 		if err := svc.checkSuperAdmin(ctx, session); err != nil {
@@ -467,6 +786,22 @@ func (svc service) Delete(ctx context.Context, session authn.Session, id string)
 	return nil
 }
 
+func (svc service) Unlock(ctx context.Context, session authn.Session, id string) (User, error) {
+	if err := svc.checkSuperAdmin(ctx, session); err != nil {
+		return User{}, err
+	}
+	user, err := svc.users.RetrieveByID(ctx, id)
+	if err != nil {
+		return User{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if err := svc.users.RemoveLoginAttempts(ctx, id); err != nil {
+		return User{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	user.Credentials.Secret = ""
+
+	return user, nil
+}
+
 func (svc *service) checkSuperAdmin(ctx context.Context, session authn.Session) error {
 	if !session.SuperAdmin {
 		if err := svc.users.CheckSuperAdmin(ctx, session.UserID); err != nil {
@@ -478,11 +813,32 @@ func (svc *service) checkSuperAdmin(ctx context.Context, session authn.Session)
 }
 
 func (svc service) OAuthCallback(ctx context.Context, user User) (User, error) {
-	ruser, err := svc.users.RetrieveByEmail(ctx, user.Email)
+	provider, _ := user.Metadata[oauthProviderKey].(string)
+	if provider == "" || user.ID == "" {
+		return User{}, errors.Wrap(svcerr.ErrAuthentication, errOAuthIdentity)
+	}
+	identity := Identity{
+		Provider:  provider,
+		Subject:   user.ID,
+		CreatedAt: time.Now(),
+	}
+
+	ruser, err := svc.users.RetrieveByIdentity(ctx, identity.Provider, identity.Subject)
+	switch {
+	case err == nil:
+		if ruser, err = svc.identityVerified(ctx, ruser); err != nil {
+			return User{}, err
+		}
+		return User{ID: ruser.ID, Role: ruser.Role}, nil
+	case !errors.Contains(err, repoerr.ErrNotFound):
+		return User{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+
+	ruser, err = svc.users.RetrieveByEmail(ctx, user.Email)
 	if err != nil {
 		switch errors.Contains(err, repoerr.ErrNotFound) {
 		case true:
-			ruser, err = svc.Register(ctx, authn.Session{}, user, true)
+			ruser, err = svc.register(ctx, user, false)
 			if err != nil {
 				return User{}, err
 			}
@@ -491,12 +847,61 @@ func (svc service) OAuthCallback(ctx context.Context, user User) (User, error) {
 		}
 	}
 
+	identity.UserID = ruser.ID
+	if err := svc.users.SaveIdentity(ctx, identity); err != nil {
+		return User{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
 	return User{
 		ID:   ruser.ID,
 		Role: ruser.Role,
 	}, nil
 }
 
+func (svc service) OAuthIssueToken(ctx context.Context, userID, device, ip string) (*grpcTokenV1.Token, string, error) {
+	if err := svc.checkLocked(ctx, userID); err != nil {
+		return &grpcTokenV1.Token{}, "", err
+	}
+
+	err := svc.checkMFA(ctx, userID, "")
+	switch {
+	case errors.Contains(err, svcerr.ErrMFARequired):
+		challenge, err := svc.createMFAChallenge(ctx, userID)
+		return &grpcTokenV1.Token{}, challenge, err
+	case err != nil:
+		return &grpcTokenV1.Token{}, "", err
+	}
+
+	token, err := svc.token.Issue(ctx, &grpcTokenV1.IssueReq{UserId: userID, Type: uint32(smqauth.AccessKey), Device: device, Ip: ip})
+	if err != nil {
+		return &grpcTokenV1.Token{}, "", errors.Wrap(errIssueToken, err)
+	}
+
+	return token, "", nil
+}
+
+// createMFAChallenge saves the MFA challenge of the user signed in with the
+// OAuth provider and returns the challenge token. The token is hashed the
+// same way as the verification token.
+func (svc service) createMFAChallenge(ctx context.Context, userID string) (string, error) {
+	b := make([]byte, verifyTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(errMFAChallenge, err)
+	}
+	token := hex.EncodeToString(b)
+
+	challenge := MFAChallenge{
+		UserID:    userID,
+		TokenHash: hashVerifyToken(token),
+		ExpiresAt: time.Now().Add(mfaChallengeTTL),
+	}
+	if err := svc.users.SaveMFAChallenge(ctx, challenge); err != nil {
+		return "", errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return token, nil
+}
+
 func (svc service) OAuthAddUserPolicy(ctx context.Context, user User) error {
 	return svc.addUserPolicy(ctx, user.ID, user.Role)
 }
@@ -593,3 +998,402 @@ func (svc service) updateUserPolicy(ctx context.Context, userID string, role Rol
 		return nil
 	}
 }
+
+// authenticate verifies the user credentials. When the directory is
+// configured, it takes precedence and only the users unknown to the
+// directory are authenticated with the local credentials.
+func (svc service) authenticate(ctx context.Context, identity, secret string) (User, error) {
+	if svc.directory != nil {
+		entry, err := svc.directory.Authenticate(ctx, identity, secret)
+		switch {
+		case err == nil:
+			return svc.provisionDirectoryUser(ctx, entry)
+		case errors.Contains(err, ErrInvalidDirectoryCredentials):
+			return User{}, svc.directoryLoginFailed(ctx, entry, errors.Wrap(svcerr.ErrLogin, err))
+		case !errors.Contains(err, ErrDirectoryUserNotFound):
+			return User{}, errors.Wrap(svcerr.ErrAuthentication, errors.Wrap(errDirectory, err))
+		}
+	}
+
+	var dbUser User
+	var err error
+
+	if _, parseErr := mail.ParseAddress(identity); parseErr != nil {
+		dbUser, err = svc.users.RetrieveByUsername(ctx, identity)
+	} else {
+		dbUser, err = svc.users.RetrieveByEmail(ctx, identity)
+	}
+
+	if err != nil {
+		return User{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+
+	if err := svc.checkLocked(ctx, dbUser.ID); err != nil {
+		return User{}, err
+	}
+
+	if err := svc.hasher.Compare(secret, dbUser.Credentials.Secret); err != nil {
+		return User{}, svc.loginFailed(ctx, dbUser.ID, errors.Wrap(svcerr.ErrLogin, err))
+	}
+
+	return dbUser, nil
+}
+
+func (svc service) checkLocked(ctx context.Context, userID string) error {
+	if svc.lockout.MaxAttempts == 0 {
+		return nil
+	}
+	attempts, err := svc.users.RetrieveLoginAttempts(ctx, userID)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return nil
+	case err != nil:
+		return errors.Wrap(svcerr.ErrAuthentication, err)
+	case attempts.Locked(time.Now()):
+		return svcerr.ErrAccountLocked
+	}
+
+	return nil
+}
+
+// loginSucceeded resets the failed login attempts and the consecutive
+// lockouts of the user.
+func (svc service) loginSucceeded(ctx context.Context, userID string) error {
+	if svc.lockout.MaxAttempts == 0 {
+		return nil
+	}
+	if err := svc.users.RemoveLoginAttempts(ctx, userID); err != nil {
+		return errors.Wrap(errLoginAttempts, err)
+	}
+
+	return nil
+}
+
+// loginFailed records the failed login attempt and locks the account once
+// the attempts reach the limit. The lockout duration doubles with every
+// consecutive lockout. The failed login error is returned unless the
+// account gets locked.
+func (svc service) loginFailed(ctx context.Context, userID string, loginErr error) error {
+	if svc.lockout.MaxAttempts == 0 {
+		return loginErr
+	}
+	attempts, err := svc.users.IncrementLoginAttempts(ctx, userID)
+	if err != nil {
+		return errors.Wrap(loginErr, errors.Wrap(errLoginAttempts, err))
+	}
+	if attempts.Failed < svc.lockout.MaxAttempts {
+		return loginErr
+	}
+
+	attempts.LockedUntil = time.Now().Add(svc.lockout.LockDuration(attempts.Lockouts))
+	attempts.Lockouts++
+	attempts.Failed = 0
+	if err := svc.users.SaveLoginAttempts(ctx, attempts); err != nil {
+		return errors.Wrap(loginErr, errors.Wrap(errLoginAttempts, err))
+	}
+
+	return errors.Wrap(svcerr.ErrAccountLocked, &LockedError{UserID: userID})
+}
+
+// checkPassword checks the password complexity and whether the password
+// appeared in a data breach.
+func (svc service) checkPassword(ctx context.Context, secret string) error {
+	if err := svc.passwords.Validate(secret); err != nil {
+		return errors.Wrap(err, svcerr.ErrPasswordPolicy)
+	}
+	if svc.breaches == nil {
+		return nil
+	}
+	breached, err := svc.breaches.Breached(ctx, secret)
+	if err != nil {
+		return errors.Wrap(errCheckBreaches, err)
+	}
+	if breached {
+		return errors.Wrap(ErrPasswordBreached, svcerr.ErrPasswordPolicy)
+	}
+
+	return nil
+}
+
+// checkNewPassword checks the password policy and makes sure the new
+// password matches neither the current nor the recent previous passwords.
+func (svc service) checkNewPassword(ctx context.Context, user User, secret string) error {
+	if err := svc.checkPassword(ctx, secret); err != nil {
+		return err
+	}
+	if svc.passwords.History == 0 {
+		return nil
+	}
+
+	hashes, err := svc.users.RetrieveSecretHistory(ctx, user.ID, svc.passwords.History-1)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if user.Credentials.Secret != "" {
+		hashes = append(hashes, user.Credentials.Secret)
+	}
+	for _, hash := range hashes {
+		if svc.hasher.Compare(secret, hash) == nil {
+			return errors.Wrap(ErrPasswordReused, svcerr.ErrPasswordPolicy)
+		}
+	}
+
+	return nil
+}
+
+func (svc service) addSecretHistory(ctx context.Context, userID, secret string) error {
+	if svc.passwords.History < 2 || secret == "" {
+		return nil
+	}
+	if err := svc.users.AddSecretHistory(ctx, userID, secret, svc.passwords.History-1); err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+// provisionDirectoryUser creates the directory user on the first login and
+// keeps the user attributes and the domain roles in sync with the directory
+// on the subsequent logins. Directory users have no local password.
+func (svc service) provisionDirectoryUser(ctx context.Context, entry DirectoryEntry) (User, error) {
+	du := entry.User
+	dbUser, err := svc.users.RetrieveByIdentity(ctx, DirectoryProvider, du.Credentials.Username)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		if dbUser, err = svc.linkDirectoryUser(ctx, du); err != nil {
+			return User{}, err
+		}
+	case err != nil:
+		return User{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if err := svc.checkLocked(ctx, dbUser.ID); err != nil {
+		return User{}, err
+	}
+	if dbUser, err = svc.identityVerified(ctx, dbUser); err != nil {
+		return User{}, err
+	}
+
+	if directoryUserChanged(dbUser, du) {
+		dbUser, err = svc.users.Update(ctx, User{
+			ID:             dbUser.ID,
+			FirstName:      du.FirstName,
+			LastName:       du.LastName,
+			Email:          du.Email,
+			ProfilePicture: du.ProfilePicture,
+			Role:           AllRole,
+			UpdatedAt:      time.Now(),
+			UpdatedBy:      dbUser.ID,
+		})
+		if err != nil {
+			return User{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+
+	if err := svc.syncDomainRoles(ctx, dbUser.ID, entry.Roles); err != nil {
+		return User{}, err
+	}
+
+	return dbUser, nil
+}
+
+// linkDirectoryUser links the directory user to the user with the same
+// username, or registers the new user without the local password. Only the
+// users without the local password, provisioned from the directory before
+// the users were linked, can be linked, so that the directory entry can't
+// take over the local or the OAuth account with the same username.
+func (svc service) linkDirectoryUser(ctx context.Context, du User) (User, error) {
+	dbUser, err := svc.users.RetrieveByUsername(ctx, du.Credentials.Username)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		du.Credentials.Secret = ""
+		du.Status = EnabledStatus
+		du.Role = UserRole
+		if dbUser, err = svc.register(ctx, du, false); err != nil {
+			return User{}, err
+		}
+	case err != nil:
+		return User{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	case dbUser.Credentials.Secret != "" || dbUser.Metadata[oauthProviderKey] != nil:
+		return User{}, errors.Wrap(svcerr.ErrLogin, ErrLocalAccount)
+	}
+
+	identity := Identity{
+		Provider:  DirectoryProvider,
+		Subject:   du.Credentials.Username,
+		UserID:    dbUser.ID,
+		CreatedAt: time.Now(),
+	}
+	if err := svc.users.SaveIdentity(ctx, identity); err != nil {
+		return User{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return dbUser, nil
+}
+
+// identityVerified checks the status of the user linked to the identity
+// provider. The identity provider verifies the users, so the unverified user
+// gets enabled, while the disabled user can't log in.
+func (svc service) identityVerified(ctx context.Context, user User) (User, error) {
+	switch user.Status {
+	case EnabledStatus:
+		return user, nil
+	case UnverifiedStatus:
+		user.Status = EnabledStatus
+		user.UpdatedAt = time.Now()
+		user.UpdatedBy = user.ID
+		if _, err := svc.users.ChangeStatus(ctx, user); err != nil {
+			return User{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+		if err := svc.users.RemoveVerification(ctx, user.ID); err != nil {
+			return User{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
+		}
+		return user, nil
+	default:
+		return User{}, errors.Wrap(svcerr.ErrLogin, errLoginDisableUser)
+	}
+}
+
+// directoryLoginFailed records the failed login of the directory user
+// linked to the directory entry, the same way as for the local users.
+func (svc service) directoryLoginFailed(ctx context.Context, entry DirectoryEntry, loginErr error) error {
+	if entry.User.Credentials.Username == "" {
+		return loginErr
+	}
+	dbUser, err := svc.users.RetrieveByIdentity(ctx, DirectoryProvider, entry.User.Credentials.Username)
+	if err != nil {
+		return loginErr
+	}
+	if err := svc.checkLocked(ctx, dbUser.ID); err != nil {
+		return err
+	}
+
+	return svc.loginFailed(ctx, dbUser.ID, loginErr)
+}
+
+// syncDomainRoles adds the user to the domain roles granted by the
+// directory groups and removes the user from the remaining roles managed
+// by the directory. Roles not managed by the directory are left intact.
+func (svc service) syncDomainRoles(ctx context.Context, userID string, granted []DirectoryRole) error {
+	if svc.roles == nil {
+		return nil
+	}
+
+	grants := make(map[DirectoryRole]bool, len(granted))
+	for _, r := range granted {
+		grants[r] = true
+	}
+
+	members := []string{userID}
+	for _, r := range svc.directory.Roles() {
+		session := authn.Session{UserID: userID, DomainID: r.DomainID, SuperAdmin: true}
+		exists, err := svc.roles.RoleCheckMembersExists(ctx, session, r.DomainID, r.RoleID, members)
+		if err != nil {
+			return errors.Wrap(errSyncDomainRoles, err)
+		}
+		switch {
+		case grants[r] && !exists:
+			if _, err := svc.roles.RoleAddMembers(ctx, session, r.DomainID, r.RoleID, members); err != nil {
+				return errors.Wrap(errSyncDomainRoles, err)
+			}
+		case !grants[r] && exists:
+			if err := svc.roles.RoleRemoveMembers(ctx, session, r.DomainID, r.RoleID, members); err != nil {
+				return errors.Wrap(errSyncDomainRoles, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func directoryUserChanged(dbUser, du User) bool {
+	return (du.FirstName != "" && du.FirstName != dbUser.FirstName) ||
+		(du.LastName != "" && du.LastName != dbUser.LastName) ||
+		(du.Email != "" && du.Email != dbUser.Email) ||
+		(du.ProfilePicture != "" && du.ProfilePicture != dbUser.ProfilePicture)
+}
+
+// checkMFA verifies the second factor of the user with enabled MFA. Users
+// without MFA are refused if any of their domains requires it.
+func (svc service) checkMFA(ctx context.Context, userID, otp string) error {
+	mfa, err := svc.users.RetrieveMFA(ctx, userID)
+	switch {
+	case err == nil && mfa.Enabled:
+		if otp == "" {
+			return svcerr.ErrMFARequired
+		}
+		return svc.validateMFACode(ctx, mfa, otp)
+	case err != nil && !errors.Contains(err, repoerr.ErrNotFound):
+		return errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+
+	res, err := svc.domains.RequiresMFA(ctx, &grpcDomainsV1.RequiresMFAReq{UserId: userID})
+	if err != nil {
+		return errors.Wrap(errCheckMFAPolicy, err)
+	}
+	if res.GetRequired() {
+		return svcerr.ErrMFAEnrolmentRequired
+	}
+
+	return nil
+}
+
+func (svc service) retrieveMFA(ctx context.Context, userID string) (MFA, error) {
+	mfa, err := svc.users.RetrieveMFA(ctx, userID)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return MFA{}, errors.Wrap(svcerr.ErrNotFound, ErrMFANotEnrolled)
+	case err != nil:
+		return MFA{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return mfa, nil
+}
+
+// validateMFACode accepts either a TOTP code or one of the recovery codes.
+// TOTP codes are accepted only after the time step of the last used code,
+// and recovery codes are single-use, so the matched one is removed.
+func (svc service) validateMFACode(ctx context.Context, mfa MFA, code string) error {
+	if step, ok := totp.ValidateStep(mfa.Secret, code, time.Now()); ok {
+		err := svc.users.UpdateMFAStep(ctx, mfa.UserID, step)
+		switch {
+		case errors.Contains(err, repoerr.ErrNotFound):
+			return svcerr.ErrInvalidMFACode
+		case err != nil:
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+		return nil
+	}
+
+	for i, hash := range mfa.RecoveryCodes {
+		if svc.hasher.Compare(strings.ToLower(code), hash) != nil {
+			continue
+		}
+		mfa.RecoveryCodes = append(mfa.RecoveryCodes[:i:i], mfa.RecoveryCodes[i+1:]...)
+		if err := svc.users.UpdateMFA(ctx, mfa); err != nil {
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+		return nil
+	}
+
+	return svcerr.ErrInvalidMFACode
+}
+
+func (svc service) generateRecoveryCodes() ([]string, []string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	codes := make([]string, recoveryCodesCount)
+	hashes := make([]string, recoveryCodesCount)
+	for i := range codes {
+		b := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+		codes[i] = strings.ToLower(encoding.EncodeToString(b))
+		hash, err := svc.hasher.Hash(codes[i])
+		if err != nil {
+			return nil, nil, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		hashes[i] = hash
+	}
+
+	return codes, hashes, nil
+}
@@ -5,25 +5,34 @@ package users_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	grpcDomainsV1 "github.com/absmach/supermq/api/grpc/domains/v1"
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
+	apiutil "github.com/absmach/supermq/api/http/util"
 	smqauth "github.com/absmach/supermq/auth"
 	authmocks "github.com/absmach/supermq/auth/mocks"
+	dmocks "github.com/absmach/supermq/domains/mocks"
 	"github.com/absmach/supermq/internal/testsutil"
 	"github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	policymocks "github.com/absmach/supermq/pkg/policies/mocks"
+	rolemocks "github.com/absmach/supermq/pkg/roles/mocks"
+	"github.com/absmach/supermq/pkg/totp"
 	"github.com/absmach/supermq/pkg/uuid"
 	"github.com/absmach/supermq/users"
 	"github.com/absmach/supermq/users/hasher"
 	"github.com/absmach/supermq/users/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -51,17 +60,20 @@ var (
 		LastName:  "lastname",
 	}
 	validToken      = "token"
+	device          = "Mozilla/5.0"
+	ip              = "127.0.0.1"
 	validID         = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
 	wrongID         = testsutil.GenerateUUID(&testing.T{})
 	errHashPassword = errors.New("generate hash from password failed")
 )
 
-func newService() (users.Service, *authmocks.TokenServiceClient, *mocks.Repository, *policymocks.Service, *mocks.Emailer) {
+func newService() (users.Service, *authmocks.TokenServiceClient, *mocks.Repository, *policymocks.Service, *mocks.Emailer, *dmocks.DomainsServiceClient) {
 	cRepo := new(mocks.Repository)
 	policies := new(policymocks.Service)
 	e := new(mocks.Emailer)
 	tokenClient := new(authmocks.TokenServiceClient)
-	return users.NewService(tokenClient, cRepo, policies, e, phasher, idProvider), tokenClient, cRepo, policies, e
+	domainsClient := new(dmocks.DomainsServiceClient)
+	return users.NewService(tokenClient, domainsClient, cRepo, policies, e, phasher, idProvider, nil, nil, users.PasswordPolicy{}, nil, users.LockoutPolicy{}, users.VerificationPolicy{}), tokenClient, cRepo, policies, e, domainsClient
 }
 
 func newServiceMinimal() (users.Service, *mocks.Repository) {
@@ -69,11 +81,32 @@ func newServiceMinimal() (users.Service, *mocks.Repository) {
 	policies := new(policymocks.Service)
 	e := new(mocks.Emailer)
 	tokenUser := new(authmocks.TokenServiceClient)
-	return users.NewService(tokenUser, cRepo, policies, e, phasher, idProvider), cRepo
+	domainsClient := new(dmocks.DomainsServiceClient)
+	return users.NewService(tokenUser, domainsClient, cRepo, policies, e, phasher, idProvider, nil, nil, users.PasswordPolicy{}, nil, users.LockoutPolicy{}, users.VerificationPolicy{}), cRepo
+}
+
+func newServiceWithPolicies(passwords users.PasswordPolicy, lockout users.LockoutPolicy) (users.Service, *authmocks.TokenServiceClient, *mocks.Repository, *policymocks.Service, *mocks.BreachChecker, *dmocks.DomainsServiceClient) {
+	cRepo := new(mocks.Repository)
+	policies := new(policymocks.Service)
+	e := new(mocks.Emailer)
+	tokenClient := new(authmocks.TokenServiceClient)
+	domainsClient := new(dmocks.DomainsServiceClient)
+	breaches := new(mocks.BreachChecker)
+	return users.NewService(tokenClient, domainsClient, cRepo, policies, e, phasher, idProvider, nil, nil, passwords, breaches, lockout, users.VerificationPolicy{}), tokenClient, cRepo, policies, breaches, domainsClient
+}
+
+func newServiceWithVerification() (users.Service, *mocks.Repository, *policymocks.Service, *mocks.Emailer) {
+	cRepo := new(mocks.Repository)
+	policies := new(policymocks.Service)
+	e := new(mocks.Emailer)
+	tokenClient := new(authmocks.TokenServiceClient)
+	domainsClient := new(dmocks.DomainsServiceClient)
+	verification := users.VerificationPolicy{Enabled: true, TokenDuration: time.Hour, ResendInterval: time.Minute}
+	return users.NewService(tokenClient, domainsClient, cRepo, policies, e, phasher, idProvider, nil, nil, users.PasswordPolicy{}, nil, users.LockoutPolicy{}, verification), cRepo, policies, e
 }
 
 func TestRegister(t *testing.T) {
-	svc, _, cRepo, policies, _ := newService()
+	svc, _, cRepo, policies, _, _ := newService()
 
 	cases := []struct {
 		desc                      string
@@ -240,7 +273,7 @@ func TestRegister(t *testing.T) {
 		policyCall1.Unset()
 	}
 
-	svc, _, cRepo, policies, _ = newService()
+	svc, _, cRepo, policies, _, _ = newService()
 
 	cases2 := []struct {
 		desc                      string
@@ -290,6 +323,156 @@ func TestRegister(t *testing.T) {
 	}
 }
 
+func TestRegisterWithPasswordPolicy(t *testing.T) {
+	passwords := users.PasswordPolicy{MinLength: 10, MinUppercase: 1, MinDigits: 1, MinSpecial: 1}
+	svc, _, cRepo, policies, breaches, _ := newServiceWithPolicies(passwords, users.LockoutPolicy{})
+
+	strongSecret := "Str0ng-secret"
+
+	cases := []struct {
+		desc        string
+		secret      string
+		breached    bool
+		breachedErr error
+		err         error
+	}{
+		{
+			desc:   "register user with secret satisfying the policy",
+			secret: strongSecret,
+			err:    nil,
+		},
+		{
+			desc:   "register user with too short secret",
+			secret: "Sh0rt-s",
+			err:    users.ErrPasswordTooShort,
+		},
+		{
+			desc:   "register user with secret without uppercase letters",
+			secret: "str0ng-secret",
+			err:    users.ErrPasswordComplexity,
+		},
+		{
+			desc:   "register user with secret without digits",
+			secret: "Strong-secret",
+			err:    users.ErrPasswordComplexity,
+		},
+		{
+			desc:   "register user with secret without special characters",
+			secret: "Str0ngsecret",
+			err:    users.ErrPasswordComplexity,
+		},
+		{
+			desc:     "register user with breached secret",
+			secret:   strongSecret,
+			breached: true,
+			err:      users.ErrPasswordBreached,
+		},
+		{
+			desc:        "register user with failed to check breaches",
+			secret:      strongSecret,
+			breachedErr: svcerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		u := user
+		u.Credentials.Secret = tc.secret
+		breachesCall := breaches.On("Breached", context.Background(), tc.secret).Return(tc.breached, tc.breachedErr)
+		policyCall := policies.On("AddPolicies", context.Background(), mock.Anything).Return(nil)
+		repoCall := cRepo.On("Save", context.Background(), mock.Anything).Return(u, nil)
+		_, err := svc.Register(context.Background(), authn.Session{}, u, true)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil && tc.breachedErr == nil {
+			assert.True(t, errors.Contains(err, svcerr.ErrPasswordPolicy), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, svcerr.ErrPasswordPolicy, err))
+		}
+		repoCall.Unset()
+		policyCall.Unset()
+		breachesCall.Unset()
+	}
+}
+
+func TestRegisterWithVerification(t *testing.T) {
+	svc, cRepo, policies, e := newServiceWithVerification()
+
+	errSend := errors.New("failed to send email")
+
+	cases := []struct {
+		desc                string
+		session             authn.Session
+		selfRegister        bool
+		status              users.Status
+		saveVerificationErr error
+		sendVerificationErr error
+		deleteErr           error
+		err                 error
+	}{
+		{
+			desc:         "self register user pending verification",
+			selfRegister: true,
+			status:       users.UnverifiedStatus,
+			err:          nil,
+		},
+		{
+			desc:    "register user by admin without verification",
+			session: authn.Session{UserID: validID, SuperAdmin: true},
+			status:  users.EnabledStatus,
+			err:     nil,
+		},
+		{
+			desc:                "self register user with failed to save verification",
+			selfRegister:        true,
+			status:              users.UnverifiedStatus,
+			saveVerificationErr: repoerr.ErrCreateEntity,
+			err:                 svcerr.ErrCreateEntity,
+		},
+		{
+			desc:                "self register user with failed to send verification",
+			selfRegister:        true,
+			status:              users.UnverifiedStatus,
+			sendVerificationErr: errSend,
+			err:                 errSend,
+		},
+		{
+			desc:                "self register user with failed to send verification and delete user",
+			selfRegister:        true,
+			status:              users.UnverifiedStatus,
+			sendVerificationErr: errSend,
+			deleteErr:           repoerr.ErrRemoveEntity,
+			err:                 apiutil.ErrRollbackTx,
+		},
+	}
+
+	for _, tc := range cases {
+		var saved users.User
+		policyCall := policies.On("AddPolicies", context.Background(), mock.Anything).Return(nil)
+		policyCall1 := policies.On("DeletePolicies", context.Background(), mock.Anything).Return(nil)
+		repoCall := cRepo.On("Save", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(users.User)
+		}).Return(func(_ context.Context, u users.User) (users.User, error) {
+			return u, nil
+		})
+		repoCall1 := cRepo.On("SaveVerification", context.Background(), mock.Anything).Return(tc.saveVerificationErr)
+		repoCall2 := cRepo.On("Delete", context.Background(), mock.Anything).Return(tc.deleteErr)
+		emailCall := e.On("SendVerification", []string{user.Email}, user.Credentials.Username, mock.Anything).Return(tc.sendVerificationErr)
+		res, err := svc.Register(context.Background(), tc.session, user, tc.selfRegister)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, saved.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, saved.Status))
+		if tc.err == nil {
+			assert.Equal(t, tc.status, res.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, res.Status))
+		}
+		if tc.sendVerificationErr != nil {
+			repoCall2.Parent.AssertCalled(t, "Delete", context.Background(), saved.ID)
+		}
+		policyCall.Unset()
+		policyCall1.Unset()
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		emailCall.Unset()
+	}
+}
+
 func TestViewUser(t *testing.T) {
 	svc, cRepo := newServiceMinimal()
 
@@ -665,7 +848,7 @@ func TestUpdateTags(t *testing.T) {
 }
 
 func TestUpdateRole(t *testing.T) {
-	svc, _, cRepo, policies, _ := newService()
+	svc, _, cRepo, policies, _, _ := newService()
 
 	user2 := user
 	user.Role = users.AdminRole
@@ -762,7 +945,7 @@ func TestUpdateRole(t *testing.T) {
 }
 
 func TestUpdateSecret(t *testing.T) {
-	svc, authUser, cRepo, _, _ := newService()
+	svc, authUser, cRepo, _, _, _ := newService()
 
 	newSecret := "newstrongSecret"
 	rUser := user
@@ -771,32 +954,27 @@ func TestUpdateSecret(t *testing.T) {
 	responseUser.Credentials.Secret = newSecret
 
 	cases := []struct {
-		desc                    string
-		oldSecret               string
-		newSecret               string
-		session                 authn.Session
-		retrieveByIDResponse    users.User
-		retrieveByEmailResponse users.User
-		updateSecretResponse    users.User
-		issueResponse           *grpcTokenV1.Token
-		response                users.User
-		retrieveByIDErr         error
-		retrieveByEmailErr      error
-		updateSecretErr         error
-		issueErr                error
-		err                     error
+		desc                 string
+		oldSecret            string
+		newSecret            string
+		session              authn.Session
+		retrieveByIDResponse users.User
+		updateSecretResponse users.User
+		response             users.User
+		retrieveByIDErr      error
+		updateSecretErr      error
+		revokeSessionsErr    error
+		err                  error
 	}{
 		{
-			desc:                    "update user secret with valid token",
-			oldSecret:               user.Credentials.Secret,
-			newSecret:               newSecret,
-			session:                 authn.Session{UserID: user.ID},
-			retrieveByEmailResponse: rUser,
-			retrieveByIDResponse:    user,
-			updateSecretResponse:    responseUser,
-			issueResponse:           &grpcTokenV1.Token{AccessToken: validToken},
-			response:                responseUser,
-			err:                     nil,
+			desc:                 "update user secret with valid token",
+			oldSecret:            user.Credentials.Secret,
+			newSecret:            newSecret,
+			session:              authn.Session{UserID: user.ID},
+			retrieveByIDResponse: rUser,
+			updateSecretResponse: responseUser,
+			response:             responseUser,
+			err:                  nil,
 		},
 		{
 			desc:                 "update user secret with failed to retrieve user by ID",
@@ -808,69 +986,148 @@ func TestUpdateSecret(t *testing.T) {
 			err:                  repoerr.ErrNotFound,
 		},
 		{
-			desc:                    "update user secret with failed to retrieve user by email",
-			oldSecret:               user.Credentials.Secret,
-			newSecret:               newSecret,
-			session:                 authn.Session{UserID: user.ID},
-			retrieveByIDResponse:    user,
-			retrieveByEmailResponse: users.User{},
-			retrieveByEmailErr:      repoerr.ErrNotFound,
-			err:                     repoerr.ErrNotFound,
+			desc:                 "update user secret with invalod old secret",
+			oldSecret:            "invalid",
+			newSecret:            newSecret,
+			session:              authn.Session{UserID: user.ID},
+			retrieveByIDResponse: rUser,
+			err:                  svcerr.ErrLogin,
 		},
 		{
-			desc:                    "update user secret with invalod old secret",
-			oldSecret:               "invalid",
-			newSecret:               newSecret,
-			session:                 authn.Session{UserID: user.ID},
-			retrieveByIDResponse:    user,
-			retrieveByEmailResponse: rUser,
-			err:                     svcerr.ErrLogin,
+			desc:                 "update user secret with too long new secret",
+			oldSecret:            user.Credentials.Secret,
+			newSecret:            strings.Repeat("a", 73),
+			session:              authn.Session{UserID: user.ID},
+			retrieveByIDResponse: rUser,
+			err:                  repoerr.ErrMalformedEntity,
 		},
 		{
-			desc:                    "update user secret with too long new secret",
-			oldSecret:               user.Credentials.Secret,
-			newSecret:               strings.Repeat("a", 73),
-			session:                 authn.Session{UserID: user.ID},
-			retrieveByIDResponse:    user,
-			retrieveByEmailResponse: rUser,
-			err:                     repoerr.ErrMalformedEntity,
+			desc:                 "update user secret with failed to update secret",
+			oldSecret:            user.Credentials.Secret,
+			newSecret:            newSecret,
+			session:              authn.Session{UserID: user.ID},
+			retrieveByIDResponse: rUser,
+			updateSecretResponse: users.User{},
+			updateSecretErr:      repoerr.ErrMalformedEntity,
+			err:                  svcerr.ErrUpdateEntity,
 		},
 		{
-			desc:                    "update user secret with failed to update secret",
-			oldSecret:               user.Credentials.Secret,
-			newSecret:               newSecret,
-			session:                 authn.Session{UserID: user.ID},
-			retrieveByIDResponse:    user,
-			retrieveByEmailResponse: rUser,
-			updateSecretResponse:    users.User{},
-			updateSecretErr:         repoerr.ErrMalformedEntity,
-			err:                     svcerr.ErrUpdateEntity,
+			desc:                 "update user secret with failed to revoke sessions",
+			oldSecret:            user.Credentials.Secret,
+			newSecret:            newSecret,
+			session:              authn.Session{UserID: user.ID},
+			retrieveByIDResponse: rUser,
+			updateSecretResponse: responseUser,
+			revokeSessionsErr:    svcerr.ErrAuthentication,
+			err:                  svcerr.ErrAuthentication,
 		},
 	}
 
 	for _, tc := range cases {
 		repoCall := cRepo.On("RetrieveByID", context.Background(), user.ID).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
-		repoCall1 := cRepo.On("RetrieveByUsername", context.Background(), user.Credentials.Username).Return(tc.retrieveByEmailResponse, tc.retrieveByEmailErr)
-		repoCall2 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(tc.updateSecretResponse, tc.updateSecretErr)
-		authCall := authUser.On("Issue", context.Background(), mock.Anything).Return(tc.issueResponse, tc.issueErr)
+		repoCall1 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(tc.updateSecretResponse, tc.updateSecretErr)
+		authCall := authUser.On("RevokeSessions", context.Background(), &grpcTokenV1.RevokeSessionsReq{UserId: user.ID}).Return(&grpcTokenV1.RevokeSessionsRes{Revoked: tc.revokeSessionsErr == nil}, tc.revokeSessionsErr)
 		updatedUser, err := svc.UpdateSecret(context.Background(), tc.session, tc.oldSecret, tc.newSecret)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		assert.Equal(t, tc.response, updatedUser, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, updatedUser))
 		if tc.err == nil {
 			ok := repoCall.Parent.AssertCalled(t, "RetrieveByID", context.Background(), tc.response.ID)
 			assert.True(t, ok, fmt.Sprintf("RetrieveByID was not called on %s", tc.desc))
-			ok = repoCall1.Parent.AssertCalled(t, "RetrieveByUsername", context.Background(), tc.response.Credentials.Username)
-			assert.True(t, ok, fmt.Sprintf("RetrieveByUsername was not called on %s", tc.desc))
-			ok = repoCall2.Parent.AssertCalled(t, "UpdateSecret", context.Background(), mock.Anything)
+			ok = repoCall1.Parent.AssertCalled(t, "UpdateSecret", context.Background(), mock.Anything)
 			assert.True(t, ok, fmt.Sprintf("UpdateSecret was not called on %s", tc.desc))
+			ok = authCall.Parent.AssertCalled(t, "RevokeSessions", context.Background(), &grpcTokenV1.RevokeSessionsReq{UserId: user.ID})
+			assert.True(t, ok, fmt.Sprintf("RevokeSessions was not called on %s", tc.desc))
 		}
 		repoCall.Unset()
 		repoCall1.Unset()
-		repoCall2.Unset()
 		authCall.Unset()
 	}
 }
 
+func TestUpdateSecretWithPasswordPolicy(t *testing.T) {
+	passwords := users.PasswordPolicy{MinLength: 8, History: 3}
+	svc, authUser, cRepo, _, breaches, _ := newServiceWithPolicies(passwords, users.LockoutPolicy{})
+
+	newSecret := "newstrongSecret"
+	previousSecret := "previousSecret"
+	rUser := user
+	rUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
+	previousHash, _ := phasher.Hash(previousSecret)
+	otherHash, _ := phasher.Hash("otherSecret")
+
+	cases := []struct {
+		desc            string
+		newSecret       string
+		history         []string
+		breached        bool
+		retrieveHistErr error
+		addHistoryErr   error
+		err             error
+	}{
+		{
+			desc:      "update user secret with new secret",
+			newSecret: newSecret,
+			history:   []string{otherHash, previousHash},
+			err:       nil,
+		},
+		{
+			desc:      "update user secret with too short secret",
+			newSecret: "short",
+			err:       users.ErrPasswordTooShort,
+		},
+		{
+			desc:      "update user secret with breached secret",
+			newSecret: newSecret,
+			breached:  true,
+			err:       users.ErrPasswordBreached,
+		},
+		{
+			desc:      "update user secret with current secret",
+			newSecret: user.Credentials.Secret,
+			err:       users.ErrPasswordReused,
+		},
+		{
+			desc:      "update user secret with previous secret",
+			newSecret: previousSecret,
+			history:   []string{otherHash, previousHash},
+			err:       users.ErrPasswordReused,
+		},
+		{
+			desc:            "update user secret with failed to retrieve secret history",
+			newSecret:       newSecret,
+			retrieveHistErr: repoerr.ErrViewEntity,
+			err:             svcerr.ErrViewEntity,
+		},
+		{
+			desc:          "update user secret with failed to add secret history",
+			newSecret:     newSecret,
+			addHistoryErr: repoerr.ErrCreateEntity,
+			err:           svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByID", context.Background(), user.ID).Return(rUser, nil)
+		breachesCall := breaches.On("Breached", context.Background(), tc.newSecret).Return(tc.breached, nil)
+		repoCall1 := cRepo.On("RetrieveSecretHistory", context.Background(), user.ID, passwords.History-1).Return(tc.history, tc.retrieveHistErr)
+		repoCall2 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(rUser, nil)
+		repoCall3 := cRepo.On("AddSecretHistory", context.Background(), user.ID, rUser.Credentials.Secret, passwords.History-1).Return(tc.addHistoryErr)
+		authCall := authUser.On("RevokeSessions", context.Background(), &grpcTokenV1.RevokeSessionsReq{UserId: user.ID}).Return(&grpcTokenV1.RevokeSessionsRes{Revoked: true}, nil)
+		_, err := svc.UpdateSecret(context.Background(), authn.Session{UserID: user.ID}, user.Credentials.Secret, tc.newSecret)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			ok := repoCall3.Parent.AssertCalled(t, "AddSecretHistory", context.Background(), user.ID, rUser.Credentials.Secret, passwords.History-1)
+			assert.True(t, ok, fmt.Sprintf("AddSecretHistory was not called on %s", tc.desc))
+		}
+		authCall.Unset()
+		repoCall3.Unset()
+		repoCall2.Unset()
+		repoCall1.Unset()
+		breachesCall.Unset()
+		repoCall.Unset()
+	}
+}
+
 func TestUpdateEmail(t *testing.T) {
 	svc, cRepo := newServiceMinimal()
 
@@ -952,135 +1209,386 @@ func TestUpdateEmail(t *testing.T) {
 	}
 }
 
-func TestUpdateProfilePicture(t *testing.T) {
-	svc, cRepo := newServiceMinimal()
+func TestUpdateEmailWithVerification(t *testing.T) {
+	svc, cRepo, _, e := newServiceWithVerification()
 
-	user.ProfilePicture = "https://example.com/profile.jpg"
-	adminID := testsutil.GenerateUUID(t)
+	newEmail := "updated@example.com"
+	errSend := errors.New("failed to send email")
 
 	cases := []struct {
-		desc                     string
-		user                     users.User
-		session                  authn.Session
-		updateProfilePicResponse users.User
-		updateProfilePicErr      error
-		checkSuperAdminErr       error
-		err                      error
+		desc                string
+		session             authn.Session
+		id                  string
+		retrieveByIDErr     error
+		saveVerificationErr error
+		sendVerificationErr error
+		verify              bool
+		err                 error
 	}{
 		{
-			desc:                     "update profile picture as normal user successfully",
-			user:                     user,
-			session:                  authn.Session{UserID: user.ID},
-			updateProfilePicResponse: user,
-			err:                      nil,
+			desc:    "update own email pending verification",
+			session: authn.Session{UserID: user.ID},
+			id:      user.ID,
+			verify:  true,
+			err:     nil,
 		},
 		{
-			desc:                     "update profile picture as normal user with repo error on update",
-			user:                     user,
-			session:                  authn.Session{UserID: user.ID},
-			updateProfilePicResponse: users.User{},
-			updateProfilePicErr:      errors.ErrMalformedEntity,
-			err:                      svcerr.ErrUpdateEntity,
+			desc:    "update email of other user as admin without verification",
+			session: authn.Session{UserID: validID, SuperAdmin: true},
+			id:      user.ID,
+			err:     nil,
 		},
 		{
-			desc:    "update profile picture as admin successfully",
-			user:    user,
-			session: authn.Session{UserID: adminID, SuperAdmin: true},
-			err:     nil,
+			desc:            "update own email with failed to retrieve user",
+			session:         authn.Session{UserID: user.ID},
+			id:              user.ID,
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrViewEntity,
 		},
 		{
-			desc:               "update profile picture as admin with failed check on super admin",
-			user:               user,
-			session:            authn.Session{UserID: adminID},
-			checkSuperAdminErr: svcerr.ErrAuthorization,
-			err:                svcerr.ErrAuthorization,
+			desc:                "update own email with failed to save verification",
+			session:             authn.Session{UserID: user.ID},
+			id:                  user.ID,
+			saveVerificationErr: repoerr.ErrCreateEntity,
+			err:                 svcerr.ErrCreateEntity,
 		},
 		{
-			desc:                     "update profile picture as admin with repo error on update",
-			user:                     user,
-			session:                  authn.Session{UserID: adminID, SuperAdmin: true},
-			updateProfilePicResponse: users.User{},
-			updateProfilePicErr:      errors.ErrMalformedEntity,
-			err:                      svcerr.ErrUpdateEntity,
+			desc:                "update own email with failed to send verification",
+			session:             authn.Session{UserID: user.ID},
+			id:                  user.ID,
+			sendVerificationErr: errSend,
+			err:                 errSend,
 		},
 	}
 
 	for _, tc := range cases {
-		repoCall := cRepo.On("CheckSuperAdmin", context.Background(), mock.Anything).Return(tc.checkSuperAdminErr)
-		repoCall1 := cRepo.On("Update", context.Background(), mock.Anything).Return(tc.updateProfilePicResponse, tc.updateProfilePicErr)
-		updatedUser, err := svc.UpdateProfilePicture(context.Background(), tc.session, tc.user)
+		var verification users.Verification
+		updated := user
+		updated.Email = newEmail
+		repoCall := cRepo.On("RetrieveByID", context.Background(), tc.id).Return(user, tc.retrieveByIDErr)
+		repoCall1 := cRepo.On("SaveVerification", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+			verification = args.Get(1).(users.Verification)
+		}).Return(tc.saveVerificationErr)
+		repoCall2 := cRepo.On("Update", context.Background(), mock.Anything).Return(updated, nil)
+		emailCall := e.On("SendVerification", []string{newEmail}, user.Credentials.Username, mock.Anything).Return(tc.sendVerificationErr)
+		res, err := svc.UpdateEmail(context.Background(), tc.session, tc.id, newEmail)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		assert.Equal(t, tc.updateProfilePicResponse, updatedUser, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.updateProfilePicResponse, updatedUser))
 		if tc.err == nil {
-			ok := repoCall1.Parent.AssertCalled(t, "Update", context.Background(), mock.Anything)
-			assert.True(t, ok, fmt.Sprintf("Update was not called on %s", tc.desc))
+			switch tc.verify {
+			case true:
+				assert.Equal(t, user.Email, res.Email, fmt.Sprintf("%s: expected unchanged email %s got %s\n", tc.desc, user.Email, res.Email))
+				assert.Equal(t, newEmail, verification.Email, fmt.Sprintf("%s: expected verification of %s got %s\n", tc.desc, newEmail, verification.Email))
+				assert.Equal(t, user.ID, verification.UserID, fmt.Sprintf("%s: expected verification of user %s got %s\n", tc.desc, user.ID, verification.UserID))
+			default:
+				assert.Equal(t, newEmail, res.Email, fmt.Sprintf("%s: expected email %s got %s\n", tc.desc, newEmail, res.Email))
+			}
 		}
 		repoCall.Unset()
 		repoCall1.Unset()
+		repoCall2.Unset()
+		emailCall.Unset()
 	}
 }
 
-func TestUpdateUsername(t *testing.T) {
-	svc, cRepo := newServiceMinimal()
+func TestVerifyEmail(t *testing.T) {
+	svc, cRepo, _, _ := newServiceWithVerification()
 
-	nuser := user
-	nuser.Credentials.Username = "newusername"
-	adminID := testsutil.GenerateUUID(t)
+	token := "verification-token"
+	hash := sha256.Sum256([]byte(token))
+	tokenHash := hex.EncodeToString(hash[:])
+
+	unverified := user
+	unverified.Status = users.UnverifiedStatus
+	verified := user
+	verified.Status = users.EnabledStatus
+	changed := verified
+	changed.Email = "updated@example.com"
 
 	cases := []struct {
-		desc                   string
-		user                   users.User
-		session                authn.Session
-		updateUsernameResponse users.User
-		updateUsernameErr      error
-		checkSuperAdminErr     error
-		err                    error
+		desc                 string
+		verification         users.Verification
+		retrieveErr          error
+		retrieveByIDResponse users.User
+		retrieveByIDErr      error
+		changeStatusErr      error
+		updateErr            error
+		removeErr            error
+		response             users.User
+		err                  error
 	}{
 		{
-			desc:                   "update username as normal user successfully",
-			user:                   user,
-			session:                authn.Session{UserID: user.ID},
-			updateUsernameResponse: nuser,
-			err:                    nil,
+			desc:                 "verify email of unverified user",
+			verification:         users.Verification{UserID: user.ID, Email: user.Email, ExpiresAt: time.Now().Add(time.Hour)},
+			retrieveByIDResponse: unverified,
+			response:             verified,
+			err:                  nil,
 		},
 		{
-			desc:                   "update username as normal user with repo error on update",
-			user:                   user,
-			session:                authn.Session{UserID: user.ID},
-			updateUsernameResponse: users.User{},
-			updateUsernameErr:      errors.ErrMalformedEntity,
-			err:                    svcerr.ErrUpdateEntity,
+			desc:                 "verify changed email",
+			verification:         users.Verification{UserID: user.ID, Email: changed.Email, ExpiresAt: time.Now().Add(time.Hour)},
+			retrieveByIDResponse: verified,
+			response:             changed,
+			err:                  nil,
 		},
 		{
-			desc:                   "update username as admin successfully",
-			user:                   user,
-			session:                authn.Session{UserID: adminID, SuperAdmin: true},
-			updateUsernameResponse: nuser,
-			err:                    nil,
+			desc:        "verify email with unknown token",
+			retrieveErr: repoerr.ErrNotFound,
+			err:         users.ErrInvalidVerificationToken,
 		},
 		{
-			desc:               "update username as admin with failed check on super admin",
-			user:               user,
-			session:            authn.Session{UserID: adminID},
-			checkSuperAdminErr: svcerr.ErrAuthorization,
-			err:                svcerr.ErrAuthorization,
+			desc:        "verify email with failed to retrieve verification",
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
 		},
 		{
-			desc:                   "update username as admin with repo error on update",
-			user:                   user,
-			session:                authn.Session{UserID: adminID, SuperAdmin: true},
-			updateUsernameResponse: users.User{},
-			updateUsernameErr:      errors.ErrMalformedEntity,
-			err:                    svcerr.ErrUpdateEntity,
+			desc:         "verify email with expired token",
+			verification: users.Verification{UserID: user.ID, Email: user.Email, ExpiresAt: time.Now().Add(-time.Minute)},
+			err:          users.ErrInvalidVerificationToken,
 		},
-	}
-
-	for _, tc := range cases {
-		repoCall := cRepo.On("CheckSuperAdmin", context.Background(), mock.Anything).Return(tc.checkSuperAdminErr)
-		repoCall1 := cRepo.On("UpdateUsername", context.Background(), mock.Anything).Return(tc.updateUsernameResponse, tc.updateUsernameErr)
-		updatedUser, err := svc.UpdateUsername(context.Background(), tc.session, tc.user.ID, tc.user.Credentials.Username)
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		assert.Equal(t, tc.updateUsernameResponse, updatedUser, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.updateUsernameResponse, updatedUser))
+		{
+			desc:            "verify email with failed to retrieve user",
+			verification:    users.Verification{UserID: user.ID, Email: user.Email, ExpiresAt: time.Now().Add(time.Hour)},
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrViewEntity,
+		},
+		{
+			desc:                 "verify email with failed to change status",
+			verification:         users.Verification{UserID: user.ID, Email: user.Email, ExpiresAt: time.Now().Add(time.Hour)},
+			retrieveByIDResponse: unverified,
+			changeStatusErr:      repoerr.ErrUpdateEntity,
+			err:                  svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:                 "verify changed email with failed to update email",
+			verification:         users.Verification{UserID: user.ID, Email: changed.Email, ExpiresAt: time.Now().Add(time.Hour)},
+			retrieveByIDResponse: verified,
+			updateErr:            repoerr.ErrConflict,
+			err:                  svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:                 "verify email with failed to remove verification",
+			verification:         users.Verification{UserID: user.ID, Email: user.Email, ExpiresAt: time.Now().Add(time.Hour)},
+			retrieveByIDResponse: unverified,
+			removeErr:            repoerr.ErrRemoveEntity,
+			err:                  svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveVerification", context.Background(), tokenHash).Return(tc.verification, tc.retrieveErr)
+		repoCall1 := cRepo.On("RetrieveByID", context.Background(), tc.verification.UserID).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
+		repoCall2 := cRepo.On("ChangeStatus", context.Background(), mock.Anything).Return(verified, tc.changeStatusErr)
+		repoCall3 := cRepo.On("Update", context.Background(), mock.Anything).Return(changed, tc.updateErr)
+		repoCall4 := cRepo.On("RemoveVerification", context.Background(), tc.verification.UserID).Return(tc.removeErr)
+		res, err := svc.VerifyEmail(context.Background(), token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.response, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, res))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	svc, cRepo, _, e := newServiceWithVerification()
+
+	errSend := errors.New("failed to send email")
+
+	cases := []struct {
+		desc                string
+		email               string
+		retrieveErr         error
+		retrieveByIDErr     error
+		createdAt           time.Time
+		saveVerificationErr error
+		sendVerificationErr error
+		err                 error
+	}{
+		{
+			desc:  "resend verification successfully",
+			email: user.Email,
+			err:   nil,
+		},
+		{
+			desc:      "resend verification within the resend interval",
+			email:     user.Email,
+			createdAt: time.Now().Add(-time.Second),
+			err:       users.ErrVerificationResent,
+		},
+		{
+			desc:        "resend verification without pending verification",
+			email:       "unknown@example.com",
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:            "resend verification with failed to retrieve user",
+			email:           user.Email,
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrViewEntity,
+		},
+		{
+			desc:                "resend verification with failed to save verification",
+			email:               user.Email,
+			saveVerificationErr: repoerr.ErrCreateEntity,
+			err:                 svcerr.ErrCreateEntity,
+		},
+		{
+			desc:                "resend verification with failed to send email",
+			email:               user.Email,
+			sendVerificationErr: errSend,
+			err:                 errSend,
+		},
+	}
+
+	for _, tc := range cases {
+		verification := users.Verification{UserID: user.ID, Email: tc.email, CreatedAt: tc.createdAt}
+		repoCall := cRepo.On("RetrieveVerificationByEmail", context.Background(), tc.email).Return(verification, tc.retrieveErr)
+		repoCall1 := cRepo.On("RetrieveByID", context.Background(), user.ID).Return(user, tc.retrieveByIDErr)
+		repoCall2 := cRepo.On("SaveVerification", context.Background(), mock.Anything).Return(tc.saveVerificationErr)
+		emailCall := e.On("SendVerification", []string{tc.email}, user.Credentials.Username, mock.Anything).Return(tc.sendVerificationErr)
+		err := svc.ResendVerification(context.Background(), tc.email)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		emailCall.Unset()
+	}
+}
+
+func TestUpdateProfilePicture(t *testing.T) {
+	svc, cRepo := newServiceMinimal()
+
+	user.ProfilePicture = "https://example.com/profile.jpg"
+	adminID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc                     string
+		user                     users.User
+		session                  authn.Session
+		updateProfilePicResponse users.User
+		updateProfilePicErr      error
+		checkSuperAdminErr       error
+		err                      error
+	}{
+		{
+			desc:                     "update profile picture as normal user successfully",
+			user:                     user,
+			session:                  authn.Session{UserID: user.ID},
+			updateProfilePicResponse: user,
+			err:                      nil,
+		},
+		{
+			desc:                     "update profile picture as normal user with repo error on update",
+			user:                     user,
+			session:                  authn.Session{UserID: user.ID},
+			updateProfilePicResponse: users.User{},
+			updateProfilePicErr:      errors.ErrMalformedEntity,
+			err:                      svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:    "update profile picture as admin successfully",
+			user:    user,
+			session: authn.Session{UserID: adminID, SuperAdmin: true},
+			err:     nil,
+		},
+		{
+			desc:               "update profile picture as admin with failed check on super admin",
+			user:               user,
+			session:            authn.Session{UserID: adminID},
+			checkSuperAdminErr: svcerr.ErrAuthorization,
+			err:                svcerr.ErrAuthorization,
+		},
+		{
+			desc:                     "update profile picture as admin with repo error on update",
+			user:                     user,
+			session:                  authn.Session{UserID: adminID, SuperAdmin: true},
+			updateProfilePicResponse: users.User{},
+			updateProfilePicErr:      errors.ErrMalformedEntity,
+			err:                      svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("CheckSuperAdmin", context.Background(), mock.Anything).Return(tc.checkSuperAdminErr)
+		repoCall1 := cRepo.On("Update", context.Background(), mock.Anything).Return(tc.updateProfilePicResponse, tc.updateProfilePicErr)
+		updatedUser, err := svc.UpdateProfilePicture(context.Background(), tc.session, tc.user)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.updateProfilePicResponse, updatedUser, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.updateProfilePicResponse, updatedUser))
+		if tc.err == nil {
+			ok := repoCall1.Parent.AssertCalled(t, "Update", context.Background(), mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("Update was not called on %s", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestUpdateUsername(t *testing.T) {
+	svc, cRepo := newServiceMinimal()
+
+	nuser := user
+	nuser.Credentials.Username = "newusername"
+	adminID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc                   string
+		user                   users.User
+		session                authn.Session
+		updateUsernameResponse users.User
+		updateUsernameErr      error
+		checkSuperAdminErr     error
+		err                    error
+	}{
+		{
+			desc:                   "update username as normal user successfully",
+			user:                   user,
+			session:                authn.Session{UserID: user.ID},
+			updateUsernameResponse: nuser,
+			err:                    nil,
+		},
+		{
+			desc:                   "update username as normal user with repo error on update",
+			user:                   user,
+			session:                authn.Session{UserID: user.ID},
+			updateUsernameResponse: users.User{},
+			updateUsernameErr:      errors.ErrMalformedEntity,
+			err:                    svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:                   "update username as admin successfully",
+			user:                   user,
+			session:                authn.Session{UserID: adminID, SuperAdmin: true},
+			updateUsernameResponse: nuser,
+			err:                    nil,
+		},
+		{
+			desc:               "update username as admin with failed check on super admin",
+			user:               user,
+			session:            authn.Session{UserID: adminID},
+			checkSuperAdminErr: svcerr.ErrAuthorization,
+			err:                svcerr.ErrAuthorization,
+		},
+		{
+			desc:                   "update username as admin with repo error on update",
+			user:                   user,
+			session:                authn.Session{UserID: adminID, SuperAdmin: true},
+			updateUsernameResponse: users.User{},
+			updateUsernameErr:      errors.ErrMalformedEntity,
+			err:                    svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("CheckSuperAdmin", context.Background(), mock.Anything).Return(tc.checkSuperAdminErr)
+		repoCall1 := cRepo.On("UpdateUsername", context.Background(), mock.Anything).Return(tc.updateUsernameResponse, tc.updateUsernameErr)
+		updatedUser, err := svc.UpdateUsername(context.Background(), tc.session, tc.user.ID, tc.user.Credentials.Username)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.updateUsernameResponse, updatedUser, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.updateUsernameResponse, updatedUser))
 		if tc.err == nil {
 			ok := repoCall1.Parent.AssertCalled(t, "UpdateUsername", context.Background(), mock.Anything)
 			assert.True(t, ok, fmt.Sprintf("UpdateUsername was not called on %s", tc.desc))
@@ -1251,168 +1759,1063 @@ func TestDisableUser(t *testing.T) {
 	}
 }
 
-func TestDeleteUser(t *testing.T) {
-	svc, cRepo := newServiceMinimal()
+func TestDeleteUser(t *testing.T) {
+	svc, cRepo := newServiceMinimal()
+
+	enabledUser1 := users.User{ID: testsutil.GenerateUUID(t), Credentials: users.Credentials{Username: "user1@example.com", Secret: "password"}, Status: users.EnabledStatus}
+	deletedUser1 := users.User{ID: testsutil.GenerateUUID(t), Credentials: users.Credentials{Username: "user3@example.com", Secret: "password"}, Status: users.DeletedStatus}
+	disenabledUser1 := enabledUser1
+	disenabledUser1.Status = users.DeletedStatus
+
+	cases := []struct {
+		desc                 string
+		id                   string
+		session              authn.Session
+		user                 users.User
+		retrieveByIDResponse users.User
+		changeStatusResponse users.User
+		response             users.User
+		retrieveByIDErr      error
+		changeStatusErr      error
+		checkSuperAdminErr   error
+		err                  error
+	}{
+		{
+			desc:                 "delete enabled user",
+			id:                   enabledUser1.ID,
+			user:                 enabledUser1,
+			session:              authn.Session{UserID: validID, SuperAdmin: true},
+			retrieveByIDResponse: enabledUser1,
+			changeStatusResponse: disenabledUser1,
+			response:             disenabledUser1,
+			err:                  nil,
+		},
+		{
+			desc:                 "delete enabled user with failed to retrieve user by ID",
+			id:                   enabledUser1.ID,
+			user:                 enabledUser1,
+			session:              authn.Session{UserID: validID, SuperAdmin: true},
+			retrieveByIDResponse: users.User{},
+			retrieveByIDErr:      repoerr.ErrNotFound,
+			err:                  repoerr.ErrNotFound,
+		},
+		{
+			desc:                 "delete already deleted user",
+			id:                   deletedUser1.ID,
+			user:                 deletedUser1,
+			session:              authn.Session{UserID: validID, SuperAdmin: true},
+			retrieveByIDResponse: deletedUser1,
+			err:                  errors.ErrStatusAlreadyAssigned,
+		},
+		{
+			desc:                 "delete enabled user with failed to change status",
+			id:                   enabledUser1.ID,
+			user:                 enabledUser1,
+			session:              authn.Session{UserID: validID, SuperAdmin: true},
+			retrieveByIDResponse: enabledUser1,
+			changeStatusResponse: users.User{},
+			changeStatusErr:      repoerr.ErrMalformedEntity,
+			err:                  svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall2 := cRepo.On("CheckSuperAdmin", context.Background(), mock.Anything).Return(tc.checkSuperAdminErr)
+		repoCall3 := cRepo.On("RetrieveByID", context.Background(), tc.id).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
+		repoCall4 := cRepo.On("ChangeStatus", context.Background(), mock.Anything).Return(tc.changeStatusResponse, tc.changeStatusErr)
+		err := svc.Delete(context.Background(), tc.session, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			ok := repoCall3.Parent.AssertCalled(t, "RetrieveByID", context.Background(), tc.id)
+			assert.True(t, ok, fmt.Sprintf("RetrieveByID was not called on %s", tc.desc))
+			ok = repoCall4.Parent.AssertCalled(t, "ChangeStatus", context.Background(), mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("ChangeStatus was not called on %s", tc.desc))
+		}
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+	}
+}
+
+func TestUnlockUser(t *testing.T) {
+	svc, cRepo := newServiceMinimal()
+
+	rUser := user
+	rUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
+	response := rUser
+	response.Credentials.Secret = ""
+
+	cases := []struct {
+		desc                 string
+		id                   string
+		session              authn.Session
+		checkSuperAdminErr   error
+		retrieveByIDResponse users.User
+		retrieveByIDErr      error
+		removeAttemptsErr    error
+		response             users.User
+		err                  error
+	}{
+		{
+			desc:                 "unlock user as super admin",
+			id:                   user.ID,
+			session:              authn.Session{UserID: validID, SuperAdmin: true},
+			retrieveByIDResponse: rUser,
+			response:             response,
+			err:                  nil,
+		},
+		{
+			desc:                 "unlock user as admin",
+			id:                   user.ID,
+			session:              authn.Session{UserID: validID},
+			retrieveByIDResponse: rUser,
+			response:             response,
+			err:                  nil,
+		},
+		{
+			desc:               "unlock user as normal user",
+			id:                 user.ID,
+			session:            authn.Session{UserID: validID},
+			checkSuperAdminErr: svcerr.ErrAuthorization,
+			err:                svcerr.ErrAuthorization,
+		},
+		{
+			desc:            "unlock non-existing user",
+			id:              wrongID,
+			session:         authn.Session{UserID: validID, SuperAdmin: true},
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrViewEntity,
+		},
+		{
+			desc:                 "unlock user with failed to remove login attempts",
+			id:                   user.ID,
+			session:              authn.Session{UserID: validID, SuperAdmin: true},
+			retrieveByIDResponse: rUser,
+			removeAttemptsErr:    repoerr.ErrRemoveEntity,
+			err:                  svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("CheckSuperAdmin", context.Background(), tc.session.UserID).Return(tc.checkSuperAdminErr)
+		repoCall1 := cRepo.On("RetrieveByID", context.Background(), tc.id).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
+		repoCall2 := cRepo.On("RemoveLoginAttempts", context.Background(), tc.id).Return(tc.removeAttemptsErr)
+		unlocked, err := svc.Unlock(context.Background(), tc.session, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.response, unlocked, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, unlocked))
+		if tc.err == nil {
+			ok := repoCall2.Parent.AssertCalled(t, "RemoveLoginAttempts", context.Background(), tc.id)
+			assert.True(t, ok, fmt.Sprintf("RemoveLoginAttempts was not called on %s", tc.desc))
+		}
+		repoCall2.Unset()
+		repoCall1.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestIssueToken(t *testing.T) {
+	svc, auth, cRepo, _, _, domains := newService()
+
+	rUser := user
+	rUser2 := user
+	rUser3 := user
+	rUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
+	rUser2.Credentials.Secret = "wrongsecret"
+	rUser3.Credentials.Secret, _ = phasher.Hash("wrongsecret")
+
+	mfaSecret, err := totp.GenerateSecret()
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating MFA secret: %s", err))
+	otp, err := totp.Generate(mfaSecret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating MFA code: %s", err))
+	recoveryCode := "abcdefgh"
+	recoveryHash, _ := phasher.Hash(recoveryCode)
+	mfa := users.MFA{UserID: user.ID, Secret: mfaSecret, Enabled: true, RecoveryCodes: []string{recoveryHash}}
+
+	cases := []struct {
+		desc                       string
+		user                       users.User
+		otp                        string
+		retrieveByUsernameResponse users.User
+		retrieveMFAResponse        users.MFA
+		requiresMFAResponse        bool
+		issueResponse              *grpcTokenV1.Token
+		retrieveByUsernameErr      error
+		retrieveMFAErr             error
+		requiresMFAErr             error
+		updateMFAErr               error
+		updateMFAStepErr           error
+		issueErr                   error
+		err                        error
+	}{
+		{
+			desc:                       "issue token for an existing user",
+			user:                       user,
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAErr:             repoerr.ErrNotFound,
+			issueResponse:              &grpcTokenV1.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
+			err:                        nil,
+		},
+		{
+			desc:                       "issue token for non-empty domain id",
+			user:                       user,
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAErr:             repoerr.ErrNotFound,
+			issueResponse:              &grpcTokenV1.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
+			err:                        nil,
+		},
+		{
+			desc:                       "issue token for a non-existing user",
+			user:                       user,
+			retrieveByUsernameResponse: users.User{},
+			retrieveByUsernameErr:      repoerr.ErrNotFound,
+			err:                        repoerr.ErrNotFound,
+		},
+		{
+			desc:                       "issue token for a user with wrong secret",
+			user:                       user,
+			retrieveByUsernameResponse: rUser3,
+			err:                        svcerr.ErrLogin,
+		},
+		{
+			desc:                       "issue token with empty domain id",
+			user:                       user,
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAErr:             repoerr.ErrNotFound,
+			issueResponse:              &grpcTokenV1.Token{},
+			issueErr:                   svcerr.ErrAuthentication,
+			err:                        svcerr.ErrAuthentication,
+		},
+		{
+			desc:                       "issue token with grpc error",
+			user:                       user,
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAErr:             repoerr.ErrNotFound,
+			issueResponse:              &grpcTokenV1.Token{},
+			issueErr:                   svcerr.ErrAuthentication,
+			err:                        svcerr.ErrAuthentication,
+		},
+		{
+			desc:                       "issue token for a user with MFA and valid code",
+			user:                       user,
+			otp:                        otp,
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAResponse:        mfa,
+			issueResponse:              &grpcTokenV1.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
+			err:                        nil,
+		},
+		{
+			desc:                       "issue token for a user with MFA and replayed code",
+			user:                       user,
+			otp:                        otp,
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAResponse:        mfa,
+			updateMFAStepErr:           repoerr.ErrNotFound,
+			err:                        svcerr.ErrInvalidMFACode,
+		},
+		{
+			desc:                       "issue token for a user with MFA and failed to update MFA step",
+			user:                       user,
+			otp:                        otp,
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAResponse:        mfa,
+			updateMFAStepErr:           repoerr.ErrUpdateEntity,
+			err:                        svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:                       "issue token for a user with MFA and recovery code",
+			user:                       user,
+			otp:                        recoveryCode,
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAResponse:        mfa,
+			issueResponse:              &grpcTokenV1.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
+			err:                        nil,
+		},
+		{
+			desc:                       "issue token for a user with MFA and failed to remove recovery code",
+			user:                       user,
+			otp:                        recoveryCode,
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAResponse:        mfa,
+			updateMFAErr:               repoerr.ErrMalformedEntity,
+			err:                        svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:                       "issue token for a user with MFA without code",
+			user:                       user,
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAResponse:        mfa,
+			err:                        svcerr.ErrMFARequired,
+		},
+		{
+			desc:                       "issue token for a user with MFA and invalid code",
+			user:                       user,
+			otp:                        "000000x",
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAResponse:        mfa,
+			err:                        svcerr.ErrInvalidMFACode,
+		},
+		{
+			desc:                       "issue token for a user with failed to retrieve MFA",
+			user:                       user,
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAErr:             repoerr.ErrViewEntity,
+			err:                        svcerr.ErrAuthentication,
+		},
+		{
+			desc:                       "issue token for a user without MFA in domain requiring MFA",
+			user:                       user,
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAErr:             repoerr.ErrNotFound,
+			requiresMFAResponse:        true,
+			err:                        svcerr.ErrMFAEnrolmentRequired,
+		},
+		{
+			desc:                       "issue token for a user with pending MFA enrolment in domain requiring MFA",
+			user:                       user,
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAResponse:        users.MFA{UserID: user.ID, Secret: mfaSecret},
+			requiresMFAResponse:        true,
+			err:                        svcerr.ErrMFAEnrolmentRequired,
+		},
+		{
+			desc:                       "issue token with failed to check domains MFA policy",
+			user:                       user,
+			retrieveByUsernameResponse: rUser,
+			retrieveMFAErr:             repoerr.ErrNotFound,
+			requiresMFAErr:             svcerr.ErrViewEntity,
+			err:                        svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByUsername", context.Background(), tc.user.Credentials.Username).Return(tc.retrieveByUsernameResponse, tc.retrieveByUsernameErr)
+		repoCall1 := cRepo.On("RetrieveMFA", context.Background(), tc.user.ID).Return(tc.retrieveMFAResponse, tc.retrieveMFAErr)
+		repoCall2 := cRepo.On("UpdateMFA", context.Background(), mock.Anything).Return(tc.updateMFAErr)
+		repoCall3 := cRepo.On("UpdateMFAStep", context.Background(), tc.user.ID, mock.Anything).Return(tc.updateMFAStepErr)
+		domainsCall := domains.On("RequiresMFA", context.Background(), &grpcDomainsV1.RequiresMFAReq{UserId: tc.user.ID}).Return(&grpcDomainsV1.RequiresMFARes{Required: tc.requiresMFAResponse}, tc.requiresMFAErr)
+		authCall := auth.On("Issue", context.Background(), &grpcTokenV1.IssueReq{UserId: tc.user.ID, Type: uint32(smqauth.AccessKey), Device: device, Ip: ip}).Return(tc.issueResponse, tc.issueErr)
+		token, err := svc.IssueToken(context.Background(), tc.user.Credentials.Username, tc.user.Credentials.Secret, tc.otp, device, ip)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, token.GetAccessToken(), fmt.Sprintf("%s: expected %s not to be empty\n", tc.desc, token.GetAccessToken()))
+			assert.NotEmpty(t, token.GetRefreshToken(), fmt.Sprintf("%s: expected %s not to be empty\n", tc.desc, token.GetRefreshToken()))
+			ok := repoCall.Parent.AssertCalled(t, "RetrieveByUsername", context.Background(), tc.user.Credentials.Username)
+			assert.True(t, ok, fmt.Sprintf("RetrieveByUsername was not called on %s", tc.desc))
+			ok = authCall.Parent.AssertCalled(t, "Issue", context.Background(), &grpcTokenV1.IssueReq{UserId: tc.user.ID, Type: uint32(smqauth.AccessKey), Device: device, Ip: ip})
+			assert.True(t, ok, fmt.Sprintf("Issue was not called on %s", tc.desc))
+		}
+		authCall.Unset()
+		domainsCall.Unset()
+		repoCall3.Unset()
+		repoCall2.Unset()
+		repoCall1.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestIssueTokenWithDirectory(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	policies := new(policymocks.Service)
+	tokenClient := new(authmocks.TokenServiceClient)
+	domainsClient := new(dmocks.DomainsServiceClient)
+	directory := new(mocks.Directory)
+	domainRoles := new(rolemocks.RoleManager)
+	svc := users.NewService(tokenClient, domainsClient, cRepo, policies, new(mocks.Emailer), phasher, idProvider, directory, domainRoles, users.PasswordPolicy{}, nil, users.LockoutPolicy{}, users.VerificationPolicy{})
+
+	adminRole := users.DirectoryRole{DomainID: validID, RoleID: "admin"}
+	memberRole := users.DirectoryRole{DomainID: validID, RoleID: "member"}
+	entry := users.DirectoryEntry{
+		User: users.User{
+			FirstName:   "first",
+			LastName:    "last",
+			Email:       "directory@example.com",
+			Credentials: users.Credentials{Username: "directory"},
+		},
+		Roles: []users.DirectoryRole{adminRole},
+	}
+	dirUser := users.User{
+		ID:          userID,
+		FirstName:   "first",
+		LastName:    "last",
+		Email:       "directory@example.com",
+		Credentials: users.Credentials{Username: "directory"},
+		Status:      users.EnabledStatus,
+	}
+	changedUser := dirUser
+	changedUser.LastName = "old"
+	disabledUser := dirUser
+	disabledUser.Status = users.DisabledStatus
+	localUser := user
+	localUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
+	localDirUser := dirUser
+	localDirUser.Credentials.Secret = localUser.Credentials.Secret
+	oauthDirUser := dirUser
+	oauthDirUser.Metadata = users.Metadata{"oauth_provider": "google"}
+
+	cases := []struct {
+		desc                       string
+		identity                   string
+		secret                     string
+		authenticateResponse       users.DirectoryEntry
+		authenticateErr            error
+		retrieveByIdentityResponse users.User
+		retrieveByIdentityErr      error
+		retrieveByUsernameResponse users.User
+		retrieveByUsernameErr      error
+		saveErr                    error
+		saveIdentityErr            error
+		updateErr                  error
+		adminMember                bool
+		memberMember               bool
+		checkMembersErr            error
+		addMembersErr              error
+		removeMembersErr           error
+		err                        error
+	}{
+		{
+			desc:                  "issue token for a new directory user",
+			identity:              "directory",
+			secret:                secret,
+			authenticateResponse:  entry,
+			retrieveByIdentityErr: repoerr.ErrNotFound,
+			retrieveByUsernameErr: repoerr.ErrNotFound,
+			memberMember:          true,
+		},
+		{
+			desc:                       "issue token for an existing directory user with changed attributes",
+			identity:                   "directory",
+			secret:                     secret,
+			authenticateResponse:       entry,
+			retrieveByIdentityResponse: changedUser,
+			adminMember:                true,
+		},
+		{
+			desc:                       "issue token for an existing directory user with failed update",
+			identity:                   "directory",
+			secret:                     secret,
+			authenticateResponse:       entry,
+			retrieveByIdentityResponse: changedUser,
+			updateErr:                  repoerr.ErrMalformedEntity,
+			err:                        svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:                       "issue token for a disabled directory user",
+			identity:                   "directory",
+			secret:                     secret,
+			authenticateResponse:       entry,
+			retrieveByIdentityResponse: disabledUser,
+			err:                        svcerr.ErrLogin,
+		},
+		{
+			desc:                  "issue token for a new directory user with failed save",
+			identity:              "directory",
+			secret:                secret,
+			authenticateResponse:  entry,
+			retrieveByIdentityErr: repoerr.ErrNotFound,
+			retrieveByUsernameErr: repoerr.ErrNotFound,
+			saveErr:               repoerr.ErrConflict,
+			err:                   svcerr.ErrCreateEntity,
+		},
+		{
+			desc:                       "issue token for a directory user with failed role check",
+			identity:                   "directory",
+			secret:                     secret,
+			authenticateResponse:       entry,
+			retrieveByIdentityResponse: dirUser,
+			checkMembersErr:            svcerr.ErrAuthorization,
+			err:                        svcerr.ErrAuthorization,
+		},
+		{
+			desc:                       "issue token for a directory user with failed role grant",
+			identity:                   "directory",
+			secret:                     secret,
+			authenticateResponse:       entry,
+			retrieveByIdentityResponse: dirUser,
+			addMembersErr:              svcerr.ErrAuthorization,
+			err:                        svcerr.ErrAuthorization,
+		},
+		{
+			desc:                       "issue token for a directory user with failed role revoke",
+			identity:                   "directory",
+			secret:                     secret,
+			authenticateResponse:       entry,
+			retrieveByIdentityResponse: dirUser,
+			adminMember:                true,
+			memberMember:               true,
+			removeMembersErr:           svcerr.ErrAuthorization,
+			err:                        svcerr.ErrAuthorization,
+		},
+		{
+			desc:                       "issue token for a directory user provisioned before linking",
+			identity:                   "directory",
+			secret:                     secret,
+			authenticateResponse:       entry,
+			retrieveByIdentityErr:      repoerr.ErrNotFound,
+			retrieveByUsernameResponse: dirUser,
+			adminMember:                true,
+		},
+		{
+			desc:                       "issue token for a directory user with failed link",
+			identity:                   "directory",
+			secret:                     secret,
+			authenticateResponse:       entry,
+			retrieveByIdentityErr:      repoerr.ErrNotFound,
+			retrieveByUsernameResponse: dirUser,
+			saveIdentityErr:            repoerr.ErrCreateEntity,
+			err:                        svcerr.ErrCreateEntity,
+		},
+		{
+			desc:                       "issue token for a directory user with the username of local user",
+			identity:                   "directory",
+			secret:                     secret,
+			authenticateResponse:       entry,
+			retrieveByIdentityErr:      repoerr.ErrNotFound,
+			retrieveByUsernameResponse: localDirUser,
+			err:                        users.ErrLocalAccount,
+		},
+		{
+			desc:                       "issue token for a directory user with the username of OAuth user",
+			identity:                   "directory",
+			secret:                     secret,
+			authenticateResponse:       entry,
+			retrieveByIdentityErr:      repoerr.ErrNotFound,
+			retrieveByUsernameResponse: oauthDirUser,
+			err:                        users.ErrLocalAccount,
+		},
+		{
+			desc:                  "issue token for a directory user with failed to retrieve linked user",
+			identity:              "directory",
+			secret:                secret,
+			authenticateResponse:  entry,
+			retrieveByIdentityErr: repoerr.ErrViewEntity,
+			err:                   svcerr.ErrAuthentication,
+		},
+		{
+			desc:            "issue token with invalid directory credentials",
+			identity:        "directory",
+			secret:          "invalid",
+			authenticateErr: users.ErrInvalidDirectoryCredentials,
+			err:             svcerr.ErrLogin,
+		},
+		{
+			desc:            "issue token with unavailable directory",
+			identity:        "directory",
+			secret:          secret,
+			authenticateErr: errors.New("connection refused"),
+			err:             svcerr.ErrAuthentication,
+		},
+		{
+			desc:                       "issue token for a local user unknown to directory",
+			identity:                   user.Credentials.Username,
+			secret:                     secret,
+			authenticateErr:            users.ErrDirectoryUserNotFound,
+			retrieveByUsernameResponse: localUser,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dirCall := directory.On("Authenticate", context.Background(), tc.identity, tc.secret).Return(tc.authenticateResponse, tc.authenticateErr)
+			dirCall1 := directory.On("Roles").Return([]users.DirectoryRole{adminRole, memberRole})
+			repoCall := cRepo.On("RetrieveByUsername", context.Background(), tc.identity).Return(tc.retrieveByUsernameResponse, tc.retrieveByUsernameErr)
+			repoCall4 := cRepo.On("RetrieveByIdentity", context.Background(), users.DirectoryProvider, "directory").Return(tc.retrieveByIdentityResponse, tc.retrieveByIdentityErr)
+			repoCall5 := cRepo.On("SaveIdentity", context.Background(), mock.Anything).Return(tc.saveIdentityErr)
+			repoCall1 := cRepo.On("Save", context.Background(), mock.Anything).Return(dirUser, tc.saveErr)
+			repoCall2 := cRepo.On("Update", context.Background(), mock.Anything).Return(dirUser, tc.updateErr)
+			repoCall3 := cRepo.On("RetrieveMFA", context.Background(), mock.Anything).Return(users.MFA{}, repoerr.ErrNotFound)
+			policyCall := policies.On("AddPolicies", context.Background(), mock.Anything).Return(nil)
+			policyCall1 := policies.On("DeletePolicies", context.Background(), mock.Anything).Return(nil)
+			rolesCall := domainRoles.On("RoleCheckMembersExists", context.Background(), mock.Anything, validID, adminRole.RoleID, []string{userID}).Return(tc.adminMember, tc.checkMembersErr)
+			rolesCall1 := domainRoles.On("RoleCheckMembersExists", context.Background(), mock.Anything, validID, memberRole.RoleID, []string{userID}).Return(tc.memberMember, tc.checkMembersErr)
+			rolesCall2 := domainRoles.On("RoleAddMembers", context.Background(), mock.Anything, validID, adminRole.RoleID, []string{userID}).Return([]string{userID}, tc.addMembersErr)
+			rolesCall3 := domainRoles.On("RoleRemoveMembers", context.Background(), mock.Anything, validID, memberRole.RoleID, []string{userID}).Return(tc.removeMembersErr)
+			domainsCall := domainsClient.On("RequiresMFA", context.Background(), mock.Anything).Return(&grpcDomainsV1.RequiresMFARes{}, nil)
+			authCall := tokenClient.On("Issue", context.Background(), mock.Anything).Return(&grpcTokenV1.Token{AccessToken: validToken, RefreshToken: &validToken}, nil)
+			_, err := svc.IssueToken(context.Background(), tc.identity, tc.secret, "", device, ip)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil && tc.authenticateErr == nil {
+				switch tc.adminMember {
+				case true:
+					domainRoles.AssertNotCalled(t, "RoleAddMembers", context.Background(), mock.Anything, validID, adminRole.RoleID, []string{userID})
+				default:
+					domainRoles.AssertCalled(t, "RoleAddMembers", context.Background(), mock.Anything, validID, adminRole.RoleID, []string{userID})
+				}
+				switch tc.memberMember {
+				case true:
+					domainRoles.AssertCalled(t, "RoleRemoveMembers", context.Background(), mock.Anything, validID, memberRole.RoleID, []string{userID})
+				default:
+					domainRoles.AssertNotCalled(t, "RoleRemoveMembers", context.Background(), mock.Anything, validID, memberRole.RoleID, []string{userID})
+				}
+			}
+			dirCall.Unset()
+			dirCall1.Unset()
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			repoCall4.Unset()
+			repoCall5.Unset()
+			policyCall.Unset()
+			policyCall1.Unset()
+			rolesCall.Unset()
+			rolesCall1.Unset()
+			rolesCall2.Unset()
+			rolesCall3.Unset()
+			domainsCall.Unset()
+			authCall.Unset()
+			domainRoles.Calls = nil
+		})
+	}
+}
+
+func TestIssueTokenWithLockout(t *testing.T) {
+	lockout := users.LockoutPolicy{MaxAttempts: 3, Duration: time.Minute, MaxDuration: 10 * time.Minute}
+	svc, auth, cRepo, _, _, domains := newServiceWithPolicies(users.PasswordPolicy{}, lockout)
+
+	rUser := user
+	rUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
+	mfaSecret, err := totp.GenerateSecret()
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating MFA secret: %s", err))
+	mfa := users.MFA{UserID: user.ID, Secret: mfaSecret, Enabled: true}
+
+	cases := []struct {
+		desc           string
+		secret         string
+		otp            string
+		retrieveMFA    users.MFA
+		retrieveMFAErr error
+		attempts       users.LoginAttempts
+		retrieveErr    error
+		incremented    users.LoginAttempts
+		incrementErr   error
+		saveErr        error
+		removeErr      error
+		lockedFor      time.Duration
+		err            error
+	}{
+		{
+			desc:           "issue token without failed login attempts",
+			secret:         user.Credentials.Secret,
+			retrieveMFAErr: repoerr.ErrNotFound,
+			retrieveErr:    repoerr.ErrNotFound,
+			err:            nil,
+		},
+		{
+			desc:           "issue token after the lockout expired",
+			secret:         user.Credentials.Secret,
+			retrieveMFAErr: repoerr.ErrNotFound,
+			attempts:       users.LoginAttempts{UserID: user.ID, Lockouts: 1, LockedUntil: time.Now().Add(-time.Minute)},
+			err:            nil,
+		},
+		{
+			desc:     "issue token for locked account",
+			secret:   user.Credentials.Secret,
+			attempts: users.LoginAttempts{UserID: user.ID, Lockouts: 1, LockedUntil: time.Now().Add(time.Minute)},
+			err:      svcerr.ErrAccountLocked,
+		},
+		{
+			desc:        "issue token with failed to retrieve login attempts",
+			secret:      user.Credentials.Secret,
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "issue token with wrong secret below the limit",
+			secret:      "wrongsecret",
+			retrieveErr: repoerr.ErrNotFound,
+			incremented: users.LoginAttempts{UserID: user.ID, Failed: 1},
+			err:         svcerr.ErrLogin,
+		},
+		{
+			desc:        "issue token with wrong secret reaching the limit",
+			secret:      "wrongsecret",
+			retrieveErr: repoerr.ErrNotFound,
+			incremented: users.LoginAttempts{UserID: user.ID, Failed: 3},
+			lockedFor:   time.Minute,
+			err:         users.ErrTooManyLoginAttempts,
+		},
+		{
+			desc:        "issue token with wrong secret reaching the limit after previous lockouts",
+			secret:      "wrongsecret",
+			attempts:    users.LoginAttempts{UserID: user.ID, Lockouts: 2, LockedUntil: time.Now().Add(-time.Minute)},
+			incremented: users.LoginAttempts{UserID: user.ID, Failed: 3, Lockouts: 2},
+			lockedFor:   4 * time.Minute,
+			err:         users.ErrTooManyLoginAttempts,
+		},
+		{
+			desc:         "issue token with wrong secret and failed to increment login attempts",
+			secret:       "wrongsecret",
+			retrieveErr:  repoerr.ErrNotFound,
+			incrementErr: repoerr.ErrUpdateEntity,
+			err:          svcerr.ErrLogin,
+		},
+		{
+			desc:        "issue token with wrong secret and failed to lock account",
+			secret:      "wrongsecret",
+			retrieveErr: repoerr.ErrNotFound,
+			incremented: users.LoginAttempts{UserID: user.ID, Failed: 3},
+			lockedFor:   time.Minute,
+			saveErr:     repoerr.ErrUpdateEntity,
+			err:         svcerr.ErrLogin,
+		},
+		{
+			desc:        "issue token with invalid MFA code reaching the limit",
+			secret:      user.Credentials.Secret,
+			otp:         "000000x",
+			retrieveMFA: mfa,
+			retrieveErr: repoerr.ErrNotFound,
+			incremented: users.LoginAttempts{UserID: user.ID, Failed: 3},
+			lockedFor:   time.Minute,
+			err:         users.ErrTooManyLoginAttempts,
+		},
+		{
+			desc:           "issue token with failed to reset login attempts",
+			secret:         user.Credentials.Secret,
+			retrieveMFAErr: repoerr.ErrNotFound,
+			retrieveErr:    repoerr.ErrNotFound,
+			removeErr:      repoerr.ErrRemoveEntity,
+			err:            repoerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		var saved users.LoginAttempts
+		repoCall := cRepo.On("RetrieveByUsername", context.Background(), user.Credentials.Username).Return(rUser, nil)
+		repoCall1 := cRepo.On("RetrieveLoginAttempts", context.Background(), user.ID).Return(tc.attempts, tc.retrieveErr)
+		repoCall2 := cRepo.On("IncrementLoginAttempts", context.Background(), user.ID).Return(tc.incremented, tc.incrementErr)
+		repoCall3 := cRepo.On("SaveLoginAttempts", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(users.LoginAttempts)
+		}).Return(tc.saveErr)
+		repoCall4 := cRepo.On("RemoveLoginAttempts", context.Background(), user.ID).Return(tc.removeErr)
+		repoCall5 := cRepo.On("RetrieveMFA", context.Background(), user.ID).Return(tc.retrieveMFA, tc.retrieveMFAErr)
+		domainsCall := domains.On("RequiresMFA", context.Background(), &grpcDomainsV1.RequiresMFAReq{UserId: user.ID}).Return(&grpcDomainsV1.RequiresMFARes{}, nil)
+		authCall := auth.On("Issue", context.Background(), &grpcTokenV1.IssueReq{UserId: user.ID, Type: uint32(smqauth.AccessKey), Device: device, Ip: ip}).Return(&grpcTokenV1.Token{AccessToken: validToken, RefreshToken: &validToken}, nil)
+		_, err := svc.IssueToken(context.Background(), user.Credentials.Username, tc.secret, tc.otp, device, ip)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		switch {
+		case tc.err == nil:
+			ok := repoCall4.Parent.AssertCalled(t, "RemoveLoginAttempts", context.Background(), user.ID)
+			assert.True(t, ok, fmt.Sprintf("RemoveLoginAttempts was not called on %s", tc.desc))
+		case errors.Contains(err, users.ErrTooManyLoginAttempts):
+			assert.True(t, errors.Contains(err, svcerr.ErrAccountLocked), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, svcerr.ErrAccountLocked, err))
+			assert.Equal(t, tc.incremented.Lockouts+1, saved.Lockouts, fmt.Sprintf("%s: expected %d lockouts got %d\n", tc.desc, tc.incremented.Lockouts+1, saved.Lockouts))
+			assert.Zero(t, saved.Failed, fmt.Sprintf("%s: expected failed attempts to be reset got %d\n", tc.desc, saved.Failed))
+			assert.WithinDuration(t, time.Now().Add(tc.lockedFor), saved.LockedUntil, time.Second, fmt.Sprintf("%s: expected account locked for %s\n", tc.desc, tc.lockedFor))
+			lockedID, ok := users.LockedUserID(err)
+			assert.True(t, ok, fmt.Sprintf("%s: expected locked user id in %s\n", tc.desc, err))
+			assert.Equal(t, user.ID, lockedID, fmt.Sprintf("%s: expected locked user id %s got %s\n", tc.desc, user.ID, lockedID))
+		}
+		authCall.Unset()
+		domainsCall.Unset()
+		repoCall5.Unset()
+		repoCall4.Unset()
+		repoCall3.Unset()
+		repoCall2.Unset()
+		repoCall1.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestIssueTokenWithDirectoryLockout(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	tokenClient := new(authmocks.TokenServiceClient)
+	domainsClient := new(dmocks.DomainsServiceClient)
+	directory := new(mocks.Directory)
+	lockout := users.LockoutPolicy{MaxAttempts: 3, Duration: time.Minute, MaxDuration: 10 * time.Minute}
+	svc := users.NewService(tokenClient, domainsClient, cRepo, new(policymocks.Service), new(mocks.Emailer), phasher, idProvider, directory, nil, users.PasswordPolicy{}, nil, lockout, users.VerificationPolicy{})
+
+	entry := users.DirectoryEntry{
+		User: users.User{
+			FirstName:   "first",
+			LastName:    "last",
+			Email:       "directory@example.com",
+			Credentials: users.Credentials{Username: "directory"},
+		},
+	}
+	dirUser := users.User{
+		ID:          userID,
+		FirstName:   "first",
+		LastName:    "last",
+		Email:       "directory@example.com",
+		Credentials: users.Credentials{Username: "directory"},
+		Status:      users.EnabledStatus,
+	}
+	failed := users.DirectoryEntry{User: users.User{Credentials: users.Credentials{Username: "directory"}}}
+	locked := users.LoginAttempts{UserID: userID, LockedUntil: time.Now().Add(time.Minute)}
+
+	cases := []struct {
+		desc                 string
+		authenticateResponse users.DirectoryEntry
+		authenticateErr      error
+		attempts             users.LoginAttempts
+		retrieveAttemptsErr  error
+		incremented          users.LoginAttempts
+		err                  error
+	}{
+		{
+			desc:                 "issue token with invalid directory credentials below the limit",
+			authenticateResponse: failed,
+			authenticateErr:      users.ErrInvalidDirectoryCredentials,
+			retrieveAttemptsErr:  repoerr.ErrNotFound,
+			incremented:          users.LoginAttempts{UserID: userID, Failed: 1},
+			err:                  svcerr.ErrLogin,
+		},
+		{
+			desc:                 "issue token with invalid directory credentials reaching the limit",
+			authenticateResponse: failed,
+			authenticateErr:      users.ErrInvalidDirectoryCredentials,
+			retrieveAttemptsErr:  repoerr.ErrNotFound,
+			incremented:          users.LoginAttempts{UserID: userID, Failed: 3},
+			err:                  users.ErrTooManyLoginAttempts,
+		},
+		{
+			desc:                 "issue token with invalid directory credentials for locked user",
+			authenticateResponse: failed,
+			authenticateErr:      users.ErrInvalidDirectoryCredentials,
+			attempts:             locked,
+			err:                  svcerr.ErrAccountLocked,
+		},
+		{
+			desc:                 "issue token with valid directory credentials for locked user",
+			authenticateResponse: entry,
+			attempts:             locked,
+			err:                  svcerr.ErrAccountLocked,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dirCall := directory.On("Authenticate", context.Background(), "directory", secret).Return(tc.authenticateResponse, tc.authenticateErr)
+			repoCall := cRepo.On("RetrieveByIdentity", context.Background(), users.DirectoryProvider, "directory").Return(dirUser, nil)
+			repoCall1 := cRepo.On("RetrieveLoginAttempts", context.Background(), userID).Return(tc.attempts, tc.retrieveAttemptsErr)
+			repoCall2 := cRepo.On("IncrementLoginAttempts", context.Background(), userID).Return(tc.incremented, nil)
+			repoCall3 := cRepo.On("SaveLoginAttempts", context.Background(), mock.Anything).Return(nil)
+			_, err := svc.IssueToken(context.Background(), "directory", secret, "", device, ip)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.attempts.UserID != "" {
+				repoCall2.Parent.AssertNotCalled(t, "IncrementLoginAttempts", context.Background(), userID)
+			}
+			dirCall.Unset()
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			cRepo.Calls = nil
+		})
+	}
+}
+
+func TestEnrollMFA(t *testing.T) {
+	svc, _, cRepo, _, _, _ := newService()
+
+	rUser := user
+	rUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
+
+	cases := []struct {
+		desc                string
+		secret              string
+		retrieveMFAResponse users.MFA
+		retrieveMFAErr      error
+		saveMFAErr          error
+		err                 error
+	}{
+		{
+			desc:           "enroll MFA for a user without MFA",
+			secret:         secret,
+			retrieveMFAErr: repoerr.ErrNotFound,
+		},
+		{
+			desc:                "enroll MFA for a user with pending enrolment",
+			secret:              secret,
+			retrieveMFAResponse: users.MFA{UserID: user.ID, Secret: "JBSWY3DPEHPK3PXP"},
+		},
+		{
+			desc:                "enroll MFA for a user with enabled MFA",
+			secret:              secret,
+			retrieveMFAResponse: users.MFA{UserID: user.ID, Secret: "JBSWY3DPEHPK3PXP", Enabled: true},
+			err:                 users.ErrMFAEnabled,
+		},
+		{
+			desc:   "enroll MFA with wrong secret",
+			secret: "wrongsecret",
+			err:    svcerr.ErrLogin,
+		},
+		{
+			desc:           "enroll MFA with failed to retrieve MFA",
+			secret:         secret,
+			retrieveMFAErr: repoerr.ErrViewEntity,
+			err:            svcerr.ErrViewEntity,
+		},
+		{
+			desc:           "enroll MFA with failed to save MFA",
+			secret:         secret,
+			retrieveMFAErr: repoerr.ErrNotFound,
+			saveMFAErr:     repoerr.ErrCreateEntity,
+			err:            svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByUsername", context.Background(), user.Credentials.Username).Return(rUser, nil)
+		repoCall1 := cRepo.On("RetrieveMFA", context.Background(), user.ID).Return(tc.retrieveMFAResponse, tc.retrieveMFAErr)
+		repoCall2 := cRepo.On("SaveMFA", context.Background(), mock.Anything).Return(tc.saveMFAErr)
+		enrolment, err := svc.EnrollMFA(context.Background(), user.Credentials.Username, tc.secret)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, enrolment.Secret, fmt.Sprintf("%s: expected secret not to be empty", tc.desc))
+			assert.True(t, strings.HasPrefix(enrolment.ProvisioningURI, "otpauth://totp/"), fmt.Sprintf("%s: unexpected provisioning URI %s", tc.desc, enrolment.ProvisioningURI))
+			ok := repoCall2.Parent.AssertCalled(t, "SaveMFA", context.Background(), users.MFA{UserID: user.ID, Secret: enrolment.Secret})
+			assert.True(t, ok, fmt.Sprintf("SaveMFA was not called on %s", tc.desc))
+		}
+		repoCall2.Unset()
+		repoCall1.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestVerifyMFA(t *testing.T) {
+	svc, _, cRepo, _, _, _ := newService()
 
-	enabledUser1 := users.User{ID: testsutil.GenerateUUID(t), Credentials: users.Credentials{Username: "user1@example.com", Secret: "password"}, Status: users.EnabledStatus}
-	deletedUser1 := users.User{ID: testsutil.GenerateUUID(t), Credentials: users.Credentials{Username: "user3@example.com", Secret: "password"}, Status: users.DeletedStatus}
-	disenabledUser1 := enabledUser1
-	disenabledUser1.Status = users.DeletedStatus
+	rUser := user
+	rUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
+	mfaSecret, err := totp.GenerateSecret()
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating MFA secret: %s", err))
+	code, err := totp.Generate(mfaSecret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating MFA code: %s", err))
+	pending := users.MFA{UserID: user.ID, Secret: mfaSecret}
 
 	cases := []struct {
-		desc                 string
-		id                   string
-		session              authn.Session
-		user                 users.User
-		retrieveByIDResponse users.User
-		changeStatusResponse users.User
-		response             users.User
-		retrieveByIDErr      error
-		changeStatusErr      error
-		checkSuperAdminErr   error
-		err                  error
+		desc                string
+		code                string
+		retrieveMFAResponse users.MFA
+		retrieveMFAErr      error
+		updateMFAErr        error
+		err                 error
 	}{
 		{
-			desc:                 "delete enabled user",
-			id:                   enabledUser1.ID,
-			user:                 enabledUser1,
-			session:              authn.Session{UserID: validID, SuperAdmin: true},
-			retrieveByIDResponse: enabledUser1,
-			changeStatusResponse: disenabledUser1,
-			response:             disenabledUser1,
-			err:                  nil,
+			desc:                "verify pending MFA enrolment with valid code",
+			code:                code,
+			retrieveMFAResponse: pending,
 		},
 		{
-			desc:                 "delete enabled user with failed to retrieve user by ID",
-			id:                   enabledUser1.ID,
-			user:                 enabledUser1,
-			session:              authn.Session{UserID: validID, SuperAdmin: true},
-			retrieveByIDResponse: users.User{},
-			retrieveByIDErr:      repoerr.ErrNotFound,
-			err:                  repoerr.ErrNotFound,
+			desc:                "verify pending MFA enrolment with invalid code",
+			code:                "invalid",
+			retrieveMFAResponse: pending,
+			err:                 svcerr.ErrInvalidMFACode,
 		},
 		{
-			desc:                 "delete already deleted user",
-			id:                   deletedUser1.ID,
-			user:                 deletedUser1,
-			session:              authn.Session{UserID: validID, SuperAdmin: true},
-			retrieveByIDResponse: deletedUser1,
-			err:                  errors.ErrStatusAlreadyAssigned,
+			desc:           "verify MFA for a user without enrolment",
+			code:           code,
+			retrieveMFAErr: repoerr.ErrNotFound,
+			err:            users.ErrMFANotEnrolled,
 		},
 		{
-			desc:                 "delete enabled user with failed to change status",
-			id:                   enabledUser1.ID,
-			user:                 enabledUser1,
-			session:              authn.Session{UserID: validID, SuperAdmin: true},
-			retrieveByIDResponse: enabledUser1,
-			changeStatusResponse: users.User{},
-			changeStatusErr:      repoerr.ErrMalformedEntity,
-			err:                  svcerr.ErrUpdateEntity,
+			desc:                "verify MFA for a user with enabled MFA",
+			code:                code,
+			retrieveMFAResponse: users.MFA{UserID: user.ID, Secret: mfaSecret, Enabled: true},
+			err:                 users.ErrMFAEnabled,
+		},
+		{
+			desc:                "verify MFA with failed to update MFA",
+			code:                code,
+			retrieveMFAResponse: pending,
+			updateMFAErr:        repoerr.ErrUpdateEntity,
+			err:                 svcerr.ErrUpdateEntity,
 		},
 	}
 
 	for _, tc := range cases {
-		repoCall2 := cRepo.On("CheckSuperAdmin", context.Background(), mock.Anything).Return(tc.checkSuperAdminErr)
-		repoCall3 := cRepo.On("RetrieveByID", context.Background(), tc.id).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
-		repoCall4 := cRepo.On("ChangeStatus", context.Background(), mock.Anything).Return(tc.changeStatusResponse, tc.changeStatusErr)
-		err := svc.Delete(context.Background(), tc.session, tc.id)
+		repoCall := cRepo.On("RetrieveByUsername", context.Background(), user.Credentials.Username).Return(rUser, nil)
+		repoCall1 := cRepo.On("RetrieveMFA", context.Background(), user.ID).Return(tc.retrieveMFAResponse, tc.retrieveMFAErr)
+		var mfa users.MFA
+		repoCall2 := cRepo.On("UpdateMFA", context.Background(), mock.Anything).Return(tc.updateMFAErr).Run(func(args mock.Arguments) {
+			mfa = args.Get(1).(users.MFA)
+		})
+		repoCall3 := cRepo.On("UpdateMFAStep", context.Background(), user.ID, mock.Anything).Return(nil)
+		codes, err := svc.VerifyMFA(context.Background(), user.Credentials.Username, secret, tc.code)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		if tc.err == nil {
-			ok := repoCall3.Parent.AssertCalled(t, "RetrieveByID", context.Background(), tc.id)
-			assert.True(t, ok, fmt.Sprintf("RetrieveByID was not called on %s", tc.desc))
-			ok = repoCall4.Parent.AssertCalled(t, "ChangeStatus", context.Background(), mock.Anything)
-			assert.True(t, ok, fmt.Sprintf("ChangeStatus was not called on %s", tc.desc))
+		if err == nil {
+			assert.Len(t, codes, 10, fmt.Sprintf("%s: expected 10 recovery codes got %d", tc.desc, len(codes)))
+			assert.True(t, mfa.Enabled, fmt.Sprintf("%s: expected MFA to be enabled", tc.desc))
+			assert.Len(t, mfa.RecoveryCodes, len(codes), fmt.Sprintf("%s: expected recovery codes to be stored", tc.desc))
+			assert.NotContains(t, mfa.RecoveryCodes, codes[0], fmt.Sprintf("%s: expected recovery codes to be stored hashed", tc.desc))
+			ok := repoCall3.Parent.AssertCalled(t, "UpdateMFAStep", context.Background(), user.ID, mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("UpdateMFAStep was not called on %s", tc.desc))
 		}
-		repoCall2.Unset()
 		repoCall3.Unset()
-		repoCall4.Unset()
+		repoCall2.Unset()
+		repoCall1.Unset()
+		repoCall.Unset()
 	}
 }
 
-func TestIssueToken(t *testing.T) {
-	svc, auth, cRepo, _, _ := newService()
+func TestDisableMFA(t *testing.T) {
+	svc, _, cRepo, _, _, _ := newService()
 
 	rUser := user
-	rUser2 := user
-	rUser3 := user
 	rUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
-	rUser2.Credentials.Secret = "wrongsecret"
-	rUser3.Credentials.Secret, _ = phasher.Hash("wrongsecret")
+	mfaSecret, err := totp.GenerateSecret()
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating MFA secret: %s", err))
+	code, err := totp.Generate(mfaSecret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating MFA code: %s", err))
+	enabled := users.MFA{UserID: user.ID, Secret: mfaSecret, Enabled: true}
 
 	cases := []struct {
-		desc                       string
-		user                       users.User
-		retrieveByUsernameResponse users.User
-		issueResponse              *grpcTokenV1.Token
-		retrieveByUsernameErr      error
-		issueErr                   error
-		err                        error
+		desc                string
+		code                string
+		retrieveMFAResponse users.MFA
+		retrieveMFAErr      error
+		removeMFAErr        error
+		err                 error
 	}{
 		{
-			desc:                       "issue token for an existing user",
-			user:                       user,
-			retrieveByUsernameResponse: rUser,
-			issueResponse:              &grpcTokenV1.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
-			err:                        nil,
-		},
-		{
-			desc:                       "issue token for non-empty domain id",
-			user:                       user,
-			retrieveByUsernameResponse: rUser,
-			issueResponse:              &grpcTokenV1.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
-			err:                        nil,
+			desc:                "disable MFA with valid code",
+			code:                code,
+			retrieveMFAResponse: enabled,
 		},
 		{
-			desc:                       "issue token for a non-existing user",
-			user:                       user,
-			retrieveByUsernameResponse: users.User{},
-			retrieveByUsernameErr:      repoerr.ErrNotFound,
-			err:                        repoerr.ErrNotFound,
+			desc:                "disable MFA with invalid code",
+			code:                "invalid",
+			retrieveMFAResponse: enabled,
+			err:                 svcerr.ErrInvalidMFACode,
 		},
 		{
-			desc:                       "issue token for a user with wrong secret",
-			user:                       user,
-			retrieveByUsernameResponse: rUser3,
-			err:                        svcerr.ErrLogin,
+			desc:                "disable MFA with pending enrolment",
+			code:                code,
+			retrieveMFAResponse: users.MFA{UserID: user.ID, Secret: mfaSecret},
+			err:                 users.ErrMFANotEnrolled,
 		},
 		{
-			desc:                       "issue token with empty domain id",
-			user:                       user,
-			retrieveByUsernameResponse: rUser,
-			issueResponse:              &grpcTokenV1.Token{},
-			issueErr:                   svcerr.ErrAuthentication,
-			err:                        svcerr.ErrAuthentication,
+			desc:           "disable MFA with failed to retrieve MFA",
+			code:           code,
+			retrieveMFAErr: repoerr.ErrViewEntity,
+			err:            svcerr.ErrViewEntity,
 		},
 		{
-			desc:                       "issue token with grpc error",
-			user:                       user,
-			retrieveByUsernameResponse: rUser,
-			issueResponse:              &grpcTokenV1.Token{},
-			issueErr:                   svcerr.ErrAuthentication,
-			err:                        svcerr.ErrAuthentication,
+			desc:                "disable MFA with failed to remove MFA",
+			code:                code,
+			retrieveMFAResponse: enabled,
+			removeMFAErr:        repoerr.ErrRemoveEntity,
+			err:                 svcerr.ErrRemoveEntity,
 		},
 	}
 
 	for _, tc := range cases {
-		repoCall := cRepo.On("RetrieveByUsername", context.Background(), tc.user.Credentials.Username).Return(tc.retrieveByUsernameResponse, tc.retrieveByUsernameErr)
-		authCall := auth.On("Issue", context.Background(), &grpcTokenV1.IssueReq{UserId: tc.user.ID, Type: uint32(smqauth.AccessKey)}).Return(tc.issueResponse, tc.issueErr)
-		token, err := svc.IssueToken(context.Background(), tc.user.Credentials.Username, tc.user.Credentials.Secret)
+		repoCall := cRepo.On("RetrieveByUsername", context.Background(), user.Credentials.Username).Return(rUser, nil)
+		repoCall1 := cRepo.On("RetrieveMFA", context.Background(), user.ID).Return(tc.retrieveMFAResponse, tc.retrieveMFAErr)
+		repoCall2 := cRepo.On("RemoveMFA", context.Background(), user.ID).Return(tc.removeMFAErr)
+		repoCall3 := cRepo.On("UpdateMFAStep", context.Background(), user.ID, mock.Anything).Return(nil)
+		err := svc.DisableMFA(context.Background(), user.Credentials.Username, secret, tc.code)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		if err == nil {
-			assert.NotEmpty(t, token.GetAccessToken(), fmt.Sprintf("%s: expected %s not to be empty\n", tc.desc, token.GetAccessToken()))
-			assert.NotEmpty(t, token.GetRefreshToken(), fmt.Sprintf("%s: expected %s not to be empty\n", tc.desc, token.GetRefreshToken()))
-			ok := repoCall.Parent.AssertCalled(t, "RetrieveByUsername", context.Background(), tc.user.Credentials.Username)
-			assert.True(t, ok, fmt.Sprintf("RetrieveByUsername was not called on %s", tc.desc))
-			ok = authCall.Parent.AssertCalled(t, "Issue", context.Background(), &grpcTokenV1.IssueReq{UserId: tc.user.ID, Type: uint32(smqauth.AccessKey)})
-			assert.True(t, ok, fmt.Sprintf("Issue was not called on %s", tc.desc))
-		}
-		authCall.Unset()
+		repoCall3.Unset()
+		repoCall2.Unset()
+		repoCall1.Unset()
 		repoCall.Unset()
 	}
 }
 
 func TestRefreshToken(t *testing.T) {
-	svc, authsvc, crepo, _, _ := newService()
+	svc, authsvc, crepo, _, _, _ := newService()
 
 	rUser := user
 	rUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
@@ -1489,7 +2892,7 @@ func TestRefreshToken(t *testing.T) {
 }
 
 func TestGenerateResetToken(t *testing.T) {
-	svc, auth, cRepo, _, e := newService()
+	svc, auth, cRepo, _, e, _ := newService()
 
 	cases := []struct {
 		desc                    string
@@ -1547,7 +2950,7 @@ func TestGenerateResetToken(t *testing.T) {
 }
 
 func TestResetSecret(t *testing.T) {
-	svc, cRepo := newServiceMinimal()
+	svc, authUser, cRepo, _, _, _ := newService()
 
 	user := users.User{
 		ID:    "userID",
@@ -1565,6 +2968,7 @@ func TestResetSecret(t *testing.T) {
 		updateSecretResponse users.User
 		retrieveByIDErr      error
 		updateSecretErr      error
+		revokeSessionsErr    error
 		err                  error
 	}{
 		{
@@ -1615,18 +3019,36 @@ func TestResetSecret(t *testing.T) {
 			retrieveByIDResponse: user,
 			err:                  errHashPassword,
 		},
+		{
+			desc:                 "reset secret with failed to revoke sessions",
+			newSecret:            "newStrongSecret",
+			session:              authn.Session{UserID: validID, SuperAdmin: true},
+			retrieveByIDResponse: user,
+			updateSecretResponse: users.User{
+				ID:    "userID",
+				Email: "test@example.com",
+				Credentials: users.Credentials{
+					Secret: "newStrongSecret",
+				},
+			},
+			revokeSessionsErr: svcerr.ErrAuthentication,
+			err:               svcerr.ErrAuthentication,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			repoCall := cRepo.On("RetrieveByID", context.Background(), mock.Anything).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
 			repoCall1 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(tc.updateSecretResponse, tc.updateSecretErr)
+			authCall := authUser.On("RevokeSessions", context.Background(), &grpcTokenV1.RevokeSessionsReq{UserId: tc.retrieveByIDResponse.ID}).Return(&grpcTokenV1.RevokeSessionsRes{Revoked: tc.revokeSessionsErr == nil}, tc.revokeSessionsErr)
 			err := svc.ResetSecret(context.Background(), tc.session, tc.newSecret)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 			if tc.err == nil {
 				repoCall1.Parent.AssertCalled(t, "UpdateSecret", context.Background(), mock.Anything)
 				repoCall.Parent.AssertCalled(t, "RetrieveByID", context.Background(), validID)
+				authCall.Parent.AssertCalled(t, "RevokeSessions", context.Background(), &grpcTokenV1.RevokeSessionsReq{UserId: tc.retrieveByIDResponse.ID})
 			}
+			authCall.Unset()
 			repoCall1.Unset()
 			repoCall.Unset()
 		})
@@ -1680,80 +3102,336 @@ func TestViewProfile(t *testing.T) {
 }
 
 func TestOAuthCallback(t *testing.T) {
-	svc, _, cRepo, policies, _ := newService()
+	svc, _, cRepo, policies, _, _ := newService()
+
+	oauthUser := users.User{
+		ID:       "provider-user-id",
+		Email:    "test@example.com",
+		Metadata: users.Metadata{"oauth_provider": "google"},
+		Status:   users.EnabledStatus,
+		Role:     users.UserRole,
+	}
 
 	cases := []struct {
-		desc                    string
-		user                    users.User
-		retrieveByEmailResponse users.User
-		retrieveByEmailErr      error
-		saveResponse            users.User
-		addPoliciesErr          error
-		err                     error
+		desc                       string
+		user                       users.User
+		retrieveByIdentityResponse users.User
+		retrieveByIdentityErr      error
+		retrieveByEmailResponse    users.User
+		retrieveByEmailErr         error
+		saveResponse               users.User
+		addPoliciesErr             error
+		saveIdentityErr            error
+		changeStatusErr            error
+		linked                     bool
+		err                        error
 	}{
 		{
-			desc: "oauth signin callback with already existing user",
-			user: users.User{
-				Email: "test@example.com",
+			desc: "oauth signin callback with linked user",
+			user: oauthUser,
+			retrieveByIdentityResponse: users.User{
+				ID:     testsutil.GenerateUUID(t),
+				Role:   users.UserRole,
+				Status: users.EnabledStatus,
+			},
+			err: nil,
+		},
+		{
+			desc: "oauth signin callback with linked disabled user",
+			user: oauthUser,
+			retrieveByIdentityResponse: users.User{
+				ID:     testsutil.GenerateUUID(t),
+				Role:   users.UserRole,
+				Status: users.DisabledStatus,
+			},
+			err: svcerr.ErrLogin,
+		},
+		{
+			desc: "oauth signin callback with linked unverified user",
+			user: oauthUser,
+			retrieveByIdentityResponse: users.User{
+				ID:     testsutil.GenerateUUID(t),
+				Role:   users.UserRole,
+				Status: users.UnverifiedStatus,
 			},
+			err: nil,
+		},
+		{
+			desc: "oauth signin callback with failed to enable linked unverified user",
+			user: oauthUser,
+			retrieveByIdentityResponse: users.User{
+				ID:     testsutil.GenerateUUID(t),
+				Role:   users.UserRole,
+				Status: users.UnverifiedStatus,
+			},
+			changeStatusErr: repoerr.ErrUpdateEntity,
+			err:             svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:                  "oauth signin callback with failed to retrieve linked user",
+			user:                  oauthUser,
+			retrieveByIdentityErr: repoerr.ErrViewEntity,
+			err:                   svcerr.ErrAuthentication,
+		},
+		{
+			desc:                  "oauth signin callback with existing user with the same email",
+			user:                  oauthUser,
+			retrieveByIdentityErr: repoerr.ErrNotFound,
 			retrieveByEmailResponse: users.User{
 				ID:   testsutil.GenerateUUID(t),
 				Role: users.UserRole,
 			},
-			err: nil,
+			linked: true,
+			err:    nil,
 		},
 		{
-			desc: "oauth signup callback with user not found",
-			user: users.User{
-				Email: "test@example.com",
-			},
-			retrieveByEmailErr: repoerr.ErrNotFound,
+			desc:                  "oauth signup callback with user not found",
+			user:                  oauthUser,
+			retrieveByIdentityErr: repoerr.ErrNotFound,
+			retrieveByEmailErr:    repoerr.ErrNotFound,
 			saveResponse: users.User{
 				ID:   testsutil.GenerateUUID(t),
 				Role: users.UserRole,
 			},
-			err: nil,
+			linked: true,
+			err:    nil,
 		},
 		{
-			desc: "oauth signup callback with malformed entity",
-			user: users.User{
-				Email: "test@example.com",
-			},
-			retrieveByEmailErr: repoerr.ErrMalformedEntity,
-			err:                repoerr.ErrMalformedEntity,
+			desc:                  "oauth signup callback with malformed entity",
+			user:                  oauthUser,
+			retrieveByIdentityErr: repoerr.ErrNotFound,
+			retrieveByEmailErr:    repoerr.ErrMalformedEntity,
+			err:                   repoerr.ErrMalformedEntity,
 		},
 		{
-			desc: "oauth signup callback with failed to register user",
-			user: users.User{
-				Email: "test@example.com",
-			},
-			addPoliciesErr:     svcerr.ErrAuthorization,
-			retrieveByEmailErr: repoerr.ErrNotFound,
-			err:                svcerr.ErrAuthorization,
+			desc:                  "oauth signup callback with failed to register user",
+			user:                  oauthUser,
+			retrieveByIdentityErr: repoerr.ErrNotFound,
+			addPoliciesErr:        svcerr.ErrAuthorization,
+			retrieveByEmailErr:    repoerr.ErrNotFound,
+			err:                   svcerr.ErrAuthorization,
 		},
 		{
-			desc: "oauth signin callback with user not in the platform",
-			user: users.User{
-				Email: "test@example.com",
-			},
+			desc:                  "oauth signin callback with failed to link user",
+			user:                  oauthUser,
+			retrieveByIdentityErr: repoerr.ErrNotFound,
 			retrieveByEmailResponse: users.User{
 				ID:   testsutil.GenerateUUID(t),
 				Role: users.UserRole,
 			},
-			err: nil,
+			saveIdentityErr: repoerr.ErrCreateEntity,
+			err:             svcerr.ErrCreateEntity,
+		},
+		{
+			desc: "oauth signin callback without provider",
+			user: users.User{
+				ID:    "provider-user-id",
+				Email: "test@example.com",
+			},
+			err: svcerr.ErrAuthentication,
 		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			repoCall := cRepo.On("RetrieveByEmail", context.Background(), tc.user.Email).Return(tc.retrieveByEmailResponse, tc.retrieveByEmailErr)
-			repoCall1 := cRepo.On("Save", context.Background(), mock.Anything).Return(tc.saveResponse, nil)
+			repoCall := cRepo.On("RetrieveByIdentity", context.Background(), "google", tc.user.ID).Return(tc.retrieveByIdentityResponse, tc.retrieveByIdentityErr)
+			repoCall1 := cRepo.On("RetrieveByEmail", context.Background(), tc.user.Email).Return(tc.retrieveByEmailResponse, tc.retrieveByEmailErr)
+			repoCall2 := cRepo.On("Save", context.Background(), mock.Anything).Return(tc.saveResponse, nil)
+			repoCall3 := cRepo.On("SaveIdentity", context.Background(), mock.Anything).Return(tc.saveIdentityErr)
+			repoCall4 := cRepo.On("ChangeStatus", context.Background(), mock.Anything).Return(users.User{}, tc.changeStatusErr)
+			repoCall5 := cRepo.On("RemoveVerification", context.Background(), tc.retrieveByIdentityResponse.ID).Return(nil)
 			policyCall := policies.On("AddPolicies", context.Background(), mock.Anything).Return(tc.addPoliciesErr)
 			_, err := svc.OAuthCallback(context.Background(), tc.user)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-			repoCall.Parent.AssertCalled(t, "RetrieveByEmail", context.Background(), tc.user.Email)
+			if tc.err == nil && tc.retrieveByIdentityResponse.Status == users.UnverifiedStatus {
+				ok := repoCall4.Parent.AssertCalled(t, "ChangeStatus", context.Background(), mock.MatchedBy(func(u users.User) bool {
+					return u.ID == tc.retrieveByIdentityResponse.ID && u.Status == users.EnabledStatus
+				}))
+				assert.True(t, ok, fmt.Sprintf("ChangeStatus was not called on %s", tc.desc))
+			}
+			if tc.linked {
+				ok := repoCall3.Parent.AssertCalled(t, "SaveIdentity", context.Background(), mock.MatchedBy(func(i users.Identity) bool {
+					return i.Provider == "google" && i.Subject == tc.user.ID && i.UserID != ""
+				}))
+				assert.True(t, ok, fmt.Sprintf("SaveIdentity was not called on %s", tc.desc))
+			}
 			repoCall.Unset()
 			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			repoCall4.Unset()
+			repoCall5.Unset()
 			policyCall.Unset()
 		})
 	}
 }
+
+func TestOAuthCallbackWithVerification(t *testing.T) {
+	svc, cRepo, policies, _ := newServiceWithVerification()
+
+	oauthUser := users.User{
+		ID:       "provider-user-id",
+		Email:    "test@example.com",
+		Metadata: users.Metadata{"oauth_provider": "google"},
+		Status:   users.EnabledStatus,
+		Role:     users.UserRole,
+	}
+
+	var saved users.User
+	repoCall := cRepo.On("RetrieveByIdentity", context.Background(), "google", oauthUser.ID).Return(users.User{}, repoerr.ErrNotFound)
+	repoCall1 := cRepo.On("RetrieveByEmail", context.Background(), oauthUser.Email).Return(users.User{}, repoerr.ErrNotFound)
+	repoCall2 := cRepo.On("Save", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(users.User)
+	}).Return(users.User{ID: testsutil.GenerateUUID(t), Role: users.UserRole, Status: users.EnabledStatus}, nil)
+	repoCall3 := cRepo.On("SaveIdentity", context.Background(), mock.Anything).Return(nil)
+	policyCall := policies.On("AddPolicies", context.Background(), mock.Anything).Return(nil)
+	_, err := svc.OAuthCallback(context.Background(), oauthUser)
+	assert.Nil(t, err, fmt.Sprintf("oauth signup callback with verification: expected nil got %s\n", err))
+	assert.Equal(t, users.EnabledStatus, saved.Status, fmt.Sprintf("oauth signup callback with verification: expected %s status got %s\n", users.EnabledStatus, saved.Status))
+	ok := repoCall2.Parent.AssertNotCalled(t, "SaveVerification", mock.Anything, mock.Anything)
+	assert.True(t, ok, "SaveVerification was called on oauth signup callback with verification")
+	repoCall.Unset()
+	repoCall1.Unset()
+	repoCall2.Unset()
+	repoCall3.Unset()
+	policyCall.Unset()
+}
+
+func TestOAuthIssueToken(t *testing.T) {
+	svc, auth, cRepo, _, _, domains := newService()
+
+	mfa := users.MFA{UserID: user.ID, Secret: "secret", Enabled: true}
+
+	cases := []struct {
+		desc                string
+		retrieveMFAResponse users.MFA
+		retrieveMFAErr      error
+		requiresMFAResponse bool
+		saveChallengeErr    error
+		issueResponse       *grpcTokenV1.Token
+		issueErr            error
+		challenge           bool
+		err                 error
+	}{
+		{
+			desc:           "issue oauth token for a user without MFA",
+			retrieveMFAErr: repoerr.ErrNotFound,
+			issueResponse:  &grpcTokenV1.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
+		},
+		{
+			desc:                "issue oauth token for a user with MFA",
+			retrieveMFAResponse: mfa,
+			challenge:           true,
+		},
+		{
+			desc:                "issue oauth token for a user with MFA with failed to save challenge",
+			retrieveMFAResponse: mfa,
+			saveChallengeErr:    repoerr.ErrCreateEntity,
+			err:                 svcerr.ErrCreateEntity,
+		},
+		{
+			desc:                "issue oauth token for a user without MFA in domain requiring MFA",
+			retrieveMFAErr:      repoerr.ErrNotFound,
+			requiresMFAResponse: true,
+			err:                 svcerr.ErrMFAEnrolmentRequired,
+		},
+		{
+			desc:           "issue oauth token with failed to issue token",
+			retrieveMFAErr: repoerr.ErrNotFound,
+			issueResponse:  &grpcTokenV1.Token{},
+			issueErr:       svcerr.ErrAuthentication,
+			err:            svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := cRepo.On("RetrieveMFA", context.Background(), user.ID).Return(tc.retrieveMFAResponse, tc.retrieveMFAErr)
+			repoCall1 := cRepo.On("SaveMFAChallenge", context.Background(), mock.Anything).Return(tc.saveChallengeErr)
+			domainsCall := domains.On("RequiresMFA", context.Background(), &grpcDomainsV1.RequiresMFAReq{UserId: user.ID}).Return(&grpcDomainsV1.RequiresMFARes{Required: tc.requiresMFAResponse}, nil)
+			authCall := auth.On("Issue", context.Background(), &grpcTokenV1.IssueReq{UserId: user.ID, Type: uint32(smqauth.AccessKey), Device: device, Ip: ip}).Return(tc.issueResponse, tc.issueErr)
+			token, challenge, err := svc.OAuthIssueToken(context.Background(), user.ID, device, ip)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.challenge, challenge != "", fmt.Sprintf("%s: expected challenge %t got %s\n", tc.desc, tc.challenge, challenge))
+				assert.Equal(t, tc.challenge, token.GetAccessToken() == "", fmt.Sprintf("%s: unexpected access token %s\n", tc.desc, token.GetAccessToken()))
+			}
+			authCall.Unset()
+			domainsCall.Unset()
+			repoCall1.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestIssueMFAToken(t *testing.T) {
+	svc, auth, cRepo, _, _, domains := newService()
+
+	mfaSecret, err := totp.GenerateSecret()
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating MFA secret: %s", err))
+	otp, err := totp.Generate(mfaSecret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating MFA code: %s", err))
+	mfa := users.MFA{UserID: user.ID, Secret: mfaSecret, Enabled: true}
+	challenge := users.MFAChallenge{UserID: user.ID, ExpiresAt: time.Now().Add(time.Minute)}
+
+	cases := []struct {
+		desc                string
+		otp                 string
+		removeChallengeResp users.MFAChallenge
+		removeChallengeErr  error
+		updateMFAStepErr    error
+		issueResponse       *grpcTokenV1.Token
+		err                 error
+	}{
+		{
+			desc:                "issue MFA token with valid challenge and code",
+			otp:                 otp,
+			removeChallengeResp: challenge,
+			issueResponse:       &grpcTokenV1.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
+		},
+		{
+			desc:               "issue MFA token with unknown challenge",
+			otp:                otp,
+			removeChallengeErr: repoerr.ErrNotFound,
+			err:                users.ErrInvalidMFAChallenge,
+		},
+		{
+			desc:                "issue MFA token with expired challenge",
+			otp:                 otp,
+			removeChallengeResp: users.MFAChallenge{UserID: user.ID, ExpiresAt: time.Now().Add(-time.Minute)},
+			err:                 users.ErrInvalidMFAChallenge,
+		},
+		{
+			desc:                "issue MFA token with invalid code",
+			otp:                 "invalid",
+			removeChallengeResp: challenge,
+			err:                 svcerr.ErrInvalidMFACode,
+		},
+		{
+			desc:                "issue MFA token with replayed code",
+			otp:                 otp,
+			removeChallengeResp: challenge,
+			updateMFAStepErr:    repoerr.ErrNotFound,
+			err:                 svcerr.ErrInvalidMFACode,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := cRepo.On("RemoveMFAChallenge", context.Background(), mock.Anything).Return(tc.removeChallengeResp, tc.removeChallengeErr)
+			repoCall1 := cRepo.On("RetrieveMFA", context.Background(), user.ID).Return(mfa, nil)
+			repoCall2 := cRepo.On("UpdateMFAStep", context.Background(), user.ID, mock.Anything).Return(tc.updateMFAStepErr)
+			domainsCall := domains.On("RequiresMFA", context.Background(), mock.Anything).Return(&grpcDomainsV1.RequiresMFARes{}, nil)
+			authCall := auth.On("Issue", context.Background(), &grpcTokenV1.IssueReq{UserId: user.ID, Type: uint32(smqauth.AccessKey), Device: device, Ip: ip}).Return(tc.issueResponse, nil)
+			token, err := svc.IssueMFAToken(context.Background(), "challenge", tc.otp, device, ip)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.NotEmpty(t, token.GetAccessToken(), fmt.Sprintf("%s: expected access token not to be empty\n", tc.desc))
+			}
+			authCall.Unset()
+			domainsCall.Unset()
+			repoCall2.Unset()
+			repoCall1.Unset()
+			repoCall.Unset()
+		})
+	}
+}
@@ -21,6 +21,9 @@ const (
 	DisabledStatus
 	// DeletedStatus represents a user that will be deleted.
 	DeletedStatus
+	// UnverifiedStatus represents a self-registered user whose email
+	// is not verified yet.
+	UnverifiedStatus
 
 	// AllStatus is used for querying purposes to list users irrespective
 	// of their status - both enabled and disabled. It is never stored in the
@@ -31,11 +34,12 @@ const (
 
 // String representation of the possible status values.
 const (
-	Disabled = "disabled"
-	Enabled  = "enabled"
-	Deleted  = "deleted"
-	All      = "all"
-	Unknown  = "unknown"
+	Disabled   = "disabled"
+	Enabled    = "enabled"
+	Deleted    = "deleted"
+	Unverified = "unverified"
+	All        = "all"
+	Unknown    = "unknown"
 )
 
 // String converts user/group status to string literal.
@@ -47,6 +51,8 @@ func (s Status) String() string {
 		return Enabled
 	case DeletedStatus:
 		return Deleted
+	case UnverifiedStatus:
+		return Unverified
 	case AllStatus:
 		return All
 	default:
@@ -63,6 +69,8 @@ func ToStatus(status string) (Status, error) {
 		return DisabledStatus, nil
 	case Deleted:
 		return DeletedStatus, nil
+	case Unverified:
+		return UnverifiedStatus, nil
 	case All:
 		return AllStatus, nil
 	}
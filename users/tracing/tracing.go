@@ -34,11 +34,43 @@ func (tm *tracingMiddleware) Register(ctx context.Context, session authn.Session
 }
 
 // IssueToken traces the "IssueToken" operation of the wrapped users.Service.
-func (tm *tracingMiddleware) IssueToken(ctx context.Context, username, secret string) (*grpcTokenV1.Token, error) {
+func (tm *tracingMiddleware) IssueToken(ctx context.Context, username, secret, otp, device, ip string) (*grpcTokenV1.Token, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_issue_token", trace.WithAttributes(attribute.String("username", username)))
 	defer span.End()
 
-	return tm.svc.IssueToken(ctx, username, secret)
+	return tm.svc.IssueToken(ctx, username, secret, otp, device, ip)
+}
+
+// IssueMFAToken traces the "IssueMFAToken" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) IssueMFAToken(ctx context.Context, challenge, otp, device, ip string) (*grpcTokenV1.Token, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_issue_mfa_token")
+	defer span.End()
+
+	return tm.svc.IssueMFAToken(ctx, challenge, otp, device, ip)
+}
+
+// EnrollMFA traces the "EnrollMFA" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) EnrollMFA(ctx context.Context, username, secret string) (users.MFAEnrolment, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_enroll_mfa", trace.WithAttributes(attribute.String("username", username)))
+	defer span.End()
+
+	return tm.svc.EnrollMFA(ctx, username, secret)
+}
+
+// VerifyMFA traces the "VerifyMFA" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) VerifyMFA(ctx context.Context, username, secret, code string) ([]string, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_verify_mfa", trace.WithAttributes(attribute.String("username", username)))
+	defer span.End()
+
+	return tm.svc.VerifyMFA(ctx, username, secret, code)
+}
+
+// DisableMFA traces the "DisableMFA" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) DisableMFA(ctx context.Context, username, secret, code string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_disable_mfa", trace.WithAttributes(attribute.String("username", username)))
+	defer span.End()
+
+	return tm.svc.DisableMFA(ctx, username, secret, code)
 }
 
 // RefreshToken traces the "RefreshToken" operation of the wrapped users.Service.
@@ -145,6 +177,24 @@ func (tm *tracingMiddleware) UpdateProfilePicture(ctx context.Context, session a
 	return tm.svc.UpdateProfilePicture(ctx, session, usr)
 }
 
+// VerifyEmail traces the "VerifyEmail" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) VerifyEmail(ctx context.Context, token string) (users.User, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_verify_email")
+	defer span.End()
+
+	return tm.svc.VerifyEmail(ctx, token)
+}
+
+// ResendVerification traces the "ResendVerification" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) ResendVerification(ctx context.Context, email string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_resend_verification", trace.WithAttributes(
+		attribute.String("email", email),
+	))
+	defer span.End()
+
+	return tm.svc.ResendVerification(ctx, email)
+}
+
 // GenerateResetToken traces the "GenerateResetToken" operation of the wrapped users.Service.
 func (tm *tracingMiddleware) GenerateResetToken(ctx context.Context, email, host string) error {
 	ctx, span := tm.tracer.Start(ctx, "svc_generate_reset_token", trace.WithAttributes(
@@ -236,6 +286,22 @@ func (tm *tracingMiddleware) Delete(ctx context.Context, session authn.Session,
 	return tm.svc.Delete(ctx, session, id)
 }
 
+// Unlock traces the "Unlock" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) Unlock(ctx context.Context, session authn.Session, id string) (users.User, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_unlock_user", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.Unlock(ctx, session, id)
+}
+
+// OAuthIssueToken traces the "OAuthIssueToken" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) OAuthIssueToken(ctx context.Context, userID, device, ip string) (*grpcTokenV1.Token, string, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_oauth_issue_token", trace.WithAttributes(attribute.String("user_id", userID)))
+	defer span.End()
+
+	return tm.svc.OAuthIssueToken(ctx, userID, device, ip)
+}
+
 // OAuthAddUserPolicy traces the "OAuthAddUserPolicy" operation of the wrapped users.Service.
 func (tm *tracingMiddleware) OAuthAddUserPolicy(ctx context.Context, user users.User) error {
 	ctx, span := tm.tracer.Start(ctx, "svc_add_user_policy", trace.WithAttributes(
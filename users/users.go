@@ -96,6 +96,73 @@ type Repository interface {
 	// Save persists the user account. A non-nil error is returned to indicate
 	// operation failure.
 	Save(ctx context.Context, user User) (User, error)
+
+	// SaveMFA persists the pending MFA enrolment, replacing the previous one.
+	SaveMFA(ctx context.Context, mfa MFA) error
+
+	// RetrieveMFA retrieves the MFA settings of the user.
+	RetrieveMFA(ctx context.Context, userID string) (MFA, error)
+
+	// UpdateMFA updates the MFA state and recovery codes of the user.
+	UpdateMFA(ctx context.Context, mfa MFA) error
+
+	// RemoveMFA removes the MFA settings of the user.
+	RemoveMFA(ctx context.Context, userID string) error
+
+	// UpdateMFAStep sets the TOTP time step of the last used code if it is
+	// after the stored one. ErrNotFound is returned if it's not, so that
+	// the code can't be replayed.
+	UpdateMFAStep(ctx context.Context, userID string, step uint64) error
+
+	// SaveMFAChallenge persists the pending MFA challenge and removes the
+	// expired ones.
+	SaveMFAChallenge(ctx context.Context, challenge MFAChallenge) error
+
+	// RemoveMFAChallenge removes and returns the MFA challenge with the
+	// challenge token hash.
+	RemoveMFAChallenge(ctx context.Context, tokenHash string) (MFAChallenge, error)
+
+	// SaveIdentity links the user to the identity provider account.
+	SaveIdentity(ctx context.Context, identity Identity) error
+
+	// RetrieveByIdentity retrieves the user linked to the identity provider account.
+	RetrieveByIdentity(ctx context.Context, provider, subject string) (User, error)
+
+	// RetrieveLoginAttempts retrieves the failed login attempts of the user.
+	RetrieveLoginAttempts(ctx context.Context, userID string) (LoginAttempts, error)
+
+	// IncrementLoginAttempts atomically increments the failed login attempts
+	// of the user and returns the updated attempts.
+	IncrementLoginAttempts(ctx context.Context, userID string) (LoginAttempts, error)
+
+	// SaveLoginAttempts persists the failed login attempts of the user.
+	SaveLoginAttempts(ctx context.Context, attempts LoginAttempts) error
+
+	// RemoveLoginAttempts removes the failed login attempts of the user.
+	RemoveLoginAttempts(ctx context.Context, userID string) error
+
+	// RetrieveSecretHistory retrieves up to limit previous secrets of the
+	// user, starting with the most recent one.
+	RetrieveSecretHistory(ctx context.Context, userID string, limit uint) ([]string, error)
+
+	// AddSecretHistory adds the previous secret of the user to the history
+	// and keeps only the keep most recent secrets.
+	AddSecretHistory(ctx context.Context, userID, secret string, keep uint) error
+
+	// SaveVerification saves the pending email verification of the user,
+	// replacing the previous one.
+	SaveVerification(ctx context.Context, verification Verification) error
+
+	// RetrieveVerification retrieves the pending email verification by the
+	// verification token hash.
+	RetrieveVerification(ctx context.Context, tokenHash string) (Verification, error)
+
+	// RetrieveVerificationByEmail retrieves the most recent pending email
+	// verification of the email.
+	RetrieveVerificationByEmail(ctx context.Context, email string) (Verification, error)
+
+	// RemoveVerification removes the pending email verification of the user.
+	RemoveVerification(ctx context.Context, userID string) error
 }
 
 // Validate returns an error if user representation is invalid.
@@ -160,9 +227,19 @@ type Service interface {
 	// UpdateTags updates the user's tags.
 	UpdateTags(ctx context.Context, session authn.Session, user User) (User, error)
 
-	// UpdateEmail updates the user's email.
+	// UpdateEmail updates the user's email. When the email verification is
+	// enabled, the users changing their own email have to confirm the new
+	// email with VerifyEmail before it replaces the current one.
 	UpdateEmail(ctx context.Context, session authn.Session, id, email string) (User, error)
 
+	// VerifyEmail confirms the email ownership with the verification token.
+	// It activates the unverified user, or applies the pending email change.
+	VerifyEmail(ctx context.Context, token string) (User, error)
+
+	// ResendVerification sends a new verification token to the email with
+	// a pending verification.
+	ResendVerification(ctx context.Context, email string) error
+
 	// UpdateUsername updates the user's username.
 	UpdateUsername(ctx context.Context, session authn.Session, id, username string) (User, error)
 
@@ -195,11 +272,31 @@ type Service interface {
 	// Delete deletes user with given ID.
 	Delete(ctx context.Context, session authn.Session, id string) error
 
+	// Unlock unlocks the user account locked after the failed login attempts.
+	Unlock(ctx context.Context, session authn.Session, id string) (User, error)
+
 	// Identify returns the user id from the given token.
 	Identify(ctx context.Context, session authn.Session) (string, error)
 
 	// IssueToken issues a new access and refresh token when provided with either a username or email.
-	IssueToken(ctx context.Context, identity, secret string) (*grpcTokenV1.Token, error)
+	// If the user enabled MFA, otp must be a valid TOTP or recovery code.
+	// The device and IP identify the client of the new session.
+	IssueToken(ctx context.Context, identity, secret, otp, device, ip string) (*grpcTokenV1.Token, error)
+
+	// IssueMFAToken issues a new access and refresh token for the MFA
+	// challenge of the OAuth sign in, if otp is a valid TOTP or recovery code.
+	IssueMFAToken(ctx context.Context, challenge, otp, device, ip string) (*grpcTokenV1.Token, error)
+
+	// EnrollMFA generates a new TOTP secret for the user authenticated by the
+	// credentials. MFA is enabled once the enrolment is verified.
+	EnrollMFA(ctx context.Context, identity, secret string) (MFAEnrolment, error)
+
+	// VerifyMFA verifies the pending enrolment with the TOTP code, enables MFA
+	// and returns the recovery codes, which are shown to the user only once.
+	VerifyMFA(ctx context.Context, identity, secret, code string) ([]string, error)
+
+	// DisableMFA disables MFA after verifying the TOTP or recovery code.
+	DisableMFA(ctx context.Context, identity, secret, code string) error
 
 	// RefreshToken refreshes expired access tokens.
 	// After an access token expires, the refresh token is used to get
@@ -208,8 +305,16 @@ type Service interface {
 
 	// OAuthCallback handles the callback from any supported OAuth provider.
 	// It processes the OAuth tokens and either signs in or signs up the user based on the provided state.
+	// The user is identified by the provider and the provider user ID. The
+	// existing user with the email verified by the provider is linked on
+	// the first sign in.
 	OAuthCallback(ctx context.Context, user User) (User, error)
 
 	// OAuthAddUserPolicy adds a policy to the user for an OAuth request.
 	OAuthAddUserPolicy(ctx context.Context, user User) error
+
+	// OAuthIssueToken issues a new access and refresh token for the user
+	// signed in with the OAuth provider. If the user enabled MFA, no token
+	// is issued and the MFA challenge used with IssueMFAToken is returned.
+	OAuthIssueToken(ctx context.Context, userID, device, ip string) (*grpcTokenV1.Token, string, error)
 }
@@ -0,0 +1,45 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// ErrInvalidVerificationToken indicates that the email verification token
+// is unknown or expired.
+var ErrInvalidVerificationToken = errors.New("invalid or expired email verification token")
+
+// ErrVerificationResent indicates that the verification email was resent
+// less than the resend interval ago.
+var ErrVerificationResent = errors.New("verification email was sent recently")
+
+// VerificationPolicy specifies the email verification. When enabled, the
+// self-registered users stay unverified until they confirm the email, and
+// the users have to confirm the new email before it replaces the current one.
+// The users signed in with the OAuth provider or the directory are verified
+// by the identity provider. The verification email can be resent once per
+// the resend interval.
+type VerificationPolicy struct {
+	Enabled        bool          `env:"ENABLED"         envDefault:"false"`
+	TokenDuration  time.Duration `env:"TOKEN_DURATION"  envDefault:"24h"`
+	ResendInterval time.Duration `env:"RESEND_INTERVAL" envDefault:"1m"`
+}
+
+// Verification represents the pending proof of the email ownership. Only
+// the hash of the verification token is stored.
+type Verification struct {
+	UserID    string
+	Email     string
+	TokenHash string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Expired returns true if the verification expired at the given time.
+func (v Verification) Expired(t time.Time) bool {
+	return !v.ExpiresAt.After(t)
+}
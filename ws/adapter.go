@@ -9,6 +9,7 @@ import (
 
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	"github.com/absmach/supermq/domains"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
@@ -62,7 +63,7 @@ func (svc *adapterService) Subscribe(ctx context.Context, clientKey, chanID, sub
 		return svcerr.ErrAuthentication
 	}
 
-	clientID, err := svc.authorize(ctx, clientKey, chanID, connections.Subscribe)
+	clientID, err := svc.authorize(ctx, clientKey, chanID, subtopic, connections.Subscribe)
 	if err != nil {
 		return svcerr.ErrAuthorization
 	}
@@ -78,7 +79,7 @@ func (svc *adapterService) Subscribe(ctx context.Context, clientKey, chanID, sub
 		ID:       clientID,
 		ClientID: clientID,
 		Topic:    subject,
-		Handler:  c,
+		Handler:  svc.newAuthzClient(clientID, chanID, subtopic, subject, c),
 	}
 	if err := svc.pubsub.Subscribe(ctx, subCfg); err != nil {
 		return ErrFailedSubscription
@@ -88,8 +89,8 @@ func (svc *adapterService) Subscribe(ctx context.Context, clientKey, chanID, sub
 }
 
 // authorize checks if the clientKey is authorized to access the channel
-// and returns the clientID if it is.
-func (svc *adapterService) authorize(ctx context.Context, clientKey, chanID string, msgType connections.ConnType) (string, error) {
+// subtopic and returns the clientID if it is.
+func (svc *adapterService) authorize(ctx context.Context, clientKey, chanID, subtopic string, msgType connections.ConnType) (string, error) {
 	authnReq := &grpcClientsV1.AuthnReq{
 		ClientSecret: clientKey,
 	}
@@ -106,6 +107,8 @@ func (svc *adapterService) authorize(ctx context.Context, clientKey, chanID stri
 		ClientId:   authnRes.GetId(),
 		Type:       uint32(msgType),
 		ChannelId:  chanID,
+		Protocol:   domains.WSProtocol,
+		Subtopic:   subtopic,
 	}
 	authzRes, err := svc.channels.Authorize(ctx, authzReq)
 	if err != nil {
@@ -117,3 +120,49 @@ func (svc *adapterService) authorize(ctx context.Context, clientKey, chanID stri
 
 	return authnRes.GetId(), nil
 }
+
+// authzClient checks the client connection to the channel before each
+// message is delivered, so the client is disconnected and unsubscribed once
+// its connection expires or is removed.
+type authzClient struct {
+	clientID  string
+	channelID string
+	subtopic  string
+	topic     string
+	svc       *adapterService
+	client    *Client
+}
+
+func (svc *adapterService) newAuthzClient(clientID, channelID, subtopic, topic string, client *Client) authzClient {
+	return authzClient{clientID, channelID, subtopic, topic, svc, client}
+}
+
+func (a authzClient) Handle(m *messaging.Message) error {
+	authzReq := &grpcChannelsV1.AuthzReq{
+		ClientType: policies.ClientType,
+		ClientId:   a.clientID,
+		Type:       uint32(connections.Subscribe),
+		ChannelId:  a.channelID,
+		Protocol:   domains.WSProtocol,
+		Subtopic:   a.subtopic,
+	}
+	res, err := a.svc.channels.Authorize(context.Background(), authzReq)
+	if err == nil && !res.GetAuthorized() {
+		err = svcerr.ErrAuthorization
+	}
+	if err != nil {
+		if unsubErr := a.svc.pubsub.Unsubscribe(context.Background(), a.clientID, a.topic); unsubErr != nil {
+			err = errors.Wrap(err, errors.Wrap(errFailedUnsubscribe, unsubErr))
+		}
+		if cancelErr := a.Cancel(); cancelErr != nil {
+			err = errors.Wrap(err, cancelErr)
+		}
+		return err
+	}
+
+	return a.client.Handle(m)
+}
+
+func (a authzClient) Cancel() error {
+	return a.client.Cancel()
+}
@@ -12,8 +12,10 @@ import (
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	chmocks "github.com/absmach/supermq/channels/mocks"
 	climocks "github.com/absmach/supermq/clients/mocks"
+	"github.com/absmach/supermq/domains"
 	"github.com/absmach/supermq/internal/testsutil"
 	"github.com/absmach/supermq/pkg/connections"
+	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/messaging/mocks"
@@ -157,24 +159,90 @@ func TestSubscribe(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		subConfig := messaging.SubscriberConfig{
-			ID:       clientID,
-			Topic:    "channels." + tc.chanID + "." + subTopic,
-			ClientID: clientID,
-			Handler:  c,
-		}
 		clientsCall := clients.On("Authenticate", mock.Anything, &grpcClientsV1.AuthnReq{ClientSecret: tc.clientKey}).Return(tc.authNRes, tc.authNErr)
 		channelsCall := channels.On("Authorize", mock.Anything, &grpcChannelsV1.AuthzReq{
 			ClientType: policies.ClientType,
 			ClientId:   tc.authNRes.GetId(),
 			Type:       uint32(connections.Subscribe),
 			ChannelId:  tc.chanID,
+			Protocol:   domains.WSProtocol,
+			Subtopic:   tc.subtopic,
 		}).Return(tc.authZRes, tc.authZErr)
-		repocall := pubsub.On("Subscribe", mock.Anything, subConfig).Return(tc.subErr)
+		var subConfig messaging.SubscriberConfig
+		repocall := pubsub.On("Subscribe", mock.Anything, mock.Anything).Return(tc.subErr).Run(func(args mock.Arguments) {
+			subConfig = args.Get(1).(messaging.SubscriberConfig)
+		})
 		err := svc.Subscribe(context.Background(), tc.clientKey, tc.chanID, tc.subtopic, c)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			topic := "channels." + tc.chanID + "." + subTopic
+			assert.Equal(t, clientID, subConfig.ID, fmt.Sprintf("%s: expected subscription ID %s got %s\n", tc.desc, clientID, subConfig.ID))
+			assert.Equal(t, topic, subConfig.Topic, fmt.Sprintf("%s: expected topic %s got %s\n", tc.desc, topic, subConfig.Topic))
+		}
 		repocall.Unset()
 		clientsCall.Unset()
 		channelsCall.Unset()
 	}
 }
+
+func TestSubscriptionDelivery(t *testing.T) {
+	svc, pubsub, clients, channels := newService()
+	topic := "channels." + chanID + "." + subTopic
+	authzReq := &grpcChannelsV1.AuthzReq{
+		ClientType: policies.ClientType,
+		ClientId:   clientID,
+		Type:       uint32(connections.Subscribe),
+		ChannelId:  chanID,
+		Protocol:   domains.WSProtocol,
+		Subtopic:   subTopic,
+	}
+
+	var handler messaging.MessageHandler
+	clients.On("Authenticate", mock.Anything, &grpcClientsV1.AuthnReq{ClientSecret: clientKey}).Return(&grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true}, nil)
+	channelsCall := channels.On("Authorize", mock.Anything, authzReq).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, nil)
+	pubsub.On("Subscribe", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		handler = args.Get(1).(messaging.SubscriberConfig).Handler
+	})
+	err := svc.Subscribe(context.Background(), clientKey, chanID, subTopic, ws.NewClient(nil))
+	assert.Nil(t, err, fmt.Sprintf("subscribe unexpected error: %s", err))
+	channelsCall.Unset()
+
+	cases := []struct {
+		desc         string
+		authZRes     *grpcChannelsV1.AuthzRes
+		authZErr     error
+		unsubscribed bool
+		err          error
+	}{
+		{
+			desc:     "deliver message to client with valid connection",
+			authZRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+		},
+		{
+			desc:         "deliver message to client with expired connection",
+			authZRes:     &grpcChannelsV1.AuthzRes{Authorized: false},
+			unsubscribed: true,
+			err:          svcerr.ErrAuthorization,
+		},
+		{
+			desc:         "deliver message with failed authorization",
+			authZErr:     svcerr.ErrAuthorization,
+			unsubscribed: true,
+			err:          svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			unsubscribed := false
+			channelsCall := channels.On("Authorize", mock.Anything, authzReq).Return(tc.authZRes, tc.authZErr)
+			pubsubCall := pubsub.On("Unsubscribe", mock.Anything, clientID, topic).Return(nil).Run(func(mock.Arguments) { unsubscribed = true })
+			// Messages published by the client itself are not written to the connection.
+			err := handler.Handle(&messaging.Message{Channel: chanID, Subtopic: subTopic, Publisher: clientID})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.unsubscribed, unsubscribed, fmt.Sprintf("%s: expected unsubscribed %t got %t\n", tc.desc, tc.unsubscribed, unsubscribed))
+			channelsCall.Unset()
+			pubsubCall.Unset()
+		})
+	}
+}
@@ -11,17 +11,23 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/absmach/mgate/pkg/session"
 	"github.com/absmach/mgate/pkg/websockets"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	grpcDomainsV1 "github.com/absmach/supermq/api/grpc/domains/v1"
 	chmocks "github.com/absmach/supermq/channels/mocks"
 	climocks "github.com/absmach/supermq/clients/mocks"
+	dmocks "github.com/absmach/supermq/domains/mocks"
 	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	authnMocks "github.com/absmach/supermq/pkg/authn/mocks"
+	authzMocks "github.com/absmach/supermq/pkg/authz/mocks"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/messaging/mocks"
+	"github.com/absmach/supermq/pkg/messaging/topics"
 	"github.com/absmach/supermq/ws"
 	"github.com/absmach/supermq/ws/api"
 	"github.com/gorilla/websocket"
@@ -36,6 +42,9 @@ const (
 	clientKey  = "c02ff576-ccd5-40f6-ba5f-c85377aad529"
 	protocol   = "ws"
 	instanceID = "5de9b29a-feb9-11ed-be56-0242ac120002"
+	domainID   = "123e4567-e89b-12d3-a456-000000000002"
+	alias      = "factory"
+	name       = "temperature"
 )
 
 var msg = []byte(`[{"n":"current","t":-1,"v":1.6}]`)
@@ -45,8 +54,17 @@ func newService(clients grpcClientsV1.ClientsServiceClient, channels grpcChannel
 	return ws.New(clients, channels, pubsub), pubsub
 }
 
-func newHTTPServer(svc ws.Service) *httptest.Server {
-	mux := api.MakeHandler(context.Background(), svc, smqlog.NewMock(), instanceID)
+func newResolver(channels *chmocks.ChannelsServiceClient) topics.Resolver {
+	domains := new(dmocks.DomainsServiceClient)
+	domains.On("RetrieveIDByAlias", mock.Anything, &grpcDomainsV1.RetrieveIDByAliasReq{Alias: alias}).Return(&grpcDomainsV1.RetrieveIDByAliasRes{Id: domainID}, nil)
+	domains.On("RetrieveIDByAlias", mock.Anything, mock.Anything).Return(&grpcDomainsV1.RetrieveIDByAliasRes{}, svcerr.ErrNotFound)
+	channels.On("RetrieveIDByName", mock.Anything, &grpcChannelsV1.RetrieveIDByNameReq{DomainId: domainID, Name: name}).Return(&grpcChannelsV1.RetrieveIDByNameRes{Id: id}, nil)
+
+	return topics.NewResolver(domains, channels, time.Minute)
+}
+
+func newHTTPServer(svc ws.Service, resolver topics.Resolver) *httptest.Server {
+	mux := api.MakeHandler(context.Background(), svc, resolver, smqlog.NewMock(), instanceID)
 	return httptest.NewServer(mux)
 }
 
@@ -97,10 +115,12 @@ func TestHandshake(t *testing.T) {
 	clients := new(climocks.ClientsServiceClient)
 	channels := new(chmocks.ChannelsServiceClient)
 	authn := new(authnMocks.Authentication)
+	authz := new(authzMocks.Authorization)
 	svc, pubsub := newService(clients, channels)
-	target := newHTTPServer(svc)
+	resolver := newResolver(channels)
+	target := newHTTPServer(svc, resolver)
 	defer target.Close()
-	handler := ws.NewHandler(pubsub, smqlog.NewMock(), authn, clients, channels)
+	handler := ws.NewHandler(pubsub, smqlog.NewMock(), authn, authz, clients, channels, resolver)
 	ts, err := newProxyHTPPServer(handler, target)
 	require.Nil(t, err)
 	defer ts.Close()
@@ -217,3 +237,63 @@ func TestHandshake(t *testing.T) {
 		})
 	}
 }
+
+func TestHandshakeWithAlias(t *testing.T) {
+	clients := new(climocks.ClientsServiceClient)
+	channels := new(chmocks.ChannelsServiceClient)
+	authn := new(authnMocks.Authentication)
+	authz := new(authzMocks.Authorization)
+	svc, pubsub := newService(clients, channels)
+	resolver := newResolver(channels)
+	target := newHTTPServer(svc, resolver)
+	defer target.Close()
+	handler := ws.NewHandler(pubsub, smqlog.NewMock(), authn, authz, clients, channels, resolver)
+	ts, err := newProxyHTPPServer(handler, target)
+	require.Nil(t, err)
+	defer ts.Close()
+	pubsub.On("Subscribe", mock.Anything, mock.Anything).Return(nil)
+	pubsub.On("Publish", mock.Anything, id, mock.Anything).Return(nil)
+	clients.On("Authenticate", mock.Anything, mock.Anything).Return(&grpcClientsV1.AuthnRes{Authenticated: true}, nil)
+	authn.On("Authenticate", mock.Anything, mock.Anything).Return(smqauthn.Session{}, nil)
+	channels.On("Authorize", mock.Anything, mock.Anything, mock.Anything).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, nil)
+
+	cases := []struct {
+		desc   string
+		path   string
+		status int
+	}{
+		{
+			desc:   "connect with domain alias and channel name",
+			path:   fmt.Sprintf("m/%s/c/%s", alias, name),
+			status: http.StatusSwitchingProtocols,
+		},
+		{
+			desc:   "connect with domain alias, channel name and subtopic",
+			path:   fmt.Sprintf("m/%s/c/%s/subtopic", alias, name),
+			status: http.StatusSwitchingProtocols,
+		},
+		{
+			desc:   "connect with unknown domain alias",
+			path:   fmt.Sprintf("m/%s/c/%s", "unknown", name),
+			status: http.StatusBadGateway,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			u, _ := url.Parse(ts.URL)
+			u.Scheme = protocol
+			header := http.Header{}
+			header.Add("Authorization", clientKey)
+			conn, res, err := websocket.DefaultDialer.Dial(fmt.Sprintf("%s/%s", u, tc.path), header)
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code '%d' got '%d'\n", tc.desc, tc.status, res.StatusCode))
+
+			if tc.status == http.StatusSwitchingProtocols {
+				assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+
+				err = conn.WriteMessage(websocket.TextMessage, msg)
+				assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+			}
+		})
+	}
+}
@@ -12,8 +12,8 @@ import (
 	"strings"
 
 	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging/topics"
 	"github.com/absmach/supermq/ws"
-	"github.com/go-chi/chi/v5"
 )
 
 var channelPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
@@ -53,19 +53,22 @@ func decodeRequest(r *http.Request) (connReq, error) {
 		authKey = authKeys[0]
 	}
 
-	chanID := chi.URLParam(r, "chanID")
-
-	req := connReq{
-		clientKey: authKey,
-		chanID:    chanID,
+	uri, err := resolver.Resolve(r.Context(), r.RequestURI)
+	if err != nil {
+		return connReq{}, err
 	}
 
-	channelParts := channelPartRegExp.FindStringSubmatch(r.RequestURI)
+	channelParts := channelPartRegExp.FindStringSubmatch(uri)
 	if len(channelParts) < 2 {
 		logger.Warn("Empty channel id or malformed url")
 		return connReq{}, errors.ErrMalformedEntity
 	}
 
+	req := connReq{
+		clientKey: authKey,
+		chanID:    channelParts[1],
+	}
+
 	subtopic, err := parseSubTopic(channelParts[2])
 	if err != nil {
 		return connReq{}, err
@@ -115,7 +118,7 @@ func encodeError(w http.ResponseWriter, err error) {
 		statusCode = http.StatusBadRequest
 	case errUnauthorizedAccess:
 		statusCode = http.StatusForbidden
-	case errMalformedSubtopic, errors.ErrMalformedEntity:
+	case errMalformedSubtopic, errors.ErrMalformedEntity, topics.ErrMalformedTopic:
 		statusCode = http.StatusBadRequest
 	default:
 		statusCode = http.StatusNotFound
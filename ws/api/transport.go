@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/pkg/messaging/topics"
 	"github.com/absmach/supermq/ws"
 	"github.com/go-chi/chi/v5"
 	"github.com/gorilla/websocket"
@@ -32,16 +33,20 @@ var (
 		WriteBufferSize: readwriteBufferSize,
 		CheckOrigin:     func(r *http.Request) bool { return true },
 	}
-	logger *slog.Logger
+	logger   *slog.Logger
+	resolver topics.Resolver
 )
 
 // MakeHandler returns http handler with handshake endpoint.
-func MakeHandler(ctx context.Context, svc ws.Service, l *slog.Logger, instanceID string) http.Handler {
+func MakeHandler(ctx context.Context, svc ws.Service, r topics.Resolver, l *slog.Logger, instanceID string) http.Handler {
 	logger = l
+	resolver = r
 
 	mux := chi.NewRouter()
 	mux.Get("/channels/{chanID}/messages", handshake(ctx, svc))
 	mux.Get("/channels/{chanID}/messages/*", handshake(ctx, svc))
+	mux.Get("/m/{domain}/c/{channel}", handshake(ctx, svc))
+	mux.Get("/m/{domain}/c/{channel}/*", handshake(ctx, svc))
 
 	mux.Get("/health", supermq.Health(service, instanceID))
 	mux.Handle("/metrics", promhttp.Handler())
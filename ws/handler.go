@@ -16,11 +16,15 @@ import (
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/domains"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/messaging/topics"
 	"github.com/absmach/supermq/pkg/policies"
 )
 
@@ -57,18 +61,22 @@ type handler struct {
 	pubsub   messaging.PubSub
 	clients  grpcClientsV1.ClientsServiceClient
 	channels grpcChannelsV1.ChannelsServiceClient
+	resolver topics.Resolver
 	authn    smqauthn.Authentication
+	authz    smqauthz.Authorization
 	logger   *slog.Logger
 }
 
 // NewHandler creates new Handler entity.
-func NewHandler(pubsub messaging.PubSub, logger *slog.Logger, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) session.Handler {
+func NewHandler(pubsub messaging.PubSub, logger *slog.Logger, authn smqauthn.Authentication, authz smqauthz.Authorization, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, resolver topics.Resolver) session.Handler {
 	return &handler{
 		logger:   logger,
 		pubsub:   pubsub,
 		authn:    authn,
+		authz:    authz,
 		clients:  clients,
 		channels: channels,
+		resolver: resolver,
 	}
 }
 
@@ -97,6 +105,12 @@ func (h *handler) AuthPublish(ctx context.Context, topic *string, payload *[]byt
 		token = string(s.Password)
 	}
 
+	resolved, err := h.resolver.Resolve(ctx, *topic)
+	if err != nil {
+		return err
+	}
+	*topic = resolved
+
 	return h.authAccess(ctx, token, *topic, connections.Publish)
 }
 
@@ -119,10 +133,15 @@ func (h *handler) AuthSubscribe(ctx context.Context, topics *[]string) error {
 		token = string(s.Password)
 	}
 
-	for _, topic := range *topics {
+	for i, topic := range *topics {
+		topic, err := h.resolver.Resolve(ctx, topic)
+		if err != nil {
+			return err
+		}
 		if err := h.authAccess(ctx, token, topic, connections.Subscribe); err != nil {
 			return err
 		}
+		(*topics)[i] = topic
 	}
 
 	return nil
@@ -145,9 +164,15 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		return errFailedMessagePublish
 	}
 
+	// The topic may be forwarded with the domain alias and the channel name.
+	resolved, err := h.resolver.Resolve(ctx, *topic)
+	if err != nil {
+		return errors.Wrap(errFailedPublish, err)
+	}
+
 	// Topics are in the format:
 	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
-	channelParts := channelRegExp.FindStringSubmatch(*topic)
+	channelParts := channelRegExp.FindStringSubmatch(resolved)
 	if len(channelParts) < 2 {
 		return errors.Wrap(errFailedPublish, errMalformedTopic)
 	}
@@ -155,7 +180,7 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 	chanID := channelParts[1]
 	subtopic := channelParts[2]
 
-	subtopic, err := parseSubtopic(subtopic)
+	subtopic, err = parseSubtopic(subtopic)
 	if err != nil {
 		return errors.Wrap(errFailedParseSubtopic, err)
 	}
@@ -181,6 +206,11 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		}
 		clientType = policies.UserType
 		clientID = authnSession.DomainUserID
+		if authnSession.Type == smqauthn.PersonalAccessToken {
+			if clientID, err = smqauthz.AuthorizeChannelPAT(ctx, h.authz, h.channels, authnSession, chanID, auth.PublishOp); err != nil {
+				return err
+			}
+		}
 	}
 
 	ar := &grpcChannelsV1.AuthzReq{
@@ -188,6 +218,8 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		ClientId:   clientID,
 		ClientType: clientType,
 		ChannelId:  chanID,
+		Protocol:   domains.WSProtocol,
+		Subtopic:   subtopic,
 	}
 	res, err := h.channels.Authorize(ctx, ar)
 	if err != nil {
@@ -243,6 +275,7 @@ func (h *handler) Disconnect(ctx context.Context) error {
 }
 
 func (h *handler) authAccess(ctx context.Context, token, topic string, msgType connections.ConnType) error {
+	var authnSession smqauthn.Session
 	var clientID, clientType string
 	switch {
 	case strings.HasPrefix(token, "Client"):
@@ -257,7 +290,8 @@ func (h *handler) authAccess(ctx context.Context, token, topic string, msgType c
 		clientType = policies.ClientType
 		clientID = authnRes.GetId()
 	default:
-		authnSession, err := h.authn.Authenticate(ctx, extractBearerToken(token))
+		var err error
+		authnSession, err = h.authn.Authenticate(ctx, extractBearerToken(token))
 		if err != nil {
 			return err
 		}
@@ -278,11 +312,28 @@ func (h *handler) authAccess(ctx context.Context, token, topic string, msgType c
 
 	chanID := channelParts[1]
 
+	subtopic, err := parseSubtopic(channelParts[2])
+	if err != nil {
+		return errors.Wrap(errFailedParseSubtopic, err)
+	}
+
+	if authnSession.Type == smqauthn.PersonalAccessToken {
+		operation := auth.SubscribeOp
+		if msgType == connections.Publish {
+			operation = auth.PublishOp
+		}
+		if clientID, err = smqauthz.AuthorizeChannelPAT(ctx, h.authz, h.channels, authnSession, chanID, operation); err != nil {
+			return err
+		}
+	}
+
 	ar := &grpcChannelsV1.AuthzReq{
 		Type:       uint32(msgType),
 		ClientId:   clientID,
 		ClientType: clientType,
 		ChannelId:  chanID,
+		Protocol:   domains.WSProtocol,
+		Subtopic:   subtopic,
 	}
 	res, err := h.channels.Authorize(ctx, ar)
 	if err != nil {